@@ -0,0 +1,216 @@
+package ctl
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// Endpoint is the server an RPC console sends a command to.
+type Endpoint int
+
+const (
+	// EndpointAuto sends wallet commands to the wallet server and all other commands to the chain server.
+	EndpointAuto Endpoint = iota
+	// EndpointNode sends every command to the chain server.
+	EndpointNode
+	// EndpointWallet sends every command to the wallet server, which passes on the commands it does not handle to the
+	// chain server.
+	EndpointWallet
+	numEndpoints
+)
+
+// String returns the name of the endpoint.
+func (ep Endpoint) String() string {
+	switch ep {
+	case EndpointNode:
+		return "node"
+	case EndpointWallet:
+		return "wallet"
+	default:
+		return "auto"
+	}
+}
+
+// Next returns the endpoint after this one, wrapping around to the first.
+func (ep Endpoint) Next() Endpoint {
+	return (ep + 1) % numEndpoints
+}
+
+// UseWallet returns whether the method is sent to the wallet server.
+func (ep Endpoint) UseWallet(method string) bool {
+	switch ep {
+	case EndpointNode:
+		return false
+	case EndpointWallet:
+		return true
+	}
+	flags, e := btcjson.MethodUsageFlags(method)
+	return e == nil && flags&btcjson.UFWalletOnly != 0
+}
+
+// ConsoleMethods returns the methods that can be used from an RPC console, sorted by name. These are the registered
+// commands that do not need a websocket connection and the offline commands.
+func ConsoleMethods() (methods []string) {
+	seen := make(map[string]struct{})
+	for _, method := range btcjson.RegisteredCmdMethods() {
+		flags, e := btcjson.MethodUsageFlags(method)
+		if e != nil || flags&btcjson.UnusableFlags != 0 {
+			continue
+		}
+		seen[method] = struct{}{}
+		methods = append(methods, method)
+	}
+	for method := range offlineCommands {
+		if _, ok := seen[method]; !ok {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return
+}
+
+// CompleteMethod returns the console methods that start with prefix, sorted by name.
+func CompleteMethod(prefix string) (methods []string) {
+	for _, method := range ConsoleMethods() {
+		if strings.HasPrefix(method, prefix) {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+// CommonPrefix returns the longest prefix shared by all of the methods.
+func CommonPrefix(methods []string) (prefix string) {
+	if len(methods) == 0 {
+		return
+	}
+	prefix = methods[0]
+	for _, method := range methods[1:] {
+		for !strings.HasPrefix(method, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return
+}
+
+// MethodHint returns the usage of a console method, showing its parameters, or an empty string if it is not a console
+// method.
+func MethodHint(method string) string {
+	if cmd, ok := offlineCommands[method]; ok {
+		return cmd.usage
+	}
+	usage, e := btcjson.MethodUsageText(method)
+	if e != nil {
+		return ""
+	}
+	return usage
+}
+
+// ErrUnterminated is returned by SplitCommandLine when a quote, array or object in the line is not closed.
+var ErrUnterminated = errors.New("unterminated quote, array or object")
+
+// SplitCommandLine splits a line typed into an RPC console into the method and its arguments. Arguments are separated
+// by white space, except inside quotes and JSON arrays and objects, so JSON arguments can be typed as they are. An
+// argument that is entirely quoted is unquoted, while JSON arguments are passed on unchanged.
+func SplitCommandLine(line string) (args []string, e error) {
+	var arg strings.Builder
+	var depth int
+	var inQuote, escaped, quoted bool
+	flush := func() (e error) {
+		if arg.Len() == 0 {
+			return
+		}
+		s := arg.String()
+		arg.Reset()
+		if quoted {
+			if s, e = strconv.Unquote(s); e != nil {
+				return
+			}
+		}
+		args = append(args, s)
+		return
+	}
+	for _, r := range line {
+		switch {
+		case inQuote:
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inQuote = false
+			}
+		case r == '"':
+			inQuote = true
+			// Only a quote that starts an argument and is not inside JSON makes the argument a quoted string.
+			quoted = arg.Len() == 0 && depth == 0 || quoted
+		case r == '[' || r == '{':
+			depth++
+		case r == ']' || r == '}':
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			if e = flush(); e != nil {
+				return
+			}
+			quoted = false
+			continue
+		}
+		arg.WriteRune(r)
+	}
+	if inQuote || depth != 0 {
+		return nil, ErrUnterminated
+	}
+	e = flush()
+	return
+}
+
+// History holds the lines entered into an RPC console so they can be recalled.
+type History struct {
+	lines []string
+	max   int
+	pos   int
+}
+
+// NewHistory returns an empty History that keeps up to max lines.
+func NewHistory(max int) *History {
+	return &History{max: max}
+}
+
+// Add appends a line to the history, unless it repeats the last line, and moves the recall position past the end.
+func (h *History) Add(line string) {
+	if line != "" && (len(h.lines) == 0 || h.lines[len(h.lines)-1] != line) {
+		h.lines = append(h.lines, line)
+		if len(h.lines) > h.max {
+			h.lines = h.lines[len(h.lines)-h.max:]
+		}
+	}
+	h.pos = len(h.lines)
+}
+
+// Prev returns the line before the recall position and moves back to it. It returns false at the first line.
+func (h *History) Prev() (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	h.pos--
+	return h.lines[h.pos], true
+}
+
+// Next returns the line after the recall position and moves forward to it. Moving past the last line returns an empty
+// line, and it returns false when already past the last line.
+func (h *History) Next() (string, bool) {
+	if h.pos >= len(h.lines) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.lines) {
+		return "", true
+	}
+	return h.lines[h.pos], true
+}
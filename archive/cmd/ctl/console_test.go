@@ -0,0 +1,98 @@
+package ctl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSplitCommandLine ensures console lines split into arguments at white space outside of quotes and JSON, and that
+// quoted arguments are unquoted.
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		args []string
+		err  error
+	}{
+		{"getblockcount", []string{"getblockcount"}, nil},
+		{"  getblock   abcd  true ", []string{"getblock", "abcd", "true"}, nil},
+		{`sendtoaddress "a b" 1`, []string{"sendtoaddress", "a b", "1"}, nil},
+		{`x "say \"hi\""`, []string{"x", `say "hi"`}, nil},
+		{
+			`createrawtransaction [{"txid": "ab", "vout": 0}] {"addr": 1.5}`,
+			[]string{"createrawtransaction", `[{"txid": "ab", "vout": 0}]`, `{"addr": 1.5}`},
+			nil,
+		},
+		{`x [1, "]" ]`, []string{"x", `[1, "]" ]`}, nil},
+		{`x [1, 2`, nil, ErrUnterminated},
+		{`x "open`, nil, ErrUnterminated},
+	}
+	for _, test := range tests {
+		args, e := SplitCommandLine(test.line)
+		if e != test.err {
+			t.Errorf("%q: got error %v, want %v", test.line, e, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%q: got %q, want %q", test.line, args, test.args)
+		}
+	}
+}
+
+// TestCompleteMethod ensures methods complete from registered and offline commands and hints show their usage.
+func TestCompleteMethod(t *testing.T) {
+	methods := CompleteMethod("getblockco")
+	if !reflect.DeepEqual(methods, []string{"getblockcount"}) {
+		t.Fatalf("got %v, want [getblockcount]", methods)
+	}
+	methods = CompleteMethod("signrawtransaction")
+	if !reflect.DeepEqual(methods, []string{"signrawtransaction", "signrawtransactionwithkey"}) {
+		t.Fatalf("got %v", methods)
+	}
+	if prefix := CommonPrefix(CompleteMethod("getblockha")); prefix != "getblockhash" {
+		t.Errorf("got common prefix %q, want getblockhash", prefix)
+	}
+	if prefix := CommonPrefix([]string{"getbalance", "getblock"}); prefix != "getb" {
+		t.Errorf("got common prefix %q, want getb", prefix)
+	}
+	if hint := MethodHint("deriveaddresses"); hint != offlineCommands["deriveaddresses"].usage {
+		t.Errorf("unexpected hint %q", hint)
+	}
+	if hint := MethodHint("getblockhash"); hint != "getblockhash index" {
+		t.Errorf("unexpected hint %q", hint)
+	}
+	if hint := MethodHint("nosuchmethod"); hint != "" {
+		t.Errorf("unexpected hint %q", hint)
+	}
+	if EndpointAuto.UseWallet("getblockcount") || !EndpointAuto.UseWallet("getbalance") ||
+		EndpointNode.UseWallet("getbalance") || !EndpointWallet.UseWallet("getblockcount") {
+		t.Errorf("commands sent to the wrong endpoint")
+	}
+}
+
+// TestHistory ensures lines are recalled in order and repeated lines are kept once.
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	if _, ok := h.Prev(); ok {
+		t.Fatalf("empty history recalled a line")
+	}
+	for _, line := range []string{"a", "b", "b", "c", "d"} {
+		h.Add(line)
+	}
+	var got []string
+	for line, ok := h.Prev(); ok; line, ok = h.Prev() {
+		got = append(got, line)
+	}
+	if !reflect.DeepEqual(got, []string{"d", "c", "b"}) {
+		t.Fatalf("got %v, want [d c b]", got)
+	}
+	if line, _ := h.Next(); line != "c" {
+		t.Errorf("got %q, want c", line)
+	}
+	h.Next()
+	if line, ok := h.Next(); !ok || line != "" {
+		t.Errorf("got %q %v, want an empty line after the last", line, ok)
+	}
+	if _, ok := h.Next(); ok {
+		t.Errorf("moved past the end of the history")
+	}
+}
@@ -16,9 +16,8 @@ import (
 	"os"
 	"strings"
 
-	"github.com/btcsuite/go-socks/socks"
-
 	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
 	"github.com/p9c/pod/pod/config"
 )
 
@@ -79,11 +78,11 @@ func newHTTPClient(cfg *config.Config) (*http.Client, func(), error) {
 		addr string) (net.Conn, error)
 	ctx, cancel := context.WithCancel(context.Background())
 	// Configure proxy if needed.
-	if cfg.ProxyAddress.V() != "" {
-		proxy := &socks.Proxy{
-			Addr:     cfg.ProxyAddress.V(),
-			Username: cfg.ProxyUser.V(),
-			Password: cfg.ProxyPass.V(),
+	if rpcProxy := cfg.RPCProxy(); rpcProxy.Enabled() {
+		proxy, e := rpcProxy.Dialer()
+		if e != nil {
+			cancel()
+			return nil, nil, e
 		}
 		dial = func(_ context.Context, network string, addr string) (
 			net.Conn, error,
@@ -258,6 +257,11 @@ func ListCommands() (s string) {
 		}
 		s += "\n"
 	}
+	s += "Offline Commands (run without a server):\n"
+	for _, usage := range OfflineUsages() {
+		s += "\t" + usage + "\n"
+	}
+	s += "\n"
 	return
 }
 
@@ -266,33 +270,21 @@ var HelpPrint = func() {
 	fmt.Println("help has not been overridden")
 }
 
-// CtlMain is the entry point for the pod.Ctl component
-func CtlMain(cx *config.Config) {
+// CtlMain is the entry point for the pod.Ctl component. The offline commands are run locally using the parameters of
+// the active network, and all other commands are sent to the chain or wallet server.
+func CtlMain(cx *config.Config, activeNet *chaincfg.Params) {
 	args := cx.ExtraArgs
 	if len(args) < 1 {
 		ListCommands()
 		os.Exit(1)
 	}
-	// Ensure the specified method identifies a valid registered command and is one of the usable types.
 	method := args[0]
-	var usageFlags btcjson.UsageFlag
+	// Since some commands, such as submitblock, can involve data which is too large for the Operating System to allow
+	// as a normal command line parameter, support using '-' as an argument to allow the argument to be read from a stdin
+	// pipe.
 	var e error
-	if usageFlags, e = btcjson.MethodUsageFlags(method); E.Chk(e) {
-		_, _ = fmt.Fprintf(os.Stderr, "Unrecognized command '%s'\n", method)
-		HelpPrint()
-		os.Exit(1)
-	}
-	if usageFlags&btcjson.UnusableFlags != 0 {
-		_, _ = fmt.Fprintf(os.Stderr, "The '%s' command can only be used via websockets\n", method)
-		HelpPrint()
-		os.Exit(1)
-	}
-	// Convert remaining command line args to a slice of interface values to be passed along as parameters to new
-	// command creation function. Since some commands, such as submitblock, can involve data which is too large for the
-	// Operating System to allow as a normal command line parameter, support using '-' as an argument to allow the
-	// argument to be read from a stdin pipe.
 	bio := bufio.NewReader(os.Stdin)
-	params := make([]interface{}, 0, len(args[1:]))
+	strParams := make([]string, 0, len(args[1:]))
 	for _, arg := range args[1:] {
 		if arg == "-" {
 			var param string
@@ -305,12 +297,39 @@ func CtlMain(cx *config.Config) {
 				os.Exit(1)
 			}
 			param = strings.TrimRight(param, "\r\n")
-			params = append(params, param)
+			strParams = append(strParams, param)
 			continue
 		}
-		params = append(params, arg)
+		strParams = append(strParams, arg)
 	}
 	var result []byte
+	var offline bool
+	if result, offline, e = CallOffline(activeNet, method, strParams...); offline {
+		if e != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", method, e)
+			os.Exit(1)
+		}
+		printResult(result)
+		return
+	}
+	// Ensure the specified method identifies a valid registered command and is one of the usable types.
+	var usageFlags btcjson.UsageFlag
+	if usageFlags, e = btcjson.MethodUsageFlags(method); E.Chk(e) {
+		_, _ = fmt.Fprintf(os.Stderr, "Unrecognized command '%s'\n", method)
+		HelpPrint()
+		os.Exit(1)
+	}
+	if usageFlags&btcjson.UnusableFlags != 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "The '%s' command can only be used via websockets\n", method)
+		HelpPrint()
+		os.Exit(1)
+	}
+	// Convert the args to a slice of interface values to be passed along as parameters to new command creation
+	// function.
+	params := make([]interface{}, len(strParams))
+	for i := range strParams {
+		params[i] = strParams[i]
+	}
 	if result, e = Call(cx, cx.UseWallet.True(), method, params...); E.Chk(e) {
 		return
 	}
@@ -344,7 +363,12 @@ func CtlMain(cx *config.Config) {
 	// 	E.Ln(e)
 	// 	os.Exit(1)
 	// }
-	// Choose how to display the result based on its type.
+	printResult(result)
+}
+
+// printResult prints the JSON result of a command, choosing how to display it based on its type.
+func printResult(result []byte) {
+	var e error
 	strResult := string(result)
 	switch {
 	case strings.HasPrefix(strResult, "{") || strings.HasPrefix(strResult, "["):
@@ -0,0 +1,315 @@
+package ctl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/chainrpc"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// offlineCommand is a ctl command that is run locally without connecting to a server, so that transactions can be
+// assembled and signed on a machine that is not connected to a network.
+type offlineCommand struct {
+	usage            string
+	minArgs, maxArgs int
+	handler          func(params *chaincfg.Params, args []string) (interface{}, error)
+}
+
+// offlineCommands are the commands ctl runs itself. The commands that share a name with a chain server command give the
+// same result as the server would.
+var offlineCommands = map[string]offlineCommand{
+	"createrawtransaction": {
+		usage:   `createrawtransaction [{"txid":"value","vout":n},...] {"address":amount,...} (locktime)`,
+		minArgs: 2,
+		maxArgs: 3,
+		handler: func(params *chaincfg.Params, args []string) (interface{}, error) {
+			return offlineServerCommand(params, "createrawtransaction", chainrpc.HandleCreateRawTransaction, args)
+		},
+	},
+	"decoderawtransaction": {
+		usage:   `decoderawtransaction "hextx"`,
+		minArgs: 1,
+		maxArgs: 1,
+		handler: func(params *chaincfg.Params, args []string) (interface{}, error) {
+			return offlineServerCommand(params, "decoderawtransaction", chainrpc.HandleDecodeRawTransaction, args)
+		},
+	},
+	"signrawtransactionwithkey": {
+		usage: `signrawtransactionwithkey "hextx" ["privkey",...] ` +
+			`([{"txid":"value","vout":n,"scriptPubKey":"value","redeemScript":"value"},...]) ("sighashtype")`,
+		minArgs: 2,
+		maxArgs: 4,
+		handler: offlineSignRawTransactionWithKey,
+	},
+	"deriveaddresses": {
+		usage:   `deriveaddresses "wif|xpub" (branch=0) (start=0) (count=1)`,
+		minArgs: 1,
+		maxArgs: 4,
+		handler: offlineDeriveAddresses,
+	},
+}
+
+// sigHashTypes are the signature hash types signrawtransactionwithkey accepts, by name.
+var sigHashTypes = map[string]txscript.SigHashType{
+	"ALL":                 txscript.SigHashAll,
+	"NONE":                txscript.SigHashNone,
+	"SINGLE":              txscript.SigHashSingle,
+	"ALL|ANYONECANPAY":    txscript.SigHashAll | txscript.SigHashAnyOneCanPay,
+	"NONE|ANYONECANPAY":   txscript.SigHashNone | txscript.SigHashAnyOneCanPay,
+	"SINGLE|ANYONECANPAY": txscript.SigHashSingle | txscript.SigHashAnyOneCanPay,
+}
+
+// DerivedAddress is an address derived by the deriveaddresses command and the index of its key in the branch.
+type DerivedAddress struct {
+	Index   uint32 `json:"index"`
+	Address string `json:"address"`
+}
+
+// OfflineUsages returns the usage of each of the commands ctl runs without a server, sorted by name.
+func OfflineUsages() (usages []string) {
+	for _, cmd := range offlineCommands {
+		usages = append(usages, cmd.usage)
+	}
+	sort.Strings(usages)
+	return
+}
+
+// CallOffline runs a command that does not need a server and returns its result as JSON. It returns false if the method
+// is not one of the offline commands.
+func CallOffline(params *chaincfg.Params, method string, args ...string) (result []byte, ok bool, e error) {
+	var cmd offlineCommand
+	if cmd, ok = offlineCommands[method]; !ok {
+		return
+	}
+	if len(args) < cmd.minArgs || len(args) > cmd.maxArgs {
+		e = errors.New("usage: " + cmd.usage)
+		return
+	}
+	var res interface{}
+	if res, e = cmd.handler(params, args); e != nil {
+		return
+	}
+	result, e = json.Marshal(res)
+	return
+}
+
+// offlineServerCommand parses the arguments of a chain server command and runs its handler locally. Only handlers that
+// need nothing from the server but its chain parameters can be run this way.
+func offlineServerCommand(
+	params *chaincfg.Params, method string,
+	handler func(*chainrpc.Server, interface{}, qu.C) (interface{}, error), args []string,
+) (interface{}, error) {
+	iargs := make([]interface{}, len(args))
+	for i := range args {
+		iargs[i] = args[i]
+	}
+	cmd, e := btcjson.NewCmd(method, iargs...)
+	if e != nil {
+		return nil, fmt.Errorf("%s command: %v", method, e)
+	}
+	return handler(&chainrpc.Server{Cfg: chainrpc.ServerConfig{ChainParams: params}}, cmd, nil)
+}
+
+// offlineSignRawTransactionWithKey signs the inputs of a raw transaction with the given private keys. As there is no
+// server to look up the outputs being spent, the script of the output spent by each input must be given.
+func offlineSignRawTransactionWithKey(params *chaincfg.Params, args []string) (interface{}, error) {
+	serializedTx, e := hex.DecodeString(args[0])
+	if e != nil {
+		return nil, fmt.Errorf("invalid transaction hex: %v", e)
+	}
+	var tx wire.MsgTx
+	if e = tx.Deserialize(bytes.NewReader(serializedTx)); e != nil {
+		return nil, fmt.Errorf("TX decode failed: %v", e)
+	}
+	var wifs []string
+	if e = json.Unmarshal([]byte(args[1]), &wifs); e != nil {
+		return nil, fmt.Errorf("invalid private key list: %v", e)
+	}
+	keys := make(map[string]*util.WIF)
+	for _, key := range wifs {
+		var wif *util.WIF
+		if wif, e = util.DecodeWIF(key); e != nil {
+			return nil, fmt.Errorf("invalid private key: %v", e)
+		}
+		if !wif.IsForNet(params) {
+			return nil, fmt.Errorf("private key is not for %s", params.Name)
+		}
+		var addr *btcaddr.PubKey
+		if addr, e = btcaddr.NewPubKey(wif.SerializePubKey(), params); e != nil {
+			return nil, e
+		}
+		keys[addr.EncodeAddress()] = wif
+	}
+	var inputs []btcjson.RawTxInput
+	if len(args) > 2 {
+		if e = json.Unmarshal([]byte(args[2]), &inputs); e != nil {
+			return nil, fmt.Errorf("invalid previous outputs: %v", e)
+		}
+	}
+	prevScripts := make(map[wire.OutPoint][]byte)
+	redeemScripts := make(map[string][]byte)
+	for _, input := range inputs {
+		var hash *chainhash.Hash
+		if hash, e = chainhash.NewHashFromStr(input.Txid); e != nil {
+			return nil, fmt.Errorf("invalid txid %s: %v", input.Txid, e)
+		}
+		var script []byte
+		if script, e = hex.DecodeString(input.ScriptPubKey); e != nil {
+			return nil, fmt.Errorf("invalid scriptPubKey: %v", e)
+		}
+		prevScripts[wire.OutPoint{Hash: *hash, Index: input.Vout}] = script
+		if input.RedeemScript != "" {
+			var redeemScript []byte
+			if redeemScript, e = hex.DecodeString(input.RedeemScript); e != nil {
+				return nil, fmt.Errorf("invalid redeemScript: %v", e)
+			}
+			var addr *btcaddr.ScriptHash
+			if addr, e = btcaddr.NewScriptHash(redeemScript, params); e != nil {
+				return nil, e
+			}
+			redeemScripts[addr.EncodeAddress()] = redeemScript
+		}
+	}
+	hashType := txscript.SigHashAll
+	if len(args) > 3 {
+		var ok bool
+		if hashType, ok = sigHashTypes[args[3]]; !ok {
+			return nil, fmt.Errorf("invalid sighash type %s", args[3])
+		}
+	}
+	getKey := txscript.KeyClosure(
+		func(addr btcaddr.Address) (*ec.PrivateKey, bool, error) {
+			wif, ok := keys[addr.EncodeAddress()]
+			if !ok {
+				return nil, false, errors.New("no key for address")
+			}
+			return wif.PrivKey, wif.CompressPubKey, nil
+		},
+	)
+	getScript := txscript.ScriptClosure(
+		func(addr btcaddr.Address) ([]byte, error) {
+			script, ok := redeemScripts[addr.EncodeAddress()]
+			if !ok {
+				return nil, errors.New("no script for address")
+			}
+			return script, nil
+		},
+	)
+	signErrors := make([]btcjson.SignRawTransactionError, 0)
+	for i, txIn := range tx.TxIn {
+		prevScript, ok := prevScripts[txIn.PreviousOutPoint]
+		if !ok {
+			signErrors = append(signErrors, signError(txIn, "the output spent by the input was not given"))
+			continue
+		}
+		// SigHashSingle inputs can only be signed if there is a corresponding output.
+		if hashType&txscript.SigHashSingle != txscript.SigHashSingle || i < len(tx.TxOut) {
+			var script []byte
+			if script, e = txscript.SignTxOutput(
+				params, &tx, i, prevScript, hashType, getKey, getScript, txIn.SignatureScript,
+			); e != nil {
+				signErrors = append(signErrors, signError(txIn, e.Error()))
+				continue
+			}
+			txIn.SignatureScript = script
+		}
+		// Check whether the input is now fully signed.
+		var vm *txscript.Engine
+		if vm, e = txscript.NewEngine(prevScript, &tx, i, txscript.StandardVerifyFlags, nil, nil, 0); e == nil {
+			e = vm.Execute()
+		}
+		if e != nil {
+			signErrors = append(signErrors, signError(txIn, e.Error()))
+		}
+	}
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if e = tx.Serialize(&buf); e != nil {
+		return nil, e
+	}
+	return btcjson.SignRawTransactionResult{
+		Hex:      hex.EncodeToString(buf.Bytes()),
+		Complete: len(signErrors) == 0,
+		Errors:   signErrors,
+	}, nil
+}
+
+// signError returns the error reported by signrawtransactionwithkey for an input that could not be signed.
+func signError(txIn *wire.TxIn, message string) btcjson.SignRawTransactionError {
+	return btcjson.SignRawTransactionError{
+		TxID:      txIn.PreviousOutPoint.Hash.String(),
+		Vout:      txIn.PreviousOutPoint.Index,
+		ScriptSig: hex.EncodeToString(txIn.SignatureScript),
+		Sequence:  txIn.Sequence,
+		Error:     message,
+	}
+}
+
+// offlineDeriveAddresses returns the pay to public key hash address of a WIF private key, or count addresses of a branch
+// of an extended public or private key starting from the key at index start. Indexes that do not derive a valid key are
+// skipped, as a wallet does.
+func offlineDeriveAddresses(params *chaincfg.Params, args []string) (interface{}, error) {
+	if wif, e := util.DecodeWIF(args[0]); e == nil {
+		if !wif.IsForNet(params) {
+			return nil, fmt.Errorf("private key is not for %s", params.Name)
+		}
+		var addr *btcaddr.PubKeyHash
+		if addr, e = btcaddr.NewPubKeyHash(btcaddr.Hash160(wif.SerializePubKey()), params); e != nil {
+			return nil, e
+		}
+		return []DerivedAddress{{Address: addr.EncodeAddress()}}, nil
+	}
+	key, e := hdkeychain.NewKeyFromString(args[0])
+	if e != nil {
+		return nil, errors.New("key is neither a WIF private key nor an extended key")
+	}
+	if !key.IsForNet(params) {
+		return nil, fmt.Errorf("extended key is not for %s", params.Name)
+	}
+	nums := []uint32{0, 0, 1}
+	for i, arg := range args[1:] {
+		var n uint64
+		if n, e = strconv.ParseUint(arg, 10, 32); e != nil {
+			return nil, fmt.Errorf("invalid number %s", arg)
+		}
+		nums[i] = uint32(n)
+	}
+	branch, start, count := nums[0], nums[1], nums[2]
+	if key, e = key.Neuter(); e != nil {
+		return nil, e
+	}
+	if key, e = key.Child(branch); e != nil {
+		return nil, fmt.Errorf("branch %d: %v", branch, e)
+	}
+	addrs := make([]DerivedAddress, 0, count)
+	for index := start; uint32(len(addrs)) < count && index < hdkeychain.HardenedKeyStart; index++ {
+		var child *hdkeychain.ExtendedKey
+		if child, e = key.Child(index); e == hdkeychain.ErrInvalidChild {
+			continue
+		} else if e != nil {
+			return nil, e
+		}
+		var addr *btcaddr.PubKeyHash
+		if addr, e = child.Address(params); e != nil {
+			return nil, e
+		}
+		addrs = append(addrs, DerivedAddress{Index: index, Address: addr.EncodeAddress()})
+	}
+	return addrs, nil
+}
@@ -0,0 +1,134 @@
+package ctl
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// TestOfflineSignRawTransaction ensures a transaction assembled with createrawtransaction is fully signed by
+// signrawtransactionwithkey and decodes with decoderawtransaction, all without a server.
+func TestOfflineSignRawTransaction(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	key, e := ec.NewPrivateKey(ec.S256())
+	if e != nil {
+		t.Fatal(e)
+	}
+	wif, e := util.NewWIF(key, params, true)
+	if e != nil {
+		t.Fatal(e)
+	}
+	addr, e := btcaddr.NewPubKeyHash(btcaddr.Hash160(wif.SerializePubKey()), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	prevHash := chainhash.DoubleHashH([]byte("previous"))
+	inputs := fmt.Sprintf(`[{"txid":"%s","vout":1}]`, prevHash)
+	amounts := fmt.Sprintf(`{"%s":1.5}`, addr.EncodeAddress())
+	result, ok, e := CallOffline(params, "createrawtransaction", inputs, amounts)
+	if !ok || e != nil {
+		t.Fatalf("createrawtransaction: %v", e)
+	}
+	var rawTx string
+	if e = json.Unmarshal(result, &rawTx); e != nil {
+		t.Fatal(e)
+	}
+	prevOuts := fmt.Sprintf(`[{"txid":"%s","vout":1,"scriptPubKey":"%s"}]`, prevHash, hex.EncodeToString(pkScript))
+	// Without the output being spent the input can not be signed.
+	if result, _, e = CallOffline(params, "signrawtransactionwithkey", rawTx, `["`+wif.String()+`"]`); e != nil {
+		t.Fatalf("signrawtransactionwithkey: %v", e)
+	}
+	var signed btcjson.SignRawTransactionResult
+	if e = json.Unmarshal(result, &signed); e != nil {
+		t.Fatal(e)
+	}
+	if signed.Complete || len(signed.Errors) != 1 {
+		t.Fatalf("signed without the spent output: %+v", signed)
+	}
+	if result, _, e = CallOffline(
+		params, "signrawtransactionwithkey", rawTx, `["`+wif.String()+`"]`, prevOuts, "ALL",
+	); e != nil {
+		t.Fatalf("signrawtransactionwithkey: %v", e)
+	}
+	if e = json.Unmarshal(result, &signed); e != nil {
+		t.Fatal(e)
+	}
+	if !signed.Complete {
+		t.Fatalf("transaction not fully signed: %+v", signed.Errors)
+	}
+	if result, _, e = CallOffline(params, "decoderawtransaction", signed.Hex); e != nil {
+		t.Fatalf("decoderawtransaction: %v", e)
+	}
+	var decoded btcjson.TxRawDecodeResult
+	if e = json.Unmarshal(result, &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if len(decoded.Vin) != 1 || decoded.Vin[0].Txid != prevHash.String() || decoded.Vin[0].ScriptSig.Hex == "" {
+		t.Fatalf("unexpected decoded inputs %+v", decoded.Vin)
+	}
+	if _, _, e = CallOffline(params, "signrawtransactionwithkey", rawTx); e == nil {
+		t.Fatalf("missing private keys were accepted")
+	}
+}
+
+// TestOfflineDeriveAddresses ensures deriveaddresses returns the addresses of the keys of a branch of an extended key,
+// and the address of a WIF private key.
+func TestOfflineDeriveAddresses(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	master, e := hdkeychain.NewMaster(make([]byte, hdkeychain.RecommendedSeedLen), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pub, e := master.Neuter()
+	if e != nil {
+		t.Fatal(e)
+	}
+	result, ok, e := CallOffline(params, "deriveaddresses", pub.String(), "1", "5", "3")
+	if !ok || e != nil {
+		t.Fatalf("deriveaddresses: %v", e)
+	}
+	var addrs []DerivedAddress
+	if e = json.Unmarshal(result, &addrs); e != nil {
+		t.Fatal(e)
+	}
+	if len(addrs) != 3 {
+		t.Fatalf("got %d addresses, want 3", len(addrs))
+	}
+	branch, _ := master.Child(1)
+	for i, addr := range addrs {
+		child, _ := branch.Child(uint32(5 + i))
+		want, _ := child.Address(params)
+		if addr.Index != uint32(5+i) || addr.Address != want.EncodeAddress() {
+			t.Errorf("address %d is %+v, want %s at index %d", i, addr, want.EncodeAddress(), 5+i)
+		}
+	}
+	key, _ := master.ECPrivKey()
+	wif, _ := util.NewWIF(key, params, true)
+	if result, _, e = CallOffline(params, "deriveaddresses", wif.String()); e != nil {
+		t.Fatalf("deriveaddresses: %v", e)
+	}
+	if e = json.Unmarshal(result, &addrs); e != nil {
+		t.Fatal(e)
+	}
+	want, _ := master.Address(params)
+	if len(addrs) != 1 || addrs[0].Address != want.EncodeAddress() {
+		t.Fatalf("got %+v, want %s", addrs, want.EncodeAddress())
+	}
+	if _, _, e = CallOffline(&chaincfg.TestNet3Params, "deriveaddresses", pub.String()); e == nil {
+		t.Fatalf("key for another network was accepted")
+	}
+}
@@ -62,6 +62,8 @@ type State struct {
 	lastNonce         int32
 	lastBlockUpdate   atomic.Int64
 	certs             []byte
+	sources           *workSources
+	expect            *expectations
 }
 
 type nodeSpec struct {
@@ -103,6 +105,8 @@ func New(
 	}
 	s.lastBlockUpdate.Store(time.Now().Add(-time.Second * 3).Unix())
 	s.generator = chainrpc.GetBlkTemplateGenerator(node, cfg, stateCfg)
+	s.sources = newWorkSources(cfg, certs, node.ChainParams, quit)
+	s.expect = newExpectations()
 	var mc *transport.Channel
 	I.S(cfg.MulticastPass.V(), cfg.MulticastPass.Bytes())
 	if mc, e = transport.NewBroadcastChannel(
@@ -117,6 +121,7 @@ func New(
 		return
 	}
 	s.multiConn = mc
+	go s.reportExpectations()
 	go func() {
 		I.Ln("starting shutdown signal watcher")
 		select {
@@ -173,6 +178,11 @@ func (s *State) startWallet() (e error) {
 			Pass:         s.cfg.Password.V(),
 			TLS:          s.cfg.ServerTLS.True(),
 			Certificates: s.certs,
+			Proxy:        s.cfg.RPCProxyAddress.V(),
+			ProxyType:    s.cfg.RPCProxyType.V(),
+			ProxyUser:    s.cfg.RPCProxyUser.V(),
+			ProxyPass:    s.cfg.RPCProxyPass.V(),
+			Params:       s.node.ChainParams,
 		}, nil, s.quit,
 	); T.Chk(e) {
 		return
@@ -302,11 +312,16 @@ out:
 						return
 					}
 				}()
+			case <-s.sources.update:
+				I.Ln("source of work changed, updating block templates")
+				if e = s.updateBlockTemplate(); E.Chk(e) {
+				}
 			case <-ticker.C:
 				// T.Ln("checking if wallet is connected")
 				if !s.checkConnected() {
 					break running
 				}
+				s.checkWorkSources()
 				// I.Ln("resending current templates...")
 				if e = s.multiConn.SendMany(job.Magic, s.templateShards); E.Chk(e) {
 					break
@@ -399,7 +414,11 @@ func (s *State) checkConnected() (connected bool) {
 
 func (s *State) doBlockUpdate(prev *block.Block) (e error) {
 	I.Ln("do block update")
-	if s.nextAddress == nil {
+	// configured payout addresses are rotated per block, and can be changed while running with setminingaddresses
+	prevHash := prev.WireBlock().BlockHash()
+	if addr := s.stateCfg.ActiveMiningAddrs.ForBlock(&prevHash); addr != nil {
+		s.nextAddress = addr
+	} else if s.nextAddress == nil {
 		I.Ln("getting new address for templates")
 		// if s.nextAddress, e = s.GetNewAddressFromMiningAddrs(); T.Chk(e) {
 		if s.nextAddress, e = s.GetNewAddressFromWallet(); T.Chk(e) {
@@ -410,12 +429,20 @@ func (s *State) doBlockUpdate(prev *block.Block) (e error) {
 	}
 	I.Ln("getting templates...", prev.WireBlock().Header.Timestamp)
 	var tpl *templates.Message
-	if tpl, e = s.GetMsgBlockTemplate(prev, s.nextAddress); E.Chk(e) {
+	// when the local node has stalled, work is taken from an upstream node
+	src := s.sources.activeSource()
+	if src != nil {
+		if tpl, e = s.GetUpstreamBlockTemplate(src, s.nextAddress); E.Chk(e) {
+			return
+		}
+	} else if tpl, e = s.GetMsgBlockTemplate(prev, s.nextAddress); E.Chk(e) {
 		s.Stop()
 		return
 	}
 	// I.S(tpl)
 	s.msgBlockTemplates.Add(tpl)
+	s.expect.setTemplate(tpl)
+	s.sources.remember(tpl.Nonce, src, s.msgBlockTemplates)
 	// I.Ln(tpl.Timestamp)
 	I.Ln("caching error corrected message shards...")
 	srl := tpl.Serialize()
@@ -623,6 +650,20 @@ func processSolMsg(
 	defer s.Start()
 	blk := block.NewBlock(msgBlock)
 	blk.SetHeight(tpl.Height)
+	if src := s.sources.submitTarget(so.Nonce); src != nil {
+		I.Ln("submitting blk to upstream node", src.host)
+		if e = withTimeout(
+			func() error {
+				return src.client.SubmitBlock(blk, nil)
+			},
+		); E.Chk(e) {
+			W.Ln("blk submitted via kopach miner rejected by", src.host, e)
+			return
+		}
+		I.Ln("the blk was accepted by", src.host, "at height", blk.Height())
+		s.nextAddress = nil
+		return
+	}
 	var isOrphan bool
 	I.Ln("submitting blk for processing")
 	if isOrphan, e = s.node.SyncManager.ProcessBlock(blk, blockchain.BFNone); E.Chk(e) {
@@ -691,6 +732,7 @@ func processHashrateMsg(
 	s.lastNonce = hr.Nonce
 	// add to total hash counts
 	s.hashCount.Add(uint64(hr.Count))
+	s.expect.addHashes(hr.Version, hr.Count)
 	return
 }
 
@@ -0,0 +1,178 @@
+package ctrl
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VividCortex/ewma"
+
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainrpc"
+	"github.com/p9c/pod/pkg/chainrpc/templates"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// The controller works out what its miners can expect to find from the hashes they report for each algorithm and the
+// targets of the templates it sends them. A block of an algorithm takes on average 2^256 / (target + 1) hashes to find,
+// so the expected time to find one is that divided by the hashrate of the algorithm. Blocks are found at random at that
+// rate, so the number found within a period follows a Poisson distribution, and the chance of finding at least one is
+// 1 - e^(-period / expected time). The expectations are reported by getminingexpectation and shown on the mining page
+// of the GUI.
+
+const (
+	// expectInterval is how often the hashrates are sampled and the expectations worked out.
+	expectInterval = time.Second
+	// hashrateAge is the number of samples the hashrate of each algorithm is averaged over. Miners report their hashes
+	// when they switch algorithms, so the average is taken over long enough to smooth out the gaps between reports.
+	hashrateAge = 120
+)
+
+// expectWindows are the periods the chance of finding a block within is given for.
+var expectWindows = []time.Duration{time.Hour, time.Hour * 24, time.Hour * 24 * 7, time.Hour * 24 * 30}
+
+// ExpectedHashes returns the average number of hashes it takes to find a block meeting the target of the compact bits.
+func ExpectedHashes(bits uint32) float64 {
+	hashes, _ := new(big.Float).SetInt(blockchain.CalcWork(bits, 0, 0)).Float64()
+	return hashes
+}
+
+// ExpectedTime returns the average time in seconds the hashrate takes to find a block meeting the target of the compact
+// bits, or 0 if the hashrate is not positive.
+func ExpectedTime(bits uint32, hashrate float64) float64 {
+	if hashrate <= 0 {
+		return 0
+	}
+	return ExpectedHashes(bits) / hashrate
+}
+
+// BlockProbability returns the chance of finding at least one block within the window at the given expected time in
+// seconds to find a block, which is 0 if the expected time is not known.
+func BlockProbability(expectedTime float64, window time.Duration) float64 {
+	if expectedTime <= 0 {
+		return 0
+	}
+	return -math.Expm1(-window.Seconds() / expectedTime)
+}
+
+// windowResults returns the chances of finding a block within each of the windows at the given expected time.
+func windowResults(expectedTime float64) (windows []btcjson.MiningWindowResult) {
+	windows = make([]btcjson.MiningWindowResult, len(expectWindows))
+	for i, w := range expectWindows {
+		windows[i] = btcjson.MiningWindowResult{
+			Window:      int64(w / time.Second),
+			Probability: BlockProbability(expectedTime, w),
+		}
+	}
+	return
+}
+
+// expectations holds the hashes reported by the miners for each algorithm, by block version, and the targets of the
+// latest templates.
+type expectations struct {
+	sync.Mutex
+	height int32
+	bits   templates.Diffs
+	counts map[int32]uint64
+	last   map[int32]uint64
+	rates  map[int32]ewma.MovingAverage
+}
+
+func newExpectations() *expectations {
+	return &expectations{
+		counts: make(map[int32]uint64),
+		last:   make(map[int32]uint64),
+		rates:  make(map[int32]ewma.MovingAverage),
+	}
+}
+
+// addHashes adds the hashes a miner reported for the algorithm of the block version.
+func (x *expectations) addHashes(version int32, count int) {
+	x.Lock()
+	defer x.Unlock()
+	x.counts[version] += uint64(count)
+}
+
+// setTemplate records the targets of the templates sent to the miners.
+func (x *expectations) setTemplate(tpl *templates.Message) {
+	x.Lock()
+	defer x.Unlock()
+	x.height, x.bits = tpl.Height, tpl.Bits
+}
+
+// sample adds the hashes reported since the last sample to the average hashrate of each algorithm, for an interval of
+// the given length.
+func (x *expectations) sample(interval time.Duration) {
+	x.Lock()
+	defer x.Unlock()
+	for version, count := range x.counts {
+		rate, ok := x.rates[version]
+		if !ok {
+			rate = ewma.NewMovingAverage(hashrateAge)
+			x.rates[version] = rate
+		}
+		rate.Add(float64(count-x.last[version]) / interval.Seconds())
+		x.last[version] = count
+	}
+}
+
+// result returns the expectation of finding a block with each of the algorithms of the latest templates, and with any
+// of them.
+func (x *expectations) result(params *chaincfg.Params, now time.Time) (result btcjson.GetMiningExpectationResult) {
+	x.Lock()
+	defer x.Unlock()
+	result = btcjson.GetMiningExpectationResult{Updated: now.Unix(), Height: x.height}
+	versions := make([]int32, 0, len(x.bits))
+	for version := range x.bits {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	// blocks of each algorithm are found independently, so the rate of finding any block is the sum of their rates
+	var blocksPerSecond float64
+	for _, version := range versions {
+		bits := x.bits[version]
+		var hashrate float64
+		if rate, ok := x.rates[version]; ok {
+			hashrate = rate.Value()
+		}
+		expected := ExpectedTime(bits, hashrate)
+		if expected > 0 {
+			blocksPerSecond += 1 / expected
+		}
+		result.Hashrate += hashrate
+		result.Algos = append(
+			result.Algos, btcjson.MiningAlgoExpectationResult{
+				Algo:         fork.GetAlgoName(version, x.height),
+				Version:      version,
+				Difficulty:   chainrpc.GetDifficultyRatio(bits, params, version),
+				Hashrate:     hashrate,
+				ExpectedTime: expected,
+				Windows:      windowResults(expected),
+			},
+		)
+	}
+	if blocksPerSecond > 0 {
+		result.ExpectedTime = 1 / blocksPerSecond
+	}
+	result.Windows = windowResults(result.ExpectedTime)
+	return
+}
+
+// reportExpectations samples the hashrates and reports the expectations to the node until the controller shuts down.
+func (s *State) reportExpectations() {
+	ticker := time.NewTicker(expectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.expect.sample(expectInterval)
+			s.node.MiningExpectation.Set(s.expect.result(s.node.ChainParams, time.Now()))
+		case <-s.quit.Wait():
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package ctrl
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainrpc/templates"
+)
+
+// TestExpectations ensures the expected time to find a block follows from the hashrate reported for each algorithm and
+// its target, that the expected time to find a block with any algorithm adds up their rates, and that the chance of
+// finding a block within a window is that of a Poisson process.
+func TestExpectations(t *testing.T) {
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-6*math.Max(math.Abs(a), math.Abs(b))
+	}
+	// a block meeting this target takes 2^24 / 0x7fffff, a little over 2, hashes on average
+	const bits = 0x207fffff
+	hashes := math.Exp2(24) / 0x7fffff
+	if got := ExpectedHashes(bits); !near(got, hashes) {
+		t.Fatalf("expected hashes %v, want %v", got, hashes)
+	}
+	if got := ExpectedTime(bits, 0); got != 0 {
+		t.Errorf("expected time with no hashrate %v, want 0", got)
+	}
+	if got := BlockProbability(3600, time.Hour); !near(got, 1-math.Exp(-1)) {
+		t.Errorf("chance of a block within its expected time %v, want %v", got, 1-math.Exp(-1))
+	}
+	x := newExpectations()
+	x.setTemplate(&templates.Message{Height: 100, Bits: templates.Diffs{2: bits, 514: bits}})
+	// enough samples to get the averages past their warm up
+	for i := 0; i < 20; i++ {
+		x.addHashes(2, 4)
+		x.addHashes(514, 2)
+		x.sample(time.Second)
+	}
+	r := x.result(&chaincfg.MainNetParams, time.Unix(1600000000, 0))
+	if r.Height != 100 || r.Updated != 1600000000 || len(r.Algos) != 2 {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	for i, want := range []struct {
+		version  int32
+		hashrate float64
+	}{{2, 4}, {514, 2}} {
+		a := r.Algos[i]
+		if a.Version != want.version || !near(a.Hashrate, want.hashrate) ||
+			!near(a.ExpectedTime, hashes/want.hashrate) {
+			t.Errorf("algorithm %d: unexpected expectation %+v", i, a)
+		}
+		if len(a.Windows) != len(expectWindows) {
+			t.Errorf("algorithm %d: %d windows, want %d", i, len(a.Windows), len(expectWindows))
+		}
+	}
+	// blocks are found at 4/hashes + 2/hashes a second with either algorithm
+	if !near(r.Hashrate, 6) || !near(r.ExpectedTime, hashes/6) {
+		t.Errorf("unexpected hashrate %v and expected time %v", r.Hashrate, r.ExpectedTime)
+	}
+	if w := r.Windows[0]; w.Window != 3600 || !near(w.Probability, -math.Expm1(-3600*6/hashes)) {
+		t.Errorf("unexpected window %+v", w)
+	}
+}
@@ -0,0 +1,321 @@
+package ctrl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/p9c/qu"
+	"go.uber.org/atomic"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/chainrpc"
+	"github.com/p9c/pod/pkg/chainrpc/templates"
+	"github.com/p9c/pod/pkg/rpcclient"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pod/config"
+)
+
+const (
+	// healthCheckInterval is how often the upstream nodes are checked, and requestTimeout how long a node has to answer
+	// a request before it is treated as unhealthy.
+	healthCheckInterval = time.Second * 10
+	requestTimeout      = time.Second * 5
+)
+
+// workSource is an upstream node the controller can take its work from when the local node stalls.
+type workSource struct {
+	host    string
+	client  *rpcclient.Client
+	healthy bool
+	height  int32
+	checked time.Time
+	err     error
+}
+
+// workSources tracks the health of the upstream nodes and which node the controller takes its work from.
+type workSources struct {
+	sync.Mutex
+	upstream     []*workSource
+	stallTimeout time.Duration
+	// active is the upstream node work is taken from, nil while it is taken from the local node, and since when.
+	active *workSource
+	since  time.Time
+	// behindSince is when the local node fell behind the best healthy upstream node, zero while it is not behind, and
+	// stalled whether it has stayed behind for the stall timeout or is not current.
+	behindSince time.Time
+	stalled     bool
+	lastCheck   time.Time
+	checking    atomic.Bool
+	// submitTo is the upstream node each of the recent templates made from upstream work came from, so solutions are
+	// submitted back to it.
+	submitTo map[uint64]*workSource
+	// update is signalled when the templates should be remade, because the source of work or its best block changed.
+	update chan struct{}
+}
+
+// newWorkSources creates a client for each of the configured upstream nodes, which use the same RPC credentials as the
+// local node.
+func newWorkSources(cfg *config.Config, certs []byte, params *chaincfg.Params, quit qu.C) (w *workSources) {
+	w = &workSources{
+		stallTimeout: cfg.ControllerStallTimeout.V(),
+		since:        time.Now(),
+		submitTo:     make(map[uint64]*workSource),
+		update:       make(chan struct{}, 1),
+	}
+	for _, host := range cfg.ControllerNodes.S() {
+		var client *rpcclient.Client
+		var e error
+		if client, e = rpcclient.New(
+			&rpcclient.ConnConfig{
+				Host:         host,
+				User:         cfg.Username.V(),
+				Pass:         cfg.Password.V(),
+				TLS:          cfg.ServerTLS.True(),
+				Certificates: certs,
+				Proxy:        cfg.RPCProxyAddress.V(),
+				ProxyType:    cfg.RPCProxyType.V(),
+				ProxyUser:    cfg.RPCProxyUser.V(),
+				ProxyPass:    cfg.RPCProxyPass.V(),
+				HTTPPostMode: true,
+				Params:       params,
+			}, nil, quit,
+		); E.Chk(e) {
+			continue
+		}
+		I.Ln("controller can take work from upstream node", host)
+		w.upstream = append(w.upstream, &workSource{host: host, client: client})
+	}
+	return
+}
+
+// withTimeout runs the request, giving up on it after the request timeout, as the RPC client does not time out requests
+// itself. Anything the request stores must only be used when it returns no error.
+func withTimeout(request func() error) (e error) {
+	done := make(chan error, 1)
+	go func() {
+		done <- request()
+	}()
+	t := time.NewTimer(requestTimeout)
+	defer t.Stop()
+	select {
+	case e = <-done:
+	case <-t.C:
+		e = errors.New("timed out")
+	}
+	return
+}
+
+// choose picks the source of work, failing over to the healthy upstream node with the best chain when the local node
+// is not current or has been behind it for the stall timeout, and back to the local node once it has caught up. An
+// active upstream node is kept while it is healthy and as far ahead as any other, so work does not flap between nodes
+// at the same height.
+func (w *workSources) choose(now time.Time, localHeight int32, localCurrent bool) (active *workSource) {
+	var best *workSource
+	for _, u := range w.upstream {
+		if u.healthy && (best == nil || u.height > best.height) {
+			best = u
+		}
+	}
+	if best == nil || localHeight >= best.height {
+		w.behindSince = time.Time{}
+	} else if w.behindSince.IsZero() {
+		w.behindSince = now
+	}
+	w.stalled = !localCurrent || (!w.behindSince.IsZero() && now.Sub(w.behindSince) >= w.stallTimeout)
+	switch {
+	case !w.stalled || best == nil:
+		return nil
+	case w.active != nil && w.active.healthy && w.active.height >= best.height:
+		return w.active
+	}
+	return best
+}
+
+// status returns the name of the active source of work and the health of all the sources, local first.
+func (w *workSources) status(now time.Time, localHeight int32, localCurrent bool) (
+	active string, sources []btcjson.WorkSourceResult,
+) {
+	active = chainrpc.LocalWorkSource
+	if w.active != nil {
+		active = w.active.host
+	}
+	local := btcjson.WorkSourceResult{
+		Host:    chainrpc.LocalWorkSource,
+		Healthy: !w.stalled,
+		Height:  localHeight,
+		Checked: now.Unix(),
+	}
+	switch {
+	case !localCurrent:
+		local.Error = "not current with the network"
+	case w.stalled:
+		local.Error = fmt.Sprintf("behind upstream nodes since %v", w.behindSince.Format(time.RFC3339))
+	}
+	sources = append(sources, local)
+	for _, u := range w.upstream {
+		r := btcjson.WorkSourceResult{
+			Host:    u.host,
+			Healthy: u.healthy,
+			Height:  u.height,
+			Checked: u.checked.Unix(),
+		}
+		if u.err != nil {
+			r.Error = u.err.Error()
+		}
+		sources = append(sources, r)
+	}
+	return
+}
+
+// checkWorkSources asks each of the upstream nodes for its best block height when the health check interval has
+// passed, then picks the source of work, reports it to the node, and signals when the templates should be remade. The
+// checks are done in the background and one at a time, so a node that does not answer does not hold up the controller.
+func (s *State) checkWorkSources() {
+	w := s.sources
+	if len(w.upstream) == 0 || time.Since(w.lastCheck) < healthCheckInterval || !w.checking.CAS(false, true) {
+		return
+	}
+	w.lastCheck = time.Now()
+	go func() {
+		defer w.checking.Store(false)
+		heights := make([]int32, len(w.upstream))
+		errs := make([]error, len(w.upstream))
+		var wg sync.WaitGroup
+		for i := range w.upstream {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var count int64
+				if errs[i] = withTimeout(
+					func() (e error) {
+						count, e = w.upstream[i].client.GetBlockCount()
+						return
+					},
+				); errs[i] == nil {
+					heights[i] = int32(count)
+				}
+			}(i)
+		}
+		wg.Wait()
+		now := time.Now()
+		localHeight := s.node.Chain.BestSnapshot().Height
+		localCurrent := s.node.SyncManager.IsCurrent()
+		w.Lock()
+		prev, prevHeight := w.active, int32(-1)
+		if prev != nil {
+			prevHeight = prev.height
+		}
+		for i, u := range w.upstream {
+			u.checked, u.err, u.healthy = now, errs[i], errs[i] == nil
+			if u.healthy {
+				u.height = heights[i]
+			} else {
+				D.Ln("upstream node", u.host, "is unhealthy:", errs[i])
+			}
+		}
+		w.active = w.choose(now, localHeight, localCurrent)
+		changed := w.active != prev
+		if changed {
+			w.since = now
+		}
+		refresh := changed || (w.active != nil && w.active.height != prevHeight)
+		active, sources := w.status(now, localHeight, localCurrent)
+		since := w.since
+		w.Unlock()
+		if changed {
+			W.Ln("controller is now taking its work from", active)
+		}
+		s.node.WorkSource.Set(active, since, sources)
+		if refresh {
+			select {
+			case w.update <- struct{}{}:
+			default:
+			}
+		}
+	}()
+}
+
+// activeSource returns the upstream node work is taken from, or nil for the local node.
+func (w *workSources) activeSource() *workSource {
+	w.Lock()
+	defer w.Unlock()
+	return w.active
+}
+
+// remember records the upstream node the template came from, nil for the local node, and forgets the nodes of templates
+// that are no longer recent.
+func (w *workSources) remember(nonce uint64, src *workSource, recent *templates.RecentMessages) {
+	w.Lock()
+	defer w.Unlock()
+	for n := range w.submitTo {
+		if recent.Find(n) == nil {
+			delete(w.submitTo, n)
+		}
+	}
+	if src != nil {
+		w.submitTo[nonce] = src
+	}
+}
+
+// submitTarget returns the upstream node a solution for the template with the nonce is submitted to, or nil to submit
+// it to the local node.
+func (w *workSources) submitTarget(nonce uint64) *workSource {
+	w.Lock()
+	defer w.Unlock()
+	return w.submitTo[nonce]
+}
+
+// GetUpstreamBlockTemplate gets a Message made from the work of an upstream node, paying to a given address
+func (s *State) GetUpstreamBlockTemplate(
+	src *workSource, addr btcaddr.Address,
+) (mbt *templates.Message, e error) {
+	T.Ln("GetUpstreamBlockTemplate", src.host)
+	var res *btcjson.GetMiningTemplateResult
+	if e = withTimeout(
+		func() (e error) {
+			res, e = src.client.GetMiningTemplate(addr.EncodeAddress())
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	var prev *chainhash.Hash
+	if prev, e = chainhash.NewHashFromStr(res.PreviousHash); E.Chk(e) {
+		return
+	}
+	mbt = &templates.Message{
+		Nonce:     rand.Uint64(),
+		UUID:      s.uuid,
+		PrevBlock: *prev,
+		Height:    res.Height,
+		Bits:      make(templates.Diffs),
+		Merkles:   make(templates.Merkles),
+	}
+	for _, b := range res.Blocks {
+		var raw []byte
+		if raw, e = hex.DecodeString(b.Hex); E.Chk(e) {
+			return
+		}
+		var blk wire.Block
+		if e = blk.Deserialize(bytes.NewReader(raw)); E.Chk(e) {
+			return
+		}
+		if blk.Header.PrevBlock != *prev {
+			e = fmt.Errorf("upstream node %s sent a %s block for another parent block", src.host, b.Algo)
+			return
+		}
+		mbt.Timestamp = blk.Header.Timestamp
+		mbt.Bits[b.Version] = blk.Header.Bits
+		mbt.Merkles[b.Version] = blk.Header.MerkleRoot
+		mbt.SetTxs(b.Version, blk.Transactions)
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package ctrl
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWorkSourceFailover ensures the controller keeps to the local node while it is current and not behind, fails over
+// to the best healthy upstream node once the local node has been behind it for the stall timeout or is not current,
+// keeps to the upstream node while it is as far ahead as any other, and goes back to the local node when it catches up.
+func TestWorkSourceFailover(t *testing.T) {
+	a, b := &workSource{host: "a:11048", healthy: true}, &workSource{host: "b:11048", healthy: true}
+	w := &workSources{upstream: []*workSource{a, b}, stallTimeout: time.Second * 30}
+	start := time.Unix(1600000000, 0)
+	steps := []struct {
+		after        time.Duration
+		local        int32
+		current      bool
+		a, b         int32
+		aHealthy     bool
+		want         *workSource
+		wantStalled  bool
+		wantLocalErr bool
+	}{
+		{0, 100, true, 100, 100, true, nil, false, false},
+		// an upstream node getting a block first is not a stall until the local node stays behind for the timeout
+		{time.Second, 100, true, 101, 100, true, nil, false, false},
+		{time.Second * 29, 100, true, 101, 100, true, nil, false, false},
+		{time.Second * 31, 100, true, 101, 100, true, a, true, true},
+		// the active node is kept while no other node is further ahead, even after it falls back to the same height
+		{time.Second * 40, 100, true, 102, 102, true, a, true, true},
+		{time.Second * 50, 100, true, 102, 103, true, b, true, true},
+		// an unhealthy node is not taken work from
+		{time.Second * 60, 100, true, 104, 103, false, b, true, true},
+		{time.Second * 70, 104, true, 104, 104, true, nil, false, false},
+		// a local node that is not current is stalled whatever the heights
+		{time.Second * 80, 104, false, 104, 104, true, a, true, true},
+	}
+	for i, step := range steps {
+		a.height, b.height, a.healthy = step.a, step.b, step.aHealthy
+		w.active = w.choose(start.Add(step.after), step.local, step.current)
+		if w.active != step.want {
+			t.Fatalf("step %d chose %v, want %v", i, w.active, step.want)
+		}
+		if w.stalled != step.wantStalled {
+			t.Fatalf("step %d stalled is %v, want %v", i, w.stalled, step.wantStalled)
+		}
+		_, sources := w.status(start.Add(step.after), step.local, step.current)
+		if len(sources) != 3 || sources[0].Healthy == step.wantStalled || (sources[0].Error != "") != step.wantLocalErr {
+			t.Fatalf("step %d reported %+v", i, sources)
+		}
+	}
+	w.active = nil
+	w.upstream = nil
+	if w.choose(start.Add(time.Hour), 0, false) != nil {
+		t.Fatal("failed over with no upstream nodes")
+	}
+	if e := withTimeout(func() error { return errors.New("failed") }); e == nil || e.Error() != "failed" {
+		t.Fatalf("request error was %v", e)
+	}
+}
@@ -0,0 +1,157 @@
+package gui
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// The receive and send address books are kept in the wallet as address metadata, so every front end of the wallet sees
+// the same entries. The lists in the State are only a copy of them for drawing the pages, which is reloaded after
+// every change. The receive entries are read as invoices, whose state the wallet finds from the payments to their
+// address, so they are reloaded along with the transactions as well.
+
+// invoiceExpiry is how long a payment request made on the receive page waits to be paid before it expires.
+const invoiceExpiry = 7 * 24 * time.Hour
+
+// invoiceStates are the states of the invoices on the receive page, with the names of the bools that select whether
+// the invoices in each state are shown.
+var invoiceStates = []struct{ state, show string }{
+	{"open", "showInvoiceOpen"},
+	{"partial", "showInvoicePartial"},
+	{"paid", "showInvoicePaid"},
+	{"expired", "showInvoiceExpired"},
+	{"cancelled", "showInvoiceCancelled"},
+}
+
+// updateAddressBooks reloads the receive and send address books from the wallet.
+func (wg *WalletGUI) updateAddressBooks() {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	var invoices []btcjson.InvoiceResult
+	var e error
+	if invoices, e = wg.WalletClient.ListInvoices("", 1); E.Chk(e) {
+		return
+	}
+	var metas []btcjson.AddressMetaResult
+	if metas, e = wg.WalletClient.ListAddressMeta("send"); E.Chk(e) {
+		return
+	}
+	receive := make([]AddressEntry, len(invoices))
+	for i := range invoices {
+		receive[i] = invoiceEntry(&invoices[i])
+	}
+	send := make([]AddressEntry, len(metas))
+	for i := range metas {
+		send[i] = addressEntry(&metas[i])
+	}
+	wg.State.receiveAddresses, wg.State.sendAddresses = receive, send
+	wg.Invalidate()
+}
+
+// showInvoice returns whether the invoices in the state are shown on the receive page.
+func (wg *WalletGUI) showInvoice(state string) bool {
+	for _, s := range invoiceStates {
+		if s.state == state {
+			return wg.bools[s.show].GetValue()
+		}
+	}
+	return true
+}
+
+// setAddressMeta changes the metadata of an address in the wallet and reloads the address books.
+func (wg *WalletGUI) setAddressMeta(address string, meta btcjson.AddressMetaFields) (e error) {
+	var addr btcaddr.Address
+	if addr, e = btcaddr.Decode(address, wg.cx.ActiveNet); E.Chk(e) {
+		return
+	}
+	if _, e = wg.WalletClient.SetAddressMeta(addr, meta); E.Chk(e) {
+		return
+	}
+	wg.updateAddressBooks()
+	return
+}
+
+// migrateAddressBooks moves the address books kept in the state file by older versions into the wallet, unless the
+// wallet already has metadata for the address, and removes them from the state file.
+func (wg *WalletGUI) migrateAddressBooks() {
+	legacy := wg.State.legacyAddresses
+	if len(legacy) == 0 || !wg.WalletAndClientRunning() {
+		return
+	}
+	I.Ln("moving", len(legacy), "address book entries from the state file into the wallet")
+	for _, ae := range legacy {
+		var addr btcaddr.Address
+		var e error
+		if addr, e = btcaddr.Decode(ae.Address, wg.cx.ActiveNet); E.Chk(e) {
+			continue
+		}
+		if _, e = wg.WalletClient.GetAddressMeta(addr); e == nil {
+			continue
+		}
+		amount := ae.Amount.ToDUO()
+		meta := btcjson.AddressMetaFields{Amount: &amount}
+		if ae.Message != "" {
+			meta.Message = &ae.Message
+		}
+		if ae.Label != "" {
+			meta.Label = &ae.Label
+		}
+		if ae.TxID != "" {
+			meta.TxID = &ae.TxID
+		}
+		if _, e = wg.WalletClient.SetAddressMeta(addr, meta); E.Chk(e) {
+			// leave the entries in the state file to try again next time
+			return
+		}
+	}
+	wg.State.legacyAddresses = nil
+	filename := filepath.Join(wg.cx.Config.DataDir.V(), "state.json")
+	if e := wg.State.Save(filename, wg.cx.Config.WalletPass.Bytes(), false); E.Chk(e) {
+	}
+	wg.updateAddressBooks()
+}
+
+// invoiceEntry returns the receive address book entry for an invoice.
+func invoiceEntry(inv *btcjson.InvoiceResult) (ae AddressEntry) {
+	ae = AddressEntry{
+		Address: inv.Address,
+		Message: inv.Message,
+		Label:   inv.Label,
+		State:   inv.State,
+		Created: time.Unix(inv.Created, 0),
+	}
+	if len(inv.Payments) > 0 {
+		ae.TxID = inv.Payments[len(inv.Payments)-1]
+	}
+	if inv.Expires != 0 {
+		ae.Expires = time.Unix(inv.Expires, 0)
+	}
+	var e error
+	if ae.Amount, e = amt.NewAmount(inv.Amount); E.Chk(e) {
+	}
+	if ae.Received, e = amt.NewAmount(inv.Received); E.Chk(e) {
+	}
+	return
+}
+
+// addressEntry returns the address book entry for the metadata of an address.
+func addressEntry(m *btcjson.AddressMetaResult) (ae AddressEntry) {
+	ae = AddressEntry{
+		Address:  m.Address,
+		Message:  m.Message,
+		Label:    m.Label,
+		State:    m.State,
+		Created:  time.Unix(m.Created, 0),
+		Modified: time.Unix(m.Modified, 0),
+		TxID:     m.TxID,
+	}
+	var e error
+	if ae.Amount, e = amt.NewAmount(m.Amount); E.Chk(e) {
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/p9c/gel"
 	"github.com/p9c/pod/cmd/gui/cfg"
+	"github.com/p9c/pod/pkg/chainrpc"
 	"github.com/p9c/pod/pkg/p9icons"
 )
 
@@ -24,7 +26,14 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 		SetLogo(&p9icons.ParallelCoin).
 		SetAppTitleText("Parallelcoin Wallet")
 	wg.MainApp = a
-	wg.config = cfg.New(wg.Window, wg.quit)
+	wg.config = cfg.New(
+		wg.Window, wg.quit, wg.cx.Config, func(name, value string) (e error) {
+			if wg.ChainClient == nil || wg.ChainClient.Disconnected() {
+				return errors.New("not connected to the node")
+			}
+			return wg.ChainClient.SetConfig(name, value)
+		},
+	)
 	wg.configs = wg.config.Config()
 	a.Pages(
 		map[string]l.Widget{
@@ -52,6 +61,11 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 					gel.WidgetSize{Widget: wg.HistoryPage()},
 				},
 			),
+			"portfolio": wg.Page(
+				"portfolio", gel.Widgets{
+					gel.WidgetSize{Widget: wg.PortfolioPage()},
+				},
+			),
 			"settings": wg.Page(
 				"settings", gel.Widgets{
 					// p9.WidgetSize{Widget: p9.EmptyMaxHeight()},
@@ -152,9 +166,7 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 			// ),
 			"mining": wg.Page(
 				"mining", gel.Widgets{
-					gel.WidgetSize{
-						Widget: a.Placeholder("mining"),
-					},
+					gel.WidgetSize{Widget: wg.MiningPage()},
 				},
 			),
 			"explorer": wg.Page(
@@ -173,8 +185,9 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 			wg.SideBarButton("send", "send", 1),
 			wg.SideBarButton("receive", "receive", 2),
 			wg.SideBarButton("history", "history", 3),
+			wg.SideBarButton("portfolio", "portfolio", 4),
 			// wg.SideBarButton("explorer", "explorer", 6),
-			// wg.SideBarButton("mining", "mining", 7),
+			wg.SideBarButton("mining", "mining", 7),
 			wg.SideBarButton("console", "console", 9),
 			wg.SideBarButton("settings", "settings", 5),
 			// wg.SideBarButton("log", "log", 10),
@@ -569,6 +582,21 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 						Fn,
 				).Fn,
 			).
+			Rigid(
+				func(gtx l.Context) l.Dimensions {
+					warning := wg.networkWarning.Load()
+					if warning == "" {
+						return l.Dimensions{}
+					}
+					return wg.Inset(
+						0.33,
+						wg.Caption(warning).
+							Font("go regular").
+							Color("Danger").
+							Fn,
+					).Fn(gtx)
+				},
+			).
 			Rigid(
 				wg.ButtonLayout(wg.statusBarButtons[7]).
 					CornerRadius(0).
@@ -611,6 +639,22 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 					).
 					Fn,
 			).
+			Rigid(
+				func(gtx l.Context) l.Dimensions {
+					// when the controller has failed over to an upstream node, show which one mining work comes from
+					source := wg.workSource.Load()
+					if !wg.cx.Config.Controller.True() || source == chainrpc.LocalWorkSource {
+						return l.Dimensions{}
+					}
+					return wg.Inset(
+						0.33,
+						wg.Caption("work from "+source).
+							Font("go regular").
+							Color("Primary").
+							Fn,
+					).Fn(gtx)
+				},
+			).
 			Rigid(
 				wg.ButtonLayout(wg.statusBarButtons[1]).
 					CornerRadius(0).
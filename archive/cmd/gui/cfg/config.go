@@ -2,6 +2,8 @@ package cfg
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
 	"golang.org/x/exp/shiny/materialdesign/icons"
 
@@ -10,6 +12,9 @@ import (
 	l "github.com/p9c/gio/layout"
 
 	"github.com/p9c/gel"
+	ot "github.com/p9c/opts/text"
+
+	"github.com/p9c/pod/pod/config"
 )
 
 type Item struct {
@@ -20,6 +25,8 @@ type Item struct {
 	widget      string
 	dataType    string
 	options     []string
+	def         string
+	live        bool
 	Slot        interface{}
 }
 
@@ -73,134 +80,162 @@ func (l Lists) Swap(i, j int) {
 	l[i], l[j] = l[j], l[i]
 }
 
+// Config builds the settings page from the schema of the configuration, so every option is shown with the widget for
+// its type, its help text, its default and whether it needs a restart to take effect.
 func (c *Config) Config() GroupsMap {
-	// schema := podcfg.GetConfigSchema(c.cx.Config)
 	tabNames := make(GroupsMap)
-	// // tabs := make(p9.WidgetMap)
-	// for i := range schema.Groups {
-	// 	for j := range schema.Groups[i].Fields {
-	// 		sgf := schema.Groups[i].Fields[j]
-	// 		if _, ok := tabNames[sgf.Group]; !ok {
-	// 			tabNames[sgf.Group] = make(ItemMap)
-	// 		}
-	// 		tabNames[sgf.Group][sgf.Slug] = &Item{
-	// 			slug:        sgf.Slug,
-	// 			typ:         sgf.Type,
-	// 			label:       sgf.Label,
-	// 			description: sgf.Title,
-	// 			widget:      sgf.Widget,
-	// 			dataType:    sgf.Datatype,
-	// 			options:     sgf.Options,
-	// 			Slot:        c.cx.ConfigMap[sgf.Slug],
-	// 		}
-	// 		// D.S(sgf)
-	// 		// create all the necessary widgets required before display
-	// 		tgs := tabNames[sgf.Group][sgf.Slug]
-	// 		switch sgf.Widget {
-	// 		case "toggle":
-	// 			c.Bools[sgf.Slug] = c.Bool(*tgs.Slot.(*bool)).SetOnChange(
-	// 				func(b bool) {
-	// 					D.Ln(sgf.Slug, "submitted", b)
-	// 					bb := c.cx.ConfigMap[sgf.Slug].(*bool)
-	// 					*bb = b
-	// 					podcfg.Save(c.cx.Config)
-	// 					if sgf.Slug == "DarkTheme" {
-	// 						c.Theme.Colors.SetTheme(b)
-	// 					}
-	// 				},
-	// 			)
-	// 		case "integer":
-	// 			c.inputs[sgf.Slug] = c.Input(
-	// 				fmt.Sprint(*tgs.Slot.(*int)), sgf.Slug, "DocText", "DocBg", "PanelBg", func(txt string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					i := c.cx.ConfigMap[sgf.Slug].(*int)
-	// 					if n, e := strconv.Atoi(txt); !E.Chk(e) {
-	// 						*i = n
-	// 					}
-	// 					podcfg.Save(c.cx.Config)
-	// 				}, nil,
-	// 			)
-	// 		case "time":
-	// 			c.inputs[sgf.Slug] = c.Input(
-	// 				fmt.Sprint(
-	// 					*tgs.Slot.(*time.
-	// 					Duration),
-	// 				), sgf.Slug, "DocText", "DocBg", "PanelBg", func(txt string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					tt := c.cx.ConfigMap[sgf.Slug].(*time.Duration)
-	// 					if d, e := time.ParseDuration(txt); !E.Chk(e) {
-	// 						*tt = d
-	// 					}
-	// 					podcfg.Save(c.cx.Config)
-	// 				}, nil,
-	// 			)
-	// 		case "float":
-	// 			c.inputs[sgf.Slug] = c.Input(
-	// 				strconv.FormatFloat(
-	// 					*tgs.Slot.(
-	// 					*float64), 'f', -1, 64,
-	// 				), sgf.Slug, "DocText", "DocBg", "PanelBg", func(txt string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					ff := c.cx.ConfigMap[sgf.Slug].(*float64)
-	// 					if f, e := strconv.ParseFloat(txt, 64); !E.Chk(e) {
-	// 						*ff = f
-	// 					}
-	// 					podcfg.Save(c.cx.Config)
-	// 				}, nil,
-	// 			)
-	// 		case "string":
-	// 			c.inputs[sgf.Slug] = c.Input(
-	// 				*tgs.Slot.(*string), sgf.Slug, "DocText", "DocBg", "PanelBg", func(txt string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					ss := c.cx.ConfigMap[sgf.Slug].(*string)
-	// 					*ss = txt
-	// 					podcfg.Save(c.cx.Config)
-	// 				}, nil,
-	// 			)
-	// 		case "password":
-	// 			c.passwords[sgf.Slug] = c.Password(
-	// 				"password",
-	// 				tgs.Slot.(*string), "DocText", "DocBg", "PanelBg",
-	// 				func(txt string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					pp := c.cx.ConfigMap[sgf.Slug].(*string)
-	// 					*pp = txt
-	// 					podcfg.Save(c.cx.Config)
-	// 				},
-	// 			)
-	// 		case "multi":
-	// 			c.multis[sgf.Slug] = c.Multiline(
-	// 				tgs.Slot.(*cli.StringSlice), "DocText", "DocBg", "PanelBg", 30, func(txt []string) {
-	// 					D.Ln(sgf.Slug, "submitted", txt)
-	// 					sss := c.cx.ConfigMap[sgf.Slug].(*cli.StringSlice)
-	// 					*sss = txt
-	// 					podcfg.Save(c.cx.Config)
-	// 				},
-	// 			)
-	// 			// c.multis[sgf.Slug]
-	// 		case "radio":
-	// 			c.checkables[sgf.Slug] = c.Checkable()
-	// 			for i := range sgf.Options {
-	// 				c.checkables[sgf.Slug+sgf.Options[i]] = c.Checkable()
-	// 			}
-	// 			txt := *tabNames[sgf.Group][sgf.Slug].Slot.(*string)
-	// 			c.enums[sgf.Slug] = c.Enum().SetValue(txt).SetOnChange(
-	// 				func(value string) {
-	// 					rr := c.cx.ConfigMap[sgf.Slug].(*string)
-	// 					*rr = value
-	// 					podcfg.Save(c.cx.Config)
-	// 				},
-	// 			)
-	// 			c.lists[sgf.Slug] = c.List()
-	// 		}
-	// 	}
-	// }
-
-	// D.S(tabNames)
-	return tabNames // .Widget(c)
-	// return func(gtx l.Context) l.Dimensions {
-	// 	return l.Dimensions{}
-	// }
+	for _, sch := range c.cfg.Schema() {
+		if _, ok := tabNames[sch.Group]; !ok {
+			tabNames[sch.Group] = make(ItemMap)
+		}
+		it := &Item{
+			slug:        sch.Name,
+			typ:         sch.Type,
+			label:       sch.Label,
+			description: sch.Description,
+			widget:      schemaWidget(sch),
+			dataType:    sch.Type,
+			options:     sch.Options,
+			def:         sch.Default,
+			live:        sch.Live,
+			Slot:        c.cfg.Map[sch.Name],
+		}
+		tabNames[sch.Group][sch.Name] = it
+		// create all the necessary widgets required before display
+		switch it.widget {
+		case "toggle":
+			c.Bools[it.slug] = c.Bool(sch.Value == "true").SetOnChange(
+				func(b bool) {
+					D.Ln(it.slug, "submitted", b)
+					c.set(it, strconv.FormatBool(b))
+					if it.slug == "DarkTheme" {
+						c.Theme.SetDarkTheme(b)
+					}
+				},
+			)
+		case "integer", "time", "float", "string":
+			c.inputs[it.slug] = c.Input(
+				sch.Value, it.slug, "DocText", "DocBg", "PanelBg", func(txt string) {
+					D.Ln(it.slug, "submitted", txt)
+					c.set(it, txt)
+				}, func(txt string) {
+					// show mistakes while the value is typed, before it is submitted
+					c.validate(it, txt)
+				},
+			)
+		case "password":
+			c.passwords[it.slug] = c.Password(
+				"password", it.Slot.(*ot.Opt), "DocText", "DocBg", "PanelBg",
+				func(txt string) {
+					D.Ln(it.slug, "submitted")
+					c.set(it, txt)
+				},
+			)
+		case "multi":
+			var lines []string
+			if sch.Value != "" {
+				lines = strings.Split(sch.Value, ",")
+			}
+			c.multis[it.slug] = c.Multiline(
+				&lines, "DocText", "DocBg", "PanelBg", 30, func(txt []string) {
+					D.Ln(it.slug, "submitted", txt)
+					c.set(it, strings.Join(txt, ","))
+				},
+			)
+		case "radio":
+			c.checkables[it.slug] = c.Checkable()
+			for i := range it.options {
+				c.checkables[it.slug+it.options[i]] = c.Checkable()
+			}
+			c.enums[it.slug] = c.Enum().SetValue(sch.Value).SetOnChange(
+				func(value string) {
+					c.set(it, value)
+				},
+			)
+			c.lists[it.slug] = c.List()
+		}
+	}
+	return tabNames
+}
+
+// schemaWidget returns the widget used to edit an option of the schema.
+func schemaWidget(sch config.SchemaItem) string {
+	switch {
+	case sch.Secret:
+		return "password"
+	case sch.Type == config.SchemaBool:
+		return "toggle"
+	case sch.Type == config.SchemaInt:
+		return "integer"
+	case sch.Type == config.SchemaFloat:
+		return "float"
+	case sch.Type == config.SchemaDuration:
+		return "time"
+	case sch.Type == config.SchemaList:
+		return "multi"
+	case len(sch.Options) > 0:
+		return "radio"
+	}
+	return "string"
+}
+
+// validate checks a value for an option without setting it, and shows the problem with it if there is one.
+func (c *Config) validate(item *Item, value string) bool {
+	if e := c.cfg.ValidateOption(item.slug, value); e != nil {
+		c.errors[item.slug] = e.Error()
+		return false
+	}
+	delete(c.errors, item.slug)
+	return true
+}
+
+// set sets an option and saves the configuration file. Options that take effect without a restart are also set on the
+// running node first, as the node saves the configuration file too and the file saved here must be the last one
+// written to keep the options that only take effect after a restart.
+func (c *Config) set(item *Item, value string) {
+	if !c.validate(item, value) {
+		return
+	}
+	var e error
+	if item.live && c.apply != nil {
+		if e = c.apply(item.slug, value); E.Chk(e) {
+			c.errors[item.slug] = "not applied to the running node: " + e.Error()
+		}
+	}
+	if e = c.cfg.SetOption(item.slug, value); E.Chk(e) {
+		c.errors[item.slug] = e.Error()
+		return
+	}
+	if e = c.cfg.WriteToFile(c.cfg.ConfigFile.V()); E.Chk(e) {
+		c.errors[item.slug] = "failed to save the configuration: " + e.Error()
+	}
+}
+
+// RenderCaption renders the help text of an option with its default, whether it takes effect only after a restart,
+// and the problem with the value last given to it, if any.
+func (c *Config) RenderCaption(item *Item) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		note := "default: " + item.def
+		if item.def == "" {
+			note = "no default"
+		}
+		if !item.live {
+			note += ", takes effect after a restart"
+		}
+		w := c.VFlex().
+			Rigid(
+				c.Caption(item.description).Fn,
+			).
+			Rigid(
+				c.Caption(note).Color("PanelText").Fn,
+			)
+		if err, ok := c.errors[item.slug]; ok {
+			w = w.Rigid(
+				c.Caption(err).Color("Danger").Fn,
+			)
+		}
+		return w.Fn(gtx)
+	}
 }
 
 func (gm GroupsMap) Widget(ng *Config) l.Widget {
@@ -379,7 +414,7 @@ func (c *Config) RenderToggle(item *Item) []l.Widget {
 								c.Body1(item.label).Fn,
 							).
 							Rigid(
-								c.Caption(item.description).Fn,
+								c.RenderCaption(item),
 							).
 							Fn,
 					).Fn,
@@ -403,7 +438,7 @@ func (c *Config) RenderInteger(item *Item) []l.Widget {
 							c.inputs[item.slug].Fn,
 						).
 						Rigid(
-							c.Caption(item.description).Fn,
+							c.RenderCaption(item),
 						).
 						Fn,
 				).Fn,
@@ -427,7 +462,7 @@ func (c *Config) RenderTime(item *Item) []l.Widget {
 							c.inputs[item.slug].Fn,
 						).
 						Rigid(
-							c.Caption(item.description).Fn,
+							c.RenderCaption(item),
 						).
 						Fn,
 				).Fn,
@@ -452,7 +487,7 @@ func (c *Config) RenderFloat(item *Item) []l.Widget {
 							c.inputs[item.slug].Fn,
 						).
 						Rigid(
-							c.Caption(item.description).Fn,
+							c.RenderCaption(item),
 						).
 						Fn,
 				).Fn,
@@ -476,7 +511,7 @@ func (c *Config) RenderString(item *Item) []l.Widget {
 						c.inputs[item.slug].Fn,
 					).
 					Rigid(
-						c.Caption(item.description).Fn,
+						c.RenderCaption(item),
 					).
 					Fn,
 			).Fn,
@@ -499,7 +534,7 @@ func (c *Config) RenderPassword(item *Item) []l.Widget {
 						c.passwords[item.slug].Fn,
 					).
 					Rigid(
-						c.Caption(item.description).Fn,
+						c.RenderCaption(item),
 					).
 					Fn,
 			).Fn,
@@ -522,7 +557,7 @@ func (c *Config) RenderMulti(item *Item, position int) []l.Widget {
 							c.Body1(item.label).Fn,
 						).
 						Rigid(
-							c.Caption(item.description).Fn,
+							c.RenderCaption(item),
 						).Fn,
 				).Fn,
 			).
@@ -577,7 +612,7 @@ func (c *Config) RenderRadio(item *Item) []l.Widget {
 						).
 						Flexed(
 							1,
-							c.Caption(item.description).Fn,
+							c.RenderCaption(item),
 						).
 						Fn,
 				).Fn,
@@ -4,21 +4,27 @@ import (
 	"github.com/p9c/qu"
 
 	"github.com/p9c/gel"
+
+	"github.com/p9c/pod/pod/config"
 )
 
-func New(w *gel.Window, killAll qu.C) *Config {
-	cfg := &Config{
+// New creates the settings page for the configuration. The apply function sets the options that take effect without a
+// restart on the running node, and may be nil when there is none.
+func New(w *gel.Window, killAll qu.C, cfg *config.Config, apply func(name, value string) error) *Config {
+	c := &Config{
 		Window: w,
-		// cx:     cx,
-		quit: killAll,
+		cfg:    cfg,
+		apply:  apply,
+		quit:   killAll,
 	}
-	// cfg.Theme = cx.App
-	return cfg.Init()
+	return c.Init()
 }
 
 type Config struct {
-	// cx *state.State
 	*gel.Window
+	cfg        *config.Config
+	apply      func(name, value string) error
+	errors     map[string]string
 	Bools      map[string]*gel.Bool
 	lists      map[string]*gel.List
 	enums      map[string]*gel.Enum
@@ -58,5 +64,6 @@ func (c *Config) Init() *Config {
 	c.inputs = make(map[string]*gel.Input)
 	c.multis = make(map[string]*gel.Multi)
 	c.passwords = make(map[string]*gel.Password)
+	c.errors = make(map[string]string)
 	return c
 }
@@ -3,10 +3,9 @@ package gui
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/atotto/clipboard"
 	"golang.org/x/exp/shiny/materialdesign/icons"
@@ -19,193 +18,119 @@ import (
 	"github.com/p9c/gel"
 )
 
+const (
+	// consoleHistorySize is the number of commands the console remembers.
+	consoleHistorySize = 256
+	// maxConsoleSuggestions is the most completions shown for the method being typed.
+	maxConsoleSuggestions = 8
+)
+
 type Console struct {
 	*gel.Window
-	output         []l.Widget
-	outputList     *gel.List
-	editor         *gel.Editor
-	clearClickable *gel.Clickable
-	clearButton    *gel.IconButton
-	copyClickable  *gel.Clickable
-	copyButton     *gel.IconButton
-	pasteClickable *gel.Clickable
-	pasteButton    *gel.IconButton
-	submitFunc     func(txt string)
-	clickables     []*gel.Clickable
+	mx                   sync.Mutex
+	output               []l.Widget
+	outputList           *gel.List
+	editor               *gel.Editor
+	clearClickable       *gel.Clickable
+	clearButton          *gel.IconButton
+	copyClickable        *gel.Clickable
+	copyButton           *gel.IconButton
+	pasteClickable       *gel.Clickable
+	pasteButton          *gel.IconButton
+	completeClickable    *gel.Clickable
+	completeButton       *gel.IconButton
+	historyUpClickable   *gel.Clickable
+	historyUpButton      *gel.IconButton
+	historyDownClickable *gel.Clickable
+	historyDownButton    *gel.IconButton
+	endpointClickable    *gel.Clickable
+	endpoint             ctl2.Endpoint
+	history              *ctl2.History
+	suggestions          []string
+	suggestionClickables []*gel.Clickable
+	hint                 string
+	submitFunc           func(txt string)
+	clickables           []*gel.Clickable
 }
 
-var findSpaceRegexp = regexp.MustCompile(`\s+`)
+// consoleCall is a command entered into the console. Its pane shows that it is waiting for a reply until the result
+// arrives, so several commands can be run at once.
+type consoleCall struct {
+	sync.Mutex
+	line     string
+	endpoint string
+	done     bool
+	out      []l.Widget
+}
 
 func (wg *WalletGUI) ConsolePage() *Console {
 	D.Ln("running ConsolePage")
 	c := &Console{
-		Window:         wg.Window,
-		editor:         wg.Editor().SingleLine().Submit(true),
-		clearClickable: wg.Clickable(),
-		copyClickable:  wg.Clickable(),
-		pasteClickable: wg.Clickable(),
-		outputList:     wg.List().ScrollToEnd(),
+		Window:               wg.Window,
+		editor:               wg.Editor().SingleLine().Submit(true),
+		clearClickable:       wg.Clickable(),
+		copyClickable:        wg.Clickable(),
+		pasteClickable:       wg.Clickable(),
+		completeClickable:    wg.Clickable(),
+		historyUpClickable:   wg.Clickable(),
+		historyDownClickable: wg.Clickable(),
+		endpointClickable:    wg.Clickable(),
+		history:              ctl2.NewHistory(consoleHistorySize),
+		outputList:           wg.List().ScrollToEnd(),
+	}
+	for i := 0; i < maxConsoleSuggestions; i++ {
+		c.suggestionClickables = append(c.suggestionClickables, wg.Clickable())
 	}
 	c.submitFunc = func(txt string) {
-		go func() {
-			D.Ln("submit", txt)
-			c.output = append(
-				c.output,
-				func(gtx l.Context) l.Dimensions {
-					return wg.VFlex().
-						Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
-						Rigid(
-							wg.Flex().
-								Flexed(
-									1,
-									wg.Body2(txt).Color("DocText").Font("bariol bold").Fn,
-								).
-								Fn,
-						).Fn(gtx)
-				},
-			)
-			c.editor.SetText("")
-			split := strings.Split(txt, " ")
-			method, args := split[0], split[1:]
-			var params []interface{}
-			var e error
-			var result []byte
-			var o string
-			var errString, prev string
-			for i := range args {
-				params = append(params, args[i])
+		txt = strings.TrimSpace(txt)
+		if txt == "" {
+			return
+		}
+		c.history.Add(txt)
+		c.setText("")
+		args, e := ctl2.SplitCommandLine(txt)
+		if e != nil {
+			c.addOutput(c.Theme.Flex().AlignStart().Rigid(wg.Body2(e.Error()).Color("Danger").Fn).Fn)
+			return
+		}
+		method, params := args[0], args[1:]
+		switch method {
+		case "clear", "cls":
+			c.mx.Lock()
+			// clear the list of display widgets
+			c.output = c.output[:0]
+			// free up the pool widgets used in the current output
+			for i := range c.clickables {
+				wg.WidgetPool.FreeClickable(c.clickables[i])
 			}
-			if method == "clear" || method == "cls" {
-				// clear the list of display widgets
-				c.output = c.output[:0]
-				// free up the pool widgets used in the current output
-				for i := range c.clickables {
-					wg.WidgetPool.FreeClickable(c.clickables[i])
-				}
-				c.clickables = c.clickables[:0]
+			c.clickables = c.clickables[:0]
+			c.mx.Unlock()
+			return
+		case "help":
+			if len(params) == 0 {
+				c.addOutput(c.helpWidgets()...)
 				return
 			}
-			if method == "help" {
-				if len(args) == 0 {
-					D.Ln("rpc called help")
-					var result1, result2 []byte
-					if result1, e = ctl2.Call(wg.cx.Config, false, method, params...); E.Chk(e) {
-					}
-					r1 := string(result1)
-					if r1, e = strconv.Unquote(r1); E.Chk(e) {
-					}
-					o = r1 + "\n"
-					if result2, e = ctl2.Call(wg.cx.Config, true, method, params...); E.Chk(e) {
-					}
-					r2 := string(result2)
-					if r2, e = strconv.Unquote(r2); E.Chk(e) {
-					}
-					o += r2 + "\n"
-					splitted := strings.Split(o, "\n")
-					sort.Strings(splitted)
-					var dedup []string
-					for i := range splitted {
-						if i > 0 {
-							if splitted[i] != prev {
-								dedup = append(dedup, splitted[i])
-							}
-						}
-						prev = splitted[i]
-					}
-					o = strings.Join(dedup, "\n")
-					if errString != "" {
-						o += "BTCJSONError:\n"
-						o += errString
-					}
-					splitResult := strings.Split(o, "\n")
-					const maxPerWidget = 6
-					for i := 0; i < len(splitResult)-maxPerWidget; i += maxPerWidget {
-						sri := strings.Join(splitResult[i:i+maxPerWidget], "\n")
-						c.output = append(
-							c.output,
-							func(gtx l.Context) l.Dimensions {
-								return wg.Flex().
-									Flexed(
-										1,
-										wg.Caption(sri).
-											Color("DocText").
-											Font("bariol regular").
-											MaxLines(maxPerWidget).Fn,
-									).
-									Fn(gtx)
-							},
-						)
-					}
-					return
-				} else {
-					var out string
-					var isErr bool
-					if result, e = ctl2.Call(wg.cx.Config, false, method, params...); E.Chk(e) {
-						isErr = true
-						out = e.Error()
-						I.Ln(out)
-						// if out, e = strconv.Unquote(); E.Chk(e) {
-						// }
-					} else {
-						if out, e = strconv.Unquote(string(result)); E.Chk(e) {
-						}
-					}
-					strings.ReplaceAll(out, "\t", "  ")
-					D.Ln(out)
-					splitResult := strings.Split(out, "\n")
-					outputColor := "DocText"
-					if isErr {
-						outputColor = "Danger"
-					}
-					for i := range splitResult {
-						sri := splitResult[i]
-						c.output = append(
-							c.output,
-							func(gtx l.Context) l.Dimensions {
-								return c.Theme.Flex().AlignStart().
-									Rigid(
-										wg.Body2(sri).
-											Color(outputColor).
-											Font("go regular").MaxLines(4).
-											Fn,
-									).
-									Fn(gtx)
-							},
-						)
-					}
-					return
-				}
-			} else {
-				D.Ln("method", method, "args", args)
-				if result, e = ctl2.Call(wg.cx.Config, false, method, params...); E.Chk(e) {
-					var errR string
-					if result, e = ctl2.Call(wg.cx.Config, true, method, params...); E.Chk(e) {
-						if e != nil {
-							errR = e.Error()
-						}
-						c.output = append(
-							c.output, c.Theme.Flex().AlignStart().
-								Rigid(wg.Body2(errR).Color("Danger").Fn).Fn,
-						)
-						return
-					}
-					if e != nil {
-						errR = e.Error()
-					}
-					c.output = append(
-						c.output, c.Theme.Flex().AlignStart().
-							Rigid(
-								wg.Body2(errR).Color("Danger").Fn,
-							).Fn,
-					)
-				}
-				c.output = append(c.output, wg.console.JSONWidget("DocText", result)...)
-			}
+		}
+		call := &consoleCall{line: txt, endpoint: c.endpoint.String()}
+		c.addOutput(c.callWidget(call))
+		go func() {
+			D.Ln("method", method, "args", params)
+			out := c.runCommand(wg, method, params)
+			call.Lock()
+			call.out, call.done = out, true
+			call.Unlock()
 			c.outputList.JumpToEnd()
+			c.Invalidate()
 		}()
 	}
+	c.editor.SetChange(
+		func(txt string) {
+			c.updateSuggestions(txt)
+		},
+	)
 	clearClickableFn := func() {
-		c.editor.SetText("")
+		c.setText("")
 		c.editor.Focus()
 	}
 	copyClickableFn := func() {
@@ -216,61 +141,238 @@ func (wg *WalletGUI) ConsolePage() *Console {
 		c.editor.Focus()
 	}
 	pasteClickableFn := func() {
-		// // col := c.editor.Caret.Col
-		// go func() {
-		// 	txt := c.editor.Text()
-		// 	var e error
-		// 	var cb string
-		// 	if cb, e = clipboard.ReadAll(); E.Chk(e) {
-		// 	}
-		// 	cb = findSpaceRegexp.ReplaceAllString(cb, " ")
-		// 	txt = txt[:col] + cb + txt[col:]
-		// 	c.editor.SetText(txt)
-		// 	c.editor.Move(col + len(cb))
-		// }()
+		go func() {
+			var e error
+			var cb string
+			if cb, e = clipboard.ReadAll(); E.Chk(e) {
+				return
+			}
+			c.setText(c.editor.Text() + strings.Join(strings.Fields(cb), " "))
+		}()
+		c.editor.Focus()
 	}
-	c.clearButton = wg.IconButton(c.clearClickable.SetClick(clearClickableFn)).
-		Icon(
-			wg.Icon().
-				Color("DocText").
-				Src(&icons2.ContentBackspace),
-		).
-		Background("").
-		ButtonInset(0.25)
-	c.copyButton = wg.IconButton(c.copyClickable.SetClick(copyClickableFn)).
-		Icon(
-			wg.Icon().
-				Color("DocText").
-				Src(&icons2.ContentContentCopy),
-		).
-		Background("").
-		ButtonInset(0.25)
-	c.pasteButton = wg.IconButton(c.pasteClickable.SetClick(pasteClickableFn)).
-		Icon(
-			wg.Icon().
-				Color("DocText").
-				Src(&icons2.ContentContentPaste),
-		).
-		Background("").
-		ButtonInset(0.25)
+	completeClickableFn := func() {
+		c.complete()
+		c.editor.Focus()
+	}
+	historyUpClickableFn := func() {
+		if line, ok := c.history.Prev(); ok {
+			c.setText(line)
+		}
+		c.editor.Focus()
+	}
+	historyDownClickableFn := func() {
+		if line, ok := c.history.Next(); ok {
+			c.setText(line)
+		}
+		c.editor.Focus()
+	}
+	c.endpointClickable.SetClick(
+		func() {
+			c.endpoint = c.endpoint.Next()
+			c.editor.Focus()
+		},
+	)
+	for i := range c.suggestionClickables {
+		i := i
+		c.suggestionClickables[i].SetClick(
+			func() {
+				if i < len(c.suggestions) {
+					c.setText(c.suggestions[i] + " ")
+				}
+				c.editor.Focus()
+			},
+		)
+	}
+	c.clearButton = c.inputButton(c.clearClickable.SetClick(clearClickableFn), &icons2.ContentBackspace)
+	c.copyButton = c.inputButton(c.copyClickable.SetClick(copyClickableFn), &icons2.ContentContentCopy)
+	c.pasteButton = c.inputButton(c.pasteClickable.SetClick(pasteClickableFn), &icons2.ContentContentPaste)
+	c.completeButton = c.inputButton(c.completeClickable.SetClick(completeClickableFn), &icons2.NavigationChevronRight)
+	c.historyUpButton = c.inputButton(c.historyUpClickable.SetClick(historyUpClickableFn), &icons2.NavigationArrowUpward)
+	c.historyDownButton = c.inputButton(
+		c.historyDownClickable.SetClick(historyDownClickableFn), &icons2.NavigationArrowDownward,
+	)
 	c.output = append(
 		c.output, func(gtx l.Context) l.Dimensions {
 			return c.Theme.Flex().AlignStart().Rigid(c.H6("Welcome to the Parallelcoin RPC console").Color("DocText").Fn).Fn(gtx)
 		}, func(gtx l.Context) l.Dimensions {
-			return c.Theme.Flex().AlignStart().Rigid(c.Caption("Type 'help' to get available commands and 'clear' or 'cls' to clear the screen").Color("DocText").Fn).Fn(gtx)
+			return c.Theme.Flex().AlignStart().Rigid(
+				c.Caption(
+					"Type 'help' to get available commands and 'clear' or 'cls' to clear the screen. Press > to " +
+						"complete a method, and choose the server commands are sent to with the endpoint button",
+				).Color("DocText").Fn,
+			).Fn(gtx)
 		},
 	)
 	return c
 }
 
+// inputButton returns an icon button for the row of buttons beside the command input.
+func (c *Console) inputButton(clickable *gel.Clickable, icon *[]byte) *gel.IconButton {
+	return c.IconButton(clickable).
+		Icon(
+			c.Icon().
+				Color("DocText").
+				Src(icon),
+		).
+		Background("").
+		ButtonInset(0.25)
+}
+
+// setText replaces the text of the command input, puts the caret at the end and updates the suggestions.
+func (c *Console) setText(txt string) {
+	c.editor.SetText(txt)
+	c.editor.SetCaret(len(txt), len(txt))
+	c.updateSuggestions(txt)
+}
+
+// updateSuggestions finds the methods that complete the method being typed, and the usage of the method once it has
+// been typed in full.
+func (c *Console) updateSuggestions(txt string) {
+	c.suggestions, c.hint = nil, ""
+	fields := strings.Fields(txt)
+	if len(fields) == 0 {
+		return
+	}
+	method := fields[0]
+	if len(fields) > 1 || strings.HasSuffix(txt, " ") {
+		c.hint = ctl2.MethodHint(method)
+		return
+	}
+	c.suggestions = ctl2.CompleteMethod(method)
+	if len(c.suggestions) == 1 && c.suggestions[0] == method {
+		c.suggestions = nil
+		c.hint = ctl2.MethodHint(method)
+	}
+	if len(c.suggestions) > maxConsoleSuggestions {
+		c.suggestions = c.suggestions[:maxConsoleSuggestions]
+	}
+}
+
+// complete extends the method being typed as far as the methods it could be agree.
+func (c *Console) complete() {
+	txt := c.editor.Text()
+	fields := strings.Fields(txt)
+	if len(fields) != 1 || strings.HasSuffix(txt, " ") {
+		return
+	}
+	methods := ctl2.CompleteMethod(fields[0])
+	switch len(methods) {
+	case 0:
+	case 1:
+		c.setText(methods[0] + " ")
+	default:
+		c.setText(ctl2.CommonPrefix(methods))
+	}
+}
+
+// addOutput adds widgets to the end of the console output.
+func (c *Console) addOutput(widgets ...l.Widget) {
+	c.mx.Lock()
+	c.output = append(c.output, widgets...)
+	c.mx.Unlock()
+	c.outputList.JumpToEnd()
+}
+
+// runCommand runs a console command, locally if it is an offline command and otherwise on the server chosen by the
+// endpoint, and returns the widgets showing its result.
+func (c *Console) runCommand(wg *WalletGUI, method string, args []string) (out []l.Widget) {
+	var result []byte
+	var offline bool
+	var e error
+	if result, offline, e = ctl2.CallOffline(wg.cx.ActiveNet, method, args...); !offline {
+		params := make([]interface{}, len(args))
+		for i := range args {
+			params[i] = args[i]
+		}
+		result, e = ctl2.Call(wg.cx.Config, c.endpoint.UseWallet(method), method, params...)
+	}
+	if E.Chk(e) {
+		for _, line := range strings.Split(e.Error(), "\n") {
+			out = append(out, c.Theme.Flex().AlignStart().Rigid(c.Body2(line).Color("Danger").Fn).Fn)
+		}
+		return
+	}
+	// Help and other text results are shown line by line.
+	var str string
+	if e = json.Unmarshal(result, &str); e == nil {
+		for _, line := range strings.Split(str, "\n") {
+			line := strings.ReplaceAll(line, "\t", "  ")
+			out = append(
+				out, c.Theme.Flex().AlignStart().Rigid(c.Body2(line).Color("DocText").Font("go regular").Fn).Fn,
+			)
+		}
+		return
+	}
+	return c.JSONWidget("DocText", result)
+}
+
+// callWidget returns the pane of a command, which shows the command, and the result once it has arrived.
+func (c *Console) callWidget(call *consoleCall) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		call.Lock()
+		done, out := call.done, call.out
+		call.Unlock()
+		v := c.Theme.VFlex().
+			Rigid(c.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
+			Rigid(
+				c.Theme.Flex().
+					Flexed(1, c.Body2(call.line).Color("DocText").Font("bariol bold").Fn).
+					Rigid(c.Caption(call.endpoint).Color("DocText").Fn).
+					Fn,
+			)
+		if !done {
+			v.Rigid(c.Caption("waiting for reply...").Color("DocText").Fn)
+		}
+		for i := range out {
+			v.Rigid(out[i])
+		}
+		return v.Fn(gtx)
+	}
+}
+
+// helpWidgets returns the list of console methods with their usage.
+func (c *Console) helpWidgets() (out []l.Widget) {
+	for _, method := range ctl2.ConsoleMethods() {
+		usage := ctl2.MethodHint(method)
+		out = append(
+			out, func(gtx l.Context) l.Dimensions {
+				return c.Theme.Flex().AlignStart().Rigid(c.Caption(usage).Color("DocText").Font("go regular").Fn).Fn(gtx)
+			},
+		)
+	}
+	return
+}
+
 func (c *Console) Fn(gtx l.Context) l.Dimensions {
+	c.mx.Lock()
+	output := c.output
+	c.mx.Unlock()
 	le := func(gtx l.Context, index int) l.Dimensions {
-		if index >= len(c.output) || index < 0 {
+		if index >= len(output) || index < 0 {
 			return l.Dimensions{}
 		} else {
-			return c.output[index](gtx)
+			return output[index](gtx)
 		}
 	}
+	suggestions := c.Theme.Flex()
+	for i := range c.suggestions {
+		suggestions.Rigid(
+			c.Inset(
+				0.125,
+				c.Button(c.suggestionClickables[i]).
+					Background("PanelBg").
+					Color("DocText").
+					TextScale(0.75).
+					Inset(0.25).
+					Text(c.suggestions[i]).
+					Fn,
+			).Fn,
+		)
+	}
+	if c.hint != "" {
+		suggestions.Rigid(c.Inset(0.25, c.Caption(c.hint).Color("DocText").Font("go regular").Fn).Fn)
+	}
 	fn := c.Theme.VFlex().
 		Flexed(
 			0.1,
@@ -285,7 +387,7 @@ func (c *Console) Fn(gtx l.Context) l.Dimensions {
 							Color("DocBg").
 							Active("Primary").
 							Vertical().
-							Length(len(c.output)).
+							Length(len(output)).
 							ListElement(le).
 							Fn,
 					).
@@ -293,17 +395,30 @@ func (c *Console) Fn(gtx l.Context) l.Dimensions {
 				},
 			).Fn,
 		).
+		Rigid(c.Fill("DocBg", l.W, c.TextSize.V, 0, suggestions.Fn).Fn).
 		Rigid(
 			c.Fill(
 				"DocBg", l.Center, c.TextSize.V, 0, c.Inset(
 					0.25,
 					c.Theme.Flex().
+						Rigid(
+							c.Button(c.endpointClickable).
+								Background("PanelBg").
+								Color("DocText").
+								TextScale(0.75).
+								Inset(0.25).
+								Text(c.endpoint.String()).
+								Fn,
+						).
 						Flexed(
 							1,
 							c.TextInput(c.editor.SetSubmit(c.submitFunc), "enter an rpc command").
 								Color("DocText").
 								Fn,
 						).
+						Rigid(c.completeButton.Fn).
+						Rigid(c.historyUpButton.Fn).
+						Rigid(c.historyDownButton.Fn).
 						Rigid(c.copyButton.Fn).
 						Rigid(c.pasteButton.Fn).
 						Rigid(c.clearButton.Fn).
@@ -95,6 +95,7 @@ func (wg *WalletGUI) cwfRestoreButton() l.Widget {
 				D.Ln("clicked restore button")
 				if !wg.restoring {
 					wg.inputs["walletRestore"].SetText("")
+					wg.inputs["walletBackupTarget"].SetText("")
 					wg.createMatch = ""
 					wg.restoring = true
 					wg.createVerifying = false
@@ -141,11 +142,15 @@ func (wg *WalletGUI) cwfSetGenesis() l.Widget {
 					func() {
 						seedString := "f4d2c4c542bb52512ed9e6bbfa2d000e576a0c8b4ebd1acafd7efa37247366bc"
 						var e error
-						if wg.createSeed, e = hex.DecodeString(seedString); F.Chk(e) {
+						var seed []byte
+						if seed, e = hex.DecodeString(seedString); F.Chk(e) {
 							panic(e)
 						}
 						var wk string
-						if wk, e = bip39.NewMnemonic(wg.createSeed); E.Chk(e) {
+						if wk, e = bip39.NewMnemonic(seed); E.Chk(e) {
+							panic(e)
+						}
+						if e = wg.setup.RestoreSeed(wk); E.Chk(e) {
 							panic(e)
 						}
 						wks := strings.Split(wk, " ")
@@ -337,6 +342,9 @@ func (wg *WalletGUI) cfwRestore() (w l.Widget) {
 			Rigid(
 				wg.inputs["walletRestore"].Fn,
 			).
+			Rigid(
+				wg.inputs["walletBackupTarget"].Fn,
+			).
 			Fn
 	}
 	return
@@ -450,7 +458,8 @@ func (wg *WalletGUI) cwfConfirmation() (out l.Widget) {
 				D.Ln("confirmed read", b)
 				// if the password has been entered, we need to copy it to the variable
 				if wg.createWalletPasswordsMatch() {
-					wg.cx.Config.WalletPass.Set(wg.passwords["confirmPassEditor"].GetPassword())
+					if e := wg.setup.SetPassphrases(wg.passwords["confirmPassEditor"].GetPassword(), ""); E.Chk(e) {
+					}
 				}
 			},
 		),
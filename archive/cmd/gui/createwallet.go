@@ -1,21 +1,14 @@
 package gui
 
 import (
-	"fmt"
 	"os"
-	"path/filepath"
-	"time"
+	"strings"
 
-	"github.com/p9c/qu"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 
 	"github.com/p9c/interrupt"
 
 	"github.com/p9c/gel"
-	"github.com/p9c/pod/cmd/wallet"
-	"github.com/p9c/pod/pkg/chaincfg"
-	"github.com/p9c/pod/pkg/constant"
-	"github.com/p9c/pod/pkg/fork"
 
 	l "github.com/p9c/gio/layout"
 )
@@ -198,61 +191,26 @@ func (wg *WalletGUI) createWalletInputsAreValid() bool {
 func (wg *WalletGUI) createWalletAction() {
 	// wg.NodeRunCommandChan <- "stop"
 	D.Ln("clicked submit wallet")
-	wg.cx.Config.WalletFile.Set(filepath.Join(wg.cx.Config.DataDir.V(), wg.cx.ActiveNet.Name, constant.DbName))
-	dbDir := wg.cx.Config.WalletFile.V()
-	loader := wallet.NewLoader(wg.cx.ActiveNet, dbDir, 250)
-	// seed, _ := hex.DecodeString(wg.inputs["walletSeed"].GetText())
-	seed := wg.createSeed
 	pass := wg.passwords["passEditor"].GetPassword()
-	wg.cx.Config.WalletPass.Set(pass)
-	D.Ln("password", pass)
-	_ = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V())
-	w, e := loader.CreateNewWallet(
-		[]byte(pass),
-		[]byte(pass),
-		seed,
-		time.Now(),
-		false,
-		wg.cx.Config,
-		qu.T(),
-	)
-	D.Ln("*** created wallet")
-	if E.Chk(e) {
-		// return
+	var e error
+	if e = wg.setup.SetPassphrases(pass, ""); E.Chk(e) {
+		return
 	}
-	w.Stop()
-	D.Ln("shutting down wallet", w.ShuttingDown())
-	w.WaitForShutdown()
-	D.Ln("starting main app")
-	wg.cx.Config.Generate.T()
-	wg.cx.Config.GenThreads.Set(1)
-	wg.cx.Config.NodeOff.F()
-	wg.cx.Config.WalletOff.F()
-	_ = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V())
-	// // we are going to assume the config is not manually misedited
-	// if apputil.FileExists(*wg.cx.Config.ConfigFile) {
-	// 	b, e := ioutil.ReadFile(*wg.cx.Config.ConfigFile)
-	// 	if e == nil {
-	// 		wg.cx.Config, wg.cx.ConfigMap = pod.EmptyConfig()
-	// 		e = json.Unmarshal(b, wg.cx.Config)
-	// 		if e != nil {
-	// 			E.Ln("error unmarshalling config", e)
-	// 			// os.Exit(1)
-	// 			panic(e)
-	// 		}
-	// 	} else {
-	// 		F.Ln("unexpected error reading configuration file:", e)
-	// 		// os.Exit(1)
-	// 		// return e
-	// 		panic(e)
-	// 	}
-	// }
+	if e = wg.setup.SetMining(true, 1); E.Chk(e) {
+		return
+	}
+	// a wallet being restored from its seed is restored from its latest backup if a backup target is given, so the
+	// labels, transaction history and imported keys it had are not lost
+	if target := strings.TrimSpace(wg.inputs["walletBackupTarget"].GetText()); wg.restoring && target != "" {
+		if e = wg.setup.RestoreBackup(target); E.Chk(e) {
+			return
+		}
+	} else if e = wg.setup.CreateWallet(); E.Chk(e) {
+		return
+	}
+	D.Ln("*** created wallet")
+	wg.cx.ActiveNet = wg.setup.ActiveNet
 	*wg.noWallet = false
-	// interrupt.Request()
-	// wg.wallet.Stop()
-	// wg.wallet.Start()
-	// wg.node.Start()
-	// wg.miner.Start()
 	wg.unlockPassword.Editor().SetText(pass)
 	wg.unlockWallet(pass)
 	interrupt.RequestRestart()
@@ -261,38 +219,20 @@ func (wg *WalletGUI) createWalletAction() {
 func (wg *WalletGUI) createWalletTestnetToggle(b bool) {
 	D.Ln("testnet on?", b)
 	// if the password has been entered, we need to copy it to the variable
-	if wg.passwords["passEditor"].GetPassword() != "" ||
-		wg.passwords["confirmPassEditor"].GetPassword() != "" ||
-		len(wg.passwords["passEditor"].GetPassword()) >= 8 ||
-		wg.passwords["passEditor"].GetPassword() ==
-			wg.passwords["confirmPassEditor"].GetPassword() {
-		wg.cx.Config.WalletPass.Set(wg.passwords["confirmPassEditor"].GetPassword())
-		D.Ln("wallet pass", wg.cx.Config.WalletPass.V())
+	if wg.createWalletPasswordsMatch() {
+		if e := wg.setup.SetPassphrases(wg.passwords["confirmPassEditor"].GetPassword(), ""); E.Chk(e) {
+		}
 	}
+	network := "mainnet"
 	if b {
-		wg.cx.ActiveNet = &chaincfg.TestNet3Params
-		fork.IsTestnet = true
-	} else {
-		wg.cx.ActiveNet = &chaincfg.MainNetParams
-		fork.IsTestnet = false
+		network = "testnet"
 	}
-	I.Ln("activenet:", wg.cx.ActiveNet.Name)
 	D.Ln("setting ports to match network")
-	wg.cx.Config.Network.Set(wg.cx.ActiveNet.Name)
-	wg.cx.Config.P2PListeners.Set(
-		[]string{"0.0.0.0:" + wg.cx.ActiveNet.DefaultPort},
-	)
-	wg.cx.Config.P2PConnect.Set([]string{"127.0.0.1:" + wg.cx.ActiveNet.
-		DefaultPort})
-	address := fmt.Sprintf(
-		"127.0.0.1:%s",
-		wg.cx.ActiveNet.RPCClientPort,
-	)
-	wg.cx.Config.RPCListeners.Set([]string{address})
-	wg.cx.Config.RPCConnect.Set(address)
-	address = fmt.Sprintf("127.0.0.1:" + wg.cx.ActiveNet.WalletRPCServerPort)
-	wg.cx.Config.WalletRPCListeners.Set([]string{address})
-	wg.cx.Config.WalletServer.Set(address)
+	if e := wg.setup.SetNetwork(network); E.Chk(e) {
+		return
+	}
+	wg.cx.ActiveNet = wg.setup.ActiveNet
 	wg.cx.Config.NodeOff.F()
-	_ = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V())
+	if e := wg.setup.Save(); E.Chk(e) {
+	}
 }
@@ -244,6 +244,10 @@ func (wg *WalletGUI) processWalletBlockNotification() bool {
 	wg.txMx.Unlock()
 	wg.RecentTransactions(10, "recent")
 	wg.RecentTransactions(-1, "history")
+	wg.updateAddressBooks()
+	wg.updateFrozen()
+	wg.updatePortfolio()
+	wg.updateUTXOReport()
 	return true
 }
 
@@ -663,8 +667,13 @@ func (wg *WalletGUI) chainClient() (e error) {
 				Pass:                 wg.cx.Config.Password.V(),
 				TLS:                  wg.cx.Config.ClientTLS.True(),
 				Certificates:         wg.certs,
+				Proxy:                wg.cx.Config.RPCProxyAddress.V(),
+				ProxyType:            wg.cx.Config.RPCProxyType.V(),
+				ProxyUser:            wg.cx.Config.RPCProxyUser.V(),
+				ProxyPass:            wg.cx.Config.RPCProxyPass.V(),
 				DisableAutoReconnect: false,
 				DisableConnectOnNew:  false,
+				Params:               wg.cx.ActiveNet,
 			}, wg.ChainNotifications(), wg.cx.KillAll,
 		); E.Chk(e) {
 			return
@@ -702,8 +711,13 @@ func (wg *WalletGUI) walletClient() (e error) {
 			Pass:                 wg.cx.Config.Password.V(),
 			TLS:                  wg.cx.Config.ClientTLS.True(),
 			Certificates:         wg.certs,
+			Proxy:                wg.cx.Config.RPCProxyAddress.V(),
+			ProxyType:            wg.cx.Config.RPCProxyType.V(),
+			ProxyUser:            wg.cx.Config.RPCProxyUser.V(),
+			ProxyPass:            wg.cx.Config.RPCProxyPass.V(),
 			DisableAutoReconnect: false,
 			DisableConnectOnNew:  false,
+			Params:               wg.cx.ActiveNet,
 		}, wg.WalletNotifications(), wg.cx.KillAll,
 	); E.Chk(e) {
 		wg.WalletMutex.Unlock()
@@ -724,5 +738,10 @@ func (wg *WalletGUI) walletClient() (e error) {
 	// 	D.Ln("subscribed to wallet client notify blocks")
 	// }
 	D.Ln("wallet connected")
+	wg.migrateAddressBooks()
+	wg.updateAddressBooks()
+	wg.updateFrozen()
+	wg.updatePortfolio()
+	wg.updateUTXOReport()
 	return
 }
@@ -0,0 +1,125 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/shiny/materialdesign/icons"
+
+	l "github.com/p9c/gio/layout"
+
+	"github.com/p9c/gel"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// The outputs frozen in the wallet are never spent by the wallet, unlike locked outputs they stay frozen when the
+// wallet is restarted. The transaction detail view of the history page shows whether the output of a received
+// transaction is frozen and why, and freezes or unfreezes it. The frozen outputs in the State are only a copy of those
+// in the wallet, which is reloaded along with the transactions and after every change.
+
+// outpointKey returns the key of an output in the frozen outputs of the State.
+func outpointKey(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
+}
+
+// updateFrozen reloads the frozen outputs from the wallet.
+func (wg *WalletGUI) updateFrozen() {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	frozen, e := wg.WalletClient.ListFrozen()
+	if E.Chk(e) {
+		return
+	}
+	outputs := make(map[string]btcjson.FrozenOutputResult, len(frozen))
+	for _, f := range frozen {
+		outputs[outpointKey(f.TxID, f.Vout)] = f
+	}
+	wg.State.frozenOutputs = outputs
+	wg.Invalidate()
+}
+
+// setFrozen freezes the output for the reason entered on the transaction detail view, or unfreezes it, and reloads the
+// frozen outputs.
+func (wg *WalletGUI) setFrozen(txid string, vout uint32, freeze bool) {
+	hash, e := chainhash.NewHashFromStr(txid)
+	if E.Chk(e) {
+		return
+	}
+	ops := []*wire.OutPoint{wire.NewOutPoint(hash, vout)}
+	if e = wg.WalletClient.FreezeUnspent(!freeze, ops, wg.inputs["freezeReason"].GetText()); E.Chk(e) {
+		return
+	}
+	wg.inputs["freezeReason"].SetText("")
+	wg.updateFrozen()
+}
+
+// txFreezeEntries returns the entries of the transaction detail view that show whether the output the wallet received
+// in the transaction is frozen, and the controls to freeze or unfreeze it. Sent transactions have none.
+func (wg *WalletGUI) txFreezeEntries(txs *btcjson.ListTransactionsResult) []l.Widget {
+	if txs.Category == "send" {
+		return nil
+	}
+	frozen, isFrozen := wg.State.frozenOutputs[outpointKey(txs.TxID, txs.Vout)]
+	status := "no"
+	label := "freeze"
+	if isFrozen {
+		status = fmt.Sprintf("since %v", time.Unix(frozen.Frozen, 0))
+		if frozen.Reason != "" {
+			status = frozen.Reason + ", " + status
+		}
+		label = "unfreeze"
+	}
+	txid, vout := txs.TxID, txs.Vout
+	control := wg.Fill(
+		"DocBg", l.Center, wg.TextSize.V, 0,
+		wg.Flex().AlignMiddle().
+			Flexed(
+				1,
+				wg.Inset(
+					0.25,
+					func(gtx l.Context) l.Dimensions {
+						if isFrozen {
+							return l.Dimensions{}
+						}
+						return wg.inputs["freezeReason"].Fn(gtx)
+					},
+				).Fn,
+			).
+			Rigid(
+				wg.Inset(
+					0.25,
+					wg.ButtonLayout(
+						wg.clickables["txFreeze"].SetClick(
+							func() {
+								wg.setFrozen(txid, vout, !isFrozen)
+							},
+						),
+					).
+						Background("Primary").
+						CornerRadius(0.5).
+						Embed(
+							wg.Inset(
+								0.25,
+								wg.Flex().AlignMiddle().
+									Rigid(
+										wg.Icon().
+											Scale(gel.Scales["H6"]).
+											Color("Light").
+											Src(&icons.ActionLock).Fn,
+									).
+									Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
+									Rigid(wg.H6(label).Color("Light").Fn).
+									Fn,
+							).Fn,
+						).Fn,
+				).Fn,
+			).Fn,
+	).Fn
+	return []l.Widget{
+		wg.txDetailEntry("Frozen", status, "DocBgDim", false),
+		control,
+	}
+}
@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"fmt"
+
+	l "github.com/p9c/gio/layout"
+	"github.com/p9c/gio/text"
+)
+
+// The wallet health card on the overview page shows how the funds of the wallet are split up into unspent outputs,
+// from the UTXO report of the wallet at its fee rate. Outputs that cost more to spend than they are worth are lost to
+// fees if they are ever spent, and many small outputs make every payment more costly, so the card shows how many there
+// are and what spending all the outputs of each range of amounts would cost, so they can be consolidated while fees
+// are low. The report is reloaded with the balances on every block.
+
+// updateUTXOReport reloads the UTXO report of the wallet.
+func (wg *WalletGUI) updateUTXOReport() {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	report, e := wg.WalletClient.GetUTXOReport(0)
+	if E.Chk(e) {
+		return
+	}
+	wg.State.utxoReport = report
+	wg.Invalidate()
+}
+
+// healthCard returns the widget of the wallet health card.
+func (wg *WalletGUI) healthCard() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		gtx.Constraints.Min.X = int(wg.TextSize.V * 16)
+		gtx.Constraints.Max.X = int(wg.TextSize.V * 16)
+		r := wg.State.utxoReport
+		if r == nil {
+			return l.Dimensions{}
+		}
+		flex := wg.VFlex().AlignStart().
+			Rigid(wg.Inset(0.25, wg.H5("wallet health").Fn).Fn).
+			Rigid(wg.healthRow(fmt.Sprintf("%d outputs", r.Count), portfolioAmount(r.Amount), "DocText"))
+		color := "DocText"
+		if r.Uneconomic > 0 {
+			color = "Danger"
+		}
+		flex.Rigid(
+			wg.healthRow(
+				fmt.Sprintf("%d uneconomic", r.Uneconomic), portfolioAmount(r.UneconomicAmount), color,
+			),
+		)
+		if r.Frozen > 0 || r.Dust > 0 {
+			flex.Rigid(wg.healthRow(fmt.Sprintf("%d frozen, %d dust", r.Frozen, r.Dust), "", "PanelText"))
+		}
+		flex.Rigid(
+			wg.Inset(
+				0.25,
+				wg.Caption(fmt.Sprintf("spending one output costs %s", portfolioAmount(r.SpendCost))).
+					Color("PanelText").Fn,
+			).Fn,
+		)
+		for i := range r.ByValue {
+			b := &r.ByValue[i]
+			if b.Count == 0 {
+				continue
+			}
+			flex.Rigid(
+				wg.healthRow(
+					fmt.Sprintf("%s: %d", b.Name, b.Count), "costs "+portfolioAmount(b.SpendCost), "PanelText",
+				),
+			)
+		}
+		return flex.Fn(gtx)
+	}
+}
+
+// healthRow returns a row of the wallet health card with the label on the left and the value on the right.
+func (wg *WalletGUI) healthRow(label, value, color string) l.Widget {
+	return wg.Inset(
+		0.25,
+		wg.Flex().AlignBaseline().
+			Rigid(wg.Body2(label).Color(color).Fn).
+			Flexed(1, wg.Body2(value).Color(color).Alignment(text.End).Fn).
+			Fn,
+	).Fn
+}
@@ -31,7 +31,7 @@ func (wg *WalletGUI) HistoryPage() l.Widget {
 						wg.txDetailEntry("Block Time", fmt.Sprint(time.Unix(txs.BlockTime, 0)), "DocBgDim", false),
 						wg.txDetailEntry("Category", txs.Category, "DocBg", false),
 						wg.txDetailEntry("Confirmations", fmt.Sprint(txs.Confirmations), "DocBgDim", false),
-						wg.txDetailEntry("Fee", fmt.Sprintf("%0.8f", txs.Fee), "DocBg", false),
+						wg.txDetailEntry("Fee", feeString(txs.Fee), "DocBg", false),
 						wg.txDetailEntry("Generated", fmt.Sprint(txs.Generated), "DocBgDim", false),
 						wg.txDetailEntry("Involves Watch Only", fmt.Sprint(txs.InvolvesWatchOnly), "DocBg", false),
 						wg.txDetailEntry("Time", fmt.Sprint(time.Unix(txs.Time, 0)), "DocBgDim", false),
@@ -42,6 +42,7 @@ func (wg *WalletGUI) HistoryPage() l.Widget {
 						wg.txDetailEntry("Comment", fmt.Sprintf("%0.8f", txs.Amount), "DocBgDim", false),
 						wg.txDetailEntry("OtherAccount", fmt.Sprint(txs.OtherAccount), "DocBg", false),
 					}
+					out = append(out, wg.txFreezeEntries(&txs)...)
 					le := func(gtx l.Context, index int) l.Dimensions {
 						return out[index](gtx)
 					}
@@ -197,3 +198,11 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 		).
 		Fn
 }
+
+// feeString formats the fee of a transaction, which is not known for transactions the wallet did not fund.
+func feeString(fee *float64) string {
+	if fee == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%0.8f", *fee)
+}
@@ -0,0 +1,137 @@
+package gui
+
+import (
+	"golang.org/x/exp/shiny/materialdesign/icons"
+
+	l "github.com/p9c/gio/layout"
+
+	"github.com/p9c/gel"
+	"github.com/p9c/pod/pkg/secretstore"
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+// keystoreService is the service the unlock key of the wallet is kept under in the secure storage of the system.
+const keystoreService = "parallelcoin wallet"
+
+// keystoreAccount returns the account the unlock key of the wallet is kept under, which is the wallet file so the
+// wallets of each network are kept apart.
+func (wg *WalletGUI) keystoreAccount() string {
+	return wg.cx.Config.WalletFile.V()
+}
+
+// openKeystore returns the secure storage of the system, or false if it can't be used here.
+func (wg *WalletGUI) openKeystore() (s secretstore.Store, ok bool) {
+	var e error
+	if s, e = secretstore.Open("", wg.cx.Config.DataDir.V()); e != nil {
+		D.Ln("secure storage is not available:", e)
+		return nil, false
+	}
+	return s, true
+}
+
+// refreshKeystore finds whether the unlock key of the wallet is in the secure storage of the system, which decides
+// whether the unlock page offers to unlock with it. This only reads whether the key is there, so it does not ask the
+// user to authenticate.
+func (wg *WalletGUI) refreshKeystore() {
+	has := false
+	if wg.cx.Config.WalletKeystore.True() {
+		if s, ok := wg.openKeystore(); ok {
+			var e error
+			if has, e = s.Has(keystoreService, wg.keystoreAccount()); E.Chk(e) {
+				has = false
+			}
+		}
+	}
+	wg.keystoreHasKey.Store(has)
+}
+
+// syncKeystore keeps the unlock key of the wallet in the secure storage of the system after it was unlocked with pass,
+// or removes it if keeping it there has been turned off since it was stored.
+func (wg *WalletGUI) syncKeystore(pass string) {
+	s, ok := wg.openKeystore()
+	if !ok {
+		return
+	}
+	var e error
+	switch {
+	case wg.cx.Config.WalletKeystore.True() && !wg.keystoreHasKey.Load():
+		if e = s.Set(keystoreService, wg.keystoreAccount(), []byte(pass)); E.Chk(e) {
+			return
+		}
+		I.Ln("the wallet unlock key has been stored in the", s.Name())
+		wg.keystoreHasKey.Store(true)
+	case !wg.cx.Config.WalletKeystore.True():
+		wg.forgetKeystore(s)
+	}
+}
+
+// forgetKeystore removes the unlock key of the wallet from the secure storage of the system.
+func (wg *WalletGUI) forgetKeystore(s secretstore.Store) {
+	if has, e := s.Has(keystoreService, wg.keystoreAccount()); E.Chk(e) || !has {
+		wg.keystoreHasKey.Store(false)
+		return
+	}
+	if e := s.Delete(keystoreService, wg.keystoreAccount()); E.Chk(e) {
+		return
+	}
+	I.Ln("the wallet unlock key has been removed from the", s.Name())
+	wg.keystoreHasKey.Store(false)
+}
+
+// keystoreUnlock unlocks the wallet with the key in the secure storage of the system, which may first ask the user to
+// authenticate. If the key can't be read the passphrase can still be entered, and if it no longer unlocks the wallet,
+// as after the passphrase was changed, it is removed so it is stored again the next time the passphrase is entered.
+func (wg *WalletGUI) keystoreUnlock() {
+	s, ok := wg.openKeystore()
+	if !ok {
+		return
+	}
+	key, e := s.Get(keystoreService, wg.keystoreAccount())
+	if e != nil {
+		W.Ln("unable to read the wallet unlock key from the", s.Name()+", enter the passphrase instead:", e)
+		if e == secretstore.ErrNotFound {
+			wg.keystoreHasKey.Store(false)
+		}
+		return
+	}
+	wg.unlockWallet(string(key))
+	zero.Bytes(key)
+	if !wg.stateLoaded.Load() {
+		W.Ln("the wallet unlock key in the", s.Name(), "is out of date, enter the passphrase instead")
+		wg.forgetKeystore(s)
+	}
+}
+
+// keystoreUnlockButton returns the button on the unlock page that unlocks the wallet with the key in the secure
+// storage of the system, which is only shown while the key is there.
+func (wg *WalletGUI) keystoreUnlockButton(clickable *gel.Clickable) l.Widget {
+	button := wg.Inset(
+		0.25,
+		wg.ButtonLayout(clickable.SetClick(wg.keystoreUnlock)).
+			Background("Primary").
+			CornerRadius(0.5).
+			Corners(0).
+			Embed(
+				wg.Inset(
+					0.25,
+					wg.Flex().AlignMiddle().
+						Rigid(
+							wg.Icon().
+								Scale(gel.Scales["H4"]).
+								Color("Light").
+								Src(&icons.ActionFingerprint).Fn,
+						).
+						Rigid(wg.Inset(0.5, gel.EmptySpace(0, 0)).Fn).
+						Rigid(wg.H6("keystore").Color("Light").Fn).
+						Rigid(wg.Inset(0.5, gel.EmptySpace(0, 0)).Fn).
+						Fn,
+				).Fn,
+			).Fn,
+	).Fn
+	return func(gtx l.Context) l.Dimensions {
+		if !wg.keystoreHasKey.Load() {
+			return l.Dimensions{}
+		}
+		return button(gtx)
+	}
+}
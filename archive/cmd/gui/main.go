@@ -1,7 +1,6 @@
 package gui
 
 import (
-	"crypto/rand"
 	"fmt"
 	"net"
 	"os"
@@ -11,11 +10,11 @@ import (
 	"time"
 
 	"github.com/niubaoshu/gotiny"
-	"github.com/tyler-smith/go-bip39"
 
 	"github.com/p9c/log"
 	"github.com/p9c/opts/meta"
 	"github.com/p9c/opts/text"
+	"github.com/p9c/pod/pkg/chainrpc"
 	"github.com/p9c/pod/pkg/chainrpc/p2padvt"
 	"github.com/p9c/pod/pkg/pipe"
 	"github.com/p9c/pod/pkg/transport"
@@ -35,6 +34,7 @@ import (
 	l "github.com/p9c/gio/layout"
 
 	"github.com/p9c/pod/cmd/gui/cfg"
+	"github.com/p9c/pod/cmd/wallet"
 	"github.com/p9c/pod/pkg/apputil"
 	"github.com/p9c/pod/pkg/rpcclient"
 	"github.com/p9c/pod/pkg/util/rununit"
@@ -52,7 +52,7 @@ func Main(cx *state.State) (e error) {
 		noWallet:   &noWallet,
 		otherNodes: make(map[uint64]*nodeSpec),
 		certs:      cx.Config.ReadCAFile(),
-
+		setup:      wallet.NewSetup(cx.Config, cx.ActiveNet),
 	}
 	return wg.Run()
 }
@@ -91,6 +91,7 @@ type WalletGUI struct {
 	statusBarButtons                         []*gel.Clickable
 	receiveAddressbookClickables             []*gel.Clickable
 	sendAddressbookClickables                []*gel.Clickable
+	portfolioRemoveClickables                []*gel.Clickable
 	quitClickable                            *gel.Clickable
 	bools                                    BoolMap
 	lists                                    ListMap
@@ -107,6 +108,7 @@ type WalletGUI struct {
 	originTxDetail                           string
 	txMx                                     sync.Mutex
 	stateLoaded                              *uberatomic.Bool
+	keystoreHasKey                           *uberatomic.Bool
 	currentReceiveQRCode                     *paint.ImageOp
 	currentReceiveAddress                    string
 	currentReceiveQR                         l.Widget
@@ -124,7 +126,7 @@ type WalletGUI struct {
 	SendPage    *SendPage
 	// toasts                    *toast.Toasts
 	// dialog                    *dialog.Dialog
-	createSeed                          []byte
+	setup                               *wallet.Setup
 	createWords, showWords, createMatch string
 	createVerifying                     bool
 	restoring                           bool
@@ -133,6 +135,8 @@ type WalletGUI struct {
 	otherNodes                          map[uint64]*nodeSpec
 	uuid                                uint64
 	peerCount                           *uberatomic.Int32
+	networkWarning                      *uberatomic.String
+	workSource                          *uberatomic.String
 	certs                               []byte
 }
 
@@ -160,8 +164,12 @@ func (wg *WalletGUI) Run() (e error) {
 	}
 	wg.multiConn = mc
 	wg.peerCount = uberatomic.NewInt32(0)
+	wg.networkWarning = uberatomic.NewString("")
+	wg.workSource = uberatomic.NewString(chainrpc.LocalWorkSource)
 	wg.prevOpenTxID = uberatomic.NewString("")
 	wg.stateLoaded = uberatomic.NewBool(false)
+	wg.keystoreHasKey = uberatomic.NewBool(false)
+	wg.refreshKeystore()
 	wg.currentReceiveRegenerate = uberatomic.NewBool(true)
 	wg.ready = uberatomic.NewBool(false)
 	wg.Window = gel.NewWindowP9(wg.quit)
@@ -254,6 +262,17 @@ func (wg *WalletGUI) Run() (e error) {
 									return
 								}
 								wg.peerCount.Store(int32(len(pi)))
+								var ni *btcjson.GetNetworkInfoResult
+								if ni, e = wg.ChainClient.GetNetworkInfo(); !E.Chk(e) {
+									wg.networkWarning.Store(ni.Warnings)
+								}
+								if wg.cx.Config.Controller.True() {
+									var ws *btcjson.GetWorkSourceResult
+									if ws, e = wg.ChainClient.GetWorkSource(); !E.Chk(e) {
+										wg.workSource.Store(ws.Active)
+									}
+									wg.updateMiningExpectation()
+								}
 								wg.Invalidate()
 							}
 						}
@@ -324,11 +343,9 @@ func (wg *WalletGUI) GetButtons() {
 }
 
 func (wg *WalletGUI) ShuffleSeed() {
-	wg.createSeed = make([]byte, 32)
-	_, _ = rand.Read(wg.createSeed)
 	var e error
 	var wk string
-	if wk, e = bip39.NewMnemonic(wg.createSeed); E.Chk(e) {
+	if wk, e = wg.setup.NewSeed(); E.Chk(e) {
 		panic(e)
 	}
 	wg.createWords = wk
@@ -377,6 +394,42 @@ func (wg *WalletGUI) GetInputs() Inputs {
 			func(pass string) {},
 			func(string) {},
 		),
+		"sendAuthCode": wg.Input(
+			"",
+			"PIN or authenticator code",
+			"DocText",
+			"PanelBg",
+			"DocBg",
+			func(string) {},
+			func(string) {},
+		),
+		"freezeReason": wg.Input(
+			"",
+			"Reason",
+			"DocText",
+			"PanelBg",
+			"DocBg",
+			func(reason string) {},
+			func(string) {},
+		),
+		"portfolioName": wg.Input(
+			"",
+			"Name",
+			"DocText",
+			"PanelBg",
+			"DocBg",
+			func(string) {},
+			func(string) {},
+		),
+		"portfolioDescriptors": wg.Input(
+			"",
+			"Descriptors or extended public keys",
+			"DocText",
+			"PanelBg",
+			"DocBg",
+			func(string) {},
+			func(string) {},
+		),
 
 		"console": wg.Input(
 			"",
@@ -399,13 +452,17 @@ func (wg *WalletGUI) GetInputs() Inputs {
 			func(seedWords string) {
 				var e error
 				wg.createMatch = seedWords
-				if wg.createSeed, e = bip39.EntropyFromMnemonic(seedWords); E.Chk(e) {
+				if e = wg.setup.RestoreSeed(seedWords); E.Chk(e) {
 					return
 				}
 				wg.createWords = seedWords
 				wg.Invalidate()
 			},
 		),
+		"walletBackupTarget": wg.Input(
+			"", "backup target URL to restore from (optional)", "DocText", "DocBg", "PanelBg", func(string) {},
+			func(string) {},
+		),
 		// "walletSeed": wg.Input(
 		// 	seedString, "wallet seed", "DocText", "DocBg", "PanelBg", func(seedHex string) {
 		// 		var e error
@@ -531,6 +588,8 @@ func (wg *WalletGUI) GetLists() (o ListMap) {
 		"received":         wg.List(),
 		"history":          wg.List(),
 		"txdetail":         wg.List(),
+		"portfolio":        wg.List(),
+		"mining":           wg.List(),
 	}
 }
 
@@ -558,6 +617,8 @@ func (wg *WalletGUI) GetClickables() ClickableMap {
 		"transactions50":          wg.Clickable(),
 		"txPageForward":           wg.Clickable(),
 		"txPageBack":              wg.Clickable(),
+		"txFreeze":                wg.Clickable(),
+		"portfolioAdd":            wg.Clickable(),
 		"theme":                   wg.Clickable(),
 	}
 }
@@ -579,6 +640,12 @@ func (wg *WalletGUI) GetBools() BoolMap {
 		"showSent":     wg.Bool(true),
 		"showReceived": wg.Bool(true),
 		"showImmature": wg.Bool(true),
+		// the open and partially paid invoices are the ones still waiting to be paid
+		"showInvoiceOpen":      wg.Bool(true),
+		"showInvoicePartial":   wg.Bool(true),
+		"showInvoicePaid":      wg.Bool(false),
+		"showInvoiceExpired":   wg.Bool(false),
+		"showInvoiceCancelled": wg.Bool(false),
 	}
 }
 
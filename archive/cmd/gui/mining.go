@@ -0,0 +1,148 @@
+package gui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	l "github.com/p9c/gio/layout"
+	"github.com/p9c/gio/text"
+)
+
+// The mining page shows what the miners taking work from the mining controller can expect to find, from the hashrate
+// they report for each algorithm and its difficulty: the average time to find a block, and the chance of finding at
+// least one within an hour, a day, a week and a month. Blocks are found at random, so a miner can go much longer than
+// the average without finding one, and the chances are there to make that plain. The expectation is reloaded from the
+// node every second while the controller is running.
+
+// updateMiningExpectation reloads the expectation of the miners of the mining controller.
+func (wg *WalletGUI) updateMiningExpectation() {
+	expectation, e := wg.ChainClient.GetMiningExpectation()
+	if E.Chk(e) {
+		return
+	}
+	wg.State.miningExpectation = expectation
+}
+
+// MiningPage returns the widget of the mining page.
+func (wg *WalletGUI) MiningPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		widgets := []l.Widget{wg.Inset(0.25, wg.H5("mining").Color("DocText").Fn).Fn}
+		x := wg.State.miningExpectation
+		if !wg.cx.Config.Controller.True() || x == nil || len(x.Algos) == 0 {
+			widgets = append(
+				widgets,
+				wg.Inset(
+					0.25,
+					wg.Body1("start the mining controller to see what your miners can expect to find").
+						Color("PanelText").Fn,
+				).Fn,
+			)
+		} else {
+			widgets = append(
+				widgets,
+				wg.miningRow(fmt.Sprintf("height %d", x.Height), formatHashrate(x.Hashrate), "DocText"),
+				wg.miningRow("a block every", formatExpectedTime(x.ExpectedTime), "DocText"),
+			)
+			for _, w := range x.Windows {
+				widgets = append(
+					widgets,
+					wg.miningRow(
+						"chance within "+formatWindow(w.Window), formatProbability(w.Probability), "PanelText",
+					),
+				)
+			}
+			for i := range x.Algos {
+				a := &x.Algos[i]
+				widgets = append(
+					widgets,
+					wg.Inset(0.25, wg.H6(a.Algo).Color("DocText").Fn).Fn,
+					wg.miningRow(fmt.Sprintf("difficulty %.8g", a.Difficulty), formatHashrate(a.Hashrate), "PanelText"),
+					wg.miningRow("a block every", formatExpectedTime(a.ExpectedTime), "PanelText"),
+				)
+				if len(a.Windows) > 1 {
+					w := a.Windows[1]
+					widgets = append(
+						widgets,
+						wg.miningRow(
+							"chance within "+formatWindow(w.Window), formatProbability(w.Probability), "PanelText",
+						),
+					)
+				}
+			}
+		}
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return widgets[index](gtx)
+		}
+		return wg.Fill(
+			"DocBg", l.Center, 0, 0,
+			wg.Inset(
+				0.25,
+				wg.lists["mining"].
+					Vertical().
+					Length(len(widgets)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// miningRow returns a row of the mining page with the label on the left and the value on the right.
+func (wg *WalletGUI) miningRow(label, value, color string) l.Widget {
+	return wg.Inset(
+		0.25,
+		wg.Flex().AlignBaseline().
+			Rigid(wg.Body2(label).Color(color).Fn).
+			Flexed(1, wg.Body2(value).Color(color).Alignment(text.End).Fn).
+			Fn,
+	).Fn
+}
+
+// formatHashrate returns a hashrate in hashes per second with the largest unit it is at least one of.
+func formatHashrate(hashrate float64) string {
+	units := []string{"H/s", "kH/s", "MH/s", "GH/s", "TH/s", "PH/s"}
+	i := 0
+	for ; hashrate >= 1000 && i < len(units)-1; i++ {
+		hashrate /= 1000
+	}
+	return fmt.Sprintf("%.2f %s", hashrate, units[i])
+}
+
+// formatExpectedTime returns an expected time in seconds in the largest unit it is at least one of.
+func formatExpectedTime(seconds float64) string {
+	const day, year = 24 * 60 * 60, 365.25 * 24 * 60 * 60
+	switch {
+	case seconds <= 0:
+		return "unknown"
+	case seconds >= year:
+		return fmt.Sprintf("%.3g years", seconds/year)
+	case seconds >= day:
+		return fmt.Sprintf("%.3g days", seconds/day)
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+}
+
+// formatWindow returns the length of a window in seconds as it is named on the page.
+func formatWindow(seconds int64) string {
+	switch d := time.Duration(seconds) * time.Second; {
+	case d == time.Hour:
+		return "an hour"
+	case d == time.Hour*24:
+		return "a day"
+	case d == time.Hour*24*7:
+		return "a week"
+	case d%(time.Hour*24) == 0:
+		return fmt.Sprintf("%d days", d/(time.Hour*24))
+	default:
+		return d.String()
+	}
+}
+
+// formatProbability returns a probability as a percentage, with as many decimals as it takes to show small chances.
+func formatProbability(p float64) string {
+	if p > 0 && p < 0.001 {
+		return fmt.Sprintf("%.*f%%", int(math.Ceil(-math.Log10(p*100)))+1, p*100)
+	}
+	return fmt.Sprintf("%.1f%%", p*100)
+}
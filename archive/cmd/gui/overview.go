@@ -163,7 +163,9 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 										0.25,
 										wg.balanceCard(),
 									).Fn,
-								).Fn,
+								).
+								Rigid(wg.Inset(0.25, wg.healthCard()).Fn).
+								Fn,
 							// ).Fn,
 						).
 						// Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
@@ -198,7 +200,9 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 										0.25,
 										wg.balanceCard(),
 									).Fn,
-								).Fn,
+								).
+								Rigid(wg.Inset(0.25, wg.healthCard()).Fn).
+								Fn,
 							// ).Fn,
 						).
 						// Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
@@ -725,7 +729,7 @@ func (wg *WalletGUI) RecentTransactions(n int, listName string) l.Widget {
 		case "history":
 			collected++
 		case "recent":
-			if txs.Category == "generate" || txs.Category == "immature" || txs.Amount < 0 && txs.Fee == 0 {
+			if txs.Category == "generate" || txs.Category == "immature" || txs.Amount < 0 && txs.Fee == nil {
 				continue
 			} else {
 				collected++
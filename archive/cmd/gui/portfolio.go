@@ -0,0 +1,274 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/shiny/materialdesign/icons"
+
+	l "github.com/p9c/gio/layout"
+	"github.com/p9c/gio/text"
+
+	"github.com/p9c/gel"
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// The portfolio page shows the cold wallets watched by the wallet, added by their public descriptors or extended public
+// keys, with the funds each holds, those of all of them added up, and the latest transactions paying to or spending
+// from them. The wallet keeps the portfolio apart from its own keys and balance and never holds private keys for it,
+// so nothing on the page can be spent. The portfolio in the State is only a copy of that in the wallet, which is
+// reloaded along with the transactions and after every change.
+
+const (
+	// portfolioRange is the number of scripts derived from each ranged descriptor of the entries added on the page.
+	portfolioRange = 1000
+	// portfolioTxCount is the number of the latest portfolio transactions shown on the page.
+	portfolioTxCount = 50
+)
+
+// updatePortfolio reloads the portfolio and its latest transactions from the wallet.
+func (wg *WalletGUI) updatePortfolio() {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	portfolio, e := wg.WalletClient.ListPortfolio(1)
+	if E.Chk(e) {
+		return
+	}
+	var txs []btcjson.PortfolioTransactionResult
+	if txs, e = wg.WalletClient.ListPortfolioTransactions("", portfolioTxCount); E.Chk(e) {
+		return
+	}
+	wg.State.portfolio, wg.State.portfolioTxs = portfolio, txs
+	wg.Invalidate()
+}
+
+// addPortfolioEntry adds the cold wallet entered on the portfolio page, whose descriptors or extended public keys are
+// separated by spaces, and searches the chain for its transactions in the background.
+func (wg *WalletGUI) addPortfolioEntry() {
+	name := strings.TrimSpace(wg.inputs["portfolioName"].GetText())
+	descriptors := strings.Fields(wg.inputs["portfolioDescriptors"].GetText())
+	if name == "" || len(descriptors) == 0 {
+		wg.State.portfolioError = "enter a name and the descriptors or extended public keys of the wallet"
+		return
+	}
+	if e := wg.WalletClient.AddPortfolioEntry(name, descriptors, portfolioRange, true); E.Chk(e) {
+		wg.State.portfolioError = e.Error()
+		return
+	}
+	wg.State.portfolioError = ""
+	wg.inputs["portfolioName"].SetText("")
+	wg.inputs["portfolioDescriptors"].SetText("")
+	wg.updatePortfolio()
+}
+
+// removePortfolioEntry removes a cold wallet from the portfolio.
+func (wg *WalletGUI) removePortfolioEntry(name string) {
+	if e := wg.WalletClient.RemovePortfolioEntry(name); E.Chk(e) {
+		wg.State.portfolioError = e.Error()
+		return
+	}
+	wg.State.portfolioError = ""
+	wg.updatePortfolio()
+}
+
+// portfolioAmount returns an amount of the portfolio given in DUO as it is shown.
+func portfolioAmount(v float64) string {
+	a, e := amt.NewAmount(v)
+	if E.Chk(e) {
+		return fmt.Sprintf("%0.8f", v)
+	}
+	return a.String()
+}
+
+// PortfolioPage returns the widget of the portfolio page.
+func (wg *WalletGUI) PortfolioPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		widgets := []l.Widget{wg.portfolioTotal(), wg.portfolioAddForm()}
+		if wg.State.portfolioError != "" {
+			widgets = append(
+				widgets, wg.Inset(0.25, wg.Caption(wg.State.portfolioError).Color("Danger").Fn).Fn,
+			)
+		}
+		widgets = append(widgets, wg.portfolioEntryCards()...)
+		if len(wg.State.portfolioTxs) > 0 {
+			widgets = append(widgets, wg.Inset(0.25, wg.H6("transactions").Color("DocText").Fn).Fn)
+			for i := range wg.State.portfolioTxs {
+				widgets = append(widgets, wg.portfolioTxRow(&wg.State.portfolioTxs[i]))
+			}
+		}
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return widgets[index](gtx)
+		}
+		return wg.Fill(
+			"DocBg", l.Center, 0, 0,
+			wg.Inset(
+				0.25,
+				wg.lists["portfolio"].
+					Vertical().
+					Length(len(widgets)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// portfolioTotal returns the widget showing the funds of all of the portfolio.
+func (wg *WalletGUI) portfolioTotal() l.Widget {
+	var balance, unconfirmed float64
+	if p := wg.State.portfolio; p != nil {
+		balance, unconfirmed = p.Balance, p.Unconfirmed
+	}
+	return wg.Inset(
+		0.25,
+		wg.VFlex().AlignStart().
+			Rigid(wg.H5("portfolio").Color("DocText").Fn).
+			Rigid(
+				wg.Flex().AlignBaseline().
+					Rigid(wg.Body1("watching only, these funds can't be spent from this wallet").Color("PanelText").Fn).
+					Flexed(1, wg.H6(portfolioAmount(balance)).Alignment(text.End).Fn).
+					Fn,
+			).
+			Rigid(
+				wg.Caption("unconfirmed "+portfolioAmount(unconfirmed)).Alignment(text.End).Fn,
+			).
+			Fn,
+	).Fn
+}
+
+// portfolioAddForm returns the form adding a cold wallet to the portfolio.
+func (wg *WalletGUI) portfolioAddForm() l.Widget {
+	return wg.Fill(
+		"PanelBg", l.Center, wg.TextSize.V, 0,
+		wg.Flex().AlignMiddle().
+			Flexed(0.3, wg.Inset(0.25, wg.inputs["portfolioName"].Fn).Fn).
+			Flexed(0.7, wg.Inset(0.25, wg.inputs["portfolioDescriptors"].Fn).Fn).
+			Rigid(
+				wg.Inset(
+					0.25,
+					wg.ButtonLayout(
+						wg.clickables["portfolioAdd"].SetClick(
+							func() {
+								wg.addPortfolioEntry()
+							},
+						),
+					).
+						Background("Primary").
+						CornerRadius(0.5).
+						Embed(
+							wg.Inset(
+								0.25,
+								wg.Flex().AlignMiddle().
+									Rigid(
+										wg.Icon().
+											Scale(gel.Scales["H6"]).
+											Color("Light").
+											Src(&icons.ContentAdd).Fn,
+									).
+									Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
+									Rigid(wg.H6("watch").Color("Light").Fn).
+									Fn,
+							).Fn,
+						).Fn,
+				).Fn,
+			).Fn,
+	).Fn
+}
+
+// portfolioEntryCards returns the cards of the cold wallets in the portfolio with the funds each holds.
+func (wg *WalletGUI) portfolioEntryCards() (widgets []l.Widget) {
+	p := wg.State.portfolio
+	if p == nil {
+		return
+	}
+	for len(wg.portfolioRemoveClickables) < len(p.Entries) {
+		wg.portfolioRemoveClickables = append(wg.portfolioRemoveClickables, wg.WidgetPool.GetClickable())
+	}
+	for i := range p.Entries {
+		entry := &p.Entries[i]
+		bg := "DocBg"
+		if i%2 == 1 {
+			bg = "DocBgDim"
+		}
+		name := entry.Name
+		widgets = append(
+			widgets, wg.Fill(
+				bg, l.Center, 0, 0,
+				wg.Inset(
+					0.25,
+					wg.Flex().AlignMiddle().
+						Flexed(
+							1,
+							wg.VFlex().AlignStart().
+								Rigid(
+									wg.Flex().AlignBaseline().
+										Rigid(wg.Body1(entry.Name).Fn).
+										Flexed(1, wg.Body1(portfolioAmount(entry.Balance)).Alignment(text.End).Fn).
+										Fn,
+								).
+								Rigid(
+									wg.Caption(
+										fmt.Sprintf(
+											"%d descriptors, %d unspent outputs, %s unconfirmed, watched since block %d",
+											len(entry.Descriptors), entry.Unspent, portfolioAmount(entry.Unconfirmed),
+											entry.Height,
+										),
+									).Color("PanelText").MaxLines(1).Fn,
+								).
+								Fn,
+						).
+						Rigid(
+							wg.Inset(
+								0.25,
+								wg.ButtonLayout(
+									wg.portfolioRemoveClickables[i].SetClick(
+										func() {
+											wg.removePortfolioEntry(name)
+										},
+									),
+								).
+									Background("Transparent").
+									Embed(
+										wg.Icon().
+											Scale(gel.Scales["H6"]).
+											Color("DocText").
+											Src(&icons.ActionDelete).Fn,
+									).Fn,
+							).Fn,
+						).Fn,
+				).Fn,
+			).Fn,
+		)
+	}
+	return
+}
+
+// portfolioTxRow returns the row of a transaction of the portfolio.
+func (wg *WalletGUI) portfolioTxRow(ptx *btcjson.PortfolioTransactionResult) l.Widget {
+	status := "unconfirmed"
+	if ptx.Confirmations > 0 {
+		status = fmt.Sprintf("%d confirmations", ptx.Confirmations)
+	}
+	return wg.Inset(
+		0.25,
+		wg.VFlex().AlignStart().
+			Rigid(
+				wg.Flex().AlignBaseline().
+					Rigid(wg.Body2(ptx.Entry).Fn).
+					Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
+					Rigid(wg.Caption(time.Unix(ptx.Time, 0).Format("2006-01-02 15:04")).Color("PanelText").Fn).
+					Flexed(1, wg.Body1(portfolioAmount(ptx.Amount)).Alignment(text.End).Fn).
+					Fn,
+			).
+			Rigid(
+				wg.Flex().AlignBaseline().
+					Flexed(1, wg.Caption(ptx.TxID).Font("go regular").MaxLines(1).Fn).
+					Rigid(wg.Caption(status).Color("PanelText").Fn).
+					Fn,
+			).
+			Fn,
+	).Fn
+}
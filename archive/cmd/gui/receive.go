@@ -3,8 +3,10 @@ package gui
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
 
 	"github.com/atotto/clipboard"
 
@@ -57,6 +59,7 @@ func (rp *ReceivePage) SmallList(gtx l.Context) l.Dimensions {
 		rp.MessageInput(),
 		rp.RegenerateButton(),
 		rp.AddressbookHeader(),
+		rp.InvoiceStateFilter(),
 	}
 	smallWidgets = append(smallWidgets, rp.GetAddressbookHistoryCards("DocBg")...)
 	le := func(gtx l.Context, index int) l.Dimensions {
@@ -117,6 +120,9 @@ func (rp *ReceivePage) MediumList(gtx l.Context) l.Dimensions {
 				Rigid(
 					rp.AddressbookHeader(),
 				).
+				Rigid(
+					rp.InvoiceStateFilter(),
+				).
 				Rigid(
 					wg.lists["receiveAddresses"].
 						Vertical().
@@ -143,6 +149,9 @@ func (rp *ReceivePage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget
 	for x := range wg.State.receiveAddresses {
 		j := x
 		i := len(wg.State.receiveAddresses) - 1 - x
+		if !wg.showInvoice(wg.State.receiveAddresses[i].State) {
+			continue
+		}
 		widgets = append(
 			widgets, func(gtx l.Context) l.Dimensions {
 				return wg.ButtonLayout(
@@ -187,6 +196,10 @@ func (rp *ReceivePage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget
 								Rigid(
 									wg.Caption(wg.State.receiveAddresses[i].Message).MaxLines(1).Fn,
 								).
+								Rigid(
+									wg.Caption(invoiceStatus(&wg.State.receiveAddresses[i])).
+										Color("PanelText").MaxLines(1).Fn,
+								).
 								Fn,
 						).
 							Fn,
@@ -197,6 +210,51 @@ func (rp *ReceivePage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget
 	return
 }
 
+// invoiceStatus returns a line describing the state of the invoice of a receive address book entry.
+func invoiceStatus(ae *AddressEntry) string {
+	switch ae.State {
+	case "partial":
+		return fmt.Sprintf("partially paid, %v of %v received", ae.Received, ae.Amount)
+	case "open":
+		if !ae.Expires.IsZero() {
+			return "unpaid, expires " + ae.Expires.Format("2006-01-02 15:04")
+		}
+		return "unpaid"
+	case "expired":
+		return "expired " + ae.Expires.Format("2006-01-02 15:04")
+	}
+	return ae.State
+}
+
+// InvoiceStateFilter selects the states of the invoices shown in the receive address history.
+func (rp *ReceivePage) InvoiceStateFilter() l.Widget {
+	wg := rp.wg
+	f := wg.Flex().AlignMiddle().
+		Rigid(
+			wg.Inset(
+				0.25,
+				wg.Caption("show").Fn,
+			).Fn,
+		)
+	for _, s := range invoiceStates {
+		s := s
+		f = f.Rigid(
+			wg.Inset(
+				0.25,
+				func(gtx l.Context) l.Dimensions {
+					return wg.CheckBox(wg.bools[s.show]).
+						TextColor("DocText").
+						TextScale(1).
+						Text(s.state).
+						IconScale(1).
+						Fn(gtx)
+				},
+			).Fn,
+		)
+	}
+	return f.Fn
+}
+
 func (rp *ReceivePage) QRMessage() l.Widget {
 	return rp.wg.Body2("Scan to send or click to copy").Alignment(text.Middle).Fn
 }
@@ -299,13 +357,18 @@ func (rp *ReceivePage) RegenerateButton() l.Widget {
 							// never store an entry without both fields filled
 							return
 						}
-						if len(wg.State.receiveAddresses) > 0 &&
-							(wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Amount == 0 ||
-								wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Message == "") {
+						if last := len(wg.State.receiveAddresses) - 1; last >= 0 &&
+							(wg.State.receiveAddresses[last].Amount == 0 ||
+								wg.State.receiveAddresses[last].Message == "") {
 							// the first entry has neither of these, and newly generated items without them are assumed to
 							// not be intentional or used addresses so we don't generate a new entry for this case
-							wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Amount = am
-							wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Message = msg
+							amount := am.ToDUO()
+							expires := time.Now().Add(invoiceExpiry).Unix()
+							if e = wg.setAddressMeta(
+								wg.State.receiveAddresses[last].Address,
+								btcjson.AddressMetaFields{Amount: &amount, Message: &msg, Expires: &expires},
+							); E.Chk(e) {
+							}
 						} else {
 							// go func() {
 							wg.GetNewReceivingAddress()
@@ -6,8 +6,8 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
 
 	"github.com/atotto/clipboard"
 
@@ -25,30 +25,25 @@ func (wg *WalletGUI) GetNewReceivingAddress() {
 			"getting new receiving address", addr.EncodeAddress(),
 			"previous:", wg.State.currentReceivingAddress.String.Load(),
 		)
-		// save to addressbook
-		var ae AddressEntry
-		ae.Address = addr.EncodeAddress()
+		// save to the address book in the wallet
+		var meta btcjson.AddressMetaFields
 		var amount float64
 		if amount, e = strconv.ParseFloat(
 			wg.inputs["receiveAmount"].GetText(),
 			64,
 		); !E.Chk(e) {
-			if ae.Amount, e = amt.NewAmount(amount); E.Chk(e) {
-			}
+			meta.Amount = &amount
 		}
 		msg := wg.inputs["receiveMessage"].GetText()
 		if len(msg) > 64 {
 			msg = msg[:64]
 		}
-		ae.Message = msg
-		ae.Created = time.Now()
-		if wg.State.IsReceivingAddress() {
-			wg.State.receiveAddresses = append(wg.State.receiveAddresses, ae)
-		} else {
-			wg.State.receiveAddresses = []AddressEntry{ae}
-			wg.State.isAddress.Store(true)
+		meta.Message = &msg
+		expires := time.Now().Add(invoiceExpiry).Unix()
+		meta.Expires = &expires
+		if e = wg.setAddressMeta(addr.EncodeAddress(), meta); E.Chk(e) {
 		}
-		D.S(wg.State.receiveAddresses)
+		wg.State.isAddress.Store(true)
 		wg.State.SetReceivingAddress(addr)
 		filename := filepath.Join(wg.cx.Config.DataDir.V(), "state.json")
 		if e = wg.State.Save(filename, wg.cx.Config.WalletPass.Bytes(), false); E.Chk(e) {
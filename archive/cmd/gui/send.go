@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
 
 	"github.com/atotto/clipboard"
 
@@ -15,12 +17,18 @@ import (
 	"github.com/p9c/gio/text"
 
 	"github.com/p9c/gel"
+	"github.com/p9c/pod/pkg/chaincfg"
 	"github.com/p9c/pod/pkg/chainhash"
+
+	uberatomic "go.uber.org/atomic"
 )
 
 type SendPage struct {
 	wg                 *WalletGUI
 	inputWidth, break1 float32
+	// authNeeded is set when the wallet refused a send for needing the PIN or authenticator code it requires above
+	// its spend limit, to show the input for the code
+	authNeeded *uberatomic.Bool
 }
 
 func (wg *WalletGUI) GetSendPage() (sp *SendPage) {
@@ -28,6 +36,7 @@ func (wg *WalletGUI) GetSendPage() (sp *SendPage) {
 		wg:         wg,
 		inputWidth: 17,
 		break1:     48,
+		authNeeded: uberatomic.NewBool(false),
 	}
 	wg.inputs["sendAddress"].SetPasteFunc = sp.pasteFunction
 	wg.inputs["sendAmount"].SetPasteFunc = sp.pasteFunction
@@ -58,6 +67,7 @@ func (sp *SendPage) SmallList(gtx l.Context) l.Dimensions {
 		sp.AddressInput(),
 		sp.AmountInput(),
 		sp.MessageInput(),
+		sp.AuthCodeInput(),
 		wg.Flex().
 			Flexed(
 				1,
@@ -102,6 +112,7 @@ func (sp *SendPage) MediumList(gtx l.Context) l.Dimensions {
 		sp.AddressInput(),
 		sp.AmountInput(),
 		sp.MessageInput(),
+		sp.AuthCodeInput(),
 		wg.Flex().
 			Flexed(
 				1,
@@ -184,6 +195,17 @@ func (sp *SendPage) MessageInput() l.Widget {
 	}
 }
 
+// AuthCodeInput is the input for the PIN or authenticator code of a send above the spend limit of the wallet, which is
+// only shown once the wallet has asked for it.
+func (sp *SendPage) AuthCodeInput() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if !sp.authNeeded.Load() {
+			return l.Dimensions{}
+		}
+		return sp.wg.inputs["sendAuthCode"].Fn(gtx)
+	}
+}
+
 func (sp *SendPage) SendButton() l.Widget {
 	return func(gtx l.Context) l.Dimensions {
 		wg := sp.wg
@@ -215,9 +237,8 @@ func (sp *SendPage) SendButton() l.Widget {
 									return
 								}
 								var addr btcaddr.Address
-								if addr, e = btcaddr.Decode(
+								if addr, e = wg.decodeSendAddress(
 									wg.inputs["sendAddress"].GetText(),
-									wg.cx.ActiveNet,
 								); E.Chk(e) {
 									D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>", e)
 									D.Ln("invalid address")
@@ -229,11 +250,30 @@ func (sp *SendPage) SendButton() l.Widget {
 									return
 								}
 								var txid *chainhash.Hash
-								if txid, e = wg.WalletClient.SendToAddress(addr, am); E.Chk(e) {
+								code := strings.TrimSpace(wg.inputs["sendAuthCode"].GetText())
+								if code != "" {
+									txid, e = wg.WalletClient.SendToAddressAuth(addr, am, code)
+								} else {
+									txid, e = wg.WalletClient.SendToAddress(addr, am)
+								}
+								if E.Chk(e) {
+									if re, ok := e.(*btcjson.RPCError); ok {
+										switch re.Code {
+										case btcjson.ErrRPCWalletSpendAuthRequired:
+											// ask for the code and let the send be tried again with it
+											sp.authNeeded.Store(true)
+											wg.Invalidate()
+										case btcjson.ErrRPCWalletSpendAuthIncorrect:
+											wg.inputs["sendAuthCode"].SetText("")
+											wg.Invalidate()
+										}
+									}
 									// TODO: indicate send failure to user somehow
 									D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>", e)
 									return
 								}
+								wg.inputs["sendAuthCode"].SetText("")
+								sp.authNeeded.Store(false)
 								wg.RecentTransactions(10, "recent")
 								wg.RecentTransactions(-1, "history")
 								wg.Invalidate()
@@ -271,28 +311,20 @@ func (sp *SendPage) saveForm(txid string) {
 	if amount == 0 {
 		return
 	}
-	var ua amt.Amount
-	if ua, e = amt.NewAmount(amount); E.Chk(e) {
-		return
-	}
 	msg := wg.inputs["sendMessage"].GetText()
 	if msg == "" {
 		return
 	}
 	addr := wg.inputs["sendAddress"].GetText()
 	var ad btcaddr.Address
-	if ad, e = btcaddr.Decode(addr, wg.cx.ActiveNet); E.Chk(e) {
+	if ad, e = wg.decodeSendAddress(addr); E.Chk(e) {
+		return
+	}
+	if e = wg.setAddressMeta(
+		ad.EncodeAddress(), btcjson.AddressMetaFields{Amount: &amount, Label: &msg, TxID: &txid},
+	); E.Chk(e) {
 		return
 	}
-	wg.State.sendAddresses = append(
-		wg.State.sendAddresses, AddressEntry{
-			Address: ad.EncodeAddress(),
-			Label:   msg,
-			Amount:  ua,
-			Created: time.Now(),
-			TxID:    txid,
-		},
-	)
 	// prevent accidental double clicks recording the same entry again
 	wg.inputs["sendAmount"].SetText("")
 	wg.inputs["sendMessage"].SetText("")
@@ -360,7 +392,7 @@ func (sp *SendPage) pasteFunction() (b bool) {
 	split2 := strings.Split(split1[1], "?")
 	addr := split2[0]
 	var ua btcaddr.Address
-	if ua, e = btcaddr.Decode(addr, wg.cx.ActiveNet); E.Chk(e) {
+	if ua, e = wg.decodeSendAddress(addr); E.Chk(e) {
 		return
 	}
 	_ = ua
@@ -496,3 +528,27 @@ func (sp *SendPage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget) {
 	}
 	return
 }
+
+// decodeSendAddress decodes an address entered to send to, refusing addresses for other networks, and segwit addresses
+// until segwit is active on the network, as payments to them could be spent by anyone before then. Raw output scripts
+// are refused too, paying to them is left to the sendmany RPC where they can be checked before they are sent.
+func (wg *WalletGUI) decodeSendAddress(addr string) (ad btcaddr.Address, e error) {
+	if ad, e = btcaddr.Decode(addr, wg.cx.ActiveNet); E.Chk(e) {
+		if _, he := hex.DecodeString(addr); he == nil {
+			e = fmt.Errorf("%s is an output script, paying to output scripts is only available with sendmany", addr)
+		}
+		return
+	}
+	if !ad.IsForNet(wg.cx.ActiveNet) {
+		return nil, fmt.Errorf("address %s is not for %s", addr, wg.cx.ActiveNet.Name)
+	}
+	switch ad.(type) {
+	case *btcaddr.WitnessPubKeyHash, *btcaddr.WitnessScriptHash:
+		if !wg.cx.ActiveNet.AddressTypeActive(chaincfg.AddressTypeBech32) {
+			return nil, fmt.Errorf(
+				"cannot pay to segwit address %s before segwit is active on %s", addr, wg.cx.ActiveNet.Name,
+			)
+		}
+	}
+	return
+}
@@ -52,14 +52,20 @@ func (c *CategoryFilter) Filter(s string) (include bool) {
 	return
 }
 
+// AddressEntry is an entry of the receive or send address book, which is read from the address metadata stored in the
+// wallet.
 type AddressEntry struct {
 	Address  string     `json:"address"`
 	Message  string     `json:"message,omitempty"`
 	Label    string     `json:"label,omitempty"`
 	Amount   amt.Amount `json:"amount"`
+	State    string     `json:"state,omitempty"`
 	Created  time.Time  `json:"created"`
 	Modified time.Time  `json:"modified"`
-	TxID     string     `json:txid,omitempty'`
+	TxID     string     `json:"txid,omitempty"`
+	// Received is the amount paid to a receive entry so far, and Expires when it stops waiting for payment, if ever.
+	Received amt.Amount `json:"received,omitempty"`
+	Expires  time.Time  `json:"expires"`
 }
 
 type State struct {
@@ -78,6 +84,20 @@ type State struct {
 	activePage              *uberatomic.String
 	sendAddresses           []AddressEntry
 	receiveAddresses        []AddressEntry
+	// legacyAddresses are the address book entries of a state file written before the address books were kept in the
+	// wallet, which are moved into the wallet when it is connected.
+	legacyAddresses []AddressEntry
+	// frozenOutputs are the outputs frozen in the wallet, keyed by the hash of their transaction and their index.
+	frozenOutputs map[string]btcjson.FrozenOutputResult
+	// portfolio is the watch-only portfolio of cold wallets in the wallet and portfolioTxs its latest transactions.
+	portfolio    *btcjson.ListPortfolioResult
+	portfolioTxs []btcjson.PortfolioTransactionResult
+	// portfolioError is the error of the last change of the portfolio made on the portfolio page.
+	portfolioError string
+	// utxoReport is the report of the unspent outputs of the wallet shown on the wallet health card.
+	utxoReport *btcjson.GetUTXOReportResult
+	// miningExpectation is what the miners of the mining controller can expect to find, shown on the mining page.
+	miningExpectation *btcjson.GetMiningExpectationResult
 }
 
 func GetNewState(params *chaincfg.Params, activePage *uberatomic.String) *State {
@@ -204,8 +224,10 @@ type Marshalled struct {
 	Filter             CategoryFilter
 	ReceivingAddress   string
 	ActivePage         string
-	ReceiveAddressBook []AddressEntry
-	SendAddressBook    []AddressEntry
+	// ReceiveAddressBook and SendAddressBook hold the address books of older versions until they are moved into the
+	// wallet.
+	ReceiveAddressBook []AddressEntry `json:",omitempty"`
+	SendAddressBook    []AddressEntry `json:",omitempty"`
 }
 
 func (s *State) Marshal() (out *Marshalled) {
@@ -219,8 +241,7 @@ func (s *State) Marshal() (out *Marshalled) {
 		Filter:             s.filter,
 		ReceivingAddress:   s.currentReceivingAddress.Load().EncodeAddress(),
 		ActivePage:         s.activePage.Load(),
-		ReceiveAddressBook: s.receiveAddresses,
-		SendAddressBook:    s.sendAddresses,
+		ReceiveAddressBook: s.legacyAddresses,
 	}
 	return
 }
@@ -234,8 +255,7 @@ func (m *Marshalled) Unmarshal(s *State) {
 	if len(s.allTxs.Load()) < len(m.AllTxs) {
 		s.allTxs.Store(m.AllTxs)
 	}
-	s.receiveAddresses = m.ReceiveAddressBook
-	s.sendAddresses = m.SendAddressBook
+	s.legacyAddresses = append(m.ReceiveAddressBook, m.SendAddressBook...)
 	s.filter = m.Filter
 
 	if m.ReceivingAddress != "1111111111111111111114oLvT2" {
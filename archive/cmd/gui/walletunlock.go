@@ -60,6 +60,7 @@ func (wg *WalletGUI) unlockWallet(pass string) {
 			if e = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V()); E.Chk(e) {
 			}
 			wg.cx.Config.WalletPass.Set(pass)
+			wg.syncKeystore(pass)
 			wg.WalletWatcher = wg.Watcher()
 			// }
 			//
@@ -102,6 +103,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gel.App) {
 	password := wg.cx.Config.WalletPass
 	exitButton := wg.WidgetPool.GetClickable()
 	unlockButton := wg.WidgetPool.GetClickable()
+	keystoreButton := wg.WidgetPool.GetClickable()
 	wg.unlockPassword = wg.Password(
 		"enter password", password, "DocText",
 		"DocBg", "PanelBg", func(pass string) {
@@ -337,6 +339,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gel.App) {
 																							).Fn,
 																					).Fn,
 																				).
+																				Rigid(wg.keystoreUnlockButton(keystoreButton)).
 																				Fn,
 																		).
 																		Fn,
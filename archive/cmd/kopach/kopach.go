@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/niubaoshu/gotiny"
@@ -64,8 +65,9 @@ type Worker struct {
 	ctx                 context.Context
 	quit                qu.C
 	sendAddresses       []*net.UDPAddr
+	clientsMx           sync.Mutex
 	clients             []*client.Client
-	workers             []*pipe.Worker
+	workers             []*pipe.Supervisor
 	FirstSender         atomic.Uint64
 	lastSent            atomic.Int64
 	Status              atomic.String
@@ -85,19 +87,41 @@ type Worker struct {
 
 func (w *Worker) Start() {
 	D.Ln("starting up kopach workers")
-	w.workers = []*pipe.Worker{}
-	w.clients = []*client.Client{}
-	for i := 0; i < w.cx.Config.GenThreads.V(); i++ {
+	threads := w.cx.Config.GenThreads.V()
+	w.workers = make([]*pipe.Supervisor, threads)
+	w.clientsMx.Lock()
+	w.clients = make([]*client.Client, threads)
+	w.clientsMx.Unlock()
+	for i := range w.workers {
 		D.Ln("starting worker", i)
-		cmd, _ := pipe.Spawn(w.quit, os.Args[0], "worker", w.id, w.cx.ActiveNet.Name, w.cx.Config.LogLevel.V())
-		w.workers = append(w.workers, cmd)
-		w.clients = append(w.clients, client.New(cmd.StdConn))
-	}
-	for i := range w.clients {
-		T.Ln("sending pass to worker", i)
-		e := w.clients[i].SendPass(w.cx.Config.MulticastPass.Bytes())
-		if e != nil {
-		}
+		// worker processes that crash are restarted, and get a new client connected to them
+		i := i
+		w.workers[i] = pipe.NewSupervisor(
+			pipe.SupervisorConfig{
+				Name:   fmt.Sprint("kopach worker ", i),
+				Args:   []string{os.Args[0], "worker", w.id, w.cx.ActiveNet.Name, w.cx.Config.LogLevel.V()},
+				Policy: pipe.DefaultRestartPolicy,
+				Started: func(p *pipe.Worker) (e error) {
+					c := client.New(p.StdConn)
+					T.Ln("sending pass to worker", i)
+					if e = c.SendPass(w.cx.Config.MulticastPass.Bytes()); E.Chk(e) {
+						return
+					}
+					w.clientsMx.Lock()
+					w.clients[i] = c
+					w.clientsMx.Unlock()
+					return
+				},
+				Report: func(st pipe.Status) {
+					if st.State != pipe.StateRunning {
+						w.clientsMx.Lock()
+						w.clients[i] = nil
+						w.clientsMx.Unlock()
+					}
+				},
+			}, w.quit,
+		)
+		w.workers[i].Start()
 	}
 	D.Ln("setting workers to active")
 	w.active.Store(true)
@@ -105,26 +129,37 @@ func (w *Worker) Start() {
 }
 
 func (w *Worker) Stop() {
-	var e error
-	for i := range w.clients {
-		if e = w.clients[i].Pause(); E.Chk(e) {
-		}
-		if e = w.clients[i].Stop(); E.Chk(e) {
-		}
-		if e = w.clients[i].Close(); E.Chk(e) {
-		}
-	}
+	w.eachClient(
+		func(i int, c *client.Client) {
+			var e error
+			if e = c.Pause(); E.Chk(e) {
+			}
+			if e = c.Stop(); E.Chk(e) {
+			}
+			if e = c.Close(); E.Chk(e) {
+			}
+		},
+	)
 	for i := range w.workers {
-		// if e = w.workers[i].Interrupt(); !E.Chk(e) {
-		// }
-		if e = w.workers[i].Kill(); !E.Chk(e) {
-		}
+		w.workers[i].Stop()
 		D.Ln("stopped worker", i)
 	}
 	w.active.Store(false)
 	w.quit.Q()
 }
 
+// eachClient calls the function with the client of each of the worker processes that is running.
+func (w *Worker) eachClient(fn func(i int, c *client.Client)) {
+	w.clientsMx.Lock()
+	clients := append([]*client.Client(nil), w.clients...)
+	w.clientsMx.Unlock()
+	for i, c := range clients {
+		if c != nil {
+			fn(i, c)
+		}
+	}
+}
+
 // Run the miner
 func Run(cx *state.State) (e error) {
 	D.Ln("miner starting")
@@ -183,12 +218,14 @@ func Run(cx *state.State) (e error) {
 					// when this string is clear other broadcasts will be listened to
 					w.FirstSender.Store(0)
 					// pause the workers
-					for i := range w.clients {
-						D.Ln("sending pause to worker", i)
-						e := w.clients[i].Pause()
-						if e != nil {
-						}
-					}
+					w.eachClient(
+						func(i int, c *client.Client) {
+							D.Ln("sending pause to worker", i)
+							e := c.Pause()
+							if e != nil {
+							}
+						},
+					)
 				}
 				// if interrupt.Requested() {
 				// 	w.StopChan <- struct{}{}
@@ -302,10 +339,12 @@ var handlers = transport.Handlers{
 		// w.FirstSender.Store(cN)
 		T.Ln("received job, starting workers on it", jr.Nonce, jr.UUID)
 		w.lastSent.Store(time.Now().UnixNano())
-		for i := range w.clients {
-			if e = w.clients[i].NewJob(&jr); E.Chk(e) {
-			}
-		}
+		w.eachClient(
+			func(i int, c *client.Client) {
+				if e := c.NewJob(&jr); E.Chk(e) {
+				}
+			},
+		)
 		return
 	},
 	string(pause.Magic): func(
@@ -321,14 +360,16 @@ var handlers = transport.Handlers{
 		np := advt.UUID
 		// np := p.GetControllerListenerPort()
 		// ns := net.JoinHostPort(strings.Split(ni.String(), ":")[0], fmt.Sprint(np))
-		D.Ln("received pause from server at", ni, np, "stopping", len(w.clients), "workers stopping")
+		D.Ln("received pause from server at", ni, np, "stopping", len(w.workers), "workers stopping")
 		if fs == np {
-			for i := range w.clients {
-				// D.Ln("sending pause to worker", i, fs, np)
-				e := w.clients[i].Pause()
-				if e != nil {
-				}
-			}
+			w.eachClient(
+				func(i int, c *client.Client) {
+					// D.Ln("sending pause to worker", i, fs, np)
+					e := c.Pause()
+					if e != nil {
+					}
+				},
+			)
 		}
 		w.FirstSender.Store(0)
 		return
@@ -0,0 +1,99 @@
+// coinsim replays a recorded wallet UTXO set and stream of payments against the coin selection strategies of the
+// wallet, and prints the fees each paid, how the number of UTXOs grew and how many payments failed.
+//
+// The UTXO set of the scenario can be replaced with that of a running wallet, saved from the output of listunspent.
+package main
+
+import (
+	js "encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/coinselect"
+)
+
+func main() {
+	path := flag.String("scenario", "", "JSON file of the scenario to replay")
+	unspent := flag.String("unspent", "", "JSON file of the output of listunspent to start from instead of the UTXOs of the scenario")
+	strategies := flag.String(
+		"strategies", "", "comma separated strategies to compare, of "+strings.Join(coinselect.StrategyNames(), ", ")+
+			", or all of them if empty",
+	)
+	asJSON := flag.Bool("json", false, "print the reports as JSON")
+	flag.Parse()
+	if e := run(*path, *unspent, *strategies, *asJSON); e != nil {
+		fmt.Fprintln(os.Stderr, e)
+		os.Exit(1)
+	}
+}
+
+func run(path, unspent, strategies string, asJSON bool) (e error) {
+	if path == "" {
+		return fmt.Errorf("no scenario given with -scenario")
+	}
+	var s *coinselect.Scenario
+	if s, e = coinselect.LoadScenario(path); e != nil {
+		return
+	}
+	if unspent != "" {
+		if s.UTXOs, e = readUnspent(unspent); e != nil {
+			return
+		}
+	}
+	var names []string
+	if strategies != "" {
+		names = strings.Split(strategies, ",")
+	}
+	var reports []*coinselect.Report
+	if reports, e = coinselect.Compare(s, names...); e != nil {
+		return
+	}
+	if asJSON {
+		var b []byte
+		if b, e = js.MarshalIndent(reports, "", "  "); e != nil {
+			return
+		}
+		fmt.Println(string(b))
+		return
+	}
+	fmt.Printf("scenario %s: %d UTXOs, %d events\n", s.Name, len(s.UTXOs), len(s.Events))
+	fmt.Printf(
+		"%-20s %8s %9s %16s %7s %7s %9s %9s\n", "strategy", "payments", "failed", "fees", "inputs", "change", "max utxos",
+		"end utxos",
+	)
+	for _, r := range reports {
+		fmt.Printf(
+			"%-20s %8d %8.1f%% %16v %7d %7d %9d %9d\n", r.Strategy, r.Sends, r.FailureRate()*100, r.Fees, r.Inputs,
+			r.Change, r.MaxUTXOs, r.FinalUTXOs,
+		)
+	}
+	return
+}
+
+// readUnspent returns the amounts in satoshis of the spendable outputs in a file of the output of listunspent.
+func readUnspent(path string) (utxos []int64, e error) {
+	var b []byte
+	if b, e = ioutil.ReadFile(path); e != nil {
+		return
+	}
+	var results []btcjson.ListUnspentResult
+	if e = js.Unmarshal(b, &results); e != nil {
+		return
+	}
+	for _, r := range results {
+		if !r.Spendable {
+			continue
+		}
+		var a amt.Amount
+		if a, e = amt.NewAmount(r.Amount); e != nil {
+			return
+		}
+		utxos = append(utxos, int64(a))
+	}
+	return
+}
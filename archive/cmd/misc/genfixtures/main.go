@@ -0,0 +1,41 @@
+// genfixtures writes a populated regression test chain and wallets made from a seed, for benchmarks and demonstrations
+// of the GUI. The same flags always make the same fixture.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/p9c/pod/pkg/fixtures"
+)
+
+func main() {
+	cfg := fixtures.DefaultConfig("")
+	flag.StringVar(&cfg.Dir, "dir", "", "directory to write the fixture to, which must not exist")
+	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "seed of the wallets and the chain")
+	blocks := flag.Int("blocks", int(cfg.Blocks), "height of the chain")
+	flag.IntVar(&cfg.Wallets, "wallets", cfg.Wallets, "number of wallets")
+	flag.IntVar(&cfg.Accounts, "accounts", cfg.Accounts, "number of accounts in each wallet besides the default account")
+	flag.IntVar(&cfg.MaxTxsPerBlock, "txs", cfg.MaxTxsPerBlock, "most transactions between the wallets in a block")
+	flag.IntVar(&cfg.Reorgs, "reorgs", cfg.Reorgs, "number of re-orgs of the chain")
+	depth := flag.Int("depth", int(cfg.MaxReorgDepth), "most blocks replaced by a re-org")
+	passphrase := flag.String("passphrase", string(cfg.PrivPassphrase), "private passphrase of the wallets")
+	flag.Parse()
+	cfg.Blocks = int32(*blocks)
+	cfg.MaxReorgDepth = int32(*depth)
+	cfg.PrivPassphrase = []byte(*passphrase)
+	m, e := fixtures.Generate(cfg)
+	if e != nil {
+		fmt.Fprintln(os.Stderr, e)
+		os.Exit(1)
+	}
+	fmt.Printf("height %d tip %s\n", m.Height, m.Tip)
+	fmt.Printf("%d transactions, %d re-orgs\n", m.Transactions, len(m.Reorgs))
+	for _, w := range m.Wallets {
+		fmt.Printf("%s: %d transactions\n", w.File, w.Transactions)
+		for _, a := range w.Accounts {
+			fmt.Printf("  %-10s %4d addresses %4d unspent %16d satoshis\n", a.Name, a.Addresses, a.Unspent, a.Balance)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// signet makes the challenge of a new signed test network from the public keys of its block producers, generating a
+// key if none are given, and prints the challenge with the magic and genesis block of the network it makes.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+)
+
+func main() {
+	keys := flag.String("keys", "", "comma separated hex encoded public keys of the block producers, a key is generated if empty")
+	required := flag.Int("required", 1, "number of the block producers that must sign each block")
+	flag.Parse()
+	if e := run(*keys, *required); e != nil {
+		fmt.Fprintln(os.Stderr, e)
+		os.Exit(1)
+	}
+}
+
+func run(keys string, required int) (e error) {
+	// The address and key encodings of the signed test networks are those of the test network.
+	net := &chaincfg.TestNet3Params
+	var pubKeys []*btcaddr.PubKey
+	if keys == "" {
+		var priv *ecc.PrivateKey
+		if priv, e = ecc.NewPrivateKey(ecc.S256()); e != nil {
+			return
+		}
+		var wif *util.WIF
+		if wif, e = util.NewWIF(priv, net, true); e != nil {
+			return
+		}
+		fmt.Println("block producer key:", wif.String())
+		keys = hex.EncodeToString(priv.PubKey().SerializeCompressed())
+	}
+	for _, k := range strings.Split(keys, ",") {
+		var serialized []byte
+		if serialized, e = hex.DecodeString(strings.TrimSpace(k)); e != nil {
+			return
+		}
+		var pubKey *btcaddr.PubKey
+		if pubKey, e = btcaddr.NewPubKey(serialized, net); e != nil {
+			return
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	if required < 1 || required > len(pubKeys) {
+		return fmt.Errorf("between 1 and %d of the block producers must sign each block", len(pubKeys))
+	}
+	var challenge []byte
+	if len(pubKeys) == 1 {
+		challenge, e = txscript.PayToAddrScript(pubKeys[0])
+	} else {
+		challenge, e = txscript.MultiSigScript(pubKeys, required)
+	}
+	if e != nil {
+		return
+	}
+	var params *chaincfg.Params
+	if params, e = chaincfg.SigNetParams(challenge); e != nil {
+		return
+	}
+	fmt.Println("signet challenge:", hex.EncodeToString(challenge))
+	fmt.Printf("network magic: %08x\n", uint32(params.Net))
+	fmt.Println("genesis block hash:", params.GenesisHash)
+	return
+}
@@ -5,8 +5,8 @@ import (
 	"time"
 
 	"github.com/p9c/pod/pkg/amt"
-	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/connmgr"
+	"github.com/p9c/pod/pkg/mining"
 
 	"github.com/p9c/pod/pkg/chaincfg"
 )
@@ -14,12 +14,14 @@ import (
 // Config stores current state of the node
 type Config struct {
 	Lookup              connmgr.LookupFunc
+	Resolver            *connmgr.Resolver
 	Oniondial           func(string, string, time.Duration) (net.Conn, error)
 	Dial                func(string, string, time.Duration) (net.Conn, error)
 	AddedCheckpoints    []chaincfg.Checkpoint
-	ActiveMiningAddrs   []btcaddr.Address
+	ActiveMiningAddrs   mining.PayoutAddrs
 	ActiveMinerKey      []byte
 	ActiveMinRelayTxFee amt.Amount
+	ActiveMaxTxFee      amt.Amount
 	ActiveWhitelists    []*net.IPNet
 	DropAddrIndex       bool
 	DropTxIndex         bool
@@ -0,0 +1,410 @@
+package byzpeer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/gcs/builder"
+	"github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Fault is a way the byzantine peer answers the requests of the peer it is connected to wrongly. Faults are flags which
+// can be combined.
+type Fault uint32
+
+const (
+	// StaleHeaders answers every getheaders request with the headers following the genesis block, whatever the locator
+	// asks for, so the peer never learns of the blocks after those it already has.
+	StaleHeaders Fault = 1 << iota
+	// WithholdBlocks ignores requests for blocks, sending neither the blocks nor a notfound message, so the peer waits
+	// for blocks that never come.
+	WithholdBlocks
+	// CorruptFilters serves committed filters with a bit flipped, so they no longer match their blocks or filter
+	// headers.
+	CorruptFilters
+)
+
+// handshakeTimeout is how long Connect and Accept wait for the handshake with the peer to complete.
+const handshakeTimeout = time.Second * 30
+
+// ErrDisconnected is returned when the byzantine peer is asked to misbehave after it has been disconnected, which is
+// what a node does with a peer once it has banned it.
+var ErrDisconnected = errors.New("the byzantine peer is disconnected")
+
+// Config is the configuration of a byzantine peer.
+type Config struct {
+	// ChainParams are the parameters of the network the peer is on.
+	ChainParams *chaincfg.Params
+	// Blocks is the chain served by the peer following the genesis block of ChainParams, which is all it serves when
+	// there are none.
+	Blocks []*wire.Block
+	// Faults are the ways the peer answers requests wrongly.
+	Faults Fault
+}
+
+// Peer is a byzantine peer connected to the node or client under test. The embedded peer.Peer gives the state of the
+// connection, and sends the messages queued on it as they are.
+type Peer struct {
+	*peer.Peer
+	cfg     Config
+	chain   []*wire.Block
+	heights map[chainhash.Hash]int32
+	// filters are the serialized committed filters of the blocks of the chain.
+	filters      [][]byte
+	verAckOnce   sync.Once
+	verAck       chan struct{}
+	disconnected chan struct{}
+	mtx          sync.Mutex
+	received     map[string]int
+}
+
+// newPeer returns a byzantine peer serving the chain of the configuration, with the committed filters of its blocks
+// worked out.
+func newPeer(cfg *Config) (p *Peer, e error) {
+	p = &Peer{
+		cfg:          *cfg,
+		chain:        append([]*wire.Block{cfg.ChainParams.GenesisBlock}, cfg.Blocks...),
+		heights:      make(map[chainhash.Hash]int32, len(cfg.Blocks)+1),
+		verAck:       make(chan struct{}),
+		disconnected: make(chan struct{}),
+		received:     make(map[string]int),
+	}
+	p.filters = make([][]byte, len(p.chain))
+	for height, block := range p.chain {
+		p.heights[block.BlockHash()] = int32(height)
+		// The outputs spent by the blocks are not known to the peer, so the filters only hold the outputs created.
+		filter, e := builder.BuildBasicFilter(block, nil)
+		if E.Chk(e) {
+			return nil, e
+		}
+		if p.filters[height], e = filter.NBytes(); E.Chk(e) {
+			return nil, e
+		}
+	}
+	return
+}
+
+// Connect connects a byzantine peer to the node listening on addr, and returns it once the handshake is complete.
+func Connect(cfg *Config, addr string) (p *Peer, e error) {
+	if p, e = newPeer(cfg); E.Chk(e) {
+		return
+	}
+	if p.Peer, e = peer.NewOutboundPeer(p.peerConfig(), addr); E.Chk(e) {
+		return nil, e
+	}
+	var conn net.Conn
+	if conn, e = net.Dial("tcp", addr); E.Chk(e) {
+		return nil, e
+	}
+	if e = p.start(conn); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// Accept runs a byzantine peer on a connection accepted from the client under test, and returns it once the handshake
+// is complete.
+func Accept(cfg *Config, conn net.Conn) (p *Peer, e error) {
+	if p, e = newPeer(cfg); E.Chk(e) {
+		return
+	}
+	p.Peer = peer.NewInboundPeer(p.peerConfig())
+	if e = p.start(conn); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// peerConfig returns the configuration of the underlying peer, whose listeners answer requests with the faults of the
+// byzantine peer.
+func (p *Peer) peerConfig() *peer.Config {
+	return &peer.Config{
+		NewestBlock: func() (*chainhash.Hash, int32, error) {
+			hash := p.chain[len(p.chain)-1].BlockHash()
+			return &hash, int32(len(p.chain) - 1), nil
+		},
+		UserAgentName:    "byzpeer",
+		UserAgentVersion: "0.0.1",
+		ChainParams:      p.cfg.ChainParams,
+		Services:         wire.SFNodeNetwork | wire.SFNodeCF,
+		Listeners: peer.MessageListeners{
+			OnVerAck: func(*peer.Peer, *wire.MsgVerAck) {
+				p.verAckOnce.Do(func() { close(p.verAck) })
+			},
+			OnGetHeaders:  p.onGetHeaders,
+			OnGetData:     p.onGetData,
+			OnGetCFilters: p.onGetCFilters,
+			OnRead:        p.onRead,
+		},
+	}
+}
+
+// start runs the peer on the connection and waits for the handshake to complete.
+func (p *Peer) start(conn net.Conn) (e error) {
+	p.AssociateConnection(conn)
+	go func() {
+		p.WaitForDisconnect()
+		close(p.disconnected)
+	}()
+	select {
+	case <-p.verAck:
+		return
+	case <-p.disconnected:
+		return errors.New("the peer disconnected during the handshake")
+	case <-time.After(handshakeTimeout):
+		p.Disconnect()
+		return errors.New("timed out waiting for the handshake with the peer")
+	}
+}
+
+// WaitForDisconnectTimeout waits up to timeout for the peer to be disconnected, such as by the node banning it, and
+// returns whether it was.
+func (p *Peer) WaitForDisconnectTimeout(timeout time.Duration) bool {
+	select {
+	case <-p.disconnected:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Received returns the number of messages with the command received from the peer, which shows how it answered the
+// misbehaviour, such as with reject or notfound messages.
+func (p *Peer) Received(command string) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.received[command]
+}
+
+// Filter returns the committed filter of the block at the height of the chain served as it should be, to check the
+// filters the client under test accepted against.
+func (p *Peer) Filter(height int32) []byte {
+	return p.filters[height]
+}
+
+// onRead counts the messages received from the peer.
+func (p *Peer) onRead(_ *peer.Peer, _ int, msg wire.Message, e error) {
+	if e != nil || msg == nil {
+		return
+	}
+	p.mtx.Lock()
+	p.received[msg.Command()]++
+	p.mtx.Unlock()
+}
+
+// locate returns the height of the first block of the locator found in the chain served, or 0 for the genesis block if
+// none of them are.
+func (p *Peer) locate(locator []*chainhash.Hash) int32 {
+	for _, hash := range locator {
+		if height, ok := p.heights[*hash]; ok {
+			return height
+		}
+	}
+	return 0
+}
+
+// onGetHeaders answers with the headers following the locator up to the stop hash, or with StaleHeaders those
+// following the genesis block.
+func (p *Peer) onGetHeaders(_ *peer.Peer, msg *wire.MsgGetHeaders) {
+	start := int32(1)
+	if p.cfg.Faults&StaleHeaders == 0 {
+		start = p.locate(msg.BlockLocatorHashes) + 1
+	}
+	headers := wire.NewMsgHeaders()
+	for height := start; height < int32(len(p.chain)); height++ {
+		if e := headers.AddBlockHeader(&p.chain[height].Header); e != nil {
+			break
+		}
+		if p.chain[height].BlockHash() == msg.HashStop {
+			break
+		}
+	}
+	p.QueueMessage(headers, nil)
+}
+
+// onGetData answers with the blocks asked for, unless they are withheld, and notfound for anything else.
+func (p *Peer) onGetData(_ *peer.Peer, msg *wire.MsgGetData) {
+	notFound := wire.NewMsgNotFound()
+	for _, iv := range msg.InvList {
+		if iv.Type == wire.InvTypeBlock {
+			if p.cfg.Faults&WithholdBlocks != 0 {
+				D.Ln("withholding block", iv.Hash)
+				continue
+			}
+			if height, ok := p.heights[iv.Hash]; ok {
+				p.QueueMessage(p.chain[height], nil)
+				continue
+			}
+		}
+		if e := notFound.AddInvVect(iv); E.Chk(e) {
+			break
+		}
+	}
+	if len(notFound.InvList) > 0 {
+		p.QueueMessage(notFound, nil)
+	}
+}
+
+// onGetCFilters answers with the committed filters of the blocks asked for, corrupted with CorruptFilters.
+func (p *Peer) onGetCFilters(_ *peer.Peer, msg *wire.MsgGetCFilters) {
+	stop, ok := p.heights[msg.StopHash]
+	if !ok || msg.FilterType != wire.GCSFilterRegular {
+		return
+	}
+	for height := int32(msg.StartHeight); height <= stop; height++ {
+		data := p.filters[height]
+		if p.cfg.Faults&CorruptFilters != 0 && len(data) > 0 {
+			data = append([]byte(nil), data...)
+			data[len(data)-1] ^= 1
+		}
+		hash := p.chain[height].BlockHash()
+		p.QueueMessage(wire.NewMsgCFilter(wire.GCSFilterRegular, &hash, data), nil)
+	}
+}
+
+// send queues a message and waits for it to be written.
+func (p *Peer) send(msg wire.Message) error {
+	if !p.Connected() {
+		return ErrDisconnected
+	}
+	done := make(chan struct{}, 1)
+	p.QueueMessage(msg, done)
+	<-done
+	return nil
+}
+
+// randomHash returns a made up hash for inventory that does not exist.
+func randomHash() (hash chainhash.Hash) {
+	rand.Read(hash[:])
+	return
+}
+
+// FloodInv announces count made up transactions in inv messages of the largest size allowed. Announcing more than
+// wire.MaxInvPerMsg in a minute is more than a well behaved peer does.
+func (p *Peer) FloodInv(count int) (e error) {
+	for count > 0 {
+		inv := wire.NewMsgInv()
+		for ; count > 0 && len(inv.InvList) < wire.MaxInvPerMsg; count-- {
+			hash := randomHash()
+			if e = inv.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &hash)); E.Chk(e) {
+				return
+			}
+		}
+		if e = p.send(inv); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// FloodGetData asks for count made up transactions in getdata messages of the largest size allowed, which a node
+// scores as an attempt to exhaust its resources.
+func (p *Peer) FloodGetData(count int) (e error) {
+	for count > 0 {
+		getData := wire.NewMsgGetData()
+		for ; count > 0 && len(getData.InvList) < wire.MaxInvPerMsg; count-- {
+			hash := randomHash()
+			if e = getData.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &hash)); E.Chk(e) {
+				return
+			}
+		}
+		if e = p.send(getData); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// SpamAddr sends count made up addresses in addr messages of the largest size allowed. Sending more than
+// wire.MaxAddrPerMsg in a minute is more than a well behaved peer does.
+func (p *Peer) SpamAddr(count int) (e error) {
+	port, _ := strconv.Atoi(p.cfg.ChainParams.DefaultPort)
+	for count > 0 {
+		addr := wire.NewMsgAddr()
+		for ; count > 0 && len(addr.AddrList) < wire.MaxAddrPerMsg; count-- {
+			ip := net.IPv4(10, byte(count>>16), byte(count>>8), byte(count))
+			if e = addr.AddAddress(wire.NewNetAddressIPPort(ip, uint16(port), wire.SFNodeNetwork)); E.Chk(e) {
+				return
+			}
+		}
+		if e = p.send(addr); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// RepeatGetHeaders sends the same getheaders request from the genesis block times times.
+func (p *Peer) RepeatGetHeaders(times int) (e error) {
+	getHeaders := wire.NewMsgGetHeaders()
+	if e = getHeaders.AddBlockLocatorHash(p.cfg.ChainParams.GenesisHash); E.Chk(e) {
+		return
+	}
+	for i := 0; i < times; i++ {
+		if e = p.send(getHeaders); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// SendOversizedInv sends an inv message announcing one more transaction than wire.MaxInvPerMsg, which the wire package
+// refuses to encode, and a node must refuse to decode.
+func (p *Peer) SendOversizedInv() error {
+	var payload bytes.Buffer
+	if e := wire.WriteVarInt(&payload, 0, wire.MaxInvPerMsg+1); E.Chk(e) {
+		return e
+	}
+	var iv [4 + chainhash.HashSize]byte
+	for i := 0; i <= wire.MaxInvPerMsg; i++ {
+		binary.LittleEndian.PutUint32(iv[:4], uint32(wire.InvTypeTx))
+		hash := randomHash()
+		copy(iv[4:], hash[:])
+		payload.Write(iv[:])
+	}
+	return p.SendRaw(wire.CmdInv, payload.Bytes())
+}
+
+// SendRaw sends a message with the command and payload as they are, so messages breaking the rules of the protocol can
+// be made up.
+func (p *Peer) SendRaw(command string, payload []byte) error {
+	return p.send(&rawMsg{command: command, payload: payload})
+}
+
+// rawMsg is a message that is sent as it is, without being checked.
+type rawMsg struct {
+	command string
+	payload []byte
+}
+
+// BtcDecode is part of the wire.Message interface, raw messages are only ever sent.
+func (m *rawMsg) BtcDecode(io.Reader, uint32, wire.MessageEncoding) error {
+	return errors.New("raw messages can't be decoded")
+}
+
+// BtcEncode writes the payload of the message. This is part of the wire.Message interface.
+func (m *rawMsg) BtcEncode(w io.Writer, _ uint32, _ wire.MessageEncoding) (e error) {
+	_, e = w.Write(m.payload)
+	return
+}
+
+// Command returns the command of the message. This is part of the wire.Message interface.
+func (m *rawMsg) Command() string {
+	return m.command
+}
+
+// MaxPayloadLength returns the largest payload of any message, so no limit is enforced on raw messages. This is part of
+// the wire.Message interface.
+func (m *rawMsg) MaxPayloadLength(uint32) uint32 {
+	return wire.MaxMessagePayload
+}
@@ -0,0 +1,181 @@
+package byzpeer_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/cmd/node/integration/byzpeer"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// replyTimeout is how long the tests wait for a reply from the byzantine peer.
+const replyTimeout = time.Second * 5
+
+// testChain returns a chain of blocks following the genesis block of the parameters, each with a coinbase paying to a
+// script of its own so their filters differ.
+func testChain(params *chaincfg.Params, n int) (blocks []*wire.Block) {
+	prev := *params.GenesisHash
+	for i := 0; i < n; i++ {
+		tx := wire.NewMsgTx(1)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{byte(i), 0}, nil))
+		tx.AddTxOut(wire.NewTxOut(1, []byte{0x51, byte(i)}))
+		block := &wire.Block{
+			Header: wire.BlockHeader{
+				Version:   1,
+				PrevBlock: prev,
+				Timestamp: params.GenesisBlock.Header.Timestamp.Add(time.Minute * time.Duration(i+1)),
+			},
+			Transactions: []*wire.MsgTx{tx},
+		}
+		blocks = append(blocks, block)
+		prev = block.BlockHash()
+	}
+	return
+}
+
+// client is a well behaved peer accepting a connection from the byzantine peer, which passes on the replies it gets.
+type client struct {
+	*peer.Peer
+	headers  chan *wire.MsgHeaders
+	blocks   chan *wire.Block
+	notFound chan *wire.MsgNotFound
+	filters  chan *wire.MsgCFilter
+}
+
+// connect connects a byzantine peer with the configuration to a client and returns both.
+func connect(t *testing.T, cfg *byzpeer.Config) (c *client, p *byzpeer.Peer) {
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer func() {
+		if e := listener.Close(); e != nil {
+			t.Log(e)
+		}
+	}()
+	c = &client{
+		headers:  make(chan *wire.MsgHeaders, 1),
+		blocks:   make(chan *wire.Block, 1),
+		notFound: make(chan *wire.MsgNotFound, 1),
+		filters:  make(chan *wire.MsgCFilter, 16),
+	}
+	c.Peer = peer.NewInboundPeer(
+		&peer.Config{
+			UserAgentName:    "client",
+			UserAgentVersion: "1.0",
+			ChainParams:      cfg.ChainParams,
+			Services:         wire.SFNodeNetwork,
+			Listeners: peer.MessageListeners{
+				OnHeaders:  func(_ *peer.Peer, msg *wire.MsgHeaders) { c.headers <- msg },
+				OnBlock:    func(_ *peer.Peer, msg *wire.Block, _ []byte) { c.blocks <- msg },
+				OnNotFound: func(_ *peer.Peer, msg *wire.MsgNotFound) { c.notFound <- msg },
+				OnCFilter:  func(_ *peer.Peer, msg *wire.MsgCFilter) { c.filters <- msg },
+			},
+		},
+	)
+	go func() {
+		conn, e := listener.Accept()
+		if e != nil {
+			t.Error(e)
+			return
+		}
+		c.AssociateConnection(conn)
+	}()
+	if p, e = byzpeer.Connect(cfg, listener.Addr().String()); e != nil {
+		t.Fatal(e)
+	}
+	return
+}
+
+// TestStaleHeaders ensures the byzantine peer answers getheaders with the headers after the locator, or with
+// StaleHeaders those after the genesis block.
+func TestStaleHeaders(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	blocks := testChain(params, 4)
+	for _, test := range []struct {
+		faults byzpeer.Fault
+		first  int
+	}{
+		{faults: 0, first: 2},
+		{faults: byzpeer.StaleHeaders, first: 0},
+	} {
+		c, p := connect(t, &byzpeer.Config{ChainParams: params, Blocks: blocks, Faults: test.faults})
+		getHeaders := wire.NewMsgGetHeaders()
+		locator := blocks[1].BlockHash()
+		if e := getHeaders.AddBlockLocatorHash(&locator); e != nil {
+			t.Fatal(e)
+		}
+		c.QueueMessage(getHeaders, nil)
+		select {
+		case headers := <-c.headers:
+			if len(headers.Headers) == 0 || headers.Headers[0].BlockHash() != blocks[test.first].BlockHash() {
+				t.Errorf("faults %d: got %d headers not starting with block %d", test.faults, len(headers.Headers), test.first)
+			}
+		case <-time.After(replyTimeout):
+			t.Errorf("faults %d: timed out waiting for headers", test.faults)
+		}
+		p.Disconnect()
+		c.Disconnect()
+	}
+}
+
+// TestWithholdBlocks ensures the byzantine peer sends the blocks asked for, and with WithholdBlocks neither sends them
+// nor a notfound message.
+func TestWithholdBlocks(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	blocks := testChain(params, 2)
+	for _, faults := range []byzpeer.Fault{0, byzpeer.WithholdBlocks} {
+		c, p := connect(t, &byzpeer.Config{ChainParams: params, Blocks: blocks, Faults: faults})
+		hash := blocks[1].BlockHash()
+		getData := wire.NewMsgGetData()
+		if e := getData.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, &hash)); e != nil {
+			t.Fatal(e)
+		}
+		c.QueueMessage(getData, nil)
+		select {
+		case block := <-c.blocks:
+			if faults != 0 {
+				t.Error("a withheld block was sent")
+			} else if block.BlockHash() != hash {
+				t.Errorf("got block %v, want %v", block.BlockHash(), hash)
+			}
+		case <-c.notFound:
+			t.Errorf("faults %d: got notfound for a block in the chain", faults)
+		case <-time.After(replyTimeout):
+			if faults == 0 {
+				t.Error("timed out waiting for the block")
+			}
+		}
+		p.Disconnect()
+		c.Disconnect()
+	}
+}
+
+// TestCorruptFilters ensures the byzantine peer serves the committed filters of its blocks, and with CorruptFilters
+// serves filters that differ from them.
+func TestCorruptFilters(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	blocks := testChain(params, 3)
+	for _, faults := range []byzpeer.Fault{0, byzpeer.CorruptFilters} {
+		c, p := connect(t, &byzpeer.Config{ChainParams: params, Blocks: blocks, Faults: faults})
+		stop := blocks[len(blocks)-1].BlockHash()
+		c.QueueMessage(wire.NewMsgGetCFilters(wire.GCSFilterRegular, 0, &stop), nil)
+		for height := int32(0); height <= int32(len(blocks)); height++ {
+			select {
+			case filter := <-c.filters:
+				if matches := bytes.Equal(filter.Data, p.Filter(height)); matches != (faults == 0) {
+					t.Errorf("faults %d: filter of block %d matches %v", faults, height, matches)
+				}
+			case <-time.After(replyTimeout):
+				t.Fatalf("faults %d: timed out waiting for the filter of block %d", faults, height)
+			}
+		}
+		p.Disconnect()
+		c.Disconnect()
+	}
+}
@@ -0,0 +1,12 @@
+// Package byzpeer provides a byzantine peer for testing how a node or SPV client deals with peers that misbehave.
+//
+// The byzantine peer completes the handshake like any other peer and serves a chain of blocks given to it, but can be
+// configured with faults that make it answer requests wrongly: sending stale headers whatever it is asked for,
+// withholding the blocks it announced, and serving committed filters that do not match their blocks. It can also be
+// made to break the limits of the protocol, flooding the peer with announcements, addresses, repeated requests and
+// messages larger than allowed, so that the banning and scoring of misbehaving peers can be covered by regression
+// tests.
+//
+// A byzantine peer either connects to a node, such as one run by the rpctest harness, with Connect, or is handed a
+// connection accepted from a client under test with Accept.
+package byzpeer
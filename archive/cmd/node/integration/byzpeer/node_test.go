@@ -0,0 +1,75 @@
+package byzpeer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/cmd/node/integration/byzpeer"
+	"github.com/p9c/pod/cmd/node/integration/rpctest"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// disconnectTimeout is how long the tests wait for the node to disconnect a misbehaving peer.
+const disconnectTimeout = time.Second * 30
+
+// TestNodeMisbehaviour runs byzantine peers against a node, ensuring it disconnects a peer sending a message over the
+// limits of the protocol, and bans a peer flooding it with requests so that it is refused when it connects again. The
+// peers connect from the same address, so the banning is tested last.
+func TestNodeMisbehaviour(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping byzantine peer test against a node in short mode")
+	}
+	h, e := rpctest.New(&chaincfg.SimNetParams, nil, nil)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = h.SetUp(false, 0); e != nil {
+		t.Fatalf("unable to complete rpctest setup: %v", e)
+	}
+	defer func() {
+		if e := h.TearDown(); e != nil {
+			t.Log(e)
+		}
+	}()
+	cfg := &byzpeer.Config{ChainParams: &chaincfg.SimNetParams}
+	t.Run(
+		"oversized", func(t *testing.T) {
+			p, e := byzpeer.Connect(cfg, h.P2PAddress())
+			if e != nil {
+				t.Fatal(e)
+			}
+			if e = p.SendOversizedInv(); e != nil {
+				t.Fatal(e)
+			}
+			if !p.WaitForDisconnectTimeout(disconnectTimeout) {
+				p.Disconnect()
+				t.Fatal("the node did not disconnect a peer sending an oversized inv")
+			}
+			if p.Received(wire.CmdReject) == 0 {
+				t.Error("the node did not reject the oversized inv")
+			}
+		},
+	)
+	t.Run(
+		"ban", func(t *testing.T) {
+			p, e := byzpeer.Connect(cfg, h.P2PAddress())
+			if e != nil {
+				t.Fatal(e)
+			}
+			// Each getdata of the largest size raises the ban score by 99, so two go over the default threshold of 100.
+			if e = p.FloodGetData(2 * wire.MaxInvPerMsg); e != nil && e != byzpeer.ErrDisconnected {
+				t.Fatal(e)
+			}
+			if !p.WaitForDisconnectTimeout(disconnectTimeout) {
+				p.Disconnect()
+				t.Fatal("the node did not disconnect a peer flooding it with getdata")
+			}
+			// The banned peer is disconnected again as soon as it connects, if the handshake completes at all.
+			if p, e = byzpeer.Connect(cfg, h.P2PAddress()); e == nil && !p.WaitForDisconnectTimeout(disconnectTimeout) {
+				p.Disconnect()
+				t.Fatal("the node let a banned peer connect again")
+			}
+		},
+	)
+}
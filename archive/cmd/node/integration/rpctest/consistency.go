@@ -0,0 +1,235 @@
+package rpctest
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// walletSyncTimeout is how long CheckConsistency waits for the in-memory wallet to process the blocks notified to it.
+const walletSyncTimeout = time.Second * 30
+
+// CheckConsistency returns an error describing the first disagreement it finds between the best chain of the harness'
+// node and the state derived from it after a re-org back to forkHeight:
+//
+// - every block header from the genesis block up links to the one before it
+//
+// - every transaction in the best chain above forkHeight is found by the transaction index in the block it is in
+//
+// - none of the orphaned transactions, which were in blocks that are no longer in the best chain, is still indexed in
+// one of those blocks, and no transaction found by the address index for the wallet's coinbase address is in a block
+// outside the best chain
+//
+// - the utxos of the in-memory wallet are exactly the unspent outputs paying to it in the node's utxo set
+//
+// The index checks require the node to be run with --txindex and --addrindex.
+func (h *Harness) CheckConsistency(forkHeight int32, orphaned []chainhash.Hash) (e error) {
+	var tip int32
+	if _, tip, e = h.Node.GetBestBlock(); E.Chk(e) {
+		return
+	}
+	if e = h.waitForWallet(tip); E.Chk(e) {
+		return
+	}
+	// mainChain maps the hashes of the blocks in the best chain to their height.
+	var mainChain map[chainhash.Hash]int32
+	if mainChain, e = h.checkHeaders(tip); E.Chk(e) {
+		return
+	}
+	// txBlocks maps the transactions in the best chain above forkHeight to the block they are in.
+	txBlocks := make(map[chainhash.Hash]chainhash.Hash)
+	var blocks []*wire.Block
+	for height := forkHeight + 1; height <= tip; height++ {
+		var hash *chainhash.Hash
+		if hash, e = h.Node.GetBlockHash(int64(height)); E.Chk(e) {
+			return
+		}
+		var blk *wire.Block
+		if blk, e = h.Node.GetBlock(hash); E.Chk(e) {
+			return
+		}
+		for _, tx := range blk.Transactions {
+			txBlocks[tx.TxHash()] = *hash
+		}
+		blocks = append(blocks, blk)
+	}
+	if e = h.checkTxIndex(txBlocks, orphaned); E.Chk(e) {
+		return
+	}
+	if e = h.checkAddrIndex(mainChain); E.Chk(e) {
+		return
+	}
+	return h.checkWallet(blocks)
+}
+
+// waitForWallet blocks until the in-memory wallet is synced to height, or returns an error if it does not get there
+// within walletSyncTimeout.
+func (h *Harness) waitForWallet(height int32) error {
+	deadline := time.Now().Add(walletSyncTimeout)
+	for !h.wallet.syncedTo(height) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"wallet synced to height %d, not to the best block at height %d", h.wallet.SyncedHeight(), height,
+			)
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	return nil
+}
+
+// checkHeaders returns an error if a block header in the best chain up to tip does not link to the one before it, and
+// otherwise the heights of the blocks of the best chain by hash.
+func (h *Harness) checkHeaders(tip int32) (mainChain map[chainhash.Hash]int32, e error) {
+	mainChain = make(map[chainhash.Hash]int32, tip+1)
+	var prevHash chainhash.Hash
+	for height := int32(0); height <= tip; height++ {
+		var hash *chainhash.Hash
+		if hash, e = h.Node.GetBlockHash(int64(height)); E.Chk(e) {
+			return
+		}
+		var header *wire.BlockHeader
+		if header, e = h.Node.GetBlockHeader(hash); E.Chk(e) {
+			return
+		}
+		if header.BlockHash() != *hash {
+			return nil, fmt.Errorf("header of block %s at height %d hashes to %s", hash, height, header.BlockHash())
+		}
+		if height > 0 && header.PrevBlock != prevHash {
+			return nil, fmt.Errorf(
+				"header of block %s at height %d links to %s instead of %s", hash, height, header.PrevBlock, prevHash,
+			)
+		}
+		mainChain[*hash] = height
+		prevHash = *hash
+	}
+	return
+}
+
+// checkTxIndex returns an error if a transaction in the best chain is not found by the transaction index in the block
+// it is in, or an orphaned transaction is found in a block it is no longer in.
+func (h *Harness) checkTxIndex(txBlocks map[chainhash.Hash]chainhash.Hash, orphaned []chainhash.Hash) (e error) {
+	for txHash, blockHash := range txBlocks {
+		txHash := txHash
+		var indexed string
+		if indexed, e = h.indexedBlock(&txHash); E.Chk(e) {
+			return fmt.Errorf("transaction %s of block %s is not indexed: %v", txHash, blockHash, e)
+		}
+		if indexed != blockHash.String() {
+			return fmt.Errorf("transaction %s of block %s is indexed in block %q", txHash, blockHash, indexed)
+		}
+	}
+	for i := range orphaned {
+		if _, ok := txBlocks[orphaned[i]]; ok {
+			// The transaction was mined again in the new best chain, which was checked above.
+			continue
+		}
+		var indexed string
+		if indexed, e = h.indexedBlock(&orphaned[i]); e != nil {
+			// Orphaned coinbases and transactions that could not go back to the mempool are forgotten.
+			e = nil
+			continue
+		}
+		if indexed != "" {
+			return fmt.Errorf("orphaned transaction %s is still indexed in block %s", orphaned[i], indexed)
+		}
+	}
+	return
+}
+
+// indexedBlock returns the hash of the block the node finds a transaction in, which is empty if it is in the mempool.
+func (h *Harness) indexedBlock(txHash *chainhash.Hash) (blockHash string, e error) {
+	var raw *btcjson.TxRawResult
+	if raw, e = h.Node.GetRawTransactionVerbose(txHash); e != nil {
+		return
+	}
+	return raw.BlockHash, nil
+}
+
+// checkAddrIndex returns an error if the address index finds a transaction paying to the wallet's coinbase address in a
+// block that is not in the best chain.
+func (h *Harness) checkAddrIndex(mainChain map[chainhash.Hash]int32) (e error) {
+	results, e := h.Node.SearchRawTransactionsVerbose(h.wallet.coinbaseAddr, 0, len(mainChain), false, false, nil)
+	if E.Chk(e) {
+		return
+	}
+	for _, res := range results {
+		if res.BlockHash == "" {
+			continue
+		}
+		var blockHash *chainhash.Hash
+		if blockHash, e = chainhash.NewHashFromStr(res.BlockHash); E.Chk(e) {
+			return
+		}
+		if _, ok := mainChain[*blockHash]; !ok {
+			return fmt.Errorf(
+				"address index finds transaction %s in block %s which is not in the best chain", res.TxID, blockHash,
+			)
+		}
+	}
+	return
+}
+
+// checkWallet returns an error if a utxo of the in-memory wallet is not in the node's utxo set with the same value, or
+// an output paying to the wallet in the blocks is in the node's utxo set but not in the wallet.
+func (h *Harness) checkWallet(blocks []*wire.Block) (e error) {
+	m := h.wallet
+	m.RLock()
+	defer m.RUnlock()
+	for op, u := range m.utxos {
+		if e = h.checkUnspent(op, u.value); E.Chk(e) {
+			return fmt.Errorf("wallet utxo %v: %v", op, e)
+		}
+	}
+	for _, blk := range blocks {
+		for _, tx := range blk.Transactions {
+			txHash := tx.TxHash()
+			for i, out := range tx.TxOut {
+				op := wire.OutPoint{Hash: txHash, Index: uint32(i)}
+				if _, ok := m.utxos[op]; ok || !m.paysTo(out.PkScript) {
+					continue
+				}
+				if e = h.checkUnspent(op, amt.Amount(out.Value)); e == nil {
+					return fmt.Errorf("unspent output %v paying to the wallet is missing from it", op)
+				}
+				e = nil
+			}
+		}
+	}
+	return
+}
+
+// checkUnspent returns an error if the outpoint is not in the node's utxo set with the given value. Outputs spent by
+// transactions in the mempool are counted as unspent, as they are by the wallet.
+func (h *Harness) checkUnspent(op wire.OutPoint, value amt.Amount) (e error) {
+	res, e := h.Node.GetTxOut(&op.Hash, op.Index, false)
+	if e != nil {
+		return
+	}
+	if res == nil {
+		return fmt.Errorf("not in the utxo set")
+	}
+	var nodeValue amt.Amount
+	if nodeValue, e = amt.NewAmount(res.Value); E.Chk(e) {
+		return
+	}
+	if nodeValue != value {
+		return fmt.Errorf("value %v in the utxo set, %v in the wallet", nodeValue, value)
+	}
+	return
+}
+
+// paysTo returns whether the output script pays to one of the wallet's addresses, matching it in the same way as
+// evalOutputs does. The wallet's mutex must be held when this function is called.
+func (m *memWallet) paysTo(pkScript []byte) bool {
+	for _, addr := range m.addrs {
+		if bytes.Contains(pkScript, addr.ScriptAddress()) {
+			return true
+		}
+	}
+	return false
+}
@@ -145,6 +145,15 @@ func (m *memWallet) SyncedHeight() int32 {
 	return m.currentHeight
 }
 
+// syncedTo returns whether the wallet has processed all the chain updates it has been sent and is synced to height.
+// This function is safe for concurrent access.
+func (m *memWallet) syncedTo(height int32) bool {
+	m.chainMtx.Lock()
+	pending := len(m.chainUpdates)
+	m.chainMtx.Unlock()
+	return pending == 0 && m.SyncedHeight() == height
+}
+
 // SetRPCClient saves the passed rpc connection to pod as the wallet's personal rpc connection.
 func (m *memWallet) SetRPCClient(rpcClient *rpcclient.Client) {
 	m.rpc = rpcClient
@@ -279,7 +288,13 @@ func (m *memWallet) UnwindBlock(height int32, header *wire.BlockHeader) {
 
 // unwindBlock undoes the effect that a particular block had on the wallet's internal utxo state.
 func (m *memWallet) unwindBlock(update *chainUpdate) {
-	undo := m.reorgJournal[update.blockHeight]
+	// The wallet is now synced to the block before the disconnected one, so coinbase maturity is judged against it until
+	// the blocks of the new main chain are connected.
+	m.currentHeight = update.blockHeight - 1
+	undo, ok := m.reorgJournal[update.blockHeight]
+	if !ok {
+		return
+	}
 	for _, utxo := range undo.utxosCreated {
 		delete(m.utxos, utxo)
 	}
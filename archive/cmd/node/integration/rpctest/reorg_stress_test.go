@@ -0,0 +1,140 @@
+package rpctest
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+var (
+	reorgSeed   = flag.Int64("reorgseed", 0, "seed of the random competing chains of TestReorgStress, 0 for the time")
+	reorgRounds = flag.Int("reorgrounds", 10, "number of re-orgs driven by TestReorgStress")
+)
+
+// maxReorgDepth is the largest number of blocks TestReorgStress disconnects in a re-org.
+const maxReorgDepth = 6
+
+// TestReorgStress partitions two nodes, has each mine a random number of blocks on top of their common tip with the
+// transactions of the wallet of one of them in its blocks, then joins them again so the node with the shorter chain
+// re-orgs to the longer one, and checks the headers, indexes and wallets of both nodes agree with the best chain after
+// each re-org. The seed is logged so a failing run can be reproduced with -reorgseed.
+func TestReorgStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping re-org stress test in short mode")
+	}
+	seed := *reorgSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t.Logf("re-org stress seed %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+	indexArgs := []string{"--txindex", "--addrindex"}
+	spender, e := New(&chaincfg.SimNetParams, nil, indexArgs)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = spender.SetUp(true, numMatureOutputs); E.Chk(e) {
+		t.Fatalf("unable to complete rpctest setup: %v", e)
+	}
+	defer func() {
+		if e := spender.TearDown(); E.Chk(e) {
+		}
+	}()
+	miner, e := New(&chaincfg.SimNetParams, nil, indexArgs)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = miner.SetUp(false, 0); E.Chk(e) {
+		t.Fatalf("unable to complete rpctest setup: %v", e)
+	}
+	defer func() {
+		if e := miner.TearDown(); E.Chk(e) {
+		}
+	}()
+	nodes := []*Harness{spender, miner}
+	if e = ConnectNode(miner, spender); E.Chk(e) {
+		t.Fatalf("unable to connect harnesses: %v", e)
+	}
+	if e = JoinNodes(nodes, Blocks); E.Chk(e) {
+		t.Fatalf("unable to join nodes on blocks: %v", e)
+	}
+	for round := 0; round < *reorgRounds; round++ {
+		if e = DisconnectNode(miner, spender); E.Chk(e) {
+			t.Fatalf("round %d: unable to disconnect harnesses: %v", round, e)
+		}
+		var forkHeight int32
+		if _, forkHeight, e = spender.Node.GetBestBlock(); E.Chk(e) {
+			t.Fatalf("round %d: unable to get best block: %v", round, e)
+		}
+		// The spender's wallet sends some of its outputs to itself so they are mined into its side of the fork.
+		for i := rng.Intn(4); i > 0; i-- {
+			sendToSelf(t, spender, amt.Amount(1+rng.Intn(10))*amt.SatoshiPerBitcoin)
+		}
+		// One side mines a longer chain than the other, which it wins with when they are joined again.
+		short := 1 + rng.Intn(maxReorgDepth)
+		long := short + 1 + rng.Intn(3)
+		winner, loser := miner, spender
+		if rng.Intn(2) == 0 {
+			winner, loser = spender, miner
+		}
+		orphaned := generate(t, loser, short)
+		generate(t, winner, long)
+		if e = ConnectNode(miner, spender); E.Chk(e) {
+			t.Fatalf("round %d: unable to connect harnesses: %v", round, e)
+		}
+		if e = JoinNodes(nodes, Blocks); E.Chk(e) {
+			t.Fatalf("round %d: unable to join nodes on blocks: %v", round, e)
+		}
+		t.Logf(
+			"round %d: re-orged %d blocks back to height %d onto a chain of %d blocks",
+			round, short, forkHeight, long,
+		)
+		for _, h := range nodes {
+			if e = h.CheckConsistency(forkHeight, orphaned); E.Chk(e) {
+				t.Fatalf("round %d: node %d is inconsistent after re-org: %v", round, h.nodeNum, e)
+			}
+		}
+	}
+}
+
+// sendToSelf sends amount from the harness' wallet to a new address of it.
+func sendToSelf(t *testing.T, h *Harness, amount amt.Amount) {
+	addr, e := h.NewAddress()
+	if e != nil {
+		t.Fatalf("unable to get new address: %v", e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatalf("unable to create script: %v", e)
+	}
+	if _, e = h.SendOutputs([]*wire.TxOut{wire.NewTxOut(int64(amount), pkScript)}, 10); e != nil {
+		// The wallet runs out of mature outputs when its blocks keep being orphaned, which only means fewer
+		// transactions are re-orged.
+		t.Logf("unable to send %v: %v", amount, e)
+	}
+}
+
+// generate mines n blocks on the harness' node and returns the hashes of the transactions in them.
+func generate(t *testing.T, h *Harness, n int) (txHashes []chainhash.Hash) {
+	blockHashes, e := h.Node.Generate(uint32(n))
+	if e != nil {
+		t.Fatalf("unable to generate blocks: %v", e)
+	}
+	for _, blockHash := range blockHashes {
+		blk, e := h.Node.GetBlock(blockHash)
+		if e != nil {
+			t.Fatalf("unable to get block %s: %v", blockHash, e)
+		}
+		for _, tx := range blk.Transactions {
+			txHashes = append(txHashes, tx.TxHash())
+		}
+	}
+	return
+}
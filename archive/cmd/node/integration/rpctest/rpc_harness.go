@@ -246,7 +246,7 @@ func (h *Harness) TearDown() (e error) {
 // function returns with an error.
 func (h *Harness) connectRPCClient() (e error) {
 	var client *rpcclient.Client
-	rpcConf := h.node.config.rpcConnConfig()
+	rpcConf := h.RPCConfig()
 	for i := 0; i < h.maxConnRetries; i++ {
 		if client, e = rpcclient.New(&rpcConf, h.handlers, qu.T()); E.Chk(e) {
 			time.Sleep(time.Duration(i) * 50 * time.Millisecond)
@@ -316,7 +316,9 @@ func (h *Harness) UnlockOutputs(inputs []*wire.TxIn) {
 // RPCConfig returns the harnesses current rpc configuration. This allows other potential RPC clients created within
 // tests to connect to a given test harness instance.
 func (h *Harness) RPCConfig() rpcclient.ConnConfig {
-	return h.node.config.rpcConnConfig()
+	rpcConf := h.node.config.rpcConnConfig()
+	rpcConf.Params = h.ActiveNet
+	return rpcConf
 }
 
 // P2PAddress returns the harness' P2P listening address. This allows potential peers ( such as SPV peers) created
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"time"
 	
+	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/rpcclient"
 )
@@ -116,6 +117,31 @@ func ConnectNode(from *Harness, to *Harness) (e error) {
 	return nil
 }
 
+// DisconnectNode removes the persistent peer-to-peer connection made by ConnectNode from the "from" harness to the "to"
+// harness, and blocks until it has been torn down, leaving the two free to mine competing chains.
+func DisconnectNode(from *Harness, to *Harness) (e error) {
+	targetAddr := to.node.config.listen
+	if e = from.Node.AddNode(targetAddr, rpcclient.ANRemove); E.Chk(e) {
+		return e
+	}
+	// Block until the connection is gone.
+	for connected := true; connected; {
+		var peerInfo []btcjson.GetPeerInfoResult
+		if peerInfo, e = from.Node.GetPeerInfo(); E.Chk(e) {
+			return e
+		}
+		connected = false
+		for i := range peerInfo {
+			if peerInfo[i].Addr == targetAddr {
+				connected = true
+				time.Sleep(time.Millisecond * 100)
+				break
+			}
+		}
+	}
+	return nil
+}
+
 // TearDownAll tears down all active test harnesses.
 func TearDownAll() (e error) {
 	harnessStateMtx.Lock()
@@ -156,6 +156,15 @@ func NodeMain(cx *state.State) (e error) {
 			return
 		}
 	}
+	// drop what the enabled analyzers have gathered so the chain is replayed into them once the node starts.
+	if cx.Config.ReindexAnalyze.True() {
+		for _, name := range cx.Config.Analyzers.S() {
+			W.Ln("dropping", name, "analyzer to replay the chain into it")
+			if e = indexers.DropAnalyzerIndex(db, name, interrupt.ShutdownRequestChan); E.Chk(e) {
+				return
+			}
+		}
+	}
 	// return now if an interrupt signal was triggered
 	if interrupt.Requested() {
 		return nil
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// ArchiveAccount archives an account, leaving it out of listaccounts and the total balance of the wallet for users
+// with many accounts they no longer use. The account is not changed otherwise: its addresses are still watched and
+// rescanned, its balance can be asked for by name and spent, and it can be unarchived at any time.
+func (w *Wallet) ArchiveAccount(scope waddrmgr.KeyScope, account uint32) error {
+	return w.setAccountArchived(scope, account, true)
+}
+
+// UnarchiveAccount restores an archived account to listaccounts and the total balance of the wallet.
+func (w *Wallet) UnarchiveAccount(scope waddrmgr.KeyScope, account uint32) error {
+	return w.setAccountArchived(scope, account, false)
+}
+
+func (w *Wallet) setAccountArchived(scope waddrmgr.KeyScope, account uint32, archive bool) (e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(scope); E.Chk(e) {
+		return
+	}
+	var props *waddrmgr.AccountProperties
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if archive {
+				e = manager.ArchiveAccount(addrmgrNs, account)
+			} else {
+				e = manager.UnarchiveAccount(addrmgrNs, account)
+			}
+			if e != nil {
+				return
+			}
+			props, e = manager.AccountProperties(addrmgrNs, account)
+			return
+		},
+	)
+	if e != nil {
+		return
+	}
+	w.NtfnServer.notifyAccountProperties(props)
+	return
+}
+
+// archivedBalance returns the spendable balance of the archived accounts of the wallet, counted as CalculateBalance
+// counts the balance of the wallet, so it can be taken out of it.
+func (w *Wallet) archivedBalance(
+	addrmgrNs, txmgrNs walletdb.ReadBucket, changeConf, receivedConf, syncHeight int32,
+) (balance amt.Amount, e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	var archived map[uint32]time.Time
+	if archived, e = manager.ArchivedAccounts(addrmgrNs); E.Chk(e) || len(archived) == 0 {
+		return
+	}
+	var unspent []wtxmgr.Credit
+	if unspent, e = w.TxStore.UnspentOutputs(txmgrNs); E.Chk(e) {
+		return
+	}
+	for i := range unspent {
+		output := &unspent[i]
+		account, ok := w.outputAccount(addrmgrNs, output.PkScript)
+		if _, isArchived := archived[account]; !ok || !isArchived {
+			continue
+		}
+		if output.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity), output.Height, syncHeight) {
+			continue
+		}
+		if creditConfirmed(output, changeConf, receivedConf, syncHeight) {
+			balance += output.Amount
+		}
+	}
+	return
+}
@@ -0,0 +1,269 @@
+package wallet
+
+import (
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+const (
+	// AddressMetaReceive is the category of the metadata of an address of the wallet, such as a payment request.
+	AddressMetaReceive = "receive"
+	// AddressMetaSend is the category of the metadata of an address that is not in the wallet, such as the recipient of
+	// a payment.
+	AddressMetaSend = "send"
+)
+
+const (
+	// InvoiceOpen is the state of a payment request that is waiting to be paid.
+	InvoiceOpen = "open"
+	// InvoicePaid is the state of a payment request that has been paid.
+	InvoicePaid = "paid"
+	// InvoiceCancelled is the state of a payment request that will not be paid.
+	InvoiceCancelled = "cancelled"
+	// InvoicePartial is the state of an open payment request that has been paid less than the amount requested. Like
+	// InvoiceExpired, it is found from the payments to the address when invoices are listed and is never stored.
+	InvoicePartial = "partial"
+	// InvoiceExpired is the state of an open payment request that was not paid before it expired.
+	InvoiceExpired = "expired"
+)
+
+// InvoiceStates are the states of a payment request made with a receiving address that can be stored.
+var InvoiceStates = []string{InvoiceOpen, InvoicePaid, InvoiceCancelled}
+
+// AddressMeta is the metadata the front ends of the wallet keep about an address, which is stored in the wallet so
+// every front end sees the same data. Receive entries are payment requests made with addresses of the wallet, and send
+// entries are the address book of the recipients of payments.
+type AddressMeta struct {
+	Address  string
+	Category string
+	// Amount is the amount requested for a receive entry, or the amount paid for a send entry.
+	Amount  amt.Amount
+	Message string
+	Label   string
+	// State is the invoice state of a receive entry, one of InvoiceStates, and empty for send entries.
+	State    string
+	TxID     string
+	Created  time.Time
+	Modified time.Time
+	// Expires is when a receive entry stops waiting for payment, and is zero if it does not expire.
+	Expires time.Time
+}
+
+// AddressMetaUpdate is a change to the metadata of an address. Fields that are nil are left as they are.
+type AddressMetaUpdate struct {
+	Amount  *amt.Amount
+	Message *string
+	Label   *string
+	State   *string
+	TxID    *string
+	// Expires is the new expiry time of a receive entry, where the zero time removes the expiry.
+	Expires *time.Time
+}
+
+// addrMetaRecord is the encoding of the metadata of an address in the database, which is keyed by the address.
+type addrMetaRecord struct {
+	Category string     `json:"category"`
+	Amount   amt.Amount `json:"amount"`
+	Message  string     `json:"message,omitempty"`
+	Label    string     `json:"label,omitempty"`
+	State    string     `json:"state,omitempty"`
+	TxID     string     `json:"txid,omitempty"`
+	Created  int64      `json:"created"`
+	Modified int64      `json:"modified"`
+	Expires  int64      `json:"expires,omitempty"`
+}
+
+// meta returns the metadata of the address that the record is stored under.
+func (r *addrMetaRecord) meta(address string) AddressMeta {
+	return AddressMeta{
+		Address:  address,
+		Category: r.Category,
+		Amount:   r.Amount,
+		Message:  r.Message,
+		Label:    r.Label,
+		State:    r.State,
+		TxID:     r.TxID,
+		Created:  time.Unix(0, r.Created),
+		Modified: time.Unix(0, r.Modified),
+		Expires:  unixNanoTime(r.Expires),
+	}
+}
+
+// unixNanoTime returns the time of the nanoseconds since 1 Jan 1970 GMT, or the zero time for zero.
+func unixNanoTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// SetAddressMeta applies the update to the metadata of the address, creating it if the address has none, and returns
+// the metadata as it is stored. The category of new metadata is receive if the address is in the wallet, in which case
+// it starts out as an open payment request, and send otherwise. The metadata is kept in its own namespace of the wallet
+// database, which is created with the first entry.
+func (w *Wallet) SetAddressMeta(addr btcaddr.Address, u AddressMetaUpdate) (m *AddressMeta, e error) {
+	if u.Amount != nil && *u.Amount < 0 {
+		return nil, InvalidParameterError{errors.New("amount must not be negative")}
+	}
+	var mine bool
+	if mine, e = w.HaveAddress(addr); E.Chk(e) {
+		return
+	}
+	return w.putAddressMeta(addr.EncodeAddress(), mine, u)
+}
+
+// putAddressMeta applies the update to the metadata stored for the address, which is a receive entry if it is new and
+// mine is set.
+func (w *Wallet) putAddressMeta(address string, mine bool, u AddressMetaUpdate) (m *AddressMeta, e error) {
+	key := []byte(address)
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(addrMetaNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(addrMetaNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			now := time.Now().UnixNano()
+			rec := addrMetaRecord{Category: AddressMetaSend, Created: now}
+			if v := ns.Get(key); v != nil {
+				if e = js.Unmarshal(v, &rec); E.Chk(e) {
+					return
+				}
+			} else if mine {
+				rec.Category, rec.State = AddressMetaReceive, InvoiceOpen
+			}
+			if u.Amount != nil {
+				rec.Amount = *u.Amount
+			}
+			if u.Message != nil {
+				rec.Message = *u.Message
+			}
+			if u.Label != nil {
+				rec.Label = *u.Label
+			}
+			if u.TxID != nil {
+				rec.TxID = *u.TxID
+			}
+			if u.Expires != nil {
+				if rec.Category != AddressMetaReceive {
+					return InvalidParameterError{
+						fmt.Errorf("%s addresses do not expire", rec.Category),
+					}
+				}
+				rec.Expires = 0
+				if !u.Expires.IsZero() {
+					rec.Expires = u.Expires.UnixNano()
+				}
+			}
+			if u.State != nil {
+				if rec.State, e = parseInvoiceState(rec.Category, *u.State); E.Chk(e) {
+					return
+				}
+			}
+			rec.Modified = now
+			var body []byte
+			if body, e = js.Marshal(rec); E.Chk(e) {
+				return
+			}
+			if e = ns.Put(key, body); E.Chk(e) {
+				return
+			}
+			meta := rec.meta(address)
+			m = &meta
+			return
+		},
+	)
+	return
+}
+
+// GetAddressMeta returns the metadata of the address, or ErrNoAddressMeta if it has none.
+func (w *Wallet) GetAddressMeta(address string) (m *AddressMeta, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			var v []byte
+			if ns := tx.ReadBucket(addrMetaNamespaceKey); ns != nil {
+				v = ns.Get([]byte(address))
+			}
+			if v == nil {
+				return ErrNoAddressMeta
+			}
+			var rec addrMetaRecord
+			if e = js.Unmarshal(v, &rec); E.Chk(e) {
+				return
+			}
+			meta := rec.meta(address)
+			m = &meta
+			return
+		},
+	)
+	return
+}
+
+// ListAddressMeta returns the metadata of all the addresses in the category, or of every address if category is empty,
+// oldest first.
+func (w *Wallet) ListAddressMeta(category string) (metas []AddressMeta, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(addrMetaNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			return ns.ForEach(
+				func(k, v []byte) (e error) {
+					var rec addrMetaRecord
+					if e = js.Unmarshal(v, &rec); E.Chk(e) {
+						return
+					}
+					if category == "" || rec.Category == category {
+						metas = append(metas, rec.meta(string(k)))
+					}
+					return
+				},
+			)
+		},
+	)
+	sort.SliceStable(
+		metas, func(i, j int) bool {
+			return metas[i].Created.Before(metas[j].Created)
+		},
+	)
+	return
+}
+
+// DeleteAddressMeta removes the metadata of the address, or returns ErrNoAddressMeta if it has none.
+func (w *Wallet) DeleteAddressMeta(address string) (e error) {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(addrMetaNamespaceKey)
+			if ns == nil || ns.Get([]byte(address)) == nil {
+				return ErrNoAddressMeta
+			}
+			return ns.Delete([]byte(address))
+		},
+	)
+}
+
+// parseInvoiceState returns the invoice state of metadata in the category, which must be one of InvoiceStates for
+// receive entries and empty for send entries.
+func parseInvoiceState(category, state string) (string, error) {
+	if category != AddressMetaReceive {
+		if state != "" {
+			return "", InvalidParameterError{fmt.Errorf("%s addresses do not have an invoice state", category)}
+		}
+		return "", nil
+	}
+	for _, s := range InvoiceStates {
+		if state == s {
+			return s, nil
+		}
+	}
+	return "", InvalidParameterError{fmt.Errorf("unknown invoice state %q, must be one of %v", state, InvoiceStates)}
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+)
+
+// TestAddressMeta ensures address metadata is created with the category and invoice state of the address, updated only
+// in the fields that are set, listed by category in the order it was created, and deleted.
+func TestAddressMeta(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	if _, e := w.GetAddressMeta("receive1"); e != ErrNoAddressMeta {
+		t.Fatalf("got error %v for an address without metadata, want %v", e, ErrNoAddressMeta)
+	}
+	amount := amt.Amount(150000000)
+	msg := "invoice 1"
+	m, e := w.putAddressMeta("receive1", true, AddressMetaUpdate{Amount: &amount, Message: &msg})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if m.Category != AddressMetaReceive || m.State != InvoiceOpen || m.Amount != amount || m.Message != msg {
+		t.Fatalf("got new receive entry %+v", m)
+	}
+	label := "shop"
+	if _, e = w.putAddressMeta("send1", false, AddressMetaUpdate{Label: &label}); e != nil {
+		t.Fatal(e)
+	}
+	paid := InvoicePaid
+	if m, e = w.putAddressMeta("receive1", false, AddressMetaUpdate{State: &paid}); e != nil {
+		t.Fatal(e)
+	}
+	if m.Category != AddressMetaReceive || m.State != InvoicePaid || m.Amount != amount || m.Message != msg {
+		t.Fatalf("got updated receive entry %+v", m)
+	}
+	if _, e = w.putAddressMeta("send1", false, AddressMetaUpdate{State: &paid}); e == nil {
+		t.Fatal("set an invoice state on a send entry")
+	}
+	bogus := "bogus"
+	if _, e = w.putAddressMeta("receive1", true, AddressMetaUpdate{State: &bogus}); e == nil {
+		t.Fatal("set an unknown invoice state")
+	}
+	metas, e := w.ListAddressMeta("")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(metas) != 2 || metas[0].Address != "receive1" || metas[1].Address != "send1" {
+		t.Fatalf("got entries %+v, want receive1 and send1", metas)
+	}
+	if metas, e = w.ListAddressMeta(AddressMetaSend); e != nil || len(metas) != 1 || metas[0].Label != label {
+		t.Fatalf("got send entries %+v, error %v", metas, e)
+	}
+	if e = w.DeleteAddressMeta("receive1"); e != nil {
+		t.Fatal(e)
+	}
+	if e = w.DeleteAddressMeta("receive1"); e != ErrNoAddressMeta {
+		t.Fatalf("got error %v deleting a deleted entry, want %v", e, ErrNoAddressMeta)
+	}
+	if metas, e = w.ListAddressMeta(AddressMetaReceive); e != nil || len(metas) != 0 {
+		t.Fatalf("got receive entries %+v after delete, error %v", metas, e)
+	}
+}
@@ -0,0 +1,354 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// auditedMethods are the RPC methods that change the wallet, or export keys from it, and are recorded in the audit log.
+var auditedMethods = map[string]struct{}{
+	"addmultisigaddress":     {},
+	"addportfolioentry":      {},
+	"createnewaccount":       {},
+	"deleteaddressmeta":      {},
+	"dropwallethistory":      {},
+	"dumpprivkey":            {},
+	"exportaccountxprv":      {},
+	"exportpaymentbundle":    {},
+	"freezeunspent":          {},
+	"importcorewallet":       {},
+	"importprivkey":          {},
+	"importscriptpubkey":     {},
+	"importtimelockscript":   {},
+	"keypoolrefill":          {},
+	"overridedust":           {},
+	"releaseinvoiceaddress":  {},
+	"removeportfolioentry":   {},
+	"renameaccount":          {},
+	"reserveinvoiceaddress":  {},
+	"sendfrom":               {},
+	"sendmany":               {},
+	"sendtoaddress":          {},
+	"setaddressmeta":         {},
+	"setinvoiceissuance":     {},
+	"settxfee":               {},
+	"sweepaccount":           {},
+	"sweepprivkey":           {},
+	"sweeptimelocked":        {},
+	"transferaccount":        {},
+	"walletpassphrasechange": {},
+}
+
+// AuditEntry is an entry of the audit log of the changes made to the wallet. Each entry is chained to the one before it
+// by Hash, which is the SHA256 of the hash of the previous entry followed by the encoding of this one, so entries can
+// not be changed or removed without breaking the chain.
+type AuditEntry struct {
+	Seq      uint64
+	Time     time.Time
+	Identity string
+	Action   string
+	Detail   string
+	Error    string
+	Hash     [sha256.Size]byte
+}
+
+// auditRecord is the encoding of an audit log entry in the database, which follows the hash of the entry.
+type auditRecord struct {
+	Time     int64  `json:"time"`
+	Identity string `json:"identity"`
+	Action   string `json:"action"`
+	Detail   string `json:"detail"`
+	Error    string `json:"error,omitempty"`
+}
+
+// auditKey returns the database key of the audit log entry with the sequence number seq, which sorts in sequence order.
+func auditKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+// auditHash returns the hash that chains the encoded audit log entry to the entry before it.
+func auditHash(prev []byte, record []byte) (h [sha256.Size]byte) {
+	return sha256.Sum256(append(append([]byte{}, prev...), record...))
+}
+
+// RecordAudit appends an entry to the audit log of the wallet, recording that the client with the given identity
+// performed the action, and the error it failed with, if any. The log is kept in its own namespace of the wallet
+// database, which is created with the first entry.
+func (w *Wallet) RecordAudit(identity, action, detail string, failure error) (e error) {
+	rec := auditRecord{
+		Time:     time.Now().Unix(),
+		Identity: identity,
+		Action:   action,
+		Detail:   detail,
+	}
+	if failure != nil {
+		rec.Error = failure.Error()
+	}
+	var body []byte
+	if body, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(auditNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(auditNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			seq := uint64(1)
+			prev := make([]byte, sha256.Size)
+			if k, v := ns.ReadCursor().Last(); k != nil {
+				if len(k) != 8 || len(v) < sha256.Size {
+					return errors.New("corrupt audit log entry")
+				}
+				seq = binary.BigEndian.Uint64(k) + 1
+				prev = v[:sha256.Size]
+			}
+			hash := auditHash(prev, body)
+			return ns.Put(auditKey(seq), append(hash[:], body...))
+		},
+	)
+}
+
+// AuditLog returns at most count entries of the audit log of the wallet from the sequence number from onwards, made at
+// or after start and at or before end, where a zero start or end leaves the range open on that side. It also returns
+// whether the hash chain of the whole log is intact, which is false if any entry was changed, removed or reordered.
+func (w *Wallet) AuditLog(from uint64, count int, start, end time.Time) (entries []AuditEntry, verified bool, e error) {
+	verified = true
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(auditNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			prev := make([]byte, sha256.Size)
+			var last uint64
+			c := ns.ReadCursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				if len(k) != 8 || len(v) < sha256.Size {
+					verified = false
+					continue
+				}
+				seq := binary.BigEndian.Uint64(k)
+				body := v[sha256.Size:]
+				hash := auditHash(prev, body)
+				if seq != last+1 || !bytes.Equal(hash[:], v[:sha256.Size]) {
+					verified = false
+				}
+				last, prev = seq, v[:sha256.Size]
+				if seq < from || len(entries) >= count {
+					continue
+				}
+				var rec auditRecord
+				if e = js.Unmarshal(body, &rec); E.Chk(e) {
+					verified = false
+					continue
+				}
+				t := time.Unix(rec.Time, 0)
+				if (!start.IsZero() && t.Before(start)) || (!end.IsZero() && t.After(end)) {
+					continue
+				}
+				entry := AuditEntry{
+					Seq:      seq,
+					Time:     t,
+					Identity: rec.Identity,
+					Action:   rec.Action,
+					Detail:   rec.Detail,
+					Error:    rec.Error,
+				}
+				copy(entry.Hash[:], v[:sha256.Size])
+				entries = append(entries, entry)
+			}
+			return nil
+		},
+	)
+	return
+}
+
+// auditRequest records an RPC request in the audit log of the wallet if its method changes the wallet, along with the
+// identity of the client that made it and the error it failed with, if any.
+func (w *Wallet) auditRequest(identity string, request *btcjson.Request, result interface{}, jsonErr *btcjson.RPCError) {
+	if _, ok := auditedMethods[request.Method]; !ok {
+		return
+	}
+	var detail string
+	if cmd, e := btcjson.UnmarshalCmd(request); e == nil {
+		detail = auditDetail(cmd, result)
+	}
+	var failure error
+	if jsonErr != nil {
+		failure = jsonErr
+	}
+	if e := w.RecordAudit(identity, request.Method, detail, failure); E.Chk(e) {
+		E.Ln("could not record", request.Method, "request in the audit log:", e)
+	}
+}
+
+// auditDetail describes the parameters and result of an audited request for the audit log. Secrets, such as private
+// keys and passphrases, are left out.
+func auditDetail(cmd interface{}, result interface{}) (detail string) {
+	switch c := cmd.(type) {
+	case *btcjson.AddMultisigAddressCmd:
+		detail = fmt.Sprintf("%d of %s", c.NRequired, strings.Join(c.Keys, ","))
+	case *btcjson.AddPortfolioEntryCmd:
+		detail = fmt.Sprintf("entry %q descriptors %s", c.Name, strings.Join(c.Descriptors, ","))
+	case *btcjson.CreateNewAccountCmd:
+		detail = fmt.Sprintf("account %q", c.Account)
+	case *btcjson.DeleteAddressMetaCmd:
+		detail = "address " + c.Address
+	case *btcjson.DumpPrivKeyCmd:
+		detail = "address " + c.Address
+	case *btcjson.ExportAccountXprvCmd:
+		detail = fmt.Sprintf("account %q plaintext %v", c.Account, c.Plaintext != nil && *c.Plaintext)
+	case *btcjson.ExportPaymentBundleCmd:
+		detail = fmt.Sprintf("account %q requests %d", c.Account, len(c.Requests))
+		if r, ok := result.(btcjson.ExportPaymentBundleResult); ok {
+			detail += " bundle " + r.ID
+		}
+	case *btcjson.FreezeUnspentCmd:
+		outputs := make([]string, len(c.Transactions))
+		for i, input := range c.Transactions {
+			outputs[i] = fmt.Sprintf("%s:%d", input.Txid, input.Vout)
+		}
+		if c.Unfreeze {
+			detail = "unfreeze " + strings.Join(outputs, ",")
+		} else {
+			detail = "freeze " + strings.Join(outputs, ",")
+			if c.Reason != nil {
+				detail += fmt.Sprintf(" reason %q", *c.Reason)
+			}
+		}
+	case *btcjson.ImportCoreWalletCmd:
+		detail = "path " + c.Path
+		if r, ok := result.(btcjson.ImportCoreWalletResult); ok {
+			detail += fmt.Sprintf(
+				" keys %d imported %d duplicates %d labels %d rescan from %d", r.Keys, r.Imported, r.Duplicates,
+				r.Labels, r.RescanFrom,
+			)
+		}
+	case *btcjson.ImportPrivKeyCmd:
+		if c.Label != nil {
+			detail = fmt.Sprintf("label %q", *c.Label)
+		}
+	case *btcjson.ImportScriptPubKeyCmd:
+		detail = "script " + c.Script
+		if c.Label != nil {
+			detail += fmt.Sprintf(" label %q", *c.Label)
+		}
+	case *btcjson.KeyPoolRefillCmd:
+		if c.NewSize != nil {
+			detail = fmt.Sprintf("new size %d", *c.NewSize)
+		}
+	case *btcjson.OverrideDustCmd:
+		outputs := make([]string, len(c.Transactions))
+		for i, input := range c.Transactions {
+			outputs[i] = fmt.Sprintf("%s:%d", input.Txid, input.Vout)
+		}
+		if c.Release {
+			detail = "release " + strings.Join(outputs, ",")
+		} else {
+			detail = "freeze " + strings.Join(outputs, ",")
+		}
+	case *btcjson.RemovePortfolioEntryCmd:
+		detail = fmt.Sprintf("entry %q", c.Name)
+	case *btcjson.ReleaseInvoiceAddressCmd:
+		detail = "address " + c.Address
+	case *btcjson.RenameAccountCmd:
+		detail = fmt.Sprintf("account %q to %q", c.OldAccount, c.NewAccount)
+	case *btcjson.ReserveInvoiceAddressCmd:
+		detail = fmt.Sprintf("account %q", c.Account)
+		if c.Reference != nil && *c.Reference != "" {
+			detail += fmt.Sprintf(" reference %q", *c.Reference)
+		}
+		if r, ok := result.(btcjson.InvoiceReservationResult); ok {
+			detail += fmt.Sprintf(" address %s index %d", r.Address, r.Index)
+		}
+	case *btcjson.SendFromCmd:
+		detail = fmt.Sprintf("from %q to %s amount %v", c.FromAccount, c.ToAddress, c.Amount)
+	case *btcjson.SendManyCmd:
+		addrs := make([]string, 0, len(c.Amounts))
+		for addr := range c.Amounts {
+			addrs = append(addrs, addr)
+		}
+		sort.Strings(addrs)
+		outputs := make([]string, len(addrs))
+		for i, addr := range addrs {
+			outputs[i] = fmt.Sprintf("%s amount %v", addr, c.Amounts[addr])
+		}
+		detail = fmt.Sprintf("from %q to %s", c.FromAccount, strings.Join(outputs, ", ")) + feeDetail(c.FeeOptions) +
+			idempotencyDetail(c.IdempotencyKey)
+	case *btcjson.SendToAddressCmd:
+		detail = fmt.Sprintf("to %s amount %v", c.Address, c.Amount) + feeDetail(c.FeeOptions) +
+			idempotencyDetail(c.IdempotencyKey)
+	case *btcjson.SetAddressMetaCmd:
+		detail = "address " + c.Address
+		if c.Meta.State != nil {
+			detail += " state " + *c.Meta.State
+		}
+	case *btcjson.SetInvoiceIssuanceCmd:
+		detail = fmt.Sprintf("account %q enable %v", c.Account, c.Enable)
+	case *btcjson.SetTxFeeCmd:
+		detail = fmt.Sprintf("fee %v", c.Amount)
+	case *btcjson.SweepAccountCmd:
+		detail = fmt.Sprintf("account %q to %s", c.Account, c.Address)
+		if c.Reserve != nil && *c.Reserve != 0 {
+			detail += fmt.Sprintf(" reserve %v", *c.Reserve)
+		}
+		if c.DryRun != nil && *c.DryRun {
+			detail += " dry run"
+		}
+	case *btcjson.SweepPrivKeyCmd:
+		if c.Account != nil {
+			detail = fmt.Sprintf("to account %q", *c.Account)
+		}
+		if r, ok := result.(btcjson.SweepPrivKeyResult); ok {
+			detail = fmt.Sprintf("from %s %s amount %v", r.Address, detail, r.Amount)
+			if r.TxID != "" {
+				detail += " txid " + r.TxID
+			} else {
+				detail += " dry run"
+			}
+		}
+	}
+	switch cmd.(type) {
+	case *btcjson.SendFromCmd, *btcjson.SendManyCmd, *btcjson.SendToAddressCmd:
+		if txid, ok := result.(string); ok {
+			detail += " txid " + txid
+		}
+	}
+	return
+}
+
+// feeDetail describes the fee options given with a send for the audit log.
+func feeDetail(opts *btcjson.SendFeeOptions) string {
+	switch {
+	case opts == nil:
+		return ""
+	case opts.FeeRate != nil:
+		return fmt.Sprintf(" fee rate %v", *opts.FeeRate)
+	case opts.Fee != nil:
+		return fmt.Sprintf(" fee %v", *opts.Fee)
+	}
+	return ""
+}
+
+// idempotencyDetail describes the idempotency key given with a send for the audit log.
+func idempotencyDetail(key *string) string {
+	if key == nil || *key == "" {
+		return ""
+	}
+	return fmt.Sprintf(" idempotency key %q", *key)
+}
@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestAuditLog ensures audit log entries are returned in order within the requested range, and that changing an entry
+// breaks the hash chain.
+func TestAuditLog(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	entries, verified, e := w.AuditLog(1, 100, time.Time{}, time.Time{})
+	if e != nil || len(entries) != 0 || !verified {
+		t.Fatalf("empty log: got %d entries, verified %v, error %v", len(entries), verified, e)
+	}
+	actions := []string{"createnewaccount", "sendtoaddress", "renameaccount", "settxfee"}
+	for i, action := range actions {
+		var failure error
+		if i == 1 {
+			failure = errors.New("insufficient funds")
+		}
+		if e = w.RecordAudit("user@127.0.0.1:1234", action, "detail", failure); e != nil {
+			t.Fatalf("RecordAudit: %v", e)
+		}
+	}
+	if entries, verified, e = w.AuditLog(2, 2, time.Time{}, time.Time{}); e != nil || !verified {
+		t.Fatalf("got verified %v, error %v", verified, e)
+	}
+	if len(entries) != 2 || entries[0].Seq != 2 || entries[1].Seq != 3 {
+		t.Fatalf("got entries %+v, want entries 2 and 3", entries)
+	}
+	if entries[0].Action != actions[1] || entries[0].Error != "insufficient funds" ||
+		entries[0].Identity != "user@127.0.0.1:1234" {
+		t.Errorf("got entry %+v", entries[0])
+	}
+	if entries, _, e = w.AuditLog(1, 100, time.Now().Add(time.Hour), time.Time{}); e != nil || len(entries) != 0 {
+		t.Fatalf("got %d entries after the last entry, error %v", len(entries), e)
+	}
+	// Change the detail of an entry, keeping its hash.
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(auditNamespaceKey)
+			v := bytes.Replace(ns.Get(auditKey(2)), []byte(`"detail":"detail"`), []byte(`"detail":"DETAIL"`), 1)
+			return ns.Put(auditKey(2), v)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, verified, e = w.AuditLog(1, 100, time.Time{}, time.Time{}); e != nil || verified {
+		t.Fatalf("changed log: got verified %v, error %v", verified, e)
+	}
+}
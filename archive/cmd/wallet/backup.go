@@ -0,0 +1,191 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/backup"
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/waddrmgr"
+)
+
+var (
+	// ErrNoBackupTargets is returned when the wallet is backed up without any backup targets configured.
+	ErrNoBackupTargets = errors.New("no wallet backup targets are configured")
+	// ErrBackupKeyUnknown is returned when the wallet is backed up before it was unlocked, as the key the backups are
+	// encrypted with is derived from the private key of its first account.
+	ErrBackupKeyUnknown = errors.New("the wallet must be unlocked once after it is started before it can be backed up")
+)
+
+// BackupStatus is the outcome of the latest backup of the wallet to a remote backup target.
+type BackupStatus struct {
+	// Target is the URL of the target without its credentials.
+	Target string
+	// Time is when the wallet was last backed up to the target, zero if it has not been since it was started.
+	Time time.Time
+	// Size is the size in bytes of the last backup.
+	Size int
+	// Error is why the latest attempt to back up the wallet to the target failed, empty if it succeeded.
+	Error string
+}
+
+// backupState is the key the wallet is backed up with and the outcome of the backups to each target. The zero value
+// is ready to use.
+type backupState struct {
+	// run is held while a backup is made, so backups started by the schedule and by request do not overlap.
+	run sync.Mutex
+	mtx sync.Mutex
+	key *backup.Key
+	// sent is the digest of the wallet database last backed up to each target, by URL, so it is only backed up again
+	// when it has changed.
+	sent   map[string][32]byte
+	status map[string]*BackupStatus
+}
+
+// backupTargets returns the URLs of the targets the wallet is backed up to.
+func (w *Wallet) backupTargets() []string {
+	if w.PodConfig == nil || w.PodConfig.WalletBackupTargets == nil {
+		return nil
+	}
+	return w.PodConfig.WalletBackupTargets.V()
+}
+
+// backupInterval returns how often the wallet is backed up when it has changed.
+func (w *Wallet) backupInterval() time.Duration {
+	if w.PodConfig == nil || w.PodConfig.WalletBackupInterval == nil || w.PodConfig.WalletBackupInterval.V() <= 0 {
+		return constant.DefaultWalletBackupInterval
+	}
+	return w.PodConfig.WalletBackupInterval.V()
+}
+
+// deriveBackupKey derives the key the wallet is backed up with from the private key of its first account when it is
+// unlocked, and backs the wallet up if it has changed. The key is kept until the wallet is stopped, so the wallet is
+// also backed up while it is locked.
+func (w *Wallet) deriveBackupKey() {
+	if len(w.backupTargets()) == 0 {
+		return
+	}
+	w.backups.mtx.Lock()
+	known := w.backups.key != nil
+	w.backups.mtx.Unlock()
+	if !known {
+		xprv, e := w.AccountPrivKey(waddrmgr.KeyScopeBIP0044, waddrmgr.DefaultAccountNum)
+		if e != nil {
+			// A watching only wallet has no private keys to derive the key from, and can be restored from its
+			// extended public keys instead.
+			D.Ln("not backing up the wallet:", e)
+			return
+		}
+		var account *hdkeychain.ExtendedKey
+		if account, e = hdkeychain.NewKeyFromString(xprv); E.Chk(e) {
+			return
+		}
+		var key *backup.Key
+		key, e = backup.NewKey(account)
+		account.Zero()
+		if E.Chk(e) {
+			return
+		}
+		w.backups.mtx.Lock()
+		w.backups.key = key
+		w.backups.mtx.Unlock()
+	}
+	if _, e := w.BackupRemote(false); E.Chk(e) {
+	}
+}
+
+// backupScheduler backs the wallet up to the backup targets at the backup interval if it has changed.
+func (w *Wallet) backupScheduler() {
+	defer w.wg.Done()
+	quit := w.quitChan()
+	ticker := time.NewTicker(w.backupInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if len(w.backupTargets()) == 0 {
+				continue
+			}
+			if _, e := w.BackupRemote(false); e != nil && e != ErrBackupKeyUnknown {
+				E.Ln("backing up the wallet failed:", e)
+			}
+		case <-quit.Wait():
+			return
+		}
+	}
+}
+
+// BackupRemote backs the wallet up to each of the backup targets it has changed since it was last backed up to, or to
+// all of them if force is set, and returns the outcome of the latest backup to each target. A failed backup to one
+// target does not stop the others, and shows in its status.
+func (w *Wallet) BackupRemote(force bool) (statuses []BackupStatus, e error) {
+	targets := w.backupTargets()
+	if len(targets) == 0 {
+		return nil, ErrNoBackupTargets
+	}
+	s := &w.backups
+	s.run.Lock()
+	defer s.run.Unlock()
+	s.mtx.Lock()
+	key := s.key
+	s.mtx.Unlock()
+	if key == nil {
+		return nil, ErrBackupKeyUnknown
+	}
+	var db bytes.Buffer
+	if e = w.db.Copy(&db); E.Chk(e) {
+		return
+	}
+	digest := sha256.Sum256(db.Bytes())
+	for _, u := range targets {
+		s.mtx.Lock()
+		sent, ok := s.sent[u]
+		s.mtx.Unlock()
+		if ok && sent == digest && !force {
+			continue
+		}
+		status := BackupStatus{Target: backup.Redact(u)}
+		var target backup.Target
+		if target, e = backup.Open(u); !E.Chk(e) {
+			e = backup.Upload(target, key, db.Bytes())
+		}
+		s.mtx.Lock()
+		if s.status == nil {
+			s.sent = make(map[string][32]byte)
+			s.status = make(map[string]*BackupStatus)
+		}
+		if last := s.status[u]; last != nil {
+			status.Time, status.Size = last.Time, last.Size
+		}
+		if e != nil {
+			status.Error = e.Error()
+			W.Ln("backing up the wallet to", status.Target, "failed:", e)
+		} else {
+			status.Time, status.Size = time.Now(), db.Len()
+			s.sent[u] = digest
+			I.Ln("backed up the wallet to", status.Target)
+		}
+		s.status[u] = &status
+		s.mtx.Unlock()
+	}
+	return w.BackupStatuses(), nil
+}
+
+// BackupStatuses returns the outcome of the latest backup to each of the backup targets, in the order they are
+// configured.
+func (w *Wallet) BackupStatuses() (statuses []BackupStatus) {
+	w.backups.mtx.Lock()
+	defer w.backups.mtx.Unlock()
+	for _, u := range w.backupTargets() {
+		if status := w.backups.status[u]; status != nil {
+			statuses = append(statuses, *status)
+		} else {
+			statuses = append(statuses, BackupStatus{Target: backup.Redact(u)})
+		}
+	}
+	return
+}
@@ -0,0 +1,204 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// BalanceAt is the balance of the wallet as of a block.
+type BalanceAt struct {
+	Height  int32
+	Hash    chainhash.Hash
+	Time    time.Time
+	Balance amt.Amount
+	// Accounts are the balances of the accounts that had a non-zero balance at the block, ordered by scope and account
+	// number. Outputs that pay to no account, such as those of watched scripts, are counted last with an empty Name.
+	Accounts []AccountBalanceAt
+}
+
+// AccountBalanceAt is the balance of an account as of a block.
+type AccountBalanceAt struct {
+	Scope   waddrmgr.KeyScope
+	Account uint32
+	Name    string
+	Balance amt.Amount
+}
+
+// balanceOwner is the account an output pays to. The zero value is used for outputs that pay to no account.
+type balanceOwner struct {
+	scope   waddrmgr.KeyScope
+	account uint32
+	ok      bool
+}
+
+// balanceReplay adds up the credits and subtracts the debits of the transactions of a range of blocks, in the order of
+// the blocks, to find the balance of the wallet and its accounts at the last of them.
+type balanceReplay struct {
+	owner    func(pkScript []byte) balanceOwner
+	outputs  map[wire.OutPoint]balanceOwner
+	balances map[balanceOwner]amt.Amount
+	total    amt.Amount
+}
+
+// newBalanceReplay returns a balanceReplay that finds the account of an output with owner.
+func newBalanceReplay(owner func(pkScript []byte) balanceOwner) *balanceReplay {
+	return &balanceReplay{
+		owner:    owner,
+		outputs:  make(map[wire.OutPoint]balanceOwner),
+		balances: make(map[balanceOwner]amt.Amount),
+	}
+}
+
+// block replays the transactions of a block. The credits are added before the debits are subtracted as the
+// transactions of a block may spend each other's outputs.
+func (r *balanceReplay) block(details []wtxmgr.TxDetails) {
+	for i := range details {
+		d := &details[i]
+		for _, credit := range d.Credits {
+			o := r.owner(d.MsgTx.TxOut[credit.Index].PkScript)
+			r.outputs[wire.OutPoint{Hash: d.Hash, Index: credit.Index}] = o
+			r.balances[o] += credit.Amount
+			r.total += credit.Amount
+		}
+	}
+	for i := range details {
+		d := &details[i]
+		for _, debit := range d.Debits {
+			prev := d.MsgTx.TxIn[debit.Index].PreviousOutPoint
+			o := r.outputs[prev]
+			delete(r.outputs, prev)
+			r.balances[o] -= debit.Amount
+			r.total -= debit.Amount
+		}
+	}
+}
+
+// BalanceAt returns the balance of the wallet and its accounts as of the block at the height, found by replaying the
+// credits and debits of the transactions mined up to and including it. Immature coinbase outputs are counted.
+func (w *Wallet) BalanceAt(height int32) (bal *BalanceAt, e error) {
+	chainClient, e := w.requireChainClient()
+	if e != nil {
+		return nil, e
+	}
+	if synced := w.Manager.SyncedTo(); height < 0 || height > synced.Height {
+		return nil, fmt.Errorf("height %d is outside the blocks the wallet is synced to (0 to %d)", height, synced.Height)
+	}
+	var hash *chainhash.Hash
+	if hash, e = chainClient.GetBlockHash(int64(height)); E.Chk(e) {
+		return nil, e
+	}
+	var header *wire.BlockHeader
+	if header, e = chainClient.GetBlockHeader(hash); E.Chk(e) {
+		return nil, e
+	}
+	bal = &BalanceAt{Height: height, Hash: *hash, Time: header.Timestamp}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			r := newBalanceReplay(
+				func(pkScript []byte) (o balanceOwner) {
+					_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+					if e != nil || len(addrs) == 0 {
+						return
+					}
+					var manager *waddrmgr.ScopedKeyManager
+					var account uint32
+					if manager, account, e = w.Manager.AddrAccount(addrmgrNs, addrs[0]); e != nil {
+						return
+					}
+					return balanceOwner{scope: manager.Scope(), account: account, ok: true}
+				},
+			)
+			e = w.TxStore.RangeTransactions(
+				txmgrNs, 0, height, func(details []wtxmgr.TxDetails) (bool, error) {
+					r.block(details)
+					return false, nil
+				},
+			)
+			if e != nil {
+				return e
+			}
+			bal.Balance = r.total
+			for o, balance := range r.balances {
+				if balance == 0 {
+					continue
+				}
+				ab := AccountBalanceAt{Scope: o.scope, Account: o.account, Balance: balance}
+				if o.ok {
+					var manager *waddrmgr.ScopedKeyManager
+					if manager, e = w.Manager.FetchScopedKeyManager(o.scope); e != nil {
+						return e
+					}
+					if ab.Name, e = manager.AccountName(addrmgrNs, o.account); e != nil {
+						return e
+					}
+				}
+				bal.Accounts = append(bal.Accounts, ab)
+			}
+			return nil
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	sort.Slice(
+		bal.Accounts, func(i, j int) bool {
+			a, b := bal.Accounts[i], bal.Accounts[j]
+			if (a.Name == "") != (b.Name == "") {
+				return b.Name == ""
+			}
+			if a.Scope != b.Scope {
+				if a.Scope.Purpose != b.Scope.Purpose {
+					return a.Scope.Purpose < b.Scope.Purpose
+				}
+				return a.Scope.Coin < b.Scope.Coin
+			}
+			return a.Account < b.Account
+		},
+	)
+	return bal, nil
+}
+
+// HeightAtTime returns the height of the last block the wallet is synced to whose timestamp is not after t. Block
+// timestamps are not strictly increasing, so the blocks are searched by halving and a block with a timestamp close to t
+// may be found on either side of it.
+func (w *Wallet) HeightAtTime(t time.Time) (height int32, e error) {
+	chainClient, e := w.requireChainClient()
+	if e != nil {
+		return 0, e
+	}
+	synced := w.Manager.SyncedTo()
+	// after returns whether the block at the height has a timestamp after t.
+	after := func(height int) bool {
+		if e != nil {
+			return true
+		}
+		var hash *chainhash.Hash
+		if hash, e = chainClient.GetBlockHash(int64(height)); E.Chk(e) {
+			return true
+		}
+		var header *wire.BlockHeader
+		if header, e = chainClient.GetBlockHeader(hash); E.Chk(e) {
+			return true
+		}
+		return header.Timestamp.After(t)
+	}
+	first := sort.Search(int(synced.Height)+1, after)
+	if e != nil {
+		return 0, e
+	}
+	if first == 0 {
+		return 0, fmt.Errorf("%v is before the genesis block", t)
+	}
+	return int32(first - 1), nil
+}
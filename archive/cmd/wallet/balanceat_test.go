@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestBalanceReplay ensures replaying blocks adds their credits and takes their debits from the account of the output
+// they spend, including outputs spent in the block they were created in.
+func TestBalanceReplay(t *testing.T) {
+	// The first byte of the scripts is the account they pay to, 0xff pays to no account.
+	r := newBalanceReplay(
+		func(pkScript []byte) balanceOwner {
+			if pkScript[0] == 0xff {
+				return balanceOwner{}
+			}
+			return balanceOwner{scope: waddrmgr.KeyScopeBIP0044, account: uint32(pkScript[0]), ok: true}
+		},
+	)
+	funding := wire.MsgTx{TxOut: []*wire.TxOut{{Value: 5e8, PkScript: []byte{0}}, {Value: 3e8, PkScript: []byte{1}}}}
+	var block1 wtxmgr.TxDetails
+	block1.MsgTx = funding
+	block1.Hash = funding.TxHash()
+	block1.Credits = []wtxmgr.CreditRecord{{Amount: 5e8, Index: 0}, {Amount: 3e8, Index: 1}}
+	// The second block spends the output of account 1 to a payment and change to account 0, and the change again to a
+	// watched script.
+	spend := wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: block1.Hash, Index: 1}}},
+		TxOut: []*wire.TxOut{{Value: 1e8, PkScript: []byte{9}}, {Value: 1.5e8, PkScript: []byte{0}}},
+	}
+	var spendDetails wtxmgr.TxDetails
+	spendDetails.MsgTx = spend
+	spendDetails.Hash = spend.TxHash()
+	spendDetails.Credits = []wtxmgr.CreditRecord{{Amount: 1.5e8, Index: 1, Change: true}}
+	spendDetails.Debits = []wtxmgr.DebitRecord{{Amount: 3e8, Index: 0}}
+	watched := wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: spendDetails.Hash, Index: 1}}},
+		TxOut: []*wire.TxOut{{Value: 1.4e8, PkScript: []byte{0xff}}},
+	}
+	var watchedDetails wtxmgr.TxDetails
+	watchedDetails.MsgTx = watched
+	watchedDetails.Hash = watched.TxHash()
+	watchedDetails.Credits = []wtxmgr.CreditRecord{{Amount: 1.4e8, Index: 0}}
+	watchedDetails.Debits = []wtxmgr.DebitRecord{{Amount: 1.5e8, Index: 0}}
+	r.block([]wtxmgr.TxDetails{block1})
+	r.block([]wtxmgr.TxDetails{watchedDetails, spendDetails})
+	if want := amt.Amount(5e8 + 1.4e8); r.total != want {
+		t.Fatalf("got total %v, want %v", r.total, want)
+	}
+	for o, want := range map[balanceOwner]amt.Amount{
+		{scope: waddrmgr.KeyScopeBIP0044, account: 0, ok: true}: 5e8,
+		{scope: waddrmgr.KeyScopeBIP0044, account: 1, ok: true}: 0,
+		{}: 1.4e8,
+	} {
+		if got := r.balances[o]; got != want {
+			t.Fatalf("got balance %v for %+v, want %v", got, o, want)
+		}
+	}
+}
@@ -32,53 +32,6 @@ func (w *Wallet) handleChainNotifications() {
 			}
 		}
 	}
-	catchUpHashes := func(
-		w *Wallet, client chainclient.Interface,
-		height int32,
-	) (e error) {
-		// TODO(aakselrod): There's a race condition here, which happens when a reorg occurs between the rescanProgress
-		//  notification and the last GetBlockHash call. The solution when using pod is to make pod send blockconnected
-		//  notifications with each block the way Neutrino does, and get rid of the loop. The other alternative is to
-		//  check the final hash and, if it doesn't match the original hash returned by the notification, to roll back
-		//  and restart the rescan.
-		I.F(
-			"handleChainNotifications: catching up block hashes to height %d, this might take a while", height,
-		)
-		e = walletdb.Update(
-			w.db, func(tx walletdb.ReadWriteTx) (e error) {
-				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
-				startBlock := w.Manager.SyncedTo()
-				for i := startBlock.Height + 1; i <= height; i++ {
-					hash, e := client.GetBlockHash(int64(i))
-					if e != nil {
-						return e
-					}
-					header, e := chainClient.GetBlockHeader(hash)
-					if e != nil {
-						return e
-					}
-					bs := wm.BlockStamp{
-						Height:    i,
-						Hash:      *hash,
-						Timestamp: header.Timestamp,
-					}
-					e = w.Manager.SetSyncedTo(ns, &bs)
-					if e != nil {
-						return e
-					}
-				}
-				return nil
-			},
-		)
-		if e != nil {
-			E.F(
-				"failed to update address manager sync state for height %d: %v",
-				height, e,
-			)
-		}
-		I.Ln("done catching up block hashes")
-		return e
-	}
 	for {
 		select {
 		case n, ok := <-chainClient.Notifications():
@@ -134,10 +87,13 @@ func (w *Wallet) handleChainNotifications() {
 					)
 				}
 				notificationName = "filteredblockconnected"
+			case chainclient.TxExpired:
+				w.handleExpiredTx(&n.Hash)
+				notificationName = "txexpired"
 			// The following require some database maintenance, but also need to be reported to the wallet's rescan
 			// goroutine.
 			case *chainclient.RescanProgress:
-				e = catchUpHashes(w, chainClient, n.Height)
+				e = w.catchUpHashes(chainClient, n.Height)
 				notificationName = "rescanprogress"
 				select {
 				case w.rescanNotifications <- n:
@@ -145,7 +101,7 @@ func (w *Wallet) handleChainNotifications() {
 					return
 				}
 			case *chainclient.RescanFinished:
-				e = catchUpHashes(w, chainClient, n.Height)
+				e = w.catchUpHashes(chainClient, n.Height)
 				notificationName = "rescanprogress"
 				w.SetChainSynced(true)
 				select {
@@ -154,7 +110,10 @@ func (w *Wallet) handleChainNotifications() {
 					return
 				}
 			}
-			if e != nil {
+			if e == nil {
+				// Wake the clients of the notification journal for the events the notification added to it.
+				w.journal.notify()
+			} else {
 				// On out-of-sync blockconnected notifications, only send a debug message.
 				errStr := "failed to process consensus server " +
 					"notification (name: `%s`, detail: `%v`)"
@@ -187,10 +146,14 @@ func (w *Wallet) connectBlock(dbtx walletdb.ReadWriteTx, b tm.BlockMeta) (e erro
 	if e != nil {
 		return e
 	}
+	if e = appendJournal(dbtx, JournalBlockConnected, &b.Hash, b.Height, nil); E.Chk(e) {
+		return e
+	}
 	// Notify interested clients of the connected block.
 	//
 	// TODO: move all notifications outside of the database transaction.
 	w.NtfnServer.notifyAttachedBlock(dbtx, &b)
+	w.NtfnServer.notifyMaturedCoinbase(dbtx, &b)
 	return nil
 }
 
@@ -202,6 +165,9 @@ func (w *Wallet) disconnectBlock(dbtx walletdb.ReadWriteTx, b tm.BlockMeta) (e e
 	if !w.ChainSynced() {
 		return nil
 	}
+	if e = appendJournal(dbtx, JournalBlockDisconnected, &b.Hash, b.Height, nil); E.Chk(e) {
+		return e
+	}
 	// Disconnect the removed block and all blocks after it if we know about the disconnected block. Otherwise, the
 	// block is in the future.
 	if b.Height <= w.Manager.SyncedTo().Height {
@@ -244,12 +210,44 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *tm.TxRecord, bloc
 	// At the moment all notified transactions are assumed to actually be relevant. This assumption will not hold true
 	// when SPV support is added, but until then, simply insert the transaction because there should either be one or
 	// more relevant inputs or outputs.
+	//
+	// Unmined transactions double spending a mined one are removed when it is inserted, so they are looked for first.
+	if block != nil {
+		w.findRescanConflicts(txmgrNs, rec, block)
+	}
 	e = w.TxStore.InsertTx(txmgrNs, rec, block)
 	if e != nil {
 		return e
 	}
+	if e = w.TxStore.SpendWatchedCredits(txmgrNs, rec, block); E.Chk(e) {
+		return e
+	}
+	if e = addPortfolioTx(dbtx, rec, block); E.Chk(e) {
+		return e
+	}
 	// Chk every output to determine whether it is controlled by a wallet key. If so, mark the output as a credit.
+	// Outputs paying to watched scripts are recorded apart from the credits.
 	for i, output := range rec.MsgTx.TxOut {
+		if w.Manager.IsWatchedScript(addrmgrNs, output.PkScript) {
+			if e = w.TxStore.AddWatchedCredit(txmgrNs, rec, block, uint32(i)); E.Chk(e) {
+				return e
+			}
+			continue
+		}
+		// Outputs paying to addresses of the wallet with a standard script are found in the script index, without
+		// decoding their addresses.
+		if loc, ok := w.Manager.LookupScript(addrmgrNs, output.PkScript); ok {
+			if e = w.TxStore.AddCredit(txmgrNs, rec, block, uint32(i), loc.Internal()); E.Chk(e) {
+				return e
+			}
+			if e = w.Manager.MarkScriptUsed(addrmgrNs, loc); E.Chk(e) {
+				return e
+			}
+			continue
+		}
+		if wm.IsIndexedScript(output.PkScript) {
+			continue
+		}
 		var addrs []btcaddr.Address
 		_, addrs, _, e = txscript.ExtractPkScriptAddrs(
 			output.PkScript,
@@ -284,6 +282,14 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *tm.TxRecord, bloc
 			}
 		}
 	}
+	if block == nil {
+		e = appendJournal(dbtx, JournalTx, nil, -1, &rec.Hash)
+	} else {
+		e = appendJournal(dbtx, JournalTx, &block.Hash, block.Height, &rec.Hash)
+	}
+	if E.Chk(e) {
+		return e
+	}
 	// Send notification of mined or unmined transaction to any interested clients.
 	//
 	// TODO: Avoid the extra db hits.
@@ -299,6 +305,8 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *tm.TxRecord, bloc
 		//  chain.RelevantTx notification from the chain backend.
 		if details != nil {
 			w.NtfnServer.notifyUnminedTransaction(dbtx, details)
+			// Failing to look for dust must not keep the transaction from being recorded.
+			_ = w.detectDust(dbtx, details)
 		}
 	} else {
 		details, e := w.TxStore.UniqueTxDetails(txmgrNs, &rec.Hash, &block.Block)
@@ -308,6 +316,7 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *tm.TxRecord, bloc
 		// We'll only notify the transaction if it was found within the wallet's set of confirmed transactions.
 		if details != nil {
 			w.NtfnServer.notifyMinedTransaction(dbtx, details, block)
+			_ = w.detectDust(dbtx, details)
 		}
 	}
 	return nil
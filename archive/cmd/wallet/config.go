@@ -6,4 +6,6 @@ type Options struct {
 	Password            string
 	MaxPOSTClients      int64
 	MaxWebsocketClients int64
+	// CompatLegacy serves requests as the legacy Parallelcoin daemon did.
+	CompatLegacy bool
 }
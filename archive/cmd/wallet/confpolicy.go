@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// MinConfPolicy is passed as the minimum number of confirmations for a balance or a transaction to use the
+// confirmation policy of the wallet configuration, which may require a different number of confirmations for change
+// outputs than for outputs received from others.
+const MinConfPolicy int32 = -1
+
+// minConfs returns the number of confirmations change outputs and received outputs need to be spendable. For
+// MinConfPolicy these are the MinConfChange and MinConfReceived settings, any other minconf applies to both.
+func (w *Wallet) minConfs(minconf int32) (change, received int32) {
+	if minconf != MinConfPolicy {
+		return minconf, minconf
+	}
+	change, received = constant.DefaultMinConfChange, constant.DefaultMinConfReceived
+	if w.PodConfig == nil {
+		return
+	}
+	if w.PodConfig.MinConfChange != nil {
+		change = int32(w.PodConfig.MinConfChange.V())
+	}
+	if w.PodConfig.MinConfReceived != nil {
+		received = int32(w.PodConfig.MinConfReceived.V())
+	}
+	return
+}
+
+// creditConfirmed returns whether an unspent output has the confirmations its kind needs to be spendable at the height
+// curHeight.
+func creditConfirmed(output *wtxmgr.Credit, change, received, curHeight int32) bool {
+	if output.Change {
+		return confirmed(change, output.Height, curHeight)
+	}
+	return confirmed(received, output.Height, curHeight)
+}
@@ -0,0 +1,173 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/corewallet"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// coreBirthdayMargin is how long before the time a key of a Bitcoin Core wallet was made its birthday is taken to be,
+// as the clocks of the wallet and of the miners of the chain may not agree.
+const coreBirthdayMargin = 2 * time.Hour
+
+// CoreWalletImport is the outcome of importing the keys of a Bitcoin Core wallet.
+type CoreWalletImport struct {
+	// Keys is how many keys the wallet has, and Imported is how many of them were not already in this wallet.
+	Keys       int
+	Imported   int
+	Duplicates int
+	// Labels is how many labels of the wallet were set on addresses of this wallet.
+	Labels int
+	// RescanFrom is the block the chain is rescanned from, which is the block before the earliest key was made.
+	RescanFrom waddrmgr.BlockStamp
+}
+
+// ImportCoreWallet imports the private keys of the Bitcoin Core wallet.dat at path into the imported account, along
+// with the labels of their addresses, and rescans the chain from the earliest of their birthdays if rescan is set. The
+// birthday of each key is the block before the time it was made, or the genesis block if its wallet did not record it,
+// and the birthday of this wallet is moved back to the earliest of them. The passphrase is only used if the keys of the
+// wallet are encrypted, and this wallet must be unlocked.
+func (w *Wallet) ImportCoreWallet(path string, passphrase []byte, rescan bool) (imp CoreWalletImport, e error) {
+	var core *corewallet.Wallet
+	if core, e = corewallet.ReadFile(path, passphrase, w.chainParams); E.Chk(e) {
+		return
+	}
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	imp.Keys = len(core.Keys)
+	stamps := make([]waddrmgr.BlockStamp, len(core.Keys))
+	// the keys are sorted by the time they were made, so the block of each is at or after the block of the one before
+	// it, and the first is the earliest
+	var from int32
+	for i, k := range core.Keys {
+		var bs *waddrmgr.BlockStamp
+		if bs, e = blockStampAt(chainClient, k.Created, from); E.Chk(e) {
+			return
+		}
+		stamps[i], from = *bs, bs.Height
+	}
+	if len(stamps) > 0 {
+		imp.RescanFrom = stamps[0]
+	} else {
+		imp.RescanFrom = waddrmgr.BlockStamp{Hash: *w.chainParams.GenesisHash}
+	}
+	var addrs []btcaddr.Address
+	var props *waddrmgr.AccountProperties
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			for i, k := range core.Keys {
+				var wif *util.WIF
+				if wif, e = util.NewWIF(k.PrivKey, w.chainParams, k.Compressed); E.Chk(e) {
+					return
+				}
+				var maddr waddrmgr.ManagedPubKeyAddress
+				if maddr, e = manager.ImportPrivateKey(addrmgrNs, wif, &stamps[i]); e != nil {
+					if waddrmgr.IsError(e, waddrmgr.ErrDuplicateAddress) {
+						imp.Duplicates++
+						continue
+					}
+					return
+				}
+				addrs = append(addrs, maddr.Address())
+				imp.Imported++
+			}
+			if props, e = manager.AccountProperties(addrmgrNs, waddrmgr.ImportedAddrAccount); E.Chk(e) {
+				return
+			}
+			if len(addrs) > 0 && imp.RescanFrom.Timestamp.Before(w.Manager.Birthday()) {
+				return w.Manager.SetBirthday(addrmgrNs, imp.RescanFrom.Timestamp)
+			}
+			return
+		},
+	)
+	if e != nil {
+		return
+	}
+	for _, k := range core.Keys {
+		if k.Label == "" {
+			continue
+		}
+		label := k.Label
+		if _, e = w.SetAddressMeta(k.Address, AddressMetaUpdate{Label: &label}); E.Chk(e) {
+			W.Ln("could not set the label of", k.Address.EncodeAddress(), "imported from", path, e)
+			continue
+		}
+		imp.Labels++
+	}
+	e = nil
+	if len(addrs) > 0 {
+		if rescan {
+			// the rescan is not waited for, as its outcome is logged where it is run
+			_ = w.SubmitRescan(&RescanJob{Addrs: addrs, BlockStamp: imp.RescanFrom})
+		} else if e = chainClient.NotifyReceived(addrs); E.Chk(e) {
+			return
+		}
+		w.NtfnServer.notifyAccountProperties(props)
+	}
+	I.F("imported %d of the %d keys of %s", imp.Imported, imp.Keys, path)
+	return
+}
+
+// blockStampAt returns the last block of the chain made at least coreBirthdayMargin before t, searching from the
+// height from, or the block at that height if t is zero or no later block is early enough.
+func blockStampAt(chainClient chainclient.Interface, t time.Time, from int32) (bs *waddrmgr.BlockStamp, e error) {
+	var best int32
+	if _, best, e = chainClient.GetBestBlock(); E.Chk(e) {
+		return
+	}
+	if from > best {
+		from = best
+	}
+	height := from
+	if !t.IsZero() {
+		t = t.Add(-coreBirthdayMargin)
+		var searchErr error
+		// the first block after from made after t, less one
+		n := sort.Search(
+			int(best-from), func(i int) bool {
+				if searchErr != nil {
+					return true
+				}
+				hash, e := chainClient.GetBlockHash(int64(from) + int64(i) + 1)
+				if e != nil {
+					searchErr = e
+					return true
+				}
+				header, e := chainClient.GetBlockHeader(hash)
+				if e != nil {
+					searchErr = e
+					return true
+				}
+				return header.Timestamp.After(t)
+			},
+		)
+		if e = searchErr; E.Chk(e) {
+			return
+		}
+		height = from + int32(n)
+	}
+	var hash *chainhash.Hash
+	if hash, e = chainClient.GetBlockHash(int64(height)); E.Chk(e) {
+		return
+	}
+	var header *wire.BlockHeader
+	if header, e = chainClient.GetBlockHeader(hash); E.Chk(e) {
+		return
+	}
+	return &waddrmgr.BlockStamp{Height: height, Hash: *hash, Timestamp: header.Timestamp}, nil
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainclient"
-	"sort"
+	"github.com/p9c/pod/pkg/coinselect"
 
 	ec "github.com/p9c/pod/pkg/ecc"
 	"github.com/p9c/pod/pkg/txauthor"
@@ -17,38 +18,20 @@ import (
 	"github.com/p9c/pod/pkg/wtxmgr"
 )
 
-// byAmount defines the methods needed to satisify sort.Interface to txsort credits by their output amount.
-type byAmount []wtxmgr.Credit
-
-func (s byAmount) Len() int           { return len(s) }
-func (s byAmount) Less(i, j int) bool { return s[i].Amount < s[j].Amount }
-func (s byAmount) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-
+// makeInputSource returns an input source spending the eligible credits. The largest are picked first, which is only
+// done for compatibility with previous tx creation code, not because it's a good idea; the strategies of the
+// coinselect package can be compared with it by replaying recorded UTXO sets and payments.
 func makeInputSource(eligible []wtxmgr.Credit) txauthor.InputSource {
-	// Pick largest outputs first. This is only done for compatibility with previous
-	// tx creation code, not because it's a good idea.
-	sort.Sort(sort.Reverse(byAmount(eligible)))
-	// Current inputs and their total value. These are closed over by the returned
-	// input source and reused across multiple calls.
-	currentTotal := amt.Amount(0)
-	currentInputs := make([]*wire.TxIn, 0, len(eligible))
-	currentScripts := make([][]byte, 0, len(eligible))
-	currentInputValues := make([]amt.Amount, 0, len(eligible))
-	return func(target amt.Amount) (
-		amt.Amount, []*wire.TxIn,
-		[]amt.Amount, [][]byte, error,
-	) {
-		for currentTotal < target && len(eligible) != 0 {
-			nextCredit := &eligible[0]
-			eligible = eligible[1:]
-			nextInput := wire.NewTxIn(&nextCredit.OutPoint, nil, nil)
-			currentTotal += nextCredit.Amount
-			currentInputs = append(currentInputs, nextInput)
-			currentScripts = append(currentScripts, nextCredit.PkScript)
-			currentInputValues = append(currentInputValues, nextCredit.Amount)
+	coins := make([]coinselect.Coin, len(eligible))
+	for i := range eligible {
+		coins[i] = coinselect.Coin{
+			OutPoint: eligible[i].OutPoint,
+			Amount:   eligible[i].Amount,
+			PkScript: eligible[i].PkScript,
+			Height:   eligible[i].Height,
 		}
-		return currentTotal, currentInputs, currentInputValues, currentScripts, nil
 	}
+	return coinselect.LargestFirst(coins)
 }
 
 // secretSource is an implementation of txauthor.SecretSource for the wallet's address manager.
@@ -101,57 +84,76 @@ func (s secretSource) GetScript(addr btcaddr.Address) ([]byte, error) {
 // outputs. Previous outputs to reedeem are chosen from the passed account's
 // UTXO set and minconf policy. An additional output may be added to return
 // change to the wallet. An appropriate fee is included based on the wallet's
-// current relay fee. The wallet must be unlocked to create the transaction.
+// current relay fee, or the fee is exactly fee when it is not zero, which must be
+// at least the minimum relay fee for the size of the transaction. The wallet must
+// be unlocked to create the transaction, unless it is signed by a remote signer.
+// A watching-only wallet with no remote signer returns the transaction unsigned,
+// for SendOutputsFee to queue for an external signer.
+// A transaction whose fee comes to more than the MaxTxFee setting is not signed
+// and an ErrRPCHighFee error returned.
+//
+// The chain server is queried before any database transaction is opened, coin
+// selection and signing are done under read transactions, and only the change
+// address lease takes a write transaction, so that read-only queries are not
+// blocked for the duration of the send. The change address is released again if
+// the transaction cannot be signed.
 func (w *Wallet) txToOutputs(
 	outputs []*wire.TxOut, account uint32,
-	minconf int32, feeSatPerKb amt.Amount,
+	minconf int32, feeSatPerKb, fee amt.Amount,
 ) (tx *txauthor.AuthoredTx, e error) {
-	var chainClient chainclient.Interface
-	if chainClient, e = w.requireChainClient(); E.Chk(e) {
-		return nil, e
-	}
-	e = walletdb.Update(
-		w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
-			addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
-			// Get current block's height and hash.
-			var bs *waddrmgr.BlockStamp
-			if bs, e = chainClient.BlockStamp(); E.Chk(e) {
-				return
-			}
-			var eligible []wtxmgr.Credit
-			if eligible, e = w.findEligibleOutputs(dbtx, account, minconf, bs); E.Chk(e) {
-				return
-			}
-			inputSource := makeInputSource(eligible)
-			changeSource := func() (b []byte, e error) {
-				// Derive the change output script. As a hack to allow spending from the
-				// imported account, change addresses are created from account 0.
-				var changeAddr btcaddr.Address
-				if account == waddrmgr.ImportedAddrAccount {
-					changeAddr, e = w.newChangeAddress(addrmgrNs, 0)
-				} else {
-					changeAddr, e = w.newChangeAddress(addrmgrNs, account)
-				}
-				if E.Chk(e) {
-					return
-				}
-				return txscript.PayToAddrScript(changeAddr)
-			}
-			if tx, e = txauthor.NewUnsignedTransaction(outputs, feeSatPerKb, inputSource, changeSource); E.Chk(e) {
+	changeSource := func() (b []byte, e error) {
+		// Derive the change output script. As a hack to allow spending from the
+		// imported account, change addresses are created from account 0.
+		changeAccount := account
+		if account == waddrmgr.ImportedAddrAccount {
+			changeAccount = 0
+		}
+		var changeAddr btcaddr.Address
+		e = walletdb.Update(
+			w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+				addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+				changeAddr, e = w.leaseChangeAddress(addrmgrNs, changeAccount)
 				return
-			}
-			// Randomize change position, if change exists, before signing. This doesn't
-			// affect the serialize size, so the change amount will still be valid.
-			if tx.ChangeIndex >= 0 {
-				tx.RandomizeChangePosition()
-			}
-			return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
-		},
-	)
+			},
+		)
+		if E.Chk(e) {
+			return
+		}
+		return txscript.PayToAddrScript(changeAddr)
+	}
+	if tx, e = w.authorTx(outputs, account, minconf, feeSatPerKb, fee, changeSource); E.Chk(e) {
+		return
+	}
+	if e = w.checkMaxTxFee(tx); E.Chk(e) {
+		w.releaseChangeAddress(tx)
+		return
+	}
+	if fee != 0 {
+		if e = w.checkMinRelayFee(tx); E.Chk(e) {
+			w.releaseChangeAddress(tx)
+			return
+		}
+	}
+	tx.Order(w.txOrdering())
+	if w.queuesPSBTs() {
+		return
+	}
+	if w.signer != nil {
+		e = w.signer.SignTx(tx)
+	} else {
+		e = walletdb.View(
+			w.db, func(dbtx walletdb.ReadTx) (e error) {
+				addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+				return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+			},
+		)
+	}
 	if E.Chk(e) {
+		w.releaseChangeAddress(tx)
 		return
 	}
 	if e = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues); E.Chk(e) {
+		w.releaseChangeAddress(tx)
 		return
 	}
 	if tx.ChangeIndex >= 0 && account == waddrmgr.ImportedAddrAccount {
@@ -163,6 +165,69 @@ func (w *Wallet) txToOutputs(
 	}
 	return
 }
+
+// txOrdering returns the order the inputs and outputs of the transactions of the wallet are put in before signing.
+func (w *Wallet) txOrdering() txauthor.Ordering {
+	if w.PodConfig == nil || w.PodConfig.WalletTxOrdering == nil {
+		return txauthor.OrderRandom
+	}
+	return txauthor.Ordering(w.PodConfig.WalletTxOrdering.V())
+}
+
+// authorTx creates an unsigned transaction paying to outputs, with inputs chosen from the outputs of the account that
+// are eligible under the minconf policy to pay for them and the fee, and any change paid to the script returned by
+// changeSource. The fee is exactly fee when it is not zero, and otherwise the fee at feeSatPerKb for the size of the
+// transaction.
+func (w *Wallet) authorTx(
+	outputs []*wire.TxOut, account uint32,
+	minconf int32, feeSatPerKb, fee amt.Amount, changeSource txauthor.ChangeSource,
+) (tx *txauthor.AuthoredTx, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return nil, e
+	}
+	// Get current block's height and hash.
+	var bs *waddrmgr.BlockStamp
+	if bs, e = chainClient.BlockStamp(); E.Chk(e) {
+		return
+	}
+	var eligible []wtxmgr.Credit
+	e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			eligible, e = w.findEligibleOutputs(dbtx, account, minconf, bs)
+			return
+		},
+	)
+	if E.Chk(e) {
+		return
+	}
+	if fee != 0 {
+		return txauthor.NewUnsignedTransactionFee(outputs, fee, feeSatPerKb, makeInputSource(eligible), changeSource)
+	}
+	return txauthor.NewUnsignedTransaction(outputs, feeSatPerKb, makeInputSource(eligible), changeSource)
+}
+
+// checkMaxTxFee returns an error if the fee paid by the transaction is over the
+// MaxTxFee setting, which guards against sending a fee that can only be a
+// mistake, such as from a fee rate given in the wrong unit.
+func (w *Wallet) checkMaxTxFee(tx *txauthor.AuthoredTx) (e error) {
+	maxFee := w.maxTxFee()
+	if maxFee <= 0 {
+		return
+	}
+	fee := tx.TotalInput
+	for _, txOut := range tx.Tx.TxOut {
+		fee -= amt.Amount(txOut.Value)
+	}
+	if fee > maxFee {
+		return btcjson.RPCError{
+			Code:    btcjson.ErrRPCHighFee,
+			Message: fmt.Sprintf("transaction fee of %v is over the maximum of %v set by maxtxfee", fee, maxFee),
+		}
+	}
+	return
+}
+
 func (w *Wallet) findEligibleOutputs(
 	dbtx walletdb.ReadTx,
 	account uint32,
@@ -180,13 +245,20 @@ func (w *Wallet) findEligibleOutputs(
 	//  filters requires matching the output script to the desired
 	//  account, this change depends on making wtxmgr a waddrmgr dependancy and
 	//  requesting unspent outputs for a single account.
+	// Outputs paid to time locked scripts are only spent by SweepTimeLocked, which sets the lock time and sequence
+	// their locks are checked against.
+	var timeLocked map[string][]byte
+	if timeLocked, e = w.timeLockScripts(dbtx); E.Chk(e) {
+		return nil, e
+	}
+	changeConf, receivedConf := w.minConfs(minconf)
 	eligible := make([]wtxmgr.Credit, 0, len(unspent))
 	for i := range unspent {
 		output := &unspent[i]
-		// Only include this output if it meets the required number of confirmations.
-		// Coinbase transactions must have have reached maturity before their outputs
-		// may be spent.
-		if !confirmed(minconf, output.Height, bs.Height) {
+		// Only include this output if it meets the required number of confirmations
+		// for change or received outputs. Coinbase transactions must have have
+		// reached maturity before their outputs may be spent.
+		if !creditConfirmed(output, changeConf, receivedConf, bs.Height) {
 			continue
 		}
 		if output.FromCoinBase {
@@ -195,8 +267,9 @@ func (w *Wallet) findEligibleOutputs(
 				continue
 			}
 		}
-		// Locked unspent outputs are skipped.
-		if w.LockedOutpoint(output.OutPoint) {
+		// Locked, frozen and time locked unspent outputs are skipped.
+		if w.LockedOutpoint(output.OutPoint) || w.FrozenOutpoint(output.OutPoint) ||
+			timeLockScript(timeLocked, output.PkScript) != nil {
 			continue
 		}
 		// Only include the output if it is associated with the passed account.
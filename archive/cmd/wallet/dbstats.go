@@ -0,0 +1,166 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// DefaultDBStatsLargest is the number of largest keys of each bucket reported by default.
+const DefaultDBStatsLargest = 5
+
+// dbStatsNamespaces are the top level buckets of the wallet database. They can not be listed through walletdb, so they
+// are named here.
+var dbStatsNamespaces = [][]byte{waddrmgrNamespaceKey, wtxmgrNamespaceKey, auditNamespaceKey}
+
+// BucketStats is the space used by a bucket of the wallet database.
+type BucketStats struct {
+	// Path is the keys of the bucket and the buckets it is nested in, separated by slashes.
+	Path string
+	// Keys and Buckets are the numbers of key/value pairs and nested buckets directly in the bucket.
+	Keys    int
+	Buckets int
+	// KeyBytes and ValueBytes are the sizes of the keys and values directly in the bucket, and TotalBytes also
+	// includes the nested buckets.
+	KeyBytes   int64
+	ValueBytes int64
+	TotalBytes int64
+	// Largest are the key/value pairs directly in the bucket with the largest sizes, largest first.
+	Largest []KeyStats
+}
+
+// KeyStats is the size of a key/value pair of the wallet database.
+type KeyStats struct {
+	Key   string
+	Bytes int
+}
+
+// DBStats walks the namespaces of the wallet database and returns the space used by each bucket, with the largest
+// key/value pairs of each, in depth first order.
+func DBStats(db walletdb.DB, largest int) (stats []BucketStats, e error) {
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			for _, ns := range dbStatsNamespaces {
+				if b := tx.ReadBucket(ns); b != nil {
+					if _, e = bucketStats(&stats, dbStatsKeyName(ns), b, largest); E.Chk(e) {
+						return
+					}
+				}
+			}
+			return
+		},
+	)
+	return
+}
+
+// DBStatsFile opens the wallet database at path and returns the space used by each of its buckets. The database file is
+// locked by a running wallet, so the wallet must not be running. The running wallet reports the same with the
+// walletdbstats RPC.
+func DBStatsFile(path string, largest int) (stats []BucketStats, e error) {
+	if _, e = os.Stat(path); E.Chk(e) {
+		return
+	}
+	var db walletdb.DB
+	if db, e = walletdb.Open("bdb", path); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := db.Close(); E.Chk(e) {
+		}
+	}()
+	return DBStats(db, largest)
+}
+
+// bucketStats appends the stats of bucket b at path and the buckets nested in it to stats, and returns the total size
+// of b.
+func bucketStats(stats *[]BucketStats, path string, b walletdb.ReadBucket, largest int) (total int64, e error) {
+	i := len(*stats)
+	*stats = append(*stats, BucketStats{Path: path})
+	s := BucketStats{Path: path}
+	var nested [][]byte
+	e = b.ForEach(
+		func(k, v []byte) (e error) {
+			s.KeyBytes += int64(len(k))
+			if v == nil && b.NestedReadBucket(k) != nil {
+				s.Buckets++
+				nested = append(nested, append([]byte{}, k...))
+				return
+			}
+			s.Keys++
+			s.ValueBytes += int64(len(v))
+			s.Largest = addLargest(s.Largest, KeyStats{Key: dbStatsKeyName(k), Bytes: len(k) + len(v)}, largest)
+			return
+		},
+	)
+	if E.Chk(e) {
+		return
+	}
+	s.TotalBytes = s.KeyBytes + s.ValueBytes
+	for _, k := range nested {
+		var n int64
+		if n, e = bucketStats(stats, path+"/"+dbStatsKeyName(k), b.NestedReadBucket(k), largest); E.Chk(e) {
+			return
+		}
+		s.TotalBytes += n
+	}
+	(*stats)[i] = s
+	return s.TotalBytes, nil
+}
+
+// addLargest adds k to the largest key/value pairs in largest first order if it is one of the n largest.
+func addLargest(largest []KeyStats, k KeyStats, n int) []KeyStats {
+	if n <= 0 || (len(largest) == n && k.Bytes <= largest[n-1].Bytes) {
+		return largest
+	}
+	i := sort.Search(
+		len(largest), func(i int) bool {
+			return largest[i].Bytes < k.Bytes
+		},
+	)
+	if len(largest) < n {
+		largest = append(largest, KeyStats{})
+	}
+	copy(largest[i+1:], largest[i:])
+	largest[i] = k
+	return largest
+}
+
+// dbStatsKeyName returns a key of the wallet database as text if it is printable, and as hex otherwise.
+func dbStatsKeyName(k []byte) string {
+	for _, c := range k {
+		if c < 0x20 || c > 0x7e || c == '/' {
+			return hex.EncodeToString(k)
+		}
+	}
+	return string(k)
+}
+
+// WriteDBStats writes a table of the space used by the buckets of the wallet database to w.
+func WriteDBStats(w io.Writer, stats []BucketStats) (e error) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	if _, e = fmt.Fprintln(tw, "keys\tbuckets\tkey bytes\tvalue bytes\ttotal bytes\t\tbucket"); E.Chk(e) {
+		return
+	}
+	for _, s := range stats {
+		largest := make([]string, len(s.Largest))
+		for i, k := range s.Largest {
+			largest[i] = fmt.Sprintf("%s (%d)", k.Key, k.Bytes)
+		}
+		line := fmt.Sprintf(
+			"%d\t%d\t%d\t%d\t%d\t\t%s", s.Keys, s.Buckets, s.KeyBytes, s.ValueBytes, s.TotalBytes, s.Path,
+		)
+		if len(largest) > 0 {
+			line += "  largest: " + strings.Join(largest, ", ")
+		}
+		if _, e = fmt.Fprintln(tw, line); E.Chk(e) {
+			return
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestDBStats ensures the bucket stats of the wallet database count the keys and bytes of each bucket and its nested
+// buckets, and keep the largest keys in order.
+func TestDBStats(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	e := walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns, e := tx.CreateTopLevelBucket(wtxmgrNamespaceKey)
+			if e != nil {
+				return e
+			}
+			for _, kv := range [][2]string{{"a", "1"}, {"bb", "22222"}, {"c", "333"}} {
+				if e = ns.Put([]byte(kv[0]), []byte(kv[1])); e != nil {
+					return e
+				}
+			}
+			nested, e := ns.CreateBucket([]byte{0x00, 0x01})
+			if e != nil {
+				return e
+			}
+			return nested.Put([]byte("key"), bytes.Repeat([]byte{1}, 10))
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	stats, e := DBStats(db, 2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("got %d buckets, want 2: %+v", len(stats), stats)
+	}
+	ns, nested := stats[0], stats[1]
+	if ns.Path != "wtxmgr" || ns.Keys != 3 || ns.Buckets != 1 || ns.KeyBytes != 6 || ns.ValueBytes != 9 ||
+		ns.TotalBytes != 28 {
+		t.Errorf("got namespace stats %+v", ns)
+	}
+	if len(ns.Largest) != 2 || ns.Largest[0] != (KeyStats{"bb", 7}) || ns.Largest[1] != (KeyStats{"c", 4}) {
+		t.Errorf("got largest keys %+v", ns.Largest)
+	}
+	if nested.Path != "wtxmgr/0001" || nested.Keys != 1 || nested.TotalBytes != 13 {
+		t.Errorf("got nested bucket stats %+v", nested)
+	}
+	var buf bytes.Buffer
+	if e = WriteDBStats(&buf, stats); e != nil {
+		t.Fatal(e)
+	}
+	if !strings.Contains(buf.String(), "wtxmgr/0001") {
+		t.Errorf("table is missing the nested bucket:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,305 @@
+package wallet
+
+import (
+	"sync"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// When a wallet is restored from its seed only the default account of each key scope is recovered by the address
+// recovery in syncWithChain. Account discovery finds the other accounts the seed was used for. Accounts are derived in
+// order in every default key scope, starting after the last account the wallet has, and the compact filters of the
+// blocks since the wallet birthday are searched for their addresses. Each account that was used is added to the wallet
+// along with its addresses up to the last one used, and the search stops after AccountDiscoveryGap accounts in a row are
+// found empty.
+//
+// Account keys are hardened, so the wallet must be unlocked to derive them. If it is locked when the initial sync
+// finishes, discovery waits for the wallet to be unlocked.
+
+// accountDiscovery is a pending discovery of the accounts used in a range of blocks.
+type accountDiscovery struct {
+	from, to int32
+}
+
+// discoveryState holds the account discovery that is waiting for the wallet to be unlocked.
+type discoveryState struct {
+	sync.Mutex
+	pending *accountDiscovery
+	running bool
+}
+
+// accountDiscoveryGap returns the configured number of empty accounts after which account discovery stops.
+func (w *Wallet) accountDiscoveryGap() uint32 {
+	if w.PodConfig != nil && w.PodConfig.AccountDiscoveryGap != nil {
+		if gap := w.PodConfig.AccountDiscoveryGap.V(); gap >= 0 {
+			return uint32(gap)
+		}
+	}
+	return constant.DefaultAccountDiscoveryGap
+}
+
+// discoverAccounts looks for accounts that were used in the blocks from height from to height to and adds them to the
+// wallet. It returns the addresses of the accounts that were added. If the wallet is locked the discovery is kept until
+// it is next unlocked.
+func (w *Wallet) discoverAccounts(chainClient chainclient.Interface, from, to int32) (
+	addrs []btcaddr.Address, e error,
+) {
+	gap := w.accountDiscoveryGap()
+	if gap == 0 || w.Manager.WatchOnly() {
+		return
+	}
+	w.discovery.Lock()
+	if w.Manager.IsLocked() {
+		w.discovery.pending = &accountDiscovery{from: from, to: to}
+		w.discovery.Unlock()
+		I.Ln("unlock the wallet to discover the accounts that were used with its seed")
+		return
+	}
+	w.discovery.pending = nil
+	w.discovery.running = true
+	w.discovery.Unlock()
+	defer func() {
+		w.discovery.Lock()
+		w.discovery.running = false
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			// The wallet was locked before discovery finished, so it starts over on the next unlock.
+			w.discovery.pending = &accountDiscovery{from: from, to: to}
+			I.Ln("the wallet was locked during account discovery, it will continue when the wallet is unlocked")
+			e = nil
+		}
+		w.discovery.Unlock()
+	}()
+	I.F("discovering accounts used between heights %d and %d", from, to)
+	var scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager
+	if scopedMgrs, e = w.defaultScopeManagers(); E.Chk(e) {
+		return
+	}
+	for _, scope := range waddrmgr.DefaultKeyScopes {
+		var found []btcaddr.Address
+		if found, e = w.discoverScopeAccounts(chainClient, scopedMgrs[scope], scope, gap, from, to); e != nil {
+			return
+		}
+		addrs = append(addrs, found...)
+	}
+	return
+}
+
+// discoverScopeAccounts discovers the accounts of one key scope.
+func (w *Wallet) discoverScopeAccounts(
+	chainClient chainclient.Interface,
+	scopedMgr *waddrmgr.ScopedKeyManager,
+	scope waddrmgr.KeyScope,
+	gap uint32,
+	from, to int32,
+) (addrs []btcaddr.Address, e error) {
+	var lastAccount uint32
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			lastAccount, e = scopedMgr.LastAccount(tx.ReadBucket(waddrmgrNamespaceKey))
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	var empty uint32
+	for account := lastAccount + 1; empty < gap && account <= waddrmgr.MaxAccountNum; account++ {
+		var state *ScopeRecoveryState
+		if state, e = w.probeAccount(chainClient, scopedMgr, scope, account, from, to); e != nil {
+			return
+		}
+		exNext, inNext := state.ExternalBranch.NextUnfound(), state.InternalBranch.NextUnfound()
+		if exNext == 0 && inNext == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		I.F("found account %d of key scope %s", account, scope.String())
+		// Accounts are numbered without gaps, so any empty accounts before this one are added too.
+		if e = walletdb.Update(
+			w.db, func(tx walletdb.ReadWriteTx) (e error) {
+				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				for number := lastAccount + 1; number <= account; number++ {
+					if e = scopedMgr.NewRawAccount(ns, number); E.Chk(e) {
+						return
+					}
+				}
+				if exNext > 0 {
+					if e = scopedMgr.ExtendExternalAddresses(ns, account, exNext-1); E.Chk(e) {
+						return
+					}
+				}
+				if inNext > 0 {
+					if e = scopedMgr.ExtendInternalAddresses(ns, account, inNext-1); E.Chk(e) {
+						return
+					}
+				}
+				return
+			},
+		); e != nil {
+			return
+		}
+		lastAccount = account
+		for _, branch := range []*BranchRecoveryState{state.ExternalBranch, state.InternalBranch} {
+			for index, addr := range branch.Addrs() {
+				if index < branch.NextUnfound() {
+					addrs = append(addrs, addr)
+				}
+			}
+		}
+	}
+	return
+}
+
+// probeAccount searches the blocks from height from to height to for the addresses of an account that need not exist
+// in the wallet yet. The returned recovery state records the last address found on each branch.
+func (w *Wallet) probeAccount(
+	chainClient chainclient.Interface,
+	scopedMgr *waddrmgr.ScopedKeyManager,
+	scope waddrmgr.KeyScope,
+	account uint32,
+	from, to int32,
+) (state *ScopeRecoveryState, e error) {
+	state = NewScopeRecoveryState(w.recoveryWindow)
+	for start := from; start <= to; start += recoveryBatchSize {
+		end := start + recoveryBatchSize - 1
+		if end > to {
+			end = to
+		}
+		var batch []wtxmgr.BlockMeta
+		if batch, e = w.blockBatch(start, end); E.Chk(e) {
+			return
+		}
+		for len(batch) > 0 {
+			if e = w.expandAccountHorizons(scopedMgr, account, state); e != nil {
+				return
+			}
+			req := &chainclient.FilterBlocksRequest{
+				Blocks:           batch,
+				ExternalAddrs:    make(map[waddrmgr.ScopedIndex]btcaddr.Address),
+				InternalAddrs:    make(map[waddrmgr.ScopedIndex]btcaddr.Address),
+				WatchedOutPoints: make(map[wire.OutPoint]btcaddr.Address),
+			}
+			for index, addr := range state.ExternalBranch.Addrs() {
+				req.ExternalAddrs[waddrmgr.ScopedIndex{Scope: scope, Index: index}] = addr
+			}
+			for index, addr := range state.InternalBranch.Addrs() {
+				req.InternalAddrs[waddrmgr.ScopedIndex{Scope: scope, Index: index}] = addr
+			}
+			var resp *chainclient.FilterBlocksResponse
+			if resp, e = chainClient.FilterBlocks(req); E.Chk(e) {
+				return
+			}
+			if resp == nil {
+				break
+			}
+			for index := range resp.FoundExternalAddrs[scope] {
+				state.ExternalBranch.ReportFound(index)
+			}
+			for index := range resp.FoundInternalAddrs[scope] {
+				state.InternalBranch.ReportFound(index)
+			}
+			batch = batch[resp.BatchIndex+1:]
+		}
+	}
+	return
+}
+
+// expandAccountHorizons derives the addresses of an account needed to keep the recovery window ahead of the last
+// address found on each branch.
+func (w *Wallet) expandAccountHorizons(
+	scopedMgr *waddrmgr.ScopedKeyManager,
+	account uint32,
+	state *ScopeRecoveryState,
+) error {
+	return walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			branches := map[uint32]*BranchRecoveryState{
+				waddrmgr.ExternalBranch: state.ExternalBranch,
+				waddrmgr.InternalBranch: state.InternalBranch,
+			}
+			for branch, brs := range branches {
+				// Invalid children push the horizon further out, so derive again until the window is full.
+				start, window := brs.ExtendHorizon()
+				for window > 0 {
+					var addrs map[uint32]btcaddr.Address
+					if addrs, e = scopedMgr.DeriveAccountAddresses(ns, account, branch, start, start+window); e != nil {
+						return
+					}
+					var invalid uint32
+					for index := start; index < start+window; index++ {
+						if addr, ok := addrs[index]; ok {
+							brs.AddAddr(index, addr)
+						} else {
+							brs.MarkInvalidChild(index)
+							invalid++
+						}
+					}
+					start, window = start+window, invalid
+				}
+			}
+			return
+		},
+	)
+}
+
+// blockBatch returns the blocks the wallet has synced to from height start to height end.
+func (w *Wallet) blockBatch(start, end int32) (batch []wtxmgr.BlockMeta, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			for height := start; height <= end; height++ {
+				hash, e := w.Manager.BlockHash(ns, height)
+				if e != nil {
+					return e
+				}
+				batch = append(batch, wtxmgr.BlockMeta{Block: wtxmgr.Block{Hash: *hash, Height: height}})
+			}
+			return nil
+		},
+	)
+	return
+}
+
+// resumeAccountDiscovery runs an account discovery that was waiting for the wallet to be unlocked, and rescans the
+// addresses of any accounts it adds.
+func (w *Wallet) resumeAccountDiscovery() {
+	w.discovery.Lock()
+	pending := w.discovery.pending
+	if pending == nil || w.discovery.running {
+		w.discovery.Unlock()
+		return
+	}
+	w.discovery.Unlock()
+	chainClient, e := w.requireChainClient()
+	if E.Chk(e) {
+		return
+	}
+	var addrs []btcaddr.Address
+	if addrs, e = w.discoverAccounts(chainClient, pending.from, pending.to); E.Chk(e) || len(addrs) == 0 {
+		return
+	}
+	var start waddrmgr.BlockStamp
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			var hash *chainhash.Hash
+			if hash, e = w.Manager.BlockHash(tx.ReadBucket(waddrmgrNamespaceKey), pending.from); E.Chk(e) {
+				return
+			}
+			start = waddrmgr.BlockStamp{Hash: *hash, Height: pending.from}
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	I.F("rescanning %d addresses of discovered accounts from height %d", len(addrs), pending.from)
+	if e = w.rescanWithTarget(addrs, nil, &start); E.Chk(e) {
+	}
+}
@@ -0,0 +1,266 @@
+package wallet
+
+import (
+	js "encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// DustAttackReason is the reason outputs taken for a dusting attack are frozen for.
+const DustAttackReason = "dusting attack output"
+
+// DustOutput is an output received by the wallet that was taken for the output of a dusting attack, which sends tiny
+// amounts to many addresses of a wallet so that the addresses can be linked when the outputs are spent together. Such
+// outputs are frozen when they are detected, and stay listed after they are released, so they can be reviewed.
+type DustOutput struct {
+	OutPoint wire.OutPoint
+	Address  string
+	Amount   amt.Amount
+	Detected time.Time
+	// Frozen is whether the output is still frozen, which is false once it has been released.
+	Frozen bool
+}
+
+// dustRecord is the encoding of a dust output in the database, which is keyed by the outpoint like frozen outputs.
+type dustRecord struct {
+	Address  string     `json:"address"`
+	Amount   amt.Amount `json:"amount"`
+	Detected int64      `json:"detected"`
+}
+
+// dustCredit is a tiny output received from others that is remembered for the length of the detection window.
+type dustCredit struct {
+	op      wire.OutPoint
+	address string
+	amount  amt.Amount
+	seen    time.Time
+	flagged bool
+}
+
+// dustDetector keeps the tiny outputs received in the last detection window. When they pay to enough distinct
+// addresses of the wallet, they are all taken for a dusting attack, as are further tiny outputs received while that
+// stays so.
+type dustDetector struct {
+	mtx    sync.Mutex
+	recent []dustCredit
+}
+
+// observe adds the tiny outputs of a transaction seen at the time of the first of them, and returns those of the
+// outputs in the window that are newly taken for a dusting attack, if they pay to at least minAddresses addresses.
+func (d *dustDetector) observe(credits []dustCredit, minAddresses int, window time.Duration) (flagged []dustCredit) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	since := credits[0].seen.Add(-window)
+	recent := d.recent[:0]
+	for _, c := range d.recent {
+		if !c.seen.Before(since) {
+			recent = append(recent, c)
+		}
+	}
+	d.recent = recent
+next:
+	for _, c := range credits {
+		// Transactions are seen again when they are mined.
+		for _, r := range d.recent {
+			if r.op == c.op {
+				continue next
+			}
+		}
+		d.recent = append(d.recent, c)
+	}
+	addresses := make(map[string]struct{}, len(d.recent))
+	for _, c := range d.recent {
+		addresses[c.address] = struct{}{}
+	}
+	if len(addresses) < minAddresses {
+		return
+	}
+	for i := range d.recent {
+		if !d.recent[i].flagged {
+			d.recent[i].flagged = true
+			flagged = append(flagged, d.recent[i])
+		}
+	}
+	return
+}
+
+// dustPolicy returns the largest amount of an output that may be dust, the number of addresses it must be sent to
+// within the window to be taken for a dusting attack, and the window. Detection is off when the amount is zero.
+func (w *Wallet) dustPolicy() (threshold amt.Amount, minAddresses int, window time.Duration) {
+	threshold = constant.DefaultDustAttackThreshold
+	minAddresses = constant.DefaultDustAttackAddresses
+	window = constant.DefaultDustAttackWindow
+	if w.PodConfig == nil {
+		return
+	}
+	if w.PodConfig.DustAttackThreshold != nil {
+		var e error
+		if threshold, e = amt.NewAmount(w.PodConfig.DustAttackThreshold.V()); E.Chk(e) {
+			threshold = 0
+		}
+	}
+	if w.PodConfig.DustAttackAddresses != nil {
+		minAddresses = w.PodConfig.DustAttackAddresses.V()
+	}
+	if w.PodConfig.DustAttackWindow != nil {
+		window = w.PodConfig.DustAttackWindow.V()
+	}
+	return
+}
+
+// detectDust looks for the outputs of a dusting attack among the outputs of a transaction received by the wallet, and
+// freezes and records those it finds in the database transaction. Transactions the wallet spends from, and coinbases,
+// are not looked at.
+//
+// The frozen outpoints kept in memory are updated straight away, so a transaction that fails to be recorded leaves
+// the outputs frozen until the wallet is restarted, which errs on the side of not spending them.
+func (w *Wallet) detectDust(dbtx walletdb.ReadWriteTx, details *wtxmgr.TxDetails) (e error) {
+	threshold, minAddresses, window := w.dustPolicy()
+	if threshold <= 0 || len(details.Debits) != 0 || blockchain.IsCoinBaseTx(&details.MsgTx) {
+		return
+	}
+	var tiny []dustCredit
+	for _, cred := range details.Credits {
+		if cred.Amount > threshold {
+			continue
+		}
+		var addrs []btcaddr.Address
+		if _, addrs, _, e = txscript.ExtractPkScriptAddrs(
+			details.MsgTx.TxOut[cred.Index].PkScript, w.chainParams,
+		); e != nil || len(addrs) == 0 {
+			e = nil
+			continue
+		}
+		tiny = append(
+			tiny, dustCredit{
+				op:      wire.OutPoint{Hash: details.Hash, Index: cred.Index},
+				address: addrs[0].EncodeAddress(),
+				amount:  cred.Amount,
+				seen:    details.Received,
+			},
+		)
+	}
+	if len(tiny) == 0 {
+		return
+	}
+	flagged := w.dust.observe(tiny, minAddresses, window)
+	if len(flagged) == 0 {
+		return
+	}
+	ns := dbtx.ReadWriteBucket(dustNamespaceKey)
+	if ns == nil {
+		if ns, e = dbtx.CreateTopLevelBucket(dustNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	now := time.Now()
+	var outputs []DustOutput
+	for _, c := range flagged {
+		key := frozenKey(&c.op)
+		// Outputs found before, such as when the wallet is rescanned, keep the review they were given.
+		if ns.Get(key) != nil {
+			continue
+		}
+		var body []byte
+		if body, e = js.Marshal(
+			dustRecord{Address: c.address, Amount: c.amount, Detected: now.UnixNano()},
+		); E.Chk(e) {
+			return
+		}
+		if e = ns.Put(key, body); E.Chk(e) {
+			return
+		}
+		var rec frozenRecord
+		if rec, e = w.putFrozen(dbtx, c.op, DustAttackReason); E.Chk(e) {
+			return
+		}
+		w.setFrozen(c.op, rec)
+		outputs = append(
+			outputs, DustOutput{OutPoint: c.op, Address: c.address, Amount: c.amount, Detected: now, Frozen: true},
+		)
+	}
+	if len(outputs) == 0 {
+		return
+	}
+	W.F(
+		"froze %d tiny outputs paying to %d or more addresses of the wallet as a dusting attack, review them with"+
+			" listdustoutputs", len(outputs), minAddresses,
+	)
+	w.NtfnServer.notifyDustAttack(outputs)
+	return
+}
+
+// DustOutputs returns the outputs taken for the outputs of a dusting attack, in the order they were detected, and
+// whether each is still frozen.
+func (w *Wallet) DustOutputs() (outputs []DustOutput, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(dustNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			return ns.ForEach(
+				func(k, v []byte) (e error) {
+					var op wire.OutPoint
+					if op, e = frozenOutPoint(k); E.Chk(e) {
+						return
+					}
+					var rec dustRecord
+					if e = js.Unmarshal(v, &rec); E.Chk(e) {
+						return
+					}
+					outputs = append(
+						outputs, DustOutput{
+							OutPoint: op,
+							Address:  rec.Address,
+							Amount:   rec.Amount,
+							Detected: time.Unix(0, rec.Detected),
+							Frozen:   w.FrozenOutpoint(op),
+						},
+					)
+					return
+				},
+			)
+		},
+	)
+	sort.SliceStable(
+		outputs, func(i, j int) bool {
+			return outputs[i].Detected.Before(outputs[j].Detected)
+		},
+	)
+	return
+}
+
+// OverrideDust releases an output taken for the output of a dusting attack, unfreezing it so it can be spent, or if
+// release is false freezes it again. Outputs that were not detected as dust are not overridden.
+func (w *Wallet) OverrideDust(op wire.OutPoint, release bool) (e error) {
+	var found bool
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			if ns := tx.ReadBucket(dustNamespaceKey); ns != nil {
+				found = ns.Get(frozenKey(&op)) != nil
+			}
+			return nil
+		},
+	); E.Chk(e) {
+		return
+	}
+	if !found {
+		return InvalidParameterError{errors.New("the output was not detected as a dusting attack output")}
+	}
+	if release {
+		return w.UnfreezeOutpoint(op)
+	}
+	return w.FreezeOutpoint(op, DustAttackReason)
+}
@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestDustDetector ensures tiny outputs are taken for a dusting attack once they pay to enough addresses within the
+// window, that outputs seen again are not counted twice, and that outputs older than the window are forgotten.
+func TestDustDetector(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	credit := func(tx byte, address string, after time.Duration) []dustCredit {
+		return []dustCredit{
+			{
+				op:      wire.OutPoint{Hash: chainhash.Hash{tx}},
+				address: address,
+				amount:  546,
+				seen:    start.Add(after),
+			},
+		}
+	}
+	var d dustDetector
+	if flagged := d.observe(credit(1, "a", 0), 3, time.Hour); len(flagged) != 0 {
+		t.Fatalf("one output was taken for a dusting attack: %v", flagged)
+	}
+	// The same output seen again when it is mined must not count as another address.
+	if flagged := d.observe(credit(1, "a", time.Minute), 3, time.Hour); len(flagged) != 0 {
+		t.Fatalf("an output seen twice was taken for a dusting attack: %v", flagged)
+	}
+	if flagged := d.observe(credit(2, "a", 2*time.Minute), 3, time.Hour); len(flagged) != 0 {
+		t.Fatalf("outputs to one address were taken for a dusting attack: %v", flagged)
+	}
+	if flagged := d.observe(credit(3, "b", 3*time.Minute), 3, time.Hour); len(flagged) != 0 {
+		t.Fatalf("outputs to two addresses were taken for a dusting attack: %v", flagged)
+	}
+	if flagged := d.observe(credit(4, "c", 4*time.Minute), 3, time.Hour); len(flagged) != 4 {
+		t.Fatalf("flagged %d outputs to three addresses, want 4", len(flagged))
+	}
+	// Further dust while the attack is in the window is flagged on its own.
+	if flagged := d.observe(credit(5, "d", 5*time.Minute), 3, time.Hour); len(flagged) != 1 {
+		t.Fatalf("flagged %d outputs after an attack, want 1", len(flagged))
+	}
+	// Once the window has passed the earlier outputs are forgotten.
+	later := 3 * time.Hour
+	if flagged := d.observe(credit(6, "e", later), 3, time.Hour); len(flagged) != 0 {
+		t.Fatalf("an output after the window was taken for a dusting attack: %v", flagged)
+	}
+	if len(d.recent) != 1 {
+		t.Fatalf("remembered %d outputs after the window, want 1", len(d.recent))
+	}
+}
@@ -62,8 +62,33 @@ var (
 		Code:    btcjson.ErrRPCNoTxInfo,
 		Message: "No information for transaction",
 	}
+	ErrXprvExportDisabled = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWallet,
+		Message: "exportaccountxprv is disabled, enable it with allowxprvexport and set an xprvexportpass",
+	}
+	ErrXprvExportPassIncorrect = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletPassphraseIncorrect,
+		Message: "The xprv export password is incorrect",
+	}
+	ErrSpendAuthRequired = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletSpendAuthRequired,
+		Message: "Sending more than the spend limit of the wallet requires its PIN or authenticator code",
+	}
+	ErrSpendAuthIncorrect = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletSpendAuthIncorrect,
+		Message: "The PIN or authenticator code is incorrect or has already been used",
+	}
+	ErrNoAddressMeta = btcjson.RPCError{
+		Code:    btcjson.ErrRPCInvalidAddressOrKey,
+		Message: "no metadata stored for address",
+	}
 	ErrReservedAccountName = btcjson.RPCError{
 		Code:    btcjson.ErrRPCInvalidParameter,
 		Message: "Account name is reserved by RPC server",
 	}
+	ErrIdempotentSendUnfinished = btcjson.RPCError{
+		Code: btcjson.ErrRPCWallet,
+		Message: "The send made with this idempotency key was interrupted and may have been made, " +
+			"check the transactions of the wallet before sending again with another key",
+	}
 )
@@ -0,0 +1,211 @@
+package wallet
+
+import (
+	"encoding/binary"
+	js "encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// maxFreezeReason is the longest reason, in bytes, an output can be frozen with.
+const maxFreezeReason = 256
+
+// FrozenOutput is an output that has been frozen so it is never selected to fund a transaction, with the reason it was
+// frozen for. Unlike the locks made with lockunspent, which are only kept until the wallet is restarted or unlocked,
+// frozen outputs are stored in the wallet and stay frozen until they are unfrozen.
+type FrozenOutput struct {
+	OutPoint wire.OutPoint
+	Reason   string
+	Frozen   time.Time
+	// Unspent is whether the output is an unspent output of the wallet, in which case PkScript and Amount are those of
+	// the output. Outputs that have been spent, or are not yet known to the wallet, stay frozen.
+	Unspent  bool
+	PkScript []byte
+	Amount   amt.Amount
+}
+
+// frozenRecord is the encoding of a frozen output in the database, which is keyed by the outpoint.
+type frozenRecord struct {
+	Reason string `json:"reason"`
+	Frozen int64  `json:"frozen"`
+}
+
+// frozenKey returns the key a frozen output is stored under, which is the hash of its transaction followed by its index
+// in big endian order so the outputs of a transaction are kept together.
+func frozenKey(op *wire.OutPoint) []byte {
+	k := make([]byte, chainhash.HashSize+4)
+	copy(k, op.Hash[:])
+	binary.BigEndian.PutUint32(k[chainhash.HashSize:], op.Index)
+	return k
+}
+
+// frozenOutPoint returns the outpoint a frozen output is stored under.
+func frozenOutPoint(k []byte) (op wire.OutPoint, e error) {
+	if len(k) != chainhash.HashSize+4 {
+		return op, errors.New("frozen output key has the wrong length")
+	}
+	copy(op.Hash[:], k)
+	op.Index = binary.BigEndian.Uint32(k[chainhash.HashSize:])
+	return
+}
+
+// loadFrozenOutpoints reads the outputs frozen in the wallet database, which is kept in memory so coin selection does
+// not have to read it for every output.
+func loadFrozenOutpoints(db walletdb.DB) (frozen map[wire.OutPoint]frozenRecord, e error) {
+	frozen = make(map[wire.OutPoint]frozenRecord)
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(frozenNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			return ns.ForEach(
+				func(k, v []byte) (e error) {
+					var op wire.OutPoint
+					if op, e = frozenOutPoint(k); E.Chk(e) {
+						return
+					}
+					var rec frozenRecord
+					if e = js.Unmarshal(v, &rec); E.Chk(e) {
+						return
+					}
+					frozen[op] = rec
+					return
+				},
+			)
+		},
+	)
+	return
+}
+
+// FrozenOutpoint returns whether an outpoint has been frozen and must not be used as an input for created transactions.
+func (w *Wallet) FrozenOutpoint(op wire.OutPoint) bool {
+	w.frozenOutpointsMtx.RLock()
+	_, frozen := w.frozenOutpoints[op]
+	w.frozenOutpointsMtx.RUnlock()
+	return frozen
+}
+
+// FreezeOutpoint freezes an outpoint for the reason, so it is never used as an input for newly created transactions
+// until it is unfrozen. Freezing an outpoint that is already frozen replaces the reason, keeping the time it was first
+// frozen.
+func (w *Wallet) FreezeOutpoint(op wire.OutPoint, reason string) (e error) {
+	var rec frozenRecord
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			rec, e = w.putFrozen(tx, op, reason)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	w.setFrozen(op, rec)
+	return
+}
+
+// putFrozen stores the outpoint as frozen for the reason in the database transaction, and returns the record that
+// setFrozen must add to the frozen outpoints once the transaction is committed.
+func (w *Wallet) putFrozen(tx walletdb.ReadWriteTx, op wire.OutPoint, reason string) (rec frozenRecord, e error) {
+	if len(reason) > maxFreezeReason {
+		return rec, InvalidParameterError{errors.New("the reason for freezing an output is too long")}
+	}
+	ns := tx.ReadWriteBucket(frozenNamespaceKey)
+	if ns == nil {
+		if ns, e = tx.CreateTopLevelBucket(frozenNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	var ok bool
+	w.frozenOutpointsMtx.RLock()
+	rec, ok = w.frozenOutpoints[op]
+	w.frozenOutpointsMtx.RUnlock()
+	if !ok {
+		rec.Frozen = time.Now().UnixNano()
+	}
+	rec.Reason = reason
+	var body []byte
+	if body, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	e = ns.Put(frozenKey(&op), body)
+	return
+}
+
+// setFrozen adds the outpoint to the frozen outpoints kept in memory.
+func (w *Wallet) setFrozen(op wire.OutPoint, rec frozenRecord) {
+	w.frozenOutpointsMtx.Lock()
+	w.frozenOutpoints[op] = rec
+	w.frozenOutpointsMtx.Unlock()
+}
+
+// UnfreezeOutpoint unfreezes an outpoint, so it may be used as an input for newly created transactions unless it is
+// also locked. Unfreezing an outpoint that is not frozen does nothing.
+func (w *Wallet) UnfreezeOutpoint(op wire.OutPoint) (e error) {
+	if !w.FrozenOutpoint(op) {
+		return
+	}
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(frozenNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			return ns.Delete(frozenKey(&op))
+		},
+	); E.Chk(e) {
+		return
+	}
+	w.frozenOutpointsMtx.Lock()
+	delete(w.frozenOutpoints, op)
+	w.frozenOutpointsMtx.Unlock()
+	return
+}
+
+// FrozenOutputs returns the frozen outputs, in the order they were frozen, along with the amount and script of those
+// that are unspent outputs of the wallet.
+func (w *Wallet) FrozenOutputs() (outputs []FrozenOutput, e error) {
+	w.frozenOutpointsMtx.RLock()
+	outputs = make([]FrozenOutput, 0, len(w.frozenOutpoints))
+	for op, rec := range w.frozenOutpoints {
+		outputs = append(
+			outputs, FrozenOutput{OutPoint: op, Reason: rec.Reason, Frozen: time.Unix(0, rec.Frozen)},
+		)
+	}
+	w.frozenOutpointsMtx.RUnlock()
+	if len(outputs) == 0 {
+		return
+	}
+	byOutPoint := make(map[wire.OutPoint]*FrozenOutput, len(outputs))
+	for i := range outputs {
+		byOutPoint[outputs[i].OutPoint] = &outputs[i]
+	}
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(tx.ReadBucket(wtxmgrNamespaceKey)); E.Chk(e) {
+				return
+			}
+			for i := range unspent {
+				if f, ok := byOutPoint[unspent[i].OutPoint]; ok {
+					f.Unspent, f.PkScript, f.Amount = true, unspent[i].PkScript, unspent[i].Amount
+				}
+			}
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	sort.SliceStable(
+		outputs, func(i, j int) bool {
+			return outputs[i].Frozen.Before(outputs[j].Frozen)
+		},
+	)
+	return
+}
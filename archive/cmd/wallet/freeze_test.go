@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestFreezeOutpoint ensures outputs are frozen with their reason, keep the time they were first frozen when the reason
+// is changed, are read back from the database, and are unfrozen.
+func TestFreezeOutpoint(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	frozen, e := loadFrozenOutpoints(db)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(frozen) != 0 {
+		t.Fatalf("a new wallet has %d frozen outputs", len(frozen))
+	}
+	w.frozenOutpoints = frozen
+	op1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	op2 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	if e = w.FreezeOutpoint(op1, "under dispute"); e != nil {
+		t.Fatal(e)
+	}
+	if e = w.FreezeOutpoint(op2, "dusting attack output"); e != nil {
+		t.Fatal(e)
+	}
+	first := w.frozenOutpoints[op1].Frozen
+	if e = w.FreezeOutpoint(op1, "still under dispute"); e != nil {
+		t.Fatal(e)
+	}
+	if !w.FrozenOutpoint(op1) || !w.FrozenOutpoint(op2) {
+		t.Fatal("frozen outputs are not frozen")
+	}
+	if e = w.FreezeOutpoint(op1, strings.Repeat("x", maxFreezeReason+1)); e == nil {
+		t.Fatal("froze an output with a reason that is too long")
+	}
+	if frozen, e = loadFrozenOutpoints(db); e != nil {
+		t.Fatal(e)
+	}
+	if len(frozen) != 2 {
+		t.Fatalf("read %d frozen outputs, want 2", len(frozen))
+	}
+	if rec := frozen[op1]; rec.Reason != "still under dispute" || rec.Frozen != first {
+		t.Fatalf("read frozen output %+v, want the new reason frozen at %d", rec, first)
+	}
+	if e = w.UnfreezeOutpoint(op1); e != nil {
+		t.Fatal(e)
+	}
+	if e = w.UnfreezeOutpoint(op1); e != nil {
+		t.Fatalf("unfreezing an output that is not frozen failed: %v", e)
+	}
+	if w.FrozenOutpoint(op1) || !w.FrozenOutpoint(op2) {
+		t.Fatal("unfreezing one output did not unfreeze only that output")
+	}
+	if frozen, e = loadFrozenOutpoints(db); e != nil {
+		t.Fatal(e)
+	}
+	if _, ok := frozen[op2]; len(frozen) != 1 || !ok {
+		t.Fatalf("read frozen outputs %v after unfreezing, want only %v", frozen, op2)
+	}
+}
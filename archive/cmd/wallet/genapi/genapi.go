@@ -252,18 +252,96 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddMultisigAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "addportfolioentry",
+		Handler: "AddPortfolioEntry",
+		Cmd:     "*btcjson.AddPortfolioEntryCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "archiveaccount",
+		Handler: "ArchiveAccount",
+		Cmd:     "*btcjson.ArchiveAccountCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "backupremote",
+		Handler: "BackupRemote",
+		Cmd:     "*btcjson.BackupRemoteCmd",
+		ResType: "[]btcjson.BackupTargetResult",
+	},
+	{
+		Method:  "cancelqueuedpsbt",
+		Handler: "CancelQueuedPSBT",
+		Cmd:     "*btcjson.CancelQueuedPSBTCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "createmultisig",
 		Handler: "CreateMultiSig",
 		Cmd:     "*btcjson.CreateMultisigCmd",
 		ResType: "btcjson.CreateMultiSigResult",
 	},
+	{
+		Method:  "createmultisigaccount",
+		Handler: "CreateMultiSigAccount",
+		Cmd:     "*btcjson.CreateMultiSigAccountCmd",
+		ResType: "btcjson.MultiSigAccountResult",
+	},
+	{
+		Method:  "createvaultaccount",
+		Handler: "CreateVaultAccount",
+		Cmd:     "*btcjson.CreateVaultAccountCmd",
+		ResType: "btcjson.VaultAccountResult",
+	},
+	{
+		Method:  "deleteaddressmeta",
+		Handler: "DeleteAddressMeta",
+		Cmd:     "*btcjson.DeleteAddressMetaCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "dumpprivkey",
 		Handler: "DumpPrivKey",
 		Cmd:     "*btcjson.DumpPrivKeyCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "exportaccountxprv",
+		Handler: "ExportAccountXprv",
+		Cmd:     "*btcjson.ExportAccountXprvCmd",
+		ResType: "btcjson.ExportAccountXprvResult",
+	},
+	{
+		Method:  "exportledger",
+		Handler: "ExportLedger",
+		Cmd:     "*btcjson.ExportLedgerCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "exportpaymentbundle",
+		Handler: "ExportPaymentBundle",
+		Cmd:     "*btcjson.ExportPaymentBundleCmd",
+		ResType: "btcjson.ExportPaymentBundleResult",
+	},
+	{
+		Method:  "exportwatchset",
+		Handler: "ExportWatchSet",
+		Cmd:     "*None",
+		ResType: "btcjson.WatchSetResult",
+	},
+	{
+		Method:  "freezeunspent",
+		Handler: "FreezeUnspent",
+		Cmd:     "*btcjson.FreezeUnspentCmd",
+		ResType: "bool",
+	},
+	{
+		Method:  "generatepaperkey",
+		Handler: "GeneratePaperKey",
+		Cmd:     "*btcjson.GeneratePaperKeyCmd",
+		ResType: "[]btcjson.PaperKeyResult",
+	},
 	{
 		Method:  "getaccount",
 		Handler: "GetAccount",
@@ -276,18 +354,48 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetAccountAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getaccountxpub",
+		Handler: "GetAccountXPub",
+		Cmd:     "*btcjson.GetAccountXPubCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "getaddressinfo",
+		Handler: "GetAddressInfo",
+		Cmd:     "*btcjson.GetAddressInfoCmd",
+		ResType: "btcjson.GetAddressInfoResult",
+	},
+	{
+		Method:  "getaddressmeta",
+		Handler: "GetAddressMeta",
+		Cmd:     "*btcjson.GetAddressMetaCmd",
+		ResType: "btcjson.AddressMetaResult",
+	},
 	{
 		Method:  "getaddressesbyaccount",
 		Handler: "GetAddressesByAccount",
 		Cmd:     "*btcjson.GetAddressesByAccountCmd",
 		ResType: "[]string",
 	},
+	{
+		Method:  "getauditlog",
+		Handler: "GetAuditLog",
+		Cmd:     "*btcjson.GetAuditLogCmd",
+		ResType: "btcjson.GetAuditLogResult",
+	},
 	{
 		Method:  "getbalance",
 		Handler: "GetBalance",
 		Cmd:     "*btcjson.GetBalanceCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "getbalanceat",
+		Handler: "GetBalanceAt",
+		Cmd:     "*btcjson.GetBalanceAtCmd",
+		ResType: "btcjson.GetBalanceAtResult",
+	},
 	{
 		Method:  "getbestblockhash",
 		Handler: "GetBestBlockHash",
@@ -312,6 +420,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetNewAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getnewmultisigaddress",
+		Handler: "GetNewMultiSigAddress",
+		Cmd:     "*btcjson.GetNewMultiSigAddressCmd",
+		ResType: "btcjson.MultiSigAddressResult",
+	},
+	{
+		Method:  "getnewvaultaddress",
+		Handler: "GetNewVaultAddress",
+		Cmd:     "*btcjson.GetNewVaultAddressCmd",
+		ResType: "btcjson.VaultAddressResult",
+	},
 	{
 		Method:  "getrawchangeaddress",
 		Handler: "GetRawChangeAddress",
@@ -330,12 +450,48 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetReceivedByAddressCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "getpaymentbundle",
+		Handler: "GetPaymentBundle",
+		Cmd:     "*btcjson.GetPaymentBundleCmd",
+		ResType: "btcjson.PaymentBundleResult",
+	},
+	{
+		Method:  "getrescaninfo",
+		Handler: "GetRescanInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetRescanInfoResult",
+	},
+	{
+		Method:  "getscrubinfo",
+		Handler: "GetScrubInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetScrubInfoResult",
+	},
+	{
+		Method:  "getspendauth",
+		Handler: "GetSpendAuth",
+		Cmd:     "*None",
+		ResType: "btcjson.SpendAuthResult",
+	},
+	{
+		Method:  "getvaultschedule",
+		Handler: "GetVaultSchedule",
+		Cmd:     "*btcjson.GetVaultScheduleCmd",
+		ResType: "btcjson.VaultScheduleResult",
+	},
 	{
 		Method:  "gettransaction",
 		Handler: "GetTransaction",
 		Cmd:     "*btcjson.GetTransactionCmd",
 		ResType: "btcjson.GetTransactionResult",
 	},
+	{
+		Method:  "getutxoreport",
+		Handler: "GetUTXOReport",
+		Cmd:     "*btcjson.GetUTXOReportCmd",
+		ResType: "btcjson.GetUTXOReportResult",
+	},
 	{
 		Method:           "help",
 		Handler:          "HelpNoChainRPC",
@@ -343,12 +499,30 @@ var handlers = handlersT{
 		Cmd:              "btcjson.HelpCmd",
 		ResType:          "string",
 	},
+	{
+		Method:  "importcorewallet",
+		Handler: "ImportCoreWallet",
+		Cmd:     "*btcjson.ImportCoreWalletCmd",
+		ResType: "btcjson.ImportCoreWalletResult",
+	},
 	{
 		Method:  "importprivkey",
 		Handler: "ImportPrivKey",
 		Cmd:     "*btcjson.ImportPrivKeyCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "importscriptpubkey",
+		Handler: "ImportScriptPubKey",
+		Cmd:     "*btcjson.ImportScriptPubKeyCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "importtimelockscript",
+		Handler: "ImportTimeLockScript",
+		Cmd:     "*btcjson.ImportTimeLockScriptCmd",
+		ResType: "btcjson.TimeLockScriptResult",
+	},
 	{
 		Method:  "keypoolrefill",
 		Handler: "KeypoolRefill",
@@ -361,12 +535,78 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.ListAccountsCmd",
 		ResType: "map[string]float64",
 	},
+	{
+		Method:  "listaddressmeta",
+		Handler: "ListAddressMeta",
+		Cmd:     "*btcjson.ListAddressMetaCmd",
+		ResType: "[]btcjson.AddressMetaResult",
+	},
+	{
+		Method:  "listimmature",
+		Handler: "ListImmature",
+		Cmd:     "*btcjson.ListImmatureCmd",
+		ResType: "btcjson.ListImmatureResult",
+	},
+	{
+		Method:  "listdustoutputs",
+		Handler: "ListDustOutputs",
+		Cmd:     "*None",
+		ResType: "[]btcjson.DustOutputResult",
+	},
+	{
+		Method:  "listfrozen",
+		Handler: "ListFrozen",
+		Cmd:     "*None",
+		ResType: "[]btcjson.FrozenOutputResult",
+	},
+	{
+		Method:  "listinvoices",
+		Handler: "ListInvoices",
+		Cmd:     "*btcjson.ListInvoicesCmd",
+		ResType: "[]btcjson.InvoiceResult",
+	},
+	{
+		Method:  "listinvoicereservations",
+		Handler: "ListInvoiceReservations",
+		Cmd:     "*btcjson.ListInvoiceReservationsCmd",
+		ResType: "[]btcjson.InvoiceReservationResult",
+	},
 	{
 		Method:  "listlockunspent",
 		Handler: "ListLockUnspent",
 		Cmd:     "*None",
 		ResType: "[]btcjson.TransactionInput",
 	},
+	{
+		Method:  "listmultisigaccounts",
+		Handler: "ListMultiSigAccounts",
+		Cmd:     "*btcjson.ListMultiSigAccountsCmd",
+		ResType: "[]btcjson.MultiSigAccountResult",
+	},
+	{
+		Method:  "listpaymentbundles",
+		Handler: "ListPaymentBundles",
+		Cmd:     "*btcjson.ListPaymentBundlesCmd",
+		ResType: "[]btcjson.PaymentBundleResult",
+	},
+	{
+		Method:  "listportfolio",
+		Handler: "ListPortfolio",
+		Cmd:     "*btcjson.ListPortfolioCmd",
+		ResType: "btcjson.ListPortfolioResult",
+	},
+	{
+		Method:  "listportfoliotransactions",
+		Handler: "ListPortfolioTransactions",
+		Cmd:     "*btcjson.ListPortfolioTransactionsCmd",
+		ResType: "[]btcjson.PortfolioTransactionResult",
+	},
+	{
+		Method:  "listqueuedpsbts",
+		Handler: "ListQueuedPSBTs",
+		Cmd:     "*None",
+		ResType: "[]btcjson.QueuedPSBTResult",
+	},
 	{
 		Method:  "listreceivedbyaccount",
 		Handler: "ListReceivedByAccount",
@@ -392,12 +632,60 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.ListTransactionsCmd",
 		ResType: "[]btcjson.ListTransactionsResult",
 	},
+	{
+		Method:  "listtransactionspage",
+		Handler: "ListTransactionsPage",
+		Cmd:     "*btcjson.ListTransactionsPageCmd",
+		ResType: "btcjson.ListTransactionsPageResult",
+	},
 	{
 		Method:  "listunspent",
 		Handler: "ListUnspent",
 		Cmd:     "*btcjson.ListUnspentCmd",
 		ResType: "[]btcjson.ListUnspentResult",
 	},
+	{
+		Method:  "listunlockattempts",
+		Handler: "ListUnlockAttempts",
+		Cmd:     "*btcjson.ListUnlockAttemptsCmd",
+		ResType: "[]btcjson.UnlockAttemptResult",
+	},
+	{
+		Method:  "listvaultaccounts",
+		Handler: "ListVaultAccounts",
+		Cmd:     "*btcjson.ListVaultAccountsCmd",
+		ResType: "[]btcjson.VaultAccountResult",
+	},
+	{
+		Method:  "overridedust",
+		Handler: "OverrideDust",
+		Cmd:     "*btcjson.OverrideDustCmd",
+		ResType: "bool",
+	},
+	{
+		Method:  "previewsend",
+		Handler: "PreviewSend",
+		Cmd:     "*btcjson.PreviewSendCmd",
+		ResType: "btcjson.PreviewSendResult",
+	},
+	{
+		Method:  "releaseinvoiceaddress",
+		Handler: "ReleaseInvoiceAddress",
+		Cmd:     "*btcjson.ReleaseInvoiceAddressCmd",
+		ResType: "bool",
+	},
+	{
+		Method:  "removeportfolioentry",
+		Handler: "RemovePortfolioEntry",
+		Cmd:     "*btcjson.RemovePortfolioEntryCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "reserveinvoiceaddress",
+		Handler: "ReserveInvoiceAddress",
+		Cmd:     "*btcjson.ReserveInvoiceAddressCmd",
+		ResType: "btcjson.InvoiceReservationResult",
+	},
 	{
 		Method:           "sendfrom",
 		Handler:          "LockUnspent",
@@ -417,6 +705,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendToAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "setaddressmeta",
+		Handler: "SetAddressMeta",
+		Cmd:     "*btcjson.SetAddressMetaCmd",
+		ResType: "btcjson.AddressMetaResult",
+	},
+	{
+		Method:  "setinvoiceissuance",
+		Handler: "SetInvoiceIssuance",
+		Cmd:     "*btcjson.SetInvoiceIssuanceCmd",
+		ResType: "bool",
+	},
+	{
+		Method:  "setspendauth",
+		Handler: "SetSpendAuth",
+		Cmd:     "*btcjson.SetSpendAuthCmd",
+		ResType: "btcjson.SpendAuthResult",
+	},
 	{
 		Method:  "settxfee",
 		Handler: "SetTxFee",
@@ -436,6 +742,42 @@ var handlers = handlersT{
 		Cmd:              "btcjson.SignRawTransactionCmd",
 		ResType:          "btcjson.SignRawTransactionResult",
 	},
+	{
+		Method:  "submitsignedpsbt",
+		Handler: "SubmitSignedPSBT",
+		Cmd:     "*btcjson.SubmitSignedPSBTCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "sweepaccount",
+		Handler: "SweepAccount",
+		Cmd:     "*btcjson.SweepAccountCmd",
+		ResType: "btcjson.SweepAccountResult",
+	},
+	{
+		Method:  "sweepprivkey",
+		Handler: "SweepPrivKey",
+		Cmd:     "*btcjson.SweepPrivKeyCmd",
+		ResType: "btcjson.SweepPrivKeyResult",
+	},
+	{
+		Method:  "sweeptimelocked",
+		Handler: "SweepTimeLocked",
+		Cmd:     "*btcjson.SweepTimeLockedCmd",
+		ResType: "btcjson.SweepTimeLockedResult",
+	},
+	{
+		Method:  "transferaccount",
+		Handler: "TransferAccount",
+		Cmd:     "*btcjson.TransferAccountCmd",
+		ResType: "btcjson.TransferAccountResult",
+	},
+	{
+		Method:  "unarchiveaccount",
+		Handler: "UnarchiveAccount",
+		Cmd:     "*btcjson.UnarchiveAccountCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "validateaddress",
 		Handler: "ValidateAddress",
@@ -448,6 +790,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.VerifyMessageCmd",
 		ResType: "bool",
 	},
+	{
+		Method:  "walletdbstats",
+		Handler: "WalletDBStats",
+		Cmd:     "*btcjson.WalletDBStatsCmd",
+		ResType: "[]btcjson.WalletDBBucketResult",
+	},
 	{
 		Method:  "walletlock",
 		Handler: "WalletLock",
@@ -466,6 +814,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.WalletPassphraseChangeCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "walletselftest",
+		Handler: "WalletSelfTest",
+		Cmd:     "*None",
+		ResType: "btcjson.WalletSelfTestResult",
+	},
+	{
+		Method:  "withdrawvault",
+		Handler: "WithdrawVault",
+		Cmd:     "*btcjson.WithdrawVaultCmd",
+		ResType: "string",
+	},
 	{
 		Method:  "createnewaccount",
 		Handler: "CreateNewAccount",
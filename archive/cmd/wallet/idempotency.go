@@ -0,0 +1,149 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	js "encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// maxIdempotencyKey is the longest idempotency key, in bytes, a send can be made with.
+const maxIdempotencyKey = 128
+
+// idempotencyRecord is the encoding of the result of a send made with an idempotency key, which is stored under the
+// key. Request is the SHA256 digest of the method and the parameters of the send, so a retry can be told apart from a
+// different send reusing the key. A record is pending from before the send is made until its result is stored.
+type idempotencyRecord struct {
+	Method  string `json:"method"`
+	Request []byte `json:"request"`
+	Pending bool   `json:"pending,omitempty"`
+	Result  string `json:"result"`
+	Sent    int64  `json:"sent"`
+}
+
+// idempotencyDigest returns the digest of the method and the parameters of a send that identifies its retries.
+func idempotencyDigest(method string, request interface{}) (digest []byte, e error) {
+	var b []byte
+	if b, e = js.Marshal(request); E.Chk(e) {
+		return
+	}
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+// getIdempotencyRecord returns the record of the send made with the key, or nil if none was made.
+func getIdempotencyRecord(ns walletdb.ReadBucket, key string) (rec *idempotencyRecord, e error) {
+	if ns == nil {
+		return
+	}
+	v := ns.Get([]byte(key))
+	if v == nil {
+		return
+	}
+	rec = &idempotencyRecord{}
+	if e = js.Unmarshal(v, rec); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// putIdempotencyRecord stores the record of the send made with the key.
+func (w *Wallet) putIdempotencyRecord(key string, rec *idempotencyRecord) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(idempotencyNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(idempotencyNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			var v []byte
+			if v, e = js.Marshal(rec); E.Chk(e) {
+				return
+			}
+			return ns.Put([]byte(key), v)
+		},
+	)
+}
+
+// deleteIdempotencyRecord removes the record of the send made with the key.
+func (w *Wallet) deleteIdempotencyRecord(key string) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) error {
+			return tx.ReadWriteBucket(idempotencyNamespaceKey).Delete([]byte(key))
+		},
+	)
+}
+
+// SendIdempotent calls send, which sends a transaction and returns its hash, and stores the hash under the idempotency
+// key chosen by the client. A send made again with the key and the same method and request, as a client does when a
+// send timed out and it can't tell whether it was made, returns the stored hash instead of sending again, and one with
+// a different request is refused. The request must not include the key nor the authorization code, which changes
+// between the retries of a send when it is time-based. Sends that fail are not stored, so they can be retried with the
+// key. Without a key, send is just called.
+//
+// The sends made with keys are serialized, so a retry made while the first send is still being made waits for it. The
+// key is stored as pending before send is called and its result stored once it returns, so a retry of a send that was
+// interrupted in between, which can't tell whether the transaction was sent, is refused instead of sending it again.
+func (w *Wallet) SendIdempotent(
+	key, method string, request interface{}, send func() (string, error),
+) (result string, e error) {
+	if key == "" {
+		return send()
+	}
+	if len(key) > maxIdempotencyKey {
+		return "", InvalidParameterError{
+			fmt.Errorf("the idempotency key is longer than %d bytes", maxIdempotencyKey),
+		}
+	}
+	var digest []byte
+	if digest, e = idempotencyDigest(method, request); E.Chk(e) {
+		return
+	}
+	w.idempotencyMtx.Lock()
+	defer w.idempotencyMtx.Unlock()
+	var rec *idempotencyRecord
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			rec, e = getIdempotencyRecord(tx.ReadBucket(idempotencyNamespaceKey), key)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	if rec != nil {
+		if rec.Method != method || string(rec.Request) != string(digest) {
+			return "", InvalidParameterError{
+				fmt.Errorf("the idempotency key %q was used for a different %s request", key, rec.Method),
+			}
+		}
+		if rec.Pending {
+			W.F("%s with idempotency key %q was interrupted at %v", method, key, time.Unix(rec.Sent, 0))
+			return "", &ErrIdempotentSendUnfinished
+		}
+		I.F("%s with idempotency key %q was already made, returning its result %s", method, key, rec.Result)
+		return rec.Result, nil
+	}
+	rec = &idempotencyRecord{Method: method, Request: digest, Pending: true, Sent: time.Now().Unix()}
+	if e = w.putIdempotencyRecord(key, rec); E.Chk(e) {
+		return
+	}
+	if result, e = send(); e != nil {
+		if de := w.deleteIdempotencyRecord(key); E.Chk(de) {
+			// the key stays pending, so a retry is refused rather than made without knowing what the send did
+			E.F("failed to remove idempotency key %q of failed %s: %v", key, method, de)
+		}
+		return
+	}
+	rec.Pending, rec.Result = false, result
+	if e := w.putIdempotencyRecord(key, rec); E.Chk(e) {
+		// the transaction was sent, so its hash is returned, and the key stays pending so a retry is not sent again
+		E.F("failed to store idempotency key %q of %s %s: %v", key, method, result, e)
+	}
+	return result, nil
+}
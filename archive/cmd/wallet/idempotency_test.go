@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestSendIdempotent ensures a send made again with its idempotency key returns the result of the first without
+// sending, that the key can't be reused for a different request, that failed sends are not stored, and that the retry
+// of a send interrupted while it was pending is refused.
+func TestSendIdempotent(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	var sends int
+	send := func(txid string) func() (string, error) {
+		return func() (string, error) {
+			sends++
+			return txid, nil
+		}
+	}
+	request := btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil, nil, nil)
+	txid, e := w.SendIdempotent("order-1", "sendtoaddress", request, send("aa"))
+	if e != nil || txid != "aa" || sends != 1 {
+		t.Fatalf("first send returned %q, %v after %d sends", txid, e, sends)
+	}
+	retry := btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil, nil, nil)
+	if txid, e = w.SendIdempotent("order-1", "sendtoaddress", retry, send("bb")); e != nil || txid != "aa" {
+		t.Fatalf("retry returned %q, %v, want the first result", txid, e)
+	}
+	if sends != 1 {
+		t.Fatal("the retry of a send was sent again")
+	}
+	other := btcjson.NewSendToAddressCmd("1Address", 0.6, nil, nil, nil, nil, nil)
+	if _, e = w.SendIdempotent("order-1", "sendtoaddress", other, send("cc")); e == nil {
+		t.Fatal("the idempotency key of a send was reused for another amount")
+	}
+	if _, e = w.SendIdempotent("order-1", "sendmany", request, send("cc")); e == nil {
+		t.Fatal("the idempotency key of a send was reused for another method")
+	}
+	if _, e = w.SendIdempotent(strings.Repeat("k", maxIdempotencyKey+1), "sendtoaddress", request, send("cc")); e == nil {
+		t.Fatal("a send was made with an idempotency key that is too long")
+	}
+	if sends != 1 {
+		t.Fatal("a refused send was sent")
+	}
+	// a failed send is not stored, so it is sent again when it is retried
+	failed := func() (string, error) {
+		sends++
+		return "", errors.New("insufficient funds")
+	}
+	if _, e = w.SendIdempotent("order-2", "sendtoaddress", request, failed); e == nil {
+		t.Fatal("the error of the send was not returned")
+	}
+	if txid, e = w.SendIdempotent("order-2", "sendtoaddress", request, send("dd")); e != nil || txid != "dd" {
+		t.Fatalf("retry of a failed send returned %q, %v", txid, e)
+	}
+	// the key is pending while the send is made, and a retry of a send interrupted then is refused
+	interrupted := func() (string, error) {
+		sends++
+		var rec *idempotencyRecord
+		if e := walletdb.View(
+			w.db, func(tx walletdb.ReadTx) (e error) {
+				rec, e = getIdempotencyRecord(tx.ReadBucket(idempotencyNamespaceKey), "order-3")
+				return
+			},
+		); e != nil {
+			t.Fatal(e)
+		}
+		if rec == nil || !rec.Pending {
+			t.Fatalf("idempotency key is %+v while the send is made, want it pending", rec)
+		}
+		return "ff", nil
+	}
+	if txid, e = w.SendIdempotent("order-3", "sendtoaddress", request, interrupted); e != nil || txid != "ff" {
+		t.Fatalf("send returned %q, %v", txid, e)
+	}
+	digest, e := idempotencyDigest("sendtoaddress", request)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = w.putIdempotencyRecord(
+		"order-4", &idempotencyRecord{Method: "sendtoaddress", Request: digest, Pending: true},
+	); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = w.SendIdempotent("order-4", "sendtoaddress", request, send("gg")); e != &ErrIdempotentSendUnfinished {
+		t.Fatalf("retry of an interrupted send returned %v", e)
+	}
+	// without a key every send is sent
+	for i := 0; i < 2; i++ {
+		if _, e = w.SendIdempotent("", "sendtoaddress", request, send("ee")); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if sends != 6 {
+		t.Fatalf("%d sends were made, want 6", sends)
+	}
+}
@@ -0,0 +1,141 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// InvoiceListStates are the states invoices can be listed by, which include the states found from the payments to
+// their address.
+var InvoiceListStates = []string{InvoiceOpen, InvoicePartial, InvoicePaid, InvoiceExpired, InvoiceCancelled}
+
+// Invoice is a payment request made with an address of the wallet, with the payments made to the address. The State of
+// the metadata is the state found from the payments rather than the one stored.
+type Invoice struct {
+	AddressMeta
+	// Received is the amount paid to the address in transactions with enough confirmations, and Pending the amount
+	// paid in transactions that do not have enough yet.
+	Received amt.Amount
+	Pending  amt.Amount
+	// Payments are the hashes of the transactions paying to the address, and LastPayment the time the latest of them
+	// was seen by the wallet.
+	Payments    []chainhash.Hash
+	LastPayment time.Time
+}
+
+// ListInvoices returns the receive entries of the address metadata as invoices, oldest first, with the payments to
+// their addresses. Payments count towards the amount requested when they have minConf confirmations. If state is not
+// empty, only the invoices in that state are returned.
+func (w *Wallet) ListInvoices(state string, minConf int32) (invoices []Invoice, e error) {
+	if state != "" {
+		if e = checkInvoiceListState(state); E.Chk(e) {
+			return
+		}
+	}
+	var metas []AddressMeta
+	if metas, e = w.ListAddressMeta(AddressMetaReceive); E.Chk(e) {
+		return
+	}
+	all := make([]Invoice, len(metas))
+	byAddress := make(map[string]*Invoice, len(metas))
+	for i := range metas {
+		all[i].AddressMeta = metas[i]
+		byAddress[metas[i].Address] = &all[i]
+	}
+	if e = w.addInvoicePayments(byAddress, minConf); E.Chk(e) {
+		return
+	}
+	now := time.Now()
+	for i := range all {
+		all[i].State = invoiceState(&all[i].AddressMeta, all[i].Received, now)
+		if state == "" || all[i].State == state {
+			invoices = append(invoices, all[i])
+		}
+	}
+	return
+}
+
+// addInvoicePayments adds the credits of the wallet paying to the addresses of the invoices to them, all in one pass
+// over the transactions of the wallet.
+func (w *Wallet) addInvoicePayments(byAddress map[string]*Invoice, minConf int32) (e error) {
+	if len(byAddress) == 0 {
+		return
+	}
+	return walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncHeight := w.Manager.SyncedTo().Height
+			rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+				for i := range details {
+					detail := &details[i]
+					for _, cred := range detail.Credits {
+						pkScript := detail.MsgTx.TxOut[cred.Index].PkScript
+						var addrs []btcaddr.Address
+						var ee error
+						// An error creating addresses from the output script only indicates a non-standard script, so
+						// ignore this credit.
+						if _, addrs, _, ee = txscript.ExtractPkScriptAddrs(pkScript, w.chainParams); ee != nil {
+							continue
+						}
+						for _, a := range addrs {
+							inv, ok := byAddress[a.EncodeAddress()]
+							if !ok {
+								continue
+							}
+							if confirmed(minConf, detail.Block.Height, syncHeight) {
+								inv.Received += cred.Amount
+							} else {
+								inv.Pending += cred.Amount
+							}
+							if n := len(inv.Payments); n == 0 || inv.Payments[n-1] != detail.Hash {
+								inv.Payments = append(inv.Payments, detail.Hash)
+							}
+							if detail.Received.After(inv.LastPayment) {
+								inv.LastPayment = detail.Received
+							}
+							break
+						}
+					}
+				}
+				return false, nil
+			}
+			return w.TxStore.RangeTransactions(txmgrNs, 0, -1, rangeFn)
+		},
+	)
+}
+
+// invoiceState returns the state of the payment request at the time now given the amount paid to its address. A
+// request that was cancelled or marked paid keeps that state, otherwise it is paid once the amount requested has been
+// received, partially paid when some of it has, and expired when nothing was paid before it expired.
+func invoiceState(m *AddressMeta, received amt.Amount, now time.Time) string {
+	switch {
+	case m.State == InvoiceCancelled, m.State == InvoicePaid:
+		return m.State
+	case received > 0 && received >= m.Amount:
+		return InvoicePaid
+	case received > 0:
+		return InvoicePartial
+	case !m.Expires.IsZero() && now.After(m.Expires):
+		return InvoiceExpired
+	}
+	return InvoiceOpen
+}
+
+// checkInvoiceListState returns an error if invoices can't be listed by the state.
+func checkInvoiceListState(state string) error {
+	for _, s := range InvoiceListStates {
+		if state == s {
+			return nil
+		}
+	}
+	return InvalidParameterError{
+		fmt.Errorf("unknown invoice state %q, must be one of %v", state, InvoiceListStates),
+	}
+}
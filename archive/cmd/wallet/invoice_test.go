@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+)
+
+// TestInvoiceState ensures the state of an invoice is found from the amount paid to its address, its expiry and the
+// state stored for it.
+func TestInvoiceState(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name     string
+		meta     AddressMeta
+		received amt.Amount
+		want     string
+	}{
+		{"unpaid", AddressMeta{Amount: 100, State: InvoiceOpen}, 0, InvoiceOpen},
+		{"not yet expired", AddressMeta{Amount: 100, State: InvoiceOpen, Expires: future}, 0, InvoiceOpen},
+		{"expired", AddressMeta{Amount: 100, State: InvoiceOpen, Expires: past}, 0, InvoiceExpired},
+		{"partially paid", AddressMeta{Amount: 100, State: InvoiceOpen}, 40, InvoicePartial},
+		{"partially paid after expiry", AddressMeta{Amount: 100, State: InvoiceOpen, Expires: past}, 40, InvoicePartial},
+		{"paid", AddressMeta{Amount: 100, State: InvoiceOpen}, 100, InvoicePaid},
+		{"overpaid", AddressMeta{Amount: 100, State: InvoiceOpen, Expires: past}, 150, InvoicePaid},
+		{"no amount requested", AddressMeta{State: InvoiceOpen}, 1, InvoicePaid},
+		{"marked paid", AddressMeta{Amount: 100, State: InvoicePaid}, 0, InvoicePaid},
+		{"cancelled", AddressMeta{Amount: 100, State: InvoiceCancelled}, 100, InvoiceCancelled},
+	}
+	for _, test := range tests {
+		if got := invoiceState(&test.meta, test.received, now); got != test.want {
+			t.Errorf("%s: got state %q, want %q", test.name, got, test.want)
+		}
+	}
+	if e := checkInvoiceListState(InvoicePartial); e != nil {
+		t.Errorf("invoices can't be listed by state %q: %v", InvoicePartial, e)
+	}
+	if e := checkInvoiceListState("bogus"); e == nil {
+		t.Error("invoices can be listed by an unknown state")
+	}
+}
@@ -0,0 +1,369 @@
+package wallet
+
+import (
+	"encoding/binary"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// maxInvoiceReference is the longest reference, in bytes, an invoice address can be reserved with.
+const maxInvoiceReference = 256
+
+// The records of sequential issuance are kept in their own namespace of the wallet database, under keys starting with
+// one of these prefixes followed by the account number in big endian order.
+const (
+	// issuanceAccountPrefix keys the record of an account that issues its addresses sequentially.
+	issuanceAccountPrefix = 'a'
+	// issuanceReservationPrefix keys a reserved address by its index, also in big endian order.
+	issuanceReservationPrefix = 'r'
+	// issuanceReferencePrefix keys the index of a reserved address by the reference it was reserved with.
+	issuanceReferencePrefix = 'n'
+)
+
+// InvoiceReservation is an external address of an account that issues its addresses sequentially, reserved for the
+// reference of an order or invoice until it is released.
+type InvoiceReservation struct {
+	Address   string
+	Account   uint32
+	Index     uint32
+	Reference string
+	Reserved  time.Time
+}
+
+// issuanceRecord is the encoding of an account that issues its addresses sequentially. Free are the indexes of the
+// addresses that were released, lowest first, which are issued again before the next address of the account is made,
+// so the indexes issued have no gaps.
+type issuanceRecord struct {
+	Enabled int64    `json:"enabled"`
+	Free    []uint32 `json:"free,omitempty"`
+}
+
+// reservationRecord is the encoding of a reserved address.
+type reservationRecord struct {
+	Reference string `json:"reference,omitempty"`
+	Reserved  int64  `json:"reserved"`
+}
+
+// issuanceKey returns the key of a record with the prefix for the account, followed by the suffix.
+func issuanceKey(prefix byte, account uint32, suffix []byte) []byte {
+	k := make([]byte, 5, 5+len(suffix))
+	k[0] = prefix
+	binary.BigEndian.PutUint32(k[1:], account)
+	return append(k, suffix...)
+}
+
+// reservationKey returns the key of the reservation of the address of the account with the index.
+func reservationKey(account, index uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], index)
+	return issuanceKey(issuanceReservationPrefix, account, b[:])
+}
+
+// getIssuance returns the issuance record of the account, or nil if it does not issue its addresses sequentially.
+func getIssuance(ns walletdb.ReadBucket, account uint32) (rec *issuanceRecord, e error) {
+	if ns == nil {
+		return
+	}
+	v := ns.Get(issuanceKey(issuanceAccountPrefix, account, nil))
+	if v == nil {
+		return
+	}
+	rec = &issuanceRecord{}
+	if e = js.Unmarshal(v, rec); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// putIssuance stores the issuance record of the account.
+func putIssuance(ns walletdb.ReadWriteBucket, account uint32, rec *issuanceRecord) (e error) {
+	var v []byte
+	if v, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	return ns.Put(issuanceKey(issuanceAccountPrefix, account, nil), v)
+}
+
+// checkNotSequential returns an error if the account of the scope issues its addresses sequentially, as its addresses
+// are then only handed out by ReserveInvoiceAddress, so every address issued is mapped to a reservation.
+func checkNotSequential(tx walletdb.ReadTx, scope waddrmgr.KeyScope, account uint32) (e error) {
+	if scope != waddrmgr.KeyScopeBIP0044 {
+		return
+	}
+	var rec *issuanceRecord
+	if rec, e = getIssuance(tx.ReadBucket(invoiceIssueNamespaceKey), account); E.Chk(e) {
+		return
+	}
+	if rec != nil {
+		return errors.New("the account issues its addresses sequentially, they are handed out with reserveinvoiceaddress")
+	}
+	return
+}
+
+// SetSequentialIssuance switches the sequential issuance of the external addresses of the BIP0044 account on or off.
+// While it is on, addresses of the account are only handed out by ReserveInvoiceAddress, strictly in order of their
+// index, and released addresses are handed out again before new ones, so merchant systems that map orders to address
+// indexes see neither gaps nor duplicates. Switching it off forgets the reservations of the account.
+func (w *Wallet) SetSequentialIssuance(account uint32, enable bool) (e error) {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(invoiceIssueNamespaceKey)
+			if ns == nil {
+				if !enable {
+					return nil
+				}
+				if ns, e = tx.CreateTopLevelBucket(invoiceIssueNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			var rec *issuanceRecord
+			if rec, e = getIssuance(ns, account); E.Chk(e) {
+				return
+			}
+			if enable {
+				if rec != nil {
+					return nil
+				}
+				// the account must exist before it can issue addresses
+				var manager *waddrmgr.ScopedKeyManager
+				if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+					return
+				}
+				addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+				if _, e = manager.AccountName(addrmgrNs, account); E.Chk(e) {
+					return
+				}
+				return putIssuance(ns, account, &issuanceRecord{Enabled: time.Now().UnixNano()})
+			}
+			if rec == nil {
+				return nil
+			}
+			// the reservations and references of the account are all keyed under its prefixes
+			var keys [][]byte
+			for _, prefix := range []byte{issuanceReservationPrefix, issuanceReferencePrefix} {
+				p := issuanceKey(prefix, account, nil)
+				c := ns.ReadCursor()
+				for k, _ := c.Seek(p); k != nil && len(k) >= len(p) && string(k[:len(p)]) == string(p); k, _ = c.Next() {
+					keys = append(keys, append([]byte{}, k...))
+				}
+			}
+			keys = append(keys, issuanceKey(issuanceAccountPrefix, account, nil))
+			for _, k := range keys {
+				if e = ns.Delete(k); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+}
+
+// ReserveInvoiceAddress reserves the next external address of the account, which must issue its addresses
+// sequentially, for the reference. The address is the lowest of those released, or the next address of the account if
+// none are, and the issuance pointer of the account is moved past it in the same database transaction the reservation
+// is stored in, so concurrent reservations are never given the same address. Reserving again with a reference that is
+// reserved returns the address it was reserved first, so a request that is retried does not use up another address.
+func (w *Wallet) ReserveInvoiceAddress(account uint32, reference string) (r *InvoiceReservation, e error) {
+	if len(reference) > maxInvoiceReference {
+		return nil, InvalidParameterError{errors.New("the reference of an invoice address is too long")}
+	}
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	var addr btcaddr.Address
+	var props *waddrmgr.AccountProperties
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(invoiceIssueNamespaceKey)
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			var rec *issuanceRecord
+			if rec, e = getIssuance(ns, account); E.Chk(e) {
+				return
+			}
+			if rec == nil {
+				return InvalidParameterError{
+					errors.New("the account does not issue its addresses sequentially, switch it on with setinvoiceissuance"),
+				}
+			}
+			var refKey []byte
+			if reference != "" {
+				refKey = issuanceKey(issuanceReferencePrefix, account, []byte(reference))
+				if v := ns.Get(refKey); v != nil {
+					r, e = getReservation(ns, manager, addrmgrNs, account, binary.BigEndian.Uint32(v))
+					return
+				}
+			}
+			var index uint32
+			if len(rec.Free) > 0 {
+				index, rec.Free = rec.Free[0], rec.Free[1:]
+				if e = putIssuance(ns, account, rec); E.Chk(e) {
+					return
+				}
+			} else {
+				var addrs []waddrmgr.ManagedAddress
+				if addrs, e = manager.NextExternalAddresses(addrmgrNs, account, 1); E.Chk(e) {
+					return
+				}
+				ma, ok := addrs[0].(waddrmgr.ManagedPubKeyAddress)
+				if !ok {
+					return fmt.Errorf("address %s has no key", addrs[0].Address().EncodeAddress())
+				}
+				_, path, _ := ma.DerivationInfo()
+				index, addr = path.Index, ma.Address()
+				if props, e = manager.AccountProperties(addrmgrNs, account); E.Chk(e) {
+					return
+				}
+			}
+			res := reservationRecord{Reference: reference, Reserved: time.Now().UnixNano()}
+			var v []byte
+			if v, e = js.Marshal(res); E.Chk(e) {
+				return
+			}
+			if e = ns.Put(reservationKey(account, index), v); E.Chk(e) {
+				return
+			}
+			if refKey != nil {
+				var b [4]byte
+				binary.BigEndian.PutUint32(b[:], index)
+				if e = ns.Put(refKey, b[:]); E.Chk(e) {
+					return
+				}
+			}
+			r, e = getReservation(ns, manager, addrmgrNs, account, index)
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	// a new address of the account is watched for payments as getnewaddress does, released addresses already are
+	if props != nil {
+		if chainClient := w.ChainClient(); chainClient != nil {
+			if e = chainClient.NotifyReceived([]btcaddr.Address{addr}); E.Chk(e) {
+				return nil, e
+			}
+			w.NtfnServer.notifyAccountProperties(props)
+		}
+	}
+	return
+}
+
+// getReservation returns the reservation of the address of the account with the index.
+func getReservation(
+	ns walletdb.ReadBucket, manager *waddrmgr.ScopedKeyManager, addrmgrNs walletdb.ReadBucket, account, index uint32,
+) (r *InvoiceReservation, e error) {
+	v := ns.Get(reservationKey(account, index))
+	if v == nil {
+		return nil, fmt.Errorf("address %d of account %d is not reserved", index, account)
+	}
+	var rec reservationRecord
+	if e = js.Unmarshal(v, &rec); E.Chk(e) {
+		return
+	}
+	var ma waddrmgr.ManagedAddress
+	if ma, e = manager.DeriveFromKeyPath(
+		addrmgrNs, waddrmgr.DerivationPath{Account: account, Branch: waddrmgr.ExternalBranch, Index: index},
+	); E.Chk(e) {
+		return
+	}
+	return &InvoiceReservation{
+		Address:   ma.Address().EncodeAddress(),
+		Account:   account,
+		Index:     index,
+		Reference: rec.Reference,
+		Reserved:  time.Unix(0, rec.Reserved),
+	}, nil
+}
+
+// ReleaseInvoiceAddress releases the reservation of the address, so it is the next address reserved in its account.
+// Addresses that have been paid are not released, as handing them out again would mix the payments of two orders.
+func (w *Wallet) ReleaseInvoiceAddress(addr btcaddr.Address) (e error) {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(invoiceIssueNamespaceKey)
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			var ma waddrmgr.ManagedAddress
+			if ma, e = w.Manager.Address(addrmgrNs, addr); E.Chk(e) {
+				return
+			}
+			pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return InvalidParameterError{errors.New("the address is not a reserved invoice address")}
+			}
+			scope, path, known := pka.DerivationInfo()
+			if !known || scope != waddrmgr.KeyScopeBIP0044 || path.Branch != waddrmgr.ExternalBranch {
+				return InvalidParameterError{errors.New("the address is not a reserved invoice address")}
+			}
+			var rec *issuanceRecord
+			if rec, e = getIssuance(ns, path.Account); E.Chk(e) {
+				return
+			}
+			key := reservationKey(path.Account, path.Index)
+			if rec == nil || ns.Get(key) == nil {
+				return InvalidParameterError{errors.New("the address is not a reserved invoice address")}
+			}
+			if ma.Used(addrmgrNs) {
+				return InvalidParameterError{errors.New("the address has been paid, so it can't be handed out again")}
+			}
+			var res reservationRecord
+			if e = js.Unmarshal(ns.Get(key), &res); E.Chk(e) {
+				return
+			}
+			if e = ns.Delete(key); E.Chk(e) {
+				return
+			}
+			if res.Reference != "" {
+				if e = ns.Delete(issuanceKey(issuanceReferencePrefix, path.Account, []byte(res.Reference))); E.Chk(e) {
+					return
+				}
+			}
+			rec.Free = append(rec.Free, path.Index)
+			sort.Slice(
+				rec.Free, func(i, j int) bool {
+					return rec.Free[i] < rec.Free[j]
+				},
+			)
+			return putIssuance(ns, path.Account, rec)
+		},
+	)
+}
+
+// ListInvoiceReservations returns the reserved addresses of the account in order of their index.
+func (w *Wallet) ListInvoiceReservations(account uint32) (rs []InvoiceReservation, e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(invoiceIssueNamespaceKey)
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			var rec *issuanceRecord
+			if rec, e = getIssuance(ns, account); E.Chk(e) {
+				return
+			}
+			if rec == nil {
+				return InvalidParameterError{errors.New("the account does not issue its addresses sequentially")}
+			}
+			p := issuanceKey(issuanceReservationPrefix, account, nil)
+			c := ns.ReadCursor()
+			for k, _ := c.Seek(p); len(k) == len(p)+4 && string(k[:len(p)]) == string(p); k, _ = c.Next() {
+				var r *InvoiceReservation
+				if r, e = getReservation(ns, manager, addrmgrNs, account, binary.BigEndian.Uint32(k[len(p):])); E.Chk(e) {
+					return
+				}
+				rs = append(rs, *r)
+			}
+			return
+		},
+	)
+	return
+}
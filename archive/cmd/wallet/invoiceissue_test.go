@@ -0,0 +1,119 @@
+package wallet
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestInvoiceIssuance ensures addresses are reserved strictly in order of their index when reserved concurrently,
+// reserving with a reference twice returns the same address, released addresses are reserved again lowest first, and
+// addresses of the account can't be handed out any other way while it issues them sequentially.
+func TestInvoiceIssuance(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	params := &chaincfg.RegressionTestParams
+	w.chainParams = params
+	e := walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns, e := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+			if e != nil {
+				return e
+			}
+			seed := make([]byte, 32)
+			if e = waddrmgr.Create(
+				ns, seed, []byte{}, []byte("priv"), params, &waddrmgr.ScryptOptions{N: 16, R: 8, P: 1}, time.Now(),
+			); e != nil {
+				return e
+			}
+			w.Manager, e = waddrmgr.Open(ns, []byte{}, params)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer w.Manager.Close()
+	account := uint32(waddrmgr.DefaultAccountNum)
+	if _, e = w.ReserveInvoiceAddress(account, "order 1"); e == nil {
+		t.Fatal("reserved an address in an account that does not issue its addresses sequentially")
+	}
+	if e = w.SetSequentialIssuance(account, true); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = w.NewAddress(account, waddrmgr.KeyScopeBIP0044, true); e == nil {
+		t.Fatal("made a new address in an account that issues its addresses sequentially")
+	}
+	const reservations = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, reservations)
+	for i := 0; i < reservations; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, e := w.ReserveInvoiceAddress(account, ""); e != nil {
+				errs <- e
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for e = range errs {
+		t.Fatal(e)
+	}
+	rs, e := w.ListInvoiceReservations(account)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(rs) != reservations {
+		t.Fatalf("got %d reservations, want %d", len(rs), reservations)
+	}
+	for i := range rs {
+		if rs[i].Index != uint32(i) {
+			t.Fatalf("reservation %d has index %d", i, rs[i].Index)
+		}
+	}
+	r, e := w.ReserveInvoiceAddress(account, "order 11")
+	if e != nil {
+		t.Fatal(e)
+	}
+	again, e := w.ReserveInvoiceAddress(account, "order 11")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if r.Index != reservations || again.Address != r.Address {
+		t.Fatalf("got %+v and %+v reserving with the same reference, want index %d twice", r, again, reservations)
+	}
+	for _, i := range []int{7, 3} {
+		var addr btcaddr.Address
+		if addr, e = btcaddr.Decode(rs[i].Address, params); e != nil {
+			t.Fatal(e)
+		}
+		if e = w.ReleaseInvoiceAddress(addr); e != nil {
+			t.Fatal(e)
+		}
+		if e = w.ReleaseInvoiceAddress(addr); e == nil {
+			t.Fatal("released an address that is not reserved")
+		}
+	}
+	for _, want := range []uint32{3, 7, reservations + 1} {
+		if r, e = w.ReserveInvoiceAddress(account, ""); e != nil {
+			t.Fatal(e)
+		}
+		if r.Index != want {
+			t.Fatalf("reserved index %d, want %d", r.Index, want)
+		}
+	}
+	if e = w.SetSequentialIssuance(account, false); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = w.ListInvoiceReservations(account); e == nil {
+		t.Fatal("listed the reservations of an account that does not issue its addresses sequentially")
+	}
+}
@@ -0,0 +1,255 @@
+package wallet
+
+import (
+	"encoding/binary"
+	js "encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// The types of the events in the notification journal.
+const (
+	// JournalBlockConnected is the type of the event of a block the wallet was synced to.
+	JournalBlockConnected = "blockconnected"
+	// JournalBlockDisconnected is the type of the event of a block that was removed from the chain the wallet is synced
+	// to.
+	JournalBlockDisconnected = "blockdisconnected"
+	// JournalTx is the type of the event of a transaction relevant to the wallet that was added to it, or mined.
+	JournalTx = "tx"
+)
+
+const (
+	// maxJournalEvents is the number of the most recent events kept in the notification journal. The oldest event is
+	// removed as each new one is added.
+	maxJournalEvents = 10000
+	// journalPollInterval is how often a client of the notification journal looks for new events when it is not woken
+	// by one, which picks up events added by database transactions that do not wake the clients.
+	journalPollInterval = time.Second * 5
+	// journalBatch is the number of events read from the notification journal at once.
+	journalBatch = 100
+)
+
+var (
+	// ErrJournalPruned is returned for events of the notification journal that were removed to make room for new ones.
+	ErrJournalPruned = errors.New("events after the sequence number were removed from the notification journal")
+	// ErrJournalUnknown is returned for a sequence number after the last event of the notification journal, or a block
+	// whose connection is not in it.
+	ErrJournalUnknown = errors.New("the notification journal has no such event")
+)
+
+// JournalEvent is an event of the notification journal, which records the changes to the wallet in the database
+// transactions that make them, in the order they are made, so a client that missed events while it was disconnected
+// can be sent them again. BlockHash is nil and Height is -1 for a transaction that is not mined.
+type JournalEvent struct {
+	Sequence  uint64
+	Type      string
+	Time      time.Time
+	BlockHash *chainhash.Hash
+	Height    int32
+	TxHash    *chainhash.Hash
+}
+
+// journalRecord is the encoding of a journal event in the database, which is keyed by its sequence number.
+type journalRecord struct {
+	Type   string `json:"type"`
+	Time   int64  `json:"time"`
+	Block  string `json:"block,omitempty"`
+	Height int32  `json:"height"`
+	TxID   string `json:"txid,omitempty"`
+}
+
+// journalWaker wakes the clients of the notification journal when events are added to it.
+type journalWaker struct {
+	mtx  sync.Mutex
+	wake chan struct{}
+}
+
+// wait returns a channel that is closed the next time the clients are woken.
+func (j *journalWaker) wait() <-chan struct{} {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if j.wake == nil {
+		j.wake = make(chan struct{})
+	}
+	return j.wake
+}
+
+// notify wakes the clients. It is called once the database transactions that add events are committed, as the events
+// can't be read before.
+func (j *journalWaker) notify() {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if j.wake != nil {
+		close(j.wake)
+		j.wake = nil
+	}
+}
+
+// appendJournal adds an event to the notification journal in the database transaction of the change it records, and
+// removes the oldest event if the journal is full. The journal is kept in its own namespace of the wallet database,
+// which is created with the first event.
+func appendJournal(dbtx walletdb.ReadWriteTx, typ string, block *chainhash.Hash, height int32, tx *chainhash.Hash) (
+	e error,
+) {
+	ns := dbtx.ReadWriteBucket(journalNamespaceKey)
+	if ns == nil {
+		if ns, e = dbtx.CreateTopLevelBucket(journalNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	rec := journalRecord{Type: typ, Time: time.Now().Unix(), Height: height}
+	if block != nil {
+		rec.Block = block.String()
+	}
+	if tx != nil {
+		rec.TxID = tx.String()
+	}
+	var body []byte
+	if body, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	seq := uint64(1)
+	if k, _ := ns.ReadCursor().Last(); k != nil {
+		if len(k) != 8 {
+			return errors.New("corrupt notification journal entry")
+		}
+		seq = binary.BigEndian.Uint64(k) + 1
+	}
+	if e = ns.Put(auditKey(seq), body); E.Chk(e) {
+		return
+	}
+	if seq > maxJournalEvents {
+		return ns.Delete(auditKey(seq - maxJournalEvents))
+	}
+	return
+}
+
+// JournalEvents returns at most count events of the notification journal after the sequence number since, in order.
+// It returns ErrJournalPruned if events after since were removed from the journal, and ErrJournalUnknown if since is
+// after the last event, so a client is never silently sent less than all the events it missed.
+func (w *Wallet) JournalEvents(since uint64, count int) (events []JournalEvent, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(journalNamespaceKey)
+			if ns == nil {
+				if since != 0 {
+					return ErrJournalUnknown
+				}
+				return
+			}
+			c := ns.ReadCursor()
+			first, _ := c.First()
+			last, _ := c.Last()
+			if first == nil {
+				if since != 0 {
+					return ErrJournalUnknown
+				}
+				return
+			}
+			if since+1 < binary.BigEndian.Uint64(first) {
+				return ErrJournalPruned
+			}
+			if since > binary.BigEndian.Uint64(last) {
+				return ErrJournalUnknown
+			}
+			for k, v := c.Seek(auditKey(since + 1)); k != nil && len(events) < count; k, v = c.Next() {
+				var rec journalRecord
+				if e = js.Unmarshal(v, &rec); E.Chk(e) {
+					return
+				}
+				ev := JournalEvent{
+					Sequence: binary.BigEndian.Uint64(k),
+					Type:     rec.Type,
+					Time:     time.Unix(rec.Time, 0),
+					Height:   rec.Height,
+				}
+				if rec.Block != "" {
+					if ev.BlockHash, e = chainhash.NewHashFromStr(rec.Block); E.Chk(e) {
+						return
+					}
+				}
+				if rec.TxID != "" {
+					if ev.TxHash, e = chainhash.NewHashFromStr(rec.TxID); E.Chk(e) {
+						return
+					}
+				}
+				events = append(events, ev)
+			}
+			return
+		},
+	)
+	return
+}
+
+// JournalBlockSequence returns the sequence number of the last connection of the block in the notification journal,
+// or ErrJournalUnknown if it is not in the journal.
+func (w *Wallet) JournalBlockSequence(hash *chainhash.Hash) (seq uint64, e error) {
+	block := hash.String()
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(journalNamespaceKey)
+			if ns == nil {
+				return ErrJournalUnknown
+			}
+			c := ns.ReadCursor()
+			for k, v := c.Last(); k != nil; k, v = c.Prev() {
+				var rec journalRecord
+				if e = js.Unmarshal(v, &rec); E.Chk(e) {
+					return
+				}
+				if rec.Type == JournalBlockConnected && rec.Block == block {
+					seq = binary.BigEndian.Uint64(k)
+					return
+				}
+			}
+			return ErrJournalUnknown
+		},
+	)
+	return
+}
+
+// JournalLastSequence returns the sequence number of the last event of the notification journal, which is zero if the
+// journal is empty.
+func (w *Wallet) JournalLastSequence() (seq uint64, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(journalNamespaceKey)
+			if ns == nil {
+				return
+			}
+			if k, _ := ns.ReadCursor().Last(); k != nil {
+				seq = binary.BigEndian.Uint64(k)
+			}
+			return
+		},
+	)
+	return
+}
+
+// JournalWait returns a channel that is closed when events are next added to the notification journal. Events added
+// by some database transactions do not close it, so a client of the journal must also look for events now and then.
+func (w *Wallet) JournalWait() <-chan struct{} {
+	return w.journal.wait()
+}
+
+// result returns the event as it is sent in a walletevent notification.
+func (ev *JournalEvent) result() btcjson.WalletEventResult {
+	r := btcjson.WalletEventResult{
+		Sequence: ev.Sequence,
+		Type:     ev.Type,
+		Time:     ev.Time.Unix(),
+		Height:   ev.Height,
+	}
+	if ev.BlockHash != nil {
+		r.BlockHash = ev.BlockHash.String()
+	}
+	if ev.TxHash != nil {
+		r.TxID = ev.TxHash.String()
+	}
+	return r
+}
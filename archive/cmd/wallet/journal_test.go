@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestJournal ensures events of the notification journal are read back in order after a sequence number or a block,
+// and that sequence numbers the journal can't replay from are refused rather than replaying less than was missed.
+func TestJournal(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	if seq, e := w.JournalLastSequence(); e != nil || seq != 0 {
+		t.Fatalf("last sequence of an empty journal is %d with error %v", seq, e)
+	}
+	_, e := w.JournalEvents(1, 1)
+	if e != ErrJournalUnknown {
+		t.Fatalf("events after a sequence number of an empty journal read with error %v", e)
+	}
+	block := chainhash.Hash{1}
+	tx := chainhash.Hash{2}
+	if e = walletdb.Update(
+		db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			if e = appendJournal(dbtx, JournalBlockConnected, &block, 5, nil); e != nil {
+				return
+			}
+			if e = appendJournal(dbtx, JournalTx, &block, 5, &tx); e != nil {
+				return
+			}
+			return appendJournal(dbtx, JournalBlockDisconnected, &block, 5, nil)
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	events, e := w.JournalEvents(0, journalBatch)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(events) != 3 || events[0].Type != JournalBlockConnected || events[1].Type != JournalTx ||
+		events[2].Type != JournalBlockDisconnected {
+		t.Fatalf("events are %+v", events)
+	}
+	if events[1].Sequence != 2 || *events[1].TxHash != tx || *events[1].BlockHash != block || events[1].Height != 5 {
+		t.Fatalf("transaction event is %+v", events[1])
+	}
+	seq, e := w.JournalBlockSequence(&block)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if events, e = w.JournalEvents(seq, 1); e != nil {
+		t.Fatal(e)
+	}
+	if len(events) != 1 || events[0].Sequence != 2 {
+		t.Fatalf("events after the block are %+v", events)
+	}
+	if _, e = w.JournalBlockSequence(&tx); e != ErrJournalUnknown {
+		t.Fatalf("sequence of a block not in the journal read with error %v", e)
+	}
+	if _, e = w.JournalEvents(4, 1); e != ErrJournalUnknown {
+		t.Fatalf("events after the last read with error %v", e)
+	}
+	// Once the journal is full the oldest events are removed, and can't be replayed.
+	if e = walletdb.Update(
+		db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			for i := 0; i < maxJournalEvents; i++ {
+				if e = appendJournal(dbtx, JournalTx, nil, -1, &tx); e != nil {
+					return
+				}
+			}
+			return
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = w.JournalEvents(1, 1); e != ErrJournalPruned {
+		t.Fatalf("pruned events read with error %v", e)
+	}
+	if events, e = w.JournalEvents(3, 1); e != nil {
+		t.Fatal(e)
+	}
+	if len(events) != 1 || events[0].Sequence != 4 || events[0].BlockHash != nil {
+		t.Fatalf("oldest event kept is %+v", events)
+	}
+}
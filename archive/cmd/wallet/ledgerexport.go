@@ -0,0 +1,400 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+const (
+	// LedgerFormat is the export format of ledger-cli and hledger journals.
+	LedgerFormat = "ledger"
+	// BeancountFormat is the export format of beancount ledgers.
+	BeancountFormat = "beancount"
+)
+
+// The accounts of the exported journal. The balances of wallet accounts are kept under ledgerAssets, and payments
+// received and sent are put under ledgerReceived and ledgerPayments along with the label of the other address, if it
+// has one.
+const (
+	ledgerAssets   = "Assets:Wallet"
+	ledgerMining   = "Income:Mining"
+	ledgerReceived = "Income:Received"
+	ledgerPayments = "Expenses:Payments"
+	ledgerFees     = "Expenses:Fees"
+)
+
+// beancountCommodity matches the commodity names beancount accepts.
+var beancountCommodity = regexp.MustCompile(`^[A-Z][A-Z0-9'._-]{0,22}[A-Z0-9]$`)
+
+// ledgerPosting is a change to the balance of an account of the journal.
+type ledgerPosting struct {
+	account string
+	amount  amt.Amount
+}
+
+// ledgerEntry is the journal entry of a transaction, whose postings add up to zero.
+type ledgerEntry struct {
+	date      time.Time
+	pending   bool
+	narration string
+	txid      string
+	postings  []ledgerPosting
+}
+
+// ledgerJournal turns the transactions of the wallet into journal entries, in the order of the blocks.
+type ledgerJournal struct {
+	// account returns the name of the wallet account a script pays to, and label the label of its address, both empty
+	// if there is none.
+	account, label func(pkScript []byte) string
+	// outputs are the journal accounts of the credits seen so far, so the debits that spend them are taken from the
+	// same account.
+	outputs map[wire.OutPoint]string
+	entries []ledgerEntry
+}
+
+// newLedgerJournal returns a ledgerJournal that finds the wallet account and label of an output with account and label.
+func newLedgerJournal(account, label func(pkScript []byte) string) *ledgerJournal {
+	return &ledgerJournal{
+		account: account,
+		label:   label,
+		outputs: make(map[wire.OutPoint]string),
+	}
+}
+
+// block adds the entries of the transactions of a block. The credits are recorded before the entries are made as the
+// transactions of a block may spend each other's outputs.
+func (j *ledgerJournal) block(details []wtxmgr.TxDetails) {
+	for i := range details {
+		d := &details[i]
+		for _, credit := range d.Credits {
+			j.outputs[wire.OutPoint{Hash: d.Hash, Index: credit.Index}] =
+				ledgerAccount(ledgerAssets, j.account(d.MsgTx.TxOut[credit.Index].PkScript))
+		}
+	}
+	for i := range details {
+		if entry, ok := j.entry(&details[i]); ok {
+			j.entries = append(j.entries, entry)
+		}
+	}
+}
+
+// entry makes the journal entry of a transaction. Credits and debits are posted to the wallet accounts the outputs
+// belong to, and balanced by income for a transaction that only pays the wallet, or by payments and the fee for one
+// the wallet spent. When the wallet did not spend all of the inputs the fee is not known, and only the net change to
+// the balance of the wallet is posted as a payment or income.
+func (j *ledgerJournal) entry(d *wtxmgr.TxDetails) (entry ledgerEntry, ok bool) {
+	entry = ledgerEntry{
+		date:    d.Received,
+		pending: d.Block.Height == -1,
+		txid:    d.Hash.String(),
+	}
+	if !entry.pending {
+		entry.date = d.Block.Time
+	}
+	var postings []ledgerPosting
+	var labels []string
+	counterpart := func(root string, pkScript []byte) string {
+		label := j.label(pkScript)
+		if label != "" {
+			labels = append(labels, label)
+		}
+		return ledgerAccount(root, label)
+	}
+	credited := make(map[uint32]bool)
+	var credits, debits amt.Amount
+	for _, credit := range d.Credits {
+		credited[credit.Index] = true
+		credits += credit.Amount
+		postings = append(
+			postings, ledgerPosting{j.outputs[wire.OutPoint{Hash: d.Hash, Index: credit.Index}], credit.Amount},
+		)
+	}
+	for _, debit := range d.Debits {
+		debits += debit.Amount
+		account, found := j.outputs[d.MsgTx.TxIn[debit.Index].PreviousOutPoint]
+		if !found {
+			// the credit was dropped from the history of the wallet
+			account = ledgerAssets
+		}
+		postings = append(postings, ledgerPosting{account, -debit.Amount})
+	}
+	switch fee, feeKnown := txFee(d); {
+	case len(d.Debits) == 0 && blockchain.IsCoinBaseTx(&d.MsgTx):
+		entry.narration = "Mined"
+		postings = append(postings, ledgerPosting{ledgerMining, -credits})
+	case len(d.Debits) == 0:
+		entry.narration = "Received"
+		for _, credit := range d.Credits {
+			postings = append(
+				postings, ledgerPosting{
+					counterpart(ledgerReceived, d.MsgTx.TxOut[credit.Index].PkScript), -credit.Amount,
+				},
+			)
+		}
+	case feeKnown:
+		entry.narration = "Transfer"
+		for i, output := range d.MsgTx.TxOut {
+			if credited[uint32(i)] {
+				continue
+			}
+			entry.narration = "Sent"
+			postings = append(
+				postings, ledgerPosting{counterpart(ledgerPayments, output.PkScript), amt.Amount(output.Value)},
+			)
+		}
+		postings = append(postings, ledgerPosting{ledgerFees, fee})
+	case debits > credits:
+		entry.narration = "Sent"
+		postings = append(postings, ledgerPosting{ledgerPayments, debits - credits})
+	default:
+		entry.narration = "Received"
+		postings = append(postings, ledgerPosting{ledgerReceived, debits - credits})
+	}
+	if len(labels) > 0 {
+		entry.narration += ": " + strings.Join(uniqueStrings(labels), ", ")
+	}
+	entry.postings = mergePostings(postings)
+	return entry, len(entry.postings) > 0
+}
+
+// mergePostings adds up the postings to the same account, in the order each account is first posted to, and drops
+// those that add up to zero.
+func mergePostings(postings []ledgerPosting) (merged []ledgerPosting) {
+	index := make(map[string]int)
+	for _, p := range postings {
+		if i, ok := index[p.account]; ok {
+			merged[i].amount += p.amount
+			continue
+		}
+		index[p.account] = len(merged)
+		merged = append(merged, p)
+	}
+	n := 0
+	for _, p := range merged {
+		if p.amount != 0 {
+			merged[n] = p
+			n++
+		}
+	}
+	return merged[:n]
+}
+
+// uniqueStrings returns the strings without repeats, in the order they first appear.
+func uniqueStrings(s []string) (unique []string) {
+	seen := make(map[string]bool)
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return
+}
+
+// ledgerAccount returns the journal account under root for a name, such as a wallet account or a label, or root
+// itself if the name has nothing that can be used in an account name.
+func ledgerAccount(root, name string) string {
+	if c := ledgerComponent(name); c != "" {
+		return root + ":" + c
+	}
+	return root
+}
+
+// ledgerComponent turns a name into a component of an account name that both ledger and beancount accept, keeping the
+// letters and digits, joining runs of them with dashes and starting it with a capital.
+func ledgerComponent(name string) string {
+	var b strings.Builder
+	var gap bool
+	for _, r := range name {
+		if r >= utf8.RuneSelf || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			gap = true
+			continue
+		}
+		if gap && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		gap = false
+		b.WriteRune(r)
+	}
+	c := b.String()
+	if c == "" {
+		return ""
+	}
+	return strings.ToUpper(c[:1]) + c[1:]
+}
+
+// ledgerAmount formats an amount exactly, with all eight decimal places.
+func ledgerAmount(a amt.Amount) string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, a/amt.SatoshiPerBitcoin, a%amt.SatoshiPerBitcoin)
+}
+
+// checkLedgerFormat returns an error if the journal cannot be written in the format with the commodity name.
+func checkLedgerFormat(format, commodity string) (e error) {
+	switch format {
+	case LedgerFormat:
+		if commodity == "" || strings.ContainsAny(commodity, "\"\n\t ") {
+			return errors.New("the commodity name must not be empty or contain spaces or quotes")
+		}
+	case BeancountFormat:
+		if !beancountCommodity.MatchString(commodity) {
+			return errors.New(
+				"a beancount commodity name must be 2 to 24 capital letters, digits or ' . _ - and start with a letter",
+			)
+		}
+	default:
+		return fmt.Errorf("the export format must be %q or %q", LedgerFormat, BeancountFormat)
+	}
+	return
+}
+
+// formatLedger writes the entries as a journal in the format, with the amounts in the commodity. Beancount journals
+// open each account on the date of the first entry that posts to it.
+func formatLedger(format, commodity string, entries []ledgerEntry) string {
+	var b strings.Builder
+	clean := strings.NewReplacer("\r", " ", "\n", " ", "\t", " ")
+	if format == BeancountFormat {
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		opened := make(map[string]time.Time)
+		for _, entry := range entries {
+			for _, p := range entry.postings {
+				if d, ok := opened[p.account]; !ok || entry.date.Before(d) {
+					opened[p.account] = entry.date
+				}
+			}
+		}
+		accounts := make([]string, 0, len(opened))
+		for account := range opened {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+		fmt.Fprintf(&b, "option \"operating_currency\" \"%s\"\n\n", commodity)
+		for _, account := range accounts {
+			fmt.Fprintf(&b, "%s open %s %s\n", opened[account].UTC().Format("2006-01-02"), account, commodity)
+		}
+		for _, entry := range entries {
+			flag := "*"
+			if entry.pending {
+				flag = "!"
+			}
+			fmt.Fprintf(
+				&b, "\n%s %s \"%s\"\n  txid: \"%s\"\n", entry.date.UTC().Format("2006-01-02"), flag,
+				quote.Replace(clean.Replace(entry.narration)), entry.txid,
+			)
+			writePostings(&b, "  ", entry.postings, commodity)
+		}
+		return b.String()
+	}
+	if strings.IndexFunc(commodity, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+		commodity = `"` + commodity + `"`
+	}
+	for i, entry := range entries {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		flag := "*"
+		if entry.pending {
+			flag = "!"
+		}
+		fmt.Fprintf(
+			&b, "%s %s %s\n    ; txid: %s\n", entry.date.UTC().Format("2006/01/02"), flag,
+			clean.Replace(entry.narration), entry.txid,
+		)
+		writePostings(&b, "    ", entry.postings, commodity)
+	}
+	return b.String()
+}
+
+// writePostings writes the postings of an entry with their amounts lined up.
+func writePostings(b *strings.Builder, indent string, postings []ledgerPosting, commodity string) {
+	var width, amountWidth int
+	amounts := make([]string, len(postings))
+	for i, p := range postings {
+		amounts[i] = ledgerAmount(p.amount)
+		if len(p.account) > width {
+			width = len(p.account)
+		}
+		if len(amounts[i]) > amountWidth {
+			amountWidth = len(amounts[i])
+		}
+	}
+	for i, p := range postings {
+		fmt.Fprintf(b, "%s%-*s  %*s %s\n", indent, width, p.account, amountWidth, amounts[i], commodity)
+	}
+}
+
+// ExportLedger returns the history of the wallet as a double-entry journal in the ledger or beancount format, with the
+// amounts in the commodity, so it can be imported into plaintext accounting tools. The balance of each wallet account
+// is kept in an asset account, payments are posted to income and expense accounts named after the label of the other
+// address, if it has one, and fees to an expense account. Transactions that are not mined are marked as pending.
+func (w *Wallet) ExportLedger(format, commodity string) (journal string, e error) {
+	if e = checkLedgerFormat(format, commodity); e != nil {
+		return
+	}
+	var metas []AddressMeta
+	if metas, e = w.ListAddressMeta(""); E.Chk(e) {
+		return
+	}
+	labels := make(map[string]string, len(metas))
+	for _, m := range metas {
+		if m.Label != "" {
+			labels[m.Address] = m.Label
+		}
+	}
+	var j *ledgerJournal
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			j = newLedgerJournal(
+				func(pkScript []byte) (name string) {
+					_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+					if e != nil || len(addrs) == 0 {
+						return
+					}
+					manager, account, e := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+					if e != nil {
+						return
+					}
+					if name, e = manager.AccountName(addrmgrNs, account); e != nil {
+						return ""
+					}
+					return
+				},
+				func(pkScript []byte) string {
+					_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+					if e != nil || len(addrs) != 1 {
+						return ""
+					}
+					return labels[addrs[0].EncodeAddress()]
+				},
+			)
+			return w.TxStore.RangeTransactions(
+				txmgrNs, 0, -1, func(details []wtxmgr.TxDetails) (bool, error) {
+					j.block(details)
+					return false, nil
+				},
+			)
+		},
+	)
+	if E.Chk(e) {
+		return
+	}
+	return formatLedger(format, commodity, j.entries), nil
+}
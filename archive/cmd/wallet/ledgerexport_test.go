@@ -0,0 +1,143 @@
+package wallet
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestLedgerJournal ensures mined coins, payments received, payments sent and transfers between accounts are each
+// turned into balanced entries with their fees as expenses, and that the journal is written in both formats.
+func TestLedgerJournal(t *testing.T) {
+	// The first byte of the scripts picks their account and label.
+	accounts := map[byte]string{0: "default", 1: "savings acct"}
+	labels := map[byte]string{1: "alice's shop", 9: "Bob"}
+	j := newLedgerJournal(
+		func(pkScript []byte) string { return accounts[pkScript[0]] },
+		func(pkScript []byte) string { return labels[pkScript[0]] },
+	)
+	day := func(d int) time.Time { return time.Date(2021, 1, d, 12, 0, 0, 0, time.UTC) }
+	details := func(tx wire.MsgTx, height int32, date time.Time) (d wtxmgr.TxDetails) {
+		d.MsgTx, d.Hash, d.Received = tx, tx.TxHash(), date
+		d.Block.Height, d.Block.Time = height, date
+		return
+	}
+	coinbase := details(
+		wire.MsgTx{
+			TxIn: []*wire.TxIn{
+				{PreviousOutPoint: wire.OutPoint{Index: math.MaxUint32}, SignatureScript: []byte{1, 1}},
+			},
+			TxOut: []*wire.TxOut{{Value: 50e8, PkScript: []byte{0}}},
+		}, 1, day(1),
+	)
+	coinbase.Credits = []wtxmgr.CreditRecord{{Amount: 50e8, Index: 0}}
+	received := details(
+		wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}}}},
+			TxOut: []*wire.TxOut{{Value: 10e8, PkScript: []byte{1}}, {Value: 7e8, PkScript: []byte{8}}},
+		}, 2, day(2),
+	)
+	received.Credits = []wtxmgr.CreditRecord{{Amount: 10e8, Index: 0}}
+	sent := details(
+		wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: coinbase.Hash}}},
+			TxOut: []*wire.TxOut{{Value: 20e8, PkScript: []byte{9}}, {Value: 29.9e8, PkScript: []byte{0}}},
+		}, 3, day(3),
+	)
+	sent.Credits = []wtxmgr.CreditRecord{{Amount: 29.9e8, Index: 1, Change: true}}
+	sent.Debits = []wtxmgr.DebitRecord{{Amount: 50e8, Index: 0}}
+	transfer := details(
+		wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: received.Hash}}},
+			TxOut: []*wire.TxOut{{Value: 9.99e8, PkScript: []byte{0}}},
+		}, -1, day(4),
+	)
+	transfer.Credits = []wtxmgr.CreditRecord{{Amount: 9.99e8, Index: 0}}
+	transfer.Debits = []wtxmgr.DebitRecord{{Amount: 10e8, Index: 0}}
+	j.block([]wtxmgr.TxDetails{coinbase})
+	j.block([]wtxmgr.TxDetails{received})
+	j.block([]wtxmgr.TxDetails{sent})
+	j.block([]wtxmgr.TxDetails{transfer})
+	want := []struct {
+		narration string
+		pending   bool
+		postings  []ledgerPosting
+	}{
+		{"Mined", false, []ledgerPosting{{"Assets:Wallet:Default", 50e8}, {"Income:Mining", -50e8}}},
+		{
+			"Received: alice's shop", false,
+			[]ledgerPosting{{"Assets:Wallet:Savings-acct", 10e8}, {"Income:Received:Alice-s-shop", -10e8}},
+		},
+		{
+			"Sent: Bob", false,
+			[]ledgerPosting{{"Assets:Wallet:Default", -20.1e8}, {"Expenses:Payments:Bob", 20e8}, {"Expenses:Fees", 0.1e8}},
+		},
+		{
+			"Transfer", true,
+			[]ledgerPosting{
+				{"Assets:Wallet:Default", 9.99e8}, {"Assets:Wallet:Savings-acct", -10e8}, {"Expenses:Fees", 0.01e8},
+			},
+		},
+	}
+	if len(j.entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(j.entries), len(want))
+	}
+	for i, entry := range j.entries {
+		if entry.narration != want[i].narration || entry.pending != want[i].pending {
+			t.Fatalf("entry %d is %q pending %v, want %q pending %v", i, entry.narration, entry.pending,
+				want[i].narration, want[i].pending)
+		}
+		var sum amt.Amount
+		for k, p := range entry.postings {
+			sum += p.amount
+			if k >= len(want[i].postings) || p != want[i].postings[k] {
+				t.Fatalf("entry %d has postings %+v, want %+v", i, entry.postings, want[i].postings)
+			}
+		}
+		if sum != 0 || len(entry.postings) != len(want[i].postings) {
+			t.Fatalf("entry %d has postings %+v adding up to %v", i, entry.postings, sum)
+		}
+	}
+	ledger := formatLedger(LedgerFormat, "DUO", j.entries[:1])
+	if wantLedger := "2021/01/01 * Mined\n    ; txid: " + coinbase.Hash.String() + "\n" +
+		"    Assets:Wallet:Default   50.00000000 DUO\n" +
+		"    Income:Mining          -50.00000000 DUO\n"; ledger != wantLedger {
+		t.Fatalf("got ledger journal\n%s\nwant\n%s", ledger, wantLedger)
+	}
+	beancount := formatLedger(BeancountFormat, "DUO", j.entries)
+	for _, line := range []string{
+		"2021-01-01 open Assets:Wallet:Default DUO\n",
+		"2021-01-03 open Expenses:Payments:Bob DUO\n",
+		"2021-01-03 open Expenses:Fees DUO\n",
+		"2021-01-02 * \"Received: alice's shop\"\n  txid: \"" + received.Hash.String() + "\"\n",
+		"2021-01-04 ! \"Transfer\"\n",
+		"  Expenses:Fees" + strings.Repeat(" ", 17) + "0.01000000 DUO\n",
+	} {
+		if !strings.Contains(beancount, line) {
+			t.Fatalf("beancount journal does not contain %q:\n%s", line, beancount)
+		}
+	}
+	for _, c := range []struct {
+		format, commodity string
+		ok                bool
+	}{
+		{LedgerFormat, "DUO", true},
+		{LedgerFormat, "my coin", false},
+		{BeancountFormat, "DUO", true},
+		{BeancountFormat, "duo", false},
+		{"csv", "DUO", false},
+	} {
+		if e := checkLedgerFormat(c.format, c.commodity); (e == nil) != c.ok {
+			t.Fatalf("format %q commodity %q got error %v", c.format, c.commodity, e)
+		}
+	}
+	if a := ledgerAmount(-1); a != "-0.00000001" {
+		t.Fatalf("got amount %s", a)
+	}
+}
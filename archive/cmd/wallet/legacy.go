@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	js "encoding/json"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/version"
+)
+
+const (
+	// defaultAccountName is the name of the default account of the wallet.
+	defaultAccountName = "default"
+	// legacyDefaultAccount is the name of the default account in the legacy Parallelcoin daemon.
+	legacyDefaultAccount = ""
+)
+
+// legacyAccountMethods are the methods whose first parameter is the name of an account, and whether they make the
+// account if there is none of that name, as the accounts of the legacy Parallelcoin daemon were made by being used.
+var legacyAccountMethods = map[string]bool{
+	"getaccountaddress":     true,
+	"getaddressesbyaccount": false,
+	"getbalance":            false,
+	"getnewaddress":         true,
+	"getreceivedbyaccount":  false,
+	"sendfrom":              false,
+	"sendmany":              false,
+}
+
+// legacyHandler returns the handler of a request made as to the legacy Parallelcoin daemon. The default account is
+// named with the empty string in the request and its result, accounts that are not found are made by the methods that
+// made them, and getinfo returns the fields of both the node and the wallet, as the legacy daemon was both.
+func legacyHandler(request *btcjson.Request, w *Wallet, chainClient chainclient.Interface) LazyHandler {
+	if w == nil {
+		return LazyApplyHandler(request, w, chainClient)
+	}
+	if request.Method == "getinfo" {
+		return func() (interface{}, *btcjson.RPCError) {
+			info, e := legacyGetInfo(w, chainClient)
+			if e != nil {
+				return nil, JSONError(e)
+			}
+			return info, nil
+		}
+	}
+	var account string
+	create, ok := legacyAccountMethods[request.Method]
+	if ok && len(request.Params) > 0 && js.Unmarshal(request.Params[0], &account) == nil {
+		if account == legacyDefaultAccount {
+			account = defaultAccountName
+			request.Params[0], _ = js.Marshal(account)
+		}
+	} else {
+		create = false
+	}
+	f := LazyApplyHandler(request, w, chainClient)
+	return func() (interface{}, *btcjson.RPCError) {
+		if create {
+			if e := legacyMakeAccount(w, account); e != nil {
+				return nil, e
+			}
+		}
+		res, jsonErr := f()
+		if jsonErr != nil {
+			return nil, jsonErr
+		}
+		return legacyAccountNames(request.Method, res), nil
+	}
+}
+
+// legacyMakeAccount makes the account of the name if there is none, which needs the wallet to be unlocked.
+func legacyMakeAccount(w *Wallet, name string) *btcjson.RPCError {
+	_, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, name)
+	if !waddrmgr.IsError(e, waddrmgr.ErrAccountNotFound) {
+		// any other error is returned by the handler of the request
+		return nil
+	}
+	if _, e = w.NextAccount(waddrmgr.KeyScopeBIP0044, name); e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return &ErrWalletUnlockNeeded
+		}
+		return JSONError(e)
+	}
+	I.F("made account %q for a legacy request", name)
+	return nil
+}
+
+// legacyAccountNames renames the default account in the result of a request of the method to the name the legacy
+// Parallelcoin daemon gave it.
+func legacyAccountNames(method string, res interface{}) interface{} {
+	switch r := res.(type) {
+	case string:
+		if method == "getaccount" && r == defaultAccountName {
+			return legacyDefaultAccount
+		}
+	case map[string]float64:
+		if bal, ok := r[defaultAccountName]; method == "listaccounts" && ok {
+			delete(r, defaultAccountName)
+			r[legacyDefaultAccount] = bal
+		}
+	case []btcjson.ListReceivedByAccountResult:
+		for i := range r {
+			if r[i].Account == defaultAccountName {
+				r[i].Account = legacyDefaultAccount
+			}
+		}
+	}
+	return res
+}
+
+// legacyGetInfo returns the getinfo result of the legacy Parallelcoin daemon, from the getinfo result of the node and
+// the state of the wallet. The wallet has no key pool, and spends inputs of any value, so the fields of those are zero,
+// and unlocked_until is zero while the wallet is locked and left out while it is unlocked, as when it will lock again
+// is not known.
+func legacyGetInfo(w *Wallet, chainClient chainclient.Interface) (info *btcjson.LegacyInfoResult, e error) {
+	client, ok := chainClient.(*chainclient.RPCClient)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoChain,
+			Message: "there is currently no chain client to get this response",
+		}
+	}
+	var res js.RawMessage
+	if res, e = client.RawRequest("getinfo", nil); E.Chk(e) {
+		return
+	}
+	info = &btcjson.LegacyInfoResult{}
+	if e = js.Unmarshal(res, info); E.Chk(e) {
+		return
+	}
+	var bal amt.Amount
+	if bal, e = w.CalculateBalance(MinConfPolicy); E.Chk(e) {
+		return
+	}
+	info.Version = int32(1000000*version.Major + 10000*version.Minor + 100*version.Patch)
+	info.WalletVersion = int32(waddrmgr.LatestMgrVersion)
+	info.Balance = bal.ToDUO()
+	info.PaytxFee = txrules.DefaultRelayFeePerKb.ToDUO()
+	if w.Locked() {
+		info.UnlockedUntil = new(int64)
+	}
+	return
+}
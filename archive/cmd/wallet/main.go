@@ -104,6 +104,9 @@ func LoadWallet(
 	// save.Save(cx.Config)
 	// }()
 	loader.Wallet = w
+	if e = setupSigner(cx, w); E.Chk(e) {
+		return
+	}
 	// D.Ln("^^^^^^^^^^^ sending back wallet")
 	// cx.WalletChan <- w
 	T.Ln("starting rpcClientConnectLoop")
@@ -277,6 +280,7 @@ func StartChainRPC(
 		config.Password.V(),
 		certs,
 		config.ClientTLS.True(),
+		config.RPCProxy(),
 		0,
 		quit,
 	); E.Chk(e) {
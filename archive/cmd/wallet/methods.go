@@ -2,11 +2,13 @@ package wallet
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	js "encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -17,12 +19,17 @@ import (
 	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainclient"
 	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/corewallet"
 	"github.com/p9c/pod/pkg/ecc"
 	"github.com/p9c/interrupt"
+	"github.com/p9c/pod/pkg/psbt"
 	"github.com/p9c/pod/pkg/rpcclient"
+	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/txauthor"
 	"github.com/p9c/pod/pkg/txrules"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/zero"
 	"github.com/p9c/pod/pkg/waddrmgr"
 	"github.com/p9c/pod/pkg/wire"
 	"github.com/p9c/pod/pkg/wtxmgr"
@@ -531,6 +538,136 @@ func AddMultiSigAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient
 	return p2shAddr.EncodeAddress(), nil
 }
 
+// AddPortfolioEntry handles an addportfolioentry request by adding a cold wallet to the portfolio, watching the output
+// scripts of its public descriptors. Without a rescan the scripts are watched from the block the wallet is synced to.
+func AddPortfolioEntry(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.AddPortfolioEntryCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["addportfolioentry"],
+		}
+	}
+	if *cmd.Range < 1 || *cmd.Range > maxPortfolioRange {
+		return nil, InvalidParameterError{fmt.Errorf("range must be 1 to %d", maxPortfolioRange)}
+	}
+	return nil, w.AddPortfolioEntry(cmd.Name, cmd.Descriptors, uint32(*cmd.Range), *cmd.Rescan)
+}
+
+// CreateMultiSigAccount handles a createmultisigaccount request by adding an M-of-N multisig account made from the
+// extended public keys of the cosigners.
+func CreateMultiSigAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CreateMultiSigAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createmultisigaccount"],
+		}
+	}
+	ma, e := w.CreateMultiSigAccount(cmd.Name, cmd.NRequired, cmd.XPubs)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount), waddrmgr.IsError(e, waddrmgr.ErrInvalidKeyType),
+		waddrmgr.IsError(e, waddrmgr.ErrKeyChain), waddrmgr.IsError(e, waddrmgr.ErrWrongNet):
+		return nil, InvalidParameterError{e}
+	case e != nil:
+		return nil, e
+	}
+	return multiSigAccountResult(w, ma), nil
+}
+
+// CreateVaultAccount handles a createvaultaccount request by adding an account whose deposit addresses are locked
+// until a block height, or for a number of blocks after they are generated.
+func CreateVaultAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CreateVaultAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createvaultaccount"],
+		}
+	}
+	if cmd.Name == "*" {
+		return nil, &ErrReservedAccountName
+	}
+	va, e := w.CreateVaultAccount(cmd.Name, *cmd.LockHeight, *cmd.Delay)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount):
+		return nil, InvalidParameterError{e}
+	case e != nil:
+		return nil, e
+	}
+	return btcjson.VaultAccountResult{Name: va.Name, LockHeight: va.LockHeight, Delay: va.Delay}, nil
+}
+
+// multiSigAccountResult returns the JSON-RPC result for a multisig account.
+func multiSigAccountResult(w *Wallet, ma *waddrmgr.MultiSigAccount) btcjson.MultiSigAccountResult {
+	result := btcjson.MultiSigAccountResult{
+		Name:      ma.Name,
+		Required:  ma.Required,
+		Cosigners: make([]btcjson.MultiSigCosignerResult, len(ma.Cosigners)),
+		NextIndex: ma.NextIndex,
+	}
+	for i, c := range ma.Cosigners {
+		result.Cosigners[i] = btcjson.MultiSigCosignerResult{XPub: c.XPub, Ours: c.Ours}
+		if c.Ours {
+			result.Cosigners[i].Account, _ = w.AccountName(waddrmgr.KeyScopeBIP0044, c.Account)
+		}
+	}
+	return result
+}
+
+// BackupRemote handles a backupremote request by backing the wallet up to the remote backup targets it has changed
+// since it was last backed up to, or to all of them if forced, and returning the outcome of the latest backup to each.
+func BackupRemote(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.BackupRemoteCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["backupremote"],
+		}
+	}
+	statuses, e := w.BackupRemote(cmd.Force != nil && *cmd.Force)
+	switch e {
+	case nil:
+	case ErrBackupKeyUnknown:
+		return nil, &btcjson.RPCError{Code: btcjson.ErrRPCWalletUnlockNeeded, Message: e.Error()}
+	default:
+		return nil, e
+	}
+	results := make([]btcjson.BackupTargetResult, len(statuses))
+	for i, s := range statuses {
+		results[i] = btcjson.BackupTargetResult{Target: s.Target, Size: s.Size, Error: s.Error}
+		if !s.Time.IsZero() {
+			results[i].Time = s.Time.Unix()
+		}
+	}
+	return results, nil
+}
+
+// CancelQueuedPSBT handles a cancelqueuedpsbt request by removing a transaction waiting for its signed PSBT from the
+// signing queue, which unlocks its inputs.
+func CancelQueuedPSBT(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CancelQueuedPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["cancelqueuedpsbt"],
+		}
+	}
+	id, e := chainhash.NewHashFromStr(cmd.ID)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Queued transaction ID decode failed: " + e.Error(),
+		}
+	}
+	if e = w.CancelQueuedPSBT(*id); e == ErrPSBTNotQueued {
+		return nil, InvalidParameterError{e}
+	}
+	return nil, e
+}
+
 // CreateMultiSig handles an createmultisig request by returning a multisig address for the given inputs.
 func CreateMultiSig(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
 	var msg string
@@ -560,6 +697,18 @@ func CreateMultiSig(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 	}, nil
 }
 
+// DeleteAddressMeta handles a deleteaddressmeta request by removing the metadata stored for an address.
+func DeleteAddressMeta(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.DeleteAddressMetaCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["deleteaddressmeta"],
+		}
+	}
+	return nil, w.DeleteAddressMeta(cmd.Address)
+}
+
 // DumpPrivKey handles a dumpprivkey request with the private key for a single address, or an appropriate error if the
 // wallet is locked.
 func DumpPrivKey(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
@@ -585,6 +734,142 @@ func DumpPrivKey(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCCli
 	return key, e
 }
 
+// ExportAccountXprv handles an exportaccountxprv request by returning the extended private key of an account, so the
+// account can be moved to other wallet software. The request is refused unless the export is enabled in the
+// configuration and the request gives the xprv export password, which is separate from the RPC credentials. The key is
+// encrypted with the export password unless plaintext is requested. Every request is recorded in the log, whether it
+// is refused or not.
+func ExportAccountXprv(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ExportAccountXprvCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["exportaccountxprv"],
+		}
+	}
+	plaintext := cmd.Plaintext != nil && *cmd.Plaintext
+	audit := func(outcome string) {
+		W.F("xprv export audit: account %q plaintext %v: %s", cmd.Account, plaintext, outcome)
+	}
+	cfg := w.PodConfig
+	if cfg == nil || cfg.AllowXprvExport == nil || cfg.AllowXprvExport.False() ||
+		cfg.XprvExportPass == nil || cfg.XprvExportPass.Empty() {
+		audit("refused, export is disabled")
+		return nil, &ErrXprvExportDisabled
+	}
+	pass := cfg.XprvExportPass.Bytes()
+	defer zero.Bytes(pass)
+	if subtle.ConstantTimeCompare(pass, []byte(cmd.Password)) != 1 {
+		audit("refused, incorrect xprv export password")
+		return nil, &ErrXprvExportPassIncorrect
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		audit("failed, " + e.Error())
+		return nil, e
+	}
+	xprv, e := w.AccountPrivKey(waddrmgr.KeyScopeBIP0044, account)
+	if e != nil {
+		audit("failed, " + e.Error())
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	result := &btcjson.ExportAccountXprvResult{Account: cmd.Account, Xprv: xprv}
+	if !plaintext {
+		var sk *snacl.SecretKey
+		if sk, e = snacl.NewSecretKey(&pass, snacl.DefaultN, snacl.DefaultR, snacl.DefaultP); E.Chk(e) {
+			audit("failed, " + e.Error())
+			return nil, e
+		}
+		var sealed []byte
+		sealed, e = sk.Encrypt([]byte(xprv))
+		sk.Zero()
+		if E.Chk(e) {
+			audit("failed, " + e.Error())
+			return nil, e
+		}
+		result.Encrypted = true
+		result.Xprv = hex.EncodeToString(sealed)
+		result.KeyParams = hex.EncodeToString(sk.Marshal())
+	}
+	audit("exported")
+	return result, nil
+}
+
+// ExportLedger handles an exportledger request by returning the history of the wallet as a double-entry journal in the
+// ledger or beancount format, for importing into plaintext accounting tools.
+func ExportLedger(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ExportLedgerCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["exportledger"],
+		}
+	}
+	format, commodity := LedgerFormat, "DUO"
+	if cmd.Format != nil {
+		format = *cmd.Format
+	}
+	if cmd.Commodity != nil {
+		commodity = *cmd.Commodity
+	}
+	if e := checkLedgerFormat(format, commodity); e != nil {
+		return nil, InvalidParameterError{e}
+	}
+	return w.ExportLedger(format, commodity)
+}
+
+// ExportPaymentBundle handles an exportpaymentbundle request by making a fresh address of an account for each payment
+// request and returning them as a signed bundle for a point of sale device.
+func ExportPaymentBundle(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ExportPaymentBundleCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["exportpaymentbundle"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	requests := make([]PaymentBundleRequest, len(cmd.Requests))
+	for i := range cmd.Requests {
+		requests[i].Label = cmd.Requests[i].Label
+		if requests[i].Amount, e = amt.NewAmount(cmd.Requests[i].Amount); e != nil {
+			return nil, InvalidParameterError{e}
+		}
+	}
+	var expires time.Time
+	if *cmd.Expires != 0 {
+		expires = time.Unix(*cmd.Expires, 0)
+	}
+	var signAddress btcaddr.Address
+	if cmd.SignAddress != nil && *cmd.SignAddress != "" {
+		if signAddress, e = DecodeAddress(*cmd.SignAddress, w.ChainParams()); e != nil {
+			return nil, e
+		}
+	}
+	bundle, e := w.ExportPaymentBundle(account, requests, expires, signAddress)
+	if e != nil {
+		return nil, e
+	}
+	return btcjson.ExportPaymentBundleResult{
+		ID:          bundle.ID,
+		Bundle:      bundle.Document,
+		SignAddress: bundle.SignAddress,
+		Signature:   bundle.Signature,
+	}, nil
+}
+
+// ExportWatchSet handles an exportwatchset request by returning the output scripts and unspent outputs of the wallet,
+// for running a watcher that alerts when its funds move.
+func ExportWatchSet(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	return w.ExportWatchSet()
+}
+
 // // dumpWallet handles a dumpwallet request by returning  all private
 // // keys in a wallet, or an appropiate error if the wallet is locked.
 // // TODO: finish this to match bitcoind by writing the dump to a file.
@@ -596,6 +881,91 @@ func DumpPrivKey(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCCli
 // 	return keys, err
 // }
 
+// GetAddressInfo handles a getaddressinfo request by returning where an address is kept in the wallet, as found from
+// the script index of the address manager.
+func GetAddressInfo(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAddressInfoCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getaddressinfo"],
+		}
+	}
+	addr, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	_, isScript := addr.(*btcaddr.ScriptHash)
+	result := btcjson.GetAddressInfoResult{
+		Address:  addr.EncodeAddress(),
+		IsScript: isScript,
+	}
+	loc, e := w.LocateAddress(addr)
+	if e != nil {
+		if !waddrmgr.IsError(e, waddrmgr.ErrAddressNotFound) {
+			return nil, e
+		}
+		// Not an address of the wallet, so there is only the output script to report.
+		var pkScript []byte
+		if pkScript, e = txscript.PayToAddrScript(addr); e != nil {
+			return nil, e
+		}
+		result.ScriptPubKey = hex.EncodeToString(pkScript)
+		return result, nil
+	}
+	result.ScriptPubKey = hex.EncodeToString(loc.PkScript)
+	result.IsMine = true
+	result.IsWatchOnly = loc.Watched
+	result.IsChange = loc.Internal()
+	result.Account = loc.AccountName
+	if loc.Derived {
+		branch, index := loc.Branch, loc.Index
+		result.Branch, result.Index = &branch, &index
+		result.HDKeyPath = loc.HDKeyPath()
+	}
+	return result, nil
+}
+
+// GetAddressMeta handles a getaddressmeta request by returning the metadata stored for an address.
+func GetAddressMeta(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAddressMetaCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getaddressmeta"],
+		}
+	}
+	m, e := w.GetAddressMeta(cmd.Address)
+	if e != nil {
+		return nil, e
+	}
+	return addressMetaResult(m), nil
+}
+
+// addressMetaResult returns the JSON-RPC result for the metadata of an address.
+func addressMetaResult(m *AddressMeta) btcjson.AddressMetaResult {
+	return btcjson.AddressMetaResult{
+		Address:  m.Address,
+		Category: m.Category,
+		Amount:   m.Amount.ToDUO(),
+		Message:  m.Message,
+		Label:    m.Label,
+		State:    m.State,
+		TxID:     m.TxID,
+		Created:  m.Created.Unix(),
+		Modified: m.Modified.Unix(),
+		Expires:  unixTime(m.Expires),
+	}
+}
+
+// unixTime returns the seconds since 1 Jan 1970 GMT of the time, or zero for the zero time.
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
 // GetAddressesByAccount handles a getaddressesbyaccount request by returning
 // all addresses for an account, or an error if the requested account does not
 // exist.
@@ -626,6 +996,62 @@ func GetAddressesByAccount(icmd interface{}, w *Wallet, chainClient ...*chaincli
 	return addrStrs, nil
 }
 
+// GetAuditLog handles a getauditlog request by returning entries of the audit log of the wallet in the requested range,
+// and whether the hash chain of the log is intact.
+func GetAuditLog(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAuditLogCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getauditlog"],
+		}
+	}
+	from := uint64(1)
+	if cmd.From != nil && *cmd.From > 1 {
+		from = uint64(*cmd.From)
+	}
+	count := 100
+	if cmd.Count != nil {
+		count = *cmd.Count
+	}
+	if count < 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "count must be non-negative",
+		}
+	}
+	var start, end time.Time
+	if cmd.StartTime != nil && *cmd.StartTime != 0 {
+		start = time.Unix(*cmd.StartTime, 0)
+	}
+	if cmd.EndTime != nil && *cmd.EndTime != 0 {
+		end = time.Unix(*cmd.EndTime, 0)
+	}
+	entries, verified, e := w.AuditLog(from, count, start, end)
+	if e != nil {
+		return nil, e
+	}
+	result := btcjson.GetAuditLogResult{
+		Entries:  make([]btcjson.AuditLogEntryResult, len(entries)),
+		Verified: verified,
+	}
+	for i, entry := range entries {
+		result.Entries[i] = btcjson.AuditLogEntryResult{
+			Seq:      entry.Seq,
+			Time:     entry.Time.Unix(),
+			Identity: entry.Identity,
+			Action:   entry.Action,
+			Detail:   entry.Detail,
+			Error:    entry.Error,
+			Hash:     hex.EncodeToString(entry.Hash[:]),
+		}
+	}
+	return result, nil
+}
+
 // GetBalance handles a getbalance request by returning the balance for an
 // account (wallet), or an error if the requested account does not exist.
 func GetBalance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
@@ -643,8 +1069,14 @@ func GetBalance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClie
 	if cmd.Account != nil {
 		accountName = *cmd.Account
 	}
+	minConf := MinConfPolicy
+	if cmd.MinConf != nil {
+		if minConf = int32(*cmd.MinConf); minConf < 0 {
+			return nil, ErrNeedPositiveMinconf
+		}
+	}
 	if accountName == "*" {
-		balance, e = w.CalculateBalance(int32(*cmd.MinConf))
+		balance, e = w.CalculateBalance(minConf)
 		if e != nil {
 			return nil, e
 		}
@@ -654,7 +1086,7 @@ func GetBalance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClie
 		if e != nil {
 			return nil, e
 		}
-		bals, e := w.CalculateAccountBalances(account, int32(*cmd.MinConf))
+		bals, e := w.CalculateAccountBalances(account, minConf)
 		if e != nil {
 			return nil, e
 		}
@@ -663,6 +1095,52 @@ func GetBalance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClie
 	return balance.ToDUO(), nil
 }
 
+// GetBalanceAt handles a getbalanceat request by returning the balance of the wallet, and optionally of each account,
+// as of a past block given by its height or a timestamp.
+func GetBalanceAt(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetBalanceAtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getbalanceat"],
+		}
+	}
+	if cmd.HeightOrTime < 0 {
+		return nil, InvalidParameterError{errors.New("height or time must not be negative")}
+	}
+	height := int32(cmd.HeightOrTime)
+	if cmd.HeightOrTime >= txscript.LockTimeThreshold {
+		var e error
+		if height, e = w.HeightAtTime(time.Unix(cmd.HeightOrTime, 0)); e != nil {
+			return nil, InvalidParameterError{e}
+		}
+	} else if synced := w.Manager.SyncedTo(); height > synced.Height {
+		return nil, InvalidParameterError{
+			fmt.Errorf("height %d is above the height %d the wallet is synced to", height, synced.Height),
+		}
+	}
+	bal, e := w.BalanceAt(height)
+	if e != nil {
+		return nil, e
+	}
+	result := btcjson.GetBalanceAtResult{
+		Height:  bal.Height,
+		Hash:    bal.Hash.String(),
+		Time:    bal.Time.Unix(),
+		Balance: bal.Balance.ToDUO(),
+	}
+	if cmd.PerAccount != nil && *cmd.PerAccount {
+		result.Accounts = make([]btcjson.AccountBalanceAtResult, len(bal.Accounts))
+		for i, ab := range bal.Accounts {
+			result.Accounts[i] = btcjson.AccountBalanceAtResult{Account: ab.Name, Balance: ab.Balance.ToDUO()}
+			if ab.Name != "" {
+				result.Accounts[i].Scope = ab.Scope.String()
+			}
+		}
+	}
+	return result, nil
+}
+
 // GetBestBlock handles a getbestblock request by returning a JSON object with
 // the height and hash of the most recently processed block.
 func GetBestBlock(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
@@ -705,7 +1183,7 @@ func GetInfo(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient)
 		return nil, e
 	}
 	var bal amt.Amount
-	bal, e = w.CalculateBalance(1)
+	bal, e = w.CalculateBalance(MinConfPolicy)
 	if e != nil {
 		return nil, e
 	}
@@ -743,6 +1221,86 @@ func DecodeAddress(s string, params *chaincfg.Params) (btcaddr.Address, error) {
 	return addr, nil
 }
 
+// FreezeUnspent handles a freezeunspent request by freezing or unfreezing the outputs. Unlike lockunspent, the outputs
+// must always be given, so all of them can't be unfrozen at once by mistake.
+func FreezeUnspent(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.FreezeUnspentCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["freezeunspent"],
+		}
+	}
+	if len(cmd.Transactions) == 0 {
+		return nil, InvalidParameterError{errors.New("no outputs to freeze or unfreeze were given")}
+	}
+	var reason string
+	if cmd.Reason != nil {
+		reason = *cmd.Reason
+	}
+	ops := make([]wire.OutPoint, len(cmd.Transactions))
+	for i, input := range cmd.Transactions {
+		txHash, e := chainhash.NewHashFromStr(input.Txid)
+		if e != nil {
+			return nil, ParseError{e}
+		}
+		ops[i] = wire.OutPoint{Hash: *txHash, Index: input.Vout}
+	}
+	for _, op := range ops {
+		var e error
+		if cmd.Unfreeze {
+			e = w.UnfreezeOutpoint(op)
+		} else {
+			e = w.FreezeOutpoint(op, reason)
+		}
+		if e != nil {
+			return nil, e
+		}
+	}
+	return true, nil
+}
+
+// GeneratePaperKey handles a generatepaperkey request by returning new key pairs that are not stored in the wallet,
+// with the text of the QR codes of their address and private key, for printing on paper wallets or giving away.
+func GeneratePaperKey(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GeneratePaperKeyCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["generatepaperkey"],
+		}
+	}
+	count := 1
+	if cmd.Count != nil {
+		count = *cmd.Count
+	}
+	if count < 1 || count > 100 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "count must be between 1 and 100",
+		}
+	}
+	keys := make([]btcjson.PaperKeyResult, count)
+	for i := range keys {
+		k, e := w.GeneratePaperKey()
+		if e != nil {
+			return nil, e
+		}
+		addr, wif := k.Address.EncodeAddress(), k.WIF.String()
+		keys[i] = btcjson.PaperKeyResult{
+			Address:   addr,
+			PrivKey:   wif,
+			PubKey:    hex.EncodeToString(k.WIF.SerializePubKey()),
+			AddressQR: paymentURIScheme + addr,
+			PrivKeyQR: wif,
+		}
+	}
+	return keys, nil
+}
+
 // GetAccount handles a getaccount request by returning the account name
 // associated with a single address.
 func GetAccount(
@@ -800,6 +1358,23 @@ func GetAccountAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.
 	return addr.EncodeAddress(), e
 }
 
+// GetAccountXPub handles a getaccountxpub request by returning the extended public key of an account, for use as a
+// cosigner key of a multisig account.
+func GetAccountXPub(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAccountXPubCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getaccountxpub"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	return w.AccountXPub(waddrmgr.KeyScopeBIP0044, account)
+}
+
 // GetUnconfirmedBalance handles a getunconfirmedbalance extension request by
 // returning the current unconfirmed balance of an account.
 func GetUnconfirmedBalance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (
@@ -822,11 +1397,52 @@ func GetUnconfirmedBalance(icmd interface{}, w *Wallet, chainClient ...*chaincli
 	if e != nil {
 		return nil, e
 	}
-	bals, e := w.CalculateAccountBalances(account, 1)
+	bals, e := w.CalculateAccountBalances(account, MinConfPolicy)
 	if e != nil {
 		return nil, e
 	}
-	return (bals.Total - bals.Spendable).ToDUO(), nil
+	// Immature coinbase rewards are mined and are reported by listimmature rather than as unconfirmed.
+	return (bals.Total - bals.Spendable - bals.ImmatureReward).ToDUO(), nil
+}
+
+// ImportCoreWallet handles an importcorewallet request by importing the private keys and address labels of a Bitcoin
+// Core wallet.dat, such as that of the legacy Qt wallet, into the imported account. The birthday of each key is taken
+// from the time its wallet recorded it was made, and the chain is rescanned from the earliest of them.
+func ImportCoreWallet(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportCoreWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importcorewallet"],
+		}
+	}
+	var passphrase []byte
+	if cmd.Passphrase != nil {
+		passphrase = []byte(*cmd.Passphrase)
+	}
+	imp, e := w.ImportCoreWallet(cmd.Path, passphrase, cmd.Rescan == nil || *cmd.Rescan)
+	switch {
+	case e == corewallet.ErrPassphraseNeeded || e == corewallet.ErrWrongPassphrase:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletPassphraseIncorrect,
+			Message: e.Error(),
+		}
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case e != nil:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "cannot import wallet: " + e.Error(),
+		}
+	}
+	return btcjson.ImportCoreWalletResult{
+		Keys:           imp.Keys,
+		Imported:       imp.Imported,
+		Duplicates:     imp.Duplicates,
+		Labels:         imp.Labels,
+		RescanFrom:     imp.RescanFrom.Height,
+		RescanFromHash: imp.RescanFrom.Hash.String(),
+	}, nil
 }
 
 // ImportPrivKey handles an importprivkey request by parsing a WIF-encoded
@@ -871,6 +1487,71 @@ func ImportPrivKey(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCC
 	return nil, e
 }
 
+// ImportScriptPubKey handles the importscriptpubkey command by watching an output script, which need not be expressible
+// as an address, for payments and their spends. Without a rescan the script is watched from the block the wallet is
+// synced to.
+func ImportScriptPubKey(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportScriptPubKeyCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importscriptpubkey"],
+		}
+	}
+	script, e := hex.DecodeString(cmd.Script)
+	if e != nil || len(script) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Output script must be non-empty hex",
+		}
+	}
+	var bs *waddrmgr.BlockStamp
+	if !*cmd.Rescan {
+		synced := w.Manager.SyncedTo()
+		bs = &synced
+	}
+	e = w.ImportScriptPubKey(script, *cmd.Label, bs, *cmd.Rescan)
+	if waddrmgr.IsError(e, waddrmgr.ErrDuplicateAddress) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Output script is already watched",
+		}
+	}
+	return nil, e
+}
+
+// ImportTimeLockScript handles an importtimelockscript request by adding a redeem script paying to a key of the wallet
+// once a time lock has passed, and returning its P2SH address and lock.
+func ImportTimeLockScript(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportTimeLockScriptCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importtimelockscript"],
+		}
+	}
+	script, e := hex.DecodeString(cmd.RedeemScript)
+	if e != nil || len(script) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Redeem script must be non-empty hex",
+		}
+	}
+	addr, tl, e := w.ImportTimeLockScript(script, *cmd.Rescan)
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	return btcjson.TimeLockScriptResult{
+		Address:  addr.EncodeAddress(),
+		Relative: tl.Relative,
+		Seconds:  tl.Seconds,
+		Lock:     tl.Value,
+	}, nil
+}
+
 // KeypoolRefill handles the keypoolrefill command. Since we handle the keypool automatically this does nothing since
 // refilling is never manually required.
 func KeypoolRefill(
@@ -914,21 +1595,55 @@ func RenameAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCC
 	if !ok {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInvalidParameter,
-			Message: HelpDescsEnUS()["renameaccount"],
-			// "invalid subcommand for addnode",
+			Message: HelpDescsEnUS()["renameaccount"],
+			// "invalid subcommand for addnode",
+		}
+	}
+	// The wildcard * is reserved by the rpc server with the special meaning of "all
+	// accounts", so disallow naming accounts to this string.
+	if cmd.NewAccount == "*" {
+		return nil, &ErrReservedAccountName
+	}
+	// Chk that given account exists
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.OldAccount)
+	if e != nil {
+		return nil, e
+	}
+	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
+}
+
+// ArchiveAccount handles an archiveaccount request by archiving an account, which leaves it out of listaccounts and the
+// total balance of the wallet until it is unarchived.
+func ArchiveAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ArchiveAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["archiveaccount"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	return nil, w.ArchiveAccount(waddrmgr.KeyScopeBIP0044, account)
+}
+
+// UnarchiveAccount handles an unarchiveaccount request by restoring an archived account to listaccounts and the total
+// balance of the wallet.
+func UnarchiveAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.UnarchiveAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["unarchiveaccount"],
 		}
 	}
-	// The wildcard * is reserved by the rpc server with the special meaning of "all
-	// accounts", so disallow naming accounts to this string.
-	if cmd.NewAccount == "*" {
-		return nil, &ErrReservedAccountName
-	}
-	// Chk that given account exists
-	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.OldAccount)
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
 	if e != nil {
 		return nil, e
 	}
-	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
+	return nil, w.UnarchiveAccount(waddrmgr.KeyScopeBIP0044, account)
 }
 
 // GetNewAddress handles a getnewaddress request by returning a new address for
@@ -948,11 +1663,19 @@ func GetNewAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCC
 	if cmd.Account != nil {
 		acctName = *cmd.Account
 	}
-	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, acctName)
+	var addressType string
+	if cmd.AddressType != nil {
+		addressType = *cmd.AddressType
+	}
+	scope, e := w.AddressTypeScope(addressType)
+	if e != nil {
+		return nil, InvalidParameterError{e}
+	}
+	account, e := w.AccountNumber(scope, acctName)
 	if e != nil {
 		return nil, e
 	}
-	addr, e := w.NewAddress(account, waddrmgr.KeyScopeBIP0044, false)
+	addr, e := w.NewAddress(account, scope, false)
 	if e != nil {
 		return nil, e
 	}
@@ -960,6 +1683,89 @@ func GetNewAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCC
 	return addr.EncodeAddress(), nil
 }
 
+// GetNewMultiSigAddress handles a getnewmultisigaddress request by returning the next deposit address of a multisig
+// account and its redeem script.
+func GetNewMultiSigAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetNewMultiSigAddressCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getnewmultisigaddress"],
+		}
+	}
+	addr, script, index, e := w.NewMultiSigAddress(cmd.Name)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case e != nil:
+		return nil, e
+	}
+	return btcjson.MultiSigAddressResult{
+		Address:      addr.EncodeAddress(),
+		RedeemScript: hex.EncodeToString(script),
+		Index:        index,
+	}, nil
+}
+
+// GetNewVaultAddress handles a getnewvaultaddress request by returning the next deposit address of a vault account and
+// its redeem script.
+func GetNewVaultAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetNewVaultAddressCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getnewvaultaddress"],
+		}
+	}
+	addr, script, e := w.NewVaultAddress(cmd.Name)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case e != nil:
+		return nil, e
+	}
+	return btcjson.VaultAddressResult{
+		Address:      addr.Address.EncodeAddress(),
+		RedeemScript: hex.EncodeToString(script),
+		LockHeight:   addr.LockHeight,
+	}, nil
+}
+
+// GetVaultSchedule handles a getvaultschedule request by returning the deposit addresses of a vault account in the
+// order they unlock, with the amount paid to each that has not been spent.
+func GetVaultSchedule(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetVaultScheduleCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getvaultschedule"],
+		}
+	}
+	locks, e := w.VaultSchedule(cmd.Name)
+	if e != nil {
+		return nil, e
+	}
+	height := w.Manager.SyncedTo().Height
+	result := btcjson.VaultScheduleResult{Height: height, Locks: make([]btcjson.VaultLockResult, len(locks))}
+	var locked, unlocked amt.Amount
+	for i, lock := range locks {
+		result.Locks[i] = btcjson.VaultLockResult{
+			Address:    lock.Address.EncodeAddress(),
+			LockHeight: lock.LockHeight,
+			Amount:     lock.Amount.ToDUO(),
+			Outputs:    lock.Outputs,
+		}
+		if lock.LockHeight > height {
+			result.Locks[i].BlocksLeft = lock.LockHeight - height
+			locked += lock.Amount
+		} else {
+			unlocked += lock.Amount
+		}
+	}
+	result.Locked, result.Unlocked = locked.ToDUO(), unlocked.ToDUO()
+	return result, nil
+}
+
 // GetRawChangeAddress handles a getrawchangeaddress request by creating and
 // returning a new change address for an account.
 //
@@ -1053,6 +1859,102 @@ func GetReceivedByAddress(icmd interface{}, w *Wallet, chainClient ...*chainclie
 	return total.ToDUO(), nil
 }
 
+// GetPaymentBundle handles a getpaymentbundle request by returning the settlement of a payment bundle exported by the
+// wallet, with the payments made to each of its addresses.
+func GetPaymentBundle(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetPaymentBundleCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getpaymentbundle"],
+		}
+	}
+	if *cmd.MinConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	if cmd.ID == "" {
+		return nil, InvalidParameterError{errors.New("the ID of the payment bundle is empty")}
+	}
+	bundles, e := w.PaymentBundles(cmd.ID, int32(*cmd.MinConf))
+	if e != nil {
+		return nil, e
+	}
+	return w.paymentBundleResult(&bundles[0]), nil
+}
+
+// GetRescanInfo handles a getrescaninfo request by returning the progress of the rescan the wallet is running, or last
+// ran, and the unmined transactions it removed because the rescan found their inputs spent.
+func GetRescanInfo(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	status := w.RescanStatus()
+	result := btcjson.GetRescanInfoResult{
+		Running:     status.Running,
+		Addresses:   status.Addresses,
+		OutPoints:   status.OutPoints,
+		StartHeight: status.StartHeight,
+		Height:      status.Height,
+		BestHeight:  status.BestHeight,
+		Queued:      status.Queued,
+		Conflicts:   make([]btcjson.RescanConflictResult, len(status.Conflicts)),
+	}
+	if !status.Started.IsZero() {
+		result.Started = status.Started.Unix()
+	}
+	for i := range status.Conflicts {
+		c := &status.Conflicts[i]
+		result.Conflicts[i] = btcjson.RescanConflictResult{
+			TxID:    c.OutPoint.Hash.String(),
+			Vout:    c.OutPoint.Index,
+			Removed: c.Removed.String(),
+			SpentBy: c.SpentBy.String(),
+			Height:  c.Height,
+		}
+	}
+	return result, nil
+}
+
+// GetScrubInfo handles a getscrubinfo request by returning the progress of the scrubbing of the wallet database and the
+// problems it found.
+func GetScrubInfo(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	status := w.ScrubStatus()
+	result := btcjson.GetScrubInfoResult{
+		Enabled:  status.Enabled,
+		Repair:   status.Repair,
+		Phase:    status.Phase,
+		Passes:   status.Passes,
+		Checked:  status.Checked,
+		Repaired: status.Repaired,
+		Findings: make([]btcjson.ScrubFindingResult, len(status.Findings)),
+	}
+	if !status.Started.IsZero() {
+		result.Started = status.Started.Unix()
+	}
+	if !status.Finished.IsZero() {
+		result.Finished = status.Finished.Unix()
+	}
+	for i := range status.Findings {
+		f := &status.Findings[i]
+		result.Findings[i] = btcjson.ScrubFindingResult{
+			Pass:     f.Pass,
+			Time:     f.Time.Unix(),
+			Kind:     f.Kind,
+			Key:      f.Key,
+			Problem:  f.Problem,
+			Repaired: f.Repaired,
+		}
+	}
+	return result, nil
+}
+
+// GetSpendAuth handles a getspendauth request by returning the code the wallet requires to send more than its spend
+// limit, and the limit.
+func GetSpendAuth(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	sa, e := w.SpendAuth()
+	if e != nil {
+		return nil, e
+	}
+	return btcjson.SpendAuthResult{Method: sa.Method, Limit: sa.Limit.ToDUO()}, nil
+}
+
 // GetTransaction handles a gettransaction request by returning details about a single transaction saved by wallet.
 func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.GetTransactionCmd)
@@ -1104,8 +2006,7 @@ func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 	var (
 		debitTotal  amt.Amount
 		creditTotal amt.Amount // Excludes change
-		fee         amt.Amount
-		feeF64      float64
+		feeF64      *float64
 	)
 	for _, deb := range details.Debits {
 		debitTotal += deb.Amount
@@ -1115,14 +2016,11 @@ func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 			creditTotal += cred.Amount
 		}
 	}
-	// Fee can only be determined if every input is a debit.
-	if len(details.Debits) == len(details.MsgTx.TxIn) {
-		var outputTotal amt.Amount
-		for _, output := range details.MsgTx.TxOut {
-			outputTotal += amt.Amount(output.Value)
-		}
-		fee = debitTotal - outputTotal
-		feeF64 = fee.ToDUO()
+	// The fee is negative, as in listtransactions, and is only known if every input is a debit.
+	if fee, known := txFee(details); known {
+		f := (-fee).ToDUO()
+		feeF64 = &f
+		ret.Fee = f
 	}
 	if len(details.Debits) == 0 {
 		// Credits must be set later, but since we know the full length
@@ -1144,9 +2042,8 @@ func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 			//  listtransactions (but using the short result format).
 			Category: "send",
 			Amount:   (-debitTotal).ToDUO(), // negative since it is a send
-			Fee:      &feeF64,
+			Fee:      feeF64,
 		}
-		ret.Fee = feeF64
 	}
 	credCat := RecvCategory(details, syncBlock.Height, w.ChainParams()).String()
 	for _, cred := range details.Credits {
@@ -1175,11 +2072,11 @@ func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 			ret.Details, btcjson.GetTransactionDetailsResult{
 				// Fields left zeroed:
 				//   InvolvesWatchOnly
-				//   Fee
 				Account:  accountName,
 				Address:  address,
 				Category: credCat,
 				Amount:   cred.Amount.ToDUO(),
+				Fee:      feeF64,
 				Vout:     cred.Index,
 			},
 		)
@@ -1188,6 +2085,69 @@ func GetTransaction(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPC
 	return ret, nil
 }
 
+// GetUTXOReport handles a getutxoreport request by returning the unspent outputs of the wallet summed up by age and by
+// amount, with the outputs that cost more to spend than they are worth at the fee rate.
+func GetUTXOReport(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetUTXOReportCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getutxoreport"],
+		}
+	}
+	var feeRate amt.Amount
+	if cmd.FeeRate != nil {
+		var e error
+		if feeRate, e = amt.NewAmount(*cmd.FeeRate); e != nil {
+			return nil, InvalidParameterError{e}
+		}
+		if feeRate <= 0 {
+			return nil, InvalidParameterError{errors.New("the fee rate must be positive")}
+		}
+	}
+	report, e := w.UTXOReport(feeRate)
+	if e != nil {
+		return nil, e
+	}
+	result := btcjson.GetUTXOReportResult{
+		Height:           report.Height,
+		FeeRate:          report.FeeRate.ToDUO(),
+		SpendCost:        report.InputCost.ToDUO(),
+		Count:            report.Count,
+		Amount:           report.Amount.ToDUO(),
+		Uneconomic:       report.Uneconomic,
+		UneconomicAmount: report.UneconomicAmount.ToDUO(),
+		Dust:             report.Dust,
+		Frozen:           report.Frozen,
+		Locked:           report.Locked,
+		ByAge:            make([]btcjson.UTXOBucketResult, len(report.ByAge)),
+		ByValue:          make([]btcjson.UTXOBucketResult, len(report.ByValue)),
+	}
+	for i := range report.ByAge {
+		b := &report.ByAge[i]
+		result.ByAge[i] = utxoBucketResult(b, float64(b.Min), float64(b.Max))
+	}
+	for i := range report.ByValue {
+		b := &report.ByValue[i]
+		result.ByValue[i] = utxoBucketResult(b, amt.Amount(b.Min).ToDUO(), amt.Amount(b.Max).ToDUO())
+	}
+	return result, nil
+}
+
+// utxoBucketResult returns the result of a bucket of the UTXO report with its range given in the unit of the result.
+func utxoBucketResult(b *UTXOBucket, min, max float64) btcjson.UTXOBucketResult {
+	return btcjson.UTXOBucketResult{
+		Name:             b.Name,
+		Min:              min,
+		Max:              max,
+		Count:            b.Count,
+		Amount:           b.Amount.ToDUO(),
+		Uneconomic:       b.Uneconomic,
+		UneconomicAmount: b.UneconomicAmount.ToDUO(),
+		SpendCost:        b.SpendCost.ToDUO(),
+	}
+}
+
 func HandleDropWalletHistory(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (
 	out interface{}, e error,
 ) {
@@ -1335,19 +2295,376 @@ func ListAccounts(
 	if e != nil {
 		return nil, e
 	}
+	includeArchived := cmd.IncludeArchived != nil && *cmd.IncludeArchived
 	for _, result := range results {
+		if result.Archived && !includeArchived {
+			continue
+		}
 		accountBalances[result.AccountName] = result.AccountBalance.ToDUO()
 	}
 	// Return the map.  This will be marshaled into a JSON object.
 	return accountBalances, nil
 }
 
-// ListLockUnspent handles a listlockunspent request by returning an slice of all locked outpoints.
-func ListLockUnspent(
-	icmd interface{}, w *Wallet,
-	chainClient ...*chainclient.RPCClient,
-) (interface{}, error) {
-	return w.LockedOutpoints(), nil
+// ListAddressMeta handles a listaddressmeta request by returning the metadata stored for the addresses in a category,
+// or for every address if no category is given, oldest first.
+func ListAddressMeta(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListAddressMetaCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listaddressmeta"],
+		}
+	}
+	var category string
+	if cmd.Category != nil {
+		category = *cmd.Category
+	}
+	switch category {
+	case "", AddressMetaReceive, AddressMetaSend:
+	default:
+		return nil, InvalidParameterError{
+			fmt.Errorf("unknown category %q, must be %q or %q", category, AddressMetaReceive, AddressMetaSend),
+		}
+	}
+	metas, e := w.ListAddressMeta(category)
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.AddressMetaResult, len(metas))
+	for i := range metas {
+		results[i] = addressMetaResult(&metas[i])
+	}
+	return results, nil
+}
+
+// ListDustOutputs handles a listdustoutputs request by returning the outputs taken for the outputs of a dusting attack,
+// in the order they were detected, and whether each is still frozen.
+func ListDustOutputs(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	dust, e := w.DustOutputs()
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.DustOutputResult, len(dust))
+	for i := range dust {
+		d := &dust[i]
+		results[i] = btcjson.DustOutputResult{
+			TxID:     d.OutPoint.Hash.String(),
+			Vout:     d.OutPoint.Index,
+			Address:  d.Address,
+			Amount:   d.Amount.ToDUO(),
+			Detected: d.Detected.Unix(),
+			Frozen:   d.Frozen,
+		}
+	}
+	return results, nil
+}
+
+// ListFrozen handles a listfrozen request by returning the outputs frozen in the wallet, in the order they were frozen,
+// with the reasons they were frozen for.
+func ListFrozen(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	frozen, e := w.FrozenOutputs()
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.FrozenOutputResult, len(frozen))
+	for i := range frozen {
+		f := &frozen[i]
+		results[i] = btcjson.FrozenOutputResult{
+			TxID:    f.OutPoint.Hash.String(),
+			Vout:    f.OutPoint.Index,
+			Reason:  f.Reason,
+			Frozen:  f.Frozen.Unix(),
+			Unspent: f.Unspent,
+		}
+		if !f.Unspent {
+			continue
+		}
+		results[i].Amount = f.Amount.ToDUO()
+		if _, addrs, _, e := txscript.ExtractPkScriptAddrs(f.PkScript, w.ChainParams()); e == nil && len(addrs) > 0 {
+			results[i].Address = addrs[0].EncodeAddress()
+		}
+	}
+	return results, nil
+}
+
+// ListInvoices handles a listinvoices request by returning the payment requests made with addresses of the wallet,
+// oldest first, with their state found from the payments to the addresses.
+func ListInvoices(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListInvoicesCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listinvoices"],
+		}
+	}
+	var state string
+	if cmd.State != nil {
+		state = *cmd.State
+	}
+	invoices, e := w.ListInvoices(state, int32(*cmd.MinConf))
+	if e != nil {
+		return nil, e
+	}
+	return invoiceResults(invoices), nil
+}
+
+// invoiceResults returns the invoices as they are returned by listinvoices.
+func invoiceResults(invoices []Invoice) []btcjson.InvoiceResult {
+	results := make([]btcjson.InvoiceResult, len(invoices))
+	for i := range invoices {
+		inv := &invoices[i]
+		results[i] = btcjson.InvoiceResult{
+			Address:     inv.Address,
+			Amount:      inv.Amount.ToDUO(),
+			Message:     inv.Message,
+			Label:       inv.Label,
+			State:       inv.State,
+			Received:    inv.Received.ToDUO(),
+			Pending:     inv.Pending.ToDUO(),
+			Payments:    make([]string, len(inv.Payments)),
+			Created:     inv.Created.Unix(),
+			Expires:     unixTime(inv.Expires),
+			LastPayment: unixTime(inv.LastPayment),
+		}
+		for j := range inv.Payments {
+			results[i].Payments[j] = inv.Payments[j].String()
+		}
+	}
+	return results
+}
+
+// ListPaymentBundles handles a listpaymentbundles request by returning the settlement of every payment bundle exported
+// by the wallet, oldest first.
+func ListPaymentBundles(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListPaymentBundlesCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listpaymentbundles"],
+		}
+	}
+	if *cmd.MinConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	bundles, e := w.PaymentBundles("", int32(*cmd.MinConf))
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.PaymentBundleResult, len(bundles))
+	for i := range bundles {
+		results[i] = w.paymentBundleResult(&bundles[i])
+	}
+	return results, nil
+}
+
+// paymentBundleResult returns the settlement of the payment bundle as it is returned by getpaymentbundle.
+func (w *Wallet) paymentBundleResult(b *PaymentBundle) btcjson.PaymentBundleResult {
+	account, e := w.AccountName(waddrmgr.KeyScopeBIP0044, b.Account)
+	if e != nil {
+		// the account may have been renamed, but its number is still known
+		account = strconv.FormatUint(uint64(b.Account), 10)
+	}
+	return btcjson.PaymentBundleResult{
+		ID:          b.ID,
+		Account:     account,
+		SignAddress: b.SignAddress,
+		State:       b.State,
+		Requested:   b.Requested.ToDUO(),
+		Received:    b.Received.ToDUO(),
+		Pending:     b.Pending.ToDUO(),
+		Paid:        b.Paid,
+		Requests:    invoiceResults(b.Requests),
+		Created:     b.Created.Unix(),
+		Expires:     unixTime(b.Expires),
+	}
+}
+
+// ListInvoiceReservations handles a listinvoicereservations request by returning the addresses reserved in an account
+// that issues its addresses sequentially, in order of their index.
+func ListInvoiceReservations(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListInvoiceReservationsCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listinvoicereservations"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, *cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	reservations, e := w.ListInvoiceReservations(account)
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.InvoiceReservationResult, len(reservations))
+	for i := range reservations {
+		results[i] = invoiceReservationResult(&reservations[i], *cmd.Account)
+	}
+	return results, nil
+}
+
+// ListPortfolio handles a listportfolio request by returning the cold wallets in the portfolio with the funds each
+// holds, and those of all of them added up.
+func ListPortfolio(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListPortfolioCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listportfolio"],
+		}
+	}
+	if *cmd.MinConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	entries, e := w.Portfolio(int32(*cmd.MinConf))
+	if e != nil {
+		return nil, e
+	}
+	result := btcjson.ListPortfolioResult{Entries: make([]btcjson.PortfolioEntryResult, len(entries))}
+	var balance, unconfirmed amt.Amount
+	for i := range entries {
+		p := &entries[i]
+		result.Entries[i] = btcjson.PortfolioEntryResult{
+			Name:        p.Name,
+			Descriptors: p.Descriptors,
+			Range:       p.Range,
+			Height:      p.Height,
+			Added:       p.Added.Unix(),
+			Balance:     p.Balance.ToDUO(),
+			Unconfirmed: p.Unconfirmed.ToDUO(),
+			Unspent:     p.Unspent,
+		}
+		balance += p.Balance
+		unconfirmed += p.Unconfirmed
+	}
+	result.Balance, result.Unconfirmed = balance.ToDUO(), unconfirmed.ToDUO()
+	return result, nil
+}
+
+// ListPortfolioTransactions handles a listportfoliotransactions request by returning the latest changes transactions
+// made to the funds of the portfolio, or of one of its entries.
+func ListPortfolioTransactions(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListPortfolioTransactionsCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listportfoliotransactions"],
+		}
+	}
+	if *cmd.Count < 0 {
+		return nil, InvalidParameterError{errors.New("count must not be negative")}
+	}
+	txs, e := w.PortfolioTransactions(*cmd.Name)
+	if e != nil {
+		return nil, e
+	}
+	if len(txs) > *cmd.Count {
+		txs = txs[:*cmd.Count]
+	}
+	syncHeight := w.Manager.SyncedTo().Height
+	results := make([]btcjson.PortfolioTransactionResult, len(txs))
+	for i := range txs {
+		ptx := &txs[i]
+		results[i] = btcjson.PortfolioTransactionResult{
+			Entry:         ptx.Entry,
+			TxID:          ptx.TxID.String(),
+			Amount:        ptx.Amount.ToDUO(),
+			Confirmations: int64(confirms(ptx.Height, syncHeight)),
+			Time:          ptx.Time.Unix(),
+		}
+		if ptx.Height != -1 {
+			results[i].BlockHeight = ptx.Height
+		}
+	}
+	return results, nil
+}
+
+// ListImmature handles a listimmature request by returning the wallet's coinbase outputs that have not yet matured and
+// the number of blocks remaining until each can be spent.
+func ListImmature(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListImmatureCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listimmature"],
+		}
+	}
+	accountName := "*"
+	if cmd.Account != nil {
+		accountName = *cmd.Account
+	}
+	if accountName != "*" {
+		// Ensure the account exists before listing its outputs.
+		if _, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, accountName); e != nil {
+			return nil, e
+		}
+	}
+	return w.ListImmature(accountName)
+}
+
+// ListLockUnspent handles a listlockunspent request by returning an slice of all locked outpoints.
+func ListLockUnspent(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	return w.LockedOutpoints(), nil
+}
+
+// ListMultiSigAccounts handles a listmultisigaccounts request by returning the multisig accounts of the wallet.
+func ListMultiSigAccounts(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	accounts, e := w.MultiSigAccounts()
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.MultiSigAccountResult, len(accounts))
+	for i, ma := range accounts {
+		results[i] = multiSigAccountResult(w, ma)
+	}
+	return results, nil
+}
+
+// ListQueuedPSBTs handles a listqueuedpsbts request by returning the transactions in the signing queue of a
+// watching-only wallet, oldest first, with the PSBT for the external signer to sign.
+func ListQueuedPSBTs(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	queued, e := w.QueuedPSBTs()
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.QueuedPSBTResult, len(queued))
+	for i := range queued {
+		q := &queued[i]
+		results[i] = btcjson.QueuedPSBTResult{
+			ID:      q.ID.String(),
+			Created: q.Created.Unix(),
+			State:   q.State,
+		}
+		if results[i].PSBT, e = q.Packet.B64Encode(); e != nil {
+			return nil, e
+		}
+		results[i].Account, _ = w.AccountName(waddrmgr.KeyScopeBIP0044, q.Account)
+		if q.TxID != nil {
+			results[i].TxID = q.TxID.String()
+		}
+	}
+	return results, nil
+}
+
+// ListVaultAccounts handles a listvaultaccounts request by returning the vault accounts of the wallet.
+func ListVaultAccounts(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	accounts, e := w.VaultAccounts()
+	if e != nil {
+		return nil, e
+	}
+	results := make([]btcjson.VaultAccountResult, len(accounts))
+	for i, va := range accounts {
+		results[i] = btcjson.VaultAccountResult{Name: va.Name, LockHeight: va.LockHeight, Delay: va.Delay}
+	}
+	return results, nil
 }
 
 // ListReceivedByAccount handles a listreceivedbyaccount request by returning a slice of objects, each one containing:
@@ -1607,6 +2924,36 @@ func ListTransactions(icmd interface{}, w *Wallet, chainClient ...*chainclient.R
 	return txs, e
 }
 
+// ListTransactionsPage handles a listtransactionspage request by returning a page of the wallet's transactions that pass
+// the filter of the request, along with the cursor to get the next page with.
+func ListTransactionsPage(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (
+	interface{}, error,
+) {
+	cmd, ok := icmd.(*btcjson.ListTransactionsPageCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listtransactionspage"],
+		}
+	}
+	var cursor string
+	if cmd.Cursor != nil {
+		cursor = *cmd.Cursor
+	}
+	count := 10
+	if cmd.Count != nil {
+		count = *cmd.Count
+	}
+	if count < 1 {
+		return nil, InvalidParameterError{errors.New("count must be positive")}
+	}
+	page, e := w.ListTransactionsPage(cursor, count, cmd.Filter)
+	if e == ErrInvalidCursor {
+		return nil, InvalidParameterError{e}
+	}
+	return page, e
+}
+
 // ListAddressTransactions handles a listaddresstransactions request by returning an array of maps with details of spent
 // and received wallet transactions.
 //
@@ -1694,6 +3041,24 @@ func ListUnspent(
 	return w.ListUnspent(int32(*cmd.MinConf), int32(*cmd.MaxConf), addresses)
 }
 
+// ListUnlockAttempts handles a listunlockattempts request by returning the audit log of the most recent attempts to
+// unlock the wallet, oldest first.
+func ListUnlockAttempts(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	attempts := w.UnlockAttempts()
+	result := make([]btcjson.UnlockAttemptResult, len(attempts))
+	for i, a := range attempts {
+		result[i] = btcjson.UnlockAttemptResult{
+			Time:    a.Time.Unix(),
+			Success: a.Success,
+			Reason:  a.Reason,
+		}
+	}
+	return result, nil
+}
+
 // LockUnspent handles the lockunspent command.
 func LockUnspent(
 	icmd interface{}, w *Wallet,
@@ -1737,6 +3102,9 @@ func MakeOutputs(pairs map[string]amt.Amount, chainParams *chaincfg.Params) ([]*
 		if e != nil {
 			return nil, fmt.Errorf("cannot decode address: %s", e)
 		}
+		if e = checkPayable(addr, chainParams); e != nil {
+			return nil, e
+		}
 		pkScript, e := txscript.PayToAddrScript(addr)
 		if e != nil {
 			return nil, fmt.Errorf("cannot create txout script: %s", e)
@@ -1746,18 +3114,277 @@ func MakeOutputs(pairs map[string]amt.Amount, chainParams *chaincfg.Params) ([]*
 	return outputs, nil
 }
 
+// checkPayable returns an error for addresses that can't be paid to safely on the network, which are segwit addresses
+// until the bech32 address type is active on it, as the outputs paying to them could be spent by anyone before then.
+func checkPayable(addr btcaddr.Address, chainParams *chaincfg.Params) error {
+	switch addr.(type) {
+	case *btcaddr.WitnessPubKeyHash, *btcaddr.WitnessScriptHash:
+		if !chainParams.AddressTypeActive(chaincfg.AddressTypeBech32) {
+			return fmt.Errorf("cannot pay to segwit address %s before segwit is active on %s", addr, chainParams.Name)
+		}
+	}
+	return nil
+}
+
+// checkScriptPayable returns an error for output scripts that can't be paid to safely on the network, which are
+// witness programs until the bech32 address type is active on it, for the same reason as checkPayable.
+func checkScriptPayable(pkScript []byte, chainParams *chaincfg.Params) error {
+	if txscript.IsWitnessProgram(pkScript) && !chainParams.AddressTypeActive(chaincfg.AddressTypeBech32) {
+		return fmt.Errorf("cannot pay to a segwit output script before segwit is active on %s", chainParams.Name)
+	}
+	return nil
+}
+
+// MakeScriptOutputs creates a slice of transaction outputs from a map of hex encoded output scripts and amounts, for
+// paying to scripts that have no address form, such as bare multisig. Each script must be one of the standard forms,
+// and segwit scripts can only be paid once segwit is active on the network.
+func MakeScriptOutputs(
+	scripts map[string]amt.Amount, chainParams *chaincfg.Params, relayFeePerKb amt.Amount,
+) ([]*wire.TxOut, error) {
+	outputs := make([]*wire.TxOut, 0, len(scripts))
+	for scriptHex, amount := range scripts {
+		pkScript, e := hex.DecodeString(scriptHex)
+		if e != nil {
+			return nil, DeserializationError{e}
+		}
+		if e = checkScriptPayable(pkScript, chainParams); e != nil {
+			return nil, InvalidParameterError{fmt.Errorf("cannot pay to script %s: %v", scriptHex, e)}
+		}
+		txOut, e := txauthor.NewScriptOutput(pkScript, amount, relayFeePerKb)
+		if e != nil {
+			if e == txrules.ErrAmountNegative {
+				return nil, ErrNeedPositiveAmount
+			}
+			return nil, InvalidParameterError{fmt.Errorf("cannot pay to script %s: %v", scriptHex, e)}
+		}
+		outputs = append(outputs, txOut)
+	}
+	return outputs, nil
+}
+
+// makeSendOutputs creates the outputs of a sendmany or previewsend request from its address and script amounts in DUO.
+func makeSendOutputs(
+	amounts map[string]float64, scripts *map[string]float64,
+	chainParams *chaincfg.Params, relayFeePerKb amt.Amount,
+) ([]*wire.TxOut, error) {
+	pairs := make(map[string]amt.Amount, len(amounts))
+	for k, v := range amounts {
+		a, e := amt.NewAmount(v)
+		if e != nil {
+			return nil, e
+		}
+		pairs[k] = a
+	}
+	outputs, e := MakeOutputs(pairs, chainParams)
+	if e != nil || scripts == nil {
+		return outputs, e
+	}
+	scriptPairs := make(map[string]amt.Amount, len(*scripts))
+	for k, v := range *scripts {
+		a, e := amt.NewAmount(v)
+		if e != nil {
+			return nil, e
+		}
+		scriptPairs[k] = a
+	}
+	scriptOutputs, e := MakeScriptOutputs(scriptPairs, chainParams, relayFeePerKb)
+	if e != nil {
+		return nil, e
+	}
+	return append(outputs, scriptOutputs...), nil
+}
+
+// OverrideDust handles an overridedust request by releasing outputs taken for the outputs of a dusting attack so they
+// can be spent, or freezing them again.
+func OverrideDust(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.OverrideDustCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["overridedust"],
+		}
+	}
+	if len(cmd.Transactions) == 0 {
+		return nil, InvalidParameterError{errors.New("no outputs to release or freeze were given")}
+	}
+	ops := make([]wire.OutPoint, len(cmd.Transactions))
+	for i, input := range cmd.Transactions {
+		txHash, e := chainhash.NewHashFromStr(input.Txid)
+		if e != nil {
+			return nil, ParseError{e}
+		}
+		ops[i] = wire.OutPoint{Hash: *txHash, Index: input.Vout}
+	}
+	for _, op := range ops {
+		if e := w.OverrideDust(op, cmd.Release); e != nil {
+			return nil, e
+		}
+	}
+	return true, nil
+}
+
+// PreviewSend handles a previewsend request by working out the transaction a sendmany with the same arguments would
+// make, without signing or broadcasting it, and returning the inputs it selects, its size, fee, change and fee rate so
+// the send can be confirmed before it is made.
+func PreviewSend(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.PreviewSendCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["previewsend"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
+	if e != nil {
+		return nil, e
+	}
+	minConf := MinConfPolicy
+	if cmd.MinConf != nil {
+		if minConf = int32(*cmd.MinConf); minConf < 0 {
+			return nil, ErrNeedPositiveMinconf
+		}
+	}
+	outputs, e := makeSendOutputs(cmd.Amounts, cmd.Scripts, w.ChainParams(), txrules.DefaultRelayFeePerKb)
+	if e != nil {
+		return nil, e
+	}
+	preview, e := w.PreviewSend(outputs, account, minConf, txrules.DefaultRelayFeePerKb)
+	if e != nil {
+		if e == txrules.ErrAmountNegative {
+			return nil, ErrNeedPositiveAmount
+		}
+		switch e.(type) {
+		case btcjson.RPCError:
+			return nil, e
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: e.Error(),
+		}
+	}
+	result := btcjson.PreviewSendResult{
+		Inputs:  make([]btcjson.PreviewSendInput, len(preview.Tx.Tx.TxIn)),
+		VSize:   preview.Size,
+		Fee:     preview.Fee.ToDUO(),
+		Change:  preview.Change.ToDUO(),
+		FeeRate: preview.FeeRate().ToDUO(),
+	}
+	for i, txIn := range preview.Tx.Tx.TxIn {
+		input := btcjson.PreviewSendInput{
+			TxID:   txIn.PreviousOutPoint.Hash.String(),
+			Vout:   txIn.PreviousOutPoint.Index,
+			Amount: preview.Tx.PrevInputValues[i].ToDUO(),
+		}
+		_, addrs, _, _ := txscript.ExtractPkScriptAddrs(preview.Tx.PrevScripts[i], w.ChainParams())
+		if len(addrs) == 1 {
+			input.Address = addrs[0].EncodeAddress()
+		}
+		result.Inputs[i] = input
+	}
+	return result, nil
+}
+
+// RemovePortfolioEntry handles a removeportfolioentry request by removing a cold wallet from the portfolio.
+func RemovePortfolioEntry(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.RemovePortfolioEntryCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["removeportfolioentry"],
+		}
+	}
+	return nil, w.RemovePortfolioEntry(cmd.Name)
+}
+
+// ReleaseInvoiceAddress handles a releaseinvoiceaddress request by releasing an address reserved for an order that was
+// not paid, so it is the next address reserved in its account.
+func ReleaseInvoiceAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ReleaseInvoiceAddressCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["releaseinvoiceaddress"],
+		}
+	}
+	addr, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	if e = w.ReleaseInvoiceAddress(addr); e != nil {
+		return nil, e
+	}
+	return true, nil
+}
+
+// ReserveInvoiceAddress handles a reserveinvoiceaddress request by reserving the next address of an account that
+// issues its addresses sequentially for the reference of an order.
+func ReserveInvoiceAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ReserveInvoiceAddressCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["reserveinvoiceaddress"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	var reference string
+	if cmd.Reference != nil {
+		reference = *cmd.Reference
+	}
+	r, e := w.ReserveInvoiceAddress(account, reference)
+	if e != nil {
+		return nil, e
+	}
+	return invoiceReservationResult(r, cmd.Account), nil
+}
+
+// invoiceReservationResult returns the result of an address reserved in the named account.
+func invoiceReservationResult(r *InvoiceReservation, account string) btcjson.InvoiceReservationResult {
+	return btcjson.InvoiceReservationResult{
+		Address:   r.Address,
+		Account:   account,
+		Index:     r.Index,
+		Reference: r.Reference,
+		Reserved:  r.Reserved.Unix(),
+	}
+}
+
 // SendPairs creates and sends payment transactions. It returns the transaction hash in string format upon success All
-// errors are returned in json.RPCError format
+// errors are returned in json.RPCError format. The transaction pays exactly fee when it is not zero, and otherwise the
+// fee at feeSatPerKb for its size.
 func SendPairs(
 	w *Wallet, amounts map[string]amt.Amount,
-	account uint32, minconf int32, feeSatPerKb amt.Amount,
+	account uint32, minconf int32, feeSatPerKb, fee amt.Amount, authCode string,
 ) (string, error) {
 	outputs, e := MakeOutputs(amounts, w.ChainParams())
 	if e != nil {
 		return "", e
 	}
-	var txHash *chainhash.Hash
-	txHash, e = w.SendOutputs(outputs, account, minconf, feeSatPerKb)
+	return sendOutputs(w, outputs, account, minconf, feeSatPerKb, fee, authCode)
+}
+
+// sendOutputs creates and sends a transaction paying to the outputs, returning the transaction hash in string format
+// upon success, with errors returned in json.RPCError format. The authorization code is checked first if the outputs
+// pay more than the spend limit of the wallet. A watching-only wallet queues the transaction for an external signer,
+// and the ID of the queued transaction is returned instead.
+func sendOutputs(
+	w *Wallet, outputs []*wire.TxOut,
+	account uint32, minconf int32, feeSatPerKb, fee amt.Amount, authCode string,
+) (string, error) {
+	var total amt.Amount
+	for _, out := range outputs {
+		total += amt.Amount(out.Value)
+	}
+	if e := w.AuthorizeSpend(total, authCode); e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return "", &ErrWalletUnlockNeeded
+		}
+		return "", e
+	}
+	txHash, e := w.SendOutputsFee(outputs, account, minconf, feeSatPerKb, fee)
 	if e != nil {
 		if e == txrules.ErrAmountNegative {
 			return "", ErrNeedPositiveAmount
@@ -1775,13 +3402,31 @@ func SendPairs(
 		}
 	}
 	txHashStr := txHash.String()
-	I.Ln("successfully sent transaction", txHashStr)
+	if !w.queuesPSBTs() {
+		I.Ln("successfully sent transaction", txHashStr)
+	}
 	return txHashStr, nil
 }
 func IsNilOrEmpty(s *string) bool {
 	return s == nil || *s == ""
 }
 
+// authCode returns the spend authorization code given with a send request, or an empty string if none was given.
+func authCode(code *string) string {
+	if code == nil {
+		return ""
+	}
+	return *code
+}
+
+// idempotencyKey returns the idempotency key given with a send request, or an empty string if none was given.
+func idempotencyKey(key *string) string {
+	if key == nil {
+		return ""
+	}
+	return *key
+}
+
 // SendFrom handles a sendfrom RPC request by creating a new transaction spending unspent transaction outputs for a
 // wallet to another payment address. Leftover inputs not sent to the payment address or a fee for the miner are sent
 // back to a new address in the wallet. Upon success, the TxID for the created transaction is returned.
@@ -1811,9 +3456,11 @@ func SendFrom(icmd interface{}, w *Wallet, chainClient *chainclient.RPCClient) (
 	if cmd.Amount < 0 {
 		return nil, ErrNeedPositiveAmount
 	}
-	minConf := int32(*cmd.MinConf)
-	if minConf < 0 {
-		return nil, ErrNeedPositiveMinconf
+	minConf := MinConfPolicy
+	if cmd.MinConf != nil {
+		if minConf = int32(*cmd.MinConf); minConf < 0 {
+			return nil, ErrNeedPositiveMinconf
+		}
 	}
 	// Create map of address and amount pairs.
 	amount, e := amt.NewAmount(cmd.Amount)
@@ -1825,7 +3472,7 @@ func SendFrom(icmd interface{}, w *Wallet, chainClient *chainclient.RPCClient) (
 	}
 	return SendPairs(
 		w, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb,
+		txrules.DefaultRelayFeePerKb, 0, authCode(cmd.AuthCode),
 	)
 }
 
@@ -1858,20 +3505,28 @@ func SendMany(
 		return nil, e
 	}
 	// Chk that minconf is positive.
-	minConf := int32(*cmd.MinConf)
-	if minConf < 0 {
-		return nil, ErrNeedPositiveMinconf
-	}
-	// Recreate address/amount pairs, using dcrutil.Amount.
-	pairs := make(map[string]amt.Amount, len(cmd.Amounts))
-	for k, v := range cmd.Amounts {
-		amt, e := amt.NewAmount(v)
-		if e != nil {
-			return nil, e
+	minConf := MinConfPolicy
+	if cmd.MinConf != nil {
+		if minConf = int32(*cmd.MinConf); minConf < 0 {
+			return nil, ErrNeedPositiveMinconf
 		}
-		pairs[k] = amt
 	}
-	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	feeSatPerKb, fee, e := w.SendFee(cmd.FeeOptions)
+	if e != nil {
+		return nil, e
+	}
+	// Recreate the outputs from the address and script amounts.
+	outputs, e := makeSendOutputs(cmd.Amounts, cmd.Scripts, w.ChainParams(), feeSatPerKb)
+	if e != nil {
+		return nil, e
+	}
+	request := *cmd
+	request.AuthCode, request.IdempotencyKey = nil, nil
+	return w.SendIdempotent(
+		idempotencyKey(cmd.IdempotencyKey), "sendmany", &request, func() (string, error) {
+			return sendOutputs(w, outputs, account, minConf, feeSatPerKb, fee, authCode(cmd.AuthCode))
+		},
+	)
 }
 
 // SendToAddress handles a sendtoaddress RPC request by creating a new transaction spending unspent transaction outputs
@@ -1887,37 +3542,153 @@ func SendToAddress(
 	if !ok {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInvalidParameter,
-			Message: HelpDescsEnUS()["sendtoaddress"],
-			// "invalid subcommand for addnode",
+			Message: HelpDescsEnUS()["sendtoaddress"],
+			// "invalid subcommand for addnode",
+		}
+	}
+	// Transaction comments are not yet supported.  ScriptError instead of
+	// pretending to save them.
+	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCUnimplemented,
+			Message: "Transaction comments are not yet supported",
+		}
+	}
+	amount, e := amt.NewAmount(cmd.Amount)
+	if e != nil {
+		D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>", e)
+		return nil, e
+	}
+	// Chk that signed integer parameters are positive.
+	if amount < 0 {
+		D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>> need positive amount")
+		return nil, ErrNeedPositiveAmount
+	}
+	feeSatPerKb, fee, e := w.SendFee(cmd.FeeOptions)
+	if e != nil {
+		return nil, e
+	}
+	// Mock up map of address and amount pairs.
+	pairs := map[string]amt.Amount{
+		cmd.Address: amount,
+	}
+	request := *cmd
+	request.AuthCode, request.IdempotencyKey = nil, nil
+	return w.SendIdempotent(
+		idempotencyKey(cmd.IdempotencyKey), "sendtoaddress", &request, func() (string, error) {
+			// sendtoaddress always spends from the default account, this matches bitcoind
+			return SendPairs(
+				w, pairs, waddrmgr.DefaultAccountNum, MinConfPolicy,
+				feeSatPerKb, fee, authCode(cmd.AuthCode),
+			)
+		},
+	)
+}
+
+// SetAddressMeta handles a setaddressmeta request by changing the fields of the metadata of an address that are set in
+// the request, creating the metadata if the address has none, and returning the metadata as it is stored.
+func SetAddressMeta(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetAddressMetaCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["setaddressmeta"],
+		}
+	}
+	addr, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	u := AddressMetaUpdate{
+		Message: cmd.Meta.Message,
+		Label:   cmd.Meta.Label,
+		State:   cmd.Meta.State,
+		TxID:    cmd.Meta.TxID,
+	}
+	if cmd.Meta.Amount != nil {
+		var amount amt.Amount
+		if amount, e = amt.NewAmount(*cmd.Meta.Amount); e != nil {
+			return nil, e
+		}
+		u.Amount = &amount
+	}
+	if cmd.Meta.Expires != nil {
+		var expires time.Time
+		if *cmd.Meta.Expires != 0 {
+			expires = time.Unix(*cmd.Meta.Expires, 0)
+		}
+		u.Expires = &expires
+	}
+	if u.TxID != nil && *u.TxID != "" {
+		if _, e = chainhash.NewHashFromStr(*u.TxID); e != nil {
+			return nil, DeserializationError{e}
+		}
+	}
+	m, e := w.SetAddressMeta(addr, u)
+	if e != nil {
+		return nil, e
+	}
+	return addressMetaResult(m), nil
+}
+
+// SetInvoiceIssuance handles a setinvoiceissuance request by switching the sequential issuance of the addresses of an
+// account on or off.
+func SetInvoiceIssuance(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetInvoiceIssuanceCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["setinvoiceissuance"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	if e = w.SetSequentialIssuance(account, cmd.Enable); e != nil {
+		return nil, e
+	}
+	return true, nil
+}
+
+// SetSpendAuth handles a setspendauth request by setting the PIN or time-based code the wallet requires to send more
+// than the limit, returning the secret and otpauth URI of a time-based code so it can be added to an authenticator.
+func SetSpendAuth(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetSpendAuthCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["setspendauth"],
 		}
 	}
-	// Transaction comments are not yet supported.  ScriptError instead of
-	// pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
+	var limit amt.Amount
+	if cmd.Limit != nil {
+		var e error
+		if limit, e = amt.NewAmount(*cmd.Limit); e != nil {
+			return nil, InvalidParameterError{e}
 		}
 	}
-	amount, e := amt.NewAmount(cmd.Amount)
+	var secret string
+	if cmd.Secret != nil {
+		secret = *cmd.Secret
+	}
+	totpSecret, e := w.SetSpendAuth(cmd.Method, limit, secret, authCode(cmd.Code))
 	if e != nil {
-		D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>", e)
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
 		return nil, e
 	}
-	// Chk that signed integer parameters are positive.
-	if amount < 0 {
-		D.Ln(">>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>> need positive amount")
-		return nil, ErrNeedPositiveAmount
+	sa, e := w.SpendAuth()
+	if e != nil {
+		return nil, e
 	}
-	// Mock up map of address and amount pairs.
-	pairs := map[string]amt.Amount{
-		cmd.Address: amount,
+	result := btcjson.SpendAuthResult{Method: sa.Method, Limit: sa.Limit.ToDUO()}
+	if totpSecret != "" {
+		result.Secret = totpSecret
+		result.URI = TOTPURI(totpSecret)
 	}
-	// sendtoaddress always spends from the default account, this matches bitcoind
-	return SendPairs(
-		w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb,
-	)
+	return result, nil
 }
 
 // SetTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1962,12 +3733,18 @@ func SignMessage(
 	if e != nil {
 		return nil, e
 	}
+	return signMessage(privKey, cmd.Message)
+}
+
+// signMessage returns the signature of the message made with the private key as it is returned by signmessage, which
+// verifymessage checks.
+func signMessage(privKey *ecc.PrivateKey, message string) (string, error) {
 	var buf bytes.Buffer
-	e = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
+	e := wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
 	if e != nil {
 		D.Ln(e)
 	}
-	e = wire.WriteVarString(&buf, 0, cmd.Message)
+	e = wire.WriteVarString(&buf, 0, message)
 	if e != nil {
 		D.Ln(e)
 	}
@@ -1977,7 +3754,7 @@ func SignMessage(
 		messageHash, true,
 	)
 	if e != nil {
-		return nil, e
+		return "", e
 	}
 	return base64.StdEncoding.EncodeToString(sigbytes), nil
 }
@@ -2077,6 +3854,7 @@ func SignRawTransaction(
 	// Now we go and look for any inputs that we were not provided by querying pod with getrawtransaction. We queue up a
 	// bunch of async requests and will wait for replies after we have checked the rest of the arguments.
 	requested := make(map[wire.OutPoint]rpcclient.FutureGetTxOutResult)
+	values := make(map[wire.OutPoint]amt.Amount)
 	for _, txIn := range tx.TxIn {
 		// Did we get this outpoint from the arguments?
 		if _, ok := inputs[txIn.PreviousOutPoint]; ok {
@@ -2129,6 +3907,23 @@ func SignRawTransaction(
 			return nil, e
 		}
 		inputs[outPoint] = script
+		if values[outPoint], e = amt.NewAmount(result.Value); E.Chk(e) {
+			return nil, e
+		}
+	}
+	// Keys given with the request are used in place of the keys of the wallet, so only a transaction signed with the
+	// wallet keys spends from the wallet, and needs a code above the spend limit.
+	if len(keys) == 0 {
+		var spend amt.Amount
+		if spend, e = w.SignedSpend(&tx, inputs, values); E.Chk(e) {
+			return nil, e
+		}
+		if e = w.AuthorizeSpend(spend, authCode(cmd.AuthCode)); e != nil {
+			if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+				return nil, &ErrWalletUnlockNeeded
+			}
+			return nil, e
+		}
 	}
 	// All args collected. Now we can sign all the inputs that we can. `complete' denotes that we successfully signed
 	// all outputs and that all scripts will run to completion. This is returned as part of the reply.
@@ -2163,6 +3958,325 @@ func SignRawTransaction(
 	}, nil
 }
 
+// SubmitSignedPSBT handles a submitsignedpsbt request by adding the signatures of a PSBT returned by an external
+// signer to the transaction it signs in the signing queue, and broadcasting the transaction once it is fully signed.
+// It returns the hash of the transaction sent.
+func SubmitSignedPSBT(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SubmitSignedPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["submitsignedpsbt"],
+		}
+	}
+	p, e := psbt.ParseBase64(cmd.PSBT)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "PSBT decode failed: " + e.Error(),
+		}
+	}
+	txid, e := w.SubmitSignedPSBT(p)
+	switch e {
+	case nil:
+	case ErrPSBTNotQueued, psbt.ErrDifferentTx:
+		return nil, InvalidParameterError{e}
+	default:
+		return nil, e
+	}
+	return txid.String(), nil
+}
+
+// SweepAccount handles a sweepaccount request by moving the whole spendable balance of an account, counting only
+// outputs with at least minconf confirmations, to an address, with the fee taken out of the amount sent. An optional
+// reserve is left in the account as change. With dry run set the sweep is only worked out, so it can be checked before
+// it is made.
+func SweepAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SweepAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["sweepaccount"],
+		}
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	if e != nil {
+		return nil, e
+	}
+	destination, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	reserve, e := amt.NewAmount(*cmd.Reserve)
+	if e != nil {
+		return nil, e
+	}
+	if reserve < 0 {
+		return nil, ErrNeedPositiveAmount
+	}
+	sweep, e := w.PrepareAccountSweep(account, destination, minConf, reserve)
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	result := btcjson.SweepAccountResult{
+		Account:     cmd.Account,
+		Destination: destination.EncodeAddress(),
+		Inputs:      len(sweep.Tx.Tx.TxIn),
+		Amount:      sweep.Amount.ToDUO(),
+		Reserve:     sweep.Reserve.ToDUO(),
+		Fee:         sweep.Fee.ToDUO(),
+	}
+	if *cmd.DryRun {
+		w.DiscardAccountSweep(sweep)
+		return result, nil
+	}
+	if e = w.AuthorizeSpend(sweep.Amount, authCode(cmd.AuthCode)); e != nil {
+		w.DiscardAccountSweep(sweep)
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	txHash, e := w.PublishAccountSweep(sweep)
+	if e != nil {
+		return nil, e
+	}
+	I.Ln("swept account", cmd.Account, "in transaction", txHash)
+	result.TxID = txHash.String()
+	return result, nil
+}
+
+// WithdrawVault handles a withdrawvault request by sending the unlocked outputs of a vault account to an address, less
+// the fee, and returning the transaction ID.
+func WithdrawVault(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.WithdrawVaultCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["withdrawvault"],
+		}
+	}
+	destination, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	txHash, e := w.WithdrawVault(cmd.Name, destination, authCode(cmd.AuthCode))
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	I.Ln("withdrew from vault account", cmd.Name, "in transaction", txHash)
+	return txHash.String(), nil
+}
+
+// SweepTimeLocked handles a sweeptimelocked request by sending the unlocked outputs of the time locked scripts of the
+// wallet to an address, less the fee. With dry run set the sweep is only worked out, so it can be checked before it is
+// made.
+func SweepTimeLocked(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SweepTimeLockedCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["sweeptimelocked"],
+		}
+	}
+	destination, e := DecodeAddress(cmd.Address, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	sweep, e := w.PrepareTimeLockedSweep(destination)
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	result := btcjson.SweepTimeLockedResult{
+		Destination: destination.EncodeAddress(),
+		Inputs:      len(sweep.Tx.Tx.TxIn),
+		Amount:      sweep.Amount.ToDUO(),
+		Fee:         sweep.Fee.ToDUO(),
+	}
+	if *cmd.DryRun {
+		return result, nil
+	}
+	if e = w.AuthorizeSpend(sweep.Amount, authCode(cmd.AuthCode)); e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	txHash, e := w.PublishTimeLockedSweep(sweep)
+	if e != nil {
+		return nil, e
+	}
+	I.Ln("swept time locked outputs in transaction", txHash)
+	result.TxID = txHash.String()
+	return result, nil
+}
+
+// SweepPrivKey handles a sweepprivkey request by moving all the funds of a key that is not in the wallet, such as the
+// key of a paper wallet, to an address of an account of the wallet. The outputs of the key are found with the address
+// index of the chain server. With dry run set the sweep is only worked out, so it can be checked before it is made.
+func SweepPrivKey(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	if len(chainClient) < 1 || chainClient[0] == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoChain,
+			Message: "there is currently no chain client to get this response",
+		}
+	}
+	cmd, ok := icmd.(*btcjson.SweepPrivKeyCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["sweepprivkey"],
+		}
+	}
+	wif, e := util.DecodeWIF(cmd.PrivKey)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "WIF decode failed: " + e.Error(),
+		}
+	}
+	if !wif.IsForNet(w.ChainParams()) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Key is not intended for " + w.ChainParams().Name,
+		}
+	}
+	acctName := "default"
+	if cmd.Account != nil {
+		acctName = *cmd.Account
+	}
+	account, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, acctName)
+	if e != nil {
+		return nil, e
+	}
+	destination, e := w.CurrentAddress(account, waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		return nil, e
+	}
+	sweep, e := w.PrepareSweep(chainClient[0], wif, destination)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "cannot sweep key: " + e.Error(),
+		}
+	}
+	result := btcjson.SweepPrivKeyResult{
+		Address:     sweep.Address.EncodeAddress(),
+		Destination: sweep.Destination.EncodeAddress(),
+		Outputs:     len(sweep.Tx.TxIn),
+		Amount:      sweep.Amount.ToDUO(),
+		Fee:         sweep.Fee.ToDUO(),
+	}
+	if cmd.DryRun != nil && *cmd.DryRun {
+		return result, nil
+	}
+	txHash, e := w.PublishSweep(sweep)
+	if e != nil {
+		return nil, e
+	}
+	result.TxID = txHash.String()
+	return result, nil
+}
+
+// TransferAccount handles a transferaccount request by moving an amount from one account of the wallet to the current
+// address of another, with the fee paid by the fee account when one is given so the source account is charged exactly
+// the amount.
+func TransferAccount(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.TransferAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["transferaccount"],
+		}
+	}
+	from, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
+	if e != nil {
+		return nil, e
+	}
+	to, e := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.ToAccount)
+	if e != nil {
+		return nil, e
+	}
+	var feeAccount uint32
+	sponsored := !IsNilOrEmpty(cmd.FeeAccount)
+	if sponsored {
+		if feeAccount, e = w.AccountNumber(waddrmgr.KeyScopeBIP0044, *cmd.FeeAccount); e != nil {
+			return nil, e
+		}
+	}
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	if cmd.Amount <= 0 {
+		return nil, ErrNeedPositiveAmount
+	}
+	amount, e := amt.NewAmount(cmd.Amount)
+	if e != nil {
+		return nil, e
+	}
+	if !*cmd.DryRun {
+		if e = w.AuthorizeSpend(amount, authCode(cmd.AuthCode)); e != nil {
+			if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+				return nil, &ErrWalletUnlockNeeded
+			}
+			return nil, e
+		}
+	}
+	transfer, e := w.PrepareAccountTransfer(from, to, amount, sponsored, feeAccount, minConf)
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, e
+	}
+	result := btcjson.TransferAccountResult{
+		FromAccount: cmd.FromAccount,
+		ToAccount:   cmd.ToAccount,
+		FeeAccount:  cmd.FromAccount,
+		Address:     transfer.Destination.EncodeAddress(),
+		Amount:      transfer.Amount.ToDUO(),
+		Fee:         transfer.Fee.ToDUO(),
+	}
+	if sponsored {
+		result.FeeAccount = *cmd.FeeAccount
+	}
+	outputs := transfer.Tx.Tx.TxOut
+	if transfer.SourceChange >= 0 {
+		result.SourceChange = amt.Amount(outputs[transfer.SourceChange].Value).ToDUO()
+	}
+	if transfer.FeeChange >= 0 {
+		result.FeeChange = amt.Amount(outputs[transfer.FeeChange].Value).ToDUO()
+	}
+	if *cmd.DryRun {
+		w.DiscardAccountTransfer(transfer)
+		return result, nil
+	}
+	txHash, e := w.PublishAccountTransfer(transfer)
+	if e != nil {
+		return nil, e
+	}
+	I.Ln("transferred", transfer.Amount, "from account", cmd.FromAccount, "to", cmd.ToAccount, "in transaction", txHash)
+	result.TxID = txHash.String()
+	return result, nil
+}
+
 // ValidateAddress handles the validateaddress command.
 func ValidateAddress(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.ValidateAddressCmd)
@@ -2294,6 +4408,56 @@ func VerifyMessage(
 	}
 }
 
+// WalletDBStats handles a walletdbstats request by returning the key counts and space used by each bucket of the wallet
+// database, with its largest keys.
+func WalletDBStats(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.WalletDBStatsCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["walletdbstats"],
+		}
+	}
+	largest := DefaultDBStatsLargest
+	if cmd.Largest != nil {
+		largest = *cmd.Largest
+	}
+	if largest < 0 || largest > 100 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "largest must be between 0 and 100",
+		}
+	}
+	stats, e := DBStats(w.db, largest)
+	if e != nil {
+		return nil, e
+	}
+	buckets := make([]btcjson.WalletDBBucketResult, len(stats))
+	for i, s := range stats {
+		buckets[i] = btcjson.WalletDBBucketResult{
+			Path:       s.Path,
+			Keys:       s.Keys,
+			Buckets:    s.Buckets,
+			KeyBytes:   s.KeyBytes,
+			ValueBytes: s.ValueBytes,
+			TotalBytes: s.TotalBytes,
+		}
+		for _, k := range s.Largest {
+			buckets[i].LargestKeys = append(buckets[i].LargestKeys, btcjson.WalletDBKeyResult{Key: k.Key, Bytes: k.Bytes})
+		}
+	}
+	return buckets, nil
+}
+
+// WalletSelfTest handles a walletselftest request by running the consistency checks of the wallet and returning what
+// they found.
+func WalletSelfTest(icmd interface{}, w *Wallet, chainClient ...*chainclient.RPCClient) (interface{}, error) {
+	return w.SelfTest(), nil
+}
+
 // WalletIsLocked handles the walletislocked extension request by returning the current lock state (false for unlocked,
 // true for locked) of an account.
 func WalletIsLocked(
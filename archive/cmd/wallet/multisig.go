@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/p9c/pod/pkg/btcaddr"
 	
+	"github.com/p9c/pod/pkg/chainclient"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/waddrmgr"
 	"github.com/p9c/pod/pkg/walletdb"
@@ -102,3 +103,66 @@ func (w *Wallet) ImportP2SHRedeemScript(script []byte) (*btcaddr.ScriptHash, err
 	)
 	return p2shAddr, e
 }
+
+// CreateMultiSigAccount adds an M-of-N multisig account with the name, needing required signatures of the cosigners
+// given by the extended public keys of their accounts. Keys that are the extended public key of one of the wallet's
+// accounts are marked as ours, and that account should be kept for the multisig account.
+func (w *Wallet) CreateMultiSigAccount(name string, required int, xpubs []string) (
+	ma *waddrmgr.MultiSigAccount, e error,
+) {
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ma, e = w.Manager.NewMultiSigAccount(tx.ReadWriteBucket(waddrmgrNamespaceKey), name, required, xpubs)
+			return
+		},
+	)
+	return
+}
+
+// MultiSigAccounts returns the multisig accounts of the wallet.
+func (w *Wallet) MultiSigAccounts() (accounts []*waddrmgr.MultiSigAccount, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			return w.Manager.ForEachMultiSigAccount(
+				tx.ReadBucket(waddrmgrNamespaceKey), func(ma *waddrmgr.MultiSigAccount) error {
+					accounts = append(accounts, ma)
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// NewMultiSigAddress returns the next deposit address of the multisig account with the name along with its redeem
+// script and index, and has the chain server notify the wallet of payments to it. The address is tracked from the block
+// the wallet is synced to, so payments a cosigner received on it before need a rescan to be found.
+func (w *Wallet) NewMultiSigAddress(name string) (addr btcaddr.Address, script []byte, index uint32, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			var ma *waddrmgr.MultiSigAccount
+			if ma, e = w.Manager.FetchMultiSigAccount(addrmgrNs, name); E.Chk(e) {
+				return
+			}
+			index = ma.NextIndex
+			bs := w.Manager.SyncedTo()
+			var msa waddrmgr.ManagedScriptAddress
+			if msa, e = w.Manager.NextMultiSigAddress(addrmgrNs, name, &bs); E.Chk(e) {
+				return
+			}
+			addr = msa.Address()
+			script, e = msa.Script()
+			return
+		},
+	)
+	if e != nil {
+		return
+	}
+	e = chainClient.NotifyReceived([]btcaddr.Address{addr})
+	return
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/p9c/pod/pkg/btcaddr"
 	"sync"
 	
+	"github.com/p9c/pod/pkg/blockchain"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/waddrmgr"
 	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
 	"github.com/p9c/pod/pkg/wtxmgr"
 )
 
@@ -37,6 +39,41 @@ type AccountNotificationsClient struct {
 	server *NotificationServer
 }
 
+// CoinbaseMaturityNotification is fired when a connected block brings the coinbase outputs paying to the wallet that
+// were mined CoinbaseMaturity blocks earlier to maturity, making them spendable.
+type CoinbaseMaturityNotification struct {
+	// Height is the height of the block whose connection matured the outputs.
+	Height int32
+	// MinedHeight is the height of the block containing the coinbase transaction.
+	MinedHeight int32
+	Outputs     []MaturedCoinbaseOutput
+}
+
+// CoinbaseMaturityNotificationsClient receives CoinbaseMaturityNotifications over the channel C.
+type CoinbaseMaturityNotificationsClient struct {
+	C      <-chan *CoinbaseMaturityNotification
+	server *NotificationServer
+}
+
+// DustAttackNotification is fired when tiny outputs paying to many addresses of the wallet in a short time are taken
+// for a dusting attack, which tries to link the addresses when the outputs are spent together, and are frozen.
+type DustAttackNotification struct {
+	Outputs []DustOutput
+}
+
+// DustAttackNotificationsClient receives DustAttackNotifications over the channel C.
+type DustAttackNotificationsClient struct {
+	C      <-chan *DustAttackNotification
+	server *NotificationServer
+}
+
+// MaturedCoinbaseOutput describes a wallet coinbase output that has just become spendable.
+type MaturedCoinbaseOutput struct {
+	OutPoint wire.OutPoint
+	Amount   amt.Amount
+	Account  uint32
+}
+
 // Block contains the properties and all relevant transactions of an attached
 // block.
 type Block struct {
@@ -61,6 +98,8 @@ type NotificationServer struct {
 	currentTxNtfn  *TransactionNotifications // coalesce this since wallet does not add mined txs together
 	spentness      map[uint32][]chan *SpentnessNotifications
 	accountClients []chan *AccountNotification
+	maturity       []chan *CoinbaseMaturityNotification
+	dust           []chan *DustAttackNotification
 	mu             sync.Mutex // Only protects registered client channels
 	wallet         *Wallet    // smells like hacks
 }
@@ -186,6 +225,36 @@ func (s *NotificationServer) AccountSpentnessNotifications(account uint32) Spent
 	}
 }
 
+// CoinbaseMaturityNotifications returns a client for receiving CoinbaseMaturityNotifications over a channel. The
+// channel is unbuffered.
+//
+// When finished, the Done method should be called on the client to disassociate it from the server.
+func (s *NotificationServer) CoinbaseMaturityNotifications() CoinbaseMaturityNotificationsClient {
+	c := make(chan *CoinbaseMaturityNotification)
+	s.mu.Lock()
+	s.maturity = append(s.maturity, c)
+	s.mu.Unlock()
+	return CoinbaseMaturityNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
+// DustAttackNotifications returns a client for receiving DustAttackNotifications over a channel. The channel is
+// unbuffered.
+//
+// When finished, the Done method should be called on the client to disassociate it from the server.
+func (s *NotificationServer) DustAttackNotifications() DustAttackNotificationsClient {
+	c := make(chan *DustAttackNotification)
+	s.mu.Lock()
+	s.dust = append(s.dust, c)
+	s.mu.Unlock()
+	return DustAttackNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
 // TransactionNotifications returns a client for receiving TransactionNotifications notifications over a channel. The
 // channel is unbuffered.
 //
@@ -277,6 +346,71 @@ func (s *NotificationServer) notifyAttachedBlock(dbtx walletdb.ReadTx, block *wt
 	}
 	s.currentTxNtfn = nil
 }
+
+// notifyMaturedCoinbase notifies registered clients of the wallet's unspent coinbase outputs that became mature with the
+// connection of the passed block.
+func (s *NotificationServer) notifyMaturedCoinbase(dbtx walletdb.ReadTx, block *wtxmgr.BlockMeta) {
+	minedHeight := block.Height - int32(s.wallet.chainParams.CoinbaseMaturity) + 1
+	if minedHeight < 0 {
+		return
+	}
+	n := &CoinbaseMaturityNotification{
+		Height:      block.Height,
+		MinedHeight: minedHeight,
+	}
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+	e := s.wallet.TxStore.RangeTransactions(
+		txmgrNs, minedHeight, minedHeight, func(details []wtxmgr.TxDetails) (bool, error) {
+			for i := range details {
+				if !blockchain.IsCoinBaseTx(&details[i].MsgTx) {
+					continue
+				}
+				for _, cred := range details[i].Credits {
+					if cred.Spent {
+						continue
+					}
+					account, _ := lookupOutputChain(dbtx, s.wallet, &details[i], cred)
+					n.Outputs = append(
+						n.Outputs, MaturedCoinbaseOutput{
+							OutPoint: wire.OutPoint{Hash: details[i].Hash, Index: cred.Index},
+							Amount:   cred.Amount,
+							Account:  account,
+						},
+					)
+				}
+			}
+			return false, nil
+		},
+	)
+	if e != nil {
+		E.Ln("cannot fetch coinbase transactions for maturity notification:", e)
+		return
+	}
+	if len(n.Outputs) == 0 {
+		return
+	}
+	for _, o := range n.Outputs {
+		I.F(
+			"coinbase output %v mined at height %d matured at height %d: %v now spendable",
+			o.OutPoint, minedHeight, block.Height, o.Amount,
+		)
+	}
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, c := range s.maturity {
+		c <- n
+	}
+}
+
+// notifyDustAttack notifies registered clients of the outputs that were frozen as the outputs of a dusting attack.
+func (s *NotificationServer) notifyDustAttack(outputs []DustOutput) {
+	n := &DustAttackNotification{Outputs: outputs}
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, c := range s.dust {
+		c <- n
+	}
+}
 func (s *NotificationServer) notifyDetachedBlock(hash *chainhash.Hash) {
 	if s.currentTxNtfn == nil {
 		s.currentTxNtfn = &TransactionNotifications{}
@@ -427,6 +561,54 @@ func (c *SpentnessNotificationsClient) Done() {
 	}()
 }
 
+// Done unregisters the client from the server and drains any remaining messages. It must be called exactly once when
+// the client is finished receiving notifications.
+func (c *CoinbaseMaturityNotificationsClient) Done() {
+	go func() {
+		// Drain notifications until the client channel is removed from the server and closed.
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.maturity
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.maturity = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
+
+// Done unregisters the client from the server and drains any remaining messages. It must be called exactly once when
+// the client is finished receiving notifications.
+func (c *DustAttackNotificationsClient) Done() {
+	go func() {
+		// Drain notifications until the client channel is removed from the server and closed.
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.dust
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.dust = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
+
 // Done unregisters the client from the server and drains any remaining messages. It must be called exactly once when
 // the client is finished receiving notifications.
 func (c *TransactionNotificationsClient) Done() {
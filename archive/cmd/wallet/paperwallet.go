@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+const (
+	// paymentURIScheme is the scheme of the payment URIs encoded in the QR codes of paper wallet addresses.
+	paymentURIScheme = "parallelcoin:"
+	// sweepSearchPage is the number of transactions requested at a time when searching the address index of the chain
+	// server for the outputs of a key being swept.
+	sweepSearchPage = 100
+)
+
+// PaperKey is a standalone key pair that is not stored in the wallet, for printing on a paper wallet or giving away.
+type PaperKey struct {
+	Address *btcaddr.PubKeyHash
+	WIF     *util.WIF
+}
+
+// GeneratePaperKey returns a new key pair for the network of the wallet. The key is not stored in the wallet, its funds
+// can be moved into the wallet later with PrepareSweep.
+func (w *Wallet) GeneratePaperKey() (k PaperKey, e error) {
+	var priv *ecc.PrivateKey
+	if priv, e = ecc.NewPrivateKey(ecc.S256()); E.Chk(e) {
+		return
+	}
+	if k.WIF, e = util.NewWIF(priv, w.chainParams, true); E.Chk(e) {
+		return
+	}
+	k.Address, e = wifAddress(k.WIF, w.chainParams)
+	return
+}
+
+// wifAddress returns the pay to public key hash address of the key in wif, in the public key format of wif.
+func wifAddress(wif *util.WIF, params *chaincfg.Params) (*btcaddr.PubKeyHash, error) {
+	return btcaddr.NewPubKeyHash(btcaddr.Hash160(wif.SerializePubKey()), params)
+}
+
+// Sweep is a signed transaction moving all the funds of a key that is not in the wallet to an address of the wallet.
+type Sweep struct {
+	// Address is the address of the swept key.
+	Address     btcaddr.Address
+	Destination btcaddr.Address
+	// Amount is the total of the swept outputs, which is sent to Destination less Fee.
+	Amount amt.Amount
+	Fee    amt.Amount
+	Tx     *wire.MsgTx
+}
+
+// PrepareSweep finds the unspent outputs paying the address of the key in wif with the address index of the chain
+// server, and returns a transaction spending all of them to destination less the relay fee, signed with the key. The
+// transaction is not broadcast, which is done with PublishSweep.
+func (w *Wallet) PrepareSweep(
+	chainClient *chainclient.RPCClient, wif *util.WIF, destination btcaddr.Address,
+) (s *Sweep, e error) {
+	if !wif.IsForNet(w.chainParams) {
+		return nil, errors.New("key network doesn't match wallet's")
+	}
+	s = &Sweep{Destination: destination}
+	var addr *btcaddr.PubKeyHash
+	if addr, e = wifAddress(wif, w.chainParams); E.Chk(e) {
+		return nil, e
+	}
+	s.Address = addr
+	var pkScript, destScript []byte
+	if pkScript, e = txscript.PayToAddrScript(addr); E.Chk(e) {
+		return nil, e
+	}
+	if destScript, e = txscript.PayToAddrScript(destination); E.Chk(e) {
+		return nil, e
+	}
+	var outPoints []wire.OutPoint
+	if outPoints, e = w.sweepCandidates(chainClient, addr, hex.EncodeToString(pkScript)); E.Chk(e) {
+		return nil, e
+	}
+	s.Tx = wire.NewMsgTx(wire.TxVersion)
+	for i := range outPoints {
+		var txOut *btcjson.GetTxOutResult
+		if txOut, e = chainClient.GetTxOut(&outPoints[i].Hash, outPoints[i].Index, true); E.Chk(e) {
+			return nil, e
+		}
+		// Spent outputs are not returned, and immature coinbases can not be spent yet.
+		if txOut == nil ||
+			(txOut.Coinbase && txOut.Confirmations < int64(w.chainParams.CoinbaseMaturity)) {
+			continue
+		}
+		var value amt.Amount
+		if value, e = amt.NewAmount(txOut.Value); E.Chk(e) {
+			return nil, e
+		}
+		s.Amount += value
+		s.Tx.AddTxIn(wire.NewTxIn(&outPoints[i], nil, nil))
+	}
+	if len(s.Tx.TxIn) == 0 {
+		return nil, fmt.Errorf("no spendable outputs pay %s", addr.EncodeAddress())
+	}
+	out := wire.NewTxOut(0, destScript)
+	size := txsizes.EstimateSerializeSize(len(s.Tx.TxIn), []*wire.TxOut{out}, false)
+	if !wif.CompressPubKey {
+		// The estimate is for compressed public keys, which are 32 bytes shorter.
+		size += len(s.Tx.TxIn) * 32
+	}
+	s.Fee = txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, size)
+	if s.Amount <= s.Fee ||
+		txrules.IsDustAmount(s.Amount-s.Fee, len(destScript), txrules.DefaultRelayFeePerKb) {
+		return nil, fmt.Errorf("%v at %s is too little to pay the fee of %v", s.Amount, addr.EncodeAddress(), s.Fee)
+	}
+	out.Value = int64(s.Amount - s.Fee)
+	s.Tx.AddTxOut(out)
+	for i := range s.Tx.TxIn {
+		if s.Tx.TxIn[i].SignatureScript, e = txscript.SignatureScript(
+			s.Tx, i, pkScript, txscript.SigHashAll, wif.PrivKey, wif.CompressPubKey,
+		); E.Chk(e) {
+			return nil, e
+		}
+	}
+	return
+}
+
+// sweepCandidates returns the outputs paying pkScript, the hex of the script of addr, in the transactions of addr in
+// the address index of the chain server, whether they are spent or not.
+func (w *Wallet) sweepCandidates(
+	chainClient *chainclient.RPCClient, addr btcaddr.Address, pkScript string,
+) (outPoints []wire.OutPoint, e error) {
+	seen := make(map[wire.OutPoint]struct{})
+	for skip := 0; ; skip += sweepSearchPage {
+		var txs []*btcjson.SearchRawTransactionsResult
+		txs, e = chainClient.SearchRawTransactionsVerbose(addr, skip, sweepSearchPage, false, false, nil)
+		if e != nil {
+			// The address index answers that it knows nothing about the address when there are no more transactions.
+			if rpcErr, ok := e.(*btcjson.RPCError); ok && rpcErr.Code == btcjson.ErrRPCNoTxInfo {
+				return outPoints, nil
+			}
+			return nil, e
+		}
+		for _, tx := range txs {
+			var hash *chainhash.Hash
+			if hash, e = chainhash.NewHashFromStr(tx.TxID); E.Chk(e) {
+				return nil, e
+			}
+			for _, vout := range tx.VOut {
+				if vout.ScriptPubKey.Hex != pkScript {
+					continue
+				}
+				op := wire.OutPoint{Hash: *hash, Index: vout.N}
+				if _, ok := seen[op]; !ok {
+					seen[op] = struct{}{}
+					outPoints = append(outPoints, op)
+				}
+			}
+		}
+		if len(txs) < sweepSearchPage {
+			return outPoints, nil
+		}
+	}
+}
+
+// PublishSweep broadcasts the transaction of a sweep and records it in the wallet, returning its hash.
+func (w *Wallet) PublishSweep(s *Sweep) (*chainhash.Hash, error) {
+	return w.publishTransaction(s.Tx)
+}
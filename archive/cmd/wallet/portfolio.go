@@ -0,0 +1,528 @@
+package wallet
+
+import (
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/descriptor"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+const (
+	// maxPortfolioName is the longest name, in bytes, a portfolio entry can have.
+	maxPortfolioName = 64
+	// maxPortfolioRange is the most scripts that are derived from each ranged descriptor of a portfolio entry.
+	maxPortfolioRange = 100000
+)
+
+// The portfolio namespace holds the cold wallets watched by the wallet, each given by the public descriptors of its
+// output scripts. It is kept apart from the address manager and the transaction store so nothing in it is ever part of
+// the balance of the wallet or spent by it, and holds no private keys. The entries bucket holds the entries keyed by
+// their name, the scripts bucket the name of the entry each derived script belongs to, and the credits bucket the
+// outputs paying to them keyed like the frozen outputs.
+var (
+	portfolioEntriesBucket = []byte("entries")
+	portfolioScriptsBucket = []byte("scripts")
+	portfolioCreditsBucket = []byte("credits")
+)
+
+// PortfolioEntry is a cold wallet watched in the portfolio, with the funds it holds as of the block the wallet is synced
+// to.
+type PortfolioEntry struct {
+	Name        string
+	Descriptors []string
+	// Range is the number of scripts derived from each ranged descriptor.
+	Range uint32
+	// Height is the height of the block from which the entry is watched.
+	Height int32
+	Added  time.Time
+	// Balance is the value of the unspent outputs with enough confirmations, and Unconfirmed that of the others.
+	Balance     amt.Amount
+	Unconfirmed amt.Amount
+	Unspent     int
+}
+
+// PortfolioTx is the change a transaction made to the funds of a portfolio entry, which is negative for a transaction
+// spending from it. Height is -1 while the transaction is unmined.
+type PortfolioTx struct {
+	Entry  string
+	TxID   chainhash.Hash
+	Amount amt.Amount
+	Height int32
+	Time   time.Time
+}
+
+// portfolioRecord is the encoding of a portfolio entry in the database.
+type portfolioRecord struct {
+	Descriptors []string `json:"descriptors"`
+	Range       uint32   `json:"range"`
+	Height      int32    `json:"height"`
+	Added       int64    `json:"added"`
+}
+
+// portfolioCredit is the encoding of an output paying to a portfolio entry in the database. The heights are -1 while
+// the transactions are unmined, and SpentBy is empty until a transaction spending the output is seen.
+type portfolioCredit struct {
+	Entry       string `json:"entry"`
+	Amount      int64  `json:"amount"`
+	Height      int32  `json:"height"`
+	Time        int64  `json:"time"`
+	SpentBy     string `json:"spentby,omitempty"`
+	SpentHeight int32  `json:"spentheight,omitempty"`
+	SpentTime   int64  `json:"spenttime,omitempty"`
+}
+
+// portfolioDescriptors returns the descriptors of a portfolio entry in canonical form. An extended public key given in
+// place of a descriptor stands for the pay to public key hash receiving and change chains derived from it. Descriptors
+// and keys holding private keys are refused, as the portfolio only ever watches.
+func portfolioDescriptors(descs []string, net *chaincfg.Params) (canonical []string, e error) {
+	if len(descs) == 0 {
+		return nil, InvalidParameterError{errors.New("a portfolio entry needs at least one descriptor")}
+	}
+	for _, desc := range descs {
+		desc = strings.TrimSpace(desc)
+		if key, e := hdkeychain.NewKeyFromString(desc); e == nil {
+			if key.IsPrivate() {
+				return nil, InvalidParameterError{errors.New("extended private keys can't be added to the portfolio")}
+			}
+			if !key.IsForNet(net) {
+				return nil, InvalidParameterError{fmt.Errorf("extended key is not for %s", net.Name)}
+			}
+			for _, chain := range []string{"0", "1"} {
+				var d *descriptor.Descriptor
+				if d, e = descriptor.Parse("pkh("+desc+"/"+chain+"/*)", net); E.Chk(e) {
+					return nil, InvalidParameterError{e}
+				}
+				canonical = append(canonical, d.String())
+			}
+			continue
+		}
+		var d *descriptor.Descriptor
+		if d, e = descriptor.Parse(desc, net); e != nil {
+			return nil, InvalidParameterError{e}
+		}
+		if d.HasPrivateKeys() {
+			return nil, InvalidParameterError{errors.New("descriptors with private keys can't be added to the portfolio")}
+		}
+		canonical = append(canonical, d.String())
+	}
+	return
+}
+
+// portfolioScripts returns the output scripts of the descriptors of a portfolio entry, those of the first rng indexes
+// of each ranged descriptor.
+func portfolioScripts(descs []string, rng uint32, net *chaincfg.Params) (scripts [][]byte, e error) {
+	for _, desc := range descs {
+		var d *descriptor.Descriptor
+		if d, e = descriptor.Parse(desc, net); E.Chk(e) {
+			return
+		}
+		var s [][]byte
+		if d.IsRange() {
+			s, e = d.Scripts(0, rng-1)
+		} else {
+			s, e = d.Scripts(0, 0)
+		}
+		if E.Chk(e) {
+			return
+		}
+		scripts = append(scripts, s...)
+	}
+	return
+}
+
+// AddPortfolioEntry adds a cold wallet to the portfolio under the name, watching the output scripts of the descriptors
+// or extended public keys, with the first rng scripts derived from each that is ranged. When rescan is true the blocks
+// from the genesis block are searched for payments to the scripts in the background, otherwise they are watched from
+// the block the wallet is synced to.
+//
+// Like the scripts imported with ImportScriptPubKey, the scripts are matched by the transaction filter of the chain
+// server, so a websocket RPC chain client is required.
+func (w *Wallet) AddPortfolioEntry(name string, descs []string, rng uint32, rescan bool) (e error) {
+	if name == "" || len(name) > maxPortfolioName {
+		return InvalidParameterError{
+			fmt.Errorf("the name of a portfolio entry must be 1 to %d bytes long", maxPortfolioName),
+		}
+	}
+	if rng == 0 || rng > maxPortfolioRange {
+		return InvalidParameterError{fmt.Errorf("the range of a portfolio entry must be 1 to %d", maxPortfolioRange)}
+	}
+	var canonical []string
+	if canonical, e = portfolioDescriptors(descs, w.chainParams); e != nil {
+		return
+	}
+	var scripts [][]byte
+	if scripts, e = portfolioScripts(canonical, rng, w.chainParams); E.Chk(e) {
+		return
+	}
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	rpcClient, ok := chainClient.(*chainclient.RPCClient)
+	if !ok {
+		return errors.New("watching a portfolio requires the RPC chain client")
+	}
+	rec := portfolioRecord{Descriptors: canonical, Range: rng, Added: time.Now().Unix()}
+	if !rescan {
+		rec.Height = w.Manager.SyncedTo().Height
+	}
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			return putPortfolioEntry(tx, name, &rec, scripts)
+		},
+	); e != nil {
+		return
+	}
+	I.F("watching portfolio entry %s of %d scripts from block %d", name, len(scripts), rec.Height)
+	if _, e = w.loadWatchFilter(rpcClient); E.Chk(e) {
+		return
+	}
+	if rescan {
+		go func() {
+			if e := w.rescanWatchedScripts(rpcClient, rec.Height); E.Chk(e) {
+				E.Ln("rescan for portfolio entry failed:", e)
+			}
+		}()
+	}
+	return
+}
+
+// putPortfolioEntry stores a portfolio entry and the scripts derived from it. Each script can belong to only one entry.
+func putPortfolioEntry(tx walletdb.ReadWriteTx, name string, rec *portfolioRecord, scripts [][]byte) (e error) {
+	ns := tx.ReadWriteBucket(portfolioNamespaceKey)
+	if ns == nil {
+		if ns, e = tx.CreateTopLevelBucket(portfolioNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	var entries, scriptsBucket walletdb.ReadWriteBucket
+	if entries, e = ns.CreateBucketIfNotExists(portfolioEntriesBucket); E.Chk(e) {
+		return
+	}
+	if scriptsBucket, e = ns.CreateBucketIfNotExists(portfolioScriptsBucket); E.Chk(e) {
+		return
+	}
+	if _, e = ns.CreateBucketIfNotExists(portfolioCreditsBucket); E.Chk(e) {
+		return
+	}
+	if entries.Get([]byte(name)) != nil {
+		return InvalidParameterError{fmt.Errorf("the portfolio already has an entry named %s", name)}
+	}
+	for _, script := range scripts {
+		if owner := scriptsBucket.Get(script); owner != nil && string(owner) != name {
+			return InvalidParameterError{fmt.Errorf("the descriptors overlap portfolio entry %s", owner)}
+		}
+		if e = scriptsBucket.Put(script, []byte(name)); E.Chk(e) {
+			return
+		}
+	}
+	var body []byte
+	if body, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	return entries.Put([]byte(name), body)
+}
+
+// RemovePortfolioEntry removes a cold wallet from the portfolio along with the outputs recorded as paying to it.
+func (w *Wallet) RemovePortfolioEntry(name string) (e error) {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(portfolioNamespaceKey)
+			if ns == nil || ns.NestedReadBucket(portfolioEntriesBucket).Get([]byte(name)) == nil {
+				return InvalidParameterError{fmt.Errorf("the portfolio has no entry named %s", name)}
+			}
+			if e = ns.NestedReadWriteBucket(portfolioEntriesBucket).Delete([]byte(name)); E.Chk(e) {
+				return
+			}
+			// keys are collected before they are deleted, as a bucket must not be changed while iterating over it
+			var owned [][]byte
+			scripts := ns.NestedReadWriteBucket(portfolioScriptsBucket)
+			if e = scripts.ForEach(
+				func(k, v []byte) error {
+					if string(v) == name {
+						owned = append(owned, append([]byte(nil), k...))
+					}
+					return nil
+				},
+			); E.Chk(e) {
+				return
+			}
+			for _, k := range owned {
+				if e = scripts.Delete(k); E.Chk(e) {
+					return
+				}
+			}
+			owned = owned[:0]
+			credits := ns.NestedReadWriteBucket(portfolioCreditsBucket)
+			if e = credits.ForEach(
+				func(k, v []byte) (e error) {
+					var c portfolioCredit
+					if e = js.Unmarshal(v, &c); E.Chk(e) {
+						return
+					}
+					if c.Entry == name {
+						owned = append(owned, append([]byte(nil), k...))
+					}
+					return
+				},
+			); E.Chk(e) {
+				return
+			}
+			for _, k := range owned {
+				if e = credits.Delete(k); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+}
+
+// portfolioFilter returns the scripts of the portfolio and the unspent outputs paying to them, which are loaded into
+// the transaction filter of the chain server along with the watched scripts.
+func (w *Wallet) portfolioFilter() (scripts [][]byte, outPoints []wire.OutPoint, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(portfolioNamespaceKey)
+			if ns == nil {
+				return
+			}
+			if e = ns.NestedReadBucket(portfolioScriptsBucket).ForEach(
+				func(k, v []byte) error {
+					scripts = append(scripts, append([]byte(nil), k...))
+					return nil
+				},
+			); E.Chk(e) {
+				return
+			}
+			return forEachPortfolioCredit(
+				ns, func(op wire.OutPoint, c *portfolioCredit) error {
+					if c.SpentBy == "" {
+						outPoints = append(outPoints, op)
+					}
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// addPortfolioTx records the outputs of a relevant transaction paying to the scripts of the portfolio, and marks those
+// it spends as spent by it.
+func addPortfolioTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord, block *wtxmgr.BlockMeta) (e error) {
+	ns := dbtx.ReadWriteBucket(portfolioNamespaceKey)
+	if ns == nil {
+		return
+	}
+	scripts := ns.NestedReadBucket(portfolioScriptsBucket)
+	credits := ns.NestedReadWriteBucket(portfolioCreditsBucket)
+	height, t := int32(-1), rec.Received
+	if block != nil {
+		height, t = block.Height, block.Time
+	}
+	put := func(k []byte, c *portfolioCredit) (e error) {
+		var body []byte
+		if body, e = js.Marshal(c); E.Chk(e) {
+			return
+		}
+		return credits.Put(k, body)
+	}
+	for _, in := range rec.MsgTx.TxIn {
+		k := frozenKey(&in.PreviousOutPoint)
+		v := credits.Get(k)
+		if v == nil {
+			continue
+		}
+		var c portfolioCredit
+		if e = js.Unmarshal(v, &c); E.Chk(e) {
+			return
+		}
+		c.SpentBy, c.SpentHeight, c.SpentTime = rec.Hash.String(), height, t.Unix()
+		if e = put(k, &c); E.Chk(e) {
+			return
+		}
+	}
+	for i, out := range rec.MsgTx.TxOut {
+		entry := scripts.Get(out.PkScript)
+		if entry == nil {
+			continue
+		}
+		k := frozenKey(&wire.OutPoint{Hash: rec.Hash, Index: uint32(i)})
+		c := portfolioCredit{Entry: string(entry), Amount: out.Value, Height: height, Time: t.Unix()}
+		if v := credits.Get(k); v != nil {
+			// The unmined notification of a transaction can come after the mined one, which leaves the output as it is.
+			if block == nil {
+				continue
+			}
+			var old portfolioCredit
+			if e = js.Unmarshal(v, &old); E.Chk(e) {
+				return
+			}
+			c.SpentBy, c.SpentHeight, c.SpentTime = old.SpentBy, old.SpentHeight, old.SpentTime
+		}
+		if e = put(k, &c); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// forEachPortfolioCredit calls fn with each output recorded as paying to the portfolio. Iteration stops at the first
+// error returned by fn, which is returned.
+func forEachPortfolioCredit(ns walletdb.ReadBucket, fn func(op wire.OutPoint, c *portfolioCredit) error) error {
+	return ns.NestedReadBucket(portfolioCreditsBucket).ForEach(
+		func(k, v []byte) (e error) {
+			var op wire.OutPoint
+			if op, e = frozenOutPoint(k); E.Chk(e) {
+				return
+			}
+			var c portfolioCredit
+			if e = js.Unmarshal(v, &c); E.Chk(e) {
+				return
+			}
+			return fn(op, &c)
+		},
+	)
+}
+
+// Portfolio returns the entries of the portfolio in the order of their names, with the funds each holds as of the
+// block the wallet is synced to counting outputs with at least minconf confirmations as confirmed.
+func (w *Wallet) Portfolio(minconf int32) (entries []PortfolioEntry, e error) {
+	return w.portfolio(w.Manager.SyncedTo().Height, minconf)
+}
+
+// portfolio returns the entries of the portfolio as of the block at syncHeight.
+func (w *Wallet) portfolio(syncHeight, minconf int32) (entries []PortfolioEntry, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(portfolioNamespaceKey)
+			if ns == nil {
+				return
+			}
+			index := make(map[string]int)
+			if e = ns.NestedReadBucket(portfolioEntriesBucket).ForEach(
+				func(k, v []byte) (e error) {
+					var rec portfolioRecord
+					if e = js.Unmarshal(v, &rec); E.Chk(e) {
+						return
+					}
+					index[string(k)] = len(entries)
+					entries = append(
+						entries, PortfolioEntry{
+							Name:        string(k),
+							Descriptors: rec.Descriptors,
+							Range:       rec.Range,
+							Height:      rec.Height,
+							Added:       time.Unix(rec.Added, 0),
+						},
+					)
+					return
+				},
+			); E.Chk(e) {
+				return
+			}
+			return forEachPortfolioCredit(
+				ns, func(_ wire.OutPoint, c *portfolioCredit) error {
+					i, ok := index[c.Entry]
+					if !ok || c.SpentBy != "" {
+						return nil
+					}
+					entries[i].Unspent++
+					if confirms(c.Height, syncHeight) >= minconf {
+						entries[i].Balance += amt.Amount(c.Amount)
+					} else {
+						entries[i].Unconfirmed += amt.Amount(c.Amount)
+					}
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// PortfolioTransactions returns the changes transactions made to the funds of the portfolio entry with the name, or of
+// every entry if name is empty, newest first with the unmined transactions before the mined ones.
+func (w *Wallet) PortfolioTransactions(name string) (txs []PortfolioTx, e error) {
+	type key struct {
+		entry string
+		txid  chainhash.Hash
+	}
+	byTx := make(map[key]*PortfolioTx)
+	add := func(entry string, txid chainhash.Hash, amount int64, height int32, t int64) {
+		k := key{entry, txid}
+		ptx, ok := byTx[k]
+		if !ok {
+			ptx = &PortfolioTx{Entry: entry, TxID: txid, Height: height, Time: time.Unix(t, 0)}
+			byTx[k] = ptx
+		}
+		ptx.Amount += amt.Amount(amount)
+	}
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(portfolioNamespaceKey)
+			if ns == nil {
+				if name != "" {
+					return InvalidParameterError{fmt.Errorf("the portfolio has no entry named %s", name)}
+				}
+				return
+			}
+			if name != "" && ns.NestedReadBucket(portfolioEntriesBucket).Get([]byte(name)) == nil {
+				return InvalidParameterError{fmt.Errorf("the portfolio has no entry named %s", name)}
+			}
+			return forEachPortfolioCredit(
+				ns, func(op wire.OutPoint, c *portfolioCredit) (e error) {
+					if name != "" && c.Entry != name {
+						return
+					}
+					add(c.Entry, op.Hash, c.Amount, c.Height, c.Time)
+					if c.SpentBy == "" {
+						return
+					}
+					var spender chainhash.Hash
+					if e = chainhash.Decode(&spender, c.SpentBy); E.Chk(e) {
+						return
+					}
+					add(c.Entry, spender, -c.Amount, c.SpentHeight, c.SpentTime)
+					return
+				},
+			)
+		},
+	); e != nil {
+		return
+	}
+	txs = make([]PortfolioTx, 0, len(byTx))
+	for _, ptx := range byTx {
+		txs = append(txs, *ptx)
+	}
+	sort.Slice(
+		txs, func(i, j int) bool {
+			a, b := &txs[i], &txs[j]
+			switch {
+			case a.Height != b.Height && (a.Height == -1 || b.Height == -1):
+				return a.Height == -1
+			case a.Height != b.Height:
+				return a.Height > b.Height
+			case !a.Time.Equal(b.Time):
+				return a.Time.After(b.Time)
+			case a.TxID != b.TxID:
+				return a.TxID.String() < b.TxID.String()
+			}
+			return a.Entry < b.Entry
+		},
+	)
+	return
+}
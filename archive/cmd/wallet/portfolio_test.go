@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestPortfolio ensures cold wallets are added to the portfolio only by their public keys, that payments to their
+// scripts and spends of them are added up for each entry, and that removing an entry removes its outputs.
+func TestPortfolio(t *testing.T) {
+	params := &chaincfg.RegressionTestParams
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	w.chainParams = params
+	db := w.db
+	master, e := hdkeychain.NewMaster(bytes.Repeat([]byte{1}, hdkeychain.RecommendedSeedLen), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, e = portfolioDescriptors([]string{master.String()}, params); e == nil {
+		t.Fatal("an extended private key was accepted for the portfolio")
+	}
+	xpub, e := master.Neuter()
+	if e != nil {
+		t.Fatal(e)
+	}
+	descs, e := portfolioDescriptors([]string{" " + xpub.String() + " "}, params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(descs) != 2 {
+		t.Fatalf("an extended public key stands for %d descriptors, want 2", len(descs))
+	}
+	scripts, e := portfolioScripts(descs, 5, params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(scripts) != 10 {
+		t.Fatalf("derived %d scripts, want 10", len(scripts))
+	}
+	add := func(name string, descs []string) error {
+		scripts, e := portfolioScripts(descs, 5, params)
+		if e != nil {
+			t.Fatal(e)
+		}
+		rec := &portfolioRecord{Descriptors: descs, Range: 5, Added: time.Now().Unix()}
+		return walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) error {
+				return putPortfolioEntry(tx, name, rec, scripts)
+			},
+		)
+	}
+	if e = add("cold", descs); e != nil {
+		t.Fatal(e)
+	}
+	if e = add("cold", descs[1:]); e == nil {
+		t.Fatal("an entry was added with the name of another")
+	}
+	if e = add("other", descs[1:]); e == nil {
+		t.Fatal("an entry was added with the scripts of another")
+	}
+	// A payment to a receiving and a change script, seen unmined and then mined, and a spend of one of its outputs.
+	pay := wire.NewMsgTx(wire.TxVersion)
+	pay.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{9}}, nil, nil))
+	pay.AddTxOut(wire.NewTxOut(1000, scripts[0]))
+	pay.AddTxOut(wire.NewTxOut(300, []byte{0x51}))
+	pay.AddTxOut(wire.NewTxOut(500, scripts[6]))
+	payRec, e := wtxmgr.NewTxRecordFromMsgTx(pay, time.Unix(100, 0))
+	if e != nil {
+		t.Fatal(e)
+	}
+	spend := wire.NewMsgTx(wire.TxVersion)
+	spend.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: payRec.Hash, Index: 0}, nil, nil))
+	spend.AddTxOut(wire.NewTxOut(900, []byte{0x51}))
+	spendRec, e := wtxmgr.NewTxRecordFromMsgTx(spend, time.Unix(300, 0))
+	if e != nil {
+		t.Fatal(e)
+	}
+	notify := func(rec *wtxmgr.TxRecord, block *wtxmgr.BlockMeta) {
+		if e := walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) error {
+				return addPortfolioTx(tx, rec, block)
+			},
+		); e != nil {
+			t.Fatal(e)
+		}
+	}
+	notify(payRec, nil)
+	entries, e := w.portfolio(9, 1)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(entries) != 1 || entries[0].Balance != 0 || entries[0].Unconfirmed != 1500 || entries[0].Unspent != 2 {
+		t.Fatalf("portfolio %+v, want 1500 unconfirmed in 2 outputs", entries)
+	}
+	notify(payRec, &wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: 10}, Time: time.Unix(200, 0)})
+	notify(payRec, nil)
+	notify(spendRec, &wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: 12}, Time: time.Unix(400, 0)})
+	if entries, e = w.portfolio(12, 1); e != nil {
+		t.Fatal(e)
+	}
+	if len(entries) != 1 || entries[0].Balance != 500 || entries[0].Unconfirmed != 0 || entries[0].Unspent != 1 {
+		t.Fatalf("portfolio %+v, want 500 in 1 output", entries)
+	}
+	txs, e := w.PortfolioTransactions("")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(txs) != 2 || txs[0].TxID != spendRec.Hash || txs[0].Amount != -1000 || txs[0].Height != 12 ||
+		txs[1].TxID != payRec.Hash || txs[1].Amount != 1500 || txs[1].Height != 10 {
+		t.Fatalf("portfolio transactions %+v, want the spend of 1000 at 12 and the payment of 1500 at 10", txs)
+	}
+	if _, e = w.PortfolioTransactions("other"); e == nil {
+		t.Fatal("the transactions of an entry that does not exist were returned")
+	}
+	filterScripts, outPoints, e := w.portfolioFilter()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(filterScripts) != 10 || len(outPoints) != 1 || outPoints[0] != (wire.OutPoint{Hash: payRec.Hash, Index: 2}) {
+		t.Fatalf("filter of %d scripts and outputs %v, want 10 scripts and the unspent payment", len(filterScripts), outPoints)
+	}
+	if e = w.RemovePortfolioEntry("cold"); e != nil {
+		t.Fatal(e)
+	}
+	if e = w.RemovePortfolioEntry("cold"); e == nil {
+		t.Fatal("an entry that does not exist was removed")
+	}
+	if filterScripts, outPoints, e = w.portfolioFilter(); e != nil {
+		t.Fatal(e)
+	}
+	if len(filterScripts) != 0 || len(outPoints) != 0 {
+		t.Fatalf("filter of %d scripts and %d outputs after the entry was removed", len(filterScripts), len(outPoints))
+	}
+	// The scripts of a removed entry can be added again.
+	if e = add("other", descs[1:]); e != nil {
+		t.Fatal(e)
+	}
+}
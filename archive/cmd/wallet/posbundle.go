@@ -0,0 +1,413 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// A payment bundle is a batch of fresh receiving addresses of an account with the labels and amounts of the payments
+// they are for, exported as a signed JSON document to a point of sale device that has no connection to the wallet. The
+// device shows the addresses as payment QR codes, and later the wallet reconciles the payments made to them. The
+// addresses of a bundle are also payment requests in the address metadata, so they are listed by listinvoices too.
+
+// maxPaymentBundleRequests is the most payment requests a bundle can be exported with.
+const maxPaymentBundleRequests = 1000
+
+const (
+	// BundleOpen is the settlement state of a payment bundle none of whose requests have been paid.
+	BundleOpen = "open"
+	// BundlePartial is the settlement state of a payment bundle some of whose requests have been paid.
+	BundlePartial = "partial"
+	// BundleSettled is the settlement state of a payment bundle all of whose requests that were not cancelled have been
+	// paid in full.
+	BundleSettled = "settled"
+	// BundleExpired is the settlement state of a payment bundle whose requests expired before any was paid.
+	BundleExpired = "expired"
+)
+
+// PaymentBundleRequest is a payment request to be made with a fresh address of a payment bundle. An amount of zero
+// accepts any amount.
+type PaymentBundleRequest struct {
+	Label  string
+	Amount amt.Amount
+}
+
+// ExportedPaymentBundle is a payment bundle as it is exported to a point of sale device. Document is the JSON document
+// of the bundle, and Signature the signature of it made with the key of SignAddress the way signmessage signs messages,
+// so the device can check that the addresses came from the wallet with verifymessage.
+type ExportedPaymentBundle struct {
+	ID          string
+	Document    string
+	SignAddress string
+	Signature   string
+}
+
+// PaymentBundle is the settlement of a payment bundle. The requests of the bundle are the invoices of its addresses,
+// in the order they were exported in, with their states found from the payments to the addresses.
+type PaymentBundle struct {
+	ID          string
+	Account     uint32
+	SignAddress string
+	Created     time.Time
+	Expires     time.Time
+	Requests    []Invoice
+	// State is the settlement state of the bundle, and Paid the number of its requests that have been paid.
+	State string
+	Paid  int
+	// Requested is the amount of all the requests added up, and Received and Pending the amounts paid to their
+	// addresses with and without enough confirmations.
+	Requested amt.Amount
+	Received  amt.Amount
+	Pending   amt.Amount
+}
+
+// paymentBundleRecord is the encoding of a payment bundle in the database, which is keyed by its ID.
+type paymentBundleRecord struct {
+	Account     uint32               `json:"account"`
+	SignAddress string               `json:"signaddress"`
+	Created     int64                `json:"created"`
+	Expires     int64                `json:"expires,omitempty"`
+	Requests    []paymentBundleEntry `json:"requests"`
+}
+
+// paymentBundleEntry is the encoding of a payment request of a bundle.
+type paymentBundleEntry struct {
+	Address string     `json:"address"`
+	Label   string     `json:"label,omitempty"`
+	Amount  amt.Amount `json:"amount"`
+}
+
+// paymentBundleDocument is the JSON document of a payment bundle exported to a point of sale device.
+type paymentBundleDocument struct {
+	ID          string                 `json:"id"`
+	Network     string                 `json:"network"`
+	Account     string                 `json:"account"`
+	SignAddress string                 `json:"signaddress"`
+	Created     int64                  `json:"created"`
+	Expires     int64                  `json:"expires,omitempty"`
+	Requests    []paymentBundleDocItem `json:"requests"`
+}
+
+// paymentBundleDocItem is a payment request in the document of a payment bundle. URI is the payment URI of the request
+// for the device to show as a QR code.
+type paymentBundleDocItem struct {
+	Address string  `json:"address"`
+	Label   string  `json:"label,omitempty"`
+	Amount  float64 `json:"amount,omitempty"`
+	URI     string  `json:"uri"`
+}
+
+// paymentURI returns the payment URI of a request to pay the amount to the address, which is left out when it is zero,
+// with the label.
+func paymentURI(address string, amount amt.Amount, label string) string {
+	q := url.Values{}
+	if amount > 0 {
+		q.Set("amount", strconv.FormatFloat(amount.ToDUO(), 'f', -1, 64))
+	}
+	if label != "" {
+		q.Set("label", label)
+	}
+	uri := "parallelcoin:" + address
+	if len(q) > 0 {
+		uri += "?" + q.Encode()
+	}
+	return uri
+}
+
+// paymentBundleSignKey returns the address and the key payment bundles of the account are signed with. Unless another
+// address of the wallet is given, this is the first receiving address of the account, so every bundle of the account
+// is signed with the same key and the device only needs to know its address to trust them. The wallet must be
+// unlocked.
+func (w *Wallet) paymentBundleSignKey(
+	account uint32, signAddress btcaddr.Address,
+) (addr btcaddr.Address, key *ec.PrivateKey, e error) {
+	if signAddress != nil {
+		if key, e = w.PrivKeyForAddress(signAddress); E.Chk(e) {
+			return
+		}
+		return signAddress, key, nil
+	}
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			var ma waddrmgr.ManagedAddress
+			if ma, e = manager.DeriveFromKeyPath(
+				tx.ReadBucket(waddrmgrNamespaceKey), waddrmgr.DerivationPath{Account: account},
+			); E.Chk(e) {
+				return
+			}
+			pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return errors.New("the first address of the account does not have a private key")
+			}
+			if key, e = pka.PrivKey(); E.Chk(e) {
+				return
+			}
+			addr = pka.Address()
+			return
+		},
+	)
+	return
+}
+
+// ExportPaymentBundle makes a fresh receiving address of the account for each request and returns them as a payment
+// bundle signed with the key of signAddress, or of the first address of the account if it is nil. The addresses are
+// recorded as payment requests with the label and amount of their request, expiring at expires unless it is the zero
+// time, and the bundle is stored so its settlement can be followed with PaymentBundles.
+func (w *Wallet) ExportPaymentBundle(
+	account uint32, requests []PaymentBundleRequest, expires time.Time, signAddress btcaddr.Address,
+) (bundle *ExportedPaymentBundle, e error) {
+	if len(requests) == 0 || len(requests) > maxPaymentBundleRequests {
+		return nil, InvalidParameterError{
+			fmt.Errorf("a payment bundle must have between 1 and %d requests", maxPaymentBundleRequests),
+		}
+	}
+	for i := range requests {
+		if requests[i].Amount < 0 {
+			return nil, InvalidParameterError{fmt.Errorf("the amount of request %d is negative", i)}
+		}
+	}
+	if !expires.IsZero() && !expires.After(time.Now()) {
+		return nil, InvalidParameterError{errors.New("the expiry time of the payment bundle has passed")}
+	}
+	var accountName string
+	if accountName, e = w.AccountName(waddrmgr.KeyScopeBIP0044, account); E.Chk(e) {
+		return
+	}
+	// the key is fetched first so a locked wallet doesn't hand out addresses for a bundle it can't sign
+	var signAddr btcaddr.Address
+	var key *ec.PrivateKey
+	if signAddr, key, e = w.paymentBundleSignKey(account, signAddress); E.Chk(e) {
+		return
+	}
+	id := make([]byte, 8)
+	if _, e = rand.Read(id); E.Chk(e) {
+		return
+	}
+	now := time.Now()
+	rec := &paymentBundleRecord{
+		Account:     account,
+		SignAddress: signAddr.EncodeAddress(),
+		Created:     now.Unix(),
+		Expires:     unixTime(expires),
+		Requests:    make([]paymentBundleEntry, len(requests)),
+	}
+	doc := &paymentBundleDocument{
+		ID:          hex.EncodeToString(id),
+		Network:     w.chainParams.Name,
+		Account:     accountName,
+		SignAddress: rec.SignAddress,
+		Created:     rec.Created,
+		Expires:     rec.Expires,
+		Requests:    make([]paymentBundleDocItem, len(requests)),
+	}
+	message := "payment bundle " + doc.ID
+	for i := range requests {
+		var addr btcaddr.Address
+		if addr, e = w.NewAddress(account, waddrmgr.KeyScopeBIP0044, false); E.Chk(e) {
+			return
+		}
+		address, r := addr.EncodeAddress(), &requests[i]
+		u := AddressMetaUpdate{Amount: &r.Amount, Message: &message, Label: &r.Label}
+		if !expires.IsZero() {
+			u.Expires = &expires
+		}
+		if _, e = w.putAddressMeta(address, true, u); E.Chk(e) {
+			return
+		}
+		rec.Requests[i] = paymentBundleEntry{Address: address, Label: r.Label, Amount: r.Amount}
+		doc.Requests[i] = paymentBundleDocItem{
+			Address: address,
+			Label:   r.Label,
+			Amount:  r.Amount.ToDUO(),
+			URI:     paymentURI(address, r.Amount, r.Label),
+		}
+	}
+	var b, v []byte
+	if b, e = js.Marshal(doc); E.Chk(e) {
+		return
+	}
+	var signature string
+	if signature, e = signMessage(key, string(b)); E.Chk(e) {
+		return
+	}
+	if v, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(posBundleNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(posBundleNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			return ns.Put([]byte(doc.ID), v)
+		},
+	); E.Chk(e) {
+		return
+	}
+	I.F("exported payment bundle %s of %d addresses of account %q", doc.ID, len(requests), accountName)
+	return &ExportedPaymentBundle{
+		ID:          doc.ID,
+		Document:    string(b),
+		SignAddress: rec.SignAddress,
+		Signature:   signature,
+	}, nil
+}
+
+// PaymentBundles returns the settlement of the payment bundle with the ID, or of every bundle if id is empty, oldest
+// first. Payments count towards the amounts requested when they have minConf confirmations.
+func (w *Wallet) PaymentBundles(id string, minConf int32) (bundles []PaymentBundle, e error) {
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(posBundleNamespaceKey)
+			add := func(k, v []byte) (e error) {
+				var rec paymentBundleRecord
+				if e = js.Unmarshal(v, &rec); E.Chk(e) {
+					return
+				}
+				bundles = append(bundles, rec.bundle(string(k)))
+				return
+			}
+			if id == "" {
+				if ns == nil {
+					return nil
+				}
+				return ns.ForEach(add)
+			}
+			var v []byte
+			if ns != nil {
+				v = ns.Get([]byte(id))
+			}
+			if v == nil {
+				return InvalidParameterError{fmt.Errorf("there is no payment bundle %q", id)}
+			}
+			return add([]byte(id), v)
+		},
+	); E.Chk(e) {
+		return
+	}
+	// the metadata of the addresses is used where it is still there, so requests cancelled or marked paid by hand
+	// keep that state
+	var metas []AddressMeta
+	if metas, e = w.ListAddressMeta(AddressMetaReceive); E.Chk(e) {
+		return
+	}
+	metaByAddress := make(map[string]*AddressMeta, len(metas))
+	for i := range metas {
+		metaByAddress[metas[i].Address] = &metas[i]
+	}
+	byAddress := make(map[string]*Invoice)
+	for i := range bundles {
+		for j := range bundles[i].Requests {
+			inv := &bundles[i].Requests[j]
+			if m, ok := metaByAddress[inv.Address]; ok {
+				inv.AddressMeta = *m
+			}
+			byAddress[inv.Address] = inv
+		}
+	}
+	if e = w.addInvoicePayments(byAddress, minConf); E.Chk(e) {
+		return
+	}
+	now := time.Now()
+	for i := range bundles {
+		bundles[i].settle(now)
+	}
+	sort.SliceStable(
+		bundles, func(i, j int) bool {
+			return bundles[i].Created.Before(bundles[j].Created)
+		},
+	)
+	return
+}
+
+// bundle returns the payment bundle with the ID that the record is stored under, with open invoices for its requests.
+func (r *paymentBundleRecord) bundle(id string) PaymentBundle {
+	b := PaymentBundle{
+		ID:          id,
+		Account:     r.Account,
+		SignAddress: r.SignAddress,
+		Created:     time.Unix(r.Created, 0),
+		Expires:     unixSecondsTime(r.Expires),
+		Requests:    make([]Invoice, len(r.Requests)),
+	}
+	for i := range r.Requests {
+		b.Requests[i].AddressMeta = AddressMeta{
+			Address:  r.Requests[i].Address,
+			Category: AddressMetaReceive,
+			Amount:   r.Requests[i].Amount,
+			Message:  "payment bundle " + id,
+			Label:    r.Requests[i].Label,
+			State:    InvoiceOpen,
+			Created:  b.Created,
+			Modified: b.Created,
+			Expires:  b.Expires,
+		}
+	}
+	return b
+}
+
+// unixSecondsTime returns the time of the seconds since 1 Jan 1970 GMT, or the zero time for zero.
+func unixSecondsTime(secs int64) time.Time {
+	if secs == 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
+// settle finds the states of the requests of the bundle at the time now from the payments added to them, and from
+// those the settlement state of the bundle and its totals. A bundle is settled once every request that was not
+// cancelled has been paid, partially settled while only some have been paid, and expired when its requests expired
+// before any was paid.
+func (b *PaymentBundle) settle(now time.Time) {
+	var outstanding, partial, expired int
+	b.Paid, b.Requested, b.Received, b.Pending = 0, 0, 0, 0
+	for i := range b.Requests {
+		inv := &b.Requests[i]
+		inv.State = invoiceState(&inv.AddressMeta, inv.Received, now)
+		b.Requested += inv.Amount
+		b.Received += inv.Received
+		b.Pending += inv.Pending
+		switch inv.State {
+		case InvoicePaid:
+			b.Paid++
+		case InvoiceCancelled:
+		case InvoicePartial:
+			partial++
+			outstanding++
+		case InvoiceExpired:
+			expired++
+			outstanding++
+		default:
+			outstanding++
+		}
+	}
+	switch {
+	case outstanding == 0:
+		b.State = BundleSettled
+	case b.Paid > 0 || partial > 0:
+		b.State = BundlePartial
+	case expired == outstanding:
+		b.State = BundleExpired
+	default:
+		b.State = BundleOpen
+	}
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+)
+
+// TestPaymentBundleSettle ensures the settlement state of a payment bundle is found from the states of its requests,
+// and that its totals add up the requests.
+func TestPaymentBundleSettle(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	rec := &paymentBundleRecord{
+		Created: past.Add(-time.Hour).Unix(),
+		Requests: []paymentBundleEntry{
+			{Address: "a", Label: "coffee", Amount: 100},
+			{Address: "b", Label: "cake", Amount: 200},
+		},
+	}
+	tests := []struct {
+		name     string
+		expires  time.Time
+		received []amt.Amount
+		pending  []amt.Amount
+		state    []string
+		want     string
+		paid     int
+	}{
+		{"unpaid", time.Time{}, []amt.Amount{0, 0}, []amt.Amount{0, 0}, nil, BundleOpen, 0},
+		{"unconfirmed", time.Time{}, []amt.Amount{0, 0}, []amt.Amount{100, 0}, nil, BundleOpen, 0},
+		{"expired", past, []amt.Amount{0, 0}, []amt.Amount{0, 0}, nil, BundleExpired, 0},
+		{"one partially paid", time.Time{}, []amt.Amount{50, 0}, []amt.Amount{0, 0}, nil, BundlePartial, 0},
+		{"one paid", past, []amt.Amount{100, 0}, []amt.Amount{0, 0}, nil, BundlePartial, 1},
+		{"all paid", time.Time{}, []amt.Amount{100, 250}, []amt.Amount{0, 0}, nil, BundleSettled, 2},
+		{
+			"one paid and one cancelled", time.Time{}, []amt.Amount{100, 0}, []amt.Amount{0, 0},
+			[]string{InvoiceOpen, InvoiceCancelled}, BundleSettled, 1,
+		},
+	}
+	for _, test := range tests {
+		rec.Expires = unixTime(test.expires)
+		b := rec.bundle("0011223344556677")
+		for i := range b.Requests {
+			b.Requests[i].Received, b.Requests[i].Pending = test.received[i], test.pending[i]
+			if test.state != nil {
+				b.Requests[i].State = test.state[i]
+			}
+		}
+		b.settle(now)
+		if b.State != test.want || b.Paid != test.paid {
+			t.Errorf("%s: got state %q with %d paid, want %q with %d", test.name, b.State, b.Paid, test.want, test.paid)
+		}
+		if b.Requested != 300 || b.Received != test.received[0]+test.received[1] ||
+			b.Pending != test.pending[0]+test.pending[1] {
+			t.Errorf(
+				"%s: got %v requested, %v received and %v pending", test.name, b.Requested, b.Received, b.Pending,
+			)
+		}
+	}
+	b := rec.bundle("0011223344556677")
+	if b.Requests[1].Address != "b" || b.Requests[1].Label != "cake" ||
+		b.Requests[1].Message != "payment bundle 0011223344556677" || b.Requests[1].Category != AddressMetaReceive {
+		t.Errorf("the request of a bundle record became the invoice %+v", b.Requests[1].AddressMeta)
+	}
+}
+
+// TestPaymentURI ensures the payment URIs of payment bundle requests leave out an amount of zero and escape labels.
+func TestPaymentURI(t *testing.T) {
+	tests := []struct {
+		amount amt.Amount
+		label  string
+		want   string
+	}{
+		{0, "", "parallelcoin:addr"},
+		{150000000, "", "parallelcoin:addr?amount=1.5"},
+		{1, "table 4 & 5", "parallelcoin:addr?amount=0.00000001&label=table+4+%26+5"},
+		{0, "tip", "parallelcoin:addr?label=tip"},
+	}
+	for _, test := range tests {
+		if got := paymentURI("addr", test.amount, test.label); got != test.want {
+			t.Errorf("got payment URI %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package wallet
+
+import (
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// SendPreview is the transaction a send of outputs from an account would make, worked out without signing or
+// broadcasting it.
+type SendPreview struct {
+	// Tx is the unsigned transaction. Its change output, if it has one, pays to a placeholder script, as no change
+	// address is taken from the account for a preview.
+	Tx *txauthor.AuthoredTx
+	// Size is the worst case size of the transaction once it is signed, which the fee is paid on.
+	Size   int
+	Fee    amt.Amount
+	Change amt.Amount
+}
+
+// FeeRate returns the fee the transaction pays per kilobyte of its size.
+func (p *SendPreview) FeeRate() amt.Amount {
+	return p.Fee * 1000 / amt.Amount(p.Size)
+}
+
+// PreviewSend runs the coin selection and transaction construction of a send of outputs from the account with inputs
+// with at least minconf confirmations, and returns the transaction it would make without signing or broadcasting it,
+// so the wallet does not need to be unlocked. The inputs are not locked, so a send made after the preview can select
+// different ones if other transactions are created in between.
+func (w *Wallet) PreviewSend(
+	outputs []*wire.TxOut, account uint32,
+	minconf int32, satPerKb amt.Amount,
+) (p *SendPreview, e error) {
+	for _, output := range outputs {
+		if e = txrules.CheckOutput(output, satPerKb); E.Chk(e) {
+			return
+		}
+	}
+	var tx *txauthor.AuthoredTx
+	if tx, e = w.authorTx(outputs, account, minconf, satPerKb, 0, w.previewChangeScript); E.Chk(e) {
+		return
+	}
+	if e = w.checkMaxTxFee(tx); E.Chk(e) {
+		return
+	}
+	p = &SendPreview{
+		Tx:   tx,
+		Size: txsizes.EstimateVirtualSize(len(tx.Tx.TxIn), 0, 0, outputs, tx.ChangeIndex >= 0),
+		Fee:  tx.TotalInput,
+	}
+	for _, txOut := range tx.Tx.TxOut {
+		p.Fee -= amt.Amount(txOut.Value)
+	}
+	if tx.ChangeIndex >= 0 {
+		p.Change = amt.Amount(tx.Tx.TxOut[tx.ChangeIndex].Value)
+	}
+	return
+}
+
+// previewChangeScript returns a pay to pubkey hash script to an all zero hash, the same size as the script of a change
+// address, to stand in for the change output of a preview.
+func (w *Wallet) previewChangeScript() (script []byte, e error) {
+	var addr *btcaddr.PubKeyHash
+	if addr, e = btcaddr.NewPubKeyHash(make([]byte, 20), w.chainParams); E.Chk(e) {
+		return
+	}
+	return txscript.PayToAddrScript(addr)
+}
@@ -0,0 +1,390 @@
+package wallet
+
+import (
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/psbt"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// The states of a transaction in the signing queue.
+const (
+	// PSBTPending is the state of a transaction waiting for its signed PSBT.
+	PSBTPending = "pending"
+	// PSBTBroadcast is the state of a transaction whose signed PSBT was returned and which was sent to the network.
+	PSBTBroadcast = "broadcast"
+)
+
+// ErrPSBTNotQueued is returned for a PSBT whose transaction is not in the signing queue.
+var ErrPSBTNotQueued = errors.New("the transaction of the PSBT is not in the signing queue")
+
+// QueuedPSBT is a transaction a watching-only wallet created, waiting in the signing queue for an external signer to
+// sign it. It is identified by the hash of the unsigned transaction, which the signatures do not change.
+type QueuedPSBT struct {
+	ID      chainhash.Hash
+	Account uint32
+	Created time.Time
+	State   string
+	// Packet is the PSBT to sign, with the signatures returned so far if more than one signer is needed, or the
+	// finalized PSBT once it is broadcast.
+	Packet *psbt.Packet
+	// TxID is the hash of the signed transaction once it is broadcast.
+	TxID *chainhash.Hash
+}
+
+// psbtQueueRecord is the encoding of a queued transaction in the database, which is keyed by its ID. ChangeIndex is
+// the index of the change output, or -1, so the change address can be released if the transaction is cancelled.
+type psbtQueueRecord struct {
+	Account     uint32 `json:"account"`
+	Created     int64  `json:"created"`
+	State       string `json:"state"`
+	PSBT        string `json:"psbt"`
+	ChangeIndex int    `json:"changeindex"`
+	TxID        string `json:"txid,omitempty"`
+}
+
+// queuesPSBTs returns whether transactions the wallet creates are queued for an external signer instead of being
+// signed, which is when the wallet is watching-only and no remote signer is connected.
+func (w *Wallet) queuesPSBTs() bool {
+	return w.Manager.WatchOnly() && w.signer == nil
+}
+
+// derivationPath returns the BIP0032 path from the master key of a key derived in the scope at the path.
+func derivationPath(scope waddrmgr.KeyScope, path waddrmgr.DerivationPath) []uint32 {
+	return []uint32{
+		scope.Purpose + hdkeychain.HardenedKeyStart,
+		scope.Coin + hdkeychain.HardenedKeyStart,
+		path.Account + hdkeychain.HardenedKeyStart,
+		path.Branch,
+		path.Index,
+	}
+}
+
+// newPSBT returns a PSBT for the unsigned transaction, carrying for each input the transaction it spends and the public
+// key and derivation path of the address it spends from, and the derivation path of the change output, which is all a
+// signer holding the master private key of the wallet needs to sign it and check the change goes back to the wallet.
+func (w *Wallet) newPSBT(addrmgrNs, txmgrNs walletdb.ReadBucket, tx *txauthor.AuthoredTx) (p *psbt.Packet, e error) {
+	if p, e = psbt.New(tx.Tx); E.Chk(e) {
+		return
+	}
+	var fingerprint [4]byte
+	if fingerprint, e = w.Manager.MasterFingerprint(addrmgrNs); E.Chk(e) {
+		return
+	}
+	derivation := func(pkScript []byte) (d *psbt.Bip32Derivation) {
+		_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+		if e != nil || len(addrs) == 0 {
+			return
+		}
+		ma, e := w.Manager.Address(addrmgrNs, addrs[0])
+		if e != nil {
+			return
+		}
+		pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+		if !ok {
+			return
+		}
+		scope, path, derived := pka.DerivationInfo()
+		if !derived {
+			return
+		}
+		pubKey := pka.PubKey().SerializeUncompressed()
+		if pka.Compressed() {
+			pubKey = pka.PubKey().SerializeCompressed()
+		}
+		return &psbt.Bip32Derivation{PubKey: pubKey, Fingerprint: fingerprint, Path: derivationPath(scope, path)}
+	}
+	for i, txIn := range tx.Tx.TxIn {
+		in := &p.Inputs[i]
+		details, e := w.TxStore.TxDetails(txmgrNs, &txIn.PreviousOutPoint.Hash)
+		if E.Chk(e) {
+			return nil, e
+		}
+		if details == nil {
+			return nil, fmt.Errorf("the transaction spent by input %d is not in the wallet", i)
+		}
+		in.NonWitnessUtxo = details.MsgTx.Copy()
+		in.SighashType = txscript.SigHashAll
+		if d := derivation(tx.PrevScripts[i]); d != nil {
+			in.Bip32Derivation = []*psbt.Bip32Derivation{d}
+		}
+	}
+	if tx.ChangeIndex >= 0 {
+		if d := derivation(tx.Tx.TxOut[tx.ChangeIndex].PkScript); d != nil {
+			p.Outputs[tx.ChangeIndex].Bip32Derivation = []*psbt.Bip32Derivation{d}
+		}
+	}
+	return
+}
+
+// queuePSBT adds the unsigned transaction created for the account to the signing queue, and locks its inputs so they
+// are not spent by other transactions while it waits to be signed. It returns the ID of the queued transaction.
+func (w *Wallet) queuePSBT(tx *txauthor.AuthoredTx, account uint32) (id chainhash.Hash, e error) {
+	id = tx.Tx.TxHash()
+	if e = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			var p *psbt.Packet
+			if p, e = w.newPSBT(
+				dbtx.ReadBucket(waddrmgrNamespaceKey), dbtx.ReadBucket(wtxmgrNamespaceKey), tx,
+			); E.Chk(e) {
+				return
+			}
+			rec := psbtQueueRecord{
+				Account:     account,
+				Created:     time.Now().UnixNano(),
+				State:       PSBTPending,
+				ChangeIndex: tx.ChangeIndex,
+			}
+			if rec.PSBT, e = p.B64Encode(); E.Chk(e) {
+				return
+			}
+			return putQueuedPSBT(dbtx, id, &rec)
+		},
+	); E.Chk(e) {
+		return
+	}
+	for _, txIn := range tx.Tx.TxIn {
+		w.LockOutpoint(txIn.PreviousOutPoint)
+	}
+	I.Ln("queued transaction", id, "for an external signer")
+	return
+}
+
+// putQueuedPSBT stores the record of a queued transaction.
+func putQueuedPSBT(dbtx walletdb.ReadWriteTx, id chainhash.Hash, rec *psbtQueueRecord) (e error) {
+	ns := dbtx.ReadWriteBucket(psbtQueueNamespaceKey)
+	if ns == nil {
+		if ns, e = dbtx.CreateTopLevelBucket(psbtQueueNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	var body []byte
+	if body, e = js.Marshal(rec); E.Chk(e) {
+		return
+	}
+	return ns.Put(id[:], body)
+}
+
+// fetchQueuedPSBT reads the record of a queued transaction, which is nil if it is not in the queue.
+func fetchQueuedPSBT(dbtx walletdb.ReadTx, id *chainhash.Hash) (rec *psbtQueueRecord, e error) {
+	ns := dbtx.ReadBucket(psbtQueueNamespaceKey)
+	if ns == nil {
+		return
+	}
+	body := ns.Get(id[:])
+	if body == nil {
+		return
+	}
+	rec = new(psbtQueueRecord)
+	if e = js.Unmarshal(body, rec); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// queuedPSBT returns the queued transaction the record is stored for.
+func (rec *psbtQueueRecord) queuedPSBT(id chainhash.Hash) (q QueuedPSBT, e error) {
+	q = QueuedPSBT{ID: id, Account: rec.Account, Created: time.Unix(0, rec.Created), State: rec.State}
+	if q.Packet, e = psbt.ParseBase64(rec.PSBT); E.Chk(e) {
+		return
+	}
+	if rec.TxID != "" {
+		if q.TxID, e = chainhash.NewHashFromStr(rec.TxID); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// loadQueuedInputs returns the inputs of the transactions waiting in the signing queue, which are locked when the
+// wallet is opened so they are not spent by other transactions.
+func loadQueuedInputs(db walletdb.DB) (inputs map[wire.OutPoint]struct{}, e error) {
+	inputs = make(map[wire.OutPoint]struct{})
+	var queued []QueuedPSBT
+	if queued, e = queuedPSBTs(db); E.Chk(e) {
+		return
+	}
+	for i := range queued {
+		if queued[i].State != PSBTPending {
+			continue
+		}
+		for _, txIn := range queued[i].Packet.UnsignedTx.TxIn {
+			inputs[txIn.PreviousOutPoint] = struct{}{}
+		}
+	}
+	return
+}
+
+// queuedPSBTs reads the signing queue, oldest first.
+func queuedPSBTs(db walletdb.DB) (queued []QueuedPSBT, e error) {
+	if e = walletdb.View(
+		db, func(dbtx walletdb.ReadTx) (e error) {
+			ns := dbtx.ReadBucket(psbtQueueNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			return ns.ForEach(
+				func(k, v []byte) (e error) {
+					var id *chainhash.Hash
+					if id, e = chainhash.NewHash(k); E.Chk(e) {
+						return
+					}
+					var rec psbtQueueRecord
+					if e = js.Unmarshal(v, &rec); E.Chk(e) {
+						return
+					}
+					var q QueuedPSBT
+					if q, e = rec.queuedPSBT(*id); E.Chk(e) {
+						return
+					}
+					queued = append(queued, q)
+					return
+				},
+			)
+		},
+	); E.Chk(e) {
+		return
+	}
+	sort.SliceStable(
+		queued, func(i, j int) bool {
+			return queued[i].Created.Before(queued[j].Created)
+		},
+	)
+	return
+}
+
+// QueuedPSBTs returns the transactions in the signing queue, oldest first, including those already broadcast.
+func (w *Wallet) QueuedPSBTs() ([]QueuedPSBT, error) {
+	return queuedPSBTs(w.db)
+}
+
+// SubmitSignedPSBT adds the signatures of a PSBT returned by an external signer to the queued transaction it signs. If
+// the transaction then has all the signatures it needs, it is finalized, checked and broadcast, and the hash of the
+// signed transaction is returned. Otherwise the signatures are kept so the PSBT can be given to the next signer, and
+// the error finalizing it is returned.
+func (w *Wallet) SubmitSignedPSBT(signed *psbt.Packet) (txid *chainhash.Hash, e error) {
+	if signed.UnsignedTx == nil {
+		return nil, psbt.ErrNoUnsignedTx
+	}
+	id := signed.UnsignedTx.TxHash()
+	var rec *psbtQueueRecord
+	if e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			rec, e = fetchQueuedPSBT(dbtx, &id)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	if rec == nil {
+		return nil, ErrPSBTNotQueued
+	}
+	if rec.State != PSBTPending {
+		return nil, fmt.Errorf("queued transaction %v was already broadcast as %s", id, rec.TxID)
+	}
+	var q QueuedPSBT
+	if q, e = rec.queuedPSBT(id); E.Chk(e) {
+		return
+	}
+	p := q.Packet
+	// The signatures are checked against the queued PSBT before they are added to it, so one a signer got wrong or made
+	// up is refused instead of being stored with the transaction.
+	if e = psbt.CheckSigs(p, signed); E.Chk(e) {
+		return nil, InvalidParameterError{e}
+	}
+	if e = psbt.Combine(p, signed); E.Chk(e) {
+		return
+	}
+	// The input metadata comes from the queued PSBT, so a signer can't make the wallet check the signatures against
+	// outputs other than those the transaction spends.
+	if finalizeErr := psbt.FinalizeAll(p); finalizeErr != nil {
+		if rec.PSBT, e = p.B64Encode(); E.Chk(e) {
+			return
+		}
+		if e = walletdb.Update(
+			w.db, func(dbtx walletdb.ReadWriteTx) error {
+				return putQueuedPSBT(dbtx, id, rec)
+			},
+		); E.Chk(e) {
+			return
+		}
+		return nil, InvalidParameterError{fmt.Errorf("the PSBT is not fully signed: %v", finalizeErr)}
+	}
+	var tx *wire.MsgTx
+	if tx, e = psbt.Extract(p); E.Chk(e) {
+		return
+	}
+	prevScripts := make([][]byte, len(tx.TxIn))
+	inputValues := make([]amt.Amount, len(tx.TxIn))
+	for i := range tx.TxIn {
+		var prevOut *wire.TxOut
+		if prevOut, e = p.PrevOut(i); E.Chk(e) {
+			return
+		}
+		prevScripts[i], inputValues[i] = prevOut.PkScript, amt.Amount(prevOut.Value)
+	}
+	if e = validateMsgTx(tx, prevScripts, inputValues); E.Chk(e) {
+		return nil, InvalidParameterError{e}
+	}
+	if txid, e = w.publishTransaction(tx); E.Chk(e) {
+		return
+	}
+	rec.State, rec.TxID = PSBTBroadcast, txid.String()
+	if rec.PSBT, e = p.B64Encode(); E.Chk(e) {
+		return
+	}
+	if e = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) error {
+			return putQueuedPSBT(dbtx, id, rec)
+		},
+	); E.Chk(e) {
+		return
+	}
+	for _, txIn := range tx.TxIn {
+		w.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+	I.Ln("broadcast queued transaction", id, "as", txid)
+	return
+}
+
+// CancelQueuedPSBT removes a transaction waiting for its signed PSBT from the signing queue, unlocking its inputs and
+// releasing its change address.
+func (w *Wallet) CancelQueuedPSBT(id chainhash.Hash) (e error) {
+	var rec *psbtQueueRecord
+	if e = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			if rec, e = fetchQueuedPSBT(dbtx, &id); E.Chk(e) || rec == nil {
+				return
+			}
+			if rec.State != PSBTPending {
+				return fmt.Errorf("queued transaction %v was already broadcast as %s", id, rec.TxID)
+			}
+			return dbtx.ReadWriteBucket(psbtQueueNamespaceKey).Delete(id[:])
+		},
+	); E.Chk(e) {
+		return
+	}
+	if rec == nil {
+		return ErrPSBTNotQueued
+	}
+	var q QueuedPSBT
+	if q, e = rec.queuedPSBT(id); E.Chk(e) {
+		return
+	}
+	for _, txIn := range q.Packet.UnsignedTx.TxIn {
+		w.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+	w.releaseChangeAddress(&txauthor.AuthoredTx{Tx: q.Packet.UnsignedTx, ChangeIndex: rec.ChangeIndex})
+	return
+}
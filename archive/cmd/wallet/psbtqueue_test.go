@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/psbt"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestDerivationPath ensures the path of a key from the master key hardens the purpose, coin type and account.
+func TestDerivationPath(t *testing.T) {
+	path := derivationPath(
+		waddrmgr.KeyScopeBIP0044, waddrmgr.DerivationPath{Account: 2, Branch: waddrmgr.InternalBranch, Index: 7},
+	)
+	want := []uint32{
+		waddrmgr.KeyScopeBIP0044.Purpose | 1<<31, waddrmgr.KeyScopeBIP0044.Coin | 1<<31, 2 | 1<<31, 1, 7,
+	}
+	if len(path) != len(want) {
+		t.Fatalf("path is %v, want %v", path, want)
+	}
+	for i := range want {
+		if path[i] != want[i] {
+			t.Fatalf("path is %v, want %v", path, want)
+		}
+	}
+}
+
+// TestPSBTQueue ensures a queued transaction is read back with its inputs locked, refuses signatures that are not
+// valid, keeps the signatures of a PSBT that is not fully signed, and is removed with its inputs unlocked when it is
+// cancelled.
+func TestPSBTQueue(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	var keys []*ecc.PrivateKey
+	var pubKeys []*btcaddr.PubKey
+	for i := 0; i < 2; i++ {
+		key, e := ecc.NewPrivateKey(ecc.S256())
+		if e != nil {
+			t.Fatal(e)
+		}
+		pubKey, e := btcaddr.NewPubKey(key.PubKey().SerializeCompressed(), &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		keys, pubKeys = append(keys, key), append(pubKeys, pubKey)
+	}
+	multiSig, e := txscript.MultiSigScript(pubKeys, 2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	prev := wire.NewMsgTx(1)
+	prev.AddTxOut(wire.NewTxOut(5e8, multiSig))
+	prevHash := prev.TxHash()
+	op := wire.OutPoint{Hash: prevHash, Index: 0}
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(&op, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(4e8, []byte{txscript.OP_TRUE}))
+	p, e := psbt.New(tx)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p.Inputs[0].NonWitnessUtxo = prev
+	p.Inputs[0].SighashType = txscript.SigHashAll
+	rec := psbtQueueRecord{Account: 1, Created: time.Now().UnixNano(), State: PSBTPending, ChangeIndex: -1}
+	if rec.PSBT, e = p.B64Encode(); e != nil {
+		t.Fatal(e)
+	}
+	id := tx.TxHash()
+	if e = walletdb.Update(
+		db, func(dbtx walletdb.ReadWriteTx) error {
+			return putQueuedPSBT(dbtx, id, &rec)
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	inputs, e := loadQueuedInputs(db)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, ok := inputs[op]; len(inputs) != 1 || !ok {
+		t.Fatalf("queued inputs are %v, want only %v", inputs, op)
+	}
+	w.lockedOutpoints = inputs
+	queued, e := w.QueuedPSBTs()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(queued) != 1 || queued[0].ID != id || queued[0].Account != 1 || queued[0].State != PSBTPending {
+		t.Fatalf("queue is %+v", queued)
+	}
+	// A signature that is not valid is refused and not kept.
+	p.Inputs[0].PartialSigs = []*psbt.PartialSig{{PubKey: []byte{2, 1}, Signature: []byte{0x30}}}
+	if _, e = w.SubmitSignedPSBT(p); e == nil {
+		t.Fatal("PSBT with an invalid signature was submitted")
+	}
+	if queued, e = w.QueuedPSBTs(); e != nil {
+		t.Fatal(e)
+	}
+	if len(queued[0].Packet.Inputs[0].PartialSigs) != 0 {
+		t.Fatalf("queued PSBT kept an invalid signature %+v", queued[0].Packet.Inputs[0].PartialSigs[0])
+	}
+	// One of the two signatures the input needs is kept, but the transaction is not sent.
+	sig, e := txscript.RawTxInSignature(tx, 0, multiSig, txscript.SigHashAll, keys[0])
+	if e != nil {
+		t.Fatal(e)
+	}
+	p.Inputs[0].PartialSigs = []*psbt.PartialSig{{PubKey: keys[0].PubKey().SerializeCompressed(), Signature: sig}}
+	if _, e = w.SubmitSignedPSBT(p); e == nil {
+		t.Fatal("PSBT that can't be finalized was submitted")
+	}
+	if queued, e = w.QueuedPSBTs(); e != nil {
+		t.Fatal(e)
+	}
+	if queued[0].State != PSBTPending || len(queued[0].Packet.Inputs[0].PartialSigs) != 1 {
+		t.Fatalf("queued PSBT after a partial submission is %+v", queued[0].Packet.Inputs[0])
+	}
+	other := tx.Copy()
+	other.TxOut[0].Value--
+	otherPacket, e := psbt.New(other)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, e = w.SubmitSignedPSBT(otherPacket); e != ErrPSBTNotQueued {
+		t.Fatalf("PSBT of a transaction that is not queued was submitted with error %v", e)
+	}
+	if e = w.CancelQueuedPSBT(chainhash.Hash{1}); e != ErrPSBTNotQueued {
+		t.Fatalf("cancelling a transaction that is not queued failed with error %v", e)
+	}
+	if e = w.CancelQueuedPSBT(id); e != nil {
+		t.Fatal(e)
+	}
+	if w.LockedOutpoint(op) {
+		t.Fatal("input of the cancelled transaction is still locked")
+	}
+	if queued, e = w.QueuedPSBTs(); e != nil {
+		t.Fatal(e)
+	}
+	if len(queued) != 0 {
+		t.Fatalf("queue after cancelling is %+v", queued)
+	}
+}
@@ -1,15 +1,72 @@
 package wallet
 
 import (
+	"sync"
+	"time"
+
 	"github.com/p9c/log"
 	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
 	"github.com/p9c/pod/pkg/wire"
 	"github.com/p9c/pod/pkg/wtxmgr"
 )
 
+// Rescans run in the background while the wallet keeps serving requests. The transactions a rescan finds are each
+// recorded in their own database transaction as they arrive, and the blocks it has passed are marked synced in batches
+// of rescanCatchUpBatch blocks, so sends and queries are only held up for as long as one of those takes.
+//
+// While a rescan runs, the balances, transactions and unspent outputs of the wallet are those found in the blocks it
+// has passed, and anything received or spent after them is added as the rescan reaches it. A transaction created in
+// the meantime may spend an output that the rescan has not yet found spent, which the chain server rejects, and the
+// transaction is then removed again. When the rescan finds a mined transaction spending an output that an unmined
+// transaction of the wallet spends, the unmined transaction is removed with those spending its outputs, and recorded as
+// a conflict of the rescan, which getrescaninfo returns.
+
+const (
+	// rescanCatchUpBatch is the number of blocks marked synced in each database transaction when catching up to the
+	// progress of a rescan.
+	rescanCatchUpBatch = 500
+	// maxRescanConflicts is the most conflicts kept for a rescan, the oldest are dropped beyond this.
+	maxRescanConflicts = 100
+)
+
+// RescanConflict is an unmined transaction of the wallet that was removed because a rescan found a mined transaction
+// spending the same output.
+type RescanConflict struct {
+	OutPoint wire.OutPoint
+	// Removed is the unmined transaction that was removed, along with the unmined transactions spending its outputs.
+	Removed chainhash.Hash
+	SpentBy chainhash.Hash
+	Height  int32
+}
+
+// RescanStatus is the state of the rescan the wallet is running, or last ran.
+type RescanStatus struct {
+	Running     bool
+	Started     time.Time
+	Addresses   int
+	OutPoints   int
+	StartHeight int32
+	// Height is the last block the rescan has passed.
+	Height int32
+	// BestHeight is the height of the best block of the chain server when the rescan started.
+	BestHeight int32
+	// Queued is the number of rescans waiting for this one to finish, which are run together after it.
+	Queued int
+	// Conflicts are those found since the rescan started, including those in blocks connected while it runs.
+	Conflicts []RescanConflict
+}
+
+// rescanState is the RescanStatus kept up to date by the rescan goroutines.
+type rescanState struct {
+	mtx    sync.Mutex
+	status RescanStatus
+}
+
 // RescanProgressMsg reports the current progress made by a rescan for a set of
 // wallet addresses.
 type RescanProgressMsg struct {
@@ -114,6 +171,7 @@ out:
 				} else {
 					nextBatch.merge(job)
 				}
+				w.setRescanQueued(len(nextBatch.errChans))
 			}
 		case n := <-w.rescanNotifications:
 			switch n := n.(type) {
@@ -140,6 +198,7 @@ out:
 					Notification: n,
 				}
 				curBatch, nextBatch = nextBatch, nil
+				w.setRescanQueued(0)
 				if curBatch != nil {
 					w.rescanBatch <- curBatch
 				}
@@ -165,12 +224,14 @@ out:
 		select {
 		case msg := <-w.rescanProgress:
 			n := msg.Notification
+			w.setRescanHeight(n.Height)
 			I.F(
 				"rescanned through block %v (height %d)",
 				n.Hash, n.Height,
 			)
 		case msg := <-w.rescanFinished:
 			n := msg.Notification
+			w.setRescanHeight(n.Height)
 			addrs := msg.Addresses
 			noun := log.PickNoun(len(addrs), "address", "addresses")
 			I.F(
@@ -207,10 +268,12 @@ out:
 				"started rescan from block %v (height %d) for %d %s",
 				batch.bs.Hash, batch.bs.Height, numAddrs, noun,
 			)
+			w.startRescan(chainClient, batch)
 			e := chainClient.Rescan(
 				&batch.bs.Hash, batch.addrs,
 				batch.outpoints,
 			)
+			w.stopRescan()
 			if e != nil {
 				E.F(
 					"rescan for %d %s failed: %v", numAddrs, noun, e,
@@ -224,6 +287,146 @@ out:
 	w.wg.Done()
 }
 
+// RescanStatus returns the state of the rescan the wallet is running, or last ran.
+func (w *Wallet) RescanStatus() RescanStatus {
+	w.rescanState.mtx.Lock()
+	defer w.rescanState.mtx.Unlock()
+	status := w.rescanState.status
+	status.Conflicts = append([]RescanConflict(nil), status.Conflicts...)
+	return status
+}
+
+// rescanRunning returns whether the wallet is running a rescan.
+func (w *Wallet) rescanRunning() bool {
+	w.rescanState.mtx.Lock()
+	defer w.rescanState.mtx.Unlock()
+	return w.rescanState.status.Running
+}
+
+// startRescan records the start of the rescan of a batch, which replaces the state of the last rescan.
+func (w *Wallet) startRescan(chainClient chainclient.Interface, batch *rescanBatch) {
+	_, best, e := chainClient.GetBestBlock()
+	if E.Chk(e) {
+		best = batch.bs.Height
+	}
+	w.rescanState.mtx.Lock()
+	w.rescanState.status = RescanStatus{
+		Running:     true,
+		Started:     time.Now(),
+		Addresses:   len(batch.addrs),
+		OutPoints:   len(batch.outpoints),
+		StartHeight: batch.bs.Height,
+		Height:      batch.bs.Height,
+		BestHeight:  best,
+		Queued:      w.rescanState.status.Queued,
+	}
+	w.rescanState.mtx.Unlock()
+}
+
+// stopRescan records that the rescan has returned.
+func (w *Wallet) stopRescan() {
+	w.rescanState.mtx.Lock()
+	w.rescanState.status.Running = false
+	w.rescanState.mtx.Unlock()
+}
+
+// setRescanHeight records the last block the rescan has passed.
+func (w *Wallet) setRescanHeight(height int32) {
+	w.rescanState.mtx.Lock()
+	if height > w.rescanState.status.Height {
+		w.rescanState.status.Height = height
+	}
+	w.rescanState.mtx.Unlock()
+}
+
+// setRescanQueued records the number of rescans waiting for the running one.
+func (w *Wallet) setRescanQueued(queued int) {
+	w.rescanState.mtx.Lock()
+	w.rescanState.status.Queued = queued
+	w.rescanState.mtx.Unlock()
+}
+
+// findRescanConflicts looks for the unmined transactions of the wallet spending the same outputs as a mined transaction
+// before it is recorded, which removes them, and records them as conflicts. Locks on the outputs are released, as they
+// can no longer be spent.
+func (w *Wallet) findRescanConflicts(ns walletdb.ReadBucket, rec *wtxmgr.TxRecord, block *wtxmgr.BlockMeta) {
+	var conflicts []RescanConflict
+	for _, in := range rec.MsgTx.TxIn {
+		op := in.PreviousOutPoint
+		for _, spender := range w.TxStore.UnminedSpenders(ns, &op) {
+			if spender == rec.Hash {
+				continue
+			}
+			W.F(
+				"transaction %v spending %v was double spent by %v mined in block %d and is removed",
+				spender, op, rec.Hash, block.Height,
+			)
+			conflicts = append(
+				conflicts, RescanConflict{OutPoint: op, Removed: spender, SpentBy: rec.Hash, Height: block.Height},
+			)
+		}
+		if w.LockedOutpoint(op) {
+			D.Ln("releasing the lock on", op, "spent by", rec.Hash)
+			w.UnlockOutpoint(op)
+		}
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+	w.rescanState.mtx.Lock()
+	c := append(w.rescanState.status.Conflicts, conflicts...)
+	if len(c) > maxRescanConflicts {
+		c = c[len(c)-maxRescanConflicts:]
+	}
+	w.rescanState.status.Conflicts = c
+	w.rescanState.mtx.Unlock()
+}
+
+// catchUpHashes marks the blocks up to height synced, after a rescan has passed them. The hashes are fetched from the
+// chain server outside of the database, and committed rescanCatchUpBatch blocks at a time, so the wallet is not held
+// up by the whole catch up.
+func (w *Wallet) catchUpHashes(client chainclient.Interface, height int32) (e error) {
+	from := w.Manager.SyncedTo().Height + 1
+	if from > height {
+		return
+	}
+	I.F("catching up block hashes from height %d to %d", from, height)
+	for from <= height {
+		to := from + rescanCatchUpBatch - 1
+		if to > height {
+			to = height
+		}
+		stamps := make([]waddrmgr.BlockStamp, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			var hash *chainhash.Hash
+			if hash, e = client.GetBlockHash(int64(i)); E.Chk(e) {
+				return
+			}
+			var header *wire.BlockHeader
+			if header, e = client.GetBlockHeader(hash); E.Chk(e) {
+				return
+			}
+			stamps = append(stamps, waddrmgr.BlockStamp{Height: i, Hash: *hash, Timestamp: header.Timestamp})
+		}
+		if e = walletdb.Update(
+			w.db, func(tx walletdb.ReadWriteTx) (e error) {
+				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				for i := range stamps {
+					if e = w.Manager.SetSyncedTo(ns, &stamps[i]); E.Chk(e) {
+						return
+					}
+				}
+				return
+			},
+		); E.Chk(e) {
+			return
+		}
+		from = to + 1
+	}
+	I.Ln("done catching up block hashes")
+	return
+}
+
 // Rescan begins a rescan for all active addresses and unspent outputs of a
 // wallet. This is intended to be used to sync a wallet back up to the current
 // best block in the main chain, and is considered an initial sync rescan.
@@ -55,22 +55,58 @@ type (
 	None struct{} 
 	// AddMultiSigAddressRes is the result from a call to AddMultiSigAddress
 	AddMultiSigAddressRes struct { Res *string; e error }
+	// AddPortfolioEntryRes is the result from a call to AddPortfolioEntry
+	AddPortfolioEntryRes struct { Res *None; e error }
+	// ArchiveAccountRes is the result from a call to ArchiveAccount
+	ArchiveAccountRes struct { Res *None; e error }
+	// BackupRemoteRes is the result from a call to BackupRemote
+	BackupRemoteRes struct { Res *[]btcjson.BackupTargetResult; e error }
+	// CancelQueuedPSBTRes is the result from a call to CancelQueuedPSBT
+	CancelQueuedPSBTRes struct { Res *None; e error }
 	// CreateMultiSigRes is the result from a call to CreateMultiSig
 	CreateMultiSigRes struct { Res *btcjson.CreateMultiSigResult; e error }
+	// CreateMultiSigAccountRes is the result from a call to CreateMultiSigAccount
+	CreateMultiSigAccountRes struct { Res *btcjson.MultiSigAccountResult; e error }
 	// CreateNewAccountRes is the result from a call to CreateNewAccount
 	CreateNewAccountRes struct { Res *None; e error }
+	// CreateVaultAccountRes is the result from a call to CreateVaultAccount
+	CreateVaultAccountRes struct { Res *btcjson.VaultAccountResult; e error }
+	// DeleteAddressMetaRes is the result from a call to DeleteAddressMeta
+	DeleteAddressMetaRes struct { Res *None; e error }
 	// HandleDropWalletHistoryRes is the result from a call to HandleDropWalletHistory
 	HandleDropWalletHistoryRes struct { Res *string; e error }
 	// DumpPrivKeyRes is the result from a call to DumpPrivKey
 	DumpPrivKeyRes struct { Res *string; e error }
+	// ExportAccountXprvRes is the result from a call to ExportAccountXprv
+	ExportAccountXprvRes struct { Res *btcjson.ExportAccountXprvResult; e error }
+	// ExportLedgerRes is the result from a call to ExportLedger
+	ExportLedgerRes struct { Res *string; e error }
+	// ExportPaymentBundleRes is the result from a call to ExportPaymentBundle
+	ExportPaymentBundleRes struct { Res *btcjson.ExportPaymentBundleResult; e error }
+	// ExportWatchSetRes is the result from a call to ExportWatchSet
+	ExportWatchSetRes struct { Res *btcjson.WatchSetResult; e error }
+	// FreezeUnspentRes is the result from a call to FreezeUnspent
+	FreezeUnspentRes struct { Res *bool; e error }
+	// GeneratePaperKeyRes is the result from a call to GeneratePaperKey
+	GeneratePaperKeyRes struct { Res *[]btcjson.PaperKeyResult; e error }
 	// GetAccountRes is the result from a call to GetAccount
 	GetAccountRes struct { Res *string; e error }
 	// GetAccountAddressRes is the result from a call to GetAccountAddress
 	GetAccountAddressRes struct { Res *string; e error }
+	// GetAccountXPubRes is the result from a call to GetAccountXPub
+	GetAccountXPubRes struct { Res *string; e error }
 	// GetAddressesByAccountRes is the result from a call to GetAddressesByAccount
 	GetAddressesByAccountRes struct { Res *[]string; e error }
+	// GetAddressInfoRes is the result from a call to GetAddressInfo
+	GetAddressInfoRes struct { Res *btcjson.GetAddressInfoResult; e error }
+	// GetAddressMetaRes is the result from a call to GetAddressMeta
+	GetAddressMetaRes struct { Res *btcjson.AddressMetaResult; e error }
+	// GetAuditLogRes is the result from a call to GetAuditLog
+	GetAuditLogRes struct { Res *btcjson.GetAuditLogResult; e error }
 	// GetBalanceRes is the result from a call to GetBalance
 	GetBalanceRes struct { Res *float64; e error }
+	// GetBalanceAtRes is the result from a call to GetBalanceAt
+	GetBalanceAtRes struct { Res *btcjson.GetBalanceAtResult; e error }
 	// GetBestBlockRes is the result from a call to GetBestBlock
 	GetBestBlockRes struct { Res *btcjson.GetBestBlockResult; e error }
 	// GetBestBlockHashRes is the result from a call to GetBestBlockHash
@@ -81,30 +117,74 @@ type (
 	GetInfoRes struct { Res *btcjson.InfoWalletResult; e error }
 	// GetNewAddressRes is the result from a call to GetNewAddress
 	GetNewAddressRes struct { Res *string; e error }
+	// GetNewMultiSigAddressRes is the result from a call to GetNewMultiSigAddress
+	GetNewMultiSigAddressRes struct { Res *btcjson.MultiSigAddressResult; e error }
+	// GetNewVaultAddressRes is the result from a call to GetNewVaultAddress
+	GetNewVaultAddressRes struct { Res *btcjson.VaultAddressResult; e error }
+	// GetPaymentBundleRes is the result from a call to GetPaymentBundle
+	GetPaymentBundleRes struct { Res *btcjson.PaymentBundleResult; e error }
 	// GetRawChangeAddressRes is the result from a call to GetRawChangeAddress
 	GetRawChangeAddressRes struct { Res *string; e error }
 	// GetReceivedByAccountRes is the result from a call to GetReceivedByAccount
 	GetReceivedByAccountRes struct { Res *float64; e error }
 	// GetReceivedByAddressRes is the result from a call to GetReceivedByAddress
 	GetReceivedByAddressRes struct { Res *float64; e error }
+	// GetRescanInfoRes is the result from a call to GetRescanInfo
+	GetRescanInfoRes struct { Res *btcjson.GetRescanInfoResult; e error }
+	// GetScrubInfoRes is the result from a call to GetScrubInfo
+	GetScrubInfoRes struct { Res *btcjson.GetScrubInfoResult; e error }
+	// GetSpendAuthRes is the result from a call to GetSpendAuth
+	GetSpendAuthRes struct { Res *btcjson.SpendAuthResult; e error }
 	// GetTransactionRes is the result from a call to GetTransaction
 	GetTransactionRes struct { Res *btcjson.GetTransactionResult; e error }
 	// GetUnconfirmedBalanceRes is the result from a call to GetUnconfirmedBalance
 	GetUnconfirmedBalanceRes struct { Res *float64; e error }
+	// GetUTXOReportRes is the result from a call to GetUTXOReport
+	GetUTXOReportRes struct { Res *btcjson.GetUTXOReportResult; e error }
+	// GetVaultScheduleRes is the result from a call to GetVaultSchedule
+	GetVaultScheduleRes struct { Res *btcjson.VaultScheduleResult; e error }
 	// HelpNoChainRPCRes is the result from a call to HelpNoChainRPC
 	HelpNoChainRPCRes struct { Res *string; e error }
+	// ImportCoreWalletRes is the result from a call to ImportCoreWallet
+	ImportCoreWalletRes struct { Res *btcjson.ImportCoreWalletResult; e error }
 	// ImportPrivKeyRes is the result from a call to ImportPrivKey
 	ImportPrivKeyRes struct { Res *None; e error }
+	// ImportScriptPubKeyRes is the result from a call to ImportScriptPubKey
+	ImportScriptPubKeyRes struct { Res *None; e error }
+	// ImportTimeLockScriptRes is the result from a call to ImportTimeLockScript
+	ImportTimeLockScriptRes struct { Res *btcjson.TimeLockScriptResult; e error }
 	// KeypoolRefillRes is the result from a call to KeypoolRefill
 	KeypoolRefillRes struct { Res *None; e error }
 	// ListAccountsRes is the result from a call to ListAccounts
 	ListAccountsRes struct { Res *map[string]float64; e error }
+	// ListAddressMetaRes is the result from a call to ListAddressMeta
+	ListAddressMetaRes struct { Res *[]btcjson.AddressMetaResult; e error }
 	// ListAddressTransactionsRes is the result from a call to ListAddressTransactions
 	ListAddressTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; e error }
 	// ListAllTransactionsRes is the result from a call to ListAllTransactions
 	ListAllTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; e error }
+	// ListDustOutputsRes is the result from a call to ListDustOutputs
+	ListDustOutputsRes struct { Res *[]btcjson.DustOutputResult; e error }
+	// ListFrozenRes is the result from a call to ListFrozen
+	ListFrozenRes struct { Res *[]btcjson.FrozenOutputResult; e error }
+	// ListImmatureRes is the result from a call to ListImmature
+	ListImmatureRes struct { Res *btcjson.ListImmatureResult; e error }
+	// ListInvoiceReservationsRes is the result from a call to ListInvoiceReservations
+	ListInvoiceReservationsRes struct { Res *[]btcjson.InvoiceReservationResult; e error }
+	// ListInvoicesRes is the result from a call to ListInvoices
+	ListInvoicesRes struct { Res *[]btcjson.InvoiceResult; e error }
 	// ListLockUnspentRes is the result from a call to ListLockUnspent
 	ListLockUnspentRes struct { Res *[]btcjson.TransactionInput; e error }
+	// ListMultiSigAccountsRes is the result from a call to ListMultiSigAccounts
+	ListMultiSigAccountsRes struct { Res *[]btcjson.MultiSigAccountResult; e error }
+	// ListPaymentBundlesRes is the result from a call to ListPaymentBundles
+	ListPaymentBundlesRes struct { Res *[]btcjson.PaymentBundleResult; e error }
+	// ListPortfolioRes is the result from a call to ListPortfolio
+	ListPortfolioRes struct { Res *btcjson.ListPortfolioResult; e error }
+	// ListPortfolioTransactionsRes is the result from a call to ListPortfolioTransactions
+	ListPortfolioTransactionsRes struct { Res *[]btcjson.PortfolioTransactionResult; e error }
+	// ListQueuedPSBTsRes is the result from a call to ListQueuedPSBTs
+	ListQueuedPSBTsRes struct { Res *[]btcjson.QueuedPSBTResult; e error }
 	// ListReceivedByAccountRes is the result from a call to ListReceivedByAccount
 	ListReceivedByAccountRes struct { Res *[]btcjson.ListReceivedByAccountResult; e error }
 	// ListReceivedByAddressRes is the result from a call to ListReceivedByAddress
@@ -113,26 +193,62 @@ type (
 	ListSinceBlockRes struct { Res *btcjson.ListSinceBlockResult; e error }
 	// ListTransactionsRes is the result from a call to ListTransactions
 	ListTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; e error }
+	// ListTransactionsPageRes is the result from a call to ListTransactionsPage
+	ListTransactionsPageRes struct { Res *btcjson.ListTransactionsPageResult; e error }
+	// ListUnlockAttemptsRes is the result from a call to ListUnlockAttempts
+	ListUnlockAttemptsRes struct { Res *[]btcjson.UnlockAttemptResult; e error }
 	// ListUnspentRes is the result from a call to ListUnspent
 	ListUnspentRes struct { Res *[]btcjson.ListUnspentResult; e error }
+	// ListVaultAccountsRes is the result from a call to ListVaultAccounts
+	ListVaultAccountsRes struct { Res *[]btcjson.VaultAccountResult; e error }
+	// OverrideDustRes is the result from a call to OverrideDust
+	OverrideDustRes struct { Res *bool; e error }
+	// PreviewSendRes is the result from a call to PreviewSend
+	PreviewSendRes struct { Res *btcjson.PreviewSendResult; e error }
+	// ReleaseInvoiceAddressRes is the result from a call to ReleaseInvoiceAddress
+	ReleaseInvoiceAddressRes struct { Res *bool; e error }
+	// RemovePortfolioEntryRes is the result from a call to RemovePortfolioEntry
+	RemovePortfolioEntryRes struct { Res *None; e error }
 	// RenameAccountRes is the result from a call to RenameAccount
 	RenameAccountRes struct { Res *None; e error }
+	// ReserveInvoiceAddressRes is the result from a call to ReserveInvoiceAddress
+	ReserveInvoiceAddressRes struct { Res *btcjson.InvoiceReservationResult; e error }
 	// LockUnspentRes is the result from a call to LockUnspent
 	LockUnspentRes struct { Res *bool; e error }
 	// SendManyRes is the result from a call to SendMany
 	SendManyRes struct { Res *string; e error }
 	// SendToAddressRes is the result from a call to SendToAddress
 	SendToAddressRes struct { Res *string; e error }
+	// SetAddressMetaRes is the result from a call to SetAddressMeta
+	SetAddressMetaRes struct { Res *btcjson.AddressMetaResult; e error }
+	// SetInvoiceIssuanceRes is the result from a call to SetInvoiceIssuance
+	SetInvoiceIssuanceRes struct { Res *bool; e error }
+	// SetSpendAuthRes is the result from a call to SetSpendAuth
+	SetSpendAuthRes struct { Res *btcjson.SpendAuthResult; e error }
 	// SetTxFeeRes is the result from a call to SetTxFee
 	SetTxFeeRes struct { Res *bool; e error }
 	// SignMessageRes is the result from a call to SignMessage
 	SignMessageRes struct { Res *string; e error }
 	// SignRawTransactionRes is the result from a call to SignRawTransaction
 	SignRawTransactionRes struct { Res *btcjson.SignRawTransactionResult; e error }
+	// SubmitSignedPSBTRes is the result from a call to SubmitSignedPSBT
+	SubmitSignedPSBTRes struct { Res *string; e error }
+	// SweepAccountRes is the result from a call to SweepAccount
+	SweepAccountRes struct { Res *btcjson.SweepAccountResult; e error }
+	// SweepPrivKeyRes is the result from a call to SweepPrivKey
+	SweepPrivKeyRes struct { Res *btcjson.SweepPrivKeyResult; e error }
+	// SweepTimeLockedRes is the result from a call to SweepTimeLocked
+	SweepTimeLockedRes struct { Res *btcjson.SweepTimeLockedResult; e error }
+	// TransferAccountRes is the result from a call to TransferAccount
+	TransferAccountRes struct { Res *btcjson.TransferAccountResult; e error }
+	// UnarchiveAccountRes is the result from a call to UnarchiveAccount
+	UnarchiveAccountRes struct { Res *None; e error }
 	// ValidateAddressRes is the result from a call to ValidateAddress
 	ValidateAddressRes struct { Res *btcjson.ValidateAddressWalletResult; e error }
 	// VerifyMessageRes is the result from a call to VerifyMessage
 	VerifyMessageRes struct { Res *bool; e error }
+	// WalletDBStatsRes is the result from a call to WalletDBStats
+	WalletDBStatsRes struct { Res *[]btcjson.WalletDBBucketResult; e error }
 	// WalletIsLockedRes is the result from a call to WalletIsLocked
 	WalletIsLockedRes struct { Res *bool; e error }
 	// WalletLockRes is the result from a call to WalletLock
@@ -141,6 +257,10 @@ type (
 	WalletPassphraseRes struct { Res *None; e error }
 	// WalletPassphraseChangeRes is the result from a call to WalletPassphraseChange
 	WalletPassphraseChangeRes struct { Res *None; e error }
+	// WalletSelfTestRes is the result from a call to WalletSelfTest
+	WalletSelfTestRes struct { Res *btcjson.WalletSelfTestResult; e error }
+	// WithdrawVaultRes is the result from a call to WithdrawVault
+	WithdrawVaultRes struct { Res *string; e error }
 )
 
 // RequestHandler is a handler function to handle an unmarshaled and parsed request into a marshalable response.  If the 
@@ -179,30 +299,84 @@ var RPCHandlers = map[string]struct {
 	"addmultisigaddress":{ 
 		Handler: AddMultiSigAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan AddMultiSigAddressRes)} }}, 
+	"addportfolioentry":{ 
+		Handler: AddPortfolioEntry, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan AddPortfolioEntryRes)} }}, 
+	"archiveaccount":{ 
+		Handler: ArchiveAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ArchiveAccountRes)} }}, 
+	"backupremote":{ 
+		Handler: BackupRemote, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan BackupRemoteRes)} }}, 
+	"cancelqueuedpsbt":{ 
+		Handler: CancelQueuedPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CancelQueuedPSBTRes)} }}, 
 	"createmultisig":{ 
 		Handler: CreateMultiSig, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateMultiSigRes)} }}, 
+	"createmultisigaccount":{ 
+		Handler: CreateMultiSigAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreateMultiSigAccountRes)} }}, 
 	"createnewaccount":{ 
 		Handler: CreateNewAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateNewAccountRes)} }}, 
+	"createvaultaccount":{ 
+		Handler: CreateVaultAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreateVaultAccountRes)} }}, 
+	"deleteaddressmeta":{ 
+		Handler: DeleteAddressMeta, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DeleteAddressMetaRes)} }}, 
 	"dropwallethistory":{ 
 		Handler: HandleDropWalletHistory, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HandleDropWalletHistoryRes)} }}, 
 	"dumpprivkey":{ 
 		Handler: DumpPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan DumpPrivKeyRes)} }}, 
+	"exportaccountxprv":{ 
+		Handler: ExportAccountXprv, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ExportAccountXprvRes)} }}, 
+	"exportledger":{ 
+		Handler: ExportLedger, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ExportLedgerRes)} }}, 
+	"exportpaymentbundle":{ 
+		Handler: ExportPaymentBundle, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ExportPaymentBundleRes)} }}, 
+	"exportwatchset":{ 
+		Handler: ExportWatchSet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ExportWatchSetRes)} }}, 
+	"freezeunspent":{ 
+		Handler: FreezeUnspent, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan FreezeUnspentRes)} }}, 
+	"generatepaperkey":{ 
+		Handler: GeneratePaperKey, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GeneratePaperKeyRes)} }}, 
 	"getaccount":{ 
 		Handler: GetAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountRes)} }}, 
 	"getaccountaddress":{ 
 		Handler: GetAccountAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountAddressRes)} }}, 
+	"getaccountxpub":{ 
+		Handler: GetAccountXPub, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAccountXPubRes)} }}, 
 	"getaddressesbyaccount":{ 
 		Handler: GetAddressesByAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAddressesByAccountRes)} }}, 
+	"getaddressinfo":{ 
+		Handler: GetAddressInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressInfoRes)} }}, 
+	"getaddressmeta":{ 
+		Handler: GetAddressMeta, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressMetaRes)} }}, 
+	"getauditlog":{ 
+		Handler: GetAuditLog, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAuditLogRes)} }}, 
 	"getbalance":{ 
 		Handler: GetBalance, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBalanceRes)} }}, 
+	"getbalanceat":{ 
+		Handler: GetBalanceAt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetBalanceAtRes)} }}, 
 	"getbestblock":{ 
 		Handler: GetBestBlock, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBestBlockRes)} }}, 
@@ -218,6 +392,15 @@ var RPCHandlers = map[string]struct {
 	"getnewaddress":{ 
 		Handler: GetNewAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetNewAddressRes)} }}, 
+	"getnewmultisigaddress":{ 
+		Handler: GetNewMultiSigAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetNewMultiSigAddressRes)} }}, 
+	"getnewvaultaddress":{ 
+		Handler: GetNewVaultAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetNewVaultAddressRes)} }}, 
+	"getpaymentbundle":{ 
+		Handler: GetPaymentBundle, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetPaymentBundleRes)} }}, 
 	"getrawchangeaddress":{ 
 		Handler: GetRawChangeAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetRawChangeAddressRes)} }}, 
@@ -227,33 +410,90 @@ var RPCHandlers = map[string]struct {
 	"getreceivedbyaddress":{ 
 		Handler: GetReceivedByAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetReceivedByAddressRes)} }}, 
+	"getrescaninfo":{ 
+		Handler: GetRescanInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetRescanInfoRes)} }}, 
+	"getscrubinfo":{ 
+		Handler: GetScrubInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetScrubInfoRes)} }}, 
+	"getspendauth":{ 
+		Handler: GetSpendAuth, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetSpendAuthRes)} }}, 
 	"gettransaction":{ 
 		Handler: GetTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetTransactionRes)} }}, 
 	"getunconfirmedbalance":{ 
 		Handler: GetUnconfirmedBalance, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetUnconfirmedBalanceRes)} }}, 
+	"getutxoreport":{ 
+		Handler: GetUTXOReport, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetUTXOReportRes)} }}, 
+	"getvaultschedule":{ 
+		Handler: GetVaultSchedule, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetVaultScheduleRes)} }}, 
 	"help":{ 
 		Handler: HelpNoChainRPC, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HelpNoChainRPCRes)} }}, 
+	"importcorewallet":{ 
+		Handler: ImportCoreWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportCoreWalletRes)} }}, 
 	"importprivkey":{ 
 		Handler: ImportPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ImportPrivKeyRes)} }}, 
+	"importscriptpubkey":{ 
+		Handler: ImportScriptPubKey, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportScriptPubKeyRes)} }}, 
+	"importtimelockscript":{ 
+		Handler: ImportTimeLockScript, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportTimeLockScriptRes)} }}, 
 	"keypoolrefill":{ 
 		Handler: KeypoolRefill, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan KeypoolRefillRes)} }}, 
 	"listaccounts":{ 
 		Handler: ListAccounts, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAccountsRes)} }}, 
+	"listaddressmeta":{ 
+		Handler: ListAddressMeta, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListAddressMetaRes)} }}, 
 	"listaddresstransactions":{ 
 		Handler: ListAddressTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAddressTransactionsRes)} }}, 
 	"listalltransactions":{ 
 		Handler: ListAllTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAllTransactionsRes)} }}, 
+	"listdustoutputs":{ 
+		Handler: ListDustOutputs, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListDustOutputsRes)} }}, 
+	"listfrozen":{ 
+		Handler: ListFrozen, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListFrozenRes)} }}, 
+	"listimmature":{ 
+		Handler: ListImmature, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListImmatureRes)} }}, 
+	"listinvoicereservations":{ 
+		Handler: ListInvoiceReservations, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListInvoiceReservationsRes)} }}, 
+	"listinvoices":{ 
+		Handler: ListInvoices, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListInvoicesRes)} }}, 
 	"listlockunspent":{ 
 		Handler: ListLockUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListLockUnspentRes)} }}, 
+	"listmultisigaccounts":{ 
+		Handler: ListMultiSigAccounts, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListMultiSigAccountsRes)} }}, 
+	"listpaymentbundles":{ 
+		Handler: ListPaymentBundles, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListPaymentBundlesRes)} }}, 
+	"listportfolio":{ 
+		Handler: ListPortfolio, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListPortfolioRes)} }}, 
+	"listportfoliotransactions":{ 
+		Handler: ListPortfolioTransactions, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListPortfolioTransactionsRes)} }}, 
+	"listqueuedpsbts":{ 
+		Handler: ListQueuedPSBTs, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListQueuedPSBTsRes)} }}, 
 	"listreceivedbyaccount":{ 
 		Handler: ListReceivedByAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListReceivedByAccountRes)} }}, 
@@ -266,12 +506,36 @@ var RPCHandlers = map[string]struct {
 	"listtransactions":{ 
 		Handler: ListTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListTransactionsRes)} }}, 
+	"listtransactionspage":{ 
+		Handler: ListTransactionsPage, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListTransactionsPageRes)} }}, 
+	"listunlockattempts":{ 
+		Handler: ListUnlockAttempts, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListUnlockAttemptsRes)} }}, 
 	"listunspent":{ 
 		Handler: ListUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListUnspentRes)} }}, 
+	"listvaultaccounts":{ 
+		Handler: ListVaultAccounts, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListVaultAccountsRes)} }}, 
+	"overridedust":{ 
+		Handler: OverrideDust, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan OverrideDustRes)} }}, 
+	"previewsend":{ 
+		Handler: PreviewSend, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan PreviewSendRes)} }}, 
+	"releaseinvoiceaddress":{ 
+		Handler: ReleaseInvoiceAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ReleaseInvoiceAddressRes)} }}, 
+	"removeportfolioentry":{ 
+		Handler: RemovePortfolioEntry, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan RemovePortfolioEntryRes)} }}, 
 	"renameaccount":{ 
 		Handler: RenameAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan RenameAccountRes)} }}, 
+	"reserveinvoiceaddress":{ 
+		Handler: ReserveInvoiceAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ReserveInvoiceAddressRes)} }}, 
 	"sendfrom":{ 
 		Handler: LockUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan LockUnspentRes)} }}, 
@@ -281,6 +545,15 @@ var RPCHandlers = map[string]struct {
 	"sendtoaddress":{ 
 		Handler: SendToAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendToAddressRes)} }}, 
+	"setaddressmeta":{ 
+		Handler: SetAddressMeta, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetAddressMetaRes)} }}, 
+	"setinvoiceissuance":{ 
+		Handler: SetInvoiceIssuance, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetInvoiceIssuanceRes)} }}, 
+	"setspendauth":{ 
+		Handler: SetSpendAuth, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetSpendAuthRes)} }}, 
 	"settxfee":{ 
 		Handler: SetTxFee, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SetTxFeeRes)} }}, 
@@ -290,12 +563,33 @@ var RPCHandlers = map[string]struct {
 	"signrawtransaction":{ 
 		Handler: SignRawTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SignRawTransactionRes)} }}, 
+	"submitsignedpsbt":{ 
+		Handler: SubmitSignedPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SubmitSignedPSBTRes)} }}, 
+	"sweepaccount":{ 
+		Handler: SweepAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SweepAccountRes)} }}, 
+	"sweepprivkey":{ 
+		Handler: SweepPrivKey, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SweepPrivKeyRes)} }}, 
+	"sweeptimelocked":{ 
+		Handler: SweepTimeLocked, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SweepTimeLockedRes)} }}, 
+	"transferaccount":{ 
+		Handler: TransferAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan TransferAccountRes)} }}, 
+	"unarchiveaccount":{ 
+		Handler: UnarchiveAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan UnarchiveAccountRes)} }}, 
 	"validateaddress":{ 
 		Handler: ValidateAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ValidateAddressRes)} }}, 
 	"verifymessage":{ 
 		Handler: VerifyMessage, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan VerifyMessageRes)} }}, 
+	"walletdbstats":{ 
+		Handler: WalletDBStats, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletDBStatsRes)} }}, 
 	"walletislocked":{ 
 		Handler: WalletIsLocked, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletIsLockedRes)} }}, 
@@ -308,6 +602,12 @@ var RPCHandlers = map[string]struct {
 	"walletpassphrasechange":{ 
 		Handler: WalletPassphraseChange, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletPassphraseChangeRes)} }}, 
+	"walletselftest":{ 
+		Handler: WalletSelfTest, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletSelfTestRes)} }}, 
+	"withdrawvault":{ 
+		Handler: WithdrawVault, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WithdrawVaultRes)} }}, 
 
 }
 
@@ -358,17 +658,17 @@ func (a API) AddMultiSigAddressWait(cmd *btcjson.AddMultisigAddressCmd) (out *st
 	return
 }
 
-// CreateMultiSig calls the method with the given parameters
-func (a API) CreateMultiSig(cmd *btcjson.CreateMultisigCmd) (e error) {
-	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
+// AddPortfolioEntry calls the method with the given parameters
+func (a API) AddPortfolioEntry(cmd *btcjson.AddPortfolioEntryCmd) (e error) {
+	RPCHandlers["addportfolioentry"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// CreateMultiSigCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// AddPortfolioEntryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) CreateMultiSigCheck() (isNew bool) {
+func (a API) AddPortfolioEntryCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan CreateMultiSigRes):
+	case o := <- a.Ch.(chan AddPortfolioEntryRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -380,36 +680,36 @@ func (a API) CreateMultiSigCheck() (isNew bool) {
 	return
 }
 
-// CreateMultiSigGetRes returns a pointer to the value in the Result field
-func (a API) CreateMultiSigGetRes() (out *btcjson.CreateMultiSigResult, e error) {
-	out, _ = a.Result.(*btcjson.CreateMultiSigResult)
+// AddPortfolioEntryGetRes returns a pointer to the value in the Result field
+func (a API) AddPortfolioEntryGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// CreateMultiSigWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) CreateMultiSigWait(cmd *btcjson.CreateMultisigCmd) (out *btcjson.CreateMultiSigResult, e error) {
-	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
+// AddPortfolioEntryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) AddPortfolioEntryWait(cmd *btcjson.AddPortfolioEntryCmd) (out *None, e error) {
+	RPCHandlers["addportfolioentry"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan CreateMultiSigRes):
+	case o := <- a.Ch.(chan AddPortfolioEntryRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// CreateNewAccount calls the method with the given parameters
-func (a API) CreateNewAccount(cmd *btcjson.CreateNewAccountCmd) (e error) {
-	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
+// ArchiveAccount calls the method with the given parameters
+func (a API) ArchiveAccount(cmd *btcjson.ArchiveAccountCmd) (e error) {
+	RPCHandlers["archiveaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// CreateNewAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ArchiveAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) CreateNewAccountCheck() (isNew bool) {
+func (a API) ArchiveAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan CreateNewAccountRes):
+	case o := <- a.Ch.(chan ArchiveAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -421,36 +721,36 @@ func (a API) CreateNewAccountCheck() (isNew bool) {
 	return
 }
 
-// CreateNewAccountGetRes returns a pointer to the value in the Result field
-func (a API) CreateNewAccountGetRes() (out *None, e error) {
+// ArchiveAccountGetRes returns a pointer to the value in the Result field
+func (a API) ArchiveAccountGetRes() (out *None, e error) {
 	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// CreateNewAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) CreateNewAccountWait(cmd *btcjson.CreateNewAccountCmd) (out *None, e error) {
-	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
+// ArchiveAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ArchiveAccountWait(cmd *btcjson.ArchiveAccountCmd) (out *None, e error) {
+	RPCHandlers["archiveaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan CreateNewAccountRes):
+	case o := <- a.Ch.(chan ArchiveAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// HandleDropWalletHistory calls the method with the given parameters
-func (a API) HandleDropWalletHistory(cmd *None) (e error) {
-	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
+// BackupRemote calls the method with the given parameters
+func (a API) BackupRemote(cmd *btcjson.BackupRemoteCmd) (e error) {
+	RPCHandlers["backupremote"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// HandleDropWalletHistoryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// BackupRemoteCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
+func (a API) BackupRemoteCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan HandleDropWalletHistoryRes):
+	case o := <- a.Ch.(chan BackupRemoteRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -462,36 +762,36 @@ func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
 	return
 }
 
-// HandleDropWalletHistoryGetRes returns a pointer to the value in the Result field
-func (a API) HandleDropWalletHistoryGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// BackupRemoteGetRes returns a pointer to the value in the Result field
+func (a API) BackupRemoteGetRes() (out *[]btcjson.BackupTargetResult, e error) {
+	out, _ = a.Result.(*[]btcjson.BackupTargetResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// HandleDropWalletHistoryWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HandleDropWalletHistoryWait(cmd *None) (out *string, e error) {
-	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
+// BackupRemoteWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) BackupRemoteWait(cmd *btcjson.BackupRemoteCmd) (out *[]btcjson.BackupTargetResult, e error) {
+	RPCHandlers["backupremote"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan HandleDropWalletHistoryRes):
+	case o := <- a.Ch.(chan BackupRemoteRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// DumpPrivKey calls the method with the given parameters
-func (a API) DumpPrivKey(cmd *btcjson.DumpPrivKeyCmd) (e error) {
-	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
+// CancelQueuedPSBT calls the method with the given parameters
+func (a API) CancelQueuedPSBT(cmd *btcjson.CancelQueuedPSBTCmd) (e error) {
+	RPCHandlers["cancelqueuedpsbt"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// DumpPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CancelQueuedPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) DumpPrivKeyCheck() (isNew bool) {
+func (a API) CancelQueuedPSBTCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan DumpPrivKeyRes):
+	case o := <- a.Ch.(chan CancelQueuedPSBTRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -503,36 +803,36 @@ func (a API) DumpPrivKeyCheck() (isNew bool) {
 	return
 }
 
-// DumpPrivKeyGetRes returns a pointer to the value in the Result field
-func (a API) DumpPrivKeyGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// CancelQueuedPSBTGetRes returns a pointer to the value in the Result field
+func (a API) CancelQueuedPSBTGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// DumpPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, e error) {
-	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
+// CancelQueuedPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CancelQueuedPSBTWait(cmd *btcjson.CancelQueuedPSBTCmd) (out *None, e error) {
+	RPCHandlers["cancelqueuedpsbt"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan DumpPrivKeyRes):
+	case o := <- a.Ch.(chan CancelQueuedPSBTRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetAccount calls the method with the given parameters
-func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (e error) {
-	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateMultiSig calls the method with the given parameters
+func (a API) CreateMultiSig(cmd *btcjson.CreateMultisigCmd) (e error) {
+	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateMultiSigCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAccountCheck() (isNew bool) {
+func (a API) CreateMultiSigCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetAccountRes):
+	case o := <- a.Ch.(chan CreateMultiSigRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -544,36 +844,36 @@ func (a API) GetAccountCheck() (isNew bool) {
 	return
 }
 
-// GetAccountGetRes returns a pointer to the value in the Result field
-func (a API) GetAccountGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// CreateMultiSigGetRes returns a pointer to the value in the Result field
+func (a API) CreateMultiSigGetRes() (out *btcjson.CreateMultiSigResult, e error) {
+	out, _ = a.Result.(*btcjson.CreateMultiSigResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAccountWait(cmd *btcjson.GetAccountCmd) (out *string, e error) {
-	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateMultiSigWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateMultiSigWait(cmd *btcjson.CreateMultisigCmd) (out *btcjson.CreateMultiSigResult, e error) {
+	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetAccountRes):
+	case o := <- a.Ch.(chan CreateMultiSigRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetAccountAddress calls the method with the given parameters
-func (a API) GetAccountAddress(cmd *btcjson.GetAccountAddressCmd) (e error) {
-	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+// CreateMultiSigAccount calls the method with the given parameters
+func (a API) CreateMultiSigAccount(cmd *btcjson.CreateMultiSigAccountCmd) (e error) {
+	RPCHandlers["createmultisigaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAccountAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateMultiSigAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAccountAddressCheck() (isNew bool) {
+func (a API) CreateMultiSigAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetAccountAddressRes):
+	case o := <- a.Ch.(chan CreateMultiSigAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -585,36 +885,36 @@ func (a API) GetAccountAddressCheck() (isNew bool) {
 	return
 }
 
-// GetAccountAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetAccountAddressGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// CreateMultiSigAccountGetRes returns a pointer to the value in the Result field
+func (a API) CreateMultiSigAccountGetRes() (out *btcjson.MultiSigAccountResult, e error) {
+	out, _ = a.Result.(*btcjson.MultiSigAccountResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetAccountAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAccountAddressWait(cmd *btcjson.GetAccountAddressCmd) (out *string, e error) {
-	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+// CreateMultiSigAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateMultiSigAccountWait(cmd *btcjson.CreateMultiSigAccountCmd) (out *btcjson.MultiSigAccountResult, e error) {
+	RPCHandlers["createmultisigaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetAccountAddressRes):
+	case o := <- a.Ch.(chan CreateMultiSigAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetAddressesByAccount calls the method with the given parameters
-func (a API) GetAddressesByAccount(cmd *btcjson.GetAddressesByAccountCmd) (e error) {
-	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateNewAccount calls the method with the given parameters
+func (a API) CreateNewAccount(cmd *btcjson.CreateNewAccountCmd) (e error) {
+	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAddressesByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateNewAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAddressesByAccountCheck() (isNew bool) {
+func (a API) CreateNewAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetAddressesByAccountRes):
+	case o := <- a.Ch.(chan CreateNewAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -626,36 +926,36 @@ func (a API) GetAddressesByAccountCheck() (isNew bool) {
 	return
 }
 
-// GetAddressesByAccountGetRes returns a pointer to the value in the Result field
-func (a API) GetAddressesByAccountGetRes() (out *[]string, e error) {
-	out, _ = a.Result.(*[]string)
+// CreateNewAccountGetRes returns a pointer to the value in the Result field
+func (a API) CreateNewAccountGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetAddressesByAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAddressesByAccountWait(cmd *btcjson.GetAddressesByAccountCmd) (out *[]string, e error) {
-	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateNewAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateNewAccountWait(cmd *btcjson.CreateNewAccountCmd) (out *None, e error) {
+	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetAddressesByAccountRes):
+	case o := <- a.Ch.(chan CreateNewAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetBalance calls the method with the given parameters
-func (a API) GetBalance(cmd *btcjson.GetBalanceCmd) (e error) {
-	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+// CreateVaultAccount calls the method with the given parameters
+func (a API) CreateVaultAccount(cmd *btcjson.CreateVaultAccountCmd) (e error) {
+	RPCHandlers["createvaultaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateVaultAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBalanceCheck() (isNew bool) {
+func (a API) CreateVaultAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetBalanceRes):
+	case o := <- a.Ch.(chan CreateVaultAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -667,36 +967,36 @@ func (a API) GetBalanceCheck() (isNew bool) {
 	return
 }
 
-// GetBalanceGetRes returns a pointer to the value in the Result field
-func (a API) GetBalanceGetRes() (out *float64, e error) {
-	out, _ = a.Result.(*float64)
+// CreateVaultAccountGetRes returns a pointer to the value in the Result field
+func (a API) CreateVaultAccountGetRes() (out *btcjson.VaultAccountResult, e error) {
+	out, _ = a.Result.(*btcjson.VaultAccountResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetBalanceWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBalanceWait(cmd *btcjson.GetBalanceCmd) (out *float64, e error) {
-	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+// CreateVaultAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateVaultAccountWait(cmd *btcjson.CreateVaultAccountCmd) (out *btcjson.VaultAccountResult, e error) {
+	RPCHandlers["createvaultaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetBalanceRes):
+	case o := <- a.Ch.(chan CreateVaultAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetBestBlock calls the method with the given parameters
-func (a API) GetBestBlock(cmd *None) (e error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// DeleteAddressMeta calls the method with the given parameters
+func (a API) DeleteAddressMeta(cmd *btcjson.DeleteAddressMetaCmd) (e error) {
+	RPCHandlers["deleteaddressmeta"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// DeleteAddressMetaCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBestBlockCheck() (isNew bool) {
+func (a API) DeleteAddressMetaCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetBestBlockRes):
+	case o := <- a.Ch.(chan DeleteAddressMetaRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -708,36 +1008,36 @@ func (a API) GetBestBlockCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, e error) {
-	out, _ = a.Result.(*btcjson.GetBestBlockResult)
+// DeleteAddressMetaGetRes returns a pointer to the value in the Result field
+func (a API) DeleteAddressMetaGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, e error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// DeleteAddressMetaWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DeleteAddressMetaWait(cmd *btcjson.DeleteAddressMetaCmd) (out *None, e error) {
+	RPCHandlers["deleteaddressmeta"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetBestBlockRes):
+	case o := <- a.Ch.(chan DeleteAddressMetaRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetBestBlockHash calls the method with the given parameters
-func (a API) GetBestBlockHash(cmd *None) (e error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// HandleDropWalletHistory calls the method with the given parameters
+func (a API) HandleDropWalletHistory(cmd *None) (e error) {
+	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockHashCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// HandleDropWalletHistoryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBestBlockHashCheck() (isNew bool) {
+func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetBestBlockHashRes):
+	case o := <- a.Ch.(chan HandleDropWalletHistoryRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -749,36 +1049,36 @@ func (a API) GetBestBlockHashCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockHashGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockHashGetRes() (out *string, e error) {
+// HandleDropWalletHistoryGetRes returns a pointer to the value in the Result field
+func (a API) HandleDropWalletHistoryGetRes() (out *string, e error) {
 	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockHashWait(cmd *None) (out *string, e error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// HandleDropWalletHistoryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HandleDropWalletHistoryWait(cmd *None) (out *string, e error) {
+	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetBestBlockHashRes):
+	case o := <- a.Ch.(chan HandleDropWalletHistoryRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetBlockCount calls the method with the given parameters
-func (a API) GetBlockCount(cmd *None) (e error) {
-	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+// DumpPrivKey calls the method with the given parameters
+func (a API) DumpPrivKey(cmd *btcjson.DumpPrivKeyCmd) (e error) {
+	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockCountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// DumpPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBlockCountCheck() (isNew bool) {
+func (a API) DumpPrivKeyCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetBlockCountRes):
+	case o := <- a.Ch.(chan DumpPrivKeyRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -790,36 +1090,36 @@ func (a API) GetBlockCountCheck() (isNew bool) {
 	return
 }
 
-// GetBlockCountGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockCountGetRes() (out *int32, e error) {
-	out, _ = a.Result.(*int32)
+// DumpPrivKeyGetRes returns a pointer to the value in the Result field
+func (a API) DumpPrivKeyGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockCountWait(cmd *None) (out *int32, e error) {
-	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+// DumpPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, e error) {
+	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetBlockCountRes):
+	case o := <- a.Ch.(chan DumpPrivKeyRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetInfo calls the method with the given parameters
-func (a API) GetInfo(cmd *None) (e error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// ExportAccountXprv calls the method with the given parameters
+func (a API) ExportAccountXprv(cmd *btcjson.ExportAccountXprvCmd) (e error) {
+	RPCHandlers["exportaccountxprv"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ExportAccountXprvCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetInfoCheck() (isNew bool) {
+func (a API) ExportAccountXprvCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetInfoRes):
+	case o := <- a.Ch.(chan ExportAccountXprvRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -831,36 +1131,36 @@ func (a API) GetInfoCheck() (isNew bool) {
 	return
 }
 
-// GetInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetInfoGetRes() (out *btcjson.InfoWalletResult, e error) {
-	out, _ = a.Result.(*btcjson.InfoWalletResult)
+// ExportAccountXprvGetRes returns a pointer to the value in the Result field
+func (a API) ExportAccountXprvGetRes() (out *btcjson.ExportAccountXprvResult, e error) {
+	out, _ = a.Result.(*btcjson.ExportAccountXprvResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoWalletResult, e error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// ExportAccountXprvWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ExportAccountXprvWait(cmd *btcjson.ExportAccountXprvCmd) (out *btcjson.ExportAccountXprvResult, e error) {
+	RPCHandlers["exportaccountxprv"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetInfoRes):
+	case o := <- a.Ch.(chan ExportAccountXprvRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetNewAddress calls the method with the given parameters
-func (a API) GetNewAddress(cmd *btcjson.GetNewAddressCmd) (e error) {
-	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+// ExportLedger calls the method with the given parameters
+func (a API) ExportLedger(cmd *btcjson.ExportLedgerCmd) (e error) {
+	RPCHandlers["exportledger"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNewAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ExportLedgerCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetNewAddressCheck() (isNew bool) {
+func (a API) ExportLedgerCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetNewAddressRes):
+	case o := <- a.Ch.(chan ExportLedgerRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -872,36 +1172,36 @@ func (a API) GetNewAddressCheck() (isNew bool) {
 	return
 }
 
-// GetNewAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetNewAddressGetRes() (out *string, e error) {
+// ExportLedgerGetRes returns a pointer to the value in the Result field
+func (a API) ExportLedgerGetRes() (out *string, e error) {
 	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetNewAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNewAddressWait(cmd *btcjson.GetNewAddressCmd) (out *string, e error) {
-	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+// ExportLedgerWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ExportLedgerWait(cmd *btcjson.ExportLedgerCmd) (out *string, e error) {
+	RPCHandlers["exportledger"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetNewAddressRes):
+	case o := <- a.Ch.(chan ExportLedgerRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetRawChangeAddress calls the method with the given parameters
-func (a API) GetRawChangeAddress(cmd *btcjson.GetRawChangeAddressCmd) (e error) {
-	RPCHandlers["getrawchangeaddress"].Call <- API{a.Ch, cmd, nil}
+// ExportPaymentBundle calls the method with the given parameters
+func (a API) ExportPaymentBundle(cmd *btcjson.ExportPaymentBundleCmd) (e error) {
+	RPCHandlers["exportpaymentbundle"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetRawChangeAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ExportPaymentBundleCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetRawChangeAddressCheck() (isNew bool) {
+func (a API) ExportPaymentBundleCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetRawChangeAddressRes):
+	case o := <- a.Ch.(chan ExportPaymentBundleRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -913,36 +1213,36 @@ func (a API) GetRawChangeAddressCheck() (isNew bool) {
 	return
 }
 
-// GetRawChangeAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetRawChangeAddressGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// ExportPaymentBundleGetRes returns a pointer to the value in the Result field
+func (a API) ExportPaymentBundleGetRes() (out *btcjson.ExportPaymentBundleResult, e error) {
+	out, _ = a.Result.(*btcjson.ExportPaymentBundleResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetRawChangeAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetRawChangeAddressWait(cmd *btcjson.GetRawChangeAddressCmd) (out *string, e error) {
-	RPCHandlers["getrawchangeaddress"].Call <- API{a.Ch, cmd, nil}
+// ExportPaymentBundleWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ExportPaymentBundleWait(cmd *btcjson.ExportPaymentBundleCmd) (out *btcjson.ExportPaymentBundleResult, e error) {
+	RPCHandlers["exportpaymentbundle"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetRawChangeAddressRes):
+	case o := <- a.Ch.(chan ExportPaymentBundleRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetReceivedByAccount calls the method with the given parameters
-func (a API) GetReceivedByAccount(cmd *btcjson.GetReceivedByAccountCmd) (e error) {
-	RPCHandlers["getreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+// ExportWatchSet calls the method with the given parameters
+func (a API) ExportWatchSet(cmd *None) (e error) {
+	RPCHandlers["exportwatchset"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetReceivedByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ExportWatchSetCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetReceivedByAccountCheck() (isNew bool) {
+func (a API) ExportWatchSetCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetReceivedByAccountRes):
+	case o := <- a.Ch.(chan ExportWatchSetRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -954,36 +1254,36 @@ func (a API) GetReceivedByAccountCheck() (isNew bool) {
 	return
 }
 
-// GetReceivedByAccountGetRes returns a pointer to the value in the Result field
-func (a API) GetReceivedByAccountGetRes() (out *float64, e error) {
-	out, _ = a.Result.(*float64)
+// ExportWatchSetGetRes returns a pointer to the value in the Result field
+func (a API) ExportWatchSetGetRes() (out *btcjson.WatchSetResult, e error) {
+	out, _ = a.Result.(*btcjson.WatchSetResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetReceivedByAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetReceivedByAccountWait(cmd *btcjson.GetReceivedByAccountCmd) (out *float64, e error) {
-	RPCHandlers["getreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+// ExportWatchSetWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ExportWatchSetWait(cmd *None) (out *btcjson.WatchSetResult, e error) {
+	RPCHandlers["exportwatchset"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetReceivedByAccountRes):
+	case o := <- a.Ch.(chan ExportWatchSetRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetReceivedByAddress calls the method with the given parameters
-func (a API) GetReceivedByAddress(cmd *btcjson.GetReceivedByAddressCmd) (e error) {
-	RPCHandlers["getreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+// FreezeUnspent calls the method with the given parameters
+func (a API) FreezeUnspent(cmd *btcjson.FreezeUnspentCmd) (e error) {
+	RPCHandlers["freezeunspent"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetReceivedByAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// FreezeUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetReceivedByAddressCheck() (isNew bool) {
+func (a API) FreezeUnspentCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetReceivedByAddressRes):
+	case o := <- a.Ch.(chan FreezeUnspentRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -995,36 +1295,36 @@ func (a API) GetReceivedByAddressCheck() (isNew bool) {
 	return
 }
 
-// GetReceivedByAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetReceivedByAddressGetRes() (out *float64, e error) {
-	out, _ = a.Result.(*float64)
+// FreezeUnspentGetRes returns a pointer to the value in the Result field
+func (a API) FreezeUnspentGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetReceivedByAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetReceivedByAddressWait(cmd *btcjson.GetReceivedByAddressCmd) (out *float64, e error) {
-	RPCHandlers["getreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+// FreezeUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) FreezeUnspentWait(cmd *btcjson.FreezeUnspentCmd) (out *bool, e error) {
+	RPCHandlers["freezeunspent"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetReceivedByAddressRes):
+	case o := <- a.Ch.(chan FreezeUnspentRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetTransaction calls the method with the given parameters
-func (a API) GetTransaction(cmd *btcjson.GetTransactionCmd) (e error) {
-	RPCHandlers["gettransaction"].Call <- API{a.Ch, cmd, nil}
+// GeneratePaperKey calls the method with the given parameters
+func (a API) GeneratePaperKey(cmd *btcjson.GeneratePaperKeyCmd) (e error) {
+	RPCHandlers["generatepaperkey"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetTransactionCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GeneratePaperKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetTransactionCheck() (isNew bool) {
+func (a API) GeneratePaperKeyCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetTransactionRes):
+	case o := <- a.Ch.(chan GeneratePaperKeyRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1036,36 +1336,36 @@ func (a API) GetTransactionCheck() (isNew bool) {
 	return
 }
 
-// GetTransactionGetRes returns a pointer to the value in the Result field
-func (a API) GetTransactionGetRes() (out *btcjson.GetTransactionResult, e error) {
-	out, _ = a.Result.(*btcjson.GetTransactionResult)
+// GeneratePaperKeyGetRes returns a pointer to the value in the Result field
+func (a API) GeneratePaperKeyGetRes() (out *[]btcjson.PaperKeyResult, e error) {
+	out, _ = a.Result.(*[]btcjson.PaperKeyResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetTransactionWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetTransactionWait(cmd *btcjson.GetTransactionCmd) (out *btcjson.GetTransactionResult, e error) {
-	RPCHandlers["gettransaction"].Call <- API{a.Ch, cmd, nil}
+// GeneratePaperKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GeneratePaperKeyWait(cmd *btcjson.GeneratePaperKeyCmd) (out *[]btcjson.PaperKeyResult, e error) {
+	RPCHandlers["generatepaperkey"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetTransactionRes):
+	case o := <- a.Ch.(chan GeneratePaperKeyRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// GetUnconfirmedBalance calls the method with the given parameters
-func (a API) GetUnconfirmedBalance(cmd *btcjson.GetUnconfirmedBalanceCmd) (e error) {
-	RPCHandlers["getunconfirmedbalance"].Call <- API{a.Ch, cmd, nil}
+// GetAccount calls the method with the given parameters
+func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (e error) {
+	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetUnconfirmedBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetUnconfirmedBalanceCheck() (isNew bool) {
+func (a API) GetAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan GetUnconfirmedBalanceRes):
+	case o := <- a.Ch.(chan GetAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1077,36 +1377,36 @@ func (a API) GetUnconfirmedBalanceCheck() (isNew bool) {
 	return
 }
 
-// GetUnconfirmedBalanceGetRes returns a pointer to the value in the Result field
-func (a API) GetUnconfirmedBalanceGetRes() (out *float64, e error) {
-	out, _ = a.Result.(*float64)
+// GetAccountGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetUnconfirmedBalanceWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetUnconfirmedBalanceWait(cmd *btcjson.GetUnconfirmedBalanceCmd) (out *float64, e error) {
-	RPCHandlers["getunconfirmedbalance"].Call <- API{a.Ch, cmd, nil}
+// GetAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountWait(cmd *btcjson.GetAccountCmd) (out *string, e error) {
+	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan GetUnconfirmedBalanceRes):
+	case o := <- a.Ch.(chan GetAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// HelpNoChainRPC calls the method with the given parameters
-func (a API) HelpNoChainRPC(cmd btcjson.HelpCmd) (e error) {
-	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+// GetAccountAddress calls the method with the given parameters
+func (a API) GetAccountAddress(cmd *btcjson.GetAccountAddressCmd) (e error) {
+	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// HelpNoChainRPCCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAccountAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) HelpNoChainRPCCheck() (isNew bool) {
+func (a API) GetAccountAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan HelpNoChainRPCRes):
+	case o := <- a.Ch.(chan GetAccountAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1118,36 +1418,36 @@ func (a API) HelpNoChainRPCCheck() (isNew bool) {
 	return
 }
 
-// HelpNoChainRPCGetRes returns a pointer to the value in the Result field
-func (a API) HelpNoChainRPCGetRes() (out *string, e error) {
+// GetAccountAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountAddressGetRes() (out *string, e error) {
 	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// HelpNoChainRPCWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HelpNoChainRPCWait(cmd btcjson.HelpCmd) (out *string, e error) {
-	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+// GetAccountAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountAddressWait(cmd *btcjson.GetAccountAddressCmd) (out *string, e error) {
+	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan HelpNoChainRPCRes):
+	case o := <- a.Ch.(chan GetAccountAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ImportPrivKey calls the method with the given parameters
-func (a API) ImportPrivKey(cmd *btcjson.ImportPrivKeyCmd) (e error) {
-	RPCHandlers["importprivkey"].Call <- API{a.Ch, cmd, nil}
+// GetAccountXPub calls the method with the given parameters
+func (a API) GetAccountXPub(cmd *btcjson.GetAccountXPubCmd) (e error) {
+	RPCHandlers["getaccountxpub"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ImportPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAccountXPubCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ImportPrivKeyCheck() (isNew bool) {
+func (a API) GetAccountXPubCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ImportPrivKeyRes):
+	case o := <- a.Ch.(chan GetAccountXPubRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1159,36 +1459,36 @@ func (a API) ImportPrivKeyCheck() (isNew bool) {
 	return
 }
 
-// ImportPrivKeyGetRes returns a pointer to the value in the Result field
-func (a API) ImportPrivKeyGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetAccountXPubGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountXPubGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ImportPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ImportPrivKeyWait(cmd *btcjson.ImportPrivKeyCmd) (out *None, e error) {
-	RPCHandlers["importprivkey"].Call <- API{a.Ch, cmd, nil}
+// GetAccountXPubWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountXPubWait(cmd *btcjson.GetAccountXPubCmd) (out *string, e error) {
+	RPCHandlers["getaccountxpub"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ImportPrivKeyRes):
+	case o := <- a.Ch.(chan GetAccountXPubRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// KeypoolRefill calls the method with the given parameters
-func (a API) KeypoolRefill(cmd *None) (e error) {
-	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
+// GetAddressesByAccount calls the method with the given parameters
+func (a API) GetAddressesByAccount(cmd *btcjson.GetAddressesByAccountCmd) (e error) {
+	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// KeypoolRefillCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAddressesByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) KeypoolRefillCheck() (isNew bool) {
+func (a API) GetAddressesByAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan KeypoolRefillRes):
+	case o := <- a.Ch.(chan GetAddressesByAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1200,36 +1500,36 @@ func (a API) KeypoolRefillCheck() (isNew bool) {
 	return
 }
 
-// KeypoolRefillGetRes returns a pointer to the value in the Result field
-func (a API) KeypoolRefillGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetAddressesByAccountGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressesByAccountGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// KeypoolRefillWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) KeypoolRefillWait(cmd *None) (out *None, e error) {
-	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
+// GetAddressesByAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressesByAccountWait(cmd *btcjson.GetAddressesByAccountCmd) (out *[]string, e error) {
+	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan KeypoolRefillRes):
+	case o := <- a.Ch.(chan GetAddressesByAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListAccounts calls the method with the given parameters
-func (a API) ListAccounts(cmd *btcjson.ListAccountsCmd) (e error) {
-	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+// GetAddressInfo calls the method with the given parameters
+func (a API) GetAddressInfo(cmd *btcjson.GetAddressInfoCmd) (e error) {
+	RPCHandlers["getaddressinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAddressInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAccountsCheck() (isNew bool) {
+func (a API) GetAddressInfoCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListAccountsRes):
+	case o := <- a.Ch.(chan GetAddressInfoRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1241,36 +1541,36 @@ func (a API) ListAccountsCheck() (isNew bool) {
 	return
 }
 
-// ListAccountsGetRes returns a pointer to the value in the Result field
-func (a API) ListAccountsGetRes() (out *map[string]float64, e error) {
-	out, _ = a.Result.(*map[string]float64)
+// GetAddressInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressInfoGetRes() (out *btcjson.GetAddressInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetAddressInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListAccountsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]float64, e error) {
-	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+// GetAddressInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressInfoWait(cmd *btcjson.GetAddressInfoCmd) (out *btcjson.GetAddressInfoResult, e error) {
+	RPCHandlers["getaddressinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListAccountsRes):
+	case o := <- a.Ch.(chan GetAddressInfoRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListAddressTransactions calls the method with the given parameters
-func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (e error) {
-	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+// GetAddressMeta calls the method with the given parameters
+func (a API) GetAddressMeta(cmd *btcjson.GetAddressMetaCmd) (e error) {
+	RPCHandlers["getaddressmeta"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListAddressTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAddressMetaCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAddressTransactionsCheck() (isNew bool) {
+func (a API) GetAddressMetaCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListAddressTransactionsRes):
+	case o := <- a.Ch.(chan GetAddressMetaRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1282,36 +1582,36 @@ func (a API) ListAddressTransactionsCheck() (isNew bool) {
 	return
 }
 
-// ListAddressTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) ListAddressTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
-	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+// GetAddressMetaGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressMetaGetRes() (out *btcjson.AddressMetaResult, e error) {
+	out, _ = a.Result.(*btcjson.AddressMetaResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListAddressTransactionsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAddressTransactionsWait(cmd *btcjson.ListAddressTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
-	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+// GetAddressMetaWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressMetaWait(cmd *btcjson.GetAddressMetaCmd) (out *btcjson.AddressMetaResult, e error) {
+	RPCHandlers["getaddressmeta"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListAddressTransactionsRes):
+	case o := <- a.Ch.(chan GetAddressMetaRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListAllTransactions calls the method with the given parameters
-func (a API) ListAllTransactions(cmd *btcjson.ListAllTransactionsCmd) (e error) {
-	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+// GetAuditLog calls the method with the given parameters
+func (a API) GetAuditLog(cmd *btcjson.GetAuditLogCmd) (e error) {
+	RPCHandlers["getauditlog"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListAllTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetAuditLogCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAllTransactionsCheck() (isNew bool) {
+func (a API) GetAuditLogCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListAllTransactionsRes):
+	case o := <- a.Ch.(chan GetAuditLogRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1323,36 +1623,36 @@ func (a API) ListAllTransactionsCheck() (isNew bool) {
 	return
 }
 
-// ListAllTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) ListAllTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
-	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+// GetAuditLogGetRes returns a pointer to the value in the Result field
+func (a API) GetAuditLogGetRes() (out *btcjson.GetAuditLogResult, e error) {
+	out, _ = a.Result.(*btcjson.GetAuditLogResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListAllTransactionsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAllTransactionsWait(cmd *btcjson.ListAllTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
-	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+// GetAuditLogWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAuditLogWait(cmd *btcjson.GetAuditLogCmd) (out *btcjson.GetAuditLogResult, e error) {
+	RPCHandlers["getauditlog"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListAllTransactionsRes):
+	case o := <- a.Ch.(chan GetAuditLogRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListLockUnspent calls the method with the given parameters
-func (a API) ListLockUnspent(cmd *None) (e error) {
-	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+// GetBalance calls the method with the given parameters
+func (a API) GetBalance(cmd *btcjson.GetBalanceCmd) (e error) {
+	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListLockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListLockUnspentCheck() (isNew bool) {
+func (a API) GetBalanceCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListLockUnspentRes):
+	case o := <- a.Ch.(chan GetBalanceRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1364,36 +1664,36 @@ func (a API) ListLockUnspentCheck() (isNew bool) {
 	return
 }
 
-// ListLockUnspentGetRes returns a pointer to the value in the Result field
-func (a API) ListLockUnspentGetRes() (out *[]btcjson.TransactionInput, e error) {
-	out, _ = a.Result.(*[]btcjson.TransactionInput)
+// GetBalanceGetRes returns a pointer to the value in the Result field
+func (a API) GetBalanceGetRes() (out *float64, e error) {
+	out, _ = a.Result.(*float64)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListLockUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListLockUnspentWait(cmd *None) (out *[]btcjson.TransactionInput, e error) {
-	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+// GetBalanceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBalanceWait(cmd *btcjson.GetBalanceCmd) (out *float64, e error) {
+	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListLockUnspentRes):
+	case o := <- a.Ch.(chan GetBalanceRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListReceivedByAccount calls the method with the given parameters
-func (a API) ListReceivedByAccount(cmd *btcjson.ListReceivedByAccountCmd) (e error) {
-	RPCHandlers["listreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+// GetBalanceAt calls the method with the given parameters
+func (a API) GetBalanceAt(cmd *btcjson.GetBalanceAtCmd) (e error) {
+	RPCHandlers["getbalanceat"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListReceivedByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetBalanceAtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListReceivedByAccountCheck() (isNew bool) {
+func (a API) GetBalanceAtCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListReceivedByAccountRes):
+	case o := <- a.Ch.(chan GetBalanceAtRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1405,36 +1705,36 @@ func (a API) ListReceivedByAccountCheck() (isNew bool) {
 	return
 }
 
-// ListReceivedByAccountGetRes returns a pointer to the value in the Result field
-func (a API) ListReceivedByAccountGetRes() (out *[]btcjson.ListReceivedByAccountResult, e error) {
-	out, _ = a.Result.(*[]btcjson.ListReceivedByAccountResult)
+// GetBalanceAtGetRes returns a pointer to the value in the Result field
+func (a API) GetBalanceAtGetRes() (out *btcjson.GetBalanceAtResult, e error) {
+	out, _ = a.Result.(*btcjson.GetBalanceAtResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListReceivedByAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListReceivedByAccountWait(cmd *btcjson.ListReceivedByAccountCmd) (out *[]btcjson.ListReceivedByAccountResult, e error) {
-	RPCHandlers["listreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+// GetBalanceAtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBalanceAtWait(cmd *btcjson.GetBalanceAtCmd) (out *btcjson.GetBalanceAtResult, e error) {
+	RPCHandlers["getbalanceat"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListReceivedByAccountRes):
+	case o := <- a.Ch.(chan GetBalanceAtRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListReceivedByAddress calls the method with the given parameters
-func (a API) ListReceivedByAddress(cmd *btcjson.ListReceivedByAddressCmd) (e error) {
-	RPCHandlers["listreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+// GetBestBlock calls the method with the given parameters
+func (a API) GetBestBlock(cmd *None) (e error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListReceivedByAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetBestBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListReceivedByAddressCheck() (isNew bool) {
+func (a API) GetBestBlockCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListReceivedByAddressRes):
+	case o := <- a.Ch.(chan GetBestBlockRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1446,36 +1746,36 @@ func (a API) ListReceivedByAddressCheck() (isNew bool) {
 	return
 }
 
-// ListReceivedByAddressGetRes returns a pointer to the value in the Result field
-func (a API) ListReceivedByAddressGetRes() (out *btcjson.ListReceivedByAddressResult, e error) {
-	out, _ = a.Result.(*btcjson.ListReceivedByAddressResult)
+// GetBestBlockGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, e error) {
+	out, _ = a.Result.(*btcjson.GetBestBlockResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListReceivedByAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListReceivedByAddressWait(cmd *btcjson.ListReceivedByAddressCmd) (out *btcjson.ListReceivedByAddressResult, e error) {
-	RPCHandlers["listreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, e error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListReceivedByAddressRes):
+	case o := <- a.Ch.(chan GetBestBlockRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListSinceBlock calls the method with the given parameters
-func (a API) ListSinceBlock(cmd btcjson.ListSinceBlockCmd) (e error) {
-	RPCHandlers["listsinceblock"].Call <- API{a.Ch, cmd, nil}
+// GetBestBlockHash calls the method with the given parameters
+func (a API) GetBestBlockHash(cmd *None) (e error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListSinceBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetBestBlockHashCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListSinceBlockCheck() (isNew bool) {
+func (a API) GetBestBlockHashCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListSinceBlockRes):
+	case o := <- a.Ch.(chan GetBestBlockHashRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1487,36 +1787,36 @@ func (a API) ListSinceBlockCheck() (isNew bool) {
 	return
 }
 
-// ListSinceBlockGetRes returns a pointer to the value in the Result field
-func (a API) ListSinceBlockGetRes() (out *btcjson.ListSinceBlockResult, e error) {
-	out, _ = a.Result.(*btcjson.ListSinceBlockResult)
+// GetBestBlockHashGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockHashGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListSinceBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListSinceBlockWait(cmd btcjson.ListSinceBlockCmd) (out *btcjson.ListSinceBlockResult, e error) {
-	RPCHandlers["listsinceblock"].Call <- API{a.Ch, cmd, nil}
+// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockHashWait(cmd *None) (out *string, e error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListSinceBlockRes):
+	case o := <- a.Ch.(chan GetBestBlockHashRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListTransactions calls the method with the given parameters
-func (a API) ListTransactions(cmd *btcjson.ListTransactionsCmd) (e error) {
-	RPCHandlers["listtransactions"].Call <- API{a.Ch, cmd, nil}
+// GetBlockCount calls the method with the given parameters
+func (a API) GetBlockCount(cmd *None) (e error) {
+	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetBlockCountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListTransactionsCheck() (isNew bool) {
+func (a API) GetBlockCountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListTransactionsRes):
+	case o := <- a.Ch.(chan GetBlockCountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1528,36 +1828,36 @@ func (a API) ListTransactionsCheck() (isNew bool) {
 	return
 }
 
-// ListTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) ListTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
-	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+// GetBlockCountGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockCountGetRes() (out *int32, e error) {
+	out, _ = a.Result.(*int32)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListTransactionsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListTransactionsWait(cmd *btcjson.ListTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
-	RPCHandlers["listtransactions"].Call <- API{a.Ch, cmd, nil}
+// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockCountWait(cmd *None) (out *int32, e error) {
+	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListTransactionsRes):
+	case o := <- a.Ch.(chan GetBlockCountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ListUnspent calls the method with the given parameters
-func (a API) ListUnspent(cmd *btcjson.ListUnspentCmd) (e error) {
-	RPCHandlers["listunspent"].Call <- API{a.Ch, cmd, nil}
+// GetInfo calls the method with the given parameters
+func (a API) GetInfo(cmd *None) (e error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListUnspentCheck() (isNew bool) {
+func (a API) GetInfoCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ListUnspentRes):
+	case o := <- a.Ch.(chan GetInfoRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1569,36 +1869,36 @@ func (a API) ListUnspentCheck() (isNew bool) {
 	return
 }
 
-// ListUnspentGetRes returns a pointer to the value in the Result field
-func (a API) ListUnspentGetRes() (out *[]btcjson.ListUnspentResult, e error) {
-	out, _ = a.Result.(*[]btcjson.ListUnspentResult)
+// GetInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetInfoGetRes() (out *btcjson.InfoWalletResult, e error) {
+	out, _ = a.Result.(*btcjson.InfoWalletResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ListUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListUnspentWait(cmd *btcjson.ListUnspentCmd) (out *[]btcjson.ListUnspentResult, e error) {
-	RPCHandlers["listunspent"].Call <- API{a.Ch, cmd, nil}
+// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoWalletResult, e error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ListUnspentRes):
+	case o := <- a.Ch.(chan GetInfoRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// RenameAccount calls the method with the given parameters
-func (a API) RenameAccount(cmd *btcjson.RenameAccountCmd) (e error) {
-	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
+// GetNewAddress calls the method with the given parameters
+func (a API) GetNewAddress(cmd *btcjson.GetNewAddressCmd) (e error) {
+	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// RenameAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetNewAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) RenameAccountCheck() (isNew bool) {
+func (a API) GetNewAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan RenameAccountRes):
+	case o := <- a.Ch.(chan GetNewAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1610,36 +1910,36 @@ func (a API) RenameAccountCheck() (isNew bool) {
 	return
 }
 
-// RenameAccountGetRes returns a pointer to the value in the Result field
-func (a API) RenameAccountGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetNewAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetNewAddressGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// RenameAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) RenameAccountWait(cmd *btcjson.RenameAccountCmd) (out *None, e error) {
-	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
+// GetNewAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNewAddressWait(cmd *btcjson.GetNewAddressCmd) (out *string, e error) {
+	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan RenameAccountRes):
+	case o := <- a.Ch.(chan GetNewAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// LockUnspent calls the method with the given parameters
-func (a API) LockUnspent(cmd btcjson.LockUnspentCmd) (e error) {
-	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+// GetNewMultiSigAddress calls the method with the given parameters
+func (a API) GetNewMultiSigAddress(cmd *btcjson.GetNewMultiSigAddressCmd) (e error) {
+	RPCHandlers["getnewmultisigaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetNewMultiSigAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) LockUnspentCheck() (isNew bool) {
+func (a API) GetNewMultiSigAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan LockUnspentRes):
+	case o := <- a.Ch.(chan GetNewMultiSigAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1651,36 +1951,36 @@ func (a API) LockUnspentCheck() (isNew bool) {
 	return
 }
 
-// LockUnspentGetRes returns a pointer to the value in the Result field
-func (a API) LockUnspentGetRes() (out *bool, e error) {
-	out, _ = a.Result.(*bool)
+// GetNewMultiSigAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetNewMultiSigAddressGetRes() (out *btcjson.MultiSigAddressResult, e error) {
+	out, _ = a.Result.(*btcjson.MultiSigAddressResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) LockUnspentWait(cmd btcjson.LockUnspentCmd) (out *bool, e error) {
-	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+// GetNewMultiSigAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNewMultiSigAddressWait(cmd *btcjson.GetNewMultiSigAddressCmd) (out *btcjson.MultiSigAddressResult, e error) {
+	RPCHandlers["getnewmultisigaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan LockUnspentRes):
+	case o := <- a.Ch.(chan GetNewMultiSigAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// SendMany calls the method with the given parameters
-func (a API) SendMany(cmd *btcjson.SendManyCmd) (e error) {
-	RPCHandlers["sendmany"].Call <- API{a.Ch, cmd, nil}
+// GetNewVaultAddress calls the method with the given parameters
+func (a API) GetNewVaultAddress(cmd *btcjson.GetNewVaultAddressCmd) (e error) {
+	RPCHandlers["getnewvaultaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SendManyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetNewVaultAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) SendManyCheck() (isNew bool) {
+func (a API) GetNewVaultAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan SendManyRes):
+	case o := <- a.Ch.(chan GetNewVaultAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1692,36 +1992,36 @@ func (a API) SendManyCheck() (isNew bool) {
 	return
 }
 
-// SendManyGetRes returns a pointer to the value in the Result field
-func (a API) SendManyGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetNewVaultAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetNewVaultAddressGetRes() (out *btcjson.VaultAddressResult, e error) {
+	out, _ = a.Result.(*btcjson.VaultAddressResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// SendManyWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SendManyWait(cmd *btcjson.SendManyCmd) (out *string, e error) {
-	RPCHandlers["sendmany"].Call <- API{a.Ch, cmd, nil}
+// GetNewVaultAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNewVaultAddressWait(cmd *btcjson.GetNewVaultAddressCmd) (out *btcjson.VaultAddressResult, e error) {
+	RPCHandlers["getnewvaultaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan SendManyRes):
+	case o := <- a.Ch.(chan GetNewVaultAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// SendToAddress calls the method with the given parameters
-func (a API) SendToAddress(cmd *btcjson.SendToAddressCmd) (e error) {
-	RPCHandlers["sendtoaddress"].Call <- API{a.Ch, cmd, nil}
+// GetPaymentBundle calls the method with the given parameters
+func (a API) GetPaymentBundle(cmd *btcjson.GetPaymentBundleCmd) (e error) {
+	RPCHandlers["getpaymentbundle"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SendToAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetPaymentBundleCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) SendToAddressCheck() (isNew bool) {
+func (a API) GetPaymentBundleCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan SendToAddressRes):
+	case o := <- a.Ch.(chan GetPaymentBundleRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1733,36 +2033,36 @@ func (a API) SendToAddressCheck() (isNew bool) {
 	return
 }
 
-// SendToAddressGetRes returns a pointer to the value in the Result field
-func (a API) SendToAddressGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetPaymentBundleGetRes returns a pointer to the value in the Result field
+func (a API) GetPaymentBundleGetRes() (out *btcjson.PaymentBundleResult, e error) {
+	out, _ = a.Result.(*btcjson.PaymentBundleResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// SendToAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SendToAddressWait(cmd *btcjson.SendToAddressCmd) (out *string, e error) {
-	RPCHandlers["sendtoaddress"].Call <- API{a.Ch, cmd, nil}
+// GetPaymentBundleWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPaymentBundleWait(cmd *btcjson.GetPaymentBundleCmd) (out *btcjson.PaymentBundleResult, e error) {
+	RPCHandlers["getpaymentbundle"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan SendToAddressRes):
+	case o := <- a.Ch.(chan GetPaymentBundleRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// SetTxFee calls the method with the given parameters
-func (a API) SetTxFee(cmd *btcjson.SetTxFeeCmd) (e error) {
-	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
+// GetRawChangeAddress calls the method with the given parameters
+func (a API) GetRawChangeAddress(cmd *btcjson.GetRawChangeAddressCmd) (e error) {
+	RPCHandlers["getrawchangeaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SetTxFeeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetRawChangeAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) SetTxFeeCheck() (isNew bool) {
+func (a API) GetRawChangeAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan SetTxFeeRes):
+	case o := <- a.Ch.(chan GetRawChangeAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1774,36 +2074,36 @@ func (a API) SetTxFeeCheck() (isNew bool) {
 	return
 }
 
-// SetTxFeeGetRes returns a pointer to the value in the Result field
-func (a API) SetTxFeeGetRes() (out *bool, e error) {
-	out, _ = a.Result.(*bool)
+// GetRawChangeAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetRawChangeAddressGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// SetTxFeeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SetTxFeeWait(cmd *btcjson.SetTxFeeCmd) (out *bool, e error) {
-	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
+// GetRawChangeAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRawChangeAddressWait(cmd *btcjson.GetRawChangeAddressCmd) (out *string, e error) {
+	RPCHandlers["getrawchangeaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan SetTxFeeRes):
+	case o := <- a.Ch.(chan GetRawChangeAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// SignMessage calls the method with the given parameters
-func (a API) SignMessage(cmd *btcjson.SignMessageCmd) (e error) {
-	RPCHandlers["signmessage"].Call <- API{a.Ch, cmd, nil}
+// GetReceivedByAccount calls the method with the given parameters
+func (a API) GetReceivedByAccount(cmd *btcjson.GetReceivedByAccountCmd) (e error) {
+	RPCHandlers["getreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SignMessageCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetReceivedByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) SignMessageCheck() (isNew bool) {
+func (a API) GetReceivedByAccountCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan SignMessageRes):
+	case o := <- a.Ch.(chan GetReceivedByAccountRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1815,36 +2115,36 @@ func (a API) SignMessageCheck() (isNew bool) {
 	return
 }
 
-// SignMessageGetRes returns a pointer to the value in the Result field
-func (a API) SignMessageGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetReceivedByAccountGetRes returns a pointer to the value in the Result field
+func (a API) GetReceivedByAccountGetRes() (out *float64, e error) {
+	out, _ = a.Result.(*float64)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// SignMessageWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SignMessageWait(cmd *btcjson.SignMessageCmd) (out *string, e error) {
-	RPCHandlers["signmessage"].Call <- API{a.Ch, cmd, nil}
+// GetReceivedByAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetReceivedByAccountWait(cmd *btcjson.GetReceivedByAccountCmd) (out *float64, e error) {
+	RPCHandlers["getreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan SignMessageRes):
+	case o := <- a.Ch.(chan GetReceivedByAccountRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// SignRawTransaction calls the method with the given parameters
-func (a API) SignRawTransaction(cmd btcjson.SignRawTransactionCmd) (e error) {
-	RPCHandlers["signrawtransaction"].Call <- API{a.Ch, cmd, nil}
+// GetReceivedByAddress calls the method with the given parameters
+func (a API) GetReceivedByAddress(cmd *btcjson.GetReceivedByAddressCmd) (e error) {
+	RPCHandlers["getreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SignRawTransactionCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetReceivedByAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) SignRawTransactionCheck() (isNew bool) {
+func (a API) GetReceivedByAddressCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan SignRawTransactionRes):
+	case o := <- a.Ch.(chan GetReceivedByAddressRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1856,36 +2156,36 @@ func (a API) SignRawTransactionCheck() (isNew bool) {
 	return
 }
 
-// SignRawTransactionGetRes returns a pointer to the value in the Result field
-func (a API) SignRawTransactionGetRes() (out *btcjson.SignRawTransactionResult, e error) {
-	out, _ = a.Result.(*btcjson.SignRawTransactionResult)
+// GetReceivedByAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetReceivedByAddressGetRes() (out *float64, e error) {
+	out, _ = a.Result.(*float64)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// SignRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SignRawTransactionWait(cmd btcjson.SignRawTransactionCmd) (out *btcjson.SignRawTransactionResult, e error) {
-	RPCHandlers["signrawtransaction"].Call <- API{a.Ch, cmd, nil}
+// GetReceivedByAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetReceivedByAddressWait(cmd *btcjson.GetReceivedByAddressCmd) (out *float64, e error) {
+	RPCHandlers["getreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan SignRawTransactionRes):
+	case o := <- a.Ch.(chan GetReceivedByAddressRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// ValidateAddress calls the method with the given parameters
-func (a API) ValidateAddress(cmd *btcjson.ValidateAddressCmd) (e error) {
-	RPCHandlers["validateaddress"].Call <- API{a.Ch, cmd, nil}
+// GetRescanInfo calls the method with the given parameters
+func (a API) GetRescanInfo(cmd *None) (e error) {
+	RPCHandlers["getrescaninfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ValidateAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetRescanInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ValidateAddressCheck() (isNew bool) {
+func (a API) GetRescanInfoCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan ValidateAddressRes):
+	case o := <- a.Ch.(chan GetRescanInfoRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1897,36 +2197,36 @@ func (a API) ValidateAddressCheck() (isNew bool) {
 	return
 }
 
-// ValidateAddressGetRes returns a pointer to the value in the Result field
-func (a API) ValidateAddressGetRes() (out *btcjson.ValidateAddressWalletResult, e error) {
-	out, _ = a.Result.(*btcjson.ValidateAddressWalletResult)
+// GetRescanInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetRescanInfoGetRes() (out *btcjson.GetRescanInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetRescanInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ValidateAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ValidateAddressWait(cmd *btcjson.ValidateAddressCmd) (out *btcjson.ValidateAddressWalletResult, e error) {
-	RPCHandlers["validateaddress"].Call <- API{a.Ch, cmd, nil}
+// GetRescanInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRescanInfoWait(cmd *None) (out *btcjson.GetRescanInfoResult, e error) {
+	RPCHandlers["getrescaninfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan ValidateAddressRes):
+	case o := <- a.Ch.(chan GetRescanInfoRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// VerifyMessage calls the method with the given parameters
-func (a API) VerifyMessage(cmd *btcjson.VerifyMessageCmd) (e error) {
-	RPCHandlers["verifymessage"].Call <- API{a.Ch, cmd, nil}
+// GetScrubInfo calls the method with the given parameters
+func (a API) GetScrubInfo(cmd *None) (e error) {
+	RPCHandlers["getscrubinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// VerifyMessageCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetScrubInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) VerifyMessageCheck() (isNew bool) {
+func (a API) GetScrubInfoCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan VerifyMessageRes):
+	case o := <- a.Ch.(chan GetScrubInfoRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1938,36 +2238,36 @@ func (a API) VerifyMessageCheck() (isNew bool) {
 	return
 }
 
-// VerifyMessageGetRes returns a pointer to the value in the Result field
-func (a API) VerifyMessageGetRes() (out *bool, e error) {
-	out, _ = a.Result.(*bool)
+// GetScrubInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetScrubInfoGetRes() (out *btcjson.GetScrubInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetScrubInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// VerifyMessageWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) VerifyMessageWait(cmd *btcjson.VerifyMessageCmd) (out *bool, e error) {
-	RPCHandlers["verifymessage"].Call <- API{a.Ch, cmd, nil}
+// GetScrubInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetScrubInfoWait(cmd *None) (out *btcjson.GetScrubInfoResult, e error) {
+	RPCHandlers["getscrubinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan VerifyMessageRes):
+	case o := <- a.Ch.(chan GetScrubInfoRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// WalletIsLocked calls the method with the given parameters
-func (a API) WalletIsLocked(cmd *None) (e error) {
-	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
+// GetSpendAuth calls the method with the given parameters
+func (a API) GetSpendAuth(cmd *None) (e error) {
+	RPCHandlers["getspendauth"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// WalletIsLockedCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetSpendAuthCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) WalletIsLockedCheck() (isNew bool) {
+func (a API) GetSpendAuthCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan WalletIsLockedRes):
+	case o := <- a.Ch.(chan GetSpendAuthRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -1979,36 +2279,36 @@ func (a API) WalletIsLockedCheck() (isNew bool) {
 	return
 }
 
-// WalletIsLockedGetRes returns a pointer to the value in the Result field
-func (a API) WalletIsLockedGetRes() (out *bool, e error) {
-	out, _ = a.Result.(*bool)
+// GetSpendAuthGetRes returns a pointer to the value in the Result field
+func (a API) GetSpendAuthGetRes() (out *btcjson.SpendAuthResult, e error) {
+	out, _ = a.Result.(*btcjson.SpendAuthResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// WalletIsLockedWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) WalletIsLockedWait(cmd *None) (out *bool, e error) {
-	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
+// GetSpendAuthWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetSpendAuthWait(cmd *None) (out *btcjson.SpendAuthResult, e error) {
+	RPCHandlers["getspendauth"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan WalletIsLockedRes):
+	case o := <- a.Ch.(chan GetSpendAuthRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// WalletLock calls the method with the given parameters
-func (a API) WalletLock(cmd *None) (e error) {
-	RPCHandlers["walletlock"].Call <- API{a.Ch, cmd, nil}
+// GetTransaction calls the method with the given parameters
+func (a API) GetTransaction(cmd *btcjson.GetTransactionCmd) (e error) {
+	RPCHandlers["gettransaction"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// WalletLockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetTransactionCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) WalletLockCheck() (isNew bool) {
+func (a API) GetTransactionCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan WalletLockRes):
+	case o := <- a.Ch.(chan GetTransactionRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -2020,36 +2320,36 @@ func (a API) WalletLockCheck() (isNew bool) {
 	return
 }
 
-// WalletLockGetRes returns a pointer to the value in the Result field
-func (a API) WalletLockGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetTransactionGetRes returns a pointer to the value in the Result field
+func (a API) GetTransactionGetRes() (out *btcjson.GetTransactionResult, e error) {
+	out, _ = a.Result.(*btcjson.GetTransactionResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// WalletLockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) WalletLockWait(cmd *None) (out *None, e error) {
-	RPCHandlers["walletlock"].Call <- API{a.Ch, cmd, nil}
+// GetTransactionWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTransactionWait(cmd *btcjson.GetTransactionCmd) (out *btcjson.GetTransactionResult, e error) {
+	RPCHandlers["gettransaction"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan WalletLockRes):
+	case o := <- a.Ch.(chan GetTransactionRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// WalletPassphrase calls the method with the given parameters
-func (a API) WalletPassphrase(cmd *btcjson.WalletPassphraseCmd) (e error) {
-	RPCHandlers["walletpassphrase"].Call <- API{a.Ch, cmd, nil}
+// GetUnconfirmedBalance calls the method with the given parameters
+func (a API) GetUnconfirmedBalance(cmd *btcjson.GetUnconfirmedBalanceCmd) (e error) {
+	RPCHandlers["getunconfirmedbalance"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// WalletPassphraseCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetUnconfirmedBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) WalletPassphraseCheck() (isNew bool) {
+func (a API) GetUnconfirmedBalanceCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan WalletPassphraseRes):
+	case o := <- a.Ch.(chan GetUnconfirmedBalanceRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -2061,36 +2361,36 @@ func (a API) WalletPassphraseCheck() (isNew bool) {
 	return
 }
 
-// WalletPassphraseGetRes returns a pointer to the value in the Result field
-func (a API) WalletPassphraseGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetUnconfirmedBalanceGetRes returns a pointer to the value in the Result field
+func (a API) GetUnconfirmedBalanceGetRes() (out *float64, e error) {
+	out, _ = a.Result.(*float64)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// WalletPassphraseWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) WalletPassphraseWait(cmd *btcjson.WalletPassphraseCmd) (out *None, e error) {
-	RPCHandlers["walletpassphrase"].Call <- API{a.Ch, cmd, nil}
+// GetUnconfirmedBalanceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetUnconfirmedBalanceWait(cmd *btcjson.GetUnconfirmedBalanceCmd) (out *float64, e error) {
+	RPCHandlers["getunconfirmedbalance"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan WalletPassphraseRes):
+	case o := <- a.Ch.(chan GetUnconfirmedBalanceRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
-// WalletPassphraseChange calls the method with the given parameters
-func (a API) WalletPassphraseChange(cmd *btcjson.WalletPassphraseChangeCmd) (e error) {
-	RPCHandlers["walletpassphrasechange"].Call <- API{a.Ch, cmd, nil}
+// GetUTXOReport calls the method with the given parameters
+func (a API) GetUTXOReport(cmd *btcjson.GetUTXOReportCmd) (e error) {
+	RPCHandlers["getutxoreport"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// WalletPassphraseChangeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetUTXOReportCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) WalletPassphraseChangeCheck() (isNew bool) {
+func (a API) GetUTXOReportCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan WalletPassphraseChangeRes):
+	case o := <- a.Ch.(chan GetUTXOReportRes):
 		if o.e != nil {
 			a.Result = o.e
 		} else {
@@ -2102,530 +2402,4294 @@ func (a API) WalletPassphraseChangeCheck() (isNew bool) {
 	return
 }
 
-// WalletPassphraseChangeGetRes returns a pointer to the value in the Result field
-func (a API) WalletPassphraseChangeGetRes() (out *None, e error) {
+// GetUTXOReportGetRes returns a pointer to the value in the Result field
+func (a API) GetUTXOReportGetRes() (out *btcjson.GetUTXOReportResult, e error) {
+	out, _ = a.Result.(*btcjson.GetUTXOReportResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetUTXOReportWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetUTXOReportWait(cmd *btcjson.GetUTXOReportCmd) (out *btcjson.GetUTXOReportResult, e error) {
+	RPCHandlers["getutxoreport"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan GetUTXOReportRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// GetVaultSchedule calls the method with the given parameters
+func (a API) GetVaultSchedule(cmd *btcjson.GetVaultScheduleCmd) (e error) {
+	RPCHandlers["getvaultschedule"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetVaultScheduleCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetVaultScheduleCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan GetVaultScheduleRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetVaultScheduleGetRes returns a pointer to the value in the Result field
+func (a API) GetVaultScheduleGetRes() (out *btcjson.VaultScheduleResult, e error) {
+	out, _ = a.Result.(*btcjson.VaultScheduleResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetVaultScheduleWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetVaultScheduleWait(cmd *btcjson.GetVaultScheduleCmd) (out *btcjson.VaultScheduleResult, e error) {
+	RPCHandlers["getvaultschedule"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan GetVaultScheduleRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// HelpNoChainRPC calls the method with the given parameters
+func (a API) HelpNoChainRPC(cmd btcjson.HelpCmd) (e error) {
+	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// HelpNoChainRPCCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) HelpNoChainRPCCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan HelpNoChainRPCRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// HelpNoChainRPCGetRes returns a pointer to the value in the Result field
+func (a API) HelpNoChainRPCGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// HelpNoChainRPCWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HelpNoChainRPCWait(cmd btcjson.HelpCmd) (out *string, e error) {
+	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan HelpNoChainRPCRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ImportCoreWallet calls the method with the given parameters
+func (a API) ImportCoreWallet(cmd *btcjson.ImportCoreWalletCmd) (e error) {
+	RPCHandlers["importcorewallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportCoreWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportCoreWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportCoreWalletRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportCoreWalletGetRes returns a pointer to the value in the Result field
+func (a API) ImportCoreWalletGetRes() (out *btcjson.ImportCoreWalletResult, e error) {
+	out, _ = a.Result.(*btcjson.ImportCoreWalletResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ImportCoreWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportCoreWalletWait(cmd *btcjson.ImportCoreWalletCmd) (out *btcjson.ImportCoreWalletResult, e error) {
+	RPCHandlers["importcorewallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ImportCoreWalletRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ImportPrivKey calls the method with the given parameters
+func (a API) ImportPrivKey(cmd *btcjson.ImportPrivKeyCmd) (e error) {
+	RPCHandlers["importprivkey"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportPrivKeyCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportPrivKeyRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportPrivKeyGetRes returns a pointer to the value in the Result field
+func (a API) ImportPrivKeyGetRes() (out *None, e error) {
 	out, _ = a.Result.(*None)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// WalletPassphraseChangeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) WalletPassphraseChangeWait(cmd *btcjson.WalletPassphraseChangeCmd) (out *None, e error) {
-	RPCHandlers["walletpassphrasechange"].Call <- API{a.Ch, cmd, nil}
+// ImportPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportPrivKeyWait(cmd *btcjson.ImportPrivKeyCmd) (out *None, e error) {
+	RPCHandlers["importprivkey"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan WalletPassphraseChangeRes):
+	case o := <- a.Ch.(chan ImportPrivKeyRes):
 		out, e = o.Res, o.e
 	}
 	return
 }
 
+// ImportScriptPubKey calls the method with the given parameters
+func (a API) ImportScriptPubKey(cmd *btcjson.ImportScriptPubKeyCmd) (e error) {
+	RPCHandlers["importscriptpubkey"].Call <- API{a.Ch, cmd, nil}
+	return
+}
 
-// RunAPI starts up the api handler server that receives rpc.API messages and runs the handler and returns the result
-// Note that the parameters are type asserted to prevent the consumer of the API from sending wrong message types not
-// because it's necessary since they are interfaces end to end
-func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet, 
-	quit qu.C) {
-	nrh := RPCHandlers
-	go func() {
-		D.Ln("starting up wallet cAPI")
-		var e error
-		var res interface{}
-		for {
-			select { 
-			case msg := <-nrh["addmultisigaddress"].Call:
-				if res, e = nrh["addmultisigaddress"].
-					Handler(msg.Params.(*btcjson.AddMultisigAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan AddMultiSigAddressRes) <- AddMultiSigAddressRes{&r, e} } 
-			case msg := <-nrh["createmultisig"].Call:
-				if res, e = nrh["createmultisig"].
-					Handler(msg.Params.(*btcjson.CreateMultisigCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.CreateMultiSigResult); ok { 
-					msg.Ch.(chan CreateMultiSigRes) <- CreateMultiSigRes{&r, e} } 
-			case msg := <-nrh["createnewaccount"].Call:
-				if res, e = nrh["createnewaccount"].
-					Handler(msg.Params.(*btcjson.CreateNewAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan CreateNewAccountRes) <- CreateNewAccountRes{&r, e} } 
-			case msg := <-nrh["dropwallethistory"].Call:
-				if res, e = nrh["dropwallethistory"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan HandleDropWalletHistoryRes) <- HandleDropWalletHistoryRes{&r, e} } 
-			case msg := <-nrh["dumpprivkey"].Call:
-				if res, e = nrh["dumpprivkey"].
-					Handler(msg.Params.(*btcjson.DumpPrivKeyCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan DumpPrivKeyRes) <- DumpPrivKeyRes{&r, e} } 
-			case msg := <-nrh["getaccount"].Call:
-				if res, e = nrh["getaccount"].
-					Handler(msg.Params.(*btcjson.GetAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan GetAccountRes) <- GetAccountRes{&r, e} } 
-			case msg := <-nrh["getaccountaddress"].Call:
-				if res, e = nrh["getaccountaddress"].
-					Handler(msg.Params.(*btcjson.GetAccountAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan GetAccountAddressRes) <- GetAccountAddressRes{&r, e} } 
-			case msg := <-nrh["getaddressesbyaccount"].Call:
-				if res, e = nrh["getaddressesbyaccount"].
-					Handler(msg.Params.(*btcjson.GetAddressesByAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]string); ok { 
-					msg.Ch.(chan GetAddressesByAccountRes) <- GetAddressesByAccountRes{&r, e} } 
-			case msg := <-nrh["getbalance"].Call:
-				if res, e = nrh["getbalance"].
-					Handler(msg.Params.(*btcjson.GetBalanceCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(float64); ok { 
-					msg.Ch.(chan GetBalanceRes) <- GetBalanceRes{&r, e} } 
-			case msg := <-nrh["getbestblock"].Call:
-				if res, e = nrh["getbestblock"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.GetBestBlockResult); ok { 
-					msg.Ch.(chan GetBestBlockRes) <- GetBestBlockRes{&r, e} } 
-			case msg := <-nrh["getbestblockhash"].Call:
-				if res, e = nrh["getbestblockhash"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan GetBestBlockHashRes) <- GetBestBlockHashRes{&r, e} } 
-			case msg := <-nrh["getblockcount"].Call:
-				if res, e = nrh["getblockcount"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(int32); ok { 
-					msg.Ch.(chan GetBlockCountRes) <- GetBlockCountRes{&r, e} } 
-			case msg := <-nrh["getinfo"].Call:
-				if res, e = nrh["getinfo"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.InfoWalletResult); ok { 
-					msg.Ch.(chan GetInfoRes) <- GetInfoRes{&r, e} } 
-			case msg := <-nrh["getnewaddress"].Call:
-				if res, e = nrh["getnewaddress"].
-					Handler(msg.Params.(*btcjson.GetNewAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan GetNewAddressRes) <- GetNewAddressRes{&r, e} } 
-			case msg := <-nrh["getrawchangeaddress"].Call:
-				if res, e = nrh["getrawchangeaddress"].
-					Handler(msg.Params.(*btcjson.GetRawChangeAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan GetRawChangeAddressRes) <- GetRawChangeAddressRes{&r, e} } 
-			case msg := <-nrh["getreceivedbyaccount"].Call:
-				if res, e = nrh["getreceivedbyaccount"].
-					Handler(msg.Params.(*btcjson.GetReceivedByAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(float64); ok { 
-					msg.Ch.(chan GetReceivedByAccountRes) <- GetReceivedByAccountRes{&r, e} } 
-			case msg := <-nrh["getreceivedbyaddress"].Call:
-				if res, e = nrh["getreceivedbyaddress"].
-					Handler(msg.Params.(*btcjson.GetReceivedByAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(float64); ok { 
-					msg.Ch.(chan GetReceivedByAddressRes) <- GetReceivedByAddressRes{&r, e} } 
-			case msg := <-nrh["gettransaction"].Call:
-				if res, e = nrh["gettransaction"].
-					Handler(msg.Params.(*btcjson.GetTransactionCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.GetTransactionResult); ok { 
-					msg.Ch.(chan GetTransactionRes) <- GetTransactionRes{&r, e} } 
-			case msg := <-nrh["getunconfirmedbalance"].Call:
-				if res, e = nrh["getunconfirmedbalance"].
-					Handler(msg.Params.(*btcjson.GetUnconfirmedBalanceCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(float64); ok { 
-					msg.Ch.(chan GetUnconfirmedBalanceRes) <- GetUnconfirmedBalanceRes{&r, e} } 
-			case msg := <-nrh["help"].Call:
-				if res, e = nrh["help"].
-					Handler(msg.Params.(btcjson.HelpCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan HelpNoChainRPCRes) <- HelpNoChainRPCRes{&r, e} } 
-			case msg := <-nrh["importprivkey"].Call:
-				if res, e = nrh["importprivkey"].
-					Handler(msg.Params.(*btcjson.ImportPrivKeyCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan ImportPrivKeyRes) <- ImportPrivKeyRes{&r, e} } 
-			case msg := <-nrh["keypoolrefill"].Call:
-				if res, e = nrh["keypoolrefill"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan KeypoolRefillRes) <- KeypoolRefillRes{&r, e} } 
-			case msg := <-nrh["listaccounts"].Call:
-				if res, e = nrh["listaccounts"].
-					Handler(msg.Params.(*btcjson.ListAccountsCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(map[string]float64); ok { 
-					msg.Ch.(chan ListAccountsRes) <- ListAccountsRes{&r, e} } 
-			case msg := <-nrh["listaddresstransactions"].Call:
-				if res, e = nrh["listaddresstransactions"].
-					Handler(msg.Params.(*btcjson.ListAddressTransactionsCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
-					msg.Ch.(chan ListAddressTransactionsRes) <- ListAddressTransactionsRes{&r, e} } 
-			case msg := <-nrh["listalltransactions"].Call:
-				if res, e = nrh["listalltransactions"].
-					Handler(msg.Params.(*btcjson.ListAllTransactionsCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
-					msg.Ch.(chan ListAllTransactionsRes) <- ListAllTransactionsRes{&r, e} } 
-			case msg := <-nrh["listlockunspent"].Call:
-				if res, e = nrh["listlockunspent"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.TransactionInput); ok { 
-					msg.Ch.(chan ListLockUnspentRes) <- ListLockUnspentRes{&r, e} } 
-			case msg := <-nrh["listreceivedbyaccount"].Call:
-				if res, e = nrh["listreceivedbyaccount"].
-					Handler(msg.Params.(*btcjson.ListReceivedByAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.ListReceivedByAccountResult); ok { 
-					msg.Ch.(chan ListReceivedByAccountRes) <- ListReceivedByAccountRes{&r, e} } 
-			case msg := <-nrh["listreceivedbyaddress"].Call:
-				if res, e = nrh["listreceivedbyaddress"].
-					Handler(msg.Params.(*btcjson.ListReceivedByAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.ListReceivedByAddressResult); ok { 
-					msg.Ch.(chan ListReceivedByAddressRes) <- ListReceivedByAddressRes{&r, e} } 
-			case msg := <-nrh["listsinceblock"].Call:
-				if res, e = nrh["listsinceblock"].
-					Handler(msg.Params.(btcjson.ListSinceBlockCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.ListSinceBlockResult); ok { 
-					msg.Ch.(chan ListSinceBlockRes) <- ListSinceBlockRes{&r, e} } 
-			case msg := <-nrh["listtransactions"].Call:
-				if res, e = nrh["listtransactions"].
-					Handler(msg.Params.(*btcjson.ListTransactionsCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
-					msg.Ch.(chan ListTransactionsRes) <- ListTransactionsRes{&r, e} } 
-			case msg := <-nrh["listunspent"].Call:
-				if res, e = nrh["listunspent"].
-					Handler(msg.Params.(*btcjson.ListUnspentCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.([]btcjson.ListUnspentResult); ok { 
-					msg.Ch.(chan ListUnspentRes) <- ListUnspentRes{&r, e} } 
-			case msg := <-nrh["renameaccount"].Call:
-				if res, e = nrh["renameaccount"].
-					Handler(msg.Params.(*btcjson.RenameAccountCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan RenameAccountRes) <- RenameAccountRes{&r, e} } 
-			case msg := <-nrh["sendfrom"].Call:
-				if res, e = nrh["sendfrom"].
-					Handler(msg.Params.(btcjson.LockUnspentCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(bool); ok { 
-					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, e} } 
-			case msg := <-nrh["sendmany"].Call:
-				if res, e = nrh["sendmany"].
-					Handler(msg.Params.(*btcjson.SendManyCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan SendManyRes) <- SendManyRes{&r, e} } 
-			case msg := <-nrh["sendtoaddress"].Call:
-				if res, e = nrh["sendtoaddress"].
-					Handler(msg.Params.(*btcjson.SendToAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan SendToAddressRes) <- SendToAddressRes{&r, e} } 
-			case msg := <-nrh["settxfee"].Call:
-				if res, e = nrh["settxfee"].
-					Handler(msg.Params.(*btcjson.SetTxFeeCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(bool); ok { 
-					msg.Ch.(chan SetTxFeeRes) <- SetTxFeeRes{&r, e} } 
-			case msg := <-nrh["signmessage"].Call:
-				if res, e = nrh["signmessage"].
-					Handler(msg.Params.(*btcjson.SignMessageCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(string); ok { 
-					msg.Ch.(chan SignMessageRes) <- SignMessageRes{&r, e} } 
-			case msg := <-nrh["signrawtransaction"].Call:
-				if res, e = nrh["signrawtransaction"].
-					Handler(msg.Params.(btcjson.SignRawTransactionCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.SignRawTransactionResult); ok { 
-					msg.Ch.(chan SignRawTransactionRes) <- SignRawTransactionRes{&r, e} } 
-			case msg := <-nrh["validateaddress"].Call:
-				if res, e = nrh["validateaddress"].
-					Handler(msg.Params.(*btcjson.ValidateAddressCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(btcjson.ValidateAddressWalletResult); ok { 
-					msg.Ch.(chan ValidateAddressRes) <- ValidateAddressRes{&r, e} } 
-			case msg := <-nrh["verifymessage"].Call:
-				if res, e = nrh["verifymessage"].
-					Handler(msg.Params.(*btcjson.VerifyMessageCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(bool); ok { 
-					msg.Ch.(chan VerifyMessageRes) <- VerifyMessageRes{&r, e} } 
-			case msg := <-nrh["walletislocked"].Call:
-				if res, e = nrh["walletislocked"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(bool); ok { 
-					msg.Ch.(chan WalletIsLockedRes) <- WalletIsLockedRes{&r, e} } 
-			case msg := <-nrh["walletlock"].Call:
-				if res, e = nrh["walletlock"].
-					Handler(msg.Params.(*None), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan WalletLockRes) <- WalletLockRes{&r, e} } 
-			case msg := <-nrh["walletpassphrase"].Call:
-				if res, e = nrh["walletpassphrase"].
-					Handler(msg.Params.(*btcjson.WalletPassphraseCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan WalletPassphraseRes) <- WalletPassphraseRes{&r, e} } 
-			case msg := <-nrh["walletpassphrasechange"].Call:
-				if res, e = nrh["walletpassphrasechange"].
-					Handler(msg.Params.(*btcjson.WalletPassphraseChangeCmd), wallet, 
-						chainRPC); E.Chk(e) {
-				}
-				if r, ok := res.(None); ok { 
-					msg.Ch.(chan WalletPassphraseChangeRes) <- WalletPassphraseChangeRes{&r, e} } 
-			case <-quit.Wait():
-				D.Ln("stopping wallet cAPI")
-				return
-			}
+// ImportScriptPubKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportScriptPubKeyCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportScriptPubKeyRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
 		}
-	}()
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportScriptPubKeyGetRes returns a pointer to the value in the Result field
+func (a API) ImportScriptPubKeyGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ImportScriptPubKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportScriptPubKeyWait(cmd *btcjson.ImportScriptPubKeyCmd) (out *None, e error) {
+	RPCHandlers["importscriptpubkey"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ImportScriptPubKeyRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ImportTimeLockScript calls the method with the given parameters
+func (a API) ImportTimeLockScript(cmd *btcjson.ImportTimeLockScriptCmd) (e error) {
+	RPCHandlers["importtimelockscript"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportTimeLockScriptCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportTimeLockScriptCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportTimeLockScriptRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportTimeLockScriptGetRes returns a pointer to the value in the Result field
+func (a API) ImportTimeLockScriptGetRes() (out *btcjson.TimeLockScriptResult, e error) {
+	out, _ = a.Result.(*btcjson.TimeLockScriptResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ImportTimeLockScriptWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportTimeLockScriptWait(cmd *btcjson.ImportTimeLockScriptCmd) (out *btcjson.TimeLockScriptResult, e error) {
+	RPCHandlers["importtimelockscript"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ImportTimeLockScriptRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// KeypoolRefill calls the method with the given parameters
+func (a API) KeypoolRefill(cmd *None) (e error) {
+	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// KeypoolRefillCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) KeypoolRefillCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan KeypoolRefillRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// KeypoolRefillGetRes returns a pointer to the value in the Result field
+func (a API) KeypoolRefillGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// KeypoolRefillWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) KeypoolRefillWait(cmd *None) (out *None, e error) {
+	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan KeypoolRefillRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListAccounts calls the method with the given parameters
+func (a API) ListAccounts(cmd *btcjson.ListAccountsCmd) (e error) {
+	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAccountsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAccountsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAccountsGetRes returns a pointer to the value in the Result field
+func (a API) ListAccountsGetRes() (out *map[string]float64, e error) {
+	out, _ = a.Result.(*map[string]float64)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAccountsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]float64, e error) {
+	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAccountsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListAddressMeta calls the method with the given parameters
+func (a API) ListAddressMeta(cmd *btcjson.ListAddressMetaCmd) (e error) {
+	RPCHandlers["listaddressmeta"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressMetaCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressMetaCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAddressMetaRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressMetaGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressMetaGetRes() (out *[]btcjson.AddressMetaResult, e error) {
+	out, _ = a.Result.(*[]btcjson.AddressMetaResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAddressMetaWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressMetaWait(cmd *btcjson.ListAddressMetaCmd) (out *[]btcjson.AddressMetaResult, e error) {
+	RPCHandlers["listaddressmeta"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAddressMetaRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListAddressTransactions calls the method with the given parameters
+func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (e error) {
+	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressTransactionsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAddressTransactionsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
+	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAddressTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressTransactionsWait(cmd *btcjson.ListAddressTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
+	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAddressTransactionsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListAllTransactions calls the method with the given parameters
+func (a API) ListAllTransactions(cmd *btcjson.ListAllTransactionsCmd) (e error) {
+	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAllTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAllTransactionsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAllTransactionsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAllTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListAllTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
+	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAllTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAllTransactionsWait(cmd *btcjson.ListAllTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
+	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAllTransactionsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListDustOutputs calls the method with the given parameters
+func (a API) ListDustOutputs(cmd *None) (e error) {
+	RPCHandlers["listdustoutputs"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListDustOutputsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListDustOutputsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListDustOutputsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListDustOutputsGetRes returns a pointer to the value in the Result field
+func (a API) ListDustOutputsGetRes() (out *[]btcjson.DustOutputResult, e error) {
+	out, _ = a.Result.(*[]btcjson.DustOutputResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListDustOutputsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListDustOutputsWait(cmd *None) (out *[]btcjson.DustOutputResult, e error) {
+	RPCHandlers["listdustoutputs"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListDustOutputsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListFrozen calls the method with the given parameters
+func (a API) ListFrozen(cmd *None) (e error) {
+	RPCHandlers["listfrozen"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListFrozenCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListFrozenCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListFrozenRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListFrozenGetRes returns a pointer to the value in the Result field
+func (a API) ListFrozenGetRes() (out *[]btcjson.FrozenOutputResult, e error) {
+	out, _ = a.Result.(*[]btcjson.FrozenOutputResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListFrozenWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListFrozenWait(cmd *None) (out *[]btcjson.FrozenOutputResult, e error) {
+	RPCHandlers["listfrozen"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListFrozenRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListImmature calls the method with the given parameters
+func (a API) ListImmature(cmd *btcjson.ListImmatureCmd) (e error) {
+	RPCHandlers["listimmature"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListImmatureCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListImmatureCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListImmatureRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListImmatureGetRes returns a pointer to the value in the Result field
+func (a API) ListImmatureGetRes() (out *btcjson.ListImmatureResult, e error) {
+	out, _ = a.Result.(*btcjson.ListImmatureResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListImmatureWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListImmatureWait(cmd *btcjson.ListImmatureCmd) (out *btcjson.ListImmatureResult, e error) {
+	RPCHandlers["listimmature"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListImmatureRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListInvoiceReservations calls the method with the given parameters
+func (a API) ListInvoiceReservations(cmd *btcjson.ListInvoiceReservationsCmd) (e error) {
+	RPCHandlers["listinvoicereservations"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListInvoiceReservationsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListInvoiceReservationsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListInvoiceReservationsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListInvoiceReservationsGetRes returns a pointer to the value in the Result field
+func (a API) ListInvoiceReservationsGetRes() (out *[]btcjson.InvoiceReservationResult, e error) {
+	out, _ = a.Result.(*[]btcjson.InvoiceReservationResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListInvoiceReservationsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListInvoiceReservationsWait(cmd *btcjson.ListInvoiceReservationsCmd) (out *[]btcjson.InvoiceReservationResult, e error) {
+	RPCHandlers["listinvoicereservations"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListInvoiceReservationsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListInvoices calls the method with the given parameters
+func (a API) ListInvoices(cmd *btcjson.ListInvoicesCmd) (e error) {
+	RPCHandlers["listinvoices"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListInvoicesCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListInvoicesCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListInvoicesRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListInvoicesGetRes returns a pointer to the value in the Result field
+func (a API) ListInvoicesGetRes() (out *[]btcjson.InvoiceResult, e error) {
+	out, _ = a.Result.(*[]btcjson.InvoiceResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListInvoicesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListInvoicesWait(cmd *btcjson.ListInvoicesCmd) (out *[]btcjson.InvoiceResult, e error) {
+	RPCHandlers["listinvoices"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListInvoicesRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListLockUnspent calls the method with the given parameters
+func (a API) ListLockUnspent(cmd *None) (e error) {
+	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListLockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListLockUnspentCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListLockUnspentRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListLockUnspentGetRes returns a pointer to the value in the Result field
+func (a API) ListLockUnspentGetRes() (out *[]btcjson.TransactionInput, e error) {
+	out, _ = a.Result.(*[]btcjson.TransactionInput)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListLockUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListLockUnspentWait(cmd *None) (out *[]btcjson.TransactionInput, e error) {
+	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListLockUnspentRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListMultiSigAccounts calls the method with the given parameters
+func (a API) ListMultiSigAccounts(cmd *btcjson.ListMultiSigAccountsCmd) (e error) {
+	RPCHandlers["listmultisigaccounts"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListMultiSigAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListMultiSigAccountsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListMultiSigAccountsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListMultiSigAccountsGetRes returns a pointer to the value in the Result field
+func (a API) ListMultiSigAccountsGetRes() (out *[]btcjson.MultiSigAccountResult, e error) {
+	out, _ = a.Result.(*[]btcjson.MultiSigAccountResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListMultiSigAccountsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListMultiSigAccountsWait(cmd *btcjson.ListMultiSigAccountsCmd) (out *[]btcjson.MultiSigAccountResult, e error) {
+	RPCHandlers["listmultisigaccounts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListMultiSigAccountsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListPaymentBundles calls the method with the given parameters
+func (a API) ListPaymentBundles(cmd *btcjson.ListPaymentBundlesCmd) (e error) {
+	RPCHandlers["listpaymentbundles"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListPaymentBundlesCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListPaymentBundlesCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListPaymentBundlesRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListPaymentBundlesGetRes returns a pointer to the value in the Result field
+func (a API) ListPaymentBundlesGetRes() (out *[]btcjson.PaymentBundleResult, e error) {
+	out, _ = a.Result.(*[]btcjson.PaymentBundleResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListPaymentBundlesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListPaymentBundlesWait(cmd *btcjson.ListPaymentBundlesCmd) (out *[]btcjson.PaymentBundleResult, e error) {
+	RPCHandlers["listpaymentbundles"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListPaymentBundlesRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListPortfolio calls the method with the given parameters
+func (a API) ListPortfolio(cmd *btcjson.ListPortfolioCmd) (e error) {
+	RPCHandlers["listportfolio"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListPortfolioCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListPortfolioCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListPortfolioRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListPortfolioGetRes returns a pointer to the value in the Result field
+func (a API) ListPortfolioGetRes() (out *btcjson.ListPortfolioResult, e error) {
+	out, _ = a.Result.(*btcjson.ListPortfolioResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListPortfolioWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListPortfolioWait(cmd *btcjson.ListPortfolioCmd) (out *btcjson.ListPortfolioResult, e error) {
+	RPCHandlers["listportfolio"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListPortfolioRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListPortfolioTransactions calls the method with the given parameters
+func (a API) ListPortfolioTransactions(cmd *btcjson.ListPortfolioTransactionsCmd) (e error) {
+	RPCHandlers["listportfoliotransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListPortfolioTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListPortfolioTransactionsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListPortfolioTransactionsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListPortfolioTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListPortfolioTransactionsGetRes() (out *[]btcjson.PortfolioTransactionResult, e error) {
+	out, _ = a.Result.(*[]btcjson.PortfolioTransactionResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListPortfolioTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListPortfolioTransactionsWait(cmd *btcjson.ListPortfolioTransactionsCmd) (out *[]btcjson.PortfolioTransactionResult, e error) {
+	RPCHandlers["listportfoliotransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListPortfolioTransactionsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListQueuedPSBTs calls the method with the given parameters
+func (a API) ListQueuedPSBTs(cmd *None) (e error) {
+	RPCHandlers["listqueuedpsbts"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListQueuedPSBTsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListQueuedPSBTsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListQueuedPSBTsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListQueuedPSBTsGetRes returns a pointer to the value in the Result field
+func (a API) ListQueuedPSBTsGetRes() (out *[]btcjson.QueuedPSBTResult, e error) {
+	out, _ = a.Result.(*[]btcjson.QueuedPSBTResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListQueuedPSBTsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListQueuedPSBTsWait(cmd *None) (out *[]btcjson.QueuedPSBTResult, e error) {
+	RPCHandlers["listqueuedpsbts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListQueuedPSBTsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListReceivedByAccount calls the method with the given parameters
+func (a API) ListReceivedByAccount(cmd *btcjson.ListReceivedByAccountCmd) (e error) {
+	RPCHandlers["listreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListReceivedByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListReceivedByAccountCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListReceivedByAccountRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListReceivedByAccountGetRes returns a pointer to the value in the Result field
+func (a API) ListReceivedByAccountGetRes() (out *[]btcjson.ListReceivedByAccountResult, e error) {
+	out, _ = a.Result.(*[]btcjson.ListReceivedByAccountResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListReceivedByAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListReceivedByAccountWait(cmd *btcjson.ListReceivedByAccountCmd) (out *[]btcjson.ListReceivedByAccountResult, e error) {
+	RPCHandlers["listreceivedbyaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListReceivedByAccountRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListReceivedByAddress calls the method with the given parameters
+func (a API) ListReceivedByAddress(cmd *btcjson.ListReceivedByAddressCmd) (e error) {
+	RPCHandlers["listreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListReceivedByAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListReceivedByAddressCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListReceivedByAddressRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListReceivedByAddressGetRes returns a pointer to the value in the Result field
+func (a API) ListReceivedByAddressGetRes() (out *btcjson.ListReceivedByAddressResult, e error) {
+	out, _ = a.Result.(*btcjson.ListReceivedByAddressResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListReceivedByAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListReceivedByAddressWait(cmd *btcjson.ListReceivedByAddressCmd) (out *btcjson.ListReceivedByAddressResult, e error) {
+	RPCHandlers["listreceivedbyaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListReceivedByAddressRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListSinceBlock calls the method with the given parameters
+func (a API) ListSinceBlock(cmd btcjson.ListSinceBlockCmd) (e error) {
+	RPCHandlers["listsinceblock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListSinceBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListSinceBlockCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListSinceBlockRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListSinceBlockGetRes returns a pointer to the value in the Result field
+func (a API) ListSinceBlockGetRes() (out *btcjson.ListSinceBlockResult, e error) {
+	out, _ = a.Result.(*btcjson.ListSinceBlockResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListSinceBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListSinceBlockWait(cmd btcjson.ListSinceBlockCmd) (out *btcjson.ListSinceBlockResult, e error) {
+	RPCHandlers["listsinceblock"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListSinceBlockRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListTransactions calls the method with the given parameters
+func (a API) ListTransactions(cmd *btcjson.ListTransactionsCmd) (e error) {
+	RPCHandlers["listtransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListTransactionsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListTransactionsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, e error) {
+	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListTransactionsWait(cmd *btcjson.ListTransactionsCmd) (out *[]btcjson.ListTransactionsResult, e error) {
+	RPCHandlers["listtransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListTransactionsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListTransactionsPage calls the method with the given parameters
+func (a API) ListTransactionsPage(cmd *btcjson.ListTransactionsPageCmd) (e error) {
+	RPCHandlers["listtransactionspage"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListTransactionsPageCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListTransactionsPageCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListTransactionsPageRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListTransactionsPageGetRes returns a pointer to the value in the Result field
+func (a API) ListTransactionsPageGetRes() (out *btcjson.ListTransactionsPageResult, e error) {
+	out, _ = a.Result.(*btcjson.ListTransactionsPageResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListTransactionsPageWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListTransactionsPageWait(cmd *btcjson.ListTransactionsPageCmd) (out *btcjson.ListTransactionsPageResult, e error) {
+	RPCHandlers["listtransactionspage"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListTransactionsPageRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListUnlockAttempts calls the method with the given parameters
+func (a API) ListUnlockAttempts(cmd *btcjson.ListUnlockAttemptsCmd) (e error) {
+	RPCHandlers["listunlockattempts"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListUnlockAttemptsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListUnlockAttemptsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListUnlockAttemptsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListUnlockAttemptsGetRes returns a pointer to the value in the Result field
+func (a API) ListUnlockAttemptsGetRes() (out *[]btcjson.UnlockAttemptResult, e error) {
+	out, _ = a.Result.(*[]btcjson.UnlockAttemptResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListUnlockAttemptsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListUnlockAttemptsWait(cmd *btcjson.ListUnlockAttemptsCmd) (out *[]btcjson.UnlockAttemptResult, e error) {
+	RPCHandlers["listunlockattempts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListUnlockAttemptsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListUnspent calls the method with the given parameters
+func (a API) ListUnspent(cmd *btcjson.ListUnspentCmd) (e error) {
+	RPCHandlers["listunspent"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListUnspentCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListUnspentRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListUnspentGetRes returns a pointer to the value in the Result field
+func (a API) ListUnspentGetRes() (out *[]btcjson.ListUnspentResult, e error) {
+	out, _ = a.Result.(*[]btcjson.ListUnspentResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListUnspentWait(cmd *btcjson.ListUnspentCmd) (out *[]btcjson.ListUnspentResult, e error) {
+	RPCHandlers["listunspent"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListUnspentRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListVaultAccounts calls the method with the given parameters
+func (a API) ListVaultAccounts(cmd *btcjson.ListVaultAccountsCmd) (e error) {
+	RPCHandlers["listvaultaccounts"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListVaultAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListVaultAccountsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListVaultAccountsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListVaultAccountsGetRes returns a pointer to the value in the Result field
+func (a API) ListVaultAccountsGetRes() (out *[]btcjson.VaultAccountResult, e error) {
+	out, _ = a.Result.(*[]btcjson.VaultAccountResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListVaultAccountsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListVaultAccountsWait(cmd *btcjson.ListVaultAccountsCmd) (out *[]btcjson.VaultAccountResult, e error) {
+	RPCHandlers["listvaultaccounts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListVaultAccountsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// OverrideDust calls the method with the given parameters
+func (a API) OverrideDust(cmd *btcjson.OverrideDustCmd) (e error) {
+	RPCHandlers["overridedust"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// OverrideDustCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) OverrideDustCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan OverrideDustRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// OverrideDustGetRes returns a pointer to the value in the Result field
+func (a API) OverrideDustGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// OverrideDustWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) OverrideDustWait(cmd *btcjson.OverrideDustCmd) (out *bool, e error) {
+	RPCHandlers["overridedust"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan OverrideDustRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// PreviewSend calls the method with the given parameters
+func (a API) PreviewSend(cmd *btcjson.PreviewSendCmd) (e error) {
+	RPCHandlers["previewsend"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// PreviewSendCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) PreviewSendCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan PreviewSendRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PreviewSendGetRes returns a pointer to the value in the Result field
+func (a API) PreviewSendGetRes() (out *btcjson.PreviewSendResult, e error) {
+	out, _ = a.Result.(*btcjson.PreviewSendResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// PreviewSendWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PreviewSendWait(cmd *btcjson.PreviewSendCmd) (out *btcjson.PreviewSendResult, e error) {
+	RPCHandlers["previewsend"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan PreviewSendRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ReleaseInvoiceAddress calls the method with the given parameters
+func (a API) ReleaseInvoiceAddress(cmd *btcjson.ReleaseInvoiceAddressCmd) (e error) {
+	RPCHandlers["releaseinvoiceaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ReleaseInvoiceAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ReleaseInvoiceAddressCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ReleaseInvoiceAddressRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ReleaseInvoiceAddressGetRes returns a pointer to the value in the Result field
+func (a API) ReleaseInvoiceAddressGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ReleaseInvoiceAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReleaseInvoiceAddressWait(cmd *btcjson.ReleaseInvoiceAddressCmd) (out *bool, e error) {
+	RPCHandlers["releaseinvoiceaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ReleaseInvoiceAddressRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// RemovePortfolioEntry calls the method with the given parameters
+func (a API) RemovePortfolioEntry(cmd *btcjson.RemovePortfolioEntryCmd) (e error) {
+	RPCHandlers["removeportfolioentry"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// RemovePortfolioEntryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) RemovePortfolioEntryCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan RemovePortfolioEntryRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// RemovePortfolioEntryGetRes returns a pointer to the value in the Result field
+func (a API) RemovePortfolioEntryGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// RemovePortfolioEntryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) RemovePortfolioEntryWait(cmd *btcjson.RemovePortfolioEntryCmd) (out *None, e error) {
+	RPCHandlers["removeportfolioentry"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan RemovePortfolioEntryRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// RenameAccount calls the method with the given parameters
+func (a API) RenameAccount(cmd *btcjson.RenameAccountCmd) (e error) {
+	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// RenameAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) RenameAccountCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan RenameAccountRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// RenameAccountGetRes returns a pointer to the value in the Result field
+func (a API) RenameAccountGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// RenameAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) RenameAccountWait(cmd *btcjson.RenameAccountCmd) (out *None, e error) {
+	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan RenameAccountRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ReserveInvoiceAddress calls the method with the given parameters
+func (a API) ReserveInvoiceAddress(cmd *btcjson.ReserveInvoiceAddressCmd) (e error) {
+	RPCHandlers["reserveinvoiceaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ReserveInvoiceAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ReserveInvoiceAddressCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ReserveInvoiceAddressRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ReserveInvoiceAddressGetRes returns a pointer to the value in the Result field
+func (a API) ReserveInvoiceAddressGetRes() (out *btcjson.InvoiceReservationResult, e error) {
+	out, _ = a.Result.(*btcjson.InvoiceReservationResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ReserveInvoiceAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReserveInvoiceAddressWait(cmd *btcjson.ReserveInvoiceAddressCmd) (out *btcjson.InvoiceReservationResult, e error) {
+	RPCHandlers["reserveinvoiceaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ReserveInvoiceAddressRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// LockUnspent calls the method with the given parameters
+func (a API) LockUnspent(cmd btcjson.LockUnspentCmd) (e error) {
+	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) LockUnspentCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan LockUnspentRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// LockUnspentGetRes returns a pointer to the value in the Result field
+func (a API) LockUnspentGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LockUnspentWait(cmd btcjson.LockUnspentCmd) (out *bool, e error) {
+	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan LockUnspentRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SendMany calls the method with the given parameters
+func (a API) SendMany(cmd *btcjson.SendManyCmd) (e error) {
+	RPCHandlers["sendmany"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SendManyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SendManyCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SendManyRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SendManyGetRes returns a pointer to the value in the Result field
+func (a API) SendManyGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SendManyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SendManyWait(cmd *btcjson.SendManyCmd) (out *string, e error) {
+	RPCHandlers["sendmany"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SendManyRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SendToAddress calls the method with the given parameters
+func (a API) SendToAddress(cmd *btcjson.SendToAddressCmd) (e error) {
+	RPCHandlers["sendtoaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SendToAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SendToAddressCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SendToAddressRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SendToAddressGetRes returns a pointer to the value in the Result field
+func (a API) SendToAddressGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SendToAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SendToAddressWait(cmd *btcjson.SendToAddressCmd) (out *string, e error) {
+	RPCHandlers["sendtoaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SendToAddressRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SetAddressMeta calls the method with the given parameters
+func (a API) SetAddressMeta(cmd *btcjson.SetAddressMetaCmd) (e error) {
+	RPCHandlers["setaddressmeta"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetAddressMetaCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetAddressMetaCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetAddressMetaRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetAddressMetaGetRes returns a pointer to the value in the Result field
+func (a API) SetAddressMetaGetRes() (out *btcjson.AddressMetaResult, e error) {
+	out, _ = a.Result.(*btcjson.AddressMetaResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetAddressMetaWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetAddressMetaWait(cmd *btcjson.SetAddressMetaCmd) (out *btcjson.AddressMetaResult, e error) {
+	RPCHandlers["setaddressmeta"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetAddressMetaRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SetInvoiceIssuance calls the method with the given parameters
+func (a API) SetInvoiceIssuance(cmd *btcjson.SetInvoiceIssuanceCmd) (e error) {
+	RPCHandlers["setinvoiceissuance"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetInvoiceIssuanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetInvoiceIssuanceCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetInvoiceIssuanceRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetInvoiceIssuanceGetRes returns a pointer to the value in the Result field
+func (a API) SetInvoiceIssuanceGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetInvoiceIssuanceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetInvoiceIssuanceWait(cmd *btcjson.SetInvoiceIssuanceCmd) (out *bool, e error) {
+	RPCHandlers["setinvoiceissuance"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetInvoiceIssuanceRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SetSpendAuth calls the method with the given parameters
+func (a API) SetSpendAuth(cmd *btcjson.SetSpendAuthCmd) (e error) {
+	RPCHandlers["setspendauth"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetSpendAuthCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetSpendAuthCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetSpendAuthRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetSpendAuthGetRes returns a pointer to the value in the Result field
+func (a API) SetSpendAuthGetRes() (out *btcjson.SpendAuthResult, e error) {
+	out, _ = a.Result.(*btcjson.SpendAuthResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetSpendAuthWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetSpendAuthWait(cmd *btcjson.SetSpendAuthCmd) (out *btcjson.SpendAuthResult, e error) {
+	RPCHandlers["setspendauth"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetSpendAuthRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SetTxFee calls the method with the given parameters
+func (a API) SetTxFee(cmd *btcjson.SetTxFeeCmd) (e error) {
+	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetTxFeeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetTxFeeCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetTxFeeRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetTxFeeGetRes returns a pointer to the value in the Result field
+func (a API) SetTxFeeGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetTxFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetTxFeeWait(cmd *btcjson.SetTxFeeCmd) (out *bool, e error) {
+	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetTxFeeRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SignMessage calls the method with the given parameters
+func (a API) SignMessage(cmd *btcjson.SignMessageCmd) (e error) {
+	RPCHandlers["signmessage"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SignMessageCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SignMessageCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SignMessageRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SignMessageGetRes returns a pointer to the value in the Result field
+func (a API) SignMessageGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SignMessageWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SignMessageWait(cmd *btcjson.SignMessageCmd) (out *string, e error) {
+	RPCHandlers["signmessage"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SignMessageRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SignRawTransaction calls the method with the given parameters
+func (a API) SignRawTransaction(cmd btcjson.SignRawTransactionCmd) (e error) {
+	RPCHandlers["signrawtransaction"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SignRawTransactionCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SignRawTransactionCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SignRawTransactionRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SignRawTransactionGetRes returns a pointer to the value in the Result field
+func (a API) SignRawTransactionGetRes() (out *btcjson.SignRawTransactionResult, e error) {
+	out, _ = a.Result.(*btcjson.SignRawTransactionResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SignRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SignRawTransactionWait(cmd btcjson.SignRawTransactionCmd) (out *btcjson.SignRawTransactionResult, e error) {
+	RPCHandlers["signrawtransaction"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SignRawTransactionRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SubmitSignedPSBT calls the method with the given parameters
+func (a API) SubmitSignedPSBT(cmd *btcjson.SubmitSignedPSBTCmd) (e error) {
+	RPCHandlers["submitsignedpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SubmitSignedPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SubmitSignedPSBTCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SubmitSignedPSBTRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SubmitSignedPSBTGetRes returns a pointer to the value in the Result field
+func (a API) SubmitSignedPSBTGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SubmitSignedPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SubmitSignedPSBTWait(cmd *btcjson.SubmitSignedPSBTCmd) (out *string, e error) {
+	RPCHandlers["submitsignedpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SubmitSignedPSBTRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SweepAccount calls the method with the given parameters
+func (a API) SweepAccount(cmd *btcjson.SweepAccountCmd) (e error) {
+	RPCHandlers["sweepaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SweepAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SweepAccountCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SweepAccountRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SweepAccountGetRes returns a pointer to the value in the Result field
+func (a API) SweepAccountGetRes() (out *btcjson.SweepAccountResult, e error) {
+	out, _ = a.Result.(*btcjson.SweepAccountResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SweepAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SweepAccountWait(cmd *btcjson.SweepAccountCmd) (out *btcjson.SweepAccountResult, e error) {
+	RPCHandlers["sweepaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SweepAccountRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SweepPrivKey calls the method with the given parameters
+func (a API) SweepPrivKey(cmd *btcjson.SweepPrivKeyCmd) (e error) {
+	RPCHandlers["sweepprivkey"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SweepPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SweepPrivKeyCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SweepPrivKeyRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SweepPrivKeyGetRes returns a pointer to the value in the Result field
+func (a API) SweepPrivKeyGetRes() (out *btcjson.SweepPrivKeyResult, e error) {
+	out, _ = a.Result.(*btcjson.SweepPrivKeyResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SweepPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SweepPrivKeyWait(cmd *btcjson.SweepPrivKeyCmd) (out *btcjson.SweepPrivKeyResult, e error) {
+	RPCHandlers["sweepprivkey"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SweepPrivKeyRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// SweepTimeLocked calls the method with the given parameters
+func (a API) SweepTimeLocked(cmd *btcjson.SweepTimeLockedCmd) (e error) {
+	RPCHandlers["sweeptimelocked"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SweepTimeLockedCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SweepTimeLockedCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SweepTimeLockedRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SweepTimeLockedGetRes returns a pointer to the value in the Result field
+func (a API) SweepTimeLockedGetRes() (out *btcjson.SweepTimeLockedResult, e error) {
+	out, _ = a.Result.(*btcjson.SweepTimeLockedResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SweepTimeLockedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SweepTimeLockedWait(cmd *btcjson.SweepTimeLockedCmd) (out *btcjson.SweepTimeLockedResult, e error) {
+	RPCHandlers["sweeptimelocked"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SweepTimeLockedRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// TransferAccount calls the method with the given parameters
+func (a API) TransferAccount(cmd *btcjson.TransferAccountCmd) (e error) {
+	RPCHandlers["transferaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// TransferAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) TransferAccountCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan TransferAccountRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// TransferAccountGetRes returns a pointer to the value in the Result field
+func (a API) TransferAccountGetRes() (out *btcjson.TransferAccountResult, e error) {
+	out, _ = a.Result.(*btcjson.TransferAccountResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// TransferAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) TransferAccountWait(cmd *btcjson.TransferAccountCmd) (out *btcjson.TransferAccountResult, e error) {
+	RPCHandlers["transferaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan TransferAccountRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// UnarchiveAccount calls the method with the given parameters
+func (a API) UnarchiveAccount(cmd *btcjson.UnarchiveAccountCmd) (e error) {
+	RPCHandlers["unarchiveaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// UnarchiveAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) UnarchiveAccountCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan UnarchiveAccountRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// UnarchiveAccountGetRes returns a pointer to the value in the Result field
+func (a API) UnarchiveAccountGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// UnarchiveAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) UnarchiveAccountWait(cmd *btcjson.UnarchiveAccountCmd) (out *None, e error) {
+	RPCHandlers["unarchiveaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan UnarchiveAccountRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ValidateAddress calls the method with the given parameters
+func (a API) ValidateAddress(cmd *btcjson.ValidateAddressCmd) (e error) {
+	RPCHandlers["validateaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ValidateAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ValidateAddressCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ValidateAddressRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ValidateAddressGetRes returns a pointer to the value in the Result field
+func (a API) ValidateAddressGetRes() (out *btcjson.ValidateAddressWalletResult, e error) {
+	out, _ = a.Result.(*btcjson.ValidateAddressWalletResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ValidateAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ValidateAddressWait(cmd *btcjson.ValidateAddressCmd) (out *btcjson.ValidateAddressWalletResult, e error) {
+	RPCHandlers["validateaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ValidateAddressRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// VerifyMessage calls the method with the given parameters
+func (a API) VerifyMessage(cmd *btcjson.VerifyMessageCmd) (e error) {
+	RPCHandlers["verifymessage"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// VerifyMessageCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) VerifyMessageCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan VerifyMessageRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// VerifyMessageGetRes returns a pointer to the value in the Result field
+func (a API) VerifyMessageGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// VerifyMessageWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) VerifyMessageWait(cmd *btcjson.VerifyMessageCmd) (out *bool, e error) {
+	RPCHandlers["verifymessage"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan VerifyMessageRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletDBStats calls the method with the given parameters
+func (a API) WalletDBStats(cmd *btcjson.WalletDBStatsCmd) (e error) {
+	RPCHandlers["walletdbstats"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletDBStatsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletDBStatsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletDBStatsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletDBStatsGetRes returns a pointer to the value in the Result field
+func (a API) WalletDBStatsGetRes() (out *[]btcjson.WalletDBBucketResult, e error) {
+	out, _ = a.Result.(*[]btcjson.WalletDBBucketResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletDBStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletDBStatsWait(cmd *btcjson.WalletDBStatsCmd) (out *[]btcjson.WalletDBBucketResult, e error) {
+	RPCHandlers["walletdbstats"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletDBStatsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletIsLocked calls the method with the given parameters
+func (a API) WalletIsLocked(cmd *None) (e error) {
+	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletIsLockedCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletIsLockedCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletIsLockedRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletIsLockedGetRes returns a pointer to the value in the Result field
+func (a API) WalletIsLockedGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletIsLockedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletIsLockedWait(cmd *None) (out *bool, e error) {
+	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletIsLockedRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletLock calls the method with the given parameters
+func (a API) WalletLock(cmd *None) (e error) {
+	RPCHandlers["walletlock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletLockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletLockCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletLockRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletLockGetRes returns a pointer to the value in the Result field
+func (a API) WalletLockGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletLockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletLockWait(cmd *None) (out *None, e error) {
+	RPCHandlers["walletlock"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletLockRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletPassphrase calls the method with the given parameters
+func (a API) WalletPassphrase(cmd *btcjson.WalletPassphraseCmd) (e error) {
+	RPCHandlers["walletpassphrase"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletPassphraseCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletPassphraseCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletPassphraseRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletPassphraseGetRes returns a pointer to the value in the Result field
+func (a API) WalletPassphraseGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletPassphraseWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletPassphraseWait(cmd *btcjson.WalletPassphraseCmd) (out *None, e error) {
+	RPCHandlers["walletpassphrase"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletPassphraseRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletPassphraseChange calls the method with the given parameters
+func (a API) WalletPassphraseChange(cmd *btcjson.WalletPassphraseChangeCmd) (e error) {
+	RPCHandlers["walletpassphrasechange"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletPassphraseChangeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletPassphraseChangeCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletPassphraseChangeRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletPassphraseChangeGetRes returns a pointer to the value in the Result field
+func (a API) WalletPassphraseChangeGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletPassphraseChangeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletPassphraseChangeWait(cmd *btcjson.WalletPassphraseChangeCmd) (out *None, e error) {
+	RPCHandlers["walletpassphrasechange"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletPassphraseChangeRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WalletSelfTest calls the method with the given parameters
+func (a API) WalletSelfTest(cmd *None) (e error) {
+	RPCHandlers["walletselftest"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletSelfTestCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletSelfTestCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletSelfTestRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletSelfTestGetRes returns a pointer to the value in the Result field
+func (a API) WalletSelfTestGetRes() (out *btcjson.WalletSelfTestResult, e error) {
+	out, _ = a.Result.(*btcjson.WalletSelfTestResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WalletSelfTestWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletSelfTestWait(cmd *None) (out *btcjson.WalletSelfTestResult, e error) {
+	RPCHandlers["walletselftest"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletSelfTestRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// WithdrawVault calls the method with the given parameters
+func (a API) WithdrawVault(cmd *btcjson.WithdrawVaultCmd) (e error) {
+	RPCHandlers["withdrawvault"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WithdrawVaultCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WithdrawVaultCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WithdrawVaultRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WithdrawVaultGetRes returns a pointer to the value in the Result field
+func (a API) WithdrawVaultGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// WithdrawVaultWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WithdrawVaultWait(cmd *btcjson.WithdrawVaultCmd) (out *string, e error) {
+	RPCHandlers["withdrawvault"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WithdrawVaultRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+
+// RunAPI starts up the api handler server that receives rpc.API messages and runs the handler and returns the result
+// Note that the parameters are type asserted to prevent the consumer of the API from sending wrong message types not
+// because it's necessary since they are interfaces end to end
+func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet, 
+	quit qu.C) {
+	nrh := RPCHandlers
+	go func() {
+		D.Ln("starting up wallet cAPI")
+		var e error
+		var res interface{}
+		for {
+			select { 
+			case msg := <-nrh["addmultisigaddress"].Call:
+				if res, e = nrh["addmultisigaddress"].
+					Handler(msg.Params.(*btcjson.AddMultisigAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan AddMultiSigAddressRes) <- AddMultiSigAddressRes{&r, e} } 
+			case msg := <-nrh["addportfolioentry"].Call:
+				if res, e = nrh["addportfolioentry"].
+					Handler(msg.Params.(*btcjson.AddPortfolioEntryCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan AddPortfolioEntryRes) <- AddPortfolioEntryRes{&r, e} } 
+			case msg := <-nrh["archiveaccount"].Call:
+				if res, e = nrh["archiveaccount"].
+					Handler(msg.Params.(*btcjson.ArchiveAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ArchiveAccountRes) <- ArchiveAccountRes{&r, e} } 
+			case msg := <-nrh["backupremote"].Call:
+				if res, e = nrh["backupremote"].
+					Handler(msg.Params.(*btcjson.BackupRemoteCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.BackupTargetResult); ok { 
+					msg.Ch.(chan BackupRemoteRes) <- BackupRemoteRes{&r, e} } 
+			case msg := <-nrh["cancelqueuedpsbt"].Call:
+				if res, e = nrh["cancelqueuedpsbt"].
+					Handler(msg.Params.(*btcjson.CancelQueuedPSBTCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan CancelQueuedPSBTRes) <- CancelQueuedPSBTRes{&r, e} } 
+			case msg := <-nrh["createmultisig"].Call:
+				if res, e = nrh["createmultisig"].
+					Handler(msg.Params.(*btcjson.CreateMultisigCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.CreateMultiSigResult); ok { 
+					msg.Ch.(chan CreateMultiSigRes) <- CreateMultiSigRes{&r, e} } 
+			case msg := <-nrh["createmultisigaccount"].Call:
+				if res, e = nrh["createmultisigaccount"].
+					Handler(msg.Params.(*btcjson.CreateMultiSigAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.MultiSigAccountResult); ok { 
+					msg.Ch.(chan CreateMultiSigAccountRes) <- CreateMultiSigAccountRes{&r, e} } 
+			case msg := <-nrh["createnewaccount"].Call:
+				if res, e = nrh["createnewaccount"].
+					Handler(msg.Params.(*btcjson.CreateNewAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan CreateNewAccountRes) <- CreateNewAccountRes{&r, e} } 
+			case msg := <-nrh["createvaultaccount"].Call:
+				if res, e = nrh["createvaultaccount"].
+					Handler(msg.Params.(*btcjson.CreateVaultAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.VaultAccountResult); ok { 
+					msg.Ch.(chan CreateVaultAccountRes) <- CreateVaultAccountRes{&r, e} } 
+			case msg := <-nrh["deleteaddressmeta"].Call:
+				if res, e = nrh["deleteaddressmeta"].
+					Handler(msg.Params.(*btcjson.DeleteAddressMetaCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan DeleteAddressMetaRes) <- DeleteAddressMetaRes{&r, e} } 
+			case msg := <-nrh["dropwallethistory"].Call:
+				if res, e = nrh["dropwallethistory"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan HandleDropWalletHistoryRes) <- HandleDropWalletHistoryRes{&r, e} } 
+			case msg := <-nrh["dumpprivkey"].Call:
+				if res, e = nrh["dumpprivkey"].
+					Handler(msg.Params.(*btcjson.DumpPrivKeyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan DumpPrivKeyRes) <- DumpPrivKeyRes{&r, e} } 
+			case msg := <-nrh["exportaccountxprv"].Call:
+				if res, e = nrh["exportaccountxprv"].
+					Handler(msg.Params.(*btcjson.ExportAccountXprvCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ExportAccountXprvResult); ok { 
+					msg.Ch.(chan ExportAccountXprvRes) <- ExportAccountXprvRes{&r, e} } 
+			case msg := <-nrh["exportledger"].Call:
+				if res, e = nrh["exportledger"].
+					Handler(msg.Params.(*btcjson.ExportLedgerCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan ExportLedgerRes) <- ExportLedgerRes{&r, e} } 
+			case msg := <-nrh["exportpaymentbundle"].Call:
+				if res, e = nrh["exportpaymentbundle"].
+					Handler(msg.Params.(*btcjson.ExportPaymentBundleCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ExportPaymentBundleResult); ok { 
+					msg.Ch.(chan ExportPaymentBundleRes) <- ExportPaymentBundleRes{&r, e} } 
+			case msg := <-nrh["exportwatchset"].Call:
+				if res, e = nrh["exportwatchset"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.WatchSetResult); ok { 
+					msg.Ch.(chan ExportWatchSetRes) <- ExportWatchSetRes{&r, e} } 
+			case msg := <-nrh["freezeunspent"].Call:
+				if res, e = nrh["freezeunspent"].
+					Handler(msg.Params.(*btcjson.FreezeUnspentCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan FreezeUnspentRes) <- FreezeUnspentRes{&r, e} } 
+			case msg := <-nrh["generatepaperkey"].Call:
+				if res, e = nrh["generatepaperkey"].
+					Handler(msg.Params.(*btcjson.GeneratePaperKeyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.PaperKeyResult); ok { 
+					msg.Ch.(chan GeneratePaperKeyRes) <- GeneratePaperKeyRes{&r, e} } 
+			case msg := <-nrh["getaccount"].Call:
+				if res, e = nrh["getaccount"].
+					Handler(msg.Params.(*btcjson.GetAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetAccountRes) <- GetAccountRes{&r, e} } 
+			case msg := <-nrh["getaccountaddress"].Call:
+				if res, e = nrh["getaccountaddress"].
+					Handler(msg.Params.(*btcjson.GetAccountAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetAccountAddressRes) <- GetAccountAddressRes{&r, e} } 
+			case msg := <-nrh["getaccountxpub"].Call:
+				if res, e = nrh["getaccountxpub"].
+					Handler(msg.Params.(*btcjson.GetAccountXPubCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetAccountXPubRes) <- GetAccountXPubRes{&r, e} } 
+			case msg := <-nrh["getaddressesbyaccount"].Call:
+				if res, e = nrh["getaddressesbyaccount"].
+					Handler(msg.Params.(*btcjson.GetAddressesByAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan GetAddressesByAccountRes) <- GetAddressesByAccountRes{&r, e} } 
+			case msg := <-nrh["getaddressinfo"].Call:
+				if res, e = nrh["getaddressinfo"].
+					Handler(msg.Params.(*btcjson.GetAddressInfoCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetAddressInfoResult); ok { 
+					msg.Ch.(chan GetAddressInfoRes) <- GetAddressInfoRes{&r, e} } 
+			case msg := <-nrh["getaddressmeta"].Call:
+				if res, e = nrh["getaddressmeta"].
+					Handler(msg.Params.(*btcjson.GetAddressMetaCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.AddressMetaResult); ok { 
+					msg.Ch.(chan GetAddressMetaRes) <- GetAddressMetaRes{&r, e} } 
+			case msg := <-nrh["getauditlog"].Call:
+				if res, e = nrh["getauditlog"].
+					Handler(msg.Params.(*btcjson.GetAuditLogCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetAuditLogResult); ok { 
+					msg.Ch.(chan GetAuditLogRes) <- GetAuditLogRes{&r, e} } 
+			case msg := <-nrh["getbalance"].Call:
+				if res, e = nrh["getbalance"].
+					Handler(msg.Params.(*btcjson.GetBalanceCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(float64); ok { 
+					msg.Ch.(chan GetBalanceRes) <- GetBalanceRes{&r, e} } 
+			case msg := <-nrh["getbalanceat"].Call:
+				if res, e = nrh["getbalanceat"].
+					Handler(msg.Params.(*btcjson.GetBalanceAtCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetBalanceAtResult); ok { 
+					msg.Ch.(chan GetBalanceAtRes) <- GetBalanceAtRes{&r, e} } 
+			case msg := <-nrh["getbestblock"].Call:
+				if res, e = nrh["getbestblock"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetBestBlockResult); ok { 
+					msg.Ch.(chan GetBestBlockRes) <- GetBestBlockRes{&r, e} } 
+			case msg := <-nrh["getbestblockhash"].Call:
+				if res, e = nrh["getbestblockhash"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetBestBlockHashRes) <- GetBestBlockHashRes{&r, e} } 
+			case msg := <-nrh["getblockcount"].Call:
+				if res, e = nrh["getblockcount"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(int32); ok { 
+					msg.Ch.(chan GetBlockCountRes) <- GetBlockCountRes{&r, e} } 
+			case msg := <-nrh["getinfo"].Call:
+				if res, e = nrh["getinfo"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.InfoWalletResult); ok { 
+					msg.Ch.(chan GetInfoRes) <- GetInfoRes{&r, e} } 
+			case msg := <-nrh["getnewaddress"].Call:
+				if res, e = nrh["getnewaddress"].
+					Handler(msg.Params.(*btcjson.GetNewAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetNewAddressRes) <- GetNewAddressRes{&r, e} } 
+			case msg := <-nrh["getnewmultisigaddress"].Call:
+				if res, e = nrh["getnewmultisigaddress"].
+					Handler(msg.Params.(*btcjson.GetNewMultiSigAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.MultiSigAddressResult); ok { 
+					msg.Ch.(chan GetNewMultiSigAddressRes) <- GetNewMultiSigAddressRes{&r, e} } 
+			case msg := <-nrh["getnewvaultaddress"].Call:
+				if res, e = nrh["getnewvaultaddress"].
+					Handler(msg.Params.(*btcjson.GetNewVaultAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.VaultAddressResult); ok { 
+					msg.Ch.(chan GetNewVaultAddressRes) <- GetNewVaultAddressRes{&r, e} } 
+			case msg := <-nrh["getpaymentbundle"].Call:
+				if res, e = nrh["getpaymentbundle"].
+					Handler(msg.Params.(*btcjson.GetPaymentBundleCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.PaymentBundleResult); ok { 
+					msg.Ch.(chan GetPaymentBundleRes) <- GetPaymentBundleRes{&r, e} } 
+			case msg := <-nrh["getrawchangeaddress"].Call:
+				if res, e = nrh["getrawchangeaddress"].
+					Handler(msg.Params.(*btcjson.GetRawChangeAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan GetRawChangeAddressRes) <- GetRawChangeAddressRes{&r, e} } 
+			case msg := <-nrh["getreceivedbyaccount"].Call:
+				if res, e = nrh["getreceivedbyaccount"].
+					Handler(msg.Params.(*btcjson.GetReceivedByAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(float64); ok { 
+					msg.Ch.(chan GetReceivedByAccountRes) <- GetReceivedByAccountRes{&r, e} } 
+			case msg := <-nrh["getreceivedbyaddress"].Call:
+				if res, e = nrh["getreceivedbyaddress"].
+					Handler(msg.Params.(*btcjson.GetReceivedByAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(float64); ok { 
+					msg.Ch.(chan GetReceivedByAddressRes) <- GetReceivedByAddressRes{&r, e} } 
+			case msg := <-nrh["getrescaninfo"].Call:
+				if res, e = nrh["getrescaninfo"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetRescanInfoResult); ok { 
+					msg.Ch.(chan GetRescanInfoRes) <- GetRescanInfoRes{&r, e} } 
+			case msg := <-nrh["getscrubinfo"].Call:
+				if res, e = nrh["getscrubinfo"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetScrubInfoResult); ok { 
+					msg.Ch.(chan GetScrubInfoRes) <- GetScrubInfoRes{&r, e} } 
+			case msg := <-nrh["getspendauth"].Call:
+				if res, e = nrh["getspendauth"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SpendAuthResult); ok { 
+					msg.Ch.(chan GetSpendAuthRes) <- GetSpendAuthRes{&r, e} } 
+			case msg := <-nrh["gettransaction"].Call:
+				if res, e = nrh["gettransaction"].
+					Handler(msg.Params.(*btcjson.GetTransactionCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetTransactionResult); ok { 
+					msg.Ch.(chan GetTransactionRes) <- GetTransactionRes{&r, e} } 
+			case msg := <-nrh["getunconfirmedbalance"].Call:
+				if res, e = nrh["getunconfirmedbalance"].
+					Handler(msg.Params.(*btcjson.GetUnconfirmedBalanceCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(float64); ok { 
+					msg.Ch.(chan GetUnconfirmedBalanceRes) <- GetUnconfirmedBalanceRes{&r, e} } 
+			case msg := <-nrh["getutxoreport"].Call:
+				if res, e = nrh["getutxoreport"].
+					Handler(msg.Params.(*btcjson.GetUTXOReportCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetUTXOReportResult); ok { 
+					msg.Ch.(chan GetUTXOReportRes) <- GetUTXOReportRes{&r, e} } 
+			case msg := <-nrh["getvaultschedule"].Call:
+				if res, e = nrh["getvaultschedule"].
+					Handler(msg.Params.(*btcjson.GetVaultScheduleCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.VaultScheduleResult); ok { 
+					msg.Ch.(chan GetVaultScheduleRes) <- GetVaultScheduleRes{&r, e} } 
+			case msg := <-nrh["help"].Call:
+				if res, e = nrh["help"].
+					Handler(msg.Params.(btcjson.HelpCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan HelpNoChainRPCRes) <- HelpNoChainRPCRes{&r, e} } 
+			case msg := <-nrh["importcorewallet"].Call:
+				if res, e = nrh["importcorewallet"].
+					Handler(msg.Params.(*btcjson.ImportCoreWalletCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ImportCoreWalletResult); ok { 
+					msg.Ch.(chan ImportCoreWalletRes) <- ImportCoreWalletRes{&r, e} } 
+			case msg := <-nrh["importprivkey"].Call:
+				if res, e = nrh["importprivkey"].
+					Handler(msg.Params.(*btcjson.ImportPrivKeyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ImportPrivKeyRes) <- ImportPrivKeyRes{&r, e} } 
+			case msg := <-nrh["importscriptpubkey"].Call:
+				if res, e = nrh["importscriptpubkey"].
+					Handler(msg.Params.(*btcjson.ImportScriptPubKeyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ImportScriptPubKeyRes) <- ImportScriptPubKeyRes{&r, e} } 
+			case msg := <-nrh["importtimelockscript"].Call:
+				if res, e = nrh["importtimelockscript"].
+					Handler(msg.Params.(*btcjson.ImportTimeLockScriptCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.TimeLockScriptResult); ok { 
+					msg.Ch.(chan ImportTimeLockScriptRes) <- ImportTimeLockScriptRes{&r, e} } 
+			case msg := <-nrh["keypoolrefill"].Call:
+				if res, e = nrh["keypoolrefill"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan KeypoolRefillRes) <- KeypoolRefillRes{&r, e} } 
+			case msg := <-nrh["listaccounts"].Call:
+				if res, e = nrh["listaccounts"].
+					Handler(msg.Params.(*btcjson.ListAccountsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(map[string]float64); ok { 
+					msg.Ch.(chan ListAccountsRes) <- ListAccountsRes{&r, e} } 
+			case msg := <-nrh["listaddressmeta"].Call:
+				if res, e = nrh["listaddressmeta"].
+					Handler(msg.Params.(*btcjson.ListAddressMetaCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.AddressMetaResult); ok { 
+					msg.Ch.(chan ListAddressMetaRes) <- ListAddressMetaRes{&r, e} } 
+			case msg := <-nrh["listaddresstransactions"].Call:
+				if res, e = nrh["listaddresstransactions"].
+					Handler(msg.Params.(*btcjson.ListAddressTransactionsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
+					msg.Ch.(chan ListAddressTransactionsRes) <- ListAddressTransactionsRes{&r, e} } 
+			case msg := <-nrh["listalltransactions"].Call:
+				if res, e = nrh["listalltransactions"].
+					Handler(msg.Params.(*btcjson.ListAllTransactionsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
+					msg.Ch.(chan ListAllTransactionsRes) <- ListAllTransactionsRes{&r, e} } 
+			case msg := <-nrh["listdustoutputs"].Call:
+				if res, e = nrh["listdustoutputs"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.DustOutputResult); ok { 
+					msg.Ch.(chan ListDustOutputsRes) <- ListDustOutputsRes{&r, e} } 
+			case msg := <-nrh["listfrozen"].Call:
+				if res, e = nrh["listfrozen"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.FrozenOutputResult); ok { 
+					msg.Ch.(chan ListFrozenRes) <- ListFrozenRes{&r, e} } 
+			case msg := <-nrh["listimmature"].Call:
+				if res, e = nrh["listimmature"].
+					Handler(msg.Params.(*btcjson.ListImmatureCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ListImmatureResult); ok { 
+					msg.Ch.(chan ListImmatureRes) <- ListImmatureRes{&r, e} } 
+			case msg := <-nrh["listinvoicereservations"].Call:
+				if res, e = nrh["listinvoicereservations"].
+					Handler(msg.Params.(*btcjson.ListInvoiceReservationsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.InvoiceReservationResult); ok { 
+					msg.Ch.(chan ListInvoiceReservationsRes) <- ListInvoiceReservationsRes{&r, e} } 
+			case msg := <-nrh["listinvoices"].Call:
+				if res, e = nrh["listinvoices"].
+					Handler(msg.Params.(*btcjson.ListInvoicesCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.InvoiceResult); ok { 
+					msg.Ch.(chan ListInvoicesRes) <- ListInvoicesRes{&r, e} } 
+			case msg := <-nrh["listlockunspent"].Call:
+				if res, e = nrh["listlockunspent"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.TransactionInput); ok { 
+					msg.Ch.(chan ListLockUnspentRes) <- ListLockUnspentRes{&r, e} } 
+			case msg := <-nrh["listmultisigaccounts"].Call:
+				if res, e = nrh["listmultisigaccounts"].
+					Handler(msg.Params.(*btcjson.ListMultiSigAccountsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.MultiSigAccountResult); ok { 
+					msg.Ch.(chan ListMultiSigAccountsRes) <- ListMultiSigAccountsRes{&r, e} } 
+			case msg := <-nrh["listpaymentbundles"].Call:
+				if res, e = nrh["listpaymentbundles"].
+					Handler(msg.Params.(*btcjson.ListPaymentBundlesCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.PaymentBundleResult); ok { 
+					msg.Ch.(chan ListPaymentBundlesRes) <- ListPaymentBundlesRes{&r, e} } 
+			case msg := <-nrh["listportfolio"].Call:
+				if res, e = nrh["listportfolio"].
+					Handler(msg.Params.(*btcjson.ListPortfolioCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ListPortfolioResult); ok { 
+					msg.Ch.(chan ListPortfolioRes) <- ListPortfolioRes{&r, e} } 
+			case msg := <-nrh["listportfoliotransactions"].Call:
+				if res, e = nrh["listportfoliotransactions"].
+					Handler(msg.Params.(*btcjson.ListPortfolioTransactionsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.PortfolioTransactionResult); ok { 
+					msg.Ch.(chan ListPortfolioTransactionsRes) <- ListPortfolioTransactionsRes{&r, e} } 
+			case msg := <-nrh["listqueuedpsbts"].Call:
+				if res, e = nrh["listqueuedpsbts"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.QueuedPSBTResult); ok { 
+					msg.Ch.(chan ListQueuedPSBTsRes) <- ListQueuedPSBTsRes{&r, e} } 
+			case msg := <-nrh["listreceivedbyaccount"].Call:
+				if res, e = nrh["listreceivedbyaccount"].
+					Handler(msg.Params.(*btcjson.ListReceivedByAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.ListReceivedByAccountResult); ok { 
+					msg.Ch.(chan ListReceivedByAccountRes) <- ListReceivedByAccountRes{&r, e} } 
+			case msg := <-nrh["listreceivedbyaddress"].Call:
+				if res, e = nrh["listreceivedbyaddress"].
+					Handler(msg.Params.(*btcjson.ListReceivedByAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ListReceivedByAddressResult); ok { 
+					msg.Ch.(chan ListReceivedByAddressRes) <- ListReceivedByAddressRes{&r, e} } 
+			case msg := <-nrh["listsinceblock"].Call:
+				if res, e = nrh["listsinceblock"].
+					Handler(msg.Params.(btcjson.ListSinceBlockCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ListSinceBlockResult); ok { 
+					msg.Ch.(chan ListSinceBlockRes) <- ListSinceBlockRes{&r, e} } 
+			case msg := <-nrh["listtransactions"].Call:
+				if res, e = nrh["listtransactions"].
+					Handler(msg.Params.(*btcjson.ListTransactionsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
+					msg.Ch.(chan ListTransactionsRes) <- ListTransactionsRes{&r, e} } 
+			case msg := <-nrh["listtransactionspage"].Call:
+				if res, e = nrh["listtransactionspage"].
+					Handler(msg.Params.(*btcjson.ListTransactionsPageCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ListTransactionsPageResult); ok { 
+					msg.Ch.(chan ListTransactionsPageRes) <- ListTransactionsPageRes{&r, e} } 
+			case msg := <-nrh["listunlockattempts"].Call:
+				if res, e = nrh["listunlockattempts"].
+					Handler(msg.Params.(*btcjson.ListUnlockAttemptsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.UnlockAttemptResult); ok { 
+					msg.Ch.(chan ListUnlockAttemptsRes) <- ListUnlockAttemptsRes{&r, e} } 
+			case msg := <-nrh["listunspent"].Call:
+				if res, e = nrh["listunspent"].
+					Handler(msg.Params.(*btcjson.ListUnspentCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.ListUnspentResult); ok { 
+					msg.Ch.(chan ListUnspentRes) <- ListUnspentRes{&r, e} } 
+			case msg := <-nrh["listvaultaccounts"].Call:
+				if res, e = nrh["listvaultaccounts"].
+					Handler(msg.Params.(*btcjson.ListVaultAccountsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.VaultAccountResult); ok { 
+					msg.Ch.(chan ListVaultAccountsRes) <- ListVaultAccountsRes{&r, e} } 
+			case msg := <-nrh["overridedust"].Call:
+				if res, e = nrh["overridedust"].
+					Handler(msg.Params.(*btcjson.OverrideDustCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan OverrideDustRes) <- OverrideDustRes{&r, e} } 
+			case msg := <-nrh["previewsend"].Call:
+				if res, e = nrh["previewsend"].
+					Handler(msg.Params.(*btcjson.PreviewSendCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.PreviewSendResult); ok { 
+					msg.Ch.(chan PreviewSendRes) <- PreviewSendRes{&r, e} } 
+			case msg := <-nrh["releaseinvoiceaddress"].Call:
+				if res, e = nrh["releaseinvoiceaddress"].
+					Handler(msg.Params.(*btcjson.ReleaseInvoiceAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan ReleaseInvoiceAddressRes) <- ReleaseInvoiceAddressRes{&r, e} } 
+			case msg := <-nrh["removeportfolioentry"].Call:
+				if res, e = nrh["removeportfolioentry"].
+					Handler(msg.Params.(*btcjson.RemovePortfolioEntryCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan RemovePortfolioEntryRes) <- RemovePortfolioEntryRes{&r, e} } 
+			case msg := <-nrh["renameaccount"].Call:
+				if res, e = nrh["renameaccount"].
+					Handler(msg.Params.(*btcjson.RenameAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan RenameAccountRes) <- RenameAccountRes{&r, e} } 
+			case msg := <-nrh["reserveinvoiceaddress"].Call:
+				if res, e = nrh["reserveinvoiceaddress"].
+					Handler(msg.Params.(*btcjson.ReserveInvoiceAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.InvoiceReservationResult); ok { 
+					msg.Ch.(chan ReserveInvoiceAddressRes) <- ReserveInvoiceAddressRes{&r, e} } 
+			case msg := <-nrh["sendfrom"].Call:
+				if res, e = nrh["sendfrom"].
+					Handler(msg.Params.(btcjson.LockUnspentCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, e} } 
+			case msg := <-nrh["sendmany"].Call:
+				if res, e = nrh["sendmany"].
+					Handler(msg.Params.(*btcjson.SendManyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan SendManyRes) <- SendManyRes{&r, e} } 
+			case msg := <-nrh["sendtoaddress"].Call:
+				if res, e = nrh["sendtoaddress"].
+					Handler(msg.Params.(*btcjson.SendToAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan SendToAddressRes) <- SendToAddressRes{&r, e} } 
+			case msg := <-nrh["setaddressmeta"].Call:
+				if res, e = nrh["setaddressmeta"].
+					Handler(msg.Params.(*btcjson.SetAddressMetaCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.AddressMetaResult); ok { 
+					msg.Ch.(chan SetAddressMetaRes) <- SetAddressMetaRes{&r, e} } 
+			case msg := <-nrh["setinvoiceissuance"].Call:
+				if res, e = nrh["setinvoiceissuance"].
+					Handler(msg.Params.(*btcjson.SetInvoiceIssuanceCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan SetInvoiceIssuanceRes) <- SetInvoiceIssuanceRes{&r, e} } 
+			case msg := <-nrh["setspendauth"].Call:
+				if res, e = nrh["setspendauth"].
+					Handler(msg.Params.(*btcjson.SetSpendAuthCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SpendAuthResult); ok { 
+					msg.Ch.(chan SetSpendAuthRes) <- SetSpendAuthRes{&r, e} } 
+			case msg := <-nrh["settxfee"].Call:
+				if res, e = nrh["settxfee"].
+					Handler(msg.Params.(*btcjson.SetTxFeeCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan SetTxFeeRes) <- SetTxFeeRes{&r, e} } 
+			case msg := <-nrh["signmessage"].Call:
+				if res, e = nrh["signmessage"].
+					Handler(msg.Params.(*btcjson.SignMessageCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan SignMessageRes) <- SignMessageRes{&r, e} } 
+			case msg := <-nrh["signrawtransaction"].Call:
+				if res, e = nrh["signrawtransaction"].
+					Handler(msg.Params.(btcjson.SignRawTransactionCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SignRawTransactionResult); ok { 
+					msg.Ch.(chan SignRawTransactionRes) <- SignRawTransactionRes{&r, e} } 
+			case msg := <-nrh["submitsignedpsbt"].Call:
+				if res, e = nrh["submitsignedpsbt"].
+					Handler(msg.Params.(*btcjson.SubmitSignedPSBTCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan SubmitSignedPSBTRes) <- SubmitSignedPSBTRes{&r, e} } 
+			case msg := <-nrh["sweepaccount"].Call:
+				if res, e = nrh["sweepaccount"].
+					Handler(msg.Params.(*btcjson.SweepAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SweepAccountResult); ok { 
+					msg.Ch.(chan SweepAccountRes) <- SweepAccountRes{&r, e} } 
+			case msg := <-nrh["sweepprivkey"].Call:
+				if res, e = nrh["sweepprivkey"].
+					Handler(msg.Params.(*btcjson.SweepPrivKeyCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SweepPrivKeyResult); ok { 
+					msg.Ch.(chan SweepPrivKeyRes) <- SweepPrivKeyRes{&r, e} } 
+			case msg := <-nrh["sweeptimelocked"].Call:
+				if res, e = nrh["sweeptimelocked"].
+					Handler(msg.Params.(*btcjson.SweepTimeLockedCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SweepTimeLockedResult); ok { 
+					msg.Ch.(chan SweepTimeLockedRes) <- SweepTimeLockedRes{&r, e} } 
+			case msg := <-nrh["transferaccount"].Call:
+				if res, e = nrh["transferaccount"].
+					Handler(msg.Params.(*btcjson.TransferAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.TransferAccountResult); ok { 
+					msg.Ch.(chan TransferAccountRes) <- TransferAccountRes{&r, e} } 
+			case msg := <-nrh["unarchiveaccount"].Call:
+				if res, e = nrh["unarchiveaccount"].
+					Handler(msg.Params.(*btcjson.UnarchiveAccountCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan UnarchiveAccountRes) <- UnarchiveAccountRes{&r, e} } 
+			case msg := <-nrh["validateaddress"].Call:
+				if res, e = nrh["validateaddress"].
+					Handler(msg.Params.(*btcjson.ValidateAddressCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.ValidateAddressWalletResult); ok { 
+					msg.Ch.(chan ValidateAddressRes) <- ValidateAddressRes{&r, e} } 
+			case msg := <-nrh["verifymessage"].Call:
+				if res, e = nrh["verifymessage"].
+					Handler(msg.Params.(*btcjson.VerifyMessageCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan VerifyMessageRes) <- VerifyMessageRes{&r, e} } 
+			case msg := <-nrh["walletdbstats"].Call:
+				if res, e = nrh["walletdbstats"].
+					Handler(msg.Params.(*btcjson.WalletDBStatsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.WalletDBBucketResult); ok { 
+					msg.Ch.(chan WalletDBStatsRes) <- WalletDBStatsRes{&r, e} } 
+			case msg := <-nrh["walletislocked"].Call:
+				if res, e = nrh["walletislocked"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan WalletIsLockedRes) <- WalletIsLockedRes{&r, e} } 
+			case msg := <-nrh["walletlock"].Call:
+				if res, e = nrh["walletlock"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan WalletLockRes) <- WalletLockRes{&r, e} } 
+			case msg := <-nrh["walletpassphrase"].Call:
+				if res, e = nrh["walletpassphrase"].
+					Handler(msg.Params.(*btcjson.WalletPassphraseCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan WalletPassphraseRes) <- WalletPassphraseRes{&r, e} } 
+			case msg := <-nrh["walletpassphrasechange"].Call:
+				if res, e = nrh["walletpassphrasechange"].
+					Handler(msg.Params.(*btcjson.WalletPassphraseChangeCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan WalletPassphraseChangeRes) <- WalletPassphraseChangeRes{&r, e} } 
+			case msg := <-nrh["walletselftest"].Call:
+				if res, e = nrh["walletselftest"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.WalletSelfTestResult); ok { 
+					msg.Ch.(chan WalletSelfTestRes) <- WalletSelfTestRes{&r, e} } 
+			case msg := <-nrh["withdrawvault"].Call:
+				if res, e = nrh["withdrawvault"].
+					Handler(msg.Params.(*btcjson.WithdrawVaultCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan WithdrawVaultRes) <- WithdrawVaultRes{&r, e} } 
+			case <-quit.Wait():
+				D.Ln("stopping wallet cAPI")
+				return
+			}
+		}
+	}()
+}
+
+// RPC API functions to use with net/rpc
+
+func (c *CAPI) AddMultiSigAddress(req *btcjson.AddMultisigAddressCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["addmultisigaddress"].Result()
+	res.Params = req
+	nrh["addmultisigaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) AddPortfolioEntry(req *btcjson.AddPortfolioEntryCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["addportfolioentry"].Result()
+	res.Params = req
+	nrh["addportfolioentry"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ArchiveAccount(req *btcjson.ArchiveAccountCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["archiveaccount"].Result()
+	res.Params = req
+	nrh["archiveaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) BackupRemote(req *btcjson.BackupRemoteCmd, resp []btcjson.BackupTargetResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["backupremote"].Result()
+	res.Params = req
+	nrh["backupremote"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.BackupTargetResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CancelQueuedPSBT(req *btcjson.CancelQueuedPSBTCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["cancelqueuedpsbt"].Result()
+	res.Params = req
+	nrh["cancelqueuedpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.CreateMultiSigResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["createmultisig"].Result()
+	res.Params = req
+	nrh["createmultisig"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.CreateMultiSigResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CreateMultiSigAccount(req *btcjson.CreateMultiSigAccountCmd, resp btcjson.MultiSigAccountResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["createmultisigaccount"].Result()
+	res.Params = req
+	nrh["createmultisigaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultiSigAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CreateNewAccount(req *btcjson.CreateNewAccountCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["createnewaccount"].Result()
+	res.Params = req
+	nrh["createnewaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CreateVaultAccount(req *btcjson.CreateVaultAccountCmd, resp btcjson.VaultAccountResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["createvaultaccount"].Result()
+	res.Params = req
+	nrh["createvaultaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.VaultAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) DeleteAddressMeta(req *btcjson.DeleteAddressMetaCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["deleteaddressmeta"].Result()
+	res.Params = req
+	nrh["deleteaddressmeta"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) HandleDropWalletHistory(req *None, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["dropwallethistory"].Result()
+	res.Params = req
+	nrh["dropwallethistory"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) DumpPrivKey(req *btcjson.DumpPrivKeyCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["dumpprivkey"].Result()
+	res.Params = req
+	nrh["dumpprivkey"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ExportAccountXprv(req *btcjson.ExportAccountXprvCmd, resp btcjson.ExportAccountXprvResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["exportaccountxprv"].Result()
+	res.Params = req
+	nrh["exportaccountxprv"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ExportAccountXprvResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ExportLedger(req *btcjson.ExportLedgerCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["exportledger"].Result()
+	res.Params = req
+	nrh["exportledger"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ExportPaymentBundle(req *btcjson.ExportPaymentBundleCmd, resp btcjson.ExportPaymentBundleResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["exportpaymentbundle"].Result()
+	res.Params = req
+	nrh["exportpaymentbundle"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ExportPaymentBundleResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ExportWatchSet(req *None, resp btcjson.WatchSetResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["exportwatchset"].Result()
+	res.Params = req
+	nrh["exportwatchset"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.WatchSetResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) FreezeUnspent(req *btcjson.FreezeUnspentCmd, resp bool) (e error) {
+	nrh := RPCHandlers
+	res := nrh["freezeunspent"].Result()
+	res.Params = req
+	nrh["freezeunspent"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GeneratePaperKey(req *btcjson.GeneratePaperKeyCmd, resp []btcjson.PaperKeyResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["generatepaperkey"].Result()
+	res.Params = req
+	nrh["generatepaperkey"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.PaperKeyResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAccount(req *btcjson.GetAccountCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaccount"].Result()
+	res.Params = req
+	nrh["getaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAccountAddress(req *btcjson.GetAccountAddressCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaccountaddress"].Result()
+	res.Params = req
+	nrh["getaccountaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAccountXPub(req *btcjson.GetAccountXPubCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaccountxpub"].Result()
+	res.Params = req
+	nrh["getaccountxpub"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAddressesByAccount(req *btcjson.GetAddressesByAccountCmd, resp []string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaddressesbyaccount"].Result()
+	res.Params = req
+	nrh["getaddressesbyaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAddressInfo(req *btcjson.GetAddressInfoCmd, resp btcjson.GetAddressInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaddressinfo"].Result()
+	res.Params = req
+	nrh["getaddressinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetAddressInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAddressMeta(req *btcjson.GetAddressMetaCmd, resp btcjson.AddressMetaResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaddressmeta"].Result()
+	res.Params = req
+	nrh["getaddressmeta"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.AddressMetaResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetAuditLog(req *btcjson.GetAuditLogCmd, resp btcjson.GetAuditLogResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getauditlog"].Result()
+	res.Params = req
+	nrh["getauditlog"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetAuditLogResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetBalance(req *btcjson.GetBalanceCmd, resp float64) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getbalance"].Result()
+	res.Params = req
+	nrh["getbalance"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetBalanceAt(req *btcjson.GetBalanceAtCmd, resp btcjson.GetBalanceAtResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getbalanceat"].Result()
+	res.Params = req
+	nrh["getbalanceat"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetBalanceAtResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetBestBlock(req *None, resp btcjson.GetBestBlockResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getbestblock"].Result()
+	res.Params = req
+	nrh["getbestblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetBestBlockResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetBestBlockHash(req *None, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getbestblockhash"].Result()
+	res.Params = req
+	nrh["getbestblockhash"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetBlockCount(req *None, resp int32) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getblockcount"].Result()
+	res.Params = req
+	nrh["getblockcount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan int32):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetInfo(req *None, resp btcjson.InfoWalletResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getinfo"].Result()
+	res.Params = req
+	nrh["getinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.InfoWalletResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetNewAddress(req *btcjson.GetNewAddressCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnewaddress"].Result()
+	res.Params = req
+	nrh["getnewaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetNewMultiSigAddress(req *btcjson.GetNewMultiSigAddressCmd, resp btcjson.MultiSigAddressResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnewmultisigaddress"].Result()
+	res.Params = req
+	nrh["getnewmultisigaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultiSigAddressResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetNewVaultAddress(req *btcjson.GetNewVaultAddressCmd, resp btcjson.VaultAddressResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnewvaultaddress"].Result()
+	res.Params = req
+	nrh["getnewvaultaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.VaultAddressResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetPaymentBundle(req *btcjson.GetPaymentBundleCmd, resp btcjson.PaymentBundleResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getpaymentbundle"].Result()
+	res.Params = req
+	nrh["getpaymentbundle"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.PaymentBundleResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetRawChangeAddress(req *btcjson.GetRawChangeAddressCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getrawchangeaddress"].Result()
+	res.Params = req
+	nrh["getrawchangeaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetReceivedByAccount(req *btcjson.GetReceivedByAccountCmd, resp float64) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getreceivedbyaccount"].Result()
+	res.Params = req
+	nrh["getreceivedbyaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetReceivedByAddress(req *btcjson.GetReceivedByAddressCmd, resp float64) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getreceivedbyaddress"].Result()
+	res.Params = req
+	nrh["getreceivedbyaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetRescanInfo(req *None, resp btcjson.GetRescanInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getrescaninfo"].Result()
+	res.Params = req
+	nrh["getrescaninfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetRescanInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetScrubInfo(req *None, resp btcjson.GetScrubInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getscrubinfo"].Result()
+	res.Params = req
+	nrh["getscrubinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetScrubInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetSpendAuth(req *None, resp btcjson.SpendAuthResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getspendauth"].Result()
+	res.Params = req
+	nrh["getspendauth"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SpendAuthResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetTransaction(req *btcjson.GetTransactionCmd, resp btcjson.GetTransactionResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["gettransaction"].Result()
+	res.Params = req
+	nrh["gettransaction"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetTransactionResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetUnconfirmedBalance(req *btcjson.GetUnconfirmedBalanceCmd, resp float64) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getunconfirmedbalance"].Result()
+	res.Params = req
+	nrh["getunconfirmedbalance"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetUTXOReport(req *btcjson.GetUTXOReportCmd, resp btcjson.GetUTXOReportResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getutxoreport"].Result()
+	res.Params = req
+	nrh["getutxoreport"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetUTXOReportResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetVaultSchedule(req *btcjson.GetVaultScheduleCmd, resp btcjson.VaultScheduleResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getvaultschedule"].Result()
+	res.Params = req
+	nrh["getvaultschedule"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.VaultScheduleResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["help"].Result()
+	res.Params = req
+	nrh["help"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ImportCoreWallet(req *btcjson.ImportCoreWalletCmd, resp btcjson.ImportCoreWalletResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["importcorewallet"].Result()
+	res.Params = req
+	nrh["importcorewallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ImportCoreWalletResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["importprivkey"].Result()
+	res.Params = req
+	nrh["importprivkey"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ImportScriptPubKey(req *btcjson.ImportScriptPubKeyCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["importscriptpubkey"].Result()
+	res.Params = req
+	nrh["importscriptpubkey"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ImportTimeLockScript(req *btcjson.ImportTimeLockScriptCmd, resp btcjson.TimeLockScriptResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["importtimelockscript"].Result()
+	res.Params = req
+	nrh["importtimelockscript"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.TimeLockScriptResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) KeypoolRefill(req *None, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["keypoolrefill"].Result()
+	res.Params = req
+	nrh["keypoolrefill"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float64) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listaccounts"].Result()
+	res.Params = req
+	nrh["listaccounts"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListAddressMeta(req *btcjson.ListAddressMetaCmd, resp []btcjson.AddressMetaResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listaddressmeta"].Result()
+	res.Params = req
+	nrh["listaddressmeta"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.AddressMetaResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listaddresstransactions"].Result()
+	res.Params = req
+	nrh["listaddresstransactions"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListAllTransactions(req *btcjson.ListAllTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listalltransactions"].Result()
+	res.Params = req
+	nrh["listalltransactions"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListDustOutputs(req *None, resp []btcjson.DustOutputResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listdustoutputs"].Result()
+	res.Params = req
+	nrh["listdustoutputs"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.DustOutputResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListFrozen(req *None, resp []btcjson.FrozenOutputResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listfrozen"].Result()
+	res.Params = req
+	nrh["listfrozen"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.FrozenOutputResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListImmature(req *btcjson.ListImmatureCmd, resp btcjson.ListImmatureResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listimmature"].Result()
+	res.Params = req
+	nrh["listimmature"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ListImmatureResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListInvoiceReservations(req *btcjson.ListInvoiceReservationsCmd, resp []btcjson.InvoiceReservationResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listinvoicereservations"].Result()
+	res.Params = req
+	nrh["listinvoicereservations"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.InvoiceReservationResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListInvoices(req *btcjson.ListInvoicesCmd, resp []btcjson.InvoiceResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listinvoices"].Result()
+	res.Params = req
+	nrh["listinvoices"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.InvoiceResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListLockUnspent(req *None, resp []btcjson.TransactionInput) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listlockunspent"].Result()
+	res.Params = req
+	nrh["listlockunspent"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.TransactionInput):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
 }
 
-// RPC API functions to use with net/rpc
+func (c *CAPI) ListMultiSigAccounts(req *btcjson.ListMultiSigAccountsCmd, resp []btcjson.MultiSigAccountResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listmultisigaccounts"].Result()
+	res.Params = req
+	nrh["listmultisigaccounts"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.MultiSigAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
 
-func (c *CAPI) AddMultiSigAddress(req *btcjson.AddMultisigAddressCmd, resp string) (e error) {
+func (c *CAPI) ListPaymentBundles(req *btcjson.ListPaymentBundlesCmd, resp []btcjson.PaymentBundleResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["addmultisigaddress"].Result()
+	res := nrh["listpaymentbundles"].Result()
 	res.Params = req
-	nrh["addmultisigaddress"].Call <- res
+	nrh["listpaymentbundles"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.PaymentBundleResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.CreateMultiSigResult) (e error) {
+func (c *CAPI) ListPortfolio(req *btcjson.ListPortfolioCmd, resp btcjson.ListPortfolioResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["createmultisig"].Result()
+	res := nrh["listportfolio"].Result()
 	res.Params = req
-	nrh["createmultisig"].Call <- res
+	nrh["listportfolio"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.CreateMultiSigResult):
+	case resp = <-res.Ch.(chan btcjson.ListPortfolioResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) CreateNewAccount(req *btcjson.CreateNewAccountCmd, resp None) (e error) {
+func (c *CAPI) ListPortfolioTransactions(req *btcjson.ListPortfolioTransactionsCmd, resp []btcjson.PortfolioTransactionResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["createnewaccount"].Result()
+	res := nrh["listportfoliotransactions"].Result()
 	res.Params = req
-	nrh["createnewaccount"].Call <- res
+	nrh["listportfoliotransactions"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan []btcjson.PortfolioTransactionResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) HandleDropWalletHistory(req *None, resp string) (e error) {
+func (c *CAPI) ListQueuedPSBTs(req *None, resp []btcjson.QueuedPSBTResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["dropwallethistory"].Result()
+	res := nrh["listqueuedpsbts"].Result()
 	res.Params = req
-	nrh["dropwallethistory"].Call <- res
+	nrh["listqueuedpsbts"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.QueuedPSBTResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) DumpPrivKey(req *btcjson.DumpPrivKeyCmd, resp string) (e error) {
+func (c *CAPI) ListReceivedByAccount(req *btcjson.ListReceivedByAccountCmd, resp []btcjson.ListReceivedByAccountResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["dumpprivkey"].Result()
+	res := nrh["listreceivedbyaccount"].Result()
 	res.Params = req
-	nrh["dumpprivkey"].Call <- res
+	nrh["listreceivedbyaccount"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.ListReceivedByAccountResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetAccount(req *btcjson.GetAccountCmd, resp string) (e error) {
+func (c *CAPI) ListReceivedByAddress(req *btcjson.ListReceivedByAddressCmd, resp btcjson.ListReceivedByAddressResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getaccount"].Result()
+	res := nrh["listreceivedbyaddress"].Result()
 	res.Params = req
-	nrh["getaccount"].Call <- res
+	nrh["listreceivedbyaddress"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan btcjson.ListReceivedByAddressResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetAccountAddress(req *btcjson.GetAccountAddressCmd, resp string) (e error) {
+func (c *CAPI) ListSinceBlock(req btcjson.ListSinceBlockCmd, resp btcjson.ListSinceBlockResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getaccountaddress"].Result()
+	res := nrh["listsinceblock"].Result()
 	res.Params = req
-	nrh["getaccountaddress"].Call <- res
+	nrh["listsinceblock"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan btcjson.ListSinceBlockResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetAddressesByAccount(req *btcjson.GetAddressesByAccountCmd, resp []string) (e error) {
+func (c *CAPI) ListTransactions(req *btcjson.ListTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getaddressesbyaccount"].Result()
+	res := nrh["listtransactions"].Result()
 	res.Params = req
-	nrh["getaddressesbyaccount"].Call <- res
+	nrh["listtransactions"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []string):
+	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetBalance(req *btcjson.GetBalanceCmd, resp float64) (e error) {
+func (c *CAPI) ListTransactionsPage(req *btcjson.ListTransactionsPageCmd, resp btcjson.ListTransactionsPageResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getbalance"].Result()
+	res := nrh["listtransactionspage"].Result()
 	res.Params = req
-	nrh["getbalance"].Call <- res
+	nrh["listtransactionspage"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan float64):
+	case resp = <-res.Ch.(chan btcjson.ListTransactionsPageResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetBestBlock(req *None, resp btcjson.GetBestBlockResult) (e error) {
+func (c *CAPI) ListUnlockAttempts(req *btcjson.ListUnlockAttemptsCmd, resp []btcjson.UnlockAttemptResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getbestblock"].Result()
+	res := nrh["listunlockattempts"].Result()
 	res.Params = req
-	nrh["getbestblock"].Call <- res
+	nrh["listunlockattempts"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.GetBestBlockResult):
+	case resp = <-res.Ch.(chan []btcjson.UnlockAttemptResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetBestBlockHash(req *None, resp string) (e error) {
+func (c *CAPI) ListUnspent(req *btcjson.ListUnspentCmd, resp []btcjson.ListUnspentResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getbestblockhash"].Result()
+	res := nrh["listunspent"].Result()
 	res.Params = req
-	nrh["getbestblockhash"].Call <- res
+	nrh["listunspent"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.ListUnspentResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetBlockCount(req *None, resp int32) (e error) {
+func (c *CAPI) ListVaultAccounts(req *btcjson.ListVaultAccountsCmd, resp []btcjson.VaultAccountResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getblockcount"].Result()
+	res := nrh["listvaultaccounts"].Result()
 	res.Params = req
-	nrh["getblockcount"].Call <- res
+	nrh["listvaultaccounts"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan int32):
+	case resp = <-res.Ch.(chan []btcjson.VaultAccountResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetInfo(req *None, resp btcjson.InfoWalletResult) (e error) {
+func (c *CAPI) OverrideDust(req *btcjson.OverrideDustCmd, resp bool) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getinfo"].Result()
+	res := nrh["overridedust"].Result()
 	res.Params = req
-	nrh["getinfo"].Call <- res
+	nrh["overridedust"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.InfoWalletResult):
+	case resp = <-res.Ch.(chan bool):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetNewAddress(req *btcjson.GetNewAddressCmd, resp string) (e error) {
+func (c *CAPI) PreviewSend(req *btcjson.PreviewSendCmd, resp btcjson.PreviewSendResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getnewaddress"].Result()
+	res := nrh["previewsend"].Result()
 	res.Params = req
-	nrh["getnewaddress"].Call <- res
+	nrh["previewsend"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.PreviewSendResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ReleaseInvoiceAddress(req *btcjson.ReleaseInvoiceAddressCmd, resp bool) (e error) {
+	nrh := RPCHandlers
+	res := nrh["releaseinvoiceaddress"].Result()
+	res.Params = req
+	nrh["releaseinvoiceaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) RemovePortfolioEntry(req *btcjson.RemovePortfolioEntryCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["removeportfolioentry"].Result()
+	res.Params = req
+	nrh["removeportfolioentry"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["renameaccount"].Result()
+	res.Params = req
+	nrh["renameaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ReserveInvoiceAddress(req *btcjson.ReserveInvoiceAddressCmd, resp btcjson.InvoiceReservationResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["reserveinvoiceaddress"].Result()
+	res.Params = req
+	nrh["reserveinvoiceaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.InvoiceReservationResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) LockUnspent(req btcjson.LockUnspentCmd, resp bool) (e error) {
+	nrh := RPCHandlers
+	res := nrh["sendfrom"].Result()
+	res.Params = req
+	nrh["sendfrom"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) SendMany(req *btcjson.SendManyCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["sendmany"].Result()
+	res.Params = req
+	nrh["sendmany"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
@@ -2634,11 +6698,11 @@ func (c *CAPI) GetNewAddress(req *btcjson.GetNewAddressCmd, resp string) (e erro
 	return 
 }
 
-func (c *CAPI) GetRawChangeAddress(req *btcjson.GetRawChangeAddressCmd, resp string) (e error) {
+func (c *CAPI) SendToAddress(req *btcjson.SendToAddressCmd, resp string) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getrawchangeaddress"].Result()
+	res := nrh["sendtoaddress"].Result()
 	res.Params = req
-	nrh["getrawchangeaddress"].Call <- res
+	nrh["sendtoaddress"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
@@ -2647,63 +6711,63 @@ func (c *CAPI) GetRawChangeAddress(req *btcjson.GetRawChangeAddressCmd, resp str
 	return 
 }
 
-func (c *CAPI) GetReceivedByAccount(req *btcjson.GetReceivedByAccountCmd, resp float64) (e error) {
+func (c *CAPI) SetAddressMeta(req *btcjson.SetAddressMetaCmd, resp btcjson.AddressMetaResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getreceivedbyaccount"].Result()
+	res := nrh["setaddressmeta"].Result()
 	res.Params = req
-	nrh["getreceivedbyaccount"].Call <- res
+	nrh["setaddressmeta"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan float64):
+	case resp = <-res.Ch.(chan btcjson.AddressMetaResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetReceivedByAddress(req *btcjson.GetReceivedByAddressCmd, resp float64) (e error) {
+func (c *CAPI) SetInvoiceIssuance(req *btcjson.SetInvoiceIssuanceCmd, resp bool) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getreceivedbyaddress"].Result()
+	res := nrh["setinvoiceissuance"].Result()
 	res.Params = req
-	nrh["getreceivedbyaddress"].Call <- res
+	nrh["setinvoiceissuance"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan float64):
+	case resp = <-res.Ch.(chan bool):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetTransaction(req *btcjson.GetTransactionCmd, resp btcjson.GetTransactionResult) (e error) {
+func (c *CAPI) SetSpendAuth(req *btcjson.SetSpendAuthCmd, resp btcjson.SpendAuthResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["gettransaction"].Result()
+	res := nrh["setspendauth"].Result()
 	res.Params = req
-	nrh["gettransaction"].Call <- res
+	nrh["setspendauth"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.GetTransactionResult):
+	case resp = <-res.Ch.(chan btcjson.SpendAuthResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) GetUnconfirmedBalance(req *btcjson.GetUnconfirmedBalanceCmd, resp float64) (e error) {
+func (c *CAPI) SetTxFee(req *btcjson.SetTxFeeCmd, resp bool) (e error) {
 	nrh := RPCHandlers
-	res := nrh["getunconfirmedbalance"].Result()
+	res := nrh["settxfee"].Result()
 	res.Params = req
-	nrh["getunconfirmedbalance"].Call <- res
+	nrh["settxfee"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan float64):
+	case resp = <-res.Ch.(chan bool):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (e error) {
+func (c *CAPI) SignMessage(req *btcjson.SignMessageCmd, resp string) (e error) {
 	nrh := RPCHandlers
-	res := nrh["help"].Result()
+	res := nrh["signmessage"].Result()
 	res.Params = req
-	nrh["help"].Call <- res
+	nrh["signmessage"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
@@ -2712,11 +6776,89 @@ func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (e error) {
 	return 
 }
 
-func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (e error) {
+func (c *CAPI) SignRawTransaction(req btcjson.SignRawTransactionCmd, resp btcjson.SignRawTransactionResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["importprivkey"].Result()
+	res := nrh["signrawtransaction"].Result()
 	res.Params = req
-	nrh["importprivkey"].Call <- res
+	nrh["signrawtransaction"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SignRawTransactionResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) SubmitSignedPSBT(req *btcjson.SubmitSignedPSBTCmd, resp string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["submitsignedpsbt"].Result()
+	res.Params = req
+	nrh["submitsignedpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) SweepAccount(req *btcjson.SweepAccountCmd, resp btcjson.SweepAccountResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["sweepaccount"].Result()
+	res.Params = req
+	nrh["sweepaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SweepAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) SweepPrivKey(req *btcjson.SweepPrivKeyCmd, resp btcjson.SweepPrivKeyResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["sweepprivkey"].Result()
+	res.Params = req
+	nrh["sweepprivkey"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SweepPrivKeyResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) SweepTimeLocked(req *btcjson.SweepTimeLockedCmd, resp btcjson.SweepTimeLockedResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["sweeptimelocked"].Result()
+	res.Params = req
+	nrh["sweeptimelocked"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SweepTimeLockedResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) TransferAccount(req *btcjson.TransferAccountCmd, resp btcjson.TransferAccountResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["transferaccount"].Result()
+	res.Params = req
+	nrh["transferaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.TransferAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) UnarchiveAccount(req *btcjson.UnarchiveAccountCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["unarchiveaccount"].Result()
+	res.Params = req
+	nrh["unarchiveaccount"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
@@ -2725,563 +6867,781 @@ func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (e error)
 	return 
 }
 
-func (c *CAPI) KeypoolRefill(req *None, resp None) (e error) {
+func (c *CAPI) ValidateAddress(req *btcjson.ValidateAddressCmd, resp btcjson.ValidateAddressWalletResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["keypoolrefill"].Result()
+	res := nrh["validateaddress"].Result()
 	res.Params = req
-	nrh["keypoolrefill"].Call <- res
+	nrh["validateaddress"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan btcjson.ValidateAddressWalletResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float64) (e error) {
+func (c *CAPI) VerifyMessage(req *btcjson.VerifyMessageCmd, resp bool) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listaccounts"].Result()
+	res := nrh["verifymessage"].Result()
 	res.Params = req
-	nrh["listaccounts"].Call <- res
+	nrh["verifymessage"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan map[string]float64):
+	case resp = <-res.Ch.(chan bool):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
+func (c *CAPI) WalletDBStats(req *btcjson.WalletDBStatsCmd, resp []btcjson.WalletDBBucketResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listaddresstransactions"].Result()
+	res := nrh["walletdbstats"].Result()
 	res.Params = req
-	nrh["listaddresstransactions"].Call <- res
+	nrh["walletdbstats"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case resp = <-res.Ch.(chan []btcjson.WalletDBBucketResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListAllTransactions(req *btcjson.ListAllTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
+func (c *CAPI) WalletIsLocked(req *None, resp bool) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listalltransactions"].Result()
+	res := nrh["walletislocked"].Result()
 	res.Params = req
-	nrh["listalltransactions"].Call <- res
+	nrh["walletislocked"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case resp = <-res.Ch.(chan bool):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListLockUnspent(req *None, resp []btcjson.TransactionInput) (e error) {
+func (c *CAPI) WalletLock(req *None, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listlockunspent"].Result()
+	res := nrh["walletlock"].Result()
 	res.Params = req
-	nrh["listlockunspent"].Call <- res
+	nrh["walletlock"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.TransactionInput):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListReceivedByAccount(req *btcjson.ListReceivedByAccountCmd, resp []btcjson.ListReceivedByAccountResult) (e error) {
+func (c *CAPI) WalletPassphrase(req *btcjson.WalletPassphraseCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listreceivedbyaccount"].Result()
+	res := nrh["walletpassphrase"].Result()
 	res.Params = req
-	nrh["listreceivedbyaccount"].Call <- res
+	nrh["walletpassphrase"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListReceivedByAccountResult):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListReceivedByAddress(req *btcjson.ListReceivedByAddressCmd, resp btcjson.ListReceivedByAddressResult) (e error) {
+func (c *CAPI) WalletPassphraseChange(req *btcjson.WalletPassphraseChangeCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listreceivedbyaddress"].Result()
+	res := nrh["walletpassphrasechange"].Result()
 	res.Params = req
-	nrh["listreceivedbyaddress"].Call <- res
+	nrh["walletpassphrasechange"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.ListReceivedByAddressResult):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListSinceBlock(req btcjson.ListSinceBlockCmd, resp btcjson.ListSinceBlockResult) (e error) {
+func (c *CAPI) WalletSelfTest(req *None, resp btcjson.WalletSelfTestResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listsinceblock"].Result()
+	res := nrh["walletselftest"].Result()
 	res.Params = req
-	nrh["listsinceblock"].Call <- res
+	nrh["walletselftest"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.ListSinceBlockResult):
+	case resp = <-res.Ch.(chan btcjson.WalletSelfTestResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListTransactions(req *btcjson.ListTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
+func (c *CAPI) WithdrawVault(req *btcjson.WithdrawVaultCmd, resp string) (e error) {
 	nrh := RPCHandlers
-	res := nrh["listtransactions"].Result()
+	res := nrh["withdrawvault"].Result()
 	res.Params = req
-	nrh["listtransactions"].Call <- res
+	nrh["withdrawvault"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ListUnspent(req *btcjson.ListUnspentCmd, resp []btcjson.ListUnspentResult) (e error) {
-	nrh := RPCHandlers
-	res := nrh["listunspent"].Result()
-	res.Params = req
-	nrh["listunspent"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan []btcjson.ListUnspentResult):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+// Client call wrappers for a CAPI client with a given Conn
+
+func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (res string, e error) {
+	var c *btcjson.AddMultisigAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.AddMultiSigAddress", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) AddPortfolioEntry(cmd ...*btcjson.AddPortfolioEntryCmd) (res None, e error) {
+	var c *btcjson.AddPortfolioEntryCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.AddPortfolioEntry", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ArchiveAccount(cmd ...*btcjson.ArchiveAccountCmd) (res None, e error) {
+	var c *btcjson.ArchiveAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ArchiveAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) BackupRemote(cmd ...*btcjson.BackupRemoteCmd) (res []btcjson.BackupTargetResult, e error) {
+	var c *btcjson.BackupRemoteCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.BackupRemote", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) CancelQueuedPSBT(cmd ...*btcjson.CancelQueuedPSBTCmd) (res None, e error) {
+	var c *btcjson.CancelQueuedPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CancelQueuedPSBT", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcjson.CreateMultiSigResult, e error) {
+	var c *btcjson.CreateMultisigCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CreateMultiSig", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateMultiSigAccount(cmd ...*btcjson.CreateMultiSigAccountCmd) (res btcjson.MultiSigAccountResult, e error) {
+	var c *btcjson.CreateMultiSigAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CreateMultiSigAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateNewAccount(cmd ...*btcjson.CreateNewAccountCmd) (res None, e error) {
+	var c *btcjson.CreateNewAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CreateNewAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateVaultAccount(cmd ...*btcjson.CreateVaultAccountCmd) (res btcjson.VaultAccountResult, e error) {
+	var c *btcjson.CreateVaultAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CreateVaultAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) DeleteAddressMeta(cmd ...*btcjson.DeleteAddressMetaCmd) (res None, e error) {
+	var c *btcjson.DeleteAddressMetaCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.DeleteAddressMeta", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) HandleDropWalletHistory(cmd ...*None) (res string, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.HandleDropWalletHistory", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) DumpPrivKey(cmd ...*btcjson.DumpPrivKeyCmd) (res string, e error) {
+	var c *btcjson.DumpPrivKeyCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.DumpPrivKey", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ExportAccountXprv(cmd ...*btcjson.ExportAccountXprvCmd) (res btcjson.ExportAccountXprvResult, e error) {
+	var c *btcjson.ExportAccountXprvCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ExportAccountXprv", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ExportLedger(cmd ...*btcjson.ExportLedgerCmd) (res string, e error) {
+	var c *btcjson.ExportLedgerCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ExportLedger", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ExportPaymentBundle(cmd ...*btcjson.ExportPaymentBundleCmd) (res btcjson.ExportPaymentBundleResult, e error) {
+	var c *btcjson.ExportPaymentBundleCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ExportPaymentBundle", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ExportWatchSet(cmd ...*None) (res btcjson.WatchSetResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ExportWatchSet", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) FreezeUnspent(cmd ...*btcjson.FreezeUnspentCmd) (res bool, e error) {
+	var c *btcjson.FreezeUnspentCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.FreezeUnspent", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GeneratePaperKey(cmd ...*btcjson.GeneratePaperKeyCmd) (res []btcjson.PaperKeyResult, e error) {
+	var c *btcjson.GeneratePaperKeyCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GeneratePaperKey", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAccount(cmd ...*btcjson.GetAccountCmd) (res string, e error) {
+	var c *btcjson.GetAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAccountAddress(cmd ...*btcjson.GetAccountAddressCmd) (res string, e error) {
+	var c *btcjson.GetAccountAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAccountAddress", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAccountXPub(cmd ...*btcjson.GetAccountXPubCmd) (res string, e error) {
+	var c *btcjson.GetAccountXPubCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAccountXPub", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddressesByAccount(cmd ...*btcjson.GetAddressesByAccountCmd) (res []string, e error) {
+	var c *btcjson.GetAddressesByAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAddressesByAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddressInfo(cmd ...*btcjson.GetAddressInfoCmd) (res btcjson.GetAddressInfoResult, e error) {
+	var c *btcjson.GetAddressInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAddressInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddressMeta(cmd ...*btcjson.GetAddressMetaCmd) (res btcjson.AddressMetaResult, e error) {
+	var c *btcjson.GetAddressMetaCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAddressMeta", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAuditLog(cmd ...*btcjson.GetAuditLogCmd) (res btcjson.GetAuditLogResult, e error) {
+	var c *btcjson.GetAuditLogCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAuditLog", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetBalance(cmd ...*btcjson.GetBalanceCmd) (res float64, e error) {
+	var c *btcjson.GetBalanceCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBalance", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (e error) {
-	nrh := RPCHandlers
-	res := nrh["renameaccount"].Result()
-	res.Params = req
-	nrh["renameaccount"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan None):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetBalanceAt(cmd ...*btcjson.GetBalanceAtCmd) (res btcjson.GetBalanceAtResult, e error) {
+	var c *btcjson.GetBalanceAtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBalanceAt", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) LockUnspent(req btcjson.LockUnspentCmd, resp bool) (e error) {
-	nrh := RPCHandlers
-	res := nrh["sendfrom"].Result()
-	res.Params = req
-	nrh["sendfrom"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan bool):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetBestBlock(cmd ...*None) (res btcjson.GetBestBlockResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBestBlock", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) SendMany(req *btcjson.SendManyCmd, resp string) (e error) {
-	nrh := RPCHandlers
-	res := nrh["sendmany"].Result()
-	res.Params = req
-	nrh["sendmany"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan string):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetBestBlockHash(cmd ...*None) (res string, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBestBlockHash", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) SendToAddress(req *btcjson.SendToAddressCmd, resp string) (e error) {
-	nrh := RPCHandlers
-	res := nrh["sendtoaddress"].Result()
-	res.Params = req
-	nrh["sendtoaddress"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan string):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetBlockCount(cmd ...*None) (res int32, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBlockCount", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) SetTxFee(req *btcjson.SetTxFeeCmd, resp bool) (e error) {
-	nrh := RPCHandlers
-	res := nrh["settxfee"].Result()
-	res.Params = req
-	nrh["settxfee"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan bool):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoWalletResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetInfo", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) SignMessage(req *btcjson.SignMessageCmd, resp string) (e error) {
-	nrh := RPCHandlers
-	res := nrh["signmessage"].Result()
-	res.Params = req
-	nrh["signmessage"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan string):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetNewAddress(cmd ...*btcjson.GetNewAddressCmd) (res string, e error) {
+	var c *btcjson.GetNewAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNewAddress", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) SignRawTransaction(req btcjson.SignRawTransactionCmd, resp btcjson.SignRawTransactionResult) (e error) {
-	nrh := RPCHandlers
-	res := nrh["signrawtransaction"].Result()
-	res.Params = req
-	nrh["signrawtransaction"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan btcjson.SignRawTransactionResult):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetNewMultiSigAddress(cmd ...*btcjson.GetNewMultiSigAddressCmd) (res btcjson.MultiSigAddressResult, e error) {
+	var c *btcjson.GetNewMultiSigAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNewMultiSigAddress", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) ValidateAddress(req *btcjson.ValidateAddressCmd, resp btcjson.ValidateAddressWalletResult) (e error) {
-	nrh := RPCHandlers
-	res := nrh["validateaddress"].Result()
-	res.Params = req
-	nrh["validateaddress"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan btcjson.ValidateAddressWalletResult):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetNewVaultAddress(cmd ...*btcjson.GetNewVaultAddressCmd) (res btcjson.VaultAddressResult, e error) {
+	var c *btcjson.GetNewVaultAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNewVaultAddress", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) VerifyMessage(req *btcjson.VerifyMessageCmd, resp bool) (e error) {
-	nrh := RPCHandlers
-	res := nrh["verifymessage"].Result()
-	res.Params = req
-	nrh["verifymessage"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan bool):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetPaymentBundle(cmd ...*btcjson.GetPaymentBundleCmd) (res btcjson.PaymentBundleResult, e error) {
+	var c *btcjson.GetPaymentBundleCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetPaymentBundle", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) WalletIsLocked(req *None, resp bool) (e error) {
-	nrh := RPCHandlers
-	res := nrh["walletislocked"].Result()
-	res.Params = req
-	nrh["walletislocked"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan bool):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetRawChangeAddress(cmd ...*btcjson.GetRawChangeAddressCmd) (res string, e error) {
+	var c *btcjson.GetRawChangeAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetRawChangeAddress", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) WalletLock(req *None, resp None) (e error) {
-	nrh := RPCHandlers
-	res := nrh["walletlock"].Result()
-	res.Params = req
-	nrh["walletlock"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan None):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetReceivedByAccount(cmd ...*btcjson.GetReceivedByAccountCmd) (res float64, e error) {
+	var c *btcjson.GetReceivedByAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetReceivedByAccount", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) WalletPassphrase(req *btcjson.WalletPassphraseCmd, resp None) (e error) {
-	nrh := RPCHandlers
-	res := nrh["walletpassphrase"].Result()
-	res.Params = req
-	nrh["walletpassphrase"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan None):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetReceivedByAddress(cmd ...*btcjson.GetReceivedByAddressCmd) (res float64, e error) {
+	var c *btcjson.GetReceivedByAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetReceivedByAddress", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-func (c *CAPI) WalletPassphraseChange(req *btcjson.WalletPassphraseChangeCmd, resp None) (e error) {
-	nrh := RPCHandlers
-	res := nrh["walletpassphrasechange"].Result()
-	res.Params = req
-	nrh["walletpassphrasechange"].Call <- res
-	select {
-	case resp = <-res.Ch.(chan None):
-	case <-time.After(c.Timeout):
-	case <-c.quit.Wait():
-	} 
-	return 
+func (r *CAPIClient) GetRescanInfo(cmd ...*None) (res btcjson.GetRescanInfoResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetRescanInfo", c, &res); E.Chk(e) {
+	}
+	return
 }
 
-// Client call wrappers for a CAPI client with a given Conn
-
-func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (res string, e error) {
-	var c *btcjson.AddMultisigAddressCmd
+func (r *CAPIClient) GetScrubInfo(cmd ...*None) (res btcjson.GetScrubInfoResult, e error) {
+	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.AddMultiSigAddress", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetScrubInfo", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcjson.CreateMultiSigResult, e error) {
-	var c *btcjson.CreateMultisigCmd
+func (r *CAPIClient) GetSpendAuth(cmd ...*None) (res btcjson.SpendAuthResult, e error) {
+	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.CreateMultiSig", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetSpendAuth", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) CreateNewAccount(cmd ...*btcjson.CreateNewAccountCmd) (res None, e error) {
-	var c *btcjson.CreateNewAccountCmd
+func (r *CAPIClient) GetTransaction(cmd ...*btcjson.GetTransactionCmd) (res btcjson.GetTransactionResult, e error) {
+	var c *btcjson.GetTransactionCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.CreateNewAccount", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetTransaction", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) HandleDropWalletHistory(cmd ...*None) (res string, e error) {
-	var c *None
+func (r *CAPIClient) GetUnconfirmedBalance(cmd ...*btcjson.GetUnconfirmedBalanceCmd) (res float64, e error) {
+	var c *btcjson.GetUnconfirmedBalanceCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.HandleDropWalletHistory", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetUnconfirmedBalance", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) DumpPrivKey(cmd ...*btcjson.DumpPrivKeyCmd) (res string, e error) {
-	var c *btcjson.DumpPrivKeyCmd
+func (r *CAPIClient) GetUTXOReport(cmd ...*btcjson.GetUTXOReportCmd) (res btcjson.GetUTXOReportResult, e error) {
+	var c *btcjson.GetUTXOReportCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.DumpPrivKey", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetUTXOReport", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetAccount(cmd ...*btcjson.GetAccountCmd) (res string, e error) {
-	var c *btcjson.GetAccountCmd
+func (r *CAPIClient) GetVaultSchedule(cmd ...*btcjson.GetVaultScheduleCmd) (res btcjson.VaultScheduleResult, e error) {
+	var c *btcjson.GetVaultScheduleCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetAccount", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.GetVaultSchedule", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetAccountAddress(cmd ...*btcjson.GetAccountAddressCmd) (res string, e error) {
-	var c *btcjson.GetAccountAddressCmd
+func (r *CAPIClient) HelpNoChainRPC(cmd ...btcjson.HelpCmd) (res string, e error) {
+	var c btcjson.HelpCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetAccountAddress", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.HelpNoChainRPC", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetAddressesByAccount(cmd ...*btcjson.GetAddressesByAccountCmd) (res []string, e error) {
-	var c *btcjson.GetAddressesByAccountCmd
+func (r *CAPIClient) ImportCoreWallet(cmd ...*btcjson.ImportCoreWalletCmd) (res btcjson.ImportCoreWalletResult, e error) {
+	var c *btcjson.ImportCoreWalletCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetAddressesByAccount", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ImportCoreWallet", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetBalance(cmd ...*btcjson.GetBalanceCmd) (res float64, e error) {
-	var c *btcjson.GetBalanceCmd
+func (r *CAPIClient) ImportPrivKey(cmd ...*btcjson.ImportPrivKeyCmd) (res None, e error) {
+	var c *btcjson.ImportPrivKeyCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetBalance", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ImportPrivKey", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetBestBlock(cmd ...*None) (res btcjson.GetBestBlockResult, e error) {
-	var c *None
+func (r *CAPIClient) ImportScriptPubKey(cmd ...*btcjson.ImportScriptPubKeyCmd) (res None, e error) {
+	var c *btcjson.ImportScriptPubKeyCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetBestBlock", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ImportScriptPubKey", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetBestBlockHash(cmd ...*None) (res string, e error) {
-	var c *None
+func (r *CAPIClient) ImportTimeLockScript(cmd ...*btcjson.ImportTimeLockScriptCmd) (res btcjson.TimeLockScriptResult, e error) {
+	var c *btcjson.ImportTimeLockScriptCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetBestBlockHash", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ImportTimeLockScript", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetBlockCount(cmd ...*None) (res int32, e error) {
+func (r *CAPIClient) KeypoolRefill(cmd ...*None) (res None, e error) {
 	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetBlockCount", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.KeypoolRefill", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoWalletResult, e error) {
-	var c *None
+func (r *CAPIClient) ListAccounts(cmd ...*btcjson.ListAccountsCmd) (res map[string]float64, e error) {
+	var c *btcjson.ListAccountsCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetInfo", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListAccounts", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetNewAddress(cmd ...*btcjson.GetNewAddressCmd) (res string, e error) {
-	var c *btcjson.GetNewAddressCmd
+func (r *CAPIClient) ListAddressMeta(cmd ...*btcjson.ListAddressMetaCmd) (res []btcjson.AddressMetaResult, e error) {
+	var c *btcjson.ListAddressMetaCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetNewAddress", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListAddressMeta", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetRawChangeAddress(cmd ...*btcjson.GetRawChangeAddressCmd) (res string, e error) {
-	var c *btcjson.GetRawChangeAddressCmd
+func (r *CAPIClient) ListAddressTransactions(cmd ...*btcjson.ListAddressTransactionsCmd) (res []btcjson.ListTransactionsResult, e error) {
+	var c *btcjson.ListAddressTransactionsCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetRawChangeAddress", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListAddressTransactions", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetReceivedByAccount(cmd ...*btcjson.GetReceivedByAccountCmd) (res float64, e error) {
-	var c *btcjson.GetReceivedByAccountCmd
+func (r *CAPIClient) ListAllTransactions(cmd ...*btcjson.ListAllTransactionsCmd) (res []btcjson.ListTransactionsResult, e error) {
+	var c *btcjson.ListAllTransactionsCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetReceivedByAccount", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListAllTransactions", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetReceivedByAddress(cmd ...*btcjson.GetReceivedByAddressCmd) (res float64, e error) {
-	var c *btcjson.GetReceivedByAddressCmd
+func (r *CAPIClient) ListDustOutputs(cmd ...*None) (res []btcjson.DustOutputResult, e error) {
+	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetReceivedByAddress", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListDustOutputs", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetTransaction(cmd ...*btcjson.GetTransactionCmd) (res btcjson.GetTransactionResult, e error) {
-	var c *btcjson.GetTransactionCmd
+func (r *CAPIClient) ListFrozen(cmd ...*None) (res []btcjson.FrozenOutputResult, e error) {
+	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetTransaction", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListFrozen", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) GetUnconfirmedBalance(cmd ...*btcjson.GetUnconfirmedBalanceCmd) (res float64, e error) {
-	var c *btcjson.GetUnconfirmedBalanceCmd
+func (r *CAPIClient) ListImmature(cmd ...*btcjson.ListImmatureCmd) (res btcjson.ListImmatureResult, e error) {
+	var c *btcjson.ListImmatureCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.GetUnconfirmedBalance", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListImmature", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) HelpNoChainRPC(cmd ...btcjson.HelpCmd) (res string, e error) {
-	var c btcjson.HelpCmd
+func (r *CAPIClient) ListInvoiceReservations(cmd ...*btcjson.ListInvoiceReservationsCmd) (res []btcjson.InvoiceReservationResult, e error) {
+	var c *btcjson.ListInvoiceReservationsCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.HelpNoChainRPC", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListInvoiceReservations", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) ImportPrivKey(cmd ...*btcjson.ImportPrivKeyCmd) (res None, e error) {
-	var c *btcjson.ImportPrivKeyCmd
+func (r *CAPIClient) ListInvoices(cmd ...*btcjson.ListInvoicesCmd) (res []btcjson.InvoiceResult, e error) {
+	var c *btcjson.ListInvoicesCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.ImportPrivKey", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListInvoices", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) KeypoolRefill(cmd ...*None) (res None, e error) {
+func (r *CAPIClient) ListLockUnspent(cmd ...*None) (res []btcjson.TransactionInput, e error) {
 	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.KeypoolRefill", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListLockUnspent", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) ListAccounts(cmd ...*btcjson.ListAccountsCmd) (res map[string]float64, e error) {
-	var c *btcjson.ListAccountsCmd
+func (r *CAPIClient) ListMultiSigAccounts(cmd ...*btcjson.ListMultiSigAccountsCmd) (res []btcjson.MultiSigAccountResult, e error) {
+	var c *btcjson.ListMultiSigAccountsCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.ListAccounts", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListMultiSigAccounts", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) ListAddressTransactions(cmd ...*btcjson.ListAddressTransactionsCmd) (res []btcjson.ListTransactionsResult, e error) {
-	var c *btcjson.ListAddressTransactionsCmd
+func (r *CAPIClient) ListPaymentBundles(cmd ...*btcjson.ListPaymentBundlesCmd) (res []btcjson.PaymentBundleResult, e error) {
+	var c *btcjson.ListPaymentBundlesCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.ListAddressTransactions", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListPaymentBundles", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) ListAllTransactions(cmd ...*btcjson.ListAllTransactionsCmd) (res []btcjson.ListTransactionsResult, e error) {
-	var c *btcjson.ListAllTransactionsCmd
+func (r *CAPIClient) ListPortfolio(cmd ...*btcjson.ListPortfolioCmd) (res btcjson.ListPortfolioResult, e error) {
+	var c *btcjson.ListPortfolioCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.ListAllTransactions", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListPortfolio", c, &res); E.Chk(e) {
 	}
 	return
 }
 
-func (r *CAPIClient) ListLockUnspent(cmd ...*None) (res []btcjson.TransactionInput, e error) {
+func (r *CAPIClient) ListPortfolioTransactions(cmd ...*btcjson.ListPortfolioTransactionsCmd) (res []btcjson.PortfolioTransactionResult, e error) {
+	var c *btcjson.ListPortfolioTransactionsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListPortfolioTransactions", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListQueuedPSBTs(cmd ...*None) (res []btcjson.QueuedPSBTResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if e = r.Call("CAPI.ListLockUnspent", c, &res); E.Chk(e) {
+	if e = r.Call("CAPI.ListQueuedPSBTs", c, &res); E.Chk(e) {
 	}
 	return
 }
@@ -3326,6 +7686,26 @@ func (r *CAPIClient) ListTransactions(cmd ...*btcjson.ListTransactionsCmd) (res
 	return
 }
 
+func (r *CAPIClient) ListTransactionsPage(cmd ...*btcjson.ListTransactionsPageCmd) (res btcjson.ListTransactionsPageResult, e error) {
+	var c *btcjson.ListTransactionsPageCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListTransactionsPage", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListUnlockAttempts(cmd ...*btcjson.ListUnlockAttemptsCmd) (res []btcjson.UnlockAttemptResult, e error) {
+	var c *btcjson.ListUnlockAttemptsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListUnlockAttempts", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ListUnspent(cmd ...*btcjson.ListUnspentCmd) (res []btcjson.ListUnspentResult, e error) {
 	var c *btcjson.ListUnspentCmd
 	if len(cmd) > 0 {
@@ -3336,6 +7716,56 @@ func (r *CAPIClient) ListUnspent(cmd ...*btcjson.ListUnspentCmd) (res []btcjson.
 	return
 }
 
+func (r *CAPIClient) ListVaultAccounts(cmd ...*btcjson.ListVaultAccountsCmd) (res []btcjson.VaultAccountResult, e error) {
+	var c *btcjson.ListVaultAccountsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListVaultAccounts", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) OverrideDust(cmd ...*btcjson.OverrideDustCmd) (res bool, e error) {
+	var c *btcjson.OverrideDustCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.OverrideDust", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) PreviewSend(cmd ...*btcjson.PreviewSendCmd) (res btcjson.PreviewSendResult, e error) {
+	var c *btcjson.PreviewSendCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.PreviewSend", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ReleaseInvoiceAddress(cmd ...*btcjson.ReleaseInvoiceAddressCmd) (res bool, e error) {
+	var c *btcjson.ReleaseInvoiceAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ReleaseInvoiceAddress", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) RemovePortfolioEntry(cmd ...*btcjson.RemovePortfolioEntryCmd) (res None, e error) {
+	var c *btcjson.RemovePortfolioEntryCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.RemovePortfolioEntry", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None, e error) {
 	var c *btcjson.RenameAccountCmd
 	if len(cmd) > 0 {
@@ -3346,6 +7776,16 @@ func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None,
 	return
 }
 
+func (r *CAPIClient) ReserveInvoiceAddress(cmd ...*btcjson.ReserveInvoiceAddressCmd) (res btcjson.InvoiceReservationResult, e error) {
+	var c *btcjson.ReserveInvoiceAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ReserveInvoiceAddress", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) LockUnspent(cmd ...btcjson.LockUnspentCmd) (res bool, e error) {
 	var c btcjson.LockUnspentCmd
 	if len(cmd) > 0 {
@@ -3376,6 +7816,36 @@ func (r *CAPIClient) SendToAddress(cmd ...*btcjson.SendToAddressCmd) (res string
 	return
 }
 
+func (r *CAPIClient) SetAddressMeta(cmd ...*btcjson.SetAddressMetaCmd) (res btcjson.AddressMetaResult, e error) {
+	var c *btcjson.SetAddressMetaCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetAddressMeta", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SetInvoiceIssuance(cmd ...*btcjson.SetInvoiceIssuanceCmd) (res bool, e error) {
+	var c *btcjson.SetInvoiceIssuanceCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetInvoiceIssuance", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SetSpendAuth(cmd ...*btcjson.SetSpendAuthCmd) (res btcjson.SpendAuthResult, e error) {
+	var c *btcjson.SetSpendAuthCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetSpendAuth", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetTxFee(cmd ...*btcjson.SetTxFeeCmd) (res bool, e error) {
 	var c *btcjson.SetTxFeeCmd
 	if len(cmd) > 0 {
@@ -3406,6 +7876,66 @@ func (r *CAPIClient) SignRawTransaction(cmd ...btcjson.SignRawTransactionCmd) (r
 	return
 }
 
+func (r *CAPIClient) SubmitSignedPSBT(cmd ...*btcjson.SubmitSignedPSBTCmd) (res string, e error) {
+	var c *btcjson.SubmitSignedPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SubmitSignedPSBT", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SweepAccount(cmd ...*btcjson.SweepAccountCmd) (res btcjson.SweepAccountResult, e error) {
+	var c *btcjson.SweepAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SweepAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SweepPrivKey(cmd ...*btcjson.SweepPrivKeyCmd) (res btcjson.SweepPrivKeyResult, e error) {
+	var c *btcjson.SweepPrivKeyCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SweepPrivKey", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SweepTimeLocked(cmd ...*btcjson.SweepTimeLockedCmd) (res btcjson.SweepTimeLockedResult, e error) {
+	var c *btcjson.SweepTimeLockedCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SweepTimeLocked", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) TransferAccount(cmd ...*btcjson.TransferAccountCmd) (res btcjson.TransferAccountResult, e error) {
+	var c *btcjson.TransferAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.TransferAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) UnarchiveAccount(cmd ...*btcjson.UnarchiveAccountCmd) (res None, e error) {
+	var c *btcjson.UnarchiveAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.UnarchiveAccount", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ValidateAddress(cmd ...*btcjson.ValidateAddressCmd) (res btcjson.ValidateAddressWalletResult, e error) {
 	var c *btcjson.ValidateAddressCmd
 	if len(cmd) > 0 {
@@ -3426,6 +7956,16 @@ func (r *CAPIClient) VerifyMessage(cmd ...*btcjson.VerifyMessageCmd) (res bool,
 	return
 }
 
+func (r *CAPIClient) WalletDBStats(cmd ...*btcjson.WalletDBStatsCmd) (res []btcjson.WalletDBBucketResult, e error) {
+	var c *btcjson.WalletDBStatsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.WalletDBStats", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) WalletIsLocked(cmd ...*None) (res bool, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3466,3 +8006,23 @@ func (r *CAPIClient) WalletPassphraseChange(cmd ...*btcjson.WalletPassphraseChan
 	return
 }
 
+func (r *CAPIClient) WalletSelfTest(cmd ...*None) (res btcjson.WalletSelfTestResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.WalletSelfTest", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) WithdrawVault(cmd ...*btcjson.WithdrawVaultCmd) (res string, e error) {
+	var c *btcjson.WithdrawVaultCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.WithdrawVault", c, &res); E.Chk(e) {
+	}
+	return
+}
+
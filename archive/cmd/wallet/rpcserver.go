@@ -215,6 +215,7 @@ func startRPCServers(cx *state.State, walletLoader *Loader) (*Server, error) {
 			Password:            cx.Config.Password.V(),
 			MaxPOSTClients:      int64(cx.Config.WalletRPCMaxClients.V()),
 			MaxWebsocketClients: int64(cx.Config.WalletRPCMaxWebsockets.V()),
+			CompatLegacy:        cx.Config.CompatLegacy.True(),
 		}
 		legacyServer = NewServer(&opts, walletLoader, listeners, nil)
 	}
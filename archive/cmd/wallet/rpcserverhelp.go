@@ -4,55 +4,116 @@ package wallet
 
 func HelpDescsEnUS() map[string]string {
 	return map[string]string{
-		"addmultisigaddress":      "addmultisigaddress nrequired [\"key\",...] (\"account\")\n\nGenerates and imports a multisig address and redeeming script to the 'imported' account.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n3. account   (string, optional)          DEPRECATED -- Unused (all imported addresses belong to the imported account)\n\nResult:\n\"value\" (string) The imported pay-to-script-hash address\n",
-		"createmultisig":          "createmultisig nrequired [\"key\",...]\n\nGenerate a multisig address and redeem script.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n{\n \"address\": \"value\",      (string) The generated pay-to-script-hash address\n \"redeemScript\": \"value\", (string) The script required to redeem outputs paid to the multisig address\n}                         \n",
-		"dumpprivkey":             "dumpprivkey \"address\"\n\nReturns the private key in WIF encoding that controls some wallet address.\n\nArguments:\n1. address (string, required) The address to return a private key for\n\nResult:\n\"value\" (string) The WIF-encoded private key\n",
-		"getaccount":              "getaccount \"address\"\n\nDEPRECATED -- Lookup the account name that some wallet address belongs to.\n\nArguments:\n1. address (string, required) The address to query the account for\n\nResult:\n\"value\" (string) The name of the account that 'address' belongs to\n",
-		"getaccountaddress":       "getaccountaddress \"account\"\n\nDEPRECATED -- Returns the most recent external payment address for an account that has not been seen publicly.\nA new address is generated for the account if the most recently generated address has been seen on the blockchain or in mempool.\n\nArguments:\n1. account (string, required) The account of the returned address\n\nResult:\n\"value\" (string) The unused address for 'account'\n",
-		"getaddressesbyaccount":   "getaddressesbyaccount \"account\"\n\nDEPRECATED -- Returns all addresses strings controlled by a single account.\n\nArguments:\n1. account (string, required) Account name to fetch addresses for\n\nResult:\n[\"value\",...] (array of string) All addresses controlled by 'account'\n",
-		"getbalance":              "getbalance (\"account\" minconf=1)\n\nCalculates and returns the balance of one or all accounts.\n\nArguments:\n1. account (string, optional)             DEPRECATED -- The account name to query the balance for, or \"*\" to consider all accounts (default=\"*\")\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an unspent output's value is included in the balance\n\nResult (account != \"*\"):\nn.nnn (numeric) The balance of 'account' valued in bitcoin\n\nResult (account = \"*\"):\nn.nnn (numeric) The balance of all accounts valued in bitcoin\n",
-		"getbestblockhash":        "getbestblockhash\n\nReturns the hash of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The hash of the most recent synced-to block\n",
-		"getblockcount":           "getblockcount\n\nReturns the blockchain height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\nn.nnn (numeric) The blockchain height of the most recent synced-to block\n",
-		"getinfo":                 "getinfo\n\nReturns a JSON object containing various state info.\n\nArguments:\nNone\n\nResult:\n{\n \"version\": n,          (numeric) The version of the server\n \"protocolversion\": n,  (numeric) The latest supported protocol version\n \"walletversion\": n,    (numeric) The version of the address manager database\n \"balance\": n.nnn,      (numeric) The balance of all accounts calculated with one block confirmation\n \"blocks\": n,           (numeric) The number of blocks processed\n \"timeoffset\": n,       (numeric) The time offset\n \"connections\": n,      (numeric) The number of connected peers\n \"proxy\": \"value\",      (string)  The proxy used by the server\n \"difficulty\": n.nnn,   (numeric) The current target difficulty\n \"testnet\": true|false, (boolean) Whether or not server is using testnet\n \"keypoololdest\": n,    (numeric) Unset\n \"keypoolsize\": n,      (numeric) Unset\n \"unlocked_until\": n,   (numeric) Unset\n \"paytxfee\": n.nnn,     (numeric) The increment used each time more fee is required for an authored transaction\n \"relayfee\": n.nnn,     (numeric) The minimum relay fee for non-free transactions in DUO/KB\n \"errors\": \"value\",     (string)  Any current errors\n}                       \n",
-		"getnewaddress":           "getnewaddress (\"account\")\n\nGenerates and returns a new payment address.\n\nArguments:\n1. account (string, optional) DEPRECATED -- Account name the new address will belong to (default=\"default\")\n\nResult:\n\"value\" (string) The payment address\n",
-		"getrawchangeaddress":     "getrawchangeaddress (\"account\")\n\nGenerates and returns a new internal payment address for use as a change address in raw transactions.\n\nArguments:\n1. account (string, optional) Account name the new internal address will belong to (default=\"default\")\n\nResult:\n\"value\" (string) The internal payment address\n",
-		"getreceivedbyaccount":    "getreceivedbyaccount \"account\" (minconf=1)\n\nDEPRECATED -- Returns the total amount received by addresses of some account, including spent outputs.\n\nArguments:\n1. account (string, required)             Account name to query total received amount for\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
-		"getreceivedbyaddress":    "getreceivedbyaddress \"address\" (minconf=1)\n\nReturns the total amount received by a single address, including spent outputs.\n\nArguments:\n1. address (string, required)             Payment address which received outputs to include in total\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
-		"gettransaction":          "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value, or 0 if 'txid' is not a sent transaction\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The included fee for a sent transaction\n  \"vout\": n,                       (numeric)         The transaction output index\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n}                                  \n",
-		"help":                    "help (\"command\")\n\nReturns a list of all commands or help for a specified command.\n\nArguments:\n1. command (string, optional) The command to retrieve help for\n\nResult (no command provided):\n\"value\" (string) List of commands\n\nResult (command specified):\n\"value\" (string) Help for specified command\n",
-		"importprivkey":           "importprivkey \"privkey\" (\"label\" rescan=true)\n\nImports a WIF-encoded private key to the 'imported' account.\n\nArguments:\n1. privkey (string, required)                The WIF-encoded private key\n2. label   (string, optional)                Unused (must be unset or 'imported')\n3. rescan  (boolean, optional, default=true) Rescan the blockchain (since the genesis block) for outputs controlled by the imported key\n\nResult:\nNothing\n",
-		"keypoolrefill":           "keypoolrefill (newsize=100)\n\nDEPRECATED -- This request does nothing since no keypool is maintained.\n\nArguments:\n1. newsize (numeric, optional, default=100) Unused\n\nResult:\nNothing\n",
-		"listaccounts":            "listaccounts (minconf=1)\n\nDEPRECATED -- Returns a JSON object of all accounts and their balances.\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an unspent output's value is included in the balance\n\nResult:\n{\n \"The account name\": The account balance valued in bitcoin, (object) JSON object with account names as keys and bitcoin amounts as values\n ...\n}\n",
-		"listlockunspent":         "listlockunspent\n\nReturns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n",
-		"listreceivedbyaccount":   "listreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\n\nDEPRECATED -- Returns a JSON array of objects listing all accounts and the total amount received by each account.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\", (string)  The name of the account\n \"amount\": n.nnn,    (numeric) Total amount received by payment addresses of the account valued in bitcoin\n \"confirmations\": n, (numeric) Number of block confirmations of the most recent transaction relevant to the account\n},...]\n",
-		"listreceivedbyaddress":   "listreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\n\nReturns a JSON array of objects listing wallet payment addresses and their total received amounts.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\",              (string)          DEPRECATED -- Unset\n \"address\": \"value\",              (string)          The payment address\n \"amount\": n.nnn,                 (numeric)         Total amount received by the payment address valued in bitcoin\n \"confirmations\": n,              (numeric)         Number of block confirmations of the most recent transaction relevant to the address\n \"txids\": [\"value\",...],          (array of string) Transaction hashes of all transactions involving this address\n \"involvesWatchonly\": true|false, (boolean)         Unset\n},...]\n",
-		"listsinceblock":          "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"bip125-replaceable\": \"value\",    (string)          Unset\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          Unset\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
-		"listtransactions":        "listtransactions (\"account\" count=10 from=0 includewatchonly=false)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. account          (string, optional)                 DEPRECATED -- Unused (must be unset or \"*\")\n2. count            (numeric, optional, default=10)    Maximum number of transactions to create results from\n3. from             (numeric, optional, default=0)     Number of transactions to skip before results are created\n4. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"listunspent":             "listunspent (minconf=1 maxconf=9999999 [\"address\",...])\n\nReturns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n\nArguments:\n1. minconf   (numeric, optional, default=1)       Minimum number of block confirmations required before a transaction output is considered\n2. maxconf   (numeric, optional, default=9999999) Maximum number of block confirmations required before a transaction output is excluded\n3. addresses (array of string, optional)          If set, limits the returned details to unspent outputs received by any of these payment addresses\n\nResult:\n{\n \"txid\": \"value\",         (string)  The transaction hash of the referenced output\n \"vout\": n,               (numeric) The output index of the referenced output\n \"address\": \"value\",      (string)  The payment address that received the output\n \"account\": \"value\",      (string)  The account associated with the receiving payment address\n \"scriptPubKey\": \"value\", (string)  The output script encoded as a hexadecimal string\n \"redeemScript\": \"value\", (string)  Unset\n \"amount\": n.nnn,         (numeric) The amount of the output valued in bitcoin\n \"confirmations\": n,      (numeric) The number of block confirmations of the transaction\n \"spendable\": true|false, (boolean) Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)\n}                         \n",
-		"lockunspent":             "lockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\n\nLocks or unlocks an unspent output.\nLocked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nLocked outputs are volatile and are not saved across wallet restarts.\nIf unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.\n\nArguments:\n1. unlock       (boolean, required)         True to unlock outputs, false to lock\n2. transactions (array of object, required) Transaction outputs to lock or unlock\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
-		"sendfrom":                "sendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\")\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required)             Account to pick unspent outputs from\n2. toaddress   (string, required)             Address to pay\n3. amount      (numeric, required)            Amount to send to the payment address valued in bitcoin\n4. minconf     (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n5. comment     (string, optional)             Unused\n6. commentto   (string, optional)             Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendmany":                "sendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\")\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required) DEPRECATED -- Account to pick unspent outputs from\n2. amounts     (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. minconf (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. comment (string, optional)             Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendtoaddress":           "sendtoaddress \"address\" amount (\"comment\" \"commentto\")\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address   (string, required)  Address to pay\n2. amount    (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment   (string, optional)  Unused\n4. commentto (string, optional)  Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"settxfee":                "settxfee amount\n\nModify the increment used each time more fee is required for an authored transaction.\n\nArguments:\n1. amount (numeric, required) The new fee increment valued in bitcoin\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
-		"signmessage":             "signmessage \"address\" \"message\"\n\nSigns a message using the private key of a payment address.\n\nArguments:\n1. address (string, required) Payment address of private key used to sign the message with\n2. message (string, required) Message to sign\n\nResult:\n\"value\" (string) The signed message encoded as a base64 string\n",
-		"signrawtransaction":      "signrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\n\nSigns transaction inputs using private keys from this wallet and request.\nThe valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n\nArguments:\n1. rawtx    (string, required)                Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string\n2. inputs   (array of object, optional)       Additional data regarding inputs that this wallet may not be tracking\n3. privkeys (array of string, optional)       Additional WIF-encoded private keys to use when creating signatures\n4. flags    (string, optional, default=\"ALL\") Sighash flags\n\nResult:\n{\n \"hex\": \"value\",         (string)          The resulting transaction encoded as a hexadecimal string\n \"complete\": true|false, (boolean)         Whether all input signatures have been created\n \"errors\": [{            (array of object) Script verification errors (if exists)\n  \"txid\": \"value\",       (string)          The transaction hash of the referenced previous output\n  \"vout\": n,             (numeric)         The output index of the referenced previous output\n  \"scriptSig\": \"value\",  (string)          The hex-encoded signature script\n  \"sequence\": n,         (numeric)         Script sequence number\n  \"error\": \"value\",      (string)          Verification or signing error related to the input\n },...],                                   \n}                        \n",
-		"validateaddress":         "validateaddress \"address\"\n\nVerify that an address is valid.\nExtra details are returned if the address is controlled by this wallet.\nThe following fields are valid only when the address is controlled by this wallet (ismine=true): isscript, pubkey, iscompressed, account, addresses, hex, script, and sigsrequired.\nThe following fields are only valid when address has an associated public key: pubkey, iscompressed.\nThe following fields are only valid when address is a pay-to-script-hash address: addresses, hex, and script.\nIf the address is a multisig address controlled by this wallet, the multisig fields will be left unset if the wallet is locked since the redeem script cannot be decrypted.\n\nArguments:\n1. address (string, required) Address to validate\n\nResult:\n{\n \"isvalid\": true|false,      (boolean)         Whether or not the address is valid\n \"address\": \"value\",         (string)          The payment address (only when isvalid is true)\n \"ismine\": true|false,       (boolean)         Whether this address is controlled by the wallet (only when isvalid is true)\n \"iswatchonly\": true|false,  (boolean)         Unset\n \"isscript\": true|false,     (boolean)         Whether the payment address is a pay-to-script-hash address (only when isvalid is true)\n \"pubkey\": \"value\",          (string)          The associated public key of the payment address, if any (only when isvalid is true)\n \"iscompressed\": true|false, (boolean)         Whether the address was created by hashing a compressed public key, if any (only when isvalid is true)\n \"account\": \"value\",         (string)          The account this payment address belongs to (only when isvalid is true)\n \"addresses\": [\"value\",...], (array of string) All associated payment addresses of the script if address is a multisig address (only when isvalid is true)\n \"hex\": \"value\",             (string)          The redeem script \n \"script\": \"value\",          (string)          The class of redeem script for a multisig address\n \"sigsrequired\": n,          (numeric)         The number of required signatures to redeem outputs to the multisig address\n}                            \n",
-		"verifymessage":           "verifymessage \"address\" \"signature\" \"message\"\n\nVerify a message was signed with the associated private key of some address.\n\nArguments:\n1. address   (string, required) Address used to sign message\n2. signature (string, required) The signature to verify\n3. message   (string, required) The message to verify\n\nResult:\ntrue|false (boolean) Whether the message was signed with the private key of 'address'\n",
-		"walletlock":              "walletlock\n\nLock the wallet.\n\nArguments:\nNone\n\nResult:\nNothing\n",
-		"walletpassphrase":        "walletpassphrase \"passphrase\" timeout\n\nUnlock the wallet.\n\nArguments:\n1. passphrase (string, required)  The wallet passphrase\n2. timeout    (numeric, required) The number of seconds to wait before the wallet automatically locks\n\nResult:\nNothing\n",
-		"walletpassphrasechange":  "walletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\n\nChange the wallet passphrase.\n\nArguments:\n1. oldpassphrase (string, required) The old wallet passphrase\n2. newpassphrase (string, required) The new wallet passphrase\n\nResult:\nNothing\n",
-		"createnewaccount":        "createnewaccount \"account\"\n\nCreates a new account.\nThe wallet must be unlocked for this request to succeed.\n\nArguments:\n1. account (string, required) Name of the new account\n\nResult:\nNothing\n",
-		"exportwatchingwallet":    "exportwatchingwallet (\"account\" download=false)\n\nCreates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.\n\nArguments:\n1. account  (string, optional)                 Unused (must be unset or \"*\")\n2. download (boolean, optional, default=false) Unused\n\nResult:\n\"value\" (string) The watching-only database encoded as a base64 string\n",
-		"getbestblock":            "getbestblock\n\nReturns the hash and height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n{\n \"hash\": \"value\", (string)  The hash of the block\n \"height\": n,     (numeric) The blockchain height of the block\n}                 \n",
-		"getunconfirmedbalance":   "getunconfirmedbalance (\"account\")\n\nCalculates the unspent output value of all unmined transaction outputs for an account.\n\nArguments:\n1. account (string, optional) The account to query the unconfirmed balance for (default=\"default\")\n\nResult:\nn.nnn (numeric) Total amount of all unmined unspent outputs of the account valued in bitcoin.\n",
-		"listaddresstransactions": "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"listalltransactions":     "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"renameaccount":           "renameaccount \"oldaccount\" \"newaccount\"\n\nRenames an account.\n\nArguments:\n1. oldaccount (string, required) The old account name to rename\n2. newaccount (string, required) The new name for the account\n\nResult:\nNothing\n",
-		"walletislocked":          "walletislocked\n\nReturns whether or not the wallet is locked.\n\nArguments:\nNone\n\nResult:\ntrue|false (boolean) Whether the wallet is locked\n",
+		"addmultisigaddress":        "addmultisigaddress nrequired [\"key\",...] (\"account\")\n\nGenerates and imports a multisig address and redeeming script to the 'imported' account.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n3. account   (string, optional)          DEPRECATED -- Unused (all imported addresses belong to the imported account)\n\nResult:\n\"value\" (string) The imported pay-to-script-hash address\n",
+		"addportfolioentry":         "addportfolioentry \"name\" [\"descriptor\",...] (range=1000 rescan=true)\n\nAdds a cold wallet to the portfolio, which watches the output scripts of its public descriptors and adds up the funds they hold apart from the balance of the wallet.\nDescriptors or extended keys with private keys are refused. Requires a websocket connection to the chain server.\n\nArguments:\n1. name        (string, required)                The name of the entry\n2. descriptors (array of string, required)       The output script descriptors of the cold wallet, an extended public key standing for its pay to public key hash receiving and change chains\n3. range       (numeric, optional, default=1000) The number of scripts derived from each ranged descriptor\n4. rescan      (boolean, optional, default=true) Search the blockchain (since the genesis block) in the background for outputs paying to the scripts, or watch them only from the current block\n\nResult:\nNothing\n",
+		"archiveaccount":            "archiveaccount \"account\"\n\nArchives an account, which leaves it out of listaccounts and the total balance of the wallet for wallets with many accounts no longer used.\nNothing else about the account changes: its addresses are still watched and rescanned, its balance can be asked for by name and spent, and it can be unarchived with unarchiveaccount.\nThe default and imported accounts cannot be archived.\n\nArguments:\n1. account (string, required) The name of the account to archive\n\nResult:\nNothing\n",
+		"backupremote":              "backupremote (force=false)\n\nBacks the wallet up to the remote backup targets it has changed since it was last backed up to, and returns the outcome of the latest backup to each target.\nBackups are encrypted with a key derived from the seed of the wallet, which is derived when the wallet is first unlocked after it is started.\n\nArguments:\n1. force (boolean, optional, default=false) Back the wallet up to every target even if it has not changed\n\nResult:\n[{\n \"target\": \"value\", (string)  The URL of the backup target without its credentials\n \"time\": n,         (numeric) The time the wallet was last backed up to the target in seconds since 1 Jan 1970 GMT, or 0 if it has not been since the wallet was started\n \"size\": n,         (numeric) The size in bytes of the last backup\n \"error\": \"value\",  (string)  Why the latest backup to the target failed, if it did\n},...]\n",
+		"cancelqueuedpsbt":          "cancelqueuedpsbt \"id\"\n\nRemoves a transaction waiting for its signed PSBT from the signing queue of a watching-only wallet, unlocking its inputs.\n\nArguments:\n1. id (string, required) The ID of the queued transaction, the hash of the unsigned transaction\n\nResult:\nNothing\n",
+		"createmultisig":            "createmultisig nrequired [\"key\",...]\n\nGenerate a multisig address and redeem script.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n{\n \"address\": \"value\",      (string) The generated pay-to-script-hash address\n \"redeemScript\": \"value\", (string) The script required to redeem outputs paid to the multisig address\n}                         \n",
+		"createmultisigaccount":     "createmultisigaccount \"name\" nrequired [\"xpub\",...]\n\nAdds an M-of-N multisig account whose deposit addresses pay to a P2SH multisig script of keys derived from the cosigner extended public keys.\nCosigner keys that are the extended public key of an account of the wallet are marked as ours, and the wallet signs for them.\n\nArguments:\n1. name      (string, required)          The name of the multisig account\n2. nrequired (numeric, required)         The number of signatures required to spend outputs paid to the account\n3. xpubs     (array of string, required) The extended public keys of the accounts of the cosigners\n\nResult:\n{\n \"name\": \"value\",     (string)          The name of the multisig account\n \"required\": n,       (numeric)         The number of signatures required to spend outputs paid to the account\n \"cosigners\": [{      (array of object) The cosigners of the account\n  \"xpub\": \"value\",    (string)          The extended public key of the account of the cosigner\n  \"ours\": true|false, (boolean)         Whether the key is the extended public key of an account of the wallet\n  \"account\": \"value\", (string)          The wallet account of the key when it is ours\n },...],                                \n \"nextindex\": n,      (numeric)         The index of the next deposit address\n}                     \n",
+		"createvaultaccount":        "createvaultaccount \"name\" (lockheight=0 delay=0)\n\nAdds an account whose deposit addresses pay to a script that can only be spent once the chain reaches the lock height of the address, protecting its funds from a thief with the keys of the wallet until then.\nEvery address is locked to the lock height when it is set, or else until the delay after the height it is generated at, as the chain has no relative lock times. The wallet must be unlocked.\n\nArguments:\n1. name       (string, required)             The name of the vault account\n2. lockheight (numeric, optional, default=0) The block height every deposit address is locked until, or 0 to use the delay\n3. delay      (numeric, optional, default=0) The number of blocks each deposit address is locked for after it is generated, when there is no lock height\n\nResult:\n{\n \"name\": \"value\", (string)  The name of the vault account\n \"lockheight\": n, (numeric) The block height every deposit address is locked until\n \"delay\": n,      (numeric) The number of blocks each deposit address is locked for after it is generated\n}                 \n",
+		"deleteaddressmeta":         "deleteaddressmeta \"address\"\n\nRemoves the metadata stored in the wallet for an address.\n\nArguments:\n1. address (string, required) The address to remove the metadata of\n\nResult:\nNothing\n",
+		"dumpprivkey":               "dumpprivkey \"address\"\n\nReturns the private key in WIF encoding that controls some wallet address.\n\nArguments:\n1. address (string, required) The address to return a private key for\n\nResult:\n\"value\" (string) The WIF-encoded private key\n",
+		"exportaccountxprv":         "exportaccountxprv \"account\" \"password\" (plaintext=false)\n\nReturns the extended private key of an account so it can be restored in other wallet software.\nThe wallet must be unlocked, and the command must be enabled with allowxprvexport and an xprvexportpass set in the wallet configuration.\n\nArguments:\n1. account   (string, required)                 The name of the account to export\n2. password  (string, required)                 The xprv export password, which is separate from the RPC password\n3. plaintext (boolean, optional, default=false) Return the key unencrypted instead of encrypted with the xprv export password\n\nResult:\n{\n \"account\": \"value\",      (string)  The name of the exported account\n \"encrypted\": true|false, (boolean) Whether xprv is encrypted with the xprv export password\n \"xprv\": \"value\",         (string)  The extended private key of the account, or the hex of the encrypted key if it is encrypted\n \"keyparams\": \"value\",    (string)  The hex of the salt and scrypt parameters that derive the encryption key from the xprv export password, unset if the key is not encrypted\n}                         \n",
+		"exportledger":              "exportledger (format=\"ledger\" commodity=\"DUO\")\n\nReturns the history of the wallet as double-entry journal entries for importing into plaintext accounting tools.\nThe balance of each wallet account is kept under Assets:Wallet, payments are posted under Income:Received and Expenses:Payments by the label of the other address, and fees to Expenses:Fees.\n\nArguments:\n1. format    (string, optional, default=\"ledger\") The journal format, ledger (also read by hledger) or beancount\n2. commodity (string, optional, default=\"DUO\")    The commodity name of the amounts\n\nResult:\n\"value\" (string) The journal entries, oldest first\n",
+		"exportpaymentbundle":       "exportpaymentbundle \"account\" [{\"label\":\"value\",\"amount\":n.nnn},...] (expires=0 \"signaddress\")\n\nMakes a fresh receiving address of an account for each payment request and returns them as a signed JSON bundle for an offline point of sale device.\nEach address is recorded as a payment request with its label and amount, so the settlement of the bundle can be followed with getpaymentbundle and its addresses are listed by listinvoices. The wallet must be unlocked.\n\nArguments:\n1. account  (string, required)          The account to make the addresses in\n2. requests (array of object, required) The payment requests, one for each address\n[{\n \"label\": \"value\", (string)  The label of the payment, shown by the device\n \"amount\": n.nnn,  (numeric) The amount requested valued in bitcoin, or 0 to accept any amount\n},...]\n3. expires     (numeric, optional, default=0) The time the payment requests expire in seconds since 1 Jan 1970 GMT, or 0 if they do not expire\n4. signaddress (string, optional)             The address of the wallet whose key signs the bundle, by default the first receiving address of the account so every bundle of the account is signed with the same key\n\nResult:\n{\n \"id\": \"value\",          (string) The ID of the bundle\n \"bundle\": \"value\",      (string) The JSON document of the bundle with the network, account, signing address and expiry, and the address, label, amount and payment URI of each request\n \"signaddress\": \"value\", (string) The address whose key signed the bundle\n \"signature\": \"value\",   (string) The signature of the bundle document as made by signmessage with the signing address, which verifymessage checks\n}                        \n",
+		"exportwatchset":            "exportwatchset\n\nReturns the output scripts of the wallet and its unspent outputs as of the block it is synced to, holding no keys.\nSave the result to the watch set file of a 'pod watch' process, on another machine, which alerts through its webhook or command when the funds of the wallet move.\n\nArguments:\nNone\n\nResult:\n{\n \"network\": \"value\",       (string)          The network the wallet is on\n \"height\": n,              (numeric)         The height of the block the wallet is synced to\n \"hash\": \"value\",          (string)          The hash of the block the wallet is synced to\n \"scripts\": [\"value\",...], (array of string) The hex of the output scripts of all addresses of the wallet and the scripts it watches\n \"outpoints\": [{           (array of object) The unspent outputs paying to the scripts\n  \"txid\": \"value\",         (string)          The hash of the transaction of the output\n  \"vout\": n,               (numeric)         The index of the output in the transaction\n  \"amount\": n.nnn,         (numeric)         The value of the output\n  \"script\": \"value\",       (string)          The hex of the output script\n },...],                                     \n}                          \n",
+		"freezeunspent":             "freezeunspent unfreeze [{\"txid\":\"value\",\"vout\":n},...] (\"reason\")\n\nFreezes or unfreezes outputs, with the reason they are frozen for.\nFrozen outputs are never chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nUnlike the locks made with lockunspent, frozen outputs are saved in the wallet and are not unfrozen by unlocking all outputs.\n\nArguments:\n1. unfreeze     (boolean, required)         True to unfreeze outputs, false to freeze\n2. transactions (array of object, required) Transaction outputs to freeze or unfreeze\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n3. reason (string, optional) Why the outputs are frozen, such as \"under dispute\" or \"dusting attack output\", which replaces the reason of outputs that are already frozen\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"generatepaperkey":          "generatepaperkey (count=1)\n\nGenerates new key pairs that are not stored in the wallet, for printing on paper wallets or giving away.\nTheir funds can be moved into the wallet later with sweepprivkey.\n\nArguments:\n1. count (numeric, optional, default=1) Number of key pairs to generate, at most 100\n\nResult:\n[{\n \"address\": \"value\",   (string) The pay to public key hash address of the key\n \"privkey\": \"value\",   (string) The private key in WIF format\n \"pubkey\": \"value\",    (string) The hex-encoded compressed public key\n \"addressqr\": \"value\", (string) The text to encode in the QR code of the address, a payment URI\n \"privkeyqr\": \"value\", (string) The text to encode in the QR code of the private key\n},...]\n",
+		"getaccount":                "getaccount \"address\"\n\nDEPRECATED -- Lookup the account name that some wallet address belongs to.\n\nArguments:\n1. address (string, required) The address to query the account for\n\nResult:\n\"value\" (string) The name of the account that 'address' belongs to\n",
+		"getaccountaddress":         "getaccountaddress \"account\"\n\nDEPRECATED -- Returns the most recent external payment address for an account that has not been seen publicly.\nA new address is generated for the account if the most recently generated address has been seen on the blockchain or in mempool.\n\nArguments:\n1. account (string, required) The account of the returned address\n\nResult:\n\"value\" (string) The unused address for 'account'\n",
+		"getaccountxpub":            "getaccountxpub \"account\"\n\nReturns the extended public key of an account, which is given to the other cosigners of a multisig account.\n\nArguments:\n1. account (string, required) The name of the account\n\nResult:\n\"value\" (string) The extended public key of the account\n",
+		"getaddressinfo":            "getaddressinfo \"address\"\n\nReturns where an address is kept in the wallet, found from the index of the output scripts paying to the addresses of the wallet.\n\nArguments:\n1. address (string, required) The address to return the information of\n\nResult:\n{\n \"address\": \"value\",        (string)  The address the information is for\n \"scriptPubKey\": \"value\",   (string)  The hex-encoded output script paying to the address\n \"ismine\": true|false,      (boolean) Whether the address is an address of the wallet\n \"iswatchonly\": true|false, (boolean) Whether the output script of the address is watched by the wallet\n \"isscript\": true|false,    (boolean) Whether the address is a pay to script hash address\n \"ischange\": true|false,    (boolean) Whether the address is on the internal branch of its account, as change addresses are\n \"account\": \"value\",        (string)  The account of the address\n \"branch\": n,               (numeric) The branch of the account the address was derived on, 0 for external and 1 for internal\n \"index\": n,                (numeric) The index of the address on its branch\n \"hdkeypath\": \"value\",      (string)  The derivation path of the key of the address, if it was derived from the seed of the wallet\n}                           \n",
+		"getaddressmeta":            "getaddressmeta \"address\"\n\nReturns the metadata stored in the wallet for an address, such as the amount and message of a payment request.\n\nArguments:\n1. address (string, required) The address to return the metadata of\n\nResult:\n{\n \"address\": \"value\",  (string)  The address the metadata is for\n \"category\": \"value\", (string)  \"receive\" for a payment request made with an address of the wallet, or \"send\" for an address book entry of a recipient\n \"amount\": n.nnn,     (numeric) The amount requested with a receive entry, or paid to a send entry, valued in bitcoin\n \"message\": \"value\",  (string)  The message of the payment request or payment\n \"label\": \"value\",    (string)  The label of the address\n \"state\": \"value\",    (string)  The stored invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"\n \"txid\": \"value\",     (string)  The hash of the transaction that paid the request or made the payment\n \"created\": n,        (numeric) The time the metadata was created in seconds since 1 Jan 1970 GMT\n \"modified\": n,       (numeric) The time the metadata was last changed in seconds since 1 Jan 1970 GMT\n \"expires\": n,        (numeric) The time a receive entry expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n}                     \n",
+		"getaddressesbyaccount":     "getaddressesbyaccount \"account\"\n\nDEPRECATED -- Returns all addresses strings controlled by a single account.\n\nArguments:\n1. account (string, required) Account name to fetch addresses for\n\nResult:\n[\"value\",...] (array of string) All addresses controlled by 'account'\n",
+		"getauditlog":               "getauditlog (from=1 count=100 starttime=0 endtime=0)\n\nReturns entries of the audit log of requests that changed the wallet or exported keys from it, oldest first.\nEach entry is chained to the one before it by its hash, so changes to the log can be detected.\n\nArguments:\n1. from      (numeric, optional, default=1)   The sequence number of the first entry to return\n2. count     (numeric, optional, default=100) Maximum number of entries to return\n3. starttime (numeric, optional, default=0)   If not 0, only entries made at or after this Unix time are returned\n4. endtime   (numeric, optional, default=0)   If not 0, only entries made at or before this Unix time are returned\n\nResult:\n{\n \"entries\": [{           (array of object) The entries of the audit log\n  \"seq\": n,              (numeric)         The sequence number of the entry\n  \"time\": n,             (numeric)         The Unix time of the request\n  \"identity\": \"value\",   (string)          The user name the client authenticated with and its address\n  \"action\": \"value\",     (string)          The RPC method of the request\n  \"detail\": \"value\",     (string)          The parameters of the request, leaving out secrets, and the transaction hash of sends\n  \"error\": \"value\",      (string)          The error the request failed with, unset if it succeeded\n  \"hash\": \"value\",       (string)          The hash of the previous entry and this one\n },...],                                   \n \"verified\": true|false, (boolean)         Whether the hash chain of the whole audit log is intact\n}                        \n",
+		"getbalance":                "getbalance (\"account\" minconf)\n\nCalculates and returns the balance of one or all accounts.\n\nArguments:\n1. account (string, optional)  DEPRECATED -- The account name to query the balance for, or \"*\" to consider all accounts (default=\"*\")\n2. minconf (numeric, optional) Minimum number of block confirmations required before an unspent output's value is included in the balance, or unset to use the wallet's minconfchange and minconfreceived settings\n\nResult (account != \"*\"):\nn.nnn (numeric) The balance of 'account' valued in bitcoin\n\nResult (account = \"*\"):\nn.nnn (numeric) The balance of all accounts valued in bitcoin, leaving out archived accounts\n",
+		"getbalanceat":              "getbalanceat heightortime (peraccount=false)\n\nReturns the balance of the wallet as of a past block, found by replaying the credits and debits of the transactions mined up to and including it.\nImmature coinbase outputs are counted. A timestamp selects the last block whose time is not after it.\n\nArguments:\n1. heightortime (numeric, required)                The block height, or a unix timestamp when it is 500000000 or more, like a transaction lock time\n2. peraccount   (boolean, optional, default=false) Also return the balance of each account\n\nResult:\n{\n \"height\": n,         (numeric)         The height of the block the balance is of\n \"hash\": \"value\",     (string)          The hash of the block the balance is of\n \"time\": n,           (numeric)         The timestamp of the block the balance is of\n \"balance\": n.nnn,    (numeric)         The balance of the wallet valued in bitcoin\n \"accounts\": [{       (array of object) The accounts with a non-zero balance, when requested, with outputs that pay to no account last with an empty name\n  \"account\": \"value\", (string)          The name of the account\n  \"scope\": \"value\",   (string)          The key scope of the account\n  \"balance\": n.nnn,   (numeric)         The balance of the account valued in bitcoin\n },...],                                \n}                     \n",
+		"getbestblockhash":          "getbestblockhash\n\nReturns the hash of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The hash of the most recent synced-to block\n",
+		"getblockcount":             "getblockcount\n\nReturns the blockchain height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\nn.nnn (numeric) The blockchain height of the most recent synced-to block\n",
+		"getinfo":                   "getinfo\n\nReturns a JSON object containing various state info.\n\nArguments:\nNone\n\nResult:\n{\n \"version\": n,          (numeric) The version of the server\n \"protocolversion\": n,  (numeric) The latest supported protocol version\n \"walletversion\": n,    (numeric) The version of the address manager database\n \"balance\": n.nnn,      (numeric) The balance of all accounts calculated with one block confirmation\n \"blocks\": n,           (numeric) The number of blocks processed\n \"timeoffset\": n,       (numeric) The time offset\n \"connections\": n,      (numeric) The number of connected peers\n \"proxy\": \"value\",      (string)  The proxy used by the server\n \"difficulty\": n.nnn,   (numeric) The current target difficulty\n \"testnet\": true|false, (boolean) Whether or not server is using testnet\n \"keypoololdest\": n,    (numeric) Unset\n \"keypoolsize\": n,      (numeric) Unset\n \"unlocked_until\": n,   (numeric) Unset\n \"paytxfee\": n.nnn,     (numeric) The increment used each time more fee is required for an authored transaction\n \"relayfee\": n.nnn,     (numeric) The minimum relay fee for non-free transactions in DUO/KB\n \"errors\": \"value\",     (string)  Any current errors\n}                       \n",
+		"getnewaddress":             "getnewaddress (\"account\" \"addresstype\")\n\nGenerates and returns a new payment address.\n\nArguments:\n1. account     (string, optional) DEPRECATED -- Account name the new address will belong to (default=\"default\")\n2. addresstype (string, optional) Type of the new address: legacy, p2sh-segwit or bech32 if active on the network (default is set by the wallet, then the configuration, then the network)\n\nResult:\n\"value\" (string) The payment address\n",
+		"getnewmultisigaddress":     "getnewmultisigaddress \"name\"\n\nReturns the next deposit address of a multisig account and imports its redeem script into the wallet.\nEvery cosigner derives the same address at the same index. The wallet must be unlocked.\n\nArguments:\n1. name (string, required) The name of the multisig account\n\nResult:\n{\n \"address\": \"value\",      (string)  The pay-to-script-hash deposit address\n \"redeemScript\": \"value\", (string)  The script required to redeem outputs paid to the address\n \"index\": n,              (numeric) The index of the address, which is the index of the cosigner keys it is made from\n}                         \n",
+		"getnewvaultaddress":        "getnewvaultaddress \"name\"\n\nReturns the next deposit address of a vault account and imports its redeem script into the wallet. The wallet must be unlocked.\n\nArguments:\n1. name (string, required) The name of the vault account\n\nResult:\n{\n \"address\": \"value\",      (string)  The pay-to-script-hash deposit address\n \"redeemScript\": \"value\", (string)  The script required to redeem outputs paid to the address\n \"lockheight\": n,         (numeric) The block height the address is locked until\n}                         \n",
+		"getpaymentbundle":          "getpaymentbundle \"id\" (minconf=1)\n\nReturns the settlement of a payment bundle exported with exportpaymentbundle, with the payments made to each of its addresses.\n\nArguments:\n1. id      (string, required)             The ID of the bundle\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations a payment needs to count towards the amount requested\n\nResult:\n{\n \"id\": \"value\",              (string)          The ID of the bundle\n \"account\": \"value\",         (string)          The account of the addresses of the bundle\n \"signaddress\": \"value\",     (string)          The address whose key signed the bundle\n \"state\": \"value\",           (string)          \"open\" while none of the requests has been paid, \"partial\" when some have, \"settled\" when every request that was not cancelled has been paid, or \"expired\" when the requests expired before any was paid\n \"requested\": n.nnn,         (numeric)         The amounts of all the requests added up valued in bitcoin\n \"received\": n.nnn,          (numeric)         The amount paid to the addresses of the bundle with enough confirmations valued in bitcoin\n \"pending\": n.nnn,           (numeric)         The amount paid to the addresses of the bundle without enough confirmations yet valued in bitcoin\n \"paid\": n,                  (numeric)         The number of requests that have been paid\n \"requests\": [{              (array of object) The payment requests of the bundle in the order they were exported in\n  \"address\": \"value\",        (string)          The address the payment was requested with\n  \"amount\": n.nnn,           (numeric)         The amount requested valued in bitcoin\n  \"message\": \"value\",        (string)          The message of the payment request\n  \"label\": \"value\",          (string)          The label of the address\n  \"state\": \"value\",          (string)          \"open\" while waiting for payment, \"partial\" when less than the amount was paid, \"paid\", \"expired\" when nothing was paid in time, or \"cancelled\"\n  \"received\": n.nnn,         (numeric)         The amount paid to the address with enough confirmations valued in bitcoin\n  \"pending\": n.nnn,          (numeric)         The amount paid to the address without enough confirmations yet valued in bitcoin\n  \"payments\": [\"value\",...], (array of string) The hashes of the transactions paying to the address\n  \"created\": n,              (numeric)         The time the payment was requested in seconds since 1 Jan 1970 GMT\n  \"expires\": n,              (numeric)         The time the payment request expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n  \"lastpayment\": n,          (numeric)         The time the latest payment was seen in seconds since 1 Jan 1970 GMT, omitted if there is none\n },...],                                       \n \"created\": n,               (numeric)         The time the bundle was exported in seconds since 1 Jan 1970 GMT\n \"expires\": n,               (numeric)         The time the requests of the bundle expire in seconds since 1 Jan 1970 GMT, omitted if they do not expire\n}                            \n",
+		"getrawchangeaddress":       "getrawchangeaddress (\"account\")\n\nGenerates and returns a new internal payment address for use as a change address in raw transactions.\n\nArguments:\n1. account (string, optional) Account name the new internal address will belong to (default=\"default\")\n\nResult:\n\"value\" (string) The internal payment address\n",
+		"getreceivedbyaccount":      "getreceivedbyaccount \"account\" (minconf=1)\n\nDEPRECATED -- Returns the total amount received by addresses of some account, including spent outputs.\n\nArguments:\n1. account (string, required)             Account name to query total received amount for\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
+		"getreceivedbyaddress":      "getreceivedbyaddress \"address\" (minconf=1)\n\nReturns the total amount received by a single address, including spent outputs.\n\nArguments:\n1. address (string, required)             Payment address which received outputs to include in total\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
+		"getrescaninfo":             "getrescaninfo\n\nReturns the progress of the rescan the wallet is running, or last ran.\nRescans run in the background, the wallet only knows of the transactions in the blocks a rescan has passed, and an unmined transaction found to double spend a mined one is removed and listed as a conflict.\n\nArguments:\nNone\n\nResult:\n{\n \"running\": true|false, (boolean)         Whether a rescan is running\n \"started\": n,          (numeric)         The time the rescan started in seconds since 1 Jan 1970 GMT, or 0 if the wallet has not rescanned since it was started\n \"addresses\": n,        (numeric)         The number of addresses rescanned for\n \"outpoints\": n,        (numeric)         The number of outputs rescanned for spends of\n \"startheight\": n,      (numeric)         The height of the block the rescan started at\n \"height\": n,           (numeric)         The height of the last block the rescan has passed\n \"bestheight\": n,       (numeric)         The height of the best block of the chain server when the rescan started\n \"queued\": n,           (numeric)         The number of rescans waiting for this one to finish\n \"conflicts\": [{        (array of object) The unmined transactions removed since the rescan started because a mined transaction spends the same output\n  \"txid\": \"value\",      (string)          The transaction hash of the output spent twice\n  \"vout\": n,            (numeric)         The output index of the output spent twice\n  \"removed\": \"value\",   (string)          The hash of the unmined transaction that was removed, along with those spending its outputs\n  \"spentby\": \"value\",   (string)          The hash of the mined transaction spending the output\n  \"height\": n,          (numeric)         The height of the block the spending transaction was mined in\n },...],                                  \n}                       \n",
+		"getscrubinfo":              "getscrubinfo\n\nReturns the progress of the scrubbing of the wallet database and the problems it found.\nThe wallet reads back every row of its database in the background, a few at a time, checking each deserializes and agrees with the rows it refers to. Problems with the index of unspent outputs are repaired if walletscrubrepair is set, others are only reported, as they need a rescan or a backup to recover from.\n\nArguments:\nNone\n\nResult:\n{\n \"enabled\": true|false,   (boolean)         Whether the wallet database is scrubbed, which is set by walletscrubinterval\n \"repair\": true|false,    (boolean)         Whether the problems that can be repaired are, which is set by walletscrubrepair\n \"phase\": \"value\",        (string)          The kind of rows being checked, omitted while waiting for the next pass\n \"passes\": n,             (numeric)         The number of passes over the database completed since the wallet was started\n \"started\": n,            (numeric)         The time the running or last pass started in seconds since 1 Jan 1970 GMT, or 0 if none has\n \"checked\": n,            (numeric)         The number of rows checked by the running or last pass\n \"finished\": n,           (numeric)         The time the last complete pass finished in seconds since 1 Jan 1970 GMT, or 0 if none has\n \"repaired\": n,           (numeric)         The number of problems repaired since the wallet was started\n \"findings\": [{           (array of object) The problems found by the running pass and the last complete pass, the latest 100\n  \"pass\": n,              (numeric)         The number of the pass that found the problem, counting from 1\n  \"time\": n,              (numeric)         The time the problem was found in seconds since 1 Jan 1970 GMT\n  \"kind\": \"value\",        (string)          The kind of rows the problem was found in (addresses, blocks, txrecords, credits, unspent, debits or unmined)\n  \"key\": \"value\",         (string)          The key of the row in hex, or the key scope for addresses\n  \"problem\": \"value\",     (string)          What is wrong with the row\n  \"repaired\": true|false, (boolean)         Whether the problem was repaired\n },...],                                    \n}                         \n",
+		"getspendauth":              "getspendauth\n\nReturns the PIN or authenticator code the wallet requires to send more than its spend limit, and the limit.\n\nArguments:\nNone\n\nResult:\n{\n \"method\": \"value\", (string)  The code required to send more than the limit, \"pin\", \"totp\" or \"none\"\n \"limit\": n.nnn,    (numeric) The most that may be sent in a transaction without the code, valued in DUO\n \"secret\": \"value\", (string)  The base32 encoded time-based code secret to add to an authenticator, only returned when it is set\n \"uri\": \"value\",    (string)  The otpauth URI of the time-based code secret, for showing as a QR code, only returned when it is set\n}                   \n",
+		"gettransaction":            "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, or 0 if the inputs of the transaction were not all spent by the wallet\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The fee of the transaction as in the fee of the result, set on every detail when the inputs of the transaction were all spent by the wallet\n  \"vout\": n,                       (numeric)         The transaction output index\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n}                                  \n",
+		"getutxoreport":             "getutxoreport (feerate)\n\nReturns the unspent outputs of the wallet, including unmined ones, summed up by age and by amount, with the outputs that cost more in fees to spend at the fee rate than they are worth.\n\nArguments:\n1. feerate (numeric, optional) The fee rate per kilobyte the cost of spending the outputs is found at, valued in bitcoin (default=the fee rate of the wallet)\n\nResult:\n{\n \"height\": n,                (numeric)         The height of the block the wallet is synced to\n \"feerate\": n.nnn,           (numeric)         The fee rate per kilobyte of the report, valued in bitcoin\n \"spendcost\": n.nnn,         (numeric)         The fee spending one output adds to a transaction at the fee rate, valued in bitcoin\n \"count\": n,                 (numeric)         The number of unspent outputs\n \"amount\": n.nnn,            (numeric)         The total amount of the unspent outputs, valued in bitcoin\n \"uneconomic\": n,            (numeric)         The number of outputs that cost more to spend than they are worth\n \"uneconomicamount\": n.nnn,  (numeric)         The total amount of the uneconomic outputs, valued in bitcoin\n \"dust\": n,                  (numeric)         The number of outputs too small for nodes to relay a transaction paying them at the fee rate\n \"frozen\": n,                (numeric)         The number of frozen outputs\n \"locked\": n,                (numeric)         The number of outputs locked with lockunspent\n \"byage\": [{                 (array of object) The outputs by the number of seconds since they were mined, with the unmined outputs first\n  \"name\": \"value\",           (string)          The name of the range\n  \"min\": n.nnn,              (numeric)         The least age or amount in the range\n  \"max\": n.nnn,              (numeric)         The age or amount the range ends below, left out for the last range\n  \"count\": n,                (numeric)         The number of outputs in the range\n  \"amount\": n.nnn,           (numeric)         The total amount of the outputs in the range, valued in bitcoin\n  \"uneconomic\": n,           (numeric)         The number of outputs in the range that cost more to spend than they are worth\n  \"uneconomicamount\": n.nnn, (numeric)         The total amount of the uneconomic outputs in the range, valued in bitcoin\n  \"spendcost\": n.nnn,        (numeric)         The fee spending all the outputs in the range adds to a transaction at the fee rate, valued in bitcoin\n },...],                                       \n \"byvalue\": [{               (array of object) The outputs by their amounts, valued in bitcoin\n  \"name\": \"value\",           (string)          The name of the range\n  \"min\": n.nnn,              (numeric)         The least age or amount in the range\n  \"max\": n.nnn,              (numeric)         The age or amount the range ends below, left out for the last range\n  \"count\": n,                (numeric)         The number of outputs in the range\n  \"amount\": n.nnn,           (numeric)         The total amount of the outputs in the range, valued in bitcoin\n  \"uneconomic\": n,           (numeric)         The number of outputs in the range that cost more to spend than they are worth\n  \"uneconomicamount\": n.nnn, (numeric)         The total amount of the uneconomic outputs in the range, valued in bitcoin\n  \"spendcost\": n.nnn,        (numeric)         The fee spending all the outputs in the range adds to a transaction at the fee rate, valued in bitcoin\n },...],                                       \n}                            \n",
+		"getvaultschedule":          "getvaultschedule \"name\"\n\nReturns the deposit addresses of a vault account in the order they unlock, with the amount paid to each that has not been spent.\n\nArguments:\n1. name (string, required) The name of the vault account\n\nResult:\n{\n \"height\": n,         (numeric)         The height of the block the wallet is synced to\n \"locked\": n.nnn,     (numeric)         The unspent amount paid to addresses that are still locked, valued in bitcoin\n \"unlocked\": n.nnn,   (numeric)         The unspent amount paid to addresses that have unlocked, which withdrawvault spends, valued in bitcoin\n \"locks\": [{          (array of object) The deposit addresses of the account\n  \"address\": \"value\", (string)          The deposit address\n  \"lockheight\": n,    (numeric)         The block height the address is locked until\n  \"blocksleft\": n,    (numeric)         The number of blocks until the address unlocks, 0 once it has\n  \"amount\": n.nnn,    (numeric)         The unspent amount paid to the address, valued in bitcoin\n  \"outputs\": n,       (numeric)         The number of unspent outputs paid to the address\n },...],                                \n}                     \n",
+		"help":                      "help (\"command\")\n\nReturns a list of all commands or help for a specified command.\n\nArguments:\n1. command (string, optional) The command to retrieve help for\n\nResult (no command provided):\n\"value\" (string) List of commands\n\nResult (command specified):\n\"value\" (string) Help for specified command\n",
+		"importcorewallet":          "importcorewallet \"path\" (passphrase=\"\" rescan=true)\n\nImports the private keys and address labels of a Bitcoin Core wallet.dat, such as that of the legacy Qt wallet, to the 'imported' account.\nThe old client should be closed first so its wallet is complete. The birthday of each key is the block before the time its wallet recorded it was made, or the genesis block if it was not recorded, and the birthday of this wallet is moved back to the earliest of them.\nThis wallet must be unlocked.\n\nArguments:\n1. path       (string, required)                The path of the wallet.dat file\n2. passphrase (string, optional, default=\"\")    The passphrase of the wallet.dat, if its keys are encrypted\n3. rescan     (boolean, optional, default=true) Rescan the blockchain from the earliest birthday of the keys for outputs controlled by them\n\nResult:\n{\n \"keys\": n,                 (numeric) The number of private keys in the wallet.dat\n \"imported\": n,             (numeric) The number of keys that were imported\n \"duplicates\": n,           (numeric) The number of keys that were already in the wallet\n \"labels\": n,               (numeric) The number of address labels that were set\n \"rescanfrom\": n,           (numeric) The height of the block the rescan starts from\n \"rescanfromhash\": \"value\", (string)  The hash of the block the rescan starts from\n}                           \n",
+		"importprivkey":             "importprivkey \"privkey\" (\"label\" rescan=true)\n\nImports a WIF-encoded private key to the 'imported' account.\n\nArguments:\n1. privkey (string, required)                The WIF-encoded private key\n2. label   (string, optional)                Unused (must be unset or 'imported')\n3. rescan  (boolean, optional, default=true) Rescan the blockchain (since the genesis block) for outputs controlled by the imported key\n\nResult:\nNothing\n",
+		"importscriptpubkey":        "importscriptpubkey \"script\" (label=\"\" rescan=true)\n\nWatches an output script, which need not pay to an address, for payments and their spends. Outputs paying to watched scripts are listed by listunspent as not spendable and are not part of the balance. Requires a websocket connection to the chain server.\n\nArguments:\n1. script (string, required)                The hex-encoded output script\n2. label  (string, optional, default=\"\")    A label for the script\n3. rescan (boolean, optional, default=true) Search the blockchain (since the genesis block) in the background for outputs paying to the script, or watch it only from the current block\n\nResult:\nNothing\n",
+		"importtimelockscript":      "importtimelockscript \"redeemscript\" (rescan=true)\n\nImports a redeem script that pays to a key of the wallet once an OP_CHECKLOCKTIMEVERIFY or OP_CHECKSEQUENCEVERIFY time lock has passed. Outputs paid to its P2SH address are listed by listunspent with the height or time they unlock at, are not spent by other sends, and are spent by sweeptimelocked once they unlock. The wallet must be unlocked.\n\nArguments:\n1. redeemscript (string, required)                The hex-encoded redeem script\n2. rescan       (boolean, optional, default=true) Search the blockchain (since the genesis block) in the background for outputs paid to the script, or watch it only from the current block\n\nResult:\n{\n \"address\": \"value\",     (string)  The P2SH address of the script\n \"relative\": true|false, (boolean) Whether the lock is checked by OP_CHECKSEQUENCEVERIFY, counting from the block an output is mined in\n \"seconds\": true|false,  (boolean) Whether the lock is in seconds rather than blocks\n \"lock\": n,              (numeric) The height or time in seconds since 1 Jan 1970 GMT the chain must reach, or for a relative lock the number of blocks or seconds that must pass\n}                        \n",
+		"keypoolrefill":             "keypoolrefill (newsize=100)\n\nDEPRECATED -- This request does nothing since no keypool is maintained.\n\nArguments:\n1. newsize (numeric, optional, default=100) Unused\n\nResult:\nNothing\n",
+		"listaccounts":              "listaccounts (minconf=1 includearchived=false)\n\nDEPRECATED -- Returns a JSON object of all accounts and their balances, leaving out archived accounts unless includearchived is set.\n\nArguments:\n1. minconf         (numeric, optional, default=1)     Minimum number of block confirmations required before an unspent output's value is included in the balance\n2. includearchived (boolean, optional, default=false) Also list the accounts archived with archiveaccount\n\nResult:\n{\n \"The account name\": The account balance valued in bitcoin, (object) JSON object with account names as keys and bitcoin amounts as values\n ...\n}\n",
+		"listaddressmeta":           "listaddressmeta (\"category\")\n\nReturns the metadata stored in the wallet for addresses, oldest first.\n\nArguments:\n1. category (string, optional) If set, only the metadata of this category, \"receive\" or \"send\", is returned\n\nResult:\n[{\n \"address\": \"value\",  (string)  The address the metadata is for\n \"category\": \"value\", (string)  \"receive\" for a payment request made with an address of the wallet, or \"send\" for an address book entry of a recipient\n \"amount\": n.nnn,     (numeric) The amount requested with a receive entry, or paid to a send entry, valued in bitcoin\n \"message\": \"value\",  (string)  The message of the payment request or payment\n \"label\": \"value\",    (string)  The label of the address\n \"state\": \"value\",    (string)  The stored invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"\n \"txid\": \"value\",     (string)  The hash of the transaction that paid the request or made the payment\n \"created\": n,        (numeric) The time the metadata was created in seconds since 1 Jan 1970 GMT\n \"modified\": n,       (numeric) The time the metadata was last changed in seconds since 1 Jan 1970 GMT\n \"expires\": n,        (numeric) The time a receive entry expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n},...]\n",
+		"listdustoutputs":           "listdustoutputs\n\nReturns the outputs taken for the outputs of a dusting attack, which sends tiny amounts to many addresses of the wallet to link them, in the order they were detected.\nSuch outputs are frozen when they are detected, release them with overridedust to spend them.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",      (string)  The transaction hash of the output\n \"vout\": n,            (numeric) The output index of the output\n \"address\": \"value\",   (string)  The address of the wallet the output pays to\n \"amount\": n.nnn,      (numeric) The value of the output valued in bitcoin\n \"detected\": n,        (numeric) The time the output was detected in seconds since 1 Jan 1970 GMT\n \"frozen\": true|false, (boolean) Whether the output is still frozen, false once it has been released\n},...]\n",
+		"listfrozen":                "listfrozen\n\nReturns the outputs frozen (with freezeunspent) in the wallet, in the order they were frozen.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",       (string)  The transaction hash of the frozen output\n \"vout\": n,             (numeric) The output index of the frozen output\n \"reason\": \"value\",     (string)  Why the output was frozen\n \"frozen\": n,           (numeric) The time the output was frozen in seconds since 1 Jan 1970 GMT\n \"unspent\": true|false, (boolean) Whether the output is an unspent output of the wallet, false if it was spent or is not known to the wallet yet\n \"address\": \"value\",    (string)  The address the output pays to, omitted unless it is unspent\n \"amount\": n.nnn,       (numeric) The value of the output valued in bitcoin, omitted unless it is unspent\n},...]\n",
+		"listimmature":              "listimmature (\"account\")\n\nReturns the wallet's coinbase outputs that have not yet reached coinbase maturity and how many blocks remain until each can be spent.\n\nArguments:\n1. account (string, optional) Only include outputs paying to this account, or \"*\" for all accounts\n\nResult:\n{\n \"total\": n.nnn,        (numeric)         The total value of the immature coinbase outputs valued in bitcoin\n \"outputs\": [{          (array of object) The immature coinbase outputs, oldest first\n  \"txid\": \"value\",      (string)          The hash of the coinbase transaction\n  \"vout\": n,            (numeric)         The output index of the coinbase output\n  \"address\": \"value\",   (string)          The payment address that received the output\n  \"account\": \"value\",   (string)          The account associated with the receiving payment address\n  \"amount\": n.nnn,      (numeric)         The amount of the output valued in bitcoin\n  \"blockhash\": \"value\", (string)          The hash of the block that mined the coinbase transaction\n  \"blockheight\": n,     (numeric)         The height of the block that mined the coinbase transaction\n  \"confirmations\": n,   (numeric)         The number of block confirmations of the coinbase transaction\n  \"maturityheight\": n,  (numeric)         The block height at which the output becomes spendable\n  \"blocksremaining\": n, (numeric)         The number of blocks remaining until the output becomes spendable\n },...],                                  \n}                       \n",
+		"listinvoicereservations":   "listinvoicereservations (account=\"default\")\n\nReturns the addresses reserved (with reserveinvoiceaddress) in an account that issues its addresses sequentially, in order of their index.\n\nArguments:\n1. account (string, optional, default=\"default\") The account to list the reserved addresses of\n\nResult:\n[{\n \"address\": \"value\",   (string)  The reserved address\n \"account\": \"value\",   (string)  The account of the address\n \"index\": n,           (numeric) The index of the address in the external branch of the account\n \"reference\": \"value\", (string)  The reference of the order the address was reserved for, omitted if there is none\n \"reserved\": n,        (numeric) The time the address was reserved in seconds since 1 Jan 1970 GMT\n},...]\n",
+		"listinvoices":              "listinvoices (\"state\" minconf=1)\n\nReturns the payment requests made with addresses of the wallet, oldest first, with the payments made to each address.\n\nArguments:\n1. state   (string, optional)             If set, only the invoices in this state, \"open\", \"partial\", \"paid\", \"expired\" or \"cancelled\", are returned\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations a payment needs to count towards the amount requested\n\nResult:\n[{\n \"address\": \"value\",        (string)          The address the payment was requested with\n \"amount\": n.nnn,           (numeric)         The amount requested valued in bitcoin\n \"message\": \"value\",        (string)          The message of the payment request\n \"label\": \"value\",          (string)          The label of the address\n \"state\": \"value\",          (string)          \"open\" while waiting for payment, \"partial\" when less than the amount was paid, \"paid\", \"expired\" when nothing was paid in time, or \"cancelled\"\n \"received\": n.nnn,         (numeric)         The amount paid to the address with enough confirmations valued in bitcoin\n \"pending\": n.nnn,          (numeric)         The amount paid to the address without enough confirmations yet valued in bitcoin\n \"payments\": [\"value\",...], (array of string) The hashes of the transactions paying to the address\n \"created\": n,              (numeric)         The time the payment was requested in seconds since 1 Jan 1970 GMT\n \"expires\": n,              (numeric)         The time the payment request expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n \"lastpayment\": n,          (numeric)         The time the latest payment was seen in seconds since 1 Jan 1970 GMT, omitted if there is none\n},...]\n",
+		"listlockunspent":           "listlockunspent\n\nReturns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n",
+		"listmultisigaccounts":      "listmultisigaccounts\n\nReturns the multisig accounts of the wallet.\n\nArguments:\nNone\n\nResult:\n[{\n \"name\": \"value\",     (string)          The name of the multisig account\n \"required\": n,       (numeric)         The number of signatures required to spend outputs paid to the account\n \"cosigners\": [{      (array of object) The cosigners of the account\n  \"xpub\": \"value\",    (string)          The extended public key of the account of the cosigner\n  \"ours\": true|false, (boolean)         Whether the key is the extended public key of an account of the wallet\n  \"account\": \"value\", (string)          The wallet account of the key when it is ours\n },...],                                \n \"nextindex\": n,      (numeric)         The index of the next deposit address\n},...]\n",
+		"listpaymentbundles":        "listpaymentbundles (minconf=1)\n\nReturns the settlement of every payment bundle exported with exportpaymentbundle, oldest first.\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations a payment needs to count towards the amount requested\n\nResult:\n[{\n \"id\": \"value\",              (string)          The ID of the bundle\n \"account\": \"value\",         (string)          The account of the addresses of the bundle\n \"signaddress\": \"value\",     (string)          The address whose key signed the bundle\n \"state\": \"value\",           (string)          \"open\" while none of the requests has been paid, \"partial\" when some have, \"settled\" when every request that was not cancelled has been paid, or \"expired\" when the requests expired before any was paid\n \"requested\": n.nnn,         (numeric)         The amounts of all the requests added up valued in bitcoin\n \"received\": n.nnn,          (numeric)         The amount paid to the addresses of the bundle with enough confirmations valued in bitcoin\n \"pending\": n.nnn,           (numeric)         The amount paid to the addresses of the bundle without enough confirmations yet valued in bitcoin\n \"paid\": n,                  (numeric)         The number of requests that have been paid\n \"requests\": [{              (array of object) The payment requests of the bundle in the order they were exported in\n  \"address\": \"value\",        (string)          The address the payment was requested with\n  \"amount\": n.nnn,           (numeric)         The amount requested valued in bitcoin\n  \"message\": \"value\",        (string)          The message of the payment request\n  \"label\": \"value\",          (string)          The label of the address\n  \"state\": \"value\",          (string)          \"open\" while waiting for payment, \"partial\" when less than the amount was paid, \"paid\", \"expired\" when nothing was paid in time, or \"cancelled\"\n  \"received\": n.nnn,         (numeric)         The amount paid to the address with enough confirmations valued in bitcoin\n  \"pending\": n.nnn,          (numeric)         The amount paid to the address without enough confirmations yet valued in bitcoin\n  \"payments\": [\"value\",...], (array of string) The hashes of the transactions paying to the address\n  \"created\": n,              (numeric)         The time the payment was requested in seconds since 1 Jan 1970 GMT\n  \"expires\": n,              (numeric)         The time the payment request expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n  \"lastpayment\": n,          (numeric)         The time the latest payment was seen in seconds since 1 Jan 1970 GMT, omitted if there is none\n },...],                                       \n \"created\": n,               (numeric)         The time the bundle was exported in seconds since 1 Jan 1970 GMT\n \"expires\": n,               (numeric)         The time the requests of the bundle expire in seconds since 1 Jan 1970 GMT, omitted if they do not expire\n},...]\n",
+		"listportfolio":             "listportfolio (minconf=1)\n\nReturns the cold wallets in the portfolio (added with addportfolioentry) in the order of their names, with the funds each holds and those of all of them added up.\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations an output needs to count towards the balance rather than the unconfirmed funds\n\nResult:\n{\n \"balance\": n.nnn,              (numeric)         The confirmed funds of all the entries\n \"unconfirmed\": n.nnn,          (numeric)         The unconfirmed funds of all the entries\n \"entries\": [{                  (array of object) The entries of the portfolio\n  \"name\": \"value\",              (string)          The name of the entry\n  \"descriptors\": [\"value\",...], (array of string) The descriptors of the entry, with their checksums\n  \"range\": n,                   (numeric)         The number of scripts derived from each ranged descriptor\n  \"height\": n,                  (numeric)         The height of the block from which the entry is watched\n  \"added\": n,                   (numeric)         The time the entry was added in seconds since 1 Jan 1970 GMT\n  \"balance\": n.nnn,             (numeric)         The value of the unspent outputs of the entry with at least minconf confirmations\n  \"unconfirmed\": n.nnn,         (numeric)         The value of the other unspent outputs of the entry\n  \"unspent\": n,                 (numeric)         The number of unspent outputs of the entry\n },...],                                          \n}                               \n",
+		"listportfoliotransactions": "listportfoliotransactions (name=\"\" count=100)\n\nReturns the changes transactions made to the funds of the cold wallets in the portfolio, newest first with the unmined transactions before the mined ones.\n\nArguments:\n1. name  (string, optional, default=\"\")   If set, only the transactions of the entry with this name are returned\n2. count (numeric, optional, default=100) The most transactions to return\n\nResult:\n[{\n \"entry\": \"value\",   (string)  The name of the entry\n \"txid\": \"value\",    (string)  The hash of the transaction\n \"amount\": n.nnn,    (numeric) The value the transaction paid to the entry less the value it spent from it, negative for a payment out of the entry\n \"confirmations\": n, (numeric) The number of block confirmations of the transaction\n \"blockheight\": n,   (numeric) The height of the block the transaction was mined in, omitted while it is unmined\n \"time\": n,          (numeric) The time the transaction was mined, or seen while it is unmined, in seconds since 1 Jan 1970 GMT\n},...]\n",
+		"listqueuedpsbts":           "listqueuedpsbts\n\nReturns the transactions in the signing queue of a watching-only wallet, oldest first.\nA watching-only wallet can't sign the transactions it sends, so they are queued with a PSBT carrying the transactions the inputs spend and the BIP0032 derivations of the keys of the inputs and change for an external signer.\nThe inputs of a pending transaction are locked until the signed PSBT is returned with submitsignedpsbt, or the transaction is cancelled with cancelqueuedpsbt.\n\nArguments:\nNone\n\nResult:\n[{\n \"id\": \"value\",      (string)  The ID of the queued transaction, the hash of the unsigned transaction, which is returned by the command that sent it\n \"account\": \"value\", (string)  The account the transaction spends from\n \"created\": n,       (numeric) The time the transaction was queued in seconds since 1 Jan 1970 GMT\n \"state\": \"value\",   (string)  Whether the transaction is pending its signatures or was broadcast\n \"psbt\": \"value\",    (string)  The base64 encoded PSBT, with the signatures submitted so far, or finalized once the transaction was broadcast\n \"txid\": \"value\",    (string)  The hash of the signed transaction, once it was broadcast\n},...]\n",
+		"listreceivedbyaccount":     "listreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\n\nDEPRECATED -- Returns a JSON array of objects listing all accounts and the total amount received by each account.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\", (string)  The name of the account\n \"amount\": n.nnn,    (numeric) Total amount received by payment addresses of the account valued in bitcoin\n \"confirmations\": n, (numeric) Number of block confirmations of the most recent transaction relevant to the account\n},...]\n",
+		"listreceivedbyaddress":     "listreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\n\nReturns a JSON array of objects listing wallet payment addresses and their total received amounts.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\",              (string)          DEPRECATED -- Unset\n \"address\": \"value\",              (string)          The payment address\n \"amount\": n.nnn,                 (numeric)         Total amount received by the payment address valued in bitcoin\n \"confirmations\": n,              (numeric)         Number of block confirmations of the most recent transaction relevant to the address\n \"txids\": [\"value\",...],          (array of string) Transaction hashes of all transactions involving this address\n \"involvesWatchonly\": true|false, (boolean)         Unset\n},...]\n",
+		"listsinceblock":            "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known\n  \"outputfee\": n.nnn,               (numeric)         The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          Unset\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
+		"listtransactions":          "listtransactions (\"account\" count=10 from=0 includewatchonly=false)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. account          (string, optional)                 DEPRECATED -- Unused (must be unset or \"*\")\n2. count            (numeric, optional, default=10)    Maximum number of transactions to create results from\n3. from             (numeric, optional, default=0)     Number of transactions to skip before results are created\n4. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known\n \"outputfee\": n.nnn,               (numeric)         The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"listtransactionspage":      "listtransactionspage (\"cursor\" count=10 {\"categories\":[\"category\",...],\"label\":label,\"starttime\":starttime,\"endtime\":endtime,\"minamount\":minamount})\n\nReturns a page of verbose details for wallet transactions, newest first, that pass the filter.\nThe next page is returned when the nextcursor of a result is passed back as the cursor.\n\nArguments:\n1. cursor (string, optional)              The nextcursor of the previous page, or unset for the first page\n2. count  (numeric, optional, default=10) Maximum number of results in the page\n3. filter (object, optional)              If set, only results that match all of the set fields of the filter are returned\n{\n \"categories\": [\"value\",...], (array of string) The categories of the results to return, such as \"send\", \"receive\", \"generate\" or \"immature\"\n \"label\": \"value\",            (string)          The account the results must belong to\n \"starttime\": n,              (numeric)         The earliest transaction time in seconds since 1 Jan 1970 GMT\n \"endtime\": n,                (numeric)         The latest transaction time in seconds since 1 Jan 1970 GMT\n \"minamount\": n.nnn,          (numeric)         The smallest absolute amount of the results in bitcoin\n}                             \n\nResult:\n{\n \"transactions\": [{                 (array of object) The results in the page\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known\n  \"outputfee\": n.nnn,               (numeric)         The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          Unset\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"nextcursor\": \"value\",             (string)          The cursor to get the next page with, unset if this is the last page\n}                                   \n",
+		"listunlockattempts":        "listunlockattempts\n\nReturns the audit log of the most recent attempts to unlock the wallet with walletpassphrase, oldest first.\nAfter repeated incorrect passphrases, attempts are refused for a time that doubles with every further incorrect passphrase.\n\nArguments:\nNone\n\nResult:\n[{\n \"time\": n,             (numeric) The time of the attempt in seconds since 1 Jan 1970 GMT\n \"success\": true|false, (boolean) Whether the wallet was unlocked\n \"reason\": \"value\",     (string)  Why the attempt failed, or 'unlocked' if it succeeded\n},...]\n",
+		"listunspent":               "listunspent (minconf=1 maxconf=9999999 [\"address\",...])\n\nReturns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n\nArguments:\n1. minconf   (numeric, optional, default=1)       Minimum number of block confirmations required before a transaction output is considered\n2. maxconf   (numeric, optional, default=9999999) Maximum number of block confirmations required before a transaction output is excluded\n3. addresses (array of string, optional)          If set, limits the returned details to unspent outputs received by any of these payment addresses\n\nResult:\n{\n \"txid\": \"value\",          (string)  The transaction hash of the referenced output\n \"vout\": n,                (numeric) The output index of the referenced output\n \"address\": \"value\",       (string)  The payment address that received the output\n \"account\": \"value\",       (string)  The account associated with the receiving payment address\n \"scriptPubKey\": \"value\",  (string)  The output script encoded as a hexadecimal string\n \"redeemScript\": \"value\",  (string)  The redeem script of an output paid to a time locked script of the wallet, unset otherwise\n \"amount\": n.nnn,          (numeric) The amount of the output valued in bitcoin\n \"confirmations\": n,       (numeric) The number of block confirmations of the transaction\n \"spendable\": true|false,  (boolean) Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)\n \"timelocked\": true|false, (boolean) Whether the output is paid to a time locked script whose lock can't yet be passed in the next block\n \"unlockheight\": n,        (numeric) The height the best block must reach for an output under a lock in blocks to be spent, unset for other outputs and relative locks of outputs that are not mined\n \"unlocktime\": n,          (numeric) The median time past, in seconds since 1 Jan 1970 GMT, the best block must reach for an output under a lock in seconds to be spent, unset for other outputs and relative locks of outputs that are not mined\n}                          \n",
+		"listvaultaccounts":         "listvaultaccounts\n\nReturns the vault accounts of the wallet.\n\nArguments:\nNone\n\nResult:\n[{\n \"name\": \"value\", (string)  The name of the vault account\n \"lockheight\": n, (numeric) The block height every deposit address is locked until\n \"delay\": n,      (numeric) The number of blocks each deposit address is locked for after it is generated\n},...]\n",
+		"lockunspent":               "lockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\n\nLocks or unlocks an unspent output.\nLocked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nLocked outputs are volatile and are not saved across wallet restarts.\nIf unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.\n\nArguments:\n1. unlock       (boolean, required)         True to unlock outputs, false to lock\n2. transactions (array of object, required) Transaction outputs to lock or unlock\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"notifywalletevents":        "notifywalletevents (sincesequence \"sinceblock\")\n\nSends the events of the notification journal of the wallet, which records blocks connected and disconnected and relevant transactions in the order they change the wallet, as walletevent notifications.\nThe events after the given sequence number or block are sent first, so a client that reconnects is sent the events it missed, and may be sent some again, in order.\nIt is an error if some of those events are no longer in the journal.\n\nArguments:\n1. sincesequence (numeric, optional) The sequence number of the last event the client received\n2. sinceblock    (string, optional)  The hash of the block after whose connection events are sent, instead of a sequence number (default=only new events)\n\nResult:\nNothing\n",
+		"overridedust":              "overridedust release [{\"txid\":\"value\",\"vout\":n},...]\n\nReleases outputs taken for the outputs of a dusting attack (listed by listdustoutputs), unfreezing them so they can be spent, or freezes them again.\nSpending dust along with other outputs links the addresses it was sent to, which is what a dusting attack is made for.\n\nArguments:\n1. release      (boolean, required)         True to release the outputs, false to freeze them again\n2. transactions (array of object, required) Dust outputs to release or freeze\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"previewsend":               "previewsend \"fromaccount\" {\"address\":amount,...} (minconf {\"hexscript\":amount,...})\n\nWorks out the transaction a sendmany with the same arguments would make, without signing or broadcasting it.\nReturns the unspent outputs selected to fund it, its size, fee, change and fee rate, so the send can be confirmed before it is made.\nThe selected outputs are not locked, so the send can select different ones if other transactions are made in between.\n\nArguments:\n1. fromaccount (string, required) Account to pick unspent outputs from\n2. amounts     (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in DUO, (object) JSON object using payment addresses as keys and output amounts valued in DUO to send to each address\n ...\n}\n3. minconf (numeric, optional) Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings\n4. scripts (object, optional)  Pairs of hex encoded output scripts and the output amount to pay each\n{\n \"Hex encoded output script to pay\": Amount to pay to the output script valued in DUO, (object) JSON object using hex encoded output scripts in one of the standard forms, such as bare multisig, as keys and output amounts valued in DUO to pay to each script\n ...\n}\n\nResult:\n{\n \"inputs\": [{         (array of object) The unspent outputs selected to fund the transaction\n  \"txid\": \"value\",    (string)          The hash of the transaction of the output\n  \"vout\": n,          (numeric)         The index of the output in its transaction\n  \"address\": \"value\", (string)          The address the output pays to\n  \"amount\": n.nnn,    (numeric)         The value of the output in DUO\n },...],                                \n \"vsize\": n,          (numeric)         The estimated size in bytes of the transaction once it is signed\n \"fee\": n.nnn,        (numeric)         The fee paid by the transaction in DUO\n \"change\": n.nnn,     (numeric)         The amount in DUO returned to the wallet as change, or 0 if there is no change output\n \"feerate\": n.nnn,    (numeric)         The fee paid per kilobyte of the transaction in DUO\n}                     \n",
+		"releaseinvoiceaddress":     "releaseinvoiceaddress \"address\"\n\nReleases an address reserved (with reserveinvoiceaddress) for an order that was not paid, so it is the next address reserved in its account.\nAddresses that have been paid can't be released.\n\nArguments:\n1. address (string, required) The reserved address to release\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"removeportfolioentry":      "removeportfolioentry \"name\"\n\nRemoves a cold wallet from the portfolio along with the outputs recorded as paying to it.\n\nArguments:\n1. name (string, required) The name of the entry\n\nResult:\nNothing\n",
+		"reserveinvoiceaddress":     "reserveinvoiceaddress \"account\" (reference=\"\")\n\nReserves the next external address of an account that issues its addresses sequentially (switched on with setinvoiceissuance) for the reference of an order.\nThe address is the lowest released one, or the next address of the account if none were released, so the indexes handed out have no gaps or duplicates even when addresses are reserved concurrently.\nReserving again with a reference that is already reserved returns the same address.\n\nArguments:\n1. account   (string, required)             The account to reserve the address in\n2. reference (string, optional, default=\"\") The reference of the order, such as its ID, which must be unique in the account if it is not empty\n\nResult:\n{\n \"address\": \"value\",   (string)  The reserved address\n \"account\": \"value\",   (string)  The account of the address\n \"index\": n,           (numeric) The index of the address in the external branch of the account\n \"reference\": \"value\", (string)  The reference of the order the address was reserved for, omitted if there is none\n \"reserved\": n,        (numeric) The time the address was reserved in seconds since 1 Jan 1970 GMT\n}                      \n",
+		"sendfrom":                  "sendfrom \"fromaccount\" \"toaddress\" amount (minconf \"comment\" \"commentto\" \"authcode\")\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required)  Account to pick unspent outputs from\n2. toaddress   (string, required)  Address to pay\n3. amount      (numeric, required) Amount to send to the payment address valued in bitcoin\n4. minconf     (numeric, optional) Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings\n5. comment     (string, optional)  Unused\n6. commentto   (string, optional)  Unused\n7. authcode    (string, optional)  The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet\n",
+		"sendmany":                  "sendmany \"fromaccount\" {\"address\":amount,...} (minconf \"comment\" {\"hexscript\":amount,...} \"authcode\" {\"feerate\":feerate,\"fee\":fee} \"idempotencykey\")\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required) DEPRECATED -- Account to pick unspent outputs from\n2. amounts     (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. minconf (numeric, optional) Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings\n4. comment (string, optional)  Unused\n5. scripts (object, optional)  Pairs of hex encoded output scripts and the output amount to pay each\n{\n \"Hex encoded output script to pay\": Amount to pay to the output script valued in DUO, (object) JSON object using hex encoded output scripts in one of the standard forms, such as bare multisig, as keys and output amounts valued in DUO to pay to each script\n ...\n}\n6. authcode   (string, optional) The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n7. feeoptions (object, optional) A fee rate or an absolute fee to pay in place of the wallet's fee rate, only one of which may be set\n{\n \"feerate\": n.nnn, (numeric) Fee rate in DUO/kB to pay, which must be at least the minimum relay fee rate\n \"fee\": n.nnn,     (numeric) Absolute fee in DUO to pay, which must be no more than the maxtxfee setting and at least the minimum relay fee for the size of the transaction\n}                  \n8. idempotencykey (string, optional) A key of up to 128 characters chosen by the client, a retry with the same key and request returns the hash of the transaction sent the first time instead of sending again, and a different request with the key, or a retry of a send that was interrupted before it finished, is refused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet\n",
+		"sendtoaddress":             "sendtoaddress \"address\" amount (\"comment\" \"commentto\" \"authcode\" {\"feerate\":feerate,\"fee\":fee} \"idempotencykey\")\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address    (string, required)  Address to pay\n2. amount     (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment    (string, optional)  Unused\n4. commentto  (string, optional)  Unused\n5. authcode   (string, optional)  The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n6. feeoptions (object, optional)  A fee rate or an absolute fee to pay in place of the wallet's fee rate, only one of which may be set\n{\n \"feerate\": n.nnn, (numeric) Fee rate in DUO/kB to pay, which must be at least the minimum relay fee rate\n \"fee\": n.nnn,     (numeric) Absolute fee in DUO to pay, which must be no more than the maxtxfee setting and at least the minimum relay fee for the size of the transaction\n}                  \n7. idempotencykey (string, optional) A key of up to 128 characters chosen by the client, a retry with the same key and request returns the hash of the transaction sent the first time instead of sending again, and a different request with the key, or a retry of a send that was interrupted before it finished, is refused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet\n",
+		"setaddressmeta":            "setaddressmeta \"address\" {\"amount\":amount,\"message\":message,\"label\":label,\"state\":state,\"txid\":txid,\"expires\":expires}\n\nChanges the metadata stored in the wallet for an address, creating it if the address has none, and returns it.\nNew metadata is a receive entry, which starts out as an open payment request, if the address is in the wallet, and a send entry otherwise.\n\nArguments:\n1. address (string, required) The address to change the metadata of\n2. meta    (object, required) The fields of the metadata to change, where fields that are not set are left as they are\n{\n \"amount\": n.nnn,    (numeric) The amount requested or paid valued in bitcoin\n \"message\": \"value\", (string)  The message of the payment request or payment\n \"label\": \"value\",   (string)  The label of the address\n \"state\": \"value\",   (string)  The invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"\n \"txid\": \"value\",    (string)  The hash of the transaction that paid the request or made the payment\n \"expires\": n,       (numeric) The time a receive entry expires in seconds since 1 Jan 1970 GMT, or 0 for it to not expire\n}                    \n\nResult:\n{\n \"address\": \"value\",  (string)  The address the metadata is for\n \"category\": \"value\", (string)  \"receive\" for a payment request made with an address of the wallet, or \"send\" for an address book entry of a recipient\n \"amount\": n.nnn,     (numeric) The amount requested with a receive entry, or paid to a send entry, valued in bitcoin\n \"message\": \"value\",  (string)  The message of the payment request or payment\n \"label\": \"value\",    (string)  The label of the address\n \"state\": \"value\",    (string)  The stored invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"\n \"txid\": \"value\",     (string)  The hash of the transaction that paid the request or made the payment\n \"created\": n,        (numeric) The time the metadata was created in seconds since 1 Jan 1970 GMT\n \"modified\": n,       (numeric) The time the metadata was last changed in seconds since 1 Jan 1970 GMT\n \"expires\": n,        (numeric) The time a receive entry expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire\n}                     \n",
+		"setinvoiceissuance":        "setinvoiceissuance \"account\" enable\n\nSwitches the sequential issuance of the external addresses of an account on or off.\nWhile it is on, the addresses of the account are only handed out by reserveinvoiceaddress, and getnewaddress and getaccountaddress fail for it. Switching it off forgets the reservations of the account.\n\nArguments:\n1. account (string, required)  The account to switch the issuance of\n2. enable  (boolean, required) True to issue the addresses of the account sequentially, false to stop\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"setspendauth":              "setspendauth \"method\" (limit=0 \"secret\" \"code\")\n\nSets the PIN or authenticator code the wallet requires to send more than a limit in a transaction, with the method \"pin\", \"totp\" or \"none\".\nA send above the limit without the code fails with error code -40 and one with a wrong code with -41, and codes are throttled after repeated wrong ones.\nChanging a spend limit that is already set requires its current code, and the wallet must be unlocked.\n\nArguments:\n1. method (string, required)             The code to require, \"pin\" for a PIN, \"totp\" for a time-based authenticator code, or \"none\" to remove the requirement\n2. limit  (numeric, optional, default=0) The most that may be sent in a transaction without the code, valued in DUO\n3. secret (string, optional)             The PIN, or the base32 encoded time-based code secret, which is generated if it is empty; an empty secret keeps the current one if the method is not changed\n4. code   (string, optional)             The current PIN or authenticator code, needed to change a spend limit that is already set\n\nResult:\n{\n \"method\": \"value\", (string)  The code required to send more than the limit, \"pin\", \"totp\" or \"none\"\n \"limit\": n.nnn,    (numeric) The most that may be sent in a transaction without the code, valued in DUO\n \"secret\": \"value\", (string)  The base32 encoded time-based code secret to add to an authenticator, only returned when it is set\n \"uri\": \"value\",    (string)  The otpauth URI of the time-based code secret, for showing as a QR code, only returned when it is set\n}                   \n",
+		"settxfee":                  "settxfee amount\n\nModify the increment used each time more fee is required for an authored transaction.\n\nArguments:\n1. amount (numeric, required) The new fee increment valued in bitcoin\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"signmessage":               "signmessage \"address\" \"message\"\n\nSigns a message using the private key of a payment address.\n\nArguments:\n1. address (string, required) Payment address of private key used to sign the message with\n2. message (string, required) Message to sign\n\nResult:\n\"value\" (string) The signed message encoded as a base64 string\n",
+		"signrawtransaction":        "signrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\" \"authcode\")\n\nSigns transaction inputs using private keys from this wallet and request.\nThe valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\nSigning with the keys of this wallet a transaction that spends more than the spend limit set by setspendauth, less what it pays back to the wallet, needs the code, failing with error code -40 without it and -41 with a wrong one, as sends do.\n\nArguments:\n1. rawtx    (string, required)                Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string\n2. inputs   (array of object, optional)       Additional data regarding inputs that this wallet may not be tracking\n3. privkeys (array of string, optional)       Additional WIF-encoded private keys to use when creating signatures\n4. flags    (string, optional, default=\"ALL\") Sighash flags\n5. authcode (string, optional)                The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n{\n \"hex\": \"value\",         (string)          The resulting transaction encoded as a hexadecimal string\n \"complete\": true|false, (boolean)         Whether all input signatures have been created\n \"errors\": [{            (array of object) Script verification errors (if exists)\n  \"txid\": \"value\",       (string)          The transaction hash of the referenced previous output\n  \"vout\": n,             (numeric)         The output index of the referenced previous output\n  \"scriptSig\": \"value\",  (string)          The hex-encoded signature script\n  \"sequence\": n,         (numeric)         Script sequence number\n  \"error\": \"value\",      (string)          Verification or signing error related to the input\n },...],                                   \n}                        \n",
+		"submitsignedpsbt":          "submitsignedpsbt \"psbt\"\n\nAdds the signatures of a PSBT returned by an external signer to the transaction it signs in the signing queue of a watching-only wallet, and broadcasts the transaction once it is fully signed.\nThe PSBT of a transaction that needs more signatures keeps those submitted, so it can be given to the next signer, and an error is returned.\nA PSBT with a signature that is not a valid signature of the transaction by a key the input pays to is refused.\n\nArguments:\n1. psbt (string, required) The base64 encoded signed PSBT\n\nResult:\n\"value\" (string) The hash of the broadcast transaction\n",
+		"sweepaccount":              "sweepaccount \"account\" \"address\" (minconf=1 reserve=0 dryrun=false \"authcode\")\n\nMoves the whole spendable balance of an account to an address, with the relay fee taken out of the amount sent.\nOnly outputs with at least minconf confirmations are spent, and a reserve can be left in the account as change.\n\nArguments:\n1. account  (string, required)                 The account to sweep\n2. address  (string, required)                 The address to move the funds to\n3. minconf  (numeric, optional, default=1)     Minimum number of block confirmations of the outputs that are spent\n4. reserve  (numeric, optional, default=0)     The amount in DUO to leave in the account\n5. dryrun   (boolean, optional, default=false) Only work out the sweep and return it, without sending the transaction\n6. authcode (string, optional)                 The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n{\n \"account\": \"value\",     (string)  The swept account\n \"destination\": \"value\", (string)  The address the funds are moved to\n \"inputs\": n,            (numeric) The number of unspent outputs of the account that are spent\n \"amount\": n.nnn,        (numeric) The amount in DUO sent to the destination, after the reserve and fee\n \"reserve\": n.nnn,       (numeric) The amount in DUO left in the account\n \"fee\": n.nnn,           (numeric) The fee paid out of the swept balance in DUO\n \"txid\": \"value\",        (string)  The hash of the sweep transaction, unset with dry run\n}                        \n",
+		"sweepprivkey":              "sweepprivkey \"privkey\" (account=\"default\" dryrun=false)\n\nMoves all the funds of a private key that is not in the wallet, such as the key of a paper wallet, to an address of an account of the wallet, less the relay fee.\nThe outputs of the key are found with the address index of the chain server, which must be enabled (--addrindex).\n\nArguments:\n1. privkey (string, required)                    The private key in WIF format\n2. account (string, optional, default=\"default\") The account to move the funds to\n3. dryrun  (boolean, optional, default=false)    Only work out the sweep and return it, without sending the transaction\n\nResult:\n{\n \"address\": \"value\",     (string)  The address of the swept key\n \"destination\": \"value\", (string)  The wallet address the funds are moved to\n \"outputs\": n,           (numeric) The number of unspent outputs of the key that are spent\n \"amount\": n.nnn,        (numeric) The total value of the outputs in DUO\n \"fee\": n.nnn,           (numeric) The fee paid out of the amount in DUO\n \"txid\": \"value\",        (string)  The hash of the sweep transaction, unset with dry run\n}                        \n",
+		"sweeptimelocked":           "sweeptimelocked \"address\" (dryrun=false \"authcode\")\n\nSends the unlocked outputs paid to the time locked scripts imported with importtimelockscript and to vault deposit addresses to an address, less the fee. Outputs under relative locks are only spent once the chain server relays transactions spending them. The wallet must be unlocked.\n\nArguments:\n1. address  (string, required)                 The address to send the funds to\n2. dryrun   (boolean, optional, default=false) Only work out the sweep and return it, without sending the transaction\n3. authcode (string, optional)                 The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n{\n \"destination\": \"value\", (string)  The address the funds are sent to\n \"inputs\": n,            (numeric) The number of unlocked time locked outputs that are spent\n \"amount\": n.nnn,        (numeric) The amount in DUO sent to the destination, after the fee\n \"fee\": n.nnn,           (numeric) The fee paid out of the unlocked outputs in DUO\n \"txid\": \"value\",        (string)  The hash of the sweep transaction, unset with dry run\n}                        \n",
+		"transferaccount":           "transferaccount \"fromaccount\" \"toaccount\" amount (feeaccount=\"\" minconf=1 dryrun=false \"authcode\")\n\nMoves an amount from one account of the wallet to the current address of another in a transaction, which is replaced by a new one once it has been paid.\nWhen a fee account is given the fee is paid from its outputs, which get their own change, so the source account goes down by exactly the amount, as for the client sub-accounts of an exchange. Otherwise the source account pays the fee as for any send.\n\nArguments:\n1. fromaccount (string, required)                 The account to take the amount from\n2. toaccount   (string, required)                 The account to move the amount to\n3. amount      (numeric, required)                The amount in DUO to move\n4. feeaccount  (string, optional, default=\"\")     The account paying the fee, which must differ from the other two, or empty for the source account to pay it\n5. minconf     (numeric, optional, default=1)     Minimum number of block confirmations of the outputs that are spent\n6. dryrun      (boolean, optional, default=false) Only work out the transfer and return it, without sending the transaction\n7. authcode    (string, optional)                 The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n{\n \"fromaccount\": \"value\", (string)  The account the amount is taken from\n \"toaccount\": \"value\",   (string)  The account the amount is moved to\n \"feeaccount\": \"value\",  (string)  The account paying the fee\n \"address\": \"value\",     (string)  The current address of the destination account the amount is paid to\n \"amount\": n.nnn,        (numeric) The amount in DUO moved\n \"fee\": n.nnn,           (numeric) The fee in DUO paid by the fee account\n \"sourcechange\": n.nnn,  (numeric) The change in DUO returned to the source account\n \"feechange\": n.nnn,     (numeric) The change in DUO returned to the fee account, when it is not the source account\n \"txid\": \"value\",        (string)  The hash of the transfer transaction, unset with dry run\n}                        \n",
+		"unarchiveaccount":          "unarchiveaccount \"account\"\n\nRestores an account archived with archiveaccount to listaccounts and the total balance of the wallet.\n\nArguments:\n1. account (string, required) The name of the account to unarchive\n\nResult:\nNothing\n",
+		"validateaddress":           "validateaddress \"address\"\n\nVerify that an address is valid.\nExtra details are returned if the address is controlled by this wallet.\nThe following fields are valid only when the address is controlled by this wallet (ismine=true): isscript, pubkey, iscompressed, account, addresses, hex, script, and sigsrequired.\nThe following fields are only valid when address has an associated public key: pubkey, iscompressed.\nThe following fields are only valid when address is a pay-to-script-hash address: addresses, hex, and script.\nIf the address is a multisig address controlled by this wallet, the multisig fields will be left unset if the wallet is locked since the redeem script cannot be decrypted.\n\nArguments:\n1. address (string, required) Address to validate\n\nResult:\n{\n \"isvalid\": true|false,      (boolean)         Whether or not the address is valid\n \"address\": \"value\",         (string)          The payment address (only when isvalid is true)\n \"ismine\": true|false,       (boolean)         Whether this address is controlled by the wallet (only when isvalid is true)\n \"iswatchonly\": true|false,  (boolean)         Unset\n \"isscript\": true|false,     (boolean)         Whether the payment address is a pay-to-script-hash address (only when isvalid is true)\n \"pubkey\": \"value\",          (string)          The associated public key of the payment address, if any (only when isvalid is true)\n \"iscompressed\": true|false, (boolean)         Whether the address was created by hashing a compressed public key, if any (only when isvalid is true)\n \"account\": \"value\",         (string)          The account this payment address belongs to (only when isvalid is true)\n \"addresses\": [\"value\",...], (array of string) All associated payment addresses of the script if address is a multisig address (only when isvalid is true)\n \"hex\": \"value\",             (string)          The redeem script \n \"script\": \"value\",          (string)          The class of redeem script for a multisig address\n \"sigsrequired\": n,          (numeric)         The number of required signatures to redeem outputs to the multisig address\n}                            \n",
+		"verifymessage":             "verifymessage \"address\" \"signature\" \"message\"\n\nVerify a message was signed with the associated private key of some address.\n\nArguments:\n1. address   (string, required) Address used to sign message\n2. signature (string, required) The signature to verify\n3. message   (string, required) The message to verify\n\nResult:\ntrue|false (boolean) Whether the message was signed with the private key of 'address'\n",
+		"walletdbstats":             "walletdbstats (largest=5)\n\nReturns the key counts and space used by each bucket of the wallet database, to see what the wallet file grows with.\nNested buckets follow the bucket they are in, and their paths are the keys of the buckets separated by slashes.\n\nArguments:\n1. largest (numeric, optional, default=5) Number of largest key/value pairs of each bucket to return, at most 100\n\nResult:\n[{\n \"path\": \"value\",  (string)          The keys of the bucket and the buckets it is in, separated by slashes, in hex if they are not printable\n \"keys\": n,        (numeric)         The number of key/value pairs in the bucket, not counting nested buckets\n \"buckets\": n,     (numeric)         The number of buckets nested in the bucket\n \"keybytes\": n,    (numeric)         The size of the keys in the bucket, including the keys of nested buckets\n \"valuebytes\": n,  (numeric)         The size of the values in the bucket\n \"totalbytes\": n,  (numeric)         The size of the keys and values in the bucket and the buckets nested in it\n \"largestkeys\": [{ (array of object) The largest key/value pairs in the bucket, largest first\n  \"key\": \"value\",  (string)          The key, in hex if it is not printable\n  \"bytes\": n,      (numeric)         The size of the key and its value\n },...],                             \n},...]\n",
+		"walletlock":                "walletlock\n\nLock the wallet.\n\nArguments:\nNone\n\nResult:\nNothing\n",
+		"walletpassphrase":          "walletpassphrase \"passphrase\" timeout\n\nUnlock the wallet.\n\nArguments:\n1. passphrase (string, required)  The wallet passphrase\n2. timeout    (numeric, required) The number of seconds to wait before the wallet automatically locks\n\nResult:\nNothing\n",
+		"walletpassphrasechange":    "walletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\n\nChange the wallet passphrase.\n\nArguments:\n1. oldpassphrase (string, required) The old wallet passphrase\n2. newpassphrase (string, required) The new wallet passphrase\n\nResult:\nNothing\n",
+		"createnewaccount":          "createnewaccount \"account\"\n\nCreates a new account.\nThe wallet must be unlocked for this request to succeed.\n\nArguments:\n1. account (string, required) Name of the new account\n\nResult:\nNothing\n",
+		"exportwatchingwallet":      "exportwatchingwallet (\"account\" download=false)\n\nCreates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.\n\nArguments:\n1. account  (string, optional)                 Unused (must be unset or \"*\")\n2. download (boolean, optional, default=false) Unused\n\nResult:\n\"value\" (string) The watching-only database encoded as a base64 string\n",
+		"getbestblock":              "getbestblock\n\nReturns the hash and height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n{\n \"hash\": \"value\", (string)  The hash of the block\n \"height\": n,     (numeric) The blockchain height of the block\n}                 \n",
+		"getunconfirmedbalance":     "getunconfirmedbalance (\"account\")\n\nCalculates the unspent output value of all unmined transaction outputs for an account.\n\nArguments:\n1. account (string, optional) The account to query the unconfirmed balance for (default=\"default\")\n\nResult:\nn.nnn (numeric) Total amount of all unmined unspent outputs of the account valued in bitcoin.\n",
+		"listaddresstransactions":   "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known\n \"outputfee\": n.nnn,               (numeric)         The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"listalltransactions":       "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known\n \"outputfee\": n.nnn,               (numeric)         The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"renameaccount":             "renameaccount \"oldaccount\" \"newaccount\"\n\nRenames an account.\n\nArguments:\n1. oldaccount (string, required) The old account name to rename\n2. newaccount (string, required) The new name for the account\n\nResult:\nNothing\n",
+		"walletislocked":            "walletislocked\n\nReturns whether or not the wallet is locked.\n\nArguments:\nNone\n\nResult:\ntrue|false (boolean) Whether the wallet is locked\n",
+		"walletselftest":            "walletselftest\n\nRuns consistency checks of the wallet and returns what they found, for diagnosing problems before attempting repairs.\nThe checks are that the balance is the sum of the unspent credits, that the addresses are those derived at their indexes, that the private keys decrypt to the keys of their addresses, which needs the wallet to be unlocked, and that the block the wallet is synced to is in the chain.\n\nArguments:\nNone\n\nResult:\n{\n \"passed\": true|false,      (boolean)         Whether none of the checks failed\n \"checks\": [{               (array of object) The outcome of each check\n  \"name\": \"value\",          (string)          The name of the check\n  \"status\": \"value\",        (string)          Whether the check passed, failed or was skipped\n  \"checked\": n,             (numeric)         The number of items the check went through\n  \"details\": [\"value\",...], (array of string) The problems found, or why the check was skipped\n },...],                                      \n}                           \n",
+		"withdrawvault":             "withdrawvault \"name\" \"address\" (\"authcode\")\n\nSends the outputs paid to the unlocked deposit addresses of a vault account to an address, less the fee. The wallet must be unlocked.\n\nArguments:\n1. name     (string, required) The name of the vault account\n2. address  (string, required) The address to send the funds to\n3. authcode (string, optional) The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts\n\nResult:\n\"value\" (string) The transaction ID of the withdrawal\n",
 	}
 }
 
 var LocaleHelpDescs = map[string]func() map[string]string{
 	"en_US": HelpDescsEnUS,
 }
-var RequestUsages = "addmultisigaddress nrequired [\"key\",...] (\"account\")\ncreatemultisig nrequired [\"key\",...]\ndumpprivkey \"address\"\ngetaccount \"address\"\ngetaccountaddress \"account\"\ngetaddressesbyaccount \"account\"\ngetbalance (\"account\" minconf=1)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (\"account\")\ngetrawchangeaddress (\"account\")\ngetreceivedbyaccount \"account\" (minconf=1)\ngetreceivedbyaddress \"address\" (minconf=1)\ngettransaction \"txid\" (includewatchonly=false)\nhelp (\"command\")\nimportprivkey \"privkey\" (\"label\" rescan=true)\nkeypoolrefill (newsize=100)\nlistaccounts (minconf=1)\nlistlockunspent\nlistreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (\"account\" count=10 from=0 includewatchonly=false)\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\nsendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\")\nsendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\")\nsendtoaddress \"address\" amount (\"comment\" \"commentto\")\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\ncreatenewaccount \"account\"\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetunconfirmedbalance (\"account\")\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nrenameaccount \"oldaccount\" \"newaccount\"\nwalletislocked"
+var RequestUsages = "addmultisigaddress nrequired [\"key\",...] (\"account\")\naddportfolioentry \"name\" [\"descriptor\",...] (range=1000 rescan=true)\narchiveaccount \"account\"\nbackupremote (force=false)\ncancelqueuedpsbt \"id\"\ncreatemultisig nrequired [\"key\",...]\ncreatemultisigaccount \"name\" nrequired [\"xpub\",...]\ncreatevaultaccount \"name\" (lockheight=0 delay=0)\ndeleteaddressmeta \"address\"\ndumpprivkey \"address\"\nexportaccountxprv \"account\" \"password\" (plaintext=false)\nexportledger (format=\"ledger\" commodity=\"DUO\")\nexportpaymentbundle \"account\" [{\"label\":\"value\",\"amount\":n.nnn},...] (expires=0 \"signaddress\")\nexportwatchset\nfreezeunspent unfreeze [{\"txid\":\"value\",\"vout\":n},...] (\"reason\")\ngeneratepaperkey (count=1)\ngetaccount \"address\"\ngetaccountaddress \"account\"\ngetaccountxpub \"account\"\ngetaddressinfo \"address\"\ngetaddressmeta \"address\"\ngetaddressesbyaccount \"account\"\ngetauditlog (from=1 count=100 starttime=0 endtime=0)\ngetbalance (\"account\" minconf)\ngetbalanceat heightortime (peraccount=false)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (\"account\" \"addresstype\")\ngetnewmultisigaddress \"name\"\ngetnewvaultaddress \"name\"\ngetrawchangeaddress (\"account\")\ngetreceivedbyaccount \"account\" (minconf=1)\ngetreceivedbyaddress \"address\" (minconf=1)\ngetpaymentbundle \"id\" (minconf=1)\ngetrescaninfo\ngetscrubinfo\ngetspendauth\ngettransaction \"txid\" (includewatchonly=false)\ngetutxoreport (feerate)\ngetvaultschedule \"name\"\nhelp (\"command\")\nimportcorewallet \"path\" (passphrase=\"\" rescan=true)\nimportprivkey \"privkey\" (\"label\" rescan=true)\nimportscriptpubkey \"script\" (label=\"\" rescan=true)\nimporttimelockscript \"redeemscript\" (rescan=true)\nkeypoolrefill (newsize=100)\nlistaccounts (minconf=1 includearchived=false)\nlistaddressmeta (\"category\")\nlistimmature (\"account\")\nlistdustoutputs\nlistfrozen\nlistinvoices (\"state\" minconf=1)\nlistinvoicereservations (account=\"default\")\nlistlockunspent\nlistmultisigaccounts\nlistpaymentbundles (minconf=1)\nlistportfolio (minconf=1)\nlistportfoliotransactions (name=\"\" count=100)\nlistqueuedpsbts\nlistreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (\"account\" count=10 from=0 includewatchonly=false)\nlisttransactionspage (\"cursor\" count=10 {\"categories\":[\"category\",...],\"label\":label,\"starttime\":starttime,\"endtime\":endtime,\"minamount\":minamount})\nlistunlockattempts\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlistvaultaccounts\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\noverridedust release [{\"txid\":\"value\",\"vout\":n},...]\npreviewsend \"fromaccount\" {\"address\":amount,...} (minconf {\"hexscript\":amount,...})\nreleaseinvoiceaddress \"address\"\nremoveportfolioentry \"name\"\nreserveinvoiceaddress \"account\" (reference=\"\")\nsendfrom \"fromaccount\" \"toaddress\" amount (minconf \"comment\" \"commentto\" \"authcode\")\nsendmany \"fromaccount\" {\"address\":amount,...} (minconf \"comment\" {\"hexscript\":amount,...} \"authcode\" {\"feerate\":feerate,\"fee\":fee} \"idempotencykey\")\nsendtoaddress \"address\" amount (\"comment\" \"commentto\" \"authcode\" {\"feerate\":feerate,\"fee\":fee} \"idempotencykey\")\nsetaddressmeta \"address\" {\"amount\":amount,\"message\":message,\"label\":label,\"state\":state,\"txid\":txid,\"expires\":expires}\nsetinvoiceissuance \"account\" enable\nsetspendauth \"method\" (limit=0 \"secret\" \"code\")\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\" \"authcode\")\nsubmitsignedpsbt \"psbt\"\nsweepaccount \"account\" \"address\" (minconf=1 reserve=0 dryrun=false \"authcode\")\nsweepprivkey \"privkey\" (account=\"default\" dryrun=false)\nsweeptimelocked \"address\" (dryrun=false \"authcode\")\ntransferaccount \"fromaccount\" \"toaccount\" amount (feeaccount=\"\" minconf=1 dryrun=false \"authcode\")\nunarchiveaccount \"account\"\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletdbstats (largest=5)\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\nwalletselftest\nwithdrawvault \"name\" \"address\" (\"authcode\")\ncreatenewaccount \"account\"\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetunconfirmedbalance (\"account\")\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nnotifywalletevents (sincesequence \"sinceblock\")\nrenameaccount \"oldaccount\" \"newaccount\"\nwalletislocked"
@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// AddressLocation is where an address of the wallet is kept, as found from the script index of the address manager.
+type AddressLocation struct {
+	waddrmgr.ScriptLocation
+	PkScript    []byte
+	AccountName string
+	// Watched is whether the output script of the address is also watched as an imported script.
+	Watched bool
+}
+
+// HDKeyPath returns the derivation path of the key of the address, or an empty string if it was not derived from the
+// seed of the wallet.
+func (l *AddressLocation) HDKeyPath() string {
+	if !l.Derived {
+		return ""
+	}
+	return fmt.Sprintf(
+		"m/%d'/%d'/%d'/%d/%d", l.Scope.Purpose, l.Scope.Coin, l.Account, l.Branch, l.Index,
+	)
+}
+
+// LocateAddress returns where the address is kept in the wallet, or a waddrmgr ErrAddressNotFound error if it is not
+// an address of the wallet. Addresses paid to with standard scripts are found in the script index, and the rest, such
+// as pay to public key addresses, are looked up in the address manager.
+func (w *Wallet) LocateAddress(addr btcaddr.Address) (loc *AddressLocation, e error) {
+	loc = &AddressLocation{}
+	if loc.PkScript, e = txscript.PayToAddrScript(addr); E.Chk(e) {
+		return nil, e
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			loc.Watched = w.Manager.IsWatchedScript(addrmgrNs, loc.PkScript)
+			if sl, ok := w.Manager.LookupScript(addrmgrNs, loc.PkScript); ok {
+				loc.ScriptLocation = *sl
+			} else if waddrmgr.IsIndexedScript(loc.PkScript) {
+				str := fmt.Sprintf("address %v is not in the wallet", addr)
+				return waddrmgr.ManagerError{ErrorCode: waddrmgr.ErrAddressNotFound, Description: str}
+			} else if e = w.locateUnindexedAddress(addrmgrNs, addr, &loc.ScriptLocation); e != nil {
+				return
+			}
+			var manager *waddrmgr.ScopedKeyManager
+			if manager, e = w.Manager.FetchScopedKeyManager(loc.Scope); E.Chk(e) {
+				return
+			}
+			loc.AccountName, e = manager.AccountName(addrmgrNs, loc.Account)
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	return
+}
+
+// locateUnindexedAddress fills in where an address whose output script is not in the script index is kept in the
+// address manager.
+func (w *Wallet) locateUnindexedAddress(
+	addrmgrNs walletdb.ReadBucket, addr btcaddr.Address, sl *waddrmgr.ScriptLocation,
+) (e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, sl.Account, e = w.Manager.AddrAccount(addrmgrNs, addr); e != nil {
+		return
+	}
+	sl.Scope = manager.Scope()
+	var ma waddrmgr.ManagedAddress
+	if ma, e = manager.Address(addrmgrNs, addr); E.Chk(e) {
+		return
+	}
+	sl.AddressID = ma.Address().ScriptAddress()
+	if pka, ok := ma.(waddrmgr.ManagedPubKeyAddress); ok {
+		var path waddrmgr.DerivationPath
+		if _, path, sl.Derived = pka.DerivationInfo(); sl.Derived {
+			sl.Branch, sl.Index = path.Branch, path.Index
+		}
+	}
+	return
+}
+
+// outputAccount returns the account of the wallet the output script pays to, or false if it pays to no address of the
+// wallet. Scripts the script index does not cover are decoded to look their address up in the address manager.
+func (w *Wallet) outputAccount(addrmgrNs walletdb.ReadBucket, pkScript []byte) (account uint32, ok bool) {
+	var loc *waddrmgr.ScriptLocation
+	if loc, ok = w.Manager.LookupScript(addrmgrNs, pkScript); ok || waddrmgr.IsIndexedScript(pkScript) {
+		if ok {
+			account = loc.Account
+		}
+		return
+	}
+	_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if e != nil || len(addrs) == 0 {
+		return 0, false
+	}
+	if _, account, e = w.Manager.AddrAccount(addrmgrNs, addrs[0]); e != nil {
+		return 0, false
+	}
+	return account, true
+}
@@ -0,0 +1,46 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+// TestMakeScriptOutputsSegwit ensures segwit output scripts are refused before segwit is active on the network, as
+// outputs paying to them could be spent by anyone, while other standard scripts are paid.
+func TestMakeScriptOutputsSegwit(t *testing.T) {
+	params := &chaincfg.RegressionTestParams
+	if params.AddressTypeActive(chaincfg.AddressTypeBech32) {
+		t.Fatalf("segwit is active on %s", params.Name)
+	}
+	segwit := chaincfg.RegressionTestParams
+	segwit.AddressTypes = append([]string{chaincfg.AddressTypeBech32}, params.AddressTypes...)
+	witnessScripts := map[string][]byte{
+		"p2wpkh": append([]byte{txscript.OP_0, txscript.OP_DATA_20}, make([]byte, 20)...),
+		"p2wsh":  append([]byte{txscript.OP_0, txscript.OP_DATA_32}, make([]byte, 32)...),
+		"v1":     append([]byte{txscript.OP_1, txscript.OP_DATA_32}, make([]byte, 32)...),
+	}
+	for name, script := range witnessScripts {
+		scripts := map[string]amt.Amount{hex.EncodeToString(script): 1e6}
+		if _, e := MakeScriptOutputs(scripts, params, txrules.DefaultRelayFeePerKb); e == nil {
+			t.Errorf("%s: paid before segwit is active", name)
+		}
+		if e := checkScriptPayable(script, &segwit); e != nil {
+			t.Errorf("%s: refused once segwit is active: %v", name, e)
+		}
+	}
+	nullData, e := txscript.NullDataScript([]byte("pod"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	outputs, e := MakeScriptOutputs(
+		map[string]amt.Amount{hex.EncodeToString(nullData): 0}, params, txrules.DefaultRelayFeePerKb,
+	)
+	if e != nil || len(outputs) != 1 {
+		t.Fatalf("null data output: got %d outputs, error %v", len(outputs), e)
+	}
+}
@@ -0,0 +1,263 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// The wallet database is scrubbed in the background: every row is read back and checked to deserialize and to agree
+// with the rows that refer to it, a few at a time with pauses in between so sends and queries are not held up, so that
+// damage is found before it surfaces as a failed send. The addresses of each account are checked first, as selftest
+// does without the private keys, followed by each kind of row of the transaction store. Problems with the unspent
+// output index are repaired, if allowed, as it is kept from the credits; other problems are logged and reported by
+// getscrubinfo, as their rows hold data that can only be recovered by a rescan or from a backup.
+
+const (
+	// scrubBatch is the most rows of the transaction store checked in one read transaction.
+	scrubBatch = 200
+	// scrubPause is the pause between the batches of a pass.
+	scrubPause = time.Second
+	// scrubDisabledPoll is how often the wallet checks whether scrubbing was enabled while it is disabled.
+	scrubDisabledPoll = time.Minute
+	// maxScrubFindings is the most findings kept, the oldest are dropped beyond this.
+	maxScrubFindings = 100
+	// scrubAddresses is the name of the phase of a pass checking the addresses of the accounts.
+	scrubAddresses = "addresses"
+)
+
+// ScrubFinding is a problem found by scrubbing the wallet database.
+type ScrubFinding struct {
+	// Pass is the number of the pass that found the problem, counting from 1.
+	Pass int
+	Time time.Time
+	// Kind is the kind of rows the problem was found in, and Key the key of the row in hex, or the key scope for
+	// addresses.
+	Kind, Key, Problem string
+	Repaired           bool
+}
+
+// ScrubStatus is the progress of the scrubbing of the wallet database.
+type ScrubStatus struct {
+	Enabled bool
+	Repair  bool
+	// Phase is the kind of rows being checked, empty while waiting for the next pass.
+	Phase string
+	// Passes is the number of passes completed since the wallet was started.
+	Passes int
+	// Started is when the running or last pass started, and Checked the number of rows it has checked.
+	Started time.Time
+	Checked int
+	// Finished is when the last complete pass finished.
+	Finished time.Time
+	// Repaired is the number of problems repaired since the wallet was started.
+	Repaired int
+	// Findings are the problems found by the running pass and the last complete pass.
+	Findings []ScrubFinding
+}
+
+// scrubState is the position of the scrubbing in the wallet database and its status. The zero value is ready to use.
+type scrubState struct {
+	mtx    sync.Mutex
+	status ScrubStatus
+	// phase is the index of the phase of the pass in scrubPhases, scope the index of the key scope whose addresses are
+	// checked next, and after the key of the last row of the transaction store checked.
+	phase int
+	scope int
+	after []byte
+}
+
+// scrubPhases are the phases of a pass, in order.
+var scrubPhases = append([]string{scrubAddresses}, wtxmgr.ScrubKinds...)
+
+// scrubInterval returns how long the wallet waits between passes, which is 0 when scrubbing is disabled.
+func (w *Wallet) scrubInterval() time.Duration {
+	if w.PodConfig == nil || w.PodConfig.WalletScrubInterval == nil {
+		return constant.DefaultWalletScrubInterval
+	}
+	return w.PodConfig.WalletScrubInterval.V()
+}
+
+// scrubRepair returns whether the problems found that can be repaired are.
+func (w *Wallet) scrubRepair() bool {
+	return w.PodConfig == nil || w.PodConfig.WalletScrubRepair == nil || w.PodConfig.WalletScrubRepair.True()
+}
+
+// ScrubStatus returns the progress of the scrubbing of the wallet database and the problems it found.
+func (w *Wallet) ScrubStatus() ScrubStatus {
+	w.scrub.mtx.Lock()
+	defer w.scrub.mtx.Unlock()
+	status := w.scrub.status
+	status.Enabled, status.Repair = w.scrubInterval() > 0, w.scrubRepair()
+	status.Findings = append([]ScrubFinding(nil), status.Findings...)
+	return status
+}
+
+// scrubber scrubs the wallet database, pausing between batches and waiting for the scrub interval between passes.
+func (w *Wallet) scrubber() {
+	defer w.wg.Done()
+	quit := w.quitChan()
+	timer := time.NewTimer(scrubPause)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			delay := scrubPause
+			switch interval := w.scrubInterval(); {
+			case interval <= 0:
+				delay = scrubDisabledPoll
+			case w.scrubStep():
+				delay = interval
+			}
+			timer.Reset(delay)
+		case <-quit.Wait():
+			return
+		}
+	}
+}
+
+// scrubStep checks the next batch of rows and returns true when it completed a pass.
+func (w *Wallet) scrubStep() (done bool) {
+	s := &w.scrub
+	s.mtx.Lock()
+	if s.phase == 0 && s.scope == 0 {
+		s.status.Started, s.status.Checked = time.Now(), 0
+		// Only the findings of the last complete pass are kept with those of the new one.
+		var kept []ScrubFinding
+		for _, f := range s.status.Findings {
+			if f.Pass == s.status.Passes {
+				kept = append(kept, f)
+			}
+		}
+		s.status.Findings = kept
+	}
+	phase, scope, after := s.phase, s.scope, s.after
+	s.status.Phase = scrubPhases[phase]
+	s.mtx.Unlock()
+	var findings []ScrubFinding
+	var checked int
+	if phase == 0 {
+		scopes := w.Manager.ActiveScopedKeyManagers()
+		if scope < len(scopes) {
+			checked, findings = w.scrubScope(scopes[scope])
+			scope++
+		}
+		if scope >= len(scopes) {
+			phase, scope = phase+1, 0
+		}
+	} else {
+		checked, findings, after = w.scrubTxStore(scrubPhases[phase], after)
+		if after == nil {
+			phase++
+		}
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for i := range findings {
+		findings[i].Pass = s.status.Passes + 1
+		if findings[i].Repaired {
+			s.status.Repaired++
+		}
+	}
+	s.status.Findings = append(s.status.Findings, findings...)
+	if len(s.status.Findings) > maxScrubFindings {
+		s.status.Findings = s.status.Findings[len(s.status.Findings)-maxScrubFindings:]
+	}
+	s.status.Checked += checked
+	s.phase, s.scope, s.after = phase, scope, after
+	if phase < len(scrubPhases) {
+		return false
+	}
+	s.phase = 0
+	s.status.Phase = ""
+	s.status.Passes++
+	s.status.Finished = time.Now()
+	D.F("scrubbed %d rows of the wallet database", s.status.Checked)
+	return true
+}
+
+// scrubScope checks the addresses of the accounts of a key scope, and returns the number checked and the problems
+// found.
+func (w *Wallet) scrubScope(scoped *waddrmgr.ScopedKeyManager) (checked int, findings []ScrubFinding) {
+	addresses := newSelfTestCheck(scrubAddresses)
+	// The private keys are not checked, as that needs the wallet to be unlocked.
+	keys := newSelfTestCheck("privatekeys")
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			var accounts []uint32
+			if e = scoped.ForEachAccount(
+				addrmgrNs, func(account uint32) error {
+					accounts = append(accounts, account)
+					return nil
+				},
+			); E.Chk(e) {
+				return
+			}
+			for _, account := range accounts {
+				w.selfTestAccount(addrmgrNs, scoped, account, addresses, keys, false)
+			}
+			return
+		},
+	)
+	if e != nil {
+		addresses.fail("the accounts cannot be read: %v", e)
+	}
+	scope := scoped.Scope()
+	for _, problem := range addresses.result().Details {
+		findings = append(findings, w.scrubFinding(scrubAddresses, scope.String(), problem, false))
+	}
+	return addresses.Checked, findings
+}
+
+// scrubTxStore checks a batch of rows of a kind of the transaction store, starting after the given key, repairs the
+// problems found if allowed, and returns the number checked, the problems found and the key of the last row checked,
+// which is nil once all the rows of the kind have been checked.
+func (w *Wallet) scrubTxStore(kind string, after []byte) (checked int, findings []ScrubFinding, last []byte) {
+	var found []*wtxmgr.ScrubFinding
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			found, checked, last, e = w.TxStore.Scrub(tx.ReadBucket(wtxmgrNamespaceKey), kind, after, scrubBatch)
+			return
+		},
+	)
+	if e != nil {
+		// Go on with the next kind of rows, as the rest of this one cannot be reached.
+		return checked, []ScrubFinding{w.scrubFinding(kind, "", fmt.Sprintf("the rows cannot be read: %v", e), false)}, nil
+	}
+	repaired := make([]bool, len(found))
+	if w.scrubRepair() {
+		for i := range found {
+			if !found[i].Repairable() {
+				continue
+			}
+			if e = walletdb.Update(
+				w.db, func(tx walletdb.ReadWriteTx) error {
+					return found[i].Repair(tx.ReadWriteBucket(wtxmgrNamespaceKey))
+				},
+			); !E.Chk(e) {
+				repaired[i] = true
+			}
+		}
+	}
+	for i, f := range found {
+		findings = append(findings, w.scrubFinding(kind, hex.EncodeToString(f.Key), f.Problem, repaired[i]))
+	}
+	return
+}
+
+// scrubFinding returns a finding, and logs it.
+func (w *Wallet) scrubFinding(kind, key, problem string, repaired bool) ScrubFinding {
+	if repaired {
+		W.F("repaired %s of the wallet database: %s", kind, problem)
+	} else {
+		E.F("found a problem in the %s of the wallet database: %s", kind, problem)
+	}
+	return ScrubFinding{Time: time.Now(), Kind: kind, Key: key, Problem: problem, Repaired: repaired}
+}
@@ -0,0 +1,257 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// Statuses of the checks of SelfTest.
+const (
+	SelfTestPassed  = "passed"
+	SelfTestFailed  = "failed"
+	SelfTestSkipped = "skipped"
+)
+
+// selfTestMaxDetails is the most problems listed for a check, so that a badly damaged wallet still gives a readable
+// report.
+const selfTestMaxDetails = 20
+
+// selfTestCheck gathers the outcome of one of the checks of SelfTest.
+type selfTestCheck struct {
+	btcjson.WalletSelfTestCheckResult
+	problems int
+}
+
+func newSelfTestCheck(name string) *selfTestCheck {
+	return &selfTestCheck{WalletSelfTestCheckResult: btcjson.WalletSelfTestCheckResult{Name: name, Status: SelfTestPassed}}
+}
+
+// fail records a problem found by the check.
+func (c *selfTestCheck) fail(format string, args ...interface{}) {
+	c.Status = SelfTestFailed
+	c.problems++
+	if c.problems <= selfTestMaxDetails {
+		c.Details = append(c.Details, fmt.Sprintf(format, args...))
+	}
+}
+
+// skip records why the check could not be run, unless it already found problems.
+func (c *selfTestCheck) skip(reason string) {
+	if c.Status == SelfTestFailed {
+		return
+	}
+	c.Status = SelfTestSkipped
+	c.Details = append(c.Details, reason)
+}
+
+// result returns the outcome of the check, noting how many problems were left out of the details.
+func (c *selfTestCheck) result() btcjson.WalletSelfTestCheckResult {
+	r := c.WalletSelfTestCheckResult
+	if c.problems > selfTestMaxDetails {
+		r.Details = append(r.Details, fmt.Sprintf("and %d more problems", c.problems-selfTestMaxDetails))
+	}
+	return r
+}
+
+// SelfTest runs consistency checks of the wallet and returns what they found, for diagnosing problems before attempting
+// repairs. It checks that the balance is the sum of the unspent credits, that the addresses are those derived at their
+// indexes, that the private keys decrypt to the keys of the addresses, which is skipped while the wallet is locked, and
+// that the block the wallet is synced to is in the chain. Errors reading the wallet are reported as problems of the
+// check that met them, so the other checks still run.
+func (w *Wallet) SelfTest() (report btcjson.WalletSelfTestResult) {
+	balance := newSelfTestCheck("balance")
+	addresses := newSelfTestCheck("addressindexes")
+	keys := newSelfTestCheck("privatekeys")
+	synced := newSelfTestCheck("syncedblock")
+	w.selfTestBalance(balance)
+	w.selfTestAddresses(addresses, keys)
+	w.selfTestSyncedBlock(synced)
+	report.Passed = true
+	for _, c := range []*selfTestCheck{balance, addresses, keys, synced} {
+		report.Checks = append(report.Checks, c.result())
+		if c.Status == SelfTestFailed {
+			report.Passed = false
+		}
+	}
+	return
+}
+
+// selfTestBalance checks that the balance kept by the transaction store is the sum of its unspent credits.
+func (w *Wallet) selfTestBalance(c *selfTestCheck) {
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncHeight := w.Manager.SyncedTo().Height
+			var balance amt.Amount
+			if balance, e = w.TxStore.Balance(txmgrNs, 0, syncHeight); E.Chk(e) {
+				return
+			}
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(txmgrNs); E.Chk(e) {
+				return
+			}
+			c.Checked = len(unspent)
+			sum := spendableCredits(unspent, syncHeight, int32(w.chainParams.CoinbaseMaturity))
+			if sum != balance {
+				c.fail("the balance is %v but the unspent credits add up to %v", balance, sum)
+			}
+			return
+		},
+	)
+	if e != nil {
+		c.fail("the balance and unspent credits cannot be read: %v", e)
+	}
+}
+
+// spendableCredits returns the sum of the credits that count towards the balance at the height, which leaves out
+// coinbase outputs that have not matured.
+func spendableCredits(credits []wtxmgr.Credit, syncHeight, coinbaseMaturity int32) (sum amt.Amount) {
+	for _, c := range credits {
+		if c.FromCoinBase && c.Height >= 0 && syncHeight-c.Height+1 < coinbaseMaturity {
+			continue
+		}
+		sum += c.Amount
+	}
+	return
+}
+
+// selfTestAddresses checks that the addresses of the accounts are those derived at their indexes, and within the
+// number of addresses of their branch, and, if the wallet is unlocked, that their private keys decrypt to the keys of
+// the addresses.
+func (w *Wallet) selfTestAddresses(addresses, keys *selfTestCheck) {
+	checkKeys := true
+	switch {
+	case w.Manager.WatchOnly():
+		keys.skip("the wallet is watching-only")
+		checkKeys = false
+	case w.Manager.IsLocked():
+		keys.skip("the wallet must be unlocked to check its private keys")
+		checkKeys = false
+	}
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			for _, scoped := range w.Manager.ActiveScopedKeyManagers() {
+				var accounts []uint32
+				if e = scoped.ForEachAccount(
+					addrmgrNs, func(account uint32) error {
+						accounts = append(accounts, account)
+						return nil
+					},
+				); E.Chk(e) {
+					return
+				}
+				for _, account := range accounts {
+					w.selfTestAccount(addrmgrNs, scoped, account, addresses, keys, checkKeys)
+				}
+			}
+			return
+		},
+	)
+	if e != nil {
+		addresses.fail("the accounts cannot be read: %v", e)
+	}
+}
+
+// selfTestAccount checks the addresses of an account for selfTestAddresses.
+func (w *Wallet) selfTestAccount(
+	addrmgrNs walletdb.ReadBucket, scoped *waddrmgr.ScopedKeyManager, account uint32,
+	addresses, keys *selfTestCheck, checkKeys bool,
+) {
+	scope := scoped.Scope()
+	scopeName := scope.String()
+	props, e := scoped.AccountProperties(addrmgrNs, account)
+	if e != nil {
+		addresses.fail("the properties of account %d of scope %s cannot be read: %v", account, scopeName, e)
+		return
+	}
+	// The addresses are gathered first, as the manager is locked while they are gone through.
+	var maddrs []waddrmgr.ManagedPubKeyAddress
+	if e = scoped.ForEachAccountAddress(
+		addrmgrNs, account, func(maddr waddrmgr.ManagedAddress) error {
+			if pka, ok := maddr.(waddrmgr.ManagedPubKeyAddress); ok {
+				maddrs = append(maddrs, pka)
+			}
+			return nil
+		},
+	); e != nil {
+		addresses.fail("the addresses of account %d of scope %s cannot be read: %v", account, scopeName, e)
+		return
+	}
+	for _, maddr := range maddrs {
+		addr := maddr.Address().EncodeAddress()
+		if checkKeys {
+			keys.Checked++
+			if priv, e := maddr.PrivKey(); e != nil {
+				keys.fail("the private key of %s cannot be decrypted: %v", addr, e)
+			} else if string(priv.PubKey().SerializeCompressed()) != string(maddr.PubKey().SerializeCompressed()) {
+				keys.fail("the private key of %s is not the key of the address", addr)
+			}
+		}
+		_, path, derived := maddr.DerivationInfo()
+		if !derived {
+			// Imported keys are not derived from the wallet seed.
+			continue
+		}
+		addresses.Checked++
+		keyPath := fmt.Sprintf("%s/%d'/%d/%d", scopeName, path.Account, path.Branch, path.Index)
+		count := props.ExternalKeyCount
+		if path.Branch == waddrmgr.InternalBranch {
+			count = props.InternalKeyCount
+		}
+		if path.Index >= count {
+			addresses.fail("%s is at %s, beyond the %d addresses of its branch", addr, keyPath, count)
+		}
+		var want waddrmgr.ManagedAddress
+		if want, e = scoped.DeriveFromKeyPath(addrmgrNs, path); e != nil {
+			addresses.fail("the key of %s cannot be derived: %v", addr, e)
+			continue
+		}
+		if want.Address().EncodeAddress() != addr {
+			addresses.fail("%s is not the address derived at %s, which is %s", addr, keyPath, want.Address().EncodeAddress())
+		}
+	}
+}
+
+// selfTestSyncedBlock checks that the wallet has recorded the block it is synced to, and that the block is in the
+// chain of the chain server.
+func (w *Wallet) selfTestSyncedBlock(c *selfTestCheck) {
+	synced := w.Manager.SyncedTo()
+	c.Checked = 1
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			hash, e := w.Manager.BlockHash(tx.ReadBucket(waddrmgrNamespaceKey), synced.Height)
+			if e != nil {
+				return
+			}
+			if *hash != synced.Hash {
+				c.fail("the wallet is synced to %s at height %d but has recorded %s there", synced.Hash, synced.Height, hash)
+			}
+			return
+		},
+	)
+	if e != nil {
+		c.fail("the wallet has not recorded the block at height %d it is synced to: %v", synced.Height, e)
+	}
+	chainClient := w.ChainClient()
+	if chainClient == nil {
+		c.skip("the wallet is not connected to a chain server")
+		return
+	}
+	hash, e := chainClient.GetBlockHash(int64(synced.Height))
+	if e != nil {
+		c.fail("the chain server has no block at height %d, which the wallet is synced to: %v", synced.Height, e)
+		return
+	}
+	if *hash != synced.Hash {
+		c.fail(
+			"the block %s at height %d the wallet is synced to is not in the chain, which has %s there",
+			synced.Hash, synced.Height, hash,
+		)
+	}
+}
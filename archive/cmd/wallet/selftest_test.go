@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestSpendableCredits ensures the credits compared with the balance leave out immature coinbase outputs only.
+func TestSpendableCredits(t *testing.T) {
+	credits := []wtxmgr.Credit{
+		{Amount: 1e8, BlockMeta: wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: 10}}},
+		{Amount: 2e8, BlockMeta: wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: -1}}},
+		// A coinbase with 9 confirmations at height 100 has matured, and one with 8 has not.
+		{Amount: 4e8, BlockMeta: wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: 92}}, FromCoinBase: true},
+		{Amount: 8e8, BlockMeta: wtxmgr.BlockMeta{Block: wtxmgr.Block{Height: 93}}, FromCoinBase: true},
+	}
+	if got, want := spendableCredits(credits, 100, 9), amt.Amount(7e8); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestSelfTestCheck ensures a check fails on a problem, is not marked skipped after failing, and lists a limited number
+// of problems.
+func TestSelfTestCheck(t *testing.T) {
+	c := newSelfTestCheck("test")
+	if r := c.result(); r.Status != SelfTestPassed || len(r.Details) != 0 {
+		t.Fatalf("new check is %+v", r)
+	}
+	for i := 0; i < selfTestMaxDetails+5; i++ {
+		c.fail("problem %d", i)
+	}
+	c.skip("not run")
+	r := c.result()
+	if r.Status != SelfTestFailed {
+		t.Fatalf("check is %s, want %s", r.Status, SelfTestFailed)
+	}
+	if len(r.Details) != selfTestMaxDetails+1 || r.Details[selfTestMaxDetails] != "and 5 more problems" {
+		t.Fatalf("check has details %q", r.Details)
+	}
+	c = newSelfTestCheck("test")
+	c.skip("not run")
+	if r = c.result(); r.Status != SelfTestSkipped || len(r.Details) != 1 {
+		t.Fatalf("skipped check is %+v", r)
+	}
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txsizes"
+)
+
+// SendFee returns the fee rate per kilobyte and the absolute fee of a send from the fee options given with it, which
+// are used in place of the fee rate of the wallet when they are not nil. Only one of the fee rate and the fee may be
+// given. A fee rate must be at least the minimum relay fee rate, and an absolute fee no more than the maxtxfee
+// setting. An absolute fee is checked against the minimum relay fee for the size of the transaction once it is made.
+func (w *Wallet) SendFee(opts *btcjson.SendFeeOptions) (satPerKb, fixed amt.Amount, e error) {
+	satPerKb = txrules.DefaultRelayFeePerKb
+	if opts == nil {
+		return
+	}
+	feeRate, fee := opts.FeeRate, opts.Fee
+	switch {
+	case feeRate != nil && fee != nil:
+		return 0, 0, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Only one of feerate and fee may be given",
+		}
+	case feeRate != nil:
+		if satPerKb, e = amt.NewAmount(*feeRate); E.Chk(e) {
+			return
+		}
+		if minFee := w.minRelayFee(); satPerKb < minFee {
+			return 0, 0, &btcjson.RPCError{
+				Code: btcjson.ErrRPCInvalidParameter,
+				Message: fmt.Sprintf(
+					"fee rate of %v/kB is below the minimum relay fee rate of %v/kB", satPerKb, minFee,
+				),
+			}
+		}
+	case fee != nil:
+		if fixed, e = amt.NewAmount(*fee); E.Chk(e) {
+			return
+		}
+		if fixed <= 0 {
+			return 0, 0, ErrNeedPositiveAmount
+		}
+		if maxFee := w.maxTxFee(); maxFee > 0 && fixed > maxFee {
+			return 0, 0, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCHighFee,
+				Message: fmt.Sprintf("fee of %v is over the maximum of %v set by maxtxfee", fixed, maxFee),
+			}
+		}
+	}
+	return
+}
+
+// minRelayFee returns the fee rate per kilobyte below which transactions are not relayed, from the MinRelayTxFee
+// setting, or the default relay fee rate if it is not set.
+func (w *Wallet) minRelayFee() (minFee amt.Amount) {
+	minFee = txrules.DefaultRelayFeePerKb
+	if w.PodConfig == nil || w.PodConfig.MinRelayTxFee == nil {
+		return
+	}
+	if f, e := amt.NewAmount(w.PodConfig.MinRelayTxFee.V()); !E.Chk(e) && f > 0 {
+		minFee = f
+	}
+	return
+}
+
+// maxTxFee returns the MaxTxFee setting, the highest fee a transaction sent by the wallet may pay, or zero if there is
+// no limit.
+func (w *Wallet) maxTxFee() (maxFee amt.Amount) {
+	if w.PodConfig == nil || w.PodConfig.MaxTxFee == nil {
+		return
+	}
+	var e error
+	if maxFee, e = amt.NewAmount(w.PodConfig.MaxTxFee.V()); E.Chk(e) || maxFee < 0 {
+		return 0
+	}
+	return
+}
+
+// checkMinRelayFee returns an error if the fee paid by the transaction is less than the minimum relay fee for its size
+// once it is signed, which a transaction paying a fee given by the sender may be.
+func (w *Wallet) checkMinRelayFee(tx *txauthor.AuthoredTx) (e error) {
+	fee := tx.TotalInput
+	for _, txOut := range tx.Tx.TxOut {
+		fee -= amt.Amount(txOut.Value)
+	}
+	outputs := tx.Tx.TxOut
+	if tx.ChangeIndex >= 0 {
+		outputs = append(outputs[:tx.ChangeIndex:tx.ChangeIndex], outputs[tx.ChangeIndex+1:]...)
+	}
+	size := txsizes.EstimateVirtualSize(len(tx.Tx.TxIn), 0, 0, outputs, tx.ChangeIndex >= 0)
+	if minFee := txrules.FeeForSerializeSize(w.minRelayFee(), size); fee < minFee {
+		return &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf(
+				"fee of %v is below the minimum relay fee of %v for a transaction of %d bytes", fee, minFee, size,
+			),
+		}
+	}
+	return
+}
@@ -20,6 +20,7 @@ import (
 	
 	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/interrupt"
 )
 
@@ -27,9 +28,12 @@ type WebsocketClient struct {
 	conn          *websocket.Conn
 	authenticated bool
 	remoteAddr    string
+	identity      string // user name and address of the client for the audit log
 	allRequests   chan []byte
 	responses     chan []byte
 	quit          qu.C // closed on disconnect
+	stopNtfns     qu.C // closed when requests are no longer served, to stop sending notifications
+	walletEvents  bool // registered for the events of the notification journal
 	wg            sync.WaitGroup
 }
 
@@ -41,6 +45,7 @@ func NewWebsocketClient(c *websocket.Conn, authenticated bool, remoteAddr string
 		allRequests:   make(chan []byte),
 		responses:     make(chan []byte),
 		quit:          qu.T(),
+		stopNtfns:     qu.T(),
 	}
 }
 func (c *WebsocketClient) Send(b []byte) (e error) {
@@ -66,6 +71,7 @@ type Server struct {
 	Upgrader            websocket.Upgrader
 	MaxPostClients      int64 // Max concurrent HTTP POST clients.
 	MaxWebsocketClients int64 // Max concurrent websocket clients.
+	CompatLegacy        bool  // Serve requests as the legacy Parallelcoin daemon did.
 	WG                  sync.WaitGroup
 	Quit                qu.C
 	QuitMutex           sync.Mutex
@@ -92,6 +98,7 @@ func NewServer(opts *Options, walletLoader *Loader, listeners []net.Listener, qu
 		WalletLoader:        walletLoader,
 		MaxPostClients:      opts.MaxPOSTClients,
 		MaxWebsocketClients: opts.MaxWebsocketClients,
+		CompatLegacy:        opts.CompatLegacy,
 		Listeners:           listeners,
 		// A hash of the HTTP basic auth string is used for a constant time comparison.
 		AuthSHA: sha256.Sum256(HTTPBasicAuth(opts.Username, opts.Password)),
@@ -146,6 +153,10 @@ func NewServer(opts *Options, walletLoader *Loader, listeners []net.Listener, qu
 					return
 				}
 				wsc := NewWebsocketClient(conn, authenticated, r.RemoteAddr)
+				if authenticated {
+					user, _, _ := r.BasicAuth()
+					wsc.identity = clientIdentity(user, r.RemoteAddr)
+				}
 				server.WebsocketClientRPC(wsc)
 			},
 		),
@@ -269,9 +280,36 @@ func (s *Server) HandlerClosure(request *btcjson.Request) LazyHandler {
 		D.Ln("HandlerClosure got the ChainClient")
 	}
 	s.HandlerMutex.Unlock()
+	if s.CompatLegacy {
+		return legacyHandler(request, wllt, chainClient)
+	}
 	return LazyApplyHandler(request, wllt, chainClient)
 }
 
+// auditedHandler wraps the handler of a request so the request is recorded in the audit log of the wallet, together
+// with the identity of the client that made it, if it changes the wallet.
+func (s *Server) auditedHandler(request *btcjson.Request, identity string, f LazyHandler) LazyHandler {
+	if _, ok := auditedMethods[request.Method]; !ok {
+		return f
+	}
+	return func() (interface{}, *btcjson.RPCError) {
+		res, jsonErr := f()
+		s.HandlerMutex.Lock()
+		wllt := s.Wallet
+		s.HandlerMutex.Unlock()
+		if wllt != nil {
+			wllt.auditRequest(identity, request, res, jsonErr)
+		}
+		return res, jsonErr
+	}
+}
+
+// clientIdentity returns the identity of an RPC client that is recorded in the audit log, the user name it
+// authenticated with and its address.
+func clientIdentity(user, remoteAddr string) string {
+	return user + "@" + remoteAddr
+}
+
 // ErrNoAuth represents an error where authentication could not succeed due to a
 // missing Authorization HTTP header.
 var ErrNoAuth = errors.New("no auth")
@@ -392,8 +430,7 @@ out:
 				// client disconnected
 				break out
 			}
-			var req btcjson.Request
-			e := js.Unmarshal(reqBytes, &req)
+			req, e := btcjson.UnmarshalRequest(reqBytes, s.CompatLegacy)
 			if e != nil {
 				if !wsc.authenticated {
 					// Disconnect immediately.
@@ -421,6 +458,11 @@ out:
 					break out
 				}
 				wsc.authenticated = true
+				if cmd, e := btcjson.UnmarshalCmd(&req); e == nil {
+					if authCmd, ok := cmd.(*btcjson.AuthenticateCmd); ok {
+						wsc.identity = clientIdentity(authCmd.Username, wsc.remoteAddr)
+					}
+				}
 				resp := MakeResponse(req.ID, nil, nil)
 				// Expected to never fail.
 				mResp, e := js.Marshal(resp)
@@ -471,9 +513,24 @@ out:
 				interrupt.Restart = true
 				s.RequestProcessShutdown()
 			// break
+			case "notifywalletevents":
+				since, jsonErr := s.registerWalletEvents(wsc, &req)
+				mResp, e := btcjson.MarshalResponse(req.ID, nil, jsonErr)
+				// Expected to never fail.
+				if e != nil {
+					panic(e)
+				}
+				if e = wsc.Send(mResp); e != nil {
+					break out
+				}
+				if jsonErr == nil {
+					// The events are sent after the response.
+					wsc.wg.Add(1)
+					go s.sendWalletEvents(wsc, since)
+				}
 			default:
 				req := req // Copy for the closure
-				f := s.HandlerClosure(&req)
+				f := s.auditedHandler(&req, wsc.identity, s.HandlerClosure(&req))
 				wsc.wg.Add(1)
 				go func() {
 					resp, jsonErr := f()
@@ -493,6 +550,7 @@ out:
 		}
 	}
 	// allow client to disconnect after all handler goroutines are done
+	wsc.stopNtfns.Q()
 	wsc.wg.Wait()
 	close(wsc.responses)
 	s.WG.Done()
@@ -533,6 +591,103 @@ out:
 	s.WG.Done()
 }
 
+// registerWalletEvents registers the client for the events of the notification journal of the wallet for a
+// notifywalletevents request, returning the sequence number of the last event the client does not need to be sent.
+func (s *Server) registerWalletEvents(wsc *WebsocketClient, req *btcjson.Request) (since uint64, jsonErr *btcjson.RPCError) {
+	cmd, e := btcjson.UnmarshalCmd(req)
+	if e != nil {
+		return 0, btcjson.ErrRPCInvalidRequest
+	}
+	c, ok := cmd.(*btcjson.NotifyWalletEventsCmd)
+	if !ok {
+		return 0, btcjson.ErrRPCInvalidRequest
+	}
+	if wsc.walletEvents {
+		return 0, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "already registered for wallet events",
+		}
+	}
+	s.HandlerMutex.Lock()
+	wllt := s.Wallet
+	s.HandlerMutex.Unlock()
+	if wllt == nil {
+		return 0, &ErrUnloadedWallet
+	}
+	switch {
+	case c.SinceSequence != nil && c.SinceBlock != nil:
+		return 0, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "only one of sincesequence and sinceblock may be given",
+		}
+	case c.SinceSequence != nil:
+		since = *c.SinceSequence
+		// Reading the events checks they are all still in the journal.
+		if _, e = wllt.JournalEvents(since, 1); e != nil {
+			return 0, &btcjson.RPCError{Code: btcjson.ErrRPCInvalidParameter, Message: e.Error()}
+		}
+	case c.SinceBlock != nil:
+		var hash *chainhash.Hash
+		if hash, e = chainhash.NewHashFromStr(*c.SinceBlock); e != nil {
+			return 0, &btcjson.RPCError{Code: btcjson.ErrRPCDeserialization, Message: e.Error()}
+		}
+		if since, e = wllt.JournalBlockSequence(hash); e != nil {
+			return 0, &btcjson.RPCError{Code: btcjson.ErrRPCInvalidParameter, Message: e.Error()}
+		}
+	default:
+		if since, e = wllt.JournalLastSequence(); e != nil {
+			return 0, &btcjson.RPCError{Code: btcjson.ErrRPCDatabase, Message: e.Error()}
+		}
+	}
+	wsc.walletEvents = true
+	return
+}
+
+// sendWalletEvents sends the client the events of the notification journal of the wallet after the sequence number
+// since, in order, and then each event as it is added, until the client disconnects. If the client falls so far behind
+// that events it was not sent are removed from the journal, it is disconnected, so it finds out about the missed events
+// when it registers again.
+func (s *Server) sendWalletEvents(wsc *WebsocketClient, since uint64) {
+	defer wsc.wg.Done()
+	s.HandlerMutex.Lock()
+	wllt := s.Wallet
+	s.HandlerMutex.Unlock()
+	poll := time.NewTicker(journalPollInterval)
+	defer poll.Stop()
+	for {
+		// The channel is taken before reading the journal, so events added while it is read wake the next read.
+		wake := wllt.JournalWait()
+		events, e := wllt.JournalEvents(since, journalBatch)
+		if e != nil {
+			W.F("cannot send wallet events to client %s: %v", wsc.remoteAddr, e)
+			if e = wsc.conn.Close(); E.Chk(e) {
+			}
+			return
+		}
+		for i := range events {
+			var ntfn []byte
+			if ntfn, e = btcjson.MarshalCmd(nil, btcjson.NewWalletEventNtfn(events[i].result())); E.Chk(e) {
+				return
+			}
+			if e = wsc.Send(ntfn); e != nil {
+				return
+			}
+			since = events[i].Sequence
+		}
+		if len(events) == journalBatch {
+			continue
+		}
+		select {
+		case <-wake:
+		case <-poll.C:
+		case <-wsc.stopNtfns.Wait():
+			return
+		case <-s.Quit.Wait():
+			return
+		}
+	}
+}
+
 // WebsocketClientRPC starts the goroutines to serve JSON-RPC requests over a websocket connection for a single client.
 func (s *Server) WebsocketClientRPC(wsc *WebsocketClient) {
 	I.F("new websocket client %s", wsc.remoteAddr)
@@ -571,7 +726,7 @@ func (s *Server) POSTClientRPC(w http.ResponseWriter, r *http.Request) {
 	// server for further processing. While checking the methods, disallow authenticate requests, as they are invalid
 	// for HTTP POST clients.
 	var req btcjson.Request
-	e = js.Unmarshal(rpcRequest, &req)
+	req, e = btcjson.UnmarshalRequest(rpcRequest, s.CompatLegacy)
 	if e != nil {
 		var resp []byte
 		resp, e = btcjson.MarshalResponse(req.ID, nil, btcjson.ErrRPCInvalidRequest)
@@ -609,7 +764,8 @@ func (s *Server) POSTClientRPC(w http.ResponseWriter, r *http.Request) {
 		stop = true
 		res = "pod/wallet restarting"
 	default:
-		res, jsonErr = s.HandlerClosure(&req)()
+		user, _, _ := r.BasicAuth()
+		res, jsonErr = s.auditedHandler(&req, clientIdentity(user, r.RemoteAddr), s.HandlerClosure(&req))()
 	}
 	// Marshal and send.
 	mResp, e := btcjson.MarshalResponse(req.ID, res, jsonErr)
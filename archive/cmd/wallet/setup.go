@@ -0,0 +1,327 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/p9c/qu"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/p9c/pod/pkg/backup"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/fork"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pod/config"
+)
+
+// MinPassphraseLength is the shortest wallet passphrase that the setup will accept.
+const MinPassphraseLength = 8
+
+var (
+	// ErrSetupPassphraseShort is returned when a passphrase shorter than MinPassphraseLength is given to the setup.
+	ErrSetupPassphraseShort = fmt.Errorf(
+		"wallet passphrase must be at least %d characters long", MinPassphraseLength,
+	)
+	// ErrSetupPassphraseUnset is returned when a wallet is created before the passphrases have been set.
+	ErrSetupPassphraseUnset = errors.New("wallet passphrase has not been set")
+	// ErrSetupSeedUnset is returned when a wallet is created before a seed has been generated or restored.
+	ErrSetupSeedUnset = errors.New("wallet seed has not been generated or restored")
+	// ErrSetupDataDirUnset is returned when an empty data directory is given to the setup.
+	ErrSetupDataDirUnset = errors.New("data directory must not be empty")
+	// ErrSetupNotRestoring is returned when a wallet is restored from a backup before the seed of the wallet has been
+	// restored, as the backup is found and decrypted with a key derived from it.
+	ErrSetupNotRestoring = errors.New("the seed of the wallet must be restored to restore it from a backup")
+)
+
+// Setup walks through the choices made when a wallet is first set up, so the GUI first run wizard does not have to
+// edit the configuration itself. Each step only changes the configuration in memory, which is written to the
+// configuration file by CreateWallet, or by Save if the wizard needs to keep its progress.
+type Setup struct {
+	Config    *config.Config
+	ActiveNet *chaincfg.Params
+	seed      []byte
+	restored  bool
+	pubPass   []byte
+	privPass  []byte
+}
+
+// NewSetup returns a Setup for the given configuration, starting on the given network.
+func NewSetup(cfg *config.Config, activeNet *chaincfg.Params) *Setup {
+	return &Setup{Config: cfg, ActiveNet: activeNet}
+}
+
+// SetNetwork switches the setup to the named network and sets the peer and RPC addresses to the default ports of the
+// network.
+func (s *Setup) SetNetwork(network string) (e error) {
+	switch network {
+	case "mainnet", "m":
+		s.ActiveNet = &chaincfg.MainNetParams
+		fork.IsTestnet = false
+	case "testnet", "testnet3", "t":
+		s.ActiveNet = &chaincfg.TestNet3Params
+		fork.IsTestnet = true
+	case "regtestnet", "regressiontest", "r":
+		s.ActiveNet = &chaincfg.RegressionTestParams
+		fork.IsTestnet = true
+	case "simnet", "s":
+		s.ActiveNet = &chaincfg.SimNetParams
+		fork.IsTestnet = true
+	case "signet":
+		var challenge []byte
+		if challenge, e = hex.DecodeString(s.Config.SignetChallenge.V()); E.Chk(e) {
+			return
+		}
+		if s.ActiveNet, e = chaincfg.SigNetParams(challenge); E.Chk(e) {
+			return
+		}
+		fork.IsTestnet = true
+	default:
+		return fmt.Errorf("unknown network '%s'", network)
+	}
+	I.Ln("activenet:", s.ActiveNet.Name)
+	cfg := s.Config
+	if e = cfg.Network.Set(s.ActiveNet.Name); E.Chk(e) {
+		return
+	}
+	if e = cfg.P2PListeners.Set([]string{"0.0.0.0:" + s.ActiveNet.DefaultPort}); E.Chk(e) {
+		return
+	}
+	if e = cfg.P2PConnect.Set([]string{"127.0.0.1:" + s.ActiveNet.DefaultPort}); E.Chk(e) {
+		return
+	}
+	address := "127.0.0.1:" + s.ActiveNet.RPCClientPort
+	if e = cfg.RPCListeners.Set([]string{address}); E.Chk(e) {
+		return
+	}
+	if e = cfg.RPCConnect.Set(address); E.Chk(e) {
+		return
+	}
+	address = "127.0.0.1:" + s.ActiveNet.WalletRPCServerPort
+	if e = cfg.WalletRPCListeners.Set([]string{address}); E.Chk(e) {
+		return
+	}
+	if e = cfg.WalletServer.Set(address); E.Chk(e) {
+		return
+	}
+	return s.setWalletFile()
+}
+
+// SetDataDir moves the data directory, and the configuration, log and wallet files kept inside it.
+func (s *Setup) SetDataDir(dataDir string) (e error) {
+	if dataDir == "" {
+		return ErrSetupDataDirUnset
+	}
+	cfg := s.Config
+	if e = cfg.DataDir.Set(dataDir); E.Chk(e) {
+		return
+	}
+	if e = cfg.ConfigFile.Set(filepath.Join(dataDir, constant.PodConfigFilename)); E.Chk(e) {
+		return
+	}
+	if e = cfg.LogDir.Set(dataDir); E.Chk(e) {
+		return
+	}
+	return s.setWalletFile()
+}
+
+// setWalletFile points the wallet file at the wallet database of the active network in the data directory.
+func (s *Setup) setWalletFile() (e error) {
+	return s.Config.WalletFile.Set(
+		filepath.Join(s.Config.DataDir.V(), s.ActiveNet.Name, constant.DbName),
+	)
+}
+
+// SetPassphrases sets the private passphrase, which unlocks the wallet, and the public passphrase, which encrypts the
+// public data in the wallet. If the public passphrase is empty the private passphrase is used for both.
+func (s *Setup) SetPassphrases(private, public string) (e error) {
+	if len(private) < MinPassphraseLength {
+		return ErrSetupPassphraseShort
+	}
+	if public == "" {
+		public = private
+	}
+	s.privPass, s.pubPass = []byte(private), []byte(public)
+	return s.Config.WalletPass.Set(public)
+}
+
+// NewSeed generates a random seed for a new wallet and returns the mnemonic words that encode it, which the user must
+// write down to be able to restore the wallet.
+func (s *Setup) NewSeed() (words string, e error) {
+	var seed []byte
+	if seed, e = hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen); E.Chk(e) {
+		return
+	}
+	if words, e = bip39.NewMnemonic(seed); E.Chk(e) {
+		return
+	}
+	s.seed, s.restored = seed, false
+	return
+}
+
+// RestoreSeed sets the seed of the wallet from the mnemonic words of a wallet being restored.
+func (s *Setup) RestoreSeed(words string) (e error) {
+	var seed []byte
+	if seed, e = bip39.EntropyFromMnemonic(strings.Join(strings.Fields(words), " ")); E.Chk(e) {
+		return
+	}
+	s.seed, s.restored = seed, true
+	return
+}
+
+// VerifySeed returns true if the words entered by the user match the seed that was generated or restored.
+func (s *Setup) VerifySeed(words string) bool {
+	seed, e := bip39.EntropyFromMnemonic(strings.Join(strings.Fields(words), " "))
+	if e != nil || s.seed == nil {
+		return false
+	}
+	return string(seed) == string(s.seed)
+}
+
+// SetMining enables or disables mining when the wallet starts, with the given number of threads.
+func (s *Setup) SetMining(enable bool, threads int) (e error) {
+	if e = s.Config.Generate.Set(enable); E.Chk(e) {
+		return
+	}
+	return s.Config.GenThreads.Set(threads)
+}
+
+// WalletExists returns true if there is already a wallet in the data directory for the active network.
+func (s *Setup) WalletExists() (bool, error) {
+	return NewLoader(s.ActiveNet, s.Config.WalletFile.V(), 250).WalletExists()
+}
+
+// CreateWallet creates the wallet with the seed and passphrases chosen in the setup, and saves the configuration so
+// the node and wallet start with it. A restored wallet gets the birthday of the genesis block of the network so the
+// whole chain is scanned for its transactions.
+func (s *Setup) CreateWallet() (e error) {
+	if s.privPass == nil {
+		return ErrSetupPassphraseUnset
+	}
+	if s.seed == nil {
+		return ErrSetupSeedUnset
+	}
+	if e = s.setWalletFile(); E.Chk(e) {
+		return
+	}
+	bday := time.Now()
+	if s.restored {
+		bday = s.ActiveNet.GenesisBlock.Header.Timestamp
+	}
+	loader := NewLoader(s.ActiveNet, s.Config.WalletFile.V(), 250)
+	if _, e = loader.CreateNewWallet(
+		s.pubPass, s.privPass, s.seed, bday, true, s.Config, qu.T(),
+	); E.Chk(e) {
+		return
+	}
+	I.Ln("created wallet", s.Config.WalletFile.V())
+	s.Config.NodeOff.F()
+	s.Config.WalletOff.F()
+	return s.Save()
+}
+
+// RestoreBackup restores the wallet from its latest backup at the backup target with the given URL, which is found and
+// decrypted with the restored seed, and saves the configuration so the node and wallet start with it and the wallet
+// keeps being backed up to the target. The passphrases must be those of the wallet when it was backed up, and are
+// checked before the restored wallet replaces the wallet file, so a wallet that cannot be opened is never left behind.
+func (s *Setup) RestoreBackup(target string) (e error) {
+	if s.privPass == nil {
+		return ErrSetupPassphraseUnset
+	}
+	if s.seed == nil || !s.restored {
+		return ErrSetupNotRestoring
+	}
+	if e = s.setWalletFile(); E.Chk(e) {
+		return
+	}
+	var exists bool
+	if exists, e = s.WalletExists(); E.Chk(e) {
+		return
+	}
+	walletFile := s.Config.WalletFile.V()
+	if exists {
+		return errors.New("wallet " + walletFile + " already exists")
+	}
+	var key *backup.Key
+	if key, e = backup.NewKeyFromSeed(s.seed, s.ActiveNet); E.Chk(e) {
+		return
+	}
+	defer key.Zero()
+	var t backup.Target
+	if t, e = backup.Open(target); E.Chk(e) {
+		return
+	}
+	var data []byte
+	if data, e = backup.Download(t, key); E.Chk(e) {
+		return
+	}
+	if e = os.MkdirAll(filepath.Dir(walletFile), 0700); E.Chk(e) {
+		return
+	}
+	temp := walletFile + ".restore"
+	if e = ioutil.WriteFile(temp, data, 0600); E.Chk(e) {
+		return
+	}
+	if e = s.checkPassphrases(temp); E.Chk(e) {
+		if e := os.Remove(temp); E.Chk(e) {
+		}
+		return
+	}
+	if e = os.Rename(temp, walletFile); E.Chk(e) {
+		return
+	}
+	I.Ln("restored wallet", walletFile, "from", backup.Redact(target))
+	targets := s.Config.WalletBackupTargets.V()
+	known := false
+	for _, u := range targets {
+		known = known || u == target
+	}
+	if !known {
+		if e = s.Config.WalletBackupTargets.Set(append(targets, target)); E.Chk(e) {
+			return
+		}
+	}
+	s.Config.NodeOff.F()
+	s.Config.WalletOff.F()
+	return s.Save()
+}
+
+// checkPassphrases opens the wallet database at the path with the passphrases of the setup, and returns an error if
+// either of them does not open it.
+func (s *Setup) checkPassphrases(dbPath string) (e error) {
+	var db walletdb.DB
+	if db, e = walletdb.Open("bdb", dbPath); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := db.Close(); E.Chk(e) {
+		}
+	}()
+	return walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			if ns == nil {
+				return errors.New("the backup is not a wallet")
+			}
+			var mgr *waddrmgr.Manager
+			if mgr, e = waddrmgr.Open(ns, s.pubPass, s.ActiveNet); E.Chk(e) {
+				return
+			}
+			defer mgr.Close()
+			return mgr.Unlock(ns, s.privPass)
+		},
+	)
+}
+
+// Save writes the configuration as it stands to the configuration file.
+func (s *Setup) Save() (e error) {
+	return s.Config.WriteToFile(s.Config.ConfigFile.V())
+}
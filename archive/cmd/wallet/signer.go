@@ -0,0 +1,353 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/p9c/interrupt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pod/state"
+)
+
+// A wallet can be split in two so that private keys never live in the wallet that is connected to the network. The
+// signer is a wallet holding the private keys, run with SignerListen set, which does nothing but sign the transactions
+// it is sent. The front is a watching-only copy of the same wallet, made with exportwatchingwallet, run with
+// SignerConnect pointing at the signer. The front builds transactions as usual and sends them to the signer to have
+// their input scripts added instead of signing them itself.
+//
+// The signer can listen on a unix socket, written "unix:/path/to/socket", or on a TCP address, which uses the TLS
+// certificate of the wallet RPC server. A TCP address can't be used with client TLS disabled, as the handshake only
+// authenticates the ends and a request sent in the clear could be swapped after it. Both ends must be given the same
+// SignerSecret, which they prove to each other before any request is served.
+
+const (
+	// signerNonceSize is the size of the random challenges exchanged when a connection to the signer is opened.
+	signerNonceSize = 32
+	// signerHandshakeTimeout is how long either end waits for the other to prove it knows the secret.
+	signerHandshakeTimeout = 10 * time.Second
+)
+
+// ErrSignerAuth is returned when the other end of a signer connection does not know the shared secret.
+var ErrSignerAuth = errors.New("signer authentication failed")
+
+// ErrSignerTLSRequired is returned when a signer is to be reached over TCP without TLS.
+var ErrSignerTLSRequired = errors.New("a signer on a TCP address requires TLS, use a unix socket or enable client TLS")
+
+// SignRequest is a transaction sent to the signer to have its input scripts added.
+type SignRequest struct {
+	// Tx is the serialized unsigned transaction.
+	Tx []byte
+	// PrevScripts are the output scripts redeemed by each input.
+	PrevScripts [][]byte
+	// InputValues are the amounts of the outputs redeemed by each input.
+	InputValues []int64
+}
+
+// SignReply carries the signed transaction back from the signer.
+type SignReply struct {
+	// Tx is the serialized signed transaction.
+	Tx []byte
+}
+
+// Signer serves signing requests with the private keys of a wallet.
+type Signer struct {
+	w *Wallet
+}
+
+// Sign adds the input scripts to the transaction in the request with the keys of the wallet, which must be unlocked.
+func (s *Signer) Sign(req *SignRequest, reply *SignReply) (e error) {
+	if s.w.Manager.IsLocked() {
+		return errors.New("signer wallet is locked")
+	}
+	tx := &wire.MsgTx{}
+	if e = tx.Deserialize(bytes.NewReader(req.Tx)); E.Chk(e) {
+		return
+	}
+	if len(req.PrevScripts) != len(tx.TxIn) || len(req.InputValues) != len(tx.TxIn) {
+		return fmt.Errorf(
+			"transaction has %d inputs but %d previous scripts and %d input values",
+			len(tx.TxIn), len(req.PrevScripts), len(req.InputValues),
+		)
+	}
+	inputValues := make([]amt.Amount, len(req.InputValues))
+	for i := range req.InputValues {
+		inputValues[i] = amt.Amount(req.InputValues[i])
+	}
+	if e = walletdb.View(
+		s.w.db, func(dbtx walletdb.ReadTx) (e error) {
+			addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+			return txauthor.AddAllInputScripts(
+				tx, req.PrevScripts, inputValues, secretSource{s.w.Manager, addrmgrNs},
+			)
+		},
+	); E.Chk(e) {
+		return
+	}
+	if e = validateMsgTx(tx, req.PrevScripts, inputValues); E.Chk(e) {
+		return
+	}
+	var buf bytes.Buffer
+	if e = tx.Serialize(&buf); E.Chk(e) {
+		return
+	}
+	reply.Tx = buf.Bytes()
+	I.Ln("signed transaction", tx.TxHash())
+	return
+}
+
+// signerAddress splits a signer address into the network and address to listen on or dial.
+func signerAddress(address string) (network, addr string) {
+	if strings.HasPrefix(address, "unix:") {
+		return "unix", strings.TrimPrefix(address, "unix:")
+	}
+	return "tcp", address
+}
+
+// ListenSigner opens the listener for a signer on the given address. A TCP address must be given a TLS configuration.
+// A unix socket is only accessible to the user running the signer.
+func ListenSigner(address string, tlsConfig *tls.Config) (listener net.Listener, e error) {
+	network, addr := signerAddress(address)
+	switch {
+	case network == "unix":
+		// Remove a socket left behind by a signer that did not shut down cleanly.
+		if e = os.Remove(addr); e != nil && !os.IsNotExist(e) {
+			return
+		}
+		if listener, e = net.Listen(network, addr); E.Chk(e) {
+			return
+		}
+		if e = os.Chmod(addr, 0600); E.Chk(e) {
+			_ = listener.Close()
+			return nil, e
+		}
+	case tlsConfig != nil:
+		listener, e = tls.Listen(network, addr, tlsConfig)
+	default:
+		e = ErrSignerTLSRequired
+	}
+	return
+}
+
+// ServeSigner serves signing requests with the keys of the wallet on the listener until the listener is closed. Each
+// connection must prove it knows the secret before any request is served.
+func ServeSigner(w *Wallet, listener net.Listener, secret []byte) (e error) {
+	if len(secret) == 0 {
+		return errors.New("the signer requires a secret")
+	}
+	server := rpc.NewServer()
+	if e = server.RegisterName("Signer", &Signer{w}); E.Chk(e) {
+		return
+	}
+	I.Ln("serving signing requests on", listener.Addr())
+	for {
+		var conn net.Conn
+		if conn, e = listener.Accept(); e != nil {
+			D.Ln("signer listener closed:", e)
+			return nil
+		}
+		go func() {
+			if e := signerHandshake(conn, secret, true); E.Chk(e) {
+				_ = conn.Close()
+				return
+			}
+			server.ServeConn(conn)
+		}()
+	}
+}
+
+// RemoteSigner sends the transactions of a watching-only wallet to a signer to be signed.
+type RemoteSigner struct {
+	Address   string
+	Secret    []byte
+	TLSConfig *tls.Config
+}
+
+// dial opens an authenticated connection to the signer.
+func (r *RemoteSigner) dial() (client *rpc.Client, e error) {
+	network, addr := signerAddress(r.Address)
+	var conn net.Conn
+	switch {
+	case network == "unix":
+		conn, e = net.DialTimeout(network, addr, signerHandshakeTimeout)
+	case r.TLSConfig != nil:
+		conn, e = tls.DialWithDialer(&net.Dialer{Timeout: signerHandshakeTimeout}, network, addr, r.TLSConfig)
+	default:
+		e = ErrSignerTLSRequired
+	}
+	if E.Chk(e) {
+		return
+	}
+	if e = signerHandshake(conn, r.Secret, false); E.Chk(e) {
+		_ = conn.Close()
+		return
+	}
+	return rpc.NewClient(conn), nil
+}
+
+// SignTx has the signer add the input scripts to the transaction. The signed transaction is only accepted if it spends
+// the same inputs to the same outputs as the one that was sent.
+func (r *RemoteSigner) SignTx(tx *txauthor.AuthoredTx) (e error) {
+	req := &SignRequest{
+		PrevScripts: tx.PrevScripts,
+		InputValues: make([]int64, len(tx.PrevInputValues)),
+	}
+	for i := range tx.PrevInputValues {
+		req.InputValues[i] = int64(tx.PrevInputValues[i])
+	}
+	var buf bytes.Buffer
+	if e = tx.Tx.Serialize(&buf); E.Chk(e) {
+		return
+	}
+	req.Tx = buf.Bytes()
+	var client *rpc.Client
+	if client, e = r.dial(); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := client.Close(); E.Chk(e) {
+		}
+	}()
+	reply := &SignReply{}
+	if e = client.Call("Signer.Sign", req, reply); E.Chk(e) {
+		return
+	}
+	signed := &wire.MsgTx{}
+	if e = signed.Deserialize(bytes.NewReader(reply.Tx)); E.Chk(e) {
+		return
+	}
+	if unsignedHash(signed) != unsignedHash(tx.Tx) {
+		return errors.New("signer returned a different transaction than it was sent")
+	}
+	tx.Tx = signed
+	return
+}
+
+// unsignedHash returns the hash of the transaction with its input scripts removed.
+func unsignedHash(tx *wire.MsgTx) string {
+	stripped := tx.Copy()
+	for _, txIn := range stripped.TxIn {
+		txIn.SignatureScript = nil
+	}
+	return stripped.TxHash().String()
+}
+
+// setupSigner connects the wallet to a remote signer, or serves signing requests with the wallet, as configured.
+func setupSigner(cx *state.State, w *Wallet) (e error) {
+	cfg := cx.Config
+	secret := cfg.SignerSecret.Bytes()
+	if cfg.SignerConnect.V() != "" {
+		if len(secret) == 0 {
+			return errors.New("a signer secret is required to connect to a signer")
+		}
+		if !w.Manager.WatchOnly() {
+			W.Ln("the wallet has private keys but its transactions will be signed by the remote signer")
+		}
+		if network, _ := signerAddress(cfg.SignerConnect.V()); network == "tcp" && !cfg.ClientTLS.True() {
+			return ErrSignerTLSRequired
+		}
+		w.signer = &RemoteSigner{Address: cfg.SignerConnect.V(), Secret: secret}
+		if cfg.ClientTLS.True() {
+			pool := x509.NewCertPool()
+			pool.AppendCertsFromPEM(cfg.ReadCAFile())
+			w.signer.TLSConfig = &tls.Config{
+				RootCAs:            pool,
+				MinVersion:         tls.VersionTLS12,
+				InsecureSkipVerify: cfg.TLSSkipVerify.True(),
+			}
+		}
+		I.Ln("transactions will be signed by the signer at", cfg.SignerConnect.V())
+	}
+	if cfg.SignerListen.V() != "" {
+		if len(secret) == 0 {
+			return errors.New("a signer secret is required to serve signing requests")
+		}
+		if w.Manager.WatchOnly() {
+			return errors.New("a watching-only wallet can't serve signing requests")
+		}
+		var tlsConfig *tls.Config
+		if cfg.ClientTLS.True() {
+			var keyPair tls.Certificate
+			if keyPair, e = OpenRPCKeyPair(cfg); E.Chk(e) {
+				return
+			}
+			tlsConfig = &tls.Config{
+				Certificates: []tls.Certificate{keyPair},
+				MinVersion:   tls.VersionTLS12,
+			}
+		}
+		var listener net.Listener
+		if listener, e = ListenSigner(cfg.SignerListen.V(), tlsConfig); E.Chk(e) {
+			return
+		}
+		interrupt.AddHandler(
+			func() {
+				D.Ln("stopping signer")
+				if e := listener.Close(); E.Chk(e) {
+				}
+			},
+		)
+		go func() {
+			if e := ServeSigner(w, listener, secret); E.Chk(e) {
+			}
+		}()
+	}
+	return
+}
+
+// signerHandshake proves to the other end of a signer connection that this end knows the secret, and checks that the
+// other end does too. Each end sends a random challenge, and answers the challenge of the other with a MAC over both
+// challenges keyed with the secret, labelled by which end it comes from so an answer can't be reflected back.
+func signerHandshake(conn net.Conn, secret []byte, server bool) (e error) {
+	if e = conn.SetDeadline(time.Now().Add(signerHandshakeTimeout)); E.Chk(e) {
+		return
+	}
+	ours := make([]byte, signerNonceSize)
+	if _, e = rand.Read(ours); E.Chk(e) {
+		return
+	}
+	if _, e = conn.Write(ours); E.Chk(e) {
+		return
+	}
+	theirs := make([]byte, signerNonceSize)
+	if _, e = io.ReadFull(conn, theirs); E.Chk(e) {
+		return
+	}
+	mac := func(label string, first, second []byte) []byte {
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte(label))
+		h.Write(first)
+		h.Write(second)
+		return h.Sum(nil)
+	}
+	ourLabel, theirLabel := "client", "server"
+	if server {
+		ourLabel, theirLabel = "server", "client"
+	}
+	if _, e = conn.Write(mac(ourLabel, theirs, ours)); E.Chk(e) {
+		return
+	}
+	answer := make([]byte, sha256.Size)
+	if _, e = io.ReadFull(conn, answer); E.Chk(e) {
+		return
+	}
+	if !hmac.Equal(answer, mac(theirLabel, ours, theirs)) {
+		return ErrSignerAuth
+	}
+	return conn.SetDeadline(time.Time{})
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"net"
+	"testing"
+)
+
+// TestSignerHandshake ensures both ends of a signer connection accept each other only when they share the secret.
+func TestSignerHandshake(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverSecret string
+		clientSecret string
+		ok           bool
+	}{
+		{"same secret", "correct horse", "correct horse", true},
+		{"different secret", "correct horse", "battery staple", false},
+	}
+	for _, test := range tests {
+		listener, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("%s: unable to listen: %v", test.name, e)
+		}
+		serverErr := make(chan error, 1)
+		go func() {
+			conn, e := listener.Accept()
+			if e != nil {
+				serverErr <- e
+				return
+			}
+			defer conn.Close()
+			serverErr <- signerHandshake(conn, []byte(test.serverSecret), true)
+		}()
+		conn, e := net.Dial("tcp", listener.Addr().String())
+		if e != nil {
+			t.Fatalf("%s: unable to dial: %v", test.name, e)
+		}
+		clientErr := signerHandshake(conn, []byte(test.clientSecret), false)
+		sErr := <-serverErr
+		_ = conn.Close()
+		_ = listener.Close()
+		if test.ok && (clientErr != nil || sErr != nil) {
+			t.Errorf("%s: handshake failed: client %v, server %v", test.name, clientErr, sErr)
+		}
+		if !test.ok && (clientErr != ErrSignerAuth || sErr != ErrSignerAuth) {
+			t.Errorf("%s: handshake should have failed: client %v, server %v", test.name, clientErr, sErr)
+		}
+	}
+}
+
+// TestSignerRequiresTLS ensures a signer is neither served nor dialled on a TCP address in the clear.
+func TestSignerRequiresTLS(t *testing.T) {
+	if listener, e := ListenSigner("127.0.0.1:0", nil); e != ErrSignerTLSRequired {
+		if listener != nil {
+			_ = listener.Close()
+		}
+		t.Errorf("listening on TCP without TLS: got %v, want %v", e, ErrSignerTLSRequired)
+	}
+	r := &RemoteSigner{Address: "127.0.0.1:1", Secret: []byte("correct horse")}
+	if _, e := r.dial(); e != ErrSignerTLSRequired {
+		t.Errorf("dialling TCP without TLS: got %v, want %v", e, ErrSignerTLSRequired)
+	}
+}
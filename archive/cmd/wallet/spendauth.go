@@ -0,0 +1,360 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	js "encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/util/zero"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// The methods a wallet can require a code with to send more than its spend limit.
+const (
+	SpendAuthNone = "none"
+	SpendAuthPIN  = "pin"
+	SpendAuthTOTP = "totp"
+)
+
+const (
+	// minSpendAuthPIN is the fewest characters a PIN may have.
+	minSpendAuthPIN = 4
+	// totpPeriod is how long each time-based code is valid for.
+	totpPeriod = 30 * time.Second
+	// totpDigits is the number of digits of a time-based code.
+	totpDigits = 6
+	// totpSkew is the number of periods before and after the current one whose codes are accepted, to allow for the
+	// clock of the authenticator being a little off.
+	totpSkew = 1
+	// totpSecretLen is the number of bytes of a generated time-based code secret, and minTOTPSecretLen the fewest a
+	// given secret may have.
+	totpSecretLen    = 20
+	minTOTPSecretLen = 16
+	// totpIssuer is the name authenticator apps show the wallet under.
+	totpIssuer = "Parallelcoin"
+)
+
+var (
+	// spendAuthKey is the key the spend authorization is stored under in its namespace.
+	spendAuthKey = []byte("settings")
+	// totpEncoding is the encoding of time-based code secrets used by authenticator apps.
+	totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// SpendAuth is the code a wallet requires to send more than its spend limit in a transaction.
+type SpendAuth struct {
+	// Method is SpendAuthNone, SpendAuthPIN or SpendAuthTOTP.
+	Method string
+	// Limit is the most that may be paid by a transaction without the code.
+	Limit amt.Amount
+}
+
+// spendAuthRecord is the encoding of the spend authorization in the database. The PIN is only stored as the
+// parameters of the key derived from it, which are enough to check a PIN but not to recover it. The secret of
+// time-based codes must be read back to compute the codes, so it can not be hashed, and is instead encrypted with the
+// private key of the wallet, which is unlocked whenever the wallet can spend.
+type spendAuthRecord struct {
+	Method string `json:"method"`
+	Limit  int64  `json:"limit"`
+	PIN    []byte `json:"pin,omitempty"`
+	TOTP   []byte `json:"totp,omitempty"`
+}
+
+// spendAuthGuard throttles attempts to authorize a spend with the same backoff as unlock attempts after repeated wrong
+// codes, and keeps the period of the last time-based code accepted so it can not be used again. The zero value is
+// ready to use.
+type spendAuthGuard struct {
+	sync.Mutex
+	failures   int
+	retryAfter time.Time
+	lastStep   uint64
+}
+
+// throttled returns how long attempts at the time now must wait before they are tried, or zero if they may be tried
+// now.
+func (g *spendAuthGuard) throttled(now time.Time) time.Duration {
+	g.Lock()
+	defer g.Unlock()
+	if now.Before(g.retryAfter) {
+		return g.retryAfter.Sub(now)
+	}
+	return 0
+}
+
+// record updates the backoff for the next attempts with the outcome of an attempt.
+func (g *spendAuthGuard) record(now time.Time, success bool) {
+	g.Lock()
+	defer g.Unlock()
+	if success {
+		g.failures = 0
+		g.retryAfter = time.Time{}
+		return
+	}
+	g.failures++
+	if backoff := unlockBackoff(g.failures); backoff > 0 {
+		g.retryAfter = now.Add(backoff)
+	}
+}
+
+// use marks the time-based code of the period step as used, returning false if a code of the same or a later period
+// has been used already.
+func (g *spendAuthGuard) use(step uint64) bool {
+	g.Lock()
+	defer g.Unlock()
+	if step <= g.lastStep {
+		return false
+	}
+	g.lastStep = step
+	return true
+}
+
+// resetSteps forgets the period of the last time-based code used, for when a new secret is set.
+func (g *spendAuthGuard) resetSteps() {
+	g.Lock()
+	g.lastStep = 0
+	g.Unlock()
+}
+
+// errSpendAuthThrottled returns the error for a code refused because it must wait before it is tried.
+func errSpendAuthThrottled(wait time.Duration) *btcjson.RPCError {
+	return &btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletSpendAuthIncorrect,
+		Message: fmt.Sprintf("too many incorrect codes, try again in %v", wait.Round(time.Second)),
+	}
+}
+
+// totpCode returns the time-based code of the secret for the period step, as computed by RFC 6238 with HMAC-SHA1.
+func totpCode(secret []byte, step uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], step)
+	mac := hmac.New(sha1.New, secret)
+	_, _ = mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, code%1000000)
+}
+
+// matchTOTP returns the period of the code if it is the time-based code of the secret for a period within totpSkew of
+// the time now.
+func matchTOTP(secret []byte, code string, now time.Time) (step uint64, ok bool) {
+	current := uint64(now.Unix()) / uint64(totpPeriod/time.Second)
+	for s := current - totpSkew; s <= current+totpSkew; s++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, s)), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// decodeTOTPSecret decodes a time-based code secret as it is shown by authenticator apps, ignoring case, spaces and
+// padding.
+func decodeTOTPSecret(secret string) (key []byte, e error) {
+	secret = strings.TrimRight(strings.ToUpper(strings.Replace(secret, " ", "", -1)), "=")
+	if key, e = totpEncoding.DecodeString(secret); e != nil {
+		return nil, fmt.Errorf("the time-based code secret is not base32 encoded: %v", e)
+	}
+	if len(key) < minTOTPSecretLen {
+		return nil, fmt.Errorf("the time-based code secret must be at least %d bytes", minTOTPSecretLen)
+	}
+	return
+}
+
+// TOTPURI returns the otpauth URI of a time-based code secret, which authenticator apps read from a QR code.
+func TOTPURI(secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", totpIssuer)
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(int(totpPeriod/time.Second)))
+	return "otpauth://totp/" + url.PathEscape(totpIssuer+":wallet") + "?" + v.Encode()
+}
+
+// loadSpendAuth reads the spend authorization of the wallet from the database.
+func loadSpendAuth(db walletdb.DB) (rec spendAuthRecord, e error) {
+	rec.Method = SpendAuthNone
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(spendAuthNamespaceKey)
+			if ns == nil {
+				return nil
+			}
+			v := ns.Get(spendAuthKey)
+			if v == nil {
+				return nil
+			}
+			return js.Unmarshal(v, &rec)
+		},
+	)
+	return
+}
+
+// SpendAuth returns the code the wallet requires to send more than its spend limit.
+func (w *Wallet) SpendAuth() (sa SpendAuth, e error) {
+	var rec spendAuthRecord
+	if rec, e = loadSpendAuth(w.db); E.Chk(e) {
+		return
+	}
+	return SpendAuth{Method: rec.Method, Limit: amt.Amount(rec.Limit)}, nil
+}
+
+// SetSpendAuth sets the code the wallet requires to send more than limit in a transaction. The secret is the PIN, or
+// for time-based codes the base32 encoded secret shared with the authenticator, which is generated if it is empty and
+// returned. An empty secret keeps the current one when the method is not changed, so the limit can be changed alone.
+// Changing a spend authorization that is already set requires its current code, and the wallet must be unlocked.
+func (w *Wallet) SetSpendAuth(method string, limit amt.Amount, secret, code string) (totpSecret string, e error) {
+	if limit < 0 {
+		return "", ErrNeedPositiveAmount
+	}
+	if w.Manager.IsLocked() {
+		return "", &ErrWalletUnlockNeeded
+	}
+	var cur spendAuthRecord
+	if cur, e = loadSpendAuth(w.db); E.Chk(e) {
+		return
+	}
+	if cur.Method != SpendAuthNone {
+		if e = w.checkSpendAuthCode(&cur, code); e != nil {
+			return
+		}
+	}
+	rec := spendAuthRecord{Method: method, Limit: int64(limit)}
+	switch {
+	case method == SpendAuthNone:
+	case secret == "" && method == cur.Method:
+		rec.PIN, rec.TOTP = cur.PIN, cur.TOTP
+	case method == SpendAuthPIN:
+		if len(secret) < minSpendAuthPIN {
+			return "", InvalidParameterError{fmt.Errorf("the PIN must have at least %d characters", minSpendAuthPIN)}
+		}
+		pin := []byte(secret)
+		var sk *snacl.SecretKey
+		sk, e = snacl.NewSecretKey(&pin, snacl.DefaultN, snacl.DefaultR, snacl.DefaultP)
+		zero.Bytes(pin)
+		if E.Chk(e) {
+			return
+		}
+		rec.PIN = sk.Marshal()
+		sk.Zero()
+	case method == SpendAuthTOTP:
+		var key []byte
+		if secret == "" {
+			key = make([]byte, totpSecretLen)
+			if _, e = rand.Read(key); E.Chk(e) {
+				return
+			}
+		} else if key, e = decodeTOTPSecret(secret); e != nil {
+			return "", InvalidParameterError{e}
+		}
+		rec.TOTP, e = w.Manager.Encrypt(waddrmgr.CKTPrivate, key)
+		totpSecret = totpEncoding.EncodeToString(key)
+		zero.Bytes(key)
+		if E.Chk(e) {
+			return "", e
+		}
+	default:
+		return "", InvalidParameterError{
+			fmt.Errorf("spend authorization method %q is not one of none, pin or totp", method),
+		}
+	}
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(spendAuthNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(spendAuthNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			if method == SpendAuthNone {
+				return ns.Delete(spendAuthKey)
+			}
+			var body []byte
+			if body, e = js.Marshal(rec); E.Chk(e) {
+				return
+			}
+			return ns.Put(spendAuthKey, body)
+		},
+	); E.Chk(e) {
+		return "", e
+	}
+	if totpSecret != "" {
+		w.spendAuthGuard.resetSteps()
+	}
+	I.F("spend authorization set to %s for spends above %v", method, limit)
+	return
+}
+
+// AuthorizeSpend checks the code given to send a transaction paying amount, which is only needed if the amount is
+// above the spend limit of the wallet.
+func (w *Wallet) AuthorizeSpend(amount amt.Amount, code string) (e error) {
+	var rec spendAuthRecord
+	if rec, e = loadSpendAuth(w.db); E.Chk(e) {
+		return
+	}
+	if rec.Method == SpendAuthNone || amount <= amt.Amount(rec.Limit) {
+		return nil
+	}
+	return w.checkSpendAuthCode(&rec, code)
+}
+
+// checkSpendAuthCode checks a code against the spend authorization, throttling attempts after repeated wrong codes.
+// Checking a time-based code needs the wallet to be unlocked to decrypt its secret.
+func (w *Wallet) checkSpendAuthCode(rec *spendAuthRecord, code string) (e error) {
+	if code == "" {
+		return &ErrSpendAuthRequired
+	}
+	g := &w.spendAuthGuard
+	now := time.Now()
+	if wait := g.throttled(now); wait > 0 {
+		return errSpendAuthThrottled(wait)
+	}
+	var ok bool
+	switch rec.Method {
+	case SpendAuthPIN:
+		sk := &snacl.SecretKey{}
+		if e = sk.Unmarshal(rec.PIN); E.Chk(e) {
+			return
+		}
+		pin := []byte(code)
+		e = sk.DeriveKey(&pin)
+		zero.Bytes(pin)
+		sk.Zero()
+		switch e {
+		case nil:
+			ok = true
+		case snacl.ErrInvalidPassword:
+			e = nil
+		default:
+			return
+		}
+	case SpendAuthTOTP:
+		var key []byte
+		if key, e = w.Manager.Decrypt(waddrmgr.CKTPrivate, rec.TOTP); E.Chk(e) {
+			return
+		}
+		var step uint64
+		if step, ok = matchTOTP(key, code, now); ok {
+			ok = g.use(step)
+		}
+		zero.Bytes(key)
+	}
+	g.record(now, ok)
+	if !ok {
+		W.Ln("spend authorization refused, incorrect or reused code")
+		return &ErrSpendAuthIncorrect
+	}
+	return nil
+}
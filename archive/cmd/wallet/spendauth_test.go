@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	js "encoding/json"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestTOTP ensures time-based codes match the test vectors of RFC 6238 cut to six digits, that codes of the periods
+// next to the current one are accepted, and that secrets are decoded as authenticator apps show them.
+func TestTOTP(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		time int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, test := range tests {
+		if code := totpCode(secret, uint64(test.time)/30); code != test.code {
+			t.Errorf("time %d: got code %s, want %s", test.time, code, test.code)
+		}
+	}
+	if step, ok := matchTOTP(secret, "287082", time.Unix(59+30, 0)); !ok || step != 1 {
+		t.Errorf("the code of the previous period was not accepted, got period %d, %v", step, ok)
+	}
+	if _, ok := matchTOTP(secret, "287082", time.Unix(59+90, 0)); ok {
+		t.Error("a code three periods old was accepted")
+	}
+	encoded := totpEncoding.EncodeToString(secret)
+	key, e := decodeTOTPSecret(encoded[:8] + " " + encoded[8:] + "====")
+	if e != nil || string(key) != string(secret) {
+		t.Errorf("got secret %q, %v decoding %s", key, e, encoded)
+	}
+	if _, e = decodeTOTPSecret(totpEncoding.EncodeToString(secret[:minTOTPSecretLen-1])); e == nil {
+		t.Error("a secret that is too short was accepted")
+	}
+	var g spendAuthGuard
+	if !g.use(5) || g.use(5) || g.use(4) || !g.use(6) {
+		t.Error("a time-based code was accepted twice")
+	}
+}
+
+// TestSpendAuthPIN ensures spends up to the limit need no code, spends above it need the PIN, which is checked against
+// the parameters stored in place of it, and that wrong PINs are throttled.
+func TestSpendAuthPIN(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	db := w.db
+	limit := amt.Amount(1e8)
+	e := w.AuthorizeSpend(limit*10, "")
+	if e != nil {
+		t.Fatalf("a wallet without a spend limit refused a spend: %v", e)
+	}
+	pin := []byte("2468")
+	sk, e := snacl.NewSecretKey(&pin, snacl.DefaultN, snacl.DefaultR, snacl.DefaultP)
+	if e != nil {
+		t.Fatal(e)
+	}
+	body, e := js.Marshal(spendAuthRecord{Method: SpendAuthPIN, Limit: int64(limit), PIN: sk.Marshal()})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			var ns walletdb.ReadWriteBucket
+			if ns, e = tx.CreateTopLevelBucket(spendAuthNamespaceKey); e != nil {
+				return
+			}
+			return ns.Put(spendAuthKey, body)
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	if sa, e := w.SpendAuth(); e != nil || sa.Method != SpendAuthPIN || sa.Limit != limit {
+		t.Fatalf("got spend authorization %+v, %v", sa, e)
+	}
+	if e = w.AuthorizeSpend(limit, ""); e != nil {
+		t.Errorf("a spend of the limit was refused: %v", e)
+	}
+	if e = w.AuthorizeSpend(limit+1, ""); e != &ErrSpendAuthRequired {
+		t.Errorf("got error %v for a spend above the limit without the PIN, want %v", e, &ErrSpendAuthRequired)
+	}
+	if e = w.AuthorizeSpend(limit+1, "1357"); e != &ErrSpendAuthIncorrect {
+		t.Errorf("got error %v for a wrong PIN, want %v", e, &ErrSpendAuthIncorrect)
+	}
+	if e = w.AuthorizeSpend(limit+1, "2468"); e != nil {
+		t.Errorf("the PIN was refused: %v", e)
+	}
+	for i := 0; i <= unlockFreeAttempts; i++ {
+		_ = w.AuthorizeSpend(limit+1, "1357")
+	}
+	e = w.AuthorizeSpend(limit+1, "2468")
+	re, ok := e.(*btcjson.RPCError)
+	if !ok || re.Code != btcjson.ErrRPCWalletSpendAuthIncorrect || re == &ErrSpendAuthIncorrect {
+		t.Errorf("got error %v after repeated wrong PINs, want the spend to be throttled", e)
+	}
+}
+
+// TestSignedSpend ensures the amount a transaction signed with the wallet keys spends counts the inputs paying wallet
+// addresses less the outputs paying back to them, and that a wallet input of unknown value needs a code for any limit.
+func TestSignedSpend(t *testing.T) {
+	w, teardown := newTestDBWallet(t)
+	defer teardown()
+	params := &chaincfg.RegressionTestParams
+	w.chainParams = params
+	e := walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns, e := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+			if e != nil {
+				return e
+			}
+			seed := make([]byte, 32)
+			if e = waddrmgr.Create(
+				ns, seed, []byte{}, []byte("priv"), params, &waddrmgr.ScryptOptions{N: 16, R: 8, P: 1}, time.Now(),
+			); e != nil {
+				return e
+			}
+			if w.Manager, e = waddrmgr.Open(ns, []byte{}, params); e != nil {
+				return e
+			}
+			if ns, e = tx.CreateTopLevelBucket(wtxmgrNamespaceKey); e != nil {
+				return e
+			}
+			if e = wtxmgr.Create(ns); e != nil {
+				return e
+			}
+			w.TxStore, e = wtxmgr.Open(ns, params)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer w.Manager.Close()
+	pkScript := func(addr btcaddr.Address) []byte {
+		script, e := txscript.PayToAddrScript(addr)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return script
+	}
+	ours, e := w.NewAddress(waddrmgr.DefaultAccountNum, waddrmgr.KeyScopeBIP0044, true)
+	if e != nil {
+		t.Fatal(e)
+	}
+	theirs, e := btcaddr.NewPubKeyHash(make([]byte, 20), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	prevScripts := make(map[wire.OutPoint][]byte)
+	prevValues := make(map[wire.OutPoint]amt.Amount)
+	for i, in := range []struct {
+		addr  btcaddr.Address
+		value amt.Amount
+	}{{ours, 5e8}, {theirs, 7e8}, {ours, 3e8}} {
+		prev := wire.OutPoint{Index: uint32(i)}
+		tx.AddTxIn(wire.NewTxIn(&prev, nil, nil))
+		prevScripts[prev] = pkScript(in.addr)
+		prevValues[prev] = in.value
+	}
+	tx.AddTxOut(wire.NewTxOut(6e8, pkScript(theirs)))
+	tx.AddTxOut(wire.NewTxOut(2e8, pkScript(ours)))
+	spend, e := w.SignedSpend(tx, prevScripts, prevValues)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if spend != 6e8 {
+		t.Errorf("got spend %v, want %v", spend, amt.Amount(6e8))
+	}
+	delete(prevValues, wire.OutPoint{Index: 1})
+	if spend, e = w.SignedSpend(tx, prevScripts, prevValues); e != nil || spend != 6e8 {
+		t.Errorf("got spend %v, %v with an input of another wallet of unknown value, want %v", spend, e, 6e8)
+	}
+	delete(prevValues, wire.OutPoint{Index: 2})
+	if spend, e = w.SignedSpend(tx, prevScripts, prevValues); e != nil || spend != amt.MaxSatoshi {
+		t.Errorf("got spend %v, %v with a wallet input of unknown value, want %v", spend, e, amt.Amount(amt.MaxSatoshi))
+	}
+}
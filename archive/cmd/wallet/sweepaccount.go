@@ -0,0 +1,187 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// AccountSweep is a signed transaction moving the spendable balance of an account, less a reserve left in the account,
+// to an address.
+type AccountSweep struct {
+	Destination btcaddr.Address
+	// Amount is the amount sent to Destination, which is the spendable balance less Reserve and Fee.
+	Amount  amt.Amount
+	Reserve amt.Amount
+	Fee     amt.Amount
+	Tx      *txauthor.AuthoredTx
+}
+
+// PrepareAccountSweep returns a transaction spending all the outputs of the account with at least minconf
+// confirmations to destination, less the fee, and sending reserve back to a change address of the account when it is
+// not zero. The transaction is not broadcast, which is done with PublishAccountSweep, and its change address must be
+// given back with DiscardAccountSweep if it won't be.
+func (w *Wallet) PrepareAccountSweep(
+	account uint32, destination btcaddr.Address, minconf int32, reserve amt.Amount,
+) (s *AccountSweep, e error) {
+	if reserve < 0 {
+		return nil, txrules.ErrAmountNegative
+	}
+	if e = checkPayable(destination, w.chainParams); E.Chk(e) {
+		return
+	}
+	var pkScript []byte
+	if pkScript, e = txscript.PayToAddrScript(destination); E.Chk(e) {
+		return
+	}
+	req := createTxRequest{
+		account:     account,
+		outputs:     []*wire.TxOut{wire.NewTxOut(0, pkScript)},
+		minconf:     minconf,
+		feeSatPerKB: txrules.DefaultRelayFeePerKb,
+		sweep:       true,
+		reserve:     reserve,
+		resp:        make(chan createTxResponse),
+	}
+	w.createTxRequests <- req
+	resp := <-req.resp
+	if resp.e != nil {
+		return nil, resp.e
+	}
+	s = &AccountSweep{Destination: destination, Reserve: reserve, Tx: resp.tx, Fee: resp.tx.TotalInput}
+	for i, txOut := range resp.tx.Tx.TxOut {
+		if i != resp.tx.ChangeIndex {
+			s.Amount = amt.Amount(txOut.Value)
+		}
+		s.Fee -= amt.Amount(txOut.Value)
+	}
+	return
+}
+
+// PublishAccountSweep broadcasts the transaction of an account sweep and records it in the wallet, returning its hash.
+func (w *Wallet) PublishAccountSweep(s *AccountSweep) (txHash *chainhash.Hash, e error) {
+	if txHash, e = w.publishTransaction(s.Tx.Tx); E.Chk(e) {
+		w.releaseChangeAddress(s.Tx)
+	}
+	return
+}
+
+// DiscardAccountSweep gives the change address of an account sweep that will not be broadcast back to the wallet.
+func (w *Wallet) DiscardAccountSweep(s *AccountSweep) {
+	w.releaseChangeAddress(s.Tx)
+}
+
+// txSweepAccount creates a signed transaction spending all the outputs of the account that are eligible under the
+// minconf policy to pkScript, less the fee, with reserve returned to the account as change when it is not zero. Like
+// txToOutputs, it must only be called by the txCreator so the outputs it spends are not spent by another transaction.
+func (w *Wallet) txSweepAccount(
+	pkScript []byte, account uint32,
+	minconf int32, reserve, feeSatPerKb amt.Amount,
+) (tx *txauthor.AuthoredTx, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	var bs *waddrmgr.BlockStamp
+	if bs, e = chainClient.BlockStamp(); E.Chk(e) {
+		return
+	}
+	var eligible []wtxmgr.Credit
+	if e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			eligible, e = w.findEligibleOutputs(dbtx, account, minconf, bs)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	if len(eligible) == 0 {
+		return nil, btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInsufficientFunds,
+			Message: "account has no spendable outputs",
+		}
+	}
+	tx = &txauthor.AuthoredTx{Tx: wire.NewMsgTx(wire.TxVersion), ChangeIndex: -1}
+	for i := range eligible {
+		tx.Tx.AddTxIn(wire.NewTxIn(&eligible[i].OutPoint, nil, nil))
+		tx.PrevScripts = append(tx.PrevScripts, eligible[i].PkScript)
+		tx.PrevInputValues = append(tx.PrevInputValues, eligible[i].Amount)
+		tx.TotalInput += eligible[i].Amount
+	}
+	out := wire.NewTxOut(0, pkScript)
+	tx.Tx.AddTxOut(out)
+	size := txsizes.EstimateSerializeSize(len(tx.Tx.TxIn), tx.Tx.TxOut, reserve > 0)
+	fee := txrules.FeeForSerializeSize(feeSatPerKb, size)
+	if tx.TotalInput <= reserve+fee ||
+		txrules.IsDustAmount(tx.TotalInput-reserve-fee, len(pkScript), feeSatPerKb) {
+		return nil, btcjson.RPCError{
+			Code: btcjson.ErrRPCWalletInsufficientFunds,
+			Message: fmt.Sprintf(
+				"spendable balance of %v is too little to leave a reserve of %v and pay the fee of %v",
+				tx.TotalInput, reserve, fee,
+			),
+		}
+	}
+	out.Value = int64(tx.TotalInput - reserve - fee)
+	if reserve > 0 {
+		if txrules.IsDustAmount(reserve, txsizes.P2PKHPkScriptSize, feeSatPerKb) {
+			return nil, fmt.Errorf("reserve of %v is too small to be an output", reserve)
+		}
+		// As in txToOutputs, change from the imported account goes to the default account.
+		changeAccount := account
+		if account == waddrmgr.ImportedAddrAccount {
+			changeAccount = 0
+		}
+		var changeAddr btcaddr.Address
+		if e = walletdb.Update(
+			w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+				changeAddr, e = w.leaseChangeAddress(dbtx.ReadWriteBucket(waddrmgrNamespaceKey), changeAccount)
+				return
+			},
+		); E.Chk(e) {
+			return nil, e
+		}
+		var changeScript []byte
+		if changeScript, e = txscript.PayToAddrScript(changeAddr); E.Chk(e) {
+			return nil, e
+		}
+		tx.Tx.AddTxOut(wire.NewTxOut(int64(reserve), changeScript))
+		tx.ChangeIndex = 1
+	}
+	tx.Order(w.txOrdering())
+	if e = w.checkMaxTxFee(tx); E.Chk(e) {
+		w.releaseChangeAddress(tx)
+		return nil, e
+	}
+	if w.signer != nil {
+		e = w.signer.SignTx(tx)
+	} else {
+		e = walletdb.View(
+			w.db, func(dbtx walletdb.ReadTx) (e error) {
+				addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+				return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+			},
+		)
+	}
+	if E.Chk(e) {
+		w.releaseChangeAddress(tx)
+		return nil, e
+	}
+	if e = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues); E.Chk(e) {
+		w.releaseChangeAddress(tx)
+		return nil, e
+	}
+	return
+}
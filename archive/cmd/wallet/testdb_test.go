@@ -0,0 +1,31 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/walletdb"
+	_ "github.com/p9c/pod/pkg/walletdb/bdb"
+)
+
+// newTestDBWallet returns a wallet with nothing but a new database in a temporary directory, for testing the parts of
+// the wallet that keep their state in the database, and a function closing and removing the database.
+func newTestDBWallet(t *testing.T) (w *Wallet, teardown func()) {
+	t.Helper()
+	dir, e := ioutil.TempDir("", "wallet")
+	if e != nil {
+		t.Fatal(e)
+	}
+	var db walletdb.DB
+	if db, e = walletdb.Create("bdb", filepath.Join(dir, "wallet.db")); e != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatal(e)
+	}
+	teardown = func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	}
+	return &Wallet{db: db}, teardown
+}
@@ -0,0 +1,403 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// medianTimeBlocks is the number of blocks whose timestamps the median time past of a block is the median of.
+const medianTimeBlocks = 11
+
+// relativeLocksSpendable is whether the chain server relays the spends of outputs locked by OP_CHECKSEQUENCEVERIFY.
+// Until it does, outputs under relative locks are reported, but are not spent.
+const relativeLocksSpendable = txscript.StandardVerifyFlags&txscript.ScriptVerifyCheckSequenceVerify != 0
+
+// ImportTimeLockScript adds a time locked redeem script paying to a key of the wallet, so the outputs paid to its P2SH
+// address are tracked, reported with the height or time they unlock at, and spent by SweepTimeLocked once they unlock.
+// When rescan is set the chain is searched for the outputs already paid to it. The wallet must be unlocked.
+func (w *Wallet) ImportTimeLockScript(script []byte, rescan bool) (
+	addr *btcaddr.ScriptHash, tl *txscript.TimeLock, e error,
+) {
+	if tl = txscript.ExtractTimeLock(script); tl == nil {
+		return nil, nil, errors.New("the script is not a time lock paying to a single key")
+	}
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	var pkh *btcaddr.PubKeyHash
+	if pkh, e = btcaddr.NewPubKeyHash(tl.PubKeyHash, w.chainParams); E.Chk(e) {
+		return
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			var ma waddrmgr.ManagedAddress
+			if ma, e = w.Manager.Address(tx.ReadBucket(waddrmgrNamespaceKey), pkh); e != nil {
+				return fmt.Errorf("the key the script pays to, of address %s, is not in the wallet", pkh.EncodeAddress())
+			}
+			if _, ok := ma.(waddrmgr.ManagedPubKeyAddress); !ok {
+				return fmt.Errorf("address %s the script pays to is not a key of the wallet", pkh.EncodeAddress())
+			}
+			return
+		},
+	)
+	if e != nil {
+		return
+	}
+	if addr, e = w.ImportP2SHRedeemScript(script); E.Chk(e) {
+		return
+	}
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(timeLockNamespaceKey)
+			if ns == nil {
+				if ns, e = tx.CreateTopLevelBucket(timeLockNamespaceKey); E.Chk(e) {
+					return
+				}
+			}
+			return ns.Put(addr.ScriptAddress(), script)
+		},
+	)
+	if e != nil {
+		return
+	}
+	if rescan {
+		job := &RescanJob{
+			Addrs:      []btcaddr.Address{addr},
+			BlockStamp: waddrmgr.BlockStamp{Hash: *w.chainParams.GenesisHash},
+		}
+		// The rescan is logged when it finishes, so its result is not waited for.
+		_ = w.SubmitRescan(job)
+	} else if e = chainClient.NotifyReceived([]btcaddr.Address{addr}); E.Chk(e) {
+		return
+	}
+	I.Ln("imported time locked script of address", addr.EncodeAddress())
+	return
+}
+
+// timeLockScripts returns the time locked redeem scripts of the wallet, keyed by their script hash, which are those
+// imported with ImportTimeLockScript and those of the deposit addresses of the vault accounts.
+func (w *Wallet) timeLockScripts(tx walletdb.ReadTx) (scripts map[string][]byte, e error) {
+	scripts = make(map[string][]byte)
+	if ns := tx.ReadBucket(timeLockNamespaceKey); ns != nil {
+		if e = ns.ForEach(
+			func(k, v []byte) error {
+				scripts[string(k)] = append([]byte{}, v...)
+				return nil
+			},
+		); E.Chk(e) {
+			return
+		}
+	}
+	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+	e = w.Manager.ForEachVaultAccount(
+		addrmgrNs, func(va *waddrmgr.VaultAccount) error {
+			return w.Manager.ForEachVaultAddress(
+				addrmgrNs, va.Name, func(addr *waddrmgr.VaultAddress) (e error) {
+					var script []byte
+					if script, e = waddrmgr.VaultScript(addr.PubKey, addr.LockHeight); E.Chk(e) {
+						return
+					}
+					scripts[string(addr.Address.ScriptAddress())] = script
+					return
+				},
+			)
+		},
+	)
+	return
+}
+
+// timeLockScript returns the time locked redeem script of the scripts the output script pays to, or nil if it pays to
+// none of them.
+func timeLockScript(scripts map[string][]byte, pkScript []byte) []byte {
+	if !txscript.IsPayToScriptHash(pkScript) {
+		return nil
+	}
+	// A P2SH script is OP_HASH160 <20 byte hash> OP_EQUAL.
+	return scripts[string(pkScript[2:22])]
+}
+
+// medianTimePast returns the median of the timestamps of the block with the hash and the blocks before it, which
+// absolute time locks are checked against and relative ones are counted with.
+func medianTimePast(chainClient chainclient.Interface, hash chainhash.Hash) (mtp time.Time, e error) {
+	stamps := make([]int64, 0, medianTimeBlocks)
+	for len(stamps) < medianTimeBlocks {
+		var header *wire.BlockHeader
+		if header, e = chainClient.GetBlockHeader(&hash); E.Chk(e) {
+			return
+		}
+		stamps = append(stamps, header.Timestamp.Unix())
+		if header.PrevBlock == (chainhash.Hash{}) {
+			break
+		}
+		hash = header.PrevBlock
+	}
+	sort.Slice(
+		stamps, func(i, j int) bool {
+			return stamps[i] < stamps[j]
+		},
+	)
+	return time.Unix(stamps[len(stamps)/2], 0), nil
+}
+
+// TimeLockedSweep is a signed transaction sending the unlocked outputs of the time locked scripts of the wallet to an
+// address.
+type TimeLockedSweep struct {
+	Destination btcaddr.Address
+	// Amount is the amount sent to Destination, which is the value of the unlocked outputs less Fee.
+	Amount amt.Amount
+	Fee    amt.Amount
+	Tx     *txauthor.AuthoredTx
+}
+
+// PrepareTimeLockedSweep returns a transaction spending the outputs of the time locked scripts of the wallet that have
+// unlocked to destination, less the fee. The outputs of the deposit addresses of vault accounts are spent along with
+// those of imported scripts. The transaction is not broadcast, which is done with PublishTimeLockedSweep.
+func (w *Wallet) PrepareTimeLockedSweep(destination btcaddr.Address) (s *TimeLockedSweep, e error) {
+	if e = checkPayable(destination, w.chainParams); E.Chk(e) {
+		return
+	}
+	var pkScript []byte
+	if pkScript, e = txscript.PayToAddrScript(destination); E.Chk(e) {
+		return
+	}
+	req := createTxRequest{
+		outputs:     []*wire.TxOut{wire.NewTxOut(0, pkScript)},
+		feeSatPerKB: txrules.DefaultRelayFeePerKb,
+		timeLocked:  true,
+		resp:        make(chan createTxResponse),
+	}
+	w.createTxRequests <- req
+	resp := <-req.resp
+	if resp.e != nil {
+		return nil, resp.e
+	}
+	// The sweep pays only the destination.
+	s = &TimeLockedSweep{Destination: destination, Amount: amt.Amount(resp.tx.Tx.TxOut[0].Value), Tx: resp.tx}
+	s.Fee = resp.tx.TotalInput - s.Amount
+	return
+}
+
+// PublishTimeLockedSweep broadcasts the transaction of a time locked sweep and records it in the wallet, returning its
+// hash.
+func (w *Wallet) PublishTimeLockedSweep(s *TimeLockedSweep) (txHash *chainhash.Hash, e error) {
+	return w.publishTransaction(s.Tx.Tx)
+}
+
+// txSweepTimeLocked creates a signed transaction spending the unlocked outputs of the time locked scripts of the wallet
+// to pkScript, less the fee.
+func (w *Wallet) txSweepTimeLocked(pkScript []byte, feeSatPerKb amt.Amount) (tx *txauthor.AuthoredTx, e error) {
+	var scripts map[string][]byte
+	if e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			scripts, e = w.timeLockScripts(dbtx)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	return w.txSpendTimeLocked(pkScript, scripts, "the wallet has no unlocked time locked outputs", feeSatPerKb)
+}
+
+// txSpendTimeLocked creates a signed transaction spending the outputs paid to the time locked redeem scripts, keyed by
+// their script hash, that can be spent in the next block to pkScript, less the fee. none is the message of the error
+// returned when none can be spent. Like txToOutputs, it must only be called by the txCreator so the outputs it spends
+// are not spent by another transaction.
+//
+// A transaction can't spend outputs under both a height and a time lock, as it has one lock time, so when both have
+// unlocked those under time locks are left for another transaction.
+func (w *Wallet) txSpendTimeLocked(pkScript []byte, scripts map[string][]byte, none string, feeSatPerKb amt.Amount) (
+	tx *txauthor.AuthoredTx, e error,
+) {
+	if w.signer != nil {
+		return nil, errors.New("time locked outputs are signed with the keys of the wallet, not a remote signer")
+	}
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	var bs *waddrmgr.BlockStamp
+	if bs, e = chainClient.BlockStamp(); E.Chk(e) {
+		return
+	}
+	type spend struct {
+		output wtxmgr.Credit
+		lock   *txscript.TimeLock
+		script []byte
+	}
+	var candidates []spend
+	e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(dbtx.ReadBucket(wtxmgrNamespaceKey)); E.Chk(e) {
+				return
+			}
+			for i := range unspent {
+				output := unspent[i]
+				script := timeLockScript(scripts, output.PkScript)
+				if script == nil || !confirmed(1, output.Height, bs.Height) ||
+					w.LockedOutpoint(output.OutPoint) || w.FrozenOutpoint(output.OutPoint) {
+					continue
+				}
+				lock := txscript.ExtractTimeLock(script)
+				if lock == nil || lock.Relative && !relativeLocksSpendable {
+					continue
+				}
+				candidates = append(candidates, spend{output, lock, script})
+			}
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	var mtp time.Time
+	for _, c := range candidates {
+		if c.lock.Seconds {
+			if mtp, e = medianTimePast(chainClient, bs.Hash); E.Chk(e) {
+				return nil, e
+			}
+			break
+		}
+	}
+	var spends []spend
+	heightLocked := false
+	for _, c := range candidates {
+		if c.lock.Reached(bs.Height, mtp, c.output.Height, c.output.Time) {
+			spends = append(spends, c)
+			heightLocked = heightLocked || !c.lock.Relative && !c.lock.Seconds
+		}
+	}
+	tx = &txauthor.AuthoredTx{Tx: wire.NewMsgTx(wire.TxVersion), ChangeIndex: -1}
+	out := wire.NewTxOut(0, pkScript)
+	tx.Tx.AddTxOut(out)
+	byScriptHash := make(map[string][]byte)
+	size := 0
+	for _, s := range spends {
+		if !s.lock.Relative && s.lock.Seconds && heightLocked {
+			continue
+		}
+		if s.lock.Relative {
+			// Relative locks are only checked for transactions of version 2 or above.
+			tx.Tx.Version = 2
+		}
+		if s.lock.LockTime() > tx.Tx.LockTime {
+			tx.Tx.LockTime = s.lock.LockTime()
+		}
+		in := wire.NewTxIn(&s.output.OutPoint, nil, nil)
+		in.Sequence = s.lock.Sequence()
+		tx.Tx.AddTxIn(in)
+		tx.PrevScripts = append(tx.PrevScripts, s.output.PkScript)
+		tx.PrevInputValues = append(tx.PrevInputValues, s.output.Amount)
+		tx.TotalInput += s.output.Amount
+		byScriptHash[string(s.output.PkScript[2:22])] = s.script
+		// Each signature script pushes a signature of at most 73 bytes, the key when the script pays to its hash, and
+		// the redeem script.
+		size += 1 + 73 + 1 + len(s.script)
+		if s.lock.PubKey == nil {
+			size += 1 + 33
+		}
+	}
+	if len(tx.Tx.TxIn) == 0 {
+		return nil, btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInsufficientFunds,
+			Message: none,
+		}
+	}
+	fee := txrules.FeeForSerializeSize(feeSatPerKb, tx.Tx.SerializeSize()+size)
+	if tx.TotalInput <= fee || txrules.IsDustAmount(tx.TotalInput-fee, len(pkScript), feeSatPerKb) {
+		return nil, btcjson.RPCError{
+			Code: btcjson.ErrRPCWalletInsufficientFunds,
+			Message: fmt.Sprintf(
+				"unlocked balance of %v is too little to pay the fee of %v", tx.TotalInput, fee,
+			),
+		}
+	}
+	out.Value = int64(tx.TotalInput - fee)
+	tx.Order(w.txOrdering())
+	if e = w.checkMaxTxFee(tx); E.Chk(e) {
+		return nil, e
+	}
+	e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			secrets := secretSource{w.Manager, dbtx.ReadBucket(waddrmgrNamespaceKey)}
+			getScript := txscript.ScriptClosure(
+				func(addr btcaddr.Address) ([]byte, error) {
+					script, ok := byScriptHash[string(addr.ScriptAddress())]
+					if !ok {
+						return nil, fmt.Errorf("no time locked script for address %s", addr.EncodeAddress())
+					}
+					return script, nil
+				},
+			)
+			for i, prevScript := range tx.PrevScripts {
+				if tx.Tx.TxIn[i].SignatureScript, e = txscript.SignTxOutput(
+					w.chainParams, tx.Tx, i, prevScript, txscript.SigHashAll, secrets, getScript, nil,
+				); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	if e = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// timeLockStatus sets the fields of the unspent outputs paid to time locked scripts that tell whether their lock can
+// be passed in the next block, and when it unlocks.
+func (w *Wallet) timeLockStatus(locked []timeLockedResult, syncBlock *waddrmgr.BlockStamp) {
+	var mtp time.Time
+	for _, l := range locked {
+		if l.lock.Seconds {
+			// Without the chain server the median time past is unknown, and outputs under time locks are shown as
+			// locked.
+			if chainClient := w.ChainClient(); chainClient != nil {
+				var e error
+				if mtp, e = medianTimePast(chainClient, syncBlock.Hash); E.Chk(e) {
+					mtp = time.Time{}
+				}
+			}
+			break
+		}
+	}
+	for _, l := range locked {
+		at, ok := l.lock.UnlockAt(l.output.Height, l.output.Time)
+		if ok && l.lock.Seconds {
+			l.result.UnlockTime = at
+		} else if ok {
+			l.result.UnlockHeight = int32(at)
+		}
+		reached := !(l.lock.Seconds && mtp.IsZero()) &&
+			l.lock.Reached(syncBlock.Height, mtp, l.output.Height, l.output.Time)
+		l.result.TimeLocked = !reached
+		l.result.Spendable = reached && (!l.lock.Relative || relativeLocksSpendable)
+	}
+}
+
+// timeLockedResult is an unspent output paid to a time locked script, with its entry in the listunspent result.
+type timeLockedResult struct {
+	result *btcjson.ListUnspentResult
+	output *wtxmgr.Credit
+	lock   *txscript.TimeLock
+}
@@ -0,0 +1,330 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// A transfer moves an amount between two accounts of the wallet. Normally the source account pays the fee as it does
+// for any send, but the fee can instead be drawn from a fee account, so the balance of the source account goes down by
+// exactly the amount and that of the destination goes up by exactly the amount, which is what an exchange keeping the
+// balances of its clients in sub-accounts needs. The transaction then spends outputs of both accounts: the outputs of
+// the source account cover the amount and get their change back, and the outputs of the fee account cover the fee and
+// get their change back, so each account is debited only for its share and the transaction history of every account,
+// as in the ledger export, adds up to the amounts of the transfer.
+
+// AccountTransfer is a signed transaction moving an amount from one account of the wallet to the current address of
+// another.
+type AccountTransfer struct {
+	From        uint32
+	To          uint32
+	Destination btcaddr.Address
+	Amount      amt.Amount
+	// Sponsored is true when the fee is paid by FeeAccount rather than by the source account.
+	Sponsored  bool
+	FeeAccount uint32
+	Fee        amt.Amount
+	// SourceChange and FeeChange are the indexes of the change outputs of the source account and the fee account, which
+	// are -1 when there is none.
+	SourceChange int
+	FeeChange    int
+	Tx           *txauthor.AuthoredTx
+}
+
+// PrepareAccountTransfer returns a transaction moving amount from the account from to the current address of the
+// account to, spending outputs with at least minconf confirmations. The current address is only replaced by a new one
+// once it has been paid, so transfers that are worked out but not made don't use up addresses. The fee is paid by
+// feeAccount when sponsored is true, and by the source account otherwise. The transaction is not broadcast, which is
+// done with PublishAccountTransfer, and its change addresses must be given back with DiscardAccountTransfer if it
+// won't be.
+func (w *Wallet) PrepareAccountTransfer(
+	from, to uint32, amount amt.Amount, sponsored bool, feeAccount uint32, minconf int32,
+) (t *AccountTransfer, e error) {
+	if amount <= 0 {
+		return nil, txrules.ErrAmountNegative
+	}
+	if from == to {
+		return nil, InvalidParameterError{fmt.Errorf("the source and destination accounts are the same")}
+	}
+	if sponsored && (feeAccount == from || feeAccount == to) {
+		return nil, InvalidParameterError{
+			fmt.Errorf("the fee account must be neither the source nor the destination account"),
+		}
+	}
+	var destination btcaddr.Address
+	if destination, e = w.CurrentAddress(to, waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	var pkScript []byte
+	if pkScript, e = txscript.PayToAddrScript(destination); E.Chk(e) {
+		return
+	}
+	out := wire.NewTxOut(int64(amount), pkScript)
+	if e = txrules.CheckOutput(out, txrules.DefaultRelayFeePerKb); E.Chk(e) {
+		return
+	}
+	req := createTxRequest{
+		account:     from,
+		outputs:     []*wire.TxOut{out},
+		minconf:     minconf,
+		feeSatPerKB: txrules.DefaultRelayFeePerKb,
+		transfer:    true,
+		sponsored:   sponsored,
+		feeAccount:  feeAccount,
+		resp:        make(chan createTxResponse),
+	}
+	w.createTxRequests <- req
+	resp := <-req.resp
+	if resp.e != nil {
+		return nil, resp.e
+	}
+	t = &AccountTransfer{
+		From:         from,
+		To:           to,
+		Destination:  destination,
+		Amount:       amount,
+		Sponsored:    sponsored,
+		FeeAccount:   feeAccount,
+		Fee:          resp.tx.TotalInput,
+		SourceChange: resp.tx.ChangeIndex,
+		FeeChange:    -1,
+		Tx:           resp.tx,
+	}
+	// The output that is neither the payment nor the change of the source account is the change of the fee account.
+	for i, txOut := range resp.tx.Tx.TxOut {
+		t.Fee -= amt.Amount(txOut.Value)
+		if sponsored && i != resp.tx.ChangeIndex && txOut != out {
+			t.FeeChange = i
+		}
+	}
+	return
+}
+
+// PublishAccountTransfer broadcasts the transaction of an account transfer and records it in the wallet, returning its
+// hash.
+func (w *Wallet) PublishAccountTransfer(t *AccountTransfer) (txHash *chainhash.Hash, e error) {
+	if txHash, e = w.publishTransaction(t.Tx.Tx); E.Chk(e) {
+		w.DiscardAccountTransfer(t)
+	}
+	return
+}
+
+// DiscardAccountTransfer gives the change addresses of an account transfer that will not be broadcast back to the
+// wallet.
+func (w *Wallet) DiscardAccountTransfer(t *AccountTransfer) {
+	for _, i := range []int{t.SourceChange, t.FeeChange} {
+		w.releaseChangeAddress(&txauthor.AuthoredTx{Tx: t.Tx.Tx, ChangeIndex: i})
+	}
+}
+
+// txTransfer creates a signed transaction paying the only output from the outputs of the account that are eligible
+// under the minconf policy, with the fee paid from the eligible outputs of feeAccount when sponsored is true. The
+// change output of the source account is the change output of the transaction. Like txToOutputs, it must only be
+// called by the txCreator so the outputs it spends are not spent by another transaction.
+func (w *Wallet) txTransfer(
+	out *wire.TxOut, account uint32, sponsored bool, feeAccount uint32,
+	minconf int32, feeSatPerKb amt.Amount,
+) (tx *txauthor.AuthoredTx, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	var bs *waddrmgr.BlockStamp
+	if bs, e = chainClient.BlockStamp(); E.Chk(e) {
+		return
+	}
+	var eligible, feeEligible []wtxmgr.Credit
+	if e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			if eligible, e = w.findEligibleOutputs(dbtx, account, minconf, bs); E.Chk(e) || !sponsored {
+				return
+			}
+			feeEligible, e = w.findEligibleOutputs(dbtx, feeAccount, minconf, bs)
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	// As in txToOutputs, change from the imported account goes to the default account. The change addresses are only
+	// leased once the inputs are chosen, and a lease that is not given back when leasing the second one fails expires.
+	changeSource := func(account uint32) txauthor.ChangeSource {
+		if account == waddrmgr.ImportedAddrAccount {
+			account = 0
+		}
+		return func() (script []byte, e error) {
+			var addr btcaddr.Address
+			if e = walletdb.Update(
+				w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+					addr, e = w.leaseChangeAddress(dbtx.ReadWriteBucket(waddrmgrNamespaceKey), account)
+					return
+				},
+			); E.Chk(e) {
+				return
+			}
+			return txscript.PayToAddrScript(addr)
+		}
+	}
+	var feeChange int
+	if sponsored {
+		tx, feeChange, e = authorSponsoredTx(
+			out, feeSatPerKb,
+			makeInputSource(eligible), changeSource(account),
+			makeInputSource(feeEligible), changeSource(feeAccount),
+		)
+	} else {
+		tx, e = txauthor.NewUnsignedTransaction(
+			[]*wire.TxOut{out}, feeSatPerKb, makeInputSource(eligible), changeSource(account),
+		)
+		feeChange = -1
+	}
+	release := func() {
+		w.releaseChangeAddress(tx)
+		if feeChange >= 0 {
+			w.releaseChangeAddress(&txauthor.AuthoredTx{Tx: tx.Tx, ChangeIndex: feeChange})
+		}
+	}
+	if E.Chk(e) {
+		return nil, e
+	}
+	tx.Order(w.txOrdering(), &feeChange)
+	if e = w.checkMaxTxFee(tx); E.Chk(e) {
+		release()
+		return nil, e
+	}
+	if w.signer != nil {
+		e = w.signer.SignTx(tx)
+	} else {
+		e = walletdb.View(
+			w.db, func(dbtx walletdb.ReadTx) (e error) {
+				addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+				return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+			},
+		)
+	}
+	if E.Chk(e) {
+		release()
+		return nil, e
+	}
+	if e = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues); E.Chk(e) {
+		release()
+		return nil, e
+	}
+	return
+}
+
+// authorSponsoredTx creates an unsigned transaction paying out, with the amount drawn from the source inputs and the
+// fee drawn from the fee inputs, and each of them getting their own change. The change of the source account is the
+// change output of the transaction, and the index of the change output of the fee account is returned with it, -1 when
+// there is none. The change of the source can't be left to the fee as that would charge the source account more than
+// the amount, so more outputs of the source are spent when it would be dust, while dust change of the fee account goes
+// to the fee.
+func authorSponsoredTx(
+	out *wire.TxOut, feeSatPerKb amt.Amount,
+	source txauthor.InputSource, sourceChange txauthor.ChangeSource,
+	sponsor txauthor.InputSource, sponsorChange txauthor.ChangeSource,
+) (tx *txauthor.AuthoredTx, feeChange int, e error) {
+	isDust := func(a amt.Amount) bool {
+		return txrules.IsDustAmount(a, txsizes.P2PKHPkScriptSize, feeSatPerKb)
+	}
+	amount := amt.Amount(out.Value)
+	var (
+		total       amt.Amount
+		inputs      []*wire.TxIn
+		inputValues []amt.Amount
+		scripts     [][]byte
+	)
+	for target := amount; ; {
+		if total, inputs, inputValues, scripts, e = source(target); E.Chk(e) {
+			return nil, -1, e
+		}
+		if total < target {
+			return nil, -1, btcjson.RPCError{
+				Code:    btcjson.ErrRPCWalletInsufficientFunds,
+				Message: fmt.Sprintf("the source account has too little to transfer %v", amount),
+			}
+		}
+		if change := total - amount; change == 0 || !isDust(change) {
+			break
+		}
+		if target > amount {
+			return nil, -1, btcjson.RPCError{
+				Code: btcjson.ErrRPCWalletInsufficientFunds,
+				Message: fmt.Sprintf(
+					"the change of %v back to the source account would be dust", total-amount,
+				),
+			}
+		}
+		target = amount + txrules.GetDustThreshold(txsizes.P2PKHPkScriptSize, feeSatPerKb)
+	}
+	changes := 1
+	if total > amount {
+		changes++
+	}
+	feeForInputs := func(n int) amt.Amount {
+		size := txsizes.EstimateSerializeSize(n, []*wire.TxOut{out}, false) + changes*txsizes.P2PKHOutputSize
+		return txrules.FeeForSerializeSize(feeSatPerKb, size)
+	}
+	var (
+		feeTotal       amt.Amount
+		feeInputs      []*wire.TxIn
+		feeInputValues []amt.Amount
+		feeScripts     [][]byte
+	)
+	fee := feeForInputs(len(inputs) + 1)
+	for {
+		if feeTotal, feeInputs, feeInputValues, feeScripts, e = sponsor(fee); E.Chk(e) {
+			return nil, -1, e
+		}
+		if feeTotal < fee {
+			return nil, -1, btcjson.RPCError{
+				Code:    btcjson.ErrRPCWalletInsufficientFunds,
+				Message: fmt.Sprintf("the fee account has too little to pay the fee of %v", fee),
+			}
+		}
+		need := feeForInputs(len(inputs) + len(feeInputs))
+		if feeTotal >= need {
+			fee = need
+			break
+		}
+		fee = need
+	}
+	tx = &txauthor.AuthoredTx{
+		Tx:              wire.NewMsgTx(wire.TxVersion),
+		PrevScripts:     append(scripts, feeScripts...),
+		PrevInputValues: append(inputValues, feeInputValues...),
+		TotalInput:      total + feeTotal,
+		ChangeIndex:     -1,
+	}
+	tx.Tx.TxIn = append(inputs, feeInputs...)
+	tx.Tx.AddTxOut(out)
+	feeChange = -1
+	var script []byte
+	if total > amount {
+		if script, e = sourceChange(); E.Chk(e) {
+			return nil, -1, e
+		}
+		tx.ChangeIndex = len(tx.Tx.TxOut)
+		tx.Tx.AddTxOut(wire.NewTxOut(int64(total-amount), script))
+	}
+	if change := feeTotal - fee; change > 0 && !isDust(change) {
+		if script, e = sponsorChange(); E.Chk(e) {
+			return nil, -1, e
+		}
+		feeChange = len(tx.Tx.TxOut)
+		tx.Tx.AddTxOut(wire.NewTxOut(int64(change), script))
+	}
+	return
+}
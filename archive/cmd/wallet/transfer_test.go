@@ -0,0 +1,101 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestAuthorSponsoredTx ensures a transfer with its fee paid by a fee account charges the source account exactly the
+// amount and the fee account exactly the fee, whatever the outputs of each account.
+func TestAuthorSponsoredTx(t *testing.T) {
+	credits := func(amounts ...amt.Amount) txauthor.InputSource {
+		c := make([]wtxmgr.Credit, len(amounts))
+		for i := range amounts {
+			c[i].Amount, c[i].PkScript = amounts[i], make([]byte, 25)
+			c[i].OutPoint.Index = uint32(i)
+		}
+		return makeInputSource(c)
+	}
+	change := func(b byte) txauthor.ChangeSource {
+		return func() ([]byte, error) {
+			script := make([]byte, 25)
+			script[0] = b
+			return script, nil
+		}
+	}
+	const feeRate = 100000
+	tests := []struct {
+		name    string
+		amount  amt.Amount
+		source  []amt.Amount
+		fees    []amt.Amount
+		inputs  int
+		changes bool
+		fails   bool
+	}{
+		// The change of 10000 left by the first output of the source is dust, so the second is spent too.
+		{"dust source change", 2e8 - 1e4, []amt.Amount{2e8, 1e8}, []amt.Amount{1e6}, 3, true, false},
+		{"exact amount", 1e8, []amt.Amount{1e8}, []amt.Amount{1e6}, 2, false, false},
+		// The change of the fee account is dust and goes to the fee.
+		{"dust fee change", 1e8, []amt.Amount{1e8}, []amt.Amount{46200}, 2, false, false},
+		{"short source", 3e8, []amt.Amount{2e8}, []amt.Amount{1e6}, 0, false, true},
+		{"short fee account", 1e8, []amt.Amount{1e8}, []amt.Amount{1e3}, 0, false, true},
+	}
+	for _, test := range tests {
+		out := wire.NewTxOut(int64(test.amount), make([]byte, 25))
+		tx, feeChange, e := authorSponsoredTx(
+			out, feeRate, credits(test.source...), change(1), credits(test.fees...), change(2),
+		)
+		if test.fails {
+			if e == nil {
+				t.Errorf("%s: no error", test.name)
+			}
+			continue
+		}
+		if e != nil {
+			t.Errorf("%s: %v", test.name, e)
+			continue
+		}
+		if len(tx.Tx.TxIn) != test.inputs || (tx.ChangeIndex >= 0 && feeChange >= 0) != test.changes {
+			t.Errorf("%s: got %d inputs and change outputs %d, %d", test.name, len(tx.Tx.TxIn), tx.ChangeIndex, feeChange)
+			continue
+		}
+		var fromSource, fromFees, sourceChange, feesChange, outputs amt.Amount
+		// the inputs of the source account come first until the transaction is ordered, and the fee account has one
+		// output
+		sources := len(tx.PrevInputValues) - 1
+		for i, v := range tx.PrevInputValues {
+			if i < sources {
+				fromSource += v
+			} else {
+				fromFees += v
+			}
+		}
+		for i := 0; i < 8; i++ {
+			tx.Order(txauthor.OrderRandom, &feeChange)
+		}
+		tx.Order(txauthor.OrderBIP69, &feeChange)
+		for i, o := range tx.Tx.TxOut {
+			outputs += amt.Amount(o.Value)
+			switch {
+			case i == tx.ChangeIndex && o.PkScript[0] == 1:
+				sourceChange = amt.Amount(o.Value)
+			case i == feeChange && o.PkScript[0] == 2:
+				feesChange = amt.Amount(o.Value)
+			case o != out:
+				t.Errorf("%s: output %d is not the payment or the change of its account", test.name, i)
+			}
+		}
+		fee := tx.TotalInput - outputs
+		if fromSource-sourceChange != test.amount || fromFees-feesChange != fee || fee <= 0 {
+			t.Errorf(
+				"%s: source charged %v and fee account %v for an amount of %v and a fee of %v",
+				test.name, fromSource-sourceChange, fromFees-feesChange, test.amount, fee,
+			)
+		}
+	}
+}
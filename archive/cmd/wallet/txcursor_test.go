@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+// TestTxCursor ensures cursors made by formatTxCursor parse back to the same position and that malformed cursors are
+// rejected.
+func TestTxCursor(t *testing.T) {
+	hash := chainhash.DoubleHashH([]byte("cursor"))
+	for _, height := range []int32{-1, 0, 123456} {
+		gotHeight, gotHash, e := parseTxCursor(formatTxCursor(height, &hash))
+		if e != nil {
+			t.Fatalf("height %d: unexpected error: %v", height, e)
+		}
+		if gotHeight != height || *gotHash != hash {
+			t.Errorf("height %d: got %d:%v, want %d:%v", height, gotHeight, gotHash, height, hash)
+		}
+	}
+	for _, cursor := range []string{"", "12", "x:" + hash.String(), "-2:" + hash.String(), "12:nothex"} {
+		if _, _, e := parseTxCursor(cursor); e != ErrInvalidCursor {
+			t.Errorf("cursor %q: got error %v, want %v", cursor, e, ErrInvalidCursor)
+		}
+	}
+}
+
+// TestMatchTxFilter ensures each field of a listtransactionspage filter selects the expected entries.
+func TestMatchTxFilter(t *testing.T) {
+	result := &btcjson.ListTransactionsResult{
+		Account:  "acct",
+		Category: "send",
+		Amount:   -1.5,
+		Time:     1500000000,
+	}
+	label, other := "acct", "other"
+	before, after := int64(1400000000), int64(1600000000)
+	small, large := 1.0, 2.0
+	tests := []struct {
+		name   string
+		filter *btcjson.ListTransactionsFilter
+		match  bool
+	}{
+		{"no filter", nil, true},
+		{"category", &btcjson.ListTransactionsFilter{Categories: []string{"receive", "send"}}, true},
+		{"other category", &btcjson.ListTransactionsFilter{Categories: []string{"receive"}}, false},
+		{"label", &btcjson.ListTransactionsFilter{Label: &label}, true},
+		{"other label", &btcjson.ListTransactionsFilter{Label: &other}, false},
+		{"in time range", &btcjson.ListTransactionsFilter{StartTime: &before, EndTime: &after}, true},
+		{"before start", &btcjson.ListTransactionsFilter{StartTime: &after}, false},
+		{"after end", &btcjson.ListTransactionsFilter{EndTime: &before}, false},
+		{"above min amount", &btcjson.ListTransactionsFilter{MinAmount: &small}, true},
+		{"below min amount", &btcjson.ListTransactionsFilter{MinAmount: &large}, false},
+	}
+	for _, test := range tests {
+		if got := matchTxFilter(result, test.filter); got != test.match {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.match)
+		}
+	}
+}
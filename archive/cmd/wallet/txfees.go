@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"math/big"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// txFee returns the fee paid by the transaction, which is only known when the wallet spent every one of its inputs, as
+// the values of outputs of other wallets are not recorded.
+func txFee(details *wtxmgr.TxDetails) (fee amt.Amount, known bool) {
+	if len(details.Debits) == 0 || len(details.Debits) != len(details.MsgTx.TxIn) {
+		return 0, false
+	}
+	for _, deb := range details.Debits {
+		fee += deb.Amount
+	}
+	for _, output := range details.MsgTx.TxOut {
+		fee -= amt.Amount(output.Value)
+	}
+	return fee, true
+}
+
+// outputFees shares the fee of the transaction between its outputs that are not change, in proportion to their value,
+// so that the fee of a send paying several addresses, as sendmany does, can be attributed to each of them. The shares
+// are rounded down, with what is left over given to the first output, so they add up to the fee exactly.
+func outputFees(details *wtxmgr.TxDetails, fee amt.Amount) map[uint32]amt.Amount {
+	change := make(map[uint32]bool)
+	for _, cred := range details.Credits {
+		if cred.Change {
+			change[cred.Index] = true
+		}
+	}
+	var total amt.Amount
+	var paid []uint32
+	for i, output := range details.MsgTx.TxOut {
+		if !change[uint32(i)] {
+			total += amt.Amount(output.Value)
+			paid = append(paid, uint32(i))
+		}
+	}
+	shares := make(map[uint32]amt.Amount, len(paid))
+	if len(paid) == 0 {
+		return shares
+	}
+	left := fee
+	for _, i := range paid {
+		var share amt.Amount
+		if total > 0 {
+			// the product of the fee and a value can overflow an int64
+			n := new(big.Int).Mul(big.NewInt(int64(fee)), big.NewInt(details.MsgTx.TxOut[i].Value))
+			share = amt.Amount(n.Quo(n, big.NewInt(int64(total))).Int64())
+		}
+		shares[i] = share
+		left -= share
+	}
+	shares[paid[0]] += left
+	return shares
+}
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestOutputFees ensures the fee of a send is known only when the wallet spent all of its inputs, and that it is shared
+// between the outputs that are not change in proportion to their value, adding up to the fee exactly.
+func TestOutputFees(t *testing.T) {
+	details := &wtxmgr.TxDetails{}
+	details.MsgTx.TxIn = []*wire.TxIn{{}, {}}
+	for _, value := range []int64{100000, 200000, 400000, 299000} {
+		details.MsgTx.TxOut = append(details.MsgTx.TxOut, &wire.TxOut{Value: value})
+	}
+	details.Debits = []wtxmgr.DebitRecord{{Amount: 600000, Index: 0}}
+	if _, known := txFee(details); known {
+		t.Fatal("the fee was known with an input the wallet did not spend")
+	}
+	details.Debits = append(details.Debits, wtxmgr.DebitRecord{Amount: 400000, Index: 1})
+	details.Credits = []wtxmgr.CreditRecord{{Amount: 299000, Index: 3, Change: true}}
+	fee, known := txFee(details)
+	if !known || fee != 1000 {
+		t.Fatalf("fee %v known %v, want 1000 satoshis", fee, known)
+	}
+	shares := outputFees(details, fee)
+	want := map[uint32]amt.Amount{0: 144, 1: 285, 2: 571}
+	if len(shares) != len(want) {
+		t.Fatalf("shared the fee between %d outputs, want %d", len(shares), len(want))
+	}
+	for i, share := range want {
+		if shares[i] != share {
+			t.Errorf("output %d has a share of %v, want %v", i, shares[i], share)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+const (
+	// unlockFreeAttempts is the number of wrong passphrases in a row that are accepted before further unlock attempts
+	// are throttled.
+	unlockFreeAttempts = 3
+	// unlockBackoffBase is how long unlock attempts are refused after the first wrong passphrase beyond
+	// unlockFreeAttempts. The delay doubles with every further wrong passphrase.
+	unlockBackoffBase = 2 * time.Second
+	// maxUnlockBackoff is the longest unlock attempts are refused after a wrong passphrase.
+	maxUnlockBackoff = 15 * time.Minute
+	// maxUnlockAuditEntries is the number of the most recent unlock attempts kept in the audit log.
+	maxUnlockAuditEntries = 100
+)
+
+// UnlockAttempt is an entry of the audit log of attempts to unlock the wallet.
+type UnlockAttempt struct {
+	Time    time.Time
+	Success bool
+	// Reason is why the attempt failed, or that it succeeded.
+	Reason string
+}
+
+// unlockGuard throttles attempts to unlock the wallet with exponential backoff after repeated wrong passphrases, and
+// keeps an audit log of the attempts. The zero value is ready to use.
+type unlockGuard struct {
+	sync.Mutex
+	failures   int
+	retryAfter time.Time
+	audit      []UnlockAttempt
+}
+
+// throttled returns how long unlock attempts at the time now must wait before they are tried, or zero if they may be
+// tried now.
+func (g *unlockGuard) throttled(now time.Time) time.Duration {
+	g.Lock()
+	defer g.Unlock()
+	if now.Before(g.retryAfter) {
+		return g.retryAfter.Sub(now)
+	}
+	return 0
+}
+
+// record adds an unlock attempt to the audit log, and updates the backoff for the next attempts. Only wrong passphrases
+// count towards the backoff, and a successful unlock clears it.
+func (g *unlockGuard) record(now time.Time, success, wrongPassphrase bool, reason string) {
+	g.Lock()
+	defer g.Unlock()
+	switch {
+	case success:
+		g.failures = 0
+		g.retryAfter = time.Time{}
+	case wrongPassphrase:
+		g.failures++
+		if backoff := unlockBackoff(g.failures); backoff > 0 {
+			g.retryAfter = now.Add(backoff)
+		}
+	}
+	if len(g.audit) >= maxUnlockAuditEntries {
+		g.audit = append(g.audit[:0], g.audit[len(g.audit)-maxUnlockAuditEntries+1:]...)
+	}
+	g.audit = append(g.audit, UnlockAttempt{Time: now, Success: success, Reason: reason})
+	if success {
+		I.Ln("wallet unlock audit:", reason)
+	} else {
+		W.Ln("wallet unlock audit: failed,", reason)
+	}
+}
+
+// unlockBackoff returns how long attempts are refused after the number of wrong secrets given in a row, which is zero
+// within unlockFreeAttempts and then doubles with every further failure up to maxUnlockBackoff.
+func unlockBackoff(failures int) time.Duration {
+	if failures <= unlockFreeAttempts {
+		return 0
+	}
+	backoff := maxUnlockBackoff
+	if shift := failures - unlockFreeAttempts - 1; shift < 16 {
+		if b := unlockBackoffBase << uint(shift); b < backoff {
+			backoff = b
+		}
+	}
+	return backoff
+}
+
+// attempts returns a copy of the audit log, oldest first.
+func (g *unlockGuard) attempts() []UnlockAttempt {
+	g.Lock()
+	defer g.Unlock()
+	return append([]UnlockAttempt(nil), g.audit...)
+}
+
+// errUnlockThrottled returns the error for an unlock attempt refused because it must wait before it is tried.
+func errUnlockThrottled(wait time.Duration) *btcjson.RPCError {
+	return &btcjson.RPCError{
+		Code: btcjson.ErrRPCWalletPassphraseIncorrect,
+		Message: fmt.Sprintf(
+			"too many incorrect passphrases, try again in %v", wait.Round(time.Second),
+		),
+	}
+}
+
+// UnlockAttempts returns the audit log of the most recent attempts to unlock the wallet, oldest first.
+func (w *Wallet) UnlockAttempts() []UnlockAttempt {
+	return w.unlockGuard.attempts()
+}
+
+// idleLockAfter returns how long the wallet may stay unlocked without being used before it is locked, whatever timeout
+// it was unlocked with, or zero if it is not locked when idle.
+func (w *Wallet) idleLockAfter() time.Duration {
+	if w.PodConfig == nil || w.PodConfig.WalletIdleLock == nil {
+		return 0
+	}
+	return w.PodConfig.WalletIdleLock.V()
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+// TestUnlockGuard ensures unlock attempts are throttled with a doubling delay once more than unlockFreeAttempts wrong
+// passphrases are given in a row, that a successful unlock clears the delay, and that the audit log keeps only the
+// most recent attempts.
+func TestUnlockGuard(t *testing.T) {
+	var g unlockGuard
+	now := time.Unix(1500000000, 0)
+	for i := 0; i < unlockFreeAttempts; i++ {
+		g.record(now, false, true, "incorrect passphrase")
+		if wait := g.throttled(now); wait != 0 {
+			t.Fatalf("attempt %d: throttled for %v within the free attempts", i, wait)
+		}
+	}
+	want := unlockBackoffBase
+	for i := 0; i < 4; i++ {
+		g.record(now, false, true, "incorrect passphrase")
+		if wait := g.throttled(now); wait != want {
+			t.Fatalf("failure %d: throttled for %v, want %v", unlockFreeAttempts+i+1, wait, want)
+		}
+		if wait := g.throttled(now.Add(want)); wait != 0 {
+			t.Fatalf("failure %d: still throttled after the delay", unlockFreeAttempts+i+1)
+		}
+		want *= 2
+	}
+	// Errors other than a wrong passphrase do not add to the delay.
+	g.record(now, false, false, "database error")
+	if wait := g.throttled(now); wait != want/2 {
+		t.Fatalf("throttled for %v after another error, want %v", wait, want/2)
+	}
+	for i := 0; i < maxUnlockAuditEntries; i++ {
+		g.record(now, false, true, "incorrect passphrase")
+	}
+	if wait := g.throttled(now); wait != maxUnlockBackoff {
+		t.Fatalf("throttled for %v, want the maximum %v", wait, maxUnlockBackoff)
+	}
+	g.record(now, true, false, "unlocked")
+	if wait := g.throttled(now); wait != 0 {
+		t.Fatalf("throttled for %v after a successful unlock", wait)
+	}
+	attempts := g.attempts()
+	if len(attempts) != maxUnlockAuditEntries {
+		t.Fatalf("audit log has %d entries, want %d", len(attempts), maxUnlockAuditEntries)
+	}
+	if last := attempts[len(attempts)-1]; !last.Success || last.Reason != "unlocked" || !last.Time.Equal(now) {
+		t.Errorf("last audit entry is %+v, want the successful unlock", last)
+	}
+}
@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// The UTXO report sums up the unspent outputs of the wallet by how old they are and how much they are worth, and finds
+// those that are uneconomic, which cost more in fees to spend at the fee rate than they are worth. It shows the health
+// of the wallet: a wallet holding many small or uneconomic outputs pays more to send and should consolidate them while
+// fees are low.
+
+// UTXOBucket is the unspent outputs of the wallet within a range of ages or amounts. Max is zero for the last bucket,
+// which has no upper bound.
+type UTXOBucket struct {
+	Name     string
+	Min, Max int64
+	Count    int
+	Amount   amt.Amount
+	// Uneconomic is the number of outputs of the bucket that cost more to spend than they are worth, and
+	// UneconomicAmount what they are worth.
+	Uneconomic       int
+	UneconomicAmount amt.Amount
+	// SpendCost is the fee that spending every output of the bucket would add to a transaction at the fee rate.
+	SpendCost amt.Amount
+}
+
+// UTXOReport is the report of the unspent outputs of the wallet at a fee rate.
+type UTXOReport struct {
+	Height  int32
+	FeeRate amt.Amount
+	// InputCost is the fee that spending one output adds to a transaction at the fee rate. The outputs are taken to be
+	// spent with inputs of the size of one redeeming a pay-to-pubkey-hash output, which is what the wallet makes.
+	InputCost        amt.Amount
+	Count            int
+	Amount           amt.Amount
+	Uneconomic       int
+	UneconomicAmount amt.Amount
+	// Dust is the number of outputs that nodes would not relay a transaction paying them at the fee rate, and Frozen
+	// and Locked the number frozen or locked, which coin selection passes over.
+	Dust   int
+	Frozen int
+	Locked int
+	// ByAge are the outputs by the time since they were mined, with the unmined outputs in the first bucket, and
+	// ByValue by their amounts.
+	ByAge   []UTXOBucket
+	ByValue []UTXOBucket
+}
+
+// utxoAgeBuckets are the ranges of ages in seconds the outputs are counted in after the bucket of unmined outputs.
+var utxoAgeBuckets = []UTXOBucket{
+	{Name: "day", Max: 24 * 60 * 60},
+	{Name: "week", Min: 24 * 60 * 60, Max: 7 * 24 * 60 * 60},
+	{Name: "month", Min: 7 * 24 * 60 * 60, Max: 30 * 24 * 60 * 60},
+	{Name: "halfyear", Min: 30 * 24 * 60 * 60, Max: 182 * 24 * 60 * 60},
+	{Name: "year", Min: 182 * 24 * 60 * 60, Max: 365 * 24 * 60 * 60},
+	{Name: "older", Min: 365 * 24 * 60 * 60},
+}
+
+// utxoValueBuckets are the ranges of amounts in satoshis the outputs are counted in.
+var utxoValueBuckets = []UTXOBucket{
+	{Name: "<0.0001", Max: 1e4},
+	{Name: "0.0001-0.001", Min: 1e4, Max: 1e5},
+	{Name: "0.001-0.01", Min: 1e5, Max: 1e6},
+	{Name: "0.01-0.1", Min: 1e6, Max: 1e7},
+	{Name: "0.1-1", Min: 1e7, Max: 1e8},
+	{Name: "1-10", Min: 1e8, Max: 1e9},
+	{Name: "10-100", Min: 1e9, Max: 1e10},
+	{Name: ">=100", Min: 1e10},
+}
+
+// UTXOReport returns the report of the unspent outputs of the wallet, including unmined ones, at the fee rate per
+// kilobyte, or the fee rate of the wallet if it is zero.
+func (w *Wallet) UTXOReport(feeRate amt.Amount) (report *UTXOReport, e error) {
+	if feeRate < 0 {
+		return nil, InvalidParameterError{fmt.Errorf("the fee rate %v is negative", feeRate)}
+	}
+	if feeRate == 0 {
+		feeRate = txrules.DefaultRelayFeePerKb
+	}
+	var credits []wtxmgr.Credit
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			credits, e = w.TxStore.UnspentOutputs(tx.ReadBucket(wtxmgrNamespaceKey))
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	report = newUTXOReport(w.Manager.SyncedTo().Height, feeRate)
+	now := time.Now()
+	for i := range credits {
+		c := &credits[i]
+		report.add(c, now, w.FrozenOutpoint(c.OutPoint), w.LockedOutpoint(c.OutPoint))
+	}
+	return
+}
+
+// newUTXOReport returns an empty report of the outputs at the fee rate as of the block height.
+func newUTXOReport(height int32, feeRate amt.Amount) *UTXOReport {
+	r := &UTXOReport{
+		Height:    height,
+		FeeRate:   feeRate,
+		InputCost: txrules.FeeForSerializeSize(feeRate, txsizes.RedeemP2PKHInputSize),
+		ByAge:     append([]UTXOBucket{{Name: "unconfirmed"}}, utxoAgeBuckets...),
+		ByValue:   append([]UTXOBucket(nil), utxoValueBuckets...),
+	}
+	return r
+}
+
+// add counts an unspent output in the report at the time now.
+func (r *UTXOReport) add(c *wtxmgr.Credit, now time.Time, frozen, locked bool) {
+	uneconomic := c.Amount < r.InputCost
+	r.Count++
+	r.Amount += c.Amount
+	if uneconomic {
+		r.Uneconomic++
+		r.UneconomicAmount += c.Amount
+	}
+	if txrules.IsDustAmount(c.Amount, len(c.PkScript), r.FeeRate) {
+		r.Dust++
+	}
+	if frozen {
+		r.Frozen++
+	}
+	if locked {
+		r.Locked++
+	}
+	age := &r.ByAge[0]
+	if c.Height >= 0 {
+		age = findUTXOBucket(r.ByAge[1:], int64(now.Sub(c.Time)/time.Second))
+	}
+	age.add(c.Amount, uneconomic, r.InputCost)
+	findUTXOBucket(r.ByValue, int64(c.Amount)).add(c.Amount, uneconomic, r.InputCost)
+}
+
+// findUTXOBucket returns the bucket the value falls in, the first if it is below all of them.
+func findUTXOBucket(buckets []UTXOBucket, v int64) *UTXOBucket {
+	for i := len(buckets) - 1; i > 0; i-- {
+		if v >= buckets[i].Min {
+			return &buckets[i]
+		}
+	}
+	return &buckets[0]
+}
+
+// add counts an output of the amount in the bucket.
+func (b *UTXOBucket) add(amount amt.Amount, uneconomic bool, inputCost amt.Amount) {
+	b.Count++
+	b.Amount += amount
+	b.SpendCost += inputCost
+	if uneconomic {
+		b.Uneconomic++
+		b.UneconomicAmount += amount
+	}
+}
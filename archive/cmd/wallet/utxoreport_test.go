@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// TestUTXOReport ensures unspent outputs are counted in the buckets of their age and amount, and that outputs worth
+// less than the fee to spend them are found uneconomic.
+func TestUTXOReport(t *testing.T) {
+	now := time.Now()
+	r := newUTXOReport(100, 100000)
+	if r.InputCost != 14900 {
+		t.Fatalf("got an input cost of %v, want 14900 satoshis", int64(r.InputCost))
+	}
+	credits := []struct {
+		amount amt.Amount
+		height int32
+		age    time.Duration
+		frozen bool
+	}{
+		{5000, -1, 0, false},
+		{2e8, 90, 2 * time.Hour, false},
+		{14899, 50, 3 * 24 * time.Hour, true},
+		{14900, 10, 400 * 24 * time.Hour, false},
+		{3e10, 1, 400 * 24 * time.Hour, false},
+	}
+	for _, c := range credits {
+		credit := &wtxmgr.Credit{Amount: c.amount, PkScript: make([]byte, 25)}
+		credit.Height, credit.Time = c.height, now.Add(-c.age)
+		r.add(credit, now, c.frozen, false)
+	}
+	if r.Count != 5 || r.Amount != 30200034799 || r.Uneconomic != 2 || r.UneconomicAmount != 19899 ||
+		r.Frozen != 1 || r.Locked != 0 {
+		t.Errorf("got report %+v", r)
+	}
+	byAge := map[string]int{"unconfirmed": 1, "day": 1, "week": 1, "older": 2}
+	for _, b := range r.ByAge {
+		if b.Count != byAge[b.Name] || b.SpendCost != amt.Amount(b.Count)*r.InputCost {
+			t.Errorf("got %d outputs costing %v in age bucket %s, want %d", b.Count, b.SpendCost, b.Name, byAge[b.Name])
+		}
+	}
+	byValue := map[string]int{"<0.0001": 1, "0.0001-0.001": 2, "1-10": 1, ">=100": 1}
+	for _, b := range r.ByValue {
+		if b.Count != byValue[b.Name] {
+			t.Errorf("got %d outputs in value bucket %s, want %d", b.Count, b.Name, byValue[b.Name])
+		}
+	}
+	if b := r.ByValue[1]; b.Uneconomic != 1 || b.UneconomicAmount != 14899 {
+		t.Errorf("got %d uneconomic outputs of %v in value bucket %s", b.Uneconomic, b.UneconomicAmount, b.Name)
+	}
+	if utxoAgeBuckets[0].Count != 0 || utxoValueBuckets[0].Count != 0 {
+		t.Error("a report counted outputs in the shared bucket ranges")
+	}
+}
@@ -0,0 +1,180 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// VanityMatcher is handed batches of candidate receive addresses by NewVanityAddress, in order of their index, and
+// returns the position in the batch of the address it accepts, or -1 to be handed the next batch. Matchers that search
+// with many threads or other hardware get a whole batch at a time for that reason.
+type VanityMatcher func(candidates []btcaddr.Address) int
+
+// PrefixMatcher returns a VanityMatcher accepting the first address whose encoding starts with the prefix.
+func PrefixMatcher(prefix string) VanityMatcher {
+	return func(candidates []btcaddr.Address) int {
+		for i, addr := range candidates {
+			if strings.HasPrefix(addr.EncodeAddress(), prefix) {
+				return i
+			}
+		}
+		return -1
+	}
+}
+
+const (
+	// vanityBatchSize is the number of candidate addresses handed to a VanityMatcher at a time.
+	vanityBatchSize = 100
+	// defaultGapLimit is the gap limit of BIP0044, used when the wallet has no recovery window.
+	defaultGapLimit = 20
+)
+
+// ErrNoVanityAddress is returned by NewVanityAddress when the matcher accepted none of the addresses within the gap
+// limit.
+var ErrNoVanityAddress = errors.New("no address within the gap limit was accepted")
+
+// NewVanityAddress returns a receive address of the account accepted by the matcher, derived from the wallet seed, so
+// that it is recovered with the seed rather than having to be imported and backed up. Candidates are derived from the
+// next index of the external branch of the account, and the addresses skipped before the accepted one are stored as
+// handed out. Only indexes within the recovery window of the wallet past the last used address of the branch are
+// tried, so that a restored wallet still finds the accepted address, and ErrNoVanityAddress is returned if none of
+// them are accepted. The manager need not be unlocked, as the candidates are derived from the account public key.
+func (w *Wallet) NewVanityAddress(
+	account uint32, scope waddrmgr.KeyScope, match VanityMatcher,
+) (addr btcaddr.Address, index uint32, e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(scope); E.Chk(e) {
+		return
+	}
+	gapLimit := w.recoveryWindow
+	if gapLimit == 0 {
+		gapLimit = defaultGapLimit
+	}
+	var next, horizon uint32
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			if e = checkNotSequential(tx, scope, account); E.Chk(e) {
+				return
+			}
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			if e = w.checkNotVault(ns, account, scope); e != nil {
+				return
+			}
+			var props *waddrmgr.AccountProperties
+			if props, e = manager.AccountProperties(ns, account); E.Chk(e) {
+				return
+			}
+			var used uint32
+			if used, e = usedExternalAddresses(ns, manager, account); E.Chk(e) {
+				return
+			}
+			next, horizon = props.ExternalKeyCount, vanityHorizon(used, gapLimit)
+			return
+		},
+	); e != nil {
+		return
+	}
+	if next >= horizon {
+		e = errors.New("the account already has as many unused addresses as the gap limit allows")
+		return
+	}
+	found := false
+	for start := next; start < horizon && !found; start += vanityBatchSize {
+		end := start + vanityBatchSize
+		if end > horizon {
+			end = horizon
+		}
+		var derived map[uint32]btcaddr.Address
+		if e = walletdb.View(
+			w.db, func(tx walletdb.ReadTx) (e error) {
+				derived, e = manager.DeriveExternalAddresses(tx.ReadBucket(waddrmgrNamespaceKey), account, start, end)
+				return
+			},
+		); E.Chk(e) {
+			return
+		}
+		candidates := make([]btcaddr.Address, 0, len(derived))
+		indexes := make([]uint32, 0, len(derived))
+		for i := start; i < end; i++ {
+			if a, ok := derived[i]; ok {
+				candidates = append(candidates, a)
+				indexes = append(indexes, i)
+			}
+		}
+		if i := match(candidates); i >= 0 && i < len(candidates) {
+			addr, index, found = candidates[i], indexes[i], true
+		}
+	}
+	if !found {
+		return nil, 0, ErrNoVanityAddress
+	}
+	var props *waddrmgr.AccountProperties
+	if e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			// Another address may have been handed out while the matcher ran, in which case the accepted one may be too.
+			if props, e = manager.AccountProperties(ns, account); E.Chk(e) {
+				return
+			}
+			if props.ExternalKeyCount != next {
+				return errors.New("addresses of the account were handed out during the search, try again")
+			}
+			if e = manager.ExtendExternalAddresses(ns, account, index); E.Chk(e) {
+				return
+			}
+			props, e = manager.AccountProperties(ns, account)
+			return
+		},
+	); e != nil {
+		return nil, 0, e
+	}
+	if chainClient := w.ChainClient(); chainClient != nil {
+		if e = chainClient.NotifyReceived([]btcaddr.Address{addr}); E.Chk(e) {
+			return nil, 0, e
+		}
+		w.NtfnServer.notifyAccountProperties(props)
+	}
+	return
+}
+
+// usedExternalAddresses returns one more than the highest index of a used address on the external branch of the
+// account, which is 0 if none have been used.
+func usedExternalAddresses(ns walletdb.ReadBucket, manager *waddrmgr.ScopedKeyManager, account uint32) (
+	used uint32, e error,
+) {
+	// The addresses are gathered first, as the manager is locked while they are gone through.
+	var maddrs []waddrmgr.ManagedPubKeyAddress
+	if e = manager.ForEachAccountAddress(
+		ns, account, func(maddr waddrmgr.ManagedAddress) error {
+			if pka, ok := maddr.(waddrmgr.ManagedPubKeyAddress); ok {
+				maddrs = append(maddrs, pka)
+			}
+			return nil
+		},
+	); E.Chk(e) {
+		return
+	}
+	for _, maddr := range maddrs {
+		_, path, derived := maddr.DerivationInfo()
+		if !derived || path.Branch != waddrmgr.ExternalBranch || path.Index < used {
+			continue
+		}
+		if maddr.Used(ns) {
+			used = path.Index + 1
+		}
+	}
+	return
+}
+
+// vanityHorizon returns the index up to which candidate addresses are tried, which leaves no more than the gap limit of
+// unused addresses after the used ones, so that the accepted address is found again when the wallet is restored.
+func vanityHorizon(used, gapLimit uint32) uint32 {
+	if used > waddrmgr.MaxAddressesPerAccount-gapLimit {
+		return waddrmgr.MaxAddressesPerAccount
+	}
+	return used + gapLimit
+}
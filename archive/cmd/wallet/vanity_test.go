@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/waddrmgr"
+)
+
+// TestVanityHorizon ensures candidates are only tried within the gap limit past the used addresses, and never beyond
+// the addresses an account can have.
+func TestVanityHorizon(t *testing.T) {
+	tests := []struct {
+		used, gapLimit, want uint32
+	}{
+		{0, 20, 20},
+		{5, 250, 255},
+		{waddrmgr.MaxAddressesPerAccount - 10, 20, waddrmgr.MaxAddressesPerAccount},
+	}
+	for _, test := range tests {
+		if got := vanityHorizon(test.used, test.gapLimit); got != test.want {
+			t.Errorf("horizon with %d used and a gap limit of %d is %d, want %d", test.used, test.gapLimit, got, test.want)
+		}
+	}
+}
+
+// TestPrefixMatcher ensures the first candidate with the prefix is accepted, and none if no candidate has it.
+func TestPrefixMatcher(t *testing.T) {
+	var candidates []btcaddr.Address
+	for i := byte(0); i < 3; i++ {
+		hash := make([]byte, 20)
+		hash[0] = i
+		addr, e := btcaddr.NewPubKeyHash(hash, &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		candidates = append(candidates, addr)
+	}
+	prefix := candidates[0].EncodeAddress()[:3]
+	if i := PrefixMatcher(prefix)(candidates); i != 0 {
+		t.Errorf("accepted candidate %d, want 0", i)
+	}
+	if i := PrefixMatcher(prefix + "!")(candidates); i != -1 {
+		t.Errorf("accepted candidate %d without the prefix", i)
+	}
+}
@@ -0,0 +1,207 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// VaultLock is a deposit address of a vault account with the outputs paid to it that the wallet has not spent.
+type VaultLock struct {
+	waddrmgr.VaultAddress
+	Amount  amt.Amount
+	Outputs int
+}
+
+// CreateVaultAccount adds an account with the name whose deposit addresses are locked until the chain reaches
+// lockHeight, or when it is zero, until delay blocks after they are generated.
+func (w *Wallet) CreateVaultAccount(name string, lockHeight, delay int32) (va *waddrmgr.VaultAccount, e error) {
+	var props *waddrmgr.AccountProperties
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if va, e = w.Manager.NewVaultAccount(addrmgrNs, name, lockHeight, delay); E.Chk(e) {
+				return
+			}
+			var manager *waddrmgr.ScopedKeyManager
+			if manager, e = w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+				return
+			}
+			props, e = manager.AccountProperties(addrmgrNs, va.Account)
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	w.NtfnServer.notifyAccountProperties(props)
+	return
+}
+
+// VaultAccounts returns the vault accounts of the wallet.
+func (w *Wallet) VaultAccounts() (accounts []*waddrmgr.VaultAccount, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			return w.Manager.ForEachVaultAccount(
+				tx.ReadBucket(waddrmgrNamespaceKey), func(va *waddrmgr.VaultAccount) error {
+					accounts = append(accounts, va)
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// NewVaultAddress returns the next deposit address of the vault account with the name along with its redeem script,
+// and has the chain server notify the wallet of payments to it.
+func (w *Wallet) NewVaultAddress(name string) (addr *waddrmgr.VaultAddress, script []byte, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			bs := w.Manager.SyncedTo()
+			addr, script, e = w.Manager.NextVaultAddress(tx.ReadWriteBucket(waddrmgrNamespaceKey), name, &bs)
+			return
+		},
+	)
+	if e != nil {
+		return
+	}
+	e = chainClient.NotifyReceived([]btcaddr.Address{addr.Address})
+	return
+}
+
+// VaultSchedule returns the deposit addresses of the vault account with the name in the order of their lock height,
+// with the outputs paid to each that the wallet has not spent.
+func (w *Wallet) VaultSchedule(name string) (locks []*VaultLock, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			var byScriptHash map[string]*VaultLock
+			if locks, byScriptHash, e = w.vaultLocks(addrmgrNs, name); E.Chk(e) {
+				return
+			}
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(tx.ReadBucket(wtxmgrNamespaceKey)); E.Chk(e) {
+				return
+			}
+			for i := range unspent {
+				if lock := vaultOutputLock(byScriptHash, unspent[i].PkScript); lock != nil {
+					lock.Amount += unspent[i].Amount
+					lock.Outputs++
+				}
+			}
+			return
+		},
+	)
+	return
+}
+
+// vaultLocks returns the deposit addresses of the vault account with the name sorted by lock height, and keyed by
+// their script hash.
+func (w *Wallet) vaultLocks(addrmgrNs walletdb.ReadBucket, name string) (
+	locks []*VaultLock, byScriptHash map[string]*VaultLock, e error,
+) {
+	if _, e = w.Manager.FetchVaultAccount(addrmgrNs, name); E.Chk(e) {
+		return
+	}
+	byScriptHash = make(map[string]*VaultLock)
+	e = w.Manager.ForEachVaultAddress(
+		addrmgrNs, name, func(addr *waddrmgr.VaultAddress) error {
+			lock := &VaultLock{VaultAddress: *addr}
+			locks = append(locks, lock)
+			byScriptHash[string(addr.Address.ScriptAddress())] = lock
+			return nil
+		},
+	)
+	sort.SliceStable(
+		locks, func(i, j int) bool {
+			return locks[i].LockHeight < locks[j].LockHeight
+		},
+	)
+	return
+}
+
+// vaultOutputLock returns the vault deposit address the output script pays to, or nil if it pays to none of them.
+func vaultOutputLock(byScriptHash map[string]*VaultLock, pkScript []byte) *VaultLock {
+	if !txscript.IsPayToScriptHash(pkScript) {
+		return nil
+	}
+	// A P2SH script is OP_HASH160 <20 byte hash> OP_EQUAL.
+	return byScriptHash[string(pkScript[2:22])]
+}
+
+// WithdrawVault spends the outputs paid to the deposit addresses of the vault account with the name whose lock height
+// the chain has reached to destination, less the fee, and returns the hash of the transaction. A withdrawal above the
+// spend limit needs the code set with SetSpendAuth.
+func (w *Wallet) WithdrawVault(
+	name string, destination btcaddr.Address, authCode string,
+) (txHash *chainhash.Hash, e error) {
+	if e = checkPayable(destination, w.chainParams); E.Chk(e) {
+		return
+	}
+	var pkScript []byte
+	if pkScript, e = txscript.PayToAddrScript(destination); E.Chk(e) {
+		return
+	}
+	req := createTxRequest{
+		outputs:     []*wire.TxOut{wire.NewTxOut(0, pkScript)},
+		feeSatPerKB: txrules.DefaultRelayFeePerKb,
+		vault:       name,
+		resp:        make(chan createTxResponse),
+	}
+	w.createTxRequests <- req
+	resp := <-req.resp
+	if resp.e != nil {
+		return nil, resp.e
+	}
+	if e = w.AuthorizeSpend(amt.Amount(resp.tx.Tx.TxOut[0].Value), authCode); E.Chk(e) {
+		return
+	}
+	return w.publishTransaction(resp.tx.Tx)
+}
+
+// txWithdrawVault creates a signed transaction spending the unlocked outputs of the vault account with the name to
+// pkScript, less the fee. Like txToOutputs, it must only be called by the txCreator so the outputs it spends are not
+// spent by another transaction.
+func (w *Wallet) txWithdrawVault(pkScript []byte, name string, feeSatPerKb amt.Amount) (
+	tx *txauthor.AuthoredTx, e error,
+) {
+	scripts := make(map[string][]byte)
+	e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			var locks []*VaultLock
+			if locks, _, e = w.vaultLocks(dbtx.ReadBucket(waddrmgrNamespaceKey), name); E.Chk(e) {
+				return
+			}
+			for _, lock := range locks {
+				var script []byte
+				if script, e = waddrmgr.VaultScript(lock.PubKey, lock.LockHeight); E.Chk(e) {
+					return
+				}
+				scripts[string(lock.Address.ScriptAddress())] = script
+			}
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	return w.txSpendTimeLocked(
+		pkScript, scripts, fmt.Sprintf("vault account '%s' has no unlocked outputs", name), feeSatPerKb,
+	)
+}
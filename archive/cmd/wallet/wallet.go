@@ -5,7 +5,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -55,12 +57,37 @@ var ErrNotSynced = errors.New("wallet is not synchronized with the chain server"
 
 // Namespace bucket keys.
 var (
-	waddrmgrNamespaceKey = []byte("waddrmgr")
-	wtxmgrNamespaceKey   = []byte("wtxmgr")
+	waddrmgrNamespaceKey     = []byte("waddrmgr")
+	wtxmgrNamespaceKey       = []byte("wtxmgr")
+	auditNamespaceKey        = []byte("auditlog")
+	addrMetaNamespaceKey     = []byte("addrmeta")
+	frozenNamespaceKey       = []byte("frozen")
+	dustNamespaceKey         = []byte("dust")
+	spendAuthNamespaceKey    = []byte("spendauth")
+	invoiceIssueNamespaceKey = []byte("invoiceissue")
+	psbtQueueNamespaceKey    = []byte("psbtqueue")
+	journalNamespaceKey      = []byte("ntfnjournal")
+	portfolioNamespaceKey    = []byte("portfolio")
+	timeLockNamespaceKey     = []byte("timelocks")
+	idempotencyNamespaceKey  = []byte("idempotency")
+	posBundleNamespaceKey    = []byte("paymentbundles")
 )
 
 // Wallet is a structure containing all the components for a complete wallet. It contains the Armory-style key store
 // addresses and keys),
+//
+// Lock ordering: when more than one of the following is held at once they must be acquired in this order, and released
+// in the reverse order:
+//
+//  1. quitMu
+//  2. chainClientLock
+//  3. a walletdb transaction (read transactions may run concurrently, write transactions are serialized by the db)
+//  4. the waddrmgr Manager and ScopedKeyManager mutexes (taken internally by the address manager)
+//  5. lockedOutpointsMtx, frozenOutpointsMtx
+//
+// chainClientSyncMtx, rescanState.mtx, backups.mtx and journal.mtx are leaves and are never held while acquiring any other lock.
+// Calls to the chain server must not be made while a walletdb write transaction is open, so that slow RPC round trips
+// do not stall concurrent readers such as balance, history and address queries.
 type Wallet struct {
 	publicPassphrase []byte
 	// Data stores
@@ -68,11 +95,19 @@ type Wallet struct {
 	Manager            *waddrmgr.Manager
 	TxStore            *wtxmgr.Store
 	chainClient        chainclient.Interface
-	chainClientLock    sync.Mutex
+	chainClientLock    sync.RWMutex
 	chainClientSynced  bool
-	chainClientSyncMtx sync.Mutex
+	chainClientSyncMtx sync.RWMutex
 	lockedOutpoints    map[wire.OutPoint]struct{}
-	recoveryWindow     uint32
+	lockedOutpointsMtx sync.RWMutex
+	// frozenOutpoints is the set of outputs frozen in the wallet database, which unlike the locked outpoints survive
+	// restarts and are not cleared by unlocking all outputs.
+	frozenOutpoints    map[wire.OutPoint]frozenRecord
+	frozenOutpointsMtx sync.RWMutex
+	// dust keeps the tiny outputs recently received, to detect dusting attacks.
+	dust           dustDetector
+	recoveryWindow uint32
+	discovery      discoveryState
 	// Channels for rescan processing. Requests are added and merged with any waiting requests, before being sent to
 	// another goroutine to call the rescan RPC.
 	rescanAddJob        chan *RescanJob
@@ -80,21 +115,34 @@ type Wallet struct {
 	rescanNotifications chan interface{} // From chain server
 	rescanProgress      chan *RescanProgressMsg
 	rescanFinished      chan *RescanFinishedMsg
+	// rescanState is the state of the running or last rescan, which is reported by getrescaninfo.
+	rescanState rescanState
+	// backups is the key the wallet is backed up to the remote backup targets with, and the outcome of the backups.
+	backups backupState
+	// scrub is the position and findings of the scrubbing of the wallet database, which are reported by getscrubinfo.
+	scrub scrubState
 	// Channel for transaction creation requests.
 	createTxRequests chan createTxRequest
+	// idempotencyMtx serializes the sends made with idempotency keys, so the retries of a send wait for it.
+	idempotencyMtx sync.Mutex
 	// Channels for the manager locker.
 	unlockRequests     chan unlockRequest
 	lockRequests       qu.C
 	holdUnlockRequests chan chan heldUnlock
 	lockState          chan bool
+	unlockGuard        unlockGuard
+	spendAuthGuard     spendAuthGuard
 	changePassphrase   chan changePassphraseRequest
 	changePassphrases  chan changePassphrasesRequest
 	// Information for reorganization handling.
 	// reorganizingLock sync.Mutex
 	// reorganizeToHash chainhash.Hash
 	// reorganizing     bool
-	NtfnServer  *NotificationServer
+	NtfnServer *NotificationServer
+	// journal wakes the clients of the notification journal when events are added to it.
+	journal     journalWaker
 	PodConfig   *config.Config
+	signer      *RemoteSigner
 	chainParams *chaincfg.Params
 	wg          sync.WaitGroup
 	started     bool
@@ -125,9 +173,11 @@ func (w *Wallet) Start() {
 	}
 	w.quitMu.Unlock()
 	T.Ln("wallet quit mutex unlocked")
-	w.wg.Add(2)
+	w.wg.Add(4)
 	go w.txCreator()
 	go w.walletLocker()
+	go w.backupScheduler()
+	go w.scrubber()
 }
 
 // SynchronizeRPC associates the wallet with the consensus RPC client, synchronizes the wallet with the latest changes
@@ -179,9 +229,9 @@ func (w *Wallet) SynchronizeRPC(chainClient chainclient.Interface) {
 // the wallet.
 func (w *Wallet) requireChainClient() (chainclient.Interface, error) {
 	T.Ln("requireChainClient")
-	w.chainClientLock.Lock()
+	w.chainClientLock.RLock()
 	chainClient := w.chainClient
-	w.chainClientLock.Unlock()
+	w.chainClientLock.RUnlock()
 	if chainClient == nil {
 		T.Ln("chain client is nil")
 		return nil, errors.New("wallet->chain RPC is inactive")
@@ -194,10 +244,10 @@ func (w *Wallet) requireChainClient() (chainclient.Interface, error) {
 // This function is unstable and will be removed once sync logic is moved out of the wallet.
 func (w *Wallet) ChainClient() chainclient.Interface {
 	T.Ln("wallet acquiring connect to chain RPC")
-	w.chainClientLock.Lock()
+	w.chainClientLock.RLock()
 	T.Ln("chainClientLock locked", w.chainClient == nil)
 	chainClient := w.chainClient
-	w.chainClientLock.Unlock()
+	w.chainClientLock.RUnlock()
 	T.Ln("chainClientLock unlocked")
 	return chainClient
 }
@@ -242,14 +292,14 @@ func (w *Wallet) ShuttingDown() bool {
 // WaitForShutdown blocks until all wallet goroutines have finished executing.
 func (w *Wallet) WaitForShutdown() {
 	T.Ln("waiting for shutdown")
-	w.chainClientLock.Lock()
+	w.chainClientLock.RLock()
 	T.Ln("locked", w.chainClient)
 	if w.chainClient != nil {
 		T.Ln("calling WaitForShutdown")
 		w.chainClient.WaitForShutdown()
 	}
 	T.Ln("unlocking")
-	w.chainClientLock.Unlock()
+	w.chainClientLock.RUnlock()
 	// T.Ln("waiting on waitgroup")
 	// w.wg.Wait()
 }
@@ -258,18 +308,18 @@ func (w *Wallet) WaitForShutdown() {
 func (w *Wallet) SynchronizingToNetwork() bool {
 	// At the moment, RPC is the only synchronization method. In the future, when SPV is added, a separate check will
 	// also be needed, or SPV could always be enabled if RPC was not explicitly specified when creating the wallet.
-	w.chainClientSyncMtx.Lock()
+	w.chainClientLock.RLock()
 	syncing := w.chainClient != nil
-	w.chainClientSyncMtx.Unlock()
+	w.chainClientLock.RUnlock()
 	return syncing
 }
 
 // ChainSynced returns whether the wallet has been attached to a chain server and synced up to the best block on the
 // main chain.
 func (w *Wallet) ChainSynced() bool {
-	w.chainClientSyncMtx.Lock()
+	w.chainClientSyncMtx.RLock()
 	synced := w.chainClientSynced
-	w.chainClientSyncMtx.Unlock()
+	w.chainClientSyncMtx.RUnlock()
 	return synced
 }
 
@@ -335,6 +385,9 @@ func (w *Wallet) syncWithChain() (e error) {
 	isInitialSync := len(unspent) == 0
 	isRecovery := w.recoveryWindow > 0
 	birthday := w.Manager.Birthday()
+	// The wallet is being restored if it has not yet synced past its birthday, in which case accounts other than the
+	// default ones are looked for once the chain has been scanned.
+	isRestore := !w.Manager.SyncedTo().Timestamp.After(birthday)
 	// If an initial sync is attempted, we will try and find the block stamp of the first block past our birthday. This
 	// will be fed into the rescan to ensure we catch transactions that are sent while performing the initial sync.
 	var birthdayStamp *waddrmgr.BlockStamp
@@ -544,6 +597,11 @@ func (w *Wallet) syncWithChain() (e error) {
 			return e
 		}
 		I.Ln("done catching up block hashes")
+		if isRecovery && isRestore && birthdayStamp != nil {
+			if _, e = w.discoverAccounts(chainClient, birthdayStamp.Height, w.Manager.SyncedTo().Height); E.Chk(e) {
+				return e
+			}
+		}
 		// Since we've spent some time catching up block hashes, we might have new addresses waiting for us that were
 		// requested during initial sync. Make sure we have those before we request a rescan later on.
 		e = walletdb.View(
@@ -617,6 +675,9 @@ func (w *Wallet) syncWithChain() (e error) {
 	if e != nil {
 		return e
 	}
+	if e = w.syncWatchedScripts(chainClient); E.Chk(e) {
+		return e
+	}
 	return w.rescanWithTarget(addrs, unspent, birthdayStamp)
 }
 
@@ -979,7 +1040,24 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB amt.Amount
-		resp        chan createTxResponse
+		// fee is the absolute fee the transaction pays when it is not zero, in place of the fee at feeSatPerKB.
+		fee amt.Amount
+		// sweep requests a transaction spending all the eligible outputs of the account to the script of the only
+		// output, which leaves reserve in the account as change.
+		sweep   bool
+		reserve amt.Amount
+		// vault requests a transaction spending the unlocked outputs of the named vault account to the script of the
+		// only output.
+		vault string
+		// timeLocked requests a transaction spending the unlocked outputs of the time locked scripts of the wallet to
+		// the script of the only output.
+		timeLocked bool
+		// transfer requests a transaction paying the only output from the account, with the fee paid from feeAccount
+		// when sponsored is true.
+		transfer   bool
+		sponsored  bool
+		feeAccount uint32
+		resp       chan createTxResponse
 	}
 	createTxResponse struct {
 		tx *txauthor.AuthoredTx
@@ -1000,18 +1078,39 @@ out:
 		select {
 		case txr := <-w.createTxRequests:
 			var e error
+			// A watching-only wallet has no private keys to keep unlocked, and its transactions are signed elsewhere.
 			var h heldUnlock
-			h, e = w.holdUnlock()
-			if e != nil {
-				txr.resp <- createTxResponse{nil, e}
-				continue
+			if !w.Manager.WatchOnly() {
+				if h, e = w.holdUnlock(); e != nil {
+					txr.resp <- createTxResponse{nil, e}
+					continue
+				}
 			}
 			var tx *txauthor.AuthoredTx
-			tx, e = w.txToOutputs(
-				txr.outputs, txr.account,
-				txr.minconf, txr.feeSatPerKB,
-			)
-			h.release()
+			switch {
+			case txr.vault != "":
+				tx, e = w.txWithdrawVault(txr.outputs[0].PkScript, txr.vault, txr.feeSatPerKB)
+			case txr.timeLocked:
+				tx, e = w.txSweepTimeLocked(txr.outputs[0].PkScript, txr.feeSatPerKB)
+			case txr.transfer:
+				tx, e = w.txTransfer(
+					txr.outputs[0], txr.account, txr.sponsored, txr.feeAccount,
+					txr.minconf, txr.feeSatPerKB,
+				)
+			case txr.sweep:
+				tx, e = w.txSweepAccount(
+					txr.outputs[0].PkScript, txr.account,
+					txr.minconf, txr.reserve, txr.feeSatPerKB,
+				)
+			default:
+				tx, e = w.txToOutputs(
+					txr.outputs, txr.account,
+					txr.minconf, txr.feeSatPerKB, txr.fee,
+				)
+			}
+			if h != nil {
+				h.release()
+			}
 			txr.resp <- createTxResponse{tx, e}
 		case <-quit.Wait():
 			break out
@@ -1020,19 +1119,30 @@ out:
 	w.wg.Done()
 }
 
-// CreateSimpleTx creates a new signed transaction spending unspent P2PKH outputs with at least minconf confirmations
-// spending to any number of address/amount pairs. Change and an appropriate transaction fee are automatically included,
+// CreateSimpleTx creates a new signed transaction spending unspent P2PKH outputs with at least minconf confirmations,
+// or the confirmations of the wallet confirmation policy for MinConfPolicy, spending to any number of address/amount
+// pairs. Change and an appropriate transaction fee are automatically included,
 // if necessary. All transaction creation through this function is serialized to prevent the creation of many
-// transactions which spend the same outputs.
+// transactions which spend the same outputs. The transaction of a watching-only wallet with no remote signer is
+// returned unsigned.
 func (w *Wallet) CreateSimpleTx(
 	account uint32, outputs []*wire.TxOut,
 	minconf int32, satPerKb amt.Amount,
+) (*txauthor.AuthoredTx, error) {
+	return w.createSimpleTx(account, outputs, minconf, satPerKb, 0)
+}
+
+// createSimpleTx creates a transaction like CreateSimpleTx, paying exactly fee when it is not zero.
+func (w *Wallet) createSimpleTx(
+	account uint32, outputs []*wire.TxOut,
+	minconf int32, satPerKb, fee amt.Amount,
 ) (*txauthor.AuthoredTx, error) {
 	req := createTxRequest{
 		account:     account,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		fee:         fee,
 		resp:        make(chan createTxResponse),
 	}
 	w.createTxRequests <- req
@@ -1064,7 +1174,14 @@ type (
 
 // walletLocker manages the locked/unlocked state of a wallet.
 func (w *Wallet) walletLocker() {
-	var timeout <-chan time.Time
+	// timeout is the lock timeout the wallet was unlocked with, and idle expires when the wallet was not used for the
+	// idle lock time, if it is set.
+	var timeout, idle <-chan time.Time
+	resetIdle := func() {
+		if d := w.idleLockAfter(); d > 0 {
+			idle = time.After(d)
+		}
+	}
 	holdChan := make(heldUnlock)
 	quit := w.quitChan()
 	// this flips to false once the first unlock has been done, for runasservice opt which shuts down on lock
@@ -1074,6 +1191,12 @@ out:
 	for {
 		select {
 		case req := <-w.unlockRequests:
+			now := time.Now()
+			if wait := w.unlockGuard.throttled(now); wait > 0 {
+				w.unlockGuard.record(now, false, false, "throttled after incorrect passphrases")
+				req.err <- errUnlockThrottled(wait)
+				continue
+			}
 			e = walletdb.View(
 				w.db, func(tx walletdb.ReadTx) (e error) {
 					addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
@@ -1081,16 +1204,26 @@ out:
 				},
 			)
 			if e != nil {
+				wrongPassphrase := waddrmgr.IsError(e, waddrmgr.ErrWrongPassphrase)
+				reason := e.Error()
+				if wrongPassphrase {
+					reason = "incorrect passphrase"
+				}
+				w.unlockGuard.record(now, false, wrongPassphrase, reason)
 				req.err <- e
 				continue
 			}
+			w.unlockGuard.record(now, true, false, "unlocked")
 			timeout = req.lockAfter
+			resetIdle()
 			if timeout == nil {
 				I.Ln("the wallet has been unlocked without a time limit")
 			} else {
 				I.Ln("the wallet has been temporarily unlocked")
 			}
 			req.err <- nil
+			go w.resumeAccountDiscovery()
+			go w.deriveBackupKey()
 			continue
 		case req := <-w.changePassphrase:
 			e = walletdb.Update(
@@ -1130,6 +1263,7 @@ out:
 			}
 			req <- holdChan
 			<-holdChan // Block until the lock is released.
+			resetIdle()
 			// If, after holding onto the unlocked wallet for some time, the timeout has expired, lock it now instead of
 			// hoping it gets unlocked next time the top level select runs.
 			select {
@@ -1146,9 +1280,11 @@ out:
 			// first = false
 		case <-timeout:
 			// first = false
+		case <-idle:
+			I.Ln("locking the wallet after it was not used for", w.idleLockAfter())
 		}
 		// Select statement fell through by an explicit lock or the timer expiring. Lock the manager here.
-		timeout = nil
+		timeout, idle = nil, nil
 		e = w.Manager.Lock()
 		if e != nil && !waddrmgr.IsError(e, waddrmgr.ErrLocked) {
 			E.Ln("could not lock wallet:", e)
@@ -1296,16 +1432,26 @@ func (w *Wallet) AccountAddresses(account uint32) (
 //
 // If confirmations is 0, all UTXOs, even those not present in a block (height -1), will be used to get the balance.
 // Otherwise, a UTXO must be in a block. If confirmations is 1 or greater, the balance will be calculated based on how
-// many how many blocks include a UTXO.
+// many how many blocks include a UTXO. MinConfPolicy uses the confirmations the wallet configuration requires for change
+// and received outputs. The balance of archived accounts is left out.
 func (w *Wallet) CalculateBalance(confirms int32) (
 	balance amt.Amount, e error,
 ) {
 	e = walletdb.View(
 		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
 			blk := w.Manager.SyncedTo()
-			balance, e = w.TxStore.Balance(txmgrNs, confirms, blk.Height)
-			return e
+			changeConf, receivedConf := w.minConfs(confirms)
+			if balance, e = w.TxStore.BalanceConfs(txmgrNs, changeConf, receivedConf, blk.Height); e != nil {
+				return e
+			}
+			var archived amt.Amount
+			if archived, e = w.archivedBalance(addrmgrNs, txmgrNs, changeConf, receivedConf, blk.Height); e != nil {
+				return e
+			}
+			balance -= archived
+			return nil
 		},
 	)
 	return balance, e
@@ -1319,7 +1465,8 @@ type Balances struct {
 }
 
 // CalculateAccountBalances sums the amounts of all unspent transaction outputs to the given account of a wallet and
-// returns the balance.
+// returns the balance. Outputs with confirms confirmations are spendable, or for MinConfPolicy, outputs with the
+// confirmations the wallet configuration requires for change and received outputs.
 //
 // This function is much slower than it needs to be since transactions outputs are not indexed by the accounts they
 // credit to, and all unspent transaction outputs must be iterated.
@@ -1333,6 +1480,7 @@ func (w *Wallet) CalculateAccountBalances(
 			// Get current block.  The block height used for calculating
 			// the number of tx confirmations.
 			syncBlock := w.Manager.SyncedTo()
+			changeConf, receivedConf := w.minConfs(confirms)
 			var unspent []wtxmgr.Credit
 			unspent, e = w.TxStore.UnspentOutputs(txmgrNs)
 			if e != nil {
@@ -1340,15 +1488,8 @@ func (w *Wallet) CalculateAccountBalances(
 			}
 			for i := range unspent {
 				output := &unspent[i]
-				var outputAcct uint32
-				var addrs []btcaddr.Address
-				_, addrs, _, e = txscript.ExtractPkScriptAddrs(
-					output.PkScript, w.chainParams,
-				)
-				if e == nil && len(addrs) > 0 {
-					_, outputAcct, e = w.Manager.AddrAccount(addrmgrNs, addrs[0])
-				}
-				if e != nil || outputAcct != account {
+				outputAcct, ok := w.outputAccount(addrmgrNs, output.PkScript)
+				if !ok || outputAcct != account {
 					continue
 				}
 				bals.Total += output.Amount
@@ -1357,7 +1498,7 @@ func (w *Wallet) CalculateAccountBalances(
 					output.Height, syncBlock.Height,
 				) {
 					bals.ImmatureReward += output.Amount
-				} else if confirmed(confirms, output.Height, syncBlock.Height) {
+				} else if creditConfirmed(output, changeConf, receivedConf, syncBlock.Height) {
 					bals.Spendable += output.Amount
 				}
 			}
@@ -1387,6 +1528,9 @@ func (w *Wallet) CurrentAddress(
 	)
 	e = walletdb.Update(
 		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			if e = checkNotSequential(tx, scope, account); E.Chk(e) {
+				return
+			}
 			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
 			maddr, e := manager.LastExternalAddress(addrmgrNs, account)
 			if e != nil {
@@ -1532,6 +1676,21 @@ func (w *Wallet) AccountNumber(
 	return account, e
 }
 
+// AccountXPub returns the extended public key of an account.
+func (w *Wallet) AccountXPub(scope waddrmgr.KeyScope, account uint32) (xpub string, e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(scope); E.Chk(e) {
+		return
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			xpub, e = manager.AccountPubKey(tx.ReadBucket(waddrmgrNamespaceKey), account)
+			return
+		},
+	)
+	return
+}
+
 // AccountName returns the name of an account.
 func (w *Wallet) AccountName(
 	scope waddrmgr.KeyScope, accountNumber uint32,
@@ -1571,6 +1730,22 @@ func (w *Wallet) AccountProperties(
 	return props, e
 }
 
+// AccountPrivKey returns the serialized extended private key of an account. The wallet must be unlocked.
+func (w *Wallet) AccountPrivKey(scope waddrmgr.KeyScope, account uint32) (xprv string, e error) {
+	var manager *waddrmgr.ScopedKeyManager
+	if manager, e = w.Manager.FetchScopedKeyManager(scope); E.Chk(e) {
+		return
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			waddrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			xprv, e = manager.AccountPrivKey(waddrmgrNs, account)
+			return e
+		},
+	)
+	return
+}
+
 // RenameAccount sets the name for an account number to newName.
 func (w *Wallet) RenameAccount(
 	scope waddrmgr.KeyScope, account uint32, newName string,
@@ -1705,20 +1880,12 @@ func listTransactions(
 	generated := blockchain.IsCoinBaseTx(&details.MsgTx)
 	recvCat := RecvCategory(details, syncHeight, net).String()
 	send := len(details.Debits) != 0
-	// Fee can only be determined if every input is a debit.
-	var feeF64 float64
-	if len(details.Debits) == len(details.MsgTx.TxIn) {
-		var debitTotal amt.Amount
-		for _, deb := range details.Debits {
-			debitTotal += deb.Amount
-		}
-		var outputTotal amt.Amount
-		for _, output := range details.MsgTx.TxOut {
-			outputTotal += amt.Amount(output.Value)
-		}
-		// Note: The actual fee is debitTotal - outputTotal. However, this RPC reports negative numbers for fees, so the
-		// inverse is calculated.
-		feeF64 = (outputTotal - debitTotal).ToDUO()
+	// Every entry of a transaction whose fee is known carries it, along with the share of it attributed to the output
+	// of the entry. Both are negative, as the fee is taken from the balance of the wallet.
+	fee, feeKnown := txFee(details)
+	var shares map[uint32]amt.Amount
+	if feeKnown {
+		shares = outputFees(details, fee)
 	}
 outputs:
 	for i, output := range details.MsgTx.TxOut {
@@ -1762,6 +1929,7 @@ outputs:
 			//   Category
 			//   Amount
 			//   Fee
+			//   OutputFee
 			Address:         address,
 			Vout:            uint32(i),
 			Confirmations:   confirmations,
@@ -1780,17 +1948,21 @@ outputs:
 		//
 		// Since credits are not saved for outputs that are not controlled by this wallet, all non-credits from
 		// transactions with debits are grouped under the send category.
+		if feeKnown {
+			feeF64 := (-fee).ToDUO()
+			outputFeeF64 := (-shares[uint32(i)]).ToDUO()
+			result.Fee = &feeF64
+			result.OutputFee = &outputFeeF64
+		}
 		if send || spentCredit {
 			result.Category = "send"
 			result.Amount = -amountF64
-			result.Fee = feeF64
 			results = append(results, result)
 		}
 		if isCredit {
 			result.Account = accountName
 			result.Category = recvCat
 			result.Amount = amountF64
-			result.Fee = 0
 			results = append(results, result)
 		}
 	}
@@ -1873,6 +2045,130 @@ func (w *Wallet) ListTransactions(from, count int) (
 	return
 }
 
+// ErrInvalidCursor is returned when a cursor passed to ListTransactionsPage was not one returned by it.
+var ErrInvalidCursor = errors.New("invalid transaction list cursor")
+
+// formatTxCursor returns the cursor that resumes a transaction listing after the transaction with the given hash at the
+// given height, -1 being the height of unmined transactions.
+func formatTxCursor(height int32, hash *chainhash.Hash) string {
+	return fmt.Sprintf("%d:%v", height, hash)
+}
+
+// parseTxCursor splits a cursor made by formatTxCursor into its height and transaction hash.
+func parseTxCursor(cursor string) (height int32, hash *chainhash.Hash, e error) {
+	parts := strings.SplitN(cursor, ":", 2)
+	if len(parts) != 2 {
+		return 0, nil, ErrInvalidCursor
+	}
+	var h int64
+	if h, e = strconv.ParseInt(parts[0], 10, 32); e != nil || h < -1 {
+		return 0, nil, ErrInvalidCursor
+	}
+	if hash, e = chainhash.NewHashFromStr(parts[1]); e != nil {
+		return 0, nil, ErrInvalidCursor
+	}
+	return int32(h), hash, nil
+}
+
+// matchTxFilter returns true if the listtransactions entry passes the filter.
+func matchTxFilter(result *btcjson.ListTransactionsResult, filter *btcjson.ListTransactionsFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if len(filter.Categories) > 0 {
+		found := false
+		for _, category := range filter.Categories {
+			if category == result.Category {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Label != nil && *filter.Label != result.Account {
+		return false
+	}
+	if filter.StartTime != nil && result.Time < *filter.StartTime {
+		return false
+	}
+	if filter.EndTime != nil && result.Time > *filter.EndTime {
+		return false
+	}
+	if filter.MinAmount != nil && math.Abs(result.Amount) < *filter.MinAmount {
+		return false
+	}
+	return true
+}
+
+// ListTransactionsPage returns the entries of up to count of the most recent transactions that pass the filter, newest
+// first, starting after the transaction the cursor points at, or at the newest transaction if the cursor is empty. The
+// entries of a transaction are never split between pages, so a page may hold a few more than count entries.
+//
+// The returned cursor resumes the listing after the last transaction of the page, and is empty when there are no more
+// transactions. As the cursor names a transaction rather than a position in the list, transactions received while
+// paging do not shift the pages that follow. If the transaction of the cursor is no longer found at its height, such as
+// when it was mined or its block was reorganized out, the listing resumes at the next height down.
+func (w *Wallet) ListTransactionsPage(cursor string, count int, filter *btcjson.ListTransactionsFilter) (
+	page btcjson.ListTransactionsPageResult, e error,
+) {
+	page.Transactions = []btcjson.ListTransactionsResult{}
+	start := int32(-1)
+	var after *chainhash.Hash
+	if cursor != "" {
+		if start, after, e = parseTxCursor(cursor); E.Chk(e) {
+			return
+		}
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncBlock := w.Manager.SyncedTo()
+			rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+				// Iterate over transactions at this height in reverse order, as ListTransactions does. Unmined
+				// transactions come in the order of their hashes, mined transactions in the order they were marked
+				// mined, so the order is the same on every call.
+				i := len(details) - 1
+				if after != nil {
+					// Skip the transactions at the height of the cursor up to and including the one it points at.
+					for ; i >= 0; i-- {
+						if details[i].Hash == *after {
+							break
+						}
+					}
+					after = nil
+					i--
+				}
+				for ; i >= 0; i-- {
+					if len(page.Transactions) >= count {
+						return true, nil
+					}
+					for _, result := range listTransactions(
+						tx, &details[i], w.Manager, syncBlock.Height, w.chainParams,
+					) {
+						if matchTxFilter(&result, filter) {
+							page.Transactions = append(page.Transactions, result)
+						}
+					}
+					page.NextCursor = formatTxCursor(details[i].Block.Height, &details[i].Hash)
+				}
+				return false, nil
+			}
+			page.NextCursor = ""
+			if e = w.TxStore.RangeTransactions(txmgrNs, start, 0, rangeFn); E.Chk(e) {
+				return
+			}
+			if len(page.Transactions) < count {
+				// The listing reached the oldest transaction.
+				page.NextCursor = ""
+			}
+			return
+		},
+	)
+	return
+}
+
 // ListAddressTransactions returns a slice of objects with details about recorded transactions to or from any address
 // belonging to a set. This is intended to be used for listaddresstransactions RPC replies.
 func (w *Wallet) ListAddressTransactions(pkHashes map[string]struct{}) (
@@ -1992,9 +2288,9 @@ func (w *Wallet) GetTransactions(
 	e error,
 ) {
 	var start, end int32 = 0, -1
-	w.chainClientLock.Lock()
+	w.chainClientLock.RLock()
 	chainClient := w.chainClient
-	w.chainClientLock.Unlock()
+	w.chainClientLock.RUnlock()
 	// TODO: Fetching block heights by their hashes is inherently racy because not all block headers are saved but when
 	//  they are for SPV the db can be queried directly without this.
 	var startResp, endResp rpcclient.FutureGetBlockVerboseResult
@@ -2186,10 +2482,11 @@ type AccountBalanceResult struct {
 	AccountNumber  uint32
 	AccountName    string
 	AccountBalance amt.Amount
+	Archived       bool
 }
 
-// AccountBalances returns all accounts in the wallet and their balances. Balances are determined by excluding
-// transactions that have not met requiredConfs confirmations.
+// AccountBalances returns all accounts in the wallet and their balances, including the archived accounts. Balances are
+// determined by excluding transactions that have not met requiredConfs confirmations.
 func (w *Wallet) AccountBalances(
 	scope waddrmgr.KeyScope,
 	requiredConfs int32,
@@ -2209,6 +2506,10 @@ func (w *Wallet) AccountBalances(
 			if e != nil {
 				return e
 			}
+			archived, e := manager.ArchivedAccounts(addrmgrNs)
+			if e != nil {
+				return e
+			}
 			results = make([]AccountBalanceResult, lastAcct+2)
 			for i := range results[:len(results)-1] {
 				var accountName string
@@ -2218,6 +2519,7 @@ func (w *Wallet) AccountBalances(
 				}
 				results[i].AccountNumber = uint32(i)
 				results[i].AccountName = accountName
+				_, results[i].Archived = archived[uint32(i)]
 			}
 			results[len(results)-1].AccountNumber = waddrmgr.ImportedAddrAccount
 			results[len(results)-1].AccountName = waddrmgr.ImportedAddrAccountName
@@ -2265,6 +2567,67 @@ func (w *Wallet) AccountBalances(
 	return results, e
 }
 
+// ListImmature returns the unspent coinbase outputs of the wallet that have not yet reached coinbase maturity, oldest
+// first, along with their total value. Passing "*" as the account name includes the outputs of all accounts.
+func (w *Wallet) ListImmature(accountName string) (res *btcjson.ListImmatureResult, e error) {
+	res = &btcjson.ListImmatureResult{Outputs: []btcjson.ListImmatureOutputResult{}}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncBlock := w.Manager.SyncedTo()
+			maturity := int32(w.chainParams.CoinbaseMaturity)
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(txmgrNs); E.Chk(e) {
+				return e
+			}
+			sort.Sort(creditSlice(unspent))
+			var total amt.Amount
+			for i := range unspent {
+				output := &unspent[i]
+				if !output.FromCoinBase || confirmed(maturity, output.Height, syncBlock.Height) {
+					continue
+				}
+				acctName := "default"
+				var address string
+				var addrs []btcaddr.Address
+				_, addrs, _, e = txscript.ExtractPkScriptAddrs(output.PkScript, w.chainParams)
+				if e == nil && len(addrs) > 0 {
+					address = addrs[0].EncodeAddress()
+					smgr, acct, e := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+					if e == nil {
+						if s, e := smgr.AccountName(addrmgrNs, acct); e == nil {
+							acctName = s
+						}
+					}
+				}
+				if accountName != "*" && acctName != accountName {
+					continue
+				}
+				confs := confirms(output.Height, syncBlock.Height)
+				res.Outputs = append(
+					res.Outputs, btcjson.ListImmatureOutputResult{
+						TxID:            output.OutPoint.Hash.String(),
+						Vout:            output.OutPoint.Index,
+						Address:         address,
+						Account:         acctName,
+						Amount:          output.Amount.ToDUO(),
+						BlockHash:       output.Block.Hash.String(),
+						BlockHeight:     output.Height,
+						Confirmations:   int64(confs),
+						MaturityHeight:  output.Height + maturity - 1,
+						BlocksRemaining: int64(maturity - confs),
+					},
+				)
+				total += output.Amount
+			}
+			res.Total = total.ToDUO()
+			return nil
+		},
+	)
+	return
+}
+
 // creditSlice satisfies the sort.Interface interface to provide sorting transaction credits from oldest to newest.
 // Credits with the same receive time and mined in the same block are not guaranteed to be sorted by the order they
 // appear in the block. Credits from the same transaction are sorted by output index.
@@ -2297,21 +2660,28 @@ func (s creditSlice) Swap(i, j int) {
 
 // ListUnspent returns a slice of objects representing the unspent wallet transactions fitting the given criteria. The
 // confirmations will be more than minconf, less than maxconf and if addresses is populated only the addresses contained
-// within it will be considered. If we know nothing about a transaction an empty array will be returned.
+// within it will be considered. If we know nothing about a transaction an empty array will be returned. Outputs paid
+// to time locked scripts of the wallet are only spendable once their lock can be passed in the next block.
 func (w *Wallet) ListUnspent(
 	minconf, maxconf int32,
 	addresses map[string]struct{},
 ) (results []*btcjson.ListUnspentResult, e error) {
+	syncBlock := w.Manager.SyncedTo()
+	// The outputs paid to time locked scripts are marked spendable once it is known whether their lock has passed.
+	var locked []timeLockedResult
 	e = walletdb.View(
 		w.db, func(tx walletdb.ReadTx) (e error) {
 			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
-			syncBlock := w.Manager.SyncedTo()
 			filter := len(addresses) != 0
 			unspent, e := w.TxStore.UnspentOutputs(txmgrNs)
 			if e != nil {
 				return e
 			}
+			var scripts map[string][]byte
+			if scripts, e = w.timeLockScripts(tx); E.Chk(e) {
+				return e
+			}
 			sort.Sort(sort.Reverse(creditSlice(unspent)))
 			defaultAccountName := "default"
 			results = make([]*btcjson.ListUnspentResult, 0, len(unspent))
@@ -2329,8 +2699,8 @@ func (w *Wallet) ListUnspent(
 						continue
 					}
 				}
-				// Exclude locked outputs from the result set.
-				if w.LockedOutpoint(output.OutPoint) {
+				// Exclude locked and frozen outputs from the result set.
+				if w.LockedOutpoint(output.OutPoint) || w.FrozenOutpoint(output.OutPoint) {
 					continue
 				}
 				// Lookup the associated account for the output. Use the default account name in case there is no associated
@@ -2374,6 +2744,8 @@ func (w *Wallet) ListUnspent(
 				//  associated private key (currently it only checks whether the pubkey exists, since the private key is
 				//  required at the moment).
 				var spendable bool
+				var lock *txscript.TimeLock
+				var redeemScript []byte
 			scSwitch:
 				switch sc {
 				case txscript.PubKeyHashTy:
@@ -2396,6 +2768,10 @@ func (w *Wallet) ListUnspent(
 						return e
 					}
 					spendable = true
+				case txscript.ScriptHashTy:
+					if redeemScript = timeLockScript(scripts, output.PkScript); redeemScript != nil {
+						lock = txscript.ExtractTimeLock(redeemScript)
+					}
 				}
 				result := &btcjson.ListUnspentResult{
 					TxID:          output.OutPoint.Hash.String(),
@@ -2411,12 +2787,24 @@ func (w *Wallet) ListUnspent(
 				if len(addrs) > 0 {
 					result.Address = addrs[0].EncodeAddress()
 				}
+				if lock != nil {
+					result.RedeemScript = hex.EncodeToString(redeemScript)
+					locked = append(locked, timeLockedResult{result, &unspent[i], lock})
+				}
 				results = append(results, result)
 			}
 			return nil
 		},
 	)
-	return results, e
+	if e != nil {
+		return nil, e
+	}
+	w.timeLockStatus(locked, &syncBlock)
+	var watched []*btcjson.ListUnspentResult
+	if watched, e = w.watchedUnspent(minconf, maxconf, addresses); E.Chk(e) {
+		return nil, e
+	}
+	return append(results, watched...), nil
 }
 
 // DumpPrivKeys returns the WIF-encoded private keys for all addresses with private keys in a wallet.
@@ -2557,28 +2945,38 @@ func (w *Wallet) ImportPrivateKey(
 // LockedOutpoint returns whether an outpoint has been marked as locked and should not be used as an input for created
 // transactions.
 func (w *Wallet) LockedOutpoint(op wire.OutPoint) bool {
+	w.lockedOutpointsMtx.RLock()
 	_, locked := w.lockedOutpoints[op]
+	w.lockedOutpointsMtx.RUnlock()
 	return locked
 }
 
 // LockOutpoint marks an outpoint as locked, that is, it should not be used as an input for newly created transactions.
 func (w *Wallet) LockOutpoint(op wire.OutPoint) {
+	w.lockedOutpointsMtx.Lock()
 	w.lockedOutpoints[op] = struct{}{}
+	w.lockedOutpointsMtx.Unlock()
 }
 
 // UnlockOutpoint marks an outpoint as unlocked, that is, it may be used as an input for newly created transactions.
 func (w *Wallet) UnlockOutpoint(op wire.OutPoint) {
+	w.lockedOutpointsMtx.Lock()
 	delete(w.lockedOutpoints, op)
+	w.lockedOutpointsMtx.Unlock()
 }
 
 // ResetLockedOutpoints resets the set of locked outpoints so all may be used as inputs for new transactions.
 func (w *Wallet) ResetLockedOutpoints() {
+	w.lockedOutpointsMtx.Lock()
 	w.lockedOutpoints = map[wire.OutPoint]struct{}{}
+	w.lockedOutpointsMtx.Unlock()
 }
 
 // LockedOutpoints returns a slice of currently locked outpoints. This is intended to be used by marshaling the result
 // as a JSON array for listlockunspent RPC results.
 func (w *Wallet) LockedOutpoints() []btcjson.TransactionInput {
+	w.lockedOutpointsMtx.RLock()
+	defer w.lockedOutpointsMtx.RUnlock()
 	locked := make([]btcjson.TransactionInput, len(w.lockedOutpoints))
 	i := 0
 	for op := range w.lockedOutpoints {
@@ -2671,6 +3069,39 @@ func (w *Wallet) resendUnminedTxs() {
 	}
 }
 
+// handleExpiredTx handles a transaction evicted from the mempool of the chain server because it was not mined in
+// time. Unmined transactions sent by the wallet are broadcast again, so they get another chance to be mined, and a
+// warning is logged for them, as they may need a higher fee or to be abandoned.
+func (w *Wallet) handleExpiredTx(hash *chainhash.Hash) {
+	var details *wtxmgr.TxDetails
+	e := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			details, e = w.TxStore.TxDetails(txmgrNs, hash)
+			return e
+		},
+	)
+	if E.Chk(e) {
+		return
+	}
+	if details == nil || details.Block.Height != -1 {
+		D.Ln("chain server expired transaction not held unmined by the wallet", hash)
+		return
+	}
+	if len(details.Debits) == 0 {
+		D.Ln("transaction paying to the wallet expired from the mempool", hash)
+		return
+	}
+	W.Ln("transaction sent by the wallet expired from the mempool without being mined, broadcasting it again", hash)
+	chainClient, e := w.requireChainClient()
+	if E.Chk(e) {
+		return
+	}
+	if _, e = chainClient.SendRawTransaction(&details.MsgTx, false); E.Chk(e) {
+		W.F("could not broadcast expired transaction %v again, it may need to be abandoned: %v", hash, e)
+	}
+}
+
 // SortedActivePaymentAddresses returns a slice of all active payment addresses in a wallet.
 func (w *Wallet) SortedActivePaymentAddresses() ([]string, error) {
 	var addrStrs []string
@@ -2709,6 +3140,9 @@ func (w *Wallet) NewAddress(
 	}
 	e = walletdb.Update(
 		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			if e = checkNotSequential(tx, scope, account); E.Chk(e) {
+				return
+			}
 			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
 			addr, props, e = w.newAddress(addrmgrNs, account, scope)
 			return e
@@ -2735,6 +3169,9 @@ func (w *Wallet) newAddress(
 	if e != nil {
 		return nil, nil, e
 	}
+	if e = w.checkNotVault(addrmgrNs, account, scope); e != nil {
+		return nil, nil, e
+	}
 	// Get next address from wallet.
 	var addrs []waddrmgr.ManagedAddress
 	if addrs, e = manager.NextExternalAddresses(addrmgrNs, account, 1); E.Chk(e) {
@@ -2751,6 +3188,119 @@ func (w *Wallet) newAddress(
 	return addrs[0].Address(), props, nil
 }
 
+// checkNotVault returns an error if the account is a vault account, which only hands out deposit addresses wrapping
+// its keys in the lock of the account.
+func (w *Wallet) checkNotVault(addrmgrNs walletdb.ReadBucket, account uint32, scope waddrmgr.KeyScope) (e error) {
+	if scope != waddrmgr.KeyScopeBIP0044 {
+		return
+	}
+	var vault bool
+	if vault, e = w.Manager.IsVaultAccount(addrmgrNs, account); E.Chk(e) {
+		return
+	}
+	if vault {
+		return errors.New("the account is a vault account, its addresses are made with getnewvaultaddress")
+	}
+	return
+}
+
+// addressTypeScopes maps the address types the wallet can hand out to the key scope their addresses are derived in.
+var addressTypeScopes = map[string]waddrmgr.KeyScope{
+	chaincfg.AddressTypeLegacy: waddrmgr.KeyScopeBIP0044,
+	chaincfg.AddressTypeBech32: waddrmgr.KeyScopeBIP0084,
+}
+
+// addressTypeSchemas are the address schemas of the key scopes that are not created with the wallet, and are created
+// when an address of their type is first handed out.
+var addressTypeSchemas = map[waddrmgr.KeyScope]waddrmgr.ScopeAddrSchema{
+	waddrmgr.KeyScopeBIP0084: {
+		ExternalAddrType: waddrmgr.WitnessPubKey,
+		InternalAddrType: waddrmgr.WitnessPubKey,
+	},
+}
+
+// AddressTypeEnabled returns whether the wallet may hand out addresses of the named type, which it may when the type is
+// active on the network, and for bech32 addresses, also enabled in the configuration. Bech32 addresses are never handed
+// out where segwit is not active, as the outputs paying to them could be spent by anyone.
+func (w *Wallet) AddressTypeEnabled(addressType string) bool {
+	if !w.chainParams.AddressTypeActive(addressType) {
+		return false
+	}
+	return addressType != chaincfg.AddressTypeBech32 ||
+		w.PodConfig != nil && w.PodConfig.WalletBech32 != nil && w.PodConfig.WalletBech32.True()
+}
+
+// DefaultAddressType returns the address type handed out when none is asked for. The setting stored in the wallet takes
+// precedence over the configuration, which takes precedence over the default of the network.
+func (w *Wallet) DefaultAddressType() (addressType string, e error) {
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addressType = w.Manager.DefaultAddressType(tx.ReadBucket(waddrmgrNamespaceKey))
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	if addressType == "" && w.PodConfig != nil && w.PodConfig.WalletAddressType != nil {
+		addressType = w.PodConfig.WalletAddressType.V()
+	}
+	if addressType == "" && len(w.chainParams.AddressTypes) > 0 {
+		addressType = w.chainParams.AddressTypes[0]
+	}
+	if addressType == "" {
+		addressType = chaincfg.AddressTypeLegacy
+	}
+	return
+}
+
+// SetDefaultAddressType stores the address type handed out when none is asked for in the wallet. An empty address type
+// removes the setting so the configuration applies again.
+func (w *Wallet) SetDefaultAddressType(addressType string) (e error) {
+	if addressType != "" {
+		if _, e = w.AddressTypeScope(addressType); E.Chk(e) {
+			return
+		}
+	}
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			return w.Manager.SetDefaultAddressType(tx.ReadWriteBucket(waddrmgrNamespaceKey), addressType)
+		},
+	)
+}
+
+// AddressTypeScope returns the key scope that addresses of the named type are derived in, using the default address
+// type if the name is empty, and creating the key scope if it does not exist yet, which requires the wallet to be
+// unlocked. An error is returned for types the wallet does not know or that are not enabled.
+func (w *Wallet) AddressTypeScope(addressType string) (scope waddrmgr.KeyScope, e error) {
+	if addressType == "" {
+		if addressType, e = w.DefaultAddressType(); E.Chk(e) {
+			return
+		}
+	}
+	if !w.AddressTypeEnabled(addressType) {
+		return scope, fmt.Errorf("address type '%s' is not active on %s", addressType, w.chainParams.Name)
+	}
+	var ok bool
+	if scope, ok = addressTypeScopes[addressType]; !ok {
+		return scope, fmt.Errorf("address type '%s' is not supported by the wallet", addressType)
+	}
+	if _, e = w.Manager.FetchScopedKeyManager(scope); e == nil {
+		return
+	}
+	schema, ok := addressTypeSchemas[scope]
+	if !ok {
+		return
+	}
+	I.F("creating key scope %s for %s addresses", &scope, addressType)
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			_, e = w.Manager.NewScopedKeyManager(tx.ReadWriteBucket(waddrmgrNamespaceKey), scope, schema)
+			return
+		},
+	)
+	return
+}
+
 // NewChangeAddress returns a new change address for a wallet.
 func (w *Wallet) NewChangeAddress(
 	account uint32,
@@ -2782,21 +3332,73 @@ func (w *Wallet) newChangeAddress(
 	addrmgrNs walletdb.ReadWriteBucket,
 	account uint32,
 ) (btcaddr.Address, error) {
+	manager, e := w.changeAddressManager()
+	if e != nil {
+		return nil, e
+	}
+	// Get next chained change address from wallet for account.
+	addrs, e := manager.NextInternalAddresses(addrmgrNs, account, 1)
+	if e != nil {
+		return nil, e
+	}
+	return addrs[0].Address(), nil
+}
+
+// changeAddressManager returns the scoped key manager that change addresses are derived from.
+func (w *Wallet) changeAddressManager() (*waddrmgr.ScopedKeyManager, error) {
 	// As we're making a change address, we'll fetch the type of manager that is able to make p2wkh output as they're
 	// the most efficient.
 	scopes := w.Manager.ScopesForExternalAddrType(
 		waddrmgr.PubKeyHash,
 	)
-	manager, e := w.Manager.FetchScopedKeyManager(scopes[0])
+	return w.Manager.FetchScopedKeyManager(scopes[0])
+}
+
+// changeLeaseDuration is how long a change address stays reserved for the transaction it was handed to before it may
+// be given to another one, unless it has been used on chain by then.
+const changeLeaseDuration = 10 * time.Minute
+
+// leaseChangeAddress reserves a change address for a transaction under construction, so that concurrently built
+// transactions get distinct change addresses.
+func (w *Wallet) leaseChangeAddress(
+	addrmgrNs walletdb.ReadWriteBucket,
+	account uint32,
+) (btcaddr.Address, error) {
+	manager, e := w.changeAddressManager()
 	if e != nil {
 		return nil, e
 	}
-	// Get next chained change address from wallet for account.
-	addrs, e := manager.NextInternalAddresses(addrmgrNs, account, 1)
+	ma, e := manager.LeaseChangeAddress(addrmgrNs, account, changeLeaseDuration)
 	if e != nil {
 		return nil, e
 	}
-	return addrs[0].Address(), nil
+	return ma.Address(), nil
+}
+
+// releaseChangeAddress gives the change address of a transaction that will not be broadcast back to the pool of
+// leasable change addresses.
+func (w *Wallet) releaseChangeAddress(tx *txauthor.AuthoredTx) {
+	if tx == nil || tx.ChangeIndex < 0 {
+		return
+	}
+	_, addrs, _, e := txscript.ExtractPkScriptAddrs(
+		tx.Tx.TxOut[tx.ChangeIndex].PkScript, w.chainParams,
+	)
+	if E.Chk(e) || len(addrs) == 0 {
+		return
+	}
+	manager, e := w.changeAddressManager()
+	if E.Chk(e) {
+		return
+	}
+	e = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return manager.ReleaseChangeAddress(addrmgrNs, addrs[0])
+		},
+	)
+	if E.Chk(e) {
+	}
 }
 
 // confirmed checks whether a transaction at height txHeight has met minconf confirmations for a blockchain at height
@@ -2949,6 +3551,20 @@ func (w *Wallet) TotalReceivedForAddr(
 func (w *Wallet) SendOutputs(
 	outputs []*wire.TxOut, account uint32,
 	minconf int32, satPerKb amt.Amount,
+) (*chainhash.Hash, error) {
+	return w.SendOutputsFee(outputs, account, minconf, satPerKb, 0)
+}
+
+// SendOutputsFee creates and sends a transaction paying to the outputs like SendOutputs, but paying exactly fee
+// rather than the fee at satPerKb for its size when fee is not zero. The fee rate is then only used to decide which
+// outputs are dust.
+//
+// A watching-only wallet with no remote signer can't sign the transaction, so it is added to the signing queue as a
+// PSBT for an external signer instead of being sent, and the hash returned is the ID of the queued transaction. It is
+// sent once the signed PSBT is submitted with SubmitSignedPSBT.
+func (w *Wallet) SendOutputsFee(
+	outputs []*wire.TxOut, account uint32,
+	minconf int32, satPerKb, fee amt.Amount,
 ) (*chainhash.Hash, error) {
 	// Ensure the outputs to be created adhere to the network's consensus rules.
 	for _, output := range outputs {
@@ -2958,12 +3574,25 @@ func (w *Wallet) SendOutputs(
 	}
 	// Create the transaction and broadcast it to the network. The transaction will be added to the database in order to
 	// ensure that we continue to re-broadcast the transaction upon restarts until it has been confirmed.
-	createdTx, e := w.CreateSimpleTx(account, outputs, minconf, satPerKb)
+	createdTx, e := w.createSimpleTx(account, outputs, minconf, satPerKb, fee)
 	if e != nil {
 		return nil, e
 	}
 	D.S(createdTx)
-	return w.publishTransaction(createdTx.Tx)
+	if w.queuesPSBTs() {
+		var id chainhash.Hash
+		if id, e = w.queuePSBT(createdTx, account); E.Chk(e) {
+			w.releaseChangeAddress(createdTx)
+			return nil, e
+		}
+		return &id, nil
+	}
+	txHash, e := w.publishTransaction(createdTx.Tx)
+	if e != nil {
+		w.releaseChangeAddress(createdTx)
+		return nil, e
+	}
+	return txHash, nil
 }
 
 // SignatureError records the underlying error when validating a transaction input signature.
@@ -3120,6 +3749,71 @@ func (w *Wallet) SignTransaction(
 	return signErrors, e
 }
 
+// SignedSpend returns the amount that signing a transaction with the keys of the wallet sends out of it, which is the
+// value of the inputs paying wallet addresses less the outputs paying back to wallet addresses. Previous output scripts
+// and values are taken from the maps before the transaction store, as SignTransaction does. An input paying a wallet
+// address whose value is not known counts as spending the most that can be sent, so it always needs a code.
+func (w *Wallet) SignedSpend(
+	tx *wire.MsgTx, prevScripts map[wire.OutPoint][]byte, prevValues map[wire.OutPoint]amt.Amount,
+) (amount amt.Amount, e error) {
+	e = walletdb.View(
+		w.db, func(dbtx walletdb.ReadTx) (e error) {
+			addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+			ours := func(pkScript []byte) bool {
+				_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+				if e != nil {
+					return false
+				}
+				for _, addr := range addrs {
+					if _, e = w.Manager.Address(addrmgrNs, addr); e == nil {
+						return true
+					}
+				}
+				return false
+			}
+			for _, txIn := range tx.TxIn {
+				prev := txIn.PreviousOutPoint
+				script, haveScript := prevScripts[prev]
+				value, haveValue := prevValues[prev]
+				if !haveScript || !haveValue {
+					var txDetails *wtxmgr.TxDetails
+					if txDetails, e = w.TxStore.TxDetails(txmgrNs, &prev.Hash); E.Chk(e) {
+						return
+					}
+					if txDetails != nil && prev.Index < uint32(len(txDetails.MsgTx.TxOut)) {
+						out := txDetails.MsgTx.TxOut[prev.Index]
+						if !haveScript {
+							script, haveScript = out.PkScript, true
+						}
+						if !haveValue {
+							value, haveValue = amt.Amount(out.Value), true
+						}
+					}
+				}
+				if !haveScript || !ours(script) {
+					continue
+				}
+				if !haveValue {
+					amount = amt.MaxSatoshi
+					return nil
+				}
+				amount += value
+			}
+			for _, out := range tx.TxOut {
+				if ours(out.PkScript) {
+					amount -= amt.Amount(out.Value)
+				}
+			}
+			return nil
+		},
+	)
+	if amount < 0 {
+		amount = 0
+	}
+	return
+}
+
 // PublishTransaction sends the transaction to the consensus RPC server so it can be propagated to other nodes and
 // eventually mined.
 //
@@ -3153,6 +3847,7 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 	if e != nil {
 		return nil, e
 	}
+	w.journal.notify()
 	txid, e := server.SendRawTransaction(tx, false)
 	switch {
 	case e == nil:
@@ -3184,6 +3879,10 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 					"unable to remove invalid tx: %v", e, dbErr,
 			)
 		}
+		// A rescan that has not yet reached the transaction spending an input leaves it unspent in the wallet.
+		if w.rescanRunning() {
+			return nil, fmt.Errorf("%v (the wallet is rescanning and may not have found yet that an input was spent)", e)
+		}
 		return nil, e
 	default:
 		return nil, e
@@ -3315,13 +4014,22 @@ func Open(
 	if e != nil {
 		return nil, e
 	}
+	var frozen map[wire.OutPoint]frozenRecord
+	if frozen, e = loadFrozenOutpoints(db); E.Chk(e) {
+		return nil, e
+	}
+	var queuedInputs map[wire.OutPoint]struct{}
+	if queuedInputs, e = loadQueuedInputs(db); E.Chk(e) {
+		return nil, e
+	}
 	T.Ln("creating wallet state") // TODO: log balance? last sync height?
 	w := &Wallet{
 		publicPassphrase:    pubPass,
 		db:                  db,
 		Manager:             addrMgr,
 		TxStore:             txMgr,
-		lockedOutpoints:     map[wire.OutPoint]struct{}{},
+		lockedOutpoints:     queuedInputs,
+		frozenOutpoints:     frozen,
 		recoveryWindow:      recoveryWindow,
 		rescanAddJob:        make(chan *RescanJob),
 		rescanBatch:         make(chan *rescanBatch),
@@ -0,0 +1,270 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainclient"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+// watchRescanBatch is the number of blocks sent to the chain server at a time when rescanning for watched scripts.
+const watchRescanBatch = 100
+
+// ImportScriptPubKey adds an output script to the scripts watched by the wallet, which can be any script, including
+// ones that can't be expressed as an address such as HTLCs or bare multisig. Outputs paying to it and their spends are
+// recorded, but are not part of the balance and are never spent by the wallet. The script is watched from the block bs
+// onwards, or the genesis block if bs is nil, and when rescan is true the blocks from there to the best block are
+// searched for it in the background.
+//
+// Scripts are matched by the transaction filter of the chain server, so a websocket RPC chain client is required.
+func (w *Wallet) ImportScriptPubKey(script []byte, label string, bs *waddrmgr.BlockStamp, rescan bool) (e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	rpcClient, ok := chainClient.(*chainclient.RPCClient)
+	if !ok {
+		return errors.New("watching scripts requires the RPC chain client")
+	}
+	if bs == nil {
+		bs = &waddrmgr.BlockStamp{Hash: *w.chainParams.GenesisHash}
+	}
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			// Scripts paying to an address of the wallet are already tracked as credits.
+			if _, addrs, _, e := txscript.ExtractPkScriptAddrs(script, w.chainParams); e == nil && len(addrs) == 1 {
+				if _, e = w.Manager.Address(addrmgrNs, addrs[0]); e == nil {
+					return fmt.Errorf("script pays to wallet address %s", addrs[0].EncodeAddress())
+				}
+			}
+			_, e = w.Manager.ImportScriptPubKey(addrmgrNs, script, label, bs)
+			return e
+		},
+	)
+	if e != nil {
+		return
+	}
+	I.F("watching output script %x from block %d", script, bs.Height)
+	if _, e = w.loadWatchFilter(rpcClient); E.Chk(e) {
+		return
+	}
+	if rescan {
+		go func() {
+			if e := w.rescanWatchedScripts(rpcClient, bs.Height); E.Chk(e) {
+				E.Ln("rescan for watched script failed:", e)
+			}
+		}()
+	}
+	return
+}
+
+// watchData returns the watched scripts of the wallet and the unspent outputs paying to them.
+func (w *Wallet) watchData() (scripts [][]byte, unspent []wtxmgr.WatchedCredit, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			if e = w.Manager.ForEachWatchedScript(
+				addrmgrNs, func(ws *waddrmgr.WatchedScript) error {
+					scripts = append(scripts, ws.Script)
+					return nil
+				},
+			); E.Chk(e) {
+				return
+			}
+			var credits []wtxmgr.WatchedCredit
+			if credits, e = w.TxStore.WatchedCredits(txmgrNs); E.Chk(e) {
+				return
+			}
+			for _, c := range credits {
+				if c.SpentBy == nil {
+					unspent = append(unspent, c)
+				}
+			}
+			return
+		},
+	)
+	return
+}
+
+// loadWatchFilter loads the watched scripts of the wallet and those of its portfolio, and the unspent outputs paying to
+// them, into the transaction filter of the chain server, which then notifies the wallet of the transactions paying to
+// or spending from them. It returns whether there were any scripts to load.
+func (w *Wallet) loadWatchFilter(chainClient *chainclient.RPCClient) (watching bool, e error) {
+	var scripts, portfolio [][]byte
+	var unspent []wtxmgr.WatchedCredit
+	var outPoints []wire.OutPoint
+	if scripts, unspent, e = w.watchData(); E.Chk(e) {
+		return
+	}
+	if portfolio, outPoints, e = w.portfolioFilter(); E.Chk(e) {
+		return
+	}
+	if scripts = append(scripts, portfolio...); len(scripts) == 0 {
+		return
+	}
+	for i := range unspent {
+		outPoints = append(outPoints, unspent[i].OutPoint)
+	}
+	if e = chainClient.LoadTxFilter(true, nil, outPoints); E.Chk(e) {
+		return
+	}
+	return true, chainClient.UpdateTxFilter(false, nil, nil, scripts)
+}
+
+// syncWatchedScripts loads the watched scripts into the transaction filter of the chain server and searches the blocks
+// after the one the wallet is synced to for them.
+func (w *Wallet) syncWatchedScripts(chainClient chainclient.Interface) (e error) {
+	rpcClient, ok := chainClient.(*chainclient.RPCClient)
+	if !ok {
+		return
+	}
+	var watching bool
+	if watching, e = w.loadWatchFilter(rpcClient); E.Chk(e) || !watching {
+		return
+	}
+	return w.rescanWatchedScripts(rpcClient, w.Manager.SyncedTo().Height+1)
+}
+
+// rescanWatchedScripts searches the blocks from height from to the best block for transactions paying to or spending
+// from the watched scripts, using the transaction filter loaded with loadWatchFilter, and records them.
+func (w *Wallet) rescanWatchedScripts(chainClient *chainclient.RPCClient, from int32) (e error) {
+	var best int32
+	if _, best, e = chainClient.GetBestBlock(); E.Chk(e) {
+		return
+	}
+	if from < 0 {
+		from = 0
+	}
+	I.F("rescanning blocks %d to %d for watched scripts", from, best)
+	for start := from; start <= best; start += watchRescanBatch {
+		select {
+		case <-w.quitChan().Wait():
+			return
+		default:
+		}
+		end := start + watchRescanBatch - 1
+		if end > best {
+			end = best
+		}
+		hashes := make([]chainhash.Hash, 0, end-start+1)
+		heights := make(map[chainhash.Hash]int32, end-start+1)
+		for height := start; height <= end; height++ {
+			var hash *chainhash.Hash
+			if hash, e = chainClient.GetBlockHash(int64(height)); E.Chk(e) {
+				return
+			}
+			hashes = append(hashes, *hash)
+			heights[*hash] = height
+		}
+		var blocks []btcjson.RescannedBlock
+		if blocks, e = chainClient.RescanBlocks(hashes); E.Chk(e) {
+			return
+		}
+		for i := range blocks {
+			if e = w.addRescannedBlock(chainClient, &blocks[i], heights); E.Chk(e) {
+				return
+			}
+		}
+	}
+	I.Ln("finished rescanning for watched scripts")
+	return
+}
+
+// addRescannedBlock records the transactions found in a block by rescanWatchedScripts.
+func (w *Wallet) addRescannedBlock(
+	chainClient *chainclient.RPCClient, b *btcjson.RescannedBlock, heights map[chainhash.Hash]int32,
+) (e error) {
+	var hash *chainhash.Hash
+	if hash, e = chainhash.NewHashFromStr(b.Hash); E.Chk(e) {
+		return
+	}
+	height, ok := heights[*hash]
+	if !ok {
+		return fmt.Errorf("rescan returned unrequested block %s", b.Hash)
+	}
+	var header *wire.BlockHeader
+	if header, e = chainClient.GetBlockHeader(hash); E.Chk(e) {
+		return
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: *hash, Height: height},
+		Time:  header.Timestamp,
+	}
+	recs := make([]*wtxmgr.TxRecord, len(b.Transactions))
+	for i, txHex := range b.Transactions {
+		var raw []byte
+		if raw, e = hex.DecodeString(txHex); E.Chk(e) {
+			return
+		}
+		if recs[i], e = wtxmgr.NewTxRecord(raw, header.Timestamp); E.Chk(e) {
+			return
+		}
+	}
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			for _, rec := range recs {
+				if e = w.addRelevantTx(tx, rec, block); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+}
+
+// watchedUnspent returns the listunspent results of the unspent outputs paying to watched scripts, which are never
+// spendable by the wallet.
+func (w *Wallet) watchedUnspent(
+	minconf, maxconf int32, addresses map[string]struct{},
+) (results []*btcjson.ListUnspentResult, e error) {
+	var unspent []wtxmgr.WatchedCredit
+	if _, unspent, e = w.watchData(); E.Chk(e) {
+		return
+	}
+	syncBlock := w.Manager.SyncedTo()
+	for _, c := range unspent {
+		confs := confirms(c.Block.Height, syncBlock.Height)
+		if confs < minconf || confs > maxconf || w.LockedOutpoint(c.OutPoint) ||
+			w.FrozenOutpoint(c.OutPoint) {
+			continue
+		}
+		var addrs []btcaddr.Address
+		_, addrs, _, _ = txscript.ExtractPkScriptAddrs(c.PkScript, w.chainParams)
+		if len(addresses) != 0 && !anyAddress(addrs, addresses) {
+			continue
+		}
+		result := &btcjson.ListUnspentResult{
+			TxID:          c.Hash.String(),
+			Vout:          c.Index,
+			ScriptPubKey:  hex.EncodeToString(c.PkScript),
+			Amount:        c.Amount.ToDUO(),
+			Confirmations: int64(confs),
+		}
+		if len(addrs) > 0 {
+			result.Address = addrs[0].EncodeAddress()
+		}
+		results = append(results, result)
+	}
+	return
+}
+
+// anyAddress returns whether any of addrs is in the set of encoded addresses.
+func anyAddress(addrs []btcaddr.Address, addresses map[string]struct{}) bool {
+	for _, addr := range addrs {
+		if _, ok := addresses[addr.EncodeAddress()]; ok {
+			return true
+		}
+	}
+	return false
+}
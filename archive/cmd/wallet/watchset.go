@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"encoding/hex"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// ExportWatchSet returns the output scripts of the wallet, those of all of its addresses and its watched scripts, and
+// the unspent outputs paying to them as of the block the wallet is synced to. It holds no keys, so it can be handed to
+// a watcher on another machine, which alerts when the funds of the wallet move from there on.
+func (w *Wallet) ExportWatchSet() (set btcjson.WatchSetResult, e error) {
+	set = btcjson.WatchSetResult{
+		Network:   w.ChainParams().Name,
+		Scripts:   []string{},
+		Outpoints: []btcjson.WatchOutpointResult{},
+	}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			synced := w.Manager.SyncedTo()
+			set.Height, set.Hash = synced.Height, synced.Hash.String()
+			if e = w.Manager.ForEachActiveAddress(
+				addrmgrNs, func(addr btcaddr.Address) (e error) {
+					var pkScript []byte
+					if pkScript, e = txscript.PayToAddrScript(addr); E.Chk(e) {
+						return
+					}
+					set.Scripts = append(set.Scripts, hex.EncodeToString(pkScript))
+					return
+				},
+			); E.Chk(e) {
+				return
+			}
+			if e = w.Manager.ForEachWatchedScript(
+				addrmgrNs, func(ws *waddrmgr.WatchedScript) error {
+					set.Scripts = append(set.Scripts, hex.EncodeToString(ws.Script))
+					return nil
+				},
+			); E.Chk(e) {
+				return
+			}
+			unspent, e := w.TxStore.UnspentOutputs(txmgrNs)
+			if E.Chk(e) {
+				return
+			}
+			for _, c := range unspent {
+				set.Outpoints = append(
+					set.Outpoints, btcjson.WatchOutpointResult{
+						TxID:   c.Hash.String(),
+						Vout:   c.Index,
+						Amount: c.Amount.ToDUO(),
+						Script: hex.EncodeToString(c.PkScript),
+					},
+				)
+			}
+			watched, e := w.TxStore.WatchedCredits(txmgrNs)
+			if E.Chk(e) {
+				return
+			}
+			for _, c := range watched {
+				if c.SpentBy != nil {
+					continue
+				}
+				set.Outpoints = append(
+					set.Outpoints, btcjson.WatchOutpointResult{
+						TxID:   c.Hash.String(),
+						Vout:   c.Index,
+						Amount: c.Amount.ToDUO(),
+						Script: hex.EncodeToString(c.PkScript),
+					},
+				)
+			}
+			return
+		},
+	)
+	return
+}
@@ -0,0 +1,9 @@
+package watch
+
+import (
+	"github.com/p9c/log"
+	"github.com/p9c/pod/version"
+)
+
+var subsystem = log.AddLoggerSubsystem(version.PathBase)
+var F, E, W, I, D, T log.LevelPrinter = log.GetLogPrinterSet(subsystem)
@@ -0,0 +1,126 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// Events of the alerts sent when funds move.
+	EventSpend   = "spend"
+	EventReceive = "receive"
+	// notifyTimeout is how long a post to the webhook or a run of the command may take.
+	notifyTimeout = time.Second * 30
+	// notifyAttempts is how many times an alert is sent to a notifier before it is given up on, and notifyRetry how long
+	// to wait between attempts.
+	notifyAttempts = 3
+	notifyRetry    = time.Second * 5
+)
+
+// Alert is sent when a transaction spends outputs of the watched wallet, or pays to its scripts. It is sent once when
+// the transaction is seen in the mempool, and again when it is mined.
+type Alert struct {
+	Event     string   `json:"event"`
+	TxID      string   `json:"txid"`
+	Outpoints []string `json:"outpoints"`
+	Addresses []string `json:"addresses,omitempty"`
+	Amount    float64  `json:"amount"`
+	Confirmed bool     `json:"confirmed"`
+	Height    int32    `json:"height,omitempty"`
+	Time      int64    `json:"time"`
+}
+
+// Notifier delivers alerts to the wallet owner.
+type Notifier interface {
+	Notify(a Alert) error
+}
+
+// WebhookNotifier posts alerts as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier posting to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+// Notify posts the alert to the webhook.
+func (n *WebhookNotifier) Notify(a Alert) (e error) {
+	var body []byte
+	if body, e = js.Marshal(a); e != nil {
+		return
+	}
+	var resp *http.Response
+	if resp, e = n.client.Post(n.url, "application/json", bytes.NewReader(body)); e != nil {
+		return
+	}
+	if e = resp.Body.Close(); e != nil {
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return
+}
+
+// CommandNotifier runs a command with each alert as JSON on its standard input, which is the hook for delivering alerts
+// by email or any other way, such as a script piping it to sendmail.
+type CommandNotifier struct {
+	args []string
+}
+
+// NewCommandNotifier returns a notifier running the command line, which is split into the executable and its arguments
+// at spaces.
+func NewCommandNotifier(command string) (n *CommandNotifier, e error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("the watch command is empty")
+	}
+	return &CommandNotifier{args: args}, nil
+}
+
+// Notify runs the command with the alert, failing if it does not exit successfully within the timeout.
+func (n *CommandNotifier) Notify(a Alert) (e error) {
+	var body []byte
+	if body, e = js.Marshal(a); e != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, n.args[0], n.args[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	var out []byte
+	if out, e = cmd.CombinedOutput(); e != nil {
+		return fmt.Errorf("%v: %s", e, strings.TrimSpace(string(out)))
+	}
+	return
+}
+
+// notify sends the alert to each of the notifiers, retrying those that fail.
+func notify(notifiers []Notifier, a Alert) {
+	W.F("%s of %v in %s, confirmed: %v", a.Event, a.Amount, a.TxID, a.Confirmed)
+	for _, n := range notifiers {
+		var e error
+		for i := 0; i < notifyAttempts; i++ {
+			if i > 0 {
+				time.Sleep(notifyRetry)
+			}
+			if e = n.Notify(a); e == nil {
+				break
+			}
+			W.Ln("failed to send", a.Event, "alert for", a.TxID, "-", e)
+		}
+		if e != nil {
+			E.Ln("giving up on", a.Event, "alert for", a.TxID, "after", notifyAttempts, "attempts")
+		}
+	}
+}
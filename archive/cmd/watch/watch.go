@@ -0,0 +1,430 @@
+// Package watch is a watcher for a wallet, which holds only the output scripts and unspent outputs of the wallet, no
+// keys, and runs apart from it, such as on a VPS, polling a node for transactions spending the funds of the wallet or
+// paying to it, and alerting through a webhook or a command when it finds them. It is an early warning that the wallet
+// is being emptied, if the machine it is on is compromised.
+//
+// The watch set it holds is made by the wallet with its exportwatchset command. Addresses made by the wallet after
+// the watch set is exported are not watched until it is exported again.
+package watch
+
+import (
+	"encoding/hex"
+	js "encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/rpcclient"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/pod/config"
+)
+
+const (
+	// SetFilename is the name of the watch set file in the network data directory, when no other is configured, and
+	// StateFilename the name of the file the state of the watcher is saved to.
+	SetFilename   = "watchset.json"
+	StateFilename = "watchstate.json"
+	// reorgDepth is how many blocks back the watcher keeps the hashes of, and the outputs spent in, to undo the blocks
+	// disconnected by a reorganisation.
+	reorgDepth = 100
+)
+
+// chain is the node the watcher polls.
+type chain interface {
+	GetBlockCount() (int64, error)
+	GetBlockHash(blockHeight int64) (*chainhash.Hash, error)
+	GetBlock(blockHash *chainhash.Hash) (*wire.Block, error)
+	GetRawMempool() ([]*chainhash.Hash, error)
+	GetRawTransaction(txHash *chainhash.Hash) (*util.Tx, error)
+}
+
+// output is an unspent output of the wallet, or one spent in the last blocks.
+type output struct {
+	Script string     `json:"script"`
+	Amount amt.Amount `json:"amount"`
+	// Height is the block the output was mined in, zero for the outputs of the watch set, and Spent the block it was
+	// spent in, zero while it is unspent.
+	Height int32 `json:"height,omitempty"`
+	Spent  int32 `json:"spent,omitempty"`
+}
+
+// state is what the watcher has found since the watch set was made. It is saved after each poll, so the watcher carries
+// on where it was when it is restarted.
+type state struct {
+	// SetHash is the block the watch set was made at, and the state is started over when the watch set is replaced.
+	SetHash string             `json:"sethash"`
+	Height  int32              `json:"height"`
+	Hashes  map[int32]string   `json:"hashes"`
+	Outputs map[string]*output `json:"outputs"`
+}
+
+// Watcher polls a node for transactions spending the outputs of a wallet or paying to its scripts.
+type Watcher struct {
+	chain     chain
+	params    *chaincfg.Params
+	scripts   map[string]struct{}
+	notifiers []Notifier
+	path      string
+	state     *state
+	// mempool is the transactions in the mempool that were already alerted on.
+	mempool map[chainhash.Hash]struct{}
+}
+
+// LoadSet reads a watch set file made with the exportwatchset wallet command.
+func LoadSet(path string) (set *btcjson.WatchSetResult, e error) {
+	var b []byte
+	if b, e = ioutil.ReadFile(path); e != nil {
+		return
+	}
+	set = &btcjson.WatchSetResult{}
+	if e = js.Unmarshal(b, set); e != nil {
+		return nil, fmt.Errorf("watch set %s is invalid: %v", path, e)
+	}
+	return
+}
+
+// NewWatcher creates a watcher for the watch set, which saves its state to the file at path, and carries on from the
+// state saved there if it was saved for the same watch set.
+func NewWatcher(
+	set *btcjson.WatchSetResult, c chain, params *chaincfg.Params, path string, notifiers []Notifier,
+) (w *Watcher, e error) {
+	if set.Network != params.Name {
+		return nil, fmt.Errorf("watch set is for %s, not %s", set.Network, params.Name)
+	}
+	w = &Watcher{
+		chain:     c,
+		params:    params,
+		scripts:   make(map[string]struct{}, len(set.Scripts)),
+		notifiers: notifiers,
+		path:      path,
+		mempool:   make(map[chainhash.Hash]struct{}),
+	}
+	for _, script := range set.Scripts {
+		if _, e = hex.DecodeString(script); e != nil {
+			return nil, fmt.Errorf("watch set script %s is invalid: %v", script, e)
+		}
+		w.scripts[script] = struct{}{}
+	}
+	var b []byte
+	if b, e = ioutil.ReadFile(path); e == nil {
+		st := &state{}
+		if e = js.Unmarshal(b, st); E.Chk(e) {
+			W.Ln("watch state", path, "is invalid, starting over from the watch set")
+		} else if st.SetHash == set.Hash && st.Hashes != nil && st.Outputs != nil {
+			w.state = st
+			return w, nil
+		}
+	} else if !os.IsNotExist(e) {
+		return nil, e
+	}
+	if w.state, e = newState(set); e != nil {
+		return nil, e
+	}
+	return w, nil
+}
+
+// newState returns the state of a watcher that has not yet polled for blocks after the watch set.
+func newState(set *btcjson.WatchSetResult) (st *state, e error) {
+	st = &state{
+		SetHash: set.Hash,
+		Height:  set.Height,
+		Hashes:  map[int32]string{set.Height: set.Hash},
+		Outputs: make(map[string]*output, len(set.Outpoints)),
+	}
+	for _, op := range set.Outpoints {
+		var hash *chainhash.Hash
+		if hash, e = chainhash.NewHashFromStr(op.TxID); e != nil {
+			return nil, fmt.Errorf("watch set outpoint %s:%d is invalid: %v", op.TxID, op.Vout, e)
+		}
+		var amount amt.Amount
+		if amount, e = amt.NewAmount(op.Amount); e != nil {
+			return nil, e
+		}
+		st.Outputs[wire.NewOutPoint(hash, op.Vout).String()] = &output{Script: op.Script, Amount: amount}
+	}
+	return
+}
+
+// Height returns the height of the last block the watcher has polled.
+func (w *Watcher) Height() int32 {
+	return w.state.Height
+}
+
+// Poll goes through the blocks mined since the last poll and the transactions in the mempool, alerting on those moving
+// funds of the wallet, and saves the state of the watcher.
+func (w *Watcher) Poll() (e error) {
+	var best int64
+	if best, e = w.chain.GetBlockCount(); e != nil {
+		return
+	}
+	if e = w.rewind(int32(best)); e != nil {
+		return
+	}
+	for w.state.Height < int32(best) {
+		height := w.state.Height + 1
+		var hash *chainhash.Hash
+		if hash, e = w.chain.GetBlockHash(int64(height)); e != nil {
+			return
+		}
+		var block *wire.Block
+		if block, e = w.chain.GetBlock(hash); e != nil {
+			return
+		}
+		// The chain may have been reorganised since the best block was polled.
+		if prev, ok := w.state.Hashes[w.state.Height]; ok && block.Header.PrevBlock.String() != prev {
+			if e = w.rewind(int32(best)); e != nil {
+				return
+			}
+			continue
+		}
+		for _, tx := range block.Transactions {
+			w.alert(w.scan(tx, height))
+		}
+		w.connected(height, hash)
+	}
+	if e = w.pollMempool(); e != nil {
+		return
+	}
+	return w.save()
+}
+
+// rewind undoes the blocks after the best block of the node, and those no longer in the chain of the node.
+func (w *Watcher) rewind(best int32) (e error) {
+	for {
+		recorded, ok := w.state.Hashes[w.state.Height]
+		if !ok {
+			return
+		}
+		if w.state.Height <= best {
+			var hash *chainhash.Hash
+			if hash, e = w.chain.GetBlockHash(int64(w.state.Height)); e != nil {
+				return
+			}
+			if hash.String() == recorded {
+				return
+			}
+		}
+		W.Ln("block", w.state.Height, recorded, "was disconnected")
+		w.disconnected(w.state.Height)
+	}
+}
+
+// connected records the block as polled, and forgets the blocks and spent outputs too deep to be reorganised.
+func (w *Watcher) connected(height int32, hash *chainhash.Hash) {
+	w.state.Height = height
+	w.state.Hashes[height] = hash.String()
+	for h := range w.state.Hashes {
+		if h <= height-reorgDepth {
+			delete(w.state.Hashes, h)
+		}
+	}
+	for op, o := range w.state.Outputs {
+		if o.Spent != 0 && o.Spent <= height-reorgDepth {
+			delete(w.state.Outputs, op)
+		}
+	}
+}
+
+// disconnected undoes the block, forgetting the outputs mined in it and marking those spent in it unspent.
+func (w *Watcher) disconnected(height int32) {
+	for op, o := range w.state.Outputs {
+		switch {
+		case o.Height == height:
+			delete(w.state.Outputs, op)
+		case o.Spent == height:
+			o.Spent = 0
+		}
+	}
+	delete(w.state.Hashes, height)
+	w.state.Height = height - 1
+}
+
+// pollMempool alerts on the transactions in the mempool that were not alerted on before.
+func (w *Watcher) pollMempool() (e error) {
+	var hashes []*chainhash.Hash
+	if hashes, e = w.chain.GetRawMempool(); e != nil {
+		return
+	}
+	seen := make(map[chainhash.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		if _, ok := w.mempool[*hash]; ok {
+			seen[*hash] = struct{}{}
+			continue
+		}
+		tx, e := w.chain.GetRawTransaction(hash)
+		if e != nil {
+			// The transaction may have left the mempool since it was listed.
+			D.Ln("could not get mempool transaction", hash, e)
+			continue
+		}
+		seen[*hash] = struct{}{}
+		w.alert(w.scan(tx.MsgTx(), 0))
+	}
+	w.mempool = seen
+	return
+}
+
+// scan returns the alerts for the transaction, which is mined at height, or in the mempool if it is zero. The outputs
+// it spends and pays to the wallet are recorded when it is mined.
+func (w *Watcher) scan(tx *wire.MsgTx, height int32) (alerts []Alert) {
+	txid := tx.TxHash()
+	now := time.Now().Unix()
+	spend := Alert{Event: EventSpend, TxID: txid.String(), Confirmed: height > 0, Height: height, Time: now}
+	var spent amt.Amount
+	for _, in := range tx.TxIn {
+		op := in.PreviousOutPoint.String()
+		o, ok := w.state.Outputs[op]
+		if !ok || o.Spent != 0 {
+			continue
+		}
+		spend.Outpoints = append(spend.Outpoints, op)
+		spend.Addresses = w.appendAddress(spend.Addresses, o.Script)
+		spent += o.Amount
+		if height > 0 {
+			o.Spent = height
+		}
+	}
+	if len(spend.Outpoints) > 0 {
+		spend.Amount = spent.ToDUO()
+		alerts = append(alerts, spend)
+	}
+	receive := Alert{Event: EventReceive, TxID: txid.String(), Confirmed: height > 0, Height: height, Time: now}
+	var received amt.Amount
+	for i, out := range tx.TxOut {
+		script := hex.EncodeToString(out.PkScript)
+		if _, ok := w.scripts[script]; !ok {
+			continue
+		}
+		op := wire.NewOutPoint(&txid, uint32(i)).String()
+		if _, ok := w.state.Outputs[op]; ok {
+			// The output was already in the watch set.
+			continue
+		}
+		receive.Outpoints = append(receive.Outpoints, op)
+		receive.Addresses = w.appendAddress(receive.Addresses, script)
+		received += amt.Amount(out.Value)
+		if height > 0 {
+			w.state.Outputs[op] = &output{Script: script, Amount: amt.Amount(out.Value), Height: height}
+		}
+	}
+	if len(receive.Outpoints) > 0 {
+		receive.Amount = received.ToDUO()
+		alerts = append(alerts, receive)
+	}
+	return
+}
+
+// appendAddress appends the addresses the script pays to that are not in addrs already.
+func (w *Watcher) appendAddress(addrs []string, script string) []string {
+	pkScript, e := hex.DecodeString(script)
+	if e != nil {
+		return addrs
+	}
+	_, scriptAddrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.params)
+	if e != nil {
+		return addrs
+	}
+next:
+	for _, a := range scriptAddrs {
+		addr := a.EncodeAddress()
+		for _, have := range addrs {
+			if have == addr {
+				continue next
+			}
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func (w *Watcher) alert(alerts []Alert) {
+	for _, a := range alerts {
+		notify(w.notifiers, a)
+	}
+}
+
+// save writes the state of the watcher to its file, through a temporary file so it is never left half written.
+func (w *Watcher) save() (e error) {
+	var b []byte
+	if b, e = js.MarshalIndent(w.state, "", "  "); e != nil {
+		return
+	}
+	tmp := w.path + ".tmp"
+	if e = ioutil.WriteFile(tmp, b, 0600); e != nil {
+		return
+	}
+	return os.Rename(tmp, w.path)
+}
+
+// Run loads the configured watch set and polls the node at the configured interval until quit is closed.
+func Run(cfg *config.Config, params *chaincfg.Params, quit qu.C) (e error) {
+	dir := filepath.Join(cfg.DataDir.V(), params.Name)
+	setPath := cfg.WatchSet.V()
+	if setPath == "" {
+		setPath = filepath.Join(dir, SetFilename)
+	}
+	var set *btcjson.WatchSetResult
+	if set, e = LoadSet(setPath); E.Chk(e) {
+		return
+	}
+	var notifiers []Notifier
+	if url := cfg.WatchWebhook.V(); url != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(url))
+	}
+	if command := cfg.WatchCommand.V(); command != "" {
+		var n *CommandNotifier
+		if n, e = NewCommandNotifier(command); E.Chk(e) {
+			return
+		}
+		notifiers = append(notifiers, n)
+	}
+	if len(notifiers) == 0 {
+		W.Ln("no watch webhook or command is configured, alerts are only logged")
+	}
+	var client *rpcclient.Client
+	if client, e = rpcclient.New(
+		&rpcclient.ConnConfig{
+			Host:         cfg.RPCConnect.V(),
+			User:         cfg.Username.V(),
+			Pass:         cfg.Password.V(),
+			TLS:          cfg.ClientTLS.True(),
+			Certificates: cfg.ReadCAFile(),
+			Proxy:        cfg.RPCProxyAddress.V(),
+			ProxyType:    cfg.RPCProxyType.V(),
+			ProxyUser:    cfg.RPCProxyUser.V(),
+			ProxyPass:    cfg.RPCProxyPass.V(),
+			HTTPPostMode: true,
+			Params:       params,
+		}, nil, quit,
+	); E.Chk(e) {
+		return
+	}
+	defer client.Shutdown()
+	var w *Watcher
+	if w, e = NewWatcher(set, client, params, filepath.Join(dir, StateFilename), notifiers); E.Chk(e) {
+		return
+	}
+	I.Ln("watching", len(set.Scripts), "scripts of the wallet from block", w.Height(), "on", cfg.RPCConnect.V())
+	t := time.NewTicker(cfg.WatchInterval.V())
+	defer t.Stop()
+	for {
+		if e := w.Poll(); e != nil {
+			W.Ln("failed to poll", cfg.RPCConnect.V(), "-", e)
+		}
+		select {
+		case <-t.C:
+		case <-quit.Wait():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package watch
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// testChain is a node with the blocks in the slice, at the height of their index.
+type testChain struct {
+	blocks  []*wire.Block
+	mempool []*wire.MsgTx
+}
+
+func (c *testChain) GetBlockCount() (int64, error) {
+	return int64(len(c.blocks) - 1), nil
+}
+
+func (c *testChain) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	if height < 0 || height >= int64(len(c.blocks)) {
+		return nil, errors.New("block height out of range")
+	}
+	hash := c.blocks[height].BlockHash()
+	return &hash, nil
+}
+
+func (c *testChain) GetBlock(hash *chainhash.Hash) (*wire.Block, error) {
+	for _, b := range c.blocks {
+		if b.BlockHash() == *hash {
+			return b, nil
+		}
+	}
+	return nil, errors.New("block not found")
+}
+
+func (c *testChain) GetRawMempool() (hashes []*chainhash.Hash, e error) {
+	for _, tx := range c.mempool {
+		hash := tx.TxHash()
+		hashes = append(hashes, &hash)
+	}
+	return
+}
+
+func (c *testChain) GetRawTransaction(hash *chainhash.Hash) (*util.Tx, error) {
+	for _, tx := range c.mempool {
+		if tx.TxHash() == *hash {
+			return util.NewTx(tx), nil
+		}
+	}
+	return nil, errors.New("transaction not found")
+}
+
+// mine adds a block with the transactions to the chain, with nonce setting it apart from blocks at the same height.
+func (c *testChain) mine(nonce uint32, txs ...*wire.MsgTx) {
+	b := &wire.Block{Header: wire.BlockHeader{Nonce: nonce}, Transactions: txs}
+	if len(c.blocks) > 0 {
+		b.Header.PrevBlock = c.blocks[len(c.blocks)-1].BlockHash()
+	}
+	c.blocks = append(c.blocks, b)
+}
+
+type testNotifier []Alert
+
+func (n *testNotifier) Notify(a Alert) error {
+	*n = append(*n, a)
+	return nil
+}
+
+// TestWatcher ensures a spend of an output of the watch set and a payment to its scripts are alerted on once from the
+// mempool and again when mined, that outputs paid to the wallet are watched for spends once mined, that blocks
+// disconnected by a reorganisation are undone, and that the state is carried on after a restart.
+func TestWatcher(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	addr, e := btcaddr.NewPubKeyHash(make([]byte, 20), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	c := &testChain{}
+	funding := &wire.MsgTx{TxOut: []*wire.TxOut{{Value: 5e8, PkScript: pkScript}}}
+	c.mine(0, funding)
+	set := &btcjson.WatchSetResult{
+		Network: params.Name,
+		Height:  0,
+		Hash:    c.blocks[0].BlockHash().String(),
+		Scripts: []string{hex.EncodeToString(pkScript)},
+		Outpoints: []btcjson.WatchOutpointResult{
+			{TxID: funding.TxHash().String(), Vout: 0, Amount: 5, Script: hex.EncodeToString(pkScript)},
+		},
+	}
+	dir, e := ioutil.TempDir("", "watch")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, StateFilename)
+	var alerts testNotifier
+	w, e := NewWatcher(set, c, params, path, []Notifier{&alerts})
+	if e != nil {
+		t.Fatal(e)
+	}
+	// A transaction spending the funded output, with change back to the wallet.
+	spend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: funding.TxHash()}}},
+		TxOut: []*wire.TxOut{{Value: 4e8, PkScript: []byte{txscript.OP_TRUE}}, {Value: 0.9e8, PkScript: pkScript}},
+	}
+	c.mempool = []*wire.MsgTx{spend}
+	for i := 0; i < 2; i++ {
+		if e = w.Poll(); e != nil {
+			t.Fatal(e)
+		}
+	}
+	want := []struct {
+		event     string
+		amount    float64
+		confirmed bool
+	}{
+		{EventSpend, 5, false},
+		{EventReceive, 0.9, false},
+		{EventSpend, 5, true},
+		{EventReceive, 0.9, true},
+		{EventSpend, 5, true},
+		{EventReceive, 0.9, true},
+		{EventSpend, 0.9, true},
+	}
+	check := func(n int) {
+		if len(alerts) != n {
+			t.Fatalf("got %d alerts %+v, want %d", len(alerts), alerts, n)
+		}
+		for i, a := range alerts {
+			if a.Event != want[i].event || a.Amount != want[i].amount || a.Confirmed != want[i].confirmed {
+				t.Fatalf("alert %d is %+v, want %+v", i, a, want[i])
+			}
+			if a.TxID == spend.TxHash().String() && (len(a.Addresses) != 1 || a.Addresses[0] != addr.EncodeAddress()) {
+				t.Fatalf("alert %d has addresses %v", i, a.Addresses)
+			}
+		}
+	}
+	check(2)
+	c.mempool = nil
+	c.mine(1, spend)
+	if e = w.Poll(); e != nil {
+		t.Fatal(e)
+	}
+	check(4)
+	// The block is replaced by another mining the spend at a greater height, which is alerted on again.
+	c.blocks = c.blocks[:1]
+	c.mine(2)
+	c.mine(3, spend)
+	if e = w.Poll(); e != nil {
+		t.Fatal(e)
+	}
+	check(6)
+	if w.Height() != 2 {
+		t.Fatalf("watcher is at height %d, want 2", w.Height())
+	}
+	// The change is watched for spends after a restart.
+	if w, e = NewWatcher(set, c, params, path, []Notifier{&alerts}); e != nil {
+		t.Fatal(e)
+	}
+	if w.Height() != 2 {
+		t.Fatalf("restarted watcher is at height %d, want 2", w.Height())
+	}
+	spendChange := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: spend.TxHash(), Index: 1}}},
+		TxOut: []*wire.TxOut{{Value: 0.8e8, PkScript: []byte{txscript.OP_TRUE}}},
+	}
+	c.mine(4, spendChange)
+	if e = w.Poll(); e != nil {
+		t.Fatal(e)
+	}
+	check(7)
+	set.Network = chaincfg.TestNet3Params.Name
+	if _, e = NewWatcher(set, c, params, path, nil); e == nil {
+		t.Fatal("a watch set for another network was accepted")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -600,6 +601,30 @@ func (a *AddrManager) AddressCache() []*wire.NetAddress {
 	return allAddr[0:numAddresses]
 }
 
+// NodeAddresses returns copies of at most count randomly chosen known addresses that are routable and not bad, or all
+// of them if count is zero, for tools that bootstrap from the node. Unless network is empty, only addresses on the
+// network of that name, as returned by Network, are chosen. The timestamp of each address is when it was last seen.
+func (a *AddrManager) NodeAddresses(count int, network string) []wire.NetAddress {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	addrs := make([]wire.NetAddress, 0, len(a.addrIndex))
+	for _, ka := range a.addrIndex {
+		if ka.isBad() || !IsRoutable(ka.na) || (network != "" && Network(ka.na) != network) {
+			continue
+		}
+		addrs = append(addrs, *ka.na)
+	}
+	a.rand.Shuffle(
+		len(addrs), func(i, j int) {
+			addrs[i], addrs[j] = addrs[j], addrs[i]
+		},
+	)
+	if count > 0 && count < len(addrs) {
+		addrs = addrs[:count]
+	}
+	return addrs
+}
+
 // reset resets the address manager by reinitialising the random source and allocating fresh empty bucket storage.
 func (a *AddrManager) reset() {
 	a.addrIndex = make(map[string]*KnownAddress)
@@ -883,6 +908,29 @@ func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPrior
 	return nil
 }
 
+// LocalAddress is a known local address to advertise and the priority it has among them.
+type LocalAddress struct {
+	NetAddress *wire.NetAddress
+	Priority   AddressPriority
+}
+
+// LocalAddresses returns the known local addresses to advertise, sorted by address.
+func (a *AddrManager) LocalAddresses() []LocalAddress {
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	keys := make([]string, 0, len(a.localAddresses))
+	for key := range a.localAddresses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	addrs := make([]LocalAddress, len(keys))
+	for i, key := range keys {
+		la := a.localAddresses[key]
+		addrs[i] = LocalAddress{NetAddress: la.na, Priority: la.score}
+	}
+	return addrs
+}
+
 // getReachabilityFrom returns the relative reachability of the provided local address to the provided remote address.
 func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	const (
@@ -184,6 +184,44 @@ func TestAddLocalAddress(t *testing.T) {
 		}
 	}
 }
+
+// TestLocalAddresses ensures the local addresses are listed in order of address with their priority, an address added
+// twice keeping the higher priority, and that unroutable addresses are left out.
+func TestLocalAddresses(t *testing.T) {
+	adds := []struct {
+		ip       string
+		priority addrmgr.AddressPriority
+	}{
+		{"192.168.0.100", addrmgr.InterfacePrio},
+		{"204.124.1.1", addrmgr.InterfacePrio},
+		{"204.124.1.1", addrmgr.BoundPrio},
+		{"2620:100::1", addrmgr.InterfacePrio},
+	}
+	amgr := addrmgr.New("testlocaladdresses", nil)
+	for _, add := range adds {
+		na := wire.NetAddress{IP: net.ParseIP(add.ip)}
+		_ = amgr.AddLocalAddress(&na, add.priority)
+	}
+	want := []struct {
+		ip       string
+		priority addrmgr.AddressPriority
+	}{
+		{"204.124.1.1", addrmgr.BoundPrio + 1},
+		{"2620:100::1", addrmgr.InterfacePrio},
+	}
+	local := amgr.LocalAddresses()
+	if len(local) != len(want) {
+		t.Fatalf("got %d local addresses, want %d", len(local), len(want))
+	}
+	for i, w := range want {
+		if !local[i].NetAddress.IP.Equal(net.ParseIP(w.ip)) || local[i].Priority != w.priority {
+			t.Errorf(
+				"local address %d: got %s with priority %d, want %s with priority %d",
+				i, local[i].NetAddress.IP, local[i].Priority, w.ip, w.priority,
+			)
+		}
+	}
+}
 func TestAttempt(t *testing.T) {
 	n := addrmgr.New("testattempt", lookupFunc)
 	// Add a new address and get it
@@ -302,6 +340,43 @@ func TestGetAddress(t *testing.T) {
 		t.Errorf("Wrong number of addresses: got %d, want %d", numAddrs, 1)
 	}
 }
+
+// TestNodeAddresses ensures the addresses given out for bootstrapping are limited to the count and network asked for.
+func TestNodeAddresses(t *testing.T) {
+	n := addrmgr.New("testnodeaddresses", lookupFunc)
+	srcAddr := wire.NewNetAddressIPPort(net.IPv4(173, 144, 173, 111), 11047, 0)
+	ips := []string{"173.194.115.66", "74.125.226.14", "8.8.8.8", "2600::1", "fd87:d87e:eb43::1"}
+	for _, ip := range ips {
+		n.AddAddress(wire.NewNetAddressIPPort(net.ParseIP(ip), 11047, wire.SFNodeNetwork), srcAddr)
+	}
+	tests := []struct {
+		count   int
+		network string
+		want    int
+	}{
+		{0, "", 5},
+		{2, "", 2},
+		{0, "ipv4", 3},
+		{1, "ipv4", 1},
+		{0, "ipv6", 1},
+		{0, "onion", 1},
+	}
+	for _, test := range tests {
+		addrs := n.NodeAddresses(test.count, test.network)
+		if len(addrs) != test.want {
+			t.Errorf("NodeAddresses(%d, %q): got %d addresses, want %d", test.count, test.network, len(addrs), test.want)
+		}
+		for i := range addrs {
+			if test.network != "" && addrmgr.Network(&addrs[i]) != test.network {
+				t.Errorf("NodeAddresses(%d, %q): got %v", test.count, test.network, addrs[i].IP)
+			}
+			if addrs[i].Services != wire.SFNodeNetwork {
+				t.Errorf("NodeAddresses(%d, %q): services of %v are %v", test.count, test.network, addrs[i].IP,
+					addrs[i].Services)
+			}
+		}
+	}
+}
 func TestGetBestLocalAddress(t *testing.T) {
 	localAddrs := []wire.NetAddress{
 		{IP: net.ParseIP("192.168.0.100")},
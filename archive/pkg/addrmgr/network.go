@@ -80,6 +80,18 @@ func IsOnionCatTor(na *wire.NetAddress) bool {
 	return onionCatNet.Contains(na.IP)
 }
 
+// Network returns the name of the network the address is on, which is "onion" for a Tor address, "ipv4" or "ipv6".
+func Network(na *wire.NetAddress) string {
+	switch {
+	case IsOnionCatTor(na):
+		return "onion"
+	case IsIPv4(na):
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
 // IsRFC1918 returns whether or not the passed address is part of the IPv4 private network address space as defined by
 // RFC1918 (10.0.0.0/8, 172.16.0.0/12, or 192.168.0.0/16).
 func IsRFC1918(na *wire.NetAddress) bool {
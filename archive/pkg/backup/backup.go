@@ -0,0 +1,227 @@
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+var (
+	// ErrNotFound is returned when there is no backup under a name at a target.
+	ErrNotFound = errors.New("backup not found")
+	// ErrWrongKey is returned when a backup can't be decrypted with the key, because it was made for another seed or
+	// was altered.
+	ErrWrongKey = errors.New("backup was not made with this seed or is damaged")
+)
+
+// Target is a remote place backups are kept.
+type Target interface {
+	// Name returns the location of the target without credentials, as shown to the user.
+	Name() string
+	// Put stores the data under the name, replacing any stored before.
+	Put(name string, data []byte) error
+	// Get returns the data stored under the name, or ErrNotFound.
+	Get(name string) ([]byte, error)
+}
+
+// Opener returns the target a URL of the scheme it is registered under points at.
+type Opener func(u *url.URL) (Target, error)
+
+var (
+	mx      sync.Mutex
+	openers = make(map[string]Opener)
+)
+
+// Register makes targets of the URL scheme available to Open. Registering a scheme twice replaces the first.
+func Register(scheme string, open Opener) {
+	mx.Lock()
+	defer mx.Unlock()
+	openers[scheme] = open
+}
+
+// Schemes returns the URL schemes of the registered targets in order.
+func Schemes() (schemes []string) {
+	mx.Lock()
+	defer mx.Unlock()
+	for scheme := range openers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return
+}
+
+// Open returns the target the URL points at, by the target registered for its scheme.
+func Open(rawURL string) (t Target, e error) {
+	var u *url.URL
+	if u, e = url.Parse(rawURL); E.Chk(e) {
+		return
+	}
+	mx.Lock()
+	open, ok := openers[strings.ToLower(u.Scheme)]
+	mx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown backup target %q, must be one of %v", u.Scheme, Schemes())
+	}
+	return open(u)
+}
+
+// Redact returns the URL of a target with the password or secret removed, so it can be shown or logged.
+func Redact(rawURL string) string {
+	u, e := url.Parse(rawURL)
+	if e != nil {
+		return "invalid backup target"
+	}
+	if u.User != nil {
+		u.User = url.User(u.User.Username())
+	}
+	q := u.Query()
+	for k := range q {
+		if strings.Contains(strings.ToLower(k), "secret") || strings.Contains(strings.ToLower(k), "pass") {
+			q.Set(k, "xxxxx")
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func init() {
+	Register("memory", func(u *url.URL) (Target, error) { return memoryTarget(u.Host), nil })
+}
+
+// Key encrypts and decrypts backups, and names them so that a wallet restored from its seed finds its own backups.
+type Key struct {
+	crypt snacl.CryptoKey
+	// Name is the name backups made with the key are stored under, which reveals nothing about the wallet.
+	Name string
+}
+
+// Labels the encryption key and name of the backups are derived with from the account key, so that neither gives away
+// the other.
+var (
+	cryptLabel = []byte("parallelcoin wallet backup encryption")
+	nameLabel  = []byte("parallelcoin wallet backup name")
+)
+
+// NewKey derives the key of the backups of a wallet from the extended private key of its first BIP0044 account, which
+// the wallet derives from its seed, so the backups are readable by anyone who has the seed and nobody else.
+func NewKey(account *hdkeychain.ExtendedKey) (k *Key, e error) {
+	var p *ec.PrivateKey
+	if p, e = account.ECPrivKey(); E.Chk(e) {
+		return
+	}
+	priv := p.Serialize()
+	defer zero.Bytes(priv)
+	k = &Key{}
+	mac := hmac.New(sha256.New, priv)
+	mac.Write(cryptLabel)
+	copy(k.crypt[:], mac.Sum(nil))
+	mac = hmac.New(sha256.New, priv)
+	mac.Write(nameLabel)
+	k.Name = "pod-wallet-" + hex.EncodeToString(mac.Sum(nil)[:16]) + ".bak"
+	return
+}
+
+// NewKeyFromSeed derives the key of the backups of the wallet created from the seed on the network, as NewKey does from
+// the key of its first account.
+func NewKeyFromSeed(seed []byte, params *chaincfg.Params) (k *Key, e error) {
+	var key *hdkeychain.ExtendedKey
+	if key, e = hdkeychain.NewMaster(seed, params); E.Chk(e) {
+		return
+	}
+	// m/44'/0'/0', the first account of the BIP0044 scope of the wallet.
+	for _, i := range []uint32{44, 0, 0} {
+		if key, e = key.Child(i + hdkeychain.HardenedKeyStart); E.Chk(e) {
+			return
+		}
+	}
+	defer key.Zero()
+	return NewKey(key)
+}
+
+// Seal encrypts a backup.
+func (k *Key) Seal(data []byte) ([]byte, error) {
+	return k.crypt.Encrypt(data)
+}
+
+// Unseal decrypts a backup sealed with the key.
+func (k *Key) Unseal(data []byte) (opened []byte, e error) {
+	if opened, e = k.crypt.Decrypt(data); e != nil {
+		return nil, ErrWrongKey
+	}
+	return
+}
+
+// Zero clears the key. The key is no longer usable after this call.
+func (k *Key) Zero() {
+	k.crypt.Zero()
+}
+
+// Upload seals the backup and stores it at the target under the name of the key.
+func Upload(t Target, k *Key, data []byte) (e error) {
+	var sealed []byte
+	if sealed, e = k.Seal(data); E.Chk(e) {
+		return
+	}
+	if e = t.Put(k.Name, sealed); E.Chk(e) {
+		return fmt.Errorf("%s: %v", t.Name(), e)
+	}
+	return
+}
+
+// Download fetches the backup stored at the target under the name of the key and unseals it.
+func Download(t Target, k *Key) (data []byte, e error) {
+	var sealed []byte
+	if sealed, e = t.Get(k.Name); e != nil {
+		if e == ErrNotFound {
+			return nil, e
+		}
+		E.Ln(e)
+		return nil, fmt.Errorf("%s: %v", t.Name(), e)
+	}
+	return k.Unseal(sealed)
+}
+
+// memoryTarget keeps backups in memory under the host of the URL, for tests, so that targets opened with the same URL
+// share them.
+type memoryTarget string
+
+var (
+	memoryMx      sync.Mutex
+	memoryBackups = make(map[string][]byte)
+)
+
+// Name returns the URL of the target.
+func (m memoryTarget) Name() string {
+	return "memory://" + string(m)
+}
+
+// Put stores a copy of the data under the name.
+func (m memoryTarget) Put(name string, data []byte) error {
+	memoryMx.Lock()
+	defer memoryMx.Unlock()
+	memoryBackups[string(m)+"/"+name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Get returns a copy of the data stored under the name.
+func (m memoryTarget) Get(name string) ([]byte, error) {
+	memoryMx.Lock()
+	defer memoryMx.Unlock()
+	data, ok := memoryBackups[string(m)+"/"+name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
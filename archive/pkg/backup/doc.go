@@ -0,0 +1,13 @@
+/*
+Package backup keeps encrypted backups of a wallet at remote targets, so that the wallet survives the loss of the
+computer it is on.
+
+A backup is encrypted before it leaves the computer with a key derived from the seed of the wallet, and stored under a
+name also derived from the seed, so that the targets learn nothing about the wallet and a wallet restored from its seed
+finds and opens its own backups without anything else to remember but where they are kept.
+
+Targets are given by URLs, and are reached through the Target interface of the target registered for the scheme of the
+URL. Targets for storage compatible with Amazon S3, WebDAV servers and SSH servers are registered when the package is
+loaded, and other targets can be added with Register.
+*/
+package backup
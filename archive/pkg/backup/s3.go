@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("s3", func(u *url.URL) (Target, error) { return newS3(u, "https") })
+	Register("s3+http", func(u *url.URL) (Target, error) { return newS3(u, "http") })
+}
+
+// s3 keeps backups in a bucket of a storage service compatible with Amazon S3, such as MinIO, Wasabi or Backblaze B2. A
+// URL s3://accesskey:secretkey@host/bucket/prefix?region=us-east-1 stores them in the bucket under the optional prefix
+// over HTTPS, and s3+http:// over plain HTTP, which should only be used on a trusted network. The region defaults to
+// us-east-1, which most compatible services accept whatever region they are in.
+type s3 struct {
+	endpoint  url.URL
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func newS3(u *url.URL, scheme string) (t Target, e error) {
+	if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("s3 backup target %q must have a host and a bucket", Redact(u.String()))
+	}
+	if u.User == nil {
+		return nil, fmt.Errorf("s3 backup target %q has no access key", Redact(u.String()))
+	}
+	s := &s3{
+		endpoint:  url.URL{Scheme: scheme, Host: u.Host, Path: "/" + strings.Trim(u.Path, "/") + "/"},
+		region:    u.Query().Get("region"),
+		accessKey: u.User.Username(),
+		client:    &http.Client{Timeout: requestTimeout},
+		now:       time.Now,
+	}
+	s.secretKey, _ = u.User.Password()
+	if s.region == "" {
+		s.region = "us-east-1"
+	}
+	return s, nil
+}
+
+// Name returns the URL of the bucket and prefix.
+func (s *s3) Name() string {
+	return s.endpoint.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sign adds the headers authenticating the request with AWS signature version 4, for the payload with the given
+// SHA-256 hash.
+func (s *s3) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join(
+		[]string{
+			req.Method,
+			req.URL.EscapedPath(),
+			req.URL.RawQuery,
+			"host:" + req.URL.Host + "\n" +
+				"x-amz-content-sha256:" + payloadHash + "\n" +
+				"x-amz-date:" + amzDate + "\n",
+			signedHeaders,
+			payloadHash,
+		}, "\n",
+	)
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	req.Header.Set(
+		"Authorization", fmt.Sprintf(
+			"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+			s.accessKey, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign)),
+		),
+	)
+}
+
+// do sends a signed request for the named object and returns the response, which must be closed.
+func (s *s3) do(method, name string, body []byte) (res *http.Response, e error) {
+	u := s.endpoint
+	u.Path += name
+	var req *http.Request
+	if req, e = http.NewRequest(method, u.String(), bytes.NewReader(body)); E.Chk(e) {
+		return
+	}
+	payloadHash := sha256.Sum256(body)
+	s.sign(req, hex.EncodeToString(payloadHash[:]))
+	return s.client.Do(req)
+}
+
+// Put uploads the data to the named object.
+func (s *s3) Put(name string, data []byte) (e error) {
+	var res *http.Response
+	if res, e = s.do(http.MethodPut, name, data); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := res.Body.Close(); E.Chk(e) {
+		}
+	}()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("uploading %s failed: %s", name, res.Status)
+	}
+	return
+}
+
+// Get downloads the named object.
+func (s *s3) Get(name string) (data []byte, e error) {
+	var res *http.Response
+	if res, e = s.do(http.MethodGet, name, nil); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := res.Body.Close(); E.Chk(e) {
+		}
+	}()
+	switch res.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	}
+	return nil, fmt.Errorf("downloading %s failed: %s", name, res.Status)
+}
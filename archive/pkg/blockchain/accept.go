@@ -69,6 +69,12 @@ func (b *BlockChain) maybeAcceptBlock(workerNumber uint32, block *block.Block, f
 	}
 	T.Ln("found no blacklisted addresses")
 	var e error
+	// On a signed test network the block must be signed by a block producer.
+	if b.params.SignetChallenge != nil {
+		if e = CheckSignetSolution(block.WireBlock(), b.params.SignetChallenge); E.Chk(e) {
+			return false, e
+		}
+	}
 	if pn != nil {
 		// The block must pass all of the validation rules which depend on the position
 		// of the block within the block chain.
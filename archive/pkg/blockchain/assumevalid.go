@@ -0,0 +1,113 @@
+package blockchain
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// The scripts of the blocks buried under the assumed valid block are not verified when they are connected, which is
+// most of the time spent on the initial block download. Everything else about the blocks is verified as usual, the
+// proof of work, the merkle root, that every input spends an unspent output and that no block creates more coins than
+// it may, so only the signatures of the spends are taken on trust, as whoever chose the assumed valid block has
+// verified them.
+//
+// A block is only assumed valid if it is an ancestor of the assumed valid block. That is known from the block index
+// once the assumed valid block has been connected, but during the initial block download the blocks are connected
+// before it, so the chain of headers from the best chain to the assumed valid block is fetched from a peer first. Each
+// header commits to the hash of the one before it, so a chain of headers that links up to the assumed valid block can
+// only be its real ancestry, whoever sent it.
+
+// assumeValidPath is the chain of headers from a block of the best chain up to the assumed valid block.
+type assumeValidPath struct {
+	mx sync.Mutex
+	// base is the height of the first hash in hashes, and height the height of the assumed valid block, which is -1
+	// until the headers reach it.
+	base   int32
+	height int32
+	hashes []chainhash.Hash
+}
+
+// AssumeValid returns the hash of the block whose ancestors are assumed to have valid scripts, which is nil when
+// scripts are always verified, and its height, which is -1 while it is not known.
+func (b *BlockChain) AssumeValid() (hash *chainhash.Hash, height int32) {
+	if b.assumeValid == nil {
+		return nil, -1
+	}
+	if node := b.Index.LookupNode(b.assumeValid); node != nil {
+		return b.assumeValid, node.height
+	}
+	b.assumeValidPath.mx.Lock()
+	defer b.assumeValidPath.mx.Unlock()
+	return b.assumeValid, b.assumeValidPath.height
+}
+
+// NeedAssumeValidHeaders returns true when the headers leading to the assumed valid block should be fetched, because
+// it is neither known to the block index nor reached by the headers already added.
+func (b *BlockChain) NeedAssumeValidHeaders() bool {
+	hash, height := b.AssumeValid()
+	return hash != nil && height < 0
+}
+
+// AddAssumeValidHeaders adds a batch of headers to the chain of headers leading to the assumed valid block. The first
+// header must follow either a block of the best chain, which starts the chain of headers over from it, or the last
+// header added. It returns true once the headers have reached the assumed valid block, after which any further
+// headers are ignored.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) AddAssumeValidHeaders(headers []*wire.BlockHeader) (done bool, e error) {
+	if b.assumeValid == nil || len(headers) == 0 {
+		return
+	}
+	p := &b.assumeValidPath
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if p.height >= 0 {
+		return true, nil
+	}
+	prev := &headers[0].PrevBlock
+	if node := b.Index.LookupNode(prev); node != nil && b.BestChain.Contains(node) {
+		p.base, p.hashes = node.height+1, p.hashes[:0]
+	} else if len(p.hashes) == 0 || !p.hashes[len(p.hashes)-1].IsEqual(prev) {
+		return false, fmt.Errorf("header %v does not follow the best chain or the headers added", headers[0].BlockHash())
+	}
+	for i := range headers {
+		if i > 0 && !headers[i].PrevBlock.IsEqual(&p.hashes[len(p.hashes)-1]) {
+			return false, fmt.Errorf("header %v does not follow the header before it", headers[i].BlockHash())
+		}
+		p.hashes = append(p.hashes, headers[i].BlockHash())
+		if p.hashes[len(p.hashes)-1].IsEqual(b.assumeValid) {
+			p.height = p.base + int32(len(p.hashes)) - 1
+			I.F("assuming the scripts of blocks up to height %d are valid", p.height)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isAssumedValid returns true if the node is the assumed valid block or one of its ancestors, so its scripts need not
+// be verified.
+func (b *BlockChain) isAssumedValid(node *BlockNode) bool {
+	if b.assumeValid == nil {
+		return false
+	}
+	if av := b.Index.LookupNode(b.assumeValid); av != nil {
+		return av.Ancestor(node.height) == node
+	}
+	p := &b.assumeValidPath
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if len(p.hashes) == 0 || node.height < p.base || node.height > p.height {
+		return false
+	}
+	if !p.hashes[node.height-p.base].IsEqual(&node.hash) {
+		return false
+	}
+	// The headers are no longer needed once the assumed valid block is connected, as it is then in the block index.
+	if node.height == p.height {
+		p.hashes = nil
+	}
+	return true
+}
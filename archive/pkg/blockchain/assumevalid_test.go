@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestAssumeValid ensures only the blocks on the chain of headers leading to the assumed valid block are assumed
+// valid, and that headers which do not link up are refused.
+func TestAssumeValid(t *testing.T) {
+	// Construct a synthetic block chain whose best chain ends at block 5, with the assumed valid block at 12 and a side
+	// chain of blocks that are not its ancestors.
+	//
+	// 	genesis -> 1 -> ... -> 5 -> 6 -> ... -> 12 -> 13
+	// 	                         \-> 6a -> 7a
+	chain := newFakeChain(&chaincfg.MainNetParams)
+	nodes := chainedNodes(chain.BestChain.Genesis(), 13)
+	side := chainedNodes(nodes[4], 2)
+	for _, node := range nodes[:5] {
+		chain.Index.AddNode(node)
+	}
+	chain.BestChain.SetTip(nodes[4])
+	chain.assumeValid, chain.assumeValidPath.height = &nodes[11].hash, -1
+	headers := func(nodes []*BlockNode) (h []*wire.BlockHeader) {
+		for _, node := range nodes {
+			header := node.Header()
+			h = append(h, &header)
+		}
+		return
+	}
+	if !chain.NeedAssumeValidHeaders() {
+		t.Fatal("the headers leading to an unknown assumed valid block are not needed")
+	}
+	if _, e := chain.AddAssumeValidHeaders(headers(nodes[6:9])); e == nil {
+		t.Fatal("headers not following the best chain were added")
+	}
+	if _, e := chain.AddAssumeValidHeaders(headers([]*BlockNode{nodes[5], nodes[7]})); e == nil {
+		t.Fatal("headers that do not link up were added")
+	}
+	done, e := chain.AddAssumeValidHeaders(headers(nodes[5:8]))
+	if e != nil || done {
+		t.Fatalf("adding the first headers returned %v, %v", done, e)
+	}
+	if chain.isAssumedValid(nodes[5]) {
+		t.Fatal("a block was assumed valid before the headers reached the assumed valid block")
+	}
+	if done, e = chain.AddAssumeValidHeaders(headers(nodes[8:])); e != nil || !done {
+		t.Fatalf("adding the headers up to the assumed valid block returned %v, %v", done, e)
+	}
+	if hash, height := chain.AssumeValid(); !hash.IsEqual(&nodes[11].hash) || height != 12 || chain.NeedAssumeValidHeaders() {
+		t.Fatalf("got assumed valid block %v at height %d", hash, height)
+	}
+	for i, node := range nodes {
+		if got, want := chain.isAssumedValid(node), i >= 5 && i <= 11; got != want {
+			t.Errorf("block %d assumed valid %v, want %v", node.height, got, want)
+		}
+	}
+	for _, node := range side {
+		if chain.isAssumedValid(node) {
+			t.Errorf("side chain block %s was assumed valid", node)
+		}
+	}
+	// Once the assumed valid block is in the block index its ancestors are found from it.
+	for _, node := range nodes[5:] {
+		chain.Index.AddNode(node)
+	}
+	if !chain.isAssumedValid(nodes[2]) || chain.isAssumedValid(nodes[12]) || chain.isAssumedValid(side[0]) {
+		t.Error("the ancestors of the assumed valid block in the block index were not found")
+	}
+}
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"container/list"
 	"fmt"
+	"math/big"
 	block2 "github.com/p9c/pod/pkg/block"
 	"github.com/p9c/pod/pkg/fork"
 	"sync"
@@ -17,9 +18,9 @@ import (
 	"github.com/p9c/pod/pkg/wire"
 )
 
-const // maxOrphanBlocks is the maximum number of orphan blocks that can be
-	// queued.
-	maxOrphanBlocks = 100
+// DefaultMaxOrphanBlocks is the maximum number of orphan blocks that can be
+// queued when Config.MaxOrphanBlocks is not set.
+const DefaultMaxOrphanBlocks = 100
 
 // BlockLocator is used to help locate a specific block. The algorithm for building the block locator is to add the
 // hashes in reverse order until the genesis block is reached. In order to keep the list of locator hashes to a
@@ -38,6 +39,7 @@ type BlockLocator []*chainhash.Hash
 // forever.
 type orphanBlock struct {
 	block      *block2.Block
+	received   time.Time
 	expiration time.Time
 }
 
@@ -87,10 +89,21 @@ type BlockChain struct {
 	checkpointsByHeight map[int32]*chaincfg.Checkpoint
 	db                  database.DB
 	params              *chaincfg.Params
+	scriptFlagSchedule  []ScriptFlagUpgrade
 	timeSource          MedianTimeSource
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	// valTrace is the log of validation traces, which is nil unless validation tracing is enabled. curTrace is the
+	// trace of the block being processed, and is protected by the chain lock.
+	valTrace *valTraceLog
+	curTrace *ValidationTrace
+	// snapshotPath is the file the chain state is snapshotted to every snapshotInterval blocks, and recovered from when
+	// it is found to be corrupt. snapshotting is set while a snapshot is being written.
+	snapshotPath     string
+	snapshotInterval int32
+	snapshotting     atomic.Bool
+	interrupt        <-chan struct{}
 	// The following fields are calculated based upon the provided chain parameters.
 	// They are also set when the instance is created and can't be changed
 	// afterwards, so there is no need to protect them with a separate mutex.
@@ -113,9 +126,15 @@ type BlockChain struct {
 	orphans      map[chainhash.Hash]*orphanBlock
 	prevOrphans  map[chainhash.Hash][]*orphanBlock
 	oldestOrphan *orphanBlock
+	maxOrphans   int
+	orphanStats  OrphanStats
 	// These fields are related to checkpoint handling. They are protected by the chain lock.
 	nextCheckpoint *chaincfg.Checkpoint
 	checkpointNode *BlockNode
+	// assumeValid is the hash of the block whose ancestors are connected without verifying their scripts, nil if
+	// scripts are always verified, and assumeValidPath the chain of headers leading to it, which has its own lock.
+	assumeValid     *chainhash.Hash
+	assumeValidPath assumeValidPath
 	// The state is used as a fairly efficient way to cache information about the
 	// current best chain state that is returned to callers when requested. It
 	// operates on the principle of MVCC such that any time a new block becomes the
@@ -238,10 +257,15 @@ func (b *BlockChain) removeOrphanBlock(orphan *orphanBlock) {
 // also imposes a maximum limit on the number of outstanding orphan blocks and
 // will remove the oldest received orphan block if the limit is exceeded.
 func (b *BlockChain) addOrphanBlock(block *block2.Block) {
-	// Remove expired orphan blocks.
+	// Remove expired orphan blocks. The oldest orphan is found again each time as the one found before may have been
+	// removed since.
+	b.oldestOrphan = nil
 	for _, oBlock := range b.orphans {
 		if time.Now().After(oBlock.expiration) {
 			b.removeOrphanBlock(oBlock)
+			b.orphanLock.Lock()
+			b.orphanStats.Expired++
+			b.orphanLock.Unlock()
 			continue
 		}
 		// Update the oldest orphan block pointer so it can be discarded in case the orphan pool fills up.
@@ -253,10 +277,13 @@ func (b *BlockChain) addOrphanBlock(block *block2.Block) {
 		}
 	}
 	// Limit orphan blocks to prevent memory exhaustion.
-	if len(b.orphans)+1 > maxOrphanBlocks {
+	if len(b.orphans)+1 > b.maxOrphans && b.oldestOrphan != nil {
 		// Remove the oldest orphan to make room for the new one.
 		b.removeOrphanBlock(b.oldestOrphan)
 		b.oldestOrphan = nil
+		b.orphanLock.Lock()
+		b.orphanStats.Evicted++
+		b.orphanLock.Unlock()
 	}
 	// Protect concurrent access. This is intentionally done here instead of near
 	// the top since removeOrphanBlock does its own locking and the range iterator
@@ -264,11 +291,13 @@ func (b *BlockChain) addOrphanBlock(block *block2.Block) {
 	b.orphanLock.Lock()
 	defer b.orphanLock.Unlock()
 	// Insert the block into the orphan map with an expiration time 1 hour from now.
-	expiration := time.Now().Add(time.Hour)
+	now := time.Now()
 	oBlock := &orphanBlock{
 		block:      block,
-		expiration: expiration,
+		received:   now,
+		expiration: now.Add(orphanExpiry),
 	}
+	b.orphanStats.Added++
 	b.orphans[*block.Hash()] = oBlock
 	// Add to previous hash lookup index for faster dependency lookups.
 	prevHash := &block.WireBlock().Header.PrevBlock
@@ -514,6 +543,7 @@ func (b *BlockChain) connectBlock(
 	)
 	// Atomically insert info into the database.
 	T.Ln("inserting block into database")
+	flushStart := time.Now()
 	e = b.db.Update(
 		func(dbTx database.Tx) (e error) {
 			// update best block state.
@@ -554,6 +584,7 @@ func (b *BlockChain) connectBlock(
 			return nil
 		},
 	)
+	b.curTrace.add(phaseUtxoFlush, flushStart)
 	if e != nil {
 		T.Ln("error updating database ", e)
 		return e
@@ -572,6 +603,7 @@ func (b *BlockChain) connectBlock(
 	b.stateLock.Lock()
 	b.stateSnapshot = state
 	b.stateLock.Unlock()
+	b.maybeSnapshot(node.height)
 	//
 	// // TODO: this should not run if the chain is syncing
 	// tN := time.Now()
@@ -822,12 +854,14 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) (e err
 	for e := attachNodes.Front(); e != nil; e = e.Next() {
 		n := e.Value.(*BlockNode)
 		var block *block2.Block
+		fetchStart := time.Now()
 		er := b.db.View(
 			func(dbTx database.Tx) (e error) {
 				block, e = dbFetchBlockByNode(dbTx, n)
 				return e
 			},
 		)
+		b.curTrace.add(phaseDeserialize, fetchStart)
 		if er != nil {
 			return er
 		}
@@ -900,7 +934,9 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) (e err
 		n := e.Value.(*BlockNode)
 		block := attachBlocks[i]
 		// Load all of the utxos referenced by the block that aren't already in the view.
+		fetchStart := time.Now()
 		e := view.fetchInputUtxos(b.db, block)
+		b.curTrace.add(phaseUtxoFetch, fetchStart)
 		if e != nil {
 			return e
 		}
@@ -988,7 +1024,9 @@ func (b *BlockChain) connectBestChain(node *BlockNode, block *block2.Block, flag
 		// In the fast add case the code to check the block connection was skipped, so the utxo view needs to load the
 		// referenced utxos, spend them, and add the new utxos being created by this block.
 		if fastAdd {
+			fetchStart := time.Now()
 			e := view.fetchInputUtxos(b.db, block)
+			b.curTrace.add(phaseUtxoFetch, fetchStart)
 			if e != nil {
 				return false, e
 			}
@@ -1117,6 +1155,19 @@ func (b *BlockChain) HeaderByHash(hash *chainhash.Hash) (bh wire.BlockHeader, e
 	return node.Header(), nil
 }
 
+// WorkSumByHash returns the work sum recorded in the block index for the block with the given hash, which is the value
+// used to compare competing chains. This function is safe for concurrent access.
+func (b *BlockChain) WorkSumByHash(hash *chainhash.Hash) (*big.Int, error) {
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return nil, fmt.Errorf("block %s is not known", hash)
+	}
+	if node.workSum == nil {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(node.workSum), nil
+}
+
 // MainChainHasBlock returns whether or not the block with the given hash is in the main chain. This function is safe
 // for concurrent access.
 func (b *BlockChain) MainChainHasBlock(hash *chainhash.Hash) bool {
@@ -1468,6 +1519,9 @@ type Config struct {
 	// Checkpoints must be sorted by height. This field can be nil if the caller does not wish to specify any
 	// checkpoints.
 	Checkpoints []chaincfg.Checkpoint
+	// AssumeValid is the hash of a block whose ancestors, and itself, are connected without verifying their scripts.
+	// This field can be nil if the scripts of every block should be verified.
+	AssumeValid *chainhash.Hash
 	// TimeSource defines the median time source to use for things such as block processing and determining whether or
 	// not the chain is current. The caller is expected to keep a reference to the time source as well and add time
 	// samples from other peers on the network so the local time is adjusted to be in agreement with other peers.
@@ -1485,6 +1539,19 @@ type Config struct {
 	// O(N^2) validation complexity due to the SigHashAll flag. This field can be nil if the caller is not interested in
 	// using a signature cache.
 	HashCache *txscript.HashCache
+	// ValTrace enables recording how long each phase of the validation of every processed block takes, kept in a
+	// rolling log that can be read with ValidationTraces.
+	ValTrace bool
+	// MaxOrphanBlocks is the maximum number of blocks whose parent is not known that are held until the parent
+	// arrives. The oldest is evicted to make room for a new one. DefaultMaxOrphanBlocks is used when it is zero.
+	MaxOrphanBlocks int
+	// SnapshotPath is the file a snapshot of the chain state is written to in the background every SnapshotInterval
+	// blocks. When the chain state is found to be corrupt it is restored from the snapshot and the blocks connected
+	// after it are replayed from the database. Snapshots are not taken when it is empty.
+	SnapshotPath string
+	// SnapshotInterval is the number of blocks between snapshots of the chain state. DefaultSnapshotInterval is used
+	// when it is zero.
+	SnapshotInterval int32
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1517,6 +1584,10 @@ func New(config *Config) (*BlockChain, error) {
 		}
 	}
 	params := config.ChainParams
+	scriptFlagSchedule, e := newScriptFlagSchedule(params.ScriptFlagSchedule)
+	if e != nil {
+		return nil, AssertError("blockchain.New " + e.Error())
+	}
 	targetTimespan := params.TargetTimespan
 	targetTimePerBlock := params.TargetTimePerBlock
 	adjustmentFactor := params.RetargetAdjustmentFactor
@@ -1525,6 +1596,7 @@ func New(config *Config) (*BlockChain, error) {
 		checkpointsByHeight: checkpointsByHeight,
 		db:                  config.DB,
 		params:              params,
+		scriptFlagSchedule:  scriptFlagSchedule,
 		timeSource:          config.TimeSource,
 		sigCache:            config.SigCache,
 		indexManager:        config.IndexManager,
@@ -1541,11 +1613,39 @@ func New(config *Config) (*BlockChain, error) {
 		DifficultyAdjustments: make(map[string]float64),
 	}
 	b.DifficultyBits.Store(make(Diffs))
+	b.assumeValid, b.assumeValidPath.height = config.AssumeValid, -1
+	if config.ValTrace {
+		b.valTrace = newValTraceLog()
+	}
+	b.maxOrphans = config.MaxOrphanBlocks
+	if b.maxOrphans <= 0 {
+		b.maxOrphans = DefaultMaxOrphanBlocks
+	}
+	b.snapshotPath, b.snapshotInterval, b.interrupt = config.SnapshotPath, config.SnapshotInterval, config.Interrupt
+	if b.snapshotInterval <= 0 {
+		b.snapshotInterval = DefaultSnapshotInterval
+	}
 	// Initialize the chain state from the passed database. When the db does not yet contain any chain state, both it
-	// and the chain state will be initialized to contain only the genesis block.
-	if e := b.initChainState(); E.Chk(e) {
+	// and the chain state will be initialized to contain only the genesis block. When it cannot be loaded, or was
+	// marked for recovery, it is recovered from the last snapshot.
+	recovering, e := b.recoveryRequested()
+	if E.Chk(e) {
 		return nil, e
 	}
+	if !recovering {
+		if e = b.initChainState(); E.Chk(e) {
+			if b.snapshotPath == "" {
+				return nil, e
+			}
+			W.Ln("could not load the chain state, recovering it from the last snapshot:", e)
+			recovering = true
+		}
+	}
+	if recovering {
+		if e = b.recoverChainState(); E.Chk(e) {
+			return nil, e
+		}
+	}
 	// Perform any upgrades to the various chain-specific buckets as needed.
 	if e := b.maybeUpgradeDbBuckets(config.Interrupt); E.Chk(e) {
 		return nil, e
@@ -1564,7 +1664,7 @@ func New(config *Config) (*BlockChain, error) {
 	bestNode := b.BestChain.Tip()
 	df, ok := bestNode.Diffs.Load().(Diffs)
 	if df == nil || !ok ||
-		len(df) != len(fork.List[1].AlgoVers) {
+		len(df) != fork.GetNumAlgos(bestNode.height+1) {
 		bitsMap, e := b.CalcNextRequiredDifficultyPlan9Controller(bestNode)
 		if e != nil {
 		}
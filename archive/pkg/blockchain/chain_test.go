@@ -873,3 +873,34 @@ func TestIntervalBlockHashes(t *testing.T) {
 		}
 	}
 }
+
+// TestCheckpointBlockLocator ensures block locators anchored at checkpoints stop stepping back at the latest checkpoint
+// in the main chain and end with the checkpoints and the genesis block.
+func TestCheckpointBlockLocator(t *testing.T) {
+	// Construct a synthetic block chain consisting of the following structure.
+	//
+	// 	genesis -> 1 -> 2 -> ... -> 40
+	chain := newFakeChain(&chaincfg.MainNetParams)
+	nodes := chainedNodes(chain.BestChain.Genesis(), 40)
+	for _, node := range nodes {
+		chain.Index.AddNode(node)
+	}
+	chain.BestChain.SetTip(tstTip(nodes))
+	// Without checkpoints the normal block locator is returned.
+	if locator := chain.CheckpointBlockLocator(); !reflect.DeepEqual(locator, chain.BestChain.BlockLocator(nil)) {
+		t.Fatalf("unexpected locator without checkpoints -- got %v, want %v", locator, chain.BestChain.BlockLocator(nil))
+	}
+	// The checkpoint at height 50 is not in the main chain yet, so the locator is anchored at height 20.
+	chain.checkpoints = []chaincfg.Checkpoint{
+		{Height: 10, Hash: &nodes[9].hash},
+		{Height: 20, Hash: &nodes[19].hash},
+		{Height: 50, Hash: &chainhash.Hash{0x01}},
+	}
+	want := zipLocators(
+		locatorHashes(nodes, 39, 38, 37, 36, 35, 34, 33, 32, 31, 30, 29, 28, 26, 22),
+		BlockLocator{chain.checkpoints[1].Hash, chain.checkpoints[0].Hash, &chain.BestChain.Genesis().hash},
+	)
+	if locator := chain.CheckpointBlockLocator(); !reflect.DeepEqual(locator, want) {
+		t.Fatalf("unexpected locator -- got %v, want %v", locator, want)
+	}
+}
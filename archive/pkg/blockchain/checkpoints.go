@@ -50,6 +50,53 @@ func (b *BlockChain) LatestCheckpoint() *chaincfg.Checkpoint {
 	return &b.checkpoints[len(b.checkpoints)-1]
 }
 
+// CheckpointBlockLocator returns a block locator for the latest known tip of the main (best) chain that is anchored at
+// checkpoints. Like a normal block locator its entries step back exponentially from the tip, but they stop at the latest
+// checkpoint in the main chain and are followed by the earlier checkpoints and the genesis block. Blocks before a
+// checkpoint can not be reorganized, so the entries a normal locator spends on them only make it longer. When the main
+// chain contains no checkpoint the normal block locator is returned.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) CheckpointBlockLocator() BlockLocator {
+	b.ChainLock.RLock()
+	defer b.ChainLock.RUnlock()
+	node := b.BestChain.Tip()
+	anchor := -1
+	for i := len(b.checkpoints) - 1; i >= 0; i-- {
+		cp := &b.checkpoints[i]
+		if n := b.BestChain.NodeByHeight(cp.Height); n != nil && n.hash.IsEqual(cp.Hash) {
+			anchor = i
+			break
+		}
+	}
+	if anchor < 0 {
+		return b.BestChain.BlockLocator(node)
+	}
+	floor := b.checkpoints[anchor].Height
+	locator := make(BlockLocator, 0, 13+int(fastLog2Floor(uint32(node.height-floor)+1))+anchor)
+	step := int32(1)
+	for node.height > floor {
+		locator = append(locator, &node.hash)
+		height := node.height - step
+		if height < floor {
+			height = floor
+		}
+		node = b.BestChain.NodeByHeight(height)
+		// Once 11 entries have been included, start doubling the distance between included hashes.
+		if len(locator) > 10 {
+			step *= 2
+		}
+	}
+	for i := anchor; i >= 0; i-- {
+		locator = append(locator, b.checkpoints[i].Hash)
+	}
+	// The genesis block ends every locator.
+	if b.checkpoints[0].Height != 0 {
+		locator = append(locator, &b.BestChain.Genesis().hash)
+	}
+	return locator
+}
+
 // verifyCheckpoint returns whether the passed block height and hash combination match the checkpoint data. It also
 // returns true if there is no checkpoint data for the passed block height.
 func (b *BlockChain) verifyCheckpoint(height int32, hash *chainhash.Hash) bool {
@@ -162,7 +162,7 @@ func chainSetup(dbName string, netparams *chaincfg.Params) (chain *BlockChain, t
 			ChainParams: &paramsCopy,
 			Checkpoints: nil,
 			TimeSource:  NewMedianTime(),
-			SigCache:    txscript.NewSigCache(1000),
+			SigCache:    txscript.NewSigCache(1000 * txscript.SigCacheEntrySize),
 		},
 	)
 	if e != nil {
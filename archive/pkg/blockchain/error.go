@@ -137,6 +137,9 @@ const (
 	ErrPrevBlockNotBest
 	// ErrBlacklisted indicates a transaction contains a blacklisted address
 	ErrBlacklisted
+	// ErrBadSignetSolution indicates that the coinbase of a block on a signed test network does not carry a signature
+	// of the block that satisfies the challenge of the network.
+	ErrBadSignetSolution
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -184,6 +187,7 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrPreviousBlockUnknown:      "ErrPreviousBlockUnknown",
 	ErrInvalidAncestorBlock:      "ErrInvalidAncestorBlock",
 	ErrPrevBlockNotBest:          "ErrPrevBlockNotBest",
+	ErrBadSignetSolution:         "ErrBadSignetSolution",
 }
 
 // String returns the ErrorCode as a human-readable name.
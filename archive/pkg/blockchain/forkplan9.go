@@ -17,7 +17,8 @@ func GetAlgStamps(algoName string, startHeight int32, lastNode *BlockNode) (last
 	found bool, algStamps []int64, version int32,
 ) {
 	
-	version = fork.P9Algos[algoName].Version
+	p, _ := fork.GetAlgoParams(1, algoName)
+	version = p.Version
 	for ln := lastNode; ln != nil && ln.height > startHeight &&
 		len(algStamps) <= int(fork.List[1].AveragingInterval); ln = ln.
 		RelativeAncestor(1) {
@@ -110,7 +111,8 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9(lastNodeP *BlockNode, algoN
 	lastNode := lastNodeP
 	
 	algoVer := fork.GetAlgoVer(algoName, lastNode.height+1)
-	ttpb := float64(fork.List[1].Algos[algoName].VersionInterval)
+	p, _ := fork.GetAlgoParams(1, algoName)
+	ttpb := float64(p.VersionInterval)
 	newTargetBits = fork.SecondPowLimitBits
 	const minAvSamples = 3
 	adjustment = 1
@@ -169,7 +171,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9(lastNodeP *BlockNode, algoN
 	// if l {
 	// if lastNode.version == algoVer {
 	I.Ln(func() string {
-		an := fork.List[1].AlgoVers[algoVer]
+		an := fork.GetAlgoName(algoVer, lastNode.height+1)
 		pad := 8 - len(an)
 		if pad > 0 {
 			an += strings.Repeat(" ", pad)
@@ -241,7 +243,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9old(lastNode *BlockNode, alg
 		T.F("newTarget %064x %08x", newTarget, newTargetBits)
 	}
 	if l {
-		an := fork.List[1].AlgoVers[algoVer]
+		an := fork.GetAlgoName(algoVer, nH)
 		pad := 9 - len(an)
 		if pad > 0 {
 			an += strings.Repeat(" ", pad)
@@ -259,7 +261,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9old(lastNode *BlockNode, alg
 				RightJustify(fmt.Sprintf("%3.2fq", qhourDiv*ttpb), 7),
 				RightJustify(fmt.Sprintf("%3.2fA", algDiv*ttpb), 7),
 				RightJustify(fmt.Sprintf("%3.0f %3.3fD",
-					since-ttpb*float64(len(fork.List[1].Algos)), timeSinceAlgo*ttpb,
+					since-ttpb*float64(fork.GetNumAlgos(nH)), timeSinceAlgo*ttpb,
 				), 13,
 				),
 				RightJustify(fmt.Sprintf("%4.4fx", 1/adjustment), 11),
@@ -37,23 +37,24 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9Controller(lastNode *BlockNo
 	nH := lastNode.height + 1
 	currFork := fork.GetCurrent(nH)
 	nTB := make(Diffs)
+	// the algorithms are those active at the height, which includes those added or removed by the registry schedule
+	active := fork.GetAlgos(nH)
 	switch currFork {
 	case 0:
-		for i := range fork.List[0].Algos {
-			v := fork.List[currFork].Algos[i].Version
+		for i := range active {
+			v := active[i].Version
 			nTB[v], e = b.CalcNextRequiredDifficultyHalcyon(lastNode, i, true)
 		}
 		return nTB, nil
 	case 1:
 		if b.DifficultyHeight.Load() != nH {
 			b.DifficultyHeight.Store(nH)
-			currFork := fork.GetCurrent(nH)
-			algos := make(AlgoList, len(fork.List[currFork].Algos))
+			algos := make(AlgoList, len(active))
 			var counter int
-			for i := range fork.List[1].Algos {
+			for i := range active {
 				algos[counter] = Algo{
 					Name:   i,
-					Params: fork.List[currFork].Algos[i],
+					Params: active[i],
 				}
 				counter++
 			}
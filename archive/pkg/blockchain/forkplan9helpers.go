@@ -191,7 +191,7 @@ func (b *BlockChain) GetP9AlgoDiv(
 			for _, x := range algIntervals {
 				awi.Add(float64(x))
 			}
-			algDiv = capP9Adjustment(awi.Value() / ttpb / float64(len(fork.P9Algos)))
+			algDiv = capP9Adjustment(awi.Value() / ttpb / float64(fork.GetNumAlgos(last.height)))
 		}
 	}
 	return
@@ -218,7 +218,7 @@ func (b *BlockChain) GetP9Since(lastNode *BlockNode, algoVer int32) (
 	}
 	since = float64(lastNode.timestamp - last.timestamp)
 	ttpb = float64(fork.List[1].TargetTimePerBlock)
-	tspb := ttpb * float64(len(fork.List[1].Algos))
+	tspb := ttpb * float64(fork.GetNumAlgos(lastNode.height+1))
 	// ratio of seconds since to target seconds per block times the all time divergence ensures the change scales with
 	// the divergence from the target, and favours algos that are later
 	timeSinceAlgo = capP9Adjustment((since / tspb) / 5)
@@ -89,7 +89,7 @@ func isSupportedDbType(dbType string) bool {
 // 		ChainParams: &paramsCopy,
 // 		Checkpoints: nil,
 // 		TimeSource:  blockchain.NewMedianTime(),
-// 		SigCache:    txscript.NewSigCache(1000),
+// 		SigCache:    txscript.NewSigCache(1000 * txscript.SigCacheEntrySize),
 // 	})
 // 	if e != nil  {
 // 		teardown()
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"sync"
@@ -16,6 +17,12 @@ const (
 	// similarTimeSecs is the number of seconds in either direction from the local clock that is used to determine that
 	// it is likley wrong and hence to show a warning.
 	similarTimeSecs = 5 * 60 // 5 minutes
+	// minSkewSamples is the number of time samples needed before the clock skew is estimated.
+	minSkewSamples = 5
+	// minOutlierFenceSecs is the least number of seconds a time sample must be beyond the quartiles of the samples to
+	// be left out of the clock skew estimate as an outlier, so that samples agreeing to within a few seconds do not
+	// reject the odd one out.
+	minOutlierFenceSecs = 60
 )
 
 var (
@@ -35,6 +42,70 @@ type MedianTimeSource interface {
 	// Offset returns the number of seconds to adjust the local clock based upon the median of the time samples added by
 	// AddTimeData.
 	Offset() time.Duration
+	// ClockSkew returns the estimate of how far the local clock is from the clocks of the peers the time samples came
+	// from, with outlying samples left out.
+	ClockSkew() ClockSkew
+}
+
+// ClockSkew is an estimate of how far the local clock is from the clocks of the network. Unlike the offset applied to
+// the local clock, which must follow the consensus rules, it rejects outlying samples and is not limited in size, so it
+// is only used to warn that the local clock is wrong.
+type ClockSkew struct {
+	// Offset is the median offset of the clocks of peers from the local clock.
+	Offset time.Duration
+	// Samples is the number of time samples the offset is the median of.
+	Samples int
+	// Outliers is the number of time samples left out as being too far from the rest.
+	Outliers int
+}
+
+// Warning returns a warning that the local clock is wrong when there are enough samples to tell and it is further than
+// the threshold from the clocks of the network, or an empty string otherwise.
+func (c ClockSkew) Warning(threshold time.Duration) string {
+	if c.Samples < minSkewSamples || threshold <= 0 {
+		return ""
+	}
+	direction := "ahead of"
+	offset := c.Offset
+	if offset > 0 {
+		direction = "behind"
+	} else {
+		offset = -offset
+	}
+	if offset <= threshold {
+		return ""
+	}
+	return fmt.Sprintf(
+		"the local clock is %v %s the network time of %d peers, check the date and time are correct",
+		offset, direction, c.Samples,
+	)
+}
+
+// estimateSkew returns the median of the sorted offsets, leaving out those further from the quartiles than one and a
+// half times the interquartile range, or minOutlierFenceSecs if that is larger.
+func estimateSkew(sorted []int64) (c ClockSkew) {
+	n := len(sorted)
+	if n == 0 {
+		return
+	}
+	q1, q3 := sorted[n/4], sorted[(3*n)/4]
+	fence := (q3 - q1) * 3 / 2
+	if fence < minOutlierFenceSecs {
+		fence = minOutlierFenceSecs
+	}
+	kept := make([]int64, 0, n)
+	for _, offset := range sorted {
+		if offset < q1-fence || offset > q3+fence {
+			c.Outliers++
+			continue
+		}
+		kept = append(kept, offset)
+	}
+	c.Samples = len(kept)
+	if c.Samples > 0 {
+		c.Offset = time.Duration(kept[c.Samples/2]) * time.Second
+	}
+	return
 }
 
 // int64Sorter implements sort.Interface to allow a slice of 64-bit integers to be sorted.
@@ -162,6 +233,18 @@ func (m *medianTime) Offset() time.Duration {
 	return time.Duration(m.offsetSecs) * time.Second
 }
 
+// ClockSkew returns the estimate of how far the local clock is from the clocks of the peers the time samples came from,
+// with outlying samples left out. This function is safe for concurrent access and is part of the MedianTimeSource
+// interface implementation.
+func (m *medianTime) ClockSkew() ClockSkew {
+	m.mtx.Lock()
+	sorted := make([]int64, len(m.offsets))
+	copy(sorted, m.offsets)
+	m.mtx.Unlock()
+	sort.Sort(int64Sorter(sorted))
+	return estimateSkew(sorted)
+}
+
 // NewMedianTime returns a new instance of concurrency-safe implementation of the MedianTimeSource interface. The
 // returned implementation contains the rules necessary for proper time handling in the chain consensus rules and
 // expects the time samples to be added from the timestamp field of the version message received from remote peers that
@@ -86,3 +86,43 @@ func TestMedianTime(t *testing.T) {
 		}
 	}
 }
+
+// TestClockSkew ensures the clock skew estimate leaves out outlying samples, is not limited like the offset applied to
+// the local clock, and only warns when there are enough samples and it is beyond the threshold.
+func TestClockSkew(t *testing.T) {
+	tests := []struct {
+		in           []int64
+		wantOffset   int64
+		wantOutliers int
+		warn         bool
+	}{
+		// Too few samples to tell.
+		{in: []int64{600, 600, 600}, wantOffset: 600},
+		// Samples that agree closely leave nothing out.
+		{in: []int64{-3, 1, 2, 0, -1}, wantOffset: 0},
+		// A lying peer far from the rest is left out.
+		{in: []int64{2, 3, 86400, 1, 2, 4}, wantOffset: 2, wantOutliers: 1},
+		// A local clock far behind the network is reported even though it is beyond the offset applied to the clock.
+		{in: []int64{3598, 3600, 3601, 3603, 3599}, wantOffset: 3600, warn: true},
+		{in: []int64{-180, -181, -179, -185, -180, 7200}, wantOffset: -180, wantOutliers: 1, warn: true},
+	}
+	for i, test := range tests {
+		filter := NewMedianTime()
+		for j, offset := range test.in {
+			filter.AddTimeSample(strconv.Itoa(j), time.Unix(time.Now().Unix(), 0).Add(time.Duration(offset)*time.Second))
+		}
+		skew := filter.ClockSkew()
+		// allow for the clock ticking over a second between taking the samples
+		if d := skew.Offset - time.Duration(test.wantOffset)*time.Second; d > 0 || d < -time.Second {
+			t.Errorf("#%d: got offset %v, want %ds", i, skew.Offset, test.wantOffset)
+		}
+		if skew.Outliers != test.wantOutliers || skew.Samples != len(test.in)-test.wantOutliers {
+			t.Errorf("#%d: got %d samples and %d outliers, want %d outliers", i, skew.Samples, skew.Outliers,
+				test.wantOutliers,
+			)
+		}
+		if warning := skew.Warning(time.Minute * 2); (warning != "") != test.warn {
+			t.Errorf("#%d: got warning %q", i, warning)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	block2 "github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// orphanExpiry is how long an orphan block is held waiting for its parent before it is removed from the orphan pool.
+const orphanExpiry = time.Hour
+
+// OrphanBlock describes a block held in the orphan pool because its parent is not known.
+type OrphanBlock struct {
+	Hash chainhash.Hash
+	// Parent is the hash of the block the orphan builds on, which may itself be an orphan.
+	Parent chainhash.Hash
+	// Root is the hash of the first orphan of the chain of orphans the block is part of, whose parent is missing.
+	Root chainhash.Hash
+	Size int
+	// Received is when the block was added to the pool and Expires when it will be removed if its parent has not
+	// arrived.
+	Received time.Time
+	Expires  time.Time
+}
+
+// OrphanStats counts what happened to the blocks added to the orphan pool since the chain was started.
+type OrphanStats struct {
+	// Processed is the number of blocks with a known parent that were processed, to compare the number of orphans to.
+	Processed int64
+	// Added is the number of blocks added to the pool.
+	Added int64
+	// Connected is the number of orphans that were accepted into the chain after their parent arrived, and Rejected
+	// the number that failed the checks that needed their parent.
+	Connected int64
+	Rejected  int64
+	// Expired is the number of orphans removed because their parent did not arrive in time, and Evicted the number
+	// removed to make room for newer orphans when the pool was full.
+	Expired int64
+	Evicted int64
+}
+
+// maybeAddOrphanBlock adds the block, whose parent is not known, to the orphan pool unless it is a block that is
+// already known or fails the checks that don't need its parent: the proof of work, the merkle root, the coinbase and
+// the sanity of the transactions. The checks that need the parent are done when it arrives.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) maybeAddOrphanBlock(block *block2.Block, flags BehaviorFlags) (e error) {
+	hash := block.Hash()
+	var exists bool
+	if exists, e = b.blockExists(hash); E.Chk(e) {
+		return
+	}
+	if exists {
+		str := fmt.Sprintf("already have block %v", hash)
+		return ruleError(ErrDuplicateBlock, str)
+	}
+	if b.IsKnownOrphan(hash) {
+		str := fmt.Sprintf("already have block (orphan) %v", hash)
+		return ruleError(ErrDuplicateBlock, str)
+	}
+	// The height of an orphan is not known until its parent arrives, so its proof of work is checked against its own
+	// bits and the limit of its algorithm at the height after the best block, which it has if it extends the best
+	// chain. Its timestamp is only checked against the time.
+	height := b.BestSnapshot().Height + 1
+	header := &block.WireBlock().Header
+	powLimit := fork.GetMinDiff(fork.GetAlgoName(blockAlgo(header, height), height), height)
+	if e = checkBlockSanity(block, powLimit, b.timeSource, flags, false, height, time.Time{}, nil); E.Chk(e) {
+		return
+	}
+	D.F("adding orphan block %v with parent %v", hash, block.WireBlock().Header.PrevBlock)
+	b.addOrphanBlock(block)
+	return
+}
+
+// Orphans returns the blocks held in the orphan pool in the order they were received.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) Orphans() []OrphanBlock {
+	b.orphanLock.RLock()
+	defer b.orphanLock.RUnlock()
+	orphans := make([]OrphanBlock, 0, len(b.orphans))
+	for hash, oBlock := range b.orphans {
+		o := OrphanBlock{
+			Hash:     hash,
+			Parent:   oBlock.block.WireBlock().Header.PrevBlock,
+			Size:     oBlock.block.WireBlock().SerializeSizeStripped(),
+			Received: oBlock.received,
+			Expires:  oBlock.expiration,
+		}
+		// The root is found the same way as GetOrphanRoot, which can't be called with the orphan lock held.
+		o.Root = hash
+		for parent, ok := b.orphans[o.Parent]; ok; parent, ok = b.orphans[parent.block.WireBlock().Header.PrevBlock] {
+			o.Root = *parent.block.Hash()
+		}
+		orphans = append(orphans, o)
+	}
+	sort.Slice(
+		orphans, func(i, j int) bool {
+			return orphans[i].Received.Before(orphans[j].Received)
+		},
+	)
+	return orphans
+}
+
+// OrphanStats returns the counts of what happened to the blocks added to the orphan pool, the number of blocks it
+// holds and the most it can hold.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) OrphanStats() (stats OrphanStats, count, limit int) {
+	b.orphanLock.RLock()
+	defer b.orphanLock.RUnlock()
+	return b.orphanStats, len(b.orphans), b.maxOrphans
+}
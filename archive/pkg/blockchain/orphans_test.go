@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	block2 "github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestOrphanPool ensures the orphan pool links orphans to the root of their chain, evicts the oldest orphan when it
+// is full, removes expired orphans and counts each of these.
+func TestOrphanPool(t *testing.T) {
+	b := &BlockChain{
+		orphans:     make(map[chainhash.Hash]*orphanBlock),
+		prevOrphans: make(map[chainhash.Hash][]*orphanBlock),
+		maxOrphans:  3,
+	}
+	newOrphan := func(prev chainhash.Hash, nonce uint32) *block2.Block {
+		return block2.NewBlock(&wire.Block{Header: wire.BlockHeader{PrevBlock: prev, Nonce: nonce}})
+	}
+	missing := chainhash.DoubleHashH([]byte("missing parent"))
+	first := newOrphan(missing, 1)
+	second := newOrphan(*first.Hash(), 2)
+	third := newOrphan(*second.Hash(), 3)
+	for _, orphan := range []*block2.Block{first, second, third} {
+		b.addOrphanBlock(orphan)
+	}
+	if root := b.GetOrphanRoot(third.Hash()); !root.IsEqual(first.Hash()) {
+		t.Fatalf("got orphan root %v, want %v", root, first.Hash())
+	}
+	for _, o := range b.Orphans() {
+		if o.Root != *first.Hash() {
+			t.Fatalf("orphan %v has root %v, want %v", o.Hash, o.Root, first.Hash())
+		}
+	}
+	// Make the first orphan the oldest so it is the one evicted by the next.
+	b.orphans[*first.Hash()].expiration = time.Now().Add(time.Minute)
+	other := newOrphan(chainhash.DoubleHashH([]byte("other parent")), 4)
+	b.addOrphanBlock(other)
+	if b.IsKnownOrphan(first.Hash()) || !b.IsKnownOrphan(other.Hash()) {
+		t.Fatalf("the oldest orphan was not evicted for the new one")
+	}
+	if root := b.GetOrphanRoot(third.Hash()); !root.IsEqual(second.Hash()) {
+		t.Fatalf("got orphan root %v after eviction, want %v", root, second.Hash())
+	}
+	b.orphans[*other.Hash()].expiration = time.Now().Add(-time.Second)
+	b.addOrphanBlock(newOrphan(missing, 5))
+	if b.IsKnownOrphan(other.Hash()) {
+		t.Fatalf("expired orphan was not removed")
+	}
+	stats, count, limit := b.OrphanStats()
+	if count != 3 || limit != 3 {
+		t.Fatalf("got %d of %d orphans, want 3 of 3", count, limit)
+	}
+	if stats.Added != 5 || stats.Evicted != 1 || stats.Expired != 1 {
+		t.Fatalf("unexpected orphan stats %+v", stats)
+	}
+}
+
+// TestProcessOrphanSanity ensures blocks whose parent is not known are only added to the orphan pool if they pass the
+// checks that don't need their parent.
+func TestProcessOrphanSanity(t *testing.T) {
+	chain, teardownFunc, e := chainSetup("orphansanity", &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("Failed to setup chain instance: %v", e)
+	}
+	defer teardownFunc()
+	tip := chain.BestChain.Tip()
+	missing := chainhash.DoubleHashH([]byte("missing parent"))
+	newOrphan := func(modify func(msgBlock *wire.Block)) *block2.Block {
+		coinbase := wire.NewMsgTx(wire.TxVersion)
+		coinbase.AddTxIn(
+			&wire.TxIn{
+				PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex),
+				SignatureScript:  []byte{byte(tip.height + 2), 0},
+				Sequence:         wire.MaxTxInSequenceNum,
+			},
+		)
+		coinbase.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_TRUE}))
+		msgBlock := &wire.Block{
+			Header: wire.BlockHeader{
+				Version:    tip.version,
+				PrevBlock:  missing,
+				MerkleRoot: coinbase.TxHash(),
+				Timestamp:  time.Unix(time.Now().Unix(), 0),
+				Bits:       tip.bits,
+			},
+			Transactions: []*wire.MsgTx{coinbase},
+		}
+		if modify != nil {
+			modify(msgBlock)
+		}
+		return block2.NewBlock(msgBlock)
+	}
+	tests := []struct {
+		name   string
+		block  *block2.Block
+		flags  BehaviorFlags
+		reject ErrorCode
+		orphan bool
+	}{
+		{"sane orphan", newOrphan(nil), BFNoPoWCheck, 0, true},
+		{
+			"target above the pow limit",
+			newOrphan(func(msgBlock *wire.Block) { msgBlock.Header.Bits = 0x2100ffff }),
+			BFNoPoWCheck, ErrUnexpectedDifficulty, false,
+		},
+		{
+			"bad merkle root",
+			newOrphan(func(msgBlock *wire.Block) { msgBlock.Header.MerkleRoot = missing }),
+			BFNoPoWCheck, ErrBadMerkleRoot, false,
+		},
+		{
+			"no coinbase",
+			newOrphan(
+				func(msgBlock *wire.Block) {
+					msgBlock.Transactions[0].TxIn[0].PreviousOutPoint.Index = 0
+					msgBlock.Header.MerkleRoot = msgBlock.Transactions[0].TxHash()
+				},
+			),
+			BFNoPoWCheck, ErrFirstTxNotCoinbase, false,
+		},
+	}
+	for _, test := range tests {
+		_, isOrphan, e := chain.ProcessBlock(0, test.block, test.flags, 0)
+		if test.orphan {
+			if e != nil || !isOrphan || !chain.IsKnownOrphan(test.block.Hash()) {
+				t.Errorf("%s: not added to the orphan pool: %v", test.name, e)
+			}
+			continue
+		}
+		if re, ok := e.(RuleError); !ok || re.ErrorCode != test.reject {
+			t.Errorf("%s: got error %v, want %v", test.name, e, test.reject)
+		}
+		if isOrphan || chain.IsKnownOrphan(test.block.Hash()) {
+			t.Errorf("%s: added to the orphan pool", test.name)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/wire"
 )
 
 // BehaviorFlags is a bitmask defining tweaks to the normal behavior when
@@ -48,30 +49,32 @@ func (b *BlockChain) ProcessBlock(
 	T.Ln("blockchain.ProcessBlock", blockHeight, log.Caller("\nfrom", 1))
 	var prevBlock *block.Block
 	var e error
-	prevBlock, e = b.BlockByHash(&candidateBlock.WireBlock().Header.PrevBlock)
+	prevHash := &candidateBlock.WireBlock().Header.PrevBlock
+	prevBlock, e = b.BlockByHash(prevHash)
 	if prevBlock != nil {
 		blockHeight = prevBlock.Height() + 1
 	} else {
-		return false, false, e
+		// Blocks building on a side chain block are not handled, while a block whose parent is not known at all is
+		// held in the orphan pool until the parent arrives.
+		if b.Index.HaveBlock(prevHash) {
+			return false, false, e
+		}
+		b.ChainLock.Lock()
+		defer b.ChainLock.Unlock()
+		if e = b.maybeAddOrphanBlock(candidateBlock, flags); E.Chk(e) {
+			return false, false, e
+		}
+		return false, true, nil
 	}
 	// trc.S(prevBlock)
 	b.ChainLock.Lock()
 	defer b.ChainLock.Unlock()
+	b.orphanLock.Lock()
+	b.orphanStats.Processed++
+	b.orphanLock.Unlock()
 	fastAdd := flags&BFFastAdd == BFFastAdd
 	blockHash := candidateBlock.Hash()
-	hf := fork.GetCurrent(blockHeight)
 	bhwa := candidateBlock.WireBlock().BlockHashWithAlgos
-	var algo int32
-	switch hf {
-	case 0:
-		if candidateBlock.WireBlock().Header.Version != 514 {
-			algo = 2
-		} else {
-			algo = 514
-		}
-	case 1:
-		algo = candidateBlock.WireBlock().Header.Version
-	}
 	// The candidateBlock must not already exist in the main chain or side chains.
 	var exists bool
 	if exists, e = b.blockExists(blockHash); E.Chk(e) {
@@ -88,32 +91,13 @@ func (b *BlockChain) ProcessBlock(
 		E.Ln(str)
 		return false, false, str
 	}
+	// Trace the time spent in each phase of validating the candidateBlock if validation tracing is enabled.
+	trace := b.startTrace(blockHash, blockHeight)
+	defer func() {
+		b.curTrace = nil
+	}()
 	// Perform preliminary sanity checks on the candidateBlock and its transactions.
-	var DoNotCheckPow bool
-	pl := fork.GetMinDiff(fork.GetAlgoName(algo, blockHeight), blockHeight)
-	T.F("powLimit %d %s %d %064x", algo, fork.GetAlgoName(algo, blockHeight), blockHeight, pl)
-	ph := &candidateBlock.WireBlock().Header.PrevBlock
-	pn := b.Index.LookupNode(ph)
-	if pn == nil {
-		return false, false, errors.New("could not find parent block of candidate block")
-	}
-	var pb *BlockNode
-	pb = pn.GetLastWithAlgo(algo)
-	if pb == nil {
-		DoNotCheckPow = true
-	}
-	T.F("checkBlockSanity powLimit %d %s %d %064x ts %v", algo, fork.GetAlgoName(algo, blockHeight), blockHeight, pl,
-		pn.Header().Timestamp,
-	)
-	if e = checkBlockSanity(
-		candidateBlock,
-		pl,
-		b.timeSource,
-		flags,
-		DoNotCheckPow,
-		blockHeight,
-		pn.Header().Timestamp,
-	); E.Chk(e) {
+	if e = b.checkBlockSanityWithParent(candidateBlock, flags, blockHeight, trace); E.Chk(e) {
 		return false, false, e
 	}
 	T.Ln("searching back to checkpoints")
@@ -164,7 +148,6 @@ func (b *BlockChain) ProcessBlock(
 	}
 	T.Ln("handling orphans")
 	// Handle orphan blocks.
-	prevHash := &blockHeader.PrevBlock
 	var prevHashExists bool
 	if prevHashExists, e = b.blockExists(prevHash); E.Chk(e) {
 		return false, false, e
@@ -187,8 +170,10 @@ func (b *BlockChain) ProcessBlock(
 	T.Ln("maybe accept candidateBlock")
 	var isMainChain bool
 	if isMainChain, e = b.maybeAcceptBlock(workerNumber, candidateBlock, flags); E.Chk(e) {
+		b.noteCorruption(e)
 		return false, false, e
 	}
+	b.finishTrace(trace)
 	// Accept any orphan blocks that depend on this candidateBlock (they are no longer
 	// orphans) and repeat for those accepted blocks until there are no more.
 	if isMainChain {
@@ -284,11 +269,31 @@ func (b *BlockChain) processOrphans(
 			orphanHash := orphan.block.Hash()
 			b.removeOrphanBlock(orphan)
 			i--
-			// Potentially accept the block into the block chain.
+			// Potentially accept the block into the block chain, tracing it apart from the
+			// block that was processed.
 			var e error
-			if _, e = b.maybeAcceptBlock(workerNumber, orphan.block, flags); E.Chk(e) {
+			parentTrace := b.curTrace
+			height := b.Index.LookupNode(processHash).height + 1
+			trace := b.startTrace(orphanHash, height)
+			// Orphans are added to the pool before the checks that need their parent, so they are done now that it
+			// is known. An orphan that fails them is dropped without failing the block that was processed.
+			if e = b.checkBlockSanityWithParent(orphan.block, flags, height, trace); e != nil {
+				b.curTrace = parentTrace
+				W.F("dropping orphan block %v: %v", orphanHash, e)
+				b.orphanLock.Lock()
+				b.orphanStats.Rejected++
+				b.orphanLock.Unlock()
+				continue
+			}
+			_, e = b.maybeAcceptBlock(workerNumber, orphan.block, flags)
+			b.curTrace = parentTrace
+			if E.Chk(e) {
 				return e
 			}
+			b.finishTrace(trace)
+			b.orphanLock.Lock()
+			b.orphanStats.Connected++
+			b.orphanLock.Unlock()
 			// Add this block to the list of blocks to process so any orphan blocks that
 			// depend on this block are handled too.
 			processHashes = append(processHashes, orphanHash)
@@ -296,3 +301,53 @@ func (b *BlockChain) processOrphans(
 	}
 	return nil
 }
+
+// checkBlockSanityWithParent performs the context free checks of the block and its transactions against the proof of
+// work limit of its algorithm at the height and the timestamp of its parent, which must be in the block index.
+func (b *BlockChain) checkBlockSanityWithParent(
+	candidateBlock *block.Block, flags BehaviorFlags, blockHeight int32, trace *ValidationTrace,
+) (e error) {
+	algo := blockAlgo(&candidateBlock.WireBlock().Header, blockHeight)
+	var DoNotCheckPow bool
+	pl := fork.GetMinDiff(fork.GetAlgoName(algo, blockHeight), blockHeight)
+	T.F("powLimit %d %s %d %064x", algo, fork.GetAlgoName(algo, blockHeight), blockHeight, pl)
+	ph := &candidateBlock.WireBlock().Header.PrevBlock
+	pn := b.Index.LookupNode(ph)
+	if pn == nil {
+		return errors.New("could not find parent block of candidate block")
+	}
+	var pb *BlockNode
+	pb = pn.GetLastWithAlgo(algo)
+	if pb == nil {
+		DoNotCheckPow = true
+	}
+	T.F("checkBlockSanity powLimit %d %s %d %064x ts %v", algo, fork.GetAlgoName(algo, blockHeight), blockHeight, pl,
+		pn.Header().Timestamp,
+	)
+	return checkBlockSanity(
+		candidateBlock,
+		pl,
+		b.timeSource,
+		flags,
+		DoNotCheckPow,
+		blockHeight,
+		pn.Header().Timestamp,
+		trace,
+	)
+}
+
+// blockAlgo returns the algorithm of the block with the header at the height, which before the first hard fork is
+// either sha256d or scrypt and after it is given by the version.
+func blockAlgo(header *wire.BlockHeader, height int32) (algo int32) {
+	switch fork.GetCurrent(height) {
+	case 0:
+		if header.Version != 514 {
+			algo = 2
+		} else {
+			algo = 514
+		}
+	case 1:
+		algo = header.Version
+	}
+	return
+}
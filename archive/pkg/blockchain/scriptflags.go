@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+// ScriptFlagUpgrade is a network upgrade from the script flag schedule of the chain parameters. Added are the script
+// verification flags it switches on and Flags all of those in force from its height onwards.
+type ScriptFlagUpgrade struct {
+	Height int32
+	Added  txscript.ScriptFlags
+	Flags  txscript.ScriptFlags
+}
+
+// newScriptFlagSchedule returns the upgrades of the script flag schedule of the chain parameters in order of height,
+// merging those at the same height.
+func newScriptFlagSchedule(changes []chaincfg.ScriptFlagChange) (upgrades []ScriptFlagUpgrade, e error) {
+	sorted := append([]chaincfg.ScriptFlagChange(nil), changes...)
+	sort.SliceStable(
+		sorted, func(i, j int) bool {
+			return sorted[i].Height < sorted[j].Height
+		},
+	)
+	var flags txscript.ScriptFlags
+	for _, c := range sorted {
+		if c.Height < 0 {
+			return nil, fmt.Errorf("script flag upgrade at negative height %d", c.Height)
+		}
+		var added txscript.ScriptFlags
+		if added, e = txscript.ParseScriptFlags(c.Flags); e != nil {
+			return nil, fmt.Errorf("script flag upgrade at height %d: %v", c.Height, e)
+		}
+		if added&flags != 0 {
+			return nil, fmt.Errorf(
+				"script flag upgrade at height %d: %s already in force", c.Height, added&flags,
+			)
+		}
+		flags |= added
+		if n := len(upgrades); n > 0 && upgrades[n-1].Height == c.Height {
+			upgrades[n-1].Added |= added
+			upgrades[n-1].Flags = flags
+			continue
+		}
+		upgrades = append(upgrades, ScriptFlagUpgrade{Height: c.Height, Added: added, Flags: flags})
+	}
+	return
+}
+
+// ScriptFlags returns the script verification flags the scripts of a block at the height are checked with. This
+// function is safe for concurrent access.
+func (b *BlockChain) ScriptFlags(height int32) (flags txscript.ScriptFlags) {
+	for _, u := range b.scriptFlagSchedule {
+		if u.Height > height {
+			break
+		}
+		flags = u.Flags
+	}
+	return
+}
+
+// ScriptFlagUpgrades returns the network upgrades that change the script verification flags, in order of height.
+// This function is safe for concurrent access.
+func (b *BlockChain) ScriptFlagUpgrades() []ScriptFlagUpgrade {
+	return append([]ScriptFlagUpgrade(nil), b.scriptFlagSchedule...)
+}
@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+// TestScriptFlagSchedule ensures the script verification flags of a block are those of the upgrades at or below its
+// height, whatever order the upgrades are listed in, and that bad schedules are refused.
+func TestScriptFlagSchedule(t *testing.T) {
+	schedule, e := newScriptFlagSchedule(
+		[]chaincfg.ScriptFlagChange{
+			{Height: 200, Flags: []string{"CHECKLOCKTIMEVERIFY"}},
+			{Height: 0, Flags: []string{"P2SH"}},
+			{Height: 100, Flags: []string{"DERSIG"}},
+			{Height: 200, Flags: []string{"CHECKSEQUENCEVERIFY"}},
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(schedule) != 3 {
+		t.Fatalf("got %d upgrades, want 3: %+v", len(schedule), schedule)
+	}
+	csv := txscript.ScriptVerifyCheckLockTimeVerify | txscript.ScriptVerifyCheckSequenceVerify
+	if u := schedule[2]; u.Height != 200 || u.Added != csv {
+		t.Errorf("the upgrades at height 200 were not merged: %+v", u)
+	}
+	b := &BlockChain{scriptFlagSchedule: schedule}
+	tests := []struct {
+		height int32
+		flags  txscript.ScriptFlags
+	}{
+		{0, txscript.ScriptBip16},
+		{99, txscript.ScriptBip16},
+		{100, txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures},
+		{199, txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures},
+		{200, txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures | csv},
+		{1000000, txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures | csv},
+	}
+	for _, test := range tests {
+		if flags := b.ScriptFlags(test.height); flags != test.flags {
+			t.Errorf("height %d: got flags %s, want %s", test.height, flags, test.flags)
+		}
+	}
+	if flags := (&BlockChain{}).ScriptFlags(100); flags != 0 {
+		t.Errorf("got flags %s without a schedule", flags)
+	}
+	bad := [][]chaincfg.ScriptFlagChange{
+		{{Height: 0, Flags: []string{"SEGWIT"}}},
+		{{Height: -1, Flags: []string{"P2SH"}}},
+		{{Height: 0, Flags: []string{"P2SH"}}, {Height: 10, Flags: []string{"DERSIG", "P2SH"}}},
+	}
+	for _, changes := range bad {
+		if _, e = newScriptFlagSchedule(changes); e == nil {
+			t.Errorf("expected an error for schedule %+v", changes)
+		}
+	}
+	for _, params := range []*chaincfg.Params{
+		&chaincfg.MainNetParams, &chaincfg.TestNet3Params, &chaincfg.RegressionTestParams, &chaincfg.SimNetParams,
+	} {
+		if schedule, e = newScriptFlagSchedule(params.ScriptFlagSchedule); e != nil {
+			t.Errorf("%s: %v", params.Name, e)
+		}
+	}
+}
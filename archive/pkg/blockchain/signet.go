@@ -0,0 +1,160 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// SignetHeader prefixes the data pushed by the coinbase output that carries the signature of the producer of a block
+// on a signed test network, which is known as the signet solution.
+var SignetHeader = []byte{0xec, 0xc7, 0xda, 0xa2}
+
+// signetVerifyFlags are the script flags that the signet solution of a block is verified with.
+const signetVerifyFlags = txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures | txscript.ScriptVerifyStrictEncoding
+
+// signetScript returns the coinbase output script carrying the signet solution.
+func signetScript(solution []byte) []byte {
+	script, _ := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_RETURN).
+		AddData(append(append([]byte{}, SignetHeader...), solution...)).
+		Script()
+	return script
+}
+
+// signetSolution returns the index of the last coinbase output carrying a signet solution and the solution, the index
+// being -1 if there is none.
+func signetSolution(coinbase *wire.MsgTx) (index int, solution []byte) {
+	for i := len(coinbase.TxOut) - 1; i >= 0; i-- {
+		pkScript := coinbase.TxOut[i].PkScript
+		if len(pkScript) == 0 || pkScript[0] != txscript.OP_RETURN {
+			continue
+		}
+		pushes, e := txscript.PushedData(pkScript)
+		if e != nil || len(pushes) != 1 || !bytes.HasPrefix(pushes[0], SignetHeader) {
+			continue
+		}
+		return i, pushes[0][len(SignetHeader):]
+	}
+	return -1, nil
+}
+
+// signetTransaction returns the transaction whose input the signet solution of the block is the signature script of,
+// spending an output that pays to the challenge. The output is created by a transaction committing to the version,
+// previous block and merkle root of the block with the solution left out of its coinbase. The timestamp and nonce are
+// not signed, so that miners can roll them without the block producer signing again.
+func signetTransaction(msgBlock *wire.Block, challenge []byte) (toSign *wire.MsgTx, e error) {
+	if len(msgBlock.Transactions) == 0 {
+		return nil, ruleError(ErrNoTransactions, "block does not contain any transactions")
+	}
+	coinbase := msgBlock.Transactions[0].Copy()
+	index, solution := signetSolution(coinbase)
+	if index < 0 {
+		return nil, ruleError(ErrBadSignetSolution, "coinbase does not carry a signet solution")
+	}
+	coinbase.TxOut[index].PkScript = signetScript(nil)
+	txs := make([]*util.Tx, len(msgBlock.Transactions))
+	txs[0] = util.NewTx(coinbase)
+	for i := 1; i < len(txs); i++ {
+		txs[i] = util.NewTx(msgBlock.Transactions[i])
+	}
+	merkleRoot := BuildMerkleTreeStore(txs, false).GetRoot()
+	commitment := make([]byte, 4, 4+2*chainhash.HashSize)
+	binary.LittleEndian.PutUint32(commitment, uint32(msgBlock.Header.Version))
+	commitment = append(commitment, msgBlock.Header.PrevBlock[:]...)
+	commitment = append(commitment, merkleRoot[:]...)
+	var scriptSig []byte
+	if scriptSig, e = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(commitment).Script(); E.Chk(e) {
+		return
+	}
+	toSpend := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+				SignatureScript:  scriptSig,
+			},
+		},
+		TxOut: []*wire.TxOut{{PkScript: challenge}},
+	}
+	toSign = &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: wire.OutPoint{Hash: toSpend.TxHash()},
+				SignatureScript:  solution,
+			},
+		},
+		TxOut: []*wire.TxOut{{PkScript: []byte{txscript.OP_RETURN}}},
+	}
+	return
+}
+
+// CheckSignetSolution ensures that the coinbase of the block carries a signature of the block that satisfies the
+// challenge of a signed test network.
+func CheckSignetSolution(msgBlock *wire.Block, challenge []byte) (e error) {
+	var toSign *wire.MsgTx
+	if toSign, e = signetTransaction(msgBlock, challenge); e != nil {
+		return
+	}
+	var vm *txscript.Engine
+	if vm, e = txscript.NewEngine(challenge, toSign, 0, signetVerifyFlags, nil, nil, 0); e != nil {
+		return ruleError(ErrBadSignetSolution, fmt.Sprint("signet solution cannot be verified: ", e))
+	}
+	if e = vm.Execute(); e != nil {
+		return ruleError(ErrBadSignetSolution, fmt.Sprint("signet solution does not satisfy the challenge: ", e))
+	}
+	return
+}
+
+// SignSignetBlock signs the block with the key of a block producer of the signed test network of the parameters,
+// putting the signet solution in its coinbase, which is replaced by a copy, and updating the merkle root. The block is
+// signed again by calling it again after any of its transactions change. If the challenge needs the signatures of
+// several producers, the solution only satisfies it if the key is enough on its own.
+func SignSignetBlock(msgBlock *wire.Block, params *chaincfg.Params, key *util.WIF) (e error) {
+	if params.SignetChallenge == nil {
+		return errors.New("the network is not a signed test network")
+	}
+	if len(msgBlock.Transactions) == 0 {
+		return errors.New("block does not contain any transactions")
+	}
+	coinbase := msgBlock.Transactions[0].Copy()
+	index, _ := signetSolution(coinbase)
+	if index < 0 {
+		coinbase.AddTxOut(wire.NewTxOut(0, nil))
+		index = len(coinbase.TxOut) - 1
+	}
+	coinbase.TxOut[index].PkScript = signetScript(nil)
+	msgBlock.Transactions[0] = coinbase
+	var toSign *wire.MsgTx
+	if toSign, e = signetTransaction(msgBlock, params.SignetChallenge); E.Chk(e) {
+		return
+	}
+	pubKey := key.SerializePubKey()
+	getKey := txscript.KeyClosure(
+		func(addr btcaddr.Address) (*ecc.PrivateKey, bool, error) {
+			if bytes.Equal(addr.ScriptAddress(), pubKey) || bytes.Equal(addr.ScriptAddress(), btcaddr.Hash160(pubKey)) {
+				return key.PrivKey, key.CompressPubKey, nil
+			}
+			return nil, false, errors.New("the key is not that of the address")
+		},
+	)
+	var solution []byte
+	if solution, e = txscript.SignTxOutput(
+		params, toSign, 0, params.SignetChallenge, txscript.SigHashAll, getKey, nil, nil,
+	); E.Chk(e) {
+		return
+	}
+	coinbase.TxOut[index].PkScript = signetScript(solution)
+	merkles := BuildMerkleTreeStore(block.NewBlock(msgBlock).Transactions(), false)
+	msgBlock.Header.MerkleRoot = *merkles.GetRoot()
+	return CheckSignetSolution(msgBlock, params.SignetChallenge)
+}
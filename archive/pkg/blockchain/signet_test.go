@@ -0,0 +1,102 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// signetKey returns a new key and the challenge of a signed test network it is the block producer of.
+func signetKey(t *testing.T) (key *util.WIF, challenge []byte) {
+	priv, e := ecc.NewPrivateKey(ecc.S256())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if key, e = util.NewWIF(priv, &chaincfg.TestNet3Params, true); e != nil {
+		t.Fatal(e)
+	}
+	pubKey, e := btcaddr.NewPubKey(key.SerializePubKey(), &chaincfg.TestNet3Params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if challenge, e = txscript.PayToAddrScript(pubKey); e != nil {
+		t.Fatal(e)
+	}
+	return
+}
+
+// TestSignetSolution ensures that a signed block satisfies the challenge with its timestamp and nonce rolled, and that
+// unsigned blocks, blocks whose transactions changed after signing and blocks signed with another key do not.
+func TestSignetSolution(t *testing.T) {
+	key, challenge := signetKey(t)
+	params, e := chaincfg.SigNetParams(challenge)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if params.Net != chaincfg.SigNetMagic(challenge) {
+		t.Fatalf("network magic is %v, want %v", params.Net, chaincfg.SigNetMagic(challenge))
+	}
+	coinbase := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex}, SignatureScript: []byte{0x51, 0x51}},
+		},
+		TxOut: []*wire.TxOut{{Value: 1e8, PkScript: []byte{txscript.OP_TRUE}}},
+	}
+	tx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: coinbase.TxHash()}}},
+		TxOut: []*wire.TxOut{{Value: 1e8, PkScript: []byte{txscript.OP_TRUE}}},
+	}
+	msgBlock := &wire.Block{
+		Header:       wire.BlockHeader{Version: 2, PrevBlock: *params.GenesisHash},
+		Transactions: []*wire.MsgTx{coinbase, tx},
+	}
+	isBadSolution := func(e error) bool {
+		re, ok := e.(RuleError)
+		return ok && re.ErrorCode == ErrBadSignetSolution
+	}
+	if e = CheckSignetSolution(msgBlock, challenge); !isBadSolution(e) {
+		t.Fatalf("unsigned block was checked with error %v", e)
+	}
+	if e = SignSignetBlock(msgBlock, params, key); e != nil {
+		t.Fatal(e)
+	}
+	if len(coinbase.TxOut) != 1 {
+		t.Fatal("the coinbase of the template was changed")
+	}
+	if len(msgBlock.Transactions[0].TxOut) != 2 {
+		t.Fatalf("signed coinbase has %d outputs, want 2", len(msgBlock.Transactions[0].TxOut))
+	}
+	merkles := BuildMerkleTreeStore([]*util.Tx{util.NewTx(msgBlock.Transactions[0]), util.NewTx(tx)}, false)
+	if msgBlock.Header.MerkleRoot != *merkles.GetRoot() {
+		t.Fatal("merkle root was not updated")
+	}
+	msgBlock.Header.Timestamp = time.Unix(1791331200, 0)
+	msgBlock.Header.Nonce = 12345
+	if e = CheckSignetSolution(msgBlock, challenge); e != nil {
+		t.Fatal(e)
+	}
+	// Signing again replaces the solution rather than adding another.
+	tx.TxOut[0].Value--
+	if e = CheckSignetSolution(msgBlock, challenge); !isBadSolution(e) {
+		t.Fatalf("block with a changed transaction was checked with error %v", e)
+	}
+	if e = SignSignetBlock(msgBlock, params, key); e != nil {
+		t.Fatal(e)
+	}
+	if len(msgBlock.Transactions[0].TxOut) != 2 {
+		t.Fatalf("signed again coinbase has %d outputs, want 2", len(msgBlock.Transactions[0].TxOut))
+	}
+	otherKey, otherChallenge := signetKey(t)
+	if e = CheckSignetSolution(msgBlock, otherChallenge); !isBadSolution(e) {
+		t.Fatalf("block was checked against another challenge with error %v", e)
+	}
+	if e = SignSignetBlock(msgBlock, params, otherKey); e == nil {
+		t.Fatal("block was signed with a key that does not satisfy the challenge")
+	}
+}
@@ -0,0 +1,421 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+const (
+	// DefaultSnapshotInterval is the number of blocks between snapshots of the chain state when Config.SnapshotInterval
+	// is not set.
+	DefaultSnapshotInterval = 5000
+	// snapshotBatchSize is the number of utxo set entries restored from a snapshot in each database transaction.
+	snapshotBatchSize = 50000
+	// maxSnapshotFieldSize is the largest field read from a snapshot, which keeps a corrupt length from exhausting memory.
+	maxSnapshotFieldSize = 1 << 20
+)
+
+var (
+	// chainStateRecoverKeyName is the name of the db key that is set when the chain state has to be recovered from the
+	// last snapshot, either because corruption was found in it or because a recovery was interrupted.
+	chainStateRecoverKeyName = []byte("chainstaterecover")
+	// snapshotMagic starts every chain state snapshot, the last byte being the version of the format.
+	snapshotMagic = [8]byte{'p', 'o', 'd', 's', 'n', 'a', 'p', 1}
+)
+
+// A chain state snapshot is a copy of the best chain state and the utxo set as of a single database transaction, which
+// reads a consistent view of the database while blocks go on being connected.
+//
+// The serialized format is:
+//
+//   <magic><network><best chain state><utxo entries><end><checksum>
+//   Field             Type             Size
+//   magic             [8]byte          8 bytes
+//   network           uint32           4 bytes
+//   best chain state  field            4 bytes + length
+//   utxo entries      field pairs      key and value field of each entry of the utxo set bucket
+//   end               uint32           4 bytes, a field of zero length
+//   checksum          sha256           32 bytes, of all that comes before it
+//
+// Each field is its length as a uint32 followed by its bytes. Keys of the utxo set are never empty, so a field of zero
+// length in place of one ends the entries.
+// -----------------------------------------------------------------------------
+
+// writeSnapshotField writes a length prefixed field of a chain state snapshot.
+func writeSnapshotField(w io.Writer, data []byte) (e error) {
+	var length [4]byte
+	byteOrder.PutUint32(length[:], uint32(len(data)))
+	if _, e = w.Write(length[:]); e != nil {
+		return
+	}
+	_, e = w.Write(data)
+	return
+}
+
+// readSnapshotField reads a length prefixed field of a chain state snapshot.
+func readSnapshotField(r io.Reader) (data []byte, e error) {
+	var length [4]byte
+	if _, e = io.ReadFull(r, length[:]); e != nil {
+		return
+	}
+	n := byteOrder.Uint32(length[:])
+	if n > maxSnapshotFieldSize {
+		return nil, fmt.Errorf("field of %d bytes is too long", n)
+	}
+	data = make([]byte, n)
+	_, e = io.ReadFull(r, data)
+	return
+}
+
+// writeChainSnapshot writes a snapshot of the chain state to the file at path, replacing the last one only once it has
+// been written completely. It returns the best chain state of the snapshot and the number of utxos in it.
+func writeChainSnapshot(
+	db database.DB, net wire.BitcoinNet, path string, interrupt <-chan struct{},
+) (state bestChainState, utxos int, e error) {
+	tmpPath := path + ".tmp"
+	var f *os.File
+	if f, e = os.Create(tmpPath); e != nil {
+		return
+	}
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+		if e != nil {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+	buf := bufio.NewWriter(f)
+	sum := sha256.New()
+	w := io.MultiWriter(buf, sum)
+	var header [12]byte
+	copy(header[:], snapshotMagic[:])
+	byteOrder.PutUint32(header[8:], uint32(net))
+	if _, e = w.Write(header[:]); e != nil {
+		return
+	}
+	e = db.View(
+		func(dbTx database.Tx) (e error) {
+			serializedState := dbTx.Metadata().Get(chainStateKeyName)
+			if state, e = deserializeBestChainState(serializedState); e != nil {
+				return e
+			}
+			if e = writeSnapshotField(w, serializedState); e != nil {
+				return e
+			}
+			return dbTx.Metadata().Bucket(utxoSetBucketName).ForEach(
+				func(k, v []byte) (e error) {
+					if utxos%1000 == 0 && interruptRequested(interrupt) {
+						return errInterruptRequested
+					}
+					if e = writeSnapshotField(w, k); e != nil {
+						return e
+					}
+					utxos++
+					return writeSnapshotField(w, v)
+				},
+			)
+		},
+	)
+	if e != nil {
+		return
+	}
+	if e = writeSnapshotField(w, nil); e != nil {
+		return
+	}
+	if _, e = buf.Write(sum.Sum(nil)); e != nil {
+		return
+	}
+	if e = buf.Flush(); e != nil {
+		return
+	}
+	if e = f.Sync(); e != nil {
+		return
+	}
+	e = f.Close()
+	f = nil
+	if e != nil {
+		return
+	}
+	e = os.Rename(tmpPath, path)
+	return
+}
+
+// readChainSnapshot reads the snapshot of the chain state in the file at path, calling fn, when it is not nil, with
+// every entry of the utxo set in it. It returns the serialized best chain state of the snapshot, and an error if the
+// snapshot is for another network or its checksum does not match, in which case fn may have been called with entries
+// of a corrupt snapshot.
+func readChainSnapshot(path string, net wire.BitcoinNet, fn func(key, value []byte) error) (serializedState []byte, e error) {
+	var f *os.File
+	if f, e = os.Open(path); e != nil {
+		return
+	}
+	defer func() {
+		_ = f.Close()
+		if e != nil {
+			e = fmt.Errorf("chain state snapshot %s: %v", path, e)
+		}
+	}()
+	br := bufio.NewReader(f)
+	sum := sha256.New()
+	r := io.TeeReader(br, sum)
+	var header [12]byte
+	if _, e = io.ReadFull(r, header[:]); e != nil {
+		return
+	}
+	if !bytes.Equal(header[:8], snapshotMagic[:]) {
+		return nil, errors.New("not a snapshot of a known version")
+	}
+	if n := wire.BitcoinNet(byteOrder.Uint32(header[8:])); n != net {
+		return nil, fmt.Errorf("snapshot is of network %v, not %v", n, net)
+	}
+	if serializedState, e = readSnapshotField(r); e != nil {
+		return
+	}
+	if _, e = deserializeBestChainState(serializedState); e != nil {
+		return
+	}
+	for {
+		var key, value []byte
+		if key, e = readSnapshotField(r); e != nil {
+			return
+		}
+		if len(key) == 0 {
+			break
+		}
+		if value, e = readSnapshotField(r); e != nil {
+			return
+		}
+		if fn != nil {
+			if e = fn(key, value); e != nil {
+				return
+			}
+		}
+	}
+	var checksum [sha256.Size]byte
+	if _, e = io.ReadFull(br, checksum[:]); e != nil {
+		return
+	}
+	if !bytes.Equal(checksum[:], sum.Sum(nil)) {
+		return nil, errors.New("checksum does not match")
+	}
+	return
+}
+
+// restoreChainSnapshot replaces the best chain state and the utxo set in the database with those of the snapshot in the
+// file at path, once it has been checked to be whole. The chain state is marked for recovery until it has been
+// restored, so that an interrupted restore is done again.
+func restoreChainSnapshot(db database.DB, net wire.BitcoinNet, path string) (state bestChainState, e error) {
+	var serializedState []byte
+	if serializedState, e = readChainSnapshot(path, net, nil); e != nil {
+		return
+	}
+	if state, e = deserializeBestChainState(serializedState); e != nil {
+		return
+	}
+	if e = db.Update(
+		func(dbTx database.Tx) (e error) {
+			meta := dbTx.Metadata()
+			if e = meta.Put(chainStateRecoverKeyName, []byte{1}); e != nil {
+				return e
+			}
+			if meta.Bucket(utxoSetBucketName) != nil {
+				if e = meta.DeleteBucket(utxoSetBucketName); e != nil {
+					return e
+				}
+			}
+			_, e = meta.CreateBucket(utxoSetBucketName)
+			return e
+		},
+	); e != nil {
+		return
+	}
+	batch := make([][2][]byte, 0, snapshotBatchSize)
+	flush := func() (e error) {
+		e = db.Update(
+			func(dbTx database.Tx) (e error) {
+				utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
+				for _, entry := range batch {
+					if e = utxoBucket.Put(entry[0], entry[1]); e != nil {
+						return e
+					}
+				}
+				return nil
+			},
+		)
+		batch = batch[:0]
+		return
+	}
+	if _, e = readChainSnapshot(
+		path, net, func(key, value []byte) (e error) {
+			batch = append(batch, [2][]byte{key, value})
+			if len(batch) < snapshotBatchSize {
+				return nil
+			}
+			return flush()
+		},
+	); e != nil {
+		return
+	}
+	if e = flush(); e != nil {
+		return
+	}
+	e = db.Update(
+		func(dbTx database.Tx) (e error) {
+			meta := dbTx.Metadata()
+			if e = meta.Put(chainStateKeyName, serializedState); e != nil {
+				return e
+			}
+			return meta.Delete(chainStateRecoverKeyName)
+		},
+	)
+	return
+}
+
+// maybeSnapshot starts writing a snapshot of the chain state in the background when the height of the new best block
+// is a multiple of the snapshot interval, unless one is being written already.
+func (b *BlockChain) maybeSnapshot(height int32) {
+	if b.snapshotPath == "" || b.snapshotInterval <= 0 || height%b.snapshotInterval != 0 {
+		return
+	}
+	if !b.snapshotting.CAS(false, true) {
+		return
+	}
+	go func() {
+		defer b.snapshotting.Store(false)
+		start := time.Now()
+		state, utxos, e := writeChainSnapshot(b.db, b.params.Net, b.snapshotPath, b.interrupt)
+		if e != nil {
+			if e != errInterruptRequested {
+				E.Ln("could not snapshot the chain state:", e)
+			}
+			return
+		}
+		I.F(
+			"snapshotted the chain state at height %d (%v) with %d utxos in %v",
+			state.height, state.hash, utxos, time.Since(start),
+		)
+	}()
+}
+
+// recoveryRequested returns whether the chain state was marked to be recovered from the last snapshot.
+func (b *BlockChain) recoveryRequested() (requested bool, e error) {
+	e = b.db.View(
+		func(dbTx database.Tx) (e error) {
+			requested = dbTx.Metadata().Get(chainStateRecoverKeyName) != nil
+			return nil
+		},
+	)
+	return
+}
+
+// noteCorruption marks the chain state to be recovered from the last snapshot when the node next starts if the error is
+// the database reporting that it is corrupt.
+func (b *BlockChain) noteCorruption(e error) {
+	if dbErr, ok := e.(database.DBError); !ok || dbErr.ErrorCode != database.ErrCorruption {
+		return
+	}
+	if b.snapshotPath == "" {
+		E.Ln("the chain state is corrupt and there is no snapshot to recover it from:", e)
+		return
+	}
+	if e := b.db.Update(
+		func(dbTx database.Tx) (e error) {
+			return dbTx.Metadata().Put(chainStateRecoverKeyName, []byte{1})
+		},
+	); E.Chk(e) {
+		return
+	}
+	E.Ln("the chain state is corrupt, it will be recovered from the last snapshot when the node is restarted:", e)
+}
+
+// recoverChainState restores the chain state from the last snapshot and then replays the blocks of the main chain
+// connected after it was taken, which are still in the database, so the node resumes from where it was instead of
+// having to sync the chain again. The optional indexes are not part of the snapshot and may already have the replayed
+// blocks, so they are left out of the replay and caught up by the index manager when it is initialized afterwards.
+func (b *BlockChain) recoverChainState() (e error) {
+	if b.snapshotPath == "" {
+		return errors.New("snapshots of the chain state are disabled, so it cannot be recovered")
+	}
+	start := time.Now()
+	var state bestChainState
+	if state, e = restoreChainSnapshot(b.db, b.params.Net, b.snapshotPath); e != nil {
+		return
+	}
+	I.F("restored the chain state snapshot at height %d (%v), replaying the blocks after it", state.height, state.hash)
+	b.Index = newBlockIndex(b.db, b.params)
+	b.BestChain = newChainView(nil)
+	if e = b.initChainState(); e != nil {
+		return
+	}
+	b.ChainLock.Lock()
+	defer b.ChainLock.Unlock()
+	indexManager := b.indexManager
+	b.indexManager = nil
+	defer func() {
+		b.indexManager = indexManager
+	}()
+	var replayed int
+	for {
+		if interruptRequested(b.interrupt) {
+			return errInterruptRequested
+		}
+		tip := b.BestChain.Tip()
+		var node *BlockNode
+		var blk *block.Block
+		if e = b.db.View(
+			func(dbTx database.Tx) (e error) {
+				// The blocks to replay are those the height index still has on top of the restored tip.
+				var hash *chainhash.Hash
+				if hash, e = dbFetchHashByHeight(dbTx, tip.height+1); e != nil {
+					return nil
+				}
+				n := b.Index.LookupNode(hash)
+				if n == nil || n.parent != tip || !b.Index.NodeStatus(n).KnownValid() {
+					return nil
+				}
+				if blk, e = dbFetchBlockByNode(dbTx, n); e != nil {
+					return e
+				}
+				node = n
+				return nil
+			},
+		); e != nil {
+			return
+		}
+		if node == nil {
+			break
+		}
+		// The blocks were validated when they were first connected, so they are added the way known valid blocks are.
+		view := NewUtxoViewpoint()
+		view.SetBestHash(&tip.hash)
+		stxos := make([]SpentTxOut, 0, countSpentOutputs(blk))
+		if e = view.fetchInputUtxos(b.db, blk); e != nil {
+			return
+		}
+		if e = view.connectTransactions(blk, &stxos); e != nil {
+			return
+		}
+		if e = b.connectBlock(node, blk, view, stxos); e != nil {
+			return
+		}
+		replayed++
+	}
+	tip := b.BestChain.Tip()
+	I.F(
+		"recovered the chain state at height %d (%v) after replaying %d blocks in %v",
+		tip.height, tip.hash, replayed, time.Since(start),
+	)
+	return
+}
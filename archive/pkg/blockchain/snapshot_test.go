@@ -0,0 +1,218 @@
+package blockchain
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestChainSnapshot ensures a snapshot of the chain state is what the chain state is recovered from when it cannot be
+// loaded, and that a snapshot that is corrupt or of another network is refused.
+func TestChainSnapshot(t *testing.T) {
+	chain, teardownFunc, e := chainSetup("snapshot", &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("Failed to setup chain instance: %v", e)
+	}
+	defer teardownFunc()
+	dir, e := ioutil.TempDir("", "snapshot")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "chainstate.snapshot")
+	state, _, e := writeChainSnapshot(chain.db, chain.params.Net, path, nil)
+	if e != nil {
+		t.Fatalf("writeChainSnapshot: %v", e)
+	}
+	if state.hash != *chain.params.GenesisHash {
+		t.Fatalf("snapshot of %v, want the genesis block %v", state.hash, chain.params.GenesisHash)
+	}
+	if _, e = readChainSnapshot(path, chaincfg.TestNet3Params.Net, nil); e == nil {
+		t.Error("expected an error reading a snapshot of another network")
+	}
+	corruptChainState := func() {
+		e = chain.db.Update(
+			func(dbTx database.Tx) (e error) {
+				return dbTx.Metadata().Put(chainStateKeyName, []byte{1})
+			},
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+	}
+	config := &Config{
+		DB:          chain.db,
+		ChainParams: chain.params,
+		TimeSource:  NewMedianTime(),
+	}
+	corruptChainState()
+	if _, e = New(config); e == nil {
+		t.Fatal("expected an error loading a corrupt chain state without a snapshot")
+	}
+	config.SnapshotPath = path
+	recovered, e := New(config)
+	if e != nil {
+		t.Fatalf("New: %v", e)
+	}
+	if best := recovered.BestSnapshot(); best.Hash != state.hash || best.Height != int32(state.height) {
+		t.Fatalf("recovered chain state at height %d (%v), want %d (%v)", best.Height, best.Hash, state.height, state.hash)
+	}
+	if requested, e := recovered.recoveryRequested(); e != nil || requested {
+		t.Fatalf("chain state still marked for recovery: %v", e)
+	}
+	// A snapshot that has been changed is refused before the chain state is touched.
+	data, e := ioutil.ReadFile(path)
+	if e != nil {
+		t.Fatal(e)
+	}
+	data[len(data)/2] ^= 1
+	if e = ioutil.WriteFile(path, data, 0600); e != nil {
+		t.Fatal(e)
+	}
+	corruptChainState()
+	if _, e = New(config); e == nil {
+		t.Fatal("expected an error recovering from a corrupt snapshot")
+	}
+}
+
+// testIndexManager is an IndexManager with a single index that, like the indexers, refuses a block that does not
+// extend its tip.
+type testIndexManager struct {
+	tip   chainhash.Hash
+	inits int
+}
+
+func (m *testIndexManager) Init(chain *BlockChain, interrupt <-chan struct{}) error {
+	m.inits++
+	return nil
+}
+
+func (m *testIndexManager) ConnectBlock(dbTx database.Tx, blk *block.Block, stxos []SpentTxOut) error {
+	if blk.WireBlock().Header.PrevBlock != m.tip {
+		return AssertError(fmt.Sprintf("block %v does not extend the index tip %v", blk.Hash(), m.tip))
+	}
+	m.tip = *blk.Hash()
+	return nil
+}
+
+func (m *testIndexManager) DisconnectBlock(dbTx database.Tx, blk *block.Block, stxos []SpentTxOut) error {
+	if *blk.Hash() != m.tip {
+		return AssertError(fmt.Sprintf("block %v is not the index tip %v", blk.Hash(), m.tip))
+	}
+	m.tip = blk.WireBlock().Header.PrevBlock
+	return nil
+}
+
+// connectTestBlock stores a block with only a coinbase on top of the best chain and connects it.
+func connectTestBlock(t *testing.T, chain *BlockChain) {
+	tip := chain.BestChain.Tip()
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex),
+			SignatureScript:  []byte{byte(tip.height + 1), 0},
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	coinbase.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_TRUE}))
+	msgBlock := &wire.Block{
+		Header: wire.BlockHeader{
+			Version:    tip.version,
+			PrevBlock:  tip.hash,
+			MerkleRoot: coinbase.TxHash(),
+			Timestamp:  time.Unix(tip.timestamp+60, 0),
+			Bits:       tip.bits,
+		},
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+	blk := block.NewBlock(msgBlock)
+	blk.SetHeight(tip.height + 1)
+	if e := chain.db.Update(
+		func(dbTx database.Tx) error {
+			return dbTx.StoreBlock(blk)
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	node := NewBlockNode(&msgBlock.Header, tip)
+	chain.Index.AddNode(node)
+	chain.Index.SetStatusFlags(node, statusDataStored|statusValid)
+	view := NewUtxoViewpoint()
+	view.SetBestHash(&tip.hash)
+	var stxos []SpentTxOut
+	if e := view.connectTransactions(blk, &stxos); e != nil {
+		t.Fatal(e)
+	}
+	chain.ChainLock.Lock()
+	defer chain.ChainLock.Unlock()
+	if e := chain.connectBlock(node, blk, view, stxos); e != nil {
+		t.Fatalf("connectBlock: %v", e)
+	}
+}
+
+// TestChainSnapshotRecoveryIndexes ensures the blocks replayed after a snapshot are not given to the indexes, which
+// already have them, and that the index manager is initialized to catch up the indexes after the recovery.
+func TestChainSnapshotRecoveryIndexes(t *testing.T) {
+	chain, teardownFunc, e := chainSetup("snapshotindexes", &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("Failed to setup chain instance: %v", e)
+	}
+	defer teardownFunc()
+	dir, e := ioutil.TempDir("", "snapshot")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "chainstate.snapshot")
+	if _, _, e = writeChainSnapshot(chain.db, chain.params.Net, path, nil); e != nil {
+		t.Fatalf("writeChainSnapshot: %v", e)
+	}
+	indexes := &testIndexManager{tip: *chain.params.GenesisHash}
+	chain.indexManager = indexes
+	const replayed = 2
+	for i := 0; i < replayed; i++ {
+		connectTestBlock(t, chain)
+	}
+	tip := chain.BestSnapshot()
+	if e = chain.db.Update(
+		func(dbTx database.Tx) (e error) {
+			return dbTx.Metadata().Put(chainStateRecoverKeyName, []byte{1})
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	recovered, e := New(
+		&Config{
+			DB:           chain.db,
+			ChainParams:  chain.params,
+			TimeSource:   NewMedianTime(),
+			IndexManager: indexes,
+			SnapshotPath: path,
+		},
+	)
+	if e != nil {
+		t.Fatalf("New: %v", e)
+	}
+	if best := recovered.BestSnapshot(); best.Hash != tip.Hash || best.Height != replayed {
+		t.Fatalf("recovered chain state at height %d (%v), want %d (%v)", best.Height, best.Hash, replayed, tip.Hash)
+	}
+	if indexes.tip != tip.Hash {
+		t.Errorf("index tip %v, want %v", indexes.tip, tip.Hash)
+	}
+	if indexes.inits != 1 {
+		t.Errorf("index manager initialized %d times, want 1", indexes.inits)
+	}
+	if recovered.indexManager != indexes {
+		t.Error("index manager not restored after the recovery")
+	}
+}
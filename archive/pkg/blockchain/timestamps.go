@@ -0,0 +1,149 @@
+package blockchain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// MaxTimestampInfoBlocks is the most recent blocks the timestamps of the algorithms can be measured over.
+const MaxTimestampInfoBlocks = 20000
+
+// TimestampInfo is the median time past of the tip of the best chain, the range of timestamps a block extending it may
+// have, and how the timestamps of the recent blocks of each algorithm drift, for miners to set valid timestamps and to
+// spot attempts to warp the time of the chain.
+type TimestampInfo struct {
+	Height     int32
+	Hash       chainhash.Hash
+	Time       time.Time
+	MedianTime time.Time
+	// AdjustedTime is the time of the node adjusted by the median offset of the clocks of its peers.
+	AdjustedTime time.Time
+	// MinTime and MaxTime are the earliest and the latest timestamps the next block may have.
+	MinTime time.Time
+	MaxTime time.Time
+	// Blocks is the number of recent blocks the timestamps of the algorithms are measured over.
+	Blocks int32
+	Algos  []AlgoTimestamps
+}
+
+// AlgoTimestamps is how the timestamps of the recent blocks of an algorithm drift. The timestamp of each block is
+// measured against the median time past of its parent, the earliest timestamp it could have had before the hard fork,
+// so blocks with timestamps close to it while their time is far ahead of it point to an attempt to warp time.
+type AlgoTimestamps struct {
+	Name       string
+	Version    int32
+	Blocks     int
+	LastHeight int32
+	LastTime   time.Time
+	// TargetSpacing is the spacing in seconds between the blocks of the algorithm that its difficulty adjustment aims
+	// for, and MeanSpacing the mean spacing between its recent blocks.
+	TargetSpacing int64
+	MeanSpacing   float64
+	// MinAhead, MeanAhead and MaxAhead are the smallest, mean and largest number of seconds the timestamps of its
+	// blocks are ahead of the median time past of their parents.
+	MinAhead  int64
+	MeanAhead float64
+	MaxAhead  int64
+	// Backwards is the number of its blocks with a timestamp before that of their parent.
+	Backwards int
+}
+
+// TimestampInfo returns the median time past of the tip of the best chain, the range of timestamps of the next block,
+// and the drift of the timestamps of each algorithm over at most the given number of recent blocks. This function is
+// safe for concurrent access.
+func (b *BlockChain) TimestampInfo(blocks int32) *TimestampInfo {
+	return timestampInfo(b.BestChain.Tip(), blocks, b.timeSource.AdjustedTime())
+}
+
+// nextMinTimestamp returns the earliest timestamp a block extending the node may have. Before the hard fork it must be
+// after the median time past of the node, and from the hard fork on at least a second after the node.
+func nextMinTimestamp(node *BlockNode) time.Time {
+	if fork.GetCurrent(node.height+1) > 0 {
+		return time.Unix(node.timestamp+1, 0)
+	}
+	return node.CalcPastMedianTime().Add(time.Second)
+}
+
+// algoTargetSpacing returns the spacing in seconds between the blocks of the algorithm at the height that its
+// difficulty adjustment aims for.
+func algoTargetSpacing(name string, height int32) int64 {
+	if hf := fork.GetCurrent(height); hf > 0 {
+		if p, ok := fork.GetAlgoParams(hf, name); ok {
+			return int64(p.VersionInterval)
+		}
+	}
+	return fork.GetTargetTimePerBlock(height)
+}
+
+// timestampInfo returns the timestamp information of the chain ending with the tip, measured over at most the given
+// number of blocks, at the adjusted time.
+func timestampInfo(tip *BlockNode, blocks int32, adjusted time.Time) (info *TimestampInfo) {
+	info = &TimestampInfo{
+		Height:       tip.height,
+		Hash:         tip.hash,
+		Time:         time.Unix(tip.timestamp, 0),
+		MedianTime:   tip.CalcPastMedianTime(),
+		AdjustedTime: adjusted.Truncate(time.Second),
+		MinTime:      nextMinTimestamp(tip),
+		MaxTime:      adjusted.Truncate(time.Second).Add(time.Second * MaxTimeOffsetSeconds),
+	}
+	type algoSums struct {
+		AlgoTimestamps
+		first int64
+		ahead int64
+	}
+	algos := make(map[string]*algoSums)
+	// the genesis block has no parent to be measured against
+	for node := tip; node != nil && node.parent != nil && info.Blocks < blocks; node = node.parent {
+		info.Blocks++
+		name := fork.GetAlgoName(node.version, node.height)
+		ahead := node.timestamp - node.parent.CalcPastMedianTime().Unix()
+		a, ok := algos[name]
+		if !ok {
+			// the blocks are visited newest first
+			a = &algoSums{
+				AlgoTimestamps: AlgoTimestamps{
+					Name:          name,
+					Version:       fork.GetAlgoVer(name, node.height),
+					LastHeight:    node.height,
+					LastTime:      time.Unix(node.timestamp, 0),
+					TargetSpacing: algoTargetSpacing(name, node.height),
+					MinAhead:      ahead,
+					MaxAhead:      ahead,
+				},
+			}
+			algos[name] = a
+		}
+		a.Blocks++
+		a.first = node.timestamp
+		a.ahead += ahead
+		if ahead < a.MinAhead {
+			a.MinAhead = ahead
+		}
+		if ahead > a.MaxAhead {
+			a.MaxAhead = ahead
+		}
+		if node.timestamp < node.parent.timestamp {
+			a.Backwards++
+		}
+	}
+	for _, a := range algos {
+		a.MeanAhead = float64(a.ahead) / float64(a.Blocks)
+		if a.Blocks > 1 {
+			a.MeanSpacing = float64(a.LastTime.Unix()-a.first) / float64(a.Blocks-1)
+		}
+		info.Algos = append(info.Algos, a.AlgoTimestamps)
+	}
+	sort.Slice(
+		info.Algos, func(i, j int) bool {
+			if info.Algos[i].Version != info.Algos[j].Version {
+				return info.Algos[i].Version < info.Algos[j].Version
+			}
+			return info.Algos[i].Name < info.Algos[j].Name
+		},
+	)
+	return
+}
@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// TestTimestampInfo ensures the range of timestamps of the next block follows the median time past of the tip before
+// the hard fork, and that the timestamps of each algorithm are measured against the median time past of their parents.
+func TestTimestampInfo(t *testing.T) {
+	params := &chaincfg.RegressionTestParams
+	genesis := NewBlockNode(&params.GenesisBlock.Header, nil)
+	start := time.Unix(genesis.timestamp, 0)
+	// sha256d blocks every 100 seconds with a scrypt block between each, the last of which is set back in time
+	node := genesis
+	for i := 1; i <= 20; i++ {
+		version, ts := int32(2), start.Add(time.Duration(i)*50*time.Second)
+		if i%2 == 1 {
+			version = 514
+		}
+		if i == 19 {
+			ts = time.Unix(node.timestamp-10, 0)
+		}
+		node = newFakeNode(node, version, params.PowLimitBits, ts)
+	}
+	adjusted := time.Unix(node.timestamp+30, 0).Add(time.Millisecond)
+	info := timestampInfo(node, 10, adjusted)
+	if info.Height != 20 || info.Blocks != 10 {
+		t.Fatalf("measured %d blocks from height %d, want 10 from 20", info.Blocks, info.Height)
+	}
+	if !info.MinTime.Equal(info.MedianTime.Add(time.Second)) {
+		t.Errorf("earliest next timestamp %v is not a second after the median time %v", info.MinTime, info.MedianTime)
+	}
+	if want := time.Unix(node.timestamp+30+MaxTimeOffsetSeconds, 0); !info.MaxTime.Equal(want) {
+		t.Errorf("latest next timestamp %v, want %v", info.MaxTime, want)
+	}
+	if len(info.Algos) != 2 || info.Algos[0].Name != fork.SHA256d || info.Algos[1].Name != fork.Scrypt {
+		t.Fatalf("algorithms %+v, want sha256d and scrypt", info.Algos)
+	}
+	sha, scrypt := info.Algos[0], info.Algos[1]
+	if sha.Blocks != 5 || sha.LastHeight != 20 || sha.MeanSpacing != 100 || sha.Backwards != 0 {
+		t.Errorf("sha256d %+v, want 5 blocks spaced 100 seconds up to height 20", sha)
+	}
+	if scrypt.Blocks != 5 || scrypt.LastHeight != 19 || scrypt.Backwards != 1 {
+		t.Errorf("scrypt %+v, want 5 blocks up to height 19 with one set back", scrypt)
+	}
+	// the median time past of the parents of blocks 50 seconds apart is 300 seconds behind, the block set back in time
+	// is 60 seconds before the timestamp it would have had
+	if sha.MinAhead != 300 || sha.MaxAhead != 300 || scrypt.MinAhead != 240 || scrypt.MaxAhead != 300 {
+		t.Errorf("sha256d ahead %d to %d, scrypt ahead %d to %d", sha.MinAhead, sha.MaxAhead, scrypt.MinAhead, scrypt.MaxAhead)
+	}
+	if info = timestampInfo(node, MaxTimestampInfoBlocks, adjusted); info.Blocks != 20 {
+		t.Errorf("measured %d blocks of a chain of 20 and its genesis block", info.Blocks)
+	}
+}
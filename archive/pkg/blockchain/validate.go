@@ -120,23 +120,19 @@ func (b *BlockChain) checkConnectBlock(
 	//
 	// These utxo entries are needed for verification of things such as transaction inputs, counting
 	// pay-to-script-hashes, and scripts.
+	fetchStart := time.Now()
 	e = view.fetchInputUtxos(b.db, block)
+	b.curTrace.add(phaseUtxoFetch, fetchStart)
 	if e != nil {
 		return e
 	}
-	// BIP0016 describes a pay-to-script-hash type that is considered a "standard" type. The rules for this BIP only
-	// apply to transactions after the timestamp defined by txscript.Bip16Activation.
+	// The rules scripts are checked with are those of the network upgrades of the chain parameters activated at or
+	// below the height of the block. Among them is BIP0016, which describes a pay-to-script-hash type that is
+	// considered a "standard" type, and whose signature operations are counted when it applies.
 	//
 	// See https://en.bitcoin.it/wiki/BIP_0016 for more details.
-	enforceBIP0016 := node.timestamp >= txscript.Bip16Activation.Unix()
-	// // Query for the Version Bits state for the segwit soft-fork deployment. If segwit is active, we'll switch over to
-	// // enforcing all the new rules.
-	// var segwitState ThresholdState
-	// segwitState, e = b.deploymentState(node.parent, chaincfg.DeploymentSegwit)
-	// if e != nil  {
-	// 		// 	return e
-	// }
-	// enforceSegWit := segwitState == ThresholdActive
+	scriptFlags := b.ScriptFlags(node.height)
+	enforceBIP0016 := scriptFlags&txscript.ScriptBip16 == txscript.ScriptBip16
 	// The number of signature operations must be less than the maximum allowed per block. Note that the preliminary
 	// sanity checks on a block also include a check similar to this one, but this check expands the count to include a
 	// precise count of pay-to -script-hash signature operations in each of the input transaction public key scripts.
@@ -294,31 +290,14 @@ func (b *BlockChain) checkConnectBlock(
 	if checkpoint != nil && node.height <= checkpoint.Height {
 		runScripts = false
 	}
-	// BlockC created after the BIP0016 activation time need to have the pay -to-script-hash checks enabled.
-	var scriptFlags txscript.ScriptFlags
-	if enforceBIP0016 {
-		scriptFlags |= txscript.ScriptBip16
+	// Scripts are not run either for blocks buried under the assumed valid block, whose scripts have been verified by
+	// whoever chose it.
+	if runScripts && b.isAssumedValid(node) {
+		runScripts = false
 	}
-	// // Enforce DER signatures for block versions 3+ once the historical activation threshold has been reached. This is
-	// // part of BIP0066.
-	// blockHeader := &block.Block().Header
-	// if blockHeader.Version >= 3 && node.height >= b.params.BIP0066Height {
-	// 	scriptFlags |= txscript.ScriptVerifyDERSignatures
-	// }
-	// // Enforce CHECKLOCKTIMEVERIFY for block versions 4+ once the historical activation threshold has been reached. This
-	// // is part of BIP0065.
-	// if blockHeader.Version >= 4 && node.height >= b.params.BIP0065Height {
-	// 	scriptFlags |= txscript.ScriptVerifyCheckLockTimeVerify
-	// }
-	// // Enforce CHECKSEQUENCEVERIFY during all block validation checks once the soft-fork deployment is fully active.
-	// csvState, e := b.deploymentState(node.parent, chaincfg.DeploymentCSV)
-	// if e != nil  {
-	// 		// 	return e
-	// }
-	// if csvState == ThresholdActive {
-	// 	// If the CSV soft-fork is now active, then modify the scriptFlags to ensure that the CSV op code is properly
-	// 	// validated during the script checks bleow.
-	// 	scriptFlags |= txscript.ScriptVerifyCheckSequenceVerify
+	// // Enforce the relative sequence number based lock-times once CHECKSEQUENCEVERIFY is in force, which is part of
+	// // the CSV soft-fork package.
+	// if scriptFlags&txscript.ScriptVerifyCheckSequenceVerify == txscript.ScriptVerifyCheckSequenceVerify {
 	// 	// We obtain the MTP of the *previous* block in order to determine if transactions in the current block are
 	// 	// final.
 	// 	medianTime := node.parent.CalcPastMedianTime()
@@ -346,19 +325,16 @@ func (b *BlockChain) checkConnectBlock(
 	// 		}
 	// 	}
 	// }
-	// // Enforce the segwit soft-fork package once the soft-fork has shifted into the "active" version bits state.
-	// if enforceSegWit {
-	// 	scriptFlags |= txscript.ScriptVerifyWitness
-	// 	scriptFlags |= txscript.ScriptStrictMultiSig
-	// }
 	// Now that the inexpensive checks are done and have passed, verify the transactions are actually allowed to spend
 	// the coins by running the expensive ECDSA signature check scripts. Doing this last helps prevent CPU exhaustion
 	// attacks.
 	if runScripts {
+		scriptStart := time.Now()
 		e := checkBlockScripts(
 			block, view, scriptFlags, b.sigCache,
 			b.hashCache,
 		)
+		b.curTrace.add(phaseScriptCheck, scriptStart)
 		if e != nil {
 			return e
 		}
@@ -402,6 +378,7 @@ func (b *BlockChain) CheckConnectBlockTemplate(block *block.Block) (e error) {
 		false,
 		block.Height(),
 		tip.Header().Timestamp,
+		nil,
 	); E.Chk(e) {
 		return e
 	}
@@ -702,7 +679,8 @@ func CalcBlockSubsidy(height int32, chainParams *chaincfg.Params, version int32)
 		}
 		// Plan 9 hard fork prescribes a smooth supply curve made using an exponential decay formula adjusted to fit the
 		// previous halving cycle and accounting for the block time difference
-		ttpb := float64(fork.List[1].Algos[fork.GetAlgoName(version, height)].VersionInterval)
+		p, _ := fork.GetAlgoParams(1, fork.GetAlgoName(version, height))
+		ttpb := float64(p.VersionInterval)
 		r = int64(2.7 * ttpb / 300 * (math.Pow(2.7, -float64(height)*300*9/ttpb/375000.0)) * 100000000 / 9)
 	}
 	return
@@ -721,7 +699,7 @@ func CheckBlockSanity(
 	prevBlockTimestamp time.Time,
 ) (e error) {
 	F.Ln("CheckBlockSanity powlimit %64x", powLimit)
-	return checkBlockSanity(block, powLimit, timeSource, BFNone, DoNotCheckPow, height, prevBlockTimestamp)
+	return checkBlockSanity(block, powLimit, timeSource, BFNone, DoNotCheckPow, height, prevBlockTimestamp, nil)
 }
 
 // CheckProofOfWork ensures the block header bits which indicate the target difficulty is in min/max range and that the
@@ -1204,6 +1182,9 @@ func checkBlockHeaderSanity(
 //
 // The flags do not modify the behavior of this function directly, however they
 // are needed to pass along to checkBlockHeaderSanity.
+//
+// The time taken to check the merkle root is added to the trace, which may be
+// nil.
 func checkBlockSanity(
 	block *block.Block,
 	powLimit *big.Int,
@@ -1212,6 +1193,7 @@ func checkBlockSanity(
 	DoNotCheckPow bool,
 	height int32,
 	prevBlockTimestamp time.Time,
+	trace *ValidationTrace,
 ) (e error) {
 	T.F("checkBlockSanity %08x %064x", block.WireBlock().Header.Bits, powLimit)
 	msgBlock := block.WireBlock()
@@ -1275,8 +1257,10 @@ func checkBlockSanity(
 	//
 	// Bitcoind builds the tree here and checks the merkle root after the following
 	// checks, but there is no reason not to check the merkle root matches here.
+	merkleStart := time.Now()
 	merkles := BuildMerkleTreeStore(block.Transactions(), false)
 	calculatedMerkleRoot := merkles.GetRoot()
+	trace.add(phaseMerkle, merkleStart)
 	if !header.MerkleRoot.IsEqual(calculatedMerkleRoot) {
 		str := fmt.Sprintf(
 			"block merkle root is invalid - block "+
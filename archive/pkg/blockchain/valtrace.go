@@ -0,0 +1,182 @@
+package blockchain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+const (
+	// ValidationTraceLogSize is the number of the most recently processed blocks the validation trace log keeps.
+	ValidationTraceLogSize = 256
+	// maxPendingDeserialize is the most deserialize timings of blocks that have not yet been processed that are kept.
+	// Timings are dropped rather than letting the set grow without bound if blocks are decoded but never processed.
+	maxPendingDeserialize = 1024
+)
+
+// tracePhase is a phase of block validation timed by a ValidationTrace.
+type tracePhase int
+
+const (
+	phaseDeserialize tracePhase = iota
+	phaseMerkle
+	phaseUtxoFetch
+	phaseScriptCheck
+	phaseUtxoFlush
+)
+
+// ValidationTrace is the time spent in each phase of processing a block.
+type ValidationTrace struct {
+	Hash   chainhash.Hash
+	Height int32
+	// Time is when processing of the block finished.
+	Time time.Time
+	// Deserialize is the time taken to decode the block, from the network or from the database during a reorganize.
+	Deserialize time.Duration
+	// Merkle is the time taken to build the merkle tree of the block and check its root.
+	Merkle time.Duration
+	// UtxoFetch is the time taken to load the outputs spent by the block into the utxo view.
+	UtxoFetch time.Duration
+	// ScriptCheck is the time taken to validate the scripts of the transactions in the block.
+	ScriptCheck time.Duration
+	// UtxoFlush is the time taken to write the block, the utxo set and the spend journal to the database.
+	UtxoFlush time.Duration
+	// Total is the time taken to process the block, including the phases above.
+	Total time.Duration
+
+	start time.Time
+}
+
+// add adds the time since start to a phase of the trace. Nothing is recorded when the trace is nil, so the phases of
+// block processing can be timed unconditionally.
+func (t *ValidationTrace) add(phase tracePhase, start time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(start)
+	switch phase {
+	case phaseDeserialize:
+		t.Deserialize += d
+	case phaseMerkle:
+		t.Merkle += d
+	case phaseUtxoFetch:
+		t.UtxoFetch += d
+	case phaseScriptCheck:
+		t.ScriptCheck += d
+	case phaseUtxoFlush:
+		t.UtxoFlush += d
+	}
+}
+
+// valTraceLog is a rolling log of the validation traces of the most recently processed blocks.
+type valTraceLog struct {
+	sync.Mutex
+	traces  []ValidationTrace
+	next    int
+	pending map[chainhash.Hash]time.Duration
+}
+
+// newValTraceLog returns an empty validation trace log.
+func newValTraceLog() *valTraceLog {
+	return &valTraceLog{
+		traces:  make([]ValidationTrace, 0, ValidationTraceLogSize),
+		pending: make(map[chainhash.Hash]time.Duration),
+	}
+}
+
+// push adds a trace to the log, replacing the oldest one when the log is full.
+func (l *valTraceLog) push(t ValidationTrace) {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.traces) < ValidationTraceLogSize {
+		l.traces = append(l.traces, t)
+	} else {
+		l.traces[l.next] = t
+	}
+	l.next = (l.next + 1) % ValidationTraceLogSize
+}
+
+// recent returns up to count of the most recent traces, newest first.
+func (l *valTraceLog) recent(count int) (traces []ValidationTrace) {
+	l.Lock()
+	defer l.Unlock()
+	if count > len(l.traces) {
+		count = len(l.traces)
+	}
+	traces = make([]ValidationTrace, 0, count)
+	for i := 1; i <= count; i++ {
+		traces = append(traces, l.traces[(l.next-i+ValidationTraceLogSize)%ValidationTraceLogSize])
+	}
+	return
+}
+
+// ValTraceEnabled returns whether the chain records validation traces.
+func (b *BlockChain) ValTraceEnabled() bool {
+	return b.valTrace != nil
+}
+
+// TraceDeserialize records how long it took to decode a block that has been received but not yet processed, to be
+// added to its validation trace when it is processed. It does nothing unless validation tracing is enabled.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) TraceDeserialize(hash *chainhash.Hash, d time.Duration) {
+	if b.valTrace == nil {
+		return
+	}
+	b.valTrace.Lock()
+	defer b.valTrace.Unlock()
+	if len(b.valTrace.pending) >= maxPendingDeserialize {
+		b.valTrace.pending = make(map[chainhash.Hash]time.Duration)
+	}
+	b.valTrace.pending[*hash] = d
+}
+
+// ValidationTraces returns up to count of the validation traces of the most recently processed blocks, newest first.
+// Nothing is returned unless validation tracing is enabled.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ValidationTraces(count int) []ValidationTrace {
+	if b.valTrace == nil || count < 1 {
+		return nil
+	}
+	return b.valTrace.recent(count)
+}
+
+// startTrace begins the validation trace of a block and makes it the current trace, if validation tracing is enabled.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) startTrace(hash *chainhash.Hash, height int32) *ValidationTrace {
+	if b.valTrace == nil {
+		return nil
+	}
+	t := &ValidationTrace{Hash: *hash, Height: height, start: time.Now()}
+	b.valTrace.Lock()
+	if d, ok := b.valTrace.pending[*hash]; ok {
+		t.Deserialize = d
+		delete(b.valTrace.pending, *hash)
+	}
+	b.valTrace.Unlock()
+	b.curTrace = t
+	return t
+}
+
+// finishTrace completes a validation trace, adds it to the log and logs the breakdown of the time spent on the block.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) finishTrace(t *ValidationTrace) {
+	if t == nil {
+		return
+	}
+	t.Time = time.Now()
+	t.Total = t.Time.Sub(t.start)
+	// The height of an orphan is not known until it has been accepted.
+	if node := b.Index.LookupNode(&t.Hash); node != nil {
+		t.Height = node.height
+	}
+	b.valTrace.push(*t)
+	I.F(
+		"valtrace block %d %v total %v deserialize %v merkle %v utxofetch %v scriptcheck %v utxoflush %v",
+		t.Height, t.Hash, t.Total, t.Deserialize, t.Merkle, t.UtxoFetch, t.ScriptCheck, t.UtxoFlush,
+	)
+}
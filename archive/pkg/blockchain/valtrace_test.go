@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+// TestValTraceLog ensures the validation trace log returns the most recent traces newest first and drops the oldest
+// traces once it is full.
+func TestValTraceLog(t *testing.T) {
+	l := newValTraceLog()
+	if got := l.recent(10); len(got) != 0 {
+		t.Fatalf("empty log returned %d traces", len(got))
+	}
+	total := ValidationTraceLogSize + 10
+	for i := 0; i < total; i++ {
+		l.push(ValidationTrace{Height: int32(i)})
+	}
+	got := l.recent(ValidationTraceLogSize + 1)
+	if len(got) != ValidationTraceLogSize {
+		t.Fatalf("got %d traces, want %d", len(got), ValidationTraceLogSize)
+	}
+	for i, trace := range got {
+		if want := int32(total - 1 - i); trace.Height != want {
+			t.Fatalf("trace %d has height %d, want %d", i, trace.Height, want)
+		}
+	}
+	if got := l.recent(3); len(got) != 3 || got[0].Height != int32(total-1) {
+		t.Fatalf("unexpected most recent traces %v", got)
+	}
+}
+
+// TestValidationTraceAdd ensures phase timings add up and that timing with a nil trace does nothing.
+func TestValidationTraceAdd(t *testing.T) {
+	var nilTrace *ValidationTrace
+	nilTrace.add(phaseMerkle, time.Now())
+	trace := &ValidationTrace{}
+	start := time.Now().Add(-time.Second)
+	trace.add(phaseScriptCheck, start)
+	trace.add(phaseScriptCheck, start)
+	if trace.ScriptCheck < 2*time.Second {
+		t.Fatalf("script check time %v, want at least 2s", trace.ScriptCheck)
+	}
+	if trace.Merkle != 0 || trace.UtxoFetch != 0 || trace.UtxoFlush != 0 || trace.Deserialize != 0 {
+		t.Fatalf("time added to the wrong phase: %+v", trace)
+	}
+}
+
+// TestTraceDeserialize ensures a recorded deserialize time is picked up by the trace of the block.
+func TestTraceDeserialize(t *testing.T) {
+	b := &BlockChain{}
+	hash := chainhash.DoubleHashH([]byte("block"))
+	b.TraceDeserialize(&hash, time.Millisecond)
+	if trace := b.startTrace(&hash, 1); trace != nil {
+		t.Fatalf("trace started with tracing disabled")
+	}
+	b.valTrace = newValTraceLog()
+	b.TraceDeserialize(&hash, time.Millisecond)
+	trace := b.startTrace(&hash, 1)
+	if trace == nil || trace.Deserialize != time.Millisecond || b.curTrace != trace {
+		t.Fatalf("unexpected trace %+v", trace)
+	}
+	if len(b.valTrace.pending) != 0 {
+		t.Fatalf("deserialize time was not consumed")
+	}
+}
@@ -1,33 +1,36 @@
 package btcaddr
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"golang.org/x/crypto/ripemd160"
 	"hash"
+	"strings"
 	
 	"github.com/p9c/pod/pkg/base58"
+	"github.com/p9c/pod/pkg/bech32"
 	"github.com/p9c/pod/pkg/chaincfg"
 	ec "github.com/p9c/pod/pkg/ecc"
 )
 
-// //
-// // // unsupportedWitnessVerError describes an error where a segwit address being
-// // // decoded has an unsupported witness version.
-// // type unsupportedWitnessVerError byte
-//
-// func (e unsupportedWitnessVerError) Error() string {
-// 	return "unsupported witness version: " + string(e)
-// }
-//
-// // unsupportedWitnessProgLenError describes an error where a segwit address
-// // being decoded has an unsupported witness program length.
-// type unsupportedWitnessProgLenError int
-//
-// func (e unsupportedWitnessProgLenError) Error() string {
-// 	return "unsupported witness program length: " + string(rune(e))
-// }
+// UnsupportedWitnessVerError describes an error where a segwit address being decoded has an unsupported witness
+// version.
+type UnsupportedWitnessVerError byte
+
+func (e UnsupportedWitnessVerError) Error() string {
+	return fmt.Sprintf("unsupported witness version: %d", byte(e))
+}
+
+// UnsupportedWitnessProgLenError describes an error where a segwit address being decoded has an unsupported witness
+// program length.
+type UnsupportedWitnessProgLenError int
+
+func (e UnsupportedWitnessProgLenError) Error() string {
+	return fmt.Sprintf("unsupported witness program length: %d", int(e))
+}
 
 var (
 	// ErrChecksumMismatch describes an error where decoding failed due to a bad checksum.
@@ -41,6 +44,9 @@ var (
 	// kinds, but for different networks. Rather than assuming or defaulting to one or the other, this error is returned
 	// and the caller must decide how to decode the address.
 	ErrAddressCollision = errors.New("address collision")
+	// ErrWrongNet describes an error where an address decoded with DecodeForNet belongs to another network than the one
+	// it was decoded for.
+	ErrWrongNet = errors.New("address is for another network")
 )
 
 // encode returns a human-readable payment address given a ripemd160 hash and netID which encodes the bitcoin
@@ -51,35 +57,33 @@ func encode(hash160 []byte, netID byte) string {
 	return base58.CheckEncode(hash160[:ripemd160.Size], netID)
 }
 
-// // encodeSegWitAddress creates a bech32 encoded address string representation
-// // from witness version and witness program.
-// func encodeSegWitAddress(hrp string, witnessVersion byte, witnessProgram []byte) (string, error) {
-// 	// Group the address bytes into 5 bit groups, as this is what is used to encode each character in the address string.
-// 	converted, e := bech32.ConvertBits(witnessProgram, 8, 5, true)
-// 	if e != nil  {
-// 		// 		return "", err
-// 	}
-// 	// Concatenate the witness version and program, and encode the resulting bytes
-// 	// using bech32 encoding.
-// 	combined := make([]byte, len(converted)+1)
-// 	combined[0] = witnessVersion
-// 	copy(combined[1:], converted)
-// 	bech, e := bech32.Encode(hrp, combined)
-// 	if e != nil  {
-// 		// 		return "", err
-// 	}
-// 	// Chk validity by decoding the created address.
-// 	var program []byte
-// 	var version byte
-// 	version, program, e = decodeSegWitAddress(bech)
-// 	if e != nil  {
-// 		// 		return "", fmt.Errorf("invalid segwit address: %v", err)
-// 	}
-// 	if version != witnessVersion || !bytes.Equal(program, witnessProgram) {
-// 		return "", fmt.Errorf("invalid segwit address")
-// 	}
-// 	return bech, nil
-// }
+// encodeSegWitAddress creates a bech32 encoded address string representation from witness version and witness
+// program.
+func encodeSegWitAddress(hrp string, witnessVersion byte, witnessProgram []byte) (bech string, e error) {
+	// Group the address bytes into 5 bit groups, as this is what is used to encode each character in the address
+	// string.
+	var converted []byte
+	if converted, e = bech32.ConvertBits(witnessProgram, 8, 5, true); e != nil {
+		return
+	}
+	// Concatenate the witness version and program, and encode the resulting bytes using bech32 encoding.
+	combined := make([]byte, len(converted)+1)
+	combined[0] = witnessVersion
+	copy(combined[1:], converted)
+	if bech, e = bech32.Encode(hrp, combined); e != nil {
+		return
+	}
+	// Chk validity by decoding the created address.
+	var program []byte
+	var version byte
+	if version, program, e = decodeSegWitAddress(bech); e != nil {
+		return "", fmt.Errorf("invalid segwit address: %v", e)
+	}
+	if version != witnessVersion || !bytes.Equal(program, witnessProgram) {
+		return "", errors.New("invalid segwit address")
+	}
+	return
+}
 
 // Address is an interface type for any type of destination a transaction output may spend to. This includes
 // pay-to-pubkey (P2PK), pay-to-pubkey-hash (P2PKH), and pay-to-script-hash (P2SH). Address is designed to be generic
@@ -112,34 +116,32 @@ type Address interface {
 // address does not encode the network, such as in the case of a raw public key,
 // the address will be associated with the passed defaultNet.
 func Decode(addr string, defaultNet *chaincfg.Params) (Address, error) {
-	// // Bech32 encoded segwit addresses start with a human-readable part (hrp) followed by '1'. For Bitcoin mainnet the
-	// // hrp is "bc", and for testnet it is "tb". If the address string has a prefix that matches one of the prefixes for
-	// // the known networks, we try to decode it as a segwit address.
-	// oneIndex := strings.LastIndexByte(addr, '1')
-	// if oneIndex > 1 {
-	// 	prefix := addr[:oneIndex+1]
-	// 	if chaincfg.IsBech32SegwitPrefix(prefix) {
-	// 		witnessVer, witnessProg, e := decodeSegWitAddress(addr)
-	// 		if e != nil  {
-	// 				// 			return nil, e
-	// 		}
-	// 		// We currently only support P2WPKH and P2WSH, which is witness version 0.
-	// 		if witnessVer != 0 {
-	// 			return nil, unsupportedWitnessVerError(witnessVer)
-	// 		}
-	// 		// The HRP is everything before the found '1'.
-	// 		hrp := prefix[:len(prefix)-1]
-	// 		switch len(witnessProg) {
-	// 		case 20:
-	// 			return newAddressWitnessPubKeyHash(hrp, witnessProg)
-	// 		case 32:
-	// 			return newAddressWitnessScriptHash(hrp, witnessProg)
-	// 		default:
-	// 			return nil, unsupportedWitnessProgLenError(len(witnessProg))
-	// 		}
-	// 	}
-	// }
-	//
+	// Bech32 encoded segwit addresses start with a human-readable part (hrp) followed by '1'. If the address string has
+	// a prefix that matches one of the prefixes for the known networks, we try to decode it as a segwit address.
+	oneIndex := strings.LastIndexByte(addr, '1')
+	if oneIndex > 1 {
+		prefix := strings.ToLower(addr[:oneIndex+1])
+		if chaincfg.IsBech32SegwitPrefix(prefix) {
+			witnessVer, witnessProg, e := decodeSegWitAddress(addr)
+			if e != nil {
+				return nil, e
+			}
+			// Only P2WPKH and P2WSH, which are witness version 0, are supported.
+			if witnessVer != 0 {
+				return nil, UnsupportedWitnessVerError(witnessVer)
+			}
+			// The HRP is everything before the found '1'.
+			hrp := prefix[:len(prefix)-1]
+			switch len(witnessProg) {
+			case 20:
+				return newWitnessPubKeyHash(hrp, witnessProg)
+			case 32:
+				return newWitnessScriptHash(hrp, witnessProg)
+			default:
+				return nil, UnsupportedWitnessProgLenError(len(witnessProg))
+			}
+		}
+	}
 	// Serialized public keys are either 65 bytes (130 hex chars) if
 	// uncompressed/hybrid or 33 bytes (66 hex chars) if compressed.
 	if len(addr) == 130 || len(addr) == 66 {
@@ -176,41 +178,50 @@ func Decode(addr string, defaultNet *chaincfg.Params) (Address, error) {
 	}
 }
 
-// // decodeSegWitAddress parses a bech32 encoded segwit address string and returns
-// // the witness version and witness program byte representation.
-// func decodeSegWitAddress(address string) (byte, []byte, error) {
-// 	// Decode the bech32 encoded address.
-// 	_, data, e := bech32.Decode(address)
-// 	if e != nil  {
-// 		// 		return 0, nil, e
-// 	}
-// 	// The first byte of the decoded address is the witness version, it must exist.
-// 	if len(data) < 1 {
-// 		return 0, nil, fmt.Errorf("no witness version")
-// 	}
-// 	// ...and be <= 16.
-// 	version := data[0]
-// 	if version > 16 {
-// 		return 0, nil, fmt.Errorf("invalid witness version: %v", version)
-// 	}
-// 	// The remaining characters of the address returned are grouped into words of 5
-// 	// bits. In order to restore the original witness program bytes, we'll need to
-// 	// regroup into 8 bit words.
-// 	regrouped, e := bech32.ConvertBits(data[1:], 5, 8, false)
-// 	if e != nil  {
-// 		// 		return 0, nil, e
-// 	}
-// 	// The regrouped data must be between 2 and 40 bytes.
-// 	if len(regrouped) < 2 || len(regrouped) > 40 {
-// 		return 0, nil, fmt.Errorf("invalid data length")
-// 	}
-// 	// For witness version 0, address MUST be exactly 20 or 32 bytes.
-// 	if version == 0 && len(regrouped) != 20 && len(regrouped) != 32 {
-// 		return 0, nil, fmt.Errorf("invalid data length for witness "+
-// 			"version 0: %v", len(regrouped))
-// 	}
-// 	return version, regrouped, nil
-// }
+// DecodeForNet decodes the string encoding of an address like Decode, using net as the default network, and returns
+// ErrWrongNet if the address is not associated with net.
+func DecodeForNet(addr string, net *chaincfg.Params) (a Address, e error) {
+	if a, e = Decode(addr, net); e != nil {
+		return
+	}
+	if !a.IsForNet(net) {
+		return nil, ErrWrongNet
+	}
+	return
+}
+
+// decodeSegWitAddress parses a bech32 encoded segwit address string and returns the witness version and witness
+// program byte representation.
+func decodeSegWitAddress(address string) (version byte, program []byte, e error) {
+	// Decode the bech32 encoded address.
+	var data []byte
+	if _, data, e = bech32.Decode(address); e != nil {
+		return
+	}
+	// The first byte of the decoded address is the witness version, it must exist.
+	if len(data) < 1 {
+		return 0, nil, errors.New("no witness version")
+	}
+	// ...and be <= 16.
+	version = data[0]
+	if version > 16 {
+		return 0, nil, fmt.Errorf("invalid witness version: %v", version)
+	}
+	// The remaining characters of the address returned are grouped into words of 5 bits. In order to restore the
+	// original witness program bytes, we'll need to regroup into 8 bit words.
+	if program, e = bech32.ConvertBits(data[1:], 5, 8, false); e != nil {
+		return 0, nil, e
+	}
+	// The regrouped data must be between 2 and 40 bytes.
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, errors.New("invalid data length")
+	}
+	// For witness version 0, address MUST be exactly 20 or 32 bytes.
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, fmt.Errorf("invalid data length for witness version 0: %v", len(program))
+	}
+	return
+}
 
 // PubKeyHash is an Address for a pay-to-pubkey-hash (P2PKH) transaction.
 type PubKeyHash struct {
@@ -425,168 +436,173 @@ func (a *PubKey) PubKey() *ec.PublicKey {
 	return a.PublicKey
 }
 
-//
-// // AddressWitnessPubKeyHash is an Address for a pay-to-witness-pubkey-hash
-// // (P2WPKH) output. See BIP 173 for further details regarding native segregated
-// // witness address encoding:
-// // https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
-// type AddressWitnessPubKeyHash struct {
-// 	hrp            string
-// 	witnessVersion byte
-// 	witnessProgram [20]byte
-// }
-
-// // NewAddressWitnessPubKeyHash returns a new AddressWitnessPubKeyHash.
-// func NewAddressWitnessPubKeyHash(witnessProg []byte, net *chaincfg.Params) (*AddressWitnessPubKeyHash, error) {
-// 	return newAddressWitnessPubKeyHash(net.Bech32HRPSegwit, witnessProg)
-// }
-
-// newAddressWitnessPubKeyHash is an internal helper function to create an
-// // AddressWitnessPubKeyHash with a known human-readable part, rather than
-// // looking it up through its parameters.
-// func newAddressWitnessPubKeyHash(hrp string, witnessProg []byte) (*AddressWitnessPubKeyHash, error) {
-// 	// Chk for valid program length for witness version 0, which is 20 for P2WPKH.
-// 	if len(witnessProg) != 20 {
-// 		return nil, errors.New("witness program must be 20 " +
-// 			"bytes for p2wpkh")
-// 	}
-// 	addr := &AddressWitnessPubKeyHash{
-// 		hrp:            strings.ToLower(hrp),
-// 		witnessVersion: 0x00,
-// 	}
-// 	copy(addr.witnessProgram[:], witnessProg)
-// 	return addr, nil
-// }
-//
-// // EncodeAddress returns the bech32 string encoding of an
-// // AddressWitnessPubKeyHash. Part of the Address interface.
-// func (a *AddressWitnessPubKeyHash) EncodeAddress() string {
-// 	str, e := encodeSegWitAddress(a.hrp, a.witnessVersion,
-// 		a.witnessProgram[:])
-// 	if e != nil  {
-// 		// 		return ""
-// 	}
-// 	return str
-// }
-//
-// // ScriptAddress returns the witness program for this address. Part of the
-// // Address interface.
-// func (a *AddressWitnessPubKeyHash) ScriptAddress() []byte {
-// 	return a.witnessProgram[:]
-// }
-//
-// // IsForNet returns whether or not the AddressWitnessPubKeyHash is associated
-// // with the passed bitcoin network. Part of the Address interface.
-// func (a *AddressWitnessPubKeyHash) IsForNet(net *chaincfg.Params) bool {
-// 	return a.hrp == net.Bech32HRPSegwit
-// }
-//
-// // String returns a human-readable string for the AddressWitnessPubKeyHash. This
-// // is equivalent to calling EncodeAddress, but is provided so the type can be
-// // used as a fmt.Stringer. Part of the Address interface.
-// func (a *AddressWitnessPubKeyHash) String() string {
-// 	return a.EncodeAddress()
-// }
-//
-// // Hrp returns the human-readable part of the bech32 encoded
-// // AddressWitnessPubKeyHash.
-// func (a *AddressWitnessPubKeyHash) Hrp() string {
-// 	return a.hrp
-// }
-//
-// // WitnessVersion returns the witness version of the AddressWitnessPubKeyHash.
-// func (a *AddressWitnessPubKeyHash) WitnessVersion() byte {
-// 	return a.witnessVersion
-// }
-//
-// // WitnessProgram returns the witness program of the AddressWitnessPubKeyHash.
-// func (a *AddressWitnessPubKeyHash) WitnessProgram() []byte {
-// 	return a.witnessProgram[:]
-// }
-//
-// // Hash160 returns the witness program of the AddressWitnessPubKeyHash as a byte
-// // array.
-// func (a *AddressWitnessPubKeyHash) Hash160() *[20]byte {
-// 	return &a.witnessProgram
-// }
-//
-// // AddressWitnessScriptHash is an Address for a pay-to-witness-script-hash
-// // (P2WSH) output. See BIP 173 for further details regarding native segregated
-// // witness address encoding:
-// // https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
-// type AddressWitnessScriptHash struct {
-// 	hrp            string
-// 	witnessVersion byte
-// 	witnessProgram [32]byte
-// }
-//
-// // NewAddressWitnessScriptHash returns a new AddressWitnessPubKeyHash.
-// func NewAddressWitnessScriptHash(witnessProg []byte, net *chaincfg.Params) (*AddressWitnessScriptHash, error) {
-// 	return newAddressWitnessScriptHash(net.Bech32HRPSegwit, witnessProg)
-// }
-//
-// // newAddressWitnessScriptHash is an internal helper function to create an
-// // AddressWitnessScriptHash with a known human-readable part, rather than
-// // looking it up through its parameters.
-// func newAddressWitnessScriptHash(hrp string, witnessProg []byte) (*AddressWitnessScriptHash, error) {
-// 	// Chk for valid program length for witness version 0, which is 32 for P2WSH.
-// 	if len(witnessProg) != 32 {
-// 		return nil, errors.New("witness program must be 32 " +
-// 			"bytes for p2wsh")
-// 	}
-// 	addr := &AddressWitnessScriptHash{
-// 		hrp:            strings.ToLower(hrp),
-// 		witnessVersion: 0x00,
-// 	}
-// 	copy(addr.witnessProgram[:], witnessProg)
-// 	return addr, nil
-// }
-//
-// // EncodeAddress returns the bech32 string encoding of an
-// // AddressWitnessScriptHash. Part of the Address interface.
-// func (a *AddressWitnessScriptHash) EncodeAddress() string {
-// 	str, e := encodeSegWitAddress(a.hrp, a.witnessVersion,
-// 		a.witnessProgram[:])
-// 	if e != nil  {
-// 		// 		return ""
-// 	}
-// 	return str
-// }
-//
-// // ScriptAddress returns the witness program for this address. Part of the
-// // Address interface.
-// func (a *AddressWitnessScriptHash) ScriptAddress() []byte {
-// 	return a.witnessProgram[:]
-// }
-//
-// // IsForNet returns whether or not the AddressWitnessScriptHash is associated
-// // with the passed bitcoin network. Part of the Address interface.
-// func (a *AddressWitnessScriptHash) IsForNet(net *chaincfg.Params) bool {
-// 	return a.hrp == net.Bech32HRPSegwit
-// }
-//
-// // String returns a human-readable string for the AddressWitnessScriptHash. This
-// // is equivalent to calling EncodeAddress, but is provided so the type can be
-// // used as a fmt.Stringer. Part of the Address interface.
-// func (a *AddressWitnessScriptHash) String() string {
-// 	return a.EncodeAddress()
-// }
-//
-// // Hrp returns the human-readable part of the bech32 encoded
-// // AddressWitnessScriptHash.
-// func (a *AddressWitnessScriptHash) Hrp() string {
-// 	return a.hrp
-// }
-//
-// // WitnessVersion returns the witness version of the AddressWitnessScriptHash.
-// func (a *AddressWitnessScriptHash) WitnessVersion() byte {
-// 	return a.witnessVersion
-// }
-//
-// // WitnessProgram returns the witness program of the AddressWitnessScriptHash.
-// func (a *AddressWitnessScriptHash) WitnessProgram() []byte {
-// 	return a.witnessProgram[:]
-// }
+// WitnessPubKeyHash is an Address for a pay-to-witness-pubkey-hash (P2WPKH) output. See BIP 173 for further details regarding
+// native segregated witness address encoding: https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+type WitnessPubKeyHash struct {
+	hrp            string
+	witnessVersion byte
+	witnessProgram [20]byte
+}
+
+// NewWitnessPubKeyHash returns a new WitnessPubKeyHash. witnessProg must be 20 bytes.
+func NewWitnessPubKeyHash(witnessProg []byte, net *chaincfg.Params) (*WitnessPubKeyHash, error) {
+	return newWitnessPubKeyHash(net.Bech32HRPSegwit, witnessProg)
+}
+
+// newWitnessPubKeyHash is the internal API to create a WitnessPubKeyHash with a known human-readable part, rather than looking
+// it up through its parameters.
+func newWitnessPubKeyHash(hrp string, witnessProg []byte) (*WitnessPubKeyHash, error) {
+	// Chk for valid program length for witness version 0, which is 20 for P2WPKH.
+	if len(witnessProg) != 20 {
+		return nil, errors.New("witness program must be 20 bytes for p2wpkh")
+	}
+	addr := &WitnessPubKeyHash{
+		hrp:            strings.ToLower(hrp),
+		witnessVersion: 0x00,
+	}
+	copy(addr.witnessProgram[:], witnessProg)
+	return addr, nil
+}
+
+// EncodeAddress returns the bech32 string encoding of a WitnessPubKeyHash. Part of the Address interface.
+func (a *WitnessPubKeyHash) EncodeAddress() string {
+	str, e := encodeSegWitAddress(a.hrp, a.witnessVersion, a.witnessProgram[:])
+	if e != nil {
+		return ""
+	}
+	return str
+}
+
+// ScriptAddress returns the witness program for this address. Part of the Address interface.
+func (a *WitnessPubKeyHash) ScriptAddress() []byte {
+	return a.witnessProgram[:]
+}
+
+// IsForNet returns whether or not the WitnessPubKeyHash is associated with the passed bitcoin network. Part of the
+// Address interface.
+func (a *WitnessPubKeyHash) IsForNet(net *chaincfg.Params) bool {
+	return a.hrp == net.Bech32HRPSegwit
+}
+
+// String returns a human-readable string for the WitnessPubKeyHash. This is equivalent to calling EncodeAddress, but
+// is provided so the type can be used as a fmt.Stringer. Part of the Address interface.
+func (a *WitnessPubKeyHash) String() string {
+	return a.EncodeAddress()
+}
+
+// Hrp returns the human-readable part of the bech32 encoded WitnessPubKeyHash.
+func (a *WitnessPubKeyHash) Hrp() string {
+	return a.hrp
+}
+
+// WitnessVersion returns the witness version of the WitnessPubKeyHash.
+func (a *WitnessPubKeyHash) WitnessVersion() byte {
+	return a.witnessVersion
+}
+
+// WitnessProgram returns the witness program of the WitnessPubKeyHash.
+func (a *WitnessPubKeyHash) WitnessProgram() []byte {
+	return a.witnessProgram[:]
+}
+
+// Hash160 returns the witness program of the WitnessPubKeyHash as a byte array.
+func (a *WitnessPubKeyHash) Hash160() *[ripemd160.Size]byte {
+	return &a.witnessProgram
+}
+
+// WitnessScriptHash is an Address for a pay-to-witness-script-hash (P2WSH) output. See BIP 173 for further details regarding
+// native segregated witness address encoding: https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+type WitnessScriptHash struct {
+	hrp            string
+	witnessVersion byte
+	witnessProgram [32]byte
+}
+
+// NewWitnessScriptHash returns a new WitnessScriptHash. witnessProg must be 32 bytes.
+func NewWitnessScriptHash(witnessProg []byte, net *chaincfg.Params) (*WitnessScriptHash, error) {
+	return newWitnessScriptHash(net.Bech32HRPSegwit, witnessProg)
+}
+
+// newWitnessScriptHash is the internal API to create a WitnessScriptHash with a known human-readable part, rather than looking
+// it up through its parameters.
+func newWitnessScriptHash(hrp string, witnessProg []byte) (*WitnessScriptHash, error) {
+	// Chk for valid program length for witness version 0, which is 32 for P2WSH.
+	if len(witnessProg) != 32 {
+		return nil, errors.New("witness program must be 32 bytes for p2wsh")
+	}
+	addr := &WitnessScriptHash{
+		hrp:            strings.ToLower(hrp),
+		witnessVersion: 0x00,
+	}
+	copy(addr.witnessProgram[:], witnessProg)
+	return addr, nil
+}
+
+// EncodeAddress returns the bech32 string encoding of a WitnessScriptHash. Part of the Address interface.
+func (a *WitnessScriptHash) EncodeAddress() string {
+	str, e := encodeSegWitAddress(a.hrp, a.witnessVersion, a.witnessProgram[:])
+	if e != nil {
+		return ""
+	}
+	return str
+}
+
+// ScriptAddress returns the witness program for this address. Part of the Address interface.
+func (a *WitnessScriptHash) ScriptAddress() []byte {
+	return a.witnessProgram[:]
+}
+
+// IsForNet returns whether or not the WitnessScriptHash is associated with the passed bitcoin network. Part of the
+// Address interface.
+func (a *WitnessScriptHash) IsForNet(net *chaincfg.Params) bool {
+	return a.hrp == net.Bech32HRPSegwit
+}
+
+// String returns a human-readable string for the WitnessScriptHash. This is equivalent to calling EncodeAddress, but
+// is provided so the type can be used as a fmt.Stringer. Part of the Address interface.
+func (a *WitnessScriptHash) String() string {
+	return a.EncodeAddress()
+}
+
+// Hrp returns the human-readable part of the bech32 encoded WitnessScriptHash.
+func (a *WitnessScriptHash) Hrp() string {
+	return a.hrp
+}
+
+// WitnessVersion returns the witness version of the WitnessScriptHash.
+func (a *WitnessScriptHash) WitnessVersion() byte {
+	return a.witnessVersion
+}
+
+// WitnessProgram returns the witness program of the WitnessScriptHash.
+func (a *WitnessScriptHash) WitnessProgram() []byte {
+	return a.witnessProgram[:]
+}
+
+// Raw is the string of an address that was not decoded, such as one of a format unknown to this package, which is
+// kept as it is so it can be shown or passed back to where it came from. It has no script address and belongs to no
+// network.
+type Raw string
+
+// EncodeAddress returns the string of the address. Part of the Address interface.
+func (a Raw) EncodeAddress() string {
+	return string(a)
+}
+
+// ScriptAddress returns nil, as the address was not decoded. Part of the Address interface.
+func (a Raw) ScriptAddress() []byte {
+	return nil
+}
+
+// IsForNet returns false, as the network of the address is not known. Part of the Address interface.
+func (a Raw) IsForNet(net *chaincfg.Params) bool {
+	return false
+}
+
+// String returns the string of the address. Part of the Address interface.
+func (a Raw) String() string {
+	return string(a)
+}
 
 // Hash160 calculates the hash ripemd160(sha256(b)).
 func Hash160(buf []byte) []byte {
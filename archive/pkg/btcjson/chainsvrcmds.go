@@ -33,6 +33,25 @@ func NewAddNodeCmd(addr string, subCmd AddNodeSubCmd) *AddNodeCmd {
 	}
 }
 
+// CalculatePackageFeeCmd defines the calculatepackagefee JSON-RPC command.
+type CalculatePackageFeeCmd struct {
+	TxIDs         []string
+	TargetFeeRate float64
+	ChildVSize    *int64
+}
+
+// NewCalculatePackageFeeCmd returns a new instance which can be used to issue a calculatepackagefee JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewCalculatePackageFeeCmd(txIDs []string, targetFeeRate float64, childVSize *int64) *CalculatePackageFeeCmd {
+	return &CalculatePackageFeeCmd{
+		TxIDs:         txIDs,
+		TargetFeeRate: targetFeeRate,
+		ChildVSize:    childVSize,
+	}
+}
+
 // TransactionInput represents the inputs to a transaction.  Specifically a transaction hash and output number pair.
 type TransactionInput struct {
 	Txid string `json:"txid"`
@@ -82,6 +101,54 @@ func NewDecodeScriptCmd(hexScript string) *DecodeScriptCmd {
 	}
 }
 
+// DeriveAddressesCmd defines the deriveaddresses JSON-RPC command.
+type DeriveAddressesCmd struct {
+	Descriptor string
+	Range      *[]int64
+}
+
+// NewDeriveAddressesCmd returns a new instance which can be used to issue a deriveaddresses JSON-RPC command. The range
+// is the first and last index to derive addresses for, or the last index alone to start from zero, and must be nil
+// unless the descriptor is a range.
+func NewDeriveAddressesCmd(descriptor string, rng *[]int64) *DeriveAddressesCmd {
+	return &DeriveAddressesCmd{
+		Descriptor: descriptor,
+		Range:      rng,
+	}
+}
+
+// Modes of the estimatesmartfee JSON-RPC command.
+const (
+	// EstimateModeBlocks estimates the fee for confirmation within a number of blocks.
+	EstimateModeBlocks = "blocks"
+	// EstimateModeTime estimates the fee for confirmation within a number of minutes.
+	EstimateModeTime = "time"
+)
+
+// EstimateSmartFeeCmd defines the estimatesmartfee JSON-RPC command.
+type EstimateSmartFeeCmd struct {
+	ConfTarget   int64
+	EstimateMode *string `jsonrpcdefault:"\"blocks\""`
+}
+
+// NewEstimateSmartFeeCmd returns a new instance which can be used to issue an estimatesmartfee JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewEstimateSmartFeeCmd(confTarget int64, estimateMode *string) *EstimateSmartFeeCmd {
+	return &EstimateSmartFeeCmd{
+		ConfTarget:   confTarget,
+		EstimateMode: estimateMode,
+	}
+}
+
+// EstimatorStatsCmd defines the estimatorstats JSON-RPC command.
+type EstimatorStatsCmd struct{}
+
+// NewEstimatorStatsCmd returns a new instance which can be used to issue an estimatorstats JSON-RPC command.
+func NewEstimatorStatsCmd() *EstimatorStatsCmd {
+	return &EstimatorStatsCmd{}
+}
+
 // GetAddedNodeInfoCmd defines the getaddednodeinfo JSON-RPC command.
 type GetAddedNodeInfoCmd struct {
 	DNS  bool
@@ -165,6 +232,20 @@ func NewGetBlockHeaderCmd(hash string, verbose *bool) *GetBlockHeaderCmd {
 	}
 }
 
+// GetBlockPropagationCmd defines the getblockpropagation JSON-RPC command.
+type GetBlockPropagationCmd struct {
+	Count *int `jsonrpcdefault:"10"`
+}
+
+// NewGetBlockPropagationCmd returns a new instance which can be used to issue a getblockpropagation JSON-RPC command.
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetBlockPropagationCmd(count *int) *GetBlockPropagationCmd {
+	return &GetBlockPropagationCmd{
+		Count: count,
+	}
+}
+
 // TemplateRequest is a request object as defined in BIP22 (https://en.bitcoin.it/wiki/BIP_0022), it is optionally
 // provided as an pointer argument to GetBlockTemplateCmd.
 type TemplateRequest struct {
@@ -271,6 +352,14 @@ func NewGetCFilterHeaderCmd(hash string,
 	}
 }
 
+// GetCacheStatsCmd defines the getcachestats JSON-RPC command.
+type GetCacheStatsCmd struct{}
+
+// NewGetCacheStatsCmd returns a new instance which can be used to issue a getcachestats JSON-RPC command.
+func NewGetCacheStatsCmd() *GetCacheStatsCmd {
+	return &GetCacheStatsCmd{}
+}
+
 // GetChainTipsCmd defines the getchaintips JSON-RPC command.
 type GetChainTipsCmd struct{}
 
@@ -287,6 +376,18 @@ func NewGetConnectionCountCmd() *GetConnectionCountCmd {
 	return &GetConnectionCountCmd{}
 }
 
+// GetDescriptorInfoCmd defines the getdescriptorinfo JSON-RPC command.
+type GetDescriptorInfoCmd struct {
+	Descriptor string
+}
+
+// NewGetDescriptorInfoCmd returns a new instance which can be used to issue a getdescriptorinfo JSON-RPC command.
+func NewGetDescriptorInfoCmd(descriptor string) *GetDescriptorInfoCmd {
+	return &GetDescriptorInfoCmd{
+		Descriptor: descriptor,
+	}
+}
+
 // GetDifficultyCmd defines the getdifficulty JSON-RPC command.
 type GetDifficultyCmd struct {
 	Algo string
@@ -299,6 +400,22 @@ func NewGetDifficultyCmd(algo string) *GetDifficultyCmd {
 	}
 }
 
+// GetDNSAuditCmd defines the getdnsaudit JSON-RPC command.
+type GetDNSAuditCmd struct{}
+
+// NewGetDNSAuditCmd returns a new instance which can be used to issue a getdnsaudit JSON-RPC command.
+func NewGetDNSAuditCmd() *GetDNSAuditCmd {
+	return &GetDNSAuditCmd{}
+}
+
+// GetFeaturesCmd defines the getfeatures JSON-RPC command.
+type GetFeaturesCmd struct{}
+
+// NewGetFeaturesCmd returns a new instance which can be used to issue a getfeatures JSON-RPC command.
+func NewGetFeaturesCmd() *GetFeaturesCmd {
+	return &GetFeaturesCmd{}
+}
+
 // GetGenerateCmd defines the getgenerate JSON-RPC command.
 type GetGenerateCmd struct{}
 
@@ -315,6 +432,21 @@ func NewGetHashesPerSecCmd() *GetHashesPerSecCmd {
 	return &GetHashesPerSecCmd{}
 }
 
+// GetIndexInfoCmd defines the getindexinfo JSON-RPC command.
+type GetIndexInfoCmd struct {
+	IndexName *string
+}
+
+// NewGetIndexInfoCmd returns a new instance which can be used to issue a getindexinfo JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetIndexInfoCmd(indexName *string) *GetIndexInfoCmd {
+	return &GetIndexInfoCmd{
+		IndexName: indexName,
+	}
+}
+
 // GetInfoCmd defines the getinfo JSON-RPC command.
 type GetInfoCmd struct{}
 
@@ -343,6 +475,14 @@ func NewGetMempoolInfoCmd() *GetMempoolInfoCmd {
 	return &GetMempoolInfoCmd{}
 }
 
+// GetMiningAddressesCmd defines the getminingaddresses JSON-RPC command.
+type GetMiningAddressesCmd struct{}
+
+// NewGetMiningAddressesCmd returns a new instance which can be used to issue a getminingaddresses JSON-RPC command.
+func NewGetMiningAddressesCmd() *GetMiningAddressesCmd {
+	return &GetMiningAddressesCmd{}
+}
+
 // GetMiningInfoCmd defines the getmininginfo JSON-RPC command.
 type GetMiningInfoCmd struct{}
 
@@ -351,6 +491,18 @@ func NewGetMiningInfoCmd() *GetMiningInfoCmd {
 	return &GetMiningInfoCmd{}
 }
 
+// GetMiningTemplateCmd defines the getminingtemplate JSON-RPC command.
+type GetMiningTemplateCmd struct {
+	Address string
+}
+
+// NewGetMiningTemplateCmd returns a new instance which can be used to issue a getminingtemplate JSON-RPC command.
+func NewGetMiningTemplateCmd(address string) *GetMiningTemplateCmd {
+	return &GetMiningTemplateCmd{
+		Address: address,
+	}
+}
+
 // GetNetworkInfoCmd defines the getnetworkinfo JSON-RPC command.
 type GetNetworkInfoCmd struct{}
 
@@ -381,6 +533,47 @@ func NewGetNetworkHashPSCmd(numBlocks, height *int) *GetNetworkHashPSCmd {
 	}
 }
 
+// GetNodeAddressesCmd defines the getnodeaddresses JSON-RPC command.
+type GetNodeAddressesCmd struct {
+	Count   *int `jsonrpcdefault:"1"`
+	Network *string
+}
+
+// NewGetNodeAddressesCmd returns a new instance which can be used to issue a getnodeaddresses JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetNodeAddressesCmd(count *int, network *string) *GetNodeAddressesCmd {
+	return &GetNodeAddressesCmd{
+		Count:   count,
+		Network: network,
+	}
+}
+
+// GetNotificationInfoCmd defines the getnotificationinfo JSON-RPC command.
+type GetNotificationInfoCmd struct{}
+
+// NewGetNotificationInfoCmd returns a new instance which can be used to issue a getnotificationinfo JSON-RPC command.
+func NewGetNotificationInfoCmd() *GetNotificationInfoCmd {
+	return &GetNotificationInfoCmd{}
+}
+
+// GetOrphanBlocksCmd defines the getorphanblocks JSON-RPC command.
+type GetOrphanBlocksCmd struct{}
+
+// NewGetOrphanBlocksCmd returns a new instance which can be used to issue a getorphanblocks JSON-RPC command.
+func NewGetOrphanBlocksCmd() *GetOrphanBlocksCmd {
+	return &GetOrphanBlocksCmd{}
+}
+
+// GetOrphanTxsCmd defines the getorphantxs JSON-RPC command.
+type GetOrphanTxsCmd struct{}
+
+// NewGetOrphanTxsCmd returns a new instance which can be used to issue a getorphantxs JSON-RPC command.
+func NewGetOrphanTxsCmd() *GetOrphanTxsCmd {
+	return &GetOrphanTxsCmd{}
+}
+
 // GetPeerInfoCmd defines the getpeerinfo JSON-RPC command.
 type GetPeerInfoCmd struct{}
 
@@ -415,6 +608,32 @@ func NewGetRawTransactionCmd(txHash string, verbose *int) *GetRawTransactionCmd
 	}
 }
 
+// GetScriptFlagsCmd defines the getscriptflags JSON-RPC command.
+type GetScriptFlagsCmd struct {
+	Height *int32
+}
+
+// NewGetScriptFlagsCmd returns a new instance which can be used to issue a getscriptflags JSON-RPC command. The
+// flags of the next block are returned when height is nil.
+func NewGetScriptFlagsCmd(height *int32) *GetScriptFlagsCmd {
+	return &GetScriptFlagsCmd{
+		Height: height,
+	}
+}
+
+// GetTimestampInfoCmd defines the gettimestampinfo JSON-RPC command.
+type GetTimestampInfoCmd struct {
+	Blocks *int `jsonrpcdefault:"720"`
+}
+
+// NewGetTimestampInfoCmd returns a new instance which can be used to issue a gettimestampinfo JSON-RPC command. The
+// timestamps of the algorithms are measured over the default number of recent blocks when blocks is nil.
+func NewGetTimestampInfoCmd(blocks *int) *GetTimestampInfoCmd {
+	return &GetTimestampInfoCmd{
+		Blocks: blocks,
+	}
+}
+
 // GetTxOutCmd defines the gettxout JSON-RPC command.
 type GetTxOutCmd struct {
 	Txid           string
@@ -453,6 +672,28 @@ func NewGetTxOutSetInfoCmd() *GetTxOutSetInfoCmd {
 	return &GetTxOutSetInfoCmd{}
 }
 
+// GetValidationTraceCmd defines the getvalidationtrace JSON-RPC command.
+type GetValidationTraceCmd struct {
+	Count *int `jsonrpcdefault:"10"`
+}
+
+// NewGetValidationTraceCmd returns a new instance which can be used to issue a getvalidationtrace JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewGetValidationTraceCmd(count *int) *GetValidationTraceCmd {
+	return &GetValidationTraceCmd{
+		Count: count,
+	}
+}
+
+// GetMiningExpectationCmd defines the getminingexpectation JSON-RPC command.
+type GetMiningExpectationCmd struct{}
+
+// NewGetMiningExpectationCmd returns a new instance which can be used to issue a getminingexpectation JSON-RPC command.
+func NewGetMiningExpectationCmd() *GetMiningExpectationCmd {
+	return &GetMiningExpectationCmd{}
+}
+
 // GetWorkCmd defines the getwork JSON-RPC command.
 type GetWorkCmd struct {
 	Data *string
@@ -465,6 +706,14 @@ func NewGetWorkCmd(data *string) *GetWorkCmd {
 	}
 }
 
+// GetWorkSourceCmd defines the getworksource JSON-RPC command.
+type GetWorkSourceCmd struct{}
+
+// NewGetWorkSourceCmd returns a new instance which can be used to issue a getworksource JSON-RPC command.
+func NewGetWorkSourceCmd() *GetWorkSourceCmd {
+	return &GetWorkSourceCmd{}
+}
+
 // HelpCmd defines the help JSON-RPC command.
 type HelpCmd struct {
 	Command *string
@@ -497,6 +746,23 @@ func NewPingCmd() *PingCmd {
 	return &PingCmd{}
 }
 
+// PrioritiseTransactionCmd defines the prioritisetransaction JSON-RPC command.
+type PrioritiseTransactionCmd struct {
+	TxID          string
+	PriorityDelta float64
+	FeeDelta      int64
+}
+
+// NewPrioritiseTransactionCmd returns a new instance which can be used to issue a prioritisetransaction JSON-RPC
+// command.
+func NewPrioritiseTransactionCmd(txID string, priorityDelta float64, feeDelta int64) *PrioritiseTransactionCmd {
+	return &PrioritiseTransactionCmd{
+		TxID:          txID,
+		PriorityDelta: priorityDelta,
+		FeeDelta:      feeDelta,
+	}
+}
+
 // PreciousBlockCmd defines the preciousblock JSON-RPC command.
 type PreciousBlockCmd struct {
 	BlockHash string
@@ -563,6 +829,42 @@ func NewSendRawTransactionCmd(hexTx string, allowHighFees *bool) *SendRawTransac
 	}
 }
 
+// SelfTestCmd defines the selftest JSON-RPC command.
+type SelfTestCmd struct{}
+
+// NewSelfTestCmd returns a new instance which can be used to issue a selftest JSON-RPC command.
+func NewSelfTestCmd() *SelfTestCmd {
+	return &SelfTestCmd{}
+}
+
+// SetConfigCmd defines the setconfig JSON-RPC command.
+type SetConfigCmd struct {
+	Name  string
+	Value string
+}
+
+// NewSetConfigCmd returns a new instance which can be used to issue a setconfig JSON-RPC command.
+func NewSetConfigCmd(name, value string) *SetConfigCmd {
+	return &SetConfigCmd{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// SetFeatureCmd defines the setfeature JSON-RPC command.
+type SetFeatureCmd struct {
+	Name   string
+	Enable bool
+}
+
+// NewSetFeatureCmd returns a new instance which can be used to issue a setfeature JSON-RPC command.
+func NewSetFeatureCmd(name string, enable bool) *SetFeatureCmd {
+	return &SetFeatureCmd{
+		Name:   name,
+		Enable: enable,
+	}
+}
+
 // SetGenerateCmd defines the setgenerate JSON-RPC command.
 type SetGenerateCmd struct {
 	Generate     bool
@@ -578,6 +880,22 @@ func NewSetGenerateCmd(generate bool, genProcLimit *int) *SetGenerateCmd {
 	}
 }
 
+// SetMiningAddressesCmd defines the setminingaddresses JSON-RPC command.
+type SetMiningAddressesCmd struct {
+	Addresses []string
+	Rotation  *string
+}
+
+// NewSetMiningAddressesCmd returns a new instance which can be used to issue a setminingaddresses JSON-RPC command.
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will keep the
+// current rotation policy.
+func NewSetMiningAddressesCmd(addresses []string, rotation *string) *SetMiningAddressesCmd {
+	return &SetMiningAddressesCmd{
+		Addresses: addresses,
+		Rotation:  rotation,
+	}
+}
+
 // StopCmd defines the stop JSON-RPC command.
 type StopCmd struct{}
 
@@ -589,7 +907,7 @@ func NewStopCmd() *StopCmd {
 // RestartCmd defines the restart JSON-RPC command.
 type RestartCmd struct{}
 
-// NewRestartCmd returns a new instance which can be used to issue a stop JSON-RPC command.
+// NewRestartCmd returns a new instance which can be used to issue a restart JSON-RPC command.
 func NewRestartCmd() *RestartCmd {
 	return &RestartCmd{}
 }
@@ -623,6 +941,23 @@ func NewSubmitBlockCmd(hexBlock string, options *SubmitBlockOptions) *SubmitBloc
 	}
 }
 
+// TraceMempoolAcceptCmd defines the tracemempoolaccept JSON-RPC command.
+type TraceMempoolAcceptCmd struct {
+	HexTx         string
+	AllowHighFees *bool `jsonrpcdefault:"false"`
+}
+
+// NewTraceMempoolAcceptCmd returns a new instance which can be used to issue a tracemempoolaccept JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewTraceMempoolAcceptCmd(hexTx string, allowHighFees *bool) *TraceMempoolAcceptCmd {
+	return &TraceMempoolAcceptCmd{
+		HexTx:         hexTx,
+		AllowHighFees: allowHighFees,
+	}
+}
+
 // UptimeCmd defines the uptime JSON-RPC command.
 type UptimeCmd struct{}
 
@@ -684,6 +1019,117 @@ func NewVerifyTxOutProofCmd(proof string) *VerifyTxOutProofCmd {
 		Proof: proof,
 	}
 }
+
+// chainSvrCmdSet declares the chain server commands that are registered through RegisterCmds along with their result
+// types.
+type chainSvrCmdSet struct {
+	GetCacheStats struct {
+		Cmd    *GetCacheStatsCmd
+		Result *GetCacheStatsResult
+	} `jsonrpcmethod:"getcachestats"`
+	GetNotificationInfo struct {
+		Cmd    *GetNotificationInfoCmd
+		Result *GetNotificationInfoResult
+	} `jsonrpcmethod:"getnotificationinfo"`
+	PrioritiseTransaction struct {
+		Cmd    *PrioritiseTransactionCmd
+		Result *bool
+	} `jsonrpcmethod:"prioritisetransaction"`
+	GetValidationTrace struct {
+		Cmd    *GetValidationTraceCmd
+		Result *[]GetValidationTraceResult
+	} `jsonrpcmethod:"getvalidationtrace"`
+	SelfTest struct {
+		Cmd    *SelfTestCmd
+		Result *SelfTestResult
+	} `jsonrpcmethod:"selftest"`
+	GetIndexInfo struct {
+		Cmd    *GetIndexInfoCmd
+		Result *map[string]GetIndexInfoResult
+	} `jsonrpcmethod:"getindexinfo"`
+	GetMiningAddresses struct {
+		Cmd    *GetMiningAddressesCmd
+		Result *GetMiningAddressesResult
+	} `jsonrpcmethod:"getminingaddresses"`
+	GetMiningExpectation struct {
+		Cmd    *GetMiningExpectationCmd
+		Result *GetMiningExpectationResult
+	} `jsonrpcmethod:"getminingexpectation"`
+	GetMiningTemplate struct {
+		Cmd    *GetMiningTemplateCmd
+		Result *GetMiningTemplateResult
+	} `jsonrpcmethod:"getminingtemplate"`
+	GetWorkSource struct {
+		Cmd    *GetWorkSourceCmd
+		Result *GetWorkSourceResult
+	} `jsonrpcmethod:"getworksource"`
+	SetMiningAddresses struct {
+		Cmd *SetMiningAddressesCmd
+	} `jsonrpcmethod:"setminingaddresses"`
+	TraceMempoolAccept struct {
+		Cmd    *TraceMempoolAcceptCmd
+		Result *TraceMempoolAcceptResult
+	} `jsonrpcmethod:"tracemempoolaccept"`
+	EstimatorStats struct {
+		Cmd    *EstimatorStatsCmd
+		Result *EstimatorStatsResult
+	} `jsonrpcmethod:"estimatorstats"`
+	GetBlockPropagation struct {
+		Cmd    *GetBlockPropagationCmd
+		Result *GetBlockPropagationResult
+	} `jsonrpcmethod:"getblockpropagation"`
+	GetFeatures struct {
+		Cmd    *GetFeaturesCmd
+		Result *[]FeatureResult
+	} `jsonrpcmethod:"getfeatures"`
+	SetFeature struct {
+		Cmd *SetFeatureCmd
+	} `jsonrpcmethod:"setfeature"`
+	SetConfig struct {
+		Cmd *SetConfigCmd
+	} `jsonrpcmethod:"setconfig"`
+	GetOrphanBlocks struct {
+		Cmd    *GetOrphanBlocksCmd
+		Result *GetOrphanBlocksResult
+	} `jsonrpcmethod:"getorphanblocks"`
+	GetOrphanTxs struct {
+		Cmd    *GetOrphanTxsCmd
+		Result *GetOrphanTxsResult
+	} `jsonrpcmethod:"getorphantxs"`
+	EstimateSmartFee struct {
+		Cmd    *EstimateSmartFeeCmd
+		Result *EstimateSmartFeeResult
+	} `jsonrpcmethod:"estimatesmartfee"`
+	GetDescriptorInfo struct {
+		Cmd    *GetDescriptorInfoCmd
+		Result *GetDescriptorInfoResult
+	} `jsonrpcmethod:"getdescriptorinfo"`
+	DeriveAddresses struct {
+		Cmd    *DeriveAddressesCmd
+		Result *[]string
+	} `jsonrpcmethod:"deriveaddresses"`
+	GetScriptFlags struct {
+		Cmd    *GetScriptFlagsCmd
+		Result *GetScriptFlagsResult
+	} `jsonrpcmethod:"getscriptflags"`
+	GetNodeAddresses struct {
+		Cmd    *GetNodeAddressesCmd
+		Result *[]NodeAddressResult
+	} `jsonrpcmethod:"getnodeaddresses"`
+	GetTimestampInfo struct {
+		Cmd    *GetTimestampInfoCmd
+		Result *GetTimestampInfoResult
+	} `jsonrpcmethod:"gettimestampinfo"`
+	GetDNSAudit struct {
+		Cmd    *GetDNSAuditCmd
+		Result *GetDNSAuditResult
+	} `jsonrpcmethod:"getdnsaudit"`
+	CalculatePackageFee struct {
+		Cmd    *CalculatePackageFeeCmd
+		Result *CalculatePackageFeeResult
+	} `jsonrpcmethod:"calculatepackagefee"`
+}
+
 func init() {
 	
 	// No special flags for commands in this file.
@@ -738,4 +1184,5 @@ func init() {
 	MustRegisterCmd("verifychain", (*VerifyChainCmd)(nil), flags)
 	MustRegisterCmd("verifymessage", (*VerifyMessageCmd)(nil), flags)
 	MustRegisterCmd("verifytxoutproof", (*VerifyTxOutProofCmd)(nil), flags)
+	MustRegisterCmds((*chainSvrCmdSet)(nil))
 }
@@ -35,6 +35,35 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"addnode","netparams":["127.0.0.1","remove"],"id":1}`,
 			unmarshalled: &btcjson.AddNodeCmd{Addr: "127.0.0.1", SubCmd: btcjson.ANRemove},
 		},
+		{
+			name: "calculatepackagefee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("calculatepackagefee", `["123","456"]`, 0.0002)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCalculatePackageFeeCmd([]string{"123", "456"}, 0.0002, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"calculatepackagefee","netparams":[["123","456"],0.0002],"id":1}`,
+			unmarshalled: &btcjson.CalculatePackageFeeCmd{
+				TxIDs:         []string{"123", "456"},
+				TargetFeeRate: 0.0002,
+			},
+		},
+		{
+			name: "calculatepackagefee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("calculatepackagefee", `["123"]`, 0.0002, 250)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCalculatePackageFeeCmd([]string{"123"}, 0.0002, btcjson.Int64(250))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"calculatepackagefee","netparams":[["123"],0.0002,250],"id":1}`,
+			unmarshalled: &btcjson.CalculatePackageFeeCmd{
+				TxIDs:         []string{"123"},
+				TargetFeeRate: 0.0002,
+				ChildVSize:    btcjson.Int64(250),
+			},
+		},
 		{
 			name: "createrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -100,6 +129,70 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"decodescript","netparams":["00"],"id":1}`,
 			unmarshalled: &btcjson.DecodeScriptCmd{HexScript: "00"},
 		},
+		{
+			name: "deriveaddresses",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("deriveaddresses", "raw(deadbeef)#89f8spxm")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDeriveAddressesCmd("raw(deadbeef)#89f8spxm", nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"deriveaddresses","netparams":["raw(deadbeef)#89f8spxm"],"id":1}`,
+			unmarshalled: &btcjson.DeriveAddressesCmd{Descriptor: "raw(deadbeef)#89f8spxm"},
+		},
+		{
+			name: "deriveaddresses optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("deriveaddresses", "pkh(xpub/*)", []int64{2, 5})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDeriveAddressesCmd("pkh(xpub/*)", &[]int64{2, 5})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"deriveaddresses","netparams":["pkh(xpub/*)",[2,5]],"id":1}`,
+			unmarshalled: &btcjson.DeriveAddressesCmd{
+				Descriptor: "pkh(xpub/*)",
+				Range:      &[]int64{2, 5},
+			},
+		},
+		{
+			name: "estimatesmartfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(6, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","netparams":[6],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   6,
+				EstimateMode: btcjson.String(btcjson.EstimateModeBlocks),
+			},
+		},
+		{
+			name: "estimatesmartfee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 30, btcjson.EstimateModeTime)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(30, btcjson.String(btcjson.EstimateModeTime))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","netparams":[30,"time"],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   30,
+				EstimateMode: btcjson.String(btcjson.EstimateModeTime),
+			},
+		},
+		{
+			name: "estimatorstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatorstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimatorStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"estimatorstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.EstimatorStatsCmd{},
+		},
 		{
 			name: "getaddednodeinfo",
 			newCmd: func() (interface{}, error) {
@@ -230,6 +323,32 @@ func TestChainSvrCmds(t *testing.T) {
 				Verbose: btcjson.Bool(true),
 			},
 		},
+		{
+			name: "getblockpropagation",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockpropagation")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockPropagationCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockpropagation","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetBlockPropagationCmd{
+				Count: btcjson.Int(10),
+			},
+		},
+		{
+			name: "getblockpropagation optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockpropagation", 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockPropagationCmd(btcjson.Int(5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockpropagation","netparams":[5],"id":1}`,
+			unmarshalled: &btcjson.GetBlockPropagationCmd{
+				Count: btcjson.Int(5),
+			},
+		},
 		{
 			name: "getblocktemplate",
 			newCmd: func() (interface{}, error) {
@@ -362,6 +481,17 @@ func TestChainSvrCmds(t *testing.T) {
 				FilterType: wire.GCSFilterRegular,
 			},
 		},
+		{
+			name: "getcachestats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getcachestats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetCacheStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getcachestats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetCacheStatsCmd{},
+		},
 		{
 			name: "getchaintips",
 			newCmd: func() (interface{}, error) {
@@ -384,6 +514,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getconnectioncount","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetConnectionCountCmd{},
 		},
+		{
+			name: "getdescriptorinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getdescriptorinfo", "raw(deadbeef)")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetDescriptorInfoCmd("raw(deadbeef)")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getdescriptorinfo","netparams":["raw(deadbeef)"],"id":1}`,
+			unmarshalled: &btcjson.GetDescriptorInfoCmd{Descriptor: "raw(deadbeef)"},
+		},
 		{
 			name: "getdifficulty",
 			newCmd: func() (interface{}, error) {
@@ -395,6 +536,28 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getdifficulty","netparams":["123"],"id":1}`,
 			unmarshalled: &btcjson.GetDifficultyCmd{Algo: "123"},
 		},
+		{
+			name: "getdnsaudit",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getdnsaudit")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetDNSAuditCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getdnsaudit","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetDNSAuditCmd{},
+		},
+		{
+			name: "getfeatures",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getfeatures")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetFeaturesCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getfeatures","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetFeaturesCmd{},
+		},
 		{
 			name: "getgenerate",
 			newCmd: func() (interface{}, error) {
@@ -417,6 +580,32 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"gethashespersec","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetHashesPerSecCmd{},
 		},
+		{
+			name: "getindexinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getindexinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetIndexInfoCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getindexinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetIndexInfoCmd{
+				IndexName: nil,
+			},
+		},
+		{
+			name: "getindexinfo optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getindexinfo", "transaction index")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetIndexInfoCmd(btcjson.String("transaction index"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getindexinfo","netparams":["transaction index"],"id":1}`,
+			unmarshalled: &btcjson.GetIndexInfoCmd{
+				IndexName: btcjson.String("transaction index"),
+			},
+		},
 		{
 			name: "getinfo",
 			newCmd: func() (interface{}, error) {
@@ -452,6 +641,39 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getmempoolinfo","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetMempoolInfoCmd{},
 		},
+		{
+			name: "getminingaddresses",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getminingaddresses")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMiningAddressesCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getminingaddresses","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetMiningAddressesCmd{},
+		},
+		{
+			name: "getminingexpectation",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getminingexpectation")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMiningExpectationCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getminingexpectation","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetMiningExpectationCmd{},
+		},
+		{
+			name: "getminingtemplate",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getminingtemplate", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMiningTemplateCmd("1Address")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getminingtemplate","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.GetMiningTemplateCmd{Address: "1Address"},
+		},
 		{
 			name: "getmininginfo",
 			newCmd: func() (interface{}, error) {
@@ -527,6 +749,114 @@ func TestChainSvrCmds(t *testing.T) {
 				Height: btcjson.Int(123),
 			},
 		},
+		{
+			name: "getnodeaddresses",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnodeaddresses")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNodeAddressesCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnodeaddresses","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetNodeAddressesCmd{
+				Count: btcjson.Int(1),
+			},
+		},
+		{
+			name: "getnodeaddresses optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnodeaddresses", 0, "onion")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNodeAddressesCmd(btcjson.Int(0), btcjson.String("onion"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnodeaddresses","netparams":[0,"onion"],"id":1}`,
+			unmarshalled: &btcjson.GetNodeAddressesCmd{
+				Count:   btcjson.Int(0),
+				Network: btcjson.String("onion"),
+			},
+		},
+		{
+			name: "gettimestampinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gettimestampinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetTimestampInfoCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettimestampinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetTimestampInfoCmd{
+				Blocks: btcjson.Int(720),
+			},
+		},
+		{
+			name: "gettimestampinfo optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gettimestampinfo", 100)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetTimestampInfoCmd(btcjson.Int(100))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettimestampinfo","netparams":[100],"id":1}`,
+			unmarshalled: &btcjson.GetTimestampInfoCmd{
+				Blocks: btcjson.Int(100),
+			},
+		},
+		{
+			name: "getnotificationinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnotificationinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNotificationInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getnotificationinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetNotificationInfoCmd{},
+		},
+		{
+			name: "getorphanblocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getorphanblocks")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetOrphanBlocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getorphanblocks","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetOrphanBlocksCmd{},
+		},
+		{
+			name: "getorphantxs",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getorphantxs")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetOrphanTxsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getorphantxs","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetOrphanTxsCmd{},
+		},
+		{
+			name: "getscriptflags",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getscriptflags")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetScriptFlagsCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getscriptflags","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetScriptFlagsCmd{},
+		},
+		{
+			name: "getscriptflags height",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getscriptflags", 2500000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetScriptFlagsCmd(btcjson.Int32(2500000))
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getscriptflags","netparams":[2500000],"id":1}`,
+			unmarshalled: &btcjson.GetScriptFlagsCmd{Height: btcjson.Int32(2500000)},
+		},
 		{
 			name: "getpeerinfo",
 			newCmd: func() (interface{}, error) {
@@ -667,6 +997,32 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"gettxoutsetinfo","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetTxOutSetInfoCmd{},
 		},
+		{
+			name: "getvalidationtrace",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getvalidationtrace")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetValidationTraceCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvalidationtrace","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetValidationTraceCmd{
+				Count: btcjson.Int(10),
+			},
+		},
+		{
+			name: "getvalidationtrace optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getvalidationtrace", 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetValidationTraceCmd(btcjson.Int(5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvalidationtrace","netparams":[5],"id":1}`,
+			unmarshalled: &btcjson.GetValidationTraceCmd{
+				Count: btcjson.Int(5),
+			},
+		},
 		{
 			name: "getwork",
 			newCmd: func() (interface{}, error) {
@@ -693,6 +1049,17 @@ func TestChainSvrCmds(t *testing.T) {
 				Data: btcjson.String("00112233"),
 			},
 		},
+		{
+			name: "getworksource",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getworksource")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWorkSourceCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getworksource","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetWorkSourceCmd{},
+		},
 		{
 			name: "help",
 			newCmd: func() (interface{}, error) {
@@ -756,6 +1123,21 @@ func TestChainSvrCmds(t *testing.T) {
 				BlockHash: "0123",
 			},
 		},
+		{
+			name: "prioritisetransaction",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("prioritisetransaction", "0123", 0.0, 10000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewPrioritiseTransactionCmd("0123", 0, 10000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"prioritisetransaction","netparams":["0123",0,10000],"id":1}`,
+			unmarshalled: &btcjson.PrioritiseTransactionCmd{
+				TxID:          "0123",
+				PriorityDelta: 0,
+				FeeDelta:      10000,
+			},
+		},
 		{
 			name: "reconsiderblock",
 			newCmd: func() (interface{}, error) {
@@ -953,6 +1335,72 @@ func TestChainSvrCmds(t *testing.T) {
 				AllowHighFees: btcjson.Bool(false),
 			},
 		},
+		{
+			name: "selftest",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("selftest")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSelfTestCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"selftest","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.SelfTestCmd{},
+		},
+		{
+			name: "setminingaddresses",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setminingaddresses", []string{"1Address", "2Address"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetMiningAddressesCmd([]string{"1Address", "2Address"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setminingaddresses","netparams":[["1Address","2Address"]],"id":1}`,
+			unmarshalled: &btcjson.SetMiningAddressesCmd{
+				Addresses: []string{"1Address", "2Address"},
+			},
+		},
+		{
+			name: "setminingaddresses optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setminingaddresses", []string{"1Address"}, "roundrobin")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetMiningAddressesCmd([]string{"1Address"}, btcjson.String("roundrobin"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setminingaddresses","netparams":[["1Address"],"roundrobin"],"id":1}`,
+			unmarshalled: &btcjson.SetMiningAddressesCmd{
+				Addresses: []string{"1Address"},
+				Rotation:  btcjson.String("roundrobin"),
+			},
+		},
+		{
+			name: "setconfig",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setconfig", "BanThreshold", "50")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetConfigCmd("BanThreshold", "50")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setconfig","netparams":["BanThreshold","50"],"id":1}`,
+			unmarshalled: &btcjson.SetConfigCmd{
+				Name:  "BanThreshold",
+				Value: "50",
+			},
+		},
+		{
+			name: "setfeature",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setfeature", "feeestimatorautobias", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetFeatureCmd("feeestimatorautobias", true)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setfeature","netparams":["feeestimatorautobias",true],"id":1}`,
+			unmarshalled: &btcjson.SetFeatureCmd{
+				Name:   "feeestimatorautobias",
+				Enable: true,
+			},
+		},
 		{
 			name: "setgenerate",
 			newCmd: func() (interface{}, error) {
@@ -1036,6 +1484,34 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tracemempoolaccept",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("tracemempoolaccept", "1122")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTraceMempoolAcceptCmd("1122", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"tracemempoolaccept","netparams":["1122"],"id":1}`,
+			unmarshalled: &btcjson.TraceMempoolAcceptCmd{
+				HexTx:         "1122",
+				AllowHighFees: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "tracemempoolaccept optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("tracemempoolaccept", "1122", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTraceMempoolAcceptCmd("1122", btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"tracemempoolaccept","netparams":["1122",true],"id":1}`,
+			unmarshalled: &btcjson.TraceMempoolAcceptCmd{
+				HexTx:         "1122",
+				AllowHighFees: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "uptime",
 			newCmd: func() (interface{}, error) {
@@ -13,6 +13,19 @@ type Bip9SoftForkDescription struct {
 	Since     int32  `json:"since"`
 }
 
+// CalculatePackageFeeResult models the data returned from the calculatepackagefee command.
+type CalculatePackageFeeResult struct {
+	TxIDs         []string `json:"txids"`
+	Ancestors     []string `json:"ancestors"`
+	VSize         int64    `json:"vsize"`
+	Fee           float64  `json:"fee"`
+	FeeRate       float64  `json:"feerate"`
+	TargetFeeRate float64  `json:"targetfeerate"`
+	ChildVSize    int64    `json:"childvsize"`
+	ChildFee      float64  `json:"childfee"`
+	ChildFeeRate  float64  `json:"childfeerate"`
+}
+
 // CreateMultiSigResult models the data returned from the createmultisig command.
 type CreateMultiSigResult struct {
 	Address      string `json:"address"`
@@ -28,6 +41,15 @@ type DecodeScriptResult struct {
 	P2sh      string   `json:"p2sh,omitempty"`
 }
 
+// GetDescriptorInfoResult models the data returned from the getdescriptorinfo command.
+type GetDescriptorInfoResult struct {
+	Descriptor     string `json:"descriptor"`
+	Checksum       string `json:"checksum"`
+	IsRange        bool   `json:"isrange"`
+	IsSolvable     bool   `json:"issolvable"`
+	HasPrivateKeys bool   `json:"hasprivatekeys"`
+}
+
 // GetAddedNodeInfoResult models the data from the getaddednodeinfo command.
 type GetAddedNodeInfoResult struct {
 	AddedNode string                        `json:"addednode"`
@@ -60,18 +82,22 @@ type GetBlockChainInfoResult struct {
 // GetBlockHeaderVerboseResult models the data from the getblockheader command when the verbose flag is set. When the
 // verbose flag is not set, getblockheader returns a hex-encoded string.
 type GetBlockHeaderVerboseResult struct {
-	Hash          string  `json:"hash"`
-	Confirmations int64   `json:"confirmations"`
-	Height        int32   `json:"height"`
-	Version       int32   `json:"version"`
-	VersionHex    string  `json:"versionHex"`
-	MerkleRoot    string  `json:"merkleroot"`
-	Time          int64   `json:"time"`
-	Nonce         uint64  `json:"nonce"`
-	Bits          string  `json:"bits"`
-	Difficulty    float64 `json:"difficulty"`
-	PreviousHash  string  `json:"previousblockhash,omitempty"`
-	NextHash      string  `json:"nextblockhash,omitempty"`
+	Hash           string  `json:"hash"`
+	Confirmations  int64   `json:"confirmations"`
+	Height         int32   `json:"height"`
+	Version        int32   `json:"version"`
+	VersionHex     string  `json:"versionHex"`
+	PowAlgoID      uint32  `json:"pow_algo_id"`
+	PowAlgo        string  `json:"pow_algo"`
+	MerkleRoot     string  `json:"merkleroot"`
+	Time           int64   `json:"time"`
+	Nonce          uint64  `json:"nonce"`
+	Bits           string  `json:"bits"`
+	Difficulty     float64 `json:"difficulty"`
+	AlgoDifficulty float64 `json:"algo_difficulty"`
+	ChainWork      string  `json:"chainwork"`
+	PreviousHash   string  `json:"previousblockhash,omitempty"`
+	NextHash       string  `json:"nextblockhash,omitempty"`
 }
 
 // GetBlockTemplateResult models the data returned from the getblocktemplate command.
@@ -129,27 +155,64 @@ type GetBlockTemplateResultTx struct {
 // GetBlockVerboseResult models the data from the getblock command when the verbose flag is set. When the verbose flag
 // is not set, getblock returns a hex-encoded string.
 type GetBlockVerboseResult struct {
-	Hash          string        `json:"hash"`
-	Confirmations int64         `json:"confirmations"`
-	StrippedSize  int32         `json:"strippedsize"`
-	Size          int32         `json:"size"`
-	Weight        int32         `json:"weight"`
-	Height        int64         `json:"height"`
-	Version       int32         `json:"version"`
-	VersionHex    string        `json:"versionHex"`
-	PowAlgoID     uint32        `json:"pow_algo_id"`
-	PowAlgo       string        `json:"pow_algo"`
-	PowHash       string        `json:"pow_hash"`
-	MerkleRoot    string        `json:"merkleroot"`
-	TxNum         int           `json:"txnum,omitempty"`
-	Tx            []string      `json:"tx,omitempty"`
-	RawTx         []TxRawResult `json:"rawtx,omitempty"`
-	Time          int64         `json:"time"`
-	Nonce         uint32        `json:"nonce"`
-	Bits          string        `json:"bits"`
-	Difficulty    float64       `json:"difficulty"`
-	PreviousHash  string        `json:"previousblockhash"`
-	NextHash      string        `json:"nextblockhash,omitempty"`
+	Hash           string        `json:"hash"`
+	Confirmations  int64         `json:"confirmations"`
+	StrippedSize   int32         `json:"strippedsize"`
+	Size           int32         `json:"size"`
+	Weight         int32         `json:"weight"`
+	Height         int64         `json:"height"`
+	Version        int32         `json:"version"`
+	VersionHex     string        `json:"versionHex"`
+	PowAlgoID      uint32        `json:"pow_algo_id"`
+	PowAlgo        string        `json:"pow_algo"`
+	PowHash        string        `json:"pow_hash"`
+	MerkleRoot     string        `json:"merkleroot"`
+	TxNum          int           `json:"txnum,omitempty"`
+	Tx             []string      `json:"tx,omitempty"`
+	RawTx          []TxRawResult `json:"rawtx,omitempty"`
+	Time           int64         `json:"time"`
+	Nonce          uint32        `json:"nonce"`
+	Bits           string        `json:"bits"`
+	Difficulty     float64       `json:"difficulty"`
+	AlgoDifficulty float64       `json:"algo_difficulty"`
+	ChainWork      string        `json:"chainwork"`
+	PreviousHash   string        `json:"previousblockhash"`
+	NextHash       string        `json:"nextblockhash,omitempty"`
+}
+
+// GetCacheStatsResult models the data returned from the getcachestats command.
+type GetCacheStatsResult struct {
+	SigCache SigCacheStatsResult `json:"sigcache"`
+}
+
+// SigCacheStatsResult models the signature cache statistics returned from the getcachestats command.
+type SigCacheStatsResult struct {
+	Entries     int     `json:"entries"`
+	HotEntries  int     `json:"hotentries"`
+	ColdEntries int     `json:"coldentries"`
+	Bytes       uint64  `json:"bytes"`
+	HotBytes    uint64  `json:"hotbytes"`
+	ColdBytes   uint64  `json:"coldbytes"`
+	MaxBytes    uint64  `json:"maxbytes"`
+	Hits        uint64  `json:"hits"`
+	Misses      uint64  `json:"misses"`
+	HitRate     float64 `json:"hitrate"`
+	Promotions  uint64  `json:"promotions"`
+	Evictions   uint64  `json:"evictions"`
+}
+
+// GetValidationTraceResult models the time spent in each phase of validating a block, in milliseconds, returned from the
+// getvalidationtrace command.
+type GetValidationTraceResult struct {
+	Hash        string  `json:"hash"`
+	Height      int32   `json:"height"`
+	Time        int64   `json:"time"`
+	Deserialize float64 `json:"deserialize"`
+	Merkle      float64 `json:"merkle"`
+	UtxoFetch   float64 `json:"utxofetch"`
+	ScriptCheck float64 `json:"scriptcheck"`
+	UtxoFlush   float64 `json:"utxoflush"`
+	Total       float64 `json:"total"`
 }
 
 // GetMempoolEntryResult models the data returned from the getmempoolentry command.
@@ -170,10 +233,43 @@ type GetMempoolEntryResult struct {
 	Depends          []string `json:"depends"`
 }
 
+// GetIndexInfoResult models the state of an index returned from the getindexinfo command, which maps the names of the
+// indexes to their state.
+type GetIndexInfoResult struct {
+	Synced          bool    `json:"synced"`
+	BestBlockHeight int32   `json:"best_block_height"`
+	Progress        float64 `json:"progress"`
+	Workers         int     `json:"workers,omitempty"`
+	BlocksPerSecond float64 `json:"blocks_per_second,omitempty"`
+}
+
 // GetMempoolInfoResult models the data returned from the getmempoolinfo command.
 type GetMempoolInfoResult struct {
-	Size  int64 `json:"size"`
-	Bytes int64 `json:"bytes"`
+	Size    int64  `json:"size"`
+	Bytes   int64  `json:"bytes"`
+	Expiry  int64  `json:"expiry"`
+	Expired uint64 `json:"expired"`
+}
+
+// GetMiningAddressesResult models the data from the getminingaddresses command.
+type GetMiningAddressesResult struct {
+	Addresses []string `json:"addresses"`
+	Rotation  string   `json:"rotation"`
+}
+
+// GetMiningTemplateResult models the data from the getminingtemplate command.
+type GetMiningTemplateResult struct {
+	Height       int32                       `json:"height"`
+	PreviousHash string                      `json:"previousblockhash"`
+	Blocks       []MiningTemplateBlockResult `json:"blocks"`
+}
+
+// MiningTemplateBlockResult models a block to mine with one of the algorithms in the data from the getminingtemplate
+// command.
+type MiningTemplateBlockResult struct {
+	Version int32  `json:"version"`
+	Algo    string `json:"algo"`
+	Hex     string `json:"hex"`
 }
 
 // GetMiningInfoResult models the data from the getmininginfo command.
@@ -231,6 +327,14 @@ type GetNetTotalsResult struct {
 	TimeMillis     int64  `json:"timemillis"`
 }
 
+// FeatureResult models a feature returned from the getfeatures command.
+type FeatureResult struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Experimental bool   `json:"experimental"`
+	Enabled      bool   `json:"enabled"`
+}
+
 // GetNetworkInfoResult models the data returned from the getnetworkinfo command.
 type GetNetworkInfoResult struct {
 	Version         int32                  `json:"version"`
@@ -239,38 +343,216 @@ type GetNetworkInfoResult struct {
 	LocalServices   string                 `json:"localservices"`
 	LocalRelay      bool                   `json:"localrelay"`
 	TimeOffset      int64                  `json:"timeoffset"`
+	ClockSkew       int64                  `json:"clockskew"`
+	TimeSamples     int                    `json:"timesamples"`
 	Connections     int32                  `json:"connections"`
 	NetworkActive   bool                   `json:"networkactive"`
 	Networks        []NetworksResult       `json:"networks"`
 	RelayFee        float64                `json:"relayfee"`
 	IncrementalFee  float64                `json:"incrementalfee"`
 	LocalAddresses  []LocalAddressesResult `json:"localaddresses"`
+	Reachability    ReachabilityResult     `json:"reachability"`
 	Warnings        string                 `json:"warnings"`
 }
 
+// ReachabilityResult models whether the node accepts inbound connections, as part of the getnetworkinfo command.
+type ReachabilityResult struct {
+	Status          string `json:"status"`
+	Method          string `json:"method,omitempty"`
+	NAT             string `json:"nat,omitempty"`
+	ExternalAddress string `json:"externaladdress,omitempty"`
+	LastChecked     int64  `json:"lastchecked,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// NotificationEndpointResult models an endpoint that notifications are published on for the getnotificationinfo
+// command.
+type NotificationEndpointResult struct {
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	HWM         int      `json:"hwm"`
+	QueueLimit  int      `json:"queuelimit"`
+	SlowClients string   `json:"slowclients"`
+}
+
+// NotificationClientResult models the subscriptions and delivery statistics of a single notification subscriber for
+// the getnotificationinfo command.
+type NotificationClientResult struct {
+	Addr             string   `json:"addr"`
+	SessionID        uint64   `json:"sessionid"`
+	Topics           []string `json:"topics"`
+	WatchedAddresses int      `json:"watchedaddresses"`
+	WatchedOutPoints int      `json:"watchedoutpoints"`
+	Queued           uint64   `json:"queued"`
+	Sent             uint64   `json:"sent"`
+	Dropped          uint64   `json:"dropped"`
+	Pending          int64    `json:"pending"`
+	PendingHWM       int64    `json:"pendinghwm"`
+	Overflows        uint64   `json:"overflows"`
+}
+
+// GetNotificationInfoResult models the data returned from the getnotificationinfo command.
+type GetNotificationInfoResult struct {
+	Endpoints []NotificationEndpointResult `json:"endpoints"`
+	Clients   []NotificationClientResult   `json:"clients"`
+	Queued    uint64                       `json:"queued"`
+	Sent      uint64                       `json:"sent"`
+	Dropped   uint64                       `json:"dropped"`
+}
+
+// GetOrphanBlocksResult models the data returned from the getorphanblocks command.
+type GetOrphanBlocksResult struct {
+	Count      int                 `json:"count"`
+	Limit      int                 `json:"limit"`
+	Processed  int64               `json:"processed"`
+	Added      int64               `json:"added"`
+	Connected  int64               `json:"connected"`
+	Rejected   int64               `json:"rejected"`
+	Expired    int64               `json:"expired"`
+	Evicted    int64               `json:"evicted"`
+	OrphanRate float64             `json:"orphanrate"`
+	Requests   int64               `json:"requests"`
+	Rerequests int64               `json:"rerequests"`
+	Abandoned  int64               `json:"abandoned"`
+	Orphans    []OrphanBlockResult `json:"orphans"`
+}
+
+// OrphanBlockResult models an orphan block returned from the getorphanblocks command.
+type OrphanBlockResult struct {
+	Hash           string   `json:"hash"`
+	PreviousHash   string   `json:"previousblockhash"`
+	Root           string   `json:"root"`
+	Size           int      `json:"size"`
+	Received       int64    `json:"received"`
+	Expires        int64    `json:"expires"`
+	Peers          []string `json:"peers,omitempty"`
+	ParentRequests int      `json:"parentrequests"`
+}
+
+// GetOrphanTxsResult models the data returned from the getorphantxs command.
+type GetOrphanTxsResult struct {
+	Count    int              `json:"count"`
+	Limit    int              `json:"limit"`
+	Added    int64            `json:"added"`
+	Resolved int64            `json:"resolved"`
+	Rejected int64            `json:"rejected"`
+	Expired  int64            `json:"expired"`
+	Evicted  int64            `json:"evicted"`
+	Removed  int64            `json:"removed"`
+	Orphans  []OrphanTxResult `json:"orphans"`
+}
+
+// OrphanTxResult models an orphan transaction returned from the getorphantxs command.
+type OrphanTxResult struct {
+	TxID     string   `json:"txid"`
+	Size     int      `json:"size"`
+	Received int64    `json:"received"`
+	Expires  int64    `json:"expires"`
+	From     uint64   `json:"from"`
+	Missing  []string `json:"missing"`
+}
+
+// GetScriptFlagsResult models the data returned from the getscriptflags command.
+type GetScriptFlagsResult struct {
+	Height   int32                     `json:"height"`
+	Flags    uint32                    `json:"flags"`
+	Names    []string                  `json:"names"`
+	Upgrades []ScriptFlagUpgradeResult `json:"upgrades"`
+}
+
+// ScriptFlagUpgradeResult models a network upgrade returned from the getscriptflags command.
+type ScriptFlagUpgradeResult struct {
+	Height int32    `json:"height"`
+	Added  []string `json:"added"`
+	Active bool     `json:"active"`
+}
+
+// GetTimestampInfoResult models the data returned from the gettimestampinfo command.
+type GetTimestampInfoResult struct {
+	Height       int32                  `json:"height"`
+	Hash         string                 `json:"hash"`
+	Time         int64                  `json:"time"`
+	MedianTime   int64                  `json:"mediantime"`
+	AdjustedTime int64                  `json:"adjustedtime"`
+	TimeOffset   int64                  `json:"timeoffset"`
+	MinTime      int64                  `json:"mintime"`
+	MaxTime      int64                  `json:"maxtime"`
+	Blocks       int32                  `json:"blocks"`
+	Algos        []AlgoTimestampsResult `json:"algos"`
+}
+
+// AlgoTimestampsResult models the drift of the timestamps of an algorithm returned from the gettimestampinfo command.
+type AlgoTimestampsResult struct {
+	Algo          string  `json:"algo"`
+	Version       int32   `json:"version"`
+	Blocks        int     `json:"blocks"`
+	LastHeight    int32   `json:"lastheight"`
+	LastTime      int64   `json:"lasttime"`
+	TargetSpacing int64   `json:"targetspacing"`
+	MeanSpacing   float64 `json:"meanspacing"`
+	MinAhead      int64   `json:"minahead"`
+	MeanAhead     float64 `json:"meanahead"`
+	MaxAhead      int64   `json:"maxahead"`
+	Backwards     int     `json:"backwards"`
+}
+
+// GetDNSAuditResult models the data returned from the getdnsaudit command.
+type GetDNSAuditResult struct {
+	Proxy       string                `json:"proxy"`
+	ProxyOnly   bool                  `json:"proxyonly"`
+	Leaks       int                   `json:"leaks"`
+	Resolutions []DNSResolutionResult `json:"resolutions"`
+}
+
+// DNSResolutionResult models how a host name was resolved returned from the getdnsaudit command.
+type DNSResolutionResult struct {
+	Host       string   `json:"host"`
+	Purposes   []string `json:"purposes"`
+	Direct     int      `json:"direct"`
+	Proxied    int      `json:"proxied"`
+	Local      int      `json:"local"`
+	Refused    int      `json:"refused"`
+	Failures   int      `json:"failures"`
+	LastPath   string   `json:"lastpath"`
+	LastLookup int64    `json:"lastlookup"`
+	LastError  string   `json:"lasterror,omitempty"`
+}
+
+// NodeAddressResult models a known address of a node returned from the getnodeaddresses command.
+type NodeAddressResult struct {
+	Time     int64  `json:"time"`
+	Services string `json:"services"`
+	Address  string `json:"address"`
+	Port     uint16 `json:"port"`
+	Network  string `json:"network"`
+}
+
 // GetPeerInfoResult models the data returned from the getpeerinfo command.
 type GetPeerInfoResult struct {
-	ID             int32   `json:"id"`
-	Addr           string  `json:"addr"`
-	AddrLocal      string  `json:"addrlocal,omitempty"`
-	Services       string  `json:"services"`
-	RelayTxes      bool    `json:"relaytxes"`
-	LastSend       int64   `json:"lastsend"`
-	LastRecv       int64   `json:"lastrecv"`
-	BytesSent      uint64  `json:"bytessent"`
-	BytesRecv      uint64  `json:"bytesrecv"`
-	ConnTime       int64   `json:"conntime"`
-	TimeOffset     int64   `json:"timeoffset"`
-	PingTime       float64 `json:"pingtime"`
-	PingWait       float64 `json:"pingwait,omitempty"`
-	Version        uint32  `json:"version"`
-	SubVer         string  `json:"subver"`
-	Inbound        bool    `json:"inbound"`
-	StartingHeight int32   `json:"startingheight"`
-	CurrentHeight  int32   `json:"currentheight,omitempty"`
-	BanScore       int32   `json:"banscore"`
-	FeeFilter      int64   `json:"feefilter"`
-	SyncNode       bool    `json:"syncnode"`
+	ID             int32             `json:"id"`
+	Addr           string            `json:"addr"`
+	AddrLocal      string            `json:"addrlocal,omitempty"`
+	Services       string            `json:"services"`
+	RelayTxes      bool              `json:"relaytxes"`
+	LastSend       int64             `json:"lastsend"`
+	LastRecv       int64             `json:"lastrecv"`
+	BytesSent      uint64            `json:"bytessent"`
+	BytesRecv      uint64            `json:"bytesrecv"`
+	ConnTime       int64             `json:"conntime"`
+	TimeOffset     int64             `json:"timeoffset"`
+	PingTime       float64           `json:"pingtime"`
+	PingWait       float64           `json:"pingwait,omitempty"`
+	Version        uint32            `json:"version"`
+	SubVer         string            `json:"subver"`
+	Inbound        bool              `json:"inbound"`
+	StartingHeight int32             `json:"startingheight"`
+	CurrentHeight  int32             `json:"currentheight,omitempty"`
+	BanScore       int32             `json:"banscore"`
+	FeeFilter      int64             `json:"feefilter"`
+	SyncNode       bool              `json:"syncnode"`
+	MsgRecv        map[string]uint64 `json:"msgrecv,omitempty"`
+	Patterns       map[string]uint64 `json:"patterns,omitempty"`
+	MsgDropped     uint64            `json:"msgdropped,omitempty"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool command when the verbose flag is set. When
@@ -286,6 +568,105 @@ type GetRawMempoolVerboseResult struct {
 	Depends          []string `json:"depends"`
 }
 
+// SelfTestResult models the data returned from the selftest command.
+type SelfTestResult struct {
+	Passed     bool                      `json:"passed"`
+	Algorithms []SelfTestAlgorithmResult `json:"algorithms"`
+}
+
+// SelfTestAlgorithmResult models the outcome of the known answer test vectors of an algorithm returned from the selftest
+// command.
+type SelfTestAlgorithmResult struct {
+	Name     string   `json:"name"`
+	Fork     int      `json:"fork"`
+	Version  int32    `json:"version"`
+	Vectors  int      `json:"vectors"`
+	Passed   bool     `json:"passed"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// TraceMempoolAcceptResult models the data returned from the tracemempoolaccept command.
+type TraceMempoolAcceptResult struct {
+	TxID           string                    `json:"txid"`
+	Accepted       bool                      `json:"accepted"`
+	Checks         []TraceMempoolCheckResult `json:"checks"`
+	MissingParents []string                  `json:"missingparents,omitempty"`
+	Fee            float64                   `json:"fee"`
+	ModifiedFee    float64                   `json:"modifiedfee"`
+	MinFee         float64                   `json:"minfee"`
+	VSize          int64                     `json:"vsize"`
+	ScriptInput    *int                      `json:"scriptinput,omitempty"`
+	ScriptPosition string                    `json:"scriptposition,omitempty"`
+}
+
+// TraceMempoolCheckResult models one of the policy checks returned from the tracemempoolaccept command.
+type TraceMempoolCheckResult struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	Details    string `json:"details,omitempty"`
+	RejectCode string `json:"rejectcode,omitempty"`
+}
+
+// EstimatorStatsResult models the data returned from the estimatorstats command.
+type EstimatorStatsResult struct {
+	Blocks         uint32                       `json:"blocks"`
+	Observed       int                          `json:"observed"`
+	Bias           float64                      `json:"bias"`
+	AutoBias       bool                         `json:"autobias"`
+	RecentCoverage float64                      `json:"recentcoverage"`
+	Targets        []EstimatorTargetStatsResult `json:"targets"`
+	MeanInterval   float64                      `json:"meaninterval"`
+	Algos          []EstimatorAlgoStatsResult   `json:"algos"`
+}
+
+// EstimatorTargetStatsResult models the calibration of one confirmation target returned from the estimatorstats
+// command.
+type EstimatorTargetStatsResult struct {
+	Target     int     `json:"target"`
+	FeeRate    float64 `json:"feerate"`
+	Predicted  int64   `json:"predicted"`
+	Confirmed  int64   `json:"confirmed"`
+	Coverage   float64 `json:"coverage"`
+	MeanBlocks float64 `json:"meanblocks"`
+}
+
+// EstimatorAlgoStatsResult models how often the recent blocks of an algorithm arrived returned from the estimatorstats
+// command.
+type EstimatorAlgoStatsResult struct {
+	Algo         string  `json:"algo"`
+	Blocks       int     `json:"blocks"`
+	Share        float64 `json:"share"`
+	MeanInterval float64 `json:"meaninterval"`
+}
+
+// EstimateSmartFeeResult models the data returned from the estimatesmartfee command.
+type EstimateSmartFeeResult struct {
+	FeeRate    float64  `json:"feerate"`
+	Blocks     int64    `json:"blocks"`
+	Likelihood float64  `json:"likelihood,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// GetBlockPropagationResult models the data returned from the getblockpropagation command.
+type GetBlockPropagationResult struct {
+	Blocks           []BlockPropagationResult `json:"blocks"`
+	AvgLatency       int64                    `json:"avglatency"`
+	MaxLatency       int64                    `json:"maxlatency"`
+	AvgAnnouncements float64                  `json:"avgannouncements"`
+}
+
+// BlockPropagationResult models the propagation of one block returned from the getblockpropagation command.
+type BlockPropagationResult struct {
+	Hash          string `json:"hash"`
+	Height        int32  `json:"height"`
+	Peer          string `json:"peer"`
+	Announcements int    `json:"announcements"`
+	Announced     int64  `json:"announced"`
+	Download      int64  `json:"download"`
+	Validation    int64  `json:"validation"`
+	Latency       int64  `json:"latency"`
+}
+
 // GetTxOutResult models the data from the gettxout command.
 type GetTxOutResult struct {
 	BestBlock     string             `json:"bestblock"`
@@ -302,6 +683,51 @@ type GetWorkResult struct {
 	Midstate string `json:"midstate"`
 	Target   string `json:"target"`
 }
+
+// GetMiningExpectationResult models the data from the getminingexpectation command.
+type GetMiningExpectationResult struct {
+	Updated      int64                         `json:"updated"`
+	Height       int32                         `json:"height"`
+	Hashrate     float64                       `json:"hashrate"`
+	ExpectedTime float64                       `json:"expectedtime"`
+	Windows      []MiningWindowResult          `json:"windows"`
+	Algos        []MiningAlgoExpectationResult `json:"algos"`
+}
+
+// MiningAlgoExpectationResult models the expectation of finding a block with one algorithm in the data from the
+// getminingexpectation command.
+type MiningAlgoExpectationResult struct {
+	Algo         string               `json:"algo"`
+	Version      int32                `json:"version"`
+	Difficulty   float64              `json:"difficulty"`
+	Hashrate     float64              `json:"hashrate"`
+	ExpectedTime float64              `json:"expectedtime"`
+	Windows      []MiningWindowResult `json:"windows"`
+}
+
+// MiningWindowResult models the chance of finding a block within a period in the data from the getminingexpectation
+// command.
+type MiningWindowResult struct {
+	Window      int64   `json:"window"`
+	Probability float64 `json:"probability"`
+}
+
+// GetWorkSourceResult models the data from the getworksource command.
+type GetWorkSourceResult struct {
+	Active  string             `json:"active"`
+	Since   int64              `json:"since"`
+	Sources []WorkSourceResult `json:"sources"`
+}
+
+// WorkSourceResult models the health of a source of mining work in the data from the getworksource command.
+type WorkSourceResult struct {
+	Host    string `json:"host"`
+	Healthy bool   `json:"healthy"`
+	Height  int32  `json:"height"`
+	Checked int64  `json:"checked"`
+	Error   string `json:"error,omitempty"`
+}
+
 type (
 	// InfoChainResult models the data returned by the chain server getinfo command.
 	InfoChainResult struct {
@@ -402,7 +828,7 @@ type (
 	// 		Status bool `json:"status"`
 	// 	} `json:"reject"`
 	// }
-	
+
 	// TxRawDecodeResult models the data from the decoderawtransaction command.
 	TxRawDecodeResult struct {
 		Txid     string `json:"txid"`
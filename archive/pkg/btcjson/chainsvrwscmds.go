@@ -30,17 +30,52 @@ func NewStopNotifyBlocksCmd() *StopNotifyBlocksCmd {
 	return &StopNotifyBlocksCmd{}
 }
 
-// NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command.
+// NotifyPeersCmd defines the notifypeers JSON-RPC command.
+type NotifyPeersCmd struct{}
+
+// NewNotifyPeersCmd returns a new instance which can be used to issue a notifypeers JSON-RPC command.
+func NewNotifyPeersCmd() *NotifyPeersCmd {
+	return &NotifyPeersCmd{}
+}
+
+// StopNotifyPeersCmd defines the stopnotifypeers JSON-RPC command.
+type StopNotifyPeersCmd struct{}
+
+// NewStopNotifyPeersCmd returns a new instance which can be used to issue a stopnotifypeers JSON-RPC command.
+func NewStopNotifyPeersCmd() *StopNotifyPeersCmd {
+	return &StopNotifyPeersCmd{}
+}
+
+// NotifyTipDeltasCmd defines the notifytipdeltas JSON-RPC command.
+type NotifyTipDeltasCmd struct{}
+
+// NewNotifyTipDeltasCmd returns a new instance which can be used to issue a notifytipdeltas JSON-RPC command.
+func NewNotifyTipDeltasCmd() *NotifyTipDeltasCmd {
+	return &NotifyTipDeltasCmd{}
+}
+
+// StopNotifyTipDeltasCmd defines the stopnotifytipdeltas JSON-RPC command.
+type StopNotifyTipDeltasCmd struct{}
+
+// NewStopNotifyTipDeltasCmd returns a new instance which can be used to issue a stopnotifytipdeltas JSON-RPC command.
+func NewStopNotifyTipDeltasCmd() *StopNotifyTipDeltasCmd {
+	return &StopNotifyTipDeltasCmd{}
+}
+
+// NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command. When Filtered is set, only transactions
+// matching the transaction filter loaded with loadtxfilter are sent.
 type NotifyNewTransactionsCmd struct {
-	Verbose *bool `jsonrpcdefault:"false"`
+	Verbose  *bool `jsonrpcdefault:"false"`
+	Filtered *bool `jsonrpcdefault:"false"`
 }
 
 // NewNotifyNewTransactionsCmd returns a new instance which can be used to issue a notifynewtransactions JSON-RPC
 // command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use
 // the default value.
-func NewNotifyNewTransactionsCmd(verbose *bool) *NotifyNewTransactionsCmd {
+func NewNotifyNewTransactionsCmd(verbose, filtered *bool) *NotifyNewTransactionsCmd {
 	return &NotifyNewTransactionsCmd{
-		Verbose: verbose,
+		Verbose:  verbose,
+		Filtered: filtered,
 	}
 }
 
@@ -89,16 +124,42 @@ type LoadTxFilterCmd struct {
 	Reload    bool
 	Addresses []string
 	OutPoints []OutPoint
+	Scripts   *[]string
 }
 
 // NewLoadTxFilterCmd returns a new instance which can be used to issue a loadtxfilter JSON-RPC command.
 //
 // NOTE: This is a pod extension ported from github.com/decred/dcrd/dcrjson and requires a websocket connection.
-func NewLoadTxFilterCmd(reload bool, addresses []string, outPoints []OutPoint) *LoadTxFilterCmd {
+func NewLoadTxFilterCmd(reload bool, addresses []string, outPoints []OutPoint, scripts *[]string) *LoadTxFilterCmd {
 	return &LoadTxFilterCmd{
 		Reload:    reload,
 		Addresses: addresses,
 		OutPoints: outPoints,
+		Scripts:   scripts,
+	}
+}
+
+// UpdateTxFilterCmd defines the updatetxfilter request parameters to add entries to or remove entries from a loaded
+// transaction filter. Scripts are hex encoded output scripts, which lets clients watch outputs that don't pay to an
+// address.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+type UpdateTxFilterCmd struct {
+	Remove    bool
+	Addresses []string
+	OutPoints []OutPoint
+	Scripts   *[]string
+}
+
+// NewUpdateTxFilterCmd returns a new instance which can be used to issue an updatetxfilter JSON-RPC command.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func NewUpdateTxFilterCmd(remove bool, addresses []string, outPoints []OutPoint, scripts *[]string) *UpdateTxFilterCmd {
+	return &UpdateTxFilterCmd{
+		Remove:    remove,
+		Addresses: addresses,
+		OutPoints: outPoints,
+		Scripts:   scripts,
 	}
 }
 
@@ -185,6 +246,13 @@ type RescanBlocksCmd struct {
 func NewRescanBlocksCmd(blockHashes []string) *RescanBlocksCmd {
 	return &RescanBlocksCmd{BlockHashes: blockHashes}
 }
+// chainSvrWsCmdSet declares the websocket-only chain server commands that are registered through RegisterCmds.
+type chainSvrWsCmdSet struct {
+	UpdateTxFilter struct {
+		Cmd *UpdateTxFilterCmd
+	} `jsonrpcmethod:"updatetxfilter" jsonrpcflags:"websocketonly"`
+}
+
 func init() {
 	
 	// The commands in this file are only usable by websockets.
@@ -193,13 +261,18 @@ func init() {
 	MustRegisterCmd("loadtxfilter", (*LoadTxFilterCmd)(nil), flags)
 	MustRegisterCmd("notifyblocks", (*NotifyBlocksCmd)(nil), flags)
 	MustRegisterCmd("notifynewtransactions", (*NotifyNewTransactionsCmd)(nil), flags)
+	MustRegisterCmd("notifypeers", (*NotifyPeersCmd)(nil), flags)
 	MustRegisterCmd("notifyreceived", (*NotifyReceivedCmd)(nil), flags)
 	MustRegisterCmd("notifyspent", (*NotifySpentCmd)(nil), flags)
+	MustRegisterCmd("notifytipdeltas", (*NotifyTipDeltasCmd)(nil), flags)
 	MustRegisterCmd("session", (*SessionCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyblocks", (*StopNotifyBlocksCmd)(nil), flags)
 	MustRegisterCmd("stopnotifynewtransactions", (*StopNotifyNewTransactionsCmd)(nil), flags)
+	MustRegisterCmd("stopnotifypeers", (*StopNotifyPeersCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyspent", (*StopNotifySpentCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyreceived", (*StopNotifyReceivedCmd)(nil), flags)
+	MustRegisterCmd("stopnotifytipdeltas", (*StopNotifyTipDeltasCmd)(nil), flags)
 	MustRegisterCmd("rescan", (*RescanCmd)(nil), flags)
 	MustRegisterCmd("rescanblocks", (*RescanBlocksCmd)(nil), flags)
+	MustRegisterCmds((*chainSvrWsCmdSet)(nil))
 }
@@ -56,17 +56,62 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyblocks","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.StopNotifyBlocksCmd{},
 		},
+		{
+			name: "notifypeers",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifypeers")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyPeersCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifypeers","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyPeersCmd{},
+		},
+		{
+			name: "stopnotifypeers",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifypeers")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyPeersCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifypeers","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyPeersCmd{},
+		},
+		{
+			name: "notifytipdeltas",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifytipdeltas")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyTipDeltasCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifytipdeltas","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyTipDeltasCmd{},
+		},
+		{
+			name: "stopnotifytipdeltas",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifytipdeltas")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyTipDeltasCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifytipdeltas","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyTipDeltasCmd{},
+		},
 		{
 			name: "notifynewtransactions",
 			newCmd: func() (interface{}, error) {
 				return btcjson.NewCmd("notifynewtransactions")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewNotifyNewTransactionsCmd(nil)
+				return btcjson.NewNotifyNewTransactionsCmd(nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"notifynewtransactions","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.NotifyNewTransactionsCmd{
-				Verbose: btcjson.Bool(false),
+				Verbose:  btcjson.Bool(false),
+				Filtered: btcjson.Bool(false),
 			},
 		},
 		{
@@ -75,11 +120,26 @@ func TestChainSvrWsCmds(t *testing.T) {
 				return btcjson.NewCmd("notifynewtransactions", true)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewNotifyNewTransactionsCmd(btcjson.Bool(true))
+				return btcjson.NewNotifyNewTransactionsCmd(btcjson.Bool(true), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"notifynewtransactions","netparams":[true],"id":1}`,
 			unmarshalled: &btcjson.NotifyNewTransactionsCmd{
-				Verbose: btcjson.Bool(true),
+				Verbose:  btcjson.Bool(true),
+				Filtered: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "notifynewtransactions filtered",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifynewtransactions", false, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyNewTransactionsCmd(btcjson.Bool(false), btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notifynewtransactions","netparams":[false,true],"id":1}`,
+			unmarshalled: &btcjson.NotifyNewTransactionsCmd{
+				Verbose:  btcjson.Bool(false),
+				Filtered: btcjson.Bool(true),
 			},
 		},
 		{
@@ -206,7 +266,7 @@ func TestChainSvrWsCmds(t *testing.T) {
 					Index: 0,
 				},
 				}
-				return btcjson.NewLoadTxFilterCmd(false, addrs, ops)
+				return btcjson.NewLoadTxFilterCmd(false, addrs, ops, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"loadtxfilter","netparams":[false,["1Address"],[{"hash":"0000000000000000000000000000000000000000000000000000000000000123","index":0}]],"id":1}`,
 			unmarshalled: &btcjson.LoadTxFilterCmd{
@@ -218,6 +278,34 @@ func TestChainSvrWsCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "updatetxfilter",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("updatetxfilter", true, `["1Address"]`,
+					`[{"hash":"0000000000000000000000000000000000000000000000000000000000000123","index":0}]`,
+					`["51"]`,
+				)
+			},
+			staticCmd: func() interface{} {
+				addrs := []string{"1Address"}
+				ops := []btcjson.OutPoint{{
+					Hash:  "0000000000000000000000000000000000000000000000000000000000000123",
+					Index: 0,
+				},
+				}
+				return btcjson.NewUpdateTxFilterCmd(true, addrs, ops, &[]string{"51"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"updatetxfilter","netparams":[true,["1Address"],[{"hash":"0000000000000000000000000000000000000000000000000000000000000123","index":0}],["51"]],"id":1}`,
+			unmarshalled: &btcjson.UpdateTxFilterCmd{
+				Remove:    true,
+				Addresses: []string{"1Address"},
+				OutPoints: []btcjson.OutPoint{{Hash: "0000000000000000000000000000000000000000000000000000000000000123",
+					Index: 0,
+				},
+				},
+				Scripts: &[]string{"51"},
+			},
+		},
 		{
 			name: "rescanblocks",
 			newCmd: func() (interface{}, error) {
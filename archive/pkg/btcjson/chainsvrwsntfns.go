@@ -38,6 +38,18 @@ const (
 	// RelevantTxAcceptedNtfnMethod is the new method used for notifications from the chain server that inform a client
 	// that a transaction that matches the loaded filter was accepted by the mempool.
 	RelevantTxAcceptedNtfnMethod = "relevanttxaccepted"
+	// TxExpiredNtfnMethod is the method used for notifications from the chain server that a transaction was evicted
+	// from the mempool because it was not mined before the mempool expiry.
+	TxExpiredNtfnMethod = "txexpired"
+	// PeerConnectedNtfnMethod is the method used for notifications from the chain server that a peer has completed the
+	// version handshake and was added to the connected peers.
+	PeerConnectedNtfnMethod = "peerconnected"
+	// PeerDisconnectedNtfnMethod is the method used for notifications from the chain server that a connected peer was
+	// disconnected, along with the reason.
+	PeerDisconnectedNtfnMethod = "peerdisconnected"
+	// TipDeltaNtfnMethod is the method used for notifications from the chain server that a block has become the new
+	// tip of the main chain, with its header and the short ids of its transactions.
+	TipDeltaNtfnMethod = "tipdelta"
 )
 
 // BlockConnectedNtfn defines the blockconnected JSON-RPC notification. NOTE: Deprecated. Use FilteredBlockConnectedNtfn
@@ -229,6 +241,47 @@ type RelevantTxAcceptedNtfn struct {
 func NewRelevantTxAcceptedNtfn(txHex string) *RelevantTxAcceptedNtfn {
 	return &RelevantTxAcceptedNtfn{Transaction: txHex}
 }
+
+// TxExpiredNtfn defines the txexpired JSON-RPC notification.
+type TxExpiredNtfn struct {
+	TxID string
+}
+
+// NewTxExpiredNtfn returns a new instance which can be used to issue a txexpired JSON-RPC notification.
+func NewTxExpiredNtfn(txHash string) *TxExpiredNtfn {
+	return &TxExpiredNtfn{TxID: txHash}
+}
+
+// PeerConnectedNtfn defines the peerconnected JSON-RPC notification.
+type PeerConnectedNtfn struct {
+	Peer PeerEventResult
+}
+
+// NewPeerConnectedNtfn returns a new instance which can be used to issue a peerconnected JSON-RPC notification.
+func NewPeerConnectedNtfn(peer PeerEventResult) *PeerConnectedNtfn {
+	return &PeerConnectedNtfn{Peer: peer}
+}
+
+// PeerDisconnectedNtfn defines the peerdisconnected JSON-RPC notification.
+type PeerDisconnectedNtfn struct {
+	Peer PeerEventResult
+}
+
+// NewPeerDisconnectedNtfn returns a new instance which can be used to issue a peerdisconnected JSON-RPC notification.
+func NewPeerDisconnectedNtfn(peer PeerEventResult) *PeerDisconnectedNtfn {
+	return &PeerDisconnectedNtfn{Peer: peer}
+}
+
+// TipDeltaNtfn defines the tipdelta JSON-RPC notification.
+type TipDeltaNtfn struct {
+	Delta TipDeltaResult
+}
+
+// NewTipDeltaNtfn returns a new instance which can be used to issue a tipdelta JSON-RPC notification.
+func NewTipDeltaNtfn(delta TipDeltaResult) *TipDeltaNtfn {
+	return &TipDeltaNtfn{Delta: delta}
+}
+
 func init() {
 	
 	// The commands in this file are only usable by websockets and are notifications.
@@ -244,4 +297,8 @@ func init() {
 	MustRegisterCmd(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
 	MustRegisterCmd(TxAcceptedVerboseNtfnMethod, (*TxAcceptedVerboseNtfn)(nil), flags)
 	MustRegisterCmd(RelevantTxAcceptedNtfnMethod, (*RelevantTxAcceptedNtfn)(nil), flags)
+	MustRegisterCmd(TxExpiredNtfnMethod, (*TxExpiredNtfn)(nil), flags)
+	MustRegisterCmd(PeerConnectedNtfnMethod, (*PeerConnectedNtfn)(nil), flags)
+	MustRegisterCmd(PeerDisconnectedNtfnMethod, (*PeerDisconnectedNtfn)(nil), flags)
+	MustRegisterCmd(TipDeltaNtfnMethod, (*TipDeltaNtfn)(nil), flags)
 }
@@ -222,6 +222,85 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Transaction: "001122",
 			},
 		},
+		{
+			name: "txexpired",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("txexpired", "123")
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTxExpiredNtfn("123")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"txexpired","netparams":["123"],"id":null}`,
+			unmarshalled: &btcjson.TxExpiredNtfn{
+				TxID: "123",
+			},
+		},
+		{
+			name: "tipdelta",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"tipdelta",
+					`{"hash":"123","height":100,"header":"0001","nonce":7,"coinbase":"0002","shortids":["0a0b0c0d0e0f"]}`,
+				)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTipDeltaNtfn(
+					btcjson.TipDeltaResult{
+						Hash:     "123",
+						Height:   100,
+						Header:   "0001",
+						Nonce:    7,
+						Coinbase: "0002",
+						ShortIDs: []string{"0a0b0c0d0e0f"},
+					},
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"tipdelta","netparams":[{"hash":"123","height":100,"header":"0001","nonce":7,"coinbase":"0002","shortids":["0a0b0c0d0e0f"]}],"id":null}`,
+			unmarshalled: &btcjson.TipDeltaNtfn{
+				Delta: btcjson.TipDeltaResult{
+					Hash:     "123",
+					Height:   100,
+					Header:   "0001",
+					Nonce:    7,
+					Coinbase: "0002",
+					ShortIDs: []string{"0a0b0c0d0e0f"},
+				},
+			},
+		},
+		{
+			name: "peerdisconnected",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"peerdisconnected",
+					`{"id":3,"addr":"127.0.0.1:11047","inbound":true,"persistent":false,"services":"SFNodeNetwork","subver":"/pod:0.1.0/","time":1600000000,"reason":"banned"}`,
+				)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewPeerDisconnectedNtfn(
+					btcjson.PeerEventResult{
+						ID:       3,
+						Addr:     "127.0.0.1:11047",
+						Inbound:  true,
+						Services: "SFNodeNetwork",
+						SubVer:   "/pod:0.1.0/",
+						Time:     1600000000,
+						Reason:   "banned",
+					},
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"peerdisconnected","netparams":[{"id":3,"addr":"127.0.0.1:11047","inbound":true,"persistent":false,"services":"SFNodeNetwork","subver":"/pod:0.1.0/","time":1600000000,"reason":"banned"}],"id":null}`,
+			unmarshalled: &btcjson.PeerDisconnectedNtfn{
+				Peer: btcjson.PeerEventResult{
+					ID:       3,
+					Addr:     "127.0.0.1:11047",
+					Inbound:  true,
+					Services: "SFNodeNetwork",
+					SubVer:   "/pod:0.1.0/",
+					Time:     1600000000,
+					Reason:   "banned",
+				},
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -12,3 +12,29 @@ type RescannedBlock struct {
 	Hash         string   `json:"hash"`
 	Transactions []string `json:"transactions"`
 }
+
+// PeerEventResult models a peer that connected to or disconnected from the chain server, as sent with the
+// peerconnected and peerdisconnected notifications and to the peer events webhook.
+type PeerEventResult struct {
+	ID         int32  `json:"id"`
+	Addr       string `json:"addr"`
+	Inbound    bool   `json:"inbound"`
+	Persistent bool   `json:"persistent"`
+	Services   string `json:"services"`
+	SubVer     string `json:"subver"`
+	Time       int64  `json:"time"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// TipDeltaResult models a block that has become the new tip of the main chain, as sent with the tipdelta notification.
+// The transactions other than the coinbase are given by their short ids, the lower 6 bytes in hex of the SipHash-2-4 of
+// the txid keyed with the first 16 bytes of the SHA256 of the serialized header followed by the nonce in little endian,
+// so a template builder can tell which of them are in its mempool without fetching the block.
+type TipDeltaResult struct {
+	Hash     string   `json:"hash"`
+	Height   int32    `json:"height"`
+	Header   string   `json:"header"`
+	Nonce    uint64   `json:"nonce"`
+	Coinbase string   `json:"coinbase"`
+	ShortIDs []string `json:"shortids"`
+}
@@ -0,0 +1,120 @@
+package btcjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// CompatBitcoind is the compatibility mode that adjusts results to the conventions of recent versions of bitcoind, so
+// explorers and accounting tools written for bitcoind can read them unchanged.
+const CompatBitcoind = "bitcoind"
+
+// CompatModes are the compatibility modes results can be converted to.
+var CompatModes = []string{CompatBitcoind}
+
+// CheckCompatMode returns an error if the mode is not empty or one of the compatibility modes.
+func CheckCompatMode(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	for _, m := range CompatModes {
+		if mode == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown compatibility mode %q, must be one of %v", mode, CompatModes)
+}
+
+// CompatResult converts a result to the conventions of a compatibility mode, returning it ready to be marshalled. An
+// empty mode returns the result as it is.
+//
+// In the bitcoind mode:
+//
+//   - scripts with a single address have it as "address" in place of the "addresses" array, and "reqSigs" is left out
+//   - the "prevOut" of an input is named "prevout"
+//   - output values are written with 8 decimal places, never in exponent notation
+//   - sizes given as strings are written as numbers
+func CompatResult(mode string, result interface{}) (interface{}, error) {
+	if e := CheckCompatMode(mode); e != nil {
+		return nil, e
+	}
+	if mode == "" || result == nil {
+		return result, nil
+	}
+	b, e := json.Marshal(result)
+	if e != nil {
+		return nil, e
+	}
+	// The numbers are decoded as they were written so those that are not changed keep their exact value.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if e = dec.Decode(&v); e != nil {
+		return nil, e
+	}
+	if b, e = json.Marshal(bitcoindCompat(v)); e != nil {
+		return nil, e
+	}
+	return json.RawMessage(b), nil
+}
+
+// bitcoindCompat converts a decoded result value to the conventions of bitcoind.
+func bitcoindCompat(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = bitcoindCompat(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = bitcoindCompat(v[k])
+		}
+		if prevOut, ok := v["prevOut"]; ok {
+			delete(v, "prevOut")
+			v["prevout"] = prevOut
+		}
+		_, isScript := v["asm"]
+		if _, ok := v["type"]; ok && isScript {
+			singleAddress(v)
+		}
+		if _, ok := v["scriptPubKey"]; ok {
+			fixedValue(v)
+		}
+		if prevOut, ok := v["prevout"].(map[string]interface{}); ok {
+			singleAddress(prevOut)
+			fixedValue(prevOut)
+		}
+		for _, k := range []string{"size", "vsize", "weight"} {
+			if s, ok := v[k].(string); ok {
+				if _, e := strconv.ParseInt(s, 10, 64); e == nil {
+					v[k] = json.Number(s)
+				}
+			}
+		}
+	}
+	return v
+}
+
+// singleAddress replaces the addresses of a script with the address of the script if it has exactly one, and drops the
+// signature count, as bitcoind does since version 22.
+func singleAddress(m map[string]interface{}) {
+	if addresses, ok := m["addresses"].([]interface{}); ok && len(addresses) == 1 {
+		m["address"] = addresses[0]
+	}
+	delete(m, "addresses")
+	delete(m, "reqSigs")
+}
+
+// fixedValue writes the value of an output with the 8 decimal places of bitcoind, as tools parsing amounts as decimals
+// may not read the exponent notation small values are otherwise written in.
+func fixedValue(m map[string]interface{}) {
+	n, ok := m["value"].(json.Number)
+	if !ok {
+		return
+	}
+	if f, e := n.Float64(); e == nil {
+		m["value"] = json.Number(strconv.FormatFloat(f, 'f', 8, 64))
+	}
+}
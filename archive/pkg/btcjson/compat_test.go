@@ -0,0 +1,90 @@
+package btcjson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// TestCompatResult ensures results converted to the bitcoind mode have the fields of bitcoind, and that results are
+// left alone without a mode.
+func TestCompatResult(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		result   interface{}
+		expected string
+	}{
+		{
+			name: "transaction",
+			result: &btcjson.TxRawResult{
+				Txid: "123",
+				Size: 100,
+				Vin:  []btcjson.Vin{{Coinbase: "00", Sequence: 1}},
+				Vout: []btcjson.Vout{
+					{
+						Value: 0.00000001,
+						ScriptPubKey: btcjson.ScriptPubKeyResult{
+							Asm: "asm", ReqSigs: 1, Type: "pubkeyhash", Addresses: []string{"addr1"},
+						},
+					},
+					{
+						Value: 2,
+						N:     1,
+						ScriptPubKey: btcjson.ScriptPubKeyResult{
+							Asm: "asm", ReqSigs: 1, Type: "multisig", Addresses: []string{"addr1", "addr2"},
+						},
+					},
+				},
+			},
+			expected: `{"hex":"","locktime":0,"size":100,"txid":"123","version":0,` +
+				`"vin":[{"coinbase":"00","sequence":1}],"vout":[` +
+				`{"n":0,"scriptPubKey":{"address":"addr1","asm":"asm","type":"pubkeyhash"},"value":0.00000001},` +
+				`{"n":1,"scriptPubKey":{"asm":"asm","type":"multisig"},"value":2.00000000}]}`,
+		},
+		{
+			name: "searchrawtransactions",
+			result: &btcjson.SearchRawTransactionsResult{
+				Size:  "250",
+				Vsize: "250",
+				Vin: []btcjson.VinPrevOut{
+					{
+						Txid:      "456",
+						ScriptSig: &btcjson.ScriptSig{Asm: "asm", Hex: "00"},
+						PrevOut:   &btcjson.PrevOut{Addresses: []string{"addr1"}, Value: 0.000005},
+					},
+				},
+			},
+			expected: `{"hash":"","locktime":0,"size":250,"txid":"","version":0,` +
+				`"vin":[{"prevout":{"address":"addr1","value":0.00000500},"scriptSig":{"asm":"asm","hex":"00"},` +
+				`"sequence":0,"txid":"456","vout":0}],"vout":null,"vsize":250}`,
+		},
+		{
+			name:     "decodescript",
+			result:   &btcjson.DecodeScriptResult{Asm: "asm", ReqSigs: 1, Type: "scripthash", Addresses: []string{"a"}},
+			expected: `{"address":"a","asm":"asm","type":"scripthash"}`,
+		},
+	}
+	for _, test := range tests {
+		converted, e := btcjson.CompatResult(btcjson.CompatBitcoind, test.result)
+		if e != nil {
+			t.Errorf("%s: %v", test.name, e)
+			continue
+		}
+		marshalled, e := json.Marshal(converted)
+		if e != nil {
+			t.Errorf("%s: %v", test.name, e)
+			continue
+		}
+		if string(marshalled) != test.expected {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, marshalled, test.expected)
+		}
+		if unchanged, _ := btcjson.CompatResult("", test.result); unchanged != test.result {
+			t.Errorf("%s: the result was changed without a compatibility mode", test.name)
+		}
+	}
+	if _, e := btcjson.CompatResult("core", nil); e == nil {
+		t.Error("an unknown compatibility mode was accepted")
+	}
+}
@@ -453,14 +453,14 @@ func GenerateHelp(method string, descs map[string]string, resultTypes ...interfa
 		if resultType == nil {
 			continue
 		}
-		rtp = reflect.TypeOf(resultType)
-		if rtp.Kind() != reflect.Ptr {
+		rrt := reflect.TypeOf(resultType)
+		if rrt.Kind() != reflect.Ptr {
 			str := fmt.Sprintf("result #%d (%v) is not a pointer",
-				i, rtp.Kind(),
+				i, rrt.Kind(),
 			)
 			return "", makeError(ErrInvalidType, str)
 		}
-		elemKind := rtp.Elem().Kind()
+		elemKind := rrt.Elem().Kind()
 		if !isValidResultType(elemKind) {
 			str := fmt.Sprintf("result #%d (%v) is not an allowed "+
 				"type", i, elemKind,
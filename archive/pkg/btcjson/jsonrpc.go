@@ -113,6 +113,23 @@ func NewRequest(id interface{}, method string, params []interface{}) (rq *Reques
 	}, nil
 }
 
+// UnmarshalRequest unmarshals a JSON-RPC request. With legacy set, the parameters of a request that has no netparams
+// member are taken from its params member, which is where clients of the legacy Parallelcoin daemon and of Bitcoin Core
+// put them.
+func UnmarshalRequest(data []byte, legacy bool) (rq Request, e error) {
+	if e = json.Unmarshal(data, &rq); e != nil || !legacy || rq.Params != nil {
+		return
+	}
+	var legacyRq struct {
+		Params []json.RawMessage `json:"params"`
+	}
+	if e = json.Unmarshal(data, &legacyRq); e != nil {
+		return
+	}
+	rq.Params = legacyRq.Params
+	return
+}
+
 // NewResponse returns a new JSON-RPC response object given the provided id, marshalled result, and RPC error. This
 // function is only provided in case the caller wants to construct raw responses for some reason. Typically callers will
 // instead want to create the fully marshalled JSON-RPC response to send over the wire with the MarshalResponse
@@ -94,6 +94,38 @@ func TestMarshalResponse(t *testing.T) {
 	}
 }
 
+// TestUnmarshalRequest ensures the parameters of a request are only taken from its params member in legacy mode, and
+// that its netparams member is preferred to it.
+func TestUnmarshalRequest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		data   string
+		legacy bool
+		params int
+	}{
+		{"netparams", `{"method":"getbalance","netparams":["acct",1],"id":1}`, false, 2},
+		{"params", `{"method":"getbalance","params":["acct",1],"id":1}`, false, 0},
+		{"legacy params", `{"method":"getbalance","params":["acct",1],"id":1}`, true, 2},
+		{"legacy both", `{"method":"getbalance","netparams":["acct"],"params":["acct",1],"id":1}`, true, 1},
+	}
+	for i, test := range tests {
+		rq, e := btcjson.UnmarshalRequest([]byte(test.data), test.legacy)
+		if e != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i, test.name, e)
+			continue
+		}
+		if rq.Method != "getbalance" || len(rq.Params) != test.params {
+			t.Errorf("Test #%d (%s) got method %q with %d params, want %d", i, test.name, rq.Method, len(rq.Params),
+				test.params,
+			)
+		}
+	}
+	if _, e := btcjson.UnmarshalRequest([]byte(`{"method":`), true); e == nil {
+		t.Error("UnmarshalRequest: did not receive error")
+	}
+}
+
 // TestMiscErrors tests a few error conditions not covered elsewhere.
 func TestMiscErrors(t *testing.T) {
 	t.Parallel()
@@ -44,6 +44,12 @@ const (
 	ErrRPCNoWallet      RPCErrorCode = -1
 	ErrRPCNoChain       RPCErrorCode = -1
 	ErrRPCUnimplemented RPCErrorCode = -1
+	ErrRPCHighFee       RPCErrorCode = -26
+	// ErrRPCWalletSpendAuthRequired is returned for a spend above the limit of the wallet sent without the code it
+	// needs, so the code can be asked for and the spend sent again.
+	ErrRPCWalletSpendAuthRequired RPCErrorCode = -40
+	// ErrRPCWalletSpendAuthIncorrect is returned for a spend sent with a code that is wrong or has already been used.
+	ErrRPCWalletSpendAuthIncorrect RPCErrorCode = -41
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -42,6 +42,7 @@ var (
 	concreteTypeToMethod = make(map[reflect.Type]string)
 	methodToConcreteType = make(map[string]reflect.Type)
 	methodToInfo         = make(map[string]MethodInfo)
+	methodToResultType   = make(map[string]reflect.Type)
 	// These fields are used to map the registered types to method names.
 	registerLock sync.RWMutex
 	// Map of UsageFlag values back to their constant names for pretty printing.
@@ -50,6 +51,12 @@ var (
 		UFWebsocketOnly: "UFWebsocketOnly",
 		UFNotification:  "UFNotification",
 	}
+	// Map of the names accepted in a 'jsonrpcflags' struct tag to their UsageFlag values.
+	usageFlagTags = map[string]UsageFlag{
+		"walletonly":    UFWalletOnly,
+		"websocketonly": UFWebsocketOnly,
+		"notification":  UFNotification,
+	}
 )
 
 // String returns the UsageFlag in human-readable form.
@@ -234,6 +241,120 @@ func RegisterCmd(method string, cmd interface{}, flags UsageFlag) (e error) {
 	return nil
 }
 
+// MustRegisterCmds performs the same function as RegisterCmds except it panics if there is an error. This should only
+// be called from package init functions.
+func MustRegisterCmds(set interface{}) {
+	if e := RegisterCmds(set); E.Chk(e) {
+		panic(fmt.Sprintf("failed to register command set %T: %v\n", set, e))
+	}
+}
+
+/*
+RegisterCmds registers every command declared in a command set, which saves repeating the method name, usage flags and
+result types of a new command across the registration, help and handler tables.
+
+A command set is a pointer to a struct whose fields each declare one command. Every field must itself be a struct with
+a 'Cmd' field holding a pointer to the command type, as accepted by RegisterCmd, and optionally a 'Result' field
+holding a pointer to the type returned by the method. The field's struct tags supply the rest:
+
+  - 'jsonrpcmethod' is the name of the method and is required
+
+  - 'jsonrpcflags' is an optional comma separated list of usage flags, any of walletonly, websocketonly and notification
+
+Parameters, their order and their defaults are taken from the command type exactly as RegisterCmd does, including
+'jsonrpcdefault' tags, so usage text and marshalling for the new command are generated with no further work. The result
+type is recorded and can be retrieved with MethodResultTypes to generate help. For example:
+
+  type fooCmds struct {
+  	GetFoo struct {
+  		Cmd    *GetFooCmd
+  		Result *GetFooResult
+  	} `jsonrpcmethod:"getfoo" jsonrpcflags:"websocketonly"`
+  }
+
+  MustRegisterCmds((*fooCmds)(nil))
+
+Like RegisterCmd, only the structure of the set is examined, so a nil pointer cast to the set type is sufficient.
+*/
+func RegisterCmds(set interface{}) (e error) {
+	rtp := reflect.TypeOf(set)
+	if rtp == nil || rtp.Kind() != reflect.Ptr || rtp.Elem().Kind() != reflect.Struct {
+		str := fmt.Sprintf("command set must be *struct not '%v'", rtp)
+		return makeError(ErrInvalidType, str)
+	}
+	rt := rtp.Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		rtf := rt.Field(i)
+		method := rtf.Tag.Get("jsonrpcmethod")
+		if method == "" {
+			str := fmt.Sprintf("command set field %q has no "+
+				"'jsonrpcmethod' tag", rtf.Name,
+			)
+			return makeError(ErrInvalidType, str)
+		}
+		if rtf.Type.Kind() != reflect.Struct {
+			str := fmt.Sprintf("command set field %q must be a "+
+				"struct not '%s'", rtf.Name, rtf.Type,
+			)
+			return makeError(ErrInvalidType, str)
+		}
+		var flags UsageFlag
+		if tag := rtf.Tag.Get("jsonrpcflags"); tag != "" {
+			for _, name := range strings.Split(tag, ",") {
+				flag, ok := usageFlagTags[strings.TrimSpace(name)]
+				if !ok {
+					str := fmt.Sprintf("unknown usage flag %q "+
+						"for method %s", name, method,
+					)
+					return makeError(ErrInvalidUsageFlags, str)
+				}
+				flags |= flag
+			}
+		}
+		cmdField, ok := rtf.Type.FieldByName("Cmd")
+		if !ok {
+			str := fmt.Sprintf("command set field %q has no 'Cmd' "+
+				"field", rtf.Name,
+			)
+			return makeError(ErrInvalidType, str)
+		}
+		var resultType reflect.Type
+		if resultField, ok := rtf.Type.FieldByName("Result"); ok {
+			resultType = resultField.Type
+			if resultType.Kind() != reflect.Ptr ||
+				!isValidResultType(resultType.Elem().Kind()) {
+				str := fmt.Sprintf("result type '%s' for method "+
+					"%s is not an allowed type", resultType, method,
+				)
+				return makeError(ErrInvalidType, str)
+			}
+		}
+		cmd := reflect.Zero(cmdField.Type).Interface()
+		if e = RegisterCmd(method, cmd, flags); e != nil {
+			return e
+		}
+		registerLock.Lock()
+		RegisteredCommands[method] = cmd
+		if resultType != nil {
+			methodToResultType[method] = resultType
+		}
+		registerLock.Unlock()
+	}
+	return nil
+}
+
+// MethodResultTypes returns the result type recorded for a method registered with RegisterCmds, as a nil pointer of
+// that type suitable for passing to GenerateHelp. The second return value is false when no result type is known.
+func MethodResultTypes(method string) ([]interface{}, bool) {
+	registerLock.RLock()
+	rt, ok := methodToResultType[method]
+	registerLock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return []interface{}{reflect.Zero(rt).Interface()}, true
+}
+
 // RegisteredCmdMethods returns a sorted list of methods for all registered commands.
 func RegisteredCmdMethods() []string {
 	registerLock.Lock()
@@ -251,3 +251,109 @@ func TestRegisteredCmdMethods(t *testing.T) {
 		t.Fatal("RegisteredCmdMethods: methods are not sorted")
 	}
 }
+
+// TestRegisterCmds ensures the RegisterCmds function registers the commands declared in a command set with the flags
+// and result types given in their struct tags, and returns the expected error when provided with invalid sets.
+func TestRegisterCmds(t *testing.T) {
+	t.Parallel()
+	type registerCmdsTestCmd struct {
+		A int
+		B *string `jsonrpcdefault:"\"b\""`
+	}
+	type registerCmdsTestResult struct {
+		C int `json:"c"`
+	}
+	type validSet struct {
+		RegisterCmdsTest struct {
+			Cmd    *registerCmdsTestCmd
+			Result *registerCmdsTestResult
+		} `jsonrpcmethod:"registercmdstest" jsonrpcflags:"walletonly,websocketonly"`
+	}
+	if e := btcjson.RegisterCmds((*validSet)(nil)); e != nil {
+		t.Fatalf("RegisterCmds: unexpected error: %v", e)
+	}
+	flags, e := btcjson.MethodUsageFlags("registercmdstest")
+	if e != nil {
+		t.Fatalf("MethodUsageFlags: unexpected error: %v", e)
+	}
+	if want := btcjson.UFWalletOnly | btcjson.UFWebsocketOnly; flags != want {
+		t.Errorf("MethodUsageFlags: got %v, want %v", flags, want)
+	}
+	usage, e := btcjson.MethodUsageText("registercmdstest")
+	if e != nil {
+		t.Fatalf("MethodUsageText: unexpected error: %v", e)
+	}
+	if want := `registercmdstest a (b="b")`; usage != want {
+		t.Errorf("MethodUsageText: got %q, want %q", usage, want)
+	}
+	resultTypes, ok := btcjson.MethodResultTypes("registercmdstest")
+	if !ok || len(resultTypes) != 1 ||
+		reflect.TypeOf(resultTypes[0]) != reflect.TypeOf((*registerCmdsTestResult)(nil)) {
+		t.Errorf("MethodResultTypes: got %v (%v)", resultTypes, ok)
+	}
+	tests := []struct {
+		name string
+		set  interface{}
+		e    btcjson.GeneralError
+	}{
+		{
+			name: "not a struct pointer",
+			set:  0,
+			e:    btcjson.GeneralError{ErrorCode: btcjson.ErrInvalidType},
+		},
+		{
+			name: "missing method tag",
+			set: (*struct {
+				A struct{ Cmd *registerCmdsTestCmd }
+			})(nil),
+			e: btcjson.GeneralError{ErrorCode: btcjson.ErrInvalidType},
+		},
+		{
+			name: "missing cmd field",
+			set: (*struct {
+				A struct{ Result *int } `jsonrpcmethod:"registercmdsnocmd"`
+			})(nil),
+			e: btcjson.GeneralError{ErrorCode: btcjson.ErrInvalidType},
+		},
+		{
+			name: "unknown flag",
+			set: (*struct {
+				A struct{ Cmd *registerCmdsTestCmd } `jsonrpcmethod:"registercmdsflag" jsonrpcflags:"bogus"`
+			})(nil),
+			e: btcjson.GeneralError{ErrorCode: btcjson.ErrInvalidUsageFlags},
+		},
+		{
+			name: "result not a pointer",
+			set: (*struct {
+				A struct {
+					Cmd    *registerCmdsTestCmd
+					Result int
+				} `jsonrpcmethod:"registercmdsresult"`
+			})(nil),
+			e: btcjson.GeneralError{ErrorCode: btcjson.ErrInvalidType},
+		},
+		{
+			name: "duplicate method",
+			set:  (*validSet)(nil),
+			e:    btcjson.GeneralError{ErrorCode: btcjson.ErrDuplicateMethod},
+		},
+	}
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		e := btcjson.RegisterCmds(test.set)
+		if reflect.TypeOf(e) != reflect.TypeOf(test.e) {
+			t.Errorf("Test #%d (%s) wrong error - got %T, "+
+				"want %T", i, test.name, e, test.e,
+			)
+			continue
+		}
+		gotErrorCode := e.(btcjson.GeneralError).ErrorCode
+		if gotErrorCode != test.e.ErrorCode {
+			t.Errorf("Test #%d (%s) mismatched error code - got "+
+				"%v, want %v", i, test.name, gotErrorCode,
+				test.e.ErrorCode,
+			)
+			continue
+		}
+	}
+}
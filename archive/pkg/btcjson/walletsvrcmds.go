@@ -18,6 +18,27 @@ func NewAddMultisigAddressCmd(nRequired int, keys []string, account *string) *Ad
 	}
 }
 
+// AddPortfolioEntryCmd defines the addportfolioentry JSON-RPC command.
+type AddPortfolioEntryCmd struct {
+	Name        string
+	Descriptors []string
+	Range       *int  `jsonrpcdefault:"1000"`
+	Rescan      *bool `jsonrpcdefault:"true"`
+}
+
+// NewAddPortfolioEntryCmd returns a new instance which can be used to issue an addportfolioentry JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewAddPortfolioEntryCmd(name string, descriptors []string, rng *int, rescan *bool) *AddPortfolioEntryCmd {
+	return &AddPortfolioEntryCmd{
+		Name:        name,
+		Descriptors: descriptors,
+		Range:       rng,
+		Rescan:      rescan,
+	}
+}
+
 // AddWitnessAddressCmd defines the addwitnessaddress JSON-RPC command.
 type AddWitnessAddressCmd struct {
 	Address string
@@ -31,6 +52,79 @@ func NewAddWitnessAddressCmd(address string) *AddWitnessAddressCmd {
 	}
 }
 
+// ArchiveAccountCmd defines the archiveaccount JSON-RPC command.
+type ArchiveAccountCmd struct {
+	Account string
+}
+
+// NewArchiveAccountCmd returns a new instance which can be used to issue an archiveaccount JSON-RPC command.
+func NewArchiveAccountCmd(account string) *ArchiveAccountCmd {
+	return &ArchiveAccountCmd{
+		Account: account,
+	}
+}
+
+// BackupRemoteCmd defines the backupremote JSON-RPC command.
+type BackupRemoteCmd struct {
+	Force *bool `jsonrpcdefault:"false"`
+}
+
+// NewBackupRemoteCmd returns a new instance which can be used to issue a backupremote JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewBackupRemoteCmd(force *bool) *BackupRemoteCmd {
+	return &BackupRemoteCmd{
+		Force: force,
+	}
+}
+
+// CancelQueuedPSBTCmd defines the cancelqueuedpsbt JSON-RPC command.
+type CancelQueuedPSBTCmd struct {
+	ID string
+}
+
+// NewCancelQueuedPSBTCmd returns a new instance which can be used to issue a cancelqueuedpsbt JSON-RPC command.
+func NewCancelQueuedPSBTCmd(id string) *CancelQueuedPSBTCmd {
+	return &CancelQueuedPSBTCmd{
+		ID: id,
+	}
+}
+
+// CreateMultiSigAccountCmd defines the createmultisigaccount JSON-RPC command.
+type CreateMultiSigAccountCmd struct {
+	Name      string
+	NRequired int
+	XPubs     []string
+}
+
+// NewCreateMultiSigAccountCmd returns a new instance which can be used to issue a createmultisigaccount JSON-RPC
+// command.
+func NewCreateMultiSigAccountCmd(name string, nRequired int, xpubs []string) *CreateMultiSigAccountCmd {
+	return &CreateMultiSigAccountCmd{
+		Name:      name,
+		NRequired: nRequired,
+		XPubs:     xpubs,
+	}
+}
+
+// CreateVaultAccountCmd defines the createvaultaccount JSON-RPC command.
+type CreateVaultAccountCmd struct {
+	Name       string
+	LockHeight *int32 `jsonrpcdefault:"0"`
+	Delay      *int32 `jsonrpcdefault:"0"`
+}
+
+// NewCreateVaultAccountCmd returns a new instance which can be used to issue a createvaultaccount JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewCreateVaultAccountCmd(name string, lockHeight, delay *int32) *CreateVaultAccountCmd {
+	return &CreateVaultAccountCmd{
+		Name:       name,
+		LockHeight: lockHeight,
+		Delay:      delay,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 type CreateMultisigCmd struct {
 	NRequired int
@@ -45,6 +139,18 @@ func NewCreateMultisigCmd(nRequired int, keys []string) *CreateMultisigCmd {
 	}
 }
 
+// DeleteAddressMetaCmd defines the deleteaddressmeta JSON-RPC command.
+type DeleteAddressMetaCmd struct {
+	Address string
+}
+
+// NewDeleteAddressMetaCmd returns a new instance which can be used to issue a deleteaddressmeta JSON-RPC command.
+func NewDeleteAddressMetaCmd(address string) *DeleteAddressMetaCmd {
+	return &DeleteAddressMetaCmd{
+		Address: address,
+	}
+}
+
 // DropWalletHistoryCmd defines the restart JSON-RPC command.
 type DropWalletHistoryCmd struct{}
 
@@ -101,6 +207,101 @@ func NewEstimatePriorityCmd(numBlocks int64) *EstimatePriorityCmd {
 	}
 }
 
+// ExportAccountXprvCmd defines the exportaccountxprv JSON-RPC command.
+type ExportAccountXprvCmd struct {
+	Account   string
+	Password  string
+	Plaintext *bool `jsonrpcdefault:"false"`
+}
+
+// NewExportAccountXprvCmd returns a new instance which can be used to issue an exportaccountxprv JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewExportAccountXprvCmd(account, password string, plaintext *bool) *ExportAccountXprvCmd {
+	return &ExportAccountXprvCmd{
+		Account:   account,
+		Password:  password,
+		Plaintext: plaintext,
+	}
+}
+
+// ExportLedgerCmd defines the exportledger JSON-RPC command. Format is "ledger" or "beancount", and Commodity is the
+// name the amounts are given in.
+type ExportLedgerCmd struct {
+	Format    *string `jsonrpcdefault:"\"ledger\""`
+	Commodity *string `jsonrpcdefault:"\"DUO\""`
+}
+
+// NewExportLedgerCmd returns a new instance which can be used to issue an exportledger JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewExportLedgerCmd(format, commodity *string) *ExportLedgerCmd {
+	return &ExportLedgerCmd{
+		Format:    format,
+		Commodity: commodity,
+	}
+}
+
+// PaymentBundleRequest is a payment request made with a fresh address of a bundle exported by the exportpaymentbundle
+// JSON-RPC command. An amount of zero accepts any amount.
+type PaymentBundleRequest struct {
+	Label  string  `json:"label"`
+	Amount float64 `json:"amount"`
+}
+
+// ExportPaymentBundleCmd defines the exportpaymentbundle JSON-RPC command. Expires is the time the requests expire in
+// seconds since 1 Jan 1970 GMT, or zero if they don't.
+type ExportPaymentBundleCmd struct {
+	Account     string
+	Requests    []PaymentBundleRequest
+	Expires     *int64 `jsonrpcdefault:"0"`
+	SignAddress *string
+}
+
+// NewExportPaymentBundleCmd returns a new instance which can be used to issue an exportpaymentbundle JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewExportPaymentBundleCmd(
+	account string, requests []PaymentBundleRequest, expires *int64, signAddress *string,
+) *ExportPaymentBundleCmd {
+	return &ExportPaymentBundleCmd{
+		Account:     account,
+		Requests:    requests,
+		Expires:     expires,
+		SignAddress: signAddress,
+	}
+}
+
+// ExportWatchSetCmd defines the exportwatchset JSON-RPC command.
+type ExportWatchSetCmd struct{}
+
+// NewExportWatchSetCmd returns a new instance which can be used to issue an exportwatchset JSON-RPC command.
+func NewExportWatchSetCmd() *ExportWatchSetCmd {
+	return &ExportWatchSetCmd{}
+}
+
+// FreezeUnspentCmd defines the freezeunspent JSON-RPC command.
+type FreezeUnspentCmd struct {
+	Unfreeze     bool
+	Transactions []TransactionInput
+	Reason       *string
+}
+
+// NewFreezeUnspentCmd returns a new instance which can be used to issue a freezeunspent JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewFreezeUnspentCmd(unfreeze bool, transactions []TransactionInput, reason *string) *FreezeUnspentCmd {
+	return &FreezeUnspentCmd{
+		Unfreeze:     unfreeze,
+		Transactions: transactions,
+		Reason:       reason,
+	}
+}
+
 // GetAccountCmd defines the getaccount JSON-RPC command.
 type GetAccountCmd struct {
 	Address string
@@ -125,6 +326,42 @@ func NewGetAccountAddressCmd(account string) *GetAccountAddressCmd {
 	}
 }
 
+// GetAccountXPubCmd defines the getaccountxpub JSON-RPC command.
+type GetAccountXPubCmd struct {
+	Account string
+}
+
+// NewGetAccountXPubCmd returns a new instance which can be used to issue a getaccountxpub JSON-RPC command.
+func NewGetAccountXPubCmd(account string) *GetAccountXPubCmd {
+	return &GetAccountXPubCmd{
+		Account: account,
+	}
+}
+
+// GetAddressInfoCmd defines the getaddressinfo JSON-RPC command.
+type GetAddressInfoCmd struct {
+	Address string
+}
+
+// NewGetAddressInfoCmd returns a new instance which can be used to issue a getaddressinfo JSON-RPC command.
+func NewGetAddressInfoCmd(address string) *GetAddressInfoCmd {
+	return &GetAddressInfoCmd{
+		Address: address,
+	}
+}
+
+// GetAddressMetaCmd defines the getaddressmeta JSON-RPC command.
+type GetAddressMetaCmd struct {
+	Address string
+}
+
+// NewGetAddressMetaCmd returns a new instance which can be used to issue a getaddressmeta JSON-RPC command.
+func NewGetAddressMetaCmd(address string) *GetAddressMetaCmd {
+	return &GetAddressMetaCmd{
+		Address: address,
+	}
+}
+
 // GetAddressesByAccountCmd defines the getaddressesbyaccount JSON-RPC command.
 type GetAddressesByAccountCmd struct {
 	Account string
@@ -138,10 +375,49 @@ func NewGetAddressesByAccountCmd(account string) *GetAddressesByAccountCmd {
 	}
 }
 
+// GetAuditLogCmd defines the getauditlog JSON-RPC command.
+type GetAuditLogCmd struct {
+	From      *int64 `jsonrpcdefault:"1"`
+	Count     *int   `jsonrpcdefault:"100"`
+	StartTime *int64 `jsonrpcdefault:"0"`
+	EndTime   *int64 `jsonrpcdefault:"0"`
+}
+
+// NewGetAuditLogCmd returns a new instance which can be used to issue a getauditlog JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetAuditLogCmd(from *int64, count *int, startTime, endTime *int64) *GetAuditLogCmd {
+	return &GetAuditLogCmd{
+		From:      from,
+		Count:     count,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+}
+
+// GetBalanceAtCmd defines the getbalanceat JSON-RPC command. HeightOrTime is a block height when it is below
+// txscript.LockTimeThreshold and a unix timestamp otherwise, like a transaction lock time.
+type GetBalanceAtCmd struct {
+	HeightOrTime int64
+	PerAccount   *bool `jsonrpcdefault:"false"`
+}
+
+// NewGetBalanceAtCmd returns a new instance which can be used to issue a getbalanceat JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetBalanceAtCmd(heightOrTime int64, perAccount *bool) *GetBalanceAtCmd {
+	return &GetBalanceAtCmd{
+		HeightOrTime: heightOrTime,
+		PerAccount:   perAccount,
+	}
+}
+
 // GetBalanceCmd defines the getbalance JSON-RPC command.
 type GetBalanceCmd struct {
 	Account *string
-	MinConf *int `jsonrpcdefault:"1"`
+	MinConf *int
 }
 
 // NewGetBalanceCmd returns a new instance which can be used to issue a getbalance JSON-RPC command. The parameters that
@@ -153,17 +429,76 @@ func NewGetBalanceCmd(account *string, minConf *int) *GetBalanceCmd {
 	}
 }
 
+// GeneratePaperKeyCmd defines the generatepaperkey JSON-RPC command.
+type GeneratePaperKeyCmd struct {
+	Count *int `jsonrpcdefault:"1"`
+}
+
+// NewGeneratePaperKeyCmd returns a new instance which can be used to issue a generatepaperkey JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGeneratePaperKeyCmd(count *int) *GeneratePaperKeyCmd {
+	return &GeneratePaperKeyCmd{
+		Count: count,
+	}
+}
+
 // GetNewAddressCmd defines the getnewaddress JSON-RPC command.
 type GetNewAddressCmd struct {
-	Account *string
+	Account     *string
+	AddressType *string
 }
 
 // NewGetNewAddressCmd returns a new instance which can be used to issue a getnewaddress JSON-RPC command. The
 // parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
 // value.
-func NewGetNewAddressCmd(account *string) *GetNewAddressCmd {
+func NewGetNewAddressCmd(account, addressType *string) *GetNewAddressCmd {
 	return &GetNewAddressCmd{
-		Account: account,
+		Account:     account,
+		AddressType: addressType,
+	}
+}
+
+// GetNewMultiSigAddressCmd defines the getnewmultisigaddress JSON-RPC command.
+type GetNewMultiSigAddressCmd struct {
+	Name string
+}
+
+// NewGetNewMultiSigAddressCmd returns a new instance which can be used to issue a getnewmultisigaddress JSON-RPC
+// command.
+func NewGetNewMultiSigAddressCmd(name string) *GetNewMultiSigAddressCmd {
+	return &GetNewMultiSigAddressCmd{
+		Name: name,
+	}
+}
+
+// GetNewVaultAddressCmd defines the getnewvaultaddress JSON-RPC command.
+type GetNewVaultAddressCmd struct {
+	Name string
+}
+
+// NewGetNewVaultAddressCmd returns a new instance which can be used to issue a getnewvaultaddress JSON-RPC command.
+func NewGetNewVaultAddressCmd(name string) *GetNewVaultAddressCmd {
+	return &GetNewVaultAddressCmd{
+		Name: name,
+	}
+}
+
+// GetPaymentBundleCmd defines the getpaymentbundle JSON-RPC command.
+type GetPaymentBundleCmd struct {
+	ID      string
+	MinConf *int `jsonrpcdefault:"1"`
+}
+
+// NewGetPaymentBundleCmd returns a new instance which can be used to issue a getpaymentbundle JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetPaymentBundleCmd(id string, minConf *int) *GetPaymentBundleCmd {
+	return &GetPaymentBundleCmd{
+		ID:      id,
+		MinConf: minConf,
 	}
 }
 
@@ -181,6 +516,42 @@ func NewGetRawChangeAddressCmd(account *string) *GetRawChangeAddressCmd {
 	}
 }
 
+// GetSpendAuthCmd defines the getspendauth JSON-RPC command.
+type GetSpendAuthCmd struct{}
+
+// NewGetSpendAuthCmd returns a new instance which can be used to issue a getspendauth JSON-RPC command.
+func NewGetSpendAuthCmd() *GetSpendAuthCmd {
+	return &GetSpendAuthCmd{}
+}
+
+// GetVaultScheduleCmd defines the getvaultschedule JSON-RPC command.
+type GetVaultScheduleCmd struct {
+	Name string
+}
+
+// NewGetVaultScheduleCmd returns a new instance which can be used to issue a getvaultschedule JSON-RPC command.
+func NewGetVaultScheduleCmd(name string) *GetVaultScheduleCmd {
+	return &GetVaultScheduleCmd{
+		Name: name,
+	}
+}
+
+// GetRescanInfoCmd defines the getrescaninfo JSON-RPC command.
+type GetRescanInfoCmd struct{}
+
+// NewGetRescanInfoCmd returns a new instance which can be used to issue a getrescaninfo JSON-RPC command.
+func NewGetRescanInfoCmd() *GetRescanInfoCmd {
+	return &GetRescanInfoCmd{}
+}
+
+// GetScrubInfoCmd defines the getscrubinfo JSON-RPC command.
+type GetScrubInfoCmd struct{}
+
+// NewGetScrubInfoCmd returns a new instance which can be used to issue a getscrubinfo JSON-RPC command.
+func NewGetScrubInfoCmd() *GetScrubInfoCmd {
+	return &GetScrubInfoCmd{}
+}
+
 // GetReceivedByAccountCmd defines the getreceivedbyaccount JSON-RPC command.
 type GetReceivedByAccountCmd struct {
 	Account string
@@ -229,6 +600,21 @@ func NewGetTransactionCmd(txHash string, includeWatchOnly *bool) *GetTransaction
 	}
 }
 
+// GetUTXOReportCmd defines the getutxoreport JSON-RPC command.
+type GetUTXOReportCmd struct {
+	FeeRate *float64
+}
+
+// NewGetUTXOReportCmd returns a new instance which can be used to issue a getutxoreport JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetUTXOReportCmd(feeRate *float64) *GetUTXOReportCmd {
+	return &GetUTXOReportCmd{
+		FeeRate: feeRate,
+	}
+}
+
 // GetWalletInfoCmd defines the getwalletinfo JSON-RPC command.
 type GetWalletInfoCmd struct{}
 
@@ -237,6 +623,25 @@ func NewGetWalletInfoCmd() *GetWalletInfoCmd {
 	return &GetWalletInfoCmd{}
 }
 
+// ImportCoreWalletCmd defines the importcorewallet JSON-RPC command.
+type ImportCoreWalletCmd struct {
+	Path       string
+	Passphrase *string `jsonrpcdefault:"\"\""`
+	Rescan     *bool   `jsonrpcdefault:"true"`
+}
+
+// NewImportCoreWalletCmd returns a new instance which can be used to issue an importcorewallet JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewImportCoreWalletCmd(path string, passphrase *string, rescan *bool) *ImportCoreWalletCmd {
+	return &ImportCoreWalletCmd{
+		Path:       path,
+		Passphrase: passphrase,
+		Rescan:     rescan,
+	}
+}
+
 // ImportPrivKeyCmd defines the importprivkey JSON-RPC command.
 type ImportPrivKeyCmd struct {
 	PrivKey string
@@ -255,6 +660,43 @@ func NewImportPrivKeyCmd(privKey string, label *string, rescan *bool) *ImportPri
 	}
 }
 
+// ImportScriptPubKeyCmd defines the importscriptpubkey JSON-RPC command.
+type ImportScriptPubKeyCmd struct {
+	Script string
+	Label  *string `jsonrpcdefault:"\"\""`
+	Rescan *bool   `jsonrpcdefault:"true"`
+}
+
+// NewImportScriptPubKeyCmd returns a new instance which can be used to issue a importscriptpubkey JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewImportScriptPubKeyCmd(script string, label *string, rescan *bool) *ImportScriptPubKeyCmd {
+	return &ImportScriptPubKeyCmd{
+		Script: script,
+		Label:  label,
+		Rescan: rescan,
+	}
+}
+
+// ImportTimeLockScriptCmd defines the importtimelockscript JSON-RPC command.
+type ImportTimeLockScriptCmd struct {
+	RedeemScript string
+	Rescan       *bool `jsonrpcdefault:"true"`
+}
+
+// NewImportTimeLockScriptCmd returns a new instance which can be used to issue a importtimelockscript JSON-RPC
+// command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewImportTimeLockScriptCmd(redeemScript string, rescan *bool) *ImportTimeLockScriptCmd {
+	return &ImportTimeLockScriptCmd{
+		RedeemScript: redeemScript,
+		Rescan:       rescan,
+	}
+}
+
 // KeyPoolRefillCmd defines the keypoolrefill JSON-RPC command.
 type KeyPoolRefillCmd struct {
 	NewSize *uint `jsonrpcdefault:"100"`
@@ -271,14 +713,16 @@ func NewKeyPoolRefillCmd(newSize *uint) *KeyPoolRefillCmd {
 
 // ListAccountsCmd defines the listaccounts JSON-RPC command.
 type ListAccountsCmd struct {
-	MinConf *int `jsonrpcdefault:"1"`
+	MinConf         *int  `jsonrpcdefault:"1"`
+	IncludeArchived *bool `jsonrpcdefault:"false"`
 }
 
 // NewListAccountsCmd returns a new instance which can be used to issue a listaccounts JSON-RPC command. The parameters
 // which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
-func NewListAccountsCmd(minConf *int) *ListAccountsCmd {
+func NewListAccountsCmd(minConf *int, includeArchived *bool) *ListAccountsCmd {
 	return &ListAccountsCmd{
-		MinConf: minConf,
+		MinConf:         minConf,
+		IncludeArchived: includeArchived,
 	}
 }
 
@@ -291,6 +735,83 @@ func NewListAddressGroupingsCmd() *ListAddressGroupingsCmd {
 	return &ListAddressGroupingsCmd{}
 }
 
+// ListAddressMetaCmd defines the listaddressmeta JSON-RPC command.
+type ListAddressMetaCmd struct {
+	Category *string
+}
+
+// NewListAddressMetaCmd returns a new instance which can be used to issue a listaddressmeta JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListAddressMetaCmd(category *string) *ListAddressMetaCmd {
+	return &ListAddressMetaCmd{
+		Category: category,
+	}
+}
+
+// ListImmatureCmd defines the listimmature JSON-RPC command.
+type ListImmatureCmd struct {
+	Account *string
+}
+
+// NewListImmatureCmd returns a new instance which can be used to issue a listimmature JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewListImmatureCmd(account *string) *ListImmatureCmd {
+	return &ListImmatureCmd{
+		Account: account,
+	}
+}
+
+// ListDustOutputsCmd defines the listdustoutputs JSON-RPC command.
+type ListDustOutputsCmd struct{}
+
+// NewListDustOutputsCmd returns a new instance which can be used to issue a listdustoutputs JSON-RPC command.
+func NewListDustOutputsCmd() *ListDustOutputsCmd {
+	return &ListDustOutputsCmd{}
+}
+
+// ListFrozenCmd defines the listfrozen JSON-RPC command.
+type ListFrozenCmd struct{}
+
+// NewListFrozenCmd returns a new instance which can be used to issue a listfrozen JSON-RPC command.
+func NewListFrozenCmd() *ListFrozenCmd {
+	return &ListFrozenCmd{}
+}
+
+// ListInvoicesCmd defines the listinvoices JSON-RPC command.
+type ListInvoicesCmd struct {
+	State   *string
+	MinConf *int `jsonrpcdefault:"1"`
+}
+
+// NewListInvoicesCmd returns a new instance which can be used to issue a listinvoices JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListInvoicesCmd(state *string, minConf *int) *ListInvoicesCmd {
+	return &ListInvoicesCmd{
+		State:   state,
+		MinConf: minConf,
+	}
+}
+
+// ListInvoiceReservationsCmd defines the listinvoicereservations JSON-RPC command.
+type ListInvoiceReservationsCmd struct {
+	Account *string `jsonrpcdefault:"\"default\""`
+}
+
+// NewListInvoiceReservationsCmd returns a new instance which can be used to issue a listinvoicereservations JSON-RPC
+// command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListInvoiceReservationsCmd(account *string) *ListInvoiceReservationsCmd {
+	return &ListInvoiceReservationsCmd{
+		Account: account,
+	}
+}
+
 // ListLockUnspentCmd defines the listlockunspent JSON-RPC command.
 type ListLockUnspentCmd struct{}
 
@@ -299,6 +820,78 @@ func NewListLockUnspentCmd() *ListLockUnspentCmd {
 	return &ListLockUnspentCmd{}
 }
 
+// ListMultiSigAccountsCmd defines the listmultisigaccounts JSON-RPC command.
+type ListMultiSigAccountsCmd struct{}
+
+// NewListMultiSigAccountsCmd returns a new instance which can be used to issue a listmultisigaccounts JSON-RPC command.
+func NewListMultiSigAccountsCmd() *ListMultiSigAccountsCmd {
+	return &ListMultiSigAccountsCmd{}
+}
+
+// ListPaymentBundlesCmd defines the listpaymentbundles JSON-RPC command.
+type ListPaymentBundlesCmd struct {
+	MinConf *int `jsonrpcdefault:"1"`
+}
+
+// NewListPaymentBundlesCmd returns a new instance which can be used to issue a listpaymentbundles JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListPaymentBundlesCmd(minConf *int) *ListPaymentBundlesCmd {
+	return &ListPaymentBundlesCmd{
+		MinConf: minConf,
+	}
+}
+
+// ListPortfolioCmd defines the listportfolio JSON-RPC command.
+type ListPortfolioCmd struct {
+	MinConf *int `jsonrpcdefault:"1"`
+}
+
+// NewListPortfolioCmd returns a new instance which can be used to issue a listportfolio JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListPortfolioCmd(minConf *int) *ListPortfolioCmd {
+	return &ListPortfolioCmd{
+		MinConf: minConf,
+	}
+}
+
+// ListPortfolioTransactionsCmd defines the listportfoliotransactions JSON-RPC command.
+type ListPortfolioTransactionsCmd struct {
+	Name  *string `jsonrpcdefault:"\"\""`
+	Count *int    `jsonrpcdefault:"100"`
+}
+
+// NewListPortfolioTransactionsCmd returns a new instance which can be used to issue a listportfoliotransactions
+// JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewListPortfolioTransactionsCmd(name *string, count *int) *ListPortfolioTransactionsCmd {
+	return &ListPortfolioTransactionsCmd{
+		Name:  name,
+		Count: count,
+	}
+}
+
+// ListQueuedPSBTsCmd defines the listqueuedpsbts JSON-RPC command.
+type ListQueuedPSBTsCmd struct{}
+
+// NewListQueuedPSBTsCmd returns a new instance which can be used to issue a listqueuedpsbts JSON-RPC command.
+func NewListQueuedPSBTsCmd() *ListQueuedPSBTsCmd {
+	return &ListQueuedPSBTsCmd{}
+}
+
+// ListUnlockAttemptsCmd defines the listunlockattempts JSON-RPC command.
+type ListUnlockAttemptsCmd struct{}
+
+// NewListUnlockAttemptsCmd returns a new instance which can be used to issue a listunlockattempts JSON-RPC command.
+func NewListUnlockAttemptsCmd() *ListUnlockAttemptsCmd {
+	return &ListUnlockAttemptsCmd{}
+}
+
 // ListReceivedByAccountCmd defines the listreceivedbyaccount JSON-RPC command.
 type ListReceivedByAccountCmd struct {
 	MinConf          *int  `jsonrpcdefault:"1"`
@@ -373,6 +966,34 @@ func NewListTransactionsCmd(account *string, count, from *int, includeWatchOnly
 	}
 }
 
+// ListTransactionsFilter restricts the entries returned by the listtransactionspage JSON-RPC command. Fields that are
+// not set do not restrict the entries.
+type ListTransactionsFilter struct {
+	Categories []string `json:"categories,omitempty"`
+	Label      *string  `json:"label,omitempty"`
+	StartTime  *int64   `json:"starttime,omitempty"`
+	EndTime    *int64   `json:"endtime,omitempty"`
+	MinAmount  *float64 `json:"minamount,omitempty"`
+}
+
+// ListTransactionsPageCmd defines the listtransactionspage JSON-RPC command.
+type ListTransactionsPageCmd struct {
+	Cursor *string
+	Count  *int `jsonrpcdefault:"10"`
+	Filter *ListTransactionsFilter
+}
+
+// NewListTransactionsPageCmd returns a new instance which can be used to issue a listtransactionspage JSON-RPC
+// command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use
+// the default value.
+func NewListTransactionsPageCmd(cursor *string, count *int, filter *ListTransactionsFilter) *ListTransactionsPageCmd {
+	return &ListTransactionsPageCmd{
+		Cursor: cursor,
+		Count:  count,
+		Filter: filter,
+	}
+}
+
 // ListUnspentCmd defines the listunspent JSON-RPC command.
 type ListUnspentCmd struct {
 	MinConf   *int `jsonrpcdefault:"1"`
@@ -390,6 +1011,14 @@ func NewListUnspentCmd(minConf, maxConf *int, addresses *[]string) *ListUnspentC
 	}
 }
 
+// ListVaultAccountsCmd defines the listvaultaccounts JSON-RPC command.
+type ListVaultAccountsCmd struct{}
+
+// NewListVaultAccountsCmd returns a new instance which can be used to issue a listvaultaccounts JSON-RPC command.
+func NewListVaultAccountsCmd() *ListVaultAccountsCmd {
+	return &ListVaultAccountsCmd{}
+}
+
 // LockUnspentCmd defines the lockunspent JSON-RPC command.
 type LockUnspentCmd struct {
 	Unlock       bool
@@ -425,19 +1054,101 @@ func NewMoveCmd(fromAccount, toAccount string, amount float64, minConf *int, com
 	}
 }
 
+// OverrideDustCmd defines the overridedust JSON-RPC command.
+type OverrideDustCmd struct {
+	Release      bool
+	Transactions []TransactionInput
+}
+
+// NewOverrideDustCmd returns a new instance which can be used to issue an overridedust JSON-RPC command.
+func NewOverrideDustCmd(release bool, transactions []TransactionInput) *OverrideDustCmd {
+	return &OverrideDustCmd{
+		Release:      release,
+		Transactions: transactions,
+	}
+}
+
+// PreviewSendCmd defines the previewsend JSON-RPC command.
+type PreviewSendCmd struct {
+	FromAccount string
+	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	MinConf     *int
+	Scripts     *map[string]float64 `jsonrpcusage:"{\"hexscript\":amount,...}"` // In DUO
+}
+
+// NewPreviewSendCmd returns a new instance which can be used to issue a previewsend JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewPreviewSendCmd(
+	fromAccount string, amounts map[string]float64, minConf *int,
+	scripts *map[string]float64,
+) *PreviewSendCmd {
+	return &PreviewSendCmd{
+		FromAccount: fromAccount,
+		Amounts:     amounts,
+		MinConf:     minConf,
+		Scripts:     scripts,
+	}
+}
+
+// ReleaseInvoiceAddressCmd defines the releaseinvoiceaddress JSON-RPC command.
+type ReleaseInvoiceAddressCmd struct {
+	Address string
+}
+
+// NewReleaseInvoiceAddressCmd returns a new instance which can be used to issue a releaseinvoiceaddress JSON-RPC
+// command.
+func NewReleaseInvoiceAddressCmd(address string) *ReleaseInvoiceAddressCmd {
+	return &ReleaseInvoiceAddressCmd{
+		Address: address,
+	}
+}
+
+// RemovePortfolioEntryCmd defines the removeportfolioentry JSON-RPC command.
+type RemovePortfolioEntryCmd struct {
+	Name string
+}
+
+// NewRemovePortfolioEntryCmd returns a new instance which can be used to issue a removeportfolioentry JSON-RPC command.
+func NewRemovePortfolioEntryCmd(name string) *RemovePortfolioEntryCmd {
+	return &RemovePortfolioEntryCmd{
+		Name: name,
+	}
+}
+
+// ReserveInvoiceAddressCmd defines the reserveinvoiceaddress JSON-RPC command.
+type ReserveInvoiceAddressCmd struct {
+	Account   string
+	Reference *string `jsonrpcdefault:"\"\""`
+}
+
+// NewReserveInvoiceAddressCmd returns a new instance which can be used to issue a reserveinvoiceaddress JSON-RPC
+// command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewReserveInvoiceAddressCmd(account string, reference *string) *ReserveInvoiceAddressCmd {
+	return &ReserveInvoiceAddressCmd{
+		Account:   account,
+		Reference: reference,
+	}
+}
+
 // SendFromCmd defines the sendfrom JSON-RPC command.
 type SendFromCmd struct {
 	FromAccount string
 	ToAddress   string
 	Amount      float64 // In DUO
-	MinConf     *int    `jsonrpcdefault:"1"`
+	MinConf     *int
 	Comment     *string
 	CommentTo   *string
+	AuthCode    *string
 }
 
 // NewSendFromCmd returns a new instance which can be used to issue a sendfrom JSON-RPC command. The parameters which
 // are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
-func NewSendFromCmd(fromAccount, toAddress string, amount float64, minConf *int, comment, commentTo *string,
+func NewSendFromCmd(
+	fromAccount, toAddress string, amount float64, minConf *int, comment, commentTo *string,
+	authCode *string,
 ) *SendFromCmd {
 	return &SendFromCmd{
 		FromAccount: fromAccount,
@@ -446,25 +1157,46 @@ func NewSendFromCmd(fromAccount, toAddress string, amount float64, minConf *int,
 		MinConf:     minConf,
 		Comment:     comment,
 		CommentTo:   commentTo,
+		AuthCode:    authCode,
 	}
 }
 
+// SendFeeOptions is the fee of a send given in place of the fee rate of the wallet, either as a fee rate or as the
+// absolute fee of the transaction.
+type SendFeeOptions struct {
+	FeeRate *float64 `json:"feerate,omitempty"` // In DUO/kB
+	Fee     *float64 `json:"fee,omitempty"`     // In DUO
+}
+
 // SendManyCmd defines the sendmany JSON-RPC command.
 type SendManyCmd struct {
 	FromAccount string
 	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
-	MinConf     *int               `jsonrpcdefault:"1"`
+	MinConf     *int
 	Comment     *string
+	Scripts     *map[string]float64 `jsonrpcusage:"{\"hexscript\":amount,...}"` // In DUO
+	AuthCode    *string
+	FeeOptions  *SendFeeOptions
+	// IdempotencyKey is a key chosen by the client, the retries of a send with the same key return the result of the
+	// first instead of sending again.
+	IdempotencyKey *string
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which
 // are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
-func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string) *SendManyCmd {
+func NewSendManyCmd(
+	fromAccount string, amounts map[string]float64, minConf *int, comment *string,
+	scripts *map[string]float64, authCode *string, feeOptions *SendFeeOptions, idempotencyKey *string,
+) *SendManyCmd {
 	return &SendManyCmd{
-		FromAccount: fromAccount,
-		Amounts:     amounts,
-		MinConf:     minConf,
-		Comment:     comment,
+		FromAccount:    fromAccount,
+		Amounts:        amounts,
+		MinConf:        minConf,
+		Comment:        comment,
+		Scripts:        scripts,
+		AuthCode:       authCode,
+		FeeOptions:     feeOptions,
+		IdempotencyKey: idempotencyKey,
 	}
 }
 
@@ -474,17 +1206,28 @@ type SendToAddressCmd struct {
 	Amount    float64
 	Comment   *string
 	CommentTo *string
+	AuthCode   *string
+	FeeOptions *SendFeeOptions
+	// IdempotencyKey is a key chosen by the client, the retries of a send with the same key return the result of the
+	// first instead of sending again.
+	IdempotencyKey *string
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a sendtoaddress JSON-RPC command. The
 // parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
 // value.
-func NewSendToAddressCmd(address string, amount float64, comment, commentTo *string) *SendToAddressCmd {
+func NewSendToAddressCmd(
+	address string, amount float64, comment, commentTo, authCode *string, feeOptions *SendFeeOptions,
+	idempotencyKey *string,
+) *SendToAddressCmd {
 	return &SendToAddressCmd{
-		Address:   address,
-		Amount:    amount,
-		Comment:   comment,
-		CommentTo: commentTo,
+		Address:        address,
+		Amount:         amount,
+		Comment:        comment,
+		CommentTo:      commentTo,
+		AuthCode:       authCode,
+		FeeOptions:     feeOptions,
+		IdempotencyKey: idempotencyKey,
 	}
 }
 
@@ -502,6 +1245,65 @@ func NewSetAccountCmd(address, account string) *SetAccountCmd {
 	}
 }
 
+// AddressMetaFields are the fields of the metadata of an address changed by the setaddressmeta JSON-RPC command.
+// Fields that are not set are left as they are.
+type AddressMetaFields struct {
+	Amount  *float64 `json:"amount,omitempty"`
+	Message *string  `json:"message,omitempty"`
+	Label   *string  `json:"label,omitempty"`
+	State   *string  `json:"state,omitempty"`
+	TxID    *string  `json:"txid,omitempty"`
+	// Expires is the time a receive entry expires in seconds since 1 Jan 1970 GMT, or zero to remove the expiry.
+	Expires *int64 `json:"expires,omitempty"`
+}
+
+// SetAddressMetaCmd defines the setaddressmeta JSON-RPC command.
+type SetAddressMetaCmd struct {
+	Address string
+	Meta    AddressMetaFields
+}
+
+// NewSetAddressMetaCmd returns a new instance which can be used to issue a setaddressmeta JSON-RPC command.
+func NewSetAddressMetaCmd(address string, meta AddressMetaFields) *SetAddressMetaCmd {
+	return &SetAddressMetaCmd{
+		Address: address,
+		Meta:    meta,
+	}
+}
+
+// SetInvoiceIssuanceCmd defines the setinvoiceissuance JSON-RPC command.
+type SetInvoiceIssuanceCmd struct {
+	Account string
+	Enable  bool
+}
+
+// NewSetInvoiceIssuanceCmd returns a new instance which can be used to issue a setinvoiceissuance JSON-RPC command.
+func NewSetInvoiceIssuanceCmd(account string, enable bool) *SetInvoiceIssuanceCmd {
+	return &SetInvoiceIssuanceCmd{
+		Account: account,
+		Enable:  enable,
+	}
+}
+
+// SetSpendAuthCmd defines the setspendauth JSON-RPC command.
+type SetSpendAuthCmd struct {
+	Method string
+	Limit  *float64 `jsonrpcdefault:"0"` // In DUO
+	Secret *string
+	Code   *string
+}
+
+// NewSetSpendAuthCmd returns a new instance which can be used to issue a setspendauth JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewSetSpendAuthCmd(method string, limit *float64, secret, code *string) *SetSpendAuthCmd {
+	return &SetSpendAuthCmd{
+		Method: method,
+		Limit:  limit,
+		Secret: secret,
+		Code:   code,
+	}
+}
+
 // SetTxFeeCmd defines the settxfee JSON-RPC command.
 type SetTxFeeCmd struct {
 	Amount float64 // In DUO
@@ -514,6 +1316,71 @@ func NewSetTxFeeCmd(amount float64) *SetTxFeeCmd {
 	}
 }
 
+// SweepAccountCmd defines the sweepaccount JSON-RPC command.
+type SweepAccountCmd struct {
+	Account string
+	Address string
+	MinConf *int     `jsonrpcdefault:"1"`
+	Reserve  *float64 `jsonrpcdefault:"0"`
+	DryRun   *bool    `jsonrpcdefault:"false"`
+	AuthCode *string
+}
+
+// NewSweepAccountCmd returns a new instance which can be used to issue a sweepaccount JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewSweepAccountCmd(
+	account, address string, minConf *int, reserve *float64, dryRun *bool, authCode *string,
+) *SweepAccountCmd {
+	return &SweepAccountCmd{
+		Account:  account,
+		Address:  address,
+		MinConf:  minConf,
+		Reserve:  reserve,
+		DryRun:   dryRun,
+		AuthCode: authCode,
+	}
+}
+
+// SweepPrivKeyCmd defines the sweepprivkey JSON-RPC command.
+type SweepPrivKeyCmd struct {
+	PrivKey string
+	Account *string `jsonrpcdefault:"\"default\""`
+	DryRun  *bool   `jsonrpcdefault:"false"`
+}
+
+// NewSweepPrivKeyCmd returns a new instance which can be used to issue a sweepprivkey JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewSweepPrivKeyCmd(privKey string, account *string, dryRun *bool) *SweepPrivKeyCmd {
+	return &SweepPrivKeyCmd{
+		PrivKey: privKey,
+		Account: account,
+		DryRun:  dryRun,
+	}
+}
+
+// SweepTimeLockedCmd defines the sweeptimelocked JSON-RPC command.
+type SweepTimeLockedCmd struct {
+	Address  string
+	DryRun   *bool `jsonrpcdefault:"false"`
+	AuthCode *string
+}
+
+// NewSweepTimeLockedCmd returns a new instance which can be used to issue a sweeptimelocked JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewSweepTimeLockedCmd(address string, dryRun *bool, authCode *string) *SweepTimeLockedCmd {
+	return &SweepTimeLockedCmd{
+		Address:  address,
+		DryRun:   dryRun,
+		AuthCode: authCode,
+	}
+}
+
 // SignMessageCmd defines the signmessage JSON-RPC command.
 type SignMessageCmd struct {
 	Address string
@@ -542,18 +1409,116 @@ type SignRawTransactionCmd struct {
 	Inputs   *[]RawTxInput
 	PrivKeys *[]string
 	Flags    *string `jsonrpcdefault:"\"ALL\""`
+	AuthCode *string
 }
 
 // NewSignRawTransactionCmd returns a new instance which can be used to issue a signrawtransaction JSON-RPC command. The
 // parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
 // value.
-func NewSignRawTransactionCmd(hexEncodedTx string, inputs *[]RawTxInput, privKeys *[]string, flags *string,
+func NewSignRawTransactionCmd(
+	hexEncodedTx string, inputs *[]RawTxInput, privKeys *[]string, flags *string, authCode *string,
 ) *SignRawTransactionCmd {
 	return &SignRawTransactionCmd{
 		RawTx:    hexEncodedTx,
 		Inputs:   inputs,
 		PrivKeys: privKeys,
 		Flags:    flags,
+		AuthCode: authCode,
+	}
+}
+
+// SubmitSignedPSBTCmd defines the submitsignedpsbt JSON-RPC command.
+type SubmitSignedPSBTCmd struct {
+	PSBT string
+}
+
+// NewSubmitSignedPSBTCmd returns a new instance which can be used to issue a submitsignedpsbt JSON-RPC command.
+func NewSubmitSignedPSBTCmd(psbt string) *SubmitSignedPSBTCmd {
+	return &SubmitSignedPSBTCmd{
+		PSBT: psbt,
+	}
+}
+
+// TransferAccountCmd defines the transferaccount JSON-RPC command.
+type TransferAccountCmd struct {
+	FromAccount string
+	ToAccount   string
+	Amount      float64 // In DUO
+	FeeAccount  *string `jsonrpcdefault:"\"\""`
+	MinConf     *int    `jsonrpcdefault:"1"`
+	DryRun      *bool   `jsonrpcdefault:"false"`
+	AuthCode    *string
+}
+
+// NewTransferAccountCmd returns a new instance which can be used to issue a transferaccount JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewTransferAccountCmd(
+	fromAccount, toAccount string, amount float64, feeAccount *string, minConf *int, dryRun *bool, authCode *string,
+) *TransferAccountCmd {
+	return &TransferAccountCmd{
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      amount,
+		FeeAccount:  feeAccount,
+		MinConf:     minConf,
+		DryRun:      dryRun,
+		AuthCode:    authCode,
+	}
+}
+
+// UnarchiveAccountCmd defines the unarchiveaccount JSON-RPC command.
+type UnarchiveAccountCmd struct {
+	Account string
+}
+
+// NewUnarchiveAccountCmd returns a new instance which can be used to issue an unarchiveaccount JSON-RPC command.
+func NewUnarchiveAccountCmd(account string) *UnarchiveAccountCmd {
+	return &UnarchiveAccountCmd{
+		Account: account,
+	}
+}
+
+// WalletDBStatsCmd defines the walletdbstats JSON-RPC command.
+type WalletDBStatsCmd struct {
+	Largest *int `jsonrpcdefault:"5"`
+}
+
+// NewWalletDBStatsCmd returns a new instance which can be used to issue a walletdbstats JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewWalletDBStatsCmd(largest *int) *WalletDBStatsCmd {
+	return &WalletDBStatsCmd{
+		Largest: largest,
+	}
+}
+
+// WalletSelfTestCmd defines the walletselftest JSON-RPC command.
+type WalletSelfTestCmd struct{}
+
+// NewWalletSelfTestCmd returns a new instance which can be used to issue a walletselftest JSON-RPC command.
+func NewWalletSelfTestCmd() *WalletSelfTestCmd {
+	return &WalletSelfTestCmd{}
+}
+
+// WithdrawVaultCmd defines the withdrawvault JSON-RPC command.
+type WithdrawVaultCmd struct {
+	Name     string
+	Address  string
+	AuthCode *string
+}
+
+// NewWithdrawVaultCmd returns a new instance which can be used to issue a withdrawvault JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewWithdrawVaultCmd(name, address string, authCode *string) *WithdrawVaultCmd {
+	return &WithdrawVaultCmd{
+		Name:     name,
+		Address:  address,
+		AuthCode: authCode,
 	}
 }
 
@@ -593,6 +1558,245 @@ func NewWalletPassphraseChangeCmd(oldPassphrase, newPassphrase string) *WalletPa
 		NewPassphrase: newPassphrase,
 	}
 }
+
+// walletSvrCmdSet declares the wallet server commands that are registered through RegisterCmds along with their result
+// types.
+type walletSvrCmdSet struct {
+	AddPortfolioEntry struct {
+		Cmd *AddPortfolioEntryCmd
+	} `jsonrpcmethod:"addportfolioentry" jsonrpcflags:"walletonly"`
+	ArchiveAccount struct {
+		Cmd *ArchiveAccountCmd
+	} `jsonrpcmethod:"archiveaccount" jsonrpcflags:"walletonly"`
+	BackupRemote struct {
+		Cmd    *BackupRemoteCmd
+		Result *[]BackupTargetResult
+	} `jsonrpcmethod:"backupremote" jsonrpcflags:"walletonly"`
+	CancelQueuedPSBT struct {
+		Cmd *CancelQueuedPSBTCmd
+	} `jsonrpcmethod:"cancelqueuedpsbt" jsonrpcflags:"walletonly"`
+	CreateMultiSigAccount struct {
+		Cmd    *CreateMultiSigAccountCmd
+		Result *MultiSigAccountResult
+	} `jsonrpcmethod:"createmultisigaccount" jsonrpcflags:"walletonly"`
+	CreateVaultAccount struct {
+		Cmd    *CreateVaultAccountCmd
+		Result *VaultAccountResult
+	} `jsonrpcmethod:"createvaultaccount" jsonrpcflags:"walletonly"`
+	DeleteAddressMeta struct {
+		Cmd *DeleteAddressMetaCmd
+	} `jsonrpcmethod:"deleteaddressmeta" jsonrpcflags:"walletonly"`
+	ExportAccountXprv struct {
+		Cmd    *ExportAccountXprvCmd
+		Result *ExportAccountXprvResult
+	} `jsonrpcmethod:"exportaccountxprv" jsonrpcflags:"walletonly"`
+	ExportLedger struct {
+		Cmd    *ExportLedgerCmd
+		Result *string
+	} `jsonrpcmethod:"exportledger" jsonrpcflags:"walletonly"`
+	ExportPaymentBundle struct {
+		Cmd    *ExportPaymentBundleCmd
+		Result *ExportPaymentBundleResult
+	} `jsonrpcmethod:"exportpaymentbundle" jsonrpcflags:"walletonly"`
+	ExportWatchSet struct {
+		Cmd    *ExportWatchSetCmd
+		Result *WatchSetResult
+	} `jsonrpcmethod:"exportwatchset" jsonrpcflags:"walletonly"`
+	FreezeUnspent struct {
+		Cmd    *FreezeUnspentCmd
+		Result *bool
+	} `jsonrpcmethod:"freezeunspent" jsonrpcflags:"walletonly"`
+	GeneratePaperKey struct {
+		Cmd    *GeneratePaperKeyCmd
+		Result *[]PaperKeyResult
+	} `jsonrpcmethod:"generatepaperkey" jsonrpcflags:"walletonly"`
+	GetAccountXPub struct {
+		Cmd    *GetAccountXPubCmd
+		Result *string
+	} `jsonrpcmethod:"getaccountxpub" jsonrpcflags:"walletonly"`
+	GetAddressInfo struct {
+		Cmd    *GetAddressInfoCmd
+		Result *GetAddressInfoResult
+	} `jsonrpcmethod:"getaddressinfo" jsonrpcflags:"walletonly"`
+	GetAddressMeta struct {
+		Cmd    *GetAddressMetaCmd
+		Result *AddressMetaResult
+	} `jsonrpcmethod:"getaddressmeta" jsonrpcflags:"walletonly"`
+	GetAuditLog struct {
+		Cmd    *GetAuditLogCmd
+		Result *GetAuditLogResult
+	} `jsonrpcmethod:"getauditlog" jsonrpcflags:"walletonly"`
+	GetBalanceAt struct {
+		Cmd    *GetBalanceAtCmd
+		Result *GetBalanceAtResult
+	} `jsonrpcmethod:"getbalanceat" jsonrpcflags:"walletonly"`
+	GetNewMultiSigAddress struct {
+		Cmd    *GetNewMultiSigAddressCmd
+		Result *MultiSigAddressResult
+	} `jsonrpcmethod:"getnewmultisigaddress" jsonrpcflags:"walletonly"`
+	GetNewVaultAddress struct {
+		Cmd    *GetNewVaultAddressCmd
+		Result *VaultAddressResult
+	} `jsonrpcmethod:"getnewvaultaddress" jsonrpcflags:"walletonly"`
+	GetPaymentBundle struct {
+		Cmd    *GetPaymentBundleCmd
+		Result *PaymentBundleResult
+	} `jsonrpcmethod:"getpaymentbundle" jsonrpcflags:"walletonly"`
+	GetRescanInfo struct {
+		Cmd    *GetRescanInfoCmd
+		Result *GetRescanInfoResult
+	} `jsonrpcmethod:"getrescaninfo" jsonrpcflags:"walletonly"`
+	GetScrubInfo struct {
+		Cmd    *GetScrubInfoCmd
+		Result *GetScrubInfoResult
+	} `jsonrpcmethod:"getscrubinfo" jsonrpcflags:"walletonly"`
+	GetSpendAuth struct {
+		Cmd    *GetSpendAuthCmd
+		Result *SpendAuthResult
+	} `jsonrpcmethod:"getspendauth" jsonrpcflags:"walletonly"`
+	GetUTXOReport struct {
+		Cmd    *GetUTXOReportCmd
+		Result *GetUTXOReportResult
+	} `jsonrpcmethod:"getutxoreport" jsonrpcflags:"walletonly"`
+	GetVaultSchedule struct {
+		Cmd    *GetVaultScheduleCmd
+		Result *VaultScheduleResult
+	} `jsonrpcmethod:"getvaultschedule" jsonrpcflags:"walletonly"`
+	ImportCoreWallet struct {
+		Cmd    *ImportCoreWalletCmd
+		Result *ImportCoreWalletResult
+	} `jsonrpcmethod:"importcorewallet" jsonrpcflags:"walletonly"`
+	ImportScriptPubKey struct {
+		Cmd *ImportScriptPubKeyCmd
+	} `jsonrpcmethod:"importscriptpubkey" jsonrpcflags:"walletonly"`
+	ImportTimeLockScript struct {
+		Cmd    *ImportTimeLockScriptCmd
+		Result *TimeLockScriptResult
+	} `jsonrpcmethod:"importtimelockscript" jsonrpcflags:"walletonly"`
+	ListAddressMeta struct {
+		Cmd    *ListAddressMetaCmd
+		Result *[]AddressMetaResult
+	} `jsonrpcmethod:"listaddressmeta" jsonrpcflags:"walletonly"`
+	ListImmature struct {
+		Cmd    *ListImmatureCmd
+		Result *ListImmatureResult
+	} `jsonrpcmethod:"listimmature" jsonrpcflags:"walletonly"`
+	ListDustOutputs struct {
+		Cmd    *ListDustOutputsCmd
+		Result *[]DustOutputResult
+	} `jsonrpcmethod:"listdustoutputs" jsonrpcflags:"walletonly"`
+	ListFrozen struct {
+		Cmd    *ListFrozenCmd
+		Result *[]FrozenOutputResult
+	} `jsonrpcmethod:"listfrozen" jsonrpcflags:"walletonly"`
+	ListInvoices struct {
+		Cmd    *ListInvoicesCmd
+		Result *[]InvoiceResult
+	} `jsonrpcmethod:"listinvoices" jsonrpcflags:"walletonly"`
+	ListInvoiceReservations struct {
+		Cmd    *ListInvoiceReservationsCmd
+		Result *[]InvoiceReservationResult
+	} `jsonrpcmethod:"listinvoicereservations" jsonrpcflags:"walletonly"`
+	ListMultiSigAccounts struct {
+		Cmd    *ListMultiSigAccountsCmd
+		Result *[]MultiSigAccountResult
+	} `jsonrpcmethod:"listmultisigaccounts" jsonrpcflags:"walletonly"`
+	ListPaymentBundles struct {
+		Cmd    *ListPaymentBundlesCmd
+		Result *[]PaymentBundleResult
+	} `jsonrpcmethod:"listpaymentbundles" jsonrpcflags:"walletonly"`
+	ListPortfolio struct {
+		Cmd    *ListPortfolioCmd
+		Result *ListPortfolioResult
+	} `jsonrpcmethod:"listportfolio" jsonrpcflags:"walletonly"`
+	ListPortfolioTransactions struct {
+		Cmd    *ListPortfolioTransactionsCmd
+		Result *[]PortfolioTransactionResult
+	} `jsonrpcmethod:"listportfoliotransactions" jsonrpcflags:"walletonly"`
+	ListQueuedPSBTs struct {
+		Cmd    *ListQueuedPSBTsCmd
+		Result *[]QueuedPSBTResult
+	} `jsonrpcmethod:"listqueuedpsbts" jsonrpcflags:"walletonly"`
+	ListTransactionsPage struct {
+		Cmd    *ListTransactionsPageCmd
+		Result *ListTransactionsPageResult
+	} `jsonrpcmethod:"listtransactionspage" jsonrpcflags:"walletonly"`
+	ListUnlockAttempts struct {
+		Cmd    *ListUnlockAttemptsCmd
+		Result *[]UnlockAttemptResult
+	} `jsonrpcmethod:"listunlockattempts" jsonrpcflags:"walletonly"`
+	ListVaultAccounts struct {
+		Cmd    *ListVaultAccountsCmd
+		Result *[]VaultAccountResult
+	} `jsonrpcmethod:"listvaultaccounts" jsonrpcflags:"walletonly"`
+	OverrideDust struct {
+		Cmd    *OverrideDustCmd
+		Result *bool
+	} `jsonrpcmethod:"overridedust" jsonrpcflags:"walletonly"`
+	PreviewSend struct {
+		Cmd    *PreviewSendCmd
+		Result *PreviewSendResult
+	} `jsonrpcmethod:"previewsend" jsonrpcflags:"walletonly"`
+	ReleaseInvoiceAddress struct {
+		Cmd    *ReleaseInvoiceAddressCmd
+		Result *bool
+	} `jsonrpcmethod:"releaseinvoiceaddress" jsonrpcflags:"walletonly"`
+	RemovePortfolioEntry struct {
+		Cmd *RemovePortfolioEntryCmd
+	} `jsonrpcmethod:"removeportfolioentry" jsonrpcflags:"walletonly"`
+	ReserveInvoiceAddress struct {
+		Cmd    *ReserveInvoiceAddressCmd
+		Result *InvoiceReservationResult
+	} `jsonrpcmethod:"reserveinvoiceaddress" jsonrpcflags:"walletonly"`
+	SetAddressMeta struct {
+		Cmd    *SetAddressMetaCmd
+		Result *AddressMetaResult
+	} `jsonrpcmethod:"setaddressmeta" jsonrpcflags:"walletonly"`
+	SetInvoiceIssuance struct {
+		Cmd    *SetInvoiceIssuanceCmd
+		Result *bool
+	} `jsonrpcmethod:"setinvoiceissuance" jsonrpcflags:"walletonly"`
+	SetSpendAuth struct {
+		Cmd    *SetSpendAuthCmd
+		Result *SpendAuthResult
+	} `jsonrpcmethod:"setspendauth" jsonrpcflags:"walletonly"`
+	SweepAccount struct {
+		Cmd    *SweepAccountCmd
+		Result *SweepAccountResult
+	} `jsonrpcmethod:"sweepaccount" jsonrpcflags:"walletonly"`
+	SweepPrivKey struct {
+		Cmd    *SweepPrivKeyCmd
+		Result *SweepPrivKeyResult
+	} `jsonrpcmethod:"sweepprivkey" jsonrpcflags:"walletonly"`
+	SweepTimeLocked struct {
+		Cmd    *SweepTimeLockedCmd
+		Result *string
+	} `jsonrpcmethod:"sweeptimelocked" jsonrpcflags:"walletonly"`
+	SubmitSignedPSBT struct {
+		Cmd    *SubmitSignedPSBTCmd
+		Result *string
+	} `jsonrpcmethod:"submitsignedpsbt" jsonrpcflags:"walletonly"`
+	TransferAccount struct {
+		Cmd    *TransferAccountCmd
+		Result *TransferAccountResult
+	} `jsonrpcmethod:"transferaccount" jsonrpcflags:"walletonly"`
+	UnarchiveAccount struct {
+		Cmd *UnarchiveAccountCmd
+	} `jsonrpcmethod:"unarchiveaccount" jsonrpcflags:"walletonly"`
+	WalletDBStats struct {
+		Cmd    *WalletDBStatsCmd
+		Result *[]WalletDBBucketResult
+	} `jsonrpcmethod:"walletdbstats" jsonrpcflags:"walletonly"`
+	WalletSelfTest struct {
+		Cmd    *WalletSelfTestCmd
+		Result *WalletSelfTestResult
+	} `jsonrpcmethod:"walletselftest" jsonrpcflags:"walletonly"`
+	WithdrawVault struct {
+		Cmd    *WithdrawVaultCmd
+		Result *string
+	} `jsonrpcmethod:"withdrawvault" jsonrpcflags:"walletonly"`
+}
+
 func init() {
 	
 	// The commands in this file are only usable with a wallet server.
@@ -637,4 +1841,5 @@ func init() {
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
+	MustRegisterCmds((*walletSvrCmdSet)(nil))
 }
@@ -55,6 +55,62 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account:   btcjson.String("test"),
 			},
 		},
+		{
+			name: "addportfolioentry",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("addportfolioentry", "cold", []string{"xpub1"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewAddPortfolioEntryCmd("cold", []string{"xpub1"}, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"addportfolioentry","netparams":["cold",["xpub1"]],"id":1}`,
+			unmarshalled: &btcjson.AddPortfolioEntryCmd{
+				Name:        "cold",
+				Descriptors: []string{"xpub1"},
+				Range:       btcjson.Int(1000),
+				Rescan:      btcjson.Bool(true),
+			},
+		},
+		{
+			name: "addportfolioentry optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("addportfolioentry", "cold", []string{"pkh(xpub1/0/*)"}, 50, false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewAddPortfolioEntryCmd(
+					"cold", []string{"pkh(xpub1/0/*)"}, btcjson.Int(50), btcjson.Bool(false),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"addportfolioentry","netparams":["cold",["pkh(xpub1/0/*)"],50,false],"id":1}`,
+			unmarshalled: &btcjson.AddPortfolioEntryCmd{
+				Name:        "cold",
+				Descriptors: []string{"pkh(xpub1/0/*)"},
+				Range:       btcjson.Int(50),
+				Rescan:      btcjson.Bool(false),
+			},
+		},
+		{
+			name: "archiveaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("archiveaccount", "old")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewArchiveAccountCmd("old")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"archiveaccount","netparams":["old"],"id":1}`,
+			unmarshalled: &btcjson.ArchiveAccountCmd{Account: "old"},
+		},
+		{
+			name: "unarchiveaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("unarchiveaccount", "old")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewUnarchiveAccountCmd("old")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"unarchiveaccount","netparams":["old"],"id":1}`,
+			unmarshalled: &btcjson.UnarchiveAccountCmd{Account: "old"},
+		},
 		{
 			name: "addwitnessaddress",
 			newCmd: func() (interface{}, error) {
@@ -83,6 +139,65 @@ func TestWalletSvrCmds(t *testing.T) {
 				Keys:      []string{"031234", "035678"},
 			},
 		},
+		{
+			name: "createmultisigaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createmultisigaccount", "vault", 2, []string{"xpub1", "xpub2", "xpub3"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreateMultiSigAccountCmd("vault", 2, []string{"xpub1", "xpub2", "xpub3"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createmultisigaccount","netparams":["vault",2,` +
+				`["xpub1","xpub2","xpub3"]],"id":1}`,
+			unmarshalled: &btcjson.CreateMultiSigAccountCmd{
+				Name:      "vault",
+				NRequired: 2,
+				XPubs:     []string{"xpub1", "xpub2", "xpub3"},
+			},
+		},
+		{
+			name: "createvaultaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createvaultaccount", "savings")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreateVaultAccountCmd("savings", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createvaultaccount","netparams":["savings"],"id":1}`,
+			unmarshalled: &btcjson.CreateVaultAccountCmd{
+				Name:       "savings",
+				LockHeight: btcjson.Int32(0),
+				Delay:      btcjson.Int32(0),
+			},
+		},
+		{
+			name: "createvaultaccount optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createvaultaccount", "savings", 0, 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreateVaultAccountCmd("savings", btcjson.Int32(0), btcjson.Int32(1000))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createvaultaccount","netparams":["savings",0,1000],"id":1}`,
+			unmarshalled: &btcjson.CreateVaultAccountCmd{
+				Name:       "savings",
+				LockHeight: btcjson.Int32(0),
+				Delay:      btcjson.Int32(1000),
+			},
+		},
+		{
+			name: "deleteaddressmeta",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("deleteaddressmeta", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDeleteAddressMetaCmd("1Address")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"deleteaddressmeta","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.DeleteAddressMetaCmd{
+				Address: "1Address",
+			},
+		},
 		{
 			name: "dumpprivkey",
 			newCmd: func() (interface{}, error) {
@@ -135,6 +250,110 @@ func TestWalletSvrCmds(t *testing.T) {
 				NumBlocks: 6,
 			},
 		},
+		{
+			name: "exportaccountxprv",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportaccountxprv", "acct", "pass")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewExportAccountXprvCmd("acct", "pass", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportaccountxprv","netparams":["acct","pass"],"id":1}`,
+			unmarshalled: &btcjson.ExportAccountXprvCmd{
+				Account:   "acct",
+				Password:  "pass",
+				Plaintext: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "exportaccountxprv optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportaccountxprv", "acct", "pass", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewExportAccountXprvCmd("acct", "pass", btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportaccountxprv","netparams":["acct","pass",true],"id":1}`,
+			unmarshalled: &btcjson.ExportAccountXprvCmd{
+				Account:   "acct",
+				Password:  "pass",
+				Plaintext: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "exportledger",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportledger")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewExportLedgerCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportledger","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ExportLedgerCmd{
+				Format:    btcjson.String("ledger"),
+				Commodity: btcjson.String("DUO"),
+			},
+		},
+		{
+			name: "exportledger optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportledger", "beancount", "PARC")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewExportLedgerCmd(btcjson.String("beancount"), btcjson.String("PARC"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportledger","netparams":["beancount","PARC"],"id":1}`,
+			unmarshalled: &btcjson.ExportLedgerCmd{
+				Format:    btcjson.String("beancount"),
+				Commodity: btcjson.String("PARC"),
+			},
+		},
+		{
+			name: "exportpaymentbundle",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportpaymentbundle", "shop", `[{"label":"coffee","amount":0.5}]`)
+			},
+			staticCmd: func() interface{} {
+				requests := []btcjson.PaymentBundleRequest{{Label: "coffee", Amount: 0.5}}
+				return btcjson.NewExportPaymentBundleCmd("shop", requests, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportpaymentbundle","netparams":["shop",[{"label":"coffee","amount":0.5}]],"id":1}`,
+			unmarshalled: &btcjson.ExportPaymentBundleCmd{
+				Account:  "shop",
+				Requests: []btcjson.PaymentBundleRequest{{Label: "coffee", Amount: 0.5}},
+				Expires:  btcjson.Int64(0),
+			},
+		},
+		{
+			name: "exportpaymentbundle optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportpaymentbundle", "shop", `[{"label":"tea","amount":0}]`, 1700000000, "1Address")
+			},
+			staticCmd: func() interface{} {
+				requests := []btcjson.PaymentBundleRequest{{Label: "tea"}}
+				return btcjson.NewExportPaymentBundleCmd(
+					"shop", requests, btcjson.Int64(1700000000), btcjson.String("1Address"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"exportpaymentbundle","netparams":["shop",[{"label":"tea","amount":0}],1700000000,"1Address"],"id":1}`,
+			unmarshalled: &btcjson.ExportPaymentBundleCmd{
+				Account:     "shop",
+				Requests:    []btcjson.PaymentBundleRequest{{Label: "tea"}},
+				Expires:     btcjson.Int64(1700000000),
+				SignAddress: btcjson.String("1Address"),
+			},
+		},
+		{
+			name: "exportwatchset",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("exportwatchset")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewExportWatchSetCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"exportwatchset","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ExportWatchSetCmd{},
+		},
 		{
 			name: "getaccount",
 			newCmd: func() (interface{}, error) {
@@ -161,6 +380,45 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "getaccountxpub",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaccountxpub", "acct")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAccountXPubCmd("acct")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaccountxpub","netparams":["acct"],"id":1}`,
+			unmarshalled: &btcjson.GetAccountXPubCmd{
+				Account: "acct",
+			},
+		},
+		{
+			name: "getaddressinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressinfo", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressInfoCmd("1Address")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressinfo","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.GetAddressInfoCmd{
+				Address: "1Address",
+			},
+		},
+		{
+			name: "getaddressmeta",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressmeta", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressMetaCmd("1Address")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressmeta","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.GetAddressMetaCmd{
+				Address: "1Address",
+			},
+		},
 		{
 			name: "getaddressesbyaccount",
 			newCmd: func() (interface{}, error) {
@@ -174,6 +432,68 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "getauditlog",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getauditlog")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAuditLogCmd(nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getauditlog","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetAuditLogCmd{
+				From:      btcjson.Int64(1),
+				Count:     btcjson.Int(100),
+				StartTime: btcjson.Int64(0),
+				EndTime:   btcjson.Int64(0),
+			},
+		},
+		{
+			name: "getauditlog optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getauditlog", 5, 10, 1500000000, 1600000000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAuditLogCmd(
+					btcjson.Int64(5), btcjson.Int(10), btcjson.Int64(1500000000), btcjson.Int64(1600000000),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getauditlog","netparams":[5,10,1500000000,1600000000],"id":1}`,
+			unmarshalled: &btcjson.GetAuditLogCmd{
+				From:      btcjson.Int64(5),
+				Count:     btcjson.Int(10),
+				StartTime: btcjson.Int64(1500000000),
+				EndTime:   btcjson.Int64(1600000000),
+			},
+		},
+		{
+			name: "getbalanceat",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getbalanceat", 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBalanceAtCmd(1000, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getbalanceat","netparams":[1000],"id":1}`,
+			unmarshalled: &btcjson.GetBalanceAtCmd{
+				HeightOrTime: 1000,
+				PerAccount:   btcjson.Bool(false),
+			},
+		},
+		{
+			name: "getbalanceat optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getbalanceat", 1609459199, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBalanceAtCmd(1609459199, btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getbalanceat","netparams":[1609459199,true],"id":1}`,
+			unmarshalled: &btcjson.GetBalanceAtCmd{
+				HeightOrTime: 1609459199,
+				PerAccount:   btcjson.Bool(true),
+			},
+		},
 		{
 			name: "getbalance",
 			newCmd: func() (interface{}, error) {
@@ -185,7 +505,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			marshalled: `{"jsonrpc":"1.0","method":"getbalance","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetBalanceCmd{
 				Account: nil,
-				MinConf: btcjson.Int(1),
+				MinConf: nil,
 			},
 		},
 		{
@@ -199,7 +519,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			marshalled: `{"jsonrpc":"1.0","method":"getbalance","netparams":["acct"],"id":1}`,
 			unmarshalled: &btcjson.GetBalanceCmd{
 				Account: btcjson.String("acct"),
-				MinConf: btcjson.Int(1),
+				MinConf: nil,
 			},
 		},
 		{
@@ -216,13 +536,39 @@ func TestWalletSvrCmds(t *testing.T) {
 				MinConf: btcjson.Int(6),
 			},
 		},
+		{
+			name: "generatepaperkey",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("generatepaperkey")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGeneratePaperKeyCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generatepaperkey","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GeneratePaperKeyCmd{
+				Count: btcjson.Int(1),
+			},
+		},
+		{
+			name: "generatepaperkey optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("generatepaperkey", 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGeneratePaperKeyCmd(btcjson.Int(5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generatepaperkey","netparams":[5],"id":1}`,
+			unmarshalled: &btcjson.GeneratePaperKeyCmd{
+				Count: btcjson.Int(5),
+			},
+		},
 		{
 			name: "getnewaddress",
 			newCmd: func() (interface{}, error) {
 				return btcjson.NewCmd("getnewaddress")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewGetNewAddressCmd(nil)
+				return btcjson.NewGetNewAddressCmd(nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getnewaddress","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetNewAddressCmd{
@@ -235,13 +581,67 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewCmd("getnewaddress", "acct")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewGetNewAddressCmd(btcjson.String("acct"))
+				return btcjson.NewGetNewAddressCmd(btcjson.String("acct"), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getnewaddress","netparams":["acct"],"id":1}`,
 			unmarshalled: &btcjson.GetNewAddressCmd{
 				Account: btcjson.String("acct"),
 			},
 		},
+		{
+			name: "getnewaddress optional2",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnewaddress", "acct", "legacy")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNewAddressCmd(btcjson.String("acct"), btcjson.String("legacy"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnewaddress","netparams":["acct","legacy"],"id":1}`,
+			unmarshalled: &btcjson.GetNewAddressCmd{
+				Account:     btcjson.String("acct"),
+				AddressType: btcjson.String("legacy"),
+			},
+		},
+		{
+			name: "getnewmultisigaddress",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnewmultisigaddress", "vault")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNewMultiSigAddressCmd("vault")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnewmultisigaddress","netparams":["vault"],"id":1}`,
+			unmarshalled: &btcjson.GetNewMultiSigAddressCmd{
+				Name: "vault",
+			},
+		},
+		{
+			name: "getnewvaultaddress",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnewvaultaddress", "savings")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNewVaultAddressCmd("savings")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnewvaultaddress","netparams":["savings"],"id":1}`,
+			unmarshalled: &btcjson.GetNewVaultAddressCmd{
+				Name: "savings",
+			},
+		},
+		{
+			name: "getpaymentbundle",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getpaymentbundle", "0123abcd")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetPaymentBundleCmd("0123abcd", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getpaymentbundle","netparams":["0123abcd"],"id":1}`,
+			unmarshalled: &btcjson.GetPaymentBundleCmd{
+				ID:      "0123abcd",
+				MinConf: btcjson.Int(1),
+			},
+		},
 		{
 			name: "getrawchangeaddress",
 			newCmd: func() (interface{}, error) {
@@ -268,6 +668,91 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: btcjson.String("acct"),
 			},
 		},
+		{
+			name: "getvaultschedule",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getvaultschedule", "savings")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetVaultScheduleCmd("savings")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvaultschedule","netparams":["savings"],"id":1}`,
+			unmarshalled: &btcjson.GetVaultScheduleCmd{
+				Name: "savings",
+			},
+		},
+		{
+			name: "backupremote",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("backupremote")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBackupRemoteCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"backupremote","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.BackupRemoteCmd{
+				Force: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "backupremote optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("backupremote", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBackupRemoteCmd(btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"backupremote","netparams":[true],"id":1}`,
+			unmarshalled: &btcjson.BackupRemoteCmd{
+				Force: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "cancelqueuedpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("cancelqueuedpsbt", "abc")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCancelQueuedPSBTCmd("abc")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"cancelqueuedpsbt","netparams":["abc"],"id":1}`,
+			unmarshalled: &btcjson.CancelQueuedPSBTCmd{
+				ID: "abc",
+			},
+		},
+		{
+			name: "getrescaninfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getrescaninfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetRescanInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getrescaninfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetRescanInfoCmd{},
+		},
+		{
+			name: "getscrubinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getscrubinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetScrubInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getscrubinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetScrubInfoCmd{},
+		},
+		{
+			name: "getspendauth",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getspendauth")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSpendAuthCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getspendauth","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetSpendAuthCmd{},
+		},
 		{
 			name: "getreceivedbyaccount",
 			newCmd: func() (interface{}, error) {
@@ -353,15 +838,69 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 		},
 		{
-			name: "getwalletinfo",
+			name: "getutxoreport",
 			newCmd: func() (interface{}, error) {
-				return btcjson.NewCmd("getwalletinfo")
+				return btcjson.NewCmd("getutxoreport")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewGetWalletInfoCmd()
+				return btcjson.NewGetUTXOReportCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getutxoreport","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetUTXOReportCmd{},
+		},
+		{
+			name: "getutxoreport optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getutxoreport", 0.0002)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetUTXOReportCmd(btcjson.Float64(0.0002))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getutxoreport","netparams":[0.0002],"id":1}`,
+			unmarshalled: &btcjson.GetUTXOReportCmd{
+				FeeRate: btcjson.Float64(0.0002),
+			},
+		},
+		{
+			name: "getwalletinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getwalletinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWalletInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getwalletinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetWalletInfoCmd{},
+		},
+		{
+			name: "importcorewallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importcorewallet", "wallet.dat")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportCoreWalletCmd("wallet.dat", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importcorewallet","netparams":["wallet.dat"],"id":1}`,
+			unmarshalled: &btcjson.ImportCoreWalletCmd{
+				Path:       "wallet.dat",
+				Passphrase: btcjson.String(""),
+				Rescan:     btcjson.Bool(true),
+			},
+		},
+		{
+			name: "importcorewallet optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importcorewallet", "wallet.dat", "pass", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportCoreWalletCmd("wallet.dat", btcjson.String("pass"), btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importcorewallet","netparams":["wallet.dat","pass",false],"id":1}`,
+			unmarshalled: &btcjson.ImportCoreWalletCmd{
+				Path:       "wallet.dat",
+				Passphrase: btcjson.String("pass"),
+				Rescan:     btcjson.Bool(false),
 			},
-			marshalled:   `{"jsonrpc":"1.0","method":"getwalletinfo","netparams":[],"id":1}`,
-			unmarshalled: &btcjson.GetWalletInfoCmd{},
 		},
 		{
 			name: "importprivkey",
@@ -408,6 +947,64 @@ func TestWalletSvrCmds(t *testing.T) {
 				Rescan:  btcjson.Bool(false),
 			},
 		},
+		{
+			name: "importscriptpubkey",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importscriptpubkey", "51")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportScriptPubKeyCmd("51", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importscriptpubkey","netparams":["51"],"id":1}`,
+			unmarshalled: &btcjson.ImportScriptPubKeyCmd{
+				Script: "51",
+				Label:  btcjson.String(""),
+				Rescan: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "importscriptpubkey optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importscriptpubkey", "51", "htlc", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportScriptPubKeyCmd("51", btcjson.String("htlc"), btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importscriptpubkey","netparams":["51","htlc",false],"id":1}`,
+			unmarshalled: &btcjson.ImportScriptPubKeyCmd{
+				Script: "51",
+				Label:  btcjson.String("htlc"),
+				Rescan: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "importtimelockscript",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importtimelockscript", "0164b175")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportTimeLockScriptCmd("0164b175", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importtimelockscript","netparams":["0164b175"],"id":1}`,
+			unmarshalled: &btcjson.ImportTimeLockScriptCmd{
+				RedeemScript: "0164b175",
+				Rescan:       btcjson.Bool(true),
+			},
+		},
+		{
+			name: "importtimelockscript optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importtimelockscript", "0164b175", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportTimeLockScriptCmd("0164b175", btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importtimelockscript","netparams":["0164b175",false],"id":1}`,
+			unmarshalled: &btcjson.ImportTimeLockScriptCmd{
+				RedeemScript: "0164b175",
+				Rescan:       btcjson.Bool(false),
+			},
+		},
 		{
 			name: "keypoolrefill",
 			newCmd: func() (interface{}, error) {
@@ -440,11 +1037,12 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewCmd("listaccounts")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewListAccountsCmd(nil)
+				return btcjson.NewListAccountsCmd(nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"listaccounts","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.ListAccountsCmd{
-				MinConf: btcjson.Int(1),
+				MinConf:         btcjson.Int(1),
+				IncludeArchived: btcjson.Bool(false),
 			},
 		},
 		{
@@ -453,11 +1051,26 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewCmd("listaccounts", 6)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewListAccountsCmd(btcjson.Int(6))
+				return btcjson.NewListAccountsCmd(btcjson.Int(6), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"listaccounts","netparams":[6],"id":1}`,
 			unmarshalled: &btcjson.ListAccountsCmd{
-				MinConf: btcjson.Int(6),
+				MinConf:         btcjson.Int(6),
+				IncludeArchived: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "listaccounts includearchived",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listaccounts", 0, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListAccountsCmd(btcjson.Int(0), btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listaccounts","netparams":[0,true],"id":1}`,
+			unmarshalled: &btcjson.ListAccountsCmd{
+				MinConf:         btcjson.Int(0),
+				IncludeArchived: btcjson.Bool(true),
 			},
 		},
 		{
@@ -471,6 +1084,116 @@ func TestWalletSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"listaddressgroupings","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.ListAddressGroupingsCmd{},
 		},
+		{
+			name: "listaddressmeta",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listaddressmeta")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListAddressMetaCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listaddressmeta","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListAddressMetaCmd{},
+		},
+		{
+			name: "listaddressmeta optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listaddressmeta", "receive")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListAddressMetaCmd(btcjson.String("receive"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listaddressmeta","netparams":["receive"],"id":1}`,
+			unmarshalled: &btcjson.ListAddressMetaCmd{
+				Category: btcjson.String("receive"),
+			},
+		},
+		{
+			name: "listinvoices",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listinvoices")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListInvoicesCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listinvoices","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListInvoicesCmd{
+				MinConf: btcjson.Int(1),
+			},
+		},
+		{
+			name: "listinvoices optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listinvoices", "partial", 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListInvoicesCmd(btcjson.String("partial"), btcjson.Int(6))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listinvoices","netparams":["partial",6],"id":1}`,
+			unmarshalled: &btcjson.ListInvoicesCmd{
+				State:   btcjson.String("partial"),
+				MinConf: btcjson.Int(6),
+			},
+		},
+		{
+			name: "listinvoicereservations",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listinvoicereservations")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListInvoiceReservationsCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listinvoicereservations","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListInvoiceReservationsCmd{
+				Account: btcjson.String("default"),
+			},
+		},
+		{
+			name: "listimmature",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listimmature")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListImmatureCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listimmature","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListImmatureCmd{},
+		},
+		{
+			name: "listimmature optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listimmature", "acct")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListImmatureCmd(btcjson.String("acct"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listimmature","netparams":["acct"],"id":1}`,
+			unmarshalled: &btcjson.ListImmatureCmd{
+				Account: btcjson.String("acct"),
+			},
+		},
+		{
+			name: "listdustoutputs",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listdustoutputs")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListDustOutputsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listdustoutputs","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListDustOutputsCmd{},
+		},
+		{
+			name: "listfrozen",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listfrozen")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListFrozenCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listfrozen","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListFrozenCmd{},
+		},
 		{
 			name: "listlockunspent",
 			newCmd: func() (interface{}, error) {
@@ -482,6 +1205,104 @@ func TestWalletSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"listlockunspent","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.ListLockUnspentCmd{},
 		},
+		{
+			name: "listmultisigaccounts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listmultisigaccounts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListMultiSigAccountsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listmultisigaccounts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListMultiSigAccountsCmd{},
+		},
+		{
+			name: "listpaymentbundles",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listpaymentbundles", 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListPaymentBundlesCmd(btcjson.Int(6))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listpaymentbundles","netparams":[6],"id":1}`,
+			unmarshalled: &btcjson.ListPaymentBundlesCmd{
+				MinConf: btcjson.Int(6),
+			},
+		},
+		{
+			name: "listportfolio",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listportfolio")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListPortfolioCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listportfolio","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListPortfolioCmd{
+				MinConf: btcjson.Int(1),
+			},
+		},
+		{
+			name: "listportfoliotransactions",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listportfoliotransactions")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListPortfolioTransactionsCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listportfoliotransactions","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListPortfolioTransactionsCmd{
+				Name:  btcjson.String(""),
+				Count: btcjson.Int(100),
+			},
+		},
+		{
+			name: "listportfoliotransactions optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listportfoliotransactions", "cold", 10)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListPortfolioTransactionsCmd(btcjson.String("cold"), btcjson.Int(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listportfoliotransactions","netparams":["cold",10],"id":1}`,
+			unmarshalled: &btcjson.ListPortfolioTransactionsCmd{
+				Name:  btcjson.String("cold"),
+				Count: btcjson.Int(10),
+			},
+		},
+		{
+			name: "listqueuedpsbts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listqueuedpsbts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListQueuedPSBTsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listqueuedpsbts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListQueuedPSBTsCmd{},
+		},
+		{
+			name: "listvaultaccounts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listvaultaccounts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListVaultAccountsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listvaultaccounts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListVaultAccountsCmd{},
+		},
+		{
+			name: "listunlockattempts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listunlockattempts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListUnlockAttemptsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listunlockattempts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListUnlockAttemptsCmd{},
+		},
 		{
 			name: "listreceivedbyaccount",
 			newCmd: func() (interface{}, error) {
@@ -746,6 +1567,53 @@ func TestWalletSvrCmds(t *testing.T) {
 				IncludeWatchOnly: btcjson.Bool(true),
 			},
 		},
+		{
+			name: "listtransactionspage",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listtransactionspage")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListTransactionsPageCmd(nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listtransactionspage","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListTransactionsPageCmd{
+				Cursor: nil,
+				Count:  btcjson.Int(10),
+				Filter: nil,
+			},
+		},
+		{
+			name: "listtransactionspage optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"listtransactionspage", "100:abcd", 20,
+					`{"categories":["send","receive"],"label":"acct","starttime":1500000000,"minamount":0.5}`,
+				)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListTransactionsPageCmd(
+					btcjson.String("100:abcd"), btcjson.Int(20),
+					&btcjson.ListTransactionsFilter{
+						Categories: []string{"send", "receive"},
+						Label:      btcjson.String("acct"),
+						StartTime:  btcjson.Int64(1500000000),
+						MinAmount:  btcjson.Float64(0.5),
+					},
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listtransactionspage","netparams":["100:abcd",20,` +
+				`{"categories":["send","receive"],"label":"acct","starttime":1500000000,"minamount":0.5}],"id":1}`,
+			unmarshalled: &btcjson.ListTransactionsPageCmd{
+				Cursor: btcjson.String("100:abcd"),
+				Count:  btcjson.Int(20),
+				Filter: &btcjson.ListTransactionsFilter{
+					Categories: []string{"send", "receive"},
+					Label:      btcjson.String("acct"),
+					StartTime:  btcjson.Int64(1500000000),
+					MinAmount:  btcjson.Float64(0.5),
+				},
+			},
+		},
 		{
 			name: "listunspent",
 			newCmd: func() (interface{}, error) {
@@ -827,6 +1695,45 @@ func TestWalletSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "freezeunspent",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("freezeunspent", false, `[{"txid":"123","vout":1}]`, "under dispute")
+			},
+			staticCmd: func() interface{} {
+				txInputs := []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				}
+				return btcjson.NewFreezeUnspentCmd(false, txInputs, btcjson.String("under dispute"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"freezeunspent","netparams":[false,[{"txid":"123","vout":1}],"under dispute"],"id":1}`,
+			unmarshalled: &btcjson.FreezeUnspentCmd{
+				Unfreeze: false,
+				Transactions: []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				},
+				Reason: btcjson.String("under dispute"),
+			},
+		},
+		{
+			name: "freezeunspent unfreeze",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("freezeunspent", true, `[{"txid":"123","vout":1}]`)
+			},
+			staticCmd: func() interface{} {
+				txInputs := []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				}
+				return btcjson.NewFreezeUnspentCmd(true, txInputs, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"freezeunspent","netparams":[true,[{"txid":"123","vout":1}]],"id":1}`,
+			unmarshalled: &btcjson.FreezeUnspentCmd{
+				Unfreeze: true,
+				Transactions: []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				},
+			},
+		},
 		{
 			name: "move",
 			newCmd: func() (interface{}, error) {
@@ -879,39 +1786,112 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 		},
 		{
-			name: "sendfrom",
+			name: "overridedust",
 			newCmd: func() (interface{}, error) {
-				return btcjson.NewCmd("sendfrom", "from", "1Address", 0.5)
+				return btcjson.NewCmd("overridedust", true, `[{"txid":"123","vout":1}]`)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSendFromCmd("from", "1Address", 0.5, nil, nil, nil)
+				txInputs := []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				}
+				return btcjson.NewOverrideDustCmd(true, txInputs)
 			},
-			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5],"id":1}`,
-			unmarshalled: &btcjson.SendFromCmd{
-				FromAccount: "from",
-				ToAddress:   "1Address",
-				Amount:      0.5,
-				MinConf:     btcjson.Int(1),
-				Comment:     nil,
-				CommentTo:   nil,
+			marshalled: `{"jsonrpc":"1.0","method":"overridedust","netparams":[true,[{"txid":"123","vout":1}]],"id":1}`,
+			unmarshalled: &btcjson.OverrideDustCmd{
+				Release: true,
+				Transactions: []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				},
 			},
 		},
 		{
-			name: "sendfrom optional1",
+			name: "releaseinvoiceaddress",
 			newCmd: func() (interface{}, error) {
-				return btcjson.NewCmd("sendfrom", "from", "1Address", 0.5, 6)
+				return btcjson.NewCmd("releaseinvoiceaddress", "1Address")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSendFromCmd("from", "1Address", 0.5, btcjson.Int(6), nil, nil)
+				return btcjson.NewReleaseInvoiceAddressCmd("1Address")
 			},
-			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5,6],"id":1}`,
-			unmarshalled: &btcjson.SendFromCmd{
-				FromAccount: "from",
-				ToAddress:   "1Address",
-				Amount:      0.5,
-				MinConf:     btcjson.Int(6),
-				Comment:     nil,
-				CommentTo:   nil,
+			marshalled: `{"jsonrpc":"1.0","method":"releaseinvoiceaddress","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.ReleaseInvoiceAddressCmd{
+				Address: "1Address",
+			},
+		},
+		{
+			name: "removeportfolioentry",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("removeportfolioentry", "cold")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewRemovePortfolioEntryCmd("cold")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"removeportfolioentry","netparams":["cold"],"id":1}`,
+			unmarshalled: &btcjson.RemovePortfolioEntryCmd{
+				Name: "cold",
+			},
+		},
+		{
+			name: "reserveinvoiceaddress",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("reserveinvoiceaddress", "shop")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewReserveInvoiceAddressCmd("shop", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"reserveinvoiceaddress","netparams":["shop"],"id":1}`,
+			unmarshalled: &btcjson.ReserveInvoiceAddressCmd{
+				Account:   "shop",
+				Reference: btcjson.String(""),
+			},
+		},
+		{
+			name: "reserveinvoiceaddress optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("reserveinvoiceaddress", "shop", "order 1001")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewReserveInvoiceAddressCmd("shop", btcjson.String("order 1001"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"reserveinvoiceaddress","netparams":["shop","order 1001"],"id":1}`,
+			unmarshalled: &btcjson.ReserveInvoiceAddressCmd{
+				Account:   "shop",
+				Reference: btcjson.String("order 1001"),
+			},
+		},
+		{
+			name: "sendfrom",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendfrom", "from", "1Address", 0.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendFromCmd("from", "1Address", 0.5, nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5],"id":1}`,
+			unmarshalled: &btcjson.SendFromCmd{
+				FromAccount: "from",
+				ToAddress:   "1Address",
+				Amount:      0.5,
+				MinConf:     nil,
+				Comment:     nil,
+				CommentTo:   nil,
+			},
+		},
+		{
+			name: "sendfrom optional1",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendfrom", "from", "1Address", 0.5, 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendFromCmd("from", "1Address", 0.5, btcjson.Int(6), nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5,6],"id":1}`,
+			unmarshalled: &btcjson.SendFromCmd{
+				FromAccount: "from",
+				ToAddress:   "1Address",
+				Amount:      0.5,
+				MinConf:     btcjson.Int(6),
+				Comment:     nil,
+				CommentTo:   nil,
 			},
 		},
 		{
@@ -921,7 +1901,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				return btcjson.NewSendFromCmd("from", "1Address", 0.5, btcjson.Int(6),
-					btcjson.String("comment"), nil,
+					btcjson.String("comment"), nil, nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5,6,"comment"],"id":1}`,
@@ -941,7 +1921,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				return btcjson.NewSendFromCmd("from", "1Address", 0.5, btcjson.Int(6),
-					btcjson.String("comment"), btcjson.String("commentto"),
+					btcjson.String("comment"), btcjson.String("commentto"), nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5,6,"comment","commentto"],"id":1}`,
@@ -954,6 +1934,76 @@ func TestWalletSvrCmds(t *testing.T) {
 				CommentTo:   btcjson.String("commentto"),
 			},
 		},
+		{
+			name: "sendfrom optional4",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendfrom", "from", "1Address", 0.5, 6, "", "", "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendFromCmd("from", "1Address", 0.5, btcjson.Int(6),
+					btcjson.String(""), btcjson.String(""), btcjson.String("123456"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","netparams":["from","1Address",0.5,6,"","","123456"],"id":1}`,
+			unmarshalled: &btcjson.SendFromCmd{
+				FromAccount: "from",
+				ToAddress:   "1Address",
+				Amount:      0.5,
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String(""),
+				CommentTo:   btcjson.String(""),
+				AuthCode:    btcjson.String("123456"),
+			},
+		},
+		{
+			name: "previewsend",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("previewsend", "from", `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewPreviewSendCmd("from", amounts, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"previewsend","netparams":["from",{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.PreviewSendCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     nil,
+			},
+		},
+		{
+			name: "previewsend optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("previewsend", "from", `{"1Address":0.5}`, 6)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewPreviewSendCmd("from", amounts, btcjson.Int(6), nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"previewsend","netparams":["from",{"1Address":0.5},6],"id":1}`,
+			unmarshalled: &btcjson.PreviewSendCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+			},
+		},
+		{
+			name: "previewsend scripts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("previewsend", "from", `{}`, 6, `{"51":0.25}`)
+			},
+			staticCmd: func() interface{} {
+				scripts := map[string]float64{"51": 0.25}
+				return btcjson.NewPreviewSendCmd("from", map[string]float64{}, btcjson.Int(6), &scripts)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"previewsend","netparams":["from",{},6,{"51":0.25}],"id":1}`,
+			unmarshalled: &btcjson.PreviewSendCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{},
+				MinConf:     btcjson.Int(6),
+				Scripts:     &map[string]float64{"51": 0.25},
+			},
+		},
 		{
 			name: "sendmany",
 			newCmd: func() (interface{}, error) {
@@ -961,13 +2011,13 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, nil, nil)
+				return btcjson.NewSendManyCmd("from", amounts, nil, nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5}],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
 				FromAccount: "from",
 				Amounts:     map[string]float64{"1Address": 0.5},
-				MinConf:     btcjson.Int(1),
+				MinConf:     nil,
 				Comment:     nil,
 			},
 		},
@@ -978,7 +2028,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil)
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -995,7 +2045,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"))
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"), nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment"],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -1005,13 +2055,113 @@ func TestWalletSvrCmds(t *testing.T) {
 				Comment:     btcjson.String("comment"),
 			},
 		},
+		{
+			name: "sendmany optional3",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"sendmany", "from", `{"1Address":0.5}`, 6, "comment", `{"51":0.25}`,
+				)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				scripts := map[string]float64{"51": 0.25}
+				return btcjson.NewSendManyCmd(
+					"from", amounts, btcjson.Int(6), btcjson.String("comment"), &scripts, nil, nil, nil,
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment",{"51":0.25}],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String("comment"),
+				Scripts:     &map[string]float64{"51": 0.25},
+			},
+		},
+		{
+			name: "sendmany optional4",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"sendmany", "from", `{"1Address":0.5}`, 6, "", `{}`, "123456",
+				)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				scripts := map[string]float64{}
+				return btcjson.NewSendManyCmd(
+					"from", amounts, btcjson.Int(6), btcjson.String(""), &scripts, btcjson.String("123456"), nil, nil,
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"",{},"123456"],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String(""),
+				Scripts:     &map[string]float64{},
+				AuthCode:    btcjson.String("123456"),
+			},
+		},
+		{
+			name: "sendmany optional5",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"sendmany", "from", `{"1Address":0.5}`, 6, "", `{}`, "", `{"fee":0.001}`,
+				)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				scripts := map[string]float64{}
+				return btcjson.NewSendManyCmd(
+					"from", amounts, btcjson.Int(6), btcjson.String(""), &scripts, btcjson.String(""),
+					&btcjson.SendFeeOptions{Fee: btcjson.Float64(0.001)}, nil,
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"",{},"",{"fee":0.001}],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String(""),
+				Scripts:     &map[string]float64{},
+				AuthCode:    btcjson.String(""),
+				FeeOptions:  &btcjson.SendFeeOptions{Fee: btcjson.Float64(0.001)},
+			},
+		},
+		{
+			name: "sendmany optional6",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"sendmany", "from", `{"1Address":0.5}`, 6, "", `{}`, "", `{}`, "order-1",
+				)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				scripts := map[string]float64{}
+				return btcjson.NewSendManyCmd(
+					"from", amounts, btcjson.Int(6), btcjson.String(""), &scripts, btcjson.String(""),
+					&btcjson.SendFeeOptions{}, btcjson.String("order-1"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"",{},"",{},"order-1"],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount:    "from",
+				Amounts:        map[string]float64{"1Address": 0.5},
+				MinConf:        btcjson.Int(6),
+				Comment:        btcjson.String(""),
+				Scripts:        &map[string]float64{},
+				AuthCode:       btcjson.String(""),
+				FeeOptions:     &btcjson.SendFeeOptions{},
+				IdempotencyKey: btcjson.String("order-1"),
+			},
+		},
 		{
 			name: "sendtoaddress",
 			newCmd: func() (interface{}, error) {
 				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil)
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5],"id":1}`,
 			unmarshalled: &btcjson.SendToAddressCmd{
@@ -1028,7 +2178,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String("comment"),
-					btcjson.String("commentto"),
+					btcjson.String("commentto"), nil, nil, nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"comment","commentto"],"id":1}`,
@@ -1039,6 +2189,67 @@ func TestWalletSvrCmds(t *testing.T) {
 				CommentTo: btcjson.String("commentto"),
 			},
 		},
+		{
+			name: "sendtoaddress optional2",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5, "", "", "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String(""),
+					btcjson.String(""), btcjson.String("123456"), nil, nil,
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","","123456"],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:   "1Address",
+				Amount:    0.5,
+				Comment:   btcjson.String(""),
+				CommentTo: btcjson.String(""),
+				AuthCode:  btcjson.String("123456"),
+			},
+		},
+		{
+			name: "sendtoaddress optional3",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5, "", "", "", `{"feerate":0.0002}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String(""),
+					btcjson.String(""), btcjson.String(""),
+					&btcjson.SendFeeOptions{FeeRate: btcjson.Float64(0.0002)}, nil,
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","","",{"feerate":0.0002}],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:    "1Address",
+				Amount:     0.5,
+				Comment:    btcjson.String(""),
+				CommentTo:  btcjson.String(""),
+				AuthCode:   btcjson.String(""),
+				FeeOptions: &btcjson.SendFeeOptions{FeeRate: btcjson.Float64(0.0002)},
+			},
+		},
+		{
+			name: "sendtoaddress optional4",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5, "", "", "", `{}`, "order-1")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String(""),
+					btcjson.String(""), btcjson.String(""), &btcjson.SendFeeOptions{}, btcjson.String("order-1"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","","",{},"order-1"],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:        "1Address",
+				Amount:         0.5,
+				Comment:        btcjson.String(""),
+				CommentTo:      btcjson.String(""),
+				AuthCode:       btcjson.String(""),
+				FeeOptions:     &btcjson.SendFeeOptions{},
+				IdempotencyKey: btcjson.String("order-1"),
+			},
+		},
 		{
 			name: "setaccount",
 			newCmd: func() (interface{}, error) {
@@ -1053,6 +2264,75 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "setaddressmeta",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setaddressmeta", "1Address", `{"amount":0.5,"message":"invoice 1","state":"paid"}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetAddressMetaCmd(
+					"1Address", btcjson.AddressMetaFields{
+						Amount:  btcjson.Float64(0.5),
+						Message: btcjson.String("invoice 1"),
+						State:   btcjson.String("paid"),
+					},
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setaddressmeta","netparams":["1Address",` +
+				`{"amount":0.5,"message":"invoice 1","state":"paid"}],"id":1}`,
+			unmarshalled: &btcjson.SetAddressMetaCmd{
+				Address: "1Address",
+				Meta: btcjson.AddressMetaFields{
+					Amount:  btcjson.Float64(0.5),
+					Message: btcjson.String("invoice 1"),
+					State:   btcjson.String("paid"),
+				},
+			},
+		},
+		{
+			name: "setinvoiceissuance",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setinvoiceissuance", "shop", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetInvoiceIssuanceCmd("shop", true)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setinvoiceissuance","netparams":["shop",true],"id":1}`,
+			unmarshalled: &btcjson.SetInvoiceIssuanceCmd{
+				Account: "shop",
+				Enable:  true,
+			},
+		},
+		{
+			name: "setspendauth",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setspendauth", "pin")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetSpendAuthCmd("pin", nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setspendauth","netparams":["pin"],"id":1}`,
+			unmarshalled: &btcjson.SetSpendAuthCmd{
+				Method: "pin",
+				Limit:  btcjson.Float64(0),
+			},
+		},
+		{
+			name: "setspendauth optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setspendauth", "totp", 2.5, "", "1234")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetSpendAuthCmd("totp", btcjson.Float64(2.5), btcjson.String(""), btcjson.String("1234"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setspendauth","netparams":["totp",2.5,"","1234"],"id":1}`,
+			unmarshalled: &btcjson.SetSpendAuthCmd{
+				Method: "totp",
+				Limit:  btcjson.Float64(2.5),
+				Secret: btcjson.String(""),
+				Code:   btcjson.String("1234"),
+			},
+		},
 		{
 			name: "settxfee",
 			newCmd: func() (interface{}, error) {
@@ -1066,6 +2346,156 @@ func TestWalletSvrCmds(t *testing.T) {
 				Amount: 0.0001,
 			},
 		},
+		{
+			name: "sweepaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweepaccount", "acct", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepAccountCmd("acct", "1Address", nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweepaccount","netparams":["acct","1Address"],"id":1}`,
+			unmarshalled: &btcjson.SweepAccountCmd{
+				Account: "acct",
+				Address: "1Address",
+				MinConf: btcjson.Int(1),
+				Reserve: btcjson.Float64(0),
+				DryRun:  btcjson.Bool(false),
+			},
+		},
+		{
+			name: "sweepaccount optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweepaccount", "acct", "1Address", 6, 0.5, true, "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepAccountCmd(
+					"acct", "1Address", btcjson.Int(6), btcjson.Float64(0.5), btcjson.Bool(true),
+					btcjson.String("123456"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweepaccount","netparams":["acct","1Address",6,0.5,true,"123456"],"id":1}`,
+			unmarshalled: &btcjson.SweepAccountCmd{
+				Account:  "acct",
+				Address:  "1Address",
+				MinConf:  btcjson.Int(6),
+				Reserve:  btcjson.Float64(0.5),
+				DryRun:   btcjson.Bool(true),
+				AuthCode: btcjson.String("123456"),
+			},
+		},
+		{
+			name: "sweepprivkey",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweepprivkey", "key")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepPrivKeyCmd("key", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweepprivkey","netparams":["key"],"id":1}`,
+			unmarshalled: &btcjson.SweepPrivKeyCmd{
+				PrivKey: "key",
+				Account: btcjson.String("default"),
+				DryRun:  btcjson.Bool(false),
+			},
+		},
+		{
+			name: "sweepprivkey optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweepprivkey", "key", "acct", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepPrivKeyCmd("key", btcjson.String("acct"), btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweepprivkey","netparams":["key","acct",true],"id":1}`,
+			unmarshalled: &btcjson.SweepPrivKeyCmd{
+				PrivKey: "key",
+				Account: btcjson.String("acct"),
+				DryRun:  btcjson.Bool(true),
+			},
+		},
+		{
+			name: "sweeptimelocked",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweeptimelocked", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepTimeLockedCmd("1Address", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweeptimelocked","netparams":["1Address"],"id":1}`,
+			unmarshalled: &btcjson.SweepTimeLockedCmd{
+				Address: "1Address",
+				DryRun:  btcjson.Bool(false),
+			},
+		},
+		{
+			name: "sweeptimelocked optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sweeptimelocked", "1Address", true, "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSweepTimeLockedCmd("1Address", btcjson.Bool(true), btcjson.String("123456"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sweeptimelocked","netparams":["1Address",true,"123456"],"id":1}`,
+			unmarshalled: &btcjson.SweepTimeLockedCmd{
+				Address:  "1Address",
+				DryRun:   btcjson.Bool(true),
+				AuthCode: btcjson.String("123456"),
+			},
+		},
+		{
+			name: "submitsignedpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("submitsignedpsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSubmitSignedPSBTCmd("cHNidP8=")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"submitsignedpsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.SubmitSignedPSBTCmd{
+				PSBT: "cHNidP8=",
+			},
+		},
+		{
+			name: "transferaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("transferaccount", "from", "to", 0.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTransferAccountCmd("from", "to", 0.5, nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"transferaccount","netparams":["from","to",0.5],"id":1}`,
+			unmarshalled: &btcjson.TransferAccountCmd{
+				FromAccount: "from",
+				ToAccount:   "to",
+				Amount:      0.5,
+				FeeAccount:  btcjson.String(""),
+				MinConf:     btcjson.Int(1),
+				DryRun:      btcjson.Bool(false),
+			},
+		},
+		{
+			name: "transferaccount optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("transferaccount", "from", "to", 0.5, "fees", 6, true, "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTransferAccountCmd(
+					"from", "to", 0.5, btcjson.String("fees"), btcjson.Int(6), btcjson.Bool(true),
+					btcjson.String("123456"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"transferaccount","netparams":["from","to",0.5,"fees",6,true,"123456"],"id":1}`,
+			unmarshalled: &btcjson.TransferAccountCmd{
+				FromAccount: "from",
+				ToAccount:   "to",
+				Amount:      0.5,
+				FeeAccount:  btcjson.String("fees"),
+				MinConf:     btcjson.Int(6),
+				DryRun:      btcjson.Bool(true),
+				AuthCode:    btcjson.String("123456"),
+			},
+		},
 		{
 			name: "signmessage",
 			newCmd: func() (interface{}, error) {
@@ -1086,7 +2516,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewCmd("signrawtransaction", "001122")
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSignRawTransactionCmd("001122", nil, nil, nil)
+				return btcjson.NewSignRawTransactionCmd("001122", nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"signrawtransaction","netparams":["001122"],"id":1}`,
 			unmarshalled: &btcjson.SignRawTransactionCmd{
@@ -1112,7 +2542,7 @@ func TestWalletSvrCmds(t *testing.T) {
 						RedeemScript: "01",
 					},
 				}
-				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, nil, nil)
+				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"signrawtransaction","netparams":["001122",[{"txid":"123","vout":1,"scriptPubKey":"00","redeemScript":"01"}]],"id":1}`,
 			unmarshalled: &btcjson.SignRawTransactionCmd{
@@ -1137,7 +2567,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			staticCmd: func() interface{} {
 				txInputs := []btcjson.RawTxInput{}
 				privKeys := []string{"abc"}
-				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, &privKeys, nil)
+				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, &privKeys, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"signrawtransaction","netparams":["001122",[],["abc"]],"id":1}`,
 			unmarshalled: &btcjson.SignRawTransactionCmd{
@@ -1156,7 +2586,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				txInputs := []btcjson.RawTxInput{}
 				privKeys := []string{}
 				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, &privKeys,
-					btcjson.String("ALL"),
+					btcjson.String("ALL"), nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"signrawtransaction","netparams":["001122",[],[],"ALL"],"id":1}`,
@@ -1167,6 +2597,93 @@ func TestWalletSvrCmds(t *testing.T) {
 				Flags:    btcjson.String("ALL"),
 			},
 		},
+		{
+			name: "signrawtransaction optional4",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("signrawtransaction", "001122", `[]`, `[]`, "ALL", "123456")
+			},
+			staticCmd: func() interface{} {
+				txInputs := []btcjson.RawTxInput{}
+				privKeys := []string{}
+				return btcjson.NewSignRawTransactionCmd("001122", &txInputs, &privKeys,
+					btcjson.String("ALL"), btcjson.String("123456"),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"signrawtransaction","netparams":["001122",[],[],"ALL","123456"],"id":1}`,
+			unmarshalled: &btcjson.SignRawTransactionCmd{
+				RawTx:    "001122",
+				Inputs:   &[]btcjson.RawTxInput{},
+				PrivKeys: &[]string{},
+				Flags:    btcjson.String("ALL"),
+				AuthCode: btcjson.String("123456"),
+			},
+		},
+		{
+			name: "walletdbstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletdbstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletDBStatsCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletdbstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.WalletDBStatsCmd{
+				Largest: btcjson.Int(5),
+			},
+		},
+		{
+			name: "walletdbstats optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletdbstats", 10)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletDBStatsCmd(btcjson.Int(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletdbstats","netparams":[10],"id":1}`,
+			unmarshalled: &btcjson.WalletDBStatsCmd{
+				Largest: btcjson.Int(10),
+			},
+		},
+		{
+			name: "walletselftest",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletselftest")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletSelfTestCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"walletselftest","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.WalletSelfTestCmd{},
+		},
+		{
+			name: "withdrawvault",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("withdrawvault", "savings", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWithdrawVaultCmd("savings", "1Address", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"withdrawvault","netparams":["savings","1Address"],"id":1}`,
+			unmarshalled: &btcjson.WithdrawVaultCmd{
+				Name:    "savings",
+				Address: "1Address",
+			},
+		},
+		{
+			name: "withdrawvault optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("withdrawvault", "savings", "1Address", "123456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWithdrawVaultCmd("savings", "1Address", btcjson.String("123456"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"withdrawvault","netparams":["savings","1Address","123456"],"id":1}`,
+			unmarshalled: &btcjson.WithdrawVaultCmd{
+				Name:     "savings",
+				Address:  "1Address",
+				AuthCode: btcjson.String("123456"),
+			},
+		},
 		{
 			name: "walletlock",
 			newCmd: func() (interface{}, error) {
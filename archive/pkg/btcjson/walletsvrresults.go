@@ -1,6 +1,104 @@
 package btcjson
 
 type (
+	// ExportAccountXprvResult models the data from the exportaccountxprv command. When Encrypted is set, Xprv is the hex
+	// of the extended private key sealed with a key derived from the export password using the scrypt parameters and
+	// salt in KeyParams.
+	ExportAccountXprvResult struct {
+		Account   string `json:"account"`
+		Encrypted bool   `json:"encrypted"`
+		Xprv      string `json:"xprv"`
+		KeyParams string `json:"keyparams,omitempty"`
+	}
+	// AccountBalanceAtResult models the balance of an account in the data from the getbalanceat command.
+	AccountBalanceAtResult struct {
+		Account string  `json:"account"`
+		Scope   string  `json:"scope,omitempty"`
+		Balance float64 `json:"balance"`
+	}
+	// AddressMetaResult models the metadata of an address in the data from the getaddressmeta, listaddressmeta and
+	// setaddressmeta commands.
+	AddressMetaResult struct {
+		Address  string  `json:"address"`
+		Category string  `json:"category"`
+		Amount   float64 `json:"amount"`
+		Message  string  `json:"message,omitempty"`
+		Label    string  `json:"label,omitempty"`
+		State    string  `json:"state,omitempty"`
+		TxID     string  `json:"txid,omitempty"`
+		Created  int64   `json:"created"`
+		Modified int64   `json:"modified"`
+		Expires  int64   `json:"expires,omitempty"`
+	}
+	// GetAddressInfoResult models the data from the getaddressinfo command. Account, Branch, Index and HDKeyPath are
+	// only set for addresses of the wallet, and the last three only for those derived from its seed.
+	GetAddressInfoResult struct {
+		Address      string  `json:"address"`
+		ScriptPubKey string  `json:"scriptPubKey"`
+		IsMine       bool    `json:"ismine"`
+		IsWatchOnly  bool    `json:"iswatchonly"`
+		IsScript     bool    `json:"isscript"`
+		IsChange     bool    `json:"ischange"`
+		Account      string  `json:"account,omitempty"`
+		Branch       *uint32 `json:"branch,omitempty"`
+		Index        *uint32 `json:"index,omitempty"`
+		HDKeyPath    string  `json:"hdkeypath,omitempty"`
+	}
+	// AuditLogEntryResult models an entry of the audit log in the data from the getauditlog command.
+	AuditLogEntryResult struct {
+		Seq      uint64 `json:"seq"`
+		Time     int64  `json:"time"`
+		Identity string `json:"identity"`
+		Action   string `json:"action"`
+		Detail   string `json:"detail"`
+		Error    string `json:"error,omitempty"`
+		Hash     string `json:"hash"`
+	}
+	// BackupTargetResult models the outcome of the latest backup to a remote backup target in the data from the
+	// backupremote command.
+	BackupTargetResult struct {
+		Target string `json:"target"`
+		Time   int64  `json:"time"`
+		Size   int    `json:"size"`
+		Error  string `json:"error,omitempty"`
+	}
+	// GetAuditLogResult models the data from the getauditlog command.
+	GetAuditLogResult struct {
+		Entries  []AuditLogEntryResult `json:"entries"`
+		Verified bool                  `json:"verified"`
+	}
+	// GetBalanceAtResult models the data from the getbalanceat command.
+	GetBalanceAtResult struct {
+		Height   int32                    `json:"height"`
+		Hash     string                   `json:"hash"`
+		Time     int64                    `json:"time"`
+		Balance  float64                  `json:"balance"`
+		Accounts []AccountBalanceAtResult `json:"accounts,omitempty"`
+	}
+	// GetRescanInfoResult models the data from the getrescaninfo command.
+	GetRescanInfoResult struct {
+		Running     bool                   `json:"running"`
+		Started     int64                  `json:"started"`
+		Addresses   int                    `json:"addresses"`
+		OutPoints   int                    `json:"outpoints"`
+		StartHeight int32                  `json:"startheight"`
+		Height      int32                  `json:"height"`
+		BestHeight  int32                  `json:"bestheight"`
+		Queued      int                    `json:"queued"`
+		Conflicts   []RescanConflictResult `json:"conflicts"`
+	}
+	// GetScrubInfoResult models the data from the getscrubinfo command.
+	GetScrubInfoResult struct {
+		Enabled  bool                 `json:"enabled"`
+		Repair   bool                 `json:"repair"`
+		Phase    string               `json:"phase,omitempty"`
+		Passes   int                  `json:"passes"`
+		Started  int64                `json:"started"`
+		Checked  int                  `json:"checked"`
+		Finished int64                `json:"finished"`
+		Repaired int                  `json:"repaired"`
+		Findings []ScrubFindingResult `json:"findings"`
+	}
 	// GetTransactionDetailsResult models the details data from the gettransaction command. This models the "short" version of the ListTransactionsResult type, which excludes fields common to the transaction.  These common fields are instead part of the GetTransactionResult.
 	GetTransactionDetailsResult struct {
 		Account           string   `json:"account"`
@@ -26,6 +124,15 @@ type (
 		Details         []GetTransactionDetailsResult `json:"details"`
 		Hex             string                        `json:"hex"`
 	}
+	// ImportCoreWalletResult models the data from the importcorewallet command.
+	ImportCoreWalletResult struct {
+		Keys           int    `json:"keys"`
+		Imported       int    `json:"imported"`
+		Duplicates     int    `json:"duplicates"`
+		Labels         int    `json:"labels"`
+		RescanFrom     int32  `json:"rescanfrom"`
+		RescanFromHash string `json:"rescanfromhash"`
+	}
 	// InfoWalletResult models the data returned by the wallet server getinfo command.
 	InfoWalletResult struct {
 		Version         int32   `json:"version"`
@@ -45,6 +152,83 @@ type (
 		RelayFee        float64 `json:"relayfee"`
 		Errors          string  `json:"errors"`
 	}
+	// DustOutputResult models an output taken for the output of a dusting attack in the data from the listdustoutputs
+	// command.
+	DustOutputResult struct {
+		TxID     string  `json:"txid"`
+		Vout     uint32  `json:"vout"`
+		Address  string  `json:"address"`
+		Amount   float64 `json:"amount"`
+		Detected int64   `json:"detected"`
+		Frozen   bool    `json:"frozen"`
+	}
+	// FrozenOutputResult models an output frozen in the wallet in the data from the listfrozen command.
+	FrozenOutputResult struct {
+		TxID    string  `json:"txid"`
+		Vout    uint32  `json:"vout"`
+		Reason  string  `json:"reason"`
+		Frozen  int64   `json:"frozen"`
+		Unspent bool    `json:"unspent"`
+		Address string  `json:"address,omitempty"`
+		Amount  float64 `json:"amount,omitempty"`
+	}
+	// InvoiceReservationResult models an address reserved for an order in the data from the reserveinvoiceaddress and
+	// listinvoicereservations commands.
+	InvoiceReservationResult struct {
+		Address   string `json:"address"`
+		Account   string `json:"account"`
+		Index     uint32 `json:"index"`
+		Reference string `json:"reference,omitempty"`
+		Reserved  int64  `json:"reserved"`
+	}
+	// ExportPaymentBundleResult models the data from the exportpaymentbundle command. Bundle is the JSON document for
+	// the point of sale device, and Signature the signature of it made with the key of SignAddress the way signmessage
+	// signs messages, so the device can check it with verifymessage.
+	ExportPaymentBundleResult struct {
+		ID          string `json:"id"`
+		Bundle      string `json:"bundle"`
+		SignAddress string `json:"signaddress"`
+		Signature   string `json:"signature"`
+	}
+	// InvoiceResult models a payment request made with an address of the wallet in the data from the listinvoices
+	// command.
+	InvoiceResult struct {
+		Address     string   `json:"address"`
+		Amount      float64  `json:"amount"`
+		Message     string   `json:"message,omitempty"`
+		Label       string   `json:"label,omitempty"`
+		State       string   `json:"state"`
+		Received    float64  `json:"received"`
+		Pending     float64  `json:"pending"`
+		Payments    []string `json:"payments"`
+		Created     int64    `json:"created"`
+		Expires     int64    `json:"expires,omitempty"`
+		LastPayment int64    `json:"lastpayment,omitempty"`
+	}
+	// LegacyInfoResult models the data returned by the wallet server getinfo command when it serves requests as the
+	// legacy Parallelcoin daemon did, which had the fields of both the node and the wallet.
+	LegacyInfoResult struct {
+		Version           int32   `json:"version"`
+		ProtocolVersion   int32   `json:"protocolversion"`
+		WalletVersion     int32   `json:"walletversion"`
+		Balance           float64 `json:"balance"`
+		Blocks            int32   `json:"blocks"`
+		TimeOffset        int64   `json:"timeoffset"`
+		Connections       int32   `json:"connections"`
+		Proxy             string  `json:"proxy"`
+		PowAlgoID         uint32  `json:"pow_algo_id"`
+		PowAlgo           string  `json:"pow_algo"`
+		Difficulty        float64 `json:"difficulty"`
+		DifficultySHA256D float64 `json:"difficulty_sha256d"`
+		DifficultyScrypt  float64 `json:"difficulty_scrypt"`
+		TestNet           bool    `json:"testnet"`
+		KeypoolOldest     int64   `json:"keypoololdest"`
+		KeypoolSize       int32   `json:"keypoolsize"`
+		PaytxFee          float64 `json:"paytxfee"`
+		MinInput          float64 `json:"mininput"`
+		UnlockedUntil     *int64  `json:"unlocked_until,omitempty"`
+		Errors            string  `json:"errors"`
+	}
 	// ListTransactionsResult models the data from the listtransactions command.
 	ListTransactionsResult struct {
 		Abandoned bool    `json:"abandoned"`
@@ -52,12 +236,17 @@ type (
 		Address   string  `json:"address,omitempty"`
 		Amount    float64 `json:"amount"`
 		// BIP125Replaceable string   `json:"bip125-replaceable,omitempty"`
-		BlockHash         string   `json:"blockhash,omitempty"`
-		BlockIndex        int64    `json:"blockindex,omitempty"`
-		BlockTime         int64    `json:"blocktime,omitempty"`
-		Category          string   `json:"category"`
-		Confirmations     int64    `json:"confirmations"`
-		Fee               float64  `json:"fee,omitempty"`
+		BlockHash     string `json:"blockhash,omitempty"`
+		BlockIndex    int64  `json:"blockindex,omitempty"`
+		BlockTime     int64  `json:"blocktime,omitempty"`
+		Category      string `json:"category"`
+		Confirmations int64  `json:"confirmations"`
+		// Fee is the fee of the transaction and OutputFee the share of it attributed to the output, in proportion to its
+		// value among the outputs that are not change. Both are negative, as the fee is taken from the balance of the
+		// wallet, and are set on every entry of a transaction whose inputs were all spent by the wallet, as the fee of
+		// any other transaction is not known.
+		Fee               *float64 `json:"fee,omitempty"`
+		OutputFee         *float64 `json:"outputfee,omitempty"`
 		Generated         bool     `json:"generated,omitempty"`
 		InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
 		Time              int64    `json:"time"`
@@ -69,6 +258,11 @@ type (
 		Comment           string   `json:"comment,omitempty"`
 		OtherAccount      string   `json:"otheraccount,omitempty"`
 	}
+	// ListTransactionsPageResult models the data from the listtransactionspage command.
+	ListTransactionsPageResult struct {
+		Transactions []ListTransactionsResult `json:"transactions"`
+		NextCursor   string                   `json:"nextcursor,omitempty"`
+	}
 	// ListReceivedByAccountResult models the data from the listreceivedbyaccount command.
 	ListReceivedByAccountResult struct {
 		Account       string  `json:"account"`
@@ -89,6 +283,24 @@ type (
 		Transactions []ListTransactionsResult `json:"transactions"`
 		LastBlock    string                   `json:"lastblock"`
 	}
+	// ListImmatureResult models the data returned by the listimmature command.
+	ListImmatureResult struct {
+		Total   float64                    `json:"total"`
+		Outputs []ListImmatureOutputResult `json:"outputs"`
+	}
+	// ListImmatureOutputResult models a coinbase output that has not yet reached maturity in a listimmature result.
+	ListImmatureOutputResult struct {
+		TxID            string  `json:"txid"`
+		Vout            uint32  `json:"vout"`
+		Address         string  `json:"address,omitempty"`
+		Account         string  `json:"account"`
+		Amount          float64 `json:"amount"`
+		BlockHash       string  `json:"blockhash"`
+		BlockHeight     int32   `json:"blockheight"`
+		Confirmations   int64   `json:"confirmations"`
+		MaturityHeight  int32   `json:"maturityheight"`
+		BlocksRemaining int64   `json:"blocksremaining"`
+	}
 	// ListUnspentResult models a successful response from the listunspent request.
 	ListUnspentResult struct {
 		TxID          string  `json:"txid"`
@@ -100,6 +312,12 @@ type (
 		Amount        float64 `json:"amount"`
 		Confirmations int64   `json:"confirmations"`
 		Spendable     bool    `json:"spendable"`
+		// TimeLocked is set for an output paid to a time locked script of the wallet whose lock can't be passed in the
+		// next block, which unlocks once the best block reaches UnlockHeight, or its median time past reaches
+		// UnlockTime.
+		TimeLocked   bool  `json:"timelocked,omitempty"`
+		UnlockHeight int32 `json:"unlockheight,omitempty"`
+		UnlockTime   int64 `json:"unlocktime,omitempty"`
 	}
 	// SignRawTransactionError models the data that contains script verification errors from the signrawtransaction
 	// request.
@@ -110,12 +328,291 @@ type (
 		Sequence  uint32 `json:"sequence"`
 		Error     string `json:"error"`
 	}
+	// MultiSigAccountResult models a multisig account in the data from the createmultisigaccount and
+	// listmultisigaccounts commands.
+	MultiSigAccountResult struct {
+		Name      string                   `json:"name"`
+		Required  int                      `json:"required"`
+		Cosigners []MultiSigCosignerResult `json:"cosigners"`
+		NextIndex uint32                   `json:"nextindex"`
+	}
+	// MultiSigAddressResult models the data from the getnewmultisigaddress command.
+	MultiSigAddressResult struct {
+		Address      string `json:"address"`
+		RedeemScript string `json:"redeemScript"`
+		Index        uint32 `json:"index"`
+	}
+	// MultiSigCosignerResult models a cosigner of a multisig account in the data from the createmultisigaccount and
+	// listmultisigaccounts commands.
+	MultiSigCosignerResult struct {
+		XPub    string `json:"xpub"`
+		Ours    bool   `json:"ours"`
+		Account string `json:"account,omitempty"`
+	}
+	// PaperKeyResult models a key pair in the data from the generatepaperkey command.
+	PaperKeyResult struct {
+		Address   string `json:"address"`
+		PrivKey   string `json:"privkey"`
+		PubKey    string `json:"pubkey"`
+		AddressQR string `json:"addressqr"`
+		PrivKeyQR string `json:"privkeyqr"`
+	}
+	// ListPortfolioResult models the data from the listportfolio command, with the funds of all the entries added up.
+	ListPortfolioResult struct {
+		Balance     float64                `json:"balance"`
+		Unconfirmed float64                `json:"unconfirmed"`
+		Entries     []PortfolioEntryResult `json:"entries"`
+	}
+	// PaymentBundleResult models the settlement of a payment bundle exported with the exportpaymentbundle command in
+	// the data from the getpaymentbundle and listpaymentbundles commands.
+	PaymentBundleResult struct {
+		ID          string          `json:"id"`
+		Account     string          `json:"account"`
+		SignAddress string          `json:"signaddress"`
+		State       string          `json:"state"`
+		Requested   float64         `json:"requested"`
+		Received    float64         `json:"received"`
+		Pending     float64         `json:"pending"`
+		Paid        int             `json:"paid"`
+		Requests    []InvoiceResult `json:"requests"`
+		Created     int64           `json:"created"`
+		Expires     int64           `json:"expires,omitempty"`
+	}
+	// PortfolioEntryResult models a cold wallet watched in the portfolio in the data from the listportfolio command.
+	PortfolioEntryResult struct {
+		Name        string   `json:"name"`
+		Descriptors []string `json:"descriptors"`
+		Range       uint32   `json:"range"`
+		Height      int32    `json:"height"`
+		Added       int64    `json:"added"`
+		Balance     float64  `json:"balance"`
+		Unconfirmed float64  `json:"unconfirmed"`
+		Unspent     int      `json:"unspent"`
+	}
+	// PortfolioTransactionResult models the change a transaction made to the funds of a portfolio entry in the data
+	// from the listportfoliotransactions command.
+	PortfolioTransactionResult struct {
+		Entry         string  `json:"entry"`
+		TxID          string  `json:"txid"`
+		Amount        float64 `json:"amount"`
+		Confirmations int64   `json:"confirmations"`
+		BlockHeight   int32   `json:"blockheight,omitempty"`
+		Time          int64   `json:"time"`
+	}
+	// PreviewSendInput models an unspent output selected to fund the transaction in the data from the previewsend
+	// command.
+	PreviewSendInput struct {
+		TxID    string  `json:"txid"`
+		Vout    uint32  `json:"vout"`
+		Address string  `json:"address,omitempty"`
+		Amount  float64 `json:"amount"`
+	}
+	// PreviewSendResult models the data from the previewsend command.
+	PreviewSendResult struct {
+		Inputs  []PreviewSendInput `json:"inputs"`
+		VSize   int                `json:"vsize"`
+		Fee     float64            `json:"fee"`
+		Change  float64            `json:"change"`
+		FeeRate float64            `json:"feerate"`
+	}
+	// QueuedPSBTResult models a transaction in the signing queue of a watching-only wallet in the data from the
+	// listqueuedpsbts command. ID is the hash of the unsigned transaction, and TxID that of the signed transaction once
+	// it is broadcast.
+	QueuedPSBTResult struct {
+		ID      string `json:"id"`
+		Account string `json:"account"`
+		Created int64  `json:"created"`
+		State   string `json:"state"`
+		PSBT    string `json:"psbt"`
+		TxID    string `json:"txid,omitempty"`
+	}
+	// RescanConflictResult models an unmined transaction removed because a rescan found a mined transaction spending
+	// the same output, in the data from the getrescaninfo command.
+	RescanConflictResult struct {
+		TxID    string `json:"txid"`
+		Vout    uint32 `json:"vout"`
+		Removed string `json:"removed"`
+		SpentBy string `json:"spentby"`
+		Height  int32  `json:"height"`
+	}
+	// ScrubFindingResult models a problem found by scrubbing the wallet database, in the data from the getscrubinfo
+	// command.
+	ScrubFindingResult struct {
+		Pass     int    `json:"pass"`
+		Time     int64  `json:"time"`
+		Kind     string `json:"kind"`
+		Key      string `json:"key,omitempty"`
+		Problem  string `json:"problem"`
+		Repaired bool   `json:"repaired"`
+	}
 	// SignRawTransactionResult models the data from the signrawtransaction command.
 	SignRawTransactionResult struct {
 		Hex      string                    `json:"hex"`
 		Complete bool                      `json:"complete"`
 		Errors   []SignRawTransactionError `json:"errors,omitempty"`
 	}
+	// SpendAuthResult models the data from the getspendauth and setspendauth commands. The secret and its URI are only
+	// returned when a time-based code is set up.
+	SpendAuthResult struct {
+		Method string  `json:"method"`
+		Limit  float64 `json:"limit"`
+		Secret string  `json:"secret,omitempty"`
+		URI    string  `json:"uri,omitempty"`
+	}
+	// SweepAccountResult models the data from the sweepaccount command.
+	SweepAccountResult struct {
+		Account     string  `json:"account"`
+		Destination string  `json:"destination"`
+		Inputs      int     `json:"inputs"`
+		Amount      float64 `json:"amount"`
+		Reserve     float64 `json:"reserve"`
+		Fee         float64 `json:"fee"`
+		TxID        string  `json:"txid,omitempty"`
+	}
+	// SweepPrivKeyResult models the data from the sweepprivkey command.
+	SweepPrivKeyResult struct {
+		Address     string  `json:"address"`
+		Destination string  `json:"destination"`
+		Outputs     int     `json:"outputs"`
+		Amount      float64 `json:"amount"`
+		Fee         float64 `json:"fee"`
+		TxID        string  `json:"txid,omitempty"`
+	}
+	// SweepTimeLockedResult models the data from the sweeptimelocked command.
+	SweepTimeLockedResult struct {
+		Destination string  `json:"destination"`
+		Inputs      int     `json:"inputs"`
+		Amount      float64 `json:"amount"`
+		Fee         float64 `json:"fee"`
+		TxID        string  `json:"txid,omitempty"`
+	}
+	// TimeLockScriptResult models the data from the importtimelockscript command. Lock is the height or Unix time the
+	// chain must reach, or for a relative lock the number of blocks or seconds that must pass after an output is mined.
+	TimeLockScriptResult struct {
+		Address  string `json:"address"`
+		Relative bool   `json:"relative"`
+		Seconds  bool   `json:"seconds"`
+		Lock     int64  `json:"lock"`
+	}
+	// TransferAccountResult models the data from the transferaccount command. The change of the fee account is left out
+	// when the source account pays the fee.
+	TransferAccountResult struct {
+		FromAccount  string  `json:"fromaccount"`
+		ToAccount    string  `json:"toaccount"`
+		FeeAccount   string  `json:"feeaccount"`
+		Address      string  `json:"address"`
+		Amount       float64 `json:"amount"`
+		Fee          float64 `json:"fee"`
+		SourceChange float64 `json:"sourcechange"`
+		FeeChange    float64 `json:"feechange,omitempty"`
+		TxID         string  `json:"txid,omitempty"`
+	}
+	// GetUTXOReportResult models the data from the getutxoreport command. Uneconomic outputs cost more in fees to spend
+	// at the fee rate than they are worth, and spendcost is the fee that spending one output adds to a transaction.
+	GetUTXOReportResult struct {
+		Height           int32              `json:"height"`
+		FeeRate          float64            `json:"feerate"`
+		SpendCost        float64            `json:"spendcost"`
+		Count            int                `json:"count"`
+		Amount           float64            `json:"amount"`
+		Uneconomic       int                `json:"uneconomic"`
+		UneconomicAmount float64            `json:"uneconomicamount"`
+		Dust             int                `json:"dust"`
+		Frozen           int                `json:"frozen"`
+		Locked           int                `json:"locked"`
+		ByAge            []UTXOBucketResult `json:"byage"`
+		ByValue          []UTXOBucketResult `json:"byvalue"`
+	}
+	// UTXOBucketResult models the outputs of the wallet within a range of ages in seconds or of amounts in the data
+	// from the getutxoreport command. Max is left out for the last range, which has no upper bound.
+	UTXOBucketResult struct {
+		Name             string  `json:"name"`
+		Min              float64 `json:"min"`
+		Max              float64 `json:"max,omitempty"`
+		Count            int     `json:"count"`
+		Amount           float64 `json:"amount"`
+		Uneconomic       int     `json:"uneconomic"`
+		UneconomicAmount float64 `json:"uneconomicamount"`
+		SpendCost        float64 `json:"spendcost"`
+	}
+	// UnlockAttemptResult models an entry of the data from the listunlockattempts command.
+	UnlockAttemptResult struct {
+		Time    int64  `json:"time"`
+		Success bool   `json:"success"`
+		Reason  string `json:"reason"`
+	}
+	// VaultAccountResult models a vault account in the data from the createvaultaccount and listvaultaccounts commands.
+	VaultAccountResult struct {
+		Name       string `json:"name"`
+		LockHeight int32  `json:"lockheight,omitempty"`
+		Delay      int32  `json:"delay,omitempty"`
+	}
+	// VaultAddressResult models the data from the getnewvaultaddress command.
+	VaultAddressResult struct {
+		Address      string `json:"address"`
+		RedeemScript string `json:"redeemScript"`
+		LockHeight   int32  `json:"lockheight"`
+	}
+	// VaultLockResult models a deposit address of a vault account in the data from the getvaultschedule command.
+	VaultLockResult struct {
+		Address    string  `json:"address"`
+		LockHeight int32   `json:"lockheight"`
+		BlocksLeft int32   `json:"blocksleft"`
+		Amount     float64 `json:"amount"`
+		Outputs    int     `json:"outputs"`
+	}
+	// VaultScheduleResult models the data from the getvaultschedule command.
+	VaultScheduleResult struct {
+		Height   int32             `json:"height"`
+		Locked   float64           `json:"locked"`
+		Unlocked float64           `json:"unlocked"`
+		Locks    []VaultLockResult `json:"locks"`
+	}
+	// WalletDBBucketResult models a bucket of the wallet database in the data from the walletdbstats command.
+	WalletDBBucketResult struct {
+		Path        string              `json:"path"`
+		Keys        int                 `json:"keys"`
+		Buckets     int                 `json:"buckets"`
+		KeyBytes    int64               `json:"keybytes"`
+		ValueBytes  int64               `json:"valuebytes"`
+		TotalBytes  int64               `json:"totalbytes"`
+		LargestKeys []WalletDBKeyResult `json:"largestkeys,omitempty"`
+	}
+	// WalletDBKeyResult models a key/value pair of a bucket in the data from the walletdbstats command.
+	WalletDBKeyResult struct {
+		Key   string `json:"key"`
+		Bytes int    `json:"bytes"`
+	}
+	// WalletSelfTestResult models the data from the walletselftest command, the outcome of the consistency checks of
+	// the wallet, which passed if none of them failed.
+	WalletSelfTestResult struct {
+		Passed bool                        `json:"passed"`
+		Checks []WalletSelfTestCheckResult `json:"checks"`
+	}
+	// WalletSelfTestCheckResult models a check in the data from the walletselftest command. Status is passed, failed or
+	// skipped, Checked is the number of items it checked, and Details are the problems found or why it was skipped.
+	WalletSelfTestCheckResult struct {
+		Name    string   `json:"name"`
+		Status  string   `json:"status"`
+		Checked int      `json:"checked"`
+		Details []string `json:"details,omitempty"`
+	}
+	// WatchSetResult models the data from the exportwatchset command, the output scripts of a wallet and its unspent
+	// outputs as of the block it is synced to, which a watcher uses to alert when funds of the wallet move.
+	WatchSetResult struct {
+		Network   string                `json:"network"`
+		Height    int32                 `json:"height"`
+		Hash      string                `json:"hash"`
+		Scripts   []string              `json:"scripts"`
+		Outpoints []WatchOutpointResult `json:"outpoints"`
+	}
+	// WatchOutpointResult models an unspent output in the data from the exportwatchset command.
+	WatchOutpointResult struct {
+		TxID   string  `json:"txid"`
+		Vout   uint32  `json:"vout"`
+		Amount float64 `json:"amount"`
+		Script string  `json:"script"`
+	}
 	// ValidateAddressWalletResult models the data returned by the wallet server validateaddress command.
 	ValidateAddressWalletResult struct {
 		IsValid      bool     `json:"isvalid"`
@@ -131,6 +628,16 @@ type (
 		Script       string   `json:"script,omitempty"`
 		SigsRequired int32    `json:"sigsrequired,omitempty"`
 	}
+	// WalletEventResult models an event of the notification journal of a wallet, sent in the walletevent
+	// notification. BlockHash is empty and Height is -1 for a transaction that is not mined.
+	WalletEventResult struct {
+		Sequence  uint64 `json:"sequence"`
+		Type      string `json:"type"`
+		Time      int64  `json:"time"`
+		BlockHash string `json:"blockhash,omitempty"`
+		Height    int32  `json:"height"`
+		TxID      string `json:"txid,omitempty"`
+	}
 	// GetBestBlockResult models the data from the getbestblock command.
 	GetBestBlockResult struct {
 		Hash   string `json:"hash"`
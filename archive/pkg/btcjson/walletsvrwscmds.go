@@ -73,6 +73,24 @@ func NewListAllTransactionsCmd(account *string) *ListAllTransactionsCmd {
 	}
 }
 
+// NotifyWalletEventsCmd defines the notifywalletevents JSON-RPC command. Events of the notification journal of the
+// wallet after SinceSequence, or after the connection of the block SinceBlock, are sent before new events. With
+// neither, only new events are sent.
+type NotifyWalletEventsCmd struct {
+	SinceSequence *uint64
+	SinceBlock    *string
+}
+
+// NewNotifyWalletEventsCmd returns a new instance which can be used to issue a notifywalletevents JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewNotifyWalletEventsCmd(sinceSequence *uint64, sinceBlock *string) *NotifyWalletEventsCmd {
+	return &NotifyWalletEventsCmd{
+		SinceSequence: sinceSequence,
+		SinceBlock:    sinceBlock,
+	}
+}
+
 // RecoverAddressesCmd defines the recoveraddresses JSON-RPC command.
 type RecoverAddressesCmd struct {
 	Account string
@@ -103,6 +121,7 @@ func init() {
 	MustRegisterCmd("getunconfirmedbalance", (*GetUnconfirmedBalanceCmd)(nil), flags)
 	MustRegisterCmd("listaddresstransactions", (*ListAddressTransactionsCmd)(nil), flags)
 	MustRegisterCmd("listalltransactions", (*ListAllTransactionsCmd)(nil), flags)
+	MustRegisterCmd("notifywalletevents", (*NotifyWalletEventsCmd)(nil), flags)
 	MustRegisterCmd("recoveraddresses", (*RecoverAddressesCmd)(nil), flags)
 	MustRegisterCmd("walletislocked", (*WalletIsLockedCmd)(nil), flags)
 }
@@ -160,6 +160,34 @@ func TestWalletSvrWsCmds(t *testing.T) {
 				Account: btcjson.String("acct"),
 			},
 		},
+		{
+			name: "notifywalletevents",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifywalletevents")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyWalletEventsCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notifywalletevents","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyWalletEventsCmd{
+				SinceSequence: nil,
+				SinceBlock:    nil,
+			},
+		},
+		{
+			name: "notifywalletevents optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifywalletevents", 12, "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyWalletEventsCmd(btcjson.Uint64(12), btcjson.String("123"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notifywalletevents","netparams":[12,"123"],"id":1}`,
+			unmarshalled: &btcjson.NotifyWalletEventsCmd{
+				SinceSequence: btcjson.Uint64(12),
+				SinceBlock:    btcjson.String("123"),
+			},
+		},
 		{
 			name: "recoveraddresses",
 			newCmd: func() (interface{}, error) {
@@ -10,6 +10,9 @@ const (
 	// NewTxNtfnMethod is the method used to notify that a wallet server has added a new transaction to the transaction
 	// store.
 	NewTxNtfnMethod = "newtx"
+	// WalletEventNtfnMethod is the method used to send the events of the notification journal of a wallet to a client
+	// that registered for them with notifywalletevents.
+	WalletEventNtfnMethod = "walletevent"
 )
 
 // AccountBalanceNtfn defines the accountbalance JSON-RPC notification.
@@ -65,6 +68,16 @@ func NewNewTxNtfn(account string, details ListTransactionsResult) *NewTxNtfn {
 		Details: details,
 	}
 }
+
+// WalletEventNtfn defines the walletevent JSON-RPC notification.
+type WalletEventNtfn struct {
+	Event WalletEventResult
+}
+
+// NewWalletEventNtfn returns a new instance which can be used to issue a walletevent JSON-RPC notification.
+func NewWalletEventNtfn(event WalletEventResult) *WalletEventNtfn {
+	return &WalletEventNtfn{Event: event}
+}
 func init() {
 	
 	// The commands in this file are only usable with a wallet server via websockets and are notifications.
@@ -73,4 +86,5 @@ func init() {
 	MustRegisterCmd(PodConnectedNtfnMethod, (*PodConnectedNtfn)(nil), flags)
 	MustRegisterCmd(WalletLockStateNtfnMethod, (*WalletLockStateNtfn)(nil), flags)
 	MustRegisterCmd(NewTxNtfnMethod, (*NewTxNtfn)(nil), flags)
+	MustRegisterCmd(WalletEventNtfnMethod, (*WalletEventNtfn)(nil), flags)
 }
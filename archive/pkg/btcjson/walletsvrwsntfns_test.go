@@ -78,7 +78,7 @@ func TestWalletSvrWsNtfns(t *testing.T) {
 					Address:         "1Address",
 					Category:        "send",
 					Amount:          1.5,
-					Fee:             *btcjson.Float64(0.0001),
+					Fee:             btcjson.Float64(0.0001),
 					Confirmations:   1,
 					TxID:            "456",
 					WalletConflicts: []string{},
@@ -99,7 +99,7 @@ func TestWalletSvrWsNtfns(t *testing.T) {
 					Address:         "1Address",
 					Category:        "send",
 					Amount:          1.5,
-					Fee:             *btcjson.Float64(0.0001),
+					Fee:             btcjson.Float64(0.0001),
 					Confirmations:   1,
 					TxID:            "456",
 					WalletConflicts: []string{},
@@ -111,6 +111,38 @@ func TestWalletSvrWsNtfns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "walletevent",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"walletevent",
+					`{"sequence":7,"type":"tx","time":12345678,"blockhash":"123","height":100,"txid":"456"}`,
+				)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewWalletEventNtfn(
+					btcjson.WalletEventResult{
+						Sequence:  7,
+						Type:      "tx",
+						Time:      12345678,
+						BlockHash: "123",
+						Height:    100,
+						TxID:      "456",
+					},
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletevent","netparams":[{"sequence":7,"type":"tx","time":12345678,"blockhash":"123","height":100,"txid":"456"}],"id":null}`,
+			unmarshalled: &btcjson.WalletEventNtfn{
+				Event: btcjson.WalletEventResult{
+					Sequence:  7,
+					Type:      "tx",
+					Time:      12345678,
+					BlockHash: "123",
+					Height:    100,
+					TxID:      "456",
+				},
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -175,3 +175,21 @@ var simNetGenesisBlock = wire.Block{
 	},
 	Transactions: []*wire.MsgTx{&genesisCoinbaseTx},
 }
+
+// sigNetGenesisBlock defines the genesis block of the block chain which serves as the public transaction ledger for the
+// signed test networks. It is the same for all of them, as they are told apart by the magic derived from their
+// challenge.
+var sigNetGenesisBlock = wire.Block{
+	Header: wire.BlockHeader{
+		Version:    2,
+		PrevBlock:  chainhash.Hash{}, // 0000000000000000000000000000000000000000000000000000000000000000
+		MerkleRoot: genesisMerkleRoot,
+		Timestamp:  time.Unix(0x6a0d6a00, 0),
+		Bits:       fork.SecondPowLimitBits,
+		Nonce:      0,
+	},
+	Transactions: []*wire.MsgTx{&genesisCoinbaseTx},
+}
+
+// sigNetGenesisHash is the hash of the first block in the block chain for the signed test networks.
+var sigNetGenesisHash = sigNetGenesisBlock.Header.BlockHash()
@@ -7,6 +7,7 @@ import (
 	"time"
 	
 	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/fork"
 	"github.com/p9c/pod/pkg/wire"
 )
 
@@ -85,6 +86,15 @@ var (
 	TestnetTargetTimespan = TestnetInterval * TestnetTargetTimePerBlock
 )
 
+const (
+	// AddressTypeLegacy is the name of the pay-to-pubkey-hash address type
+	AddressTypeLegacy = "legacy"
+	// AddressTypeP2SHSegwit is the name of the pay-to-witness-pubkey-hash nested in pay-to-script-hash address type
+	AddressTypeP2SHSegwit = "p2sh-segwit"
+	// AddressTypeBech32 is the name of the bech32 encoded pay-to-witness-pubkey-hash address type
+	AddressTypeBech32 = "bech32"
+)
+
 // Checkpoint identifies a known good point in the block chain. Using checkpoints allows a few optimizations for old
 // blocks during initial download and also prevents forks from old blocks. Each checkpoint is selected based upon
 // several factors. See the documentation for blockchain.IsCheckpointCandidate for details on the selection criteria.
@@ -93,6 +103,14 @@ type Checkpoint struct {
 	Hash   *chainhash.Hash
 }
 
+// ScriptFlagChange is a network upgrade that switches on script verification rules for the blocks from a height
+// onwards. Flags are the names of the rules, as given by the Names method of txscript.ScriptFlags, which apply along
+// with those of the upgrades at lower heights.
+type ScriptFlagChange struct {
+	Height int32
+	Flags  []string
+}
+
 // DNSSeed identifies a DNS seed.
 type DNSSeed struct {
 	// Host defines the hostname of the seed.
@@ -184,6 +202,15 @@ type Params struct {
 	GenerateSupported bool
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints []Checkpoint
+	// AssumeValid is the hash of the block the scripts of whose ancestors are not verified by default, which is moved
+	// up for each release to a block deep enough in the chain that it is not reorganized. It is nil when scripts are
+	// always verified.
+	AssumeValid *chainhash.Hash
+	// ScriptFlagSchedule is the network upgrades that change the rules scripts in blocks are verified with.
+	ScriptFlagSchedule []ScriptFlagChange
+	// AlgoSchedule is the changes to the proof of work algorithms of the hard forks at later heights, which add
+	// algorithms from the registry of the fork package or remove them.
+	AlgoSchedule []fork.AlgoChange
 	// These fields are related to voting on consensus rule changes as defined by BIP0009.
 	//
 	// RuleChangeActivationThreshold is the number of blocks in a threshold state retarget window for which a positive
@@ -196,8 +223,11 @@ type Params struct {
 	// Deployments [DefinedDeployments]ConsensusDeployment
 	// Mempool parameters
 	RelayNonStdTxs bool
-	// // Human-readable part for Bech32 encoded segwit addresses, as defined in BIP 173.
-	// Bech32HRPSegwit string
+	// AddressTypes are the address types wallets may hand out on the network, the first of them being the default.
+	// Types that are not listed have not been activated on the network yet.
+	AddressTypes []string
+	// Bech32HRPSegwit is the human-readable part for Bech32 encoded segwit addresses, as defined in BIP 173.
+	Bech32HRPSegwit string
 	// Address encoding magics
 	PubKeyHashAddrID byte // First byte of a P2PKH address
 	ScriptHashAddrID byte // First byte of a P2SH address
@@ -221,4 +251,7 @@ type Params struct {
 	// PowLimit defines the highest allowed proof of work value for a scrypt block as a uint256.
 	ScryptPowLimit     *big.Int
 	ScryptPowLimitBits uint32
+	// SignetChallenge is the output script that the signature of the producer of each block, carried in its coinbase,
+	// must satisfy on a signed test network. It is nil on networks that anyone may mine.
+	SignetChallenge []byte
 }
@@ -51,6 +51,15 @@ var MainNetParams = Params{
 		// {, newHashFromStr("")},
 		// {200069, newHashFromStr("000000000000044e641986c8ee672460e853a11b352869cb8a4a8ba0b3f3e6dc")},
 	},
+	// There is no default assumed valid block yet. One may only be set to a block a release has itself verified the
+	// scripts of the chain up to, with the script rules and proof of work algorithms of the hard forks it is released
+	// with, and deep enough that it is not reorganized. As with the checkpoints, none has been chosen that way, so
+	// every script is verified unless the assumevalid option names a block.
+	AssumeValid: nil,
+	// Pay to script hash has been checked since the genesis block, which came after it was activated on Bitcoin.
+	ScriptFlagSchedule: []ScriptFlagChange{
+		{Height: 0, Flags: []string{"P2SH"}},
+	},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -76,9 +85,11 @@ var MainNetParams = Params{
 	// },
 	// Mempool parameters
 	RelayNonStdTxs: false,
-	// // Human-readable part for Bech32 encoded segwit addresses, as defined in
-	// // BIP 173.
-	// Bech32HRPSegwit: "p9", // always bc for main net
+	// Address types wallets may hand out
+	AddressTypes: []string{AddressTypeLegacy},
+	// Human-readable part for Bech32 encoded segwit addresses, as defined in
+	// BIP 173.
+	Bech32HRPSegwit: "p9",
 	// Address encoding magics
 	PubKeyHashAddrID: 83,  // 0x00, // starts with 1
 	ScriptHashAddrID: 9,   // 0x05, // starts with 3
@@ -29,6 +29,10 @@ var RegressionTestParams = Params{
 	GenerateSupported:        true,
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
+	// Pay to script hash has been checked since the genesis block, which came after it was activated on Bitcoin.
+	ScriptFlagSchedule: []ScriptFlagChange{
+		{Height: 0, Flags: []string{"P2SH"}},
+	},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -54,9 +58,11 @@ var RegressionTestParams = Params{
 	// },
 	// Mempool parameters
 	RelayNonStdTxs: true,
+	// Address types wallets may hand out
+	AddressTypes: []string{AddressTypeLegacy},
 	// Human-readable part for Bech32 encoded segwit addresses, as defined in
-	// // BIP 173.
-	// Bech32HRPSegwit: "bcrt", // always bcrt for reg test net
+	// BIP 173.
+	Bech32HRPSegwit: "r9",
 	// Address encoding magics
 	PubKeyHashAddrID: 0x00,
 	ScriptHashAddrID: 0x05,
@@ -0,0 +1,94 @@
+package chaincfg
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/fork"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// sigNetParams is the template of the parameters of the signed test networks, on which blocks are only valid with a
+// signature of the block producers satisfying the challenge of the network in their coinbase. As random miners cannot
+// produce blocks, the chain of such a network is stable and it can serve as a public test network that is never reset.
+// The parameters of a network are made from it by SigNetParams.
+var sigNetParams = Params{
+	Name:        "signet",
+	DefaultPort: "51047",
+	DNSSeeds:    []DNSSeed{},
+	// Chain parameters
+	GenesisBlock:             &sigNetGenesisBlock,
+	GenesisHash:              &sigNetGenesisHash,
+	PowLimit:                 &fork.SecondPowLimit,
+	PowLimitBits:             fork.SecondPowLimitBits,
+	CoinbaseMaturity:         9,
+	SubsidyReductionInterval: 250000,
+	TargetTimespan:           TestnetTargetTimespan,
+	TargetTimePerBlock:       TestnetTargetTimePerBlock,
+	RetargetAdjustmentFactor: 2,
+	ReduceMinDifficulty:      false,
+	MinDiffReductionTime:     0,
+	GenerateSupported:        true,
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: []Checkpoint{},
+	// Pay to script hash has been checked since the genesis block.
+	ScriptFlagSchedule: []ScriptFlagChange{
+		{Height: 0, Flags: []string{"P2SH"}},
+	},
+	// Consensus rule change deployments.
+	//
+	// The miner confirmation window is defined as:
+	//   target proof of work timespan / target proof of work spacing
+	RuleChangeActivationThreshold: 2,
+	MinerConfirmationWindow:       2016,
+	// Mempool parameters
+	RelayNonStdTxs: true,
+	// Address types wallets may hand out
+	AddressTypes: []string{AddressTypeLegacy},
+	// Human-readable part for Bech32 encoded segwit addresses, as defined in BIP 173.
+	Bech32HRPSegwit: "t9",
+	// Address encoding magics, which are those of the test network
+	PubKeyHashAddrID: 18,  // starts with m or n
+	ScriptHashAddrID: 188, // starts with 2
+	PrivateKeyID:     239, // starts with 9 (uncompressed) or c (compressed)
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	// BIP44 coin type used in the hierarchical deterministic path for address generation.
+	HDCoinType: 1,
+	// Parallelcoin specific difficulty adjustment parameters
+	Interval:                TestnetInterval,
+	AveragingInterval:       TestnetAveragingInterval,
+	AveragingTargetTimespan: TestnetAveragingTargetTimespan,
+	MaxAdjustDown:           TestnetMaxAdjustDown,
+	MaxAdjustUp:             TestnetMaxAdjustUp,
+	TargetTimespanAdjDown:   TestnetAveragingTargetTimespan * (TestnetInterval + TestnetMaxAdjustDown) / TestnetInterval,
+	MinActualTimespan:       TestnetAveragingTargetTimespan * (TestnetInterval - TestnetMaxAdjustUp) / TestnetInterval,
+	MaxActualTimespan:       TestnetAveragingTargetTimespan * (TestnetInterval + TestnetMaxAdjustDown) / TestnetInterval,
+	ScryptPowLimit:          &scryptPowLimit,
+	ScryptPowLimitBits:      ScryptPowLimitBits,
+	RPCClientPort:           "51048",
+	WalletRPCServerPort:     "51046",
+}
+
+// SigNetMagic returns the magic of the signed test network with the challenge, which is the first 4 bytes of its double
+// sha256 hash, so that nodes of networks with different signers do not connect to each other.
+func SigNetMagic(challenge []byte) wire.BitcoinNet {
+	return wire.BitcoinNet(binary.LittleEndian.Uint32(chainhash.DoubleHashB(challenge)[:4]))
+}
+
+// SigNetParams returns the parameters of the signed test network whose blocks must be signed by the producers
+// satisfying the challenge, and registers them if they have not been.
+func SigNetParams(challenge []byte) (params *Params, e error) {
+	if len(challenge) == 0 {
+		return nil, errors.New("a signed test network needs the challenge that its block signatures must satisfy")
+	}
+	p := sigNetParams
+	p.Net = SigNetMagic(challenge)
+	p.SignetChallenge = challenge
+	if e = Register(&p); e != nil && e != ErrDuplicateNet {
+		return
+	}
+	return &p, nil
+}
@@ -34,6 +34,10 @@ var SimNetParams = Params{
 	GenerateSupported:        true,
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
+	// Pay to script hash has been checked since the genesis block, which came after it was activated on Bitcoin.
+	ScriptFlagSchedule: []ScriptFlagChange{
+		{Height: 0, Flags: []string{"P2SH"}},
+	},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -59,9 +63,11 @@ var SimNetParams = Params{
 	// },
 	// Mempool parameters
 	RelayNonStdTxs: true,
-	// // Human-readable part for Bech32 encoded segwit addresses, as defined in
-	// // BIP 173.
-	// Bech32HRPSegwit: "sb", // always sb for sim net
+	// Address types wallets may hand out
+	AddressTypes: []string{AddressTypeLegacy},
+	// Human-readable part for Bech32 encoded segwit addresses, as defined in
+	// BIP 173.
+	Bech32HRPSegwit: "s9",
 	// Address encoding magics
 	PubKeyHashAddrID: 0x3f, // starts with S
 	ScriptHashAddrID: 0x7b, // starts with s
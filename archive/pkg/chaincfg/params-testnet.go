@@ -34,6 +34,12 @@ var TestNet3Params = Params{
 	Checkpoints: []Checkpoint{
 		// {546, newHashFromStr("000000002a936ca763904c3c35fce2f3556c559c0214345d31b1bcebf76acb70")},
 	},
+	// As on mainnet, there is no default assumed valid block until a release has verified the chain up to one.
+	AssumeValid: nil,
+	// Pay to script hash has been checked since the genesis block, which came after it was activated on Bitcoin.
+	ScriptFlagSchedule: []ScriptFlagChange{
+		{Height: 0, Flags: []string{"P2SH"}},
+	},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -59,8 +65,10 @@ var TestNet3Params = Params{
 	// },
 	// Mempool parameters
 	RelayNonStdTxs: true,
-	// // Human-readable part for Bech32 encoded segwit addresses, as defined in BIP 173.
-	// Bech32HRPSegwit: "t9", // always tb for test net
+	// Address types wallets may hand out
+	AddressTypes: []string{AddressTypeLegacy},
+	// Human-readable part for Bech32 encoded segwit addresses, as defined in BIP 173.
+	Bech32HRPSegwit: "t9",
 	// Address encoding magics
 	PubKeyHashAddrID: 18,  // starts with m or n
 	ScriptHashAddrID: 188, // starts with 2
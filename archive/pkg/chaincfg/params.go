@@ -24,9 +24,9 @@ func Register(params *Params) (e error) {
 	pubKeyHashAddrIDs[params.PubKeyHashAddrID] = struct{}{}
 	scriptHashAddrIDs[params.ScriptHashAddrID] = struct{}{}
 	hdPrivToPubKeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID[:]
-	// // A valid Bech32 encoded segwit address always has as prefix the human-readable part for the given net followed by
-	// // '1'.
-	// bech32SegwitPrefixes[params.Bech32HRPSegwit+"1"] = struct{}{}
+	// A valid Bech32 encoded segwit address always has as prefix the human-readable part for the given net followed by
+	// '1'.
+	bech32SegwitPrefixes[params.Bech32HRPSegwit+"1"] = struct{}{}
 	return nil
 }
 
@@ -64,6 +64,16 @@ func IsBech32SegwitPrefix(prefix string) bool {
 	return ok
 }
 
+// AddressTypeActive returns whether wallets may hand out addresses of the named type on the network.
+func (p *Params) AddressTypeActive(addressType string) bool {
+	for _, t := range p.AddressTypes {
+		if t == addressType {
+			return true
+		}
+	}
+	return false
+}
+
 // HDPrivateKeyToPublicKeyID accepts a private hierarchical deterministic extended key id and returns the associated
 // public key id. When the provided id is not registered, the ErrUnknownHDKeyID error will be returned.
 func HDPrivateKeyToPublicKeyID(id []byte) ([]byte, error) {
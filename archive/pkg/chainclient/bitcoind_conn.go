@@ -61,6 +61,7 @@ func NewBitcoindConn(
 		DisableConnectOnNew:  true,
 		TLS:                  false,
 		HTTPPostMode:         true,
+		Params:               chainParams,
 	}
 	client, e := rpcclient.New(clientCfg, nil, qu.T())
 	if e != nil {
@@ -84,6 +84,11 @@ type (
 		TxRecord *wtxmgr.TxRecord
 		Block    *wtxmgr.BlockMeta // nil if unmined
 	}
+	// TxExpired is a notification that an unmined transaction was evicted from the mempool of the chain server
+	// because it was not mined in time.
+	TxExpired struct {
+		Hash chainhash.Hash
+	}
 	// RescanProgress is a notification describing the current status of an in-progress rescan.
 	RescanProgress struct {
 		Hash   *chainhash.Hash
@@ -14,6 +14,7 @@ import (
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/gcs"
 	"github.com/p9c/pod/pkg/gcs/builder"
+	"github.com/p9c/pod/pkg/netproxy"
 	"github.com/p9c/pod/pkg/rpcclient"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/waddrmgr"
@@ -46,6 +47,7 @@ func NewRPCClient(
 	connect, user, pass string,
 	certs []byte,
 	tls bool,
+	proxy netproxy.Config,
 	reconnectAttempts int,
 	quit qu.C,
 ) (*RPCClient, error) {
@@ -63,6 +65,11 @@ func NewRPCClient(
 			DisableAutoReconnect: false,
 			DisableConnectOnNew:  true,
 			TLS:                  tls,
+			Proxy:                proxy.Addr,
+			ProxyType:            proxy.Kind,
+			ProxyUser:            proxy.User,
+			ProxyPass:            proxy.Pass,
+			Params:               chainParams,
 		},
 		chainParams:         chainParams,
 		reconnectAttempts:   reconnectAttempts,
@@ -72,13 +79,16 @@ func NewRPCClient(
 		quit:                quit,
 	}
 	ntfnCallbacks := &rpcclient.NotificationHandlers{
-		OnClientConnected:   client.onClientConnect,
-		OnBlockConnected:    client.onBlockConnected,
-		OnBlockDisconnected: client.onBlockDisconnected,
-		OnRecvTx:            client.onRecvTx,
-		OnRedeemingTx:       client.onRedeemingTx,
-		OnRescanFinished:    client.onRescanFinished,
-		OnRescanProgress:    client.onRescanProgress,
+		OnClientConnected:        client.onClientConnect,
+		OnBlockConnected:         client.onBlockConnected,
+		OnBlockDisconnected:      client.onBlockDisconnected,
+		OnFilteredBlockConnected: client.onFilteredBlockConnected,
+		OnRecvTx:                 client.onRecvTx,
+		OnRedeemingTx:            client.onRedeemingTx,
+		OnRelevantTxAccepted:     client.onRelevantTxAccepted,
+		OnRescanFinished:         client.onRescanFinished,
+		OnRescanProgress:         client.onRescanProgress,
+		OnTxExpired:              client.onTxExpired,
 	}
 	W.Ln("*actually* creating rpc client")
 	rpcClient, e := rpcclient.New(client.connConfig, ntfnCallbacks, client.quit)
@@ -343,10 +353,57 @@ func (c *RPCClient) onRecvTx(tx *util.Tx, block *btcjson.BlockDetails) {
 	case <-c.quit.Wait():
 	}
 }
+// onFilteredBlockConnected passes on the transactions of a connected block that matched the transaction filter loaded
+// with LoadTxFilter, such as those paying to watched scripts. Blocks without matching transactions are left to
+// onBlockConnected.
+func (c *RPCClient) onFilteredBlockConnected(height int32, header *wire.BlockHeader, txs []*util.Tx) {
+	if len(txs) == 0 {
+		return
+	}
+	blk := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{
+			Hash:   header.BlockHash(),
+			Height: height,
+		},
+		Time: header.Timestamp,
+	}
+	recs := make([]*wtxmgr.TxRecord, 0, len(txs))
+	for _, tx := range txs {
+		rec, e := wtxmgr.NewTxRecordFromMsgTx(tx.MsgTx(), header.Timestamp)
+		if e != nil {
+			E.Ln("cannot create transaction record for filtered tx:", e)
+			return
+		}
+		recs = append(recs, rec)
+	}
+	select {
+	case c.enqueueNotification <- FilteredBlockConnected{Block: blk, RelevantTxs: recs}:
+	case <-c.quit.Wait():
+	}
+}
+
+// onRelevantTxAccepted passes on an unmined transaction that matched the transaction filter loaded with LoadTxFilter.
+func (c *RPCClient) onRelevantTxAccepted(transaction []byte) {
+	rec, e := wtxmgr.NewTxRecord(transaction, time.Now())
+	if e != nil {
+		E.Ln("cannot create transaction record for relevant tx:", e)
+		return
+	}
+	select {
+	case c.enqueueNotification <- RelevantTx{rec, nil}:
+	case <-c.quit.Wait():
+	}
+}
 func (c *RPCClient) onRedeemingTx(tx *util.Tx, block *btcjson.BlockDetails) {
 	// Handled exactly like recvtx notifications.
 	c.onRecvTx(tx, block)
 }
+func (c *RPCClient) onTxExpired(hash *chainhash.Hash) {
+	select {
+	case c.enqueueNotification <- TxExpired{*hash}:
+	case <-c.quit.Wait():
+	}
+}
 func (c *RPCClient) onRescanProgress(hash *chainhash.Hash, height int32, blkTime time.Time) {
 	select {
 	case c.enqueueNotification <- &RescanProgress{hash, height, blkTime}:
@@ -0,0 +1,78 @@
+package chainrpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/p9c/qu"
+)
+
+// ArchivalLimiter paces the serving of historical blocks to peers that are syncing from the node, so that an archival
+// node on a home connection does not spend all of its upload on them. It is a budget of bytes per second shared by all
+// peers, which blocks near the tip are not held to, so new blocks are relayed as fast as before while the history is
+// being served.
+type ArchivalLimiter struct {
+	// Rate is the number of bytes of historical blocks that may be served each second, and Depth how far below the tip
+	// a block must be to be historical.
+	Rate  int
+	Depth int32
+	mtx   sync.Mutex
+	// budget is what may be sent before having to wait, which goes negative as blocks are reserved ahead of the time
+	// they may be sent, so peers waiting on the limiter are served in turn.
+	budget float64
+	last   time.Time
+}
+
+// NewArchivalLimiter returns a limiter serving rate bytes of blocks at least depth blocks below the tip each second, or
+// nil if rate is not positive, as serving is not limited then.
+func NewArchivalLimiter(rate int, depth int32) *ArchivalLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &ArchivalLimiter{Rate: rate, Depth: depth}
+}
+
+// Historical returns whether a block at the height is historical when the best block is at the tip height.
+func (l *ArchivalLimiter) Historical(height, tip int32) bool {
+	return tip-height >= l.Depth
+}
+
+// reserve takes the size of a block from the budget and returns how long to wait before sending it. The budget is
+// refilled at the rate since the last reservation, up to one second of it, so a limiter that was idle allows a short
+// burst.
+func (l *ArchivalLimiter) reserve(now time.Time, size int) (wait time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	rate := float64(l.Rate)
+	if l.last.IsZero() {
+		l.budget = rate
+	} else if now.After(l.last) {
+		l.budget += now.Sub(l.last).Seconds() * rate
+		if l.budget > rate {
+			l.budget = rate
+		}
+	}
+	l.last = now
+	l.budget -= float64(size)
+	if l.budget < 0 {
+		wait = time.Duration(-l.budget / rate * float64(time.Second))
+	}
+	return
+}
+
+// Wait blocks until a historical block of the size may be sent, and returns false if quit is closed first.
+func (l *ArchivalLimiter) Wait(size int, quit qu.C) bool {
+	wait := l.reserve(time.Now(), size)
+	if wait <= 0 {
+		return true
+	}
+	T.F("waiting %v to serve a historical block of %d bytes", wait, size)
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-quit.Wait():
+		return false
+	}
+}
@@ -0,0 +1,34 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+)
+
+// TestArchivalLimiter ensures the limiter allows a burst of one second of its rate, then makes each block wait for the
+// budget it takes, with blocks reserved in turn waiting behind each other, and that it refills while idle.
+func TestArchivalLimiter(t *testing.T) {
+	if NewArchivalLimiter(0, 144) != nil {
+		t.Fatal("a limiter was made with no rate")
+	}
+	l := NewArchivalLimiter(1000, 144)
+	if !l.Historical(100, 244) || l.Historical(101, 244) {
+		t.Error("blocks were taken for history at the wrong depth")
+	}
+	start := time.Unix(1600000000, 0)
+	if wait := l.reserve(start, 1000); wait != 0 {
+		t.Fatalf("the first second of the rate waited %v", wait)
+	}
+	if wait := l.reserve(start, 500); wait != 500*time.Millisecond {
+		t.Fatalf("a block past the budget waited %v, want 500ms", wait)
+	}
+	if wait := l.reserve(start.Add(100*time.Millisecond), 500); wait != 900*time.Millisecond {
+		t.Fatalf("a block reserved behind another waited %v, want 900ms", wait)
+	}
+	if wait := l.reserve(start.Add(time.Hour), 1000); wait != 0 {
+		t.Fatalf("a block after the limiter was idle waited %v", wait)
+	}
+	if wait := l.reserve(start.Add(time.Hour), 1); wait <= 0 {
+		t.Fatal("the budget refilled past one second of the rate")
+	}
+}
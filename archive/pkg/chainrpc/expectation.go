@@ -0,0 +1,39 @@
+package chainrpc
+
+import (
+	"sync"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// MiningExpectation holds what the miners taking work from the mining controller can expect to find, as last worked out
+// by the controller, for getminingexpectation.
+type MiningExpectation struct {
+	mtx    sync.Mutex
+	result btcjson.GetMiningExpectationResult
+}
+
+// Set stores the expectation worked out by the controller.
+func (m *MiningExpectation) Set(result btcjson.GetMiningExpectationResult) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.result = result
+}
+
+// Result returns the expectation as the result of getminingexpectation, which is empty until the controller has
+// worked one out.
+func (m *MiningExpectation) Result() (result btcjson.GetMiningExpectationResult) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	result = m.result
+	result.Windows = append([]btcjson.MiningWindowResult{}, m.result.Windows...)
+	result.Algos = append([]btcjson.MiningAlgoExpectationResult{}, m.result.Algos...)
+	return
+}
+
+// HandleGetMiningExpectation implements the getminingexpectation command.
+func HandleGetMiningExpectation(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	return s.Cfg.MiningExpectation.Result(), nil
+}
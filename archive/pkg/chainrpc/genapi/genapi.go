@@ -31,6 +31,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddNodeCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "calculatepackagefee",
+		Handler: "CalculatePackageFee",
+		Cmd:     "*btcjson.CalculatePackageFeeCmd",
+		ResType: "btcjson.CalculatePackageFeeResult",
+	},
 	{
 		Method:  "createrawtransaction",
 		Handler: "CreateRawTransaction",
@@ -49,12 +55,30 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.DecodeScriptCmd",
 		ResType: "btcjson.DecodeScriptResult",
 	},
+	{
+		Method:  "deriveaddresses",
+		Handler: "DeriveAddresses",
+		Cmd:     "*btcjson.DeriveAddressesCmd",
+		ResType: "[]string",
+	},
 	{
 		Method:  "estimatefee",
 		Handler: "EstimateFee",
 		Cmd:     "*btcjson.EstimateFeeCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "estimatesmartfee",
+		Handler: "EstimateSmartFee",
+		Cmd:     "*btcjson.EstimateSmartFeeCmd",
+		ResType: "btcjson.EstimateSmartFeeResult",
+	},
+	{
+		Method:  "estimatorstats",
+		Handler: "EstimatorStats",
+		Cmd:     "*btcjson.EstimatorStatsCmd",
+		ResType: "btcjson.EstimatorStatsResult",
+	},
 	{
 		Method:  "generate",
 		Handler: "Generate",
@@ -109,12 +133,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetBlockHeaderCmd",
 		ResType: "btcjson.GetBlockHeaderVerboseResult",
 	},
+	{
+		Method:  "getblockpropagation",
+		Handler: "GetBlockPropagation",
+		Cmd:     "*btcjson.GetBlockPropagationCmd",
+		ResType: "btcjson.GetBlockPropagationResult",
+	},
 	{
 		Method:  "getblocktemplate",
 		Handler: "GetBlockTemplate",
 		Cmd:     "*btcjson.GetBlockTemplateCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getcachestats",
+		Handler: "GetCacheStats",
+		Cmd:     "*None",
+		ResType: "btcjson.GetCacheStatsResult",
+	},
 	{
 		Method:  "getcfilter",
 		Handler: "GetCFilter",
@@ -139,12 +175,30 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "string",
 	},
+	{
+		Method:  "getdescriptorinfo",
+		Handler: "GetDescriptorInfo",
+		Cmd:     "*btcjson.GetDescriptorInfoCmd",
+		ResType: "btcjson.GetDescriptorInfoResult",
+	},
 	{
 		Method:  "getdifficulty",
 		Handler: "GetDifficulty",
 		Cmd:     "*btcjson.GetDifficultyCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "getdnsaudit",
+		Handler: "GetDNSAudit",
+		Cmd:     "*None",
+		ResType: "btcjson.GetDNSAuditResult",
+	},
+	{
+		Method:  "getfeatures",
+		Handler: "GetFeatures",
+		Cmd:     "*None",
+		ResType: "[]btcjson.FeatureResult",
+	},
 	{
 		Method:  "getgenerate",
 		Handler: "GetGenerate",
@@ -163,6 +217,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetHeadersCmd",
 		ResType: "[]string",
 	},
+	{
+		Method:  "getindexinfo",
+		Handler: "GetIndexInfo",
+		Cmd:     "*btcjson.GetIndexInfoCmd",
+		ResType: "map[string]btcjson.GetIndexInfoResult",
+	},
 	{
 		Method:  "getinfo",
 		Handler: "GetInfo",
@@ -175,6 +235,24 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "btcjson.GetMempoolInfoResult",
 	},
+	{
+		Method:  "getminingaddresses",
+		Handler: "GetMiningAddresses",
+		Cmd:     "*None",
+		ResType: "btcjson.GetMiningAddressesResult",
+	},
+	{
+		Method:  "getminingexpectation",
+		Handler: "GetMiningExpectation",
+		Cmd:     "*None",
+		ResType: "btcjson.GetMiningExpectationResult",
+	},
+	{
+		Method:  "getminingtemplate",
+		Handler: "GetMiningTemplate",
+		Cmd:     "*btcjson.GetMiningTemplateCmd",
+		ResType: "btcjson.GetMiningTemplateResult",
+	},
 	{
 		Method:  "getmininginfo",
 		Handler: "GetMiningInfo",
@@ -193,6 +271,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetNetworkHashPSCmd",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getnetworkinfo",
+		Handler: "GetNetworkInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetNetworkInfoResult",
+	},
+	{
+		Method:  "getnodeaddresses",
+		Handler: "GetNodeAddresses",
+		Cmd:     "*btcjson.GetNodeAddressesCmd",
+		ResType: "[]btcjson.NodeAddressResult",
+	},
+	{
+		Method:  "getnotificationinfo",
+		Handler: "GetNotificationInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetNotificationInfoResult",
+	},
+	{
+		Method:  "getorphanblocks",
+		Handler: "GetOrphanBlocks",
+		Cmd:     "*None",
+		ResType: "btcjson.GetOrphanBlocksResult",
+	},
+	{
+		Method:  "getorphantxs",
+		Handler: "GetOrphanTxs",
+		Cmd:     "*None",
+		ResType: "btcjson.GetOrphanTxsResult",
+	},
 	{
 		Method:  "getpeerinfo",
 		Handler: "GetPeerInfo",
@@ -211,12 +319,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetRawTransactionCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getscriptflags",
+		Handler: "GetScriptFlags",
+		Cmd:     "*btcjson.GetScriptFlagsCmd",
+		ResType: "btcjson.GetScriptFlagsResult",
+	},
+	{
+		Method:  "gettimestampinfo",
+		Handler: "GetTimestampInfo",
+		Cmd:     "*btcjson.GetTimestampInfoCmd",
+		ResType: "btcjson.GetTimestampInfoResult",
+	},
 	{
 		Method:  "gettxout",
 		Handler: "GetTxOut",
 		Cmd:     "*btcjson.GetTxOutCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getvalidationtrace",
+		Handler: "GetValidationTrace",
+		Cmd:     "*btcjson.GetValidationTraceCmd",
+		ResType: "[]btcjson.GetValidationTraceResult",
+	},
+	{
+		Method:  "getworksource",
+		Handler: "GetWorkSource",
+		Cmd:     "*None",
+		ResType: "btcjson.GetWorkSourceResult",
+	},
 	{
 		Method:  "help",
 		Handler: "Help",
@@ -235,6 +367,12 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "None",
 	},
+	{
+		Method:  "prioritisetransaction",
+		Handler: "PrioritiseTransaction",
+		Cmd:     "*btcjson.PrioritiseTransactionCmd",
+		ResType: "bool",
+	},
 	{
 		Method:  "searchrawtransactions",
 		Handler: "SearchRawTransactions",
@@ -247,12 +385,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendRawTransactionCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "selftest",
+		Handler: "SelfTest",
+		Cmd:     "*None",
+		ResType: "btcjson.SelfTestResult",
+	},
+	{
+		Method:  "setconfig",
+		Handler: "SetConfig",
+		Cmd:     "*btcjson.SetConfigCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "setfeature",
+		Handler: "SetFeature",
+		Cmd:     "*btcjson.SetFeatureCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "setgenerate",
 		Handler: "SetGenerate",
 		Cmd:     "*btcjson.SetGenerateCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "setminingaddresses",
+		Handler: "SetMiningAddresses",
+		Cmd:     "*btcjson.SetMiningAddressesCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "stop",
 		Handler: "Stop",
@@ -277,6 +439,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SubmitBlockCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "tracemempoolaccept",
+		Handler: "TraceMempoolAccept",
+		Cmd:     "*btcjson.TraceMempoolAcceptCmd",
+		ResType: "btcjson.TraceMempoolAcceptResult",
+	},
 	{
 		Method:  "uptime",
 		Handler: "Uptime",
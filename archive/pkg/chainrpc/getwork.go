@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"time"
 
 	"github.com/p9c/pod/pkg/bits"
@@ -68,7 +67,7 @@ func BigToLEUint256(n *big.Int) [Uint256Size]byte {
 // HandleGetWork handles the getwork call
 func HandleGetWork(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	c := cmd.(*btcjson.GetWorkCmd)
-	if len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if s.StateCfg.ActiveMiningAddrs.Len() == 0 {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInternal.Code,
 			Message: "No payment addresses specified via --miningaddr",
@@ -96,11 +95,16 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan qu.C) (interface{}, err
 	if c.Data != nil {
 		return HandleGetWorkSubmission(s, *c.Data)
 	}
-	// Choose a payment address at random.
-	rand.Seed(time.Now().UnixNano())
-	payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.StateCfg.ActiveMiningAddrs))]
 	lastTxUpdate := s.GBTWorkState.LastTxUpdate
 	latestHash := &s.Cfg.Chain.BestSnapshot().Hash
+	// Choose the payment address of the block according to the rotation policy.
+	payToAddr := s.StateCfg.ActiveMiningAddrs.ForBlock(latestHash)
+	if payToAddr == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: "No payment addresses specified via --miningaddr",
+		}
+	}
 	generator := s.Cfg.Generator
 	if state.Template == nil {
 		var e error
@@ -6,11 +6,13 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/p9c/pod/pkg/addrmgr"
 	"github.com/p9c/pod/pkg/amt"
 	block2 "github.com/p9c/pod/pkg/block"
 	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/fork"
 	"github.com/p9c/log"
+	"math"
 	"math/big"
 	"net"
 	"strconv"
@@ -21,14 +23,21 @@ import (
 	
 	"github.com/p9c/pod/pkg/blockchain"
 	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
 	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/connmgr"
 	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/descriptor"
 	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/features"
 	"github.com/p9c/interrupt"
 	"github.com/p9c/pod/pkg/mempool"
+	"github.com/p9c/pod/pkg/netsync"
 	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/wire"
+	"github.com/p9c/pod/version"
 )
 
 // HandleAddNode handles addnode commands.
@@ -84,6 +93,83 @@ func HandleAskWallet(
 	return nil, ErrRPCNoWallet
 }
 
+// HandleCalculatePackageFee implements the calculatepackagefee command. The package is the transactions asked for and
+// all of their ancestors in the mempool, and the child is a transaction spending from it that pays for the whole
+// package to reach the target fee rate, as is done to speed up the confirmation of a transaction paying too little.
+func HandleCalculatePackageFee(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.CalculatePackageFeeCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("calculatepackagefee")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if len(c.TxIDs) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "no transactions given",
+		}
+	}
+	hashes := make([]*chainhash.Hash, len(c.TxIDs))
+	for i := range c.TxIDs {
+		var e error
+		if hashes[i], e = chainhash.NewHashFromStr(c.TxIDs[i]); E.Chk(e) {
+			return nil, DecodeHexError(c.TxIDs[i])
+		}
+	}
+	targetFeeRate, e := amt.NewAmount(c.TargetFeeRate)
+	if e != nil || targetFeeRate <= 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "the target fee rate must be a positive amount in DUO per kB",
+		}
+	}
+	// Unless told otherwise the child spends one output of the package to a single pay to pubkey hash output.
+	childVSize := int64(txsizes.EstimateSerializeSize(1, nil, true))
+	if c.ChildVSize != nil {
+		if childVSize = *c.ChildVSize; childVSize <= 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "the size of the child must be positive",
+			}
+		}
+	}
+	p, e := s.Cfg.TxMemPool.PackageFee(hashes)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoTxInfo,
+			Message: e.Error(),
+		}
+	}
+	childFee := p.ChildFee(childVSize, int64(targetFeeRate))
+	result := &btcjson.CalculatePackageFeeResult{
+		TxIDs:         make([]string, len(p.Txs)),
+		Ancestors:     make([]string, len(p.Ancestors)),
+		VSize:         p.VSize,
+		Fee:           amt.Amount(p.Fee).ToDUO(),
+		FeeRate:       amt.Amount(p.FeeRate()).ToDUO(),
+		TargetFeeRate: targetFeeRate.ToDUO(),
+		ChildVSize:    childVSize,
+		ChildFee:      amt.Amount(childFee).ToDUO(),
+		ChildFeeRate:  amt.Amount(childFee * 1000 / childVSize).ToDUO(),
+	}
+	for i := range p.Txs {
+		result.TxIDs[i] = p.Txs[i].String()
+	}
+	for i := range p.Ancestors {
+		result.Ancestors[i] = p.Ancestors[i].String()
+	}
+	return result, nil
+}
+
 // HandleCreateRawTransaction handles createrawtransaction commands.
 func HandleCreateRawTransaction(
 	s *Server,
@@ -321,6 +407,92 @@ func HandleDecodeScript(
 	return reply, nil
 }
 
+// maxDeriveAddresses is the most indexes deriveaddresses derives addresses for in one request.
+const maxDeriveAddresses = 10000
+
+// HandleDeriveAddresses handles deriveaddresses commands.
+func HandleDeriveAddresses(
+	s *Server,
+	cmd interface{},
+	closeChan qu.C,
+) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.DeriveAddressesCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("deriveaddresses")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	// the checksum is required so that a mistyped descriptor is not mistaken for another one
+	if _, _, e = descriptor.SplitChecksum(c.Descriptor, true); E.Chk(e) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	var d *descriptor.Descriptor
+	if d, e = descriptor.Parse(c.Descriptor, s.Cfg.ChainParams); E.Chk(e) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	var begin, end int64
+	switch {
+	case c.Range == nil:
+		if d.IsRange() {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "range must be specified for a ranged descriptor",
+			}
+		}
+	case !d.IsRange():
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "range should not be specified for an un-ranged descriptor",
+		}
+	case len(*c.Range) == 1:
+		end = (*c.Range)[0]
+	case len(*c.Range) == 2:
+		begin, end = (*c.Range)[0], (*c.Range)[1]
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "range must be the last index, or the first and last index",
+		}
+	}
+	if begin < 0 || end < begin || end > math.MaxInt32 || end-begin >= maxDeriveAddresses {
+		return nil, &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf(
+				"range [%d,%d] is invalid, it must be within [0,%d] and cover at most %d indexes",
+				begin, end, math.MaxInt32, maxDeriveAddresses,
+			),
+		}
+	}
+	var addrs []btcaddr.Address
+	if addrs, e = d.Addresses(uint32(begin), uint32(end)); E.Chk(e) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	result := make([]string, len(addrs))
+	for i, addr := range addrs {
+		result[i] = addr.EncodeAddress()
+	}
+	return result, nil
+}
+
 // HandleEstimateFee handles estimatefee commands.
 func HandleEstimateFee(
 	s *Server,
@@ -359,6 +531,133 @@ func HandleEstimateFee(
 	return float64(feeRate), nil
 }
 
+// HandleEstimateSmartFee handles estimatesmartfee commands.
+func HandleEstimateSmartFee(
+	s *Server,
+	cmd interface{},
+	closeChan qu.C,
+) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.EstimateSmartFeeCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("estimatesmartfee")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if s.Cfg.FeeEstimator == nil {
+		return nil, errors.New("fee estimation disabled")
+	}
+	if c.ConfTarget <= 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "parameter ConfTarget must be positive",
+		}
+	}
+	mode := btcjson.EstimateModeBlocks
+	if c.EstimateMode != nil {
+		mode = *c.EstimateMode
+	}
+	var result btcjson.EstimateSmartFeeResult
+	switch mode {
+	case btcjson.EstimateModeBlocks:
+		var feeRate mempool.DUOPerKilobyte
+		if feeRate, e = s.Cfg.FeeEstimator.EstimateFee(uint32(c.ConfTarget)); E.Chk(e) {
+			return nil, e
+		}
+		result.FeeRate, result.Blocks = float64(feeRate), c.ConfTarget
+	case btcjson.EstimateModeTime:
+		var estimate mempool.TimeEstimate
+		if estimate, e = s.Cfg.FeeEstimator.EstimateFeeByTime(time.Duration(c.ConfTarget) * time.Minute); E.Chk(e) {
+			return nil, e
+		}
+		result.FeeRate = float64(estimate.FeeRate)
+		result.Blocks = int64(estimate.Blocks)
+		result.Likelihood = estimate.Likelihood
+		if estimate.Likelihood < mempool.TimeTargetConfidence {
+			result.Errors = append(
+				result.Errors, fmt.Sprintf(
+					"a block arrived within %d minutes in only %.0f%% of recent intervals",
+					c.ConfTarget, estimate.Likelihood*100,
+				),
+			)
+		}
+	default:
+		return nil, &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf(
+				"invalid estimate mode %q, must be %q or %q", mode,
+				btcjson.EstimateModeBlocks, btcjson.EstimateModeTime,
+			),
+		}
+	}
+	return result, nil
+}
+
+// HandleEstimatorStats handles estimatorstats commands.
+func HandleEstimatorStats(
+	s *Server,
+	cmd interface{},
+	closeChan qu.C,
+) (interface{}, error) {
+	var msg string
+	var e error
+	if _, ok := cmd.(*btcjson.EstimatorStatsCmd); !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("estimatorstats")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if s.Cfg.FeeEstimator == nil {
+		return nil, errors.New("fee estimation disabled")
+	}
+	stats := s.Cfg.FeeEstimator.Stats()
+	result := btcjson.EstimatorStatsResult{
+		Blocks:         stats.Blocks,
+		Observed:       stats.Observed,
+		Bias:           stats.Bias,
+		AutoBias:       stats.AutoBias,
+		RecentCoverage: stats.RecentCoverage,
+		Targets:        make([]btcjson.EstimatorTargetStatsResult, len(stats.Targets)),
+		MeanInterval:   stats.MeanInterval.Seconds(),
+		Algos:          make([]btcjson.EstimatorAlgoStatsResult, len(stats.Algos)),
+	}
+	for i, t := range stats.Targets {
+		result.Targets[i] = btcjson.EstimatorTargetStatsResult{
+			Target:     t.Target,
+			FeeRate:    float64(t.FeeRate),
+			Predicted:  t.Predicted,
+			Confirmed:  t.Confirmed,
+			Coverage:   t.Coverage,
+			MeanBlocks: t.MeanBlocks,
+		}
+	}
+	for i, a := range stats.Algos {
+		result.Algos[i] = btcjson.EstimatorAlgoStatsResult{
+			Algo:         a.Algo,
+			Blocks:       a.Blocks,
+			Share:        a.Share,
+			MeanInterval: a.MeanInterval.Seconds(),
+		}
+	}
+	return result, nil
+}
+
 // HandleGenerate handles generate commands.
 func HandleGenerate(
 	s *Server,
@@ -366,7 +665,7 @@ func HandleGenerate(
 	closeChan qu.C,
 ) (interface{}, error) {
 	// Respond with an error if there are no addresses to pay the created blocks to.
-	if len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if s.StateCfg.ActiveMiningAddrs.Len() == 0 {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInternal.Code,
 			Message: "No payment addresses specified via --miningaddr",
@@ -485,7 +784,7 @@ func HandleGetAddedNodeInfo(
 			ipList[0] = host
 		default:
 			// Do a DNS lookup for the address. If the lookup fails, just use the host.
-			ips, e := Lookup(s.StateCfg)(host)
+			ips, e := Lookup(s.StateCfg, connmgr.PurposePeer)(host)
 			if e != nil {
 				ipList = make([]string, 1)
 				ipList[0] = host
@@ -595,31 +894,38 @@ func HandleGetBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{}, er
 		}
 		nextHashString = nextHash.String()
 	}
+	chainWork, e := s.Cfg.Chain.WorkSumByHash(hash)
+	if e != nil {
+		context := "Failed to get chain work"
+		return nil, InternalRPCError(e.Error(), context)
+	}
 	params := s.Cfg.ChainParams
 	blockHeader := &blk.WireBlock().Header
 	algoname := fork.GetAlgoName(blockHeader.Version, blockHeight)
 	a := fork.GetAlgoVer(algoname, blockHeight)
 	algoid := fork.GetAlgoID(algoname, blockHeight)
 	blockReply := btcjson.GetBlockVerboseResult{
-		Hash:          c.Hash,
-		Version:       blockHeader.Version,
-		VersionHex:    fmt.Sprintf("%08x", blockHeader.Version),
-		PowAlgoID:     algoid,
-		PowAlgo:       algoname,
-		PowHash:       blk.WireBlock().BlockHashWithAlgos(blockHeight).String(),
-		MerkleRoot:    blockHeader.MerkleRoot.String(),
-		PreviousHash:  blockHeader.PrevBlock.String(),
-		Nonce:         blockHeader.Nonce,
-		Time:          blockHeader.Timestamp.Unix(),
-		Confirmations: int64(1 + best.Height - blockHeight),
-		Height:        int64(blockHeight),
-		TxNum:         len(blk.Transactions()),
-		Size:          int32(len(blkBytes)),
-		StrippedSize:  int32(blk.WireBlock().SerializeSizeStripped()),
-		Weight:        int32(blockchain.GetBlockWeight(blk)),
-		Bits:          strconv.FormatInt(int64(blockHeader.Bits), 16),
-		Difficulty:    GetDifficultyRatio(blockHeader.Bits, params, a),
-		NextHash:      nextHashString,
+		Hash:           c.Hash,
+		Version:        blockHeader.Version,
+		VersionHex:     fmt.Sprintf("%08x", blockHeader.Version),
+		PowAlgoID:      algoid,
+		PowAlgo:        algoname,
+		PowHash:        blk.WireBlock().BlockHashWithAlgos(blockHeight).String(),
+		MerkleRoot:     blockHeader.MerkleRoot.String(),
+		PreviousHash:   blockHeader.PrevBlock.String(),
+		Nonce:          blockHeader.Nonce,
+		Time:           blockHeader.Timestamp.Unix(),
+		Confirmations:  int64(1 + best.Height - blockHeight),
+		Height:         int64(blockHeight),
+		TxNum:          len(blk.Transactions()),
+		Size:           int32(len(blkBytes)),
+		StrippedSize:   int32(blk.WireBlock().SerializeSizeStripped()),
+		Weight:         int32(blockchain.GetBlockWeight(blk)),
+		Bits:           strconv.FormatInt(int64(blockHeader.Bits), 16),
+		Difficulty:     GetDifficultyRatio(blockHeader.Bits, params, a),
+		AlgoDifficulty: fork.GetAlgoDifficulty(algoname, blockHeight, blockHeader.Bits),
+		ChainWork:      fmt.Sprintf("%064x", chainWork),
+		NextHash:       nextHashString,
 	}
 	if c.VerboseTx == nil || !*c.VerboseTx {
 		transactions := blk.Transactions()
@@ -852,28 +1158,100 @@ func HandleGetBlockHeader(
 		}
 		nextHashString = nextHash.String()
 	}
-	var a int32 = 2
-	if blockHeader.Version == 514 {
-		a = 514
+	chainWork, e := s.Cfg.Chain.WorkSumByHash(hash)
+	if e != nil {
+		context := "Failed to get chain work"
+		return nil, InternalRPCError(e.Error(), context)
 	}
 	params := s.Cfg.ChainParams
+	algoname := fork.GetAlgoName(blockHeader.Version, blockHeight)
+	a := fork.GetAlgoVer(algoname, blockHeight)
 	blockHeaderReply := btcjson.GetBlockHeaderVerboseResult{
-		Hash:          c.Hash,
-		Confirmations: int64(1 + best.Height - blockHeight),
-		Height:        blockHeight,
-		Version:       blockHeader.Version,
-		VersionHex:    fmt.Sprintf("%08x", blockHeader.Version),
-		MerkleRoot:    blockHeader.MerkleRoot.String(),
-		NextHash:      nextHashString,
-		PreviousHash:  blockHeader.PrevBlock.String(),
-		Nonce:         uint64(blockHeader.Nonce),
-		Time:          blockHeader.Timestamp.Unix(),
-		Bits:          strconv.FormatInt(int64(blockHeader.Bits), 16),
-		Difficulty:    GetDifficultyRatio(blockHeader.Bits, params, a),
+		Hash:           c.Hash,
+		Confirmations:  int64(1 + best.Height - blockHeight),
+		Height:         blockHeight,
+		Version:        blockHeader.Version,
+		VersionHex:     fmt.Sprintf("%08x", blockHeader.Version),
+		PowAlgoID:      fork.GetAlgoID(algoname, blockHeight),
+		PowAlgo:        algoname,
+		MerkleRoot:     blockHeader.MerkleRoot.String(),
+		NextHash:       nextHashString,
+		PreviousHash:   blockHeader.PrevBlock.String(),
+		Nonce:          uint64(blockHeader.Nonce),
+		Time:           blockHeader.Timestamp.Unix(),
+		Bits:           strconv.FormatInt(int64(blockHeader.Bits), 16),
+		Difficulty:     GetDifficultyRatio(blockHeader.Bits, params, a),
+		AlgoDifficulty: fork.GetAlgoDifficulty(algoname, blockHeight, blockHeader.Bits),
+		ChainWork:      fmt.Sprintf("%064x", chainWork),
 	}
 	return blockHeaderReply, nil
 }
 
+// HandleGetBlockPropagation implements the getblockpropagation command.
+func HandleGetBlockPropagation(
+	s *Server,
+	cmd interface{},
+	closeChan qu.C,
+) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetBlockPropagationCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getblockpropagation")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	count := 10
+	if c.Count != nil {
+		count = *c.Count
+	}
+	if count < 1 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "count must be at least 1",
+		}
+	}
+	blocks := s.Cfg.SyncMgr.BlockPropagation()
+	if len(blocks) > count {
+		blocks = blocks[len(blocks)-count:]
+	}
+	result := btcjson.GetBlockPropagationResult{
+		Blocks: make([]btcjson.BlockPropagationResult, len(blocks)),
+	}
+	var announcements int
+	for i, b := range blocks {
+		latency := b.Validated.Sub(b.Announced)
+		result.Blocks[i] = btcjson.BlockPropagationResult{
+			Hash:          b.Hash.String(),
+			Height:        b.Height,
+			Peer:          b.Peer,
+			Announcements: b.Announcements,
+			Announced:     b.Announced.Unix(),
+			Download:      int64(b.Received.Sub(b.Announced) / time.Millisecond),
+			Validation:    int64(b.Validated.Sub(b.Received) / time.Millisecond),
+			Latency:       int64(latency / time.Millisecond),
+		}
+		result.AvgLatency += result.Blocks[i].Latency
+		if result.Blocks[i].Latency > result.MaxLatency {
+			result.MaxLatency = result.Blocks[i].Latency
+		}
+		announcements += b.Announcements
+	}
+	if len(blocks) > 0 {
+		result.AvgLatency /= int64(len(blocks))
+		result.AvgAnnouncements = float64(announcements) / float64(len(blocks))
+	}
+	return result, nil
+}
+
 // HandleGetBlockTemplate implements the getblocktemplate command. See https:// en.bitcoin.it/wiki/BIP_0022 and
 // https://en.bitcoin.it/wiki/BIP_0023 for more details.
 func HandleGetBlockTemplate(
@@ -1081,7 +1459,7 @@ func HandleGetBlockTemplateRequest(
 	}
 	// When a coinbase transaction has been requested, respond with an error if there are no addresses to pay the
 	// created block template to.
-	if !useCoinbaseValue && len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if !useCoinbaseValue && s.StateCfg.ActiveMiningAddrs.Len() == 0 {
 		return nil, &btcjson.RPCError{
 			Code: btcjson.ErrRPCInternal.Code,
 			Message: "A coinbase transaction has been requested, " +
@@ -1226,6 +1604,32 @@ func HandleGetCFilterHeader(s *Server, cmd interface{}, closeChan qu.C) (interfa
 	return hash.String(), nil
 }
 
+// HandleGetCacheStats implements the getcachestats command.
+func HandleGetCacheStats(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	result := btcjson.GetCacheStatsResult{}
+	if s.Cfg.SigCache == nil {
+		return result, nil
+	}
+	stats := s.Cfg.SigCache.Stats()
+	result.SigCache = btcjson.SigCacheStatsResult{
+		Entries:     stats.HotEntries + stats.ColdEntries,
+		HotEntries:  stats.HotEntries,
+		ColdEntries: stats.ColdEntries,
+		Bytes:       uint64(stats.HotBytes + stats.ColdBytes),
+		HotBytes:    uint64(stats.HotBytes),
+		ColdBytes:   uint64(stats.ColdBytes),
+		MaxBytes:    uint64(stats.MaxBytes),
+		Hits:        stats.Hits,
+		Misses:      stats.Misses,
+		Promotions:  stats.Promotions,
+		Evictions:   stats.Evictions,
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		result.SigCache.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return result, nil
+}
+
 // HandleGetConnectionCount implements the getconnectioncount command.
 func HandleGetConnectionCount(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	return s.Cfg.ConnMgr.ConnectedCount(), nil
@@ -1236,6 +1640,48 @@ func HandleGetCurrentNet(s *Server, cmd interface{}, closeChan qu.C) (interface{
 	return s.Cfg.ChainParams.Net, nil
 }
 
+// HandleGetDescriptorInfo handles getdescriptorinfo commands.
+func HandleGetDescriptorInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetDescriptorInfoCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getdescriptorinfo")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	var d *descriptor.Descriptor
+	if d, e = descriptor.Parse(c.Descriptor, s.Cfg.ChainParams); E.Chk(e) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	// the checksum is of the descriptor as given, which may have private keys the canonical descriptor does not
+	var body, checksum string
+	if body, _, e = descriptor.SplitChecksum(c.Descriptor, false); E.Chk(e) {
+		return nil, e
+	}
+	if checksum, e = descriptor.Checksum(body); E.Chk(e) {
+		return nil, e
+	}
+	return btcjson.GetDescriptorInfoResult{
+		Descriptor:     d.String(),
+		Checksum:       checksum,
+		IsRange:        d.IsRange(),
+		IsSolvable:     d.IsSolvable(),
+		HasPrivateKeys: d.HasPrivateKeys(),
+	}, nil
+}
+
 // HandleGetDifficulty implements the getdifficulty command.
 // TODO: This command should default to the configured algo for cpu mining
 //  and take an optional parameter to query by algo
@@ -1302,6 +1748,50 @@ func HandleGetDifficulty(s *Server, cmd interface{}, closeChan qu.C) (interface{
 	return GetDifficultyRatio(bestbits, s.Cfg.ChainParams, algo), nil
 }
 
+// HandleGetDNSAudit implements the getdnsaudit command.
+func HandleGetDNSAudit(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	reply := btcjson.GetDNSAuditResult{Resolutions: []btcjson.DNSResolutionResult{}}
+	r := s.StateCfg.Resolver
+	if r == nil {
+		return reply, nil
+	}
+	reply.Proxy, reply.ProxyOnly = r.Proxy, r.ProxyOnly
+	for _, res := range r.Resolutions() {
+		if res.Direct > 0 {
+			reply.Leaks++
+		}
+		reply.Resolutions = append(
+			reply.Resolutions, btcjson.DNSResolutionResult{
+				Host:       res.Host,
+				Purposes:   res.Purposes,
+				Direct:     res.Direct,
+				Proxied:    res.Proxied,
+				Local:      res.Local,
+				Refused:    res.Refused,
+				Failures:   res.Failures,
+				LastPath:   res.LastPath,
+				LastLookup: res.LastLookup.Unix(),
+				LastError:  res.LastError,
+			},
+		)
+	}
+	return reply, nil
+}
+
+// HandleGetFeatures implements the getfeatures command.
+func HandleGetFeatures(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	result := make([]btcjson.FeatureResult, len(features.Known))
+	for i, ft := range features.Known {
+		result[i] = btcjson.FeatureResult{
+			Name:         ft.Name,
+			Description:  ft.Description,
+			Experimental: ft.Experimental,
+			Enabled:      s.Cfg.Features.Enabled(ft.Name),
+		}
+	}
+	return result, nil
+}
+
 // HandleGetGenerate implements the getgenerate command.
 func HandleGetGenerate(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) { // cpuminer
 	_, ok := cmd.(*btcjson.GetGenerateCmd)
@@ -1386,18 +1876,54 @@ func HandleGetHeaders(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 	return hexBlockHeaders, nil
 }
 
-// HandleGetInfo implements the getinfo command. We only return the fields that are not related to wallet functionality.
-// TODO: simplify this, break it up
-func HandleGetInfo(
-	s *Server,
-	cmd interface{},
-	closeChan qu.C,
-) (ret interface{}, e error) {
-	var Difficulty, dBlake2b, dBlake14lr, dBlake2s, dKeccak, dScrypt, dSHA256D,
-	dSkein, dStribog, dX11 float64
-	var lastbitsScrypt, lastbitsSHA256D uint32
-	best := s.
-		Cfg.
+// HandleGetIndexInfo implements the getindexinfo command.
+func HandleGetIndexInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetIndexInfoCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getindexinfo")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	result := make(map[string]btcjson.GetIndexInfoResult)
+	if s.Cfg.IndexManager == nil {
+		return result, nil
+	}
+	for _, info := range s.Cfg.IndexManager.IndexInfo() {
+		if c.IndexName != nil && *c.IndexName != info.Name {
+			continue
+		}
+		result[info.Name] = btcjson.GetIndexInfoResult{
+			Synced:          info.Synced,
+			BestBlockHeight: info.Height,
+			Progress:        info.Progress,
+			Workers:         info.Workers,
+			BlocksPerSecond: info.BlocksPerSecond,
+		}
+	}
+	return result, nil
+}
+
+// HandleGetInfo implements the getinfo command. We only return the fields that are not related to wallet functionality.
+// TODO: simplify this, break it up
+func HandleGetInfo(
+	s *Server,
+	cmd interface{},
+	closeChan qu.C,
+) (ret interface{}, e error) {
+	var Difficulty, dBlake2b, dBlake14lr, dBlake2s, dKeccak, dScrypt, dSHA256D,
+	dSkein, dStribog, dX11 float64
+	var lastbitsScrypt, lastbitsSHA256D uint32
+	best := s.
+		Cfg.
 		Chain.
 		BestSnapshot()
 	v := s.Cfg.Chain.Index.LookupNode(&best.Hash)
@@ -1528,13 +2054,29 @@ func HandleGetMempoolInfo(s *Server, cmd interface{}, closeChan qu.C) (interface
 	for _, txD := range mempoolTxns {
 		numBytes += int64(txD.Tx.MsgTx().SerializeSize())
 	}
+	expiry, expired := s.Cfg.TxMemPool.ExpiryInfo()
 	ret := &btcjson.GetMempoolInfoResult{
-		Size:  int64(len(mempoolTxns)),
-		Bytes: numBytes,
+		Size:    int64(len(mempoolTxns)),
+		Bytes:   numBytes,
+		Expiry:  int64(expiry / time.Hour),
+		Expired: expired,
 	}
 	return ret, nil
 }
 
+// HandleGetMiningAddresses implements the getminingaddresses command.
+func HandleGetMiningAddresses(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	addrs, rotation := s.StateCfg.ActiveMiningAddrs.Get()
+	result := btcjson.GetMiningAddressesResult{
+		Addresses: make([]string, len(addrs)),
+		Rotation:  rotation,
+	}
+	for i := range addrs {
+		result.Addresses[i] = addrs[i].EncodeAddress()
+	}
+	return result, nil
+}
+
 // HandleGetMiningInfo implements the getmininginfo command. We only return the fields that are not related to wallet
 // functionality. This function returns more information than parallelcoind. TODO: simplify this, break it up
 func HandleGetMiningInfo(
@@ -1680,6 +2222,118 @@ func HandleGetNetTotals(
 	return reply, nil
 }
 
+// HandleGetNetworkInfo implements the getnetworkinfo command.
+func HandleGetNetworkInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	proxy := s.Config.ProxyAddress.V()
+	onionProxy := s.Config.OnionProxyAddress.V()
+	if onionProxy == "" {
+		onionProxy = proxy
+	}
+	onion := s.Config.OnionEnabled.True() && onionProxy != ""
+	isolate := s.Config.TorIsolation.True()
+	relayFee := s.StateCfg.ActiveMinRelayTxFee.ToDUO()
+	skew := s.Cfg.TimeSource.ClockSkew()
+	reply := &btcjson.GetNetworkInfoResult{
+		Version:         int32(1000000*version.Major + 10000*version.Minor + 100*version.Patch),
+		SubVersion:      fmt.Sprintf("/%s:%s/", UserAgentName, UserAgentVersion),
+		ProtocolVersion: int32(MaxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.Cfg.ConnMgr.Services())),
+		LocalRelay:      s.Config.BlocksOnly.False(),
+		TimeOffset:      int64(s.Cfg.TimeSource.Offset().Seconds()),
+		ClockSkew:       int64(skew.Offset.Seconds()),
+		TimeSamples:     skew.Samples,
+		Connections:     s.Cfg.ConnMgr.ConnectedCount(),
+		NetworkActive:   true,
+		Networks: []btcjson.NetworksResult{
+			{Name: "ipv4", Reachable: true, Proxy: proxy, ProxyRandomizeCredentials: isolate},
+			{Name: "ipv6", Reachable: true, Proxy: proxy, ProxyRandomizeCredentials: isolate},
+			{Name: "onion", Limited: !onion, Reachable: onion, Proxy: onionProxy, ProxyRandomizeCredentials: isolate},
+		},
+		RelayFee:       relayFee,
+		IncrementalFee: relayFee,
+		LocalAddresses: []btcjson.LocalAddressesResult{},
+		Reachability:   btcjson.ReachabilityResult{Status: ReachabilityUnknown},
+	}
+	if s.Cfg.Reachability != nil {
+		reply.Reachability = s.Cfg.Reachability.Info()
+	}
+	for _, la := range s.Cfg.ConnMgr.LocalAddresses() {
+		reply.LocalAddresses = append(
+			reply.LocalAddresses, btcjson.LocalAddressesResult{
+				Address: la.NetAddress.IP.String(),
+				Port:    la.NetAddress.Port,
+				Score:   int32(la.Priority),
+			},
+		)
+	}
+	var warnings []string
+	if warning := skew.Warning(s.Config.ClockSkewWarning.V()); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	if experimental := s.Cfg.Features.Experimental(); len(experimental) > 0 {
+		warnings = append(warnings, "experimental features are enabled: "+strings.Join(experimental, ", "))
+	}
+	reply.Warnings = strings.Join(warnings, "; ")
+	return reply, nil
+}
+
+// HandleGetNodeAddresses implements the getnodeaddresses command.
+func HandleGetNodeAddresses(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetNodeAddressesCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getnodeaddresses")
+		D.Ln(h, e)
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	count := 1
+	if c.Count != nil {
+		count = *c.Count
+	}
+	if count < 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Address count out of range",
+		}
+	}
+	var network string
+	if c.Network != nil {
+		network = *c.Network
+	}
+	switch network {
+	case "", "ipv4", "ipv6", "onion":
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Network %q is not ipv4, ipv6 or onion", network),
+		}
+	}
+	addrs := s.Cfg.ConnMgr.NodeAddresses(count, network)
+	reply := make([]btcjson.NodeAddressResult, len(addrs))
+	for i := range addrs {
+		na := &addrs[i]
+		// The host of the address key is the .onion name of a Tor address.
+		host, _, _ := net.SplitHostPort(addrmgr.NetAddressKey(na))
+		reply[i] = btcjson.NodeAddressResult{
+			Time:     na.Timestamp.Unix(),
+			Services: fmt.Sprintf("%016x", uint64(na.Services)),
+			Address:  host,
+			Port:     na.Port,
+			Network:  addrmgr.Network(na),
+		}
+	}
+	return reply, nil
+}
+
 // HandleGetNetworkHashPS implements the getnetworkhashps command. This command does not default to the same end block
 // as the parallelcoind. TODO: Really this needs to be expanded to show per-algorithm hashrates
 func HandleGetNetworkHashPS(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
@@ -1787,6 +2441,133 @@ func HandleGetNetworkHashPS(s *Server, cmd interface{}, closeChan qu.C) (interfa
 	return hashesPerSec.Int64(), nil
 }
 
+// HandleGetNotificationInfo implements the getnotificationinfo command.
+func HandleGetNotificationInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	scheme := "ws"
+	if s.Config.ServerTLS.True() {
+		scheme = "wss"
+	}
+	topics := []string{
+		btcjson.BlockConnectedNtfnMethod,
+		btcjson.BlockDisconnectedNtfnMethod,
+		btcjson.FilteredBlockConnectedNtfnMethod,
+		btcjson.FilteredBlockDisconnectedNtfnMethod,
+		btcjson.TxAcceptedNtfnMethod,
+		btcjson.TxAcceptedVerboseNtfnMethod,
+		btcjson.RelevantTxAcceptedNtfnMethod,
+		btcjson.RedeemingTxNtfnMethod,
+		btcjson.RecvTxNtfnMethod,
+		btcjson.TxExpiredNtfnMethod,
+		btcjson.PeerConnectedNtfnMethod,
+		btcjson.PeerDisconnectedNtfnMethod,
+		btcjson.TipDeltaNtfnMethod,
+	}
+	slowClients, e := ParseSlowClientPolicy(s.Config.RPCSlowClients.V())
+	if E.Chk(e) {
+		return nil, &btcjson.RPCError{Code: btcjson.ErrRPCInternal.Code, Message: e.Error()}
+	}
+	reply := &btcjson.GetNotificationInfoResult{
+		Endpoints: make([]btcjson.NotificationEndpointResult, 0, len(s.Cfg.Listeners)),
+	}
+	for _, listener := range s.Cfg.Listeners {
+		reply.Endpoints = append(
+			reply.Endpoints, btcjson.NotificationEndpointResult{
+				Address:     scheme + "://" + listener.Addr().String() + "/ws",
+				Topics:      topics,
+				HWM:         WebsocketSendBufferSize,
+				QueueLimit:  s.Config.RPCNtfnQueueLimit.V(),
+				SlowClients: slowClients,
+			},
+		)
+	}
+	reply.Clients = s.NtfnMgr.NotificationInfo()
+	for i := range reply.Clients {
+		reply.Queued += reply.Clients[i].Queued
+		reply.Sent += reply.Clients[i].Sent
+		reply.Dropped += reply.Clients[i].Dropped
+	}
+	return reply, nil
+}
+
+// HandleGetOrphanBlocks implements the getorphanblocks command.
+func HandleGetOrphanBlocks(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	stats, count, limit := s.Cfg.Chain.OrphanStats()
+	requests := s.Cfg.SyncMgr.OrphanRequests()
+	reply := btcjson.GetOrphanBlocksResult{
+		Count:      count,
+		Limit:      limit,
+		Processed:  stats.Processed,
+		Added:      stats.Added,
+		Connected:  stats.Connected,
+		Rejected:   stats.Rejected,
+		Expired:    stats.Expired,
+		Evicted:    stats.Evicted,
+		Requests:   requests.Requests,
+		Rerequests: requests.Rerequests,
+		Abandoned:  requests.Abandoned,
+	}
+	if stats.Processed+stats.Added > 0 {
+		reply.OrphanRate = float64(stats.Added) / float64(stats.Processed+stats.Added)
+	}
+	pending := make(map[chainhash.Hash]netsync.OrphanParentRequest, len(requests.Pending))
+	for _, r := range requests.Pending {
+		pending[r.Root] = r
+	}
+	orphans := s.Cfg.Chain.Orphans()
+	reply.Orphans = make([]btcjson.OrphanBlockResult, len(orphans))
+	for i, o := range orphans {
+		reply.Orphans[i] = btcjson.OrphanBlockResult{
+			Hash:           o.Hash.String(),
+			PreviousHash:   o.Parent.String(),
+			Root:           o.Root.String(),
+			Size:           o.Size,
+			Received:       o.Received.Unix(),
+			Expires:        o.Expires.Unix(),
+			Peers:          pending[o.Root].Peers,
+			ParentRequests: pending[o.Root].Attempts,
+		}
+	}
+	return reply, nil
+}
+
+// HandleGetOrphanTxs implements the getorphantxs command.
+func HandleGetOrphanTxs(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	stats, count, limit := s.Cfg.TxMemPool.OrphanStats()
+	orphans, e := s.Cfg.TxMemPool.Orphans()
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDatabase,
+			Message: "Failed to look up the inputs of orphan transactions: " + e.Error(),
+		}
+	}
+	reply := btcjson.GetOrphanTxsResult{
+		Count:    count,
+		Limit:    limit,
+		Added:    stats.Added,
+		Resolved: stats.Resolved,
+		Rejected: stats.Rejected,
+		Expired:  stats.Expired,
+		Evicted:  stats.Evicted,
+		Removed:  stats.Removed,
+		Orphans:  make([]btcjson.OrphanTxResult, len(orphans)),
+	}
+	for i, o := range orphans {
+		missing := make([]string, len(o.Missing))
+		for j := range o.Missing {
+			missing[j] = o.Missing[j].String()
+		}
+		reply.Orphans[i] = btcjson.OrphanTxResult{
+			TxID:     o.Tx.Hash().String(),
+			Size:     o.Tx.MsgTx().SerializeSize(),
+			Received: o.Received.Unix(),
+			Expires:  o.Expires.Unix(),
+			From:     uint64(o.Tag),
+			Missing:  missing,
+		}
+	}
+	return reply, nil
+}
+
 // HandleGetPeerInfo implements the getpeerinfo command.
 func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	peers := s.Cfg.ConnMgr.ConnectedPeers()
@@ -1830,6 +2611,7 @@ func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 			FeeFilter:      p.GetFeeFilter(),
 			SyncNode:       statsSnap.ID == syncPeerID,
 		}
+		info.MsgRecv, info.Patterns, info.MsgDropped = p.GetMsgStats()
 		if p.ToPeer().LastPingNonce() != 0 {
 			wait := float64(time.Since(statsSnap.LastPingTime).Nanoseconds())
 			// We actually want microseconds.
@@ -1978,6 +2760,109 @@ func HandleGetRawTransaction(s *Server, cmd interface{}, closeChan qu.C) (interf
 	return *rawTxn, nil
 }
 
+// HandleGetScriptFlags implements the getscriptflags command.
+func HandleGetScriptFlags(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetScriptFlagsCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getscriptflags")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	// the flags of the next block are the ones blocks are checked with now
+	height := s.Cfg.Chain.BestSnapshot().Height + 1
+	if c.Height != nil {
+		if height = *c.Height; height < 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Block height must not be negative",
+			}
+		}
+	}
+	flags := s.Cfg.Chain.ScriptFlags(height)
+	reply := btcjson.GetScriptFlagsResult{
+		Height: height,
+		Flags:  uint32(flags),
+		Names:  flags.Names(),
+	}
+	upgrades := s.Cfg.Chain.ScriptFlagUpgrades()
+	reply.Upgrades = make([]btcjson.ScriptFlagUpgradeResult, len(upgrades))
+	for i, u := range upgrades {
+		reply.Upgrades[i] = btcjson.ScriptFlagUpgradeResult{
+			Height: u.Height,
+			Added:  u.Added.Names(),
+			Active: u.Height <= height,
+		}
+	}
+	return reply, nil
+}
+
+// HandleGetTimestampInfo implements the gettimestampinfo command.
+func HandleGetTimestampInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetTimestampInfoCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("gettimestampinfo")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	blocks := 720
+	if c.Blocks != nil {
+		blocks = *c.Blocks
+	}
+	if blocks < 1 || blocks > blockchain.MaxTimestampInfoBlocks {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Number of blocks must be between 1 and %d", blockchain.MaxTimestampInfoBlocks),
+		}
+	}
+	info := s.Cfg.Chain.TimestampInfo(int32(blocks))
+	reply := btcjson.GetTimestampInfoResult{
+		Height:       info.Height,
+		Hash:         info.Hash.String(),
+		Time:         info.Time.Unix(),
+		MedianTime:   info.MedianTime.Unix(),
+		AdjustedTime: info.AdjustedTime.Unix(),
+		TimeOffset:   info.AdjustedTime.Unix() - time.Now().Unix(),
+		MinTime:      info.MinTime.Unix(),
+		MaxTime:      info.MaxTime.Unix(),
+		Blocks:       info.Blocks,
+		Algos:        make([]btcjson.AlgoTimestampsResult, len(info.Algos)),
+	}
+	for i, a := range info.Algos {
+		reply.Algos[i] = btcjson.AlgoTimestampsResult{
+			Algo:          a.Name,
+			Version:       a.Version,
+			Blocks:        a.Blocks,
+			LastHeight:    a.LastHeight,
+			LastTime:      a.LastTime.Unix(),
+			TargetSpacing: a.TargetSpacing,
+			MeanSpacing:   a.MeanSpacing,
+			MinAhead:      a.MinAhead,
+			MeanAhead:     a.MeanAhead,
+			MaxAhead:      a.MaxAhead,
+			Backwards:     a.Backwards,
+		}
+	}
+	return reply, nil
+}
+
 // HandleGetTxOut handles gettxout commands.
 func HandleGetTxOut(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	var msg string
@@ -2086,6 +2971,56 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan qu.C) (interface{}, er
 	return txOutReply, nil
 }
 
+// HandleGetValidationTrace implements the getvalidationtrace command.
+func HandleGetValidationTrace(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.GetValidationTraceCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("getvalidationtrace")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if !s.Cfg.Chain.ValTraceEnabled() {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "validation tracing is not enabled, restart the node with --valtrace",
+		}
+	}
+	count := 10
+	if c.Count != nil {
+		count = *c.Count
+	}
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	traces := s.Cfg.Chain.ValidationTraces(count)
+	result := make([]btcjson.GetValidationTraceResult, 0, len(traces))
+	for _, t := range traces {
+		result = append(
+			result, btcjson.GetValidationTraceResult{
+				Hash:        t.Hash.String(),
+				Height:      t.Height,
+				Time:        t.Time.Unix(),
+				Deserialize: ms(t.Deserialize),
+				Merkle:      ms(t.Merkle),
+				UtxoFetch:   ms(t.UtxoFetch),
+				ScriptCheck: ms(t.ScriptCheck),
+				UtxoFlush:   ms(t.UtxoFlush),
+				Total:       ms(t.Total),
+			},
+		)
+	}
+	return result, nil
+}
+
 // HandleHelp implements the help command.
 func HandleHelp(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
@@ -2253,6 +3188,34 @@ func HandlePing(s *Server, cmd interface{}, closeChan qu.C) (
 	return nil, nil
 }
 
+// HandlePrioritiseTransaction implements the prioritisetransaction command.
+func HandlePrioritiseTransaction(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.PrioritiseTransactionCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("prioritisetransaction")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	txHash, e := chainhash.NewHashFromStr(c.TxID)
+	if e != nil {
+		return nil, DecodeHexError(c.TxID)
+	}
+	if c.PriorityDelta != 0 {
+		W.Ln("prioritisetransaction priority delta is not supported and was ignored")
+	}
+	s.Cfg.TxMemPool.PrioritiseTransaction(txHash, c.FeeDelta)
+	return true, nil
+}
+
 // HandleSearchRawTransactions implements the searchrawtransactions command.
 // TODO: simplify this, break it up
 func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
@@ -2485,6 +3448,31 @@ func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan qu.C) (in
 	return srtList, nil
 }
 
+// HandleSelfTest implements the selftest command.
+func HandleSelfTest(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	results, passed := fork.SelfTest()
+	result := btcjson.SelfTestResult{
+		Passed:     passed,
+		Algorithms: make([]btcjson.SelfTestAlgorithmResult, len(results)),
+	}
+	for i, r := range results {
+		result.Algorithms[i] = btcjson.SelfTestAlgorithmResult{
+			Name:     r.Name,
+			Fork:     r.Fork,
+			Vectors:  r.Vectors,
+			Passed:   len(r.Failures) == 0,
+			Failures: r.Failures,
+		}
+		if a, ok := fork.Registered(r.Name); ok {
+			result.Algorithms[i].Version = a.Params.Version
+		}
+	}
+	if !passed {
+		E.Ln("proof of work algorithm self test failed")
+	}
+	return result, nil
+}
+
 // HandleSendRawTransaction implements the sendrawtransaction command.
 func HandleSendRawTransaction(
 	s *Server,
@@ -2527,8 +3515,17 @@ func HandleSendRawTransaction(
 	}
 	// Use 0 for the tag to represent local node.
 	tx := util.NewTx(&msgTx)
-	acceptedTxs, e := s.Cfg.TxMemPool.ProcessTransaction(s.Cfg.Chain, tx, false, false, 0)
+	allowHighFees := c.AllowHighFees != nil && *c.AllowHighFees
+	acceptedTxs, e := s.Cfg.TxMemPool.ProcessTransaction(s.Cfg.Chain, tx, false, false, allowHighFees, 0)
 	if e != nil {
+		// A fee over the maximum gets its own code so the client can warn about it specifically.
+		if mempool.IsAbsurdFee(e) {
+			D.F("rejected transaction %v: %v", tx.Hash(), e)
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCHighFee,
+				Message: "TX rejected: " + e.Error(),
+			}
+		}
 		// When the error is a rule error, it means the transaction was simply rejected as opposed to something actually
 		// going wrong, so log such. Otherwise, something really did go wrong, so log an actual error. In both cases, a
 		// JSON-RPC error is returned to the client with the deserialization error code (to match bitcoind behavior).
@@ -2569,6 +3566,91 @@ func HandleSendRawTransaction(
 	return tx.Hash().String(), nil
 }
 
+// HandleSetConfig implements the setconfig command. Only the options that are read each time they are used can be set,
+// and the configuration file is saved so the new value is kept when the node restarts.
+func HandleSetConfig(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.SetConfigCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("setconfig")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	o, ok := s.Config.Map[c.Name]
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "there is no option " + c.Name,
+		}
+	}
+	if !o.GetMetadata().Live {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "option " + c.Name + " only takes effect when the node restarts",
+		}
+	}
+	if e := s.Config.SetOption(c.Name, c.Value); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: e.Error(),
+		}
+	}
+	I.Ln("configuration option", c.Name, "set to", c.Value)
+	if e := s.Config.WriteToFile(s.Config.ConfigFile.V()); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "failed to save the configuration: " + e.Error(),
+		}
+	}
+	return nil, nil
+}
+
+// HandleSetFeature implements the setfeature command. The state of the flags is saved in the database straight away,
+// so it is kept if the node does not shut down cleanly.
+func HandleSetFeature(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.SetFeatureCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("setfeature")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if e := s.Cfg.Features.Set(c.Name, c.Enable); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: e.Error(),
+		}
+	}
+	I.Ln("feature", c.Name, "set to", c.Enable)
+	if e := s.Cfg.DB.Update(
+		func(tx database.Tx) error {
+			return tx.Metadata().Put(features.DatabaseKey, s.Cfg.Features.Save())
+		},
+	); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDatabase,
+			Message: "failed to save feature flags: " + e.Error(),
+		}
+	}
+	return nil, nil
+}
+
 // HandleSetGenerate implements the setgenerate command.
 func HandleSetGenerate(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) { // cpuminer
 	c, ok := cmd.(*btcjson.SetGenerateCmd)
@@ -2665,24 +3747,82 @@ func HandleSetGenerate(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 	// return nil, nil
 }
 
-// HandleStop implements the stop command.
+// HandleSetMiningAddresses implements the setminingaddresses command. The new addresses apply from the next block
+// template, and are also set in the configuration, though it is not saved.
+func HandleSetMiningAddresses(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.SetMiningAddressesCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("setminingaddresses")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	addrs, e := DecodeMiningAddrs(c.Addresses, s.Cfg.ChainParams)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	_, rotation := s.StateCfg.ActiveMiningAddrs.Get()
+	if c.Rotation != nil {
+		rotation = *c.Rotation
+	}
+	if e = s.StateCfg.ActiveMiningAddrs.Set(addrs, rotation); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: e.Error(),
+		}
+	}
+	_, rotation = s.StateCfg.ActiveMiningAddrs.Get()
+	if e = s.Config.MiningAddrs.Set(c.Addresses); E.Chk(e) {
+	}
+	if e = s.Config.MiningAddrRotation.Set(rotation); E.Chk(e) {
+	}
+	I.F("mining to %d addresses with %s rotation", len(addrs), rotation)
+	return nil, nil
+}
+
+// DecodeMiningAddrs decodes the addresses mined blocks pay to, which must be for the network of params.
+func DecodeMiningAddrs(addresses []string, params *chaincfg.Params) (addrs []btcaddr.Address, e error) {
+	addrs = make([]btcaddr.Address, 0, len(addresses))
+	for _, address := range addresses {
+		var addr btcaddr.Address
+		if addr, e = btcaddr.Decode(address, params); e != nil {
+			return nil, fmt.Errorf("mining address '%s' failed to decode: %v", address, e)
+		}
+		if !addr.IsForNet(params) {
+			return nil, fmt.Errorf("mining address '%s' is on the wrong network", address)
+		}
+		addrs = append(addrs, addr)
+	}
+	return
+}
+
+// HandleStop implements the stop command. The shutdown it requests waits for this reply to be sent, saves the fee
+// estimator and mempool and closes the database before the process exits.
 func HandleStop(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
 ) {
 	interrupt.Request()
-	return nil, nil
+	return "pod stopping.", nil
 }
 
-// HandleRestart implements the restart command.
+// HandleRestart implements the restart command. It performs the same graceful shutdown as stop and then executes the
+// node again with the arguments it was started with.
 func HandleRestart(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
 ) {
-	// select {
-	// case s.RequestProcessShutdown <- struct{}{}:
-	// default:
-	// }
 	interrupt.RequestRestart()
-	return nil, nil
+	return "pod restarting.", nil
 }
 
 // HandleSubmitBlock implements the submitblock command.
@@ -2733,6 +3873,72 @@ func HandleSubmitBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 	return nil, nil
 }
 
+// HandleTraceMempoolAccept implements the tracemempoolaccept command.
+func HandleTraceMempoolAccept(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.TraceMempoolAcceptCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("tracemempoolaccept")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hexStr := c.HexTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, e := hex.DecodeString(hexStr)
+	if e != nil {
+		return nil, DecodeHexError(hexStr)
+	}
+	var msgTx wire.MsgTx
+	e = msgTx.Deserialize(bytes.NewReader(serializedTx))
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "TX decode failed: " + e.Error(),
+		}
+	}
+	tx := util.NewTx(&msgTx)
+	allowHighFees := c.AllowHighFees != nil && *c.AllowHighFees
+	trace := s.Cfg.TxMemPool.TraceAcceptTransaction(s.Cfg.Chain, tx, allowHighFees)
+	result := btcjson.TraceMempoolAcceptResult{
+		TxID:        tx.Hash().String(),
+		Accepted:    trace.Accepted,
+		Checks:      make([]btcjson.TraceMempoolCheckResult, len(trace.Checks)),
+		Fee:         amt.Amount(trace.Fee).ToDUO(),
+		ModifiedFee: amt.Amount(trace.ModifiedFee).ToDUO(),
+		MinFee:      amt.Amount(trace.MinFee).ToDUO(),
+		VSize:       trace.Size,
+	}
+	for i, check := range trace.Checks {
+		result.Checks[i] = btcjson.TraceMempoolCheckResult{
+			Name:    check.Name,
+			Passed:  check.Passed,
+			Details: check.Details,
+		}
+		if !check.Passed {
+			result.Checks[i].RejectCode = check.RejectCode.String()
+		}
+	}
+	for _, parent := range trace.MissingParents {
+		result.MissingParents = append(result.MissingParents, parent.String())
+	}
+	if trace.ScriptInput >= 0 {
+		input := trace.ScriptInput
+		result.ScriptInput = &input
+		result.ScriptPosition = trace.ScriptPosition
+	}
+	return result, nil
+}
+
 // HandleUnimplemented is the handler for commands that should ultimately be supported but are not yet implemented.
 func HandleUnimplemented(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	return nil, ErrRPCUnimplemented
@@ -0,0 +1,222 @@
+package chainrpc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// MsgPattern is a pathological pattern of messages from a peer which is harmless message by message, and so does not
+// raise the ban score, but wastes the resources of the node when kept up.
+type MsgPattern int
+
+const (
+	// PatternInvFlood is a peer announcing more inventory vectors in a window than a well behaved peer would.
+	PatternInvFlood MsgPattern = iota
+	// PatternGetHeadersRepeat is a peer sending the same getheaders request again and again in a window.
+	PatternGetHeadersRepeat
+	// PatternAddrSpam is a peer sending more addresses in a window than fit in a reply to getaddr.
+	PatternAddrSpam
+	numMsgPatterns
+)
+
+// msgPatternNames are the names of the message patterns used in the configuration and by getpeerinfo.
+var msgPatternNames = [numMsgPatterns]string{
+	PatternInvFlood:         "invflood",
+	PatternGetHeadersRepeat: "getheadersrepeat",
+	PatternAddrSpam:         "addrspam",
+}
+
+// String returns the name of the message pattern.
+func (p MsgPattern) String() string {
+	if p < 0 || p >= numMsgPatterns {
+		return fmt.Sprintf("MsgPattern(%d)", int(p))
+	}
+	return msgPatternNames[p]
+}
+
+// PatternResponse is what the node does to a peer that shows a misbehaving message pattern.
+type PatternResponse int
+
+const (
+	// ResponseThrottle ignores the messages of the pattern from the peer until the end of the window.
+	ResponseThrottle PatternResponse = iota
+	// ResponseDisconnect disconnects the peer.
+	ResponseDisconnect
+	// ResponseBan bans and disconnects the peer.
+	ResponseBan
+)
+
+// patternResponseNames are the names of the responses used in the configuration.
+var patternResponseNames = [...]string{
+	ResponseThrottle:   "throttle",
+	ResponseDisconnect: "disconnect",
+	ResponseBan:        "ban",
+}
+
+// String returns the name of the response.
+func (r PatternResponse) String() string {
+	if r < 0 || int(r) >= len(patternResponseNames) {
+		return fmt.Sprintf("PatternResponse(%d)", int(r))
+	}
+	return patternResponseNames[r]
+}
+
+const (
+	// msgPatternWindow is the length of the windows in which the messages of the patterns are counted.
+	msgPatternWindow = time.Minute
+	// invFloodLimit is the number of inventory vectors a peer can announce in a window.
+	invFloodLimit = wire.MaxInvPerMsg
+	// getHeadersRepeatLimit is the number of times a peer can send the same getheaders request in a window.
+	getHeadersRepeatLimit = 8
+	// addrSpamLimit is the number of addresses a peer can send in a window.
+	addrSpamLimit = wire.MaxAddrPerMsg
+)
+
+// msgPatternLimits are the limits above which the patterns are detected.
+var msgPatternLimits = [numMsgPatterns]int{
+	PatternInvFlood:         invFloodLimit,
+	PatternGetHeadersRepeat: getHeadersRepeatLimit,
+	PatternAddrSpam:         addrSpamLimit,
+}
+
+// PatternResponses are the responses of the node to each of the misbehaving message patterns.
+type PatternResponses [numMsgPatterns]PatternResponse
+
+// ParsePatternResponses parses the responses to misbehaving message patterns from entries of the form pattern=response,
+// such as addrspam=ban. Patterns without an entry are throttled.
+func ParsePatternResponses(entries []string) (responses PatternResponses, e error) {
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return responses, fmt.Errorf("message pattern response %q is not of the form pattern=response", entry)
+		}
+		pattern, response := MsgPattern(-1), PatternResponse(-1)
+		for i := range msgPatternNames {
+			if strings.EqualFold(parts[0], msgPatternNames[i]) {
+				pattern = MsgPattern(i)
+			}
+		}
+		for i := range patternResponseNames {
+			if strings.EqualFold(parts[1], patternResponseNames[i]) {
+				response = PatternResponse(i)
+			}
+		}
+		if pattern < 0 {
+			return responses, fmt.Errorf(
+				"unknown message pattern %q, must be one of %s", parts[0], strings.Join(msgPatternNames[:], ", "),
+			)
+		}
+		if response < 0 {
+			return responses, fmt.Errorf(
+				"unknown message pattern response %q, must be one of %s", parts[1],
+				strings.Join(patternResponseNames[:], ", "),
+			)
+		}
+		responses[pattern] = response
+	}
+	return
+}
+
+// MsgStats counts the messages received from a peer by command and detects the misbehaving message patterns in them.
+// The zero value is ready to use, and it is safe for concurrent access.
+type MsgStats struct {
+	mtx      sync.Mutex
+	recv     map[string]uint64
+	detected [numMsgPatterns]uint64
+	dropped  uint64
+	// The following fields count the messages of the patterns in the current window.
+	windowStart    time.Time
+	counts         [numMsgPatterns]int
+	lastGetHeaders chainhash.Hash
+}
+
+// Received counts a message received from the peer.
+func (s *MsgStats) Received(command string) {
+	s.mtx.Lock()
+	if s.recv == nil {
+		s.recv = make(map[string]uint64)
+	}
+	s.recv[command]++
+	s.mtx.Unlock()
+}
+
+// observe adds n messages of the pattern to the current window, which starts afresh when the last one has ended. It
+// returns whether the peer has gone over the limit of the pattern in the window, and whether it went over it with
+// these messages. The caller must hold the lock.
+func (s *MsgStats) observe(now time.Time, pattern MsgPattern, n int) (over, first bool) {
+	if now.Sub(s.windowStart) >= msgPatternWindow {
+		s.windowStart = now
+		s.counts = [numMsgPatterns]int{}
+	}
+	limit := msgPatternLimits[pattern]
+	was := s.counts[pattern]
+	s.counts[pattern] += n
+	over = s.counts[pattern] > limit
+	first = over && was <= limit
+	if first {
+		s.detected[pattern]++
+	}
+	return
+}
+
+// ObserveInv counts the inventory vectors of an inv message towards PatternInvFlood.
+func (s *MsgStats) ObserveInv(now time.Time, msg *wire.MsgInv) (over, first bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.observe(now, PatternInvFlood, len(msg.InvList))
+}
+
+// ObserveAddr counts the addresses of an addr message towards PatternAddrSpam.
+func (s *MsgStats) ObserveAddr(now time.Time, msg *wire.MsgAddr) (over, first bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.observe(now, PatternAddrSpam, len(msg.AddrList))
+}
+
+// ObserveGetHeaders counts a getheaders message towards PatternGetHeadersRepeat when it is the same as the one before
+// it, and otherwise starts counting the repeats of it.
+func (s *MsgStats) ObserveGetHeaders(now time.Time, msg *wire.MsgGetHeaders) (over, first bool) {
+	b := make([]byte, 0, (len(msg.BlockLocatorHashes)+1)*chainhash.HashSize)
+	for _, hash := range msg.BlockLocatorHashes {
+		b = append(b, hash[:]...)
+	}
+	b = append(b, msg.HashStop[:]...)
+	key := chainhash.HashH(b)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if key != s.lastGetHeaders {
+		s.lastGetHeaders = key
+		s.counts[PatternGetHeadersRepeat] = 0
+	}
+	return s.observe(now, PatternGetHeadersRepeat, 1)
+}
+
+// Dropped counts a message that was ignored because the peer is throttled.
+func (s *MsgStats) Dropped() {
+	s.mtx.Lock()
+	s.dropped++
+	s.mtx.Unlock()
+}
+
+// Snapshot returns the number of messages received from the peer by command, the number of times each of the
+// misbehaving message patterns was detected by name, and the number of messages ignored because of throttling.
+func (s *MsgStats) Snapshot() (recv, detected map[string]uint64, dropped uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	recv = make(map[string]uint64, len(s.recv))
+	for command, n := range s.recv {
+		recv[command] = n
+	}
+	detected = make(map[string]uint64)
+	for i, n := range s.detected {
+		if n > 0 {
+			detected[msgPatternNames[i]] = n
+		}
+	}
+	return recv, detected, s.dropped
+}
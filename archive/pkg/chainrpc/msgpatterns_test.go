@@ -0,0 +1,74 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestMsgStats ensures the misbehaving message patterns are detected once per window and counted.
+func TestMsgStats(t *testing.T) {
+	var s MsgStats
+	now := time.Now()
+	getHeaders := wire.NewMsgGetHeaders()
+	if e := getHeaders.AddBlockLocatorHash(&chainhash.Hash{1}); e != nil {
+		t.Fatal(e)
+	}
+	for i := 1; i <= getHeadersRepeatLimit+2; i++ {
+		over, first := s.ObserveGetHeaders(now, getHeaders)
+		if over != (i > getHeadersRepeatLimit) || first != (i == getHeadersRepeatLimit+1) {
+			t.Fatalf("getheaders %d: got over %v first %v", i, over, first)
+		}
+	}
+	// A different request starts the count of repeats afresh.
+	other := wire.NewMsgGetHeaders()
+	if over, _ := s.ObserveGetHeaders(now, other); over {
+		t.Fatal("different getheaders request counted as a repeat")
+	}
+	inv := wire.NewMsgInv()
+	for i := 0; i < invFloodLimit; i++ {
+		if e := inv.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &chainhash.Hash{})); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if over, _ := s.ObserveInv(now, inv); over {
+		t.Fatal("inv flood detected at the limit")
+	}
+	if over, first := s.ObserveInv(now, inv); !over || !first {
+		t.Fatalf("inv flood not detected over the limit: over %v first %v", over, first)
+	}
+	// The counts start afresh in the next window.
+	if over, _ := s.ObserveInv(now.Add(msgPatternWindow), inv); over {
+		t.Fatal("inv flood detected in a new window")
+	}
+	s.Received(wire.CmdInv)
+	s.Dropped()
+	recv, detected, dropped := s.Snapshot()
+	if recv[wire.CmdInv] != 1 || dropped != 1 || detected["invflood"] != 1 ||
+		detected["getheadersrepeat"] != 1 || len(detected) != 2 {
+		t.Fatalf("got recv %v detected %v dropped %d", recv, detected, dropped)
+	}
+}
+
+// TestParsePatternResponses ensures the configured responses to misbehaving message patterns are parsed.
+func TestParsePatternResponses(t *testing.T) {
+	responses, e := ParsePatternResponses([]string{"addrspam=ban", "InvFlood=disconnect"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := PatternResponses{
+		PatternInvFlood:         ResponseDisconnect,
+		PatternGetHeadersRepeat: ResponseThrottle,
+		PatternAddrSpam:         ResponseBan,
+	}
+	if responses != want {
+		t.Fatalf("got responses %v, want %v", responses, want)
+	}
+	for _, entry := range []string{"addrspam", "pingflood=ban", "addrspam=ignore"} {
+		if _, e = ParsePatternResponses([]string{entry}); e == nil {
+			t.Errorf("entry %q parsed without error", entry)
+		}
+	}
+}
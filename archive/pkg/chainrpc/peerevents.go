@@ -0,0 +1,152 @@
+package chainrpc
+
+import (
+	"bytes"
+	js "encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+const (
+	// peerEventsQueueSize is how many peer events can wait to be posted to the webhook before new ones are dropped.
+	peerEventsQueueSize = 256
+	// peerEventsTimeout is how long a post to the webhook may take.
+	peerEventsTimeout = time.Second * 10
+)
+
+// Reasons a peer is disconnected, reported with the peerdisconnected notification. Peers that go away without the
+// node disconnecting them are reported with ReasonConnectionClosed.
+const (
+	ReasonBanned           = "banned"
+	ReasonBanScore         = "ban score exceeded"
+	ReasonMaxPeers         = "max peers reached"
+	ReasonShutdown         = "node shutting down"
+	ReasonConnectionClosed = "connection closed"
+)
+
+// PeerEvent is the body posted to the peer events webhook.
+type PeerEvent struct {
+	Event string                  `json:"event"`
+	Peer  btcjson.PeerEventResult `json:"peer"`
+}
+
+// PeerEventsWebhook posts peer connect and disconnect events as JSON to a URL. Events are queued and posted in order
+// from a goroutine of its own, so a slow endpoint never holds up the peer handler. When the queue is full new events
+// are dropped.
+type PeerEventsWebhook struct {
+	url    string
+	client *http.Client
+	events chan PeerEvent
+	quit   qu.C
+}
+
+// NewPeerEventsWebhook returns a webhook posting to url which stops when quit is closed.
+func NewPeerEventsWebhook(url string, quit qu.C) *PeerEventsWebhook {
+	w := &PeerEventsWebhook{
+		url:    url,
+		client: &http.Client{Timeout: peerEventsTimeout},
+		events: make(chan PeerEvent, peerEventsQueueSize),
+		quit:   quit,
+	}
+	go w.run()
+	return w
+}
+
+// Post queues an event to be posted to the webhook.
+func (w *PeerEventsWebhook) Post(ev PeerEvent) {
+	select {
+	case w.events <- ev:
+	default:
+		W.Ln("peer events webhook queue is full, dropping", ev.Event, "event of", ev.Peer.Addr)
+	}
+}
+
+func (w *PeerEventsWebhook) run() {
+	for {
+		select {
+		case ev := <-w.events:
+			if e := w.post(ev); e != nil {
+				W.Ln("failed to post peer event to webhook:", e)
+			}
+		case <-w.quit.Wait():
+			return
+		}
+	}
+}
+
+func (w *PeerEventsWebhook) post(ev PeerEvent) (e error) {
+	var body []byte
+	if body, e = js.Marshal(ev); e != nil {
+		return
+	}
+	var resp *http.Response
+	if resp, e = w.client.Post(w.url, "application/json", bytes.NewReader(body)); e != nil {
+		return
+	}
+	if e = resp.Body.Close(); e != nil {
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return
+}
+
+// DisconnectWithReason records why the node is disconnecting the peer, for the peerdisconnected notification, and
+// disconnects it. Only the first reason given is kept.
+func (np *NodePeer) DisconnectWithReason(reason string) {
+	if np.disconnectReason.Load() == "" {
+		np.disconnectReason.Store(reason)
+	}
+	np.Disconnect()
+}
+
+// peerEventResult returns the details of the peer sent with peer events.
+func (np *NodePeer) peerEventResult(reason string) btcjson.PeerEventResult {
+	return btcjson.PeerEventResult{
+		ID:         np.ID(),
+		Addr:       np.Addr(),
+		Inbound:    np.Inbound(),
+		Persistent: np.Persistent,
+		Services:   np.Services().String(),
+		SubVer:     np.UserAgent(),
+		Time:       time.Now().Unix(),
+		Reason:     reason,
+	}
+}
+
+// AnnouncePeerConnected notifies websocket clients and the peer events webhook that the peer was added to the
+// connected peers.
+func (n *Node) AnnouncePeerConnected(sp *NodePeer) {
+	n.announcePeerEvent(btcjson.PeerConnectedNtfnMethod, sp.peerEventResult(""))
+}
+
+// AnnouncePeerDisconnected notifies websocket clients and the peer events webhook that the peer was removed from the
+// connected peers, with the reason the node disconnected it.
+func (n *Node) AnnouncePeerDisconnected(sp *NodePeer) {
+	reason := sp.disconnectReason.Load()
+	if reason == "" {
+		reason = ReasonConnectionClosed
+	}
+	n.announcePeerEvent(btcjson.PeerDisconnectedNtfnMethod, sp.peerEventResult(reason))
+}
+
+func (n *Node) announcePeerEvent(method string, peer btcjson.PeerEventResult) {
+	if n.PeerEventsWebhook != nil {
+		n.PeerEventsWebhook.Post(PeerEvent{Event: method, Peer: peer})
+	}
+	// Notifications only happen when the RPC server is active.
+	if n.Config.DisableRPC.True() {
+		return
+	}
+	for i := range n.RPCServers {
+		if n.RPCServers[i] != nil {
+			n.RPCServers[i].NotifyPeerEvent(method, peer)
+		}
+	}
+}
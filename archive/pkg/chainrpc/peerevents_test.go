@@ -0,0 +1,50 @@
+package chainrpc
+
+import (
+	js "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// TestPeerEventsWebhook ensures peer events are posted to the webhook as JSON in the order they happened.
+func TestPeerEventsWebhook(t *testing.T) {
+	received := make(chan PeerEvent, 2)
+	srv := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				var ev PeerEvent
+				if e := js.NewDecoder(r.Body).Decode(&ev); e != nil {
+					t.Errorf("can't decode peer event: %v", e)
+				}
+				received <- ev
+			},
+		),
+	)
+	defer srv.Close()
+	quit := qu.T()
+	defer quit.Q()
+	w := NewPeerEventsWebhook(srv.URL, quit)
+	peer := btcjson.PeerEventResult{ID: 1, Addr: "127.0.0.1:11047", Inbound: true}
+	w.Post(PeerEvent{Event: btcjson.PeerConnectedNtfnMethod, Peer: peer})
+	peer.Reason = ReasonBanScore
+	w.Post(PeerEvent{Event: btcjson.PeerDisconnectedNtfnMethod, Peer: peer})
+	for _, want := range []PeerEvent{
+		{Event: btcjson.PeerConnectedNtfnMethod, Peer: btcjson.PeerEventResult{ID: 1, Addr: "127.0.0.1:11047", Inbound: true}},
+		{Event: btcjson.PeerDisconnectedNtfnMethod, Peer: peer},
+	} {
+		select {
+		case ev := <-received:
+			if ev != want {
+				t.Fatalf("got peer event %+v, want %+v", ev, want)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("timed out waiting for %s event", want.Event)
+		}
+	}
+}
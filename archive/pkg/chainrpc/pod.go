@@ -38,12 +38,17 @@ var Dial = func(stateCfg *active.Config) func(addr net.Addr) (net.Conn, error) {
 // Lookup resolves the IP of the given host using the correct DNS lookup function depending on the configuration
 // options. For example, addresses will be resolved using tor when the --proxy flag was specified unless --noonion was
 // also specified in which case the normal system DNS resolver will be used. Any attempt to resolve a tor address (.
-// onion) will return an error since they are not intended to be resolved outside of the tor proxy.
-var Lookup = func(stateCfg *active.Config) connmgr.LookupFunc {
+// onion) will return an error since they are not intended to be resolved outside of the tor proxy. The lookups are
+// recorded by the resolver of the node for the purpose, so the paths names were resolved by can be audited.
+var Lookup = func(stateCfg *active.Config, purpose string) connmgr.LookupFunc {
+	lookup := stateCfg.Lookup
+	if stateCfg.Resolver != nil {
+		lookup = stateCfg.Resolver.Lookup(purpose)
+	}
 	return func(host string) ([]net.IP, error) {
 		if strings.HasSuffix(host, ".onion") {
 			return nil, fmt.Errorf("attempt to resolve tor address %s", host)
 		}
-		return stateCfg.Lookup(host)
+		return lookup(host)
 	}
 }
@@ -0,0 +1,190 @@
+package chainrpc
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/addrmgr"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Whether the node accepts inbound connections, as reported by getnetworkinfo.
+const (
+	ReachabilityUnknown     = "unknown"
+	ReachabilityReachable   = "reachable"
+	ReachabilityUnreachable = "unreachable"
+)
+
+// How the reachability of the node was found out.
+const (
+	// ReachabilitySelfConnect means the node connected to itself through its external address.
+	ReachabilitySelfConnect = "selfconnect"
+	// ReachabilityInbound means a peer on the internet connected to the node.
+	ReachabilityInbound = "inbound"
+)
+
+const (
+	// reachabilityProbeTimeout is how long a self connection may take to arrive at the listener.
+	reachabilityProbeTimeout = time.Second * 30
+	// reachabilityInboundAge is how long an inbound connection from the internet is taken as evidence the node is
+	// reachable. Many routers do not forward connections from inside the network to its external address, so a failed
+	// self connection does not override it.
+	reachabilityInboundAge = time.Hour
+)
+
+// Reachability keeps track of whether the listener of the node can be reached from the internet, from the port mapping
+// made on the router, connections of the node to itself through the mapped address and inbound connections from
+// routable addresses.
+type Reachability struct {
+	btcnet      wire.BitcoinNet
+	mtx         sync.Mutex
+	status      string
+	method      string
+	protocol    string
+	external    string
+	checked     time.Time
+	lastInbound time.Time
+	lastError   string
+	// probes are the self connections waiting to arrive at the listener, by the nonce of their version message.
+	probes map[uint64]chan struct{}
+}
+
+// NewReachability returns a Reachability for a node on the network with the magic number btcnet.
+func NewReachability(btcnet wire.BitcoinNet) *Reachability {
+	return &Reachability{
+		btcnet: btcnet,
+		status: ReachabilityUnknown,
+		probes: make(map[uint64]chan struct{}),
+	}
+}
+
+// SetMapping records the result of mapping the listening port on the router with the NAT protocol, giving the external
+// address the listener is mapped to, or the error the mapping failed with.
+func (r *Reachability) SetMapping(protocol, external string, e error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.protocol = protocol
+	r.checked = time.Now()
+	if e != nil {
+		r.lastError = e.Error()
+		if !r.recentInbound() {
+			r.status, r.method = ReachabilityUnreachable, ""
+		}
+		return
+	}
+	r.external = external
+	r.lastError = ""
+}
+
+// Inbound records an inbound connection from the remote address, which shows the node is reachable if it is routable.
+func (r *Reachability) Inbound(remote net.IP) {
+	if remote == nil || !addrmgr.IsRoutable(wire.NewNetAddressIPPort(remote, 0, 0)) {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.lastInbound = time.Now()
+	r.checked = r.lastInbound
+	r.status, r.method = ReachabilityReachable, ReachabilityInbound
+	r.lastError = ""
+}
+
+// SelfConnected is called with the nonce of the version message of an inbound connection from the node itself, and
+// completes the probe that sent it.
+func (r *Reachability) SelfConnected(nonce uint64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if arrived, ok := r.probes[nonce]; ok {
+		close(arrived)
+		delete(r.probes, nonce)
+	}
+}
+
+// Probe connects to the node through the external address and sends a version message, which the listener recognises
+// as coming from the node itself once the connection arrives. It returns whether the connection arrived before the
+// timeout, and records the result.
+func (r *Reachability) Probe(addr string, quit qu.C) (reachable bool) {
+	nonce := uint64(rand.Int63())
+	arrived := make(chan struct{})
+	r.mtx.Lock()
+	r.probes[nonce] = arrived
+	r.mtx.Unlock()
+	defer func() {
+		r.mtx.Lock()
+		delete(r.probes, nonce)
+		r.mtx.Unlock()
+	}()
+	e := r.probe(addr, nonce, arrived, quit)
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.checked = time.Now()
+	if e != nil {
+		D.Ln("reachability probe of", addr, "failed:", e)
+		r.lastError = e.Error()
+		if !r.recentInbound() {
+			r.status, r.method = ReachabilityUnreachable, ""
+		}
+		return false
+	}
+	I.Ln("listener is reachable at", addr)
+	r.status, r.method = ReachabilityReachable, ReachabilitySelfConnect
+	r.lastError = ""
+	return true
+}
+
+// probe dials the address and waits for the version message with the nonce to arrive at the listener.
+func (r *Reachability) probe(addr string, nonce uint64, arrived chan struct{}, quit qu.C) (e error) {
+	var conn net.Conn
+	if conn, e = net.DialTimeout("tcp", addr, reachabilityProbeTimeout); e != nil {
+		return
+	}
+	defer func() {
+		if e := conn.Close(); E.Chk(e) {
+		}
+	}()
+	you := wire.NewNetAddress(conn.RemoteAddr().(*net.TCPAddr), 0)
+	me := wire.NewNetAddress(conn.LocalAddr().(*net.TCPAddr), 0)
+	// The nonce is one the node sent itself, so the listener drops the connection as it does any self connection.
+	peer.SentNonces.Add(nonce)
+	msg := wire.NewMsgVersion(me, you, nonce, 0)
+	if e = wire.WriteMessage(conn, msg, peer.MaxProtocolVersion, r.btcnet); e != nil {
+		return
+	}
+	select {
+	case <-arrived:
+		return nil
+	case <-time.After(reachabilityProbeTimeout):
+		return errors.New("connection to the external address did not arrive at the listener")
+	case <-quit.Wait():
+		return errors.New("node is shutting down")
+	}
+}
+
+// recentInbound returns whether an inbound connection from the internet was seen recently. The mutex must be held.
+func (r *Reachability) recentInbound() bool {
+	return !r.lastInbound.IsZero() && time.Since(r.lastInbound) < reachabilityInboundAge
+}
+
+// Info returns the reachability for the getnetworkinfo command.
+func (r *Reachability) Info() (result btcjson.ReachabilityResult) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	result = btcjson.ReachabilityResult{
+		Status:          r.status,
+		Method:          r.method,
+		NAT:             r.protocol,
+		ExternalAddress: r.external,
+		Error:           r.lastError,
+	}
+	if !r.checked.IsZero() {
+		result.LastChecked = r.checked.Unix()
+	}
+	return
+}
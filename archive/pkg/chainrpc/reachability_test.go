@@ -0,0 +1,71 @@
+package chainrpc
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestReachabilityProbe ensures a probe is reported reachable once its version message arrives at the listener and the
+// listener reports the self connection.
+func TestReachabilityProbe(t *testing.T) {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer l.Close()
+	r := NewReachability(wire.MainNet)
+	go func() {
+		conn, e := l.Accept()
+		if e != nil {
+			return
+		}
+		defer conn.Close()
+		msg, _, e := wire.ReadMessage(conn, peer.MaxProtocolVersion, wire.MainNet)
+		if e != nil {
+			t.Error(e)
+			return
+		}
+		version := msg.(*wire.MsgVersion)
+		if !peer.SentNonces.Exists(version.Nonce) {
+			t.Error("probe nonce is not a sent nonce")
+		}
+		r.SelfConnected(version.Nonce)
+	}()
+	quit := qu.T()
+	defer quit.Q()
+	if !r.Probe(l.Addr().String(), quit) {
+		t.Fatalf("probe failed: %s", r.Info().Error)
+	}
+	info := r.Info()
+	if info.Status != ReachabilityReachable || info.Method != ReachabilitySelfConnect || info.LastChecked == 0 {
+		t.Fatalf("reachability is %+v", info)
+	}
+}
+
+// TestReachabilityInbound ensures only inbound connections from routable addresses show the node is reachable, and
+// that they are not overridden by a failed mapping.
+func TestReachabilityInbound(t *testing.T) {
+	r := NewReachability(wire.MainNet)
+	if info := r.Info(); info.Status != ReachabilityUnknown || info.LastChecked != 0 {
+		t.Fatalf("new reachability is %+v", info)
+	}
+	r.Inbound(net.ParseIP("192.168.1.20"))
+	if info := r.Info(); info.Status != ReachabilityUnknown {
+		t.Fatalf("connection from a private address made reachability %+v", info)
+	}
+	r.Inbound(net.ParseIP("8.8.8.8"))
+	r.SetMapping("upnp", "", errors.New("no mapping"))
+	info := r.Info()
+	if info.Status != ReachabilityReachable || info.Method != ReachabilityInbound || info.NAT != "upnp" {
+		t.Fatalf("reachability is %+v", info)
+	}
+	if info.Error != "no mapping" {
+		t.Fatalf("error is %q", info.Error)
+	}
+}
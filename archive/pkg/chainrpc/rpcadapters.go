@@ -5,6 +5,7 @@ import (
 
 	"github.com/p9c/pod/pkg/block"
 
+	"github.com/p9c/pod/pkg/addrmgr"
 	"github.com/p9c/pod/pkg/blockchain"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/mempool"
@@ -50,6 +51,14 @@ func (p *Peer) GetFeeFilter() int64 {
 	return atomic.LoadInt64(&(*NodePeer)(p).FeeFilter)
 }
 
+// GetMsgStats returns the number of messages received from the peer by command, the number of times each misbehaving
+// message pattern was detected by name, and the number of messages ignored because of throttling.
+//
+// This function is safe for concurrent access and is part of the RPCServerPeer interface implementation.
+func (p *Peer) GetMsgStats() (recv, patterns map[string]uint64, dropped uint64) {
+	return (*NodePeer)(p).MsgStats.Snapshot()
+}
+
 // ConnManager provides a connection manager for use with the RPC Server and implements the rpcserver ConnManager
 // interface.
 type ConnManager struct {
@@ -149,6 +158,28 @@ func (cm *ConnManager) NetTotals() (uint64, uint64) {
 	return cm.Server.NetTotals()
 }
 
+// Services returns the services the node offers to peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Services() wire.ServiceFlag {
+	return cm.Server.Services
+}
+
+// LocalAddresses returns the local addresses the node advertises to peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalAddresses() []addrmgr.LocalAddress {
+	return cm.Server.AddrManager.LocalAddresses()
+}
+
+// NodeAddresses returns at most count randomly chosen known addresses of nodes that are routable and not bad, or all
+// of them if count is zero, on the named network unless it is empty.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) NodeAddresses(count int, network string) []wire.NetAddress {
+	return cm.Server.AddrManager.NodeAddresses(count, network)
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
@@ -241,6 +272,20 @@ func (b *SyncManager) SyncPeerID() int32 {
 	return b.SyncMgr.SyncPeerID()
 }
 
+// BlockPropagation returns how the most recently connected blocks announced by peers reached the node, oldest first.
+//
+// This function is safe for concurrent access and is part of the RPCServerSyncManager interface implementation.
+func (b *SyncManager) BlockPropagation() []netsync.BlockPropagation {
+	return b.SyncMgr.BlockPropagation()
+}
+
+// OrphanRequests returns the requests for the missing parents of the chains of orphan blocks held by the chain.
+//
+// This function is safe for concurrent access and is part of the RPCServerSyncManager interface implementation.
+func (b *SyncManager) OrphanRequests() netsync.OrphanRequests {
+	return b.SyncMgr.OrphanRequests()
+}
+
 // LocateHeaders returns the hashes of the blocks after the first known block in
 // the provided locators until the provided
 // stop hash or the current tip is reached, up to a max of wire.MaxBlockHeadersPerMsg hashes.
@@ -55,14 +55,22 @@ type (
 	None struct{} 
 	// AddNodeRes is the result from a call to AddNode
 	AddNodeRes struct { Res *None; Err error }
+	// CalculatePackageFeeRes is the result from a call to CalculatePackageFee
+	CalculatePackageFeeRes struct { Res *btcjson.CalculatePackageFeeResult; Err error }
 	// CreateRawTransactionRes is the result from a call to CreateRawTransaction
 	CreateRawTransactionRes struct { Res *string; Err error }
 	// DecodeRawTransactionRes is the result from a call to DecodeRawTransaction
 	DecodeRawTransactionRes struct { Res *btcjson.TxRawDecodeResult; Err error }
 	// DecodeScriptRes is the result from a call to DecodeScript
 	DecodeScriptRes struct { Res *btcjson.DecodeScriptResult; Err error }
+	// DeriveAddressesRes is the result from a call to DeriveAddresses
+	DeriveAddressesRes struct { Res *[]string; Err error }
 	// EstimateFeeRes is the result from a call to EstimateFee
 	EstimateFeeRes struct { Res *float64; Err error }
+	// EstimateSmartFeeRes is the result from a call to EstimateSmartFee
+	EstimateSmartFeeRes struct { Res *btcjson.EstimateSmartFeeResult; Err error }
+	// EstimatorStatsRes is the result from a call to EstimatorStats
+	EstimatorStatsRes struct { Res *btcjson.EstimatorStatsResult; Err error }
 	// GenerateRes is the result from a call to Generate
 	GenerateRes struct { Res *[]string; Err error }
 	// GetAddedNodeInfoRes is the result from a call to GetAddedNodeInfo
@@ -81,8 +89,12 @@ type (
 	GetBlockHashRes struct { Res *string; Err error }
 	// GetBlockHeaderRes is the result from a call to GetBlockHeader
 	GetBlockHeaderRes struct { Res *btcjson.GetBlockHeaderVerboseResult; Err error }
+	// GetBlockPropagationRes is the result from a call to GetBlockPropagation
+	GetBlockPropagationRes struct { Res *btcjson.GetBlockPropagationResult; Err error }
 	// GetBlockTemplateRes is the result from a call to GetBlockTemplate
 	GetBlockTemplateRes struct { Res *string; Err error }
+	// GetCacheStatsRes is the result from a call to GetCacheStats
+	GetCacheStatsRes struct { Res *btcjson.GetCacheStatsResult; Err error }
 	// GetCFilterRes is the result from a call to GetCFilter
 	GetCFilterRes struct { Res *string; Err error }
 	// GetCFilterHeaderRes is the result from a call to GetCFilterHeader
@@ -91,52 +103,96 @@ type (
 	GetConnectionCountRes struct { Res *int32; Err error }
 	// GetCurrentNetRes is the result from a call to GetCurrentNet
 	GetCurrentNetRes struct { Res *string; Err error }
+	// GetDescriptorInfoRes is the result from a call to GetDescriptorInfo
+	GetDescriptorInfoRes struct { Res *btcjson.GetDescriptorInfoResult; Err error }
 	// GetDifficultyRes is the result from a call to GetDifficulty
 	GetDifficultyRes struct { Res *float64; Err error }
+	// GetDNSAuditRes is the result from a call to GetDNSAudit
+	GetDNSAuditRes struct { Res *btcjson.GetDNSAuditResult; Err error }
+	// GetFeaturesRes is the result from a call to GetFeatures
+	GetFeaturesRes struct { Res *[]btcjson.FeatureResult; Err error }
 	// GetGenerateRes is the result from a call to GetGenerate
 	GetGenerateRes struct { Res *bool; Err error }
 	// GetHashesPerSecRes is the result from a call to GetHashesPerSec
 	GetHashesPerSecRes struct { Res *float64; Err error }
 	// GetHeadersRes is the result from a call to GetHeaders
 	GetHeadersRes struct { Res *[]string; Err error }
+	// GetIndexInfoRes is the result from a call to GetIndexInfo
+	GetIndexInfoRes struct { Res *map[string]btcjson.GetIndexInfoResult; Err error }
 	// GetInfoRes is the result from a call to GetInfo
 	GetInfoRes struct { Res *btcjson.InfoChainResult0; Err error }
 	// GetMempoolInfoRes is the result from a call to GetMempoolInfo
 	GetMempoolInfoRes struct { Res *btcjson.GetMempoolInfoResult; Err error }
+	// GetMiningAddressesRes is the result from a call to GetMiningAddresses
+	GetMiningAddressesRes struct { Res *btcjson.GetMiningAddressesResult; Err error }
+	// GetMiningExpectationRes is the result from a call to GetMiningExpectation
+	GetMiningExpectationRes struct { Res *btcjson.GetMiningExpectationResult; Err error }
 	// GetMiningInfoRes is the result from a call to GetMiningInfo
 	GetMiningInfoRes struct { Res *btcjson.GetMiningInfoResult; Err error }
+	// GetMiningTemplateRes is the result from a call to GetMiningTemplate
+	GetMiningTemplateRes struct { Res *btcjson.GetMiningTemplateResult; Err error }
 	// GetNetTotalsRes is the result from a call to GetNetTotals
 	GetNetTotalsRes struct { Res *btcjson.GetNetTotalsResult; Err error }
 	// GetNetworkHashPSRes is the result from a call to GetNetworkHashPS
 	GetNetworkHashPSRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
+	// GetNetworkInfoRes is the result from a call to GetNetworkInfo
+	GetNetworkInfoRes struct { Res *btcjson.GetNetworkInfoResult; Err error }
+	// GetNodeAddressesRes is the result from a call to GetNodeAddresses
+	GetNodeAddressesRes struct { Res *[]btcjson.NodeAddressResult; Err error }
+	// GetNotificationInfoRes is the result from a call to GetNotificationInfo
+	GetNotificationInfoRes struct { Res *btcjson.GetNotificationInfoResult; Err error }
+	// GetOrphanBlocksRes is the result from a call to GetOrphanBlocks
+	GetOrphanBlocksRes struct { Res *btcjson.GetOrphanBlocksResult; Err error }
+	// GetOrphanTxsRes is the result from a call to GetOrphanTxs
+	GetOrphanTxsRes struct { Res *btcjson.GetOrphanTxsResult; Err error }
 	// GetPeerInfoRes is the result from a call to GetPeerInfo
 	GetPeerInfoRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
 	// GetRawMempoolRes is the result from a call to GetRawMempool
 	GetRawMempoolRes struct { Res *[]string; Err error }
 	// GetRawTransactionRes is the result from a call to GetRawTransaction
 	GetRawTransactionRes struct { Res *string; Err error }
+	// GetScriptFlagsRes is the result from a call to GetScriptFlags
+	GetScriptFlagsRes struct { Res *btcjson.GetScriptFlagsResult; Err error }
+	// GetTimestampInfoRes is the result from a call to GetTimestampInfo
+	GetTimestampInfoRes struct { Res *btcjson.GetTimestampInfoResult; Err error }
 	// GetTxOutRes is the result from a call to GetTxOut
 	GetTxOutRes struct { Res *string; Err error }
+	// GetValidationTraceRes is the result from a call to GetValidationTrace
+	GetValidationTraceRes struct { Res *[]btcjson.GetValidationTraceResult; Err error }
+	// GetWorkSourceRes is the result from a call to GetWorkSource
+	GetWorkSourceRes struct { Res *btcjson.GetWorkSourceResult; Err error }
 	// HelpRes is the result from a call to Help
 	HelpRes struct { Res *string; Err error }
 	// NodeRes is the result from a call to Node
 	NodeRes struct { Res *None; Err error }
 	// PingRes is the result from a call to Ping
 	PingRes struct { Res *None; Err error }
+	// PrioritiseTransactionRes is the result from a call to PrioritiseTransaction
+	PrioritiseTransactionRes struct { Res *bool; Err error }
 	// ResetChainRes is the result from a call to ResetChain
 	ResetChainRes struct { Res *None; Err error }
 	// RestartRes is the result from a call to Restart
 	RestartRes struct { Res *None; Err error }
 	// SearchRawTransactionsRes is the result from a call to SearchRawTransactions
 	SearchRawTransactionsRes struct { Res *[]btcjson.SearchRawTransactionsResult; Err error }
+	// SelfTestRes is the result from a call to SelfTest
+	SelfTestRes struct { Res *btcjson.SelfTestResult; Err error }
 	// SendRawTransactionRes is the result from a call to SendRawTransaction
 	SendRawTransactionRes struct { Res *None; Err error }
+	// SetConfigRes is the result from a call to SetConfig
+	SetConfigRes struct { Res *None; Err error }
+	// SetFeatureRes is the result from a call to SetFeature
+	SetFeatureRes struct { Res *None; Err error }
 	// SetGenerateRes is the result from a call to SetGenerate
 	SetGenerateRes struct { Res *None; Err error }
+	// SetMiningAddressesRes is the result from a call to SetMiningAddresses
+	SetMiningAddressesRes struct { Res *None; Err error }
 	// StopRes is the result from a call to Stop
 	StopRes struct { Res *None; Err error }
 	// SubmitBlockRes is the result from a call to SubmitBlock
 	SubmitBlockRes struct { Res *string; Err error }
+	// TraceMempoolAcceptRes is the result from a call to TraceMempoolAccept
+	TraceMempoolAcceptRes struct { Res *btcjson.TraceMempoolAcceptResult; Err error }
 	// UptimeRes is the result from a call to Uptime
 	UptimeRes struct { Res *btcjson.GetMempoolInfoResult; Err error }
 	// ValidateAddressRes is the result from a call to ValidateAddress
@@ -166,6 +222,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"addnode":{ 
 		Fn: HandleAddNode, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan AddNodeRes)} }}, 
+	"calculatepackagefee":{ 
+		Fn: HandleCalculatePackageFee, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan CalculatePackageFeeRes)} }}, 
 	"createrawtransaction":{ 
 		Fn: HandleCreateRawTransaction, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan CreateRawTransactionRes)} }}, 
@@ -175,9 +234,18 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"decodescript":{ 
 		Fn: HandleDecodeScript, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan DecodeScriptRes)} }}, 
+	"deriveaddresses":{ 
+		Fn: HandleDeriveAddresses, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan DeriveAddressesRes)} }}, 
 	"estimatefee":{ 
 		Fn: HandleEstimateFee, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan EstimateFeeRes)} }}, 
+	"estimatesmartfee":{ 
+		Fn: HandleEstimateSmartFee, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan EstimateSmartFeeRes)} }}, 
+	"estimatorstats":{ 
+		Fn: HandleEstimatorStats, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan EstimatorStatsRes)} }}, 
 	"generate":{ 
 		Fn: HandleGenerate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GenerateRes)} }}, 
@@ -205,9 +273,15 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getblockheader":{ 
 		Fn: HandleGetBlockHeader, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetBlockHeaderRes)} }}, 
+	"getblockpropagation":{ 
+		Fn: HandleGetBlockPropagation, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetBlockPropagationRes)} }}, 
 	"getblocktemplate":{ 
 		Fn: HandleGetBlockTemplate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetBlockTemplateRes)} }}, 
+	"getcachestats":{ 
+		Fn: HandleGetCacheStats, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetCacheStatsRes)} }}, 
 	"getcfilter":{ 
 		Fn: HandleGetCFilter, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetCFilterRes)} }}, 
@@ -220,9 +294,18 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getcurrentnet":{ 
 		Fn: HandleGetCurrentNet, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetCurrentNetRes)} }}, 
+	"getdescriptorinfo":{ 
+		Fn: HandleGetDescriptorInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetDescriptorInfoRes)} }}, 
 	"getdifficulty":{ 
 		Fn: HandleGetDifficulty, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetDifficultyRes)} }}, 
+	"getdnsaudit":{ 
+		Fn: HandleGetDNSAudit, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetDNSAuditRes)} }}, 
+	"getfeatures":{ 
+		Fn: HandleGetFeatures, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetFeaturesRes)} }}, 
 	"getgenerate":{ 
 		Fn: HandleGetGenerate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetGenerateRes)} }}, 
@@ -232,21 +315,48 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getheaders":{ 
 		Fn: HandleGetHeaders, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetHeadersRes)} }}, 
+	"getindexinfo":{ 
+		Fn: HandleGetIndexInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetIndexInfoRes)} }}, 
 	"getinfo":{ 
 		Fn: HandleGetInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetInfoRes)} }}, 
 	"getmempoolinfo":{ 
 		Fn: HandleGetMempoolInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetMempoolInfoRes)} }}, 
+	"getminingaddresses":{ 
+		Fn: HandleGetMiningAddresses, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMiningAddressesRes)} }}, 
+	"getminingexpectation":{ 
+		Fn: HandleGetMiningExpectation, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMiningExpectationRes)} }}, 
 	"getmininginfo":{ 
 		Fn: HandleGetMiningInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetMiningInfoRes)} }}, 
+	"getminingtemplate":{ 
+		Fn: HandleGetMiningTemplate, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMiningTemplateRes)} }}, 
 	"getnettotals":{ 
 		Fn: HandleGetNetTotals, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetNetTotalsRes)} }}, 
 	"getnetworkhashps":{ 
 		Fn: HandleGetNetworkHashPS, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetNetworkHashPSRes)} }}, 
+	"getnetworkinfo":{ 
+		Fn: HandleGetNetworkInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetNetworkInfoRes)} }}, 
+	"getnodeaddresses":{ 
+		Fn: HandleGetNodeAddresses, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetNodeAddressesRes)} }}, 
+	"getnotificationinfo":{ 
+		Fn: HandleGetNotificationInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetNotificationInfoRes)} }}, 
+	"getorphanblocks":{ 
+		Fn: HandleGetOrphanBlocks, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetOrphanBlocksRes)} }}, 
+	"getorphantxs":{ 
+		Fn: HandleGetOrphanTxs, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetOrphanTxsRes)} }}, 
 	"getpeerinfo":{ 
 		Fn: HandleGetPeerInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetPeerInfoRes)} }}, 
@@ -256,9 +366,21 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getrawtransaction":{ 
 		Fn: HandleGetRawTransaction, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetRawTransactionRes)} }}, 
+	"getscriptflags":{ 
+		Fn: HandleGetScriptFlags, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetScriptFlagsRes)} }}, 
+	"gettimestampinfo":{ 
+		Fn: HandleGetTimestampInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetTimestampInfoRes)} }}, 
 	"gettxout":{ 
 		Fn: HandleGetTxOut, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetTxOutRes)} }}, 
+	"getvalidationtrace":{ 
+		Fn: HandleGetValidationTrace, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetValidationTraceRes)} }}, 
+	"getworksource":{ 
+		Fn: HandleGetWorkSource, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetWorkSourceRes)} }}, 
 	"help":{ 
 		Fn: HandleHelp, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan HelpRes)} }}, 
@@ -268,6 +390,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"ping":{ 
 		Fn: HandlePing, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan PingRes)} }}, 
+	"prioritisetransaction":{ 
+		Fn: HandlePrioritiseTransaction, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan PrioritiseTransactionRes)} }}, 
 	"resetchain":{ 
 		Fn: HandleResetChain, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan ResetChainRes)} }}, 
@@ -277,18 +402,33 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"searchrawtransactions":{ 
 		Fn: HandleSearchRawTransactions, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SearchRawTransactionsRes)} }}, 
+	"selftest":{ 
+		Fn: HandleSelfTest, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SelfTestRes)} }}, 
 	"sendrawtransaction":{ 
 		Fn: HandleSendRawTransaction, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SendRawTransactionRes)} }}, 
+	"setconfig":{ 
+		Fn: HandleSetConfig, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SetConfigRes)} }}, 
+	"setfeature":{ 
+		Fn: HandleSetFeature, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SetFeatureRes)} }}, 
 	"setgenerate":{ 
 		Fn: HandleSetGenerate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SetGenerateRes)} }}, 
+	"setminingaddresses":{ 
+		Fn: HandleSetMiningAddresses, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SetMiningAddressesRes)} }}, 
 	"stop":{ 
 		Fn: HandleStop, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan StopRes)} }}, 
 	"submitblock":{ 
 		Fn: HandleSubmitBlock, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SubmitBlockRes)} }}, 
+	"tracemempoolaccept":{ 
+		Fn: HandleTraceMempoolAccept, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan TraceMempoolAcceptRes)} }}, 
 	"uptime":{ 
 		Fn: HandleUptime, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan UptimeRes)} }}, 
@@ -355,6 +495,47 @@ func (a API) AddNodeWait(cmd *btcjson.AddNodeCmd) (out *None, e error) {
 	return
 }
 
+// CalculatePackageFee calls the method with the given parameters
+func (a API) CalculatePackageFee(cmd *btcjson.CalculatePackageFeeCmd) (e error) {
+	RPCHandlers["calculatepackagefee"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// CalculatePackageFeeChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) CalculatePackageFeeChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan CalculatePackageFeeRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// CalculatePackageFeeGetRes returns a pointer to the value in the Result field
+func (a API) CalculatePackageFeeGetRes() (out *btcjson.CalculatePackageFeeResult, e error) {
+	out, _ = a.Result.(*btcjson.CalculatePackageFeeResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// CalculatePackageFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CalculatePackageFeeWait(cmd *btcjson.CalculatePackageFeeCmd) (out *btcjson.CalculatePackageFeeResult, e error) {
+	RPCHandlers["calculatepackagefee"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan CalculatePackageFeeRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // CreateRawTransaction calls the method with the given parameters
 func (a API) CreateRawTransaction(cmd *btcjson.CreateRawTransactionCmd) (e error) {
 	RPCHandlers["createrawtransaction"].Call <-API{a.Ch, cmd, nil}
@@ -478,6 +659,47 @@ func (a API) DecodeScriptWait(cmd *btcjson.DecodeScriptCmd) (out *btcjson.Decode
 	return
 }
 
+// DeriveAddresses calls the method with the given parameters
+func (a API) DeriveAddresses(cmd *btcjson.DeriveAddressesCmd) (e error) {
+	RPCHandlers["deriveaddresses"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// DeriveAddressesChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) DeriveAddressesChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan DeriveAddressesRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// DeriveAddressesGetRes returns a pointer to the value in the Result field
+func (a API) DeriveAddressesGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// DeriveAddressesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DeriveAddressesWait(cmd *btcjson.DeriveAddressesCmd) (out *[]string, e error) {
+	RPCHandlers["deriveaddresses"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan DeriveAddressesRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // EstimateFee calls the method with the given parameters
 func (a API) EstimateFee(cmd *btcjson.EstimateFeeCmd) (e error) {
 	RPCHandlers["estimatefee"].Call <-API{a.Ch, cmd, nil}
@@ -519,6 +741,88 @@ func (a API) EstimateFeeWait(cmd *btcjson.EstimateFeeCmd) (out *float64, e error
 	return
 }
 
+// EstimateSmartFee calls the method with the given parameters
+func (a API) EstimateSmartFee(cmd *btcjson.EstimateSmartFeeCmd) (e error) {
+	RPCHandlers["estimatesmartfee"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// EstimateSmartFeeChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) EstimateSmartFeeChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan EstimateSmartFeeRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// EstimateSmartFeeGetRes returns a pointer to the value in the Result field
+func (a API) EstimateSmartFeeGetRes() (out *btcjson.EstimateSmartFeeResult, e error) {
+	out, _ = a.Result.(*btcjson.EstimateSmartFeeResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// EstimateSmartFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EstimateSmartFeeWait(cmd *btcjson.EstimateSmartFeeCmd) (out *btcjson.EstimateSmartFeeResult, e error) {
+	RPCHandlers["estimatesmartfee"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan EstimateSmartFeeRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// EstimatorStats calls the method with the given parameters
+func (a API) EstimatorStats(cmd *btcjson.EstimatorStatsCmd) (e error) {
+	RPCHandlers["estimatorstats"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// EstimatorStatsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) EstimatorStatsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan EstimatorStatsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// EstimatorStatsGetRes returns a pointer to the value in the Result field
+func (a API) EstimatorStatsGetRes() (out *btcjson.EstimatorStatsResult, e error) {
+	out, _ = a.Result.(*btcjson.EstimatorStatsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// EstimatorStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EstimatorStatsWait(cmd *btcjson.EstimatorStatsCmd) (out *btcjson.EstimatorStatsResult, e error) {
+	RPCHandlers["estimatorstats"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan EstimatorStatsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // Generate calls the method with the given parameters
 func (a API) Generate(cmd *None) (e error) {
 	RPCHandlers["generate"].Call <-API{a.Ch, cmd, nil}
@@ -888,6 +1192,47 @@ func (a API) GetBlockHeaderWait(cmd *btcjson.GetBlockHeaderCmd) (out *btcjson.Ge
 	return
 }
 
+// GetBlockPropagation calls the method with the given parameters
+func (a API) GetBlockPropagation(cmd *btcjson.GetBlockPropagationCmd) (e error) {
+	RPCHandlers["getblockpropagation"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockPropagationChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockPropagationChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockPropagationRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockPropagationGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockPropagationGetRes() (out *btcjson.GetBlockPropagationResult, e error) {
+	out, _ = a.Result.(*btcjson.GetBlockPropagationResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetBlockPropagationWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockPropagationWait(cmd *btcjson.GetBlockPropagationCmd) (out *btcjson.GetBlockPropagationResult, e error) {
+	RPCHandlers["getblockpropagation"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetBlockPropagationRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetBlockTemplate calls the method with the given parameters
 func (a API) GetBlockTemplate(cmd *btcjson.GetBlockTemplateCmd) (e error) {
 	RPCHandlers["getblocktemplate"].Call <-API{a.Ch, cmd, nil}
@@ -929,6 +1274,47 @@ func (a API) GetBlockTemplateWait(cmd *btcjson.GetBlockTemplateCmd) (out *string
 	return
 }
 
+// GetCacheStats calls the method with the given parameters
+func (a API) GetCacheStats(cmd *None) (e error) {
+	RPCHandlers["getcachestats"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetCacheStatsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetCacheStatsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetCacheStatsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetCacheStatsGetRes returns a pointer to the value in the Result field
+func (a API) GetCacheStatsGetRes() (out *btcjson.GetCacheStatsResult, e error) {
+	out, _ = a.Result.(*btcjson.GetCacheStatsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetCacheStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetCacheStatsWait(cmd *None) (out *btcjson.GetCacheStatsResult, e error) {
+	RPCHandlers["getcachestats"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetCacheStatsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetCFilter calls the method with the given parameters
 func (a API) GetCFilter(cmd *btcjson.GetCFilterCmd) (e error) {
 	RPCHandlers["getcfilter"].Call <-API{a.Ch, cmd, nil}
@@ -1093,6 +1479,47 @@ func (a API) GetCurrentNetWait(cmd *None) (out *string, e error) {
 	return
 }
 
+// GetDescriptorInfo calls the method with the given parameters
+func (a API) GetDescriptorInfo(cmd *btcjson.GetDescriptorInfoCmd) (e error) {
+	RPCHandlers["getdescriptorinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetDescriptorInfoChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetDescriptorInfoChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetDescriptorInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetDescriptorInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetDescriptorInfoGetRes() (out *btcjson.GetDescriptorInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetDescriptorInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetDescriptorInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetDescriptorInfoWait(cmd *btcjson.GetDescriptorInfoCmd) (out *btcjson.GetDescriptorInfoResult, e error) {
+	RPCHandlers["getdescriptorinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetDescriptorInfoRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetDifficulty calls the method with the given parameters
 func (a API) GetDifficulty(cmd *btcjson.GetDifficultyCmd) (e error) {
 	RPCHandlers["getdifficulty"].Call <-API{a.Ch, cmd, nil}
@@ -1134,6 +1561,88 @@ func (a API) GetDifficultyWait(cmd *btcjson.GetDifficultyCmd) (out *float64, e e
 	return
 }
 
+// GetDNSAudit calls the method with the given parameters
+func (a API) GetDNSAudit(cmd *None) (e error) {
+	RPCHandlers["getdnsaudit"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetDNSAuditChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetDNSAuditChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetDNSAuditRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetDNSAuditGetRes returns a pointer to the value in the Result field
+func (a API) GetDNSAuditGetRes() (out *btcjson.GetDNSAuditResult, e error) {
+	out, _ = a.Result.(*btcjson.GetDNSAuditResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetDNSAuditWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetDNSAuditWait(cmd *None) (out *btcjson.GetDNSAuditResult, e error) {
+	RPCHandlers["getdnsaudit"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetDNSAuditRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetFeatures calls the method with the given parameters
+func (a API) GetFeatures(cmd *None) (e error) {
+	RPCHandlers["getfeatures"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetFeaturesChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetFeaturesChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetFeaturesRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetFeaturesGetRes returns a pointer to the value in the Result field
+func (a API) GetFeaturesGetRes() (out *[]btcjson.FeatureResult, e error) {
+	out, _ = a.Result.(*[]btcjson.FeatureResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetFeaturesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetFeaturesWait(cmd *None) (out *[]btcjson.FeatureResult, e error) {
+	RPCHandlers["getfeatures"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetFeaturesRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetGenerate calls the method with the given parameters
 func (a API) GetGenerate(cmd *btcjson.GetHeadersCmd) (e error) {
 	RPCHandlers["getgenerate"].Call <-API{a.Ch, cmd, nil}
@@ -1257,9 +1766,50 @@ func (a API) GetHeadersWait(cmd *btcjson.GetHeadersCmd) (out *[]string, e error)
 	return
 }
 
-// GetInfo calls the method with the given parameters
-func (a API) GetInfo(cmd *None) (e error) {
-	RPCHandlers["getinfo"].Call <-API{a.Ch, cmd, nil}
+// GetIndexInfo calls the method with the given parameters
+func (a API) GetIndexInfo(cmd *btcjson.GetIndexInfoCmd) (e error) {
+	RPCHandlers["getindexinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetIndexInfoChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetIndexInfoChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetIndexInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetIndexInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetIndexInfoGetRes() (out *map[string]btcjson.GetIndexInfoResult, e error) {
+	out, _ = a.Result.(*map[string]btcjson.GetIndexInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetIndexInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetIndexInfoWait(cmd *btcjson.GetIndexInfoCmd) (out *map[string]btcjson.GetIndexInfoResult, e error) {
+	RPCHandlers["getindexinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetIndexInfoRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetInfo calls the method with the given parameters
+func (a API) GetInfo(cmd *None) (e error) {
+	RPCHandlers["getinfo"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
@@ -1339,17 +1889,17 @@ func (a API) GetMempoolInfoWait(cmd *None) (out *btcjson.GetMempoolInfoResult, e
 	return
 }
 
-// GetMiningInfo calls the method with the given parameters
-func (a API) GetMiningInfo(cmd *None) (e error) {
-	RPCHandlers["getmininginfo"].Call <-API{a.Ch, cmd, nil}
+// GetMiningAddresses calls the method with the given parameters
+func (a API) GetMiningAddresses(cmd *None) (e error) {
+	RPCHandlers["getminingaddresses"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetMiningInfoChk checks if a new message arrived on the result channel and
+// GetMiningAddressesChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetMiningInfoChk() (isNew bool) {
+func (a API) GetMiningAddressesChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetMiningInfoRes):
+	case o := <-a.Ch.(chan GetMiningAddressesRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1361,36 +1911,36 @@ func (a API) GetMiningInfoChk() (isNew bool) {
 	return
 }
 
-// GetMiningInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetMiningInfoGetRes() (out *btcjson.GetMiningInfoResult, e error) {
-	out, _ = a.Result.(*btcjson.GetMiningInfoResult)
+// GetMiningAddressesGetRes returns a pointer to the value in the Result field
+func (a API) GetMiningAddressesGetRes() (out *btcjson.GetMiningAddressesResult, e error) {
+	out, _ = a.Result.(*btcjson.GetMiningAddressesResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetMiningInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetMiningInfoWait(cmd *None) (out *btcjson.GetMiningInfoResult, e error) {
-	RPCHandlers["getmininginfo"].Call <-API{a.Ch, cmd, nil}
+// GetMiningAddressesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMiningAddressesWait(cmd *None) (out *btcjson.GetMiningAddressesResult, e error) {
+	RPCHandlers["getminingaddresses"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetMiningInfoRes):
+	case o := <-a.Ch.(chan GetMiningAddressesRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetNetTotals calls the method with the given parameters
-func (a API) GetNetTotals(cmd *None) (e error) {
-	RPCHandlers["getnettotals"].Call <-API{a.Ch, cmd, nil}
+// GetMiningExpectation calls the method with the given parameters
+func (a API) GetMiningExpectation(cmd *None) (e error) {
+	RPCHandlers["getminingexpectation"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNetTotalsChk checks if a new message arrived on the result channel and
+// GetMiningExpectationChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetNetTotalsChk() (isNew bool) {
+func (a API) GetMiningExpectationChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetNetTotalsRes):
+	case o := <-a.Ch.(chan GetMiningExpectationRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1402,36 +1952,36 @@ func (a API) GetNetTotalsChk() (isNew bool) {
 	return
 }
 
-// GetNetTotalsGetRes returns a pointer to the value in the Result field
-func (a API) GetNetTotalsGetRes() (out *btcjson.GetNetTotalsResult, e error) {
-	out, _ = a.Result.(*btcjson.GetNetTotalsResult)
+// GetMiningExpectationGetRes returns a pointer to the value in the Result field
+func (a API) GetMiningExpectationGetRes() (out *btcjson.GetMiningExpectationResult, e error) {
+	out, _ = a.Result.(*btcjson.GetMiningExpectationResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetNetTotalsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNetTotalsWait(cmd *None) (out *btcjson.GetNetTotalsResult, e error) {
-	RPCHandlers["getnettotals"].Call <-API{a.Ch, cmd, nil}
+// GetMiningExpectationWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMiningExpectationWait(cmd *None) (out *btcjson.GetMiningExpectationResult, e error) {
+	RPCHandlers["getminingexpectation"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetNetTotalsRes):
+	case o := <-a.Ch.(chan GetMiningExpectationRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetNetworkHashPS calls the method with the given parameters
-func (a API) GetNetworkHashPS(cmd *btcjson.GetNetworkHashPSCmd) (e error) {
-	RPCHandlers["getnetworkhashps"].Call <-API{a.Ch, cmd, nil}
+// GetMiningInfo calls the method with the given parameters
+func (a API) GetMiningInfo(cmd *None) (e error) {
+	RPCHandlers["getmininginfo"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNetworkHashPSChk checks if a new message arrived on the result channel and
+// GetMiningInfoChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetNetworkHashPSChk() (isNew bool) {
+func (a API) GetMiningInfoChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetNetworkHashPSRes):
+	case o := <-a.Ch.(chan GetMiningInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1443,36 +1993,36 @@ func (a API) GetNetworkHashPSChk() (isNew bool) {
 	return
 }
 
-// GetNetworkHashPSGetRes returns a pointer to the value in the Result field
-func (a API) GetNetworkHashPSGetRes() (out *[]btcjson.GetPeerInfoResult, e error) {
-	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
+// GetMiningInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetMiningInfoGetRes() (out *btcjson.GetMiningInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetMiningInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetNetworkHashPSWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcjson.GetPeerInfoResult, e error) {
-	RPCHandlers["getnetworkhashps"].Call <-API{a.Ch, cmd, nil}
+// GetMiningInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMiningInfoWait(cmd *None) (out *btcjson.GetMiningInfoResult, e error) {
+	RPCHandlers["getmininginfo"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetNetworkHashPSRes):
+	case o := <-a.Ch.(chan GetMiningInfoRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetPeerInfo calls the method with the given parameters
-func (a API) GetPeerInfo(cmd *None) (e error) {
-	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
+// GetMiningTemplate calls the method with the given parameters
+func (a API) GetMiningTemplate(cmd *btcjson.GetMiningTemplateCmd) (e error) {
+	RPCHandlers["getminingtemplate"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetPeerInfoChk checks if a new message arrived on the result channel and
+// GetMiningTemplateChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetPeerInfoChk() (isNew bool) {
+func (a API) GetMiningTemplateChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetPeerInfoRes):
+	case o := <-a.Ch.(chan GetMiningTemplateRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1484,36 +2034,36 @@ func (a API) GetPeerInfoChk() (isNew bool) {
 	return
 }
 
-// GetPeerInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetPeerInfoGetRes() (out *[]btcjson.GetPeerInfoResult, e error) {
-	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
+// GetMiningTemplateGetRes returns a pointer to the value in the Result field
+func (a API) GetMiningTemplateGetRes() (out *btcjson.GetMiningTemplateResult, e error) {
+	out, _ = a.Result.(*btcjson.GetMiningTemplateResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetPeerInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetPeerInfoWait(cmd *None) (out *[]btcjson.GetPeerInfoResult, e error) {
-	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
+// GetMiningTemplateWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMiningTemplateWait(cmd *btcjson.GetMiningTemplateCmd) (out *btcjson.GetMiningTemplateResult, e error) {
+	RPCHandlers["getminingtemplate"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetPeerInfoRes):
+	case o := <-a.Ch.(chan GetMiningTemplateRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetRawMempool calls the method with the given parameters
-func (a API) GetRawMempool(cmd *btcjson.GetRawMempoolCmd) (e error) {
-	RPCHandlers["getrawmempool"].Call <-API{a.Ch, cmd, nil}
+// GetNetTotals calls the method with the given parameters
+func (a API) GetNetTotals(cmd *None) (e error) {
+	RPCHandlers["getnettotals"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetRawMempoolChk checks if a new message arrived on the result channel and
+// GetNetTotalsChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetRawMempoolChk() (isNew bool) {
+func (a API) GetNetTotalsChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetRawMempoolRes):
+	case o := <-a.Ch.(chan GetNetTotalsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1525,36 +2075,36 @@ func (a API) GetRawMempoolChk() (isNew bool) {
 	return
 }
 
-// GetRawMempoolGetRes returns a pointer to the value in the Result field
-func (a API) GetRawMempoolGetRes() (out *[]string, e error) {
-	out, _ = a.Result.(*[]string)
+// GetNetTotalsGetRes returns a pointer to the value in the Result field
+func (a API) GetNetTotalsGetRes() (out *btcjson.GetNetTotalsResult, e error) {
+	out, _ = a.Result.(*btcjson.GetNetTotalsResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetRawMempoolWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetRawMempoolWait(cmd *btcjson.GetRawMempoolCmd) (out *[]string, e error) {
-	RPCHandlers["getrawmempool"].Call <-API{a.Ch, cmd, nil}
+// GetNetTotalsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetTotalsWait(cmd *None) (out *btcjson.GetNetTotalsResult, e error) {
+	RPCHandlers["getnettotals"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetRawMempoolRes):
+	case o := <-a.Ch.(chan GetNetTotalsRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetRawTransaction calls the method with the given parameters
-func (a API) GetRawTransaction(cmd *btcjson.GetRawTransactionCmd) (e error) {
-	RPCHandlers["getrawtransaction"].Call <-API{a.Ch, cmd, nil}
+// GetNetworkHashPS calls the method with the given parameters
+func (a API) GetNetworkHashPS(cmd *btcjson.GetNetworkHashPSCmd) (e error) {
+	RPCHandlers["getnetworkhashps"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetRawTransactionChk checks if a new message arrived on the result channel and
+// GetNetworkHashPSChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetRawTransactionChk() (isNew bool) {
+func (a API) GetNetworkHashPSChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetRawTransactionRes):
+	case o := <-a.Ch.(chan GetNetworkHashPSRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1566,36 +2116,36 @@ func (a API) GetRawTransactionChk() (isNew bool) {
 	return
 }
 
-// GetRawTransactionGetRes returns a pointer to the value in the Result field
-func (a API) GetRawTransactionGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetNetworkHashPSGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkHashPSGetRes() (out *[]btcjson.GetPeerInfoResult, e error) {
+	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetRawTransactionWait(cmd *btcjson.GetRawTransactionCmd) (out *string, e error) {
-	RPCHandlers["getrawtransaction"].Call <-API{a.Ch, cmd, nil}
+// GetNetworkHashPSWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcjson.GetPeerInfoResult, e error) {
+	RPCHandlers["getnetworkhashps"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetRawTransactionRes):
+	case o := <-a.Ch.(chan GetNetworkHashPSRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// GetTxOut calls the method with the given parameters
-func (a API) GetTxOut(cmd *btcjson.GetTxOutCmd) (e error) {
-	RPCHandlers["gettxout"].Call <-API{a.Ch, cmd, nil}
+// GetNetworkInfo calls the method with the given parameters
+func (a API) GetNetworkInfo(cmd *None) (e error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// GetTxOutChk checks if a new message arrived on the result channel and
+// GetNetworkInfoChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetTxOutChk() (isNew bool) {
+func (a API) GetNetworkInfoChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetTxOutRes):
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1607,36 +2157,36 @@ func (a API) GetTxOutChk() (isNew bool) {
 	return
 }
 
-// GetTxOutGetRes returns a pointer to the value in the Result field
-func (a API) GetTxOutGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetNetworkInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkInfoGetRes() (out *btcjson.GetNetworkInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetNetworkInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// GetTxOutWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetTxOutWait(cmd *btcjson.GetTxOutCmd) (out *string, e error) {
-	RPCHandlers["gettxout"].Call <-API{a.Ch, cmd, nil}
+// GetNetworkInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkInfoWait(cmd *None) (out *btcjson.GetNetworkInfoResult, e error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan GetTxOutRes):
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// Help calls the method with the given parameters
-func (a API) Help(cmd *btcjson.HelpCmd) (e error) {
-	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+// GetNodeAddresses calls the method with the given parameters
+func (a API) GetNodeAddresses(cmd *btcjson.GetNodeAddressesCmd) (e error) {
+	RPCHandlers["getnodeaddresses"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// HelpChk checks if a new message arrived on the result channel and
+// GetNodeAddressesChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) HelpChk() (isNew bool) {
+func (a API) GetNodeAddressesChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan GetNodeAddressesRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1648,36 +2198,36 @@ func (a API) HelpChk() (isNew bool) {
 	return
 }
 
-// HelpGetRes returns a pointer to the value in the Result field
-func (a API) HelpGetRes() (out *string, e error) {
-	out, _ = a.Result.(*string)
+// GetNodeAddressesGetRes returns a pointer to the value in the Result field
+func (a API) GetNodeAddressesGetRes() (out *[]btcjson.NodeAddressResult, e error) {
+	out, _ = a.Result.(*[]btcjson.NodeAddressResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// HelpWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, e error) {
-	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+// GetNodeAddressesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNodeAddressesWait(cmd *btcjson.GetNodeAddressesCmd) (out *[]btcjson.NodeAddressResult, e error) {
+	RPCHandlers["getnodeaddresses"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan GetNodeAddressesRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// Node calls the method with the given parameters
-func (a API) Node(cmd *btcjson.NodeCmd) (e error) {
-	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+// GetNotificationInfo calls the method with the given parameters
+func (a API) GetNotificationInfo(cmd *None) (e error) {
+	RPCHandlers["getnotificationinfo"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// NodeChk checks if a new message arrived on the result channel and
+// GetNotificationInfoChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) NodeChk() (isNew bool) {
+func (a API) GetNotificationInfoChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan GetNotificationInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1689,36 +2239,36 @@ func (a API) NodeChk() (isNew bool) {
 	return
 }
 
-// NodeGetRes returns a pointer to the value in the Result field
-func (a API) NodeGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetNotificationInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetNotificationInfoGetRes() (out *btcjson.GetNotificationInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetNotificationInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// NodeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, e error) {
-	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+// GetNotificationInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNotificationInfoWait(cmd *None) (out *btcjson.GetNotificationInfoResult, e error) {
+	RPCHandlers["getnotificationinfo"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan GetNotificationInfoRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// Ping calls the method with the given parameters
-func (a API) Ping(cmd *None) (e error) {
-	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
+// GetOrphanBlocks calls the method with the given parameters
+func (a API) GetOrphanBlocks(cmd *None) (e error) {
+	RPCHandlers["getorphanblocks"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// PingChk checks if a new message arrived on the result channel and
+// GetOrphanBlocksChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) PingChk() (isNew bool) {
+func (a API) GetOrphanBlocksChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan PingRes):
+	case o := <-a.Ch.(chan GetOrphanBlocksRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1730,36 +2280,36 @@ func (a API) PingChk() (isNew bool) {
 	return
 }
 
-// PingGetRes returns a pointer to the value in the Result field
-func (a API) PingGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetOrphanBlocksGetRes returns a pointer to the value in the Result field
+func (a API) GetOrphanBlocksGetRes() (out *btcjson.GetOrphanBlocksResult, e error) {
+	out, _ = a.Result.(*btcjson.GetOrphanBlocksResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// PingWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) PingWait(cmd *None) (out *None, e error) {
-	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
+// GetOrphanBlocksWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetOrphanBlocksWait(cmd *None) (out *btcjson.GetOrphanBlocksResult, e error) {
+	RPCHandlers["getorphanblocks"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan PingRes):
+	case o := <-a.Ch.(chan GetOrphanBlocksRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// ResetChain calls the method with the given parameters
-func (a API) ResetChain(cmd *None) (e error) {
-	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
+// GetOrphanTxs calls the method with the given parameters
+func (a API) GetOrphanTxs(cmd *None) (e error) {
+	RPCHandlers["getorphantxs"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// ResetChainChk checks if a new message arrived on the result channel and
+// GetOrphanTxsChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) ResetChainChk() (isNew bool) {
+func (a API) GetOrphanTxsChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan ResetChainRes):
+	case o := <-a.Ch.(chan GetOrphanTxsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1771,36 +2321,36 @@ func (a API) ResetChainChk() (isNew bool) {
 	return
 }
 
-// ResetChainGetRes returns a pointer to the value in the Result field
-func (a API) ResetChainGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetOrphanTxsGetRes returns a pointer to the value in the Result field
+func (a API) GetOrphanTxsGetRes() (out *btcjson.GetOrphanTxsResult, e error) {
+	out, _ = a.Result.(*btcjson.GetOrphanTxsResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// ResetChainWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ResetChainWait(cmd *None) (out *None, e error) {
-	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
+// GetOrphanTxsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetOrphanTxsWait(cmd *None) (out *btcjson.GetOrphanTxsResult, e error) {
+	RPCHandlers["getorphantxs"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan ResetChainRes):
+	case o := <-a.Ch.(chan GetOrphanTxsRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// Restart calls the method with the given parameters
-func (a API) Restart(cmd *None) (e error) {
-	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+// GetPeerInfo calls the method with the given parameters
+func (a API) GetPeerInfo(cmd *None) (e error) {
+	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// RestartChk checks if a new message arrived on the result channel and
+// GetPeerInfoChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) RestartChk() (isNew bool) {
+func (a API) GetPeerInfoChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan RestartRes):
+	case o := <-a.Ch.(chan GetPeerInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1812,36 +2362,36 @@ func (a API) RestartChk() (isNew bool) {
 	return
 }
 
-// RestartGetRes returns a pointer to the value in the Result field
-func (a API) RestartGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// GetPeerInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetPeerInfoGetRes() (out *[]btcjson.GetPeerInfoResult, e error) {
+	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// RestartWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) RestartWait(cmd *None) (out *None, e error) {
-	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+// GetPeerInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPeerInfoWait(cmd *None) (out *[]btcjson.GetPeerInfoResult, e error) {
+	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan RestartRes):
+	case o := <-a.Ch.(chan GetPeerInfoRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// SearchRawTransactions calls the method with the given parameters
-func (a API) SearchRawTransactions(cmd *btcjson.SearchRawTransactionsCmd) (e error) {
-	RPCHandlers["searchrawtransactions"].Call <-API{a.Ch, cmd, nil}
+// GetRawMempool calls the method with the given parameters
+func (a API) GetRawMempool(cmd *btcjson.GetRawMempoolCmd) (e error) {
+	RPCHandlers["getrawmempool"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// SearchRawTransactionsChk checks if a new message arrived on the result channel and
+// GetRawMempoolChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) SearchRawTransactionsChk() (isNew bool) {
+func (a API) GetRawMempoolChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan SearchRawTransactionsRes):
+	case o := <-a.Ch.(chan GetRawMempoolRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1853,11 +2403,544 @@ func (a API) SearchRawTransactionsChk() (isNew bool) {
 	return
 }
 
-// SearchRawTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) SearchRawTransactionsGetRes() (out *[]btcjson.SearchRawTransactionsResult, e error) {
-	out, _ = a.Result.(*[]btcjson.SearchRawTransactionsResult)
-	e, _ = a.Result.(error)
-	return 
+// GetRawMempoolGetRes returns a pointer to the value in the Result field
+func (a API) GetRawMempoolGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetRawMempoolWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRawMempoolWait(cmd *btcjson.GetRawMempoolCmd) (out *[]string, e error) {
+	RPCHandlers["getrawmempool"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetRawMempoolRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetRawTransaction calls the method with the given parameters
+func (a API) GetRawTransaction(cmd *btcjson.GetRawTransactionCmd) (e error) {
+	RPCHandlers["getrawtransaction"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetRawTransactionChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetRawTransactionChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetRawTransactionRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetRawTransactionGetRes returns a pointer to the value in the Result field
+func (a API) GetRawTransactionGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRawTransactionWait(cmd *btcjson.GetRawTransactionCmd) (out *string, e error) {
+	RPCHandlers["getrawtransaction"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetRawTransactionRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetScriptFlags calls the method with the given parameters
+func (a API) GetScriptFlags(cmd *btcjson.GetScriptFlagsCmd) (e error) {
+	RPCHandlers["getscriptflags"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetScriptFlagsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetScriptFlagsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetScriptFlagsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetScriptFlagsGetRes returns a pointer to the value in the Result field
+func (a API) GetScriptFlagsGetRes() (out *btcjson.GetScriptFlagsResult, e error) {
+	out, _ = a.Result.(*btcjson.GetScriptFlagsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetScriptFlagsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetScriptFlagsWait(cmd *btcjson.GetScriptFlagsCmd) (out *btcjson.GetScriptFlagsResult, e error) {
+	RPCHandlers["getscriptflags"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetScriptFlagsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetTimestampInfo calls the method with the given parameters
+func (a API) GetTimestampInfo(cmd *btcjson.GetTimestampInfoCmd) (e error) {
+	RPCHandlers["gettimestampinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetTimestampInfoChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetTimestampInfoChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetTimestampInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetTimestampInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetTimestampInfoGetRes() (out *btcjson.GetTimestampInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetTimestampInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetTimestampInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTimestampInfoWait(cmd *btcjson.GetTimestampInfoCmd) (out *btcjson.GetTimestampInfoResult, e error) {
+	RPCHandlers["gettimestampinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetTimestampInfoRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetTxOut calls the method with the given parameters
+func (a API) GetTxOut(cmd *btcjson.GetTxOutCmd) (e error) {
+	RPCHandlers["gettxout"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetTxOutChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetTxOutChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetTxOutRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetTxOutGetRes returns a pointer to the value in the Result field
+func (a API) GetTxOutGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetTxOutWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTxOutWait(cmd *btcjson.GetTxOutCmd) (out *string, e error) {
+	RPCHandlers["gettxout"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetTxOutRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetValidationTrace calls the method with the given parameters
+func (a API) GetValidationTrace(cmd *btcjson.GetValidationTraceCmd) (e error) {
+	RPCHandlers["getvalidationtrace"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetValidationTraceChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetValidationTraceChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetValidationTraceRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetValidationTraceGetRes returns a pointer to the value in the Result field
+func (a API) GetValidationTraceGetRes() (out *[]btcjson.GetValidationTraceResult, e error) {
+	out, _ = a.Result.(*[]btcjson.GetValidationTraceResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetValidationTraceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetValidationTraceWait(cmd *btcjson.GetValidationTraceCmd) (out *[]btcjson.GetValidationTraceResult, e error) {
+	RPCHandlers["getvalidationtrace"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetValidationTraceRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetWorkSource calls the method with the given parameters
+func (a API) GetWorkSource(cmd *None) (e error) {
+	RPCHandlers["getworksource"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetWorkSourceChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetWorkSourceChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetWorkSourceRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetWorkSourceGetRes returns a pointer to the value in the Result field
+func (a API) GetWorkSourceGetRes() (out *btcjson.GetWorkSourceResult, e error) {
+	out, _ = a.Result.(*btcjson.GetWorkSourceResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetWorkSourceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWorkSourceWait(cmd *None) (out *btcjson.GetWorkSourceResult, e error) {
+	RPCHandlers["getworksource"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetWorkSourceRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// Help calls the method with the given parameters
+func (a API) Help(cmd *btcjson.HelpCmd) (e error) {
+	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// HelpChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) HelpChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan HelpRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// HelpGetRes returns a pointer to the value in the Result field
+func (a API) HelpGetRes() (out *string, e error) {
+	out, _ = a.Result.(*string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// HelpWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, e error) {
+	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan HelpRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// Node calls the method with the given parameters
+func (a API) Node(cmd *btcjson.NodeCmd) (e error) {
+	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// NodeChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) NodeChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan NodeRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// NodeGetRes returns a pointer to the value in the Result field
+func (a API) NodeGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// NodeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, e error) {
+	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan NodeRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// Ping calls the method with the given parameters
+func (a API) Ping(cmd *None) (e error) {
+	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// PingChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) PingChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PingRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PingGetRes returns a pointer to the value in the Result field
+func (a API) PingGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// PingWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PingWait(cmd *None) (out *None, e error) {
+	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan PingRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// PrioritiseTransaction calls the method with the given parameters
+func (a API) PrioritiseTransaction(cmd *btcjson.PrioritiseTransactionCmd) (e error) {
+	RPCHandlers["prioritisetransaction"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// PrioritiseTransactionChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) PrioritiseTransactionChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PrioritiseTransactionRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PrioritiseTransactionGetRes returns a pointer to the value in the Result field
+func (a API) PrioritiseTransactionGetRes() (out *bool, e error) {
+	out, _ = a.Result.(*bool)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// PrioritiseTransactionWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PrioritiseTransactionWait(cmd *btcjson.PrioritiseTransactionCmd) (out *bool, e error) {
+	RPCHandlers["prioritisetransaction"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan PrioritiseTransactionRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// ResetChain calls the method with the given parameters
+func (a API) ResetChain(cmd *None) (e error) {
+	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ResetChainChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ResetChainChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ResetChainRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ResetChainGetRes returns a pointer to the value in the Result field
+func (a API) ResetChainGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ResetChainWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ResetChainWait(cmd *None) (out *None, e error) {
+	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ResetChainRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// Restart calls the method with the given parameters
+func (a API) Restart(cmd *None) (e error) {
+	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// RestartChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) RestartChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan RestartRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// RestartGetRes returns a pointer to the value in the Result field
+func (a API) RestartGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// RestartWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) RestartWait(cmd *None) (out *None, e error) {
+	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan RestartRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// SearchRawTransactions calls the method with the given parameters
+func (a API) SearchRawTransactions(cmd *btcjson.SearchRawTransactionsCmd) (e error) {
+	RPCHandlers["searchrawtransactions"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SearchRawTransactionsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SearchRawTransactionsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SearchRawTransactionsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SearchRawTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) SearchRawTransactionsGetRes() (out *[]btcjson.SearchRawTransactionsResult, e error) {
+	out, _ = a.Result.(*[]btcjson.SearchRawTransactionsResult)
+	e, _ = a.Result.(error)
+	return 
 }
 
 // SearchRawTransactionsWait calls the method and blocks until it returns or 5 seconds passes
@@ -1872,6 +2955,47 @@ func (a API) SearchRawTransactionsWait(cmd *btcjson.SearchRawTransactionsCmd) (o
 	return
 }
 
+// SelfTest calls the method with the given parameters
+func (a API) SelfTest(cmd *None) (e error) {
+	RPCHandlers["selftest"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SelfTestChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SelfTestChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SelfTestRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SelfTestGetRes returns a pointer to the value in the Result field
+func (a API) SelfTestGetRes() (out *btcjson.SelfTestResult, e error) {
+	out, _ = a.Result.(*btcjson.SelfTestResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SelfTestWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SelfTestWait(cmd *None) (out *btcjson.SelfTestResult, e error) {
+	RPCHandlers["selftest"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan SelfTestRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // SendRawTransaction calls the method with the given parameters
 func (a API) SendRawTransaction(cmd *btcjson.SendRawTransactionCmd) (e error) {
 	RPCHandlers["sendrawtransaction"].Call <-API{a.Ch, cmd, nil}
@@ -1913,6 +3037,88 @@ func (a API) SendRawTransactionWait(cmd *btcjson.SendRawTransactionCmd) (out *No
 	return
 }
 
+// SetConfig calls the method with the given parameters
+func (a API) SetConfig(cmd *btcjson.SetConfigCmd) (e error) {
+	RPCHandlers["setconfig"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SetConfigChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetConfigChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetConfigRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetConfigGetRes returns a pointer to the value in the Result field
+func (a API) SetConfigGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetConfigWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetConfigWait(cmd *btcjson.SetConfigCmd) (out *None, e error) {
+	RPCHandlers["setconfig"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan SetConfigRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// SetFeature calls the method with the given parameters
+func (a API) SetFeature(cmd *btcjson.SetFeatureCmd) (e error) {
+	RPCHandlers["setfeature"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SetFeatureChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetFeatureChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetFeatureRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetFeatureGetRes returns a pointer to the value in the Result field
+func (a API) SetFeatureGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetFeatureWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetFeatureWait(cmd *btcjson.SetFeatureCmd) (out *None, e error) {
+	RPCHandlers["setfeature"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan SetFeatureRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // SetGenerate calls the method with the given parameters
 func (a API) SetGenerate(cmd *btcjson.SetGenerateCmd) (e error) {
 	RPCHandlers["setgenerate"].Call <-API{a.Ch, cmd, nil}
@@ -1954,6 +3160,47 @@ func (a API) SetGenerateWait(cmd *btcjson.SetGenerateCmd) (out *None, e error) {
 	return
 }
 
+// SetMiningAddresses calls the method with the given parameters
+func (a API) SetMiningAddresses(cmd *btcjson.SetMiningAddressesCmd) (e error) {
+	RPCHandlers["setminingaddresses"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SetMiningAddressesChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetMiningAddressesChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetMiningAddressesRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetMiningAddressesGetRes returns a pointer to the value in the Result field
+func (a API) SetMiningAddressesGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetMiningAddressesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetMiningAddressesWait(cmd *btcjson.SetMiningAddressesCmd) (out *None, e error) {
+	RPCHandlers["setminingaddresses"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan SetMiningAddressesRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // Stop calls the method with the given parameters
 func (a API) Stop(cmd *None) (e error) {
 	RPCHandlers["stop"].Call <-API{a.Ch, cmd, nil}
@@ -2036,6 +3283,47 @@ func (a API) SubmitBlockWait(cmd *btcjson.SubmitBlockCmd) (out *string, e error)
 	return
 }
 
+// TraceMempoolAccept calls the method with the given parameters
+func (a API) TraceMempoolAccept(cmd *btcjson.TraceMempoolAcceptCmd) (e error) {
+	RPCHandlers["tracemempoolaccept"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// TraceMempoolAcceptChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) TraceMempoolAcceptChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan TraceMempoolAcceptRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// TraceMempoolAcceptGetRes returns a pointer to the value in the Result field
+func (a API) TraceMempoolAcceptGetRes() (out *btcjson.TraceMempoolAcceptResult, e error) {
+	out, _ = a.Result.(*btcjson.TraceMempoolAcceptResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// TraceMempoolAcceptWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) TraceMempoolAcceptWait(cmd *btcjson.TraceMempoolAcceptCmd) (out *btcjson.TraceMempoolAcceptResult, e error) {
+	RPCHandlers["tracemempoolaccept"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan TraceMempoolAcceptRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // Uptime calls the method with the given parameters
 func (a API) Uptime(cmd *None) (e error) {
 	RPCHandlers["uptime"].Call <-API{a.Ch, cmd, nil}
@@ -2259,6 +3547,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan AddNodeRes) <-AddNodeRes{&r, e} } 
+			case msg := <-nrh["calculatepackagefee"].Call:
+				if res, e = nrh["calculatepackagefee"].
+					Fn(server, msg.Params.(*btcjson.CalculatePackageFeeCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.CalculatePackageFeeResult); ok { 
+					msg.Ch.(chan CalculatePackageFeeRes) <-CalculatePackageFeeRes{&r, e} } 
 			case msg := <-nrh["createrawtransaction"].Call:
 				if res, e = nrh["createrawtransaction"].
 					Fn(server, msg.Params.(*btcjson.CreateRawTransactionCmd), nil); E.Chk(e) {
@@ -2277,12 +3571,30 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(btcjson.DecodeScriptResult); ok { 
 					msg.Ch.(chan DecodeScriptRes) <-DecodeScriptRes{&r, e} } 
+			case msg := <-nrh["deriveaddresses"].Call:
+				if res, e = nrh["deriveaddresses"].
+					Fn(server, msg.Params.(*btcjson.DeriveAddressesCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan DeriveAddressesRes) <-DeriveAddressesRes{&r, e} } 
 			case msg := <-nrh["estimatefee"].Call:
 				if res, e = nrh["estimatefee"].
 					Fn(server, msg.Params.(*btcjson.EstimateFeeCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(float64); ok { 
 					msg.Ch.(chan EstimateFeeRes) <-EstimateFeeRes{&r, e} } 
+			case msg := <-nrh["estimatesmartfee"].Call:
+				if res, e = nrh["estimatesmartfee"].
+					Fn(server, msg.Params.(*btcjson.EstimateSmartFeeCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.EstimateSmartFeeResult); ok { 
+					msg.Ch.(chan EstimateSmartFeeRes) <-EstimateSmartFeeRes{&r, e} } 
+			case msg := <-nrh["estimatorstats"].Call:
+				if res, e = nrh["estimatorstats"].
+					Fn(server, msg.Params.(*btcjson.EstimatorStatsCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.EstimatorStatsResult); ok { 
+					msg.Ch.(chan EstimatorStatsRes) <-EstimatorStatsRes{&r, e} } 
 			case msg := <-nrh["generate"].Call:
 				if res, e = nrh["generate"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2337,12 +3649,24 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(btcjson.GetBlockHeaderVerboseResult); ok { 
 					msg.Ch.(chan GetBlockHeaderRes) <-GetBlockHeaderRes{&r, e} } 
+			case msg := <-nrh["getblockpropagation"].Call:
+				if res, e = nrh["getblockpropagation"].
+					Fn(server, msg.Params.(*btcjson.GetBlockPropagationCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetBlockPropagationResult); ok { 
+					msg.Ch.(chan GetBlockPropagationRes) <-GetBlockPropagationRes{&r, e} } 
 			case msg := <-nrh["getblocktemplate"].Call:
 				if res, e = nrh["getblocktemplate"].
 					Fn(server, msg.Params.(*btcjson.GetBlockTemplateCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetBlockTemplateRes) <-GetBlockTemplateRes{&r, e} } 
+			case msg := <-nrh["getcachestats"].Call:
+				if res, e = nrh["getcachestats"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetCacheStatsResult); ok { 
+					msg.Ch.(chan GetCacheStatsRes) <-GetCacheStatsRes{&r, e} } 
 			case msg := <-nrh["getcfilter"].Call:
 				if res, e = nrh["getcfilter"].
 					Fn(server, msg.Params.(*btcjson.GetCFilterCmd), nil); E.Chk(e) {
@@ -2367,12 +3691,30 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetCurrentNetRes) <-GetCurrentNetRes{&r, e} } 
+			case msg := <-nrh["getdescriptorinfo"].Call:
+				if res, e = nrh["getdescriptorinfo"].
+					Fn(server, msg.Params.(*btcjson.GetDescriptorInfoCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetDescriptorInfoResult); ok { 
+					msg.Ch.(chan GetDescriptorInfoRes) <-GetDescriptorInfoRes{&r, e} } 
 			case msg := <-nrh["getdifficulty"].Call:
 				if res, e = nrh["getdifficulty"].
 					Fn(server, msg.Params.(*btcjson.GetDifficultyCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(float64); ok { 
 					msg.Ch.(chan GetDifficultyRes) <-GetDifficultyRes{&r, e} } 
+			case msg := <-nrh["getdnsaudit"].Call:
+				if res, e = nrh["getdnsaudit"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetDNSAuditResult); ok { 
+					msg.Ch.(chan GetDNSAuditRes) <-GetDNSAuditRes{&r, e} } 
+			case msg := <-nrh["getfeatures"].Call:
+				if res, e = nrh["getfeatures"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.FeatureResult); ok { 
+					msg.Ch.(chan GetFeaturesRes) <-GetFeaturesRes{&r, e} } 
 			case msg := <-nrh["getgenerate"].Call:
 				if res, e = nrh["getgenerate"].
 					Fn(server, msg.Params.(*btcjson.GetHeadersCmd), nil); E.Chk(e) {
@@ -2391,6 +3733,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]string); ok { 
 					msg.Ch.(chan GetHeadersRes) <-GetHeadersRes{&r, e} } 
+			case msg := <-nrh["getindexinfo"].Call:
+				if res, e = nrh["getindexinfo"].
+					Fn(server, msg.Params.(*btcjson.GetIndexInfoCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(map[string]btcjson.GetIndexInfoResult); ok { 
+					msg.Ch.(chan GetIndexInfoRes) <-GetIndexInfoRes{&r, e} } 
 			case msg := <-nrh["getinfo"].Call:
 				if res, e = nrh["getinfo"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2403,12 +3751,30 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(btcjson.GetMempoolInfoResult); ok { 
 					msg.Ch.(chan GetMempoolInfoRes) <-GetMempoolInfoRes{&r, e} } 
+			case msg := <-nrh["getminingaddresses"].Call:
+				if res, e = nrh["getminingaddresses"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetMiningAddressesResult); ok { 
+					msg.Ch.(chan GetMiningAddressesRes) <-GetMiningAddressesRes{&r, e} } 
+			case msg := <-nrh["getminingexpectation"].Call:
+				if res, e = nrh["getminingexpectation"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetMiningExpectationResult); ok { 
+					msg.Ch.(chan GetMiningExpectationRes) <-GetMiningExpectationRes{&r, e} } 
 			case msg := <-nrh["getmininginfo"].Call:
 				if res, e = nrh["getmininginfo"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
 				}
 				if r, ok := res.(btcjson.GetMiningInfoResult); ok { 
 					msg.Ch.(chan GetMiningInfoRes) <-GetMiningInfoRes{&r, e} } 
+			case msg := <-nrh["getminingtemplate"].Call:
+				if res, e = nrh["getminingtemplate"].
+					Fn(server, msg.Params.(*btcjson.GetMiningTemplateCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetMiningTemplateResult); ok { 
+					msg.Ch.(chan GetMiningTemplateRes) <-GetMiningTemplateRes{&r, e} } 
 			case msg := <-nrh["getnettotals"].Call:
 				if res, e = nrh["getnettotals"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2421,6 +3787,36 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok { 
 					msg.Ch.(chan GetNetworkHashPSRes) <-GetNetworkHashPSRes{&r, e} } 
+			case msg := <-nrh["getnetworkinfo"].Call:
+				if res, e = nrh["getnetworkinfo"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetNetworkInfoResult); ok { 
+					msg.Ch.(chan GetNetworkInfoRes) <-GetNetworkInfoRes{&r, e} } 
+			case msg := <-nrh["getnodeaddresses"].Call:
+				if res, e = nrh["getnodeaddresses"].
+					Fn(server, msg.Params.(*btcjson.GetNodeAddressesCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.NodeAddressResult); ok { 
+					msg.Ch.(chan GetNodeAddressesRes) <-GetNodeAddressesRes{&r, e} } 
+			case msg := <-nrh["getnotificationinfo"].Call:
+				if res, e = nrh["getnotificationinfo"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetNotificationInfoResult); ok { 
+					msg.Ch.(chan GetNotificationInfoRes) <-GetNotificationInfoRes{&r, e} } 
+			case msg := <-nrh["getorphanblocks"].Call:
+				if res, e = nrh["getorphanblocks"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetOrphanBlocksResult); ok { 
+					msg.Ch.(chan GetOrphanBlocksRes) <-GetOrphanBlocksRes{&r, e} } 
+			case msg := <-nrh["getorphantxs"].Call:
+				if res, e = nrh["getorphantxs"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetOrphanTxsResult); ok { 
+					msg.Ch.(chan GetOrphanTxsRes) <-GetOrphanTxsRes{&r, e} } 
 			case msg := <-nrh["getpeerinfo"].Call:
 				if res, e = nrh["getpeerinfo"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2439,12 +3835,36 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetRawTransactionRes) <-GetRawTransactionRes{&r, e} } 
+			case msg := <-nrh["getscriptflags"].Call:
+				if res, e = nrh["getscriptflags"].
+					Fn(server, msg.Params.(*btcjson.GetScriptFlagsCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetScriptFlagsResult); ok { 
+					msg.Ch.(chan GetScriptFlagsRes) <-GetScriptFlagsRes{&r, e} } 
+			case msg := <-nrh["gettimestampinfo"].Call:
+				if res, e = nrh["gettimestampinfo"].
+					Fn(server, msg.Params.(*btcjson.GetTimestampInfoCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetTimestampInfoResult); ok { 
+					msg.Ch.(chan GetTimestampInfoRes) <-GetTimestampInfoRes{&r, e} } 
 			case msg := <-nrh["gettxout"].Call:
 				if res, e = nrh["gettxout"].
 					Fn(server, msg.Params.(*btcjson.GetTxOutCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetTxOutRes) <-GetTxOutRes{&r, e} } 
+			case msg := <-nrh["getvalidationtrace"].Call:
+				if res, e = nrh["getvalidationtrace"].
+					Fn(server, msg.Params.(*btcjson.GetValidationTraceCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.GetValidationTraceResult); ok { 
+					msg.Ch.(chan GetValidationTraceRes) <-GetValidationTraceRes{&r, e} } 
+			case msg := <-nrh["getworksource"].Call:
+				if res, e = nrh["getworksource"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetWorkSourceResult); ok { 
+					msg.Ch.(chan GetWorkSourceRes) <-GetWorkSourceRes{&r, e} } 
 			case msg := <-nrh["help"].Call:
 				if res, e = nrh["help"].
 					Fn(server, msg.Params.(*btcjson.HelpCmd), nil); E.Chk(e) {
@@ -2463,6 +3883,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan PingRes) <-PingRes{&r, e} } 
+			case msg := <-nrh["prioritisetransaction"].Call:
+				if res, e = nrh["prioritisetransaction"].
+					Fn(server, msg.Params.(*btcjson.PrioritiseTransactionCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan PrioritiseTransactionRes) <-PrioritiseTransactionRes{&r, e} } 
 			case msg := <-nrh["resetchain"].Call:
 				if res, e = nrh["resetchain"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2481,18 +3907,42 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]btcjson.SearchRawTransactionsResult); ok { 
 					msg.Ch.(chan SearchRawTransactionsRes) <-SearchRawTransactionsRes{&r, e} } 
+			case msg := <-nrh["selftest"].Call:
+				if res, e = nrh["selftest"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SelfTestResult); ok { 
+					msg.Ch.(chan SelfTestRes) <-SelfTestRes{&r, e} } 
 			case msg := <-nrh["sendrawtransaction"].Call:
 				if res, e = nrh["sendrawtransaction"].
 					Fn(server, msg.Params.(*btcjson.SendRawTransactionCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan SendRawTransactionRes) <-SendRawTransactionRes{&r, e} } 
+			case msg := <-nrh["setconfig"].Call:
+				if res, e = nrh["setconfig"].
+					Fn(server, msg.Params.(*btcjson.SetConfigCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetConfigRes) <-SetConfigRes{&r, e} } 
+			case msg := <-nrh["setfeature"].Call:
+				if res, e = nrh["setfeature"].
+					Fn(server, msg.Params.(*btcjson.SetFeatureCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetFeatureRes) <-SetFeatureRes{&r, e} } 
 			case msg := <-nrh["setgenerate"].Call:
 				if res, e = nrh["setgenerate"].
 					Fn(server, msg.Params.(*btcjson.SetGenerateCmd), nil); E.Chk(e) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan SetGenerateRes) <-SetGenerateRes{&r, e} } 
+			case msg := <-nrh["setminingaddresses"].Call:
+				if res, e = nrh["setminingaddresses"].
+					Fn(server, msg.Params.(*btcjson.SetMiningAddressesCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetMiningAddressesRes) <-SetMiningAddressesRes{&r, e} } 
 			case msg := <-nrh["stop"].Call:
 				if res, e = nrh["stop"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2505,6 +3955,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan SubmitBlockRes) <-SubmitBlockRes{&r, e} } 
+			case msg := <-nrh["tracemempoolaccept"].Call:
+				if res, e = nrh["tracemempoolaccept"].
+					Fn(server, msg.Params.(*btcjson.TraceMempoolAcceptCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.TraceMempoolAcceptResult); ok { 
+					msg.Ch.(chan TraceMempoolAcceptRes) <-TraceMempoolAcceptRes{&r, e} } 
 			case msg := <-nrh["uptime"].Call:
 				if res, e = nrh["uptime"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2545,13 +4001,26 @@ func RunAPI(server *Server, quit qu.C) {
 
 // RPC API functions to use with net/rpc
 
-func (c *CAPI) AddNode(req *btcjson.AddNodeCmd, resp None) (e error) {
+func (c *CAPI) AddNode(req *btcjson.AddNodeCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["addnode"].Result()
+	res.Params = req
+	nrh["addnode"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CalculatePackageFee(req *btcjson.CalculatePackageFeeCmd, resp btcjson.CalculatePackageFeeResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["addnode"].Result()
+	res := nrh["calculatepackagefee"].Result()
 	res.Params = req
-	nrh["addnode"].Call <- res
+	nrh["calculatepackagefee"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan btcjson.CalculatePackageFeeResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
@@ -2597,6 +4066,19 @@ func (c *CAPI) DecodeScript(req *btcjson.DecodeScriptCmd, resp btcjson.DecodeScr
 	return 
 }
 
+func (c *CAPI) DeriveAddresses(req *btcjson.DeriveAddressesCmd, resp []string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["deriveaddresses"].Result()
+	res.Params = req
+	nrh["deriveaddresses"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) EstimateFee(req *btcjson.EstimateFeeCmd, resp float64) (e error) {
 	nrh := RPCHandlers
 	res := nrh["estimatefee"].Result()
@@ -2610,6 +4092,32 @@ func (c *CAPI) EstimateFee(req *btcjson.EstimateFeeCmd, resp float64) (e error)
 	return 
 }
 
+func (c *CAPI) EstimateSmartFee(req *btcjson.EstimateSmartFeeCmd, resp btcjson.EstimateSmartFeeResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["estimatesmartfee"].Result()
+	res.Params = req
+	nrh["estimatesmartfee"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.EstimateSmartFeeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) EstimatorStats(req *btcjson.EstimatorStatsCmd, resp btcjson.EstimatorStatsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["estimatorstats"].Result()
+	res.Params = req
+	nrh["estimatorstats"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.EstimatorStatsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Generate(req *None, resp []string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["generate"].Result()
@@ -2727,6 +4235,19 @@ func (c *CAPI) GetBlockHeader(req *btcjson.GetBlockHeaderCmd, resp btcjson.GetBl
 	return 
 }
 
+func (c *CAPI) GetBlockPropagation(req *btcjson.GetBlockPropagationCmd, resp btcjson.GetBlockPropagationResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getblockpropagation"].Result()
+	res.Params = req
+	nrh["getblockpropagation"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetBlockPropagationResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetBlockTemplate(req *btcjson.GetBlockTemplateCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getblocktemplate"].Result()
@@ -2740,6 +4261,19 @@ func (c *CAPI) GetBlockTemplate(req *btcjson.GetBlockTemplateCmd, resp string) (
 	return 
 }
 
+func (c *CAPI) GetCacheStats(req *None, resp btcjson.GetCacheStatsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getcachestats"].Result()
+	res.Params = req
+	nrh["getcachestats"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetCacheStatsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetCFilter(req *btcjson.GetCFilterCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getcfilter"].Result()
@@ -2792,6 +4326,19 @@ func (c *CAPI) GetCurrentNet(req *None, resp string) (e error) {
 	return 
 }
 
+func (c *CAPI) GetDescriptorInfo(req *btcjson.GetDescriptorInfoCmd, resp btcjson.GetDescriptorInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getdescriptorinfo"].Result()
+	res.Params = req
+	nrh["getdescriptorinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetDescriptorInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetDifficulty(req *btcjson.GetDifficultyCmd, resp float64) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getdifficulty"].Result()
@@ -2805,6 +4352,32 @@ func (c *CAPI) GetDifficulty(req *btcjson.GetDifficultyCmd, resp float64) (e err
 	return 
 }
 
+func (c *CAPI) GetDNSAudit(req *None, resp btcjson.GetDNSAuditResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getdnsaudit"].Result()
+	res.Params = req
+	nrh["getdnsaudit"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetDNSAuditResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetFeatures(req *None, resp []btcjson.FeatureResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getfeatures"].Result()
+	res.Params = req
+	nrh["getfeatures"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.FeatureResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetGenerate(req *btcjson.GetHeadersCmd, resp bool) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getgenerate"].Result()
@@ -2844,6 +4417,19 @@ func (c *CAPI) GetHeaders(req *btcjson.GetHeadersCmd, resp []string) (e error) {
 	return 
 }
 
+func (c *CAPI) GetIndexInfo(req *btcjson.GetIndexInfoCmd, resp map[string]btcjson.GetIndexInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getindexinfo"].Result()
+	res.Params = req
+	nrh["getindexinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]btcjson.GetIndexInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetInfo(req *None, resp btcjson.InfoChainResult0) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getinfo"].Result()
@@ -2870,6 +4456,32 @@ func (c *CAPI) GetMempoolInfo(req *None, resp btcjson.GetMempoolInfoResult) (e e
 	return 
 }
 
+func (c *CAPI) GetMiningAddresses(req *None, resp btcjson.GetMiningAddressesResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getminingaddresses"].Result()
+	res.Params = req
+	nrh["getminingaddresses"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMiningAddressesResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMiningExpectation(req *None, resp btcjson.GetMiningExpectationResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getminingexpectation"].Result()
+	res.Params = req
+	nrh["getminingexpectation"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMiningExpectationResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetMiningInfo(req *None, resp btcjson.GetMiningInfoResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getmininginfo"].Result()
@@ -2883,6 +4495,19 @@ func (c *CAPI) GetMiningInfo(req *None, resp btcjson.GetMiningInfoResult) (e err
 	return 
 }
 
+func (c *CAPI) GetMiningTemplate(req *btcjson.GetMiningTemplateCmd, resp btcjson.GetMiningTemplateResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getminingtemplate"].Result()
+	res.Params = req
+	nrh["getminingtemplate"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMiningTemplateResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetNetTotals(req *None, resp btcjson.GetNetTotalsResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getnettotals"].Result()
@@ -2909,6 +4534,71 @@ func (c *CAPI) GetNetworkHashPS(req *btcjson.GetNetworkHashPSCmd, resp []btcjson
 	return 
 }
 
+func (c *CAPI) GetNetworkInfo(req *None, resp btcjson.GetNetworkInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnetworkinfo"].Result()
+	res.Params = req
+	nrh["getnetworkinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetNetworkInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetNodeAddresses(req *btcjson.GetNodeAddressesCmd, resp []btcjson.NodeAddressResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnodeaddresses"].Result()
+	res.Params = req
+	nrh["getnodeaddresses"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.NodeAddressResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetNotificationInfo(req *None, resp btcjson.GetNotificationInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnotificationinfo"].Result()
+	res.Params = req
+	nrh["getnotificationinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetNotificationInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetOrphanBlocks(req *None, resp btcjson.GetOrphanBlocksResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getorphanblocks"].Result()
+	res.Params = req
+	nrh["getorphanblocks"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetOrphanBlocksResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetOrphanTxs(req *None, resp btcjson.GetOrphanTxsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getorphantxs"].Result()
+	res.Params = req
+	nrh["getorphantxs"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetOrphanTxsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getpeerinfo"].Result()
@@ -2948,6 +4638,32 @@ func (c *CAPI) GetRawTransaction(req *btcjson.GetRawTransactionCmd, resp string)
 	return 
 }
 
+func (c *CAPI) GetScriptFlags(req *btcjson.GetScriptFlagsCmd, resp btcjson.GetScriptFlagsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getscriptflags"].Result()
+	res.Params = req
+	nrh["getscriptflags"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetScriptFlagsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetTimestampInfo(req *btcjson.GetTimestampInfoCmd, resp btcjson.GetTimestampInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["gettimestampinfo"].Result()
+	res.Params = req
+	nrh["gettimestampinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetTimestampInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetTxOut(req *btcjson.GetTxOutCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["gettxout"].Result()
@@ -2961,50 +4677,141 @@ func (c *CAPI) GetTxOut(req *btcjson.GetTxOutCmd, resp string) (e error) {
 	return 
 }
 
+func (c *CAPI) GetValidationTrace(req *btcjson.GetValidationTraceCmd, resp []btcjson.GetValidationTraceResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getvalidationtrace"].Result()
+	res.Params = req
+	nrh["getvalidationtrace"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.GetValidationTraceResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetWorkSource(req *None, resp btcjson.GetWorkSourceResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getworksource"].Result()
+	res.Params = req
+	nrh["getworksource"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWorkSourceResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["help"].Result()
 	res.Params = req
-	nrh["help"].Call <- res
+	nrh["help"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) Node(req *btcjson.NodeCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["node"].Result()
+	res.Params = req
+	nrh["node"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) Ping(req *None, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["ping"].Result()
+	res.Params = req
+	nrh["ping"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) PrioritiseTransaction(req *btcjson.PrioritiseTransactionCmd, resp bool) (e error) {
+	nrh := RPCHandlers
+	res := nrh["prioritisetransaction"].Result()
+	res.Params = req
+	nrh["prioritisetransaction"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ResetChain(req *None, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["resetchain"].Result()
+	res.Params = req
+	nrh["resetchain"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) Restart(req *None, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["restart"].Result()
+	res.Params = req
+	nrh["restart"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) Node(req *btcjson.NodeCmd, resp None) (e error) {
+func (c *CAPI) SearchRawTransactions(req *btcjson.SearchRawTransactionsCmd, resp []btcjson.SearchRawTransactionsResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["node"].Result()
+	res := nrh["searchrawtransactions"].Result()
 	res.Params = req
-	nrh["node"].Call <- res
+	nrh["searchrawtransactions"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan []btcjson.SearchRawTransactionsResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) Ping(req *None, resp None) (e error) {
+func (c *CAPI) SelfTest(req *None, resp btcjson.SelfTestResult) (e error) {
 	nrh := RPCHandlers
-	res := nrh["ping"].Result()
+	res := nrh["selftest"].Result()
 	res.Params = req
-	nrh["ping"].Call <- res
+	nrh["selftest"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan btcjson.SelfTestResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) ResetChain(req *None, resp None) (e error) {
+func (c *CAPI) SendRawTransaction(req *btcjson.SendRawTransactionCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["resetchain"].Result()
+	res := nrh["sendrawtransaction"].Result()
 	res.Params = req
-	nrh["resetchain"].Call <- res
+	nrh["sendrawtransaction"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
@@ -3013,11 +4820,11 @@ func (c *CAPI) ResetChain(req *None, resp None) (e error) {
 	return 
 }
 
-func (c *CAPI) Restart(req *None, resp None) (e error) {
+func (c *CAPI) SetConfig(req *btcjson.SetConfigCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["restart"].Result()
+	res := nrh["setconfig"].Result()
 	res.Params = req
-	nrh["restart"].Call <- res
+	nrh["setconfig"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
@@ -3026,24 +4833,24 @@ func (c *CAPI) Restart(req *None, resp None) (e error) {
 	return 
 }
 
-func (c *CAPI) SearchRawTransactions(req *btcjson.SearchRawTransactionsCmd, resp []btcjson.SearchRawTransactionsResult) (e error) {
+func (c *CAPI) SetFeature(req *btcjson.SetFeatureCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["searchrawtransactions"].Result()
+	res := nrh["setfeature"].Result()
 	res.Params = req
-	nrh["searchrawtransactions"].Call <- res
+	nrh["setfeature"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.SearchRawTransactionsResult):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
 	return 
 }
 
-func (c *CAPI) SendRawTransaction(req *btcjson.SendRawTransactionCmd, resp None) (e error) {
+func (c *CAPI) SetGenerate(req *btcjson.SetGenerateCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["sendrawtransaction"].Result()
+	res := nrh["setgenerate"].Result()
 	res.Params = req
-	nrh["sendrawtransaction"].Call <- res
+	nrh["setgenerate"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
@@ -3052,11 +4859,11 @@ func (c *CAPI) SendRawTransaction(req *btcjson.SendRawTransactionCmd, resp None)
 	return 
 }
 
-func (c *CAPI) SetGenerate(req *btcjson.SetGenerateCmd, resp None) (e error) {
+func (c *CAPI) SetMiningAddresses(req *btcjson.SetMiningAddressesCmd, resp None) (e error) {
 	nrh := RPCHandlers
-	res := nrh["setgenerate"].Result()
+	res := nrh["setminingaddresses"].Result()
 	res.Params = req
-	nrh["setgenerate"].Call <- res
+	nrh["setminingaddresses"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
@@ -3091,6 +4898,19 @@ func (c *CAPI) SubmitBlock(req *btcjson.SubmitBlockCmd, resp string) (e error) {
 	return 
 }
 
+func (c *CAPI) TraceMempoolAccept(req *btcjson.TraceMempoolAcceptCmd, resp btcjson.TraceMempoolAcceptResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["tracemempoolaccept"].Result()
+	res.Params = req
+	nrh["tracemempoolaccept"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.TraceMempoolAcceptResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Uptime(req *None, resp btcjson.GetMempoolInfoResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["uptime"].Result()
@@ -3168,6 +4988,16 @@ func (r *CAPIClient) AddNode(cmd ...*btcjson.AddNodeCmd) (res None, e error) {
 	return
 }
 
+func (r *CAPIClient) CalculatePackageFee(cmd ...*btcjson.CalculatePackageFeeCmd) (res btcjson.CalculatePackageFeeResult, e error) {
+	var c *btcjson.CalculatePackageFeeCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.CalculatePackageFee", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateRawTransaction(cmd ...*btcjson.CreateRawTransactionCmd) (res string, e error) {
 	var c *btcjson.CreateRawTransactionCmd
 	if len(cmd) > 0 {
@@ -3198,6 +5028,16 @@ func (r *CAPIClient) DecodeScript(cmd ...*btcjson.DecodeScriptCmd) (res btcjson.
 	return
 }
 
+func (r *CAPIClient) DeriveAddresses(cmd ...*btcjson.DeriveAddressesCmd) (res []string, e error) {
+	var c *btcjson.DeriveAddressesCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.DeriveAddresses", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) EstimateFee(cmd ...*btcjson.EstimateFeeCmd) (res float64, e error) {
 	var c *btcjson.EstimateFeeCmd
 	if len(cmd) > 0 {
@@ -3208,6 +5048,26 @@ func (r *CAPIClient) EstimateFee(cmd ...*btcjson.EstimateFeeCmd) (res float64, e
 	return
 }
 
+func (r *CAPIClient) EstimateSmartFee(cmd ...*btcjson.EstimateSmartFeeCmd) (res btcjson.EstimateSmartFeeResult, e error) {
+	var c *btcjson.EstimateSmartFeeCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.EstimateSmartFee", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) EstimatorStats(cmd ...*btcjson.EstimatorStatsCmd) (res btcjson.EstimatorStatsResult, e error) {
+	var c *btcjson.EstimatorStatsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.EstimatorStats", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) Generate(cmd ...*None) (res []string, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3298,6 +5158,16 @@ func (r *CAPIClient) GetBlockHeader(cmd ...*btcjson.GetBlockHeaderCmd) (res btcj
 	return
 }
 
+func (r *CAPIClient) GetBlockPropagation(cmd ...*btcjson.GetBlockPropagationCmd) (res btcjson.GetBlockPropagationResult, e error) {
+	var c *btcjson.GetBlockPropagationCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetBlockPropagation", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBlockTemplate(cmd ...*btcjson.GetBlockTemplateCmd) (res string, e error) {
 	var c *btcjson.GetBlockTemplateCmd
 	if len(cmd) > 0 {
@@ -3308,6 +5178,16 @@ func (r *CAPIClient) GetBlockTemplate(cmd ...*btcjson.GetBlockTemplateCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetCacheStats(cmd ...*None) (res btcjson.GetCacheStatsResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetCacheStats", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetCFilter(cmd ...*btcjson.GetCFilterCmd) (res string, e error) {
 	var c *btcjson.GetCFilterCmd
 	if len(cmd) > 0 {
@@ -3348,6 +5228,16 @@ func (r *CAPIClient) GetCurrentNet(cmd ...*None) (res string, e error) {
 	return
 }
 
+func (r *CAPIClient) GetDescriptorInfo(cmd ...*btcjson.GetDescriptorInfoCmd) (res btcjson.GetDescriptorInfoResult, e error) {
+	var c *btcjson.GetDescriptorInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetDescriptorInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetDifficulty(cmd ...*btcjson.GetDifficultyCmd) (res float64, e error) {
 	var c *btcjson.GetDifficultyCmd
 	if len(cmd) > 0 {
@@ -3358,6 +5248,26 @@ func (r *CAPIClient) GetDifficulty(cmd ...*btcjson.GetDifficultyCmd) (res float6
 	return
 }
 
+func (r *CAPIClient) GetDNSAudit(cmd ...*None) (res btcjson.GetDNSAuditResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetDNSAudit", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetFeatures(cmd ...*None) (res []btcjson.FeatureResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetFeatures", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetGenerate(cmd ...*btcjson.GetHeadersCmd) (res bool, e error) {
 	var c *btcjson.GetHeadersCmd
 	if len(cmd) > 0 {
@@ -3388,6 +5298,16 @@ func (r *CAPIClient) GetHeaders(cmd ...*btcjson.GetHeadersCmd) (res []string, e
 	return
 }
 
+func (r *CAPIClient) GetIndexInfo(cmd ...*btcjson.GetIndexInfoCmd) (res map[string]btcjson.GetIndexInfoResult, e error) {
+	var c *btcjson.GetIndexInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetIndexInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoChainResult0, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3408,6 +5328,26 @@ func (r *CAPIClient) GetMempoolInfo(cmd ...*None) (res btcjson.GetMempoolInfoRes
 	return
 }
 
+func (r *CAPIClient) GetMiningAddresses(cmd ...*None) (res btcjson.GetMiningAddressesResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMiningAddresses", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMiningExpectation(cmd ...*None) (res btcjson.GetMiningExpectationResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMiningExpectation", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetMiningInfo(cmd ...*None) (res btcjson.GetMiningInfoResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3418,6 +5358,16 @@ func (r *CAPIClient) GetMiningInfo(cmd ...*None) (res btcjson.GetMiningInfoResul
 	return
 }
 
+func (r *CAPIClient) GetMiningTemplate(cmd ...*btcjson.GetMiningTemplateCmd) (res btcjson.GetMiningTemplateResult, e error) {
+	var c *btcjson.GetMiningTemplateCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMiningTemplate", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetNetTotals(cmd ...*None) (res btcjson.GetNetTotalsResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3438,6 +5388,56 @@ func (r *CAPIClient) GetNetworkHashPS(cmd ...*btcjson.GetNetworkHashPSCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetNetworkInfo(cmd ...*None) (res btcjson.GetNetworkInfoResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNetworkInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetNodeAddresses(cmd ...*btcjson.GetNodeAddressesCmd) (res []btcjson.NodeAddressResult, e error) {
+	var c *btcjson.GetNodeAddressesCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNodeAddresses", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetNotificationInfo(cmd ...*None) (res btcjson.GetNotificationInfoResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNotificationInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetOrphanBlocks(cmd ...*None) (res btcjson.GetOrphanBlocksResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetOrphanBlocks", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetOrphanTxs(cmd ...*None) (res btcjson.GetOrphanTxsResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetOrphanTxs", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3468,6 +5468,26 @@ func (r *CAPIClient) GetRawTransaction(cmd ...*btcjson.GetRawTransactionCmd) (re
 	return
 }
 
+func (r *CAPIClient) GetScriptFlags(cmd ...*btcjson.GetScriptFlagsCmd) (res btcjson.GetScriptFlagsResult, e error) {
+	var c *btcjson.GetScriptFlagsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetScriptFlags", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetTimestampInfo(cmd ...*btcjson.GetTimestampInfoCmd) (res btcjson.GetTimestampInfoResult, e error) {
+	var c *btcjson.GetTimestampInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetTimestampInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetTxOut(cmd ...*btcjson.GetTxOutCmd) (res string, e error) {
 	var c *btcjson.GetTxOutCmd
 	if len(cmd) > 0 {
@@ -3478,6 +5498,26 @@ func (r *CAPIClient) GetTxOut(cmd ...*btcjson.GetTxOutCmd) (res string, e error)
 	return
 }
 
+func (r *CAPIClient) GetValidationTrace(cmd ...*btcjson.GetValidationTraceCmd) (res []btcjson.GetValidationTraceResult, e error) {
+	var c *btcjson.GetValidationTraceCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetValidationTrace", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetWorkSource(cmd ...*None) (res btcjson.GetWorkSourceResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetWorkSource", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, e error) {
 	var c *btcjson.HelpCmd
 	if len(cmd) > 0 {
@@ -3508,6 +5548,16 @@ func (r *CAPIClient) Ping(cmd ...*None) (res None, e error) {
 	return
 }
 
+func (r *CAPIClient) PrioritiseTransaction(cmd ...*btcjson.PrioritiseTransactionCmd) (res bool, e error) {
+	var c *btcjson.PrioritiseTransactionCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.PrioritiseTransaction", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ResetChain(cmd ...*None) (res None, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3538,6 +5588,16 @@ func (r *CAPIClient) SearchRawTransactions(cmd ...*btcjson.SearchRawTransactions
 	return
 }
 
+func (r *CAPIClient) SelfTest(cmd ...*None) (res btcjson.SelfTestResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SelfTest", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) SendRawTransaction(cmd ...*btcjson.SendRawTransactionCmd) (res None, e error) {
 	var c *btcjson.SendRawTransactionCmd
 	if len(cmd) > 0 {
@@ -3548,6 +5608,26 @@ func (r *CAPIClient) SendRawTransaction(cmd ...*btcjson.SendRawTransactionCmd) (
 	return
 }
 
+func (r *CAPIClient) SetConfig(cmd ...*btcjson.SetConfigCmd) (res None, e error) {
+	var c *btcjson.SetConfigCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetConfig", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) SetFeature(cmd ...*btcjson.SetFeatureCmd) (res None, e error) {
+	var c *btcjson.SetFeatureCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetFeature", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetGenerate(cmd ...*btcjson.SetGenerateCmd) (res None, e error) {
 	var c *btcjson.SetGenerateCmd
 	if len(cmd) > 0 {
@@ -3558,6 +5638,16 @@ func (r *CAPIClient) SetGenerate(cmd ...*btcjson.SetGenerateCmd) (res None, e er
 	return
 }
 
+func (r *CAPIClient) SetMiningAddresses(cmd ...*btcjson.SetMiningAddressesCmd) (res None, e error) {
+	var c *btcjson.SetMiningAddressesCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetMiningAddresses", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) Stop(cmd ...*None) (res None, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3578,6 +5668,16 @@ func (r *CAPIClient) SubmitBlock(cmd ...*btcjson.SubmitBlockCmd) (res string, e
 	return
 }
 
+func (r *CAPIClient) TraceMempoolAccept(cmd ...*btcjson.TraceMempoolAcceptCmd) (res btcjson.TraceMempoolAcceptResult, e error) {
+	var c *btcjson.TraceMempoolAcceptCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.TraceMempoolAccept", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) Uptime(cmd ...*None) (res btcjson.GetMempoolInfoResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -6,7 +6,6 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
-	js "encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +26,7 @@ import (
 	"github.com/btcsuite/websocket"
 	uberatomic "go.uber.org/atomic"
 
+	"github.com/p9c/pod/pkg/addrmgr"
 	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/bits"
 	"github.com/p9c/pod/pkg/block"
@@ -40,9 +40,11 @@ import (
 	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/features"
 	"github.com/p9c/pod/pkg/indexers"
 	"github.com/p9c/pod/pkg/mempool"
 	"github.com/p9c/pod/pkg/mining"
+	"github.com/p9c/pod/pkg/netsync"
 	p "github.com/p9c/pod/pkg/peer"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/util"
@@ -107,6 +109,7 @@ type Server struct {
 	StatusLines                     map[int]string
 	StatusLock                      sync.RWMutex
 	WG                              sync.WaitGroup
+	Requests                        sync.WaitGroup
 	GBTWorkState                    *GBTWorkState
 	HelpCacher                      *HelpCacher
 	RequestProcessShutdown          qu.C
@@ -156,8 +159,21 @@ type ServerConfig struct {
 	TxIndex   *indexers.TxIndex
 	AddrIndex *indexers.AddrIndex
 	CfIndex   *indexers.CFIndex
+	// IndexManager reports the sync state of the optional indexes.
+	IndexManager *indexers.Manager
 	// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into blocks.
 	FeeEstimator *mempool.FeeEstimator
+	// Features switches the optional subsystems of the node on and off, and is reported on by getfeatures.
+	Features *features.Flags
+	// SigCache is the signature verification cache, which is reported on by getcachestats.
+	SigCache *txscript.SigCache
+	// WorkSource is the status of the sources of work of the mining controller, which is reported on by getworksource.
+	WorkSource *WorkSourceStatus
+	// MiningExpectation is the expected time for the miners of the mining controller to find a block, which is
+	// reported on by getminingexpectation.
+	MiningExpectation *MiningExpectation
+	// Reachability is whether the node accepts inbound connections, which is reported on by getnetworkinfo.
+	Reachability *Reachability
 	// Algo sets the algorithm expected from the RPC endpoint. This allows multiple ports to serve multiple types of
 	// miners with one main node per algorithm. Currently 514 for Scrypt and anything else passes for SHA256d.
 	Algo string
@@ -196,6 +212,13 @@ type ServerConnManager interface {
 	ConnectedCount() int32
 	// NetTotals returns the sum of all bytes received and sent across the network for all peers.
 	NetTotals() (uint64, uint64)
+	// Services returns the services the node offers to peers.
+	Services() wire.ServiceFlag
+	// LocalAddresses returns the local addresses the node advertises to peers.
+	LocalAddresses() []addrmgr.LocalAddress
+	// NodeAddresses returns at most count randomly chosen known addresses of nodes that are routable and not bad, or
+	// all of them if count is zero, on the named network unless it is empty.
+	NodeAddresses(count int, network string) []wire.NetAddress
 	// ConnectedPeers returns an array consisting of all connected peers.
 	ConnectedPeers() []ServerPeer
 	// PersistentPeers returns an array consisting of all the persistent peers.
@@ -223,6 +246,9 @@ type ServerPeer interface {
 	GetBanScore() uint32
 	// GetFeeFilter returns the requested current minimum fee rate for which transactions should be announced.
 	GetFeeFilter() int64
+	// GetMsgStats returns the number of messages received from the peer by command, the number of times each
+	// misbehaving message pattern was detected by name, and the number of messages ignored because of throttling.
+	GetMsgStats() (recv, patterns map[string]uint64, dropped uint64)
 }
 
 // ServerSyncManager represents a sync manager for use with the RPC Server.
@@ -240,6 +266,11 @@ type ServerSyncManager interface {
 	Pause() chan<- struct{}
 	// SyncPeerID returns the ID of the peer that is currently the peer being used to sync from or 0 if there is none.
 	SyncPeerID() int32
+	// BlockPropagation returns how the most recently connected blocks announced by peers reached the node, oldest
+	// first.
+	BlockPropagation() []netsync.BlockPropagation
+	// OrphanRequests returns the requests for the missing parents of the chains of orphan blocks held by the chain.
+	OrphanRequests() netsync.OrphanRequests
 	// LocateHeaders returns the headers of the blocks after the first known block in the provided locators until the
 	// provided stop hash or the current tip is reached, up to a max of wire.MaxBlockHeadersPerMsg hashes.
 	LocateHeaders(
@@ -256,6 +287,9 @@ const (
 	// RPCAuthTimeoutSeconds is the number of seconds a connection to the RPC Server is allowed to stay open without
 	// authenticating before it is closed.
 	RPCAuthTimeoutSeconds = 10
+	// RequestDrainTimeout is how long the RPC Server waits for requests that are being handled to finish when it is
+	// stopped.
+	RequestDrainTimeout = 10 * time.Second
 	// GBTNonceRange is two 32-bit big-endian hexadecimal integers which represent the valid ranges of nonces returned
 	// by the getblocktemplate RPC.
 	GBTNonceRange = "00000000ffffffff"
@@ -310,49 +344,109 @@ var (
 	// RPCAskWallet is list of commands that we recognize, but for which pod has no support because it lacks support for
 	// wallet functionality. For these commands the user should ask a connected instance of the wallet.
 	RPCAskWallet = map[string]CommandHandler{
-		"addmultisigaddress":     {},
-		"backupwallet":           {},
-		"createencryptedwallet":  {},
-		"createmultisig":         {},
-		"dumpprivkey":            {},
-		"dumpwallet":             {},
-		"dropwallethistory":      {},
-		"encryptwallet":          {},
-		"getaccount":             {},
-		"getaccountaddress":      {},
-		"getaddressesbyaccount":  {},
-		"getbalance":             {},
-		"getnewaddress":          {},
-		"getrawchangeaddress":    {},
-		"getreceivedbyaccount":   {},
-		"getreceivedbyaddress":   {},
-		"gettransaction":         {},
-		"gettxoutsetinfo":        {},
-		"getunconfirmedbalance":  {},
-		"getwalletinfo":          {},
-		"importprivkey":          {},
-		"importwallet":           {},
-		"keypoolrefill":          {},
-		"listaccounts":           {},
-		"listaddressgroupings":   {},
-		"listlockunspent":        {},
-		"listreceivedbyaccount":  {},
-		"listreceivedbyaddress":  {},
-		"listsinceblock":         {},
-		"listtransactions":       {},
-		"listunspent":            {},
-		"lockunspent":            {},
-		"move":                   {},
-		"sendfrom":               {},
-		"sendmany":               {},
-		"sendtoaddress":          {},
-		"setaccount":             {},
-		"settxfee":               {},
-		"signmessage":            {},
-		"signrawtransaction":     {},
-		"walletlock":             {},
-		"walletpassphrase":       {},
-		"walletpassphrasechange": {},
+		"addmultisigaddress":        {},
+		"addportfolioentry":         {},
+		"archiveaccount":            {},
+		"backupremote":              {},
+		"cancelqueuedpsbt":          {},
+		"backupwallet":              {},
+		"createencryptedwallet":     {},
+		"createmultisig":            {},
+		"createmultisigaccount":     {},
+		"createvaultaccount":        {},
+		"dumpprivkey":               {},
+		"dumpwallet":                {},
+		"deleteaddressmeta":         {},
+		"dropwallethistory":         {},
+		"encryptwallet":             {},
+		"exportaccountxprv":         {},
+		"exportledger":              {},
+		"exportpaymentbundle":       {},
+		"exportwatchset":            {},
+		"freezeunspent":             {},
+		"generatepaperkey":          {},
+		"getaccount":                {},
+		"getaccountaddress":         {},
+		"getaccountxpub":            {},
+		"getaddressinfo":            {},
+		"getaddressmeta":            {},
+		"getaddressesbyaccount":     {},
+		"getauditlog":               {},
+		"getbalance":                {},
+		"getbalanceat":              {},
+		"getnewaddress":             {},
+		"getnewmultisigaddress":     {},
+		"getnewvaultaddress":        {},
+		"getrawchangeaddress":       {},
+		"getreceivedbyaccount":      {},
+		"getreceivedbyaddress":      {},
+		"getpaymentbundle":          {},
+		"getrescaninfo":             {},
+		"getscrubinfo":              {},
+		"getspendauth":              {},
+		"gettransaction":            {},
+		"getutxoreport":             {},
+		"getvaultschedule":          {},
+		"gettxoutsetinfo":           {},
+		"getunconfirmedbalance":     {},
+		"getwalletinfo":             {},
+		"importcorewallet":          {},
+		"importprivkey":             {},
+		"importscriptpubkey":        {},
+		"importtimelockscript":      {},
+		"importwallet":              {},
+		"keypoolrefill":             {},
+		"listaccounts":              {},
+		"listaddressgroupings":      {},
+		"listaddressmeta":           {},
+		"listimmature":              {},
+		"listdustoutputs":           {},
+		"listfrozen":                {},
+		"listinvoices":              {},
+		"listinvoicereservations":   {},
+		"listlockunspent":           {},
+		"listmultisigaccounts":      {},
+		"listpaymentbundles":        {},
+		"listportfolio":             {},
+		"listportfoliotransactions": {},
+		"listqueuedpsbts":           {},
+		"listvaultaccounts":         {},
+		"listreceivedbyaccount":     {},
+		"listreceivedbyaddress":     {},
+		"listsinceblock":            {},
+		"listtransactions":          {},
+		"listtransactionspage":      {},
+		"listunlockattempts":        {},
+		"listunspent":               {},
+		"lockunspent":               {},
+		"move":                      {},
+		"overridedust":              {},
+		"previewsend":               {},
+		"releaseinvoiceaddress":     {},
+		"removeportfolioentry":      {},
+		"reserveinvoiceaddress":     {},
+		"sendfrom":                  {},
+		"sendmany":                  {},
+		"sendtoaddress":             {},
+		"setaccount":                {},
+		"setaddressmeta":            {},
+		"setinvoiceissuance":        {},
+		"setspendauth":              {},
+		"settxfee":                  {},
+		"signmessage":               {},
+		"signrawtransaction":        {},
+		"submitsignedpsbt":          {},
+		"sweepaccount":              {},
+		"sweepprivkey":              {},
+		"sweeptimelocked":           {},
+		"transferaccount":           {},
+		"unarchiveaccount":          {},
+		"walletdbstats":             {},
+		"walletlock":                {},
+		"walletpassphrase":          {},
+		"walletpassphrasechange":    {},
+		"walletselftest":            {},
+		"withdrawvault":             {},
 	}
 
 	// RPCHandlers maps RPC command strings to appropriate handler functions.
@@ -655,29 +749,43 @@ var (
 		"rescan":                {},
 		"rescanblocks":          {},
 		"session":               {},
+		"updatetxfilter":        {},
 		// Websockets AND HTTP/S commands
 		"help": {},
 		// HTTP/S-only commands
+		"calculatepackagefee":   {},
 		"createrawtransaction":  {},
 		"decoderawtransaction":  {},
 		"decodescript":          {},
+		"deriveaddresses":       {},
 		"estimatefee":           {},
+		"estimatesmartfee":      {},
+		"estimatorstats":        {},
 		"getbestblock":          {},
 		"getbestblockhash":      {},
 		"getblock":              {},
 		"getblockcount":         {},
 		"getblockhash":          {},
 		"getblockheader":        {},
+		"getblockpropagation":   {},
 		"getcfilter":            {},
 		"getcfilterheader":      {},
 		"getcurrentnet":         {},
+		"getdescriptorinfo":     {},
 		"getdifficulty":         {},
+		"getfeatures":           {},
 		"getheaders":            {},
 		"getinfo":               {},
 		"getnettotals":          {},
 		"getnetworkhashps":      {},
+		"getnetworkinfo":        {},
+		"getnodeaddresses":      {},
+		"getorphanblocks":       {},
+		"getorphantxs":          {},
 		"getrawmempool":         {},
 		"getrawtransaction":     {},
+		"getscriptflags":        {},
+		"gettimestampinfo":      {},
 		"gettxout":              {},
 		"searchrawtransactions": {},
 		"sendrawtransaction":    {},
@@ -692,7 +800,6 @@ var (
 		"estimatepriority": {},
 		"getchaintips":     {},
 		"getmempoolentry":  {},
-		"getnetworkinfo":   {},
 		"getwork":          {},
 		"invalidateblock":  {},
 		"preciousblock":    {},
@@ -947,8 +1054,8 @@ func (state *GBTWorkState) TemplateUpdateChan(
 // consensus rules).
 //
 // Finally, if the useCoinbaseValue flag is false and the existing block template does not already contain a valid
-// payment address, the block template will be updated with a randomly selected payment address from the list of
-// configured addresses.
+// payment address, the block template will be updated with a payment address selected from the list of configured
+// addresses according to the rotation policy.
 //
 // This function MUST be called with the state locked.
 func (state *GBTWorkState) UpdateBlockTemplate(
@@ -978,16 +1085,13 @@ func (state *GBTWorkState) UpdateBlockTemplate(
 		// Reset the previous best hash the block template was generated against so any errors below cause the next
 		// invocation to try again.
 		state.prevHash = nil
-		// Choose a payment address at random if the caller requests a full coinbase as opposed to only the pertinent
-		// details needed to create their own coinbase.
+		// Choose a payment address according to the rotation policy if the caller requests a full coinbase as opposed
+		// to only the pertinent details needed to create their own coinbase.
 		var payAddr btcaddr.Address
 		if !useCoinbaseValue {
-			payAddr = s.StateCfg.ActiveMiningAddrs[rand.Intn(
-				len(
-					s.StateCfg.
-						ActiveMiningAddrs,
-				),
-			)]
+			if payAddr = s.StateCfg.ActiveMiningAddrs.ForBlock(latestHash); payAddr == nil {
+				return InternalRPCError("no payment addresses specified via --miningaddr", "")
+			}
 		}
 		// Create a new block template that has a coinbase which anyone can redeem.
 		//
@@ -1038,14 +1142,12 @@ func (state *GBTWorkState) UpdateBlockTemplate(
 		// Since this requires mining addresses to be specified via the config, an error is returned if none have been
 		// specified.
 		if !useCoinbaseValue && !template.ValidPayAddress {
-			// Choose a payment address at random.
-			payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(
-				len(
-					s.
-						StateCfg.ActiveMiningAddrs,
-				),
-			)]
-			// Update the block coinbase output of the template to pay to the randomly selected payment address.
+			// Choose a payment address according to the rotation policy.
+			payToAddr := s.StateCfg.ActiveMiningAddrs.ForBlock(latestHash)
+			if payToAddr == nil {
+				return InternalRPCError("no payment addresses specified via --miningaddr", "")
+			}
+			// Update the block coinbase output of the template to pay to the selected payment address.
 			pkScript, e := txscript.PayToAddrScript(payToAddr)
 			if e != nil {
 				context := "Failed to create pay-to-addr script"
@@ -1095,6 +1197,16 @@ func (s *Server) NotifyNewTransactions(txns []*mempool.TxDesc) {
 	}
 }
 
+// NotifyExpiredTransaction notifies websocket clients of a transaction evicted from the mempool because it expired.
+func (s *Server) NotifyExpiredTransaction(tx *util.Tx) {
+	s.NtfnMgr.SendNotifyTxExpired(tx)
+}
+
+// NotifyPeerEvent notifies websocket clients registered for peer updates that a peer connected or disconnected.
+func (s *Server) NotifyPeerEvent(method string, peer btcjson.PeerEventResult) {
+	s.NtfnMgr.SendNotifyPeerEvent(method, peer)
+}
+
 // RequestedProcessShutdown returns a channel that is sent to when an authorized RPC client requests the process to
 // shutdown. If the request can not be read immediately, it is dropped.
 func (s *Server) RequestedProcessShutdown() qu.C {
@@ -1189,6 +1301,18 @@ func (s *Server) Stop() (e error) {
 			return e
 		}
 	}
+	// Let the requests already being handled, such as the stop command that triggered the shutdown, send their replies
+	// before the chain and database are torn down.
+	drained := qu.T()
+	go func() {
+		s.Requests.Wait()
+		drained.Q()
+	}()
+	select {
+	case <-drained.Wait():
+	case <-time.After(RequestDrainTimeout):
+		W.Ln("timed out waiting for RPC requests to finish")
+	}
 	s.NtfnMgr.Shutdown()
 	s.NtfnMgr.WaitForShutdown()
 	s.WG.Wait()
@@ -1325,6 +1449,8 @@ func (s *Server) JSONRPCRead(
 	if atomic.LoadInt32(&s.Shutdown) != 0 {
 		return
 	}
+	s.Requests.Add(1)
+	defer s.Requests.Done()
 	// Read and close the JSON-RPC request body from the caller.
 	var e error
 	var body []byte
@@ -1380,7 +1506,7 @@ func (s *Server) JSONRPCRead(
 	var jsonErr error
 	var result interface{}
 	var request btcjson.Request
-	if e = js.Unmarshal(body, &request); E.Chk(e) {
+	if request, e = btcjson.UnmarshalRequest(body, s.Config.CompatLegacy.True()); E.Chk(e) {
 		jsonErr = &btcjson.RPCError{
 			Code:    btcjson.ErrRPCParse.Code,
 			Message: "Failed to parse request: " + e.Error(),
@@ -1426,6 +1552,17 @@ func (s *Server) JSONRPCRead(
 				}
 			}
 		}
+		// The results can be converted for tools written for other servers by giving a compatibility mode in the query
+		// of the request URL, such as /?compat=bitcoind.
+		compat := r.URL.Query().Get("compat")
+		if jsonErr == nil {
+			if e = btcjson.CheckCompatMode(compat); e != nil {
+				jsonErr = &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidParams.Code,
+					Message: e.Error(),
+				}
+			}
+		}
 		if jsonErr == nil {
 			// Attempt to parse the JSON-RPC request into a known concrete command.
 			parsedCmd := ParseCmd(&request)
@@ -1436,6 +1573,14 @@ func (s *Server) JSONRPCRead(
 				result, jsonErr = s.StandardCmdResult(parsedCmd, closeChan)
 			}
 		}
+		if jsonErr == nil {
+			if result, e = btcjson.CompatResult(compat, result); E.Chk(e) {
+				jsonErr = &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInternal.Code,
+					Message: "Failed to convert result: " + e.Error(),
+				}
+			}
+		}
 	}
 	// Marshal the response.
 	var msg []byte
@@ -39,6 +39,23 @@ var HelpDescsEnUS = map[string]string{
 	// TransactionInput help.
 	"transactioninput-txid": "The hash of the input transaction",
 	"transactioninput-vout": "The specific output of the input transaction to redeem",
+	// CalculatePackageFeeCmd help.
+	"calculatepackagefee--synopsis": "Returns the fee and size of a package of unconfirmed transactions, the transactions given with all of their ancestors in the memory pool, and the fee a child transaction spending from the package must pay for the package with the child to reach a target fee rate.\n" +
+		"Fees changed with prioritisetransaction are not counted, as other nodes do not see them.",
+	"calculatepackagefee-txids":         "The transaction ids of the transactions in the memory pool to include in the package",
+	"calculatepackagefee-targetfeerate": "The fee rate the package with the child should reach in DUO per kB",
+	"calculatepackagefee-childvsize":    "The virtual size of the child in bytes, the size of a transaction spending one input to one pay to pubkey hash output if omitted",
+	// CalculatePackageFeeResult help.
+	"calculatepackagefeeresult-txids":         "The transaction ids of the package, each after the transactions it spends from",
+	"calculatepackagefeeresult-ancestors":     "The transaction ids that were added to the package because the transactions given spend from them",
+	"calculatepackagefeeresult-vsize":         "The total virtual size of the package in bytes",
+	"calculatepackagefeeresult-fee":           "The total fee paid by the package in DUO",
+	"calculatepackagefeeresult-feerate":       "The fee rate of the package in DUO per kB",
+	"calculatepackagefeeresult-targetfeerate": "The target fee rate in DUO per kB",
+	"calculatepackagefeeresult-childvsize":    "The virtual size of the child in bytes",
+	"calculatepackagefeeresult-childfee":      "The fee the child must pay in DUO, zero if the package already pays enough for the child as well",
+	"calculatepackagefeeresult-childfeerate":  "The fee rate of the child alone in DUO per kB",
+	
 	// CreateRawTransactionCmd help.
 	"createrawtransaction--synopsis": "Returns a new transaction spending" +
 		" the provided inputs and sending to the provided addresses.\n" +
@@ -111,6 +128,12 @@ var HelpDescsEnUS = map[string]string{
 	"decodescript--synopsis": "Returns a JSON object with information about" +
 		" the provided hex-encoded script.",
 	"decodescript-hexscript": "Hex-encoded script",
+	// DeriveAddressesCmd help.
+	"deriveaddresses--synopsis": "Derives the addresses of the output scripts a descriptor describes, without a wallet.\n" +
+		"Pay to public key scripts are given the address of the hash of their key. Scripts without an address, such as bare multisig or null data scripts, are an error.",
+	"deriveaddresses-descriptor": "The descriptor, which must have its checksum appended as given by getdescriptorinfo",
+	"deriveaddresses-range":      "For a ranged descriptor, the first and last index to derive addresses for, or the last index alone to start from 0",
+	"deriveaddresses--result0":   "The derived addresses",
 	// EstimateFeeCmd help.
 	"estimatefee--synopsis": "Estimate the fee per kilobyte in satoshis " +
 		"required for a transaction to be mined before a certain number of " +
@@ -119,6 +142,41 @@ var HelpDescsEnUS = map[string]string{
 		"generated before the transaction is mined.",
 	"estimatefee--result0": "Estimated fee per kilobyte in satoshis for a block to " +
 		"be mined in the next NumBlocks blocks.",
+	// EstimateSmartFeeCmd help.
+	"estimatesmartfee--synopsis": "Estimates the fee per kilobyte in DUO for a transaction to confirm within a number of blocks, or of minutes.\n" +
+		"In time mode the estimate is for the most blocks that arrived within the time in 90% of the recent stretches of the chain, which accounts for the uneven arrival of the blocks of the different algorithms.",
+	"estimatesmartfee-conftarget":   "The number of blocks, or in time mode of minutes, the transaction should confirm within",
+	"estimatesmartfee-estimatemode": "Either blocks or time",
+	// EstimateSmartFeeResult help.
+	"estimatesmartfeeresult-feerate":    "The estimated fee per kilobyte in DUO",
+	"estimatesmartfeeresult-blocks":     "The number of blocks the estimate is for",
+	"estimatesmartfeeresult-likelihood": "In time mode, the share of recent stretches of the chain in which that many blocks arrived within the time",
+	"estimatesmartfeeresult-errors":     "Warnings about the estimate, such as blocks rarely arriving within the time",
+	// EstimatorStatsCmd help.
+	"estimatorstats--synopsis": "Returns how well the fee estimates have matched the blocks the observed transactions paying them took to confirm.\n" +
+		"Each transaction is predicted to confirm within the lowest target whose estimate its fee rate pays when it enters the memory pool.\n" +
+		"The counts start over when the node is started.",
+	// EstimatorStatsResult help.
+	"estimatorstatsresult-blocks":         "The number of blocks registered with the fee estimator",
+	"estimatorstatsresult-observed":       "The number of transactions the fee estimator is tracking",
+	"estimatorstatsresult-bias":           "The factor the estimates are multiplied by",
+	"estimatorstatsresult-autobias":       "Whether the bias is adjusted to keep the share of recent predictions that are met near 90%",
+	"estimatorstatsresult-recentcoverage": "The share of the most recent predictions that were met",
+	"estimatorstatsresult-targets":        "The calibration of each target transactions have been predicted to confirm within",
+	"estimatorstatsresult-meaninterval":   "The average number of seconds between the recent blocks the estimates for a number of minutes are made from",
+	"estimatorstatsresult-algos":          "How often the recent blocks of each algorithm arrived",
+	// EstimatorAlgoStatsResult help.
+	"estimatoralgostatsresult-algo":         "The name of the algorithm",
+	"estimatoralgostatsresult-blocks":       "The number of the recent blocks mined with it",
+	"estimatoralgostatsresult-share":        "The share of the recent blocks mined with it",
+	"estimatoralgostatsresult-meaninterval": "The average number of seconds between the recent blocks mined with it",
+	// EstimatorTargetStatsResult help.
+	"estimatortargetstatsresult-target":     "The number of blocks the estimate is for",
+	"estimatortargetstatsresult-feerate":    "The current estimate for the target in DUO per kilobyte, including the bias",
+	"estimatortargetstatsresult-predicted":  "The number of transactions predicted to confirm within the target whose outcome is known",
+	"estimatortargetstatsresult-confirmed":  "The number of them that confirmed within the target",
+	"estimatortargetstatsresult-coverage":   "The share of the predicted transactions that confirmed within the target",
+	"estimatortargetstatsresult-meanblocks": "The average number of blocks the predicted transactions that were mined took to confirm",
 	// GenerateCmd help
 	"generate--synopsis": "Generates a set number of blocks (simnet or" +
 		" regtest only) and returns a JSON\n" +
@@ -251,6 +309,11 @@ var HelpDescsEnUS = map[string]string{
 	"getblockverboseresult-nextblockhash":     "The hash of the next block (only if there is one)",
 	"getblockverboseresult-strippedsize":      "The size of the block without witness data",
 	"getblockverboseresult-weight":            "The weight of the block",
+	"getblockverboseresult-pow_algo_id":       "The ID of the proof-of-work algorithm used for the block",
+	"getblockverboseresult-pow_algo":          "The name of the proof-of-work algorithm used for the block",
+	"getblockverboseresult-pow_hash":          "The proof-of-work hash of the block header",
+	"getblockverboseresult-chainwork":         "The work sum recorded in the block index for this block, in hexadecimal",
+	"getblockverboseresult-algo_difficulty":   "The proof-of-work difficulty as a multiple of the minimum difficulty of the block's algorithm",
 	// GetBlockCountCmd help.
 	"getblockcount--synopsis": "Returns the number of blocks in the longest block chain.",
 	"getblockcount--result0":  "The current block count",
@@ -279,6 +342,28 @@ var HelpDescsEnUS = map[string]string{
 		" difficulty as a multiple of the minimum difficulty",
 	"getblockheaderverboseresult-previousblockhash": "The hash of the previous block",
 	"getblockheaderverboseresult-nextblockhash":     "The hash of the next block (only if there is one)",
+	"getblockheaderverboseresult-pow_algo_id":       "The ID of the proof-of-work algorithm used for the block",
+	"getblockheaderverboseresult-pow_algo":          "The name of the proof-of-work algorithm used for the block",
+	"getblockheaderverboseresult-algo_difficulty":   "The proof-of-work difficulty as a multiple of the minimum difficulty of the block's algorithm",
+	"getblockheaderverboseresult-chainwork":         "The work sum recorded in the block index for this block, in hexadecimal",
+	// GetBlockPropagationCmd help.
+	"getblockpropagation--synopsis": "Returns how the most recently connected blocks announced by peers reached the node.\n" +
+		"Only blocks announced while the chain is current are recorded, and the record starts over when the node is started.",
+	"getblockpropagation-count": "The number of most recent blocks to return",
+	// GetBlockPropagationResult help.
+	"getblockpropagationresult-blocks":           "The propagation of each block, oldest first",
+	"getblockpropagationresult-avglatency":       "The average time from the first announcement of the blocks to them being connected to the chain in milliseconds",
+	"getblockpropagationresult-maxlatency":       "The longest time from the first announcement of a block to it being connected to the chain in milliseconds",
+	"getblockpropagationresult-avgannouncements": "The average number of peers that announced the blocks before they finished downloading",
+	// BlockPropagationResult help.
+	"blockpropagationresult-hash":          "The hash of the block",
+	"blockpropagationresult-height":        "The height of the block",
+	"blockpropagationresult-peer":          "The address of the peer that announced the block first",
+	"blockpropagationresult-announcements": "The number of peers that announced the block before it finished downloading",
+	"blockpropagationresult-announced":     "The time the block was first announced in seconds since 1 Jan 1970 GMT",
+	"blockpropagationresult-download":      "The time from the first announcement to the block finishing downloading in milliseconds",
+	"blockpropagationresult-validation":    "The time taken to validate the block and connect it to the chain in milliseconds",
+	"blockpropagationresult-latency":       "The time from the first announcement to the block being connected to the chain in milliseconds",
 	// TemplateRequest help.
 	"templaterequest-mode":         "This is 'template', 'proposal', or omitted",
 	"templaterequest-capabilities": "List of capabilities",
@@ -356,6 +441,26 @@ var HelpDescsEnUS = map[string]string{
 	"getblocktemplate--condition2": "mode=proposal, accepted",
 	"getblocktemplate--result1":    "An error string which represents why the proposal was rejected or nothing if accepted",
 	
+	// GetCacheStatsCmd help.
+	"getcachestats--synopsis": "Returns statistics of the caches used by block and transaction validation.",
+	
+	// GetCacheStatsResult help.
+	"getcachestatsresult-sigcache": "Statistics of the signature verification cache",
+	
+	// SigCacheStatsResult help.
+	"sigcachestatsresult-entries":     "Number of signatures in the cache",
+	"sigcachestatsresult-hotentries":  "Number of signatures in the hot tier, which holds signatures that have been looked up since they were added",
+	"sigcachestatsresult-coldentries": "Number of signatures in the cold tier, which holds signatures that have not been looked up recently",
+	"sigcachestatsresult-bytes":       "Approximate memory used by the cache in bytes",
+	"sigcachestatsresult-hotbytes":    "Approximate memory used by the hot tier in bytes",
+	"sigcachestatsresult-coldbytes":   "Approximate memory used by the cold tier in bytes",
+	"sigcachestatsresult-maxbytes":    "The most memory the cache may use in bytes",
+	"sigcachestatsresult-hits":        "Number of lookups that found the signature",
+	"sigcachestatsresult-misses":      "Number of lookups that did not find the signature",
+	"sigcachestatsresult-hitrate":     "The share of lookups that found the signature",
+	"sigcachestatsresult-promotions":  "Number of signatures moved from the cold tier to the hot tier",
+	"sigcachestatsresult-evictions":   "Number of signatures removed to make room for new ones",
+	
 	// GetCFilterCmd help.
 	"getcfilter--synopsis":  "Returns a block's committed filter given its hash.",
 	"getcfilter-filtertype": "The type of filter to return (0=regular)",
@@ -376,10 +481,49 @@ var HelpDescsEnUS = map[string]string{
 	"getcurrentnet--synopsis": "Get bitcoin network the Server is running on.",
 	"getcurrentnet--result0":  "The network identifer",
 	
+	// GetDescriptorInfoCmd help.
+	"getdescriptorinfo--synopsis": "Analyses an output script descriptor.\n" +
+		"The descriptor may contain private keys, which are replaced by their public keys in the canonical descriptor returned. Segregated witness descriptors are not supported, as it is not active on this chain.",
+	"getdescriptorinfo-descriptor": "The descriptor, optionally with its checksum appended",
+	// GetDescriptorInfoResult help.
+	"getdescriptorinforesult-descriptor":     "The descriptor in canonical form, with public keys only and its checksum appended",
+	"getdescriptorinforesult-checksum":       "The checksum of the descriptor as it was given",
+	"getdescriptorinforesult-isrange":        "Whether the descriptor describes a range of scripts derived from extended keys",
+	"getdescriptorinforesult-issolvable":     "Whether the descriptor has the information needed to sign for its scripts, given the private keys",
+	"getdescriptorinforesult-hasprivatekeys": "Whether any of the keys of the descriptor were given as private keys",
 	// GetDifficultyCmd help.
 	"getdifficulty--synopsis": "Returns the proof-of-work difficulty as a multiple of the minimum difficulty.",
 	"getdifficulty--result0":  "The difficulty",
 	
+	// GetDNSAuditCmd help.
+	"getdnsaudit--synopsis": "Returns how every host name the node looked up since it started, for DNS seeds and peers, and the RPC server it is configured to connect to, was resolved, so names leaked to the local network by lookups made with the system resolver instead of the proxy can be found.",
+	// GetDNSAuditResult help.
+	"getdnsauditresult-proxy":       "The address of the SOCKS proxy names are resolved through, empty if they are resolved with the system resolver",
+	"getdnsauditresult-proxyonly":   "Whether lookups with the system resolver are refused (toronly)",
+	"getdnsauditresult-leaks":       "The number of host names that were resolved with the system resolver at least once",
+	"getdnsauditresult-resolutions": "How each host name was resolved, in the order of the names",
+	// DNSResolutionResult help.
+	"dnsresolutionresult-host":       "The host name",
+	"dnsresolutionresult-purposes":   "What the host name was resolved for, any of dnsseed, peer, rpcconnect and other",
+	"dnsresolutionresult-direct":     "The number of lookups of the name made with the system resolver",
+	"dnsresolutionresult-proxied":    "The number of lookups of the name made through the proxy",
+	"dnsresolutionresult-local":      "The number of lookups of a name of the machine itself, such as localhost, which never leave it",
+	"dnsresolutionresult-refused":    "The number of lookups of the name with the system resolver that were refused",
+	"dnsresolutionresult-failures":   "The number of lookups of the name that failed",
+	"dnsresolutionresult-lastpath":   "How the name was resolved the last time, one of direct, proxy, local or refused",
+	"dnsresolutionresult-lastlookup": "The time of the latest lookup of the name in seconds since 1 Jan 1970 GMT",
+	"dnsresolutionresult-lasterror":  "The error of the latest lookup of the name, if it failed",
+	
+	// GetFeaturesCmd help.
+	"getfeatures--synopsis": "Returns the optional features of the node and whether each one is on.",
+	"getfeatures--result0":  "The features",
+	
+	// FeatureResult help.
+	"featureresult-name":         "The name of the feature, as given to setfeature",
+	"featureresult-description":  "What the feature does",
+	"featureresult-experimental": "Whether the feature is experimental, which getnetworkinfo warns about while it is on",
+	"featureresult-enabled":      "Whether the feature is on",
+	
 	// GetGenerateCmd help.
 	"getgenerate--synopsis": "Returns if the Server is set to generate coins (mine) or not.",
 	"getgenerate--result0":  "True if mining, false if not",
@@ -424,6 +568,20 @@ var HelpDescsEnUS = map[string]string{
 	"getheaders-hashstop":      "Block hash to stop including block headers for; if not found, all headers to the latest known block are returned.",
 	"getheaders--result0":      "Serialized block headers of all located blocks, limited to some arbitrary maximum number of hashes (currently 2000, which matches the wire protocol headers message, but this is not guaranteed)",
 	
+	// GetIndexInfoCmd help.
+	"getindexinfo--synopsis":       "Returns the sync state of the optional indexes. Indexes that are behind the best chain are caught up in the background.",
+	"getindexinfo-indexname":       "Only return the state of the index with this name",
+	"getindexinfo--result0--desc":  "Index states keyed by the name of the index",
+	"getindexinfo--result0--key":   "Index name",
+	"getindexinfo--result0--value": "Object containing the state of the index",
+	
+	// GetIndexInfoResult help.
+	"getindexinforesult-synced":            "Whether the index has the best block of the chain",
+	"getindexinforesult-best_block_height": "The height of the last block in the index, -1 if it has no blocks yet",
+	"getindexinforesult-progress":          "The fraction of the blocks of the chain that are in the index, from 0 to 1",
+	"getindexinforesult-workers":           "The number of workers building the index while it is caught up in parallel",
+	"getindexinforesult-blocks_per_second":  "The rate the index is being caught up at while it is caught up in parallel",
+	
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
 	
@@ -431,8 +589,10 @@ var HelpDescsEnUS = map[string]string{
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 	
 	// GetMempoolInfoResult help.
-	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
-	"getmempoolinforesult-size":  "Number of transactions in the mempool",
+	"getmempoolinforesult-bytes":   "Size in bytes of the mempool",
+	"getmempoolinforesult-size":    "Number of transactions in the mempool",
+	"getmempoolinforesult-expiry":  "Hours a transaction may stay in the mempool without being mined, 0 if transactions do not expire",
+	"getmempoolinforesult-expired": "Number of transactions evicted from the mempool because they expired",
 	
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":             "Height of the latest best block",
@@ -448,6 +608,52 @@ var HelpDescsEnUS = map[string]string{
 	"getmininginforesult-pooledtx":           "Number of transactions in the memory pool",
 	"getmininginforesult-testnet":            "Whether or not Server is using testnet",
 	
+	// GetMiningAddressesCmd help.
+	"getminingaddresses--synopsis": "Returns the addresses the coinbase of mined blocks pays to, and how the address of each block is picked from them.",
+	
+	// GetMiningAddressesResult help.
+	"getminingaddressesresult-addresses": "The addresses mined blocks pay to",
+	"getminingaddressesresult-rotation":  "How the address of each block is picked: random or roundrobin",
+	
+	// GetMiningExpectationCmd help.
+	"getminingexpectation--synopsis": "Returns how long the miners taking work from the mining controller of this node can expect to take to find a block, from the hashrate they report and the difficulty of each algorithm.\n" +
+		"Blocks are found at random, so the chance of finding at least one within each of a few periods is given as well. The result is empty while the controller is not running.",
+	
+	// GetMiningExpectationResult help.
+	"getminingexpectationresult-updated":      "When the expectation was last worked out in seconds since 1 Jan 1970 GMT",
+	"getminingexpectationresult-height":       "The height of the blocks being mined",
+	"getminingexpectationresult-hashrate":     "The hashes per second reported by the miners for all the algorithms",
+	"getminingexpectationresult-expectedtime": "The average number of seconds to find a block with any of the algorithms, or 0 if no hashes have been reported",
+	"getminingexpectationresult-windows":      "The chance of finding at least one block with any of the algorithms within each period",
+	"getminingexpectationresult-algos":        "The expectation for each of the algorithms",
+	
+	// MiningAlgoExpectationResult help.
+	"miningalgoexpectationresult-algo":         "The name of the algorithm",
+	"miningalgoexpectationresult-version":      "The block version of the algorithm",
+	"miningalgoexpectationresult-difficulty":   "The difficulty of the next block of the algorithm",
+	"miningalgoexpectationresult-hashrate":     "The hashes per second reported by the miners for the algorithm",
+	"miningalgoexpectationresult-expectedtime": "The average number of seconds to find a block with the algorithm, or 0 if no hashes have been reported for it",
+	"miningalgoexpectationresult-windows":      "The chance of finding at least one block with the algorithm within each period",
+	
+	// MiningWindowResult help.
+	"miningwindowresult-window":      "The length of the period in seconds",
+	"miningwindowresult-probability": "The chance of finding at least one block within the period, from 0 to 1",
+	
+	// GetMiningTemplateCmd help.
+	"getminingtemplate--synopsis": "Returns a block to mine with each of the algorithms, building on the best block of this node and paying to the given address.\n" +
+		"Used by mining controllers to take their work from this node when their own node stalls.",
+	"getminingtemplate-address": "The address the coinbase of the blocks pays to",
+	
+	// GetMiningTemplateResult help.
+	"getminingtemplateresult-height":            "The height of the blocks",
+	"getminingtemplateresult-previousblockhash": "The hash of the block the blocks build on",
+	"getminingtemplateresult-blocks":            "A block to mine for each of the algorithms",
+	
+	// MiningTemplateBlockResult help.
+	"miningtemplateblockresult-version": "The block version of the algorithm",
+	"miningtemplateblockresult-algo":    "The name of the algorithm",
+	"miningtemplateblockresult-hex":     "The serialized, hex-encoded block",
+	
 	// GetMiningInfoCmd help.
 	"getmininginfo--synopsis": "Returns a JSON object containing mining-related information.",
 	
@@ -465,28 +671,204 @@ var HelpDescsEnUS = map[string]string{
 	"getnettotalsresult-totalbytessent": "Total bytes sent",
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 	
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing information about the peer-to-peer network state of the node.",
+	
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the node as a numeric value",
+	"getnetworkinforesult-subversion":      "The user agent the node sends to peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "The services the node offers to peers, in hexadecimal",
+	"getnetworkinforesult-localrelay":      "Whether transactions are relayed, which they are not in blocks only mode",
+	"getnetworkinforesult-timeoffset":      "The time offset in seconds",
+	"getnetworkinforesult-clockskew":       "The median offset in seconds of the clocks of peers from the local clock, leaving out outliers, which unlike the time offset is not limited",
+	"getnetworkinforesult-timesamples":     "The number of peer time samples the clock skew is estimated from",
+	"getnetworkinforesult-connections":     "The number of connected peers",
+	"getnetworkinforesult-networkactive":   "Whether networking is enabled",
+	"getnetworkinforesult-networks":        "The state of each network the node can connect over",
+	"getnetworkinforesult-relayfee":        "The minimum fee rate for transactions to be relayed in DUO/kB",
+	"getnetworkinforesult-incrementalfee":  "The minimum fee rate increase for transactions to be accepted, the same as the relay fee",
+	"getnetworkinforesult-localaddresses":  "The local addresses the node advertises to peers",
+	"getnetworkinforesult-reachability":    "Whether the node accepts inbound connections from the internet",
+	"getnetworkinforesult-warnings":        "Warnings about the state of the node, such as the local clock being wrong or experimental features being enabled",
+	
+	// NetworksResult help.
+	"networksresult-name":                        "The network, ipv4, ipv6 or onion",
+	"networksresult-limited":                     "Whether connections over the network are disabled",
+	"networksresult-reachable":                   "Whether peers can be reached over the network",
+	"networksresult-proxy":                       "The proxy used to connect over the network, empty if there is none",
+	"networksresult-proxy_randomize_credentials": "Whether random credentials are used for each connection through the proxy",
+	
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The local address",
+	"localaddressesresult-port":    "The port of the local address",
+	"localaddressesresult-score":   "The priority of the local address, higher is preferred",
+	
+	// ReachabilityResult help.
+	"reachabilityresult-status":          "Whether the node can be reached from the internet, reachable, unreachable or unknown",
+	"reachabilityresult-method":          "How the node was found to be reachable, selfconnect when it connected to itself through its external address or inbound when a peer on the internet connected to it",
+	"reachabilityresult-nat":             "The protocol the listening port is mapped on the router with, upnp, natpmp or pcp, empty if it is not mapped",
+	"reachabilityresult-externaladdress": "The external address the listening port is mapped to",
+	"reachabilityresult-lastchecked":     "The time reachability was last checked in seconds since 1 Jan 1970 GMT",
+	"reachabilityresult-error":           "The error the last port mapping or probe of the external address failed with",
+	
+	// GetNodeAddressesCmd help.
+	"getnodeaddresses--synopsis": "Returns randomly chosen addresses of nodes known to the address manager that are routable and have not repeatedly failed, for crawlers and for bootstrapping other nodes.",
+	"getnodeaddresses-count":     "The most addresses to return, or 0 for all of them",
+	"getnodeaddresses-network":   "If set, only addresses on this network, ipv4, ipv6 or onion, are returned",
+	"getnodeaddresses--result0":  "The addresses of the nodes",
+	
+	// NodeAddressResult help.
+	"nodeaddressresult-time":     "The time the node was last seen in seconds since 1 Jan 1970 GMT",
+	"nodeaddressresult-services": "The services the node advertised, in hexadecimal",
+	"nodeaddressresult-address":  "The IP address, or the .onion name of a Tor address, of the node",
+	"nodeaddressresult-port":     "The port of the node",
+	"nodeaddressresult-network":  "The network the address is on, ipv4, ipv6 or onion",
+	
+	// GetNotificationInfoCmd help.
+	"getnotificationinfo--synopsis": "Returns the websocket notification endpoints, the topics each connected client is subscribed to and notification delivery statistics.",
+	
+	// GetNotificationInfoResult help.
+	"getnotificationinforesult-endpoints": "The endpoints notifications are published on",
+	"getnotificationinforesult-clients":   "The currently connected notification subscribers",
+	"getnotificationinforesult-queued":    "Total notifications queued for connected subscribers",
+	"getnotificationinforesult-sent":      "Total notifications delivered to connected subscribers",
+	"getnotificationinforesult-dropped":   "Total notifications discarded because the subscriber disconnected or fell too far behind",
+	
+	// NotificationEndpointResult help.
+	"notificationendpointresult-address":     "The URL subscribers connect to",
+	"notificationendpointresult-topics":      "The notification types that can be subscribed to on this endpoint",
+	"notificationendpointresult-hwm":         "Number of outbound messages buffered per subscriber before queueing",
+	"notificationendpointresult-queuelimit":  "Number of notifications queued per subscriber before the slow subscriber policy applies",
+	"notificationendpointresult-slowclients": "What happens to subscribers whose queue is full, drop to discard the oldest notifications, disconnect to disconnect them",
+	
+	// NotificationClientResult help.
+	"notificationclientresult-addr":             "The remote address of the subscriber",
+	"notificationclientresult-sessionid":        "The session ID of the subscriber",
+	"notificationclientresult-topics":           "The notification types the subscriber currently receives",
+	"notificationclientresult-watchedaddresses": "Number of addresses watched for received outputs",
+	"notificationclientresult-watchedoutpoints": "Number of outpoints watched for spends",
+	"notificationclientresult-queued":           "Notifications queued for the subscriber",
+	"notificationclientresult-sent":             "Notifications written to the subscriber",
+	"notificationclientresult-dropped":          "Notifications discarded because the subscriber disconnected or fell too far behind",
+	"notificationclientresult-pending":          "Notifications waiting to be written",
+	"notificationclientresult-pendinghwm":       "The highest number of notifications that have been waiting to be written",
+	"notificationclientresult-overflows":        "Notifications that found the queue of the subscriber full",
+	
+	// GetOrphanBlocksCmd help.
+	"getorphanblocks--synopsis": "Returns the blocks held because their parent is not known, what happened to the blocks held since the node was started, and the requests for their missing parents.",
+	// GetOrphanBlocksResult help.
+	"getorphanblocksresult-count":      "The number of orphan blocks held",
+	"getorphanblocksresult-limit":      "The most orphan blocks that are held, set with --maxorphanblocks",
+	"getorphanblocksresult-processed":  "The number of blocks with a known parent that were processed",
+	"getorphanblocksresult-added":      "The number of orphan blocks that were held",
+	"getorphanblocksresult-connected":  "The number of orphan blocks accepted into the chain after their parent arrived",
+	"getorphanblocksresult-rejected":   "The number of orphan blocks that failed the checks done once their parent arrived",
+	"getorphanblocksresult-expired":    "The number of orphan blocks dropped because their parent did not arrive within an hour",
+	"getorphanblocksresult-evicted":    "The number of the oldest orphan blocks dropped to make room for newer ones",
+	"getorphanblocksresult-orphanrate": "The fraction of the blocks processed that were orphans",
+	"getorphanblocksresult-requests":   "The number of times the blocks leading up to orphan blocks were requested from peers",
+	"getorphanblocksresult-rerequests": "The number of those requests made to another peer after an earlier request timed out",
+	"getorphanblocksresult-abandoned":  "The number of missing parents that were given up on after being requested too many times",
+	"getorphanblocksresult-orphans":    "The orphan blocks held, oldest first",
+	// OrphanBlockResult help.
+	"orphanblockresult-hash":              "The hash of the block",
+	"orphanblockresult-previousblockhash": "The hash of the parent of the block",
+	"orphanblockresult-root":              "The hash of the first orphan block of the chain of orphans the block is part of, whose parent is missing",
+	"orphanblockresult-size":              "The size of the block in bytes",
+	"orphanblockresult-received":          "The time the block was received in seconds since 1 Jan 1970 GMT",
+	"orphanblockresult-expires":           "The time the block is dropped if its parent has not arrived in seconds since 1 Jan 1970 GMT",
+	"orphanblockresult-peers":             "The addresses of the peers known to have the chain of orphans",
+	"orphanblockresult-parentrequests":    "The number of times the missing parent of the chain of orphans was requested",
+	// GetOrphanTxsCmd help.
+	"getorphantxs--synopsis": "Returns the transactions held because some of the outputs they spend are not known, with those outputs, and what happened to the orphan transactions held since the node was started.",
+	// GetOrphanTxsResult help.
+	"getorphantxsresult-count":    "The number of orphan transactions held",
+	"getorphantxsresult-limit":    "The most orphan transactions that are held, set with --maxorphantxs",
+	"getorphantxsresult-added":    "The number of orphan transactions that were held",
+	"getorphantxsresult-resolved": "The number of orphan transactions accepted into the memory pool after their parents arrived",
+	"getorphantxsresult-rejected": "The number of orphan transactions found to be invalid once their parents arrived, along with those spending their outputs",
+	"getorphantxsresult-expired":  "The number of orphan transactions dropped because their parents did not arrive in time",
+	"getorphantxsresult-evicted":  "The number of orphan transactions dropped to make room for newer ones",
+	"getorphantxsresult-removed":  "The number of orphan transactions dropped because they were mined, conflicted with a transaction in the memory pool or the peer that sent them disconnected",
+	"getorphantxsresult-orphans":  "The orphan transactions held, oldest first",
+	// OrphanTxResult help.
+	"orphantxresult-txid":     "The hash of the transaction",
+	"orphantxresult-size":     "The size of the transaction in bytes",
+	"orphantxresult-received": "The time the transaction was received in seconds since 1 Jan 1970 GMT",
+	"orphantxresult-expires":  "The time the transaction is dropped if its parents have not arrived in seconds since 1 Jan 1970 GMT",
+	"orphantxresult-from":     "The ID of the peer the transaction was received from",
+	"orphantxresult-missing":  "The outputs spent by the transaction that are not known, as txid:index",
+	
+	// GetScriptFlagsCmd help.
+	"getscriptflags--synopsis": "Returns the script verification rules blocks are checked with at a height, and the network upgrades that switch them on.",
+	"getscriptflags-height":    "The height of the block to return the rules of, the next block when omitted",
+	// GetScriptFlagsResult help.
+	"getscriptflagsresult-height":   "The height of the block the rules are of",
+	"getscriptflagsresult-flags":    "The bitmask of the script verification flags",
+	"getscriptflagsresult-names":    "The names of the script verification flags",
+	"getscriptflagsresult-upgrades": "The network upgrades that switch on script verification flags, in order of height",
+	// ScriptFlagUpgradeResult help.
+	"scriptflagupgraderesult-height": "The height of the first block checked with the flags of the upgrade",
+	"scriptflagupgraderesult-added":  "The names of the script verification flags the upgrade switches on",
+	"scriptflagupgraderesult-active": "Whether the upgrade applies to the block at the height asked for",
+	// GetTimestampInfoCmd help.
+	"gettimestampinfo--synopsis": "Returns the median time past of the best block, the earliest and latest timestamps the next block may have, and how the timestamps of the recent blocks of each algorithm drift, for miners to set valid timestamps and for monitoring to spot attempts to warp time.",
+	"gettimestampinfo-blocks":    "The number of recent blocks to measure the timestamps of the algorithms over, at most 20000",
+	// GetTimestampInfoResult help.
+	"gettimestampinforesult-height":       "The height of the best block",
+	"gettimestampinforesult-hash":         "The hash of the best block",
+	"gettimestampinforesult-time":         "The timestamp of the best block in seconds since 1 Jan 1970 GMT",
+	"gettimestampinforesult-mediantime":   "The median time past of the best block in seconds since 1 Jan 1970 GMT",
+	"gettimestampinforesult-adjustedtime": "The time of the node adjusted by the median offset of the clocks of its peers in seconds since 1 Jan 1970 GMT",
+	"gettimestampinforesult-timeoffset":   "The offset in seconds of the adjusted time from the clock of the node",
+	"gettimestampinforesult-mintime":      "The earliest timestamp the next block may have in seconds since 1 Jan 1970 GMT",
+	"gettimestampinforesult-maxtime":      "The latest timestamp the next block may have in seconds since 1 Jan 1970 GMT, the adjusted time plus the allowed offset",
+	"gettimestampinforesult-blocks":       "The number of recent blocks the timestamps of the algorithms were measured over",
+	"gettimestampinforesult-algos":        "The drift of the timestamps of each algorithm with blocks among the recent blocks",
+	// AlgoTimestampsResult help.
+	"algotimestampsresult-algo":          "The name of the algorithm",
+	"algotimestampsresult-version":       "The block version of the algorithm",
+	"algotimestampsresult-blocks":        "The number of recent blocks of the algorithm",
+	"algotimestampsresult-lastheight":    "The height of the newest block of the algorithm",
+	"algotimestampsresult-lasttime":      "The timestamp of the newest block of the algorithm in seconds since 1 Jan 1970 GMT",
+	"algotimestampsresult-targetspacing": "The spacing in seconds between blocks of the algorithm its difficulty adjustment aims for",
+	"algotimestampsresult-meanspacing":   "The mean spacing in seconds between the recent blocks of the algorithm",
+	"algotimestampsresult-minahead":      "The fewest seconds the timestamp of a block of the algorithm is ahead of the median time past of its parent",
+	"algotimestampsresult-meanahead":     "The mean seconds the timestamps of the blocks of the algorithm are ahead of the median time past of their parents",
+	"algotimestampsresult-maxahead":      "The most seconds the timestamp of a block of the algorithm is ahead of the median time past of its parent",
+	"algotimestampsresult-backwards":     "The number of blocks of the algorithm with a timestamp before that of their parent",
 	// GetPeerInfoResult help.
-	"getpeerinforesult-id":             "A unique node ID",
-	"getpeerinforesult-addr":           "The ip address and port of the peer",
-	"getpeerinforesult-addrlocal":      "Local address",
-	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
-	"getpeerinforesult-relaytxes":      "Peer has requested transactions be relayed to it",
-	"getpeerinforesult-lastsend":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-lastrecv":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-bytessent":      "Total bytes sent",
-	"getpeerinforesult-bytesrecv":      "Total bytes received",
-	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-timeoffset":     "The time offset of the peer",
-	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
-	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
-	"getpeerinforesult-version":        "The protocol version of the peer",
-	"getpeerinforesult-subver":         "The user agent of the peer",
-	"getpeerinforesult-inbound":        "Whether or not the peer is an inbound connection",
-	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
-	"getpeerinforesult-currentheight":  "The current height of the peer",
-	"getpeerinforesult-banscore":       "The ban score",
-	"getpeerinforesult-feefilter":      "The requested minimum fee a transaction must have to be announced to the peer",
-	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-id":              "A unique node ID",
+	"getpeerinforesult-addr":            "The ip address and port of the peer",
+	"getpeerinforesult-addrlocal":       "Local address",
+	"getpeerinforesult-services":        "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":       "Peer has requested transactions be relayed to it",
+	"getpeerinforesult-lastsend":        "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":        "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":       "Total bytes sent",
+	"getpeerinforesult-bytesrecv":       "Total bytes received",
+	"getpeerinforesult-conntime":        "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":      "The time offset of the peer",
+	"getpeerinforesult-pingtime":        "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":        "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-version":         "The protocol version of the peer",
+	"getpeerinforesult-subver":          "The user agent of the peer",
+	"getpeerinforesult-inbound":         "Whether or not the peer is an inbound connection",
+	"getpeerinforesult-startingheight":  "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":   "The current height of the peer",
+	"getpeerinforesult-banscore":        "The ban score",
+	"getpeerinforesult-feefilter":       "The requested minimum fee a transaction must have to be announced to the peer",
+	"getpeerinforesult-syncnode":        "Whether or not the peer is the sync peer",
+	"getpeerinforesult-msgrecv":         "The number of messages received from the peer by command",
+	"getpeerinforesult-msgrecv--key":    "command",
+	"getpeerinforesult-msgrecv--value":  "n",
+	"getpeerinforesult-msgrecv--desc":   "The number of messages received with the command",
+	"getpeerinforesult-patterns":        "The number of times each misbehaving message pattern was detected",
+	"getpeerinforesult-patterns--key":   "pattern",
+	"getpeerinforesult-patterns--value": "n",
+	"getpeerinforesult-patterns--desc":  "The number of times the pattern (invflood, getheadersrepeat or addrspam) was detected",
+	"getpeerinforesult-msgdropped":      "The number of messages ignored because the peer was throttled for a misbehaving message pattern",
 	
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
@@ -499,8 +881,8 @@ var HelpDescsEnUS = map[string]string{
 	"getrawmempoolverboseresult-startingpriority": "Priority when transaction entered the pool",
 	"getrawmempoolverboseresult-currentpriority":  "Current priority",
 	"getrawmempoolverboseresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
-	"getrawmempoolverboseresult-vsize":            "The virtual size of a transaction",
-	"getrawmempoolverboseresult-weight":           "The transaction's weight (between vsize*4-3 and vsize*4)",
+	"getrawmempoolverboseresult-vsize":            "The virtual size of a transaction, counting each signature operation for bytespersigop bytes where they come to more than its weight",
+	"getrawmempoolverboseresult-weight":           "The transaction's weight (at most vsize*4)",
 	
 	// GetRawMempoolCmd help.
 	"getrawmempool--synopsis":   "Returns information about all of the transactions currently in the memory pool.",
@@ -531,6 +913,37 @@ var HelpDescsEnUS = map[string]string{
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
 	
+	// GetValidationTraceCmd help.
+	"getvalidationtrace--synopsis": "Returns how long each phase of validating the most recently processed blocks took, newest first.\n" +
+		"The node must be started with --valtrace.",
+	"getvalidationtrace-count": "The number of blocks to return",
+	
+	// GetValidationTraceResult help.
+	"getvalidationtraceresult-hash":        "The hash of the block",
+	"getvalidationtraceresult-height":      "The height of the block",
+	"getvalidationtraceresult-time":        "When the block finished processing in seconds since 1 Jan 1970 GMT",
+	"getvalidationtraceresult-deserialize": "Milliseconds taken to decode the block",
+	"getvalidationtraceresult-merkle":      "Milliseconds taken to build the merkle tree and check the merkle root",
+	"getvalidationtraceresult-utxofetch":   "Milliseconds taken to load the outputs spent by the block",
+	"getvalidationtraceresult-scriptcheck": "Milliseconds taken to validate the transaction scripts",
+	"getvalidationtraceresult-utxoflush":   "Milliseconds taken to write the block and the utxo set changes to the database",
+	"getvalidationtraceresult-total":      "Milliseconds taken to process the block, including the phases above",
+	
+	// GetWorkSourceCmd help.
+	"getworksource--synopsis": "Returns the node the mining controller is taking its work from, and the health of the nodes it can fail over to.",
+	
+	// GetWorkSourceResult help.
+	"getworksourceresult-active":  "The node work is being taken from, local for this node",
+	"getworksourceresult-since":   "When work started being taken from the active node in seconds since 1 Jan 1970 GMT",
+	"getworksourceresult-sources": "The health of each of the nodes work can be taken from",
+	
+	// WorkSourceResult help.
+	"worksourceresult-host":    "The address of the node, local for this node",
+	"worksourceresult-healthy": "Whether work can be taken from the node",
+	"worksourceresult-height":  "The height of the best block of the node",
+	"worksourceresult-checked": "When the node was last checked in seconds since 1 Jan 1970 GMT",
+	"worksourceresult-error":   "Why the node is not healthy, if it is not",
+	
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -543,6 +956,14 @@ var HelpDescsEnUS = map[string]string{
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 	
+	// PrioritiseTransactionCmd help.
+	"prioritisetransaction--synopsis": "Changes the fee a transaction is ranked by when it is accepted to the memory pool and selected for block templates, without changing the fee it pays.\n" +
+		"Deltas for the same transaction add up, and can be set before the transaction arrives. They apply until it leaves the memory pool.",
+	"prioritisetransaction-txid":          "The hash of the transaction",
+	"prioritisetransaction-prioritydelta": "This parameter is currently ignored",
+	"prioritisetransaction-feedelta":      "The amount in satoshi to add to the fee of the transaction, or subtract if it is negative",
+	"prioritisetransaction--result0":      "Always true",
+	
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -560,21 +981,59 @@ var HelpDescsEnUS = map[string]string{
 	"searchrawtransactions-filteraddrs": "Address list.  Only inputs or outputs with matching address will be returned",
 	"searchrawtransactions--result0":    "Hex-encoded serialized transaction",
 	
+	// SelfTestCmd help.
+	"selftest--synopsis": "Runs the known answer test vectors of the registered proof of work algorithms and returns the outcome for each.",
+	
+	// SelfTestResult help.
+	"selftestresult-passed":     "Whether the vectors of all algorithms passed",
+	"selftestresult-algorithms": "The outcome of the vectors of each algorithm",
+	
+	// SelfTestAlgorithmResult help.
+	"selftestalgorithmresult-name":     "The name of the algorithm",
+	"selftestalgorithmresult-fork":     "The hard fork the algorithm belongs to",
+	"selftestalgorithmresult-version":  "The block version of the algorithm",
+	"selftestalgorithmresult-vectors":  "The number of test vectors of the algorithm",
+	"selftestalgorithmresult-passed":   "Whether all the vectors of the algorithm passed",
+	"selftestalgorithmresult-failures": "The vectors that failed and the hashes they produced",
+	
 	// SendRawTransactionCmd help.
 	"sendrawtransaction--synopsis":     "Submits the serialized, hex-encoded transaction to the local peer and relays it to the network.",
 	"sendrawtransaction-hextx":         "Serialized, hex-encoded signed transaction",
-	"sendrawtransaction-allowhighfees": "Whether or not to allow insanely high fees (btcd does not yet implement this parameter, so it has no effect)",
+	"sendrawtransaction-allowhighfees": "Whether or not to allow fees over the maxtxfee setting of the node, which are otherwise rejected as a likely mistake",
 	"sendrawtransaction-maxfeerate":    "Used by bitcoind on or after v0.19.0",
 	"sendrawtransaction--result0":      "The hash of the transaction",
 	
+	// SetConfigCmd help.
+	"setconfig--synopsis": "Sets an option of the configuration that takes effect without restarting the node, and saves the configuration file.\n" +
+		"Options that need a restart are refused, and are set in the configuration file instead.",
+	"setconfig-name":  "The name of the option, as in the configuration file",
+	"setconfig-value": "The new value, with lists comma separated and durations such as 90s or 1h30m",
+	
+	// SetFeatureCmd help.
+	"setfeature--synopsis": "Switches an optional feature of the node on or off.\n" +
+		"The setting is saved and kept when the node restarts, taking precedence over the features configuration.",
+	"setfeature-name":   "The name of the feature, as listed by getfeatures",
+	"setfeature-enable": "Use true to switch the feature on, false to switch it off",
+	
 	// SetGenerateCmd help.
 	"setgenerate--synopsis":    "Set the Server to generate coins (mine) or not.",
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
 	"setgenerate-genproclimit": "The number of processors (cores) to limit generation to or -1 for default",
 	
+	// SetMiningAddressesCmd help.
+	"setminingaddresses--synopsis": "Replaces the addresses the coinbase of mined blocks pays to, from the next block template on.\n" +
+		"Each block pays to one of the addresses, picked at random or in turn, so a single address is not reused for every block. " +
+		"The change is not saved to the configuration file.",
+	"setminingaddresses-addresses": "The addresses mined blocks pay to, or an empty list to stop paying to configured addresses",
+	"setminingaddresses-rotation":  "How the address of each block is picked: random or roundrobin (default: keep the current policy)",
+	
 	// StopCmd help.
-	"stop--synopsis": "Shutdown btcd.",
-	"stop--result0":  "The string 'btcd stopping.'",
+	"stop--synopsis": "Gracefully shut down the node, saving the fee estimator and mempool and closing the database.",
+	"stop--result0":  "The string 'pod stopping.'",
+	
+	// RestartCmd help.
+	"restart--synopsis": "Gracefully shut down the node like stop, then start it again with the same arguments.",
+	"restart--result0":  "The string 'pod restarting.'",
 	
 	// SubmitBlockOptions help.
 	"submitblockoptions-workid": "This parameter is currently ignored",
@@ -587,6 +1046,30 @@ var HelpDescsEnUS = map[string]string{
 	"submitblock--condition1": "Block rejected",
 	"submitblock--result1":    "The reason the block was rejected",
 	
+	// TraceMempoolAcceptCmd help.
+	"tracemempoolaccept--synopsis": "Runs the checks a transaction has to pass to be accepted to the memory pool and returns them in order, without adding it to the pool or relaying it.\n" +
+		"Checking stops at the first failure, whose details say why the transaction would be rejected.",
+	"tracemempoolaccept-hextx":         "Serialized, hex-encoded signed transaction",
+	"tracemempoolaccept-allowhighfees": "Don't fail the check of the fee against the maximum fee of the node",
+	
+	// TraceMempoolAcceptResult help.
+	"tracemempoolacceptresult-txid":           "The hash of the transaction",
+	"tracemempoolacceptresult-accepted":       "Whether the transaction passed every check and would be accepted to the memory pool",
+	"tracemempoolacceptresult-checks":         "The checks that were run, in order",
+	"tracemempoolacceptresult-missingparents": "The hashes of the transactions spent from that are not known, when the transaction is an orphan",
+	"tracemempoolacceptresult-fee":            "The fee paid by the transaction in DUO, 0 if the inputs were not checked",
+	"tracemempoolacceptresult-modifiedfee":    "The fee including any delta set with prioritisetransaction in DUO",
+	"tracemempoolacceptresult-minfee":         "The fee required to relay the transaction in DUO",
+	"tracemempoolacceptresult-vsize":          "The virtual size of the transaction the fees are computed from",
+	"tracemempoolacceptresult-scriptinput":    "The index of the input whose script failed to verify",
+	"tracemempoolacceptresult-scriptposition": "The script index, offset and disassembly of the opcode the script failed at",
+	
+	// TraceMempoolCheckResult help.
+	"tracemempoolcheckresult-name":       "The name of the check",
+	"tracemempoolcheckresult-passed":     "Whether the transaction passed the check",
+	"tracemempoolcheckresult-details":    "The values that were compared, or the reason the check failed",
+	"tracemempoolcheckresult-rejectcode": "The reject code a peer relaying the transaction would be sent, only when it failed",
+	
 	// ValidateAddressResult help.
 	"validateaddresschainresult-isvalid": "Whether or not the address is valid",
 	"validateaddresschainresult-address": "The bitcoin address (only when isvalid is true)",
@@ -627,10 +1110,24 @@ var HelpDescsEnUS = map[string]string{
 	// NotifyNewTransactionsCmd help.
 	"notifynewtransactions--synopsis": "Send either a txaccepted or a txacceptedverbose notification when a new transaction is accepted into the mempool.",
 	"notifynewtransactions-verbose":   "Specifies which type of notification to receive. If verbose is true, then the caller receives txacceptedverbose, otherwise the caller receives txaccepted",
+	"notifynewtransactions-filtered":  "Only send notifications for transactions matching the transaction filter loaded with loadtxfilter",
 	
 	// StopNotifyNewTransactionsCmd help.
 	"stopnotifynewtransactions--synopsis": "Stop sending either a txaccepted or a txacceptedverbose notification when a new transaction is accepted into the mempool.",
 	
+	// NotifyPeersCmd help.
+	"notifypeers--synopsis": "Send a peerconnected notification when a peer completes the version handshake and a peerdisconnected notification, with the reason, when a connected peer is disconnected.",
+	
+	// StopNotifyPeersCmd help.
+	"stopnotifypeers--synopsis": "Stop sending peerconnected and peerdisconnected notifications.",
+	
+	// NotifyTipDeltasCmd help.
+	"notifytipdeltas--synopsis": "Send a tipdelta notification with the header, the coinbase and the short ids of the other transactions of each block that becomes the new tip of the main chain.\n" +
+		"The short id of a transaction is the lower 6 bytes of the SipHash-2-4 of its txid, keyed with the first 16 bytes of the SHA256 of the header followed by the nonce of the notification in little endian.",
+	
+	// StopNotifyTipDeltasCmd help.
+	"stopnotifytipdeltas--synopsis": "Stop sending tipdelta notifications.",
+	
 	// NotifyReceivedCmd help.
 	"notifyreceived--synopsis": "Send a recvtx notification when a transaction added to mempool or appears in a newly-attached block contains a txout pkScript sending to any of the passed addresses.\n" +
 		"Matching outpoints are automatically registered for redeemingtx notifications.",
@@ -657,6 +1154,14 @@ var HelpDescsEnUS = map[string]string{
 	"loadtxfilter-reload":    "Load a new filter instead of adding data to an existing one",
 	"loadtxfilter-addresses": "Array of addresses to add to the transaction filter",
 	"loadtxfilter-outpoints": "Array of outpoints to add to the transaction filter",
+	"loadtxfilter-scripts":   "Array of hex encoded output scripts to add to the transaction filter, for outputs that don't pay to an address",
+	
+	// UpdateTxFilterCmd help.
+	"updatetxfilter--synopsis": "Add entries to or remove entries from a websocket client's loaded transaction filter.",
+	"updatetxfilter-remove":    "Remove the entries from the filter instead of adding them",
+	"updatetxfilter-addresses": "Array of addresses to add to or remove from the transaction filter",
+	"updatetxfilter-outpoints": "Array of outpoints to add to or remove from the transaction filter",
+	"updatetxfilter-scripts":   "Array of hex encoded output scripts to add to or remove from the transaction filter",
 	
 	// Rescan help.
 	"rescan--synopsis": "Rescan block chain for transactions to addresses.\n" +
@@ -701,11 +1206,15 @@ var HelpDescsEnUS = map[string]string{
 // pointer to the type (or nil to indicate no return value).
 var ResultTypes = map[string][]interface{}{
 	"addnode":               nil,
+	"calculatepackagefee":   {(*btcjson.CalculatePackageFeeResult)(nil)},
 	"createrawtransaction":  {(*string)(nil)},
 	"debuglevel":            {(*string)(nil), (*string)(nil)},
 	"decoderawtransaction":  {(*btcjson.TxRawDecodeResult)(nil)},
 	"decodescript":          {(*btcjson.DecodeScriptResult)(nil)},
+	"deriveaddresses":       {(*[]string)(nil)},
 	"estimatefee":           {(*float64)(nil)},
+	"estimatesmartfee":      {(*btcjson.EstimateSmartFeeResult)(nil)},
+	"estimatorstats":        {(*btcjson.EstimatorStatsResult)(nil)},
 	"generate":              {(*[]string)(nil)},
 	"getaddednodeinfo":      {(*[]string)(nil), (*[]btcjson.GetAddedNodeInfoResult)(nil)},
 	"getbestblock":          {(*btcjson.GetBestBlockResult)(nil)},
@@ -714,36 +1223,59 @@ var ResultTypes = map[string][]interface{}{
 	"getblockcount":         {(*int64)(nil)},
 	"getblockhash":          {(*string)(nil)},
 	"getblockheader":        {(*string)(nil), (*btcjson.GetBlockHeaderVerboseResult)(nil)},
+	"getblockpropagation":   {(*btcjson.GetBlockPropagationResult)(nil)},
 	"getblocktemplate":      {(*btcjson.GetBlockTemplateResult)(nil), (*string)(nil), nil},
 	"getblockchaininfo":     {(*btcjson.GetBlockChainInfoResult)(nil)},
+	"getcachestats":         {(*btcjson.GetCacheStatsResult)(nil)},
 	"getcfilter":            {(*string)(nil)},
 	"getcfilterheader":      {(*string)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
 	"getcurrentnet":         {(*uint32)(nil)},
+	"getdescriptorinfo":     {(*btcjson.GetDescriptorInfoResult)(nil)},
 	"getdifficulty":         {(*float64)(nil)},
+	"getdnsaudit":           {(*btcjson.GetDNSAuditResult)(nil)},
+	"getfeatures":           {(*[]btcjson.FeatureResult)(nil)},
 	"getgenerate":           {(*bool)(nil)},
 	"gethashespersec":       {(*float64)(nil)},
 	"getheaders":            {(*[]string)(nil)},
+	"getindexinfo":          {(*map[string]btcjson.GetIndexInfoResult)(nil)},
 	"getinfo":               {(*btcjson.InfoChainResult)(nil)},
 	"getmempoolinfo":        {(*btcjson.GetMempoolInfoResult)(nil)},
+	"getminingaddresses":    {(*btcjson.GetMiningAddressesResult)(nil)},
+	"getminingexpectation":  {(*btcjson.GetMiningExpectationResult)(nil)},
 	"getmininginfo":         {(*btcjson.GetMiningInfoResult)(nil)},
+	"getminingtemplate":     {(*btcjson.GetMiningTemplateResult)(nil)},
 	"getnettotals":          {(*btcjson.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnetworkinfo":        {(*btcjson.GetNetworkInfoResult)(nil)},
+	"getnodeaddresses":      {(*[]btcjson.NodeAddressResult)(nil)},
+	"getorphanblocks":       {(*btcjson.GetOrphanBlocksResult)(nil)},
+	"getorphantxs":          {(*btcjson.GetOrphanTxsResult)(nil)},
 	"getpeerinfo":           {(*[]btcjson.GetPeerInfoResult)(nil)},
 	"getrawmempool":         {(*[]string)(nil), (*btcjson.GetRawMempoolVerboseResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*btcjson.TxRawResult)(nil)},
+	"getscriptflags":        {(*btcjson.GetScriptFlagsResult)(nil)},
+	"gettimestampinfo":      {(*btcjson.GetTimestampInfoResult)(nil)},
 	"gettxout":              {(*btcjson.GetTxOutResult)(nil)},
+	"getvalidationtrace":    {(*[]btcjson.GetValidationTraceResult)(nil)},
+	"getworksource":         {(*btcjson.GetWorkSourceResult)(nil)},
 	"node":                  nil,
 	"help":                  {(*string)(nil), (*string)(nil)},
 	"ping":                  nil,
+	"prioritisetransaction": {(*bool)(nil)},
 	"searchrawtransactions": {(*string)(nil), (*[]btcjson.SearchRawTransactionsResult)(nil)},
+	"selftest":              {(*btcjson.SelfTestResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
+	"setconfig":             nil,
+	"setfeature":            nil,
 	"setgenerate":           nil,
+	"setminingaddresses":    nil,
 	"stop":                  {(*string)(nil)},
 	"restart":               {(*string)(nil)},
 	"resetchain":            {(*string)(nil)},
 	// "dropwallethistory":     {(*string)(nil)},
 	"submitblock":     {nil, (*string)(nil)},
+	"tracemempoolaccept": {(*btcjson.TraceMempoolAcceptResult)(nil)},
 	"uptime":          {(*int64)(nil)},
 	"validateaddress": {(*btcjson.ValidateAddressChainResult)(nil)},
 	"verifychain":     {(*bool)(nil)},
@@ -751,11 +1283,16 @@ var ResultTypes = map[string][]interface{}{
 	"version":         {(*map[string]btcjson.VersionResult)(nil)},
 	// Websocket commands.
 	"loadtxfilter":              nil,
+	"updatetxfilter":            nil,
 	"session":                   {(*btcjson.SessionResult)(nil)},
 	"notifyblocks":              nil,
 	"stopnotifyblocks":          nil,
 	"notifynewtransactions":     nil,
 	"stopnotifynewtransactions": nil,
+	"notifypeers":               nil,
+	"stopnotifypeers":           nil,
+	"notifytipdeltas":           nil,
+	"stopnotifytipdeltas":       nil,
 	"notifyreceived":            nil,
 	"stopnotifyreceived":        nil,
 	"notifyspent":               nil,
@@ -772,6 +1309,15 @@ type HelpCacher struct {
 	methodHelp map[string]string
 }
 
+// methodResultTypes returns the result types for a method, falling back to the result type registered along with the
+// command in btcjson for commands that are not listed in ResultTypes.
+func methodResultTypes(method string) ([]interface{}, bool) {
+	if resultTypes, ok := ResultTypes[method]; ok {
+		return resultTypes, true
+	}
+	return btcjson.MethodResultTypes(method)
+}
+
 // RPCMethodHelp returns an RPC help string for the provided method. This function is safe for concurrent access.
 func (c *HelpCacher) RPCMethodHelp(method string) (string, error) {
 	c.Lock()
@@ -781,7 +1327,7 @@ func (c *HelpCacher) RPCMethodHelp(method string) (string, error) {
 		return help, nil
 	}
 	// Look up the result types for the method.
-	resultTypes, ok := ResultTypes[method]
+	resultTypes, ok := methodResultTypes(method)
 	if !ok {
 		return "", errors.New(
 			"no result types specified for method " +
@@ -9,7 +9,7 @@ import (
 func TestHelp(t *testing.T) {
 	// Ensure there are result types specified for every handler.
 	for k := range RPCHandlers {
-		if _, ok := ResultTypes[k]; !ok {
+		if _, ok := methodResultTypes(k); !ok {
 			t.Errorf("RPC handler defined for method '%v' without "+
 				"also specifying result types", k,
 			)
@@ -17,7 +17,7 @@ func TestHelp(t *testing.T) {
 		}
 	}
 	for k := range WSHandlers {
-		if _, ok := ResultTypes[k]; !ok {
+		if _, ok := methodResultTypes(k); !ok {
 			t.Errorf("RPC handler defined for method '%v' without "+
 				"also specifying result types", k,
 			)
@@ -2,16 +2,15 @@ package chainrpc
 
 import (
 	"bytes"
-	"container/list"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"strconv"
 	"sync"
 	"time"
 
@@ -23,6 +22,7 @@ import (
 	"github.com/p9c/qu"
 
 	"github.com/btcsuite/websocket"
+	uberatomic "go.uber.org/atomic"
 	"golang.org/x/crypto/ripemd160"
 
 	"github.com/p9c/pod/pkg/blockchain"
@@ -48,6 +48,8 @@ type NotificationRegisterBlocks WSClient
 
 type NotificationRegisterClient WSClient
 type NotificationRegisterNewMempoolTxs WSClient
+type NotificationRegisterPeers WSClient
+type NotificationRegisterTipDeltas WSClient
 type NotificationRegisterSpent struct {
 	WSC *WSClient
 	OPs []*wire.OutPoint
@@ -56,6 +58,11 @@ type NotificationTxAcceptedByMempool struct {
 	IsNew bool
 	Tx    *util.Tx
 }
+type NotificationTxExpired util.Tx
+type NotificationPeerEvent struct {
+	Method string
+	Peer   btcjson.PeerEventResult
+}
 type NotificationUnregisterAddr struct {
 	WSC  *WSClient
 	Addr string
@@ -63,10 +70,16 @@ type NotificationUnregisterAddr struct {
 type NotificationUnregisterBlocks WSClient
 type NotificationUnregisterClient WSClient
 type NotificationUnregisterNewMempoolTxs WSClient
+type NotificationUnregisterPeers WSClient
+type NotificationUnregisterTipDeltas WSClient
 type NotificationUnregisterSpent struct {
 	WSC *WSClient
 	OP  *wire.OutPoint
 }
+
+// NotificationInfoRequest asks the notification handler for a snapshot of the current subscribers, which is sent back
+// on the channel.
+type NotificationInfoRequest chan []btcjson.NotificationClientResult
 type RescanKeys struct {
 	Fallbacks           map[string]struct{}
 	PubKeyHashes        map[[ripemd160.Size]byte]struct{}
@@ -115,7 +128,7 @@ type WSClient struct {
 	FilterData *WSClientFilter
 	// Networking infrastructure.
 	ServiceRequestSem Semaphore
-	NtfnChan          chan []byte
+	NtfnChan          chan *NtfnPayload
 	SendChan          chan WSResponse
 	Quit              qu.C
 	WG                sync.WaitGroup
@@ -129,6 +142,21 @@ type WSClient struct {
 	IsAdmin bool
 	// VerboseTxUpdates specifies whether a client has requested verbose information about all new transactions.
 	VerboseTxUpdates bool
+	// FilteredTxUpdates specifies whether a client has asked to only be told about new transactions matching its
+	// FilterData.
+	FilteredTxUpdates bool
+	// NtfnQueued, NtfnSent and NtfnDropped count the notifications queued for, written to and discarded for this
+	// client, for the getnotificationinfo RPC.
+	NtfnQueued, NtfnSent, NtfnDropped uberatomic.Uint64
+	// NtfnPending is the number of notifications waiting to be written and NtfnPendingHWM the highest it has been.
+	NtfnPending, NtfnPendingHWM uberatomic.Int64
+	// NtfnOverflows counts the notifications that found the queue of the client full, each of which either pushed out
+	// the oldest waiting notification or got the client disconnected, depending on SlowClientPolicy.
+	NtfnOverflows uberatomic.Uint64
+	// NtfnQueueLimit is the most notifications that may wait to be written to the client, and SlowClientPolicy what
+	// happens when it falls further behind.
+	NtfnQueueLimit   int
+	SlowClientPolicy string
 	// AddrRequests is a set of addresses the caller has requested to be notified about. It is maintained here so all
 	// requests can be removed when a wallet disconnects. Owned by the notification manager.
 }
@@ -147,6 +175,8 @@ type WSClientFilter struct {
 	OtherAddresses map[string]struct{}
 	// Outpoints of Unspent outputs.
 	Unspent map[wire.OutPoint]struct{}
+	// Output scripts, for outputs that don't pay to an address.
+	Scripts map[string]struct{}
 }
 
 // WSCommandHandler describes a callback function used to handle a specific command.
@@ -170,6 +200,9 @@ type WSNtfnMgr struct {
 	NotificationMsgs chan interface{}
 	// Access channel for current number of connected clients.
 	NumClients chan int
+	// MarshalQueue feeds the marshal workers with the payloads of notifications, so they are marshalled off the
+	// notification handler goroutine.
+	MarshalQueue chan *NtfnPayload
 	// Shutdown handling
 	WG   sync.WaitGroup
 	Quit qu.C
@@ -211,15 +244,20 @@ var WSHandlersBeforeInit = map[string]WSCommandHandler{
 	"help":                      HandleWebsocketHelp,
 	"notifyblocks":              HandleNotifyBlocks,
 	"notifynewtransactions":     HandleNotifyNewTransactions,
+	"notifypeers":               HandleNotifyPeers,
 	"notifyreceived":            HandleNotifyReceived,
 	"notifyspent":               HandleNotifySpent,
+	"notifytipdeltas":           HandleNotifyTipDeltas,
 	"session":                   HandleSession,
 	"stopnotifyblocks":          HandleStopNotifyBlocks,
 	"stopnotifynewtransactions": HandleStopNotifyNewTransactions,
+	"stopnotifypeers":           HandleStopNotifyPeers,
 	"stopnotifyspent":           HandleStopNotifySpent,
 	"stopnotifyreceived":        HandleStopNotifyReceived,
+	"stopnotifytipdeltas":       HandleStopNotifyTipDeltas,
 	"rescan":                    HandleRescan,
 	"rescanblocks":              HandleRescanBlocks,
+	"updatetxfilter":            HandleUpdateTxFilter,
 }
 
 // UnspentSlice returns a slice of currently-unspent outpoints for the rescan lookup keys. This is primarily intended to
@@ -308,11 +346,24 @@ func (c *WSClient) IsDisconnected() bool {
 // If the client is in the process of shutting down, this function returns ErrClientQuit. This is intended to be checked
 // by long-running notification handlers to stop processing if there is no more work needed to be done.
 func (c *WSClient) QueueNotification(marshalledJSON []byte) (e error) {
+	return c.QueuePayload(MarshalledNtfnPayload(marshalledJSON))
+}
+
+// QueuePayload queues the passed notification payload to be sent to the websocket client like QueueNotification. The
+// payload may be queued to many clients, and is marshalled only once.
+func (c *WSClient) QueuePayload(p *NtfnPayload) (e error) {
 	// Don't queue the message if disconnected.
 	if c.IsDisconnected() {
+		c.NtfnDropped.Inc()
+		return ErrClientQuit
+	}
+	c.NtfnQueued.Inc()
+	select {
+	case c.NtfnChan <- p:
+	case <-c.Quit.Wait():
+		c.NtfnDropped.Inc()
 		return ErrClientQuit
 	}
-	c.NtfnChan <- marshalledJSON
 	return nil
 }
 
@@ -371,7 +422,7 @@ out:
 			break out
 		}
 		var request btcjson.Request
-		e = json.Unmarshal(msg, &request)
+		request, e = btcjson.UnmarshalRequest(msg, c.Server.Config.CompatLegacy.True())
 		if e != nil {
 			if !c.Authenticated {
 				break out
@@ -505,6 +556,10 @@ out:
 // for various sources of input to ensure that queuing up notifications to be sent will not block. Otherwise, slow
 // clients could bog down the other systems (such as the mempool or block manager) which are queuing the data. The data
 // is passed on to outHandler to actually be written. It must be run as a goroutine.
+//
+// At most NtfnQueueLimit notifications wait to be written, so a client that doesn't keep up can't make the node hold
+// an ever growing backlog for it. Beyond that the oldest notifications are dropped, or the client is disconnected,
+// according to SlowClientPolicy.
 func (c *WSClient) NotificationQueueHandler() {
 	ntfnSentChan := make(chan bool, 1) // nonblocking sync
 	// pendingNtfns is used as a queue for notifications that are ready to be sent once there are no outstanding
@@ -516,8 +571,20 @@ func (c *WSClient) NotificationQueueHandler() {
 	// Currently no special cleanup is needed, however if something like a done channel is added to notifications in the
 	// future, not knowing what has and hasn't been sent to the outHandler (and thus who should respond to the done
 	// channel) would be problematic without using this approach.
-	pendingNtfns := list.New()
+	pendingNtfns := NewNtfnQueue(c.NtfnQueueLimit, c.SlowClientPolicy)
 	waiting := false
+	// send passes the notification on to the outHandler, and returns false when there is nothing to wait for because
+	// it could not be marshalled.
+	send := func(p *NtfnPayload) bool {
+		msg, e := p.Bytes()
+		if e != nil {
+			E.Ln("failed to marshal notification:", e)
+			c.NtfnDropped.Inc()
+			return false
+		}
+		c.SendMessage(msg, ntfnSentChan)
+		return true
+	}
 out:
 	for {
 		select {
@@ -525,30 +592,53 @@ out:
 		//
 		// It will either send the message immediately if a send is not already in progress, or queue the message to
 		// be sent once the other pending messages are sent.
-		case msg := <-c.NtfnChan:
+		case p := <-c.NtfnChan:
 			if !waiting {
-				c.SendMessage(msg, ntfnSentChan)
-			} else {
-				pendingNtfns.PushBack(msg)
+				waiting = send(p)
+				continue
+			}
+			dropped, full := pendingNtfns.Push(p)
+			if dropped > 0 || full {
+				c.NtfnOverflows.Inc()
+				c.NtfnDropped.Add(uint64(dropped))
+			}
+			if full {
+				c.NtfnDropped.Inc()
+				W.F(
+					"websocket client %s fell %d notifications behind, disconnecting",
+					c.Addr, pendingNtfns.Len(),
+				)
+				c.Disconnect()
+				break out
 			}
-			waiting = true
+			c.trackPending(pendingNtfns.Len())
 			// This channel is notified when a notification has been sent across the
 			// network socket.
-		case <-ntfnSentChan:
+		case sent := <-ntfnSentChan:
+			if sent {
+				c.NtfnSent.Inc()
+			} else {
+				c.NtfnDropped.Inc()
+			}
 			// No longer waiting if there are no more messages in the pending
 			// messages queue.
-			next := pendingNtfns.Front()
-			if next == nil {
-				waiting = false
-				continue
+			waiting = false
+			for !waiting {
+				next := pendingNtfns.Pop()
+				if next == nil {
+					break
+				}
+				// Notify the outHandler about the next item to asynchronously send.
+				waiting = send(next)
 			}
-			// Notify the outHandler about the next item to asynchronously send.
-			msg := pendingNtfns.Remove(next).([]byte)
-			c.SendMessage(msg, ntfnSentChan)
+			c.trackPending(pendingNtfns.Len())
 		case <-c.Quit.Wait():
 			break out
 		}
 	}
+	// Anything still waiting in the queue will never be delivered.
+	c.NtfnDropped.Add(uint64(pendingNtfns.Len()))
+	c.NtfnPending.Store(0)
 	// Drain any wait channels before exiting so nothing is left waiting around to send.
 cleanup:
 	for {
@@ -563,6 +653,15 @@ cleanup:
 	T.Ln("websocket client notification queue handler done for", c.Addr)
 }
 
+// trackPending records the current depth of the pending notification queue and raises the high water mark if it has
+// been exceeded.
+func (c *WSClient) trackPending(n int) {
+	c.NtfnPending.Store(int64(n))
+	if int64(n) > c.NtfnPendingHWM.Load() {
+		c.NtfnPendingHWM.Store(int64(n))
+	}
+}
+
 // OutHandler handles all outgoing messages for the websocket connection. It must be run as a goroutine.
 //
 // It uses a buffered channel to serialize output messages while allowing the sender to continue running asynchronously.
@@ -716,49 +815,70 @@ func (f *WSClientFilter) ExistsUnspentOutPoint(op *wire.OutPoint) bool {
 	return ok
 }
 
-// // removeAddress removes the passed address, if it exists, from the
-// wsClientFilter. NOTE: This extension was ported from github.com/decred/dcrd
-// func (f *wsClientFilter) removeAddress(a util.Address) {
-// 	switch a := a.(type) {
-// 	case *util.PubKeyHash:
-// 		delete(f.pubKeyHashes, *a.Hash160())
-// 		return
-// 	case *util.ScriptHash:
-// 		delete(f.scriptHashes, *a.Hash160())
-// 		return
-// 	case *util.PubKey:
-// 		serializedPubKey := a.ScriptAddress()
-// 		switch len(serializedPubKey) {
-// 		case 33: // compressed
-// 			var compressedPubKey [33]byte
-// 			copy(compressedPubKey[:], serializedPubKey)
-// 			delete(f.compressedPubKeys, compressedPubKey)
-// 			return
-// 		case 65: // uncompressed
-// 			var uncompressedPubKey [65]byte
-// 			copy(uncompressedPubKey[:], serializedPubKey)
-// 			delete(f.uncompressedPubKeys, uncompressedPubKey)
-// 			return
-// 		}
-// 	}
-// 	delete(f.otherAddresses, a.EncodeAddress())
-// }
-// // removeAddressStr parses an address from a string and then removes it from
-// // the wsClientFilter using removeAddress. NOTE: This extension was ported
-// // from github.com/decred/dcrd
-// func (f *wsClientFilter) removeAddressStr(s string, netparams *chaincfg.Params) {
-// 	a, e := util.Decode(s, netparams)
-// 	if e ==  nil {
-// 		f.removeAddress(a)
-// 	} else {
-// 		delete(f.otherAddresses, s)
-// 	}
-// }
-// // removeUnspentOutPoint removes the passed outpoint, if it exists, from the
-// wsClientFilter. NOTE: This extension was ported from github.com/decred/dcrd
-// func (f *wsClientFilter) removeUnspentOutPoint(op *wire.OutPoint) {
-// 	delete(f.unspent, *op)
-// }
+// RemoveAddress removes the passed address, if it exists, from the wsClientFilter.
+//
+// NOTE: This extension was ported from github.com/decred/dcrd
+func (f *WSClientFilter) RemoveAddress(a btcaddr.Address) {
+	switch a := a.(type) {
+	case *btcaddr.PubKeyHash:
+		delete(f.PubKeyHashes, *a.Hash160())
+		return
+	case *btcaddr.ScriptHash:
+		delete(f.ScriptHashes, *a.Hash160())
+		return
+	case *btcaddr.PubKey:
+		serializedPubKey := a.ScriptAddress()
+		switch len(serializedPubKey) {
+		case 33: // compressed
+			var compressedPubKey [33]byte
+			copy(compressedPubKey[:], serializedPubKey)
+			delete(f.CompressedPubKeys, compressedPubKey)
+			return
+		case 65: // uncompressed
+			var uncompressedPubKey [65]byte
+			copy(uncompressedPubKey[:], serializedPubKey)
+			delete(f.UncompressedPubKeys, uncompressedPubKey)
+			return
+		}
+	}
+	delete(f.OtherAddresses, a.EncodeAddress())
+}
+
+// RemoveAddressStr parses an address from a string and then removes it from the wsClientFilter using RemoveAddress.
+//
+// NOTE: This extension was ported from github.com/decred/dcrd
+func (f *WSClientFilter) RemoveAddressStr(s string, params *chaincfg.Params) {
+	a, e := btcaddr.Decode(s, params)
+	if e == nil {
+		f.RemoveAddress(a)
+	} else {
+		delete(f.OtherAddresses, s)
+	}
+}
+
+// RemoveUnspentOutPoint removes the passed outpoint, if it exists, from the wsClientFilter.
+//
+// NOTE: This extension was ported from github.com/decred/dcrd
+func (f *WSClientFilter) RemoveUnspentOutPoint(op *wire.OutPoint) {
+	delete(f.Unspent, *op)
+}
+
+// AddScript adds an output script to the wsClientFilter, so that outputs paying to it match the filter whether or not
+// the script pays to an address.
+func (f *WSClientFilter) AddScript(pkScript []byte) {
+	f.Scripts[string(pkScript)] = struct{}{}
+}
+
+// RemoveScript removes the passed output script, if it exists, from the wsClientFilter.
+func (f *WSClientFilter) RemoveScript(pkScript []byte) {
+	delete(f.Scripts, string(pkScript))
+}
+
+// ExistsScript returns true if the passed output script has been added to the wsClientFilter.
+func (f *WSClientFilter) ExistsScript(pkScript []byte) bool {
+	_, ok := f.Scripts[string(pkScript)]
+	return ok
+}
 
 // AddClient adds the passed websocket client to the notification manager.
 func (m *WSNtfnMgr) AddClient(wsc *WSClient) {
@@ -802,6 +922,28 @@ func (m *WSNtfnMgr) SendNotifyMempoolTx(tx *util.Tx, isNew bool) {
 	}
 }
 
+// SendNotifyTxExpired passes a transaction evicted from the mempool because it expired to the notification manager for
+// notifying the clients registered for block updates.
+func (m *WSNtfnMgr) SendNotifyTxExpired(tx *util.Tx) {
+	// As NotifyTxExpired will be called by the block manager and the RPC Server may no longer be running, use a select
+	// statement to unblock enqueuing the notification once the RPC Server has begun shutting down.
+	select {
+	case m.QueueNotification <- (*NotificationTxExpired)(tx):
+	case <-m.Quit.Wait():
+	}
+}
+
+// SendNotifyPeerEvent passes a peer connecting or disconnecting to the notification manager for notifying the clients
+// registered for peer updates.
+func (m *WSNtfnMgr) SendNotifyPeerEvent(method string, peer btcjson.PeerEventResult) {
+	// The peer handler may keep running after the RPC Server has begun shutting down, so use a select statement to
+	// unblock enqueuing the notification.
+	select {
+	case m.QueueNotification <- &NotificationPeerEvent{Method: method, Peer: peer}:
+	case <-m.Quit.Wait():
+	}
+}
+
 // GetNumClients returns the number of clients actively being served.
 func (m *WSNtfnMgr) GetNumClients() (n int) {
 	select {
@@ -811,6 +953,21 @@ func (m *WSNtfnMgr) GetNumClients() (n int) {
 	return
 }
 
+// NotificationInfo returns the subscriptions and delivery statistics of every connected websocket client.
+func (m *WSNtfnMgr) NotificationInfo() (info []btcjson.NotificationClientResult) {
+	req := make(NotificationInfoRequest, 1)
+	select {
+	case m.QueueNotification <- req:
+	case <-m.Quit.Wait():
+		return
+	}
+	select {
+	case info = <-req:
+	case <-m.Quit.Wait():
+	}
+	return
+}
+
 // RegisterBlockUpdates requests block update notifications to the passed websocket client.
 func (m *WSNtfnMgr) RegisterBlockUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationRegisterBlocks)(wsc)
@@ -822,6 +979,17 @@ func (m *WSNtfnMgr) RegisterNewMempoolTxsUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationRegisterNewMempoolTxs)(wsc)
 }
 
+// RegisterPeerUpdates requests notifications to the passed websocket client when peers connect or disconnect.
+func (m *WSNtfnMgr) RegisterPeerUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationRegisterPeers)(wsc)
+}
+
+// RegisterTipDeltaUpdates requests notifications to the passed websocket client when a block becomes the new tip of
+// the main chain.
+func (m *WSNtfnMgr) RegisterTipDeltaUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationRegisterTipDeltas)(wsc)
+}
+
 // RegisterSpentRequests requests a notification when each of the passed outpoints is confirmed spent (contained in a
 // block connected to the main chain) for the passed websocket client. The request is automatically removed once the
 // notification has been sent.
@@ -860,6 +1028,11 @@ func (m *WSNtfnMgr) Shutdown() {
 func (m *WSNtfnMgr) Start() {
 	go m.QueueHandler()
 	go m.NotificationHandler()
+	workers := NtfnMarshalWorkers()
+	m.WG.Add(workers)
+	for i := 0; i < workers; i++ {
+		go m.MarshalWorker()
+	}
 }
 
 // UnregisterBlockUpdates removes block update notifications for the passed websocket client.
@@ -873,6 +1046,17 @@ func (m *WSNtfnMgr) UnregisterNewMempoolTxsUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationUnregisterNewMempoolTxs)(wsc)
 }
 
+// UnregisterPeerUpdates removes notifications to the passed websocket client when peers connect or disconnect.
+func (m *WSNtfnMgr) UnregisterPeerUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationUnregisterPeers)(wsc)
+}
+
+// UnregisterTipDeltaUpdates removes notifications to the passed websocket client when a block becomes the new tip of
+// the main chain.
+func (m *WSNtfnMgr) UnregisterTipDeltaUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationUnregisterTipDeltas)(wsc)
+}
+
 // UnregisterSpentRequest removes a request from the passed websocket client to be notified when the passed outpoint is
 // confirmed spent (contained in a block connected to the main chain).
 func (m *WSNtfnMgr) UnregisterSpentRequest(
@@ -964,6 +1148,8 @@ func (m *WSNtfnMgr) NotificationHandler() {
 	// than using the entire struct.
 	blockNotifications := make(map[qu.C]*WSClient)
 	txNotifications := make(map[qu.C]*WSClient)
+	peerNotifications := make(map[qu.C]*WSClient)
+	tipDeltaNotifications := make(map[qu.C]*WSClient)
 	watchedOutPoints := make(map[wire.OutPoint]map[qu.C]*WSClient)
 	watchedAddrs := make(map[string]map[qu.C]*WSClient)
 out:
@@ -996,6 +1182,7 @@ out:
 						block,
 					)
 				}
+				m.NotifyTipDelta(tipDeltaNotifications, block)
 			case *NotificationBlockDisconnected:
 				block := (*block.Block)(n)
 				if len(blockNotifications) != 0 {
@@ -1009,11 +1196,28 @@ out:
 					)
 				}
 			case *NotificationTxAcceptedByMempool:
+				subscribed := m.GetSubscribedClients(n.Tx, clients)
 				if n.IsNew && len(txNotifications) != 0 {
-					m.NotifyForNewTx(txNotifications, n.Tx)
+					m.NotifyForNewTx(txNotifications, n.Tx, subscribed)
 				}
 				m.NotifyForTx(watchedOutPoints, watchedAddrs, n.Tx, nil)
-				m.NotifyRelevantTxAccepted(n.Tx, clients)
+				m.NotifyRelevantTxAccepted(n.Tx, clients, subscribed)
+			case *NotificationTxExpired:
+				m.NotifyTxExpired(blockNotifications, (*util.Tx)(n))
+			case *NotificationPeerEvent:
+				m.NotifyPeerEvent(peerNotifications, n)
+			case *NotificationRegisterPeers:
+				wsc := (*WSClient)(n)
+				peerNotifications[wsc.Quit] = wsc
+			case *NotificationUnregisterPeers:
+				wsc := (*WSClient)(n)
+				delete(peerNotifications, wsc.Quit)
+			case *NotificationRegisterTipDeltas:
+				wsc := (*WSClient)(n)
+				tipDeltaNotifications[wsc.Quit] = wsc
+			case *NotificationUnregisterTipDeltas:
+				wsc := (*WSClient)(n)
+				delete(tipDeltaNotifications, wsc.Quit)
 			case *NotificationRegisterBlocks:
 				wsc := (*WSClient)(n)
 				blockNotifications[wsc.Quit] = wsc
@@ -1028,6 +1232,8 @@ out:
 				// Remove any requests made by the client as well as the client itself.
 				delete(blockNotifications, wsc.Quit)
 				delete(txNotifications, wsc.Quit)
+				delete(peerNotifications, wsc.Quit)
+				delete(tipDeltaNotifications, wsc.Quit)
 				for k := range wsc.SpentRequests {
 					op := k
 					m.RemoveSpentRequest(watchedOutPoints, wsc, &op)
@@ -1050,6 +1256,62 @@ out:
 			case *NotificationUnregisterNewMempoolTxs:
 				wsc := (*WSClient)(n)
 				delete(txNotifications, wsc.Quit)
+			case NotificationInfoRequest:
+				info := make([]btcjson.NotificationClientResult, 0, len(clients))
+				for q, wsc := range clients {
+					var topics []string
+					wsc.Lock()
+					verbose, filtered := wsc.VerboseTxUpdates, wsc.FilterData != nil
+					wsc.Unlock()
+					if _, ok := blockNotifications[q]; ok {
+						topics = append(
+							topics,
+							btcjson.BlockConnectedNtfnMethod,
+							btcjson.BlockDisconnectedNtfnMethod,
+							btcjson.FilteredBlockConnectedNtfnMethod,
+							btcjson.FilteredBlockDisconnectedNtfnMethod,
+							btcjson.TxExpiredNtfnMethod,
+						)
+					}
+					if _, ok := txNotifications[q]; ok {
+						if verbose {
+							topics = append(topics, btcjson.TxAcceptedVerboseNtfnMethod)
+						} else {
+							topics = append(topics, btcjson.TxAcceptedNtfnMethod)
+						}
+					}
+					if _, ok := peerNotifications[q]; ok {
+						topics = append(topics, btcjson.PeerConnectedNtfnMethod, btcjson.PeerDisconnectedNtfnMethod)
+					}
+					if _, ok := tipDeltaNotifications[q]; ok {
+						topics = append(topics, btcjson.TipDeltaNtfnMethod)
+					}
+					if len(wsc.SpentRequests) > 0 {
+						topics = append(topics, btcjson.RedeemingTxNtfnMethod)
+					}
+					if len(wsc.AddrRequests) > 0 {
+						topics = append(topics, btcjson.RecvTxNtfnMethod)
+					}
+					if filtered {
+						topics = append(topics, btcjson.RelevantTxAcceptedNtfnMethod)
+					}
+					info = append(
+						info, btcjson.NotificationClientResult{
+							Addr:             wsc.Addr,
+							SessionID:        wsc.SessionID,
+							Topics:           topics,
+							WatchedAddresses: len(wsc.AddrRequests),
+							WatchedOutPoints: len(wsc.SpentRequests),
+							Queued:           wsc.NtfnQueued.Load(),
+							Sent:             wsc.NtfnSent.Load(),
+							Dropped:          wsc.NtfnDropped.Load(),
+							Pending:          wsc.NtfnPending.Load(),
+							PendingHWM:       wsc.NtfnPendingHWM.Load(),
+							Overflows:        wsc.NtfnOverflows.Load(),
+						},
+					)
+				}
+				n <- info
 			default:
 				W.Ln("unhandled notification type")
 			}
@@ -1067,7 +1329,7 @@ out:
 
 // NotifyBlockConnected notifies websocket clients that have registered for block updates when a block is connected to
 // the main chain.
-func (*WSNtfnMgr) NotifyBlockConnected(
+func (m *WSNtfnMgr) NotifyBlockConnected(
 	clients map[qu.C]*WSClient, block *block.Block,
 ) {
 	// Notify interested websocket clients about the connected block.
@@ -1075,13 +1337,9 @@ func (*WSNtfnMgr) NotifyBlockConnected(
 		block.Hash().String(), block.Height(),
 		block.WireBlock().Header.Timestamp.Unix(),
 	)
-	marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
-	if e != nil {
-		E.Ln("failed to marshal block connected notification:", e)
-		return
-	}
+	payload := m.Payload(ntfn)
 	for _, wsc := range clients {
-		e := wsc.QueueNotification(marshalledJSON)
+		e := wsc.QueuePayload(payload)
 		if e != nil {
 		}
 	}
@@ -1089,7 +1347,7 @@ func (*WSNtfnMgr) NotifyBlockConnected(
 
 // NotifyBlockDisconnected notifies websocket clients that have registered for block updates when a block is
 // disconnected from the main chain (due to a reorganize).
-func (*WSNtfnMgr) NotifyBlockDisconnected(
+func (m *WSNtfnMgr) NotifyBlockDisconnected(
 	clients map[qu.C]*WSClient, block *block.Block,
 ) {
 	// Skip notification creation if no clients have requested block connected/ disconnected notifications.
@@ -1101,16 +1359,9 @@ func (*WSNtfnMgr) NotifyBlockDisconnected(
 		block.Hash().String(),
 		block.Height(), block.WireBlock().Header.Timestamp.Unix(),
 	)
-	marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
-	if e != nil {
-		E.Ln(
-			"failed to marshal block disconnected notification:",
-			e,
-		)
-		return
-	}
+	payload := m.Payload(ntfn)
 	for _, wsc := range clients {
-		e := wsc.QueueNotification(marshalledJSON)
+		e := wsc.QueuePayload(payload)
 		if e != nil {
 		}
 	}
@@ -1131,33 +1382,35 @@ func (m *WSNtfnMgr) NotifyFilteredBlockConnected(
 		)
 		return
 	}
-	ntfn := btcjson.NewFilteredBlockConnectedNtfn(
-		block.Height(),
-		hex.EncodeToString(w.Bytes()), nil,
-	)
+	header := hex.EncodeToString(w.Bytes())
 	// Search for relevant transactions for each client and save them serialized in hex encoding for the notification.
+	// The indexes of the transactions are kept too, so clients subscribed to the same transactions, such as all of
+	// those with no new-style filter, share a payload.
 	subscribedTxs := make(map[qu.C][]string)
-	for _, tx := range block.Transactions() {
+	subscribedIdx := make(map[qu.C][]byte)
+	for i, tx := range block.Transactions() {
 		var txHex string
 		for quitChan := range m.GetSubscribedClients(tx, clients) {
 			if txHex == "" {
 				txHex = TxHexString(tx.MsgTx())
 			}
 			subscribedTxs[quitChan] = append(subscribedTxs[quitChan], txHex)
+			subscribedIdx[quitChan] = strconv.AppendInt(append(subscribedIdx[quitChan], ','), int64(i), 10)
 		}
 	}
+	payloads := make(map[string]*NtfnPayload)
 	for quitChan, wsc := range clients {
 		// Add all discovered transactions for this client. For clients that have no new-style filter, add the empty
 		// string slice.
-		ntfn.SubscribedTxs = subscribedTxs[quitChan]
-		// Marshal and queue notification.
-		marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
-		if e != nil {
-			E.F("failed to marshal filtered block connected notification:", e)
-			return
+		key := string(subscribedIdx[quitChan])
+		payload, ok := payloads[key]
+		if !ok {
+			payload = m.Payload(
+				btcjson.NewFilteredBlockConnectedNtfn(block.Height(), header, subscribedTxs[quitChan]),
+			)
+			payloads[key] = payload
 		}
-		e = wsc.QueueNotification(marshalledJSON)
-		if e != nil {
+		if e = wsc.QueuePayload(payload); e != nil {
 			D.Ln(e)
 		}
 	}
@@ -1165,7 +1418,7 @@ func (m *WSNtfnMgr) NotifyFilteredBlockConnected(
 
 // NotifyFilteredBlockDisconnected notifies websocket clients that have registered for block updates when a block is
 // disconnected from the main chain (due to a reorganize).
-func (*WSNtfnMgr) NotifyFilteredBlockDisconnected(
+func (m *WSNtfnMgr) NotifyFilteredBlockDisconnected(
 	clients map[qu.C]*WSClient, block *block.Block,
 ) {
 	// Skip notification creation if no clients have requested block connected/ disconnected notifications.
@@ -1180,13 +1433,9 @@ func (*WSNtfnMgr) NotifyFilteredBlockDisconnected(
 		return
 	}
 	ntfn := btcjson.NewFilteredBlockDisconnectedNtfn(block.Height(), hex.EncodeToString(w.Bytes()))
-	marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
-	if e != nil {
-		E.Ln("failed to marshal filtered block disconnected notification:", e)
-		return
-	}
+	payload := m.Payload(ntfn)
 	for _, wsc := range clients {
-		e := wsc.QueueNotification(marshalledJSON)
+		e := wsc.QueuePayload(payload)
 		if e != nil {
 			D.Ln(e)
 		}
@@ -1194,10 +1443,11 @@ func (*WSNtfnMgr) NotifyFilteredBlockDisconnected(
 }
 
 // NotifyForNewTx notifies websocket clients that have registered for updates when a new transaction is added to the
-// memory pool.
+// memory pool. Clients that asked for filtered updates are only notified when they are in subscribed, the set of
+// clients whose transaction filter matches the transaction.
 func (m *WSNtfnMgr) NotifyForNewTx(
 	clients map[qu.C]*WSClient,
-	tx *util.Tx,
+	tx *util.Tx, subscribed map[qu.C]struct{},
 ) {
 	txHashStr := tx.Hash().String()
 	mtx := tx.MsgTx()
@@ -1206,15 +1456,25 @@ func (m *WSNtfnMgr) NotifyForNewTx(
 		amount += txOut.Value
 	}
 	ntfn := btcjson.NewTxAcceptedNtfn(txHashStr, amount2.Amount(amount).ToDUO())
-	marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
-	if e != nil {
-		E.Ln("failed to marshal tx notification:", e)
-		return
-	}
-	var verboseNtfn *btcjson.TxAcceptedVerboseNtfn
-	var marshalledJSONVerbose []byte
-	for _, wsc := range clients {
-		if wsc.VerboseTxUpdates {
+	payload := m.Payload(ntfn)
+	var payloadVerbose *NtfnPayload
+	for quitChan, wsc := range clients {
+		wsc.Lock()
+		verbose, filtered := wsc.VerboseTxUpdates, wsc.FilteredTxUpdates
+		wsc.Unlock()
+		if filtered {
+			if _, ok := subscribed[quitChan]; !ok {
+				continue
+			}
+		}
+		var e error
+		if !verbose {
+			if e = wsc.QueuePayload(payload); e != nil {
+				D.Ln(e)
+			}
+			continue
+		}
+		if payloadVerbose == nil {
 			net := m.Server.Cfg.ChainParams
 			var rawTx *btcjson.TxRawResult
 			rawTx, e = CreateTxRawResult(
@@ -1224,29 +1484,10 @@ func (m *WSNtfnMgr) NotifyForNewTx(
 			if e != nil {
 				return
 			}
-			verboseNtfn = btcjson.NewTxAcceptedVerboseNtfn(*rawTx)
-			marshalledJSONVerbose, e = btcjson.MarshalCmd(
-				nil,
-				verboseNtfn,
-			)
-			if e != nil {
-				E.Ln("failed to marshal verbose tx notification:", e)
-			}
-			if marshalledJSONVerbose != nil {
-				e = wsc.QueueNotification(marshalledJSONVerbose)
-				if e != nil {
-					D.Ln(e)
-				}
-				continue
-			}
-			return
+			payloadVerbose = m.Payload(btcjson.NewTxAcceptedVerboseNtfn(*rawTx))
 		}
-		e = wsc.QueueNotification(marshalledJSONVerbose)
-		if e != nil {
-		} else {
-			e := wsc.QueueNotification(marshalledJSON)
-			if e != nil {
-			}
+		if e = wsc.QueuePayload(payloadVerbose); e != nil {
+			D.Ln(e)
 		}
 	}
 }
@@ -1367,8 +1608,8 @@ func (m *WSNtfnMgr) NotifyForTxOuts(
 // Any outputs paying to a watched address result in the output being watched as well for future notifications.
 func (m *WSNtfnMgr) NotifyRelevantTxAccepted(
 	tx *util.Tx, clients map[qu.C]*WSClient,
+	clientsToNotify map[qu.C]struct{},
 ) {
-	clientsToNotify := m.GetSubscribedClients(tx, clients)
 	if len(clientsToNotify) != 0 {
 		n := btcjson.NewRelevantTxAcceptedNtfn(TxHexString(tx.MsgTx()))
 		marshalled, e := btcjson.MarshalCmd(nil, n)
@@ -1384,6 +1625,47 @@ func (m *WSNtfnMgr) NotifyRelevantTxAccepted(
 	}
 }
 
+// NotifyTxExpired notifies websocket clients that have registered for block updates when a transaction is evicted from
+// the mempool because it expired, so wallets can rebroadcast or abandon their expired transactions.
+func (*WSNtfnMgr) NotifyTxExpired(clients map[qu.C]*WSClient, tx *util.Tx) {
+	if len(clients) == 0 {
+		return
+	}
+	marshalledJSON, e := btcjson.MarshalCmd(nil, btcjson.NewTxExpiredNtfn(tx.Hash().String()))
+	if e != nil {
+		E.Ln("failed to marshal tx expired notification:", e)
+		return
+	}
+	for _, wsc := range clients {
+		if e = wsc.QueueNotification(marshalledJSON); E.Chk(e) {
+		}
+	}
+}
+
+// NotifyPeerEvent notifies websocket clients that have registered for peer updates when a peer connects or
+// disconnects.
+func (*WSNtfnMgr) NotifyPeerEvent(clients map[qu.C]*WSClient, n *NotificationPeerEvent) {
+	if len(clients) == 0 {
+		return
+	}
+	var ntfn interface{}
+	switch n.Method {
+	case btcjson.PeerConnectedNtfnMethod:
+		ntfn = btcjson.NewPeerConnectedNtfn(n.Peer)
+	default:
+		ntfn = btcjson.NewPeerDisconnectedNtfn(n.Peer)
+	}
+	marshalledJSON, e := btcjson.MarshalCmd(nil, ntfn)
+	if e != nil {
+		E.Ln("failed to marshal peer notification:", e)
+		return
+	}
+	for _, wsc := range clients {
+		if e = wsc.QueueNotification(marshalledJSON); E.Chk(e) {
+		}
+	}
+}
+
 // QueueHandler maintains a queue of notifications and notification handler control messages.
 func (m *WSNtfnMgr) QueueHandler() {
 	QueueHandler(m.QueueNotification, m.NotificationMsgs, m.Quit)
@@ -1472,8 +1754,8 @@ func (m *WSNtfnMgr) GetSubscribedClients(
 			output.PkScript, m.Server.Cfg.ChainParams,
 		)
 		if e != nil {
-			// Clients are not able to subscribe to nonstandard or non-address outputs.
-			continue
+			// Nonstandard and non-address outputs can only be subscribed to by their script.
+			addrs = nil
 		}
 		for quitChan, wsc := range clients {
 			wsc.Lock()
@@ -1483,16 +1765,20 @@ func (m *WSNtfnMgr) GetSubscribedClients(
 				continue
 			}
 			filter.mu.Lock()
+			matched := filter.ExistsScript(output.PkScript)
 			for _, a := range addrs {
 				if filter.ExistsAddress(a) {
-					subscribed[quitChan] = struct{}{}
-					op := wire.OutPoint{
-						Hash:  *tx.Hash(),
-						Index: uint32(i),
-					}
-					filter.AddUnspentOutPoint(&op)
+					matched = true
 				}
 			}
+			if matched {
+				subscribed[quitChan] = struct{}{}
+				op := wire.OutPoint{
+					Hash:  *tx.Hash(),
+					Index: uint32(i),
+				}
+				filter.AddUnspentOutPoint(&op)
+			}
 			filter.mu.Unlock()
 		}
 	}
@@ -1576,19 +1862,9 @@ func DeserializeOutpoints(serializedOuts []btcjson.OutPoint) (
 // ported from github.com/decred/dcrd
 func HandleLoadTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	cmd := icmd.(*btcjson.LoadTxFilterCmd)
-	outPoints := make([]wire.OutPoint, len(cmd.OutPoints))
-	for i := range cmd.OutPoints {
-		hash, e := chainhash.NewHashFromStr(cmd.OutPoints[i].Hash)
-		if e != nil {
-			return nil, &btcjson.RPCError{
-				Code:    btcjson.ErrRPCInvalidParameter,
-				Message: e.Error(),
-			}
-		}
-		outPoints[i] = wire.OutPoint{
-			Hash:  *hash,
-			Index: cmd.OutPoints[i].Index,
-		}
+	outPoints, pkScripts, e := ParseTxFilterEntries(cmd.OutPoints, cmd.Scripts)
+	if e != nil {
+		return nil, e
 	}
 	params := wsc.Server.Cfg.ChainParams
 	wsc.Lock()
@@ -1597,6 +1873,11 @@ func HandleLoadTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
 			cmd.Addresses, outPoints,
 			params,
 		)
+		wsc.FilterData.mu.Lock()
+		for _, pkScript := range pkScripts {
+			wsc.FilterData.AddScript(pkScript)
+		}
+		wsc.FilterData.mu.Unlock()
 		wsc.Unlock()
 	} else {
 		wsc.Unlock()
@@ -1607,11 +1888,91 @@ func HandleLoadTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
 		for i := range outPoints {
 			wsc.FilterData.AddUnspentOutPoint(&outPoints[i])
 		}
+		for _, pkScript := range pkScripts {
+			wsc.FilterData.AddScript(pkScript)
+		}
 		wsc.FilterData.mu.Unlock()
 	}
 	return nil, nil
 }
 
+// HandleUpdateTxFilter implements the updatetxfilter command extension for websocket connections, which adds entries to
+// or removes entries from the filter loaded with loadtxfilter.
+func HandleUpdateTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.UpdateTxFilterCmd)
+	if !ok {
+		return nil, btcjson.ErrRPCInternal
+	}
+	outPoints, pkScripts, e := ParseTxFilterEntries(cmd.OutPoints, cmd.Scripts)
+	if e != nil {
+		return nil, e
+	}
+	wsc.Lock()
+	filter := wsc.FilterData
+	wsc.Unlock()
+	if filter == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Transaction filter must be loaded before it can be updated",
+		}
+	}
+	params := wsc.Server.Cfg.ChainParams
+	filter.mu.Lock()
+	defer filter.mu.Unlock()
+	if cmd.Remove {
+		for _, a := range cmd.Addresses {
+			filter.RemoveAddressStr(a, params)
+		}
+		for i := range outPoints {
+			filter.RemoveUnspentOutPoint(&outPoints[i])
+		}
+		for _, pkScript := range pkScripts {
+			filter.RemoveScript(pkScript)
+		}
+		return nil, nil
+	}
+	for _, a := range cmd.Addresses {
+		filter.AddAddressStr(a, params)
+	}
+	for i := range outPoints {
+		filter.AddUnspentOutPoint(&outPoints[i])
+	}
+	for _, pkScript := range pkScripts {
+		filter.AddScript(pkScript)
+	}
+	return nil, nil
+}
+
+// ParseTxFilterEntries decodes the outpoints and hex encoded output scripts of a loadtxfilter or updatetxfilter request.
+func ParseTxFilterEntries(ops []btcjson.OutPoint, scripts *[]string) (
+	outPoints []wire.OutPoint, pkScripts [][]byte, e error,
+) {
+	outPoints = make([]wire.OutPoint, len(ops))
+	for i := range ops {
+		var hash *chainhash.Hash
+		if hash, e = chainhash.NewHashFromStr(ops[i].Hash); e != nil {
+			return nil, nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: e.Error(),
+			}
+		}
+		outPoints[i] = wire.OutPoint{
+			Hash:  *hash,
+			Index: ops[i].Index,
+		}
+	}
+	if scripts == nil {
+		return outPoints, nil, nil
+	}
+	pkScripts = make([][]byte, len(*scripts))
+	for i, script := range *scripts {
+		if pkScripts[i], e = hex.DecodeString(script); e != nil {
+			return nil, nil, DecodeHexError(script)
+		}
+	}
+	return outPoints, pkScripts, nil
+}
+
 // HandleNotifyBlocks implements the notifyblocks command extension for websocket connections.
 func HandleNotifyBlocks(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	wsc.Server.NtfnMgr.RegisterBlockUpdates(wsc)
@@ -1628,11 +1989,20 @@ func HandleNotifyNewTransactions(
 	if !ok {
 		return nil, btcjson.ErrRPCInternal
 	}
+	wsc.Lock()
 	wsc.VerboseTxUpdates = cmd.Verbose != nil && *cmd.Verbose
+	wsc.FilteredTxUpdates = cmd.Filtered != nil && *cmd.Filtered
+	wsc.Unlock()
 	wsc.Server.NtfnMgr.RegisterNewMempoolTxsUpdates(wsc)
 	return nil, nil
 }
 
+// HandleNotifyPeers implements the notifypeers command extension for websocket connections.
+func HandleNotifyPeers(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.RegisterPeerUpdates(wsc)
+	return nil, nil
+}
+
 // HandleNotifyReceived implements the notifyreceived command extension for websocket connections.
 func HandleNotifyReceived(wsc *WSClient, icmd interface{}) (
 	interface{},
@@ -2036,6 +2406,12 @@ func HandleStopNotifyNewTransactions(
 	return nil, nil
 }
 
+// HandleStopNotifyPeers implements the stopnotifypeers command extension for websocket connections.
+func HandleStopNotifyPeers(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.UnregisterPeerUpdates(wsc)
+	return nil, nil
+}
+
 // HandleStopNotifyReceived implements the stopnotifyreceived command extension for websocket connections.
 func HandleStopNotifyReceived(wsc *WSClient, icmd interface{}) (
 	interface{},
@@ -2151,6 +2527,7 @@ func NewWSClientFilter(
 			map[wire.OutPoint]struct{},
 			len(unspentOutPoints),
 		),
+		Scripts: map[string]struct{}{},
 	}
 	for _, s := range addresses {
 		filter.AddAddressStr(s, params)
@@ -2175,6 +2552,10 @@ func NewWebsocketClient(
 	if e != nil {
 		return nil, e
 	}
+	var policy string
+	if policy, e = ParseSlowClientPolicy(server.Config.RPCSlowClients.V()); E.Chk(e) {
+		return nil, e
+	}
 	client := &WSClient{
 		Conn:              conn,
 		Addr:              remoteAddr,
@@ -2185,9 +2566,11 @@ func NewWebsocketClient(
 		AddrRequests:      make(map[string]struct{}),
 		SpentRequests:     make(map[wire.OutPoint]struct{}),
 		ServiceRequestSem: MakeSemaphore(server.Config.RPCMaxConcurrentReqs.V()),
-		NtfnChan:          make(chan []byte, 1), // nonblocking sync
+		NtfnChan:          make(chan *NtfnPayload, 1), // nonblocking sync
 		SendChan:          make(chan WSResponse, WebsocketSendBufferSize),
 		Quit:              qu.T(),
+		NtfnQueueLimit:    server.Config.RPCNtfnQueueLimit.V(),
+		SlowClientPolicy:  policy,
 	}
 	return client, nil
 }
@@ -2200,6 +2583,7 @@ func NewWSNotificationManager(server *Server) *WSNtfnMgr {
 		QueueNotification: make(chan interface{}),
 		NotificationMsgs:  make(chan interface{}),
 		NumClients:        make(chan int),
+		MarshalQueue:      make(chan *NtfnPayload, NtfnMarshalQueueSize),
 		Quit:              qu.T(),
 	}
 }
@@ -2439,25 +2823,29 @@ func RescanBlockFilter(
 				output.PkScript, params,
 			)
 			if e != nil {
-				continue
+				addrs = nil
 			}
+			matched := filter.ExistsScript(output.PkScript)
 			for _, a := range addrs {
-				if !filter.ExistsAddress(a) {
-					continue
-				}
-				op := wire.OutPoint{
-					Hash:  *tx.Hash(),
-					Index: uint32(i),
-				}
-				filter.AddUnspentOutPoint(&op)
-				if !added {
-					transactions = append(
-						transactions,
-						TxHexString(msgTx),
-					)
-					added = true
+				if filter.ExistsAddress(a) {
+					matched = true
 				}
 			}
+			if !matched {
+				continue
+			}
+			op := wire.OutPoint{
+				Hash:  *tx.Hash(),
+				Index: uint32(i),
+			}
+			filter.AddUnspentOutPoint(&op)
+			if !added {
+				transactions = append(
+					transactions,
+					TxHexString(msgTx),
+				)
+				added = true
+			}
 		}
 	}
 	filter.mu.Unlock()
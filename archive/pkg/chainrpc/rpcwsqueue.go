@@ -0,0 +1,155 @@
+package chainrpc
+
+import (
+	"container/list"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+const (
+	// SlowClientDrop discards the oldest notifications waiting for a websocket client whose queue is full, so a slow
+	// client misses notifications but stays connected.
+	SlowClientDrop = "drop"
+	// SlowClientDisconnect disconnects a websocket client whose queue is full, so it can reconnect and resynchronize
+	// instead of silently missing notifications.
+	SlowClientDisconnect = "disconnect"
+	// NtfnMarshalQueueSize is the number of notifications that may wait for a marshal worker. When it is full the
+	// notifications are marshalled by the first client that sends them instead.
+	NtfnMarshalQueueSize = 256
+)
+
+// SlowClientPolicies are the policies for websocket clients that read their notifications slower than they are queued.
+var SlowClientPolicies = []string{SlowClientDrop, SlowClientDisconnect}
+
+// ParseSlowClientPolicy returns the slow client policy in the case used in SlowClientPolicies, or an error if it is not
+// one of them. An empty policy is SlowClientDrop.
+func ParseSlowClientPolicy(policy string) (string, error) {
+	if policy == "" {
+		return SlowClientDrop, nil
+	}
+	for _, p := range SlowClientPolicies {
+		if strings.EqualFold(policy, p) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"unknown slow websocket client policy %q, must be one of %s", policy, strings.Join(SlowClientPolicies, ", "),
+	)
+}
+
+// NtfnPayload is a notification queued for websocket clients. It is marshalled only once, by a marshal worker or by
+// the first client to write it, whichever comes first, and the JSON is shared by every client it was queued for. This
+// keeps the notification handler from serializing large notifications such as those of blocks itself, which would
+// delay the delivery of everything after them to all clients.
+type NtfnPayload struct {
+	once sync.Once
+	ntfn interface{}
+	json []byte
+	e    error
+}
+
+// NewNtfnPayload returns a payload that marshals the notification when it is first needed.
+func NewNtfnPayload(ntfn interface{}) *NtfnPayload {
+	return &NtfnPayload{ntfn: ntfn}
+}
+
+// MarshalledNtfnPayload returns a payload for a notification that is already marshalled.
+func MarshalledNtfnPayload(marshalledJSON []byte) *NtfnPayload {
+	return &NtfnPayload{json: marshalledJSON}
+}
+
+// Bytes returns the marshalled notification, marshalling it if this has not been done yet. The notification must not
+// be changed once the payload has been queued. This function is safe for concurrent access.
+func (p *NtfnPayload) Bytes() ([]byte, error) {
+	p.once.Do(
+		func() {
+			if p.ntfn != nil {
+				p.json, p.e = btcjson.MarshalCmd(nil, p.ntfn)
+			}
+		},
+	)
+	return p.json, p.e
+}
+
+// NtfnQueue is the queue of notifications waiting to be written to a websocket client. It holds at most Limit
+// notifications, and what happens when another is pushed onto a full queue depends on the slow client Policy.
+type NtfnQueue struct {
+	l      *list.List
+	Limit  int
+	Policy string
+}
+
+// NewNtfnQueue returns an empty notification queue with the limit and slow client policy. A limit below 1 leaves the
+// queue unbounded.
+func NewNtfnQueue(limit int, policy string) *NtfnQueue {
+	return &NtfnQueue{l: list.New(), Limit: limit, Policy: policy}
+}
+
+// Push queues the notification. When the queue is full and the policy is SlowClientDrop the oldest notification is
+// discarded to make room and dropped is 1. When it is full and the policy is SlowClientDisconnect the notification is
+// not queued and full is true, the client must be disconnected.
+func (q *NtfnQueue) Push(p *NtfnPayload) (dropped int, full bool) {
+	if q.Limit > 0 && q.l.Len() >= q.Limit {
+		if q.Policy == SlowClientDisconnect {
+			return 0, true
+		}
+		q.l.Remove(q.l.Front())
+		dropped = 1
+	}
+	q.l.PushBack(p)
+	return
+}
+
+// Pop removes and returns the oldest notification, or nil if the queue is empty.
+func (q *NtfnQueue) Pop() *NtfnPayload {
+	next := q.l.Front()
+	if next == nil {
+		return nil
+	}
+	return q.l.Remove(next).(*NtfnPayload)
+}
+
+// Len returns the number of notifications in the queue.
+func (q *NtfnQueue) Len() int {
+	return q.l.Len()
+}
+
+// NtfnMarshalWorkers returns the number of goroutines marshalling notifications for the notification manager.
+func NtfnMarshalWorkers() int {
+	if n := runtime.NumCPU(); n < 4 {
+		return n
+	}
+	return 4
+}
+
+// Payload returns the payload of a notification for queueing to websocket clients and hands it to the marshal workers,
+// so it is usually marshalled by the time the clients write it. If the workers are all busy the first client to write
+// it marshals it.
+func (m *WSNtfnMgr) Payload(ntfn interface{}) *NtfnPayload {
+	p := NewNtfnPayload(ntfn)
+	select {
+	case m.MarshalQueue <- p:
+	default:
+	}
+	return p
+}
+
+// MarshalWorker marshals the notification payloads handed to it until the notification manager shuts down. It must be
+// run as a goroutine.
+func (m *WSNtfnMgr) MarshalWorker() {
+out:
+	for {
+		select {
+		case p := <-m.MarshalQueue:
+			// errors are logged by the clients writing the notification
+			_, _ = p.Bytes()
+		case <-m.Quit.Wait():
+			break out
+		}
+	}
+	m.WG.Done()
+}
@@ -0,0 +1,96 @@
+package chainrpc
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcjson"
+)
+
+// TestNtfnPayload ensures a payload queued for many clients is marshalled once and gives them all the same JSON.
+func TestNtfnPayload(t *testing.T) {
+	ntfn := btcjson.NewBlockConnectedNtfn("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f", 1, 1231006505)
+	want, e := btcjson.MarshalCmd(nil, ntfn)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p := NewNtfnPayload(ntfn)
+	results := make([][]byte, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = p.Bytes()
+		}(i)
+	}
+	wg.Wait()
+	for i := range results {
+		if !bytes.Equal(results[i], want) {
+			t.Fatalf("client %d got %s, want %s", i, results[i], want)
+		}
+		if &results[i][0] != &results[0][0] {
+			t.Fatal("the notification was marshalled more than once")
+		}
+	}
+	if b, e := MarshalledNtfnPayload(want).Bytes(); e != nil || !bytes.Equal(b, want) {
+		t.Fatalf("marshalled payload gave %s, %v", b, e)
+	}
+}
+
+// TestNtfnQueue ensures a full notification queue drops its oldest notifications or reports that the client must be
+// disconnected, depending on the slow client policy.
+func TestNtfnQueue(t *testing.T) {
+	payloads := make([]*NtfnPayload, 4)
+	for i := range payloads {
+		payloads[i] = MarshalledNtfnPayload([]byte{byte(i)})
+	}
+	q := NewNtfnQueue(2, SlowClientDrop)
+	var dropped int
+	for _, p := range payloads {
+		d, full := q.Push(p)
+		if full {
+			t.Fatal("the queue asked for a client to be disconnected when dropping")
+		}
+		dropped += d
+	}
+	if dropped != 2 || q.Len() != 2 {
+		t.Fatalf("dropped %d and kept %d notifications, want 2 of each", dropped, q.Len())
+	}
+	for _, want := range payloads[2:] {
+		if p := q.Pop(); p != want {
+			t.Fatal("the newest notifications were not kept in order")
+		}
+	}
+	if q.Pop() != nil {
+		t.Fatal("an empty queue returned a notification")
+	}
+	q = NewNtfnQueue(2, SlowClientDisconnect)
+	for i, p := range payloads[:3] {
+		if d, full := q.Push(p); d != 0 || full != (i == 2) {
+			t.Fatalf("push %d onto a queue of 2 dropped %d, full %v", i, d, full)
+		}
+	}
+	if q.Len() != 2 || q.Pop() != payloads[0] {
+		t.Fatal("a queue disconnecting its client dropped queued notifications")
+	}
+	q = NewNtfnQueue(0, SlowClientDisconnect)
+	for _, p := range payloads {
+		if _, full := q.Push(p); full {
+			t.Fatal("an unbounded queue was full")
+		}
+	}
+}
+
+// TestParseSlowClientPolicy ensures slow client policies are parsed regardless of case and unknown ones are refused.
+func TestParseSlowClientPolicy(t *testing.T) {
+	for in, want := range map[string]string{"": SlowClientDrop, "Drop": SlowClientDrop, "DISCONNECT": SlowClientDisconnect} {
+		if got, e := ParseSlowClientPolicy(in); e != nil || got != want {
+			t.Errorf("policy %q parsed as %q, %v, want %q", in, got, e, want)
+		}
+	}
+	if _, e := ParseSlowClientPolicy("block"); e == nil {
+		t.Error("an unknown slow client policy was accepted")
+	}
+}
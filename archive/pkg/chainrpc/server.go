@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -40,6 +41,7 @@ import (
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/connmgr"
 	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/features"
 	"github.com/p9c/pod/pkg/indexers"
 	"github.com/p9c/pod/pkg/mempool"
 	"github.com/p9c/pod/pkg/netsync"
@@ -157,12 +159,29 @@ type (
 		TxIndex   *indexers.TxIndex
 		AddrIndex *indexers.AddrIndex
 		CFIndex   *indexers.CFIndex
+		// IndexManager keeps the optional indexes in sync with the chain. It is always present, so indexes can be added
+		// to it after startup.
+		IndexManager *indexers.Manager
 		// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into
 		// blocks.
 		FeeEstimator *mempool.FeeEstimator
+		// Features switches the optional subsystems of the node on and off.
+		Features *features.Flags
 		// CFCheckptCaches stores a cached slice of filter headers for cfcheckpt messages for each filter type.
 		CFCheckptCaches                 map[wire.FilterType][]CFHeaderKV
 		CFCheckptCachesMtx              sync.RWMutex
+		PatternResponses                PatternResponses
+		// PeerEventsWebhook posts peer connect and disconnect events to the configured URL, nil if there is none.
+		PeerEventsWebhook               *PeerEventsWebhook
+		// ArchivalLimiter paces the serving of historical blocks to peers, nil if it is not limited.
+		ArchivalLimiter                 *ArchivalLimiter
+		// WorkSource is the status of the sources of work of the mining controller.
+		WorkSource                      *WorkSourceStatus
+		// MiningExpectation is what the miners of the mining controller can expect to find.
+		MiningExpectation               *MiningExpectation
+		// Reachability is whether the listener can be reached from the internet, found from the port mapping, probes
+		// through the external address and inbound connections.
+		Reachability                    *Reachability
 		Config                          *config.Config
 		ActiveNet                       *chaincfg.Params
 		StateCfg                        *active.Config
@@ -171,6 +190,9 @@ type (
 		Shutdown                        int32
 		ShutdownSched                   int32
 		HighestKnown                    uberatomic.Int32
+		// ClockSkewed is set while the local clock is further from the time of the network than the warning threshold,
+		// so the warning is logged once each time it goes out of line.
+		ClockSkewed                     uberatomic.Bool
 		peerState                       *PeerState
 		StartController, StopController qu.C
 	}
@@ -186,6 +208,7 @@ type (
 		Filter         *bloom.Filter
 		KnownAddresses map[string]struct{}
 		BanScore       connmgr.DynamicBanScore
+		MsgStats       MsgStats
 		Quit           qu.C
 		// The following chans are used to sync blockmanager and server.
 		TxProcessed    qu.C
@@ -196,6 +219,8 @@ type (
 		DisableRelayTx bool
 		IP             net.IP
 		Port           uint16
+		// disconnectReason is why the node disconnected the peer, set by DisconnectWithReason.
+		disconnectReason uberatomic.String
 	}
 	// SimpleAddr implements the net.Addr interface with two struct fields
 	SimpleAddr struct {
@@ -226,6 +251,11 @@ const (
 	// when connecting to persistent peers. It is adjusted by the number of retries
 	// such that there is a retry backoff.
 	ConnectionRetryInterval = time.Minute
+	// PortMappingLease is how long in seconds the listening port is mapped on the router for. The mapping is renewed
+	// at half of it.
+	PortMappingLease = 20 * 60
+	// PortMappingRetry is how soon a failed mapping of the listening port is tried again.
+	PortMappingRetry = time.Minute
 )
 
 var (
@@ -320,6 +350,25 @@ func (n *Node) AnnounceNewTransactions(txns []*mempool.TxDesc) {
 	}
 }
 
+// AddBanScore increases the ban score of the connected peer, for misbehaviour found outside the peer handlers, such as
+// by the sync manager. The peer is looked up in a new goroutine so the caller is not held up by the peer handler.
+func (n *Node) AddBanScore(p *peer.Peer, persistent, transient uint32, reason string) {
+	go func() {
+		replyChan := make(chan []*NodePeer)
+		select {
+		case n.Query <- GetPeersMsg{Reply: replyChan}:
+		case <-n.Quit.Wait():
+			return
+		}
+		for _, np := range <-replyChan {
+			if np.Peer == p {
+				np.AddBanScore(persistent, transient, reason)
+				return
+			}
+		}
+	}()
+}
+
 // BanPeer bans a peer that has already been connected to the server by ip.
 func (n *Node) BanPeer(sp *NodePeer) {
 	n.BanPeers <- sp
@@ -481,12 +530,14 @@ func (n *Node) Stop() (e error) {
 			}
 		}
 	}
-	// Save fee estimator state in the database.
+	// Save fee estimator state and the transactions in the mempool in the database.
 	if e = n.DB.Update(
 		func(tx database.Tx) (e error) {
 			metadata := tx.Metadata()
 			if e = metadata.Put(mempool.EstimateFeeDatabaseKey, n.FeeEstimator.Save()); E.Chk(e) {
 			}
+			if e = metadata.Put(mempool.PoolDatabaseKey, n.TxMemPool.Save()); E.Chk(e) {
+			}
 			return nil
 		},
 	); E.Chk(e) {
@@ -511,6 +562,78 @@ func (n *Node) TransactionConfirmed(tx *util.Tx) {
 	n.RemoveRebroadcastInventory(iv)
 }
 
+// TransactionExpired was evicted from the mempool without being mined. It is no longer rebroadcast, and websocket
+// clients are notified so wallets can decide whether to rebroadcast or abandon it.
+func (n *Node) TransactionExpired(tx *util.Tx) {
+	// Rebroadcasting and notifications only happen when the RPC server is active.
+	if n.Config.DisableRPC.True() {
+		return
+	}
+	iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
+	n.RemoveRebroadcastInventory(iv)
+	for i := range n.RPCServers {
+		if n.RPCServers[i] != nil {
+			n.RPCServers[i].NotifyExpiredTransaction(tx)
+		}
+	}
+}
+
+// UnspentOutput returns the output at op if it is unspent in the best chain, or with checkMempool, if it is created by a
+// transaction in the memory pool and not spent by one. Outputs in the memory pool have the height
+// wire.UTXOMempoolHeight. It returns nil if the output is spent or unknown.
+func (n *Node) UnspentOutput(op wire.OutPoint, checkMempool bool) *wire.UTXO {
+	if checkMempool {
+		if n.TxMemPool.CheckSpend(op) != nil {
+			return nil
+		}
+		if tx, e := n.TxMemPool.FetchTransaction(&op.Hash); e == nil {
+			mtx := tx.MsgTx()
+			if op.Index >= uint32(len(mtx.TxOut)) {
+				return nil
+			}
+			return &wire.UTXO{
+				TxVersion: uint32(mtx.Version),
+				Height:    wire.UTXOMempoolHeight,
+				Out:       *mtx.TxOut[op.Index],
+			}
+		}
+	}
+	entry, e := n.Chain.FetchUtxoEntry(op)
+	if E.Chk(e) || entry == nil || entry.IsSpent() {
+		return nil
+	}
+	return &wire.UTXO{
+		TxVersion: n.txVersion(&op.Hash),
+		Height:    uint32(entry.BlockHeight()),
+		Out:       wire.TxOut{Value: entry.Amount(), PkScript: entry.PkScript()},
+	}
+}
+
+// txVersion returns the version of the transaction with the given hash in the best chain. The utxo set does not keep
+// the version of the transactions, so it is read from the start of the transaction found with the transaction index,
+// and is zero when the index is not enabled.
+func (n *Node) txVersion(hash *chainhash.Hash) uint32 {
+	if n.TxIndex == nil {
+		return 0
+	}
+	region, e := n.TxIndex.TxBlockRegion(hash)
+	if E.Chk(e) || region == nil || region.Len < 4 {
+		return 0
+	}
+	versionRegion := *region
+	versionRegion.Len = 4
+	var b []byte
+	if e = n.DB.View(
+		func(dbTx database.Tx) (e error) {
+			b, e = dbTx.FetchBlockRegion(&versionRegion)
+			return e
+		},
+	); E.Chk(e) {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
 // UpdatePeerHeights updates the heights of all peers who have have announced the latest connected main chain block, or
 // a recognized orphan.
 //
@@ -541,7 +664,7 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 	// Ignore new peers if we're shutting down.
 	if atomic.LoadInt32(&n.Shutdown) != 0 {
 		I.F("new peer %n ignored - server is shutting down", sp)
-		sp.Disconnect()
+		sp.DisconnectWithReason(ReasonShutdown)
 		return false
 	}
 	// Disconnect banned peers.
@@ -559,7 +682,7 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 				"peer %n is banned for another %v - disconnecting %n",
 				host, time.Until(banEnd),
 			)
-			sp.Disconnect()
+			sp.DisconnectWithReason(ReasonBanned)
 			return false
 		}
 		I.F("peer %n is no longer banned", host)
@@ -573,7 +696,7 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 			"max peers reached [%d] - disconnecting peer %n",
 			n.Config.MaxPeers, sp.Addr(),
 		)
-		sp.Disconnect()
+		sp.DisconnectWithReason(ReasonMaxPeers)
 		// TODO: how to handle permanent peers here? they should be rescheduled.
 		return false
 	}
@@ -630,6 +753,7 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 			state.OutboundPeers[sp.ID()] = sp
 		}
 	}
+	n.AnnouncePeerConnected(sp)
 	return true
 }
 
@@ -684,6 +808,7 @@ func (n *Node) HandleDonePeerMsg(state *PeerState, sp *NodePeer) {
 		}
 		delete(list, sp.ID())
 		T.Ln("removed peer ", sp)
+		n.AnnouncePeerDisconnected(sp)
 		return
 	}
 	if sp.ConnReq != nil {
@@ -949,6 +1074,7 @@ func (n *Node) InboundPeerConnected(conn net.Conn) {
 	}
 	localIP := net.ParseIP(hh)
 	I.Ln("inbound peer connected", ca, cla, remoteIP)
+	n.Reachability.Inbound(remoteIP)
 	sp := NewServerPeer(n, localIP, false)
 	sp.IsWhitelisted = GetIsWhitelisted(n.StateCfg, conn.RemoteAddr())
 	sp.Peer = peer.NewInboundPeer(NewPeerConfig(sp))
@@ -1056,7 +1182,7 @@ func (n *Node) PeerHandler() {
 		// Add peers discovered through DNS to the address manager.
 		connmgr.SeedFromDNS(
 			n.ActiveNet, DefaultRequiredServices,
-			Lookup(n.StateCfg), func(addrs []*wire.NetAddress) {
+			Lookup(n.StateCfg, connmgr.PurposeDNSSeed), func(addrs []*wire.NetAddress) {
 				// Bitcoind uses a lookup of the dns seeder here. This is rather strange since the values looked up by
 				// the DNS seed lookups will vary quite a lot. To replicate this behaviour we put all addresses as
 				// having come from the first one.
@@ -1127,7 +1253,7 @@ out:
 			n.peerState.ForAllPeers(
 				func(sp *NodePeer) {
 					T.F("shutdown peer %n", sp.Addr())
-					sp.Disconnect()
+					sp.DisconnectWithReason(ReasonShutdown)
 				},
 			)
 			break out
@@ -1196,6 +1322,17 @@ func (n *Node) PushBlockMsg(
 		}
 		return e
 	}
+	// Blocks deep below the tip are served to syncing peers within the budget for history, so they do not crowd out
+	// the relay of new blocks. Whitelisted peers are not held to it.
+	if l := n.ArchivalLimiter; l != nil && !sp.IsWhitelisted {
+		height, e := n.Chain.BlockHeightByHash(hash)
+		if e == nil && l.Historical(height, n.Chain.BestSnapshot().Height) && !l.Wait(len(blockBytes), sp.Quit) {
+			if doneChan != nil {
+				doneChan <- struct{}{}
+			}
+			return fmt.Errorf("peer %s disconnected before block %v could be served", sp, hash)
+		}
+	}
 	// Once we have fetched data wait for any previous operation to finish.
 	if waitChan != nil {
 		<-waitChan
@@ -1365,12 +1502,16 @@ func (n *Node) RelayTransactions(txns []*mempool.TxDesc) {
 		n.RelayInventory(iv, txD)
 	}
 }
+
+// UPNPUpdateThread maps the listening port on the router and renews the mapping at half its lease, so that it does
+// not lapse between renewals. On each renewal the external address is looked up again so a change of it is advertised,
+// and the mapped address is probed to find whether it can be reached from outside.
 func (n *Node) UPNPUpdateThread() {
-	// Go off immediately to prevent code duplication, thereafter we renew lease
-	// every 15 minutes.
+	// Go off immediately to prevent code duplication, thereafter we renew the lease.
 	timer := time.NewTimer(0 * time.Second)
 	lport, _ := strconv.ParseInt(n.ActiveNet.DefaultPort, 10, 16)
-	first := true
+	protocol := n.NAT.Protocol()
+	var local *wire.NetAddress
 out:
 	for {
 		select {
@@ -1379,31 +1520,23 @@ out:
 			// TODO: know which ports we are listening to on an external net.
 			// TODO: if specific listen port doesn't work then ask for wildcard
 			//  listen port?
-			// XXX this assumes timeout is in seconds.
-			listenPort, e := n.NAT.AddPortMapping(
-				"tcp", int(lport), int(lport), "pod listen port",
-				20*60,
-			)
+			na, e := n.MapListenPort(int(lport))
 			if e != nil {
-				E.F("can't add UPnP port mapping: %v %n", e)
+				E.F("can't map listening port with %s: %v", protocol, e)
+				n.Reachability.SetMapping(protocol, "", e)
+				timer.Reset(PortMappingRetry)
+				continue out
 			}
-			if first && e == nil {
-				// TODO: look this up periodically to see if upnp domain changed and so did ip.
-				externalip, e := n.NAT.GetExternalAddress()
-				if e != nil {
-					E.F("UPnP can't get external address: %v", e)
-					continue out
+			addr := addrmgr.NetAddressKey(na)
+			n.Reachability.SetMapping(protocol, addr, nil)
+			if local == nil || addrmgr.NetAddressKey(local) != addr {
+				if e = n.AddrManager.AddLocalAddress(na, addrmgr.UpnpPrio); !E.Chk(e) {
+					I.F("successfully bound via %s to %s", protocol, addr)
 				}
-				na := wire.NewNetAddressIPPort(externalip, uint16(listenPort), n.Services)
-				e = n.AddrManager.AddLocalAddress(na, addrmgr.UpnpPrio)
-				if e != nil {
-					_ = e
-					// XXX DeletePortMapping?
-				}
-				W.F("successfully bound via UPnP to %n", addrmgr.NetAddressKey(na))
-				first = false
+				local = na
 			}
-			timer.Reset(time.Minute * 15)
+			go n.Reachability.Probe(addr, n.Quit)
+			timer.Reset(PortMappingLease * time.Second / 2)
 		case <-n.Quit.Wait():
 			break out
 		}
@@ -1413,13 +1546,27 @@ out:
 		"tcp", int(lport),
 		int(lport),
 	); E.Chk(e) {
-		D.F("unable to remove UPnP port mapping: %v %n", e)
+		D.F("unable to remove %s port mapping: %v", protocol, e)
 	} else {
-		D.Ln("successfully cleared UPnP port mapping")
+		D.Ln("successfully cleared", protocol, "port mapping")
 	}
 	n.WG.Done()
 }
 
+// MapListenPort maps the listening port on the router for PortMappingLease seconds and returns the external address
+// it is reached at.
+func (n *Node) MapListenPort(port int) (na *wire.NetAddress, e error) {
+	var mapped int
+	if mapped, e = n.NAT.AddPortMapping("tcp", port, port, "pod listen port", PortMappingLease); e != nil {
+		return
+	}
+	var externalIP net.IP
+	if externalIP, e = n.NAT.GetExternalAddress(); e != nil {
+		return
+	}
+	return wire.NewNetAddressIPPort(externalIP, uint16(mapped), n.Services), nil
+}
+
 // OnAddr is invoked when a peer receives an addr bitcoin message and is used to notify the server about advertised addresses.
 func (np *NodePeer) OnAddr(
 	_ *peer.Peer,
@@ -1444,6 +1591,10 @@ func (np *NodePeer) OnAddr(
 		np.Disconnect()
 		return
 	}
+	over, first := np.MsgStats.ObserveAddr(time.Now(), msg)
+	if np.RespondToPattern(PatternAddrSpam, over, first) {
+		return
+	}
 	for _, na := range msg.AddrList {
 		// Don't add more address if we're disconnecting.
 		if !np.Connected() {
@@ -1471,6 +1622,7 @@ func (np *NodePeer) OnBlock(p *peer.Peer, msg *wire.Block, buf []byte) {
 	// Convert the raw Block to a util.Block which provides some convenience
 	// methods and things such as hash caching.
 	block := block2.NewFromBlockAndBytes(msg, buf)
+	np.Server.Chain.TraceDeserialize(block.Hash(), p.LastDecodeTime())
 	// Add the block to the known inventory for the peer.
 	iv := wire.NewInvVect(wire.InvTypeBlock, block.Hash())
 	np.AddKnownInventory(iv)
@@ -1996,6 +2148,10 @@ func (np *NodePeer) OnGetHeaders(
 	_ *peer.Peer,
 	msg *wire.MsgGetHeaders,
 ) {
+	over, first := np.MsgStats.ObserveGetHeaders(time.Now(), msg)
+	if np.RespondToPattern(PatternGetHeadersRepeat, over, first) {
+		return
+	}
 	// Ignore getheaders requests if not in sync.
 	if !np.Server.SyncManager.IsCurrent() {
 		return
@@ -2015,6 +2171,39 @@ func (np *NodePeer) OnGetHeaders(
 	np.QueueMessage(&wire.MsgHeaders{Headers: blockHeaders}, nil)
 }
 
+// OnGetUTXOs is invoked when a peer receives a getutxos bitcoin message (BIP0064). It replies with a utxos message
+// marking which of the outpoints asked about are unspent in the best chain, and also in the memory pool when the peer
+// asks for it, along with their outputs. The peer is disconnected if the server is not configured to answer utxo
+// queries.
+func (np *NodePeer) OnGetUTXOs(
+	_ *peer.Peer,
+	msg *wire.MsgGetUTXOs,
+) {
+	// Only allow utxo queries if the server has the utxo service enabled.
+	if np.Server.Services&wire.SFNodeGetUTXO != wire.SFNodeGetUTXO {
+		D.Ln(
+			"peer", np, "sent getutxos request with the utxo service disabled"+
+				" -- disconnecting",
+		)
+		np.Disconnect()
+		return
+	}
+	// A decaying ban score increase in proportion to the size of the query is applied to prevent flooding. Queries of
+	// more than wire.MaxGetUTXOsOutPoints outpoints are rejected when the message is read.
+	if np.AddBanScore(0, uint32(len(msg.OutPoints))/4+1, "getutxos") {
+		return
+	}
+	best := np.Server.Chain.BestSnapshot()
+	reply := wire.NewMsgUTXOs(uint32(best.Height), &best.Hash)
+	for i := range msg.OutPoints {
+		e := reply.AddUTXO(i, np.Server.UnspentOutput(msg.OutPoints[i], msg.CheckMempool))
+		if E.Chk(e) {
+			return
+		}
+	}
+	np.QueueMessage(reply, nil)
+}
+
 // OnHeaders is invoked when a peer receives a headers bitcoin message. The message is passed down to the sync manager.
 func (np *NodePeer) OnHeaders(
 	_ *peer.Peer,
@@ -2030,6 +2219,10 @@ func (np *NodePeer) OnInv(
 	_ *peer.Peer,
 	msg *wire.MsgInv,
 ) {
+	over, first := np.MsgStats.ObserveInv(time.Now(), msg)
+	if np.RespondToPattern(PatternInvFlood, over, first) {
+		return
+	}
 	if !np.Server.Config.BlocksOnly.True() {
 		if len(msg.InvList) > 0 {
 			np.Server.SyncManager.QueueInv(msg, np.Peer)
@@ -2058,6 +2251,15 @@ func (np *NodePeer) OnInv(
 	}
 }
 
+// OnNotFound is invoked when a peer receives a notfound bitcoin message. The message is passed down to the sync manager
+// so the transactions the peer does not have are requested from other peers.
+func (np *NodePeer) OnNotFound(
+	_ *peer.Peer,
+	msg *wire.MsgNotFound,
+) {
+	np.Server.SyncManager.QueueNotFound(msg, np.Peer)
+}
+
 // OnMemPool is invoked when a peer receives a mempool bitcoin message. It creates and sends an inventory message with
 // the contents of the memory pool up to the maximum inventory allowed per message. When the peer has a bloom filter
 // loaded, the contents are filtered accordingly.
@@ -2110,6 +2312,9 @@ func (np *NodePeer) OnRead(
 	bytesRead int, msg wire.Message, e error,
 ) {
 	np.Server.AddBytesReceived(uint64(bytesRead))
+	if msg != nil {
+		np.MsgStats.Received(msg.Command())
+	}
 }
 
 // OnTx is invoked when a peer receives a tx bitcoin message. It blocks until the bitcoin transaction has been fully
@@ -2219,8 +2424,13 @@ func (np *NodePeer) OnVersion(
 		addrManager.Good(remoteAddr)
 	}
 	// Add the remote peer time as a sample for creating an offset against the local clock to keep the network time in
-	// sync.
-	np.Server.TimeSource.AddTimeSample(np.Addr(), msg.Timestamp)
+	// sync. Samples are taken once per host, so a host opening several connections can not outweigh the others.
+	sampleID := np.Addr()
+	if host, _, e := net.SplitHostPort(sampleID); e == nil {
+		sampleID = host
+	}
+	np.Server.TimeSource.AddTimeSample(sampleID, msg.Timestamp)
+	np.Server.checkClockSkew()
 	// Signal the sync manager this peer is a new sync candidate.
 	np.Server.SyncManager.NewPeer(np.Peer)
 	// Choose whether or not to relay transactions before a filter command is received.
@@ -2270,13 +2480,41 @@ func (np *NodePeer) AddBanScore(persistent, transient uint32, reason string) boo
 		if int(score) > np.Server.Config.BanThreshold.V() {
 			W.F("misbehaving peer %s -- banning and disconnecting", np)
 			np.Server.BanPeer(np)
-			np.Disconnect()
+			np.DisconnectWithReason(ReasonBanScore)
 			return true
 		}
 	}
 	return false
 }
 
+// RespondToPattern carries out the configured response when the peer has gone over the limit of a misbehaving message
+// pattern, as reported by over and first from MsgStats, and returns whether the message should be ignored. Whitelisted
+// peers are never throttled, disconnected or banned.
+func (np *NodePeer) RespondToPattern(pattern MsgPattern, over, first bool) bool {
+	if !over || np.IsWhitelisted {
+		if first {
+			D.F("whitelisted peer %s shows misbehaving message pattern %s", np, pattern)
+		}
+		return false
+	}
+	response := np.Server.PatternResponses[pattern]
+	if first {
+		W.F("peer %s shows misbehaving message pattern %s -- %s", np, pattern, response)
+	}
+	switch response {
+	case ResponseBan:
+		if np.Server.Config.DisableBanning.False() {
+			np.Server.BanPeer(np)
+		}
+		fallthrough
+	case ResponseDisconnect:
+		np.DisconnectWithReason("message pattern " + pattern.String())
+	default:
+		np.MsgStats.Dropped()
+	}
+	return true
+}
+
 // AddKnownAddresses adds the given addresses to the set of known addresses to the peer to prevent sending duplicate
 // addresses.
 func (np *NodePeer) AddKnownAddresses(addresses []*wire.NetAddress) {
@@ -2464,7 +2702,7 @@ func AddrStringToNetAddr(config *config.Config, stateCfg *active.Config, addr st
 		return &OnionAddr{Addr: addr}, nil
 	}
 	// Attempt to look up an IP address associated with the parsed host.
-	ips, e := Lookup(stateCfg)(host)
+	ips, e := Lookup(stateCfg, connmgr.PurposePeer)(host)
 	if e != nil {
 		return nil, e
 	}
@@ -2530,7 +2768,7 @@ func GetHasServices(advertised, desired wire.ServiceFlag) bool {
 }
 
 // InitListeners initializes the configured net listeners and adds any bound addresses to the address manager. Returns
-// the listeners and a upnp.NAT interface, which is non-nil if UPnP is in use.
+// the listeners and a upnp.NAT interface, which is non-nil if UPnP, NAT-PMP or PCP is in use.
 func InitListeners(
 	config *config.Config, activeNet *chaincfg.Params,
 	aMgr *addrmgr.AddrManager, listenAddrs []string, services wire.ServiceFlag,
@@ -2591,11 +2829,11 @@ func InitListeners(
 	} else {
 		if config.UPNP.True() {
 			var e error
-			nat, e = upnp.Discover()
+			nat, e = upnp.DiscoverAny()
 			if e != nil {
-				E.F("can't discover upnp: %v", e)
+				E.F("can't discover a router to map the listening port on: %v", e)
 			}
-			// nil upnp.nat here is fine, just means no upnp on network.
+			// nil upnp.nat here is fine, just means no port mapping on network.
 		}
 		// Add bound addresses to address manager to be advertised to peers.
 		for _, listener := range listeners {
@@ -2677,6 +2915,7 @@ func NewPeerConfig(sp *NodePeer) *peer.Config {
 			OnBlock:        sp.OnBlock,
 			OnInv:          sp.OnInv,
 			OnHeaders:      sp.OnHeaders,
+			OnNotFound:     sp.OnNotFound,
 			OnGetData:      sp.OnGetData,
 			OnGetBlocks:    sp.OnGetBlocks,
 			OnGetHeaders:   sp.OnGetHeaders,
@@ -2684,6 +2923,7 @@ func NewPeerConfig(sp *NodePeer) *peer.Config {
 			OnGetCFHeaders: sp.OnGetCFHeaders,
 			OnGetCFCheckpt: sp.OnGetCFCheckpt,
 			OnFeeFilter:    sp.OnFeeFilter,
+			OnGetUTXOs:     sp.OnGetUTXOs,
 			OnFilterAdd:    sp.OnFilterAdd,
 			OnFilterClear:  sp.OnFilterClear,
 			OnFilterLoad:   sp.OnFilterLoad,
@@ -2709,6 +2949,8 @@ func NewPeerConfig(sp *NodePeer) *peer.Config {
 		TrickleInterval:   sp.Server.Config.TrickleInterval.V(),
 		IP:                sp.IP,
 		Port:              sp.Port,
+		LocalFastPath:     sp.Server.Config.LocalFastPath.V(),
+		OnSelfConnection:  sp.Server.Reachability.SelfConnected,
 	}
 }
 
@@ -2739,7 +2981,10 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 	if cx.Config.NoCFilters.True() {
 		services &^= wire.SFNodeCF
 	}
-	aMgr := addrmgr.New(cx.Config.DataDir.V()+string(os.PathSeparator)+cx.ActiveNet.Name, Lookup(cx.StateCfg))
+	if cx.Config.PeerUTXOService.True() {
+		services |= wire.SFNodeGetUTXO
+	}
+	aMgr := addrmgr.New(cx.Config.DataDir.V()+string(os.PathSeparator)+cx.ActiveNet.Name, Lookup(cx.StateCfg, connmgr.PurposePeer))
 	var lstn []net.Listener
 	var nat upnp.NAT
 	if cx.Config.DisableListen.False() {
@@ -2759,6 +3004,18 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		thr = cx.Config.GenThreads.V()
 	}
 	T.Ln("set genthreads to ", thr)
+	patternResponses, e := ParsePatternResponses(cx.Config.PeerPatternResponses.S())
+	if E.Chk(e) {
+		return nil, e
+	}
+	miningAddrs, e := DecodeMiningAddrs(cx.Config.MiningAddrs.S(), cx.ActiveNet)
+	if E.Chk(e) {
+		return nil, e
+	}
+	if e = cx.StateCfg.ActiveMiningAddrs.Set(miningAddrs, cx.Config.MiningAddrRotation.V()); E.Chk(e) {
+		return nil, e
+	}
+	sigCacheBytes := txscript.SigCacheMaxBytes(uint(cx.Config.SigCacheMaxSize.V()))
 	s := Node{
 		ChainParams:          cx.ActiveNet,
 		AddrManager:          aMgr,
@@ -2776,16 +3033,26 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		DB:                   db,
 		TimeSource:           blockchain.NewMedianTime(),
 		Services:             services,
-		SigCache:             txscript.NewSigCache(uint(cx.Config.SigCacheMaxSize.V())),
-		HashCache:            txscript.NewHashCache(uint(cx.Config.SigCacheMaxSize.V())),
+		SigCache:             txscript.NewSigCache(sigCacheBytes),
+		HashCache:            txscript.NewHashCache(sigCacheBytes / txscript.SigCacheEntrySize),
 		CFCheckptCaches:      make(map[wire.FilterType][]CFHeaderKV),
+		PatternResponses:     patternResponses,
 		GenThreads:           uint32(thr),
 		Config:               cx.Config,
 		StateCfg:             cx.StateCfg,
 		ActiveNet:            cx.ActiveNet,
 		StartController:      qu.Ts(2),
 		StopController:       qu.Ts(2),
+		WorkSource:           NewWorkSourceStatus(),
+		MiningExpectation:    &MiningExpectation{},
+		Reachability:         NewReachability(cx.ActiveNet.Net),
 	}
+	if url := cx.Config.PeerEventsWebhook.V(); url != "" {
+		s.PeerEventsWebhook = NewPeerEventsWebhook(url, s.Quit)
+	}
+	s.ArchivalLimiter = NewArchivalLimiter(
+		cx.Config.ArchivalServeRate.V()*1024, int32(cx.Config.ArchivalServeDepth.V()),
+	)
 	// Create the transaction and address indexes if needed.
 	//
 	// CAUTION: the txindex needs to be first in the indexes array because the addrindex uses data from the txindex
@@ -2815,11 +3082,17 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		s.CFIndex = indexers.NewCfIndex(db, cx.ActiveNet)
 		indexes = append(indexes, s.CFIndex)
 	}
-	// Create an index manager if any of the optional indexes are enabled.
-	var indexManager blockchain.IndexManager
-	if len(indexes) > 0 {
-		indexManager = indexers.NewManager(db, indexes)
+	// Analyzers come last, as they are replayed from the genesis block when they are first enabled.
+	for _, name := range cx.Config.Analyzers.S() {
+		var analyzer *indexers.AnalyzerIndex
+		if analyzer, e = indexers.NewAnalyzerIndex(name, cx.ActiveNet); E.Chk(e) {
+			return nil, e
+		}
+		I.Ln(analyzer.Name(), "is enabled")
+		indexes = append(indexes, analyzer)
 	}
+	// Create the index manager, which catches up the enabled indexes in the background.
+	s.IndexManager = indexers.NewManager(db, indexes)
 	// Merge given checkpoints with the default ones unless they are disabled.
 	var checkpoints []chaincfg.Checkpoint
 	if !cx.Config.DisableCheckpoints.True() {
@@ -2827,18 +3100,44 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 			s.ChainParams.Checkpoints, cx.StateCfg.AddedCheckpoints,
 		)
 	}
+	// The scripts of the ancestors of the assumed valid block of the network are not verified, unless another block is
+	// configured, or 0 to verify every script.
+	assumeValid := s.ChainParams.AssumeValid
+	switch av := cx.Config.AssumeValid.V(); av {
+	case "":
+	case "0":
+		assumeValid = nil
+	default:
+		if assumeValid, e = chainhash.NewHashFromStr(av); E.Chk(e) {
+			return nil, e
+		}
+	}
+	if assumeValid != nil {
+		I.Ln("the scripts of the blocks up to", assumeValid, "are assumed valid")
+	} else {
+		I.Ln("no block is assumed valid on", s.ChainParams.Name, "so the scripts of every block are verified")
+	}
+	// Snapshot the chain state next to the block database, unless it is kept in memory.
+	var snapshotPath string
+	if cx.Config.SnapshotInterval.V() > 0 && cx.Config.DbType.V() != "memdb" {
+		snapshotPath = filepath.Join(cx.Config.DataDir.V(), cx.ActiveNet.Name, "chainstate.snapshot")
+	}
 	// Create a new block chain instance with the appropriate configuration.
-	var e error
 	s.Chain, e = blockchain.New(
 		&blockchain.Config{
-			DB:           s.DB,
-			Interrupt:    interruptChan,
-			ChainParams:  s.ChainParams,
-			Checkpoints:  checkpoints,
-			TimeSource:   s.TimeSource,
-			SigCache:     s.SigCache,
-			IndexManager: indexManager,
-			HashCache:    s.HashCache,
+			DB:               s.DB,
+			Interrupt:        interruptChan,
+			ChainParams:      s.ChainParams,
+			Checkpoints:      checkpoints,
+			AssumeValid:      assumeValid,
+			TimeSource:       s.TimeSource,
+			SigCache:         s.SigCache,
+			IndexManager:     s.IndexManager,
+			HashCache:        s.HashCache,
+			ValTrace:         cx.Config.ValTrace.True(),
+			MaxOrphanBlocks:  cx.Config.MaxOrphanBlocks.V(),
+			SnapshotPath:     snapshotPath,
+			SnapshotInterval: int32(cx.Config.SnapshotInterval.V()),
 		},
 	)
 	if e != nil {
@@ -2880,6 +3179,25 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 			mempool.DefaultEstimateFeeMinRegisteredBlocks,
 		)
 	}
+	// Switch on the configured features, then put back the state they were last set to with setfeature.
+	enabled := append([]string{}, cx.Config.Features.S()...)
+	if cx.Config.FeeEstimatorAutoBias.True() {
+		enabled = append(enabled, features.FeeEstimatorAutoBias)
+	}
+	if s.Features, e = features.New(enabled); E.Chk(e) {
+		return nil, e
+	}
+	e = db.View(
+		func(tx database.Tx) (e error) {
+			if data := tx.Metadata().Get(features.DatabaseKey); data != nil {
+				return s.Features.Restore(data)
+			}
+			return nil
+		},
+	)
+	if E.Chk(e) {
+	}
+	s.Features.Watch(features.FeeEstimatorAutoBias, s.FeeEstimator.SetAutoBias)
 	txC := mempool.Config{
 		Policy: mempool.Policy{
 			DisableRelayPriority: cx.Config.NoRelayPriority.True(),
@@ -2888,8 +3206,11 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 			MaxOrphanTxs:         cx.Config.MaxOrphanTxs.V(),
 			MaxOrphanTxSize:      DefaultMaxOrphanTxSize,
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
+			BytesPerSigOp:        cx.Config.BytesPerSigOp.V(),
 			MinRelayTxFee:        cx.StateCfg.ActiveMinRelayTxFee,
+			MaxTxFee:             cx.StateCfg.ActiveMaxTxFee,
 			MaxTxVersion:         2,
+			Expiry:               cx.Config.MempoolExpiry.V(),
 		},
 		ChainParams:   cx.ActiveNet,
 		FetchUtxoView: s.Chain.FetchUtxoView,
@@ -2912,6 +3233,35 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		UpdateHook:   mempoolUpdateHook,
 	}
 	s.TxMemPool = mempool.New(&txC)
+	// Put back the transactions that were in the mempool when the node was last shut down. Those that are no longer
+	// valid, such as ones that were mined or double spent elsewhere in the meantime, are dropped.
+	var savedPool []byte
+	e = db.Update(
+		func(tx database.Tx) (e error) {
+			metadata := tx.Metadata()
+			if data := metadata.Get(mempool.PoolDatabaseKey); data != nil {
+				savedPool = append(savedPool, data...)
+				return metadata.Delete(mempool.PoolDatabaseKey)
+			}
+			return nil
+		},
+	)
+	if E.Chk(e) {
+	}
+	if savedPool != nil {
+		var txs []*util.Tx
+		if txs, e = mempool.RestorePool(savedPool); !E.Chk(e) {
+			var restored int
+			for _, tx := range txs {
+				if _, e = s.TxMemPool.ProcessTransaction(s.Chain, tx, false, false, true, 0); e != nil {
+					D.Ln("dropping saved mempool transaction", tx.Hash(), e)
+					continue
+				}
+				restored++
+			}
+			I.F("restored %d of %d saved mempool transactions", restored, len(txs))
+		}
+	}
 	s.SyncManager, e =
 		netsync.New(
 			&netsync.Config{
@@ -3053,17 +3403,23 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 					ChainParams: cx.ActiveNet,
 					DB:          db,
 					TxMemPool:   s.TxMemPool,
-					// Generator:    blockTemplateGenerator,
+					Generator:       GetBlkTemplateGenerator(&s, cx.Config, cx.StateCfg),
 					// CPUMiner:     s.CPUMiner,
-					TxIndex:         s.TxIndex,
-					AddrIndex:       s.AddrIndex,
-					CfIndex:         s.CFIndex,
-					FeeEstimator:    s.FeeEstimator,
-					Algo:            l,
-					Hashrate:        cx.Hashrate,
-					Quit:            s.Quit,
-					StartController: s.StartController,
-					StopController:  s.StopController,
+					TxIndex:           s.TxIndex,
+					AddrIndex:         s.AddrIndex,
+					CfIndex:           s.CFIndex,
+					IndexManager:      s.IndexManager,
+					FeeEstimator:      s.FeeEstimator,
+					Features:          s.Features,
+					SigCache:          s.SigCache,
+					WorkSource:        s.WorkSource,
+					MiningExpectation: s.MiningExpectation,
+					Reachability:      s.Reachability,
+					Algo:              l,
+					Hashrate:          cx.Hashrate,
+					Quit:              s.Quit,
+					StartController:   s.StartController,
+					StopController:    s.StopController,
 				}, cx.StateCfg, cx.Config,
 			)
 			if e != nil {
@@ -3261,7 +3617,7 @@ func FileExists(name string) bool {
 
 func GetBlkTemplateGenerator(node *Node, cfg *config.Config, stateCfg *active.Config) *mining.BlkTmplGenerator {
 	D.Ln("getting a block template generator")
-	return mining.NewBlkTmplGenerator(
+	g := mining.NewBlkTmplGenerator(
 		&mining.Policy{
 			BlockMinWeight:    uint32(cfg.BlockMinWeight.V()),
 			BlockMaxWeight:    uint32(cfg.BlockMaxWeight.V()),
@@ -3277,4 +3633,27 @@ func GetBlkTemplateGenerator(node *Node, cfg *config.Config, stateCfg *active.Co
 		node.SigCache,
 		node.HashCache,
 	)
+	// On a signed test network the templates are signed with the key of the block producer, if this node is one.
+	if node.ChainParams.SignetChallenge != nil && cfg.SignetKey.V() != "" {
+		key, e := util.DecodeWIF(cfg.SignetKey.V())
+		if E.Chk(e) {
+			return g
+		}
+		g.SignBlock = func(msgBlock *wire.Block) error {
+			return blockchain.SignSignetBlock(msgBlock, node.ChainParams, key)
+		}
+	}
+	return g
+}
+
+// checkClockSkew logs a warning when the local clock goes further than the warning threshold from the time of the
+// network, and when it comes back in line.
+func (n *Node) checkClockSkew() {
+	warning := n.TimeSource.ClockSkew().Warning(n.Config.ClockSkewWarning.V())
+	switch {
+	case warning != "" && !n.ClockSkewed.Swap(true):
+		W.Ln(warning)
+	case warning == "" && n.ClockSkewed.Swap(false):
+		I.Ln("the local clock is back in line with the network time")
+	}
 }
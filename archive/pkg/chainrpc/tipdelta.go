@@ -0,0 +1,115 @@
+package chainrpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/aead/siphash"
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Clients registered with notifytipdeltas are sent a tipdelta notification whenever a block is connected to the main
+// chain, carrying its header, its coinbase and the short ids of the rest of its transactions, in the manner of the
+// compact blocks of BIP 152. A template builder can work out the short ids of the transactions in its mempool with the
+// key of the notification, drop those that were mined and build on the new tip right away, and only needs to fetch the
+// block when a short id matches none of them. A fresh nonce is drawn for each block so that transactions can not be
+// crafted ahead of time to collide with the short ids of others.
+
+// shortIDMask keeps the lower 6 bytes of a SipHash, which is the length of a short id.
+const shortIDMask = 1<<48 - 1
+
+// ShortIDKey returns the SipHash key of the short ids of a tipdelta notification, which is the first 16 bytes of the
+// SHA256 of the serialized header followed by the nonce in little endian.
+func ShortIDKey(header *wire.BlockHeader, nonce uint64) (key [16]byte, e error) {
+	var w bytes.Buffer
+	if e = header.Serialize(&w); E.Chk(e) {
+		return
+	}
+	var n [8]byte
+	binary.LittleEndian.PutUint64(n[:], nonce)
+	w.Write(n[:])
+	sum := sha256.Sum256(w.Bytes())
+	copy(key[:], sum[:16])
+	return
+}
+
+// ShortTxID returns the short id of a transaction for the key of a tipdelta notification.
+func ShortTxID(key *[16]byte, txid *chainhash.Hash) uint64 {
+	return siphash.Sum64(txid[:], key) & shortIDMask
+}
+
+// FormatShortTxID returns a short id as it is written in a tipdelta notification.
+func FormatShortTxID(id uint64) string {
+	return fmt.Sprintf("%012x", id)
+}
+
+// NewTipDelta returns the tipdelta of a block that has become the new tip of the main chain, keyed with the nonce.
+func NewTipDelta(blk *block.Block, nonce uint64) (delta btcjson.TipDeltaResult, e error) {
+	header := &blk.WireBlock().Header
+	var key [16]byte
+	if key, e = ShortIDKey(header, nonce); E.Chk(e) {
+		return
+	}
+	var w bytes.Buffer
+	if e = header.Serialize(&w); E.Chk(e) {
+		return
+	}
+	txs := blk.Transactions()
+	delta = btcjson.TipDeltaResult{
+		Hash:   blk.Hash().String(),
+		Height: blk.Height(),
+		Header: hex.EncodeToString(w.Bytes()),
+		Nonce:  nonce,
+	}
+	if len(txs) == 0 {
+		return
+	}
+	delta.Coinbase = TxHexString(txs[0].MsgTx())
+	delta.ShortIDs = make([]string, 0, len(txs)-1)
+	for _, tx := range txs[1:] {
+		delta.ShortIDs = append(delta.ShortIDs, FormatShortTxID(ShortTxID(&key, tx.Hash())))
+	}
+	return
+}
+
+// NotifyTipDelta notifies websocket clients that have registered for tip deltas when a block is connected to the main
+// chain.
+func (m *WSNtfnMgr) NotifyTipDelta(clients map[qu.C]*WSClient, blk *block.Block) {
+	if len(clients) == 0 {
+		return
+	}
+	nonce, e := wire.RandomUint64()
+	if E.Chk(e) {
+		return
+	}
+	var delta btcjson.TipDeltaResult
+	if delta, e = NewTipDelta(blk, nonce); E.Chk(e) {
+		return
+	}
+	payload := m.Payload(btcjson.NewTipDeltaNtfn(delta))
+	for _, wsc := range clients {
+		if e = wsc.QueuePayload(payload); e != nil {
+			D.Ln(e)
+		}
+	}
+}
+
+// HandleNotifyTipDeltas implements the notifytipdeltas command extension for websocket connections.
+func HandleNotifyTipDeltas(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.RegisterTipDeltaUpdates(wsc)
+	return nil, nil
+}
+
+// HandleStopNotifyTipDeltas implements the stopnotifytipdeltas command extension for websocket connections.
+func HandleStopNotifyTipDeltas(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.UnregisterTipDeltaUpdates(wsc)
+	return nil, nil
+}
@@ -0,0 +1,66 @@
+package chainrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestNewTipDelta ensures a tip delta carries the header and coinbase of the block and the short ids of the rest of its
+// transactions in order, so that a template builder working them out with the same key finds the same ids.
+func TestNewTipDelta(t *testing.T) {
+	msgBlock := &wire.Block{
+		Header: wire.BlockHeader{Version: 2, Bits: 0x207fffff, Timestamp: time.Unix(1600000000, 0), Nonce: 1},
+	}
+	for i := 0; i < 3; i++ {
+		tx := wire.NewMsgTx(1)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: uint32(i)}, []byte{byte(i)}, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(i), nil))
+		if e := msgBlock.AddTransaction(tx); e != nil {
+			t.Fatal(e)
+		}
+	}
+	blk := block.NewBlock(msgBlock)
+	blk.SetHeight(100)
+	delta, e := NewTipDelta(blk, 7)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var w bytes.Buffer
+	if e = msgBlock.Header.Serialize(&w); e != nil {
+		t.Fatal(e)
+	}
+	if delta.Height != 100 || delta.Nonce != 7 || delta.Hash != blk.Hash().String() ||
+		delta.Header != hex.EncodeToString(w.Bytes()) || delta.Coinbase != TxHexString(msgBlock.Transactions[0]) {
+		t.Fatalf("unexpected tip delta %+v", delta)
+	}
+	key, e := ShortIDKey(&msgBlock.Header, 7)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(delta.ShortIDs) != 2 {
+		t.Fatalf("%d short ids, want 2", len(delta.ShortIDs))
+	}
+	for i, tx := range msgBlock.Transactions[1:] {
+		txid := tx.TxHash()
+		id := ShortTxID(&key, &txid)
+		if id > shortIDMask {
+			t.Errorf("short id %x is longer than 6 bytes", id)
+		}
+		if want := FormatShortTxID(id); delta.ShortIDs[i] != want {
+			t.Errorf("short id %d is %s, want %s", i, delta.ShortIDs[i], want)
+		}
+	}
+	// a different nonce keys the short ids differently
+	other, e := ShortIDKey(&msgBlock.Header, 8)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if other == key {
+		t.Error("the short id key does not depend on the nonce")
+	}
+}
@@ -0,0 +1,128 @@
+package chainrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// LocalWorkSource is the name the mining controller reports for the node it runs in when it is taking its work from it.
+const LocalWorkSource = "local"
+
+// WorkSourceStatus holds which node the mining controller is taking its work from, and the health of the nodes it can
+// fail over to, as last reported by the controller, for getworksource.
+type WorkSourceStatus struct {
+	mtx    sync.Mutex
+	result btcjson.GetWorkSourceResult
+}
+
+// NewWorkSourceStatus returns a status with work taken from the local node, as it is until the controller reports.
+func NewWorkSourceStatus() *WorkSourceStatus {
+	return &WorkSourceStatus{
+		result: btcjson.GetWorkSourceResult{
+			Active: LocalWorkSource,
+			Since:  time.Now().Unix(),
+		},
+	}
+}
+
+// Set stores the active source of work, the time work started being taken from it, and the health of all the sources.
+func (w *WorkSourceStatus) Set(active string, since time.Time, sources []btcjson.WorkSourceResult) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.result = btcjson.GetWorkSourceResult{
+		Active:  active,
+		Since:   since.Unix(),
+		Sources: sources,
+	}
+}
+
+// Result returns a copy of the status as the result of getworksource.
+func (w *WorkSourceStatus) Result() (result btcjson.GetWorkSourceResult) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	result = w.result
+	result.Sources = append([]btcjson.WorkSourceResult(nil), w.result.Sources...)
+	return
+}
+
+// HandleGetMiningTemplate implements the getminingtemplate command, which returns a block to mine for each of the
+// algorithms, paying to the given address, so a mining controller whose own node has stalled can take its work from
+// this one.
+func HandleGetMiningTemplate(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c, ok := cmd.(*btcjson.GetMiningTemplateCmd)
+	if !ok {
+		var h string
+		var e error
+		var msg string
+		h, e = s.HelpCacher.RPCMethodHelp("getminingtemplate")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	addrs, e := DecodeMiningAddrs([]string{c.Address}, s.Cfg.ChainParams)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: e.Error(),
+		}
+	}
+	// Work from a node that is behind would be wasted, which is what the controller is failing over to avoid.
+	if !s.Cfg.SyncMgr.IsCurrent() {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCClientInInitialDownload,
+			Message: "Pod is not yet synchronised...",
+		}
+	}
+	if s.Cfg.Generator == nil {
+		return nil, InternalRPCError("no block template generator", "")
+	}
+	best := s.Cfg.Chain.BestSnapshot()
+	result := btcjson.GetMiningTemplateResult{
+		Height:       best.Height + 1,
+		PreviousHash: best.Hash.String(),
+	}
+	for next, curr, more := fork.AlgoVerIterator(result.Height); more(); next() {
+		algo := fork.GetAlgoName(curr(), result.Height)
+		tpl, e := s.Cfg.Generator.NewBlockTemplate(addrs[0], algo)
+		if D.Chk(e) || tpl == nil {
+			continue
+		}
+		// A new block may have been connected while the templates were being made, and they must all build on the
+		// same one.
+		if tpl.Block.Header.PrevBlock != best.Hash {
+			return nil, InternalRPCError("the best block changed while making the templates", "")
+		}
+		var buf bytes.Buffer
+		if e = tpl.Block.Serialize(&buf); E.Chk(e) {
+			return nil, InternalRPCError(e.Error(), "")
+		}
+		result.Blocks = append(
+			result.Blocks, btcjson.MiningTemplateBlockResult{
+				Version: curr(),
+				Algo:    algo,
+				Hex:     hex.EncodeToString(buf.Bytes()),
+			},
+		)
+	}
+	if len(result.Blocks) == 0 {
+		return nil, InternalRPCError("no block templates could be made", "")
+	}
+	return result, nil
+}
+
+// HandleGetWorkSource implements the getworksource command.
+func HandleGetWorkSource(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	return s.Cfg.WorkSource.Result(), nil
+}
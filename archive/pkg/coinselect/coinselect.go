@@ -0,0 +1,128 @@
+package coinselect
+
+import (
+	"sort"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Coin is an unspent output a transaction can spend.
+type Coin struct {
+	OutPoint wire.OutPoint
+	Amount   amt.Amount
+	PkScript []byte
+	// Height is the height of the block the output was mined in, or -1 if it is not mined.
+	Height int32
+}
+
+// Strategy returns an input source for txauthor.NewUnsignedTransaction that spends from the coins. The input source is
+// called with increasing targets as the fee grows with the inputs, and the inputs it returns each time replace those
+// it returned before.
+type Strategy func(coins []Coin) txauthor.InputSource
+
+// Strategies are the coin selection strategies by name.
+var Strategies = map[string]Strategy{
+	"largestfirst":       LargestFirst,
+	"oldestfirst":        OldestFirst,
+	"smallestfirst":      SmallestFirst,
+	"smallestsufficient": SmallestSufficient,
+}
+
+// StrategyNames returns the names of the strategies in alphabetical order.
+func StrategyNames() (names []string) {
+	for name := range Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// LargestFirst spends the largest coins first, which makes payments with the fewest inputs but leaves the small coins
+// to pile up. It is the strategy the wallet has always used.
+func LargestFirst(coins []Coin) txauthor.InputSource {
+	return inOrder(
+		sorted(
+			coins, func(a, b *Coin) bool {
+				return a.Amount > b.Amount
+			},
+		),
+	)
+}
+
+// SmallestFirst spends the smallest coins first, which keeps the number of coins down at the cost of larger
+// transactions.
+func SmallestFirst(coins []Coin) txauthor.InputSource {
+	return inOrder(
+		sorted(
+			coins, func(a, b *Coin) bool {
+				return a.Amount < b.Amount
+			},
+		),
+	)
+}
+
+// OldestFirst spends the coins mined longest ago first, and unmined coins last.
+func OldestFirst(coins []Coin) txauthor.InputSource {
+	return inOrder(
+		sorted(
+			coins, func(a, b *Coin) bool {
+				if a.Height < 0 || b.Height < 0 {
+					return b.Height < 0 && a.Height >= 0
+				}
+				return a.Height < b.Height
+			},
+		),
+	)
+}
+
+// SmallestSufficient spends the smallest coin that pays for the target on its own, so as few large coins as possible
+// are broken into change, and spends the largest coins first when no coin is enough.
+func SmallestSufficient(coins []Coin) txauthor.InputSource {
+	byAmount := sorted(
+		coins, func(a, b *Coin) bool {
+			return a.Amount < b.Amount
+		},
+	)
+	largestFirst := LargestFirst(coins)
+	return func(target amt.Amount) (amt.Amount, []*wire.TxIn, []amt.Amount, [][]byte, error) {
+		i := sort.Search(
+			len(byAmount), func(i int) bool {
+				return byAmount[i].Amount >= target
+			},
+		)
+		if i == len(byAmount) {
+			return largestFirst(target)
+		}
+		return inOrder(byAmount[i : i+1])(target)
+	}
+}
+
+// sorted returns a copy of the coins sorted by less, keeping the order of coins neither is less than.
+func sorted(coins []Coin, less func(a, b *Coin) bool) []Coin {
+	s := make([]Coin, len(coins))
+	copy(s, coins)
+	sort.SliceStable(
+		s, func(i, j int) bool {
+			return less(&s[i], &s[j])
+		},
+	)
+	return s
+}
+
+// inOrder returns an input source spending the coins in the order they are in until the target is met.
+func inOrder(coins []Coin) txauthor.InputSource {
+	return func(target amt.Amount) (
+		total amt.Amount, inputs []*wire.TxIn, values []amt.Amount, scripts [][]byte, e error,
+	) {
+		for i := 0; total < target && i < len(coins); i++ {
+			c := &coins[i]
+			total += c.Amount
+			inputs = append(inputs, wire.NewTxIn(&c.OutPoint, nil, nil))
+			values = append(values, c.Amount)
+			scripts = append(scripts, c.PkScript)
+		}
+		return
+	}
+}
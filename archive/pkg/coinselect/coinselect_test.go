@@ -0,0 +1,80 @@
+package coinselect
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestStrategies ensures each strategy spends the coins it is meant to for a target, and returns less than the target
+// when the coins are not enough.
+func TestStrategies(t *testing.T) {
+	amounts := []amt.Amount{300, 100, 500, 200}
+	heights := []int32{7, -1, 9, 3}
+	coins := make([]Coin, len(amounts))
+	for i := range coins {
+		coins[i] = Coin{
+			OutPoint: wire.OutPoint{Hash: chainhash.Hash{byte(i)}},
+			Amount:   amounts[i],
+			Height:   heights[i],
+		}
+	}
+	tests := []struct {
+		name   string
+		target amt.Amount
+		want   []amt.Amount
+	}{
+		{"largestfirst", 600, []amt.Amount{500, 300}},
+		{"smallestfirst", 250, []amt.Amount{100, 200}},
+		{"oldestfirst", 400, []amt.Amount{200, 300}},
+		{"oldestfirst", 1050, []amt.Amount{200, 300, 500, 100}},
+		{"smallestsufficient", 250, []amt.Amount{300}},
+		{"smallestsufficient", 500, []amt.Amount{500}},
+		{"smallestsufficient", 700, []amt.Amount{500, 300}},
+	}
+	for _, test := range tests {
+		total, inputs, values, _, e := Strategies[test.name](coins)(test.target)
+		if e != nil {
+			t.Fatalf("%s %d: %v", test.name, test.target, e)
+		}
+		if len(values) != len(test.want) || len(inputs) != len(values) {
+			t.Errorf("%s %d: spent %v, want %v", test.name, test.target, values, test.want)
+			continue
+		}
+		var sum amt.Amount
+		for i, v := range values {
+			if v != test.want[i] {
+				t.Errorf("%s %d: spent %v, want %v", test.name, test.target, values, test.want)
+				break
+			}
+			if inputs[i].PreviousOutPoint != coins[amountIndex(amounts, v)].OutPoint {
+				t.Errorf("%s %d: input %d spends the wrong coin", test.name, test.target, i)
+			}
+			sum += v
+		}
+		if total != sum {
+			t.Errorf("%s %d: total %d, want %d", test.name, test.target, total, sum)
+		}
+	}
+	for _, name := range StrategyNames() {
+		if total, _, _, _, _ := Strategies[name](coins)(1101); total >= 1101 {
+			t.Errorf("%s: total %d of the coins is more than they are worth", name, total)
+		}
+	}
+	// The coins passed to a strategy are left in their order.
+	if coins[0].Amount != 300 || coins[1].Amount != 100 {
+		t.Errorf("coins were reordered to %v", coins)
+	}
+}
+
+// amountIndex returns the index of the amount in amounts.
+func amountIndex(amounts []amt.Amount, v amt.Amount) int {
+	for i := range amounts {
+		if amounts[i] == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,11 @@
+// Package coinselect chooses the unspent outputs a wallet spends to make a payment, with the strategies a wallet can
+// choose between, and replays recorded wallet UTXO sets and streams of payments against them.
+//
+// A strategy is judged by more than whether it finds the funds for each payment: one that spends few inputs pays low
+// fees now but leaves small outputs to pile up, which later payments pay to spend or which are never worth spending at
+// all. Simulate replays a Scenario, the UTXO set of a wallet and the payments it made and received afterwards, against
+// a strategy and reports the fees paid, how the number of UTXOs grew and how many payments failed, so the strategies
+// can be compared on data from the wallets of this chain before one of them is made the default.
+//
+// Scenarios are kept as JSON, and the coinsim command in cmd/misc replays them against each strategy.
+package coinselect
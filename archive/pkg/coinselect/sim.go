@@ -0,0 +1,211 @@
+package coinselect
+
+import (
+	"encoding/binary"
+	js "encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txauthor"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Scenario is a recorded UTXO set of a wallet and the stream of payments it made and received afterwards, which
+// Simulate replays against a strategy. Amounts are in satoshis.
+type Scenario struct {
+	Name string `json:"name"`
+	// FeeRate is the fee rate in satoshis per kB that payments pay until an event changes it.
+	FeeRate int64 `json:"feerate"`
+	// UTXOs are the amounts of the unspent outputs the wallet starts with.
+	UTXOs []int64 `json:"utxos"`
+	// Events are the payments, in the order they were made.
+	Events []Event `json:"events"`
+}
+
+// Event is a payment made or received by the wallet, or a change of the fee rate. Exactly one of its fields is set.
+type Event struct {
+	Send    int64 `json:"send,omitempty"`
+	Receive int64 `json:"receive,omitempty"`
+	FeeRate int64 `json:"feerate,omitempty"`
+}
+
+// Report is what replaying a scenario against a strategy came to.
+type Report struct {
+	Strategy string `json:"strategy"`
+	Scenario string `json:"scenario"`
+	// Sends is the number of payments made by the wallet that were attempted, and Failures the number of them the
+	// strategy could not find the funds for.
+	Sends    int `json:"sends"`
+	Failures int `json:"failures"`
+	// Sent is the value of the payments that were made.
+	Sent amt.Amount `json:"sent"`
+	// Fees is the total fee paid, including change too small to be worth an output.
+	Fees amt.Amount `json:"fees"`
+	// Inputs is the number of coins spent, and Change the number of change outputs made.
+	Inputs int `json:"inputs"`
+	Change int `json:"change"`
+	// UTXOs is the number of coins the wallet had after each event.
+	UTXOs []int `json:"utxos"`
+	// MaxUTXOs is the most coins the wallet had at once, and FinalUTXOs the number it had at the end.
+	MaxUTXOs   int `json:"maxutxos"`
+	FinalUTXOs int `json:"finalutxos"`
+	// Balance is the value of the coins at the end.
+	Balance amt.Amount `json:"balance"`
+}
+
+// FailureRate returns the share of the payments attempted that failed.
+func (r *Report) FailureRate() float64 {
+	if r.Sends == 0 {
+		return 0
+	}
+	return float64(r.Failures) / float64(r.Sends)
+}
+
+// payScript is the output script of the payments made in a simulation, which pays to a pay to public key hash address,
+// as does change.
+var payScript, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).
+	AddData(make([]byte, 20)).AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+
+// LoadScenario reads a scenario from a JSON file.
+func LoadScenario(path string) (s *Scenario, e error) {
+	var b []byte
+	if b, e = ioutil.ReadFile(path); E.Chk(e) {
+		return
+	}
+	s = &Scenario{}
+	if e = js.Unmarshal(b, s); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// check returns an error if the scenario has amounts that are not positive or events that are not one thing.
+func (s *Scenario) check() error {
+	if s.FeeRate < 0 {
+		return fmt.Errorf("scenario %s has a negative fee rate", s.Name)
+	}
+	for i, v := range s.UTXOs {
+		if v <= 0 {
+			return fmt.Errorf("UTXO %d of scenario %s has an amount of %d", i, s.Name, v)
+		}
+	}
+	for i, ev := range s.Events {
+		set := 0
+		for _, v := range []int64{ev.Send, ev.Receive, ev.FeeRate} {
+			if v < 0 {
+				return fmt.Errorf("event %d of scenario %s has a negative amount", i, s.Name)
+			}
+			if v != 0 {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("event %d of scenario %s is not one send, receive or fee rate", i, s.Name)
+		}
+	}
+	return nil
+}
+
+// Simulate replays the scenario against the strategy. A payment the strategy can't find the funds for is counted as a
+// failure and leaves the coins as they were. Coins received and made as change are mined before the next event, so
+// each payment can spend any coin the wallet has.
+func Simulate(s *Scenario, name string, strategy Strategy) (r *Report, e error) {
+	if e = s.check(); E.Chk(e) {
+		return
+	}
+	r = &Report{Strategy: name, Scenario: s.Name, UTXOs: make([]int, 0, len(s.Events))}
+	var n uint64
+	// newCoin returns a coin with an outpoint of its own, mined at the height.
+	newCoin := func(v int64, height int32) Coin {
+		n++
+		var hash chainhash.Hash
+		binary.LittleEndian.PutUint64(hash[:], n)
+		return Coin{OutPoint: wire.OutPoint{Hash: hash}, Amount: amt.Amount(v), PkScript: payScript, Height: height}
+	}
+	coins := make([]Coin, 0, len(s.UTXOs))
+	for _, v := range s.UTXOs {
+		coins = append(coins, newCoin(v, 0))
+	}
+	feeRate := amt.Amount(s.FeeRate)
+	changeSource := func() ([]byte, error) {
+		return payScript, nil
+	}
+	for i, ev := range s.Events {
+		height := int32(i + 1)
+		switch {
+		case ev.FeeRate != 0:
+			feeRate = amt.Amount(ev.FeeRate)
+		case ev.Receive != 0:
+			coins = append(coins, newCoin(ev.Receive, height))
+		default:
+			r.Sends++
+			var tx *txauthor.AuthoredTx
+			outputs := []*wire.TxOut{wire.NewTxOut(ev.Send, payScript)}
+			if tx, e = txauthor.NewUnsignedTransaction(outputs, feeRate, strategy(coins), changeSource); e != nil {
+				if _, ok := e.(txauthor.InputSourceError); !ok {
+					return nil, e
+				}
+				e = nil
+				r.Failures++
+				break
+			}
+			spent := make(map[wire.OutPoint]struct{}, len(tx.Tx.TxIn))
+			for _, in := range tx.Tx.TxIn {
+				spent[in.PreviousOutPoint] = struct{}{}
+			}
+			unspent := coins[:0]
+			for _, c := range coins {
+				if _, ok := spent[c.OutPoint]; !ok {
+					unspent = append(unspent, c)
+				}
+			}
+			coins = unspent
+			var out amt.Amount
+			for _, o := range tx.Tx.TxOut {
+				out += amt.Amount(o.Value)
+			}
+			r.Sent += amt.Amount(ev.Send)
+			r.Fees += tx.TotalInput - out
+			r.Inputs += len(tx.Tx.TxIn)
+			if tx.ChangeIndex >= 0 {
+				r.Change++
+				coins = append(coins, newCoin(tx.Tx.TxOut[tx.ChangeIndex].Value, height))
+			}
+		}
+		r.UTXOs = append(r.UTXOs, len(coins))
+		if len(coins) > r.MaxUTXOs {
+			r.MaxUTXOs = len(coins)
+		}
+	}
+	if len(s.UTXOs) > r.MaxUTXOs {
+		r.MaxUTXOs = len(s.UTXOs)
+	}
+	r.FinalUTXOs = len(coins)
+	for _, c := range coins {
+		r.Balance += c.Amount
+	}
+	return
+}
+
+// Compare replays the scenario against each of the named strategies, or all of them if names is empty, and returns the
+// reports in the same order.
+func Compare(s *Scenario, names ...string) (reports []*Report, e error) {
+	if len(names) == 0 {
+		names = StrategyNames()
+	}
+	for _, name := range names {
+		strategy, ok := Strategies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown coin selection strategy %s", name)
+		}
+		var r *Report
+		if r, e = Simulate(s, name, strategy); E.Chk(e) {
+			return nil, e
+		}
+		reports = append(reports, r)
+	}
+	return
+}
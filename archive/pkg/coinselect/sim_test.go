@@ -0,0 +1,66 @@
+package coinselect
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+)
+
+// TestSimulate replays the recorded scenario against every strategy and ensures the value of the coins is accounted
+// for by the payments and fees, and that the payment the wallet has too little for fails with every strategy.
+func TestSimulate(t *testing.T) {
+	s, e := LoadScenario(filepath.Join("testdata", "payments.json"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	reports, e := Compare(s)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(reports) != len(Strategies) {
+		t.Fatalf("got %d reports for %d strategies", len(reports), len(Strategies))
+	}
+	var start, received amt.Amount
+	for _, v := range s.UTXOs {
+		start += amt.Amount(v)
+	}
+	for _, ev := range s.Events {
+		received += amt.Amount(ev.Receive)
+	}
+	for _, r := range reports {
+		if r.Failures < 1 {
+			t.Errorf("%s: the payment of more than the wallet has did not fail", r.Strategy)
+		}
+		if len(r.UTXOs) != len(s.Events) || r.UTXOs[len(r.UTXOs)-1] != r.FinalUTXOs {
+			t.Errorf("%s: UTXO counts %v do not end with %d", r.Strategy, r.UTXOs, r.FinalUTXOs)
+		}
+		if start+received-r.Sent-r.Fees != r.Balance {
+			t.Errorf(
+				"%s: %d + %d received - %d sent - %d fees is not the balance of %d", r.Strategy, start, received,
+				r.Sent, r.Fees, r.Balance,
+			)
+		}
+		if r.Fees <= 0 || r.Inputs < r.Sends-r.Failures {
+			t.Errorf("%s: %d inputs paid %d fees for %d payments", r.Strategy, r.Inputs, r.Fees, r.Sends-r.Failures)
+		}
+	}
+	// A strategy spending the smallest coins first ends with no more coins than one spending the largest first.
+	byName := make(map[string]*Report)
+	for _, r := range reports {
+		byName[r.Strategy] = r
+	}
+	if byName["smallestfirst"].FinalUTXOs > byName["largestfirst"].FinalUTXOs {
+		t.Errorf(
+			"smallest first ends with %d coins, largest first with %d", byName["smallestfirst"].FinalUTXOs,
+			byName["largestfirst"].FinalUTXOs,
+		)
+	}
+	if _, e = Compare(s, "nosuchstrategy"); e == nil {
+		t.Error("an unknown strategy was compared")
+	}
+	s.Events = append(s.Events, Event{Send: 1, Receive: 1})
+	if _, e = Simulate(s, "largestfirst", LargestFirst); e == nil {
+		t.Error("a scenario with an event that is both a send and a receive was replayed")
+	}
+}
@@ -0,0 +1,198 @@
+package connmgr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The paths a host name can be resolved by.
+const (
+	// ResolveDirect is a lookup made with the system resolver, which anyone watching the network of the node can see.
+	ResolveDirect = "direct"
+	// ResolveProxy is a lookup made through the SOCKS proxy, which only the exit of the proxy sees.
+	ResolveProxy = "proxy"
+	// ResolveLocal is a lookup of a name of the machine itself, such as localhost, which never leaves it.
+	ResolveLocal = "local"
+	// ResolveRefused is a direct lookup that was refused because only lookups through the proxy are allowed.
+	ResolveRefused = "refused"
+)
+
+// The purposes host names are resolved for.
+const (
+	// PurposeDNSSeed is the lookup of a DNS seed for addresses of peers.
+	PurposeDNSSeed = "dnsseed"
+	// PurposePeer is the lookup of the host name of a peer added with addnode, connect or addpeer.
+	PurposePeer = "peer"
+	// PurposeRPCConnect is the lookup of the host name of the RPC server an RPC client connects to.
+	PurposeRPCConnect = "rpcconnect"
+	// PurposeOther is a lookup made for any other reason.
+	PurposeOther = "other"
+)
+
+// ErrDirectLookup is returned for a host name that would be resolved with the system resolver when only lookups
+// through the proxy are allowed.
+var ErrDirectLookup = errors.New("direct DNS lookup refused while only resolving through the proxy")
+
+// Resolver resolves host names by the path the configuration of the node allows, through the SOCKS proxy when there
+// is one that can resolve names, and keeps a record of how each host name was resolved so leaks of the names the node
+// looks up to the local network can be audited.
+type Resolver struct {
+	// Direct resolves names with the system resolver.
+	Direct LookupFunc
+	// Proxied resolves names through the proxy, it is nil when there is no proxy that can resolve names.
+	Proxied LookupFunc
+	// Proxy is the address of the proxy names are resolved through.
+	Proxy string
+	// ProxyOnly refuses every lookup that would be made with the system resolver.
+	ProxyOnly bool
+	mx        sync.Mutex
+	hosts     map[string]*Resolution
+}
+
+// Resolution is the record of how a host name was resolved.
+type Resolution struct {
+	Host string
+	// Purposes are the purposes the host name was resolved for, in alphabetical order.
+	Purposes []string
+	// Direct, Proxied, Local and Refused are the number of lookups of the host name made by each path.
+	Direct   int
+	Proxied  int
+	Local    int
+	Refused  int
+	Failures int
+	// LastPath is the path of the latest lookup, made at LastLookup, and LastError its error, if it failed.
+	LastPath   string
+	LastLookup time.Time
+	LastError  string
+}
+
+// NewResolver returns a resolver that resolves names through the SOCKS proxy at proxy, or with the system resolver
+// when proxy is empty, unless proxyOnly is set, in which case it refuses to.
+func NewResolver(proxy string, proxyOnly bool) *Resolver {
+	r := &Resolver{Direct: net.LookupIP, Proxy: proxy, ProxyOnly: proxyOnly}
+	if proxy != "" {
+		r.Proxied = func(host string) ([]net.IP, error) {
+			return TorLookupIP(host, proxy)
+		}
+	}
+	return r
+}
+
+// Lookup returns the lookup function resolving names for the purpose.
+func (r *Resolver) Lookup(purpose string) LookupFunc {
+	return func(host string) (ips []net.IP, e error) {
+		if ip := net.ParseIP(host); ip != nil {
+			return []net.IP{ip}, nil
+		}
+		path := r.Path(host, r.Proxied != nil)
+		switch path {
+		case ResolveProxy:
+			ips, e = r.Proxied(host)
+		case ResolveRefused:
+			e = fmt.Errorf("%v: %s", ErrDirectLookup, host)
+		default:
+			ips, e = r.Direct(host)
+		}
+		r.record(purpose, host, path, e)
+		return
+	}
+}
+
+// Path returns the path a name is resolved by when it is resolved through the proxy if proxied is true, and with the
+// system resolver if not.
+func (r *Resolver) Path(host string, proxied bool) string {
+	switch {
+	case IsLocalName(host):
+		return ResolveLocal
+	case proxied:
+		return ResolveProxy
+	case r.ProxyOnly:
+		return ResolveRefused
+	}
+	return ResolveDirect
+}
+
+// Route records the path of a host name resolved by a client that makes its own lookups, such as an RPC client that
+// resolves the name of its server through the proxy it connects with if proxied is true. It returns ErrDirectLookup
+// if the name would be resolved with the system resolver and only lookups through the proxy are allowed.
+func (r *Resolver) Route(purpose, host string, proxied bool) (e error) {
+	if net.ParseIP(host) != nil {
+		return
+	}
+	path := r.Path(host, proxied)
+	if path == ResolveRefused {
+		e = fmt.Errorf("%v: %s", ErrDirectLookup, host)
+	}
+	r.record(purpose, host, path, e)
+	return
+}
+
+// Resolutions returns the records of the host names resolved, in the order of their names.
+func (r *Resolver) Resolutions() (res []Resolution) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	res = make([]Resolution, 0, len(r.hosts))
+	for _, rr := range r.hosts {
+		c := *rr
+		c.Purposes = append([]string(nil), rr.Purposes...)
+		res = append(res, c)
+	}
+	sort.Slice(
+		res, func(i, j int) bool {
+			return res[i].Host < res[j].Host
+		},
+	)
+	return
+}
+
+// record adds a lookup of the host name by the path to its record.
+func (r *Resolver) record(purpose, host, path string, e error) {
+	if purpose == "" {
+		purpose = PurposeOther
+	}
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.hosts == nil {
+		r.hosts = make(map[string]*Resolution)
+	}
+	host = strings.ToLower(host)
+	rr, ok := r.hosts[host]
+	if !ok {
+		rr = &Resolution{Host: host}
+		r.hosts[host] = rr
+	}
+	i := sort.SearchStrings(rr.Purposes, purpose)
+	if i == len(rr.Purposes) || rr.Purposes[i] != purpose {
+		rr.Purposes = append(rr.Purposes, "")
+		copy(rr.Purposes[i+1:], rr.Purposes[i:])
+		rr.Purposes[i] = purpose
+	}
+	switch path {
+	case ResolveDirect:
+		rr.Direct++
+	case ResolveProxy:
+		rr.Proxied++
+	case ResolveLocal:
+		rr.Local++
+	case ResolveRefused:
+		rr.Refused++
+		W.F("refused to resolve %s for %s with the system resolver", host, purpose)
+	}
+	rr.LastPath, rr.LastLookup, rr.LastError = path, time.Now(), ""
+	if e != nil {
+		rr.Failures++
+		rr.LastError = e.Error()
+	}
+}
+
+// IsLocalName returns true if the host name is a name of the machine itself, which is resolved without asking any
+// name server.
+func IsLocalName(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host == "localhost" || strings.HasSuffix(host, ".localhost")
+}
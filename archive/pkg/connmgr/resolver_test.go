@@ -0,0 +1,81 @@
+package connmgr
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+// TestResolver ensures names are resolved through the proxy when there is one, that direct lookups are refused when
+// only lookups through the proxy are allowed, and that every lookup is recorded by host name.
+func TestResolver(t *testing.T) {
+	var direct, proxied int
+	r := &Resolver{
+		Direct: func(string) ([]net.IP, error) {
+			direct++
+			return []net.IP{net.IPv4(127, 0, 0, 1)}, nil
+		},
+	}
+	resolveProxied := func(host string) ([]net.IP, error) {
+		proxied++
+		if host == "bad.example" {
+			return nil, errors.New("tor host is unreachable")
+		}
+		return []net.IP{net.IPv4(10, 0, 0, 1)}, nil
+	}
+	if _, e := r.Lookup(PurposeDNSSeed)("seed.example"); e != nil || direct != 1 {
+		t.Fatalf("lookup without a proxy made %d direct lookups, %v", direct, e)
+	}
+	if ips, e := r.Lookup(PurposePeer)("10.1.2.3"); e != nil || len(ips) != 1 || direct != 1 {
+		t.Fatalf("an IP address was looked up, or returned as %v, %v", ips, e)
+	}
+	r.Proxied, r.ProxyOnly = resolveProxied, true
+	if _, e := r.Lookup(PurposePeer)("Seed.example"); e != nil || proxied != 1 {
+		t.Fatalf("lookup with a proxy made %d proxied lookups, %v", proxied, e)
+	}
+	if _, e := r.Lookup(PurposePeer)("bad.example"); e == nil {
+		t.Fatal("the error of a proxied lookup was not returned")
+	}
+	if _, e := r.Lookup("")("localhost"); e != nil || direct != 2 {
+		t.Fatalf("localhost was not resolved locally, %v", e)
+	}
+	r.Proxied = nil
+	if _, e := r.Lookup(PurposeDNSSeed)("seed.example"); e == nil || direct != 2 {
+		t.Fatal("a direct lookup was made while only lookups through the proxy are allowed")
+	}
+	if e := r.Route(PurposeRPCConnect, "node.example", false); e == nil {
+		t.Fatal("an RPC client was let resolve its server directly while only lookups through the proxy are allowed")
+	}
+	if e := r.Route(PurposeRPCConnect, "node.example", true); e != nil {
+		t.Fatal(e)
+	}
+	if e := r.Route(PurposeRPCConnect, "127.0.0.1", false); e != nil {
+		t.Fatal(e)
+	}
+	res := r.Resolutions()
+	if len(res) != 4 {
+		t.Fatalf("got %d resolutions, want 4: %+v", len(res), res)
+	}
+	want := []Resolution{
+		{Host: "bad.example", Proxied: 1, Failures: 1, LastPath: ResolveProxy},
+		{Host: "localhost", Local: 1, LastPath: ResolveLocal},
+		{Host: "node.example", Proxied: 1, Refused: 1, Failures: 1, LastPath: ResolveProxy},
+		{Host: "seed.example", Direct: 1, Proxied: 1, Refused: 1, Failures: 1, LastPath: ResolveRefused},
+	}
+	for i, w := range want {
+		g := res[i]
+		if g.Host != w.Host || g.Direct != w.Direct || g.Proxied != w.Proxied || g.Local != w.Local ||
+			g.Refused != w.Refused || g.Failures != w.Failures || g.LastPath != w.LastPath {
+			t.Errorf("got resolution %+v, want %+v", g, w)
+		}
+	}
+	if p := res[3].Purposes; len(p) != 2 || p[0] != PurposeDNSSeed || p[1] != PurposePeer {
+		t.Errorf("got purposes %v for %s", p, res[3].Host)
+	}
+	if p := res[1].Purposes; len(p) != 1 || p[0] != PurposeOther {
+		t.Errorf("got purposes %v for a lookup without a purpose", p)
+	}
+	if res[3].LastError == "" || res[2].LastError != "" {
+		t.Error("the error of the latest lookup of a host name was not kept")
+	}
+}
@@ -21,6 +21,7 @@ const (
 	DefaultMaxRPCClients         = 10
 	DefaultMaxRPCWebsockets      = 25
 	DefaultMaxRPCConcurrentReqs  = 20
+	DefaultRPCNtfnQueueLimit     = 1000
 	DefaultDbType                = "ffldb"
 	DefaultFreeTxRelayLimit      = 15.0
 	DefaultTrickleInterval       = peer.DefaultTrickleInterval
@@ -31,11 +32,56 @@ const (
 	BlockMaxWeightMin            = 4000
 	BlockMaxWeightMax            = blockchain.MaxBlockWeight - 4000
 	DefaultMaxOrphanTransactions = 100
-	DefaultSigCacheMaxSize       = 100000
+	DefaultSigCacheMaxSize       = 32 << 20
+	// DefaultArchivalServeDepth is how many blocks below the tip a block must be for serving it to a peer to count as
+	// serving history rather than relaying a new block.
+	DefaultArchivalServeDepth = 144
+	// DefaultAccountDiscoveryGap is the number of empty accounts in a row after which account discovery stops when a
+	// wallet is restored. BIP0044 stops at the first account without transactions.
+	DefaultAccountDiscoveryGap = 1
 	// DefaultBlockPrioritySize is the default size in bytes for high - priority / low-fee transactions. It is used to
 	// help determine which are allowed into the mempool and consequently affects their relay and inclusion when
 	// generating block templates.
 	DefaultBlockPrioritySize = 50000
+	// DefaultBytesPerSigOp is the number of virtual bytes a signature operation is counted as when the virtual size of a
+	// transaction is compared to its fee, so that a transaction with more signature operations than its size pays for
+	// can't crowd out others.
+	DefaultBytesPerSigOp = 20
+	// DefaultDustAttackThreshold is the largest amount in satoshi of a received output that is taken for dust when
+	// looking for dusting attacks.
+	DefaultDustAttackThreshold = amt.Amount(1e4)
+	// DefaultDustAttackAddresses is the number of addresses of the wallet that must receive dust within the window for
+	// it to be taken for a dusting attack.
+	DefaultDustAttackAddresses = 3
+	// DefaultDustAttackWindow is how long received dust is remembered when looking for dusting attacks.
+	DefaultDustAttackWindow = time.Hour * 24
+	// DefaultLogAlertWindow is the window errors are counted in for the log alert error rate, and the least time
+	// between two alerts of the same rule.
+	DefaultLogAlertWindow = time.Minute * 10
+	// DefaultLogShipLevel is the least severe level of the log entries shipped to a remote log server.
+	DefaultLogShipLevel = "warn"
+	// DefaultMaxTxFee is the highest fee in satoshi that a transaction sent by the wallet or accepted to the mempool may
+	// pay before it is treated as a mistake.
+	DefaultMaxTxFee = amt.Amount(1e7)
+	// DefaultMempoolExpiry is how long a transaction may stay in the mempool without being mined before it is evicted.
+	DefaultMempoolExpiry = time.Hour * 336
+	// DefaultMinConfChange is the number of confirmations before change outputs of transactions sent by the wallet are
+	// spendable.
+	DefaultMinConfChange = 1
+	// DefaultMinConfReceived is the number of confirmations before outputs received from others are spendable.
+	DefaultMinConfReceived = 1
+	// DefaultClockSkewWarning is how far the local clock may be from the time of the network before a warning is shown.
+	DefaultClockSkewWarning = time.Minute * 2
+	// DefaultControllerStallTimeout is how long the local node may stay behind a healthy upstream node before the mining
+	// controller takes its work from the upstream node.
+	DefaultControllerStallTimeout = time.Second * 30
+	// DefaultWalletBackupInterval is how often the wallet is backed up to the remote backup targets when it has changed.
+	DefaultWalletBackupInterval = time.Hour
+	// DefaultWalletScrubInterval is how long the wallet waits after checking all the rows of its database before it
+	// starts checking them again.
+	DefaultWalletScrubInterval = time.Hour * 24
+	// DefaultWatchInterval is how often the watcher polls the node for new blocks and mempool transactions.
+	DefaultWatchInterval = time.Second * 30
 	// DefaultMinRelayTxFee is the minimum fee in satoshi that is required for a
 	// transaction to be treated as free for relay and mining purposes. It is also
 	// used to help determine if a transaction is considered dust and as a base for
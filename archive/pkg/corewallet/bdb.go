@@ -0,0 +1,152 @@
+package corewallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// btreeMagic is the magic number of a Berkeley DB btree file, which is stored in the byte order of the machine that
+	// made it.
+	btreeMagic = 0x053162
+	// pageHeaderSize is the size of the header at the start of every page after the first.
+	pageHeaderSize = 26
+	// pageTypeLeaf and pageTypeOverflow are the types of the leaf pages of a btree, which hold its records, and of the
+	// pages holding the items too large to fit in a leaf page.
+	pageTypeLeaf     = 5
+	pageTypeOverflow = 7
+	// itemKeyData and itemOverflow are the types of the items in a leaf page that hold their data, and that hold where
+	// their data is in the overflow pages. itemDeleted is set in the type of a deleted item.
+	itemKeyData  = 1
+	itemOverflow = 3
+	itemDeleted  = 0x80
+)
+
+// record is a key and value read from a Berkeley DB btree file.
+type record struct {
+	key, value []byte
+}
+
+// bdbFile is the data of a Berkeley DB btree file cut into its pages.
+type bdbFile struct {
+	data     []byte
+	order    binary.ByteOrder
+	pageSize int
+}
+
+// readRecords returns the records in the leaf pages of the Berkeley DB btree file data, in the order they are in the
+// file. The records of every database in the file are returned, as the names of the databases in it are records too.
+func readRecords(data []byte) (records []record, e error) {
+	if len(data) < 512 {
+		return nil, errors.New("file is too small to be a Berkeley DB file")
+	}
+	f := &bdbFile{data: data}
+	switch {
+	case binary.LittleEndian.Uint32(data[12:]) == btreeMagic:
+		f.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data[12:]) == btreeMagic:
+		f.order = binary.BigEndian
+	default:
+		return nil, errors.New("not a Berkeley DB btree file")
+	}
+	f.pageSize = int(f.order.Uint32(data[20:]))
+	if f.pageSize < 512 || f.pageSize > 65536 || f.pageSize&(f.pageSize-1) != 0 {
+		return nil, fmt.Errorf("invalid page size %d", f.pageSize)
+	}
+	if data[24] != 0 {
+		return nil, errors.New("encrypted Berkeley DB files are not supported")
+	}
+	if data[26]&1 != 0 {
+		return nil, errors.New("Berkeley DB files with page checksums are not supported")
+	}
+	for pgno := 1; pgno < len(data)/f.pageSize; pgno++ {
+		page := f.page(pgno)
+		if page[25] != pageTypeLeaf {
+			continue
+		}
+		entries := int(f.order.Uint16(page[20:]))
+		if pageHeaderSize+entries*2 > len(page) {
+			return nil, fmt.Errorf("page %d has too many entries", pgno)
+		}
+		// The entries of a leaf page are the keys and values of its records in turn.
+		for i := 0; i+1 < entries; i += 2 {
+			var key, value []byte
+			var ok bool
+			if key, ok, e = f.item(page, i); e != nil {
+				return nil, fmt.Errorf("page %d: %v", pgno, e)
+			}
+			if !ok {
+				continue
+			}
+			if value, ok, e = f.item(page, i+1); e != nil {
+				return nil, fmt.Errorf("page %d: %v", pgno, e)
+			}
+			if ok {
+				records = append(records, record{key: key, value: value})
+			}
+		}
+	}
+	return
+}
+
+// page returns the page with the number.
+func (f *bdbFile) page(pgno int) []byte {
+	return f.data[pgno*f.pageSize : (pgno+1)*f.pageSize]
+}
+
+// item returns the data of the entry of the leaf page, which is not ok if it is deleted or not of a kind that holds
+// data.
+func (f *bdbFile) item(page []byte, i int) (data []byte, ok bool, e error) {
+	offset := int(f.order.Uint16(page[pageHeaderSize+i*2:]))
+	if offset < pageHeaderSize || offset+3 > len(page) {
+		return nil, false, fmt.Errorf("entry %d is out of the page", i)
+	}
+	length, kind := int(f.order.Uint16(page[offset:])), page[offset+2]
+	if kind&itemDeleted != 0 {
+		return nil, false, nil
+	}
+	switch kind {
+	case itemKeyData:
+		if offset+3+length > len(page) {
+			return nil, false, fmt.Errorf("entry %d is out of the page", i)
+		}
+		return page[offset+3 : offset+3+length], true, nil
+	case itemOverflow:
+		if offset+12 > len(page) {
+			return nil, false, fmt.Errorf("entry %d is out of the page", i)
+		}
+		data, e = f.overflow(int(f.order.Uint32(page[offset+4:])), int(f.order.Uint32(page[offset+8:])))
+		return data, e == nil, e
+	}
+	return nil, false, nil
+}
+
+// overflow returns the data of an item of the given length that is held in the chain of overflow pages starting at the
+// page with the number.
+func (f *bdbFile) overflow(pgno, length int) (data []byte, e error) {
+	pages := len(f.data) / f.pageSize
+	if length > len(f.data) {
+		return nil, fmt.Errorf("overflow item of %d bytes is larger than the file", length)
+	}
+	data = make([]byte, 0, length)
+	for n := 0; pgno != 0; n++ {
+		if pgno >= pages || n >= pages {
+			return nil, fmt.Errorf("overflow page %d is out of the file", pgno)
+		}
+		page := f.page(pgno)
+		if page[25] != pageTypeOverflow {
+			return nil, fmt.Errorf("page %d is not an overflow page", pgno)
+		}
+		used := int(f.order.Uint16(page[22:]))
+		if pageHeaderSize+used > len(page) {
+			return nil, fmt.Errorf("overflow page %d is overfull", pgno)
+		}
+		data = append(data, page[pageHeaderSize:pageHeaderSize+used]...)
+		pgno = int(f.order.Uint32(page[16:]))
+	}
+	if len(data) != length {
+		return nil, fmt.Errorf("overflow item is %d bytes, expected %d", len(data), length)
+	}
+	return
+}
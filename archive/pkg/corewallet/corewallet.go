@@ -0,0 +1,363 @@
+package corewallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+)
+
+// maxWalletSize is the size of the largest wallet.dat that will be read.
+const maxWalletSize = 256 << 20
+
+var (
+	// ErrPassphraseNeeded is returned when a wallet with encrypted keys is read without a passphrase.
+	ErrPassphraseNeeded = errors.New("wallet is encrypted and a passphrase is needed to read its keys")
+	// ErrWrongPassphrase is returned when a wallet with encrypted keys is read with a passphrase that is not its own.
+	ErrWrongPassphrase = errors.New("the passphrase of the wallet is not correct")
+)
+
+// Key is a private key read from a wallet.dat.
+type Key struct {
+	PrivKey *ecc.PrivateKey
+	// Compressed is whether the wallet used the compressed public key of the key, and so the address made from it.
+	Compressed bool
+	Address    *btcaddr.PubKeyHash
+	Label      string
+	// Created is when the key was made, which is zero if the wallet does not record it.
+	Created time.Time
+}
+
+// Wallet is the keys and labels read from a wallet.dat.
+type Wallet struct {
+	// Keys are the private keys of the wallet, in the order they were made, with the keys made at an unknown time first.
+	Keys []Key
+	// Encrypted is whether the keys of the wallet are encrypted with a passphrase.
+	Encrypted bool
+	// Labels are the labels of the address book of the wallet by address, which includes addresses that are not its own.
+	Labels map[string]string
+}
+
+// masterKey is a master key record of a wallet, which holds the key the private keys of the wallet are encrypted with,
+// encrypted with a key derived from the passphrase of the wallet.
+type masterKey struct {
+	crypted []byte
+	salt    []byte
+	method  uint32
+	rounds  uint32
+}
+
+// ReadFile reads the keys and labels of the wallet.dat at path for the network. The passphrase is only used if the keys
+// of the wallet are encrypted.
+func ReadFile(path string, passphrase []byte, params *chaincfg.Params) (w *Wallet, e error) {
+	var data []byte
+	if data, e = ioutil.ReadFile(path); e != nil {
+		return
+	}
+	return Read(data, passphrase, params)
+}
+
+// Read reads the keys and labels of the wallet.dat in data for the network. The passphrase is only used if the keys of
+// the wallet are encrypted.
+func Read(data []byte, passphrase []byte, params *chaincfg.Params) (w *Wallet, e error) {
+	if len(data) > maxWalletSize {
+		return nil, fmt.Errorf("wallet of %d bytes is too large", len(data))
+	}
+	var records []record
+	if records, e = readRecords(data); e != nil {
+		return
+	}
+	w = &Wallet{Labels: make(map[string]string)}
+	plain := make(map[string][]byte)
+	crypted := make(map[string][]byte)
+	created := make(map[string]time.Time)
+	var masterKeys []masterKey
+	for _, rec := range records {
+		r := &reader{b: rec.key}
+		kind := r.str()
+		if r.e != nil {
+			continue
+		}
+		v := &reader{b: rec.value}
+		switch kind {
+		case "key":
+			pub := r.bytes()
+			der := v.bytes()
+			if r.e != nil || v.e != nil {
+				return nil, errors.New("invalid key record")
+			}
+			var secret []byte
+			if secret, e = parsePrivKey(der); e != nil {
+				return nil, e
+			}
+			plain[string(pub)] = secret
+		case "wkey":
+			pub := r.bytes()
+			v.uint32()
+			der := v.bytes()
+			t := v.int64()
+			if r.e != nil || v.e != nil {
+				return nil, errors.New("invalid wkey record")
+			}
+			var secret []byte
+			if secret, e = parsePrivKey(der); e != nil {
+				return nil, e
+			}
+			plain[string(pub)] = secret
+			if t > 0 {
+				created[string(pub)] = time.Unix(t, 0)
+			}
+		case "ckey":
+			pub := r.bytes()
+			secret := v.bytes()
+			if r.e != nil || v.e != nil {
+				return nil, errors.New("invalid ckey record")
+			}
+			crypted[string(pub)] = secret
+		case "mkey":
+			var mk masterKey
+			mk.crypted = v.bytes()
+			mk.salt = v.bytes()
+			mk.method = v.uint32()
+			mk.rounds = v.uint32()
+			if v.e != nil {
+				return nil, errors.New("invalid mkey record")
+			}
+			masterKeys = append(masterKeys, mk)
+		case "name":
+			addr := r.str()
+			label := v.str()
+			if r.e != nil || v.e != nil {
+				return nil, errors.New("invalid name record")
+			}
+			w.Labels[addr] = label
+		case "keymeta":
+			pub := r.bytes()
+			v.uint32()
+			t := v.int64()
+			if r.e != nil || v.e != nil {
+				return nil, errors.New("invalid keymeta record")
+			}
+			if t > 0 {
+				created[string(pub)] = time.Unix(t, 0)
+			}
+		}
+	}
+	if len(crypted) > 0 {
+		w.Encrypted = true
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseNeeded
+		}
+		if len(masterKeys) == 0 {
+			return nil, errors.New("wallet has encrypted keys but no master key")
+		}
+		if e = decryptKeys(crypted, plain, masterKeys, passphrase); e != nil {
+			return nil, e
+		}
+	}
+	for pub, secret := range plain {
+		var k Key
+		if k, e = makeKey([]byte(pub), secret, params); e != nil {
+			return nil, e
+		}
+		k.Label = w.Labels[k.Address.EncodeAddress()]
+		k.Created = created[pub]
+		w.Keys = append(w.Keys, k)
+	}
+	sort.Slice(
+		w.Keys, func(i, j int) bool {
+			if !w.Keys[i].Created.Equal(w.Keys[j].Created) {
+				return w.Keys[i].Created.Before(w.Keys[j].Created)
+			}
+			return w.Keys[i].Address.EncodeAddress() < w.Keys[j].Address.EncodeAddress()
+		},
+	)
+	return
+}
+
+// decryptKeys decrypts the encrypted private keys by public key into plain, with the master key that the passphrase
+// decrypts. The first master key that decrypts a private key to the key of its public key is the one used.
+func decryptKeys(crypted, plain map[string][]byte, masterKeys []masterKey, passphrase []byte) (e error) {
+	for _, mk := range masterKeys {
+		if mk.method != 0 {
+			continue
+		}
+		key, iv := bytesToKey(passphrase, mk.salt, mk.rounds)
+		var master []byte
+		if master, e = decryptAES(key, iv, mk.crypted); e != nil || len(master) != 32 {
+			continue
+		}
+		decrypted := make(map[string][]byte, len(crypted))
+		ok := true
+		for pub, c := range crypted {
+			var secret []byte
+			secret, e = decryptAES(master, chainhash.DoubleHashB([]byte(pub))[:aes.BlockSize], c)
+			if e != nil || len(secret) != 32 || !matchesPubKey([]byte(pub), secret) {
+				ok = false
+				break
+			}
+			decrypted[pub] = secret
+		}
+		if ok {
+			for pub, secret := range decrypted {
+				plain[pub] = secret
+			}
+			return nil
+		}
+	}
+	return ErrWrongPassphrase
+}
+
+// bytesToKey derives the key and initialisation vector that a master key is encrypted with from the passphrase, as
+// OpenSSL EVP_BytesToKey does with SHA512.
+func bytesToKey(passphrase, salt []byte, rounds uint32) (key, iv []byte) {
+	d := sha512.Sum512(append(append([]byte{}, passphrase...), salt...))
+	for i := uint32(1); i < rounds; i++ {
+		d = sha512.Sum512(d[:])
+	}
+	return d[:32], d[32:48]
+}
+
+// decryptAES decrypts data encrypted with AES-256 in CBC mode and PKCS#7 padding.
+func decryptAES(key, iv, data []byte) (plain []byte, e error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("encrypted data is not a whole number of blocks")
+	}
+	var block cipher.Block
+	if block, e = aes.NewCipher(key); e != nil {
+		return
+	}
+	plain = make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > aes.BlockSize || !bytes.Equal(plain[len(plain)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, errors.New("invalid padding")
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// parsePrivKey returns the secret of a private key in the DER encoding of OpenSSL that wallets store keys in.
+func parsePrivKey(der []byte) (secret []byte, e error) {
+	// the key is a sequence, whose length may take more than one byte, starting with version 1 and the secret
+	i := 2
+	if len(der) > 1 && der[1]&0x80 != 0 {
+		i += int(der[1] & 0x7f)
+	}
+	if len(der) < i+5 || der[0] != 0x30 || !bytes.Equal(der[i:i+4], []byte{0x02, 0x01, 0x01, 0x04}) {
+		return nil, errors.New("invalid private key encoding")
+	}
+	n := int(der[i+4])
+	if n == 0 || n > 32 || len(der) < i+5+n {
+		return nil, errors.New("invalid private key encoding")
+	}
+	secret = make([]byte, 32)
+	copy(secret[32-n:], der[i+5:i+5+n])
+	return
+}
+
+// matchesPubKey returns whether the public key is the compressed or uncompressed public key of the secret.
+func matchesPubKey(pub, secret []byte) bool {
+	_, pk := ecc.PrivKeyFromBytes(ecc.S256(), secret)
+	return bytes.Equal(pub, pk.SerializeCompressed()) || bytes.Equal(pub, pk.SerializeUncompressed())
+}
+
+// makeKey returns the key of the secret, which must be the private key of the public key, with its address for the
+// network.
+func makeKey(pub, secret []byte, params *chaincfg.Params) (k Key, e error) {
+	priv, pk := ecc.PrivKeyFromBytes(ecc.S256(), secret)
+	switch {
+	case bytes.Equal(pub, pk.SerializeCompressed()):
+		k.Compressed = true
+	case bytes.Equal(pub, pk.SerializeUncompressed()):
+	default:
+		return k, errors.New("private key does not match its public key")
+	}
+	k.PrivKey = priv
+	if k.Address, e = btcaddr.NewPubKeyHash(btcaddr.Hash160(pub), params); e != nil {
+		return
+	}
+	return
+}
+
+// reader reads the fields of the serialization of a wallet record, which are little endian. The first error is kept
+// and the fields read after it are empty.
+type reader struct {
+	b []byte
+	e error
+}
+
+// next returns the next n bytes.
+func (r *reader) next(n uint64) []byte {
+	if r.e != nil {
+		return nil
+	}
+	if n > uint64(len(r.b)) {
+		r.e = errors.New("record is too short")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+// compactSize reads a variable length integer.
+func (r *reader) compactSize() uint64 {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	switch b[0] {
+	case 0xfd:
+		if b = r.next(2); b != nil {
+			return uint64(binary.LittleEndian.Uint16(b))
+		}
+	case 0xfe:
+		if b = r.next(4); b != nil {
+			return uint64(binary.LittleEndian.Uint32(b))
+		}
+	case 0xff:
+		if b = r.next(8); b != nil {
+			return binary.LittleEndian.Uint64(b)
+		}
+	default:
+		return uint64(b[0])
+	}
+	return 0
+}
+
+// bytes reads a byte string prefixed with its length.
+func (r *reader) bytes() []byte {
+	return r.next(r.compactSize())
+}
+
+// str reads a string prefixed with its length.
+func (r *reader) str() string {
+	return string(r.bytes())
+}
+
+// uint32 reads a 32 bit integer.
+func (r *reader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// int64 reads a 64 bit integer.
+func (r *reader) int64() int64 {
+	if b := r.next(8); b != nil {
+		return int64(binary.LittleEndian.Uint64(b))
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+package corewallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+)
+
+const testPageSize = 512
+
+// bdbWriter builds a Berkeley DB btree file holding records, for testing.
+type bdbWriter struct {
+	pages [][]byte
+}
+
+func newBDBWriter() *bdbWriter {
+	meta := make([]byte, testPageSize)
+	binary.LittleEndian.PutUint32(meta[12:], btreeMagic)
+	binary.LittleEndian.PutUint32(meta[20:], testPageSize)
+	return &bdbWriter{pages: [][]byte{meta}}
+}
+
+// overflow stores data in a chain of overflow pages and returns the number of the first.
+func (w *bdbWriter) overflow(data []byte) uint32 {
+	first := uint32(len(w.pages))
+	for len(data) > 0 {
+		page := make([]byte, testPageSize)
+		n := copy(page[pageHeaderSize:], data)
+		data = data[n:]
+		binary.LittleEndian.PutUint16(page[22:], uint16(n))
+		page[25] = pageTypeOverflow
+		if len(data) > 0 {
+			binary.LittleEndian.PutUint32(page[16:], uint32(len(w.pages)+1))
+		}
+		w.pages = append(w.pages, page)
+	}
+	return first
+}
+
+// leaf adds a leaf page holding the records. Data too large to fit in the page is put in overflow pages.
+func (w *bdbWriter) leaf(records ...record) {
+	var items [][]byte
+	for _, rec := range records {
+		for _, data := range [][]byte{rec.key, rec.value} {
+			if len(data) > 100 {
+				item := make([]byte, 12)
+				item[2] = itemOverflow
+				binary.LittleEndian.PutUint32(item[8:], uint32(len(data)))
+				binary.LittleEndian.PutUint32(item[4:], w.overflow(data))
+				items = append(items, item)
+				continue
+			}
+			item := make([]byte, 3, 3+len(data))
+			binary.LittleEndian.PutUint16(item, uint16(len(data)))
+			item[2] = itemKeyData
+			items = append(items, append(item, data...))
+		}
+	}
+	page := make([]byte, testPageSize)
+	page[25] = pageTypeLeaf
+	binary.LittleEndian.PutUint16(page[20:], uint16(len(items)))
+	offset := testPageSize
+	for i, item := range items {
+		offset -= len(item)
+		copy(page[offset:], item)
+		binary.LittleEndian.PutUint16(page[pageHeaderSize+i*2:], uint16(offset))
+	}
+	w.pages = append(w.pages, page)
+}
+
+func (w *bdbWriter) bytes() []byte {
+	return bytes.Join(w.pages, nil)
+}
+
+// ser joins the serialized fields of a wallet record.
+func ser(fields ...interface{}) []byte {
+	var b bytes.Buffer
+	for _, f := range fields {
+		switch v := f.(type) {
+		case string:
+			b.WriteByte(byte(len(v)))
+			b.WriteString(v)
+		case []byte:
+			b.WriteByte(byte(len(v)))
+			b.Write(v)
+		case uint32:
+			_ = binary.Write(&b, binary.LittleEndian, v)
+		case int64:
+			_ = binary.Write(&b, binary.LittleEndian, v)
+		}
+	}
+	return b.Bytes()
+}
+
+// der encodes a secret as OpenSSL does in the private key records of a wallet.
+func der(secret []byte) []byte {
+	body := append([]byte{0x02, 0x01, 0x01, 0x04, byte(len(secret))}, secret...)
+	// the rest of the encoding, holding the curve and public key, makes the length take two bytes
+	body = append(body, make([]byte, 200)...)
+	return append([]byte{0x30, 0x81, byte(len(body))}, body...)
+}
+
+func encryptAES(key, iv, data []byte) []byte {
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	data = append(append([]byte{}, data...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	block, _ := aes.NewCipher(key)
+	out := make([]byte, len(data))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+	return out
+}
+
+func testKey(b byte) (secret, pub []byte) {
+	secret = bytes.Repeat([]byte{b}, 32)
+	_, pk := ecc.PrivKeyFromBytes(ecc.S256(), secret)
+	return secret, pk.SerializeCompressed()
+}
+
+// TestRead ensures the keys, labels and creation times of plain and encrypted wallets are read, and that encrypted
+// keys are only read with the passphrase of the wallet.
+func TestRead(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	secret1, pub1 := testKey(1)
+	secret2, _ := testKey(2)
+	_, pk2 := ecc.PrivKeyFromBytes(ecc.S256(), secret2)
+	pub2 := pk2.SerializeUncompressed()
+	created := time.Unix(1400000000, 0)
+	plain := newBDBWriter()
+	plain.leaf(
+		record{ser("key", pub1), ser(der(secret1))},
+		record{ser("keymeta", pub1), ser(uint32(1), created.Unix())},
+	)
+	k1, e := makeKey(pub1, secret1, params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	plain.leaf(
+		record{ser("key", pub2), ser(der(secret2))},
+		record{ser("name", k1.Address.EncodeAddress()), ser("savings")},
+	)
+	w, e := Read(plain.bytes(), nil, params)
+	if e != nil {
+		t.Fatalf("Read: %v", e)
+	}
+	if w.Encrypted || len(w.Keys) != 2 {
+		t.Fatalf("read %d keys, encrypted %v, want 2 plain keys", len(w.Keys), w.Encrypted)
+	}
+	// the key made at an unknown time sorts first
+	if w.Keys[0].Compressed || !w.Keys[0].Created.IsZero() {
+		t.Errorf("first key compressed %v created %v, want the uncompressed key", w.Keys[0].Compressed, w.Keys[0].Created)
+	}
+	if k := w.Keys[1]; !k.Compressed || !k.Created.Equal(created) || k.Label != "savings" ||
+		k.Address.EncodeAddress() != k1.Address.EncodeAddress() {
+		t.Errorf("second key %s compressed %v created %v label %q", k.Address.EncodeAddress(), k.Compressed, k.Created,
+			k.Label)
+	}
+	// an encrypted wallet
+	passphrase := []byte("correct horse")
+	master := bytes.Repeat([]byte{7}, 32)
+	salt := []byte("saltsalt")
+	key, iv := bytesToKey(passphrase, salt, 25000)
+	crypted := newBDBWriter()
+	crypted.leaf(
+		record{ser("mkey", uint32(1)), ser(encryptAES(key, iv, master), salt, uint32(0), uint32(25000), []byte{})},
+		record{ser("ckey", pub1), ser(encryptAES(master, chainhash.DoubleHashB(pub1)[:16], secret1))},
+	)
+	data := crypted.bytes()
+	if _, e = Read(data, nil, params); e != ErrPassphraseNeeded {
+		t.Errorf("read without a passphrase: %v, want %v", e, ErrPassphraseNeeded)
+	}
+	if _, e = Read(data, []byte("wrong"), params); e != ErrWrongPassphrase {
+		t.Errorf("read with the wrong passphrase: %v, want %v", e, ErrWrongPassphrase)
+	}
+	if w, e = Read(data, passphrase, params); e != nil {
+		t.Fatalf("Read: %v", e)
+	}
+	if !w.Encrypted || len(w.Keys) != 1 || w.Keys[0].Address.EncodeAddress() != k1.Address.EncodeAddress() {
+		t.Errorf("read %d keys, encrypted %v, want the encrypted key", len(w.Keys), w.Encrypted)
+	}
+	// a file that is not a wallet
+	if _, e = Read(make([]byte, 4096), nil, params); e == nil {
+		t.Error("expected an error reading a file that is not a Berkeley DB file")
+	}
+}
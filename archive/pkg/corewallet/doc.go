@@ -0,0 +1,12 @@
+/*
+Package corewallet reads the keys and address labels out of a wallet.dat made by Bitcoin Core era wallets, such as the
+legacy Parallelcoin Qt wallet, so they can be imported into this wallet by users moving from the old client.
+
+A wallet.dat is a Berkeley DB btree file. Its records are read straight from the leaf pages of the file, without Berkeley
+DB, so the old client does not need to be installed. Records still only in the log files of Berkeley DB are not seen, so
+the old client should be closed normally before its wallet is read.
+
+Unencrypted keys and keys encrypted with the passphrase of the wallet are both read, along with the time each key was
+made, which is when payments to it can first have been made.
+*/
+package corewallet
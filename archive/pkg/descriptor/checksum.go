@@ -0,0 +1,110 @@
+package descriptor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ChecksumLen is the number of characters of a descriptor checksum.
+const ChecksumLen = 8
+
+const (
+	// inputCharset is the characters a descriptor may contain, ordered so that the characters most used in descriptors
+	// differ only in the low five bits that the checksum guards most strongly.
+	inputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	// checksumCharset is the characters of the checksum, which are those of bech32.
+	checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// polyMod updates the checksum with the next symbol, computing the remainder of the BCH code over GF(32) the checksum is
+// made with.
+func polyMod(c uint64, val int) uint64 {
+	c0 := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(val)
+	if c0&1 != 0 {
+		c ^= 0xf5dee51989
+	}
+	if c0&2 != 0 {
+		c ^= 0xa9fdca3312
+	}
+	if c0&4 != 0 {
+		c ^= 0x1bab10e32d
+	}
+	if c0&8 != 0 {
+		c ^= 0x3706b1677a
+	}
+	if c0&16 != 0 {
+		c ^= 0x644d626ffd
+	}
+	return c
+}
+
+// Checksum returns the checksum of the descriptor, which must not have one appended.
+func Checksum(desc string) (checksum string, e error) {
+	c := uint64(1)
+	cls, clsCount := 0, 0
+	for i := 0; i < len(desc); i++ {
+		pos := strings.IndexByte(inputCharset, desc[i])
+		if pos < 0 {
+			return "", fmt.Errorf("invalid character %q in descriptor", desc[i])
+		}
+		// each character is fed in as its position within its group, and the groups of every three characters as one
+		// more symbol
+		c = polyMod(c, pos&31)
+		cls = cls*3 + pos>>5
+		if clsCount++; clsCount == 3 {
+			c = polyMod(c, cls)
+			cls, clsCount = 0, 0
+		}
+	}
+	if clsCount > 0 {
+		c = polyMod(c, cls)
+	}
+	for i := 0; i < ChecksumLen; i++ {
+		c = polyMod(c, 0)
+	}
+	c ^= 1
+	out := make([]byte, ChecksumLen)
+	for i := range out {
+		out[i] = checksumCharset[(c>>(5*(7-uint(i))))&31]
+	}
+	return string(out), nil
+}
+
+// AddChecksum returns the descriptor with its checksum appended.
+func AddChecksum(desc string) (string, error) {
+	checksum, e := Checksum(desc)
+	if e != nil {
+		return "", e
+	}
+	return desc + "#" + checksum, nil
+}
+
+// SplitChecksum separates the checksum appended to the descriptor from it, checking that it matches. The checksum
+// returned is empty if the descriptor has none, which is an error if it is required.
+func SplitChecksum(desc string, require bool) (body, checksum string, e error) {
+	i := strings.LastIndexByte(desc, '#')
+	if i < 0 {
+		if require {
+			return "", "", errors.New("missing checksum")
+		}
+		return desc, "", nil
+	}
+	body, checksum = desc[:i], desc[i+1:]
+	if len(checksum) != ChecksumLen {
+		return "", "", fmt.Errorf(
+			"expected %d character checksum, not %d characters", ChecksumLen, len(checksum),
+		)
+	}
+	var computed string
+	if computed, e = Checksum(body); e != nil {
+		return "", "", e
+	}
+	if computed != checksum {
+		return "", "", fmt.Errorf("provided checksum %q does not match computed checksum %q", checksum, computed)
+	}
+	return
+}
@@ -0,0 +1,365 @@
+package descriptor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+const (
+	// maxBareMultiSigKeys is the most keys a multisig script outside of a pay to script hash may have to be standard.
+	maxBareMultiSigKeys = 3
+	// maxMultiSigKeys is the most keys a multisig script may have.
+	maxMultiSigKeys = txscript.MaxPubKeysPerMultiSig
+)
+
+var (
+	// ErrNoAddress is returned when the addresses of a descriptor whose scripts have no address are derived.
+	ErrNoAddress = errors.New("descriptor does not have a corresponding address")
+	// ErrNotRange is returned when a range of scripts is derived from a descriptor which is not a range.
+	ErrNotRange = errors.New("descriptor is not a range")
+)
+
+// Descriptor is a parsed output script descriptor, which describes the output scripts a wallet can spend, or a range
+// of them derived from extended keys.
+type Descriptor struct {
+	// fn is the name of the function of the descriptor, such as pkh or multi.
+	fn        string
+	keys      []*key
+	threshold int
+	// sub is the descriptor inside an sh descriptor.
+	sub    *Descriptor
+	addr   btcaddr.Address
+	script []byte
+	net    *chaincfg.Params
+}
+
+// Parse parses a descriptor for the network. A checksum appended to the descriptor is checked, but is not required.
+func Parse(desc string, net *chaincfg.Params) (d *Descriptor, e error) {
+	var body string
+	if body, _, e = SplitChecksum(desc, false); e != nil {
+		return
+	}
+	if d, e = parse(body, net, true); e != nil {
+		return
+	}
+	// a pay to script hash script too long to be pushed can never be spent
+	if d.fn == "sh" {
+		var scripts [][]byte
+		if scripts, e = d.sub.scripts(0); e != nil {
+			return nil, e
+		}
+		if len(scripts[0]) > txscript.MaxScriptElementSize {
+			return nil, fmt.Errorf(
+				"P2SH script is too large, %d bytes is larger than %d bytes",
+				len(scripts[0]), txscript.MaxScriptElementSize,
+			)
+		}
+	}
+	return
+}
+
+// splitCall splits a function call expression into the name of the function and its arguments, which are separated by
+// commas outside of any brackets within them.
+func splitCall(s string) (fn string, args []string, e error) {
+	open := strings.IndexByte(s, '(')
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return "", nil, fmt.Errorf("%q is not a valid descriptor function", s)
+	}
+	fn, s = s[:open], s[open+1:len(s)-1]
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth--; depth < 0 {
+				return "", nil, fmt.Errorf("unbalanced brackets in %s()", fn)
+			}
+		case ',':
+			if depth == 0 {
+				args, start = append(args, s[start:i]), i+1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, fmt.Errorf("unbalanced brackets in %s()", fn)
+	}
+	return fn, append(args, s[start:]), nil
+}
+
+// parse parses a script expression, at the top level of the descriptor or inside sh().
+func parse(s string, net *chaincfg.Params, top bool) (d *Descriptor, e error) {
+	var args []string
+	d = &Descriptor{net: net}
+	if d.fn, args, e = splitCall(s); e != nil {
+		return nil, e
+	}
+	switch d.fn {
+	case "pk", "pkh", "combo", "addr", "raw", "sh":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s() takes exactly one argument, got %d", d.fn, len(args))
+		}
+	}
+	switch d.fn {
+	case "pk", "pkh":
+		var k *key
+		if k, e = parseKey(args[0], net); e != nil {
+			return nil, e
+		}
+		d.keys = []*key{k}
+	case "combo":
+		if !top {
+			return nil, errors.New("can only have combo() at top level")
+		}
+		var k *key
+		if k, e = parseKey(args[0], net); e != nil {
+			return nil, e
+		}
+		d.keys = []*key{k}
+	case "multi", "sortedmulti":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("%s() needs a threshold and at least one key", d.fn)
+		}
+		if d.threshold, e = strconv.Atoi(args[0]); e != nil {
+			return nil, fmt.Errorf("multi threshold %q is not valid", args[0])
+		}
+		for _, arg := range args[1:] {
+			var k *key
+			if k, e = parseKey(arg, net); e != nil {
+				return nil, e
+			}
+			d.keys = append(d.keys, k)
+		}
+		switch {
+		case d.threshold < 1 || d.threshold > len(d.keys):
+			return nil, fmt.Errorf(
+				"multisig threshold cannot be %d, must be at least 1 and at most %d", d.threshold, len(d.keys),
+			)
+		case len(d.keys) > maxMultiSigKeys:
+			return nil, fmt.Errorf("cannot have %d keys in multisig; must have at most %d", len(d.keys), maxMultiSigKeys)
+		case top && len(d.keys) > maxBareMultiSigKeys:
+			return nil, fmt.Errorf(
+				"cannot have %d pubkeys in bare multisig; only at most %d pubkeys", len(d.keys), maxBareMultiSigKeys,
+			)
+		}
+	case "sh":
+		if !top {
+			return nil, errors.New("can only have sh() at top level")
+		}
+		if d.sub, e = parse(args[0], net, false); e != nil {
+			return nil, e
+		}
+		switch d.sub.fn {
+		case "pk", "pkh", "multi", "sortedmulti":
+		default:
+			return nil, fmt.Errorf("can not have %s() inside sh()", d.sub.fn)
+		}
+	case "addr":
+		if !top {
+			return nil, errors.New("can only have addr() at top level")
+		}
+		if d.addr, e = btcaddr.DecodeForNet(args[0], net); e != nil {
+			return nil, fmt.Errorf("address %q is not valid for the %s network", args[0], net.Name)
+		}
+	case "raw":
+		if !top {
+			return nil, errors.New("can only have raw() at top level")
+		}
+		if d.script, e = hex.DecodeString(args[0]); e != nil {
+			return nil, fmt.Errorf("raw script %q is not hex", args[0])
+		}
+	case "wpkh", "wsh", "tr":
+		return nil, fmt.Errorf("%s() is not supported, as segregated witness is not active on this chain", d.fn)
+	default:
+		return nil, fmt.Errorf("%s() is not a valid descriptor function", d.fn)
+	}
+	return d, nil
+}
+
+// format returns the descriptor without its checksum, with the private keys given if private is set.
+func (d *Descriptor) format(private bool) (s string, e error) {
+	var args []string
+	switch d.fn {
+	case "sh":
+		var sub string
+		if sub, e = d.sub.format(private); e != nil {
+			return
+		}
+		args = []string{sub}
+	case "addr":
+		args = []string{d.addr.EncodeAddress()}
+	case "raw":
+		args = []string{hex.EncodeToString(d.script)}
+	case "multi", "sortedmulti":
+		args = []string{strconv.Itoa(d.threshold)}
+	}
+	for _, k := range d.keys {
+		var arg string
+		if arg, e = k.String(private); e != nil {
+			return
+		}
+		args = append(args, arg)
+	}
+	return d.fn + "(" + strings.Join(args, ",") + ")", nil
+}
+
+// String returns the descriptor in canonical form with its checksum, with public keys in place of any private keys
+// it was given.
+func (d *Descriptor) String() string {
+	s, e := d.format(false)
+	if e != nil {
+		return ""
+	}
+	if s, e = AddChecksum(s); e != nil {
+		return ""
+	}
+	return s
+}
+
+// PrivateString returns the descriptor in canonical form with its checksum, keeping any private keys it was given.
+func (d *Descriptor) PrivateString() (s string, e error) {
+	if s, e = d.format(true); e != nil {
+		return
+	}
+	return AddChecksum(s)
+}
+
+// IsRange returns true if the descriptor derives scripts for a range of indexes.
+func (d *Descriptor) IsRange() bool {
+	if d.sub != nil {
+		return d.sub.IsRange()
+	}
+	for _, k := range d.keys {
+		if k.isRange() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSolvable returns true if the descriptor has the information needed to sign for its scripts given the private keys,
+// which those given only by an address or as a raw script do not.
+func (d *Descriptor) IsSolvable() bool {
+	return d.fn != "addr" && d.fn != "raw"
+}
+
+// HasPrivateKeys returns true if any of the keys of the descriptor were given with their private key.
+func (d *Descriptor) HasPrivateKeys() bool {
+	if d.sub != nil {
+		return d.sub.HasPrivateKeys()
+	}
+	for _, k := range d.keys {
+		if k.isPrivate() {
+			return true
+		}
+	}
+	return false
+}
+
+// scripts returns the output scripts of the descriptor for the index.
+func (d *Descriptor) scripts(index uint32) (scripts [][]byte, e error) {
+	pubs := make([]*btcaddr.PubKey, len(d.keys))
+	for i, k := range d.keys {
+		if pubs[i], e = k.derive(index, d.net); e != nil {
+			return
+		}
+	}
+	var script []byte
+	switch d.fn {
+	case "pk":
+		script, e = txscript.PayToAddrScript(pubs[0])
+	case "pkh":
+		script, e = txscript.PayToAddrScript(pubs[0].PubKeyHash())
+	case "combo":
+		if script, e = txscript.PayToAddrScript(pubs[0]); e != nil {
+			return
+		}
+		scripts = append(scripts, script)
+		script, e = txscript.PayToAddrScript(pubs[0].PubKeyHash())
+	case "multi":
+		script, e = txscript.MultiSigScript(pubs, d.threshold)
+	case "sortedmulti":
+		sort.Slice(
+			pubs, func(i, j int) bool {
+				return bytes.Compare(pubs[i].ScriptAddress(), pubs[j].ScriptAddress()) < 0
+			},
+		)
+		script, e = txscript.MultiSigScript(pubs, d.threshold)
+	case "sh":
+		var sub [][]byte
+		if sub, e = d.sub.scripts(index); e != nil {
+			return
+		}
+		var addr *btcaddr.ScriptHash
+		if addr, e = btcaddr.NewScriptHash(sub[0], d.net); e != nil {
+			return
+		}
+		script, e = txscript.PayToAddrScript(addr)
+	case "addr":
+		script, e = txscript.PayToAddrScript(d.addr)
+	case "raw":
+		script = d.script
+	}
+	if e != nil {
+		return nil, e
+	}
+	return append(scripts, script), nil
+}
+
+// Scripts returns the output scripts of the descriptor for each index from begin to end inclusive, which must both be
+// zero if the descriptor is not a range.
+func (d *Descriptor) Scripts(begin, end uint32) (scripts [][]byte, e error) {
+	if !d.IsRange() && (begin != 0 || end != 0) {
+		return nil, ErrNotRange
+	}
+	for i := uint64(begin); i <= uint64(end); i++ {
+		var s [][]byte
+		if s, e = d.scripts(uint32(i)); e != nil {
+			return nil, e
+		}
+		scripts = append(scripts, s...)
+	}
+	return
+}
+
+// Addresses returns the addresses of the output scripts of the descriptor for each index from begin to end inclusive,
+// which must both be zero if the descriptor is not a range. Pay to public key scripts have the address of the hash of
+// their key, which only appears once for the scripts of a combo() descriptor.
+func (d *Descriptor) Addresses(begin, end uint32) (addrs []btcaddr.Address, e error) {
+	var scripts [][]byte
+	if scripts, e = d.Scripts(begin, end); e != nil {
+		return
+	}
+	seen := make(map[string]struct{})
+	for _, script := range scripts {
+		var class txscript.ScriptClass
+		var scriptAddrs []btcaddr.Address
+		if class, scriptAddrs, _, e = txscript.ExtractPkScriptAddrs(script, d.net); e != nil {
+			return nil, e
+		}
+		var addr btcaddr.Address
+		switch class {
+		case txscript.PubKeyTy:
+			addr = scriptAddrs[0].(*btcaddr.PubKey).PubKeyHash()
+		case txscript.PubKeyHashTy, txscript.ScriptHashTy:
+			addr = scriptAddrs[0]
+		default:
+			return nil, ErrNoAddress
+		}
+		if _, ok := seen[addr.EncodeAddress()]; ok {
+			continue
+		}
+		seen[addr.EncodeAddress()] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	return
+}
@@ -0,0 +1,158 @@
+package descriptor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// TestChecksum ensures checksums match those of descriptors from the descriptor specification, and that descriptors
+// with a wrong checksum are rejected.
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		desc     string
+		checksum string
+	}{
+		{"addr(mkmZxiEcEd8ZqjQWVZuC6so5dFMKEFpN2j)", "02wpgw69"},
+		{"raw(deadbeef)", "89f8spxm"},
+		{"pkh(02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5)", "8fhd9pwu"},
+	}
+	for _, test := range tests {
+		checksum, e := Checksum(test.desc)
+		if e != nil || checksum != test.checksum {
+			t.Errorf("%s: got checksum %s, %v, want %s", test.desc, checksum, e, test.checksum)
+		}
+		var body string
+		if body, _, e = SplitChecksum(test.desc+"#"+test.checksum, true); e != nil || body != test.desc {
+			t.Errorf("%s: got %s, %v splitting the checksum", test.desc, body, e)
+		}
+	}
+	if _, _, e := SplitChecksum("raw(deadbeef)#89f8spxn", false); e == nil {
+		t.Error("expected an error for a descriptor with the wrong checksum")
+	}
+	if _, _, e := SplitChecksum("raw(deadbeef)", true); e == nil {
+		t.Error("expected an error for a descriptor without a required checksum")
+	}
+	if _, e := Checksum("raw(dead\x00beef)"); e == nil {
+		t.Error("expected an error for a descriptor with an invalid character")
+	}
+}
+
+// TestDescriptors ensures descriptors are put in canonical form and derive the scripts and addresses of the keys they
+// describe.
+func TestDescriptors(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	master, e := hdkeychain.NewMaster(bytes.Repeat([]byte{7}, hdkeychain.RecommendedSeedLen), net)
+	if e != nil {
+		t.Fatal(e)
+	}
+	xpub, e := master.Neuter()
+	if e != nil {
+		t.Fatal(e)
+	}
+	d, e := Parse("pkh([d34db33f/44h/0h/0h]"+master.String()+"/1h/*)", net)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !d.IsRange() || !d.IsSolvable() || !d.HasPrivateKeys() {
+		t.Errorf("got range %v, solvable %v, private keys %v", d.IsRange(), d.IsSolvable(), d.HasPrivateKeys())
+	}
+	if want := "pkh([d34db33f/44'/0'/0']" + xpub.String() + "/1'/*)#"; !strings.HasPrefix(d.String(), want) {
+		t.Errorf("got canonical descriptor %s, want %s", d.String(), want)
+	}
+	if strings.Contains(d.String(), master.String()) {
+		t.Error("the canonical descriptor gives away the private key")
+	}
+	addrs, e := d.Addresses(0, 2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(addrs) != 3 {
+		t.Fatalf("got %d addresses for 3 indexes", len(addrs))
+	}
+	account, e := master.Child(hdkeychain.HardenedKeyStart + 1)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for i, addr := range addrs {
+		child, e := account.Child(uint32(i))
+		if e != nil {
+			t.Fatal(e)
+		}
+		var want *btcaddr.PubKeyHash
+		if want, e = child.Address(net); e != nil {
+			t.Fatal(e)
+		}
+		if addr.EncodeAddress() != want.EncodeAddress() {
+			t.Errorf("index %d: got address %s, want %s", i, addr.EncodeAddress(), want.EncodeAddress())
+		}
+	}
+	// the order of the keys of a sortedmulti() descriptor does not change its script
+	first, e := master.Child(0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	second, e := master.Child(1)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var pubs []string
+	for _, k := range []*hdkeychain.ExtendedKey{first, second} {
+		pub, e := k.ECPubKey()
+		if e != nil {
+			t.Fatal(e)
+		}
+		pubs = append(pubs, hex.EncodeToString(pub.SerializeCompressed()))
+	}
+	var scripts [][]byte
+	for _, desc := range []string{
+		"sh(sortedmulti(1," + pubs[0] + "," + pubs[1] + "))",
+		"sh(sortedmulti(1," + pubs[1] + "," + pubs[0] + "))",
+	} {
+		if d, e = Parse(desc, net); e != nil {
+			t.Fatal(e)
+		}
+		if d.IsRange() {
+			t.Errorf("%s: is a range", desc)
+		}
+		var s [][]byte
+		if s, e = d.Scripts(0, 0); e != nil {
+			t.Fatal(e)
+		}
+		scripts = append(scripts, s...)
+		if _, e = d.Scripts(0, 1); e != ErrNotRange {
+			t.Errorf("%s: got error %v deriving a range, want %v", desc, e, ErrNotRange)
+		}
+	}
+	if !bytes.Equal(scripts[0], scripts[1]) {
+		t.Error("the order of the keys of a sortedmulti() descriptor changed its script")
+	}
+	if d, e = Parse("raw(6a)", net); e != nil {
+		t.Fatal(e)
+	}
+	if d.IsSolvable() {
+		t.Error("a raw() descriptor is solvable")
+	}
+	if _, e = d.Addresses(0, 0); e != ErrNoAddress {
+		t.Errorf("got error %v deriving the address of a null data script, want %v", e, ErrNoAddress)
+	}
+	for _, desc := range []string{
+		"wpkh(" + pubs[0] + ")",
+		"pkh(" + xpub.String() + "/0h/*)",
+		"multi(1," + strings.Repeat(pubs[0]+",", 3) + pubs[1] + ")",
+		"multi(3," + pubs[0] + "," + pubs[1] + ")",
+		"sh(sh(pk(" + pubs[0] + ")))",
+		"pk(" + pubs[0] + ",)",
+		"pk(" + pubs[0] + ")#8fhd9pwu",
+		"pkh(" + pubs[0],
+	} {
+		if _, e = Parse(desc, net); e == nil {
+			t.Errorf("%s: expected an error", desc)
+		}
+	}
+}
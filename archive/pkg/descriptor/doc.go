@@ -0,0 +1,13 @@
+/*
+Package descriptor parses output script descriptors, which describe in a line of text the output scripts a wallet can
+spend, such as pkh(xpub.../0/*) for the pay to public key hash scripts of the keys derived from an extended public key.
+
+The descriptors of pay to public key, pay to public key hash, multisig and pay to script hash scripts are supported,
+along with combo(), addr() and raw(). Keys may be given as public keys, private keys in wallet import format or extended
+keys with a derivation path, each with the fingerprint and path of the key they were derived from. Segregated witness
+descriptors are rejected, as it is not active on this chain.
+
+Descriptors are guarded against typing errors with the checksum appended after a #, which is computed the same way as
+in other wallets, so a descriptor can be carried between them.
+*/
+package descriptor
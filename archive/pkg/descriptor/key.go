@@ -0,0 +1,233 @@
+package descriptor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// wildcard is how the last step of the derivation of a key from an extended key is chosen.
+type wildcard int
+
+const (
+	// noWildcard derives a single key.
+	noWildcard wildcard = iota
+	// unhardened derives a key for each index given to the descriptor.
+	unhardened
+	// hardened derives a hardened key for each index given to the descriptor, which needs the extended private key.
+	hardened
+)
+
+// key is a key expression of a descriptor: a public key, a private key in wallet import format, or an extended key and
+// the path of the keys derived from it, each optionally with the fingerprint of the master key and path it was derived
+// from.
+type key struct {
+	// origin is the fingerprint of the master key followed by the path of the key from it, or empty if not given.
+	origin []uint32
+	pub    *ec.PublicKey
+	// compressed is whether pub is serialized in the compressed form.
+	compressed bool
+	wif        *util.WIF
+	ext        *hdkeychain.ExtendedKey
+	path       []uint32
+	wildcard   wildcard
+}
+
+// parsePath parses the steps of a derivation path separated by slashes, each a number marked as hardened by a
+// following apostrophe or h.
+func parsePath(steps []string) (path []uint32, e error) {
+	for _, step := range steps {
+		offset := uint32(0)
+		if strings.HasSuffix(step, "'") || strings.HasSuffix(step, "h") {
+			step, offset = step[:len(step)-1], hdkeychain.HardenedKeyStart
+		}
+		var n uint64
+		if n, e = strconv.ParseUint(step, 10, 32); e != nil || n >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("key path value %q is out of range", step)
+		}
+		path = append(path, uint32(n)+offset)
+	}
+	return
+}
+
+// formatPath formats the steps of a derivation path, each preceded by a slash.
+func formatPath(path []uint32) string {
+	var b strings.Builder
+	for _, step := range path {
+		b.WriteByte('/')
+		if step >= hdkeychain.HardenedKeyStart {
+			b.WriteString(strconv.FormatUint(uint64(step-hdkeychain.HardenedKeyStart), 10))
+			b.WriteByte('\'')
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(step), 10))
+		}
+	}
+	return b.String()
+}
+
+// parseKey parses a key expression for the network. Uncompressed public keys are only allowed outside of segregated
+// witness scripts, which this chain does not have, so they are accepted everywhere.
+func parseKey(s string, net *chaincfg.Params) (k *key, e error) {
+	k = &key{}
+	if strings.HasPrefix(s, "[") {
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, errors.New("key origin start '[' character without corresponding end ']'")
+		}
+		steps := strings.Split(s[1:end], "/")
+		if len(steps[0]) != 8 {
+			return nil, fmt.Errorf("fingerprint %q is not 4 bytes", steps[0])
+		}
+		var fingerprint []byte
+		if fingerprint, e = hex.DecodeString(steps[0]); e != nil {
+			return nil, fmt.Errorf("fingerprint %q is not hex", steps[0])
+		}
+		var path []uint32
+		if path, e = parsePath(steps[1:]); e != nil {
+			return nil, e
+		}
+		k.origin = append([]uint32{binary.BigEndian.Uint32(fingerprint)}, path...)
+		s = s[end+1:]
+	}
+	steps := strings.Split(s, "/")
+	if len(steps) == 1 {
+		var b []byte
+		if b, e = hex.DecodeString(s); e == nil {
+			// hybrid public keys parse, but are not standard in scripts
+			if len(b) == 0 || b[0] == 0x06 || b[0] == 0x07 {
+				return nil, fmt.Errorf("pubkey %q is invalid", s)
+			}
+			if k.pub, e = ec.ParsePubKey(b, ec.S256()); e != nil {
+				return nil, fmt.Errorf("pubkey %q is invalid", s)
+			}
+			k.compressed = len(b) == ec.PubKeyBytesLenCompressed
+			return k, nil
+		}
+		if k.wif, e = util.DecodeWIF(s); e == nil {
+			if !k.wif.IsForNet(net) {
+				return nil, fmt.Errorf("private key is not for the %s network", net.Name)
+			}
+			k.pub, k.compressed = k.wif.PrivKey.PubKey(), k.wif.CompressPubKey
+			return k, nil
+		}
+	}
+	encoded := steps[0]
+	if k.ext, e = hdkeychain.NewKeyFromString(encoded); e != nil {
+		return nil, fmt.Errorf("key %q is not valid", encoded)
+	}
+	if !k.ext.IsForNet(net) {
+		return nil, fmt.Errorf("extended key is not for the %s network", net.Name)
+	}
+	steps = steps[1:]
+	if n := len(steps); n > 0 {
+		switch steps[n-1] {
+		case "*":
+			k.wildcard = unhardened
+		case "*'", "*h":
+			k.wildcard = hardened
+		}
+		if k.wildcard != noWildcard {
+			steps = steps[:n-1]
+		}
+	}
+	if k.path, e = parsePath(steps); e != nil {
+		return nil, e
+	}
+	if !k.ext.IsPrivate() && (k.wildcard == hardened || hasHardened(k.path)) {
+		return nil, fmt.Errorf("hardened derivation from extended public key %q requires its private key", encoded)
+	}
+	return k, nil
+}
+
+// hasHardened returns true if any step of the path is hardened.
+func hasHardened(path []uint32) bool {
+	for _, step := range path {
+		if step >= hdkeychain.HardenedKeyStart {
+			return true
+		}
+	}
+	return false
+}
+
+// isRange returns true if the key is derived for each index given to the descriptor.
+func (k *key) isRange() bool {
+	return k.wildcard != noWildcard
+}
+
+// isPrivate returns true if the key expression contains a private key.
+func (k *key) isPrivate() bool {
+	return k.wif != nil || (k.ext != nil && k.ext.IsPrivate())
+}
+
+// String returns the key expression in canonical form, with the private key if it was given and private is set, or
+// else with the public key.
+func (k *key) String(private bool) (s string, e error) {
+	var b strings.Builder
+	if k.origin != nil {
+		fingerprint := make([]byte, 4)
+		binary.BigEndian.PutUint32(fingerprint, k.origin[0])
+		b.WriteString("[" + hex.EncodeToString(fingerprint) + formatPath(k.origin[1:]) + "]")
+	}
+	switch {
+	case k.ext != nil:
+		ext := k.ext
+		if !private {
+			if ext, e = ext.Neuter(); e != nil {
+				return "", e
+			}
+		}
+		b.WriteString(ext.String() + formatPath(k.path))
+		switch k.wildcard {
+		case unhardened:
+			b.WriteString("/*")
+		case hardened:
+			b.WriteString("/*'")
+		}
+	case private && k.wif != nil:
+		b.WriteString(k.wif.String())
+	case k.compressed:
+		b.WriteString(hex.EncodeToString(k.pub.SerializeCompressed()))
+	default:
+		b.WriteString(hex.EncodeToString(k.pub.SerializeUncompressed()))
+	}
+	return b.String(), nil
+}
+
+// derive returns the public key for the index, which is only used if the key is a range, in the form it appears in
+// scripts, with the addresses for the network.
+func (k *key) derive(index uint32, net *chaincfg.Params) (pub *btcaddr.PubKey, e error) {
+	if k.ext == nil {
+		serialized := k.pub.SerializeUncompressed()
+		if k.compressed {
+			serialized = k.pub.SerializeCompressed()
+		}
+		return btcaddr.NewPubKey(serialized, net)
+	}
+	path := k.path
+	switch k.wildcard {
+	case unhardened:
+		path = append(path[:len(path):len(path)], index)
+	case hardened:
+		path = append(path[:len(path):len(path)], index+hdkeychain.HardenedKeyStart)
+	}
+	ext := k.ext
+	for _, step := range path {
+		if ext, e = ext.Child(step); e != nil {
+			return nil, e
+		}
+	}
+	var ecPub *ec.PublicKey
+	if ecPub, e = ext.ECPubKey(); e != nil {
+		return nil, e
+	}
+	return btcaddr.NewPubKey(ecPub.SerializeCompressed(), net)
+}
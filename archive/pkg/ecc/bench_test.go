@@ -5,6 +5,7 @@
 package ecc
 
 import (
+	"crypto/ecdsa"
 	"encoding/hex"
 	"testing"
 )
@@ -115,6 +116,31 @@ func BenchmarkSigVerify(b *testing.B) {
 	}
 }
 
+// BenchmarkSigVerifyECDSA benchmarks how long it takes to verify the same
+// signature as BenchmarkSigVerify with ecdsa.Verify, for comparison.
+func BenchmarkSigVerifyECDSA(b *testing.B) {
+	b.StopTimer()
+	pubKey := PublicKey{
+		Curve: S256(),
+		X:     fromHex("d2e670a19c6d753d1a6d8b20bd045df8a08fb162cf508956c31268c6d81ffdab"),
+		Y:     fromHex("ab65528eefbb8057aa85d597258a3fbd481a24633bc9b47a9aa045c91371de52"),
+	}
+	msgHash := fromHex("8de472e2399610baaa7f84840547cd409434e31f5d3bd71e4d947f283874f9c0")
+	sig := Signature{
+		R: fromHex("fef45d2892953aa5bbcdb057b5e98b208f1617a7498af7eb765574e29b5d9c2c"),
+		S: fromHex("d47563f52aac6b04b55de236b7c515eb9311757db01e02cff079c3ca6efb063f"),
+	}
+	if !ecdsa.Verify(pubKey.ToECDSA(), msgHash.Bytes(), sig.R, sig.S) {
+		b.Errorf("Signature failed to verify")
+		return
+	}
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		ecdsa.Verify(pubKey.ToECDSA(), msgHash.Bytes(), sig.R, sig.S)
+	}
+}
+
 // BenchmarkFieldNormalize benchmarks how long it takes the internal field
 // to perform normalization (which includes modular reduction).
 func BenchmarkFieldNormalize(b *testing.B) {
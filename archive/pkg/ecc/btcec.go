@@ -112,6 +112,27 @@ func (curve *KoblitzCurve) fieldJacobianToBigAffine(x, y, z *fieldVal) (*big.Int
 	return x3, y3
 }
 
+// toAffineBatch converts the passed Jacobian points to affine coordinates in
+// place, leaving them with a z value of one, using a single field inversion for
+// all of them.  Points at infinity are left unchanged.
+func toAffineBatch(points []*[3]fieldVal) {
+	zInvs := make([]*fieldVal, len(points))
+	for i, p := range points {
+		zInvs[i] = new(fieldVal).Set(&p[2])
+	}
+	batchInverse(zInvs)
+	var zInv2 fieldVal
+	for i, p := range points {
+		if zInvs[i].IsZero() {
+			continue
+		}
+		zInv2.SquareVal(zInvs[i])                 // zInv2 = Z^-2
+		p[0].Mul(&zInv2).Normalize()              // X = X/Z^2
+		p[1].Mul(zInv2.Mul(zInvs[i])).Normalize() // Y = Y/Z^3
+		p[2].SetInt(1)                            // Z = 1
+	}
+}
+
 // IsOnCurve returns boolean if the point (x,y) is on the curve.
 // Part of the elliptic.Curve interface. This function differs from the
 // crypto/elliptic algorithm since a = 0 not -3.
@@ -762,10 +783,24 @@ func NAF(k []byte) ([]byte, []byte) {
 
 // ScalarMult returns k*(Bx, By) where k is a big endian integer.
 // Part of the elliptic.Curve interface.
+//
+// NOTE: The time taken depends on k, so this must not be used with secret
+// scalars where the timing can be observed.
 func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
 	// Point Q = ∞ (point at infinity).
 	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+	p1x, p1y := curve.bigAffineToField(Bx, By)
+	curve.scalarMultJacobian(p1x, p1y, k, qx, qy, qz)
 
+	// Convert the Jacobian coordinate field values back to affine big.Ints.
+	return curve.fieldJacobianToBigAffine(qx, qy, qz)
+}
+
+// scalarMultJacobian computes k*(p1x, p1y) where k is a big endian integer and
+// stores the result in (qx, qy, qz) as a Jacobian point.  The result is left in
+// Jacobian coordinates so callers that go on to add to it do not pay for a
+// field inversion.
+func (curve *KoblitzCurve) scalarMultJacobian(p1x, p1y *fieldVal, k []byte, qx, qy, qz *fieldVal) {
 	// Decompose K into k1 and k2 in order to halve the number of EC ops.
 	// See Algorithm 3.74 in [GECC].
 	k1, k2, signK1, signK2 := curve.splitK(curve.moduloReduce(k))
@@ -774,7 +809,6 @@ func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big
 	//   k * P = k1 * P + k2 * ϕ(P)
 	//
 	// P1 below is P in the equation, P2 below is ϕ(P) in the equation
-	p1x, p1y := curve.bigAffineToField(Bx, By)
 	p1yNeg := new(fieldVal).NegateVal(p1y, 1)
 	p1z := new(fieldVal).SetInt(1)
 
@@ -859,14 +893,17 @@ func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big
 			k2ByteNeg <<= 1
 		}
 	}
-
-	// Convert the Jacobian coordinate field values back to affine big.Ints.
-	return curve.fieldJacobianToBigAffine(qx, qy, qz)
 }
 
 // ScalarBaseMult returns k*G where G is the base point of the group and k is a
 // big endian integer.
 // Part of the elliptic.Curve interface.
+//
+// This is used with secret scalars when deriving public keys and signing, so
+// the points are looked up from the precomputed table with selectBytePoint,
+// which reads the same memory whatever the value of k.  The point additions
+// still take a shortcut when a byte of k is zero, and the scalar is converted
+// from and to big.Ints, which are not constant time.
 func (curve *KoblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
 	newK := curve.moduloReduce(k)
 	diff := len(curve.bytePoints) - len(newK)
@@ -879,13 +916,48 @@ func (curve *KoblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
 	// expressing k in base-256 which it already sort of is.
 	// Each "digit" in the 8-bit window can be looked up using bytePoints
 	// and added together.
+	var p [3]fieldVal
 	for i, byteVal := range newK {
-		p := curve.bytePoints[diff+i][byteVal]
+		curve.selectBytePoint(diff+i, byteVal, &p)
 		curve.addJacobian(qx, qy, qz, &p[0], &p[1], &p[2], qx, qy, qz)
 	}
 	return curve.fieldJacobianToBigAffine(qx, qy, qz)
 }
 
+// scalarBaseMultJacobian computes k*G where G is the base point of the group
+// and k is a big endian integer, and stores the result in (qx, qy, qz) as a
+// Jacobian point.  Unlike ScalarBaseMult the points are looked up from the
+// precomputed table directly, so this must only be used with public scalars,
+// as when verifying signatures.
+func (curve *KoblitzCurve) scalarBaseMultJacobian(k []byte, qx, qy, qz *fieldVal) {
+	newK := curve.moduloReduce(k)
+	diff := len(curve.bytePoints) - len(newK)
+	for i, byteVal := range newK {
+		p := curve.bytePoints[diff+i][byteVal]
+		curve.addJacobian(qx, qy, qz, &p[0], &p[1], &p[2], qx, qy, qz)
+	}
+}
+
+// selectBytePoint sets p to the point for the given byte value in the given
+// window of the precomputed table.  Every point of the window is read and
+// masked in, so neither the memory accessed nor the time taken depend on the
+// byte value.
+func (curve *KoblitzCurve) selectBytePoint(window int, byteVal byte, p *[3]fieldVal) {
+	p[0].Zero()
+	p[1].Zero()
+	p[2].Zero()
+	for i := range curve.bytePoints[window] {
+		// The mask is all ones when i is the byte value and zero otherwise.
+		mask := -(((uint32(i) ^ uint32(byteVal)) - 1) >> 31)
+		point := &curve.bytePoints[window][i]
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 10; k++ {
+				p[j].n[k] |= point[j].n[k] & mask
+			}
+		}
+	}
+}
+
 // QPlus1Div4 returns the (P+1)/4 constant for the curve for use in calculating
 // square roots via exponentiation.
 //
@@ -596,6 +596,47 @@ func TestBaseMultVerify(t *testing.T) {
 	}
 }
 
+// TestBytePointsAffine ensures that the precomputed points used for scalar base
+// multiplication were converted to affine coordinates on the curve when they
+// were loaded.
+func TestBytePointsAffine(t *testing.T) {
+	s256 := S256()
+	for window := range s256.bytePoints {
+		for i := range s256.bytePoints[window] {
+			p := &s256.bytePoints[window][i]
+			if i == 0 {
+				if !p[2].IsZero() {
+					t.Errorf("window %d: point 0 is not the point at infinity", window)
+				}
+				continue
+			}
+			if !p[2].Equals(fieldOne) || !isJacobianOnS256Curve(&p[0], &p[1], &p[2]) {
+				t.Errorf("window %d: point %d is not an affine point on the curve", window, i)
+			}
+		}
+	}
+}
+
+// TestScalarBaseMultJacobian ensures that scalar base multiplication with the
+// table looked up directly gives the same point as ScalarBaseMult, which looks
+// the points up in constant time.
+func TestScalarBaseMultJacobian(t *testing.T) {
+	s256 := S256()
+	for i := 0; i < 100; i++ {
+		data := make([]byte, 1+i%40)
+		if _, err := rand.Read(data); err != nil {
+			t.Fatalf("failed to read random data for %d", i)
+		}
+		var x, y, z fieldVal
+		s256.scalarBaseMultJacobian(data, &x, &y, &z)
+		gotX, gotY := s256.fieldJacobianToBigAffine(&x, &y, &z)
+		wantX, wantY := s256.ScalarBaseMult(data)
+		if gotX.Cmp(wantX) != 0 || gotY.Cmp(wantY) != 0 {
+			t.Errorf("%d: bad output for %X: got (%X, %X), want (%X, %X)", i, data, gotX, gotY, wantX, wantY)
+		}
+	}
+}
+
 func TestScalarMult(t *testing.T) {
 	tests := []struct {
 		x  string
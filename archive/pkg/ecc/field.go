@@ -1240,6 +1240,41 @@ func (f *fieldVal) Inverse() *fieldVal {
 	return f.Mul(&a45)                             // f = a^(2^256 - 4294968275) = a^(p-2)
 }
 
+// batchInverse replaces each of the passed field values with its modular
+// multiplicative inverse.  It uses Montgomery's trick, which takes a single
+// inversion and three multiplications per value, rather than an inversion per
+// value.  Zero values have no inverse and are left as zero.
+//
+// The field values are normalized on return.
+func batchInverse(vals []*fieldVal) {
+	// products[i] is the product of the nonzero values before index i.
+	products := make([]fieldVal, len(vals))
+	var acc fieldVal
+	acc.SetInt(1)
+	for i, val := range vals {
+		products[i].Set(&acc)
+		if val.Normalize().IsZero() {
+			continue
+		}
+		acc.Mul(val)
+	}
+
+	// acc is now the inverse of the product of all of the nonzero values.
+	// Going backwards, multiplying it by the product of the values before a
+	// value gives the inverse of that value, and multiplying it by the value
+	// removes the value from it for the next one.
+	acc.Inverse()
+	var inv fieldVal
+	for i := len(vals) - 1; i >= 0; i-- {
+		if vals[i].IsZero() {
+			continue
+		}
+		inv.Mul2(&acc, &products[i])
+		acc.Mul(vals[i])
+		vals[i].Set(&inv).Normalize()
+	}
+}
+
 // SqrtVal computes the square root of x modulo the curve's prime, and stores
 // the result in f. The square root is computed via exponentiation of x by the
 // value Q = (P+1)/4 using the curve's precomputed big-endian representation of
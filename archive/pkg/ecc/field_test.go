@@ -824,6 +824,28 @@ func TestInverse(t *testing.T) {
 	}
 }
 
+// TestBatchInverse ensures that inverting field values together gives the same
+// results as inverting each of them, and leaves zero values as zero.
+func TestBatchInverse(t *testing.T) {
+	vals := make([]*fieldVal, 20)
+	want := make([]*fieldVal, len(vals))
+	for i := range vals {
+		val := randFieldVal(t)
+		if i%7 == 3 {
+			val.Zero()
+		}
+		vals[i] = new(fieldVal).Set(&val)
+		want[i] = new(fieldVal).Set(&val).Inverse().Normalize()
+	}
+	batchInverse(vals)
+	for i := range vals {
+		if !vals[i].Equals(want[i]) {
+			t.Errorf("batchInverse #%d wrong result\ngot: %v\nwant: %v", i, vals[i], want[i])
+		}
+	}
+	batchInverse(nil)
+}
+
 // randFieldVal returns a random, normalized element in the field.
 func randFieldVal(t *testing.T) fieldVal {
 	var b [32]byte
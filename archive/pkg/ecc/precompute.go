@@ -64,6 +64,16 @@ func loadS256BytePoints() error {
 			}
 		}
 	}
+
+	// Convert the points to affine coordinates, as adding a point with a z
+	// value of one is much faster than adding a point in Jacobian coordinates.
+	points := make([]*[3]fieldVal, 0, len(bytePoints)*len(bytePoints[0]))
+	for byteNum := range bytePoints {
+		for i := range bytePoints[byteNum] {
+			points = append(points, &bytePoints[byteNum][i])
+		}
+	}
+	toAffineBatch(points)
 	secp256k1.bytePoints = &bytePoints
 	return nil
 }
@@ -71,12 +71,72 @@ func (sig *Signature) Serialize() []byte {
 	return b
 }
 
-// Verify calls ecdsa.Verify to verify the signature of hash using the public
-// key.  It returns true if the signature is valid, false otherwise.
+// Verify verifies the signature of hash using the public key.  It returns true
+// if the signature is valid, false otherwise.
+//
+// Signatures for keys on the secp256k1 curve are verified with verifySig, which
+// gives the same result as ecdsa.Verify in a fraction of the time.  Keys on
+// other curves are passed to ecdsa.Verify.
 func (sig *Signature) Verify(hash []byte, pubKey *PublicKey) bool {
+	if curve, ok := pubKey.Curve.(*KoblitzCurve); ok {
+		return verifySig(curve, pubKey, hash, sig.R, sig.S)
+	}
 	return ecdsa.Verify(pubKey.ToECDSA(), hash, sig.R, sig.S)
 }
 
+// verifySig verifies the signature (r, s) of hash using the public key as
+// described in section 4.1.4 of SEC 1 Ver 2.0.  The point u1*G + u2*Q is kept
+// in Jacobian coordinates throughout, with u1*G taken from the precomputed table
+// of the curve, and its x coordinate is compared against r without converting
+// it to affine coordinates, so no field inversion is needed.
+//
+// NOTE: Only public values go into a signature verification, so the time taken
+// depending on them does not matter.
+func verifySig(curve *KoblitzCurve, pubKey *PublicKey, hash []byte, r, s *big.Int) bool {
+	// Fail if r and s are not in [1, N-1].
+	N := curve.N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+
+	// u1 = e/s mod N and u2 = r/s mod N.
+	e := hashToInt(hash, curve)
+	w := new(big.Int).ModInverse(s, N)
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, N)
+
+	// X = u1*G + u2*Q.
+	var u1x, u1y, u1z, u2x, u2y, u2z, x, y, z fieldVal
+	curve.scalarBaseMultJacobian(u1.Bytes(), &u1x, &u1y, &u1z)
+	qx, qy := curve.bigAffineToField(pubKey.X, pubKey.Y)
+	curve.scalarMultJacobian(qx, qy, u2.Bytes(), &u2x, &u2y, &u2z)
+	curve.addJacobian(&u1x, &u1y, &u1z, &u2x, &u2y, &u2z, &x, &y, &z)
+	x.Normalize()
+	y.Normalize()
+	z.Normalize()
+	if (x.IsZero() && y.IsZero()) || z.IsZero() {
+		return false
+	}
+
+	// The affine x coordinate of X is x/z^2, which is reduced modulo N to
+	// compare it to r.  As it is less than P, which is only a little larger
+	// than N, it is either r or r+N, so check whether r*z^2 or (r+N)*z^2 is x.
+	var zz, rzz, fr fieldVal
+	zz.SquareVal(&z)
+	fr.SetByteSlice(r.Bytes())
+	if rzz.Mul2(&fr, &zz).Normalize().Equals(&x) {
+		return true
+	}
+	rn := new(big.Int).Add(r, N)
+	if rn.Cmp(curve.P) >= 0 {
+		return false
+	}
+	fr.SetByteSlice(rn.Bytes())
+	return rzz.Mul2(&fr, &zz).Normalize().Equals(&x)
+}
+
 // IsEqual compares this Signature instance to the one passed, returning true
 // if both Signatures are equivalent. A signature is equivalent to another, if
 // they both have the same scalar value for R and S.
@@ -6,6 +6,7 @@ package ecc
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -706,6 +707,49 @@ func TestRFC6979(t *testing.T) {
 	}
 }
 
+// TestVerifyMatchesECDSA ensures that Verify gives the same result as
+// ecdsa.Verify for valid signatures and for signatures with a changed hash, r or
+// s.
+func TestVerifyMatchesECDSA(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		priv, err := NewPrivateKey(S256())
+		if err != nil {
+			t.Fatalf("%d: failed to generate key: %v", i, err)
+		}
+		pub := priv.PubKey()
+		hash := sha256.Sum256([]byte(fmt.Sprintf("message %d", i)))
+		sig, err := priv.Sign(hash[:])
+		if err != nil {
+			t.Fatalf("%d: failed to sign: %v", i, err)
+		}
+		otherHash := sha256.Sum256([]byte(fmt.Sprintf("other message %d", i)))
+		one := big.NewInt(1)
+		tests := []struct {
+			name string
+			hash []byte
+			sig  *Signature
+		}{
+			{"valid", hash[:], sig},
+			{"other hash", otherHash[:], sig},
+			{"r+1", hash[:], &Signature{R: new(big.Int).Add(sig.R, one), S: sig.S}},
+			{"s+1", hash[:], &Signature{R: sig.R, S: new(big.Int).Add(sig.S, one)}},
+			{"negated s", hash[:], &Signature{R: sig.R, S: new(big.Int).Sub(S256().N, sig.S)}},
+			{"zero r", hash[:], &Signature{R: new(big.Int), S: sig.S}},
+			{"r of N", hash[:], &Signature{R: S256().N, S: sig.S}},
+		}
+		for _, test := range tests {
+			got := test.sig.Verify(test.hash, pub)
+			want := ecdsa.Verify(pub.ToECDSA(), test.hash, test.sig.R, test.sig.S)
+			if got != want {
+				t.Errorf("%d %s: got %v, want %v", i, test.name, got, want)
+			}
+			if test.name == "valid" && !got {
+				t.Errorf("%d: valid signature failed to verify", i)
+			}
+		}
+	}
+}
+
 func TestSignatureIsEqual(t *testing.T) {
 	sig1 := &Signature{
 		R: fromHex("0082235e21a2300022738dabb8e1bbd9d19cfb1e7ab8c30a23b0afbb8d178abcf3"),
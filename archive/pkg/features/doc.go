@@ -0,0 +1,9 @@
+/*
+Package features holds the flags that switch optional subsystems of the node on and off while it runs, so that
+experimental code can ship disabled and be enabled selectively.
+
+Subsystems gated by a flag call Watch with a function that switches them, which is called with the state the node
+starts with and again whenever the flag is set with the setfeature RPC. The state of the flags is saved in the database
+so it is kept across restarts.
+*/
+package features
@@ -0,0 +1,141 @@
+package features
+
+import (
+	js "encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Names of the features that can be switched on and off.
+const (
+	// FeeEstimatorAutoBias raises fee estimates when transactions paying them confirm slower than predicted.
+	FeeEstimatorAutoBias = "feeestimatorautobias"
+)
+
+// Feature describes a feature that can be switched on and off.
+type Feature struct {
+	Name        string
+	Description string
+	// Experimental features are reported in the getnetworkinfo warnings while they are on.
+	Experimental bool
+}
+
+// Known are the features that can be switched on and off.
+var Known = []Feature{
+	{
+		Name:         FeeEstimatorAutoBias,
+		Description:  "raise fee estimates when transactions paying them confirm slower than predicted",
+		Experimental: true,
+	},
+}
+
+// DatabaseKey is the key that the state of the flags is stored under in the database metadata.
+var DatabaseKey = []byte("features")
+
+// Flags holds which of the known features are on and tells the subsystems they gate when they are switched. It is safe
+// for concurrent access.
+type Flags struct {
+	mx       sync.Mutex
+	on       map[string]bool
+	watchers map[string][]func(on bool)
+}
+
+// New returns flags with the named features on and the rest off. An error is returned if any of them are not known.
+func New(enabled []string) (f *Flags, e error) {
+	f = &Flags{
+		on:       make(map[string]bool),
+		watchers: make(map[string][]func(on bool)),
+	}
+	for _, name := range enabled {
+		if _, ok := lookup(name); !ok {
+			return nil, fmt.Errorf("unknown feature %s", name)
+		}
+		f.on[name] = true
+	}
+	return
+}
+
+// lookup returns the known feature with the name.
+func lookup(name string) (Feature, bool) {
+	for _, ft := range Known {
+		if ft.Name == name {
+			return ft, true
+		}
+	}
+	return Feature{}, false
+}
+
+// Enabled returns whether the named feature is on.
+func (f *Flags) Enabled(name string) bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	return f.on[name]
+}
+
+// Set switches the named feature on or off and calls the functions watching it with the new state.
+func (f *Flags) Set(name string, on bool) (e error) {
+	if _, ok := lookup(name); !ok {
+		return fmt.Errorf("unknown feature %s", name)
+	}
+	f.mx.Lock()
+	f.on[name] = on
+	watchers := f.watchers[name]
+	f.mx.Unlock()
+	for _, fn := range watchers {
+		fn(on)
+	}
+	return
+}
+
+// Watch calls fn with the current state of the named feature and again each time it is switched.
+func (f *Flags) Watch(name string, fn func(on bool)) {
+	f.mx.Lock()
+	f.watchers[name] = append(f.watchers[name], fn)
+	on := f.on[name]
+	f.mx.Unlock()
+	fn(on)
+}
+
+// Experimental returns the names of the experimental features that are on, sorted.
+func (f *Flags) Experimental() (names []string) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	for _, ft := range Known {
+		if ft.Experimental && f.on[ft.Name] {
+			names = append(names, ft.Name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+// Save serializes the state of the flags so it can be put back with Restore.
+func (f *Flags) Save() []byte {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	data, e := js.Marshal(f.on)
+	if e != nil {
+		F.Ln("failed to serialize feature flags", e)
+	}
+	return data
+}
+
+// Restore sets the flags to the state serialized by Save, which takes precedence over the features the flags were
+// created with. Features that are no longer known are ignored.
+func (f *Flags) Restore(data []byte) (e error) {
+	var saved map[string]bool
+	if e = js.Unmarshal(data, &saved); e != nil {
+		return
+	}
+	for name, on := range saved {
+		if _, ok := lookup(name); !ok {
+			D.Ln("ignoring saved flag of unknown feature", name)
+			continue
+		}
+		if e = f.Set(name, on); e != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package features
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFlags ensures flags start as configured, tell their watchers when they are switched and keep their state through
+// Save and Restore.
+func TestFlags(t *testing.T) {
+	if _, e := New([]string{"nosuchfeature"}); e == nil {
+		t.Fatal("expected an error for an unknown feature")
+	}
+	f, e := New([]string{FeeEstimatorAutoBias})
+	if e != nil {
+		t.Fatal(e)
+	}
+	var seen []bool
+	f.Watch(FeeEstimatorAutoBias, func(on bool) { seen = append(seen, on) })
+	if want := []string{FeeEstimatorAutoBias}; !reflect.DeepEqual(f.Experimental(), want) {
+		t.Fatalf("got experimental features %v, want %v", f.Experimental(), want)
+	}
+	if e = f.Set(FeeEstimatorAutoBias, false); e != nil {
+		t.Fatal(e)
+	}
+	if e = f.Set("nosuchfeature", true); e == nil {
+		t.Fatal("expected an error setting an unknown feature")
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(seen, want) {
+		t.Fatalf("watcher saw %v, want %v", seen, want)
+	}
+	if len(f.Experimental()) != 0 {
+		t.Fatalf("got experimental features %v, want none", f.Experimental())
+	}
+	// The saved state takes precedence over the state the flags are created with.
+	restored, e := New([]string{FeeEstimatorAutoBias})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = restored.Restore(f.Save()); e != nil {
+		t.Fatal(e)
+	}
+	if restored.Enabled(FeeEstimatorAutoBias) {
+		t.Fatal("restored flags have the feature on, want off")
+	}
+	if e = restored.Restore([]byte(`{"removedfeature":true}`)); e != nil {
+		t.Fatalf("unexpected error restoring a flag of an unknown feature: %v", e)
+	}
+}
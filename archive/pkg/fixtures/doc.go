@@ -0,0 +1,12 @@
+// Package fixtures generates a populated regression test chain and the wallets that transact on it, for benchmarks and
+// demonstrations of the GUI that need a realistic dataset that is the same every time it is made.
+//
+// Everything in a fixture follows from its Config: the seeds of the wallets and the transactions, block times,
+// algorithms and re-orgs of the chain are all drawn from its seed, so the chain made from a Config always has the same
+// blocks. The wallets are written with the address and transaction managers directly, as a synced wallet would have
+// recorded the chain, and the manifest written alongside them records what they should hold.
+//
+// A fixture directory holds the block database of a node in node/regtest, each wallet in wallet<n>/regtest/wallet.db,
+// and the manifest in fixture.json, so the node and wallets can be run on it with the node directory as the data
+// directory of the node and the wallet file set to one of the wallets.
+package fixtures
@@ -0,0 +1,198 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/fork"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// ManifestName is the name of the file in a fixture directory that describes the fixture.
+const ManifestName = "fixture.json"
+
+// accountNames are the names given to the accounts made in each wallet besides the default account, in turn.
+var accountNames = []string{"savings", "spending", "business", "travel", "household", "donations", "trading", "gifts"}
+
+// Config is the shape of a fixture. Everything in the fixture follows from it, so the same Config always makes the same
+// chain and wallets.
+type Config struct {
+	// Dir is the directory the fixture is written to, which must not already exist.
+	Dir string
+	// Seed seeds the wallets and every choice made in building the chain.
+	Seed int64
+	// Blocks is the height of the chain made.
+	Blocks int32
+	// Wallets is the number of wallets made, and Accounts the number of accounts made in each of them besides the
+	// default account.
+	Wallets  int
+	Accounts int
+	// MaxTxsPerBlock is the most transactions between the wallets put in a block.
+	MaxTxsPerBlock int
+	// Reorgs is the number of times a longer branch replaces the tip of the chain, which is at most MaxReorgDepth
+	// blocks deep.
+	Reorgs        int
+	MaxReorgDepth int32
+	// PrivPassphrase is the private passphrase of the wallets. Their public passphrase is empty, as is the default of
+	// the wallet.
+	PrivPassphrase []byte
+	// Scrypt is the key derivation the passphrases of the wallets are stretched with, the default of the address
+	// manager if it is nil.
+	Scrypt *waddrmgr.ScryptOptions
+}
+
+// DefaultConfig returns the Config of the standard fixture, which has a few thousand transactions between the accounts
+// of two wallets.
+func DefaultConfig(dir string) *Config {
+	return &Config{
+		Dir:            dir,
+		Seed:           1,
+		Blocks:         1200,
+		Wallets:        2,
+		Accounts:       3,
+		MaxTxsPerBlock: 8,
+		Reorgs:         5,
+		MaxReorgDepth:  6,
+		PrivPassphrase: []byte("password"),
+	}
+}
+
+// Manifest describes a fixture. It is written to the fixture directory so that benchmarks and tests can check what they
+// read from the fixture against it.
+type Manifest struct {
+	Seed           int64  `json:"seed"`
+	Network        string `json:"network"`
+	MaxTxsPerBlock int    `json:"maxtxsperblock"`
+	// Height and Tip are the height and hash of the best block of the chain.
+	Height int32  `json:"height"`
+	Tip    string `json:"tip"`
+	// NodeDir is the data directory of the node holding the chain, relative to the fixture directory.
+	NodeDir string `json:"nodedir"`
+	// Transactions is the number of transactions in the best chain that are not coinbases.
+	Transactions int              `json:"transactions"`
+	Reorgs       []ReorgManifest  `json:"reorgs"`
+	Wallets      []WalletManifest `json:"wallets"`
+}
+
+// ReorgManifest describes a re-org of the chain of a fixture.
+type ReorgManifest struct {
+	// ForkHeight is the height of the last block the branches have in common.
+	ForkHeight int32 `json:"forkheight"`
+	// Orphaned are the hashes of the blocks that were replaced, from the lowest.
+	Orphaned []string `json:"orphaned"`
+}
+
+// WalletManifest describes a wallet of a fixture.
+type WalletManifest struct {
+	// File is the wallet database, relative to the fixture directory.
+	File string `json:"file"`
+	// Transactions is the number of transactions in the best chain paying to or spending from the wallet.
+	Transactions int               `json:"transactions"`
+	Accounts     []AccountManifest `json:"accounts"`
+}
+
+// AccountManifest describes an account of a wallet of a fixture. The amounts are in satoshis.
+type AccountManifest struct {
+	Name   string `json:"name"`
+	Number uint32 `json:"number"`
+	// Addresses is the number of addresses made in the account, of both branches.
+	Addresses int `json:"addresses"`
+	// Unspent is the number of unspent outputs of the account, and Balance their total value, of which Immature is in
+	// coinbases that cannot be spent yet.
+	Unspent  int   `json:"unspent"`
+	Balance  int64 `json:"balance"`
+	Immature int64 `json:"immature"`
+}
+
+// Generate writes the fixture of the Config and returns its manifest.
+func Generate(cfg *Config) (m *Manifest, e error) {
+	params := chaincfg.RegressionTestParams
+	if e = cfg.check(&params); e != nil {
+		return
+	}
+	if _, e = os.Stat(cfg.Dir); !os.IsNotExist(e) {
+		return nil, fmt.Errorf("fixture directory %s already exists", cfg.Dir)
+	}
+	if e = os.MkdirAll(cfg.Dir, 0700); E.Chk(e) {
+		return
+	}
+	// the node uses the difficulty and algorithm schedule of the test networks on the regression test network
+	fork.IsTestnet = true
+	g := &generator{
+		cfg:    cfg,
+		params: &params,
+		rng:    rand.New(rand.NewSource(cfg.Seed)),
+		keys:   make(map[string]*key),
+		owners: make(map[wire.OutPoint]int),
+	}
+	defer g.close()
+	if e = g.open(); E.Chk(e) {
+		return
+	}
+	if e = g.run(); E.Chk(e) {
+		return
+	}
+	m = g.manifest()
+	var b []byte
+	if b, e = json.MarshalIndent(m, "", "  "); E.Chk(e) {
+		return
+	}
+	if e = ioutil.WriteFile(filepath.Join(cfg.Dir, ManifestName), b, 0600); E.Chk(e) {
+		return
+	}
+	I.F("wrote fixture of %d blocks and %d transactions to %s", m.Height, m.Transactions, cfg.Dir)
+	return
+}
+
+// ReadManifest reads the manifest of the fixture in dir.
+func ReadManifest(dir string) (m *Manifest, e error) {
+	var b []byte
+	if b, e = ioutil.ReadFile(filepath.Join(dir, ManifestName)); e != nil {
+		return
+	}
+	m = &Manifest{}
+	if e = json.Unmarshal(b, m); e != nil {
+		return nil, e
+	}
+	return
+}
+
+// check returns an error if the Config cannot make a fixture on the network.
+func (cfg *Config) check(params *chaincfg.Params) error {
+	switch {
+	case cfg.Dir == "":
+		return errors.New("no fixture directory was given")
+	case cfg.Wallets < 1:
+		return errors.New("a fixture needs at least one wallet")
+	case cfg.Accounts < 0 || cfg.Accounts > len(accountNames):
+		return fmt.Errorf("wallets can have at most %d accounts besides the default account", len(accountNames))
+	case cfg.MaxTxsPerBlock < 0:
+		return errors.New("the number of transactions in a block cannot be negative")
+	case len(cfg.PrivPassphrase) == 0:
+		return errors.New("the wallets need a private passphrase")
+	case cfg.Reorgs < 0:
+		return errors.New("the number of re-orgs cannot be negative")
+	}
+	maturity := int32(params.CoinbaseMaturity)
+	if cfg.Reorgs > 0 {
+		// The transactions of the replaced blocks are mined again in the branch replacing them, which is only possible
+		// if none of them spends one of their coinbases.
+		if cfg.MaxReorgDepth < 1 || cfg.MaxReorgDepth >= maturity {
+			return fmt.Errorf("re-orgs must be from 1 to %d blocks deep", maturity-1)
+		}
+		if int(cfg.Blocks-1-maturity-cfg.MaxReorgDepth) < 2*cfg.Reorgs {
+			return fmt.Errorf("a chain of %d blocks is too short for %d re-orgs", cfg.Blocks, cfg.Reorgs)
+		}
+	}
+	if cfg.Blocks <= maturity {
+		return fmt.Errorf("a chain of %d blocks has no coinbases that can be spent", cfg.Blocks)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package fixtures
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+func testConfig(dir string) *Config {
+	cfg := DefaultConfig(dir)
+	cfg.Blocks = 130
+	cfg.Accounts = 1
+	cfg.MaxTxsPerBlock = 4
+	cfg.Reorgs = 2
+	cfg.MaxReorgDepth = 3
+	cfg.Scrypt = &waddrmgr.ScryptOptions{N: 16, R: 8, P: 1}
+	return cfg
+}
+
+// TestGenerate ensures a fixture is made as its Config describes, that the same Config makes the same fixture, and that
+// the wallets of the fixture are synced to the tip of its chain.
+func TestGenerate(t *testing.T) {
+	dir, e := ioutil.TempDir("", "fixtures")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	m, e := Generate(testConfig(filepath.Join(dir, "a")))
+	if e != nil {
+		t.Fatalf("Generate: %v", e)
+	}
+	if m.Height != 130 || m.Transactions == 0 || len(m.Reorgs) != 2 || len(m.Wallets) != 2 {
+		t.Fatalf("made height %d with %d transactions, %d re-orgs and %d wallets", m.Height, m.Transactions,
+			len(m.Reorgs), len(m.Wallets))
+	}
+	again, e := Generate(testConfig(filepath.Join(dir, "b")))
+	if e != nil {
+		t.Fatalf("Generate: %v", e)
+	}
+	if !reflect.DeepEqual(m, again) {
+		t.Errorf("the same Config made different fixtures:\n%+v\n%+v", m, again)
+	}
+	read, e := ReadManifest(filepath.Join(dir, "a"))
+	if e != nil {
+		t.Fatalf("ReadManifest: %v", e)
+	}
+	if !reflect.DeepEqual(m, read) {
+		t.Errorf("read manifest %+v, want %+v", read, m)
+	}
+	if _, e = Generate(testConfig(filepath.Join(dir, "a"))); e == nil {
+		t.Error("expected an error making a fixture in a directory that exists")
+	}
+	db, e := walletdb.Open("bdb", filepath.Join(dir, "a", m.Wallets[0].File))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer db.Close()
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			mgr, e := waddrmgr.Open(tx.ReadBucket(waddrmgrNamespaceKey), []byte{}, &chaincfg.RegressionTestParams)
+			if e != nil {
+				return e
+			}
+			defer mgr.Close()
+			synced := mgr.SyncedTo()
+			if tip, _ := chainhash.NewHashFromStr(m.Tip); synced.Height != m.Height || synced.Hash != *tip {
+				t.Errorf("wallet is synced to %d %s, want %d %s", synced.Height, synced.Hash, m.Height, m.Tip)
+			}
+			return nil
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+}
@@ -0,0 +1,549 @@
+package fixtures
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/bits"
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/database/blockdb"
+	_ "github.com/p9c/pod/pkg/database/ffldb"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/fork"
+	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/txsizes"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+const (
+	// dbType is the type of the block database of the fixture, the default of the node.
+	dbType = "ffldb"
+	// maxInputs is the most outputs a transaction spends.
+	maxInputs = 3
+)
+
+// utxo is an output paying to a wallet of the fixture that has not been spent by the generator.
+type utxo struct {
+	op       wire.OutPoint
+	value    int64
+	key      *key
+	height   int32
+	coinbase bool
+}
+
+// generator builds the chain of a fixture and records it in the wallets.
+type generator struct {
+	cfg     *Config
+	params  *chaincfg.Params
+	rng     *rand.Rand
+	db      database.DB
+	chain   *blockchain.BlockChain
+	wallets []*wallet
+	// external is paid by the transactions that send coins out of the wallets.
+	external *key
+	// keys are the addresses made by the generator by their output script.
+	keys map[string]*key
+	// owners are the wallets the outputs paying to one of them belong to, which are kept after they are spent so the
+	// transactions spending them are known to be of the wallet.
+	owners map[wire.OutPoint]int
+	// blocks is the best chain by height.
+	blocks []*block.Block
+	reorgs []ReorgManifest
+}
+
+// open makes the block database and the wallets of the fixture.
+func (g *generator) open() (e error) {
+	path := filepath.Join(g.cfg.Dir, "node", g.params.Name, blockdb.NamePrefix+"_"+dbType)
+	if g.db, e = database.Create(dbType, path, g.params.Net); E.Chk(e) {
+		return
+	}
+	if g.chain, e = blockchain.New(
+		&blockchain.Config{
+			DB:          g.db,
+			ChainParams: g.params,
+			TimeSource:  blockchain.NewMedianTime(),
+			SigCache:    txscript.NewSigCache(1000),
+		},
+	); E.Chk(e) {
+		return
+	}
+	var genesis *block.Block
+	if genesis, e = g.chain.BlockByHeight(0); E.Chk(e) {
+		return
+	}
+	g.blocks = []*block.Block{genesis}
+	for i := 0; i < g.cfg.Wallets; i++ {
+		var w *wallet
+		w, e = g.openWallet(i)
+		if w != nil {
+			g.wallets = append(g.wallets, w)
+		}
+		if e != nil {
+			return
+		}
+	}
+	seed := sha256.Sum256(walletSeed(g.cfg.Seed, -1))
+	g.external = &key{wallet: -1}
+	g.external.priv, _ = ecc.PrivKeyFromBytes(ecc.S256(), seed[:])
+	if g.external.addr, e = btcaddr.NewPubKeyHash(
+		btcaddr.Hash160(g.external.priv.PubKey().SerializeCompressed()), g.params,
+	); E.Chk(e) {
+		return
+	}
+	g.external.script, e = txscript.PayToAddrScript(g.external.addr)
+	return
+}
+
+// close closes the block database and the wallets.
+func (g *generator) close() {
+	for _, w := range g.wallets {
+		w.close()
+	}
+	if g.db != nil {
+		if e := g.db.Close(); E.Chk(e) {
+		}
+	}
+}
+
+// tip returns the best block of the chain.
+func (g *generator) tip() *block.Block {
+	return g.blocks[len(g.blocks)-1]
+}
+
+// run builds the chain up to the height of the Config, with the re-orgs spread evenly along it once the first
+// coinbases can be spent.
+func (g *generator) run() (e error) {
+	lo := int32(g.params.CoinbaseMaturity) + g.cfg.MaxReorgDepth
+	var reorgAt []int32
+	if g.cfg.Reorgs > 0 {
+		// each re-org is in its own stretch of the chain, at least two blocks after the one before it, as a re-org
+		// lengthens the chain by a block
+		step := (g.cfg.Blocks - 1 - lo) / int32(g.cfg.Reorgs)
+		for i := int32(0); i < int32(g.cfg.Reorgs); i++ {
+			reorgAt = append(reorgAt, lo+i*step+g.rng.Int31n(step-1))
+		}
+	}
+	for g.tip().Height() < g.cfg.Blocks {
+		height := g.tip().Height() + 1
+		var txs []*wire.MsgTx
+		var fees int64
+		if txs, fees, e = g.makeTxs(height); E.Chk(e) {
+			return
+		}
+		var payee *key
+		if payee, e = g.randomKey(); E.Chk(e) {
+			return
+		}
+		version := g.randomVersion(height)
+		var blk *block.Block
+		if blk, e = g.makeBlock(g.tip(), g.nextTime(g.tip(), height), version, 0, txs, fees, payee, 0); E.Chk(e) {
+			return
+		}
+		if e = g.process(blk); E.Chk(e) {
+			return
+		}
+		if e = g.connect(blk); E.Chk(e) {
+			return
+		}
+		if len(reorgAt) > 0 && height >= reorgAt[0] {
+			reorgAt = reorgAt[1:]
+			if e = g.reorg(1 + g.rng.Int31n(g.cfg.MaxReorgDepth)); E.Chk(e) {
+				return
+			}
+		}
+	}
+	return
+}
+
+// randomVersion returns the version of the block at the height, which picks one of the algorithms of that height.
+func (g *generator) randomVersion(height int32) int32 {
+	versions := fork.GetAlgoVerSlice(height)
+	return versions[g.rng.Intn(len(versions))]
+}
+
+// nextTime returns the time of the block after prev, a random spacing of up to twice the target time between blocks
+// after it.
+func (g *generator) nextTime(prev *block.Block, height int32) time.Time {
+	spacing := fork.GetTargetTimePerBlock(height)
+	return prev.WireBlock().Header.Timestamp.Add(time.Duration(1+g.rng.Int63n(2*spacing)) * time.Second)
+}
+
+// makeBlock makes and solves the block on top of prev with the transactions, paying the subsidy and fees to the payee.
+// If diffBits is zero the difficulty the chain requires of the block on top of its tip is used. The tag sets apart
+// the coinbases of blocks at the same height on different branches.
+func (g *generator) makeBlock(
+	prev *block.Block, t time.Time, version int32, diffBits uint32, txs []*wire.MsgTx, fees int64, payee *key, tag int64,
+) (blk *block.Block, e error) {
+	height := prev.Height() + 1
+	if diffBits == 0 {
+		if diffBits, e = g.chain.CalcNextRequiredDifficulty(fork.GetAlgoName(version, height)); E.Chk(e) {
+			return
+		}
+	}
+	var script []byte
+	if script, e = txscript.NewScriptBuilder().AddInt64(int64(height)).AddInt64(tag).Script(); E.Chk(e) {
+		return
+	}
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex),
+			SignatureScript:  script,
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	coinbase.AddTxOut(
+		&wire.TxOut{
+			Value:    blockchain.CalcBlockSubsidy(height, g.params, version) + fees,
+			PkScript: payee.script,
+		},
+	)
+	utilTxs := []*util.Tx{util.NewTx(coinbase)}
+	for _, tx := range txs {
+		utilTxs = append(utilTxs, util.NewTx(tx))
+	}
+	merkles := blockchain.BuildMerkleTreeStore(utilTxs, false)
+	var b wire.Block
+	b.Header = wire.BlockHeader{
+		Version:    version,
+		PrevBlock:  *prev.Hash(),
+		MerkleRoot: *merkles.GetRoot(),
+		Timestamp:  t,
+		Bits:       diffBits,
+	}
+	for _, tx := range utilTxs {
+		if e = b.AddTransaction(tx.MsgTx()); E.Chk(e) {
+			return
+		}
+	}
+	if e = solve(&b.Header, height); E.Chk(e) {
+		return
+	}
+	blk = block.NewBlock(&b)
+	blk.SetHeight(height)
+	return
+}
+
+// solve finds the first nonce that makes the proof of work hash of the header meet its target.
+func solve(header *wire.BlockHeader, height int32) error {
+	target := bits.CompactToBig(header.Bits)
+	for nonce := uint32(0); ; nonce++ {
+		header.Nonce = nonce
+		hash := header.BlockHashWithAlgos(height)
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return nil
+		}
+		if nonce == ^uint32(0) {
+			return errors.New("no nonce solves the block")
+		}
+	}
+}
+
+// process adds the block to the chain, which it must extend.
+func (g *generator) process(blk *block.Block) (e error) {
+	var isMainChain bool
+	if isMainChain, _, e = g.chain.ProcessBlock(0, blk, blockchain.BFNone, blk.Height()); E.Chk(e) {
+		return
+	}
+	if !isMainChain {
+		return fmt.Errorf("block %d %s was not added to the main chain", blk.Height(), blk.Hash())
+	}
+	return
+}
+
+// connect adds the block to the best chain of the generator and records it in the wallets.
+func (g *generator) connect(blk *block.Block) (e error) {
+	g.blocks = append(g.blocks, blk)
+	coinbase := blk.WireBlock().Transactions[0]
+	g.addOutputs(coinbase, blk.Height(), true)
+	return g.connectWallets(blk)
+}
+
+// reorg replaces the blocks of the best chain above the given depth with a branch that is one block longer. The branch
+// mines the transactions of the blocks it replaces again, at the same heights, with coinbases paying other addresses,
+// and its blocks have the same times, versions and so difficulty as the blocks they replace. Its blocks are processed
+// from the second up, which makes them orphans until the first is processed and they are connected behind it.
+func (g *generator) reorg(depth int32) (e error) {
+	forkHeight := g.tip().Height() - depth
+	forkBlock := g.blocks[forkHeight]
+	orphaned := append([]*block.Block{}, g.blocks[forkHeight+1:]...)
+	r := ReorgManifest{ForkHeight: forkHeight}
+	for _, blk := range orphaned {
+		r.Orphaned = append(r.Orphaned, blk.Hash().String())
+		// the coinbases of the replaced blocks are immature, so none of them has been spent
+		g.removeOutput(wire.OutPoint{Hash: blk.Transactions()[0].MsgTx().TxHash()})
+	}
+	var branch []*block.Block
+	prev := forkBlock
+	for _, old := range orphaned {
+		header := old.WireBlock().Header
+		txs := old.WireBlock().Transactions[1:]
+		fees := old.WireBlock().Transactions[0].TxOut[0].Value -
+			blockchain.CalcBlockSubsidy(old.Height(), g.params, header.Version)
+		var payee *key
+		if payee, e = g.randomKey(); E.Chk(e) {
+			return
+		}
+		var blk *block.Block
+		if blk, e = g.makeBlock(prev, header.Timestamp, header.Version, header.Bits, txs, fees, payee, 1); E.Chk(e) {
+			return
+		}
+		branch = append(branch, blk)
+		prev = blk
+	}
+	// The node only replaces the tip with a branch whose last block alone has more work than the tip and its parent
+	// together, so the block that makes the branch longer is made about twice as hard as that, rather than as hard as
+	// the difficulty adjustment would have it. The branch is then added without the difficulty checks, as the blocks
+	// of a fixture are trusted.
+	var tipWork *big.Int
+	if tipWork, e = g.chain.WorkSumByHash(g.tip().Hash()); E.Chk(e) {
+		return
+	}
+	nextBits := bits.BigToCompact(new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), 256), new(big.Int).Lsh(tipWork, 1)))
+	var payee *key
+	if payee, e = g.randomKey(); E.Chk(e) {
+		return
+	}
+	height := g.tip().Height() + 1
+	version := g.randomVersion(height)
+	var blk *block.Block
+	if blk, e = g.makeBlock(prev, g.nextTime(g.tip(), height), version, nextBits, nil, 0, payee, 1); E.Chk(e) {
+		return
+	}
+	branch = append(branch, blk)
+	for _, blk := range append(branch[1:], branch[0]) {
+		if _, _, e = g.chain.ProcessBlock(0, blk, blockchain.BFFastAdd, blk.Height()); E.Chk(e) {
+			return
+		}
+	}
+	if best := g.chain.BestSnapshot(); best.Hash != *blk.Hash() {
+		return fmt.Errorf("branch from height %d did not replace the tip, the best block is %s", forkHeight, best.Hash)
+	}
+	g.blocks = g.blocks[:forkHeight+1]
+	if e = g.rollbackWallets(forkBlock); E.Chk(e) {
+		return
+	}
+	for _, blk := range branch {
+		if e = g.connect(blk); E.Chk(e) {
+			return
+		}
+	}
+	g.reorgs = append(g.reorgs, r)
+	D.F("re-org of %d blocks from height %d", depth, forkHeight)
+	return
+}
+
+// randomKey returns a new address of a random account of a random wallet.
+func (g *generator) randomKey() (*key, error) {
+	wi := g.rng.Intn(len(g.wallets))
+	return g.newKey(wi, g.rng.Intn(len(g.wallets[wi].accounts)), false)
+}
+
+// payee returns the address a transaction from the account of the wallet pays. Most payments are to other wallets,
+// some are between the accounts of the same wallet, and some send coins out of the wallets. An address is paid again
+// now and then.
+func (g *generator) payee(wi int) (k *key, e error) {
+	switch r := g.rng.Intn(10); {
+	case r < 2:
+		return g.external, nil
+	case r < 4:
+	default:
+		wi = g.rng.Intn(len(g.wallets))
+	}
+	ai := g.rng.Intn(len(g.wallets[wi].accounts))
+	if last := g.wallets[wi].accounts[ai].last; last != nil && g.rng.Intn(5) == 0 {
+		return last, nil
+	}
+	return g.newKey(wi, ai, false)
+}
+
+// makeTxs makes the transactions of the block at the height, which spend the outputs of random accounts of the wallets
+// that can be spent at that height, and returns them with their fees.
+func (g *generator) makeTxs(height int32) (txs []*wire.MsgTx, fees int64, e error) {
+	n := g.rng.Intn(g.cfg.MaxTxsPerBlock + 1)
+	for i := 0; i < n; i++ {
+		// the accounts with outputs that can be spent, in a fixed order
+		var spenders [][2]int
+		for wi, w := range g.wallets {
+			for ai, a := range w.accounts {
+				if len(g.spendable(a, height)) > 0 {
+					spenders = append(spenders, [2]int{wi, ai})
+				}
+			}
+		}
+		if len(spenders) == 0 {
+			return
+		}
+		s := spenders[g.rng.Intn(len(spenders))]
+		var tx *wire.MsgTx
+		var fee int64
+		if tx, fee, e = g.makeTx(s[0], s[1], height); E.Chk(e) {
+			return
+		}
+		if tx != nil {
+			txs = append(txs, tx)
+			fees += fee
+		}
+	}
+	return
+}
+
+// spendable returns the outputs of the account that can be spent in the block at the height.
+func (g *generator) spendable(a *account, height int32) (utxos []*utxo) {
+	for _, u := range a.utxos {
+		if !u.coinbase || height-u.height >= int32(g.params.CoinbaseMaturity) {
+			utxos = append(utxos, u)
+		}
+	}
+	return
+}
+
+// makeTx makes a transaction spending some of the outputs of the account that can be spent at the height, paying part
+// of them to a payee and the rest less the fee to a change address of the account. Nil is returned if the outputs are
+// too small to be worth spending.
+func (g *generator) makeTx(wi, ai int, height int32) (tx *wire.MsgTx, fee int64, e error) {
+	a := g.wallets[wi].accounts[ai]
+	candidates := g.spendable(a, height)
+	var inputs []*utxo
+	var total int64
+	for i, n := 0, 1+g.rng.Intn(maxInputs); i < n && len(candidates) > 0; i++ {
+		j := g.rng.Intn(len(candidates))
+		inputs = append(inputs, candidates[j])
+		total += candidates[j].value
+		candidates = append(candidates[:j], candidates[j+1:]...)
+	}
+	var payee *key
+	if payee, e = g.payee(wi); E.Chk(e) {
+		return
+	}
+	tx = wire.NewMsgTx(wire.TxVersion)
+	for _, u := range inputs {
+		tx.AddTxIn(wire.NewTxIn(&u.op, nil, nil))
+	}
+	pay := total * int64(10+g.rng.Intn(81)) / 100
+	tx.AddTxOut(wire.NewTxOut(pay, payee.script))
+	fee = int64(txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, txsizes.EstimateSerializeSize(len(inputs), tx.TxOut, true)))
+	change := total - pay - fee
+	if change < 0 || txrules.IsDustAmount(amt.Amount(change), len(payee.script), txrules.DefaultRelayFeePerKb) {
+		// the change is not worth an output of its own, so it is all paid
+		fee = int64(txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, txsizes.EstimateSerializeSize(len(inputs), tx.TxOut, false)))
+		tx.TxOut[0].Value = total - fee
+		if txrules.IsDustOutput(tx.TxOut[0], txrules.DefaultRelayFeePerKb) {
+			return nil, 0, nil
+		}
+	} else {
+		var changeKey *key
+		if changeKey, e = g.newKey(wi, ai, true); E.Chk(e) {
+			return
+		}
+		tx.AddTxOut(wire.NewTxOut(change, changeKey.script))
+	}
+	for i, u := range inputs {
+		if tx.TxIn[i].SignatureScript, e = txscript.SignatureScript(
+			tx, i, u.key.script, txscript.SigHashAll, u.key.priv, true,
+		); E.Chk(e) {
+			return
+		}
+		g.removeOutput(u.op)
+	}
+	g.addOutputs(tx, height, false)
+	return
+}
+
+// addOutputs adds the outputs of the transaction paying to the wallets to the outputs of their accounts.
+func (g *generator) addOutputs(tx *wire.MsgTx, height int32, coinbase bool) {
+	hash := tx.TxHash()
+	for i, out := range tx.TxOut {
+		k, ok := g.keys[string(out.PkScript)]
+		if !ok {
+			continue
+		}
+		op := wire.OutPoint{Hash: hash, Index: uint32(i)}
+		a := g.wallets[k.wallet].accounts[k.account]
+		a.utxos = append(a.utxos, &utxo{op: op, value: out.Value, key: k, height: height, coinbase: coinbase})
+		g.owners[op] = k.wallet
+	}
+}
+
+// removeOutput removes the output from the outputs of the account it pays to.
+func (g *generator) removeOutput(op wire.OutPoint) {
+	wi, ok := g.owners[op]
+	if !ok {
+		return
+	}
+	for _, a := range g.wallets[wi].accounts {
+		for i, u := range a.utxos {
+			if u.op == op {
+				a.utxos = append(a.utxos[:i], a.utxos[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// relevant returns whether the transaction pays to or spends from the wallet.
+func (g *generator) relevant(wi int, tx *wire.MsgTx) bool {
+	for _, out := range tx.TxOut {
+		if k, ok := g.keys[string(out.PkScript)]; ok && k.wallet == wi {
+			return true
+		}
+	}
+	for _, in := range tx.TxIn {
+		if owner, ok := g.owners[in.PreviousOutPoint]; ok && owner == wi {
+			return true
+		}
+	}
+	return false
+}
+
+// manifest returns the manifest of the chain and wallets made.
+func (g *generator) manifest() (m *Manifest) {
+	tip := g.tip()
+	m = &Manifest{
+		Seed:           g.cfg.Seed,
+		Network:        g.params.Name,
+		MaxTxsPerBlock: g.cfg.MaxTxsPerBlock,
+		Height:         tip.Height(),
+		Tip:            tip.Hash().String(),
+		NodeDir:        "node",
+		Reorgs:         g.reorgs,
+	}
+	for _, blk := range g.blocks[1:] {
+		m.Transactions += len(blk.WireBlock().Transactions) - 1
+	}
+	for wi, w := range g.wallets {
+		wm := WalletManifest{File: w.file}
+		for _, blk := range g.blocks[1:] {
+			for _, tx := range blk.WireBlock().Transactions {
+				if g.relevant(wi, tx) {
+					wm.Transactions++
+				}
+			}
+		}
+		for _, a := range w.accounts {
+			am := AccountManifest{Name: a.name, Number: a.number, Addresses: a.addresses, Unspent: len(a.utxos)}
+			for _, u := range a.utxos {
+				am.Balance += u.value
+				if u.coinbase && tip.Height()+1-u.height < int32(g.params.CoinbaseMaturity) {
+					am.Immature += u.value
+				}
+			}
+			wm.Accounts = append(wm.Accounts, am)
+		}
+		m.Wallets = append(m.Wallets, wm)
+	}
+	return
+}
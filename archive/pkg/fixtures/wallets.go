@@ -0,0 +1,246 @@
+package fixtures
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/waddrmgr"
+	"github.com/p9c/pod/pkg/walletdb"
+	_ "github.com/p9c/pod/pkg/walletdb/bdb"
+	"github.com/p9c/pod/pkg/wtxmgr"
+)
+
+var (
+	// waddrmgrNamespaceKey and wtxmgrNamespaceKey are the buckets of the wallet database the address manager and the
+	// transaction manager are kept in, as the wallet names them.
+	waddrmgrNamespaceKey = []byte("waddrmgr")
+	wtxmgrNamespaceKey   = []byte("wtxmgr")
+)
+
+// wallet is a wallet of the fixture, written with its address and transaction managers.
+type wallet struct {
+	file     string
+	db       walletdb.DB
+	manager  *waddrmgr.Manager
+	store    *wtxmgr.Store
+	scope    *waddrmgr.ScopedKeyManager
+	accounts []*account
+}
+
+// account is an account of a wallet, with the outputs paying to it that the generator has not spent yet.
+type account struct {
+	name      string
+	number    uint32
+	addresses int
+	// last is the external address most recently made, which is paid again now and then, as people do.
+	last  *key
+	utxos []*utxo
+}
+
+// key is an address of a wallet and its private key, or of no wallet if its wallet is negative.
+type key struct {
+	priv     *ecc.PrivateKey
+	addr     btcaddr.Address
+	script   []byte
+	wallet   int
+	account  int
+	internal bool
+}
+
+// walletSeed returns the seed of the HD keys of the wallet with the index, which follows from the seed of the fixture.
+func walletSeed(seed int64, index int) []byte {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b, uint64(seed))
+	binary.LittleEndian.PutUint64(b[8:], uint64(index))
+	s := sha256.Sum256(append([]byte("fixture wallet "), b...))
+	return s[:]
+}
+
+// openWallet makes the wallet with the index in the fixture directory, with the accounts of the Config, and unlocks it
+// so the keys of its addresses can sign.
+func (g *generator) openWallet(index int) (w *wallet, e error) {
+	w = &wallet{file: filepath.Join(fmt.Sprintf("wallet%d", index), g.params.Name, constant.WalletDbName)}
+	path := filepath.Join(g.cfg.Dir, w.file)
+	if e = os.MkdirAll(filepath.Dir(path), 0700); E.Chk(e) {
+		return
+	}
+	if w.db, e = walletdb.Create("bdb", path); E.Chk(e) {
+		return
+	}
+	pubPass := []byte{}
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			var addrmgrNs, txmgrNs walletdb.ReadWriteBucket
+			if addrmgrNs, e = tx.CreateTopLevelBucket(waddrmgrNamespaceKey); E.Chk(e) {
+				return
+			}
+			if txmgrNs, e = tx.CreateTopLevelBucket(wtxmgrNamespaceKey); E.Chk(e) {
+				return
+			}
+			if e = waddrmgr.Create(
+				addrmgrNs, walletSeed(g.cfg.Seed, index), pubPass, g.cfg.PrivPassphrase, g.params, g.cfg.Scrypt,
+				g.params.GenesisBlock.Header.Timestamp,
+			); E.Chk(e) {
+				return
+			}
+			if e = wtxmgr.Create(txmgrNs); E.Chk(e) {
+				return
+			}
+			if w.manager, e = waddrmgr.Open(addrmgrNs, pubPass, g.params); E.Chk(e) {
+				return
+			}
+			if w.store, e = wtxmgr.Open(txmgrNs, g.params); E.Chk(e) {
+				return
+			}
+			if e = w.manager.Unlock(addrmgrNs, g.cfg.PrivPassphrase); E.Chk(e) {
+				return
+			}
+			if w.scope, e = w.manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044); E.Chk(e) {
+				return
+			}
+			w.accounts = append(w.accounts, &account{name: "default", number: waddrmgr.DefaultAccountNum})
+			for _, name := range accountNames[:g.cfg.Accounts] {
+				a := &account{name: name}
+				if a.number, e = w.scope.NewAccount(addrmgrNs, name); E.Chk(e) {
+					return
+				}
+				w.accounts = append(w.accounts, a)
+			}
+			return
+		},
+	)
+	return
+}
+
+// close closes the database of the wallet.
+func (w *wallet) close() {
+	if w.manager != nil {
+		w.manager.Close()
+	}
+	if w.db != nil {
+		if e := w.db.Close(); E.Chk(e) {
+		}
+	}
+}
+
+// newKey makes the next address of the account of the wallet, of the internal branch for change if internal is set.
+func (g *generator) newKey(wi, ai int, internal bool) (k *key, e error) {
+	w := g.wallets[wi]
+	a := w.accounts[ai]
+	e = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			var addrs []waddrmgr.ManagedAddress
+			if internal {
+				addrs, e = w.scope.NextInternalAddresses(ns, a.number, 1)
+			} else {
+				addrs, e = w.scope.NextExternalAddresses(ns, a.number, 1)
+			}
+			if E.Chk(e) {
+				return
+			}
+			ma, ok := addrs[0].(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return fmt.Errorf("address %s has no key", addrs[0].Address().EncodeAddress())
+			}
+			k = &key{addr: ma.Address(), wallet: wi, account: ai, internal: internal}
+			if k.priv, e = ma.PrivKey(); E.Chk(e) {
+				return
+			}
+			k.script, e = txscript.PayToAddrScript(k.addr)
+			return
+		},
+	)
+	if e != nil {
+		return nil, e
+	}
+	a.addresses++
+	if !internal {
+		a.last = k
+	}
+	g.keys[string(k.script)] = k
+	return
+}
+
+// connectWallets records the transactions of the block that pay to or spend from each wallet, and marks the wallets
+// synced to it, as a wallet does when a block is connected to the chain it follows.
+func (g *generator) connectWallets(blk *block.Block) (e error) {
+	meta := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: *blk.Hash(), Height: blk.Height()},
+		Time:  blk.WireBlock().Header.Timestamp,
+	}
+	for wi, w := range g.wallets {
+		e = walletdb.Update(
+			w.db, func(tx walletdb.ReadWriteTx) (e error) {
+				addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+				for _, msgTx := range blk.WireBlock().Transactions {
+					if !g.relevant(wi, msgTx) {
+						continue
+					}
+					var rec *wtxmgr.TxRecord
+					if rec, e = wtxmgr.NewTxRecordFromMsgTx(msgTx, meta.Time); E.Chk(e) {
+						return
+					}
+					if e = w.store.InsertTx(txmgrNs, rec, meta); E.Chk(e) {
+						return
+					}
+					for i, out := range msgTx.TxOut {
+						k, ok := g.keys[string(out.PkScript)]
+						if !ok || k.wallet != wi {
+							continue
+						}
+						if e = w.store.AddCredit(txmgrNs, rec, meta, uint32(i), k.internal); E.Chk(e) {
+							return
+						}
+						if e = w.manager.MarkUsed(addrmgrNs, k.addr); E.Chk(e) {
+							return
+						}
+					}
+				}
+				return w.manager.SetSyncedTo(
+					addrmgrNs, &waddrmgr.BlockStamp{Height: meta.Height, Hash: meta.Hash, Timestamp: meta.Time},
+				)
+			},
+		)
+		if e != nil {
+			return
+		}
+	}
+	return
+}
+
+// rollbackWallets removes the blocks above the block from each wallet, as a wallet does when they are disconnected
+// from the chain it follows. Their transactions that are not coinbases become unmined until they are mined again.
+func (g *generator) rollbackWallets(forkBlock *block.Block) (e error) {
+	for _, w := range g.wallets {
+		e = walletdb.Update(
+			w.db, func(tx walletdb.ReadWriteTx) (e error) {
+				addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+				if e = w.store.Rollback(txmgrNs, forkBlock.Height()+1); E.Chk(e) {
+					return
+				}
+				return w.manager.SetSyncedTo(
+					addrmgrNs, &waddrmgr.BlockStamp{
+						Height:    forkBlock.Height(),
+						Hash:      *forkBlock.Hash(),
+						Timestamp: forkBlock.WireBlock().Header.Timestamp,
+					},
+				)
+			},
+		)
+		if e != nil {
+			return
+		}
+	}
+	return
+}
@@ -107,7 +107,9 @@ func ForkCalc() {
 	}
 	D.Ln(P9Average)
 	P9Average = baseVersionInterval / P9Average
-	D.Ln(P9Average)}
+	D.Ln(P9Average)
+	epochs = baseEpochs()
+}
 
 var (
 	AlgoSlices []AlgoSpecs
@@ -220,7 +222,14 @@ func GetAlgoID(algoname string, height int32) uint32 {
 	if GetCurrent(height) > 1 {
 		return P9Algos[algoname].AlgoID
 	}
-	return Algos[algoname].AlgoID
+	if p, ok := Algos[algoname]; ok {
+		return p.AlgoID
+	}
+	if _, ok := P9Algos[algoname]; ok {
+		return 0
+	}
+	// algorithms added by the schedule
+	return activeEpoch(height).algos[algoname].AlgoID
 }
 
 // GetAlgoName returns the string identifier of an algorithm depending on
@@ -228,7 +237,7 @@ func GetAlgoID(algoname string, height int32) uint32 {
 func GetAlgoName(algoVer int32, height int32) (name string) {
 	hf := GetCurrent(height)
 	var ok bool
-	name, ok = List[hf].AlgoVers[algoVer]
+	name, ok = activeEpoch(height).vers[algoVer]
 	if hf < 1 && !ok {
 		name = SHA256d
 	}
@@ -239,50 +248,34 @@ func GetAlgoName(algoVer int32, height int32) (name string) {
 // GetRandomVersion returns a random version relevant to the current hard fork state and height
 func GetRandomVersion(height int32) int32 {
 	rand.Seed(time.Now().UnixNano())
-	return int32(rand.Intn(len(List[GetCurrent(height)].Algos)) + 5)
+	vers := activeEpoch(height).verSlice
+	return vers[rand.Intn(len(vers))]
 }
 
 // GetAlgoVer returns the version number for a given algorithm (by string name) at a given height. If "random" is given,
 // a random number is taken from the system secure random source (for randomised cpu mining)
 func GetAlgoVer(name string, height int32) (version int32) {
-	hf := GetCurrent(height)
-	n := AlgoSlices[hf][0].Name
-	// D.Ln("GetAlgoVer", name, height, hf, n)
-	if _, ok := List[hf].Algos[name]; ok {
+	ep := activeEpoch(height)
+	n := ep.defaultName
+	// D.Ln("GetAlgoVer", name, height, n)
+	if _, ok := ep.algos[name]; ok {
 		n = name
 	}
-	version = List[hf].Algos[n].Version
+	version = ep.algos[n].Version
 	return
 }
 
-var algoVerSlice [][]int32
-
+// GetAlgoVerSlice returns the block versions of the algorithms active at a given height
 func GetAlgoVerSlice(height int32) (o []int32) {
-	hf := GetCurrent(height)
-	if algoVerSlice == nil {
-		algoVerSlice = make([][]int32, 0, len(List))
-		for i := range List {
-			av := make([]int32, 0, len(List[i].AlgoVers))
-			for j := range List[i].AlgoVers {
-				av = append(av, j)
-			}
-			algoVerSlice = append(algoVerSlice, av)
-		}
-	}
-	// D.S(algoVerSlice)
-	return algoVerSlice[hf]
+	return activeEpoch(height).verSlice
 }
 
 // AlgoVerIterator returns a next and more function to use in a for loop to
 // iterate over block versions at current height
 func AlgoVerIterator(height int32) (next func(), curr func() int32, more func() bool) {
-	current := GetCurrent(height)
 	var cursor int32
 	length := int32(GetNumAlgos(height))
-	var verNumbers []int32
-	for i := range List[current].AlgoVers {
-		verNumbers = append(verNumbers, List[current].Algos[List[current].AlgoVers[i]].Version)
-	}
+	verNumbers := activeEpoch(height).verSlice
 	curr = func() int32 {
 		return verNumbers[cursor]
 	}
@@ -300,26 +293,12 @@ func AlgoVerIterator(height int32) (next func(), curr func() int32, more func()
 
 // GetAlgos returns the map of names and algorithm parameters
 func GetAlgos(height int32) (o map[string]AlgoParams) {
-	current := GetCurrent(height)
-	for i := range List {
-		if List[i].Number == current {
-			o = List[i].Algos
-			break
-		}
-	}
-	return
+	return activeEpoch(height).algos
 }
 
 // GetNumAlgos returns the number of algos at a given height
 func GetNumAlgos(height int32) (numAlgos int) {
-	current := GetCurrent(height)
-	for i := range List {
-		if List[i].Number == current {
-			numAlgos = len(List[i].Algos)
-			break
-		}
-	}
-	return
+	return len(activeEpoch(height).algos)
 }
 
 // GetAveragingInterval returns the active block interval target based on hard fork status
@@ -349,9 +328,8 @@ func GetCurrent(height int32) (curr int) {
 
 // GetMinBits returns the minimum diff bits based on height and testnet
 func GetMinBits(algoname string, height int32) (mb uint32) {
-	curr := GetCurrent(height)
-	// F.Ln("GetMinBits", algoname, height, curr, List[curr].Algos)
-	mb = List[curr].Algos[algoname].MinBits
+	// F.Ln("GetMinBits", algoname, height, GetAlgos(height))
+	mb = activeEpoch(height).algos[algoname].MinBits
 	// TraceF("minbits %08x, %d", mb, mb)
 	return
 }
@@ -364,6 +342,19 @@ func GetMinDiff(algoname string, height int32) (md *big.Int) {
 	return bits.CompactToBig(minbits)
 }
 
+// GetAlgoDifficulty returns the difficulty of the target encoded in bits relative to the minimum difficulty of the named
+// algorithm at the given height, which makes the difficulty of blocks mined with different algorithms comparable to
+// their own floor rather than to a single network wide limit
+func GetAlgoDifficulty(algoname string, height int32, b uint32) (diff float64) {
+	minDiff := GetMinDiff(algoname, height)
+	target := bits.CompactToBig(b)
+	if minDiff.Sign() <= 0 || target.Sign() <= 0 {
+		return 0
+	}
+	diff, _ = new(big.Rat).SetFrac(minDiff, target).Float64()
+	return
+}
+
 // GetTargetTimePerBlock returns the active block interval target based on hard fork status
 func GetTargetTimePerBlock(height int32) (r int64) {
 	r = int64(List[GetCurrent(height)].TargetTimePerBlock)
@@ -0,0 +1,255 @@
+package fork
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Vector is a known answer test of the hash function of an algorithm: the hex of the hash of the hex Input under the
+// rules of hard fork Fork, with Reps repetitions of the division step where the hard fork uses it
+type Vector struct {
+	Fork   int
+	Reps   int
+	Input  string
+	Output string
+}
+
+// Algorithm is a proof of work algorithm in the registry. It provides the block hash function, the difficulty
+// parameters and the known answer test vectors of the algorithm, and the hard fork it belongs to
+type Algorithm struct {
+	Name   string
+	Fork   int
+	Params AlgoParams
+	// Hash returns the proof of work hash of a serialized block header under the rules of hard fork hf, with reps
+	// repetitions of the division step where the hard fork uses it
+	Hash    func(header []byte, hf, reps int) []byte
+	Vectors []Vector
+}
+
+// AlgoChange schedules the addition of a registered algorithm to, or its removal from, the algorithms of a hard fork
+// from a block height onwards
+type AlgoChange struct {
+	Fork   int
+	Height int32
+	Name   string
+	Remove bool
+}
+
+// SelfTestResult is the outcome of running the known answer test vectors of an algorithm
+type SelfTestResult struct {
+	Name     string
+	Fork     int
+	Vectors  int
+	Failures []string
+}
+
+// algoEpoch is the set of algorithms of a hard fork from a height onwards
+type algoEpoch struct {
+	height   int32
+	algos    map[string]AlgoParams
+	vers     map[int32]string
+	verSlice []int32
+	// defaultName is the algorithm with the highest version, used where an unknown algorithm is asked for
+	defaultName string
+}
+
+var (
+	registry = make(map[string]*Algorithm)
+	// epochs are the algorithm sets of each hard fork in order of height, the first being the set of the hard fork in
+	// List
+	epochs [][]algoEpoch
+)
+
+// Register adds an algorithm to the registry. Algorithms of the hard forks in List must have the same parameters as
+// they have there, others can be added to a hard fork at a height with SetSchedule
+func Register(a *Algorithm) (e error) {
+	switch {
+	case a.Name == "":
+		return errors.New("algorithm has no name")
+	case a.Hash == nil:
+		return fmt.Errorf("algorithm %s has no hash function", a.Name)
+	case a.Fork < 0 || a.Fork >= len(List):
+		return fmt.Errorf("algorithm %s is for unknown hard fork %d", a.Name, a.Fork)
+	}
+	if _, ok := registry[a.Name]; ok {
+		return fmt.Errorf("algorithm %s is already registered", a.Name)
+	}
+	if p, ok := List[a.Fork].Algos[a.Name]; ok && p != a.Params {
+		return fmt.Errorf(
+			"algorithm %s parameters %+v differ from hard fork %d parameters %+v", a.Name, a.Params, a.Fork, p,
+		)
+	}
+	registry[a.Name] = a
+	return
+}
+
+// Registered returns the algorithm registered with the given name
+func Registered(name string) (a *Algorithm, ok bool) {
+	a, ok = registry[name]
+	return
+}
+
+// GetAlgoParams returns the parameters of the named algorithm of hard fork hf, which is one of the algorithms of the
+// hard fork in List or a registered algorithm that can be scheduled for it
+func GetAlgoParams(hf int, name string) (p AlgoParams, ok bool) {
+	if p, ok = List[hf].Algos[name]; ok {
+		return
+	}
+	var a *Algorithm
+	if a, ok = registry[name]; ok && a.Fork == hf {
+		return a.Params, true
+	}
+	return AlgoParams{}, false
+}
+
+// Algorithms returns the registered algorithms in order of hard fork and version
+func Algorithms() (algos []*Algorithm) {
+	for _, a := range registry {
+		algos = append(algos, a)
+	}
+	sort.Slice(
+		algos, func(i, j int) bool {
+			if algos[i].Fork != algos[j].Fork {
+				return algos[i].Fork < algos[j].Fork
+			}
+			return algos[i].Params.Version < algos[j].Params.Version
+		},
+	)
+	return
+}
+
+// SelfTest runs the known answer test vectors of the registered algorithms and returns the results, and whether all
+// of them passed
+func SelfTest() (results []SelfTestResult, passed bool) {
+	passed = true
+	for _, a := range Algorithms() {
+		r := SelfTestResult{Name: a.Name, Fork: a.Fork, Vectors: len(a.Vectors)}
+		for i, v := range a.Vectors {
+			in, e := hex.DecodeString(v.Input)
+			if e != nil {
+				r.Failures = append(r.Failures, fmt.Sprintf("vector %d: invalid input: %v", i, e))
+				continue
+			}
+			var want []byte
+			if want, e = hex.DecodeString(v.Output); e != nil {
+				r.Failures = append(r.Failures, fmt.Sprintf("vector %d: invalid output: %v", i, e))
+				continue
+			}
+			if got := a.Hash(in, v.Fork, v.Reps); !bytes.Equal(got, want) {
+				r.Failures = append(r.Failures, fmt.Sprintf("vector %d: got %x, want %x", i, got, want))
+			}
+		}
+		if len(r.Failures) > 0 {
+			passed = false
+		}
+		results = append(results, r)
+	}
+	return
+}
+
+// SetSchedule sets the changes to the algorithms of the hard forks at later heights, replacing any set before. It must
+// be called at startup, before blocks are validated or mined, as the algorithm lookups are not synchronised with it
+func SetSchedule(changes []AlgoChange) (e error) {
+	sorted := append([]AlgoChange(nil), changes...)
+	sort.SliceStable(
+		sorted, func(i, j int) bool {
+			return sorted[i].Height < sorted[j].Height
+		},
+	)
+	es := baseEpochs()
+	for _, c := range sorted {
+		if c.Fork < 0 || c.Fork >= len(List) {
+			return fmt.Errorf("scheduled change of %s is for unknown hard fork %d", c.Name, c.Fork)
+		}
+		prev := es[c.Fork][len(es[c.Fork])-1]
+		algos := make(map[string]AlgoParams, len(prev.algos)+1)
+		for n, p := range prev.algos {
+			algos[n] = p
+		}
+		if c.Remove {
+			if _, ok := algos[c.Name]; !ok {
+				return fmt.Errorf("scheduled removal of %s at height %d: not active", c.Name, c.Height)
+			}
+			delete(algos, c.Name)
+			if len(algos) == 0 {
+				return fmt.Errorf("scheduled removal of %s at height %d leaves no algorithms", c.Name, c.Height)
+			}
+		} else {
+			a, ok := registry[c.Name]
+			if !ok {
+				return fmt.Errorf("scheduled addition of %s at height %d: not registered", c.Name, c.Height)
+			}
+			if a.Fork != c.Fork {
+				return fmt.Errorf(
+					"scheduled addition of %s at height %d: registered for hard fork %d, not %d", c.Name, c.Height,
+					a.Fork, c.Fork,
+				)
+			}
+			if _, ok = algos[c.Name]; ok {
+				return fmt.Errorf("scheduled addition of %s at height %d: already active", c.Name, c.Height)
+			}
+			for n, p := range algos {
+				if p.Version == a.Params.Version {
+					return fmt.Errorf(
+						"scheduled addition of %s at height %d: version %d is used by %s", c.Name, c.Height,
+						p.Version, n,
+					)
+				}
+			}
+			algos[c.Name] = a.Params
+		}
+		ep := newEpoch(c.Height, algos)
+		if prev.height == c.Height {
+			es[c.Fork][len(es[c.Fork])-1] = ep
+		} else {
+			es[c.Fork] = append(es[c.Fork], ep)
+		}
+	}
+	epochs = es
+	return
+}
+
+// baseEpochs returns the algorithm sets of the hard forks in List without scheduled changes
+func baseEpochs() (es [][]algoEpoch) {
+	es = make([][]algoEpoch, len(List))
+	for i := range List {
+		es[i] = []algoEpoch{newEpoch(math.MinInt32, List[i].Algos)}
+	}
+	return
+}
+
+// newEpoch returns the algorithm set of the given algorithms from height onwards
+func newEpoch(height int32, algos map[string]AlgoParams) (ep algoEpoch) {
+	ep = algoEpoch{
+		height: height,
+		algos:  algos,
+		vers:   make(map[int32]string, len(algos)),
+	}
+	for n, p := range algos {
+		ep.vers[p.Version] = n
+		ep.verSlice = append(ep.verSlice, p.Version)
+		if ep.defaultName == "" || p.Version > algos[ep.defaultName].Version {
+			ep.defaultName = n
+		}
+	}
+	sort.Slice(
+		ep.verSlice, func(i, j int) bool {
+			return ep.verSlice[i] < ep.verSlice[j]
+		},
+	)
+	return
+}
+
+// activeEpoch returns the algorithm set in effect at the given height
+func activeEpoch(height int32) *algoEpoch {
+	es := epochs[GetCurrent(height)]
+	i := len(es) - 1
+	for i > 0 && height < es[i].height {
+		i--
+	}
+	return &es[i]
+}
@@ -0,0 +1,179 @@
+package fork
+
+import (
+	"testing"
+)
+
+// withEmptyRegistry runs a test with no registered algorithms and no schedule, restoring both afterwards
+func withEmptyRegistry(t *testing.T, test func(t *testing.T)) {
+	savedRegistry, savedEpochs := registry, epochs
+	registry, epochs = make(map[string]*Algorithm), baseEpochs()
+	defer func() {
+		registry, epochs = savedRegistry, savedEpochs
+	}()
+	test(t)
+}
+
+func testHash(header []byte, hf, reps int) []byte {
+	return header
+}
+
+// TestRegister ensures algorithms are only registered with a name, a hash function, a known hard fork and the
+// parameters the hard fork gives them
+func TestRegister(t *testing.T) {
+	withEmptyRegistry(
+		t, func(t *testing.T) {
+			name := P9AlgoVers[5]
+			tests := []struct {
+				name string
+				algo *Algorithm
+			}{
+				{"no name", &Algorithm{Fork: 1, Hash: testHash}},
+				{"no hash", &Algorithm{Name: "test", Fork: 1}},
+				{"unknown hard fork", &Algorithm{Name: "test", Fork: len(List), Hash: testHash}},
+				{"negative hard fork", &Algorithm{Name: "test", Fork: -1, Hash: testHash}},
+				{"other parameters", &Algorithm{Name: name, Fork: 1, Hash: testHash, Params: AlgoParams{Version: 99}}},
+			}
+			for _, test := range tests {
+				if e := Register(test.algo); e == nil {
+					t.Errorf("%s: algorithm was registered", test.name)
+				}
+			}
+			if e := Register(&Algorithm{Name: name, Fork: 1, Hash: testHash, Params: P9Algos[name]}); e != nil {
+				t.Fatalf("hard fork algorithm was not registered: %v", e)
+			}
+			if a, ok := Registered(name); !ok || a.Params != P9Algos[name] {
+				t.Fatalf("registered algorithm %s not found", name)
+			}
+			if e := Register(&Algorithm{Name: name, Fork: 1, Hash: testHash, Params: P9Algos[name]}); e == nil {
+				t.Error("algorithm was registered twice")
+			}
+			added := &Algorithm{Name: "test", Fork: 1, Hash: testHash, Params: AlgoParams{Version: 20}}
+			if e := Register(added); e != nil {
+				t.Fatalf("new algorithm was not registered: %v", e)
+			}
+			if p, ok := GetAlgoParams(1, "test"); !ok || p != added.Params {
+				t.Errorf("parameters of registered algorithm: got %+v, %v", p, ok)
+			}
+			if _, ok := GetAlgoParams(0, "test"); ok {
+				t.Error("registered algorithm has parameters for another hard fork")
+			}
+		},
+	)
+}
+
+// TestSetSchedule ensures scheduled algorithms are added and removed at their heights, that the lookups by height
+// follow the schedule, and that invalid schedules are refused without changing the active one
+func TestSetSchedule(t *testing.T) {
+	withEmptyRegistry(
+		t, func(t *testing.T) {
+			added := &Algorithm{
+				Name: "test", Fork: 1, Hash: testHash,
+				Params: AlgoParams{Version: 20, MinBits: p9PowLimitBits, AlgoID: 9, VersionInterval: 9},
+			}
+			if e := Register(added); e != nil {
+				t.Fatalf("unable to register algorithm: %v", e)
+			}
+			fork0 := &Algorithm{Name: "fork0", Fork: 0, Hash: testHash, Params: AlgoParams{Version: 3}}
+			if e := Register(fork0); e != nil {
+				t.Fatalf("unable to register algorithm: %v", e)
+			}
+			clash := &Algorithm{Name: "clash", Fork: 1, Hash: testHash, Params: P9Algos[P9AlgoVers[6]]}
+			if e := Register(clash); e != nil {
+				t.Fatalf("unable to register algorithm: %v", e)
+			}
+			start := List[1].ActivationHeight
+			removed := P9AlgoVers[5]
+			schedule := []AlgoChange{
+				// given out of order, as the schedule is sorted by height
+				{Fork: 1, Height: start + 2000, Name: removed, Remove: true},
+				{Fork: 1, Height: start + 1000, Name: "test"},
+			}
+			if e := SetSchedule(schedule); e != nil {
+				t.Fatalf("SetSchedule: %v", e)
+			}
+			base := len(P9Algos)
+			tests := []struct {
+				height     int32
+				numAlgos   int
+				hasAdded   bool
+				hasRemoved bool
+			}{
+				{start, base, false, true},
+				{start + 999, base, false, true},
+				{start + 1000, base + 1, true, true},
+				{start + 1999, base + 1, true, true},
+				{start + 2000, base, true, false},
+				{start + 100000, base, true, false},
+			}
+			for _, test := range tests {
+				ep := activeEpoch(test.height)
+				if _, ok := ep.algos["test"]; ok != test.hasAdded {
+					t.Errorf("height %d: scheduled algorithm active %v, want %v", test.height, ok, test.hasAdded)
+				}
+				if _, ok := ep.algos[removed]; ok != test.hasRemoved {
+					t.Errorf("height %d: removed algorithm active %v, want %v", test.height, ok, test.hasRemoved)
+				}
+				if n := GetNumAlgos(test.height); n != test.numAlgos {
+					t.Errorf("height %d: got %d algorithms, want %d", test.height, n, test.numAlgos)
+				}
+				if n := len(GetAlgoVerSlice(test.height)); n != test.numAlgos {
+					t.Errorf("height %d: got %d versions, want %d", test.height, n, test.numAlgos)
+				}
+				if name := GetAlgoName(added.Params.Version, test.height); (name == "test") != test.hasAdded {
+					t.Errorf("height %d: version %d is named %q", test.height, added.Params.Version, name)
+				}
+			}
+			if v := GetAlgoVer("test", start+1000); v != added.Params.Version {
+				t.Errorf("version of scheduled algorithm: got %d, want %d", v, added.Params.Version)
+			}
+			if bits := GetMinBits("test", start+1000); bits != added.Params.MinBits {
+				t.Errorf("minimum bits of scheduled algorithm: got %08x, want %08x", bits, added.Params.MinBits)
+			}
+			// the versions are not contiguous once the scheduled algorithm is added and the first one removed, and
+			// random versions must only be those active
+			for i := 0; i < 200; i++ {
+				v := GetRandomVersion(start + 2000)
+				if _, ok := activeEpoch(start + 2000).vers[v]; !ok {
+					t.Fatalf("random version %d is not active", v)
+				}
+			}
+			invalid := []struct {
+				name     string
+				schedule []AlgoChange
+			}{
+				{"unknown hard fork", []AlgoChange{{Fork: len(List), Height: start, Name: "test"}}},
+				{"not registered", []AlgoChange{{Fork: 1, Height: start, Name: "unknown"}}},
+				{"other hard fork", []AlgoChange{{Fork: 1, Height: start, Name: "fork0"}}},
+				{"already active", []AlgoChange{{Fork: 1, Height: start, Name: removed}}},
+				{"removal of inactive", []AlgoChange{{Fork: 1, Height: start, Name: "test", Remove: true}}},
+				{"version in use", []AlgoChange{{Fork: 1, Height: start, Name: "clash"}}},
+				{
+					"added twice", []AlgoChange{
+						{Fork: 1, Height: start, Name: "test"},
+						{Fork: 1, Height: start + 1, Name: "test"},
+					},
+				},
+			}
+			for _, test := range invalid {
+				if e := SetSchedule(test.schedule); e == nil {
+					t.Errorf("%s: schedule was accepted", test.name)
+				}
+			}
+			if !activeEpochHas(start+1000, "test") {
+				t.Error("refused schedule replaced the active one")
+			}
+			if e := SetSchedule(nil); e != nil {
+				t.Fatalf("SetSchedule: %v", e)
+			}
+			if activeEpochHas(start+1000, "test") {
+				t.Error("empty schedule did not clear the scheduled algorithm")
+			}
+		},
+	)
+}
+
+func activeEpochHas(height int32, name string) bool {
+	_, ok := activeEpoch(height).algos[name]
+	return ok
+}
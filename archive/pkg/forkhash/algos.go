@@ -0,0 +1,99 @@
+package forkhash
+
+import (
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// vectorInput is the block header hashed by the known answer test vectors of the algorithms
+const vectorInput = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f" +
+	"303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f"
+
+// vectorOutputs are the expected hashes of vectorInput by the algorithms from before the first hard fork, in the order
+// of the vectors of algorithmVectors
+var vectorOutputs = map[string][]string{
+	fork.SHA256d: {
+		"852c98044fb00507122ff63bda7b529566348fc204f72b00dff1afd7b40501e4",
+		"8586aa1b86b18b17cc20ce1d223a73614d72163c3538ce23811983e4c2f5eb46",
+		"f90b69bdccf98d9e12b4c6dffaf8e59dfe8df56e9a37be3c8fa5103261db17f8",
+	},
+	fork.Scrypt: {
+		"bc540a1a801df96e493005c71e010e2d387607fbf0fec416fd3c2645aa1ba9d2",
+		"1463a2e8f20de52562201b2d9204345c03b00721a4ca07dc685e87c52e25a9c8",
+		"0c0c59a10f30ee297cb9a4a5852708ea9db1ea4afbf5b6ba957a664b5a33b263",
+	},
+}
+
+// divVectorOutputs are the expected hashes of vectorInput by the algorithms of the first hard fork, which all hash the
+// product of the division steps with Blake3
+var divVectorOutputs = []string{
+	"777640407c7cbae61942b06918ebfc5862d80dc66dc5cef68b1a911c08bf6011",
+	"8cf0473e46649f178c256affbe46c7e1cf2bfc2f4882c251f02f8b37cedabd84",
+}
+
+func init() {
+	for name, p := range fork.List[0].Algos {
+		var hash func([]byte) []byte
+		switch name {
+		case fork.Scrypt:
+			hash = ScryptHash
+		case fork.SHA256d:
+			hash = chainhash.DoubleHashB
+		}
+		registerAlgorithm(name, 0, p, preForkHash(hash), vectorOutputs[name])
+	}
+	for name, p := range fork.List[1].Algos {
+		registerAlgorithm(name, 1, p, divHash(Blake3), divVectorOutputs)
+	}
+}
+
+// registerAlgorithm adds an algorithm with its known answer test vectors to the fork registry
+func registerAlgorithm(
+	name string, hf int, p fork.AlgoParams, hash func(header []byte, hf, reps int) []byte, outputs []string,
+) {
+	a := &fork.Algorithm{
+		Name:    name,
+		Fork:    hf,
+		Params:  p,
+		Hash:    hash,
+		Vectors: algorithmVectors(hf, outputs),
+	}
+	if e := fork.Register(a); E.Chk(e) {
+		panic(e)
+	}
+}
+
+// algorithmVectors returns the known answer test vectors of an algorithm of hard fork hf with the given outputs. The
+// algorithms from before the first hard fork are also tested under its rules, as they are still used on testnet
+func algorithmVectors(hf int, outputs []string) (vectors []fork.Vector) {
+	if hf == 0 {
+		vectors = append(vectors, fork.Vector{Fork: 0})
+	}
+	vectors = append(vectors, fork.Vector{Fork: 1, Reps: 0}, fork.Vector{Fork: 1, Reps: HashReps})
+	for i := range vectors {
+		vectors[i].Input = vectorInput
+		if i < len(outputs) {
+			vectors[i].Output = outputs[i]
+		}
+	}
+	return
+}
+
+// preForkHash returns the hash function of an algorithm from before the first hard fork, which after the hard fork is
+// run on the product of the division steps
+func preForkHash(hash func([]byte) []byte) func(header []byte, hf, reps int) []byte {
+	return func(header []byte, hf, reps int) []byte {
+		if hf > 0 {
+			return DivHash(hash, header, reps)
+		}
+		return hash(header)
+	}
+}
+
+// divHash returns the hash function of an algorithm of the first hard fork, which runs hash on the product of the
+// division steps
+func divHash(hash func([]byte) []byte) func(header []byte, hf, reps int) []byte {
+	return func(header []byte, hf, reps int) []byte {
+		return DivHash(hash, header, reps)
+	}
+}
@@ -116,7 +116,7 @@ func DivHash(hf func([]byte) []byte, blockbytes []byte, howmany int) []byte {
 	return hf(ddd)
 }
 
-// Hash computes the hash of bytes using the named hash
+// Hash computes the hash of bytes using the hash function of the named algorithm in the fork registry
 func Hash(bytes []byte, name string, height int32) (out chainhash.Hash) {
 	hR := HashReps
 	if fork.IsTestnet {
@@ -127,24 +127,9 @@ func Hash(bytes []byte, name string, height int32) (out chainhash.Hash) {
 			//	hR = 6
 		}
 	}
-	switch name {
-	case fork.Scrypt:
-		if fork.GetCurrent(height) > 0 {
-			_ = out.SetBytes(DivHash(ScryptHash, bytes, hR))
-		} else {
-			_ = out.SetBytes(ScryptHash(bytes))
-		}
-	case fork.SHA256d:
-		if fork.GetCurrent(height) > 0 {
-			_ = out.SetBytes(DivHash(chainhash.DoubleHashB, bytes, hR))
-		} else {
-			_ = out.SetBytes(
-				chainhash.DoubleHashB(
-					bytes,
-				),
-			)
-		}
-	default:
+	if a, ok := fork.Registered(name); ok {
+		_ = out.SetBytes(a.Hash(bytes, fork.GetCurrent(height), hR))
+	} else {
 		_ = out.SetBytes(DivHash(Blake3, bytes, hR))
 	}
 	return
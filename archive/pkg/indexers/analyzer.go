@@ -0,0 +1,153 @@
+package indexers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/p9c/qu"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/database"
+)
+
+// Analyzer is a plugin that gathers statistics or catalogs of its own from the blocks of the chain, such as a catalog
+// of OP_RETURN payloads or the distribution of script types over time. An analyzer is run by the index manager as an
+// index with a bucket of its own, so when it is enabled the chain is replayed into it from the genesis block in the
+// background, with its progress shown by getindexinfo, and it is then kept up to date with the chain and rolled back
+// with reorganizations. An analyzer that needs the outputs spent by the blocks implements NeedsInputser.
+type Analyzer interface {
+	// Name returns the name the analyzer is registered and enabled with.
+	Name() string
+	// ConnectBlock is given the bucket of the analyzer and each block connected to the main chain, with the outputs
+	// spent by the block if the analyzer needs them.
+	ConnectBlock(bucket database.Bucket, blk *block.Block, stxos []blockchain.SpentTxOut) error
+	// DisconnectBlock is given the bucket of the analyzer and each block disconnected from the main chain, and removes
+	// what ConnectBlock wrote for it.
+	DisconnectBlock(bucket database.Bucket, blk *block.Block, stxos []blockchain.SpentTxOut) error
+}
+
+// NewAnalyzerFunc returns a new analyzer for the chain with the given parameters.
+type NewAnalyzerFunc func(params *chaincfg.Params) Analyzer
+
+var (
+	analyzersMtx sync.Mutex
+	analyzers    = make(map[string]NewAnalyzerFunc)
+)
+
+// RegisterAnalyzer makes an analyzer available to be enabled by name. It is usually called from the init function of
+// the package of the analyzer, and panics if the name is already registered.
+func RegisterAnalyzer(name string, newAnalyzer NewAnalyzerFunc) {
+	analyzersMtx.Lock()
+	defer analyzersMtx.Unlock()
+	if _, ok := analyzers[name]; ok {
+		panic(fmt.Sprintf("analyzer %s is already registered", name))
+	}
+	analyzers[name] = newAnalyzer
+}
+
+// Analyzers returns the names of the registered analyzers in alphabetical order.
+func Analyzers() (names []string) {
+	analyzersMtx.Lock()
+	defer analyzersMtx.Unlock()
+	for name := range analyzers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// analyzerIndexKey returns the key of the index an analyzer is run as, which is also the key of its bucket.
+func analyzerIndexKey(name string) []byte {
+	return []byte("analyzer-" + name)
+}
+
+// analyzerIndexName returns the human-readable name of the index an analyzer is run as.
+func analyzerIndexName(name string) string {
+	return name + " analyzer"
+}
+
+// AnalyzerIndex runs an analyzer as an index managed by the index manager. It implements the Indexer interface.
+type AnalyzerIndex struct {
+	analyzer Analyzer
+	key      []byte
+}
+
+// Ensure the AnalyzerIndex type implements the Indexer and NeedsInputser interfaces.
+var (
+	_ Indexer       = (*AnalyzerIndex)(nil)
+	_ NeedsInputser = (*AnalyzerIndex)(nil)
+)
+
+// NewAnalyzerIndex returns the index running the registered analyzer with the given name.
+func NewAnalyzerIndex(name string, params *chaincfg.Params) (*AnalyzerIndex, error) {
+	analyzersMtx.Lock()
+	newAnalyzer, ok := analyzers[name]
+	analyzersMtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown analyzer %s, the registered analyzers are %v", name, Analyzers())
+	}
+	return &AnalyzerIndex{analyzer: newAnalyzer(params), key: analyzerIndexKey(name)}, nil
+}
+
+// Key returns the key of the index of the analyzer. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) Key() []byte {
+	return idx.key
+}
+
+// Name returns the human-readable name of the index of the analyzer. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) Name() string {
+	return analyzerIndexName(idx.analyzer.Name())
+}
+
+// Create creates the bucket of the analyzer. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) Create(dbTx database.Tx) (e error) {
+	_, e = dbTx.Metadata().CreateBucket(idx.key)
+	return
+}
+
+// Init does nothing, as analyzers keep all their state in their bucket. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) Init() error {
+	return nil
+}
+
+// ConnectBlock passes the block to the analyzer with its bucket. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) ConnectBlock(dbTx database.Tx, blk *block.Block, stxos []blockchain.SpentTxOut) error {
+	return idx.analyzer.ConnectBlock(dbTx.Metadata().Bucket(idx.key), blk, stxos)
+}
+
+// DisconnectBlock passes the block to the analyzer with its bucket. This is part of the Indexer interface.
+func (idx *AnalyzerIndex) DisconnectBlock(dbTx database.Tx, blk *block.Block, stxos []blockchain.SpentTxOut) error {
+	return idx.analyzer.DisconnectBlock(dbTx.Metadata().Bucket(idx.key), blk, stxos)
+}
+
+// NeedsInputs returns whether the analyzer needs the outputs spent by the blocks. This is part of the NeedsInputser
+// interface.
+func (idx *AnalyzerIndex) NeedsInputs() bool {
+	if n, ok := idx.analyzer.(NeedsInputser); ok {
+		return n.NeedsInputs()
+	}
+	return false
+}
+
+// ViewAnalyzer calls fn with the bucket of the analyzer with the given name in a read-only database transaction, or
+// returns an error if the analyzer has never been enabled.
+func ViewAnalyzer(db database.DB, name string, fn func(bucket database.Bucket) error) error {
+	return db.View(
+		func(dbTx database.Tx) error {
+			bucket := dbTx.Metadata().Bucket(analyzerIndexKey(name))
+			if bucket == nil {
+				return fmt.Errorf("the %s has not been run", analyzerIndexName(name))
+			}
+			return fn(bucket)
+		},
+	)
+}
+
+// DropAnalyzerIndex drops what the analyzer with the given name has gathered, so that the chain is replayed into it
+// from the genesis block the next time it is enabled.
+func DropAnalyzerIndex(db database.DB, name string, interrupt qu.C) error {
+	return dropIndex(db, analyzerIndexKey(name), analyzerIndexName(name), interrupt)
+}
@@ -0,0 +1,144 @@
+package indexers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// bucket is embedded by mapBucket for the methods of a database bucket it does not have, which is done through another
+// type as a bucket has a Bucket method.
+type bucket interface {
+	database.Bucket
+}
+
+// mapBucket is a database bucket kept in a map, with only the methods used by the built in analyzers.
+type mapBucket struct {
+	bucket
+	entries map[string][]byte
+}
+
+func (b *mapBucket) Put(key, value []byte) error {
+	b.entries[string(key)] = value
+	return nil
+}
+
+func (b *mapBucket) Delete(key []byte) error {
+	delete(b.entries, string(key))
+	return nil
+}
+
+// analyzerTestBlock returns a block at height 7 with a standard OP_RETURN output, one pushing two payloads, one that
+// is not made of pushes and two other outputs.
+func analyzerTestBlock(t *testing.T) *block.Block {
+	nullData, e := txscript.NullDataScript([]byte("hello"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	pushes := append(append([]byte{}, nullData...), 1, '!')
+	msgBlock := &wire.Block{
+		Transactions: []*wire.MsgTx{
+			{
+				TxOut: []*wire.TxOut{
+					{Value: 1e8, PkScript: []byte{txscript.OP_TRUE}},
+					{PkScript: pushes},
+					{PkScript: nullData},
+				},
+			},
+			{
+				TxOut: []*wire.TxOut{
+					{PkScript: []byte{txscript.OP_RETURN, txscript.OP_NOP}},
+					{Value: 1e8, PkScript: []byte{txscript.OP_TRUE}},
+				},
+			},
+		},
+	}
+	blk := block.NewBlock(msgBlock)
+	blk.SetHeight(7)
+	return blk
+}
+
+// TestOpReturnAnalyzer ensures the payloads of the OP_RETURN outputs of a block are cataloged under its height, and
+// removed when it is disconnected.
+func TestOpReturnAnalyzer(t *testing.T) {
+	blk := analyzerTestBlock(t)
+	bucket := &mapBucket{entries: make(map[string][]byte)}
+	if e := (opReturnAnalyzer{}).ConnectBlock(bucket, blk, nil); e != nil {
+		t.Fatal(e)
+	}
+	txs := blk.Transactions()
+	want := map[string][]byte{
+		string(opReturnKey(7, txs[0].Hash(), 1)): []byte("hello!"),
+		string(opReturnKey(7, txs[0].Hash(), 2)): []byte("hello"),
+		string(opReturnKey(7, txs[1].Hash(), 0)): {txscript.OP_NOP},
+	}
+	if len(bucket.entries) != len(want) {
+		t.Fatalf("cataloged %d outputs, want %d", len(bucket.entries), len(want))
+	}
+	for key, payload := range want {
+		if got := bucket.entries[key]; !bytes.Equal(got, payload) {
+			t.Errorf("payload %x, want %x", got, payload)
+		}
+		if len(key) != opReturnKeyLen || !bytes.HasPrefix([]byte(key), heightKey(7)) {
+			t.Errorf("key %x is not of the block height", key)
+		}
+	}
+	if e := (opReturnAnalyzer{}).DisconnectBlock(bucket, blk, nil); e != nil {
+		t.Fatal(e)
+	}
+	if len(bucket.entries) != 0 {
+		t.Fatalf("%d outputs left after disconnecting the block", len(bucket.entries))
+	}
+}
+
+// TestScriptTypesAnalyzer ensures the outputs of a block are counted by script type under its height, and the counts
+// removed when it is disconnected.
+func TestScriptTypesAnalyzer(t *testing.T) {
+	blk := analyzerTestBlock(t)
+	bucket := &mapBucket{entries: make(map[string][]byte)}
+	if e := (scriptTypesAnalyzer{}).ConnectBlock(bucket, blk, nil); e != nil {
+		t.Fatal(e)
+	}
+	counts, e := deserializeScriptTypeCounts(bucket.entries[string(heightKey(7))])
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := map[txscript.ScriptClass]uint32{txscript.NonStandardTy: 4, txscript.NullDataTy: 1}
+	if len(counts) != len(want) {
+		t.Fatalf("counted %v, want %v", counts, want)
+	}
+	for class, n := range want {
+		if counts[class] != n {
+			t.Errorf("counted %d %s outputs, want %d", counts[class], class, n)
+		}
+	}
+	if e = (scriptTypesAnalyzer{}).DisconnectBlock(bucket, blk, nil); e != nil {
+		t.Fatal(e)
+	}
+	if len(bucket.entries) != 0 {
+		t.Fatal("counts left after disconnecting the block")
+	}
+	if _, e = deserializeScriptTypeCounts([]byte{1, 2}); e == nil {
+		t.Fatal("corrupt counts were deserialized")
+	}
+}
+
+// TestRegisteredAnalyzers ensures the built in analyzers are registered and an unknown one is not found.
+func TestRegisteredAnalyzers(t *testing.T) {
+	for _, name := range []string{OpReturnAnalyzerName, ScriptTypesAnalyzerName} {
+		idx, e := NewAnalyzerIndex(name, nil)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !bytes.Equal(idx.Key(), analyzerIndexKey(name)) || idx.NeedsInputs() {
+			t.Errorf("%s has key %s and needs inputs %v", idx.Name(), idx.Key(), idx.NeedsInputs())
+		}
+	}
+	if _, e := NewAnalyzerIndex("unknown", nil); e == nil {
+		t.Fatal("unknown analyzer was found")
+	}
+}
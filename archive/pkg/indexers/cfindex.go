@@ -2,6 +2,7 @@ package indexers
 
 import (
 	"errors"
+	"fmt"
 	"github.com/p9c/pod/pkg/block"
 	"github.com/p9c/pod/pkg/chaincfg"
 	
@@ -74,6 +75,9 @@ var _ Indexer = (*CFIndex)(nil)
 // Ensure the CfIndex type implements the NeedsInputser interface.
 var _ NeedsInputser = (*CFIndex)(nil)
 
+// Ensure the CfIndex type implements the Preparer interface.
+var _ Preparer = (*CFIndex)(nil)
+
 // NeedsInputs signals that the index requires the referenced inputs in order to properly create the index. This
 // implements the NeedsInputser interface.
 func (idx *CFIndex) NeedsInputs() bool {
@@ -185,13 +189,35 @@ func (idx *CFIndex) ConnectBlock(
 	dbTx database.Tx, block *block.Block,
 	stxos []blockchain.SpentTxOut,
 ) (e error) {
+	var f interface{}
+	if f, e = idx.PrepareBlock(block, stxos); e != nil {
+		return e
+	}
+	return idx.ConnectPrepared(dbTx, block, f)
+}
+
+// PrepareBlock builds the basic filter for the block, which needs no database, so filters of historical blocks can be
+// built in parallel. This is part of the Preparer interface.
+func (idx *CFIndex) PrepareBlock(
+	block *block.Block,
+	stxos []blockchain.SpentTxOut,
+) (interface{}, error) {
 	prevScripts := make([][]byte, len(stxos))
 	for i, stxo := range stxos {
 		prevScripts[i] = stxo.PkScript
 	}
-	f, e := builder.BuildBasicFilter(block.WireBlock(), prevScripts)
-	if e != nil {
-		return e
+	return builder.BuildBasicFilter(block.WireBlock(), prevScripts)
+}
+
+// ConnectPrepared stores the filter built by PrepareBlock for the block along with its header. This is part of the
+// Preparer interface.
+func (idx *CFIndex) ConnectPrepared(
+	dbTx database.Tx, block *block.Block,
+	prepared interface{},
+) error {
+	f, ok := prepared.(*gcs.Filter)
+	if !ok {
+		return AssertError(fmt.Sprintf("prepared filter for block %v is a %T", block.Hash(), prepared))
 	}
 	return storeFilter(dbTx, block, f, wire.GCSFilterRegular)
 }
@@ -1,7 +1,11 @@
 package indexers
 
 import (
+	"bytes"
 	"fmt"
+	"sync"
+	"time"
+
 	"github.com/p9c/pod/pkg/block"
 	
 	"github.com/p9c/pod/pkg/blockchain"
@@ -60,6 +64,16 @@ func dbIndexConnectBlock(
 	dbTx database.Tx, indexer Indexer, block *block.Block,
 	stxo []blockchain.SpentTxOut,
 ) (e error) {
+	return dbIndexConnect(
+		dbTx, indexer, block, func() error {
+			return indexer.ConnectBlock(dbTx, block, stxo)
+		},
+	)
+}
+
+// dbIndexConnect adds the index entries of the given block with connect and updates the tip of the indexer like
+// dbIndexConnectBlock.
+func dbIndexConnect(dbTx database.Tx, indexer Indexer, block *block.Block, connect func() error) (e error) {
 	// Assert that the block being connected properly connects to the current tip of the index.
 	idxKey := indexer.Key()
 	var curTipHash *chainhash.Hash
@@ -77,7 +91,7 @@ func dbIndexConnectBlock(
 		)
 	}
 	// Notify the indexer with the connected block so it can index it.
-	if e := indexer.ConnectBlock(dbTx, block, stxo); E.Chk(e) {
+	if e := connect(); E.Chk(e) {
 		return e
 	}
 	// Update the current index tip.
@@ -120,8 +134,39 @@ func dbIndexDisconnectBlock(
 // Manager defines an index manager that manages multiple optional indexes and implements the blockchain. IndexManager
 // interface so it can be seamlessly plugged into normal chain processing.
 type Manager struct {
-	db             database.DB
+	db database.DB
+	// chain and interrupt are set by Init, and used to catch up the indexes that are behind the best chain tip.
+	chain     *blockchain.BlockChain
+	interrupt <-chan struct{}
+	// mtx protects the fields below, which change as indexes are added and blocks are indexed. It is always taken
+	// inside the database transaction that indexes a block, so that the tips agree with the database.
+	mtx            sync.Mutex
 	enabledIndexes []Indexer
+	tips           map[string]*indexTip
+	catchingUp     bool
+	// building is the state of the indexes being caught up in parallel, if any are.
+	building *buildProgress
+}
+
+// indexTip is the last block connected to an index.
+type indexTip struct {
+	hash   chainhash.Hash
+	height int32
+}
+
+// IndexInfo is the sync state of an index managed by a Manager.
+type IndexInfo struct {
+	Name string
+	// Height is the height of the last block connected to the index, which is -1 when it has no blocks yet.
+	Height int32
+	// Synced is whether the index has the best chain tip, and is kept up to date as blocks are connected.
+	Synced bool
+	// Progress is the part of the best chain the index has, from 0 to 1.
+	Progress float64
+	// Workers is the number of workers preparing blocks for the index if it is being caught up in parallel, and
+	// BlocksPerSecond how fast it is being caught up.
+	Workers         int
+	BlocksPerSecond float64
 }
 
 // Ensure the Manager type implements the blockchain.IndexManager interface.
@@ -135,11 +180,11 @@ func indexDropKey(idxKey []byte) []byte {
 	return dropKey
 }
 
-// maybeFinishDrops determines if each of the enabled indexes are in the middle of being dropped and finishes dropping
+// maybeFinishDrops determines if each of the given indexes are in the middle of being dropped and finishes dropping
 // them when the are. This is necessary because dropping and index has to be done in several atomic steps rather than
 // one big atomic step due to the massive number of entries.
-func (m *Manager) maybeFinishDrops(interrupt <-chan struct{}) (e error) {
-	indexNeedsDrop := make([]bool, len(m.enabledIndexes))
+func (m *Manager) maybeFinishDrops(indexes []Indexer, interrupt <-chan struct{}) (e error) {
+	indexNeedsDrop := make([]bool, len(indexes))
 	if e = m.db.View(
 		func(dbTx database.Tx) (e error) {
 			// None of the indexes needs to be dropped if the index tips bucket hasn't been created yet.
@@ -148,7 +193,7 @@ func (m *Manager) maybeFinishDrops(interrupt <-chan struct{}) (e error) {
 				return nil
 			}
 			// Mark the indexer as requiring a drop if one is already in progress.
-			for i, indexer := range m.enabledIndexes {
+			for i, indexer := range indexes {
 				dropKey := indexDropKey(indexer.Key())
 				if indexesBucket.Get(dropKey) != nil {
 					indexNeedsDrop[i] = true
@@ -164,7 +209,7 @@ func (m *Manager) maybeFinishDrops(interrupt <-chan struct{}) (e error) {
 	}
 	// Finish dropping any of the enabled indexes that are already in the
 	// middle of being dropped.
-	for i, indexer := range m.enabledIndexes {
+	for i, indexer := range indexes {
 		if !indexNeedsDrop[i] {
 			continue
 		}
@@ -180,10 +225,10 @@ func (m *Manager) maybeFinishDrops(interrupt <-chan struct{}) (e error) {
 	return nil
 }
 
-// maybeCreateIndexes determines if each of the enabled indexes have already been created and creates them if not.
-func (m *Manager) maybeCreateIndexes(dbTx database.Tx) (e error) {
+// maybeCreateIndexes determines if each of the given indexes have already been created and creates them if not.
+func (m *Manager) maybeCreateIndexes(dbTx database.Tx, indexes []Indexer) (e error) {
 	indexesBucket := dbTx.Metadata().Bucket(indexTipsBucketName)
-	for _, indexer := range m.enabledIndexes {
+	for _, indexer := range indexes {
 		// Nothing to do if the index tip already exists.
 		idxKey := indexer.Key()
 		if indexesBucket.Get(idxKey) != nil {
@@ -203,11 +248,12 @@ func (m *Manager) maybeCreateIndexes(dbTx database.Tx) (e error) {
 	return nil
 }
 
-// Init initializes the enabled indexes. This is called during chain initialization and primarily consists of catching
-// up all indexes to the current best chain tip. This is necessary since each index can be disabled and re-enabled at
-// any time and attempting to catch-up indexes at the same time new blocks are being downloaded would lead to an overall
-// longer time to catch up due to the I/O contention. This is part of the blockchain.IndexManager interface.
+// Init initializes the enabled indexes. This is called during chain initialization and consists of rolling back indexes
+// whose tip was orphaned while they were disabled, and starting to catch up the indexes that are behind the current
+// best chain tip. Each index can be disabled and re-enabled at any time, and is caught up in the background from its
+// own tip while the node serves traffic. This is part of the blockchain.IndexManager interface.
 func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{}) (e error) {
+	m.chain, m.interrupt = chain, interrupt
 	// Nothing to do when no indexes are enabled.
 	if len(m.enabledIndexes) == 0 {
 		return nil
@@ -216,7 +262,7 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 		return errInterruptRequested
 	}
 	// Finish and drops that were previously interrupted.
-	if e = m.maybeFinishDrops(interrupt); E.Chk(e) {
+	if e = m.maybeFinishDrops(m.enabledIndexes, interrupt); E.Chk(e) {
 		return e
 	}
 	// Create the initial state for the indexes as needed.
@@ -227,7 +273,7 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 			if _, e = meta.CreateBucketIfNotExists(indexTipsBucketName); E.Chk(e) {
 				return e
 			}
-			return m.maybeCreateIndexes(dbTx)
+			return m.maybeCreateIndexes(dbTx, m.enabledIndexes)
 		},
 	)
 	if E.Chk(e) {
@@ -325,96 +371,280 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 			)
 		}
 	}
-	// Fetch the current tip heights for each index along with tracking the lowest one so the catchup code only needs to
-	// start at the earliest block and is able to skip connecting the block for the indexes that don't need it.
-	bestHeight := chain.BestSnapshot().Height
-	lowestHeight := bestHeight
-	indexerHeights := make([]int32, len(m.enabledIndexes))
-	e = m.db.View(
+	// Load the tips of the indexes and catch up the ones that are behind the best chain tip in the background.
+	if e = m.loadTips(m.enabledIndexes); E.Chk(e) {
+		return e
+	}
+	m.mtx.Lock()
+	m.startCatchUp()
+	m.mtx.Unlock()
+	return nil
+}
+
+// loadTips reads the tips of the given indexes from the database.
+func (m *Manager) loadTips(indexes []Indexer) (e error) {
+	tips := make(map[string]*indexTip, len(indexes))
+	if e = m.db.View(
 		func(dbTx database.Tx) (e error) {
-			for i, indexer := range m.enabledIndexes {
-				idxKey := indexer.Key()
-				_, height, e := dbFetchIndexerTip(dbTx, idxKey)
-				if e != nil {
+			for _, indexer := range indexes {
+				tip := &indexTip{}
+				var hash *chainhash.Hash
+				if hash, tip.height, e = dbFetchIndexerTip(dbTx, indexer.Key()); E.Chk(e) {
 					return e
 				}
-				T.F(
-					"current %s tip (height %d, hash %v)",
-					indexer.Name(),
-					height,
-					hash,
-				)
-				indexerHeights[i] = height
-				if height < lowestHeight {
-					lowestHeight = height
-				}
+				tip.hash = *hash
+				T.F("current %s tip (height %d, hash %v)", indexer.Name(), tip.height, hash)
+				tips[string(indexer.Key())] = tip
 			}
 			return nil
 		},
-	)
-	if e != nil {
-		return e
+	); E.Chk(e) {
+		return
 	}
-	// Nothing to index if all of the indexes are caught up.
-	if lowestHeight == bestHeight {
-		return nil
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.tips == nil {
+		m.tips = make(map[string]*indexTip, len(tips))
 	}
-	// Create a progress logger for the indexing process below.
-	progressLogger := newBlockProgressLogger(
-		"Indexed",
-		// log.L,
-	)
-	// At this point, one or more indexes are behind the current best chain tip and need to be caught up, so log the
-	// details and loop through each block that needs to be indexed.
-	I.F(
-		"catching up indexes from height %d to %d",
-		lowestHeight,
-		bestHeight,
-	)
-	for height := lowestHeight + 1; height <= bestHeight; height++ {
-		// Load the block for the height since it is required to index it.
-		block, e := chain.BlockByHeight(height)
+	for k, tip := range tips {
+		m.tips[k] = tip
+	}
+	return
+}
+
+// startCatchUp starts catching up the indexes in the background if it is not running already. The manager mutex must be
+// held.
+func (m *Manager) startCatchUp() {
+	if m.catchingUp || m.chain == nil {
+		return
+	}
+	m.catchingUp = true
+	go m.catchUp()
+}
+
+// catchUp connects the blocks of the best chain to the indexes that are behind it, a block at a time from the lowest
+// tip, until all of them are synced or an interrupt is requested. Blocks connected to the chain in the meantime are only
+// connected by ConnectBlock to the indexes that are synced, so the others keep catching up to the moving tip here.
+func (m *Manager) catchUp() {
+	progressLogger := newBlockProgressLogger("Indexed")
+	var indexed bool
+	defer func() {
+		m.mtx.Lock()
+		m.catchingUp = false
+		m.mtx.Unlock()
+	}()
+	for !interruptRequested(m.interrupt) {
+		best := m.chain.BestSnapshot()
+		m.mtx.Lock()
+		tip, behind := m.lowestTip(best.Height)
+		m.mtx.Unlock()
+		if len(behind) == 0 {
+			if indexed {
+				I.Ln("indexes caught up to height", best.Height)
+			}
+			return
+		}
+		var blk *block.Block
+		var e error
+		p := preparers(behind)
+		if p != nil && best.Height-tip.height >= minParallelBlocks {
+			blk, e = m.catchUpParallel(tip, behind, best.Height, progressLogger)
+		} else {
+			p = nil
+			blk, e = m.catchUpBlock(tip, behind)
+		}
 		if e != nil {
-			return e
+			E.Ln("stopped catching up indexes:", e)
+			// The tips in memory may no longer agree with the database if the update failed.
+			if e = m.loadTips(behind); E.Chk(e) {
+			}
+			return
 		}
-		if interruptRequested(interrupt) {
-			return errInterruptRequested
+		if blk != nil {
+			indexed = true
+			if p == nil {
+				progressLogger.LogBlockHeight(blk)
+			}
+		}
+	}
+}
+
+// lowestTip returns the lowest tip of the indexes that are behind the best chain tip at bestHeight, and the indexes that
+// have it. The manager mutex must be held.
+func (m *Manager) lowestTip(bestHeight int32) (lowest indexTip, indexes []Indexer) {
+	for _, indexer := range m.enabledIndexes {
+		tip := m.tips[string(indexer.Key())]
+		if tip == nil || tip.height >= bestHeight {
+			continue
+		}
+		switch {
+		case len(indexes) == 0 || tip.height < lowest.height:
+			lowest, indexes = *tip, []Indexer{indexer}
+		case tip.height == lowest.height && tip.hash == lowest.hash:
+			indexes = append(indexes, indexer)
+		}
+	}
+	return
+}
+
+// catchUpBlock connects the block after tip in the best chain to the given indexes, or disconnects tip from them if a
+// reorganization has taken it out of the best chain, and returns the block that was connected. Indexes that were moved
+// on from tip by the chain while the block was loaded are left alone.
+func (m *Manager) catchUpBlock(tip indexTip, indexes []Indexer) (blk *block.Block, e error) {
+	var spentTxos []blockchain.SpentTxOut
+	if tip.height >= 0 && !m.chain.MainChainHasBlock(&tip.hash) {
+		// The tip is orphaned, so load it from the database directly, as the chain only returns main chain blocks.
+		var orphan *block.Block
+		if e = m.db.View(
+			func(dbTx database.Tx) (e error) {
+				var blockBytes []byte
+				if blockBytes, e = dbTx.FetchBlock(&tip.hash); E.Chk(e) {
+					return
+				}
+				if orphan, e = block.NewFromBytes(blockBytes); E.Chk(e) {
+					return
+				}
+				orphan.SetHeight(tip.height)
+				return
+			},
+		); E.Chk(e) {
+			return
 		}
-		// Connect the block for all indexes that need it.
-		var spentTxos []blockchain.SpentTxOut
-		for i, indexer := range m.enabledIndexes {
-			// Skip indexes that don't need to be updated with this block.
-			if indexerHeights[i] >= height {
-				continue
+		if spentTxos, e = m.chain.FetchSpendJournal(orphan); E.Chk(e) {
+			return
+		}
+		return nil, m.updateIndexes(
+			tip, indexes, func(dbTx database.Tx, indexer Indexer) error {
+				return dbIndexDisconnectBlock(dbTx, indexer, orphan, spentTxos)
+			},
+		)
+	}
+	if blk, e = m.chain.BlockByHeight(tip.height + 1); e != nil {
+		// The chain was reorganized to a lower height since the tip was checked, try again.
+		D.Ln("block after index tip is gone:", e)
+		return nil, nil
+	}
+	if !blk.WireBlock().Header.PrevBlock.IsEqual(&tip.hash) {
+		// The chain was reorganized since the tip was checked, try again.
+		return nil, nil
+	}
+	for _, indexer := range indexes {
+		if indexNeedsInputs(indexer) {
+			if spentTxos, e = m.chain.FetchSpendJournal(blk); E.Chk(e) {
+				return nil, e
 			}
-			// When the index requires all of the referenced txouts and they haven't been loaded yet, they need to be
-			// retrieved from the spend journal.
-			if spentTxos == nil && indexNeedsInputs(indexer) {
-				spentTxos, e = chain.FetchSpendJournal(block)
-				if e != nil {
-					return e
+			break
+		}
+	}
+	return blk, m.updateIndexes(
+		tip, indexes, func(dbTx database.Tx, indexer Indexer) error {
+			return dbIndexConnectBlock(dbTx, indexer, blk, spentTxos)
+		},
+	)
+}
+
+// updateIndexes applies update to the given indexes that are still at tip in a database transaction, and reloads their
+// tips from it.
+func (m *Manager) updateIndexes(
+	tip indexTip, indexes []Indexer, update func(dbTx database.Tx, indexer Indexer) error,
+) error {
+	return m.db.Update(
+		func(dbTx database.Tx) (e error) {
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			for _, indexer := range indexes {
+				t := m.tips[string(indexer.Key())]
+				if t == nil || t.hash != tip.hash {
+					continue
+				}
+				if e = update(dbTx, indexer); E.Chk(e) {
+					return
 				}
+				var hash *chainhash.Hash
+				if hash, t.height, e = dbFetchIndexerTip(dbTx, indexer.Key()); E.Chk(e) {
+					return
+				}
+				t.hash = *hash
 			}
-			e := m.db.Update(
-				func(dbTx database.Tx) (e error) {
-					return dbIndexConnectBlock(
-						dbTx, indexer, block, spentTxos,
-					)
-				},
-			)
-			if e != nil {
-				return e
+			return
+		},
+	)
+}
+
+// AddIndex enables an index on a running manager. The index is created if it does not exist yet, and is caught up to
+// the best chain tip in the background like the indexes that are behind when the manager is initialized.
+func (m *Manager) AddIndex(indexer Indexer) (e error) {
+	m.mtx.Lock()
+	for _, idx := range m.enabledIndexes {
+		if bytes.Equal(idx.Key(), indexer.Key()) {
+			m.mtx.Unlock()
+			return fmt.Errorf("%s is already enabled", indexer.Name())
+		}
+	}
+	if m.chain == nil {
+		// Init has not been called yet, and sets up the index with the others.
+		m.enabledIndexes = append(m.enabledIndexes, indexer)
+		m.mtx.Unlock()
+		return
+	}
+	m.mtx.Unlock()
+	indexes := []Indexer{indexer}
+	if e = m.maybeFinishDrops(indexes, m.interrupt); E.Chk(e) {
+		return
+	}
+	if e = m.db.Update(
+		func(dbTx database.Tx) (e error) {
+			if _, e = dbTx.Metadata().CreateBucketIfNotExists(indexTipsBucketName); E.Chk(e) {
+				return
 			}
-			indexerHeights[i] = height
+			return m.maybeCreateIndexes(dbTx, indexes)
+		},
+	); E.Chk(e) {
+		return
+	}
+	if e = indexer.Init(); E.Chk(e) {
+		return
+	}
+	if e = m.loadTips(indexes); E.Chk(e) {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.enabledIndexes = append(m.enabledIndexes, indexer)
+	m.startCatchUp()
+	I.Ln("enabled", indexer.Name())
+	return
+}
+
+// IndexInfo returns the sync state of the enabled indexes, in the order they were enabled.
+func (m *Manager) IndexInfo() (infos []IndexInfo) {
+	var best *blockchain.BestState
+	if m.chain != nil {
+		best = m.chain.BestSnapshot()
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, indexer := range m.enabledIndexes {
+		info := IndexInfo{Name: indexer.Name(), Height: -1}
+		if tip := m.tips[string(indexer.Key())]; tip != nil {
+			info.Height = tip.height
+			info.Synced = best != nil && tip.hash == best.Hash
 		}
-		// Log indexing progress.
-		progressLogger.LogBlockHeight(block)
-		if interruptRequested(interrupt) {
-			return errInterruptRequested
+		switch {
+		case info.Synced:
+			info.Progress = 1
+		case best != nil:
+			info.Progress = float64(info.Height+1) / float64(best.Height+1)
 		}
+		if b := m.building; b != nil && b.indexes[string(indexer.Key())] {
+			info.Workers = b.workers
+			if elapsed := time.Since(b.start).Seconds(); elapsed > 0 {
+				info.BlocksPerSecond = float64(info.Height-b.startHeight) / elapsed
+			}
+		}
+		infos = append(infos, info)
 	}
-	I.Ln("indexes caught up to height", bestHeight)
-	return nil
+	return
 }
 
 // indexNeedsInputs returns whether or not the index needs access to the txouts referenced by the transaction inputs
@@ -460,11 +690,22 @@ func (m *Manager) ConnectBlock(
 	stxos []blockchain.SpentTxOut,
 ) (e error) {
 	// Call each of the currently active optional indexes with the block being connected so they can update accordingly.
+	// Indexes that are behind are caught up in the background instead, and so are the indexes after them, as later
+	// indexes can depend on earlier ones.
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	prevHash := &block.WireBlock().Header.PrevBlock
+	var behind bool
 	for _, index := range m.enabledIndexes {
-		e := dbIndexConnectBlock(dbTx, index, block, stxos)
-		if e != nil {
+		tip := m.tips[string(index.Key())]
+		if behind || tip == nil || !tip.hash.IsEqual(prevHash) {
+			behind = true
+			continue
+		}
+		if e = dbIndexConnectBlock(dbTx, index, block, stxos); E.Chk(e) {
 			return e
 		}
+		tip.hash, tip.height = *block.Hash(), block.Height()
 	}
 	return nil
 }
@@ -477,12 +718,18 @@ func (m *Manager) DisconnectBlock(
 	stxo []blockchain.SpentTxOut,
 ) (e error) {
 	// Call each of the currently active optional indexes with the block being disconnected so they can update
-	// accordingly.
+	// accordingly. Indexes that are behind do not have the block yet.
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
 	for _, index := range m.enabledIndexes {
-		e := dbIndexDisconnectBlock(dbTx, index, block, stxo)
-		if e != nil {
+		tip := m.tips[string(index.Key())]
+		if tip == nil || !tip.hash.IsEqual(block.Hash()) {
+			continue
+		}
+		if e = dbIndexDisconnectBlock(dbTx, index, block, stxo); E.Chk(e) {
 			return e
 		}
+		tip.hash, tip.height = block.WireBlock().Header.PrevBlock, block.Height()-1
 	}
 	return nil
 }
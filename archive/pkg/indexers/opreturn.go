@@ -0,0 +1,136 @@
+package indexers
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+// OpReturnAnalyzerName is the name of the analyzer cataloging the payloads of the OP_RETURN outputs of the chain.
+const OpReturnAnalyzerName = "opreturn"
+
+func init() {
+	RegisterAnalyzer(
+		OpReturnAnalyzerName, func(*chaincfg.Params) Analyzer {
+			return opReturnAnalyzer{}
+		},
+	)
+}
+
+// The catalog has an entry for each output whose script starts with OP_RETURN, keyed by the height of its block, so
+// that it is read in order of the chain:
+//
+//   <block height><tx hash><output index> = <payload>
+//
+//   Field           Type              Size
+//   block height    uint32 big endian 4
+//   tx hash         chainhash.Hash    32
+//   output index    uint32 big endian 4
+//   payload         []byte            rest
+//
+// The payload is the data pushed by the script, or the rest of the script after the OP_RETURN if it is not made of
+// pushes.
+
+// opReturnKeyLen is the length of the keys of the catalog.
+const opReturnKeyLen = 4 + chainhash.HashSize + 4
+
+// opReturnAnalyzer catalogs the payloads of the OP_RETURN outputs of the chain.
+type opReturnAnalyzer struct{}
+
+// Name returns the name of the analyzer. This is part of the Analyzer interface.
+func (opReturnAnalyzer) Name() string {
+	return OpReturnAnalyzerName
+}
+
+// ConnectBlock adds the OP_RETURN outputs of the block to the catalog. This is part of the Analyzer interface.
+func (opReturnAnalyzer) ConnectBlock(bucket database.Bucket, blk *block.Block, _ []blockchain.SpentTxOut) (e error) {
+	return forEachOpReturn(
+		blk, func(key, payload []byte) error {
+			return bucket.Put(key, payload)
+		},
+	)
+}
+
+// DisconnectBlock removes the OP_RETURN outputs of the block from the catalog. This is part of the Analyzer interface.
+func (opReturnAnalyzer) DisconnectBlock(bucket database.Bucket, blk *block.Block, _ []blockchain.SpentTxOut) (e error) {
+	return forEachOpReturn(
+		blk, func(key, _ []byte) error {
+			return bucket.Delete(key)
+		},
+	)
+}
+
+// forEachOpReturn calls fn with the catalog key and payload of each OP_RETURN output of the block.
+func forEachOpReturn(blk *block.Block, fn func(key, payload []byte) error) (e error) {
+	for _, tx := range blk.Transactions() {
+		for i, out := range tx.MsgTx().TxOut {
+			if len(out.PkScript) == 0 || out.PkScript[0] != txscript.OP_RETURN {
+				continue
+			}
+			if e = fn(opReturnKey(blk.Height(), tx.Hash(), uint32(i)), opReturnPayload(out.PkScript)); E.Chk(e) {
+				return
+			}
+		}
+	}
+	return
+}
+
+// opReturnKey returns the catalog key of an OP_RETURN output.
+func opReturnKey(height int32, txHash *chainhash.Hash, index uint32) []byte {
+	key := append(heightKey(height), txHash[:]...)
+	var i [4]byte
+	binary.BigEndian.PutUint32(i[:], index)
+	return append(key, i[:]...)
+}
+
+// opReturnPayload returns the payload of an OP_RETURN output script.
+func opReturnPayload(pkScript []byte) []byte {
+	if !txscript.IsPushOnlyScript(pkScript[1:]) {
+		return pkScript[1:]
+	}
+	pushes, _ := txscript.PushedData(pkScript[1:])
+	return bytes.Join(pushes, nil)
+}
+
+// OpReturnEntry is an OP_RETURN output in the catalog of the opreturn analyzer.
+type OpReturnEntry struct {
+	Height  int32
+	TxHash  chainhash.Hash
+	Index   uint32
+	Payload []byte
+}
+
+// ForEachOpReturn calls fn with each OP_RETURN output cataloged by the opreturn analyzer in blocks from height start up
+// to and including end, in order of the chain, stopping early if fn returns an error.
+func ForEachOpReturn(db database.DB, start, end int32, fn func(entry *OpReturnEntry) error) error {
+	return ViewAnalyzer(
+		db, OpReturnAnalyzerName, func(bucket database.Bucket) error {
+			cursor := bucket.Cursor()
+			for ok := cursor.Seek(heightKey(start)); ok; ok = cursor.Next() {
+				key := cursor.Key()
+				if len(key) != opReturnKeyLen {
+					return errDeserialize("corrupt opreturn analyzer entry")
+				}
+				entry := &OpReturnEntry{
+					Height:  int32(binary.BigEndian.Uint32(key)),
+					Index:   binary.BigEndian.Uint32(key[4+chainhash.HashSize:]),
+					Payload: append([]byte{}, cursor.Value()...),
+				}
+				if entry.Height > end {
+					break
+				}
+				copy(entry.TxHash[:], key[4:])
+				if e := fn(entry); e != nil {
+					return e
+				}
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,282 @@
+package indexers
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/database"
+)
+
+const (
+	// minParallelBlocks is how far behind the best chain tip indexes must be for them to be caught up in parallel,
+	// below which starting the workers is not worth it.
+	minParallelBlocks = 1000
+	// parallelWindow is how many blocks per worker may be loaded and prepared ahead of the next block to be connected,
+	// which bounds the memory used by blocks waiting for the ones before them.
+	parallelWindow = 16
+	// parallelBatch is the most blocks connected to the indexes in one database transaction.
+	parallelBatch = 200
+)
+
+// BuildWorkers is the number of workers that prepare blocks for indexes that are caught up in parallel.
+var BuildWorkers = runtime.NumCPU()
+
+// Preparer is implemented by indexes that can do the costly part of indexing a block, such as building its filter,
+// without the database. Indexes that are far behind the best chain tip and all implement it are caught up by
+// preparing blocks in parallel and connecting them in order.
+type Preparer interface {
+	// PrepareBlock returns what the index needs to connect the block, given the outputs it spends.
+	PrepareBlock(*block.Block, []blockchain.SpentTxOut) (interface{}, error)
+	// ConnectPrepared connects the block to the index with what PrepareBlock returned for it.
+	ConnectPrepared(dbTx database.Tx, blk *block.Block, prepared interface{}) error
+}
+
+// preparedBlock is a block of the best chain prepared for the indexes being caught up in parallel, with what each of
+// them returned for it in the order of the indexes.
+type preparedBlock struct {
+	height   int32
+	blk      *block.Block
+	prepared []interface{}
+	e        error
+}
+
+// buildProgress is the state of the indexes being caught up in parallel.
+type buildProgress struct {
+	indexes     map[string]bool
+	workers     int
+	start       time.Time
+	startHeight int32
+}
+
+// reorderBuffer holds prepared blocks that arrive out of order until the blocks before them have arrived.
+type reorderBuffer struct {
+	next    int32
+	pending map[int32]*preparedBlock
+}
+
+// newReorderBuffer returns a buffer that releases blocks from the height next onwards.
+func newReorderBuffer(next int32) *reorderBuffer {
+	return &reorderBuffer{next: next, pending: make(map[int32]*preparedBlock)}
+}
+
+// add holds a prepared block until it is released.
+func (r *reorderBuffer) add(pb *preparedBlock) {
+	r.pending[pb.height] = pb
+}
+
+// release returns up to max of the held blocks that follow the blocks already released without a gap, in order.
+func (r *reorderBuffer) release(max int) (ready []*preparedBlock) {
+	for len(ready) < max {
+		pb, ok := r.pending[r.next]
+		if !ok {
+			break
+		}
+		delete(r.pending, r.next)
+		ready = append(ready, pb)
+		r.next++
+	}
+	return
+}
+
+// preparers returns the indexes that implement Preparer, or nil if any of them do not.
+func preparers(indexes []Indexer) (p []Preparer) {
+	for _, indexer := range indexes {
+		preparer, ok := indexer.(Preparer)
+		if !ok {
+			return nil
+		}
+		p = append(p, preparer)
+	}
+	return
+}
+
+// catchUpParallel connects the blocks of the best chain from the one after tip up to the height target to the given
+// indexes, which must all implement Preparer. Blocks are loaded and prepared by BuildWorkers workers and connected in
+// order, a batch at a time, and logged to progressLogger. It stops early without an error if the chain is reorganized
+// under it, leaving the rest to be caught up a block at a time, and returns the last block connected.
+func (m *Manager) catchUpParallel(
+	tip indexTip, indexes []Indexer, target int32, progressLogger *blockProgressLogger,
+) (last *block.Block, e error) {
+	prep := preparers(indexes)
+	workers := BuildWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	m.setBuilding(indexes, workers, tip.height)
+	defer m.setBuilding(nil, 0, 0)
+	I.F(
+		"catching up %d indexes from height %d to %d with %d workers", len(indexes), tip.height+1, target,
+		workers,
+	)
+	stop := make(chan struct{})
+	heights := make(chan int32)
+	results := make(chan *preparedBlock, workers)
+	// credits limits how far ahead of the next block to be connected blocks are prepared.
+	credits := make(chan struct{}, workers*parallelWindow)
+	var wg sync.WaitGroup
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(heights)
+		for height := tip.height + 1; height <= target; height++ {
+			select {
+			case credits <- struct{}{}:
+			case <-stop:
+				return
+			}
+			select {
+			case heights <- height:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				select {
+				case results <- m.prepareBlock(height, indexes, prep):
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+	buffer := newReorderBuffer(tip.height + 1)
+	for buffer.next <= target {
+		select {
+		case pb := <-results:
+			buffer.add(pb)
+		case <-m.interrupt:
+			return last, nil
+		}
+		for {
+			batch := buffer.release(parallelBatch)
+			if len(batch) == 0 {
+				break
+			}
+			for range batch {
+				<-credits
+			}
+			for i, pb := range batch {
+				if pb.e != nil {
+					return last, pb.e
+				}
+				prevHash := &tip.hash
+				if i > 0 {
+					prevHash = batch[i-1].blk.Hash()
+				}
+				if pb.blk == nil || !pb.blk.WireBlock().Header.PrevBlock.IsEqual(prevHash) {
+					// The chain was reorganized since the blocks were loaded, so leave the rest to the serial catch up.
+					batch = batch[:i]
+					break
+				}
+			}
+			if len(batch) == 0 {
+				return last, nil
+			}
+			if e = m.connectPrepared(tip, indexes, prep, batch); E.Chk(e) {
+				return last, e
+			}
+			for _, pb := range batch {
+				progressLogger.LogBlockHeight(pb.blk)
+			}
+			last = batch[len(batch)-1].blk
+			tip = indexTip{hash: *last.Hash(), height: last.Height()}
+			if last.Height() < buffer.next-1 {
+				// The batch was cut short by a reorganization.
+				return last, nil
+			}
+		}
+	}
+	return
+}
+
+// prepareBlock loads the block of the best chain at the height and prepares it for the indexes. The block is nil if the
+// chain no longer reaches the height.
+func (m *Manager) prepareBlock(height int32, indexes []Indexer, prep []Preparer) (pb *preparedBlock) {
+	pb = &preparedBlock{height: height}
+	var blk *block.Block
+	var e error
+	if blk, e = m.chain.BlockByHeight(height); e != nil {
+		D.Ln("block to index is gone:", e)
+		return
+	}
+	var spentTxos []blockchain.SpentTxOut
+	for _, indexer := range indexes {
+		if indexNeedsInputs(indexer) {
+			if spentTxos, pb.e = m.chain.FetchSpendJournal(blk); E.Chk(pb.e) {
+				return
+			}
+			break
+		}
+	}
+	pb.prepared = make([]interface{}, len(prep))
+	for i, p := range prep {
+		if pb.prepared[i], pb.e = p.PrepareBlock(blk, spentTxos); E.Chk(pb.e) {
+			return
+		}
+	}
+	pb.blk = blk
+	return
+}
+
+// connectPrepared connects the prepared blocks, which follow tip in order, to the given indexes that are still at tip
+// in one database transaction.
+func (m *Manager) connectPrepared(
+	tip indexTip, indexes []Indexer, prep []Preparer, batch []*preparedBlock,
+) error {
+	last := batch[len(batch)-1].blk
+	return m.db.Update(
+		func(dbTx database.Tx) (e error) {
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			for i, indexer := range indexes {
+				t := m.tips[string(indexer.Key())]
+				if t == nil || t.hash != tip.hash {
+					continue
+				}
+				for _, pb := range batch {
+					if e = dbIndexConnect(
+						dbTx, indexer, pb.blk, func() error {
+							return prep[i].ConnectPrepared(dbTx, pb.blk, pb.prepared[i])
+						},
+					); E.Chk(e) {
+						return
+					}
+				}
+				t.hash, t.height = *last.Hash(), last.Height()
+			}
+			return
+		},
+	)
+}
+
+// setBuilding records the indexes being caught up in parallel from the height startHeight, or that none are if indexes
+// is nil.
+func (m *Manager) setBuilding(indexes []Indexer, workers int, startHeight int32) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if indexes == nil {
+		m.building = nil
+		return
+	}
+	m.building = &buildProgress{
+		indexes:     make(map[string]bool, len(indexes)),
+		workers:     workers,
+		start:       time.Now(),
+		startHeight: startHeight,
+	}
+	for _, indexer := range indexes {
+		m.building.indexes[string(indexer.Key())] = true
+	}
+}
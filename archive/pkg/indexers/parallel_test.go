@@ -0,0 +1,51 @@
+package indexers
+
+import (
+	"testing"
+)
+
+// TestReorderBuffer ensures prepared blocks that arrive out of order are released in order, without gaps and no more
+// than asked for at a time.
+func TestReorderBuffer(t *testing.T) {
+	r := newReorderBuffer(10)
+	heights := func(blocks []*preparedBlock) (h []int32) {
+		for _, pb := range blocks {
+			h = append(h, pb.height)
+		}
+		return
+	}
+	equal := func(a, b []int32) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+	tests := []struct {
+		name string
+		add  []int32
+		max  int
+		want []int32
+	}{
+		{name: "waits for the next block", add: []int32{12, 11}, max: 10, want: nil},
+		{name: "releases in order", add: []int32{10}, max: 10, want: []int32{10, 11, 12}},
+		{name: "stops at a gap", add: []int32{13, 15}, max: 10, want: []int32{13}},
+		{name: "releases no more than max", add: []int32{14, 16, 17}, max: 2, want: []int32{14, 15}},
+		{name: "releases the rest", add: nil, max: 10, want: []int32{16, 17}},
+	}
+	for _, test := range tests {
+		for _, height := range test.add {
+			r.add(&preparedBlock{height: height})
+		}
+		if got := heights(r.release(test.max)); !equal(got, test.want) {
+			t.Errorf("%s: released %v, want %v", test.name, got, test.want)
+		}
+	}
+	if r.next != 18 || len(r.pending) != 0 {
+		t.Errorf("next is %d with %d pending, want 18 with none pending", r.next, len(r.pending))
+	}
+}
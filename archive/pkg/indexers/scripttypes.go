@@ -0,0 +1,116 @@
+package indexers
+
+import (
+	"encoding/binary"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/database"
+	"github.com/p9c/pod/pkg/txscript"
+)
+
+// ScriptTypesAnalyzerName is the name of the analyzer counting the outputs of each script type in the blocks of the
+// chain, giving the distribution of script types over time.
+const ScriptTypesAnalyzerName = "scripttypes"
+
+func init() {
+	RegisterAnalyzer(
+		ScriptTypesAnalyzerName, func(*chaincfg.Params) Analyzer {
+			return scriptTypesAnalyzer{}
+		},
+	)
+}
+
+// The analyzer has an entry for each block with the number of its outputs of each script type that it has:
+//
+//   <block height> = <script type><count>...
+//
+//   Field           Type              Size
+//   block height    uint32 big endian 4
+//   script type     uint8             1
+//   count           uint32            4
+
+// scriptTypesAnalyzer counts the outputs of each script type in the blocks of the chain.
+type scriptTypesAnalyzer struct{}
+
+// Name returns the name of the analyzer. This is part of the Analyzer interface.
+func (scriptTypesAnalyzer) Name() string {
+	return ScriptTypesAnalyzerName
+}
+
+// ConnectBlock records the number of outputs of each script type in the block. This is part of the Analyzer interface.
+func (scriptTypesAnalyzer) ConnectBlock(bucket database.Bucket, blk *block.Block, _ []blockchain.SpentTxOut) error {
+	counts := make(map[txscript.ScriptClass]uint32)
+	for _, tx := range blk.Transactions() {
+		for _, out := range tx.MsgTx().TxOut {
+			counts[txscript.GetScriptClass(out.PkScript)]++
+		}
+	}
+	return bucket.Put(heightKey(blk.Height()), serializeScriptTypeCounts(counts))
+}
+
+// DisconnectBlock removes the counts of the block. This is part of the Analyzer interface.
+func (scriptTypesAnalyzer) DisconnectBlock(bucket database.Bucket, blk *block.Block, _ []blockchain.SpentTxOut) error {
+	return bucket.Delete(heightKey(blk.Height()))
+}
+
+// heightKey returns a key of a block height that sorts in order of the chain.
+func heightKey(height int32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, uint32(height))
+	return key
+}
+
+// serializeScriptTypeCounts returns the serialized counts of the script types, in order of script type.
+func serializeScriptTypeCounts(counts map[txscript.ScriptClass]uint32) []byte {
+	serialized := make([]byte, 0, len(counts)*5)
+	for class := txscript.NonStandardTy; class <= txscript.NullDataTy; class++ {
+		count, ok := counts[class]
+		if !ok {
+			continue
+		}
+		var c [4]byte
+		byteOrder.PutUint32(c[:], count)
+		serialized = append(append(serialized, byte(class)), c[:]...)
+	}
+	return serialized
+}
+
+// deserializeScriptTypeCounts returns the counts of the script types serialized by serializeScriptTypeCounts.
+func deserializeScriptTypeCounts(serialized []byte) (counts map[txscript.ScriptClass]uint32, e error) {
+	if len(serialized)%5 != 0 {
+		return nil, errDeserialize("corrupt scripttypes analyzer entry")
+	}
+	counts = make(map[txscript.ScriptClass]uint32, len(serialized)/5)
+	for i := 0; i < len(serialized); i += 5 {
+		counts[txscript.ScriptClass(serialized[i])] = byteOrder.Uint32(serialized[i+1:])
+	}
+	return
+}
+
+// ForEachScriptTypeCount calls fn with the number of outputs of each script type in the blocks from height start up to
+// and including end, in order of the chain, stopping early if fn returns an error.
+func ForEachScriptTypeCount(
+	db database.DB, start, end int32, fn func(height int32, counts map[txscript.ScriptClass]uint32) error,
+) error {
+	return ViewAnalyzer(
+		db, ScriptTypesAnalyzerName, func(bucket database.Bucket) (e error) {
+			cursor := bucket.Cursor()
+			for ok := cursor.Seek(heightKey(start)); ok; ok = cursor.Next() {
+				height := int32(binary.BigEndian.Uint32(cursor.Key()))
+				if height > end {
+					break
+				}
+				var counts map[txscript.ScriptClass]uint32
+				if counts, e = deserializeScriptTypeCounts(cursor.Value()); E.Chk(e) {
+					return
+				}
+				if e = fn(height, counts); e != nil {
+					return
+				}
+			}
+			return
+		},
+	)
+}
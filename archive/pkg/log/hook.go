@@ -0,0 +1,99 @@
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	uberatomic "go.uber.org/atomic"
+)
+
+// logHook is a function log entries of its level or more severe are passed to.
+type logHook struct {
+	level int32
+	fn    func(ent *Entry)
+}
+
+var (
+	// hooks is replaced rather than changed when hooks are added or removed, so it can be ranged over without holding
+	// the lock.
+	hooks   []*logHook
+	hooksMx sync.Mutex
+	// hookLevel is the least severe level any hook wants, so entries no hook wants are not built.
+	hookLevel = uberatomic.NewInt32(_Off)
+)
+
+// AddLogHook adds a function that is passed each log entry of the level or more severe, whatever the level of the
+// printed log, such as to ship the entries to a remote log server. It returns a function that removes the hook. Hooks
+// are called by the goroutine that logs the entry, so they must return quickly and must not log.
+func AddLogHook(level string, fn func(ent *Entry)) (remove func()) {
+	h := &logHook{level: levelID(level), fn: fn}
+	hooksMx.Lock()
+	hooks = append(append([]*logHook{}, hooks...), h)
+	storeHookLevel()
+	hooksMx.Unlock()
+	return func() {
+		hooksMx.Lock()
+		defer hooksMx.Unlock()
+		var kept []*logHook
+		for _, o := range hooks {
+			if o != h {
+				kept = append(kept, o)
+			}
+		}
+		hooks = kept
+		storeHookLevel()
+	}
+}
+
+// storeHookLevel sets the hook level to the least severe level of the hooks. hooksMx must be held.
+func storeHookLevel() {
+	lvl := int32(_Off)
+	for _, h := range hooks {
+		if h.level > lvl {
+			lvl = h.level
+		}
+	}
+	hookLevel.Store(lvl)
+}
+
+// levelID returns the id of a level given by name, which like SetLogLevel can be truncated down to one character.
+func levelID(l string) int32 {
+	if l == "" {
+		l = Info
+	}
+	lvl := logLevels.Info
+	for i := range LevelSpecs {
+		if LevelSpecs[i].Name[:1] == l[:1] {
+			lvl = LevelSpecs[i].ID
+		}
+	}
+	return lvl
+}
+
+// hooked returns whether any hook wants entries of the level.
+func hooked(level int32) bool {
+	return level != _Off && level <= hookLevel.Load()
+}
+
+// runHooks passes an entry to the hooks that want entries of its level. It must be called directly by the printing
+// function so the code location is that of its caller.
+func runHooks(level int32, subsystem, text string) {
+	_, file, line, _ := runtime.Caller(2)
+	ent := &Entry{
+		Time:         time.Now(),
+		Level:        Levels[level],
+		Package:      subsystem,
+		CodeLocation: fmt.Sprint(file, ":", line),
+		Text:         text,
+	}
+	hooksMx.Lock()
+	hs := hooks
+	hooksMx.Unlock()
+	for _, h := range hs {
+		if level <= h.level {
+			h.fn(ent)
+		}
+	}
+}
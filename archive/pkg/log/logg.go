@@ -340,7 +340,15 @@ func getTimeText(level int32) string {
 
 func _ln(level int32, subsystem string) func(a ...interface{}) {
 	return func(a ...interface{}) {
-		if level <= currentLevel.Load() && !_isSubsystemFiltered(subsystem) {
+		printed, hook := level <= currentLevel.Load(), hooked(level)
+		if (printed || hook) && !_isSubsystemFiltered(subsystem) {
+			text := joinStrings(" ", a...)
+			if hook {
+				runHooks(level, subsystem, text)
+			}
+			if !printed {
+				return
+			}
 			printer := fmt.Sprintf
 			if _isHighlighted(subsystem) {
 				printer = color.Bold.Sprintf
@@ -357,7 +365,7 @@ func _ln(level int32, subsystem string) func(a ...interface{}) {
 						color.Bit24(20, 20, 20, true).
 							Sprint(" "+LevelSpecs[level].Name+" "),
 					),
-					AppColorizer(joinStrings(" ", a...)),
+					AppColorizer(text),
 				),
 			)
 		}
@@ -366,7 +374,15 @@ func _ln(level int32, subsystem string) func(a ...interface{}) {
 
 func _f(level int32, subsystem string) func(format string, a ...interface{}) {
 	return func(format string, a ...interface{}) {
-		if level <= currentLevel.Load() && !_isSubsystemFiltered(subsystem) {
+		printed, hook := level <= currentLevel.Load(), hooked(level)
+		if (printed || hook) && !_isSubsystemFiltered(subsystem) {
+			text := fmt.Sprintf(format, a...)
+			if hook {
+				runHooks(level, subsystem, text)
+			}
+			if !printed {
+				return
+			}
 			printer := fmt.Sprintf
 			if _isHighlighted(subsystem) {
 				printer = color.Bold.Sprintf
@@ -383,7 +399,7 @@ func _f(level int32, subsystem string) func(format string, a ...interface{}) {
 						color.Bit24(20, 20, 20, true).
 							Sprint(" "+LevelSpecs[level].Name+" "),
 					),
-					AppColorizer(fmt.Sprintf(format, a...)),
+					AppColorizer(text),
 				),
 			)
 		}
@@ -392,7 +408,15 @@ func _f(level int32, subsystem string) func(format string, a ...interface{}) {
 
 func _s(level int32, subsystem string) func(a ...interface{}) {
 	return func(a ...interface{}) {
-		if level <= currentLevel.Load() && !_isSubsystemFiltered(subsystem) {
+		printed, hook := level <= currentLevel.Load(), hooked(level)
+		if (printed || hook) && !_isSubsystemFiltered(subsystem) {
+			text := spew.Sdump(a)
+			if hook {
+				runHooks(level, subsystem, text)
+			}
+			if !printed {
+				return
+			}
 			printer := fmt.Sprintf
 			if _isHighlighted(subsystem) {
 				printer = color.Bold.Sprintf
@@ -413,7 +437,7 @@ func _s(level int32, subsystem string) func(a ...interface{}) {
 						" spew:",
 					),
 					fmt.Sprint(
-						color.Bit24(20, 20, 20, true).Sprint("\n\n"+spew.Sdump(a)),
+						color.Bit24(20, 20, 20, true).Sprint("\n\n"+text),
 						"\n",
 					),
 				),
@@ -424,7 +448,15 @@ func _s(level int32, subsystem string) func(a ...interface{}) {
 
 func _c(level int32, subsystem string) func(closure func() string) {
 	return func(closure func() string) {
-		if level <= currentLevel.Load() && !_isSubsystemFiltered(subsystem) {
+		printed, hook := level <= currentLevel.Load(), hooked(level)
+		if (printed || hook) && !_isSubsystemFiltered(subsystem) {
+			text := closure()
+			if hook {
+				runHooks(level, subsystem, text)
+			}
+			if !printed {
+				return
+			}
 			printer := fmt.Sprintf
 			if _isHighlighted(subsystem) {
 				printer = color.Bold.Sprintf
@@ -441,7 +473,7 @@ func _c(level int32, subsystem string) func(closure func() string) {
 						color.Bit24(20, 20, 20, true).
 							Sprint(" "+LevelSpecs[level].Name+" "),
 					),
-					AppColorizer(closure()),
+					AppColorizer(text),
 				),
 			)
 		}
@@ -450,6 +482,9 @@ func _c(level int32, subsystem string) func(closure func() string) {
 
 func _chk(level int32, subsystem string) func(e error) bool {
 	return func(e error) bool {
+		if e != nil && hooked(level) && !_isSubsystemFiltered(subsystem) {
+			runHooks(level, subsystem, e.Error())
+		}
 		if level <= currentLevel.Load() && !_isSubsystemFiltered(subsystem) {
 			if e != nil {
 				printer := fmt.Sprintf
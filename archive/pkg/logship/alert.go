@@ -0,0 +1,86 @@
+package logship
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// RuleErrorRate is the rule raising an alert when errors come faster than the configured rate.
+	RuleErrorRate = "errorrate"
+	// RuleMatch is the rule raising an alert when an entry contains one of the alert patterns.
+	RuleMatch = "match"
+)
+
+// Alert is raised by an alert rule and posted to the alert webhook.
+type Alert struct {
+	Rule string    `json:"rule"`
+	Host string    `json:"host"`
+	App  string    `json:"app"`
+	Time time.Time `json:"time"`
+	// Count is the number of errors in the window for the error rate rule.
+	Count int `json:"count,omitempty"`
+	// Window is the window in seconds the errors were counted in.
+	Window int64 `json:"window,omitempty"`
+	// Match is the pattern the entry contained for the match rule.
+	Match string `json:"match,omitempty"`
+	// Entry is the entry that raised the alert.
+	Entry *Entry `json:"entry"`
+}
+
+// alertRules checks entries against the error rate and match rules. An alert of a rule is raised at most once in the
+// alert window, so a node in trouble does not flood the webhook.
+type alertRules struct {
+	errors    int
+	window    time.Duration
+	match     []string
+	times     []time.Time
+	lastErr   time.Time
+	lastMatch map[string]time.Time
+}
+
+func newAlertRules(cfg Config) *alertRules {
+	r := &alertRules{errors: cfg.AlertErrors, window: cfg.AlertWindow, lastMatch: make(map[string]time.Time)}
+	for _, m := range cfg.AlertMatch {
+		if m != "" {
+			r.match = append(r.match, m)
+		}
+	}
+	return r
+}
+
+// check returns the alerts raised by an entry.
+func (r *alertRules) check(ent *Entry) (alerts []*Alert) {
+	now := ent.Time
+	if r.errors > 0 && (ent.Level == "error" || ent.Level == "fatal") {
+		r.times = append(r.times, now)
+		i := 0
+		for i < len(r.times) && now.Sub(r.times[i]) >= r.window {
+			i++
+		}
+		r.times = r.times[i:]
+		if len(r.times) >= r.errors && (r.lastErr.IsZero() || now.Sub(r.lastErr) >= r.window) {
+			r.lastErr = now
+			alerts = append(
+				alerts, &Alert{
+					Rule:   RuleErrorRate,
+					Time:   now,
+					Count:  len(r.times),
+					Window: int64(r.window / time.Second),
+					Entry:  ent,
+				},
+			)
+		}
+	}
+	for _, m := range r.match {
+		if !strings.Contains(ent.Text, m) {
+			continue
+		}
+		if last, ok := r.lastMatch[m]; ok && now.Sub(last) < r.window {
+			continue
+		}
+		r.lastMatch[m] = now
+		alerts = append(alerts, &Alert{Rule: RuleMatch, Time: now, Match: m, Entry: ent})
+	}
+	return
+}
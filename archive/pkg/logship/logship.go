@@ -0,0 +1,231 @@
+// Package logship ships log entries to a remote syslog server or an HTTP bulk endpoint, and posts alerts to a webhook
+// when errors come too fast or an entry matches one of the alert patterns, so unattended nodes can tell their operators
+// about trouble without a log agent running alongside them.
+package logship
+
+import (
+	"bytes"
+	js "encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/p9c/log"
+	"github.com/p9c/qu"
+)
+
+const (
+	// queueSize is how many entries can wait to be shipped before new ones are dropped.
+	queueSize = 1024
+	// batchSize is how many entries are sent to the HTTP endpoint at most in one request, and the number of pending
+	// entries that has a batch sent before the batch interval is up.
+	batchSize = 100
+	// batchInterval is how often the pending entries are sent to the HTTP endpoint.
+	batchInterval = time.Second * 5
+	// maxPending is how many entries are kept for the HTTP endpoint while it can't be reached, the oldest are dropped
+	// beyond this.
+	maxPending = 1000
+	// postTimeout is how long a post to the HTTP endpoint or the alert webhook may take.
+	postTimeout = time.Second * 10
+)
+
+// Config is where log entries are shipped and when alerts are raised. Shipping and alerting are each enabled by giving
+// where the entries or alerts go.
+type Config struct {
+	// Level is the least severe level of the entries shipped and checked by the alert rules.
+	Level string
+	// Syslog is the address of a syslog server as udp://host:port or tcp://host:port.
+	Syslog string
+	// HTTP is the URL of an endpoint the entries are posted to in batches as a JSON array.
+	HTTP string
+	// AlertWebhook is the URL alerts are posted to as JSON.
+	AlertWebhook string
+	// AlertErrors is the number of errors within AlertWindow that raises an alert, 0 for no alert on the error rate.
+	AlertErrors int
+	// AlertWindow is the window the errors are counted in, and the least time between two alerts of the same rule.
+	AlertWindow time.Duration
+	// AlertMatch are texts, such as error codes, that raise an alert when an entry contains one.
+	AlertMatch []string
+	// App is the name of the application sent with the entries and alerts.
+	App string
+}
+
+// Entry is a log entry as it is shipped to the HTTP endpoint and sent with alerts.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Host     string    `json:"host"`
+	App      string    `json:"app"`
+	Level    string    `json:"level"`
+	Package  string    `json:"package"`
+	Location string    `json:"location"`
+	Text     string    `json:"text"`
+}
+
+// Shipper ships the log entries passed to it and checks them against the alert rules.
+type Shipper struct {
+	cfg     Config
+	host    string
+	client  *http.Client
+	syslog  *syslogWriter
+	alerts  *alertRules
+	entries chan *Entry
+	pending []*Entry
+	dropped int64
+	quit    qu.C
+}
+
+// Start adds a log hook shipping the entries of the configured level or more severe, if shipping or alerting is
+// enabled, until quit is closed.
+func Start(cfg Config, quit qu.C) (s *Shipper, e error) {
+	if cfg.Syslog == "" && cfg.HTTP == "" && cfg.AlertWebhook == "" {
+		return
+	}
+	if s, e = New(cfg, quit); E.Chk(e) {
+		return
+	}
+	remove := log.AddLogHook(cfg.Level, s.hook)
+	go func() {
+		s.run()
+		remove()
+	}()
+	I.Ln("shipping log entries of level", cfg.Level, "and above")
+	return
+}
+
+// New returns a shipper for the configuration, which ships the entries passed to Ship once it is running.
+func New(cfg Config, quit qu.C) (s *Shipper, e error) {
+	s = &Shipper{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: postTimeout},
+		alerts:  newAlertRules(cfg),
+		entries: make(chan *Entry, queueSize),
+		quit:    quit,
+	}
+	if s.host, e = os.Hostname(); E.Chk(e) {
+		s.host = "-"
+	}
+	if cfg.Syslog != "" {
+		if s.syslog, e = newSyslogWriter(cfg.Syslog, s.host, cfg.App); E.Chk(e) {
+			return nil, e
+		}
+	}
+	return s, nil
+}
+
+// hook is the log hook of the shipper. The entries logged by the shipper itself are not shipped, so a remote server
+// that can't be reached is not flooded with the errors from reaching it.
+func (s *Shipper) hook(ent *log.Entry) {
+	if ent.Package == subsystem {
+		return
+	}
+	s.Ship(
+		&Entry{
+			Time:     ent.Time,
+			Host:     s.host,
+			App:      s.cfg.App,
+			Level:    ent.Level,
+			Package:  ent.Package,
+			Location: ent.CodeLocation,
+			Text:     ent.Text,
+		},
+	)
+}
+
+// Ship queues an entry to be shipped. When the queue is full the entry is dropped.
+func (s *Shipper) Ship(ent *Entry) {
+	select {
+	case s.entries <- ent:
+	default:
+		// Nothing can be logged here, as logging would call the hook again.
+		atomic.AddInt64(&s.dropped, 1)
+	}
+}
+
+func (s *Shipper) run() {
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ent := <-s.entries:
+			s.ship(ent)
+		case <-ticker.C:
+			if dropped := atomic.SwapInt64(&s.dropped, 0); dropped > 0 {
+				W.Ln("the log shipping queue was full, dropped", dropped, "entries")
+			}
+			s.flush()
+		case <-s.quit.Wait():
+			s.flush()
+			if s.syslog != nil {
+				s.syslog.close()
+			}
+			return
+		}
+	}
+}
+
+// ship sends an entry to the syslog server, adds it to the entries pending for the HTTP endpoint and checks it
+// against the alert rules.
+func (s *Shipper) ship(ent *Entry) {
+	if s.syslog != nil {
+		if e := s.syslog.write(ent); e != nil {
+			D.Ln("failed to ship log entry to syslog:", e)
+		}
+	}
+	if s.cfg.HTTP != "" {
+		s.pending = append(s.pending, ent)
+		if len(s.pending) > maxPending {
+			s.pending = s.pending[len(s.pending)-maxPending:]
+		}
+		if len(s.pending) >= batchSize {
+			s.flush()
+		}
+	}
+	if s.cfg.AlertWebhook != "" {
+		for _, a := range s.alerts.check(ent) {
+			a.Host, a.App = s.host, s.cfg.App
+			go func(a *Alert) {
+				if e := s.post(s.cfg.AlertWebhook, a); e != nil {
+					W.Ln("failed to post log alert to webhook:", e)
+				}
+			}(a)
+		}
+	}
+}
+
+// flush sends the entries pending for the HTTP endpoint in batches, keeping them for the next flush if it can't be
+// reached.
+func (s *Shipper) flush() {
+	for len(s.pending) > 0 {
+		n := len(s.pending)
+		if n > batchSize {
+			n = batchSize
+		}
+		if e := s.post(s.cfg.HTTP, s.pending[:n]); e != nil {
+			D.Ln("failed to ship log entries to", s.cfg.HTTP, e)
+			return
+		}
+		s.pending = s.pending[n:]
+	}
+	s.pending = nil
+}
+
+// post posts v as JSON to url.
+func (s *Shipper) post(url string, v interface{}) (e error) {
+	var body []byte
+	if body, e = js.Marshal(v); e != nil {
+		return
+	}
+	var resp *http.Response
+	if resp, e = s.client.Post(url, "application/json", bytes.NewReader(body)); e != nil {
+		return
+	}
+	if e = resp.Body.Close(); e != nil {
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned status %s", resp.Status)
+	}
+	return
+}
@@ -0,0 +1,138 @@
+package logship
+
+import (
+	js "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSyslogFormat ensures entries are written in the format of RFC 5424 with the severity of their level.
+func TestSyslogFormat(t *testing.T) {
+	w, e := newSyslogWriter("udp://127.0.0.1:514", "node1", "pod")
+	if e != nil {
+		t.Fatal(e)
+	}
+	ent := &Entry{Time: time.Unix(1600000000, 0), Level: "error", Package: "chainrpc", Text: "something broke"}
+	want := "<27>1 2020-09-13T12:26:40Z node1 pod - chainrpc - something broke"
+	if got := w.format(ent); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, addr := range []string{"127.0.0.1:514", "http://127.0.0.1:514", "udp://"} {
+		if _, e = newSyslogWriter(addr, "", ""); e == nil {
+			t.Errorf("syslog address %q was accepted", addr)
+		}
+	}
+}
+
+// TestAlertRules ensures the error rate rule raises an alert when the errors in the window reach the threshold, the
+// match rule when an entry contains a pattern, and neither again within the window.
+func TestAlertRules(t *testing.T) {
+	r := newAlertRules(Config{AlertErrors: 3, AlertWindow: time.Minute, AlertMatch: []string{"-25"}})
+	start := time.Unix(1600000000, 0)
+	entry := func(offset time.Duration, level, text string) *Entry {
+		return &Entry{Time: start.Add(offset), Level: level, Text: text}
+	}
+	if a := r.check(entry(0, "error", "a")); len(a) != 0 {
+		t.Fatalf("alert raised by the first error: %+v", a[0])
+	}
+	if a := r.check(entry(time.Second*70, "error", "b")); len(a) != 0 {
+		t.Fatal("alert raised by errors outside the window")
+	}
+	if a := r.check(entry(time.Second*71, "warn", "c")); len(a) != 0 {
+		t.Fatal("alert raised by a warning")
+	}
+	r.check(entry(time.Second*72, "error", "d"))
+	a := r.check(entry(time.Second*73, "fatal", "e"))
+	if len(a) != 1 || a[0].Rule != RuleErrorRate || a[0].Count != 3 || a[0].Window != 60 {
+		t.Fatalf("unexpected alerts %+v", a)
+	}
+	if a = r.check(entry(time.Second*74, "error", "f")); len(a) != 0 {
+		t.Fatal("error rate alert raised again within the window")
+	}
+	a = r.check(entry(time.Second*75, "info", "rpc error -25"))
+	if len(a) != 1 || a[0].Rule != RuleMatch || a[0].Match != "-25" {
+		t.Fatalf("unexpected alerts %+v", a)
+	}
+	if a = r.check(entry(time.Second*76, "info", "rpc error -25")); len(a) != 0 {
+		t.Fatal("match alert raised again within the window")
+	}
+	if a = r.check(entry(time.Second*136, "info", "rpc error -25")); len(a) != 1 {
+		t.Fatal("match alert not raised after the window")
+	}
+}
+
+// TestShipHTTP ensures entries are posted to the HTTP endpoint in batches, kept while it fails and alerts are posted to
+// the webhook.
+func TestShipHTTP(t *testing.T) {
+	var mx sync.Mutex
+	var batches [][]*Entry
+	var alerts []*Alert
+	failing := true
+	srv := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mx.Lock()
+				defer mx.Unlock()
+				if r.URL.Path == "/alert" {
+					var a Alert
+					if e := js.NewDecoder(r.Body).Decode(&a); e != nil {
+						t.Error(e)
+					}
+					alerts = append(alerts, &a)
+					return
+				}
+				if failing {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				var batch []*Entry
+				if e := js.NewDecoder(r.Body).Decode(&batch); e != nil {
+					t.Error(e)
+				}
+				batches = append(batches, batch)
+			},
+		),
+	)
+	defer srv.Close()
+	s, e := New(
+		Config{HTTP: srv.URL + "/logs", AlertWebhook: srv.URL + "/alert", AlertMatch: []string{"boom"}}, nil,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for i := 0; i < batchSize+10; i++ {
+		s.ship(&Entry{Time: time.Now(), Level: "warn", Text: "entry"})
+	}
+	if len(s.pending) != batchSize+10 {
+		t.Fatalf("%d entries pending while the endpoint fails, want %d", len(s.pending), batchSize+10)
+	}
+	mx.Lock()
+	failing = false
+	mx.Unlock()
+	s.ship(&Entry{Time: time.Now(), Level: "error", Text: "boom"})
+	s.flush()
+	if len(s.pending) != 0 {
+		t.Fatalf("%d entries still pending", len(s.pending))
+	}
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		mx.Lock()
+		n := len(alerts)
+		mx.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	if len(batches) != 2 || len(batches[0]) != batchSize || len(batches[1]) != 11 {
+		t.Errorf("unexpected batches of %d entries", len(batches))
+	}
+	if len(alerts) != 1 || alerts[0].Rule != RuleMatch || alerts[0].Entry.Text != "boom" {
+		t.Errorf("unexpected alerts %+v", alerts)
+	}
+}
@@ -0,0 +1,98 @@
+package logship
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	// facility is the syslog facility entries are sent as, system daemons.
+	facility = 3
+	// writeTimeout is how long writing an entry to a syslog server over tcp may take.
+	writeTimeout = time.Second * 5
+)
+
+// severities are the syslog severities of the log levels.
+var severities = map[string]int{
+	"fatal": 2,
+	"error": 3,
+	"check": 4,
+	"warn":  4,
+	"info":  6,
+	"debug": 7,
+	"trace": 7,
+}
+
+// syslogWriter writes entries to a syslog server in the format of RFC 5424, connecting again after a failed write.
+type syslogWriter struct {
+	network, addr string
+	host, app     string
+	conn          net.Conn
+}
+
+// newSyslogWriter returns a writer to the syslog server at addr, given as udp://host:port or tcp://host:port.
+func newSyslogWriter(addr, host, app string) (w *syslogWriter, e error) {
+	var u *url.URL
+	if u, e = url.Parse(addr); e != nil {
+		return
+	}
+	if (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+		return nil, fmt.Errorf("syslog address %s is not udp://host:port or tcp://host:port", addr)
+	}
+	if host == "" {
+		host = "-"
+	}
+	if app == "" {
+		app = "-"
+	}
+	return &syslogWriter{network: u.Scheme, addr: u.Host, host: host, app: app}, nil
+}
+
+// format returns the syslog message of an entry.
+func (w *syslogWriter) format(ent *Entry) string {
+	severity, ok := severities[ent.Level]
+	if !ok {
+		severity = 6
+	}
+	msgID := ent.Package
+	if msgID == "" {
+		msgID = "-"
+	}
+	return fmt.Sprintf(
+		"<%d>1 %s %s %s - %s - %s", facility*8+severity, ent.Time.UTC().Format(time.RFC3339Nano), w.host, w.app,
+		msgID, ent.Text,
+	)
+}
+
+// write sends an entry to the syslog server, connecting first if there is no connection. Messages over tcp are framed
+// by their length.
+func (w *syslogWriter) write(ent *Entry) (e error) {
+	if w.conn == nil {
+		if w.conn, e = net.DialTimeout(w.network, w.addr, writeTimeout); e != nil {
+			w.conn = nil
+			return
+		}
+	}
+	msg := w.format(ent)
+	if w.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+		if e = w.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); e != nil {
+			w.close()
+			return
+		}
+	}
+	if _, e = w.conn.Write([]byte(msg)); e != nil {
+		w.close()
+	}
+	return
+}
+
+// close closes the connection to the syslog server, if there is one.
+func (w *syslogWriter) close() {
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+}
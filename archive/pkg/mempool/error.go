@@ -1,6 +1,9 @@
 package mempool
 
 import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/blockchain"
 	"github.com/p9c/pod/pkg/wire"
 )
@@ -34,6 +37,26 @@ func (e TxRuleError) Error() string {
 	return e.Description
 }
 
+// AbsurdFeeError identifies a transaction that was rejected because it pays more than the MaxTxFee of the policy.
+type AbsurdFeeError struct {
+	Fee    amt.Amount // The fee paid by the transaction
+	MaxFee amt.Amount // The highest fee allowed by the policy
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e AbsurdFeeError) Error() string {
+	return fmt.Sprintf("absurdly high fee of %v is over the maximum of %v", e.Fee, e.MaxFee)
+}
+
+// IsAbsurdFee returns true if the error is the rejection of a transaction for paying more than the maximum fee.
+func IsAbsurdFee(e error) bool {
+	if rerr, ok := e.(RuleError); ok {
+		e = rerr.Err
+	}
+	_, ok := e.(AbsurdFeeError)
+	return ok
+}
+
 // txRuleError creates an underlying TxRuleError with the given a set of arguments and returns a RuleError that
 // encapsulates it.
 func txRuleError(c wire.RejectCode, desc string) RuleError {
@@ -83,6 +106,8 @@ func extractRejectCode(e error) (wire.RejectCode, bool) {
 		return code, true
 	case TxRuleError:
 		return er.RejectCode, true
+	case AbsurdFeeError:
+		return wire.RejectNonstandard, true
 	case nil:
 		return wire.RejectInvalid, false
 	}
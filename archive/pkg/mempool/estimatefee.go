@@ -42,6 +42,16 @@ type FeeEstimator struct {
 	cached []SatoshiPerByte
 	// Transactions that have been removed from the bins. This allows us to revert in case of an orphaned block.
 	dropped []*registeredBlock
+	// How the transactions predicted to confirm within each target fared, and whether the most recent predictions were
+	// met, in a ring starting at nextOutcome once it is full.
+	calibration [estimateFeeDepth]calibrationCount
+	outcomes    []bool
+	nextOutcome int
+	// The fraction the estimates are raised by, adjusted after each block when autoBias is set.
+	bias     float64
+	autoBias bool
+	// The most recent blocks, which the estimates for a length of time are worked out from.
+	timed []timedBlock
 }
 
 // FeeEstimatorState represents a saved FeeEstimator that can be restored with data from an earlier session of the
@@ -68,6 +78,9 @@ type observedTransaction struct {
 	observed int32
 	// The height of the block in which it was mined. If the transaction has not yet been mined, it is zero.
 	mined int32
+	// The confirmation target whose estimate the fee rate paid when it was observed, or zero once its outcome has been
+	// recorded or if it paid less than every estimate.
+	target int32
 }
 
 // observedTxSet is a set of txs that can that is sorted by hash. It exists for serialization purposes so that a
@@ -131,7 +144,7 @@ func (ef *FeeEstimator) EstimateFee(numBlocks uint32) (DUOPerKilobyte, error) {
 	if ef.cached == nil {
 		ef.cached = ef.estimates()
 	}
-	return ef.cached[int(numBlocks)-1].ToBtcPerKb(), nil
+	return ef.biased(ef.cached[int(numBlocks)-1]).ToBtcPerKb(), nil
 }
 
 func // LastKnownHeight returns the height of the last block which was
@@ -155,11 +168,13 @@ func (ef *FeeEstimator) ObserveTransaction(
 	hash := *t.Tx.Hash()
 	if _, ok := ef.observed[hash]; !ok {
 		size := uint32(GetTxVirtualSize(t.Tx))
+		feeRate := NewSatoshiPerByte(amt.Amount(t.Fee), size)
 		ef.observed[hash] = &observedTransaction{
 			hash:     hash,
-			feeRate:  NewSatoshiPerByte(amt.Amount(t.Fee), size),
+			feeRate:  feeRate,
 			observed: t.Height,
 			mined:    mining.UnminedHeight,
+			target:   ef.predictTarget(feeRate),
 		}
 	}
 }
@@ -182,6 +197,7 @@ func (ef *FeeEstimator) RegisterBlock(
 	// Update the last known height.
 	ef.lastKnownHeight = height
 	ef.numBlocksRegistered++
+	ef.timeBlock(block)
 	// Randomly order txs in block.
 	transactions := make(map[*util.Tx]struct{})
 	for _, t := range block.Transactions() {
@@ -209,6 +225,11 @@ func (ef *FeeEstimator) RegisterBlock(
 			E.Ln("Estimate fee: transaction ", hash, " has already been mined")
 			return errors.New("transaction has already been mined")
 		}
+		// Chk the prediction made when it was observed against the blocks it took to confirm.
+		if o.target > 0 {
+			ef.recordOutcome(o.target, blocksToConfirm+1)
+			o.target = 0
+		}
 		// This shouldn't happen but check just in case to avoid an out-of -bounds array index later.
 		if blocksToConfirm >= estimateFeeDepth {
 			continue
@@ -236,9 +257,13 @@ func (ef *FeeEstimator) RegisterBlock(
 	// Go through the mempool for txs that have been in too long.
 	for hash, o := range ef.observed {
 		if o.mined == mining.UnminedHeight && height-o.observed >= estimateFeeDepth {
+			if o.target > 0 {
+				ef.recordOutcome(o.target, 0)
+			}
 			delete(ef.observed, hash)
 		}
 	}
+	ef.adjustBias()
 	// Add dropped list to history.
 	if ef.maxRollback == 0 {
 		return nil
@@ -449,6 +474,7 @@ func (ef *FeeEstimator) rollback() {
 		}
 	}
 	ef.dropped = ef.dropped[0:last]
+	ef.untimeBlock()
 	// The number of blocks the fee estimator has seen is decremented.
 	ef.numBlocksRegistered--
 	ef.lastKnownHeight--
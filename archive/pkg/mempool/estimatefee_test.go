@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"github.com/p9c/pod/pkg/amt"
 	block2 "github.com/p9c/pod/pkg/block"
+	"math"
 	"math/rand"
 	"testing"
+	"time"
 	
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/mining"
@@ -401,3 +403,104 @@ func newTestFeeEstimator(binSize, maxReplacements, maxRollback uint32) *FeeEstim
 		dropped:             make([]*registeredBlock, 0, maxRollback),
 	}
 }
+
+// TestEstimatorCalibration tests that the predictions made for observed transactions are checked against the blocks
+// they took to confirm, and that the automatic bias raises the estimates when they are missed.
+func TestEstimatorCalibration(t *testing.T) {
+	ef := newTestFeeEstimator(10, 10, 1)
+	eft := estimateFeeTester{ef: ef, t: t}
+	// Seed the estimates with a transaction confirmed in the next block.
+	seed := eft.testTx(1000000)
+	ef.ObserveTransaction(seed)
+	eft.newBlock([]*wire.MsgTx{seed.Tx.MsgTx()})
+	// One transaction pays the estimate for the next block and takes two, one pays less than every estimate.
+	fast := eft.testTx(2000000)
+	ef.ObserveTransaction(fast)
+	slow := eft.testTx(10)
+	ef.ObserveTransaction(slow)
+	eft.newBlock([]*wire.MsgTx{})
+	eft.newBlock([]*wire.MsgTx{fast.Tx.MsgTx(), slow.Tx.MsgTx()})
+	stats := ef.Stats()
+	if len(stats.Targets) != 1 {
+		t.Fatalf("expected predictions for 1 target, got %d", len(stats.Targets))
+	}
+	target := stats.Targets[0]
+	if target.Target != 1 || target.Predicted != 1 || target.Confirmed != 0 || target.MeanBlocks != 2 {
+		t.Errorf("unexpected calibration for the next block %+v", target)
+	}
+	if stats.Bias != 1 {
+		t.Errorf("expected no bias without automatic bias, got %f", stats.Bias)
+	}
+	// Missing enough predictions raises the estimates once automatic bias is enabled.
+	ef.SetAutoBias(true)
+	before, _ := ef.EstimateFee(1)
+	for i := 0; i < calibrationMinOutcomes; i++ {
+		ef.recordOutcome(1, 3)
+	}
+	ef.adjustBias()
+	after, _ := ef.EstimateFee(1)
+	if expected := before * (1 + calibrationBiasStep); math.Abs(float64(after-expected)) > 1e-12 {
+		t.Errorf("expected biased estimate %f, got %f", expected, after)
+	}
+	ef.SetAutoBias(false)
+	if restored, _ := ef.EstimateFee(1); restored != before {
+		t.Errorf("expected estimate %f once automatic bias is disabled, got %f", before, restored)
+	}
+}
+
+// TestEstimateFeeByTime tests that the estimates for a length of time are for the most blocks that arrived within it
+// in nearly all the recent stretches of the chain, and are only made once enough blocks have been timed.
+func TestEstimateFeeByTime(t *testing.T) {
+	// Blocks arrive a minute apart, except for every tenth which takes ten.
+	var intervals []time.Duration
+	for i := 0; i < 100; i++ {
+		d := time.Minute
+		if i%10 == 9 {
+			d = 10 * time.Minute
+		}
+		intervals = append(intervals, d)
+	}
+	for _, test := range []struct {
+		within     time.Duration
+		blocks     uint32
+		likelihood float64
+	}{
+		// Not even one block arrives within half a minute.
+		{within: time.Minute / 2, blocks: 1, likelihood: 0},
+		// One block arrives within a minute in nine of ten intervals.
+		{within: time.Minute, blocks: 1, likelihood: 0.9},
+		// Two blocks always arrive within eleven minutes, but three take twelve whenever one of them took ten.
+		{within: 11 * time.Minute, blocks: 2, likelihood: 1},
+		// Ten blocks always arrive within 19 minutes.
+		{within: 19 * time.Minute, blocks: 10, likelihood: 1},
+	} {
+		blocks, likelihood := blocksWithin(intervals, test.within)
+		if blocks != test.blocks || math.Abs(likelihood-test.likelihood) > 1e-9 {
+			t.Errorf(
+				"within %v: expected %d blocks with likelihood %f, got %d with %f",
+				test.within, test.blocks, test.likelihood, blocks, likelihood,
+			)
+		}
+	}
+	ef := newTestFeeEstimator(5, 3, 1)
+	eft := estimateFeeTester{ef: ef, t: t}
+	for i := 0; i < minTimedIntervals; i++ {
+		eft.newBlock([]*wire.MsgTx{})
+	}
+	if _, e := ef.EstimateFeeByTime(time.Minute); e == nil {
+		t.Error("expected no estimate before enough blocks were timed")
+	}
+	eft.newBlock([]*wire.MsgTx{})
+	// The test blocks all have the same timestamp, so every one of them arrived within any length of time.
+	estimate, e := ef.EstimateFeeByTime(time.Minute)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if estimate.Blocks != estimateFeeDepth || estimate.Likelihood != 1 {
+		t.Errorf("expected an estimate for %d blocks, got %+v", estimateFeeDepth, estimate)
+	}
+	eft.rollback()
+	if _, e := ef.EstimateFeeByTime(time.Minute); e == nil {
+		t.Error("expected no estimate once a timed block was rolled back")
+	}
+}
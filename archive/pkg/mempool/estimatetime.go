@@ -0,0 +1,172 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/block"
+	"github.com/p9c/pod/pkg/fork"
+)
+
+// Blocks of the different algorithms arrive unevenly, so a number of confirmations says little about how long a
+// transaction will take to confirm. The estimates for a length of time are the estimates for the most blocks that
+// arrived within that time in nearly all the recent stretches of the chain, which captures the spread of the times
+// between blocks for the mix of algorithms mining them.
+
+const (
+	// timedBlockWindow is the number of most recent blocks whose arrival times the estimates for a length of time are
+	// worked out from.
+	timedBlockWindow = 500
+	// minTimedIntervals is the number of times between blocks that must have been seen before estimates for a length
+	// of time are made.
+	minTimedIntervals = estimateFeeDepth * 2
+	// TimeTargetConfidence is the share of the recent stretches of the chain in which the blocks an estimate for a
+	// length of time is made for must have arrived within it.
+	TimeTargetConfidence = 0.9
+)
+
+// timedBlock is the algorithm and timestamp of a registered block.
+type timedBlock struct {
+	algo string
+	time time.Time
+}
+
+// TimeEstimate is an estimate of the fee for a transaction to confirm within a length of time.
+type TimeEstimate struct {
+	FeeRate DUOPerKilobyte
+	// Blocks is the confirmation target the fee rate is the estimate for, which is the most blocks that arrived within
+	// the time in at least TimeTargetConfidence of the recent stretches of the chain, or 1 if not even one block did.
+	Blocks uint32
+	// Likelihood is the share of the recent stretches of the chain in which Blocks blocks arrived within the time.
+	Likelihood float64
+}
+
+// AlgoIntervalStats shows how often the recent blocks of an algorithm have arrived.
+type AlgoIntervalStats struct {
+	Algo string
+	// Blocks is the number of the recent blocks mined with the algorithm.
+	Blocks int
+	// Share is Blocks as a share of the recent blocks.
+	Share float64
+	// MeanInterval is the average time between the recent blocks mined with the algorithm, zero with fewer than two.
+	MeanInterval time.Duration
+}
+
+// timeBlock records the algorithm and timestamp of a registered block. The caller must hold the lock.
+func (ef *FeeEstimator) timeBlock(b *block.Block) {
+	header := &b.WireBlock().Header
+	ef.timed = append(ef.timed, timedBlock{algo: fork.GetAlgoName(header.Version, b.Height()), time: header.Timestamp})
+	if len(ef.timed) > timedBlockWindow+1 {
+		ef.timed = append(ef.timed[:0], ef.timed[1:]...)
+	}
+}
+
+// untimeBlock forgets the last block timed when it is rolled back. The caller must hold the lock.
+func (ef *FeeEstimator) untimeBlock() {
+	if n := len(ef.timed); n > 0 {
+		ef.timed = ef.timed[:n-1]
+	}
+}
+
+// intervals returns the times between the timed blocks. A block timestamped before the one before it counts as arriving
+// along with it. The caller must hold the lock.
+func (ef *FeeEstimator) intervals() (intervals []time.Duration) {
+	for i := 1; i < len(ef.timed); i++ {
+		d := ef.timed[i].time.Sub(ef.timed[i-1].time)
+		if d < 0 {
+			d = 0
+		}
+		intervals = append(intervals, d)
+	}
+	return
+}
+
+// blocksWithin returns the most blocks, up to estimateFeeDepth, that arrived within the length of time in at least
+// TimeTargetConfidence of the stretches of consecutive intervals, and the share of the stretches in which they did.
+// When not even one block arrived within it often enough, it returns 1 and the share of intervals no longer than it.
+func blocksWithin(intervals []time.Duration, within time.Duration) (blocks uint32, likelihood float64) {
+	sums := make([]time.Duration, len(intervals)+1)
+	for i, d := range intervals {
+		sums[i+1] = sums[i] + d
+	}
+	blocks = 1
+	for k := 1; k <= estimateFeeDepth && k <= len(intervals); k++ {
+		stretches := len(intervals) - k + 1
+		var met int
+		for i := 0; i < stretches; i++ {
+			if sums[i+k]-sums[i] <= within {
+				met++
+			}
+		}
+		share := float64(met) / float64(stretches)
+		if k == 1 {
+			likelihood = share
+		}
+		if share < TimeTargetConfidence {
+			break
+		}
+		blocks, likelihood = uint32(k), share
+	}
+	return
+}
+
+// EstimateFeeByTime estimates the fee per kilobyte to have a tx confirmed within a length of time from now, from the
+// times between the recent blocks. The times are not saved with the estimator, so after the node is started no
+// estimates are made until enough blocks have arrived.
+func (ef *FeeEstimator) EstimateFeeByTime(within time.Duration) (estimate TimeEstimate, e error) {
+	if within <= 0 {
+		return estimate, errors.New("cannot confirm transaction in no time")
+	}
+	ef.mtx.Lock()
+	intervals := ef.intervals()
+	ef.mtx.Unlock()
+	if len(intervals) < minTimedIntervals {
+		return estimate, fmt.Errorf(
+			"not enough blocks have arrived to estimate for a length of time, %d of %d",
+			len(intervals), minTimedIntervals+1,
+		)
+	}
+	estimate.Blocks, estimate.Likelihood = blocksWithin(intervals, within)
+	estimate.FeeRate, e = ef.EstimateFee(estimate.Blocks)
+	return
+}
+
+// algoStats returns how often the recent blocks of each algorithm have arrived, by algorithm name, and the average time
+// between all of them. The caller must hold the lock.
+func (ef *FeeEstimator) algoStats() (algos []AlgoIntervalStats, mean time.Duration) {
+	if len(ef.timed) == 0 {
+		return
+	}
+	first := make(map[string]time.Time)
+	last := make(map[string]time.Time)
+	counts := make(map[string]int)
+	for _, t := range ef.timed {
+		if _, ok := first[t.algo]; !ok {
+			first[t.algo] = t.time
+		}
+		last[t.algo] = t.time
+		counts[t.algo]++
+	}
+	for algo, n := range counts {
+		stats := AlgoIntervalStats{Algo: algo, Blocks: n, Share: float64(n) / float64(len(ef.timed))}
+		if n > 1 {
+			stats.MeanInterval = last[algo].Sub(first[algo]) / time.Duration(n-1)
+		}
+		algos = append(algos, stats)
+	}
+	sort.Slice(
+		algos, func(i, j int) bool {
+			return algos[i].Algo < algos[j].Algo
+		},
+	)
+	if intervals := ef.intervals(); len(intervals) > 0 {
+		var total time.Duration
+		for _, d := range intervals {
+			total += d
+		}
+		mean = total / time.Duration(len(intervals))
+	}
+	return
+}
@@ -0,0 +1,190 @@
+package mempool
+
+import (
+	"time"
+)
+
+const (
+	// calibrationWindow is the number of most recent predictions the automatic bias is worked out from.
+	calibrationWindow = 500
+	// calibrationMinOutcomes is the number of predictions that must have been checked before the bias is adjusted.
+	calibrationMinOutcomes = 50
+	// calibrationTargetCoverage is the share of transactions paying the estimate for a target that should confirm
+	// within it. The bias is raised when the coverage falls short of it by more than calibrationTolerance and lowered
+	// again when it exceeds it by as much.
+	calibrationTargetCoverage = 0.9
+	calibrationTolerance      = 0.05
+	// calibrationBiasStep is how much the bias is changed by after each block, and calibrationMaxBias the most
+	// estimates can be raised by.
+	calibrationBiasStep = 0.05
+	calibrationMaxBias  = 1.0
+)
+
+// calibrationCount is the running tally of how the transactions predicted to confirm within a target fared.
+type calibrationCount struct {
+	predicted   int64
+	confirmed   int64
+	mined       int64
+	totalBlocks int64
+}
+
+// EstimatorTargetStats shows how well the estimates for a confirmation target have matched what happened to the
+// transactions paying them.
+type EstimatorTargetStats struct {
+	// Target is the number of blocks the estimate is for.
+	Target int
+	// FeeRate is the current estimate for the target, including the bias.
+	FeeRate DUOPerKilobyte
+	// Predicted is the number of observed transactions with a known outcome that paid the estimate for the target but
+	// not the one for the target before it.
+	Predicted int64
+	// Confirmed is how many of them were mined within the target.
+	Confirmed int64
+	// Coverage is Confirmed as a share of Predicted.
+	Coverage float64
+	// MeanBlocks is the average number of blocks the ones that were mined took to confirm.
+	MeanBlocks float64
+}
+
+// EstimatorStats is the calibration of the fee estimator against the observed transactions that have been mined or
+// have stayed in the mempool longer than it tracks them.
+type EstimatorStats struct {
+	// Blocks is the number of blocks registered with the estimator.
+	Blocks uint32
+	// Observed is the number of transactions the estimator is tracking.
+	Observed int
+	// Bias is the factor the estimates are multiplied by, 1 unless AutoBias has raised it.
+	Bias float64
+	// AutoBias is true when the bias is adjusted to keep the coverage of recent predictions near the target coverage.
+	AutoBias bool
+	// RecentCoverage is the share of the recent predictions, that the bias is worked out from, that were met.
+	RecentCoverage float64
+	// Targets are the targets that transactions have been predicted for, in ascending order.
+	Targets []EstimatorTargetStats
+	// MeanInterval is the average time between the recent blocks the estimates for a length of time are worked out
+	// from, and Algos how often the blocks of each algorithm among them arrived.
+	MeanInterval time.Duration
+	Algos        []AlgoIntervalStats
+}
+
+// SetAutoBias enables or disables automatically raising the estimates when transactions paying them confirm slower
+// than predicted. Disabling it removes any bias added so far.
+func (ef *FeeEstimator) SetAutoBias(enabled bool) {
+	ef.mtx.Lock()
+	defer ef.mtx.Unlock()
+	ef.autoBias = enabled
+	if !enabled {
+		ef.bias = 0
+	}
+}
+
+// Stats returns the calibration of the fee estimator. The calibration is not saved with the estimator, so it starts
+// over each time the node is started.
+func (ef *FeeEstimator) Stats() *EstimatorStats {
+	ef.mtx.Lock()
+	defer ef.mtx.Unlock()
+	stats := &EstimatorStats{
+		Blocks:         ef.numBlocksRegistered,
+		Observed:       len(ef.observed),
+		Bias:           1 + ef.bias,
+		AutoBias:       ef.autoBias,
+		RecentCoverage: ef.recentCoverage(),
+	}
+	stats.Algos, stats.MeanInterval = ef.algoStats()
+	if ef.cached == nil {
+		ef.cached = ef.estimates()
+	}
+	for i, c := range ef.calibration {
+		if c.predicted == 0 {
+			continue
+		}
+		target := EstimatorTargetStats{
+			Target:    i + 1,
+			FeeRate:   ef.biased(ef.cached[i]).ToBtcPerKb(),
+			Predicted: c.predicted,
+			Confirmed: c.confirmed,
+			Coverage:  float64(c.confirmed) / float64(c.predicted),
+		}
+		if c.mined > 0 {
+			target.MeanBlocks = float64(c.totalBlocks) / float64(c.mined)
+		}
+		stats.Targets = append(stats.Targets, target)
+	}
+	return stats
+}
+
+// biased returns the rate raised by the bias.
+func (ef *FeeEstimator) biased(rate SatoshiPerByte) SatoshiPerByte {
+	return rate * SatoshiPerByte(1+ef.bias)
+}
+
+// predictTarget returns the lowest confirmation target whose estimate the fee rate pays, or 0 if no estimates can be
+// made yet or it pays less than all of them.
+func (ef *FeeEstimator) predictTarget(feeRate SatoshiPerByte) int32 {
+	if ef.numBlocksRegistered < ef.minRegisteredBlocks {
+		return 0
+	}
+	if ef.cached == nil {
+		ef.cached = ef.estimates()
+	}
+	for i, rate := range ef.cached {
+		if rate > 0 && feeRate >= ef.biased(rate) {
+			return int32(i) + 1
+		}
+	}
+	return 0
+}
+
+// recordOutcome records whether a transaction predicted to confirm within target did so. blocks is the number of blocks
+// it took to confirm, or 0 if it stayed in the mempool longer than the estimator tracks transactions.
+func (ef *FeeEstimator) recordOutcome(target, blocks int32) {
+	c := &ef.calibration[target-1]
+	c.predicted++
+	met := blocks > 0 && blocks <= target
+	if met {
+		c.confirmed++
+	}
+	if blocks > 0 {
+		c.mined++
+		c.totalBlocks += int64(blocks)
+	}
+	if len(ef.outcomes) < calibrationWindow {
+		ef.outcomes = append(ef.outcomes, met)
+	} else {
+		ef.outcomes[ef.nextOutcome] = met
+	}
+	ef.nextOutcome = (ef.nextOutcome + 1) % calibrationWindow
+}
+
+// recentCoverage returns the share of the recent predictions that were met.
+func (ef *FeeEstimator) recentCoverage() float64 {
+	if len(ef.outcomes) == 0 {
+		return 0
+	}
+	var met int
+	for _, m := range ef.outcomes {
+		if m {
+			met++
+		}
+	}
+	return float64(met) / float64(len(ef.outcomes))
+}
+
+// adjustBias raises the bias when the recent predictions have been met less often than the target coverage and lowers
+// it when they have been met more often, if automatic bias is enabled.
+func (ef *FeeEstimator) adjustBias() {
+	if !ef.autoBias || len(ef.outcomes) < calibrationMinOutcomes {
+		return
+	}
+	coverage := ef.recentCoverage()
+	switch {
+	case coverage < calibrationTargetCoverage-calibrationTolerance:
+		if ef.bias += calibrationBiasStep; ef.bias > calibrationMaxBias {
+			ef.bias = calibrationMaxBias
+		}
+	case coverage > calibrationTargetCoverage+calibrationTolerance:
+		if ef.bias -= calibrationBiasStep; ef.bias < 0 {
+			ef.bias = 0
+		}
+	}
+}
@@ -82,8 +82,18 @@ type Policy struct {
 	// MaxSigOpCostPerTx is the cumulative maximum cost of all the signature operations in a single transaction we will
 	// relay or mine. It is a fraction of the max signature operations for a block.
 	MaxSigOpCostPerTx int
+	// BytesPerSigOp is the number of virtual bytes each signature operation of a transaction is counted as when its
+	// virtual size is used to compare its fee, if that is more than its weight. Zero counts the weight alone.
+	BytesPerSigOp int
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be considered a non-zero fee.
 	MinRelayTxFee amt.Amount
+	// MaxTxFee is the highest fee a transaction submitted to the node itself may pay before it is rejected as absurd, as
+	// such a fee is almost certainly a mistake by whoever created the transaction. Transactions relayed by peers are
+	// valid whatever fee they pay and are not checked. Zero disables the check.
+	MaxTxFee amt.Amount
+	// Expiry is how long a transaction may stay in the pool without being mined before it is evicted. Zero disables
+	// expiry.
+	Expiry time.Duration
 }
 
 // Tag represents an identifier to use for tagging orphan transactions. The caller may choose any scheme it desires
@@ -108,6 +118,9 @@ type TxPool struct {
 	orphans       map[chainhash.Hash]*orphanTx
 	orphansByPrev map[wire.OutPoint]map[chainhash.Hash]*util.Tx
 	outpoints     map[wire.OutPoint]*util.Tx
+	// feeDeltas holds the amounts set by PrioritiseTransaction to add to the fees of transactions when they are
+	// ranked, including for transactions that have not arrived in the pool yet.
+	feeDeltas     map[chainhash.Hash]int64
 	pennyTotal    float64 // exponentially decaying total for penny spends.
 	lastPennyUnix int64   // unix time of last ``penny spend''
 	// nextExpireScan is the time after which the orphan pool will be scanned in order to evict orphans. This is NOT
@@ -115,6 +128,10 @@ type TxPool struct {
 	// unconditional timer.
 	nextExpireScan time.Time
 	updateHook     func()
+	// expired is the number of transactions evicted from the pool because they expired.
+	expired uint64
+	// orphanStats counts what happened to the transactions added to the orphan pool.
+	orphanStats OrphanStats
 }
 
 // orphanTx is normal transaction that references an ancestor transaction that is not yet available. It also contains
@@ -122,6 +139,7 @@ type TxPool struct {
 type orphanTx struct {
 	tx         *util.Tx
 	tag        Tag
+	received   time.Time
 	expiration time.Time
 }
 
@@ -216,7 +234,9 @@ func (mp *TxPool) MaybeAcceptTransaction(
 ) (hashes []*chainhash.Hash, txD *TxDesc, e error) {
 	// Protect concurrent access.
 	mp.mtx.Lock()
-	hashes, txD, e = mp.maybeAcceptTransaction(b, tx, isNew, rateLimit, true)
+	// Transactions that are not new, such as those of disconnected blocks, have already been mined, so their fees are
+	// not checked against the maximum.
+	hashes, txD, e = mp.maybeAcceptTransaction(b, tx, isNew, rateLimit, true, !isNew, nil)
 	mp.mtx.Unlock()
 	return hashes, txD, e
 }
@@ -251,11 +271,12 @@ func (mp *TxPool) ProcessOrphans(b *blockchain.BlockChain, acceptedTx *util.Tx)
 // pool. It includes functionality such as rejecting duplicate transactions, ensuring transactions follow all rules,
 // orphan transaction handling, and insertion into the memory pool. It returns a slice of transactions added to the
 // mempool. When the error is nil the list will include the passed transaction itself along with any additional orphan
-// transactions that were added as a result of the passed one being accepted. This function is safe for concurrent
-// access.
+// transactions that were added as a result of the passed one being accepted. Transactions paying more than the MaxTxFee
+// of the policy are rejected unless allowHighFees is set, which only submissions to the node itself should leave unset.
+// This function is safe for concurrent access.
 func (mp *TxPool) ProcessTransaction(
 	b *blockchain.BlockChain, tx *util.Tx,
-	allowOrphan, rateLimit bool, tag Tag,
+	allowOrphan, rateLimit, allowHighFees bool, tag Tag,
 ) ([]*TxDesc, error) {
 	D.Ln("processing transaction", tx.Hash())
 	// Protect concurrent access.
@@ -264,7 +285,7 @@ func (mp *TxPool) ProcessTransaction(
 	// Potentially accept the transaction to the memory pool.
 	missingParents, txD, e := mp.maybeAcceptTransaction(
 		b, tx, true,
-		rateLimit, true,
+		rateLimit, true, allowHighFees, nil,
 	)
 	if e != nil {
 		return nil, e
@@ -297,6 +318,32 @@ func (mp *TxPool) ProcessTransaction(
 	return nil, e
 }
 
+// PrioritiseTransaction adds the fee delta, in satoshi, to the fee of the transaction with the given hash when it is
+// ranked for relay and for inclusion in block templates, without changing the fee it actually pays. Deltas for the same
+// transaction accumulate, and may be negative to lower its priority. A delta can be set before the transaction arrives
+// in the pool, and is dropped when the transaction leaves it. The new total delta of the transaction is returned. This
+// function is safe for concurrent access.
+func (mp *TxPool) PrioritiseTransaction(hash *chainhash.Hash, feeDelta int64) int64 {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	delta := mp.feeDeltas[*hash] + feeDelta
+	if delta == 0 {
+		delete(mp.feeDeltas, *hash)
+	} else {
+		mp.feeDeltas[*hash] = delta
+	}
+	if txD, exists := mp.pool[*hash]; exists {
+		txD.FeeDelta = delta
+		// Mark the pool as updated so block templates are rebuilt with the new ranking.
+		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
+		if mp.updateHook != nil {
+			mp.updateHook()
+		}
+	}
+	I.F("prioritised transaction %v by %d, delta is now %d", hash, feeDelta, delta)
+	return delta
+}
+
 // RawMempoolVerbose returns all of the entries in the mempool as a fully populated json result. This function is safe
 // for concurrent access.
 func (mp *TxPool) RawMempoolVerbose() map[string]*btcjson.GetRawMempoolVerboseResult {
@@ -318,7 +365,7 @@ func (mp *TxPool) RawMempoolVerbose() map[string]*btcjson.GetRawMempoolVerboseRe
 		}
 		mpd := &btcjson.GetRawMempoolVerboseResult{
 			Size:             int32(tx.MsgTx().SerializeSize()),
-			VSize:            int32(GetTxVirtualSize(tx)),
+			VSize:            int32(desc.VSize),
 			Fee:              amt.Amount(desc.Fee).ToDUO(),
 			Time:             desc.Added.Unix(),
 			Height:           int64(desc.Height),
@@ -361,7 +408,9 @@ func (mp *TxPool) RemoveDoubleSpends(tx *util.Tx) {
 // safe for concurrent access.
 func (mp *TxPool) RemoveOrphan(tx *util.Tx) {
 	mp.mtx.Lock()
+	numOrphans := len(mp.orphans)
 	mp.removeOrphan(tx, false)
+	mp.orphanStats.Removed += int64(numOrphans - len(mp.orphans))
 	mp.mtx.Unlock()
 }
 
@@ -370,12 +419,14 @@ func (mp *TxPool) RemoveOrphan(tx *util.Tx) {
 func (mp *TxPool) RemoveOrphansByTag(tag Tag) uint64 {
 	var numEvicted uint64
 	mp.mtx.Lock()
+	numOrphans := len(mp.orphans)
 	for _, otx := range mp.orphans {
 		if otx.tag == tag {
 			mp.removeOrphan(otx.tx, true)
 			numEvicted++
 		}
 	}
+	mp.orphanStats.Removed += int64(numOrphans - len(mp.orphans))
 	mp.mtx.Unlock()
 	return numEvicted
 }
@@ -390,6 +441,36 @@ func (mp *TxPool) RemoveTransaction(tx *util.Tx, removeRedeemers bool) {
 	mp.mtx.Unlock()
 }
 
+// ExpireTransactions evicts the transactions that have been in the pool for longer than the expiry set in the policy,
+// along with the transactions that spend their outputs, and returns them. This function is safe for concurrent access.
+func (mp *TxPool) ExpireTransactions() (expired []*util.Tx) {
+	if mp.cfg.Policy.Expiry <= 0 {
+		return
+	}
+	mp.mtx.Lock()
+	cutoff := time.Now().Add(-mp.cfg.Policy.Expiry)
+	for _, desc := range mp.pool {
+		if desc.Added.Before(cutoff) {
+			expired = append(expired, mp.expireTransaction(desc.Tx)...)
+		}
+	}
+	mp.expired += uint64(len(expired))
+	mp.mtx.Unlock()
+	if len(expired) > 0 {
+		D.F("evicted %d expired transactions from the mempool", len(expired))
+	}
+	return
+}
+
+// ExpiryInfo returns how long transactions may stay in the pool and how many have been evicted because they expired.
+// This function is safe for concurrent access.
+func (mp *TxPool) ExpiryInfo() (expiry time.Duration, expired uint64) {
+	mp.mtx.RLock()
+	expiry, expired = mp.cfg.Policy.Expiry, mp.expired
+	mp.mtx.RUnlock()
+	return
+}
+
 // TxDescs returns a slice of descriptors for all the transactions in the pool. The descriptors are to be treated as
 // read only. This function is safe for concurrent access.
 func (mp *TxPool) TxDescs() []*TxDesc {
@@ -432,11 +513,14 @@ func (mp *TxPool) addOrphan(tx *util.Tx, tag Tag) {
 	if e != nil {
 		W.Ln("failed to set orphan limit", e)
 	}
+	now := time.Now()
 	mp.orphans[*tx.Hash()] = &orphanTx{
 		tx:         tx,
 		tag:        tag,
-		expiration: time.Now().Add(orphanTTL),
+		received:   now,
+		expiration: now.Add(orphanTTL),
 	}
+	mp.orphanStats.Added++
 	for _, txIn := range tx.MsgTx().TxIn {
 		if _, exists := mp.orphansByPrev[txIn.PreviousOutPoint]; !exists {
 			mp.orphansByPrev[txIn.PreviousOutPoint] =
@@ -449,8 +533,10 @@ func (mp *TxPool) addOrphan(tx *util.Tx, tag Tag) {
 
 // addTransaction adds the passed transaction to the memory pool. It should not be called directly as it doesn't perform
 // any validation. This is a helper for maybeAcceptTransaction. This function MUST be called with the mempool lock held
-// (for writes).
-func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, tx *util.Tx, height int32, fee int64) *TxDesc {
+// (for writes). The fee rate of the transaction is computed over vSize, its sigop adjusted virtual size.
+func (mp *TxPool) addTransaction(
+	utxoView *blockchain.UtxoViewpoint, tx *util.Tx, height int32, fee, vSize int64,
+) *TxDesc {
 	// Add the transaction to the pool and mark the referenced outpoints as spent by the pool.
 	txD := &TxDesc{
 		TxDesc: mining.TxDesc{
@@ -458,7 +544,9 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, tx *util.Tx
 			Added:    time.Now(),
 			Height:   height,
 			Fee:      fee,
-			FeePerKB: fee * 1000 / GetTxVirtualSize(tx),
+			FeePerKB: fee * 1000 / vSize,
+			VSize:    vSize,
+			FeeDelta: mp.feeDeltas[*tx.Hash()],
 		},
 		StartingPriority: mining.CalcPriority(tx.MsgTx(), utxoView, height),
 	}
@@ -568,6 +656,7 @@ func (mp *TxPool) limitNumOrphans() (e error) {
 		// Set next expiration scan to occur after the scan interval.
 		mp.nextExpireScan = now.Add(orphanExpireScanInterval)
 		numOrphans := len(mp.orphans)
+		mp.orphanStats.Expired += int64(origNumOrphans - numOrphans)
 		if numExpired := origNumOrphans - numOrphans; numExpired > 0 {
 			D.F(
 				"Expired %d %s (remaining: %d)",
@@ -588,15 +677,17 @@ func (mp *TxPool) limitNumOrphans() (e error) {
 		// Don't remove redeemers in the case of a random eviction since it is quite possible it might be needed again
 		// shortly.
 		mp.removeOrphan(otx.tx, false)
+		mp.orphanStats.Evicted++
 		break
 	}
 	return nil
 }
 
 // maybeAcceptTransaction is the internal function which implements the public MaybeAcceptTransaction. See the comment
-// for MaybeAcceptTransaction for more details. This function MUST be called with the mempool lock held (for writes).
+// for MaybeAcceptTransaction for more details. When trace is not nil every check is recorded in it and the pool is left
+// untouched. This function MUST be called with the mempool lock held (for writes).
 func (mp *TxPool) maybeAcceptTransaction(
-	b *blockchain.BlockChain, tx *util.Tx, isNew, rateLimit, rejectDupOrphans bool,
+	b *blockchain.BlockChain, tx *util.Tx, isNew, rateLimit, rejectDupOrphans, allowHighFees bool, trace *AcceptTrace,
 ) ([]*chainhash.Hash, *TxDesc, error) {
 	txHash := tx.Hash()
 	// // If a transaction has witness data, and segwit isn't active yet, If segwit isn't active yet, then we won't accept
@@ -612,32 +703,36 @@ func (mp *TxPool) maybeAcceptTransaction(
 	// 	}
 	// }
 	if blockchain.ContainsBlacklisted(b, tx, hardfork.Blacklist) {
-		return nil, nil, errors.New("transaction contains blacklisted address")
+		return nil, nil, trace.fail("blacklist", errors.New("transaction contains blacklisted address"))
 	}
+	trace.pass("blacklist", "")
 	// Don't accept the transaction if it already exists in the pool. This applies to orphan transactions as well when
 	// the reject duplicate orphans flag is set. This check is intended to be a quick check to weed out duplicates.
 	if mp.isTransactionInPool(txHash) || (rejectDupOrphans &&
 		mp.isOrphanInPool(txHash)) {
 		str := fmt.Sprintf("already have transaction %v", txHash)
-		return nil, nil, txRuleError(wire.RejectDuplicate, str)
+		return nil, nil, trace.fail("duplicate", txRuleError(wire.RejectDuplicate, str))
 	}
+	trace.pass("duplicate", "")
 	// Perform preliminary sanity checks on the transaction. This makes use of blockchain which contains the invariant
 	// rules for what transactions are allowed into blocks.
 	e := blockchain.CheckTransactionSanity(tx)
 	if e != nil {
 		if cErr, ok := e.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, nil, trace.fail("sanity", chainRuleError(cErr))
 		}
-		return nil, nil, e
+		return nil, nil, trace.fail("sanity", e)
 	}
+	trace.pass("sanity", "")
 	// A standalone transaction must not be a coinbase transaction.
 	if blockchain.IsCoinBase(tx) {
 		str := fmt.Sprintf(
 			"transaction %v is an individual coinbase",
 			txHash,
 		)
-		return nil, nil, txRuleError(wire.RejectInvalid, str)
+		return nil, nil, trace.fail("coinbase", txRuleError(wire.RejectInvalid, str))
 	}
+	trace.pass("coinbase", "")
 	// Get the current height of the main chain. A standalone transaction will be mined into the next block at best, so
 	// its height is at least one more than the current height.
 	bestHeight := mp.cfg.BestHeight()
@@ -663,8 +758,13 @@ func (mp *TxPool) maybeAcceptTransaction(
 				"transaction %v is not standard: %v",
 				txHash, e,
 			)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, nil, trace.fail("standard", txRuleError(rejectCode, str))
 		}
+		trace.pass(
+			"standard", fmt.Sprintf(
+				"weight %d, max %d", blockchain.GetTransactionWeight(tx), maxStandardTxWeight,
+			),
+		)
 	}
 	// The transaction may not use any of the same outputs as other transactions already in the pool as that would
 	// ultimately result in a double spend. This check is intended to be quick and therefore only detects double spends
@@ -673,31 +773,36 @@ func (mp *TxPool) maybeAcceptTransaction(
 	// from the main chain which examines the actual spend data and prevents double spends.
 	e = mp.checkPoolDoubleSpend(tx)
 	if e != nil {
-		return nil, nil, e
+		return nil, nil, trace.fail("pooldoublespend", e)
 	}
+	trace.pass("pooldoublespend", "")
 	// Fetch all of the unspent transaction outputs referenced by the inputs to this transaction. This function also
 	// attempts to fetch the transaction itself to be used for detecting a duplicate transaction without needing to do a
 	// separate lookup.
 	utxoView, e := mp.fetchInputUtxos(tx)
 	if e != nil {
 		if cErr, ok := e.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, nil, trace.fail("fetchinputs", chainRuleError(cErr))
 		}
-		return nil, nil, e
+		return nil, nil, trace.fail("fetchinputs", e)
 	}
+	trace.pass("fetchinputs", "")
 	// Don't allow the transaction if it exists in the main chain and is not not already fully spent.
 	prevOut := wire.OutPoint{Hash: *txHash}
 	for txOutIdx := range tx.MsgTx().TxOut {
 		prevOut.Index = uint32(txOutIdx)
 		entry := utxoView.LookupEntry(prevOut)
 		if entry != nil && !entry.IsSpent() {
-			return nil, nil, txRuleError(
-				wire.RejectDuplicate,
-				"transaction already exists",
+			return nil, nil, trace.fail(
+				"inchain", txRuleError(
+					wire.RejectDuplicate,
+					"transaction already exists",
+				),
 			)
 		}
 		utxoView.RemoveEntry(prevOut)
 	}
+	trace.pass("inchain", "")
 	// Transaction is an orphan if any of the referenced transaction outputs don't exist or are already spent. Adding
 	// orphans to the orphan pool is not handled by this function, and the caller should use maybeAddOrphan if this
 	// behavior is desired.
@@ -711,8 +816,10 @@ func (mp *TxPool) maybeAcceptTransaction(
 		}
 	}
 	if len(missingParents) > 0 {
+		trace.orphan(missingParents)
 		return missingParents, nil, nil
 	}
+	trace.pass("orphan", "")
 	// // Don't allow the transaction into the mempool unless its sequence lock is active, meaning that it'll be allowed
 	// // into the next block with respect to its defined relative lock times.
 	// sequenceLock, e := mp.cfg.CalcSequenceLock(tx, utxoView)
@@ -739,10 +846,20 @@ func (mp *TxPool) maybeAcceptTransaction(
 	)
 	if e != nil {
 		if cErr, ok := e.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, nil, trace.fail("inputs", chainRuleError(cErr))
 		}
-		return nil, nil, e
+		return nil, nil, trace.fail("inputs", e)
 	}
+	trace.pass("inputs", fmt.Sprintf("fee %v", amt.Amount(txFee)))
+	// Don't allow transactions paying a fee so high that it can only be a mistake, unless the caller has asked for it.
+	if !allowHighFees && mp.cfg.Policy.MaxTxFee > 0 && amt.Amount(txFee) > mp.cfg.Policy.MaxTxFee {
+		return nil, nil, trace.fail(
+			"absurdfee", RuleError{
+				Err: AbsurdFeeError{Fee: amt.Amount(txFee), MaxFee: mp.cfg.Policy.MaxTxFee},
+			},
+		)
+	}
+	trace.pass("absurdfee", fmt.Sprintf("fee %v, max %v", amt.Amount(txFee), mp.cfg.Policy.MaxTxFee))
 	// Don't allow transactions with non-standard inputs if the network parameters forbid their acceptance.
 	if !mp.cfg.Policy.AcceptNonStd {
 		e = checkInputsStandard(tx, utxoView)
@@ -757,8 +874,9 @@ func (mp *TxPool) maybeAcceptTransaction(
 				"transaction %v has a non-standard "+
 					"input: %v", txHash, e,
 			)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, nil, trace.fail("standardinputs", txRuleError(rejectCode, str))
 		}
+		trace.pass("standardinputs", "")
 	}
 	// NOTE: if you modify this code to accept non-standard transactions, you should add code here to check that the
 	// transaction does a reasonable number of ECDSA signature verifications. Don't allow transactions with an excessive
@@ -770,38 +888,49 @@ func (mp *TxPool) maybeAcceptTransaction(
 	sigOpCost, e = blockchain.GetSigOpCost(tx, false, utxoView, true)
 	if e != nil {
 		if cErr, ok := e.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, nil, trace.fail("sigopcost", chainRuleError(cErr))
 		}
-		return nil, nil, e
+		return nil, nil, trace.fail("sigopcost", e)
 	}
 	if sigOpCost > mp.cfg.Policy.MaxSigOpCostPerTx {
 		str := fmt.Sprintf(
 			"transaction %v sigop cost is too high: %d > %d",
 			txHash, sigOpCost, mp.cfg.Policy.MaxSigOpCostPerTx,
 		)
-		return nil, nil, txRuleError(wire.RejectNonstandard, str)
+		return nil, nil, trace.fail("sigopcost", txRuleError(wire.RejectNonstandard, str))
 	}
+	trace.pass("sigopcost", fmt.Sprintf("cost %d, max %d", sigOpCost, mp.cfg.Policy.MaxSigOpCostPerTx))
 	// Don't allow transactions with fees too low to get into a mined block. Most miners allow a free transaction area
 	// in blocks they mine to go alongside the area used for high-priority transactions as well as transactions with
 	// fees. A transaction size of up to 1000 bytes is considered safe to go into this section. Further, the minimum fee
 	// calculated below on its own would encourage several small transactions to avoid fees rather than one single
 	// larger transaction which is more desirable. Therefore as long as the size of the transaction does not exceed 1000
 	// less than the reserved space for high-priority transactions, don't require a fee for it.
-	serializedSize := GetTxVirtualSize(tx)
+	// A fee delta set with PrioritiseTransaction counts towards the fee required for relay. The size the fee is required
+	// for counts the signature operations of the transaction, so a transaction heavy in them pays for the share of the
+	// block's signature operations it takes up.
+	modifiedFee := txFee + mp.feeDeltas[*txHash]
+	serializedSize := GetSigOpAdjustedVirtualSize(tx, sigOpCost, mp.cfg.Policy.BytesPerSigOp)
 	minFee := calcMinRequiredTxRelayFee(
 		serializedSize,
 		mp.cfg.Policy.MinRelayTxFee,
 	)
-	if serializedSize >= (constant.DefaultBlockPrioritySize-1000) && txFee < minFee {
+	trace.fees(txFee, modifiedFee, minFee, serializedSize)
+	if serializedSize >= (constant.DefaultBlockPrioritySize-1000) && modifiedFee < minFee {
 		str := fmt.Sprintf(
 			"transaction %v has %d fees which is under the required amount of %d",
 			txHash, txFee, minFee,
 		)
-		return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+		return nil, nil, trace.fail("minrelayfee", txRuleError(wire.RejectInsufficientFee, str))
 	}
+	trace.pass(
+		"minrelayfee", fmt.Sprintf(
+			"fee %d (modified %d), required %d for %d vbytes", txFee, modifiedFee, minFee, serializedSize,
+		),
+	)
 	// Require that free transactions have sufficient priority to be mined in the next block. Transactions which are
 	// being added back to the memory pool from blocks that have been disconnected during a reorg are exempted.
-	if isNew && !mp.cfg.Policy.DisableRelayPriority && txFee < minFee {
+	if isNew && !mp.cfg.Policy.DisableRelayPriority && modifiedFee < minFee {
 		currentPriority := mining.CalcPriority(
 			tx.MsgTx(), utxoView,
 			nextBlockHeight,
@@ -812,35 +941,47 @@ func (mp *TxPool) maybeAcceptTransaction(
 					"priority (%v <= %v)", txHash,
 				currentPriority, mining.MinHighPriority,
 			)
-			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+			return nil, nil, trace.fail("priority", txRuleError(wire.RejectInsufficientFee, str))
 		}
+		trace.pass("priority", fmt.Sprintf("priority %v, min %v", currentPriority, mining.MinHighPriority))
 	}
 	// Free-to-relay transactions are rate limited here to prevent penny -flooding with tiny transactions as a form of
 	// attack.
-	if rateLimit && txFee < minFee {
+	if rateLimit && modifiedFee < minFee {
 		nowUnix := time.Now().Unix()
 		// Decay passed data with an exponentially decaying ~10 minute window - matches bitcoind handling.
-		mp.pennyTotal *= math.Pow(
+		pennyTotal := mp.pennyTotal * math.Pow(
 			1.0-1.0/600.0,
 			float64(nowUnix-mp.lastPennyUnix),
 		)
-		mp.lastPennyUnix = nowUnix
 		// Are we still over the limit?
-		if mp.pennyTotal >= mp.cfg.Policy.FreeTxRelayLimit*10*1000 {
+		if pennyTotal >= mp.cfg.Policy.FreeTxRelayLimit*10*1000 {
+			if trace == nil {
+				mp.pennyTotal = pennyTotal
+				mp.lastPennyUnix = nowUnix
+			}
 			str := fmt.Sprintf(
 				"transaction %v has been rejected "+
 					"by the rate limiter due to low fees", txHash,
 			)
-			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+			return nil, nil, trace.fail("ratelimit", txRuleError(wire.RejectInsufficientFee, str))
 		}
-		oldTotal := mp.pennyTotal
-		mp.pennyTotal += float64(serializedSize)
-		T.F(
-			"rate limit: curTotal %v, nextTotal: %v, limit %v",
-			oldTotal,
-			mp.pennyTotal,
-			mp.cfg.Policy.FreeTxRelayLimit*10*1000,
+		trace.pass(
+			"ratelimit", fmt.Sprintf(
+				"total %v, limit %v", pennyTotal, mp.cfg.Policy.FreeTxRelayLimit*10*1000,
+			),
 		)
+		// A trace only looks at the rate limiter, it doesn't count towards it.
+		if trace == nil {
+			mp.pennyTotal = pennyTotal + float64(serializedSize)
+			mp.lastPennyUnix = nowUnix
+			T.F(
+				"rate limit: curTotal %v, nextTotal: %v, limit %v",
+				pennyTotal,
+				mp.pennyTotal,
+				mp.cfg.Policy.FreeTxRelayLimit*10*1000,
+			)
+		}
 	}
 	// Verify crypto signatures for each input and reject the transaction if any don't verify.
 	e = blockchain.ValidateTransactionScripts(
@@ -849,13 +990,24 @@ func (mp *TxPool) maybeAcceptTransaction(
 		mp.cfg.HashCache,
 	)
 	if e != nil {
+		if trace != nil {
+			// Run the scripts again one opcode at a time so the trace can say where they failed.
+			if se := traceTransactionScripts(tx, utxoView, mp.cfg.SigCache); se != nil {
+				e = se
+			}
+		}
 		if cErr, ok := e.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, nil, trace.fail("scripts", chainRuleError(cErr))
 		}
-		return nil, nil, e
+		return nil, nil, trace.fail("scripts", e)
+	}
+	trace.pass("scripts", "")
+	if trace != nil {
+		trace.Accepted = true
+		return nil, nil, nil
 	}
 	// Add to transaction pool.
-	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
+	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee, serializedSize)
 	D.F(
 		"accepted transaction %v (pool size: %v) %s",
 		txHash,
@@ -910,15 +1062,18 @@ func (mp *TxPool) processOrphans(b *blockchain.BlockChain, acceptedTx *util.Tx)
 			if !exists {
 				continue
 			}
-			// Potentially accept an orphan into the tx pool.
+			// Potentially accept an orphan into the tx pool. Orphans come from peers, so their fees are not checked
+			// against the maximum.
 			for _, tx := range orphans {
 				missing, txD, e := mp.maybeAcceptTransaction(
-					b, tx, true, true, false,
+					b, tx, true, true, false, true, nil,
 				)
 				if e != nil {
 					// The orphan is now invalid so there is no way any other orphans which redeem any of its outputs
 					// can be accepted. Remove them.
+					numOrphans := len(mp.orphans)
 					mp.removeOrphan(tx, true)
+					mp.orphanStats.Rejected += int64(numOrphans - len(mp.orphans))
 					break
 				}
 				// Transaction is still an orphan. Try the next orphan which redeems this output.
@@ -930,6 +1085,7 @@ func (mp *TxPool) processOrphans(b *blockchain.BlockChain, acceptedTx *util.Tx)
 				// any orphans that depend on it are handled too.
 				acceptedTxns = append(acceptedTxns, txD)
 				mp.removeOrphan(tx, false)
+				mp.orphanStats.Resolved++
 				processList.PushBack(tx)
 				// Only one transaction for this outpoint can be accepted, so the rest are now double spends and are
 				// removed later.
@@ -939,10 +1095,12 @@ func (mp *TxPool) processOrphans(b *blockchain.BlockChain, acceptedTx *util.Tx)
 	}
 	// Recursively remove any orphans that also redeem any outputs redeemed by the accepted transactions since those are
 	// now definitive double spends.
+	numOrphans := len(mp.orphans)
 	mp.removeOrphanDoubleSpends(acceptedTx)
 	for _, txD := range acceptedTxns {
 		mp.removeOrphanDoubleSpends(txD.Tx)
 	}
+	mp.orphanStats.Removed += int64(numOrphans - len(mp.orphans))
 	return acceptedTxns
 }
 
@@ -1017,6 +1175,7 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeRedeemers bool) {
 			delete(mp.outpoints, txIn.PreviousOutPoint)
 		}
 		delete(mp.pool, *txHash)
+		delete(mp.feeDeltas, *txHash)
 		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 		if mp.updateHook != nil {
 			mp.updateHook()
@@ -1024,6 +1183,23 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeRedeemers bool) {
 	}
 }
 
+// expireTransaction removes an expired transaction and the transactions that spend its outputs, and returns the
+// removed transactions. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) expireTransaction(tx *util.Tx) (expired []*util.Tx) {
+	txHash := tx.Hash()
+	if _, exists := mp.pool[*txHash]; !exists {
+		return
+	}
+	for i := uint32(0); i < uint32(len(tx.MsgTx().TxOut)); i++ {
+		prevOut := wire.OutPoint{Hash: *txHash, Index: i}
+		if txRedeemer, exists := mp.outpoints[prevOut]; exists {
+			expired = append(expired, mp.expireTransaction(txRedeemer)...)
+		}
+	}
+	mp.removeTransaction(tx, false)
+	return append(expired, tx)
+}
+
 // New returns a new memory pool for validating and storing standalone transactions until they are mined into a block.
 func New(cfg *Config) *TxPool {
 	return &TxPool{
@@ -1033,6 +1209,7 @@ func New(cfg *Config) *TxPool {
 		orphansByPrev:  make(map[wire.OutPoint]map[chainhash.Hash]*util.Tx),
 		nextExpireScan: time.Now().Add(orphanExpireScanInterval),
 		outpoints:      make(map[wire.OutPoint]*util.Tx),
+		feeDeltas:      make(map[chainhash.Hash]int64),
 		updateHook:     cfg.UpdateHook,
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/p9c/pod/pkg/blockchain"
 	"github.com/p9c/pod/pkg/chaincfg"
 	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/constant"
 	"github.com/p9c/pod/pkg/ecc"
 	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/util"
@@ -289,6 +290,7 @@ func newPoolHarness(chainParams *chaincfg.Params) (*poolHarness, []spendableOutp
 					MaxOrphanTxs:         5,
 					MaxOrphanTxSize:      1000,
 					MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
+					BytesPerSigOp:        constant.DefaultBytesPerSigOp,
 					MinRelayTxFee:        1000, // 1 Satoshi per byte
 					MaxTxVersion:         1,
 				},
@@ -381,7 +383,7 @@ func TestSimpleOrphanChain(t *testing.T) {
 		var acceptedTxns []*TxDesc
 		acceptedTxns, e = harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -405,7 +407,7 @@ func TestSimpleOrphanChain(t *testing.T) {
 	// linked.
 	acceptedTxns, e := harness.txPool.ProcessTransaction(
 		nil, chainedTxns[0],
-		false, false, 0,
+		false, false, false, 0,
 	)
 	if e != nil {
 		t.Fatalf(
@@ -446,7 +448,7 @@ func TestOrphanReject(t *testing.T) {
 	for _, tx := range chainedTxns[1:] {
 		acceptedTxns, e := harness.txPool.ProcessTransaction(
 			nil, tx, false,
-			false, 0,
+			false, false, 0,
 		)
 		if e == nil {
 			t.Fatalf(
@@ -506,7 +508,7 @@ func TestOrphanEviction(t *testing.T) {
 	for _, tx := range chainedTxns[1:] {
 		acceptedTxns, e := harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -566,7 +568,7 @@ func TestBasicOrphanRemoval(t *testing.T) {
 		var acceptedTxns []*TxDesc
 		acceptedTxns, e = harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -637,7 +639,7 @@ func TestOrphanChainRemoval(t *testing.T) {
 	for _, tx := range chainedTxns[1 : maxOrphans+1] {
 		acceptedTxns, e := harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -696,7 +698,7 @@ func TestMultiInputOrphanDoubleSpend(t *testing.T) {
 		var acceptedTxns []*TxDesc
 		acceptedTxns, e = harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -727,7 +729,7 @@ func TestMultiInputOrphanDoubleSpend(t *testing.T) {
 	}
 	acceptedTxns, e := harness.txPool.ProcessTransaction(
 		nil, doubleSpendTx,
-		true, false, 0,
+		true, false, false, 0,
 	)
 	if e != nil {
 		t.Fatalf(
@@ -748,7 +750,7 @@ func TestMultiInputOrphanDoubleSpend(t *testing.T) {
 	// double spending orphan to be removed.
 	acceptedTxns, e = harness.txPool.ProcessTransaction(
 		nil, chainedTxns[0],
-		false, false, 0,
+		false, false, false, 0,
 	)
 	if e != nil {
 		t.Fatalf("ProcessTransaction: failed to accept valid tx %v", e)
@@ -792,7 +794,7 @@ func TestCheckSpend(t *testing.T) {
 	for _, tx := range chainedTxns {
 		_, e := harness.txPool.ProcessTransaction(
 			nil, tx, true,
-			false, 0,
+			false, false, 0,
 		)
 		if e != nil {
 			t.Fatalf(
@@ -835,3 +837,364 @@ func TestCheckSpend(t *testing.T) {
 		t.Fatalf("Unexpeced spend found in pool: %v", spend)
 	}
 }
+
+// TestSaveRestorePool ensures that the transactions saved from a pool can be processed into a new pool without any of
+// them becoming orphans.
+func TestSaveRestorePool(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	const txChainLength = 5
+	chainedTxns, e := harness.CreateTxChain(outputs[0], txChainLength)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	for _, tx := range chainedTxns {
+		if _, e = harness.txPool.ProcessTransaction(nil, tx, false, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	restored, e := RestorePool(harness.txPool.Save())
+	if e != nil {
+		t.Fatalf("RestorePool: %v", e)
+	}
+	if len(restored) != len(chainedTxns) {
+		t.Fatalf("RestorePool: got %d transactions, want %d", len(restored), len(chainedTxns))
+	}
+	pool := New(&harness.txPool.cfg)
+	for _, tx := range restored {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept restored tx %v: %v", tx.Hash(), e)
+		}
+	}
+	for _, tx := range chainedTxns {
+		if !pool.HaveTransaction(tx.Hash()) {
+			t.Errorf("transaction %v was not restored", tx.Hash())
+		}
+	}
+	if _, e = RestorePool([]byte{0, 0, 0, 2}); e == nil {
+		t.Error("RestorePool: accepted a saved pool of an unknown version")
+	}
+}
+
+// TestAbsurdFee ensures that a transaction paying more than the maximum fee of the policy is rejected unless high fees
+// are allowed.
+func TestAbsurdFee(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	harness.txPool.cfg.Policy.MaxTxFee = 100000
+	// Create a transaction that pays all but a small part of its input as the fee.
+	input := outputs[0]
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: input.outPoint,
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	tx.AddTxOut(&wire.TxOut{PkScript: harness.payScript, Value: 1000000})
+	if int64(input.amount)-1000000 <= int64(harness.txPool.cfg.Policy.MaxTxFee) {
+		t.Fatalf("spendable output of %v is too small for the test", input.amount)
+	}
+	sigScript, e := txscript.SignatureScript(
+		tx, 0, harness.payScript,
+		txscript.SigHashAll, harness.signKey, true,
+	)
+	if e != nil {
+		t.Fatalf("unable to sign transaction: %v", e)
+	}
+	tx.TxIn[0].SignatureScript = sigScript
+	highFeeTx := util.NewTx(tx)
+	_, e = harness.txPool.ProcessTransaction(nil, highFeeTx, false, false, false, 0)
+	if !IsAbsurdFee(e) {
+		t.Fatalf("ProcessTransaction: expected an absurd fee error, got %v", e)
+	}
+	if code, _ := ErrToRejectErr(e); code != wire.RejectNonstandard {
+		t.Fatalf("ErrToRejectErr: unexpected reject code %v", code)
+	}
+	if _, e = harness.txPool.ProcessTransaction(nil, highFeeTx, false, false, true, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx with high fees allowed: %v", e)
+	}
+}
+
+// TestPrioritiseTransaction ensures that fee deltas set before and after a transaction arrives in the pool are applied
+// to its mining descriptor and dropped when it leaves the pool.
+func TestPrioritiseTransaction(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tx, e := harness.CreateSignedTx([]spendableOutput{outputs[0]}, 1)
+	if e != nil {
+		t.Fatalf("unable to create signed tx: %v", e)
+	}
+	// Set a delta before the transaction arrives, which must be applied when it is accepted.
+	if delta := harness.txPool.PrioritiseTransaction(tx.Hash(), 5000); delta != 5000 {
+		t.Fatalf("PrioritiseTransaction: unexpected delta %d", delta)
+	}
+	if _, e = harness.txPool.ProcessTransaction(nil, tx, false, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	feeDelta := func() int64 {
+		for _, desc := range harness.txPool.MiningDescs() {
+			if *desc.Tx.Hash() == *tx.Hash() {
+				return desc.FeeDelta
+			}
+		}
+		t.Fatalf("transaction %v not found in pool", tx.Hash())
+		return 0
+	}
+	if delta := feeDelta(); delta != 5000 {
+		t.Fatalf("mining descriptor has delta %d, want 5000", delta)
+	}
+	// Deltas for the same transaction accumulate.
+	harness.txPool.PrioritiseTransaction(tx.Hash(), -2000)
+	if delta := feeDelta(); delta != 3000 {
+		t.Fatalf("mining descriptor has delta %d, want 3000", delta)
+	}
+	// The delta is dropped when the transaction leaves the pool.
+	harness.txPool.RemoveTransaction(tx, false)
+	if delta := harness.txPool.PrioritiseTransaction(tx.Hash(), 0); delta != 0 {
+		t.Fatalf("delta %d remained after the transaction was removed", delta)
+	}
+}
+
+// TestSigOpAdjustedFeeRate ensures that the fee rate of a transaction with more signature operations than its size
+// pays for is computed over its sigop adjusted virtual size.
+func TestSigOpAdjustedFeeRate(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	pubKey, e := btcaddr.NewPubKey(harness.signKey.PubKey().SerializeCompressed(), harness.chainParams)
+	if e != nil {
+		t.Fatalf("unable to create public key address: %v", e)
+	}
+	// Each bare multisig output counts for the maximum number of signature operations of a multisig script.
+	multiSigScript, e := txscript.MultiSigScript([]*btcaddr.PubKey{pubKey}, 1)
+	if e != nil {
+		t.Fatalf("unable to create multisig script: %v", e)
+	}
+	const fee = 100000
+	input := outputs[0]
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: input.outPoint,
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	for i := 0; i < 3; i++ {
+		tx.AddTxOut(&wire.TxOut{PkScript: multiSigScript, Value: 100000})
+	}
+	tx.AddTxOut(&wire.TxOut{PkScript: harness.payScript, Value: int64(input.amount) - 300000 - fee})
+	sigScript, e := txscript.SignatureScript(
+		tx, 0, harness.payScript,
+		txscript.SigHashAll, harness.signKey, true,
+	)
+	if e != nil {
+		t.Fatalf("unable to sign transaction: %v", e)
+	}
+	tx.TxIn[0].SignatureScript = sigScript
+	sigOpTx := util.NewTx(tx)
+	wantSize := int64(blockchain.CountSigOps(sigOpTx) * constant.DefaultBytesPerSigOp)
+	if wantSize <= GetTxVirtualSize(sigOpTx) {
+		t.Fatalf("test transaction of %d vbytes isn't heavy in signature operations", GetTxVirtualSize(sigOpTx))
+	}
+	if _, e = harness.txPool.ProcessTransaction(nil, sigOpTx, false, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	for _, desc := range harness.txPool.MiningDescs() {
+		if *desc.Tx.Hash() != *sigOpTx.Hash() {
+			continue
+		}
+		if desc.VSize != wantSize {
+			t.Fatalf("mining descriptor has size %d, want %d", desc.VSize, wantSize)
+		}
+		if desc.FeePerKB != fee*1000/wantSize {
+			t.Fatalf("mining descriptor has fee rate %d, want %d", desc.FeePerKB, fee*1000/wantSize)
+		}
+		return
+	}
+	t.Fatalf("transaction %v not found in pool", sigOpTx.Hash())
+}
+
+// TestExpireTransactions ensures that transactions older than the expiry of the policy are evicted together with the
+// transactions spending them, and that the evictions are counted.
+func TestExpireTransactions(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	const txChainLength = 4
+	chainedTxns, e := harness.CreateTxChain(outputs[0], txChainLength)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	for _, tx := range chainedTxns {
+		if _, e = harness.txPool.ProcessTransaction(nil, tx, false, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	// Nothing expires while expiry is disabled.
+	if expired := harness.txPool.ExpireTransactions(); len(expired) != 0 {
+		t.Fatalf("ExpireTransactions: evicted %d transactions with expiry disabled", len(expired))
+	}
+	// Expiring the second transaction of the chain evicts the rest of the chain that spends it, but not the first.
+	harness.txPool.cfg.Policy.Expiry = time.Hour
+	harness.txPool.mtx.Lock()
+	harness.txPool.pool[*chainedTxns[1].Hash()].Added = time.Now().Add(-2 * time.Hour)
+	harness.txPool.mtx.Unlock()
+	expired := harness.txPool.ExpireTransactions()
+	if len(expired) != txChainLength-1 {
+		t.Fatalf("ExpireTransactions: evicted %d transactions, want %d", len(expired), txChainLength-1)
+	}
+	for _, tx := range chainedTxns[1:] {
+		if harness.txPool.HaveTransaction(tx.Hash()) {
+			t.Errorf("expired transaction %v is still in the pool", tx.Hash())
+		}
+	}
+	if !harness.txPool.HaveTransaction(chainedTxns[0].Hash()) {
+		t.Errorf("unexpired transaction %v was evicted", chainedTxns[0].Hash())
+	}
+	if expiry, count := harness.txPool.ExpiryInfo(); expiry != time.Hour || count != txChainLength-1 {
+		t.Errorf("ExpiryInfo: got %v and %d evictions, want %v and %d", expiry, count, time.Hour, txChainLength-1)
+	}
+}
+
+// TestTraceAcceptTransaction ensures that tracing a transaction reports the checks in order, points at the opcode a
+// failing script stopped at and leaves the pool untouched.
+func TestTraceAcceptTransaction(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tx, e := harness.CreateSignedTx([]spendableOutput{outputs[0]}, 1)
+	if e != nil {
+		t.Fatalf("unable to create signed tx: %v", e)
+	}
+	trace := harness.txPool.TraceAcceptTransaction(nil, tx, false)
+	if !trace.Accepted {
+		t.Fatalf("TraceAcceptTransaction: valid tx not accepted: %+v", trace.Checks)
+	}
+	if last := trace.Checks[len(trace.Checks)-1]; last.Name != "scripts" || !last.Passed {
+		t.Fatalf("TraceAcceptTransaction: unexpected last check %+v", last)
+	}
+	if harness.txPool.IsTransactionInPool(tx.Hash()) {
+		t.Fatal("TraceAcceptTransaction: transaction was added to the pool")
+	}
+	// Break the signature so the script fails at the signature check.
+	msgTx := tx.MsgTx().Copy()
+	sigScript := append([]byte{}, msgTx.TxIn[0].SignatureScript...)
+	sigScript[10] ^= 0xff
+	msgTx.TxIn[0].SignatureScript = sigScript
+	trace = harness.txPool.TraceAcceptTransaction(nil, util.NewTx(msgTx), false)
+	if trace.Accepted {
+		t.Fatal("TraceAcceptTransaction: tx with a broken signature accepted")
+	}
+	last := trace.Checks[len(trace.Checks)-1]
+	if last.Name != "scripts" || last.Passed {
+		t.Fatalf("TraceAcceptTransaction: unexpected last check %+v", last)
+	}
+	for _, check := range trace.Checks[:len(trace.Checks)-1] {
+		if !check.Passed {
+			t.Fatalf("TraceAcceptTransaction: check %s failed before the scripts", check.Name)
+		}
+	}
+	if trace.ScriptInput != 0 || trace.ScriptPosition == "" {
+		t.Fatalf(
+			"TraceAcceptTransaction: unexpected script failure input %d position %q",
+			trace.ScriptInput, trace.ScriptPosition,
+		)
+	}
+}
+
+// TestOrphanIntrospection ensures the orphans held are reported with the outpoints they are missing, and that the
+// orphans accepted once their parents arrive are counted.
+func TestOrphanIntrospection(t *testing.T) {
+	t.Parallel()
+	harness, spendableOuts, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	chainedTxns, e := harness.CreateTxChain(spendableOuts[0], 3)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	for _, tx := range chainedTxns[1:] {
+		if _, e = harness.txPool.ProcessTransaction(nil, tx, true, false, false, 7); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept valid orphan %v", e)
+		}
+	}
+	orphans, e := harness.txPool.Orphans()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("got %d orphans, want 2", len(orphans))
+	}
+	for _, o := range orphans {
+		if o.Tag != 7 || o.Received.IsZero() || !o.Expires.After(o.Received) {
+			t.Errorf("orphan %v: got tag %d, received %v, expires %v", o.Tx.Hash(), o.Tag, o.Received, o.Expires)
+		}
+		want := o.Tx.MsgTx().TxIn[0].PreviousOutPoint
+		if len(o.Missing) != 1 || o.Missing[0] != want {
+			t.Errorf("orphan %v: got missing outpoints %v, want %v", o.Tx.Hash(), o.Missing, want)
+		}
+	}
+	if _, e = harness.txPool.ProcessTransaction(nil, chainedTxns[0], false, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept valid transaction %v", e)
+	}
+	stats, count, limit := harness.txPool.OrphanStats()
+	if stats.Added != 2 || stats.Resolved != 2 || count != 0 || limit != harness.txPool.cfg.Policy.MaxOrphanTxs {
+		t.Errorf("got orphan stats %+v, count %d and limit %d", stats, count, limit)
+	}
+}
+
+// TestPackageFee ensures a package holds the transactions asked for after their ancestors in the pool, and that the
+// fee a child must pay covers the whole package at the target fee rate.
+func TestPackageFee(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	chain, e := harness.CreateTxChain(outputs[0], 3)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	var vsize int64
+	for _, tx := range chain {
+		if _, e = harness.txPool.ProcessTransaction(nil, tx, false, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+		vsize += GetTxVirtualSize(tx)
+	}
+	p, e := harness.txPool.PackageFee([]*chainhash.Hash{chain[2].Hash(), chain[0].Hash()})
+	if e != nil {
+		t.Fatalf("PackageFee: %v", e)
+	}
+	if len(p.Txs) != 3 || len(p.Ancestors) != 1 || *p.Ancestors[0] != *chain[1].Hash() || p.VSize != vsize {
+		t.Fatalf("PackageFee: unexpected package %+v", p)
+	}
+	for i := range chain {
+		if *p.Txs[i] != *chain[i].Hash() {
+			t.Fatalf("PackageFee: transaction %d of the package is %v, want %v", i, p.Txs[i], chain[i].Hash())
+		}
+	}
+	if fee := p.ChildFee(200, 1000); fee != p.VSize+200-p.Fee {
+		t.Fatalf("ChildFee: got %d for a package of %d bytes paying %d", fee, p.VSize, p.Fee)
+	}
+	if _, e = harness.txPool.PackageFee([]*chainhash.Hash{{}}); e == nil {
+		t.Fatal("PackageFee: no error for a transaction not in the pool")
+	}
+}
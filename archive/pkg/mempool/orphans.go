@@ -0,0 +1,79 @@
+package mempool
+
+import (
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// OrphanTxDesc describes a transaction held in the orphan pool because some of the outputs it spends are not known.
+type OrphanTxDesc struct {
+	Tx *util.Tx
+	// Tag identifies where the transaction came from, which is the ID of the peer that relayed it.
+	Tag Tag
+	// Received is when the transaction was added to the pool and Expires when it will be removed if its parents have
+	// not arrived.
+	Received time.Time
+	Expires  time.Time
+	// Missing are the outpoints spent by the transaction that are neither in the chain nor in the pool.
+	Missing []wire.OutPoint
+}
+
+// OrphanStats counts what happened to the transactions added to the orphan pool since the pool was created.
+type OrphanStats struct {
+	// Added is the number of transactions added to the orphan pool.
+	Added int64
+	// Resolved is the number of orphans accepted into the pool after their parents arrived, and Rejected the number
+	// that turned out to be invalid, along with the orphans spending their outputs.
+	Resolved int64
+	Rejected int64
+	// Expired is the number of orphans removed because their parents did not arrive in time, and Evicted the number
+	// removed to make room for newer orphans when the pool was full.
+	Expired int64
+	Evicted int64
+	// Removed is the number of orphans removed because they were mined, conflicted with a transaction accepted into
+	// the pool or the peer that sent them disconnected.
+	Removed int64
+}
+
+// Orphans returns the transactions held in the orphan pool in the order they were received, with the outpoints each
+// is missing. This function is safe for concurrent access.
+func (mp *TxPool) Orphans() (orphans []OrphanTxDesc, e error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	orphans = make([]OrphanTxDesc, 0, len(mp.orphans))
+	for _, otx := range mp.orphans {
+		desc := OrphanTxDesc{
+			Tx:       otx.tx,
+			Tag:      otx.tag,
+			Received: otx.received,
+			Expires:  otx.expiration,
+		}
+		utxoView, e := mp.fetchInputUtxos(otx.tx)
+		if e != nil {
+			return nil, e
+		}
+		for _, txIn := range otx.tx.MsgTx().TxIn {
+			if entry := utxoView.LookupEntry(txIn.PreviousOutPoint); entry == nil || entry.IsSpent() {
+				desc.Missing = append(desc.Missing, txIn.PreviousOutPoint)
+			}
+		}
+		orphans = append(orphans, desc)
+	}
+	sort.Slice(
+		orphans, func(i, j int) bool {
+			return orphans[i].Received.Before(orphans[j].Received)
+		},
+	)
+	return
+}
+
+// OrphanStats returns the counts of what happened to the transactions added to the orphan pool, the number of
+// transactions it holds and the most it can hold. This function is safe for concurrent access.
+func (mp *TxPool) OrphanStats() (stats OrphanStats, count, limit int) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	return mp.orphanStats, len(mp.orphans), mp.cfg.Policy.MaxOrphanTxs
+}
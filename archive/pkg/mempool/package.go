@@ -0,0 +1,78 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+// PackageFee is the fee and size of a package of unconfirmed transactions, a set of transactions from the pool along
+// with all of their ancestors in the pool, which a block must include together for any of them to be mined.
+type PackageFee struct {
+	// Txs are the hashes of the transactions of the package, each after the transactions it spends from.
+	Txs []*chainhash.Hash
+	// Ancestors are the hashes of the transactions that are in the package only because a transaction asked for spends
+	// from them.
+	Ancestors []*chainhash.Hash
+	// Fee is the total fee paid by the package in satoshi, not counting prioritisetransaction deltas as other miners do
+	// not see them, and VSize its total virtual size.
+	Fee, VSize int64
+}
+
+// FeeRate returns the fee paid by the package in satoshi per 1000 bytes of virtual size.
+func (p *PackageFee) FeeRate() int64 {
+	if p.VSize == 0 {
+		return 0
+	}
+	return p.Fee * 1000 / p.VSize
+}
+
+// ChildFee returns the fee in satoshi that a transaction of the given virtual size spending from the package must pay
+// for the package with it to reach the fee rate, in satoshi per 1000 bytes. It is zero when the package already pays
+// enough for the child as well.
+func (p *PackageFee) ChildFee(childSize, feeRate int64) int64 {
+	need := (feeRate*(p.VSize+childSize)+999)/1000 - p.Fee
+	if need < 0 {
+		return 0
+	}
+	return need
+}
+
+// PackageFee returns the package of the transactions with the given hashes, which are all in the pool, and their
+// ancestors in the pool. This function is safe for concurrent access.
+func (mp *TxPool) PackageFee(hashes []*chainhash.Hash) (p *PackageFee, e error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	asked := make(map[chainhash.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		if _, ok := mp.pool[*hash]; !ok {
+			return nil, fmt.Errorf("transaction %v is not in the pool", hash)
+		}
+		asked[*hash] = struct{}{}
+	}
+	p = &PackageFee{}
+	added := make(map[chainhash.Hash]struct{})
+	var add func(desc *TxDesc)
+	add = func(desc *TxDesc) {
+		hash := desc.Tx.Hash()
+		if _, ok := added[*hash]; ok {
+			return
+		}
+		added[*hash] = struct{}{}
+		for _, txIn := range desc.Tx.MsgTx().TxIn {
+			if parent, ok := mp.pool[txIn.PreviousOutPoint.Hash]; ok {
+				add(parent)
+			}
+		}
+		p.Txs = append(p.Txs, hash)
+		if _, ok := asked[*hash]; !ok {
+			p.Ancestors = append(p.Ancestors, hash)
+		}
+		p.Fee += desc.Fee
+		p.VSize += desc.VSize
+	}
+	for _, hash := range hashes {
+		add(mp.pool[*hash])
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package mempool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// In case the format of the saved pool changes, a version number is stored in front of it. A saved pool of a different
+// version is discarded rather than upgraded.
+const poolSaveVersion = 1
+
+var (
+	// PoolDatabaseKey is the key that the transactions in the pool are stored under in the database when the node shuts
+	// down, so that they can be restored when it starts again.
+	PoolDatabaseKey = []byte("mempool")
+)
+
+// Save serializes the transactions in the pool so they can be restored with RestorePool. A transaction is always
+// written after the unconfirmed transactions it spends, so they can be processed in order without becoming orphans.
+func (mp *TxPool) Save() []byte {
+	descs := mp.TxDescs()
+	sort.Slice(
+		descs, func(i, j int) bool {
+			return descs[i].Added.Before(descs[j].Added)
+		},
+	)
+	pool := make(map[chainhash.Hash]*util.Tx, len(descs))
+	for _, desc := range descs {
+		pool[*desc.Tx.Hash()] = desc.Tx
+	}
+	w := bytes.NewBuffer(make([]byte, 0))
+	e := binary.Write(w, binary.BigEndian, uint32(poolSaveVersion))
+	if e != nil {
+		F.Ln("failed to write mempool", e)
+	}
+	e = binary.Write(w, binary.BigEndian, uint32(len(descs)))
+	if e != nil {
+		F.Ln("failed to write mempool", e)
+	}
+	written := make(map[chainhash.Hash]struct{}, len(descs))
+	var write func(tx *util.Tx)
+	write = func(tx *util.Tx) {
+		if _, ok := written[*tx.Hash()]; ok {
+			return
+		}
+		written[*tx.Hash()] = struct{}{}
+		for _, txIn := range tx.MsgTx().TxIn {
+			if parent, ok := pool[txIn.PreviousOutPoint.Hash]; ok {
+				write(parent)
+			}
+		}
+		if e := tx.MsgTx().Serialize(w); E.Chk(e) {
+			F.Ln("failed to write mempool", e)
+		}
+	}
+	for _, desc := range descs {
+		write(desc.Tx)
+	}
+	return w.Bytes()
+}
+
+// RestorePool deserializes the transactions written by Save, in the order they should be processed to add them back to
+// a pool.
+func RestorePool(data []byte) (txs []*util.Tx, e error) {
+	r := bytes.NewReader(data)
+	var version uint32
+	if e = binary.Read(r, binary.BigEndian, &version); E.Chk(e) {
+		return nil, e
+	}
+	if version != poolSaveVersion {
+		return nil, fmt.Errorf(
+			"incorrect version: expected %d found %d",
+			poolSaveVersion, version,
+		)
+	}
+	var count uint32
+	if e = binary.Read(r, binary.BigEndian, &count); E.Chk(e) {
+		return nil, e
+	}
+	txs = make([]*util.Tx, 0, count)
+	for i := uint32(0); i < count; i++ {
+		msgTx := &wire.MsgTx{}
+		if e = msgTx.Deserialize(r); E.Chk(e) {
+			return nil, fmt.Errorf("failed to read transaction %d of %d: %v", i, count, e)
+		}
+		txs = append(txs, util.NewTx(msgTx))
+	}
+	return txs, nil
+}
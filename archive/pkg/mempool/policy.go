@@ -362,3 +362,15 @@ func GetTxVirtualSize(tx *util.Tx) int64 {
 	return (blockchain.GetTransactionWeight(tx) + (blockchain.WitnessScaleFactor - 1)) /
 		blockchain.WitnessScaleFactor
 }
+
+// GetSigOpAdjustedVirtualSize computes the virtual size of a transaction with the given signature operation cost, taking
+// each signature operation for bytesPerSigOp virtual bytes where that is more than its weight. Fee rates are compared
+// with this size so that a transaction can't fill the signature operations of a block for the fee of its bytes.
+func GetSigOpAdjustedVirtualSize(tx *util.Tx, sigOpCost, bytesPerSigOp int) int64 {
+	weight := blockchain.GetTransactionWeight(tx)
+	// The cost of a signature operation is already scaled by the witness scale factor, like the weight.
+	if sigOpWeight := int64(sigOpCost) * int64(bytesPerSigOp); sigOpWeight > weight {
+		weight = sigOpWeight
+	}
+	return (weight + (blockchain.WitnessScaleFactor - 1)) / blockchain.WitnessScaleFactor
+}
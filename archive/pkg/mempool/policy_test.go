@@ -94,6 +94,33 @@ func TestCalcMinRequiredTxRelayFee(t *testing.T) {
 	}
 }
 
+// TestGetSigOpAdjustedVirtualSize tests the GetSigOpAdjustedVirtualSize API.
+func TestGetSigOpAdjustedVirtualSize(t *testing.T) {
+	msgTx := wire.NewMsgTx(1)
+	msgTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, []byte{txscript.OP_TRUE}, nil))
+	msgTx.AddTxOut(wire.NewTxOut(1, []byte{txscript.OP_TRUE}))
+	tx := util.NewTx(msgTx)
+	vSize := GetTxVirtualSize(tx)
+	tests := []struct {
+		name          string // test description.
+		sigOpCost     int    // signature operation cost of the transaction.
+		bytesPerSigOp int    // virtual bytes per signature operation.
+		want          int64  // Expected virtual size.
+	}{
+		{"no signature operations", 0, 20, vSize},
+		{"signature operations counted for nothing", 1000, 0, vSize},
+		{"signature operations under the weight", 4, 20, vSize},
+		{"signature operations over the weight", 400, 20, 2000},
+		{"rounded up to a whole virtual byte", 401, 5, 502},
+	}
+	for _, test := range tests {
+		got := GetSigOpAdjustedVirtualSize(tx, test.sigOpCost, test.bytesPerSigOp)
+		if got != test.want {
+			t.Errorf("TestGetSigOpAdjustedVirtualSize test '%s' failed: got %v want %v", test.name, got, test.want)
+		}
+	}
+}
+
 // TestCheckPkScriptStandard tests the checkPkScriptStandard API.
 func TestCheckPkScriptStandard(t *testing.T) {
 	var pubKeys [][]byte
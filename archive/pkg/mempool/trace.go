@@ -0,0 +1,154 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// AcceptCheck is the outcome of one of the policy checks run when a transaction is offered to the pool.
+type AcceptCheck struct {
+	// Name is the short name of the check, such as minrelayfee or scripts.
+	Name string
+	// Passed is true when the transaction satisfied the check.
+	Passed bool
+	// Details holds the values the check compared when it passed, or the rejection reason when it failed.
+	Details string
+	// RejectCode is the code that would be sent to the peer that relayed the transaction, only set when it failed.
+	RejectCode wire.RejectCode
+}
+
+// AcceptTrace records, in order, the checks run by TraceAcceptTransaction and the values they were computed from.
+type AcceptTrace struct {
+	// Checks are the checks that were run, in order. Checking stops at the first failure, so only the last one can have
+	// failed.
+	Checks []AcceptCheck
+	// Accepted is true if the transaction passed every check and would have been added to the pool.
+	Accepted bool
+	// MissingParents lists the transactions an orphan spends from that are not in the chain or the pool.
+	MissingParents []*chainhash.Hash
+	// Fee is the fee paid by the transaction and ModifiedFee includes any prioritisetransaction delta.
+	Fee, ModifiedFee int64
+	// MinFee is the fee required for the transaction to be relayed.
+	MinFee int64
+	// Size is the virtual size of the transaction used for the fee calculation.
+	Size int64
+	// ScriptInput is the index of the input whose script failed to verify, or -1.
+	ScriptInput int
+	// ScriptPosition is the disassembly of the opcode the script failed at, prefixed with the index of the script and
+	// the offset of the opcode in it.
+	ScriptPosition string
+}
+
+// ScriptTraceError is returned when stepping through the scripts of a transaction one opcode at a time fails.
+type ScriptTraceError struct {
+	Input    int    // The index of the input that failed
+	Position string // The disassembly of the opcode that failed, empty if the script could not be started
+	Err      error  // The error returned by the script engine
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e ScriptTraceError) Error() string {
+	if e.Position == "" {
+		return fmt.Sprintf("input %d: %v", e.Input, e.Err)
+	}
+	return fmt.Sprintf("input %d at %s: %v", e.Input, e.Position, e.Err)
+}
+
+// TraceAcceptTransaction runs the same checks as MaybeAcceptTransaction against the passed transaction and returns
+// the ordered list of checks with their outcome. The pool, the orphan pool and the free transaction rate limiter are
+// not changed. This function is safe for concurrent access.
+func (mp *TxPool) TraceAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx, allowHighFees bool) *AcceptTrace {
+	trace := &AcceptTrace{ScriptInput: -1}
+	// The write lock is needed as the checks share the state of the rate limiter, even though a trace leaves it alone.
+	mp.mtx.Lock()
+	_, _, _ = mp.maybeAcceptTransaction(b, tx, true, true, true, allowHighFees, trace)
+	mp.mtx.Unlock()
+	return trace
+}
+
+// pass records a check the transaction satisfied. It does nothing on a nil trace.
+func (t *AcceptTrace) pass(name, details string) {
+	if t == nil {
+		return
+	}
+	t.Checks = append(t.Checks, AcceptCheck{Name: name, Passed: true, Details: details})
+}
+
+// fail records a check the transaction was rejected by and returns the error so it can be handed straight back to the
+// caller. It does nothing but return the error on a nil trace.
+func (t *AcceptTrace) fail(name string, e error) error {
+	if t == nil {
+		return e
+	}
+	check := AcceptCheck{Name: name, Details: e.Error()}
+	check.RejectCode, _ = extractRejectCode(e)
+	t.Checks = append(t.Checks, check)
+	if se, ok := e.(ScriptTraceError); ok {
+		t.ScriptInput = se.Input
+		t.ScriptPosition = se.Position
+	}
+	return e
+}
+
+// orphan records the parents that are missing for the transaction to be accepted. It does nothing on a nil trace.
+func (t *AcceptTrace) orphan(missingParents []*chainhash.Hash) {
+	if t == nil {
+		return
+	}
+	t.MissingParents = missingParents
+	t.Checks = append(
+		t.Checks, AcceptCheck{
+			Name:       "orphan",
+			Details:    fmt.Sprintf("%d referenced transactions are not known", len(missingParents)),
+			RejectCode: wire.RejectDuplicate,
+		},
+	)
+}
+
+// fees records the values the fee checks are computed from. It does nothing on a nil trace.
+func (t *AcceptTrace) fees(fee, modifiedFee, minFee, size int64) {
+	if t == nil {
+		return
+	}
+	t.Fee, t.ModifiedFee, t.MinFee, t.Size = fee, modifiedFee, minFee, size
+}
+
+// traceTransactionScripts verifies the scripts of each input of the transaction in turn, stepping through them one
+// opcode at a time, and returns a ScriptTraceError pointing at the first opcode that fails.
+func traceTransactionScripts(tx *util.Tx, utxoView *blockchain.UtxoViewpoint, sigCache *txscript.SigCache) error {
+	for i, txIn := range tx.MsgTx().TxIn {
+		utxo := utxoView.LookupEntry(txIn.PreviousOutPoint)
+		if utxo == nil {
+			return ScriptTraceError{
+				Input: i,
+				Err:   fmt.Errorf("unable to find unspent output %v", txIn.PreviousOutPoint),
+			}
+		}
+		vm, e := txscript.NewEngine(
+			utxo.PkScript(), tx.MsgTx(), i,
+			txscript.StandardVerifyFlags, sigCache, nil, utxo.Amount(),
+		)
+		if e != nil {
+			return ScriptTraceError{Input: i, Err: e}
+		}
+		var pc string
+		for done := false; !done; {
+			// Once the last opcode is executed there is no next one to disassemble, so keep the last position.
+			if next, e := vm.DisasmPC(); e == nil {
+				pc = next
+			}
+			if done, e = vm.Step(); e != nil {
+				return ScriptTraceError{Input: i, Position: pc, Err: e}
+			}
+		}
+		if e = vm.CheckErrorCondition(true); e != nil {
+			return ScriptTraceError{Input: i, Position: pc, Err: e}
+		}
+	}
+	return nil
+}
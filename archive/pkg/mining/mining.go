@@ -43,8 +43,15 @@ type (
 		Height int32
 		// Fee is the total fee the transaction associated with the entry pays.
 		Fee int64
-		// FeePerKB is the fee the transaction pays in Satoshi per 1000 bytes.
+		// FeePerKB is the fee the transaction pays in Satoshi per 1000 bytes of VSize.
 		FeePerKB int64
+		// VSize is the virtual size of the transaction, counting its signature
+		// operations for more bytes where they cost more than its weight.
+		VSize int64
+		// FeeDelta is added to the fee of the transaction when it is ranked for
+		// inclusion in a block, as set by prioritisetransaction. It is not part of the
+		// fees collected by the block.
+		FeeDelta int64
 	}
 	// TxSource represents a source of transactions to consider for inclusion in new
 	// blocks. The interface contract requires that all of these methods are safe
@@ -122,6 +129,9 @@ type (
 		TimeSource  blockchain.MedianTimeSource
 		SigCache    *txscript.SigCache
 		HashCache   *txscript.HashCache
+		// SignBlock signs the templates with the key of a block producer on a signed test network, and is called again
+		// whenever their coinbase changes. It is nil when the templates are not signed.
+		SignBlock func(msgBlock *wire.Block) error
 	}
 )
 
@@ -581,8 +591,17 @@ mempoolLoop:
 			tx.MsgTx(), utxos,
 			nextBlockHeight,
 		)
-		// Calculate the fee in Satoshi/kB.
+		// Calculate the fee in Satoshi/kB, ranking the transaction by its fee with any
+		// delta it was prioritised by.
 		prioItem.feePerKB = txDesc.FeePerKB
+		if txDesc.FeeDelta != 0 {
+			virtualSize := txDesc.VSize
+			if virtualSize == 0 {
+				virtualSize = (blockchain.GetTransactionWeight(tx) + blockchain.WitnessScaleFactor - 1) /
+					blockchain.WitnessScaleFactor
+			}
+			prioItem.feePerKB += txDesc.FeeDelta * 1000 / virtualSize
+		}
 		prioItem.fee = txDesc.Fee
 		// Add the transaction to the priority queue to mark it ready for inclusion in
 		// the block unless it has dependencies.
@@ -841,7 +860,8 @@ mempoolLoop:
 	if fork.GetCurrent(nextBlockHeight) > 0 {
 		ots := g.Chain.BestChain.NodeByHeight(best.Height).Header().Timestamp.Truncate(time.Second).Add(time.Second)
 		D.Ln("prev timestamp+1", ots)
-		tn := time.Now().Truncate(time.Second)
+		// the network adjusted time is used as it is what peers check the timestamp is not too far in the future with
+		tn := g.TimeSource.AdjustedTime()
 		if tn.After(ots) {
 			ts = tn
 		} else {
@@ -870,6 +890,11 @@ mempoolLoop:
 			return nil, e
 		}
 	}
+	if g.SignBlock != nil {
+		if e = g.SignBlock(&msgBlock); E.Chk(e) {
+			return nil, e
+		}
+	}
 	// Finally, perform a full check on the created block against the chain
 	// consensus rules to ensure it properly connects to the current best chain with
 	// no issues.
@@ -973,6 +998,9 @@ func (g *BlkTmplGenerator) UpdateExtraNonce(
 	block := block2.NewBlock(msgBlock)
 	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
 	msgBlock.Header.MerkleRoot = *merkles.GetRoot()
+	if g.SignBlock != nil {
+		return g.SignBlock(msgBlock)
+	}
 	return nil
 }
 
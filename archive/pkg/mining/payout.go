@@ -0,0 +1,108 @@
+package mining
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+const (
+	// RotationRandom pays each block to an address picked at random from the payout addresses.
+	RotationRandom = "random"
+	// RotationRoundRobin pays each block to the payout address after the one the block before it was paid to.
+	RotationRoundRobin = "roundrobin"
+)
+
+// PayoutRotations are the policies for picking the payout address of each block.
+var PayoutRotations = []string{RotationRandom, RotationRoundRobin}
+
+// PayoutAddrs is the list of addresses the coinbase of mined blocks pays to, and the policy for rotating between them
+// so a miner does not reuse a single address for every block. The address is picked once per block, so templates for
+// the same block pay to the same address as they are regenerated. The zero value has no addresses and rotates at
+// random, and it is safe for concurrent access, so the list can be changed while mining.
+type PayoutAddrs struct {
+	mtx      sync.Mutex
+	addrs    []btcaddr.Address
+	rotation string
+	// next is the index of the address the next block is paid to with round robin rotation.
+	next int
+	// prevBlock is the block that the current address was picked for a block on top of.
+	prevBlock chainhash.Hash
+	current   btcaddr.Address
+}
+
+// Set replaces the payout addresses and the rotation policy, which must be one of PayoutRotations, or empty for random.
+func (p *PayoutAddrs) Set(addrs []btcaddr.Address, rotation string) (e error) {
+	if rotation, e = parseRotation(rotation); E.Chk(e) {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.addrs = append([]btcaddr.Address(nil), addrs...)
+	p.rotation = rotation
+	p.next = 0
+	p.current = nil
+	return
+}
+
+// Get returns the payout addresses and the rotation policy.
+func (p *PayoutAddrs) Get() (addrs []btcaddr.Address, rotation string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	rotation = p.rotation
+	if rotation == "" {
+		rotation = RotationRandom
+	}
+	return append([]btcaddr.Address(nil), p.addrs...), rotation
+}
+
+// Len returns the number of payout addresses.
+func (p *PayoutAddrs) Len() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return len(p.addrs)
+}
+
+// ForBlock returns the address to pay the coinbase of a block building on prevBlock to, which is the same for every
+// call with the same prevBlock until the next block arrives. It returns nil if there are no payout addresses.
+func (p *PayoutAddrs) ForBlock(prevBlock *chainhash.Hash) btcaddr.Address {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if len(p.addrs) == 0 {
+		return nil
+	}
+	if p.current != nil && prevBlock != nil && p.prevBlock.IsEqual(prevBlock) {
+		return p.current
+	}
+	switch p.rotation {
+	case RotationRoundRobin:
+		p.current = p.addrs[p.next%len(p.addrs)]
+		p.next = (p.next + 1) % len(p.addrs)
+	default:
+		p.current = p.addrs[rand.Intn(len(p.addrs))]
+	}
+	if prevBlock != nil {
+		p.prevBlock = *prevBlock
+	}
+	return p.current
+}
+
+// parseRotation returns the name of a rotation policy in the case used in PayoutRotations, or an error if it is not
+// one of them.
+func parseRotation(rotation string) (string, error) {
+	if rotation == "" {
+		return RotationRandom, nil
+	}
+	for _, r := range PayoutRotations {
+		if strings.EqualFold(rotation, r) {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"unknown payout address rotation %q, must be one of %s", rotation, strings.Join(PayoutRotations, ", "),
+	)
+}
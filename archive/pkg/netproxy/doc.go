@@ -0,0 +1,12 @@
+/*
+Package netproxy provides the dialers used to make outbound connections through a proxy server, for both peer to peer
+and RPC client traffic.
+
+A proxy is either a SOCKS 5 proxy, such as Tor, or an HTTP proxy that opens a tunnel to the destination with the
+CONNECT method, as found on corporate networks where nothing else can get out. HTTP proxies may be connected to over
+TLS, and both kinds take an optional username and password, which HTTP proxies are sent with basic authentication.
+
+Each kind of proxy has the dial functions of the SOCKS proxy the node has always used, so any of them can be set as
+the dial function of the node or given to an RPC client.
+*/
+package netproxy
@@ -0,0 +1,127 @@
+package netproxy
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/go-socks/socks"
+)
+
+// connectProxy runs an HTTP proxy that tunnels CONNECT requests carrying the credentials and refuses all others.
+func connectProxy(t *testing.T, user, pass string) net.Listener {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	login := "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+	go func() {
+		for {
+			conn, e := l.Accept()
+			if e != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, e := http.ReadRequest(bufio.NewReader(conn))
+				if e != nil {
+					return
+				}
+				if req.Method != http.MethodConnect || req.Header.Get("Proxy-Authorization") != login {
+					_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					return
+				}
+				dest, e := net.Dial("tcp", req.Host)
+				if e != nil {
+					_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+					return
+				}
+				defer dest.Close()
+				_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				go func() { _, _ = io.Copy(dest, conn) }()
+				_, _ = io.Copy(conn, dest)
+			}()
+		}
+	}()
+	return l
+}
+
+// TestHTTPProxy ensures connections are tunnelled through an HTTP proxy with the credentials it was given, and that
+// a proxy refusing them is reported.
+func TestHTTPProxy(t *testing.T) {
+	echo, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer echo.Close()
+	go func() {
+		for {
+			conn, e := echo.Accept()
+			if e != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	proxy := connectProxy(t, "user", "secret")
+	defer proxy.Close()
+	d, e := Config{Addr: "http://user:secret@" + proxy.Addr().String()}.Dialer()
+	if e != nil {
+		t.Fatal(e)
+	}
+	conn, e := d.DialTimeout("tcp", echo.Addr().String(), time.Second*5)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer conn.Close()
+	if _, e = io.WriteString(conn, "ping"); e != nil {
+		t.Fatal(e)
+	}
+	b := make([]byte, 4)
+	if _, e = io.ReadFull(conn, b); e != nil || string(b) != "ping" {
+		t.Errorf("got %q, %v through the proxy, want ping", b, e)
+	}
+	if d, e = (Config{Kind: HTTP, Addr: proxy.Addr().String(), User: "user", Pass: "wrong"}).Dialer(); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = d.Dial("tcp", echo.Addr().String()); e != ErrProxyAuth {
+		t.Errorf("got error %v with the wrong password, want %v", e, ErrProxyAuth)
+	}
+	if _, e = d.Dial("udp", echo.Addr().String()); e == nil {
+		t.Error("expected an error dialling UDP through an HTTP proxy")
+	}
+}
+
+// TestConfig ensures the kind of proxy is taken from the configuration or the scheme of its address.
+func TestConfig(t *testing.T) {
+	d, e := Config{Addr: "127.0.0.1:9050", TorIsolation: true}.Dialer()
+	if p, ok := d.(*socks.Proxy); e != nil || !ok || !p.TorIsolation {
+		t.Errorf("got dialer %#v, %v for a SOCKS proxy", d, e)
+	}
+	d, e = Config{Kind: HTTPS, Addr: "proxy.example.com:3128"}.Dialer()
+	if p, ok := d.(*HTTPProxy); e != nil || !ok || p.TLS == nil {
+		t.Errorf("got dialer %#v, %v for an HTTPS proxy", d, e)
+	}
+	c := Config{Kind: SOCKS5, Addr: "http://proxy.example.com:8080", User: "user", Pass: "pass"}
+	if d, e = c.Dialer(); e != nil {
+		t.Fatal(e)
+	}
+	if p, ok := d.(*HTTPProxy); !ok || p.TLS != nil || p.Addr != "proxy.example.com:8080" || p.Username != "user" {
+		t.Errorf("got dialer %#v for an HTTP proxy URL", d)
+	}
+	if c.IsSOCKS() {
+		t.Error("an HTTP proxy was taken for a SOCKS proxy")
+	}
+	for _, bad := range []Config{{Addr: "proxy.example.com"}, {Kind: "socks4", Addr: "127.0.0.1:1080"}} {
+		if _, e = bad.Dialer(); e == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package netsync
+
+import (
+	"github.com/p9c/pod/pkg/blockchain"
+	peerpkg "github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// requestAssumeValidHeaders asks the peer for the headers from the best chain up to the assumed valid block, so the
+// blocks before it can be connected without verifying their scripts, unless the chain does not need them or they are
+// already being fetched from a peer. They are fetched alongside the blocks, and the blocks connected before they
+// arrive are verified in full.
+func (sm *SyncManager) requestAssumeValidHeaders(peer *peerpkg.Peer) {
+	if sm.assumeValidPeer != nil || !sm.chain.NeedAssumeValidHeaders() {
+		return
+	}
+	hash, _ := sm.chain.AssumeValid()
+	if e := peer.PushGetHeadersMsg(sm.chain.CheckpointBlockLocator(), hash); E.Chk(e) {
+		return
+	}
+	sm.assumeValidPeer = peer
+	I.Ln("downloading the headers up to assumed valid block", hash, "from peer", peer.Addr())
+}
+
+// handleAssumeValidHeaders adds the headers sent by the peer asked for the headers leading to the assumed valid block
+// to the chain, and asks for more until they reach it. When the peer does not have the block, its headers do not link
+// up or it goes away, the headers are asked of the next sync peer.
+func (sm *SyncManager) handleAssumeValidHeaders(peer *peerpkg.Peer, headers []*wire.BlockHeader) {
+	hash, _ := sm.chain.AssumeValid()
+	done, e := sm.chain.AddAssumeValidHeaders(headers)
+	switch {
+	case e != nil:
+		W.Ln("headers from", peer, "do not lead to the assumed valid block:", e)
+	case done:
+	case len(headers) < wire.MaxBlockHeadersPerMsg:
+		W.Ln("peer", peer, "does not have assumed valid block", hash)
+	default:
+		last := headers[len(headers)-1].BlockHash()
+		if e = peer.PushGetHeadersMsg(blockchain.BlockLocator{&last}, hash); !E.Chk(e) {
+			return
+		}
+	}
+	sm.assumeValidPeer = nil
+}
@@ -0,0 +1,132 @@
+package netsync
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	peerpkg "github.com/p9c/pod/pkg/peer"
+)
+
+const (
+	// maxPendingBlockPropagations is the maximum number of announced blocks not yet connected to the chain whose
+	// propagation is tracked. Announcements of further blocks are not tracked until some of them are connected or
+	// given up on.
+	maxPendingBlockPropagations = 64
+	// blockPropagationTimeout is how long an announced block may take to be connected to the chain before it is no
+	// longer tracked.
+	blockPropagationTimeout = 10 * time.Minute
+	// maxBlockPropagations is the number of the most recently connected blocks whose propagation is kept.
+	maxBlockPropagations = 100
+)
+
+// BlockPropagation records how a block announced by peers reached the node.
+type BlockPropagation struct {
+	Hash   chainhash.Hash
+	Height int32
+	// Peer is the address of the peer that announced the block first.
+	Peer string
+	// Announcements is the number of peers that announced the block before it finished downloading.
+	Announcements int
+	// Announced is when the block was first announced, Received when it finished downloading and Validated when it
+	// was connected to the chain.
+	Announced time.Time
+	Received  time.Time
+	Validated time.Time
+}
+
+// pendingBlockPropagation is the propagation of a block that has been announced but not yet connected to the chain.
+type pendingBlockPropagation struct {
+	BlockPropagation
+	// peers are the peers that announced the block, until it finishes downloading.
+	peers map[*peerpkg.Peer]struct{}
+}
+
+// blockPropagationTracker records the time from the first announcement of each block to its arrival and to it being
+// connected to the chain, and keeps the propagation of the most recently connected blocks. It must only be used from
+// the blockHandler thread.
+type blockPropagationTracker struct {
+	pending map[chainhash.Hash]*pendingBlockPropagation
+	// recent is a ring of the most recently connected blocks, next is where the next one is stored.
+	recent []BlockPropagation
+	next   int
+}
+
+// newBlockPropagationTracker returns an empty blockPropagationTracker.
+func newBlockPropagationTracker() *blockPropagationTracker {
+	return &blockPropagationTracker{
+		pending: make(map[chainhash.Hash]*pendingBlockPropagation),
+	}
+}
+
+// announce records that the peer announced the block at the time now. Announcements after the block finished
+// downloading are not counted.
+func (t *blockPropagationTracker) announce(hash chainhash.Hash, peer *peerpkg.Peer, now time.Time) {
+	p, exists := t.pending[hash]
+	if !exists {
+		if len(t.pending) >= maxPendingBlockPropagations {
+			t.expire(now)
+			if len(t.pending) >= maxPendingBlockPropagations {
+				return
+			}
+		}
+		p = &pendingBlockPropagation{
+			BlockPropagation: BlockPropagation{Hash: hash, Peer: peer.Addr(), Announced: now},
+			peers:            make(map[*peerpkg.Peer]struct{}),
+		}
+		t.pending[hash] = p
+	}
+	if p.peers == nil {
+		return
+	}
+	if _, announced := p.peers[peer]; !announced {
+		p.peers[peer] = struct{}{}
+		p.Announcements++
+	}
+}
+
+// received records that the block finished downloading at the time now.
+func (t *blockPropagationTracker) received(hash chainhash.Hash, now time.Time) {
+	if p, exists := t.pending[hash]; exists && p.peers != nil {
+		p.Received = now
+		p.peers = nil
+	}
+}
+
+// validated records that the block was connected to the chain at the given height at the time now and moves it to
+// the recently connected blocks.
+func (t *blockPropagationTracker) validated(hash chainhash.Hash, height int32, now time.Time) {
+	p, exists := t.pending[hash]
+	if !exists {
+		return
+	}
+	delete(t.pending, hash)
+	p.Height = height
+	p.Validated = now
+	if len(t.recent) < maxBlockPropagations {
+		t.recent = append(t.recent, p.BlockPropagation)
+		return
+	}
+	t.recent[t.next] = p.BlockPropagation
+	t.next = (t.next + 1) % maxBlockPropagations
+}
+
+// forget stops tracking the block, used when it was rejected or is an orphan.
+func (t *blockPropagationTracker) forget(hash chainhash.Hash) {
+	delete(t.pending, hash)
+}
+
+// expire stops tracking blocks announced longer than blockPropagationTimeout before now.
+func (t *blockPropagationTracker) expire(now time.Time) {
+	for hash, p := range t.pending {
+		if now.Sub(p.Announced) > blockPropagationTimeout {
+			delete(t.pending, hash)
+		}
+	}
+}
+
+// blocks returns the propagation of the most recently connected blocks, oldest first.
+func (t *blockPropagationTracker) blocks() []BlockPropagation {
+	blocks := make([]BlockPropagation, 0, len(t.recent))
+	blocks = append(blocks, t.recent[t.next:]...)
+	return append(blocks, t.recent[:t.next]...)
+}
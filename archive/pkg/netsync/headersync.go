@@ -0,0 +1,163 @@
+package netsync
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/blockchain"
+	"github.com/p9c/pod/pkg/chainhash"
+	peerpkg "github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+const (
+	// headerStallTimeout is how long the sync peer may take to answer a getheaders request before another peer is used
+	// to download the headers.
+	headerStallTimeout = 30 * time.Second
+	// headerStallCheckInterval is how often the sync manager checks whether the sync peer has stalled while sending
+	// headers.
+	headerStallCheckInterval = 5 * time.Second
+	// minHeaderRate is the throughput in bytes per second of serialized headers below which a batch of headers is
+	// slow. It is a hundred headers a second, so a full batch takes 20 seconds.
+	minHeaderRate = 100 * wire.MaxBlockHeaderPayload
+	// headerRateCollapse is the fraction of the smoothed header throughput of a peer below which a batch is slow even
+	// if it is above minHeaderRate.
+	headerRateCollapse = 0.125
+	// headerRateWeight is the weight of the latest batch in the smoothed header throughput of a peer.
+	headerRateWeight = 0.3
+	// maxSlowHeaderBatches is the number of slow batches of headers in a row after which another sync peer is used.
+	maxSlowHeaderBatches = 3
+	// slowPeerPenalty is how long a peer that was replaced as sync peer for serving headers slowly is passed over when
+	// a sync peer is chosen, unless no other peer is a candidate.
+	slowPeerPenalty = 5 * time.Minute
+)
+
+// pushGetHeaders sends a getheaders message to the peer and records when it was sent, so the throughput of the
+// headers sent in reply can be measured.
+func (sm *SyncManager) pushGetHeaders(
+	peer *peerpkg.Peer, locator blockchain.BlockLocator, stopHash *chainhash.Hash,
+) (e error) {
+	if e = peer.PushGetHeadersMsg(locator, stopHash); e != nil {
+		return
+	}
+	if state, exists := sm.peerStates[peer]; exists {
+		state.headersRequested = time.Now()
+	}
+	return
+}
+
+// headerLocator returns a block locator for the latest header in the header list. Its entries step back exponentially
+// through the list, and it ends with the block locator of the chain anchored at checkpoints, so a peer that does not
+// have the latest headers still finds the point where its chain joins the list.
+func (sm *SyncManager) headerLocator() blockchain.BlockLocator {
+	var locator blockchain.BlockLocator
+	step, skip := 1, 0
+	for el := sm.headerList.Back(); el != nil && el != sm.headerList.Front(); el = el.Prev() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		locator = append(locator, el.Value.(*headerNode).hash)
+		// Once 11 entries have been included, start doubling the distance between included hashes.
+		if len(locator) > 10 {
+			step *= 2
+		}
+		skip = step - 1
+	}
+	return append(locator, sm.chain.CheckpointBlockLocator()...)
+}
+
+// rewindHeaderList removes the headers after the header with the passed hash from the header list so headers that
+// build on it can be added. It returns false if the header is not in the list.
+func (sm *SyncManager) rewindHeaderList(hash *chainhash.Hash) bool {
+	el := sm.headerList.Back()
+	for ; el != nil; el = el.Prev() {
+		if el.Value.(*headerNode).hash.IsEqual(hash) {
+			break
+		}
+	}
+	if el == nil {
+		return false
+	}
+	for next := el.Next(); next != nil; next = el.Next() {
+		if next == sm.startHeader {
+			sm.startHeader = nil
+		}
+		sm.headerList.Remove(next)
+	}
+	return true
+}
+
+// recordHeaderBatch updates the header throughput of the sync peer with a batch of headers it sent in reply to a
+// getheaders request. It returns true when the throughput has collapsed and another peer should be used.
+func (sm *SyncManager) recordHeaderBatch(state *peerSyncState, numHeaders int) bool {
+	if state.headersRequested.IsZero() {
+		return false
+	}
+	elapsed := time.Since(state.headersRequested).Seconds()
+	state.headersRequested = time.Time{}
+	// A batch short of the most headers a message can hold is the last one before the stop hash, and is as fast as the
+	// round trip allows rather than as the peer can serve headers, so it is not counted.
+	if numHeaders < wire.MaxBlockHeadersPerMsg || elapsed <= 0 {
+		return false
+	}
+	rate := float64(numHeaders*wire.MaxBlockHeaderPayload) / elapsed
+	slow := rate < minHeaderRate || (state.headerRate > 0 && rate < state.headerRate*headerRateCollapse)
+	if state.headerRate == 0 {
+		state.headerRate = rate
+	} else {
+		state.headerRate += headerRateWeight * (rate - state.headerRate)
+	}
+	if !slow {
+		state.slowHeaderBatches = 0
+		return false
+	}
+	state.slowHeaderBatches++
+	D.F("slow batch of %d headers at %.0f bytes/s, %d in a row", numHeaders, rate, state.slowHeaderBatches)
+	return state.slowHeaderBatches >= maxSlowHeaderBatches
+}
+
+// checkHeaderStall replaces the sync peer if it has not answered a getheaders request within headerStallTimeout.
+func (sm *SyncManager) checkHeaderStall() {
+	if sm.syncPeer == nil || !sm.headersFirstMode {
+		return
+	}
+	state, exists := sm.peerStates[sm.syncPeer]
+	if !exists || state.headersRequested.IsZero() || time.Since(state.headersRequested) < headerStallTimeout {
+		return
+	}
+	sm.replaceSyncPeer("stalled sending headers")
+}
+
+// replaceSyncPeer stops syncing from the sync peer and starts syncing from another candidate, passing over the current
+// sync peer for slowPeerPenalty. Nothing is done when there is no other candidate to sync from.
+func (sm *SyncManager) replaceSyncPeer(reason string) {
+	peer := sm.syncPeer
+	state := sm.peerStates[peer]
+	others := false
+	for p, s := range sm.peerStates {
+		if p != peer && s.syncCandidate && time.Now().After(s.slowUntil) {
+			others = true
+			break
+		}
+	}
+	if !others {
+		T.Ln("sync peer", peer, reason, "but there is no other peer to sync from")
+		state.headersRequested = time.Time{}
+		state.slowHeaderBatches = 0
+		return
+	}
+	I.Ln("sync peer", peer, reason, "-- switching to another peer")
+	state.slowUntil = time.Now().Add(slowPeerPenalty)
+	state.headersRequested = time.Time{}
+	state.slowHeaderBatches = 0
+	state.headerRate = 0
+	for blockHash := range state.requestedBlocks {
+		delete(sm.requestedBlocks, blockHash)
+		delete(state.requestedBlocks, blockHash)
+	}
+	peer.SetZeroCopyBlocks(false)
+	sm.syncPeer = nil
+	best := sm.chain.BestSnapshot()
+	sm.resetHeaderState(&best.Hash, best.Height)
+	sm.startSync()
+}
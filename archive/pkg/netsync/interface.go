@@ -17,6 +17,8 @@ type PeerNotifier interface {
 	UpdatePeerHeights(latestBlkHash *chainhash.Hash, latestHeight int32, updateSource *peer.Peer)
 	RelayInventory(invVect *wire.InvVect, data interface{})
 	TransactionConfirmed(tx *util.Tx)
+	TransactionExpired(tx *util.Tx)
+	AddBanScore(p *peer.Peer, persistent, transient uint32, reason string)
 }
 
 // Config is a configuration struct used to initialize a new SyncManager.
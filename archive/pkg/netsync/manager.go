@@ -41,7 +41,9 @@ type (
 		quit           qu.C
 		// These fields should only be accessed from the blockHandler thread
 		rejectedTxns    map[chainhash.Hash]struct{}
-		requestedTxns   map[chainhash.Hash]struct{}
+		txRequests      *txRequestManager
+		propagation     *blockPropagationTracker
+		orphanRequests  *orphanRequestManager
 		requestedBlocks map[chainhash.Hash]struct{}
 		syncPeer        *peerpkg.Peer
 		peerStates      map[*peerpkg.Peer]*peerSyncState
@@ -50,6 +52,8 @@ type (
 		headerList       *list.List
 		startHeader      *list.Element
 		nextCheckpoint   *chaincfg.Checkpoint
+		// assumeValidPeer is the peer the headers leading to the assumed valid block are being fetched from.
+		assumeValidPeer *peerpkg.Peer
 		// An optional fee estimator.
 		feeEstimator *mempool.FeeEstimator
 	}
@@ -69,6 +73,16 @@ type (
 	getSyncPeerMsg struct {
 		reply chan int32
 	}
+	// getBlockPropagationMsg is a message type to be sent across the message
+	// channel for retrieving the propagation of recently connected blocks.
+	getBlockPropagationMsg struct {
+		reply chan []BlockPropagation
+	}
+	// getOrphanRequestsMsg is a message type to be sent across the message
+	// channel for retrieving the requests for the parents of orphan blocks.
+	getOrphanRequestsMsg struct {
+		reply chan OrphanRequests
+	}
 	// headerNode is used as a node in a list of headers that are linked together
 	// between checkpoints.
 	headerNode struct {
@@ -93,6 +107,12 @@ type (
 	isCurrentMsg struct {
 		reply chan bool
 	}
+	// notFoundMsg packages a bitcoin notfound message and the peer it came from
+	// together so the block handler has access to that information.
+	notFoundMsg struct {
+		notFound *wire.MsgNotFound
+		peer     *peerpkg.Peer
+	}
 	// newPeerMsg signifies a newly connected peer to the block handler.
 	newPeerMsg struct {
 		peer *peerpkg.Peer
@@ -108,8 +128,12 @@ type (
 	peerSyncState struct {
 		syncCandidate   bool
 		requestQueue    []*wire.InvVect
-		requestedTxns   map[chainhash.Hash]struct{}
 		requestedBlocks map[chainhash.Hash]struct{}
+		// The following fields track how fast the peer serves headers in headers-first mode.
+		headersRequested  time.Time
+		headerRate        float64
+		slowHeaderBatches int
+		slowUntil         time.Time
 	}
 	// processBlockMsg is a message type to be sent across the message channel for
 	// requested a block is processed. Note this call differs from blockMsg above in
@@ -145,9 +169,6 @@ const (
 	// maxRequestedBlocks is the maximum number of requested block hashes to store
 	// in memory.
 	maxRequestedBlocks = wire.MaxInvPerMsg
-	// maxRequestedTxns is the maximum number of requested transactions hashes to
-	// store in memory.
-	maxRequestedTxns = wire.MaxInvPerMsg
 )
 
 // zeroHash is the zero value hash (all zeros)
@@ -234,6 +255,17 @@ func (sm *SyncManager) QueueInv(inv *wire.MsgInv, peer *peerpkg.Peer) {
 	sm.msgChan <- &invMsg{inv: inv, peer: peer}
 }
 
+// QueueNotFound adds the passed notfound message and peer to the block handling
+// queue.
+func (sm *SyncManager) QueueNotFound(notFound *wire.MsgNotFound, peer *peerpkg.Peer) {
+	// No channel handling here because peers do not need to block on notfound
+	// messages.
+	if atomic.LoadInt32(&sm.shutdown) != 0 {
+		return
+	}
+	sm.msgChan <- &notFoundMsg{notFound: notFound, peer: peer}
+}
+
 // QueueTx adds the passed transaction message and peer to the block handling
 // queue. Responds to the done channel argument after the tx message is
 // processed.
@@ -277,6 +309,22 @@ func (sm *SyncManager) SyncPeerID() int32 {
 	return <-reply
 }
 
+// BlockPropagation returns how the most recently connected blocks announced by
+// peers reached the node, oldest first.
+func (sm *SyncManager) BlockPropagation() []BlockPropagation {
+	reply := make(chan []BlockPropagation)
+	sm.msgChan <- getBlockPropagationMsg{reply: reply}
+	return <-reply
+}
+
+// OrphanRequests returns the requests for the missing parents of the chains of
+// orphan blocks held by the chain.
+func (sm *SyncManager) OrphanRequests() OrphanRequests {
+	reply := make(chan OrphanRequests)
+	sm.msgChan <- getOrphanRequestsMsg{reply: reply}
+	return <-reply
+}
+
 // blockHandler is the main handler for the sync manager. It must be run as a
 // goroutine. It processes block and inv messages in a separate goroutine from
 // the peer handlers so the block (Block) messages are handled by a single
@@ -284,9 +332,21 @@ func (sm *SyncManager) SyncPeerID() int32 {
 // because the sync manager controls which blocks are needed and how the
 // fetching should proceed.
 func (sm *SyncManager) blockHandler(workerNumber uint32) {
+	stallTicker := time.NewTicker(headerStallCheckInterval)
+	defer stallTicker.Stop()
+	txRequestTicker := time.NewTicker(txRequestCheckInterval)
+	defer txRequestTicker.Stop()
+	orphanRequestTicker := time.NewTicker(orphanRequestCheckInterval)
+	defer orphanRequestTicker.Stop()
 out:
 	for {
 		select {
+		case <-stallTicker.C:
+			sm.checkHeaderStall()
+		case <-txRequestTicker.C:
+			sm.checkTxRequests()
+		case <-orphanRequestTicker.C:
+			sm.checkOrphanRequests()
 		case m := <-sm.msgChan:
 			switch msg := m.(type) {
 			case *newPeerMsg:
@@ -301,6 +361,8 @@ out:
 				sm.handleInvMsg(msg)
 			case *headersMsg:
 				sm.handleHeadersMsg(msg)
+			case *notFoundMsg:
+				sm.handleNotFoundMsg(msg)
 			case *donePeerMsg:
 				sm.handleDonePeerMsg(msg.peer)
 			case getSyncPeerMsg:
@@ -309,6 +371,10 @@ out:
 					peerID = sm.syncPeer.ID()
 				}
 				msg.reply <- peerID
+			case getBlockPropagationMsg:
+				msg.reply <- sm.propagation.blocks()
+			case getOrphanRequestsMsg:
+				msg.reply <- sm.orphanRequests.state()
 			case processBlockMsg:
 				T.Ln("received processBlockMsg")
 				var heightUpdate int32
@@ -496,6 +562,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 			return
 		}
 	}
+	sm.propagation.received(*blockHash, time.Now())
 	// When in headers-first mode, if the block matches the hash of the first header
 	// in the list of headers that are being fetched, it's eligible for less
 	// validation since the headers have already been verified to link together and
@@ -545,6 +612,17 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		behaviorFlags, heightUpdate,
 	)
 	if e != nil {
+		// A block whose parent is not known that fails the checks that don't need the parent is invalid whatever the
+		// parent turns out to be, so the peer that sent it is penalised rather than asked for its parents.
+		if re, ok := e.(blockchain.RuleError); ok && re.ErrorCode != blockchain.ErrDuplicateBlock &&
+			!sm.chain.Index.HaveBlock(&header.PrevBlock) {
+			E.F("rejected orphan block %v from %s: %v", blockHash, pp, e)
+			sm.propagation.forget(*blockHash)
+			code, reason := mempool.ErrToRejectErr(e)
+			pp.PushRejectMsg(wire.CmdBlock, code, reason, blockHash, false)
+			sm.peerNotifier.AddBanScore(pp, 50, 0, "invalid orphan block")
+			return
+		}
 		if heightUpdate+1 <= sm.chain.BestChain.Height() {
 			// Process the block to include validation, best chain selection, orphan handling, etc.
 			// When the error is a rule error, it means the block was simply rejected as
@@ -563,6 +641,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 				database.ErrCorruption {
 				panic(dbErr)
 			}
+			sm.propagation.forget(*blockHash)
 			code, reason := mempool.ErrToRejectErr(e)
 			pp.PushRejectMsg(wire.CmdBlock, code, reason, blockHash, false)
 			return
@@ -580,6 +659,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 	// current or who may have lost the lock announcment race. Request the parents
 	// for the orphan block from the peer that sent it.
 	if isOrphan {
+		sm.propagation.forget(*blockHash)
 		// We've just received an orphan block from a peer. In order to update the
 		// height of the peer, we try to extract the block height from the scriptSig of
 		// the coinbase transaction. Extraction is only attempted if the block's version
@@ -600,22 +680,13 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 				blkHashUpdate = blockHash
 			}
 		}
-		orphanRoot := sm.chain.GetOrphanRoot(blockHash)
-		var locator blockchain.BlockLocator
-		locator, e = sm.chain.LatestBlockLocator()
-		if e != nil {
-			E.F(
-				"failed to get block locator for the latest block: %v",
-				e,
-			)
-		} else {
-			e = pp.PushGetBlocksMsg(locator, orphanRoot)
-			if e != nil {
-			}
-		}
+		// Ask the peer for the blocks leading up to the orphan, and ask the other
+		// peers that have the orphan if it does not send them in time.
+		sm.requestOrphanParents(sm.chain.GetOrphanRoot(blockHash), pp)
 	} else {
 		// When the block is not an orphan, log information about it and update the
 		// chain state.
+		sm.propagation.validated(*blockHash, bmsg.block.Height(), time.Now())
 		sm.progressLogger.LogBlockHeight(bmsg.block)
 		// Update this peer's latest block height, for future potential sync node
 		// candidacy.
@@ -659,7 +730,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 	sm.nextCheckpoint = sm.findNextHeaderCheckpoint(prevHeight)
 	if sm.nextCheckpoint != nil {
 		locator := blockchain.BlockLocator([]*chainhash.Hash{prevHash})
-		e = pp.PushGetHeadersMsg(locator, sm.nextCheckpoint.Hash)
+		e = sm.pushGetHeaders(pp, locator, sm.nextCheckpoint.Hash)
 		if e != nil {
 			E.F(
 				"failed to send getheaders message to peer %s: %v",
@@ -678,6 +749,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 	// after this one up to the end of the chain (zero hash).
 	sm.headersFirstMode = false
 	sm.headerList.Init()
+	pp.SetZeroCopyBlocks(false)
 	I.Ln(
 		"reached the final checkpoint -- switching to normal mode",
 	)
@@ -689,6 +761,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		)
 		return
 	}
+	sm.requestAssumeValidHeaders(pp)
 }
 
 // handleBlockchainNotification handles notifications from blockchain. It does
@@ -732,6 +805,11 @@ func (sm *SyncManager) handleBlockchainNotification(notification *blockchain.Not
 			acceptedTxs := sm.txMemPool.ProcessOrphans(sm.chain, tx)
 			sm.peerNotifier.AnnounceNewTransactions(acceptedTxs)
 		}
+		// Evict the transactions that stayed in the pool for longer than the
+		// expiry without being mined.
+		for _, tx := range sm.txMemPool.ExpireTransactions() {
+			sm.peerNotifier.TransactionExpired(tx)
+		}
 		// Register block with the fee estimator, if it exists.
 		if sm.feeEstimator != nil {
 			e := sm.feeEstimator.RegisterBlock(block)
@@ -786,11 +864,10 @@ func (sm *SyncManager) handleDonePeerMsg(peer *peerpkg.Peer) {
 	// Remove the peer from the list of candidate peers.
 	delete(sm.peerStates, peer)
 	T.Ln("lost peer ", peer)
-	// Remove requested transactions from the global map so that they will be
-	// fetched from elsewhere next time we get an inv.
-	for txHash := range state.requestedTxns {
-		delete(sm.requestedTxns, txHash)
-	}
+	// Forget the transactions announced by the peer so that the ones it was
+	// sending are requested from other peers that announced them.
+	sm.txRequests.removePeer(peer)
+	sm.orphanRequests.removePeer(peer)
 	// Remove requested blocks from the global map so that they will be fetched from
 	// elsewhere next time we get an inv.
 	//
@@ -798,6 +875,9 @@ func (sm *SyncManager) handleDonePeerMsg(peer *peerpkg.Peer) {
 	for blockHash := range state.requestedBlocks {
 		delete(sm.requestedBlocks, blockHash)
 	}
+	if sm.assumeValidPeer == peer {
+		sm.assumeValidPeer = nil
+	}
 	// Attempt to find a new peer to sync from if the quitting peer is the sync
 	// peer. Also, reset the headers-first state if in headers-first mode so
 	if sm.syncPeer == peer {
@@ -814,13 +894,17 @@ func (sm *SyncManager) handleDonePeerMsg(peer *peerpkg.Peer) {
 // requested when performing a headers-first sync.
 func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 	peer := hmsg.peer
-	_, exists := sm.peerStates[peer]
+	state, exists := sm.peerStates[peer]
 	if !exists {
 		T.Ln("received headers message from unknown peer", peer)
 		return
 	}
-	// The remote peer is misbehaving if we didn't request headers.
 	msg := hmsg.headers
+	if peer == sm.assumeValidPeer {
+		sm.handleAssumeValidHeaders(peer, msg.Headers)
+		return
+	}
+	// The remote peer is misbehaving if we didn't request headers.
 	numHeaders := len(msg.Headers)
 	if !sm.headersFirstMode {
 		T.F(
@@ -830,17 +914,33 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 		peer.Disconnect()
 		return
 	}
+	// A peer that was replaced for sending headers slowly may still answer the last request it was sent.
+	if peer != sm.syncPeer && time.Now().Before(state.slowUntil) {
+		T.Ln("ignoring late headers from replaced sync peer", peer)
+		return
+	}
+	// Replace the sync peer if the rate it sends headers at has collapsed.
+	if peer == sm.syncPeer && sm.recordHeaderBatch(state, numHeaders) {
+		sm.replaceSyncPeer("is sending headers too slowly")
+		return
+	}
 	// Nothing to do for an empty headers message.
 	if numHeaders == 0 {
 		return
 	}
+	// A peer that did not have the latest header in the list replies with headers that build on an earlier entry of
+	// the locator, so the list is rewound to the header they connect to.
+	if prevNodeEl := sm.headerList.Back(); prevNodeEl != nil && peer == sm.syncPeer {
+		prevHash := &msg.Headers[0].PrevBlock
+		if !prevNodeEl.Value.(*headerNode).hash.IsEqual(prevHash) && sm.rewindHeaderList(prevHash) {
+			D.Ln("rewound the header list to", prevHash, "for headers from", peer)
+		}
+	}
 	// Process all of the received headers ensuring each one connects to the
 	// previous and that checkpoints match.
 	receivedCheckpoint := false
-	var finalHash *chainhash.Hash
 	for _, blockHeader := range msg.Headers {
 		blockHash := blockHeader.BlockHash()
-		finalHash = &blockHash
 		// Ensure there is a previous header to compare against.
 		prevNodeEl := sm.headerList.Back()
 		if prevNodeEl == nil {
@@ -910,8 +1010,7 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 	}
 	// This header is not a checkpoint, so request the next batch of headers
 	// starting from the latest known header and ending with the next checkpoint.
-	locator := blockchain.BlockLocator([]*chainhash.Hash{finalHash})
-	e := peer.PushGetHeadersMsg(locator, sm.nextCheckpoint.Hash)
+	e := sm.pushGetHeaders(peer, sm.headerLocator(), sm.nextCheckpoint.Hash)
 	if e != nil {
 		E.F(
 			"failed to send getheaders message to peer %s: %v", peer,
@@ -984,11 +1083,22 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 			continue
 		}
 		if !haveInv {
+			// Only blocks announced while the chain is current are new blocks
+			// propagating through the network.
+			if iv.Type == wire.InvTypeBlock && sm.current() {
+				sm.propagation.announce(iv.Hash, peer, time.Now())
+			}
 			if iv.Type == wire.InvTypeTx {
 				// Skip the transaction if it has already been rejected.
 				if _, exists := sm.rejectedTxns[iv.Hash]; exists {
 					continue
 				}
+				// Skip the transaction if the peer already announced it, it is
+				// requested when the peer or another that announced it can take
+				// the request.
+				if !sm.txRequests.announce(iv.Hash, peer) {
+					continue
+				}
 			}
 			// Ignore invs block invs from non-witness enabled peers, as after segwit
 			// activation we only want to download from peers that can provide us full
@@ -1010,15 +1120,7 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 			// to be requested.
 			if sm.chain.IsKnownOrphan(&iv.Hash) {
 				// Request blocks starting at the latest known up to the root of the orphan that just came in.
-				orphanRoot := sm.chain.GetOrphanRoot(&iv.Hash)
-				locator, e := sm.chain.LatestBlockLocator()
-				if e != nil {
-					E.Ln("failed to get block locator for the latest block:", e)
-					continue
-				}
-				e = peer.PushGetBlocksMsg(locator, orphanRoot)
-				if e != nil {
-				}
+				sm.requestOrphanParents(sm.chain.GetOrphanRoot(&iv.Hash), peer)
 				continue
 			}
 			// We already have the final block advertised by this inventory message, so
@@ -1037,6 +1139,7 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 	// Request as much as possible at once. Anything that won't fit into request
 	// will be requested on the next inv message.
 	numRequested := 0
+	now := time.Now()
 	gdmsg := wire.NewMsgGetData()
 	requestQueue := state.requestQueue
 	for len(requestQueue) != 0 {
@@ -1063,11 +1166,10 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 		// case wire.InvTypeWitnessTx:
 		// 	fallthrough
 		case wire.InvTypeTx:
-			// Request the transaction if there is not already a pending request.
-			if _, exists := sm.requestedTxns[iv.Hash]; !exists {
-				sm.requestedTxns[iv.Hash] = struct{}{}
-				sm.limitMap(sm.requestedTxns, maxRequestedTxns)
-				state.requestedTxns[iv.Hash] = struct{}{}
+			// Request the transaction if there is not already a pending request
+			// and the peer has room for more requests in flight. Otherwise it
+			// is requested later by checkTxRequests.
+			if sm.txRequests.request(iv.Hash, peer, now) {
 				// If the peer is capable, request the txn including all witness
 				// data.
 				// if peer.IsWitnessEnabled() {
@@ -1105,7 +1207,6 @@ func (sm *SyncManager) handleNewPeerMsg(peer *peerpkg.Peer) {
 	}
 	sm.peerStates[peer] = &peerSyncState{
 		syncCandidate:   isSyncCandidate,
-		requestedTxns:   make(map[chainhash.Hash]struct{}),
 		requestedBlocks: make(map[chainhash.Hash]struct{}),
 	}
 	// Start syncing by choosing the best candidate if needed.
@@ -1117,7 +1218,7 @@ func (sm *SyncManager) handleNewPeerMsg(peer *peerpkg.Peer) {
 // handleTxMsg handles transaction messages from all peers.
 func (sm *SyncManager) handleTxMsg(tmsg *txMsg) {
 	peer := tmsg.peer
-	state, exists := sm.peerStates[peer]
+	_, exists := sm.peerStates[peer]
 	if !exists {
 		W.C(
 			func() string {
@@ -1148,16 +1249,16 @@ func (sm *SyncManager) handleTxMsg(tmsg *txMsg) {
 		return
 	}
 	// Process the transaction to include validation, insertion in the memory pool,
-	// orphan handling, etc.
+	// orphan handling, etc. The maximum fee only guards local submissions, so the
+	// fees of relayed transactions are allowed whatever they are.
 	acceptedTxs, e := sm.txMemPool.ProcessTransaction(
 		sm.chain, tmsg.tx,
-		true, true, mempool.Tag(peer.ID()),
+		true, true, true, mempool.Tag(peer.ID()),
 	)
-	// Remove transaction from request maps. Either the mempool/chain already knows
-	// about it and as such we shouldn't have any more instances of trying to fetch
-	// it, or we failed to insert and thus we'll retry next time we get an inv.
-	delete(state.requestedTxns, *txHash)
-	delete(sm.requestedTxns, *txHash)
+	// Stop tracking the transaction. Either the mempool/chain already knows about
+	// it and as such we shouldn't have any more instances of trying to fetch it,
+	// or we failed to insert and thus we'll retry next time we get an inv.
+	sm.txRequests.forget(*txHash)
 	if e != nil {
 		// Do not request this transaction again until a new block has been processed.
 		sm.rejectedTxns[*txHash] = struct{}{}
@@ -1320,7 +1421,8 @@ func (sm *SyncManager) startSync() {
 	// 	return
 	// }
 	best := sm.chain.BestSnapshot()
-	var bestPeer *peerpkg.Peer
+	var bestPeer, slowPeer *peerpkg.Peer
+	now := time.Now()
 	for peer, state := range sm.peerStates {
 		if !state.syncCandidate {
 			continue
@@ -1339,19 +1441,25 @@ func (sm *SyncManager) startSync() {
 			// state.syncCandidate = false
 			continue
 		}
-		// TODO(davec): Use a better algorithm to choose the best peer. For now, just pick the first available candidate.
-		bestPeer = peer
+		// Peers recently replaced for sending headers slowly are only used when there is no other candidate.
+		if now.Before(state.slowUntil) {
+			slowPeer = peer
+			continue
+		}
+		// Prefer the peer that has sent headers the fastest.
+		if bestPeer == nil || state.headerRate > sm.peerStates[bestPeer].headerRate {
+			bestPeer = peer
+		}
+	}
+	if bestPeer == nil {
+		bestPeer = slowPeer
 	}
 	// Start syncing from the best peer if one was selected.
 	if bestPeer != nil {
 		// Clear the requestedBlocks if the sync peer changes, otherwise we may ignore blocks we need that the last sync
 		// peer failed to send.
 		sm.requestedBlocks = make(map[chainhash.Hash]struct{})
-		locator, e := sm.chain.LatestBlockLocator()
-		if e != nil {
-			E.Ln("failed to get block locator for the latest block:", e)
-			return
-		}
+		locator := sm.chain.CheckpointBlockLocator()
 		T.C(
 			func() string {
 				return fmt.Sprintf("syncing to block height %d from peer %v", bestPeer.LastBlock(), bestPeer.Addr())
@@ -1374,8 +1482,7 @@ func (sm *SyncManager) startSync() {
 		if sm.nextCheckpoint != nil &&
 			best.Height < sm.nextCheckpoint.Height &&
 			sm.chainParams != &chaincfg.RegressionTestParams {
-			e := bestPeer.PushGetHeadersMsg(locator, sm.nextCheckpoint.Hash)
-			if e != nil {
+			if e := sm.pushGetHeaders(bestPeer, locator, sm.nextCheckpoint.Hash); E.Chk(e) {
 			}
 			sm.headersFirstMode = true
 			I.F(
@@ -1388,7 +1495,12 @@ func (sm *SyncManager) startSync() {
 			e := bestPeer.PushGetBlocksMsg(locator, &zeroHash)
 			if e != nil {
 			}
+			sm.requestAssumeValidHeaders(bestPeer)
 		}
+		// Blocks below the final checkpoint are decoded without copying their
+		// scripts, as they are only downloaded once and kept no longer than it
+		// takes to connect them.
+		bestPeer.SetZeroCopyBlocks(sm.headersFirstMode)
 		sm.syncPeer = bestPeer
 	} else {
 		T.Ln("no sync peer candidates available")
@@ -1404,7 +1516,9 @@ func New(config *Config) (*SyncManager, error) {
 		txMemPool:       config.TxMemPool,
 		chainParams:     config.ChainParams,
 		rejectedTxns:    make(map[chainhash.Hash]struct{}),
-		requestedTxns:   make(map[chainhash.Hash]struct{}),
+		txRequests:      newTxRequestManager(),
+		propagation:     newBlockPropagationTracker(),
+		orphanRequests:  newOrphanRequestManager(),
 		requestedBlocks: make(map[chainhash.Hash]struct{}),
 		peerStates:      make(map[*peerpkg.Peer]*peerSyncState),
 		progressLogger:  newBlockProgressLogger("processed"),
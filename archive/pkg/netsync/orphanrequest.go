@@ -0,0 +1,191 @@
+package netsync
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	peerpkg "github.com/p9c/pod/pkg/peer"
+)
+
+const (
+	// orphanParentTimeout is how long a peer may take to send the blocks leading up to a chain of orphan blocks before
+	// they are requested from another peer that announced or sent one of the orphans.
+	orphanParentTimeout = 30 * time.Second
+	// orphanRequestCheckInterval is how often the sync manager looks for chains of orphan blocks whose missing parent
+	// has not arrived in time.
+	orphanRequestCheckInterval = 10 * time.Second
+	// maxOrphanParentRequests is how many times the blocks leading up to a chain of orphan blocks are requested before
+	// they are given up on, leaving the orphans to expire from the orphan pool.
+	maxOrphanParentRequests = 5
+)
+
+// orphanParentRequest holds the peers that announced or sent the orphan blocks of a chain of orphans and the last
+// request for the blocks leading up to it.
+type orphanParentRequest struct {
+	// peers are the peers known to have the orphans, in the order they announced them.
+	peers         []*peerpkg.Peer
+	requestedFrom *peerpkg.Peer
+	requested     time.Time
+	attempts      int
+}
+
+// OrphanParentRequest describes the requests for the missing parent of a chain of orphan blocks.
+type OrphanParentRequest struct {
+	// Root is the hash of the first orphan of the chain, whose parent is missing.
+	Root chainhash.Hash
+	// Peers are the addresses of the peers known to have the orphans.
+	Peers     []string
+	Attempts  int
+	Requested time.Time
+}
+
+// OrphanRequests is the state of the requests for the missing parents of the chains of orphan blocks.
+type OrphanRequests struct {
+	Pending []OrphanParentRequest
+	// Requests is the number of requests for missing parents made, Rerequests the number of them that were made again
+	// after an earlier request timed out, and Abandoned the number of missing parents given up on.
+	Requests   int64
+	Rerequests int64
+	Abandoned  int64
+}
+
+// orphanRequestManager requests the blocks leading up to each chain of orphan blocks from the peers that announced or
+// sent the orphans, one at a time, until the orphans are connected to the chain or have been requested
+// maxOrphanParentRequests times. It must only be used from the blockHandler thread.
+type orphanRequestManager struct {
+	roots                           map[chainhash.Hash]*orphanParentRequest
+	requests, rerequests, abandoned int64
+}
+
+// newOrphanRequestManager returns an empty orphanRequestManager.
+func newOrphanRequestManager() *orphanRequestManager {
+	return &orphanRequestManager{
+		roots: make(map[chainhash.Hash]*orphanParentRequest),
+	}
+}
+
+// announce records that the peer has the chain of orphans starting with root.
+func (m *orphanRequestManager) announce(root chainhash.Hash, peer *peerpkg.Peer) {
+	r, exists := m.roots[root]
+	if !exists {
+		r = &orphanParentRequest{}
+		m.roots[root] = r
+	}
+	for _, p := range r.peers {
+		if p == peer {
+			return
+		}
+	}
+	r.peers = append(r.peers, peer)
+}
+
+// requested records that the blocks leading up to root were requested from the peer at the time now.
+func (m *orphanRequestManager) requested(root chainhash.Hash, peer *peerpkg.Peer, now time.Time) {
+	r, exists := m.roots[root]
+	if !exists {
+		return
+	}
+	// Requests made while an earlier one has not yet timed out, as when a peer sends several orphans of the chain, are
+	// not counted as further attempts.
+	if r.attempts > 0 && now.Sub(r.requested) < orphanParentTimeout {
+		return
+	}
+	if r.attempts > 0 {
+		m.rerequests++
+	}
+	m.requests++
+	r.requestedFrom = peer
+	r.requested = now
+	r.attempts++
+}
+
+// due stops tracking the chains of orphans that are no longer orphans according to isOrphan, and returns the peer to
+// request the blocks leading up to each chain from next, for those whose last request timed out before now. Chains
+// already requested maxOrphanParentRequests times are given up on.
+func (m *orphanRequestManager) due(
+	now time.Time, isOrphan func(hash *chainhash.Hash) bool,
+) (retries map[chainhash.Hash]*peerpkg.Peer) {
+	retries = make(map[chainhash.Hash]*peerpkg.Peer)
+	for root, r := range m.roots {
+		root := root
+		if !isOrphan(&root) {
+			delete(m.roots, root)
+			continue
+		}
+		if now.Sub(r.requested) < orphanParentTimeout {
+			continue
+		}
+		if r.attempts >= maxOrphanParentRequests {
+			m.abandoned++
+			delete(m.roots, root)
+			continue
+		}
+		// Take the peer after the last one requested from, wrapping around to the first.
+		next := 0
+		for i, p := range r.peers {
+			if p == r.requestedFrom {
+				next = (i + 1) % len(r.peers)
+			}
+		}
+		retries[root] = r.peers[next]
+	}
+	return
+}
+
+// removePeer forgets the disconnected peer, and the chains of orphans no other peer is known to have.
+func (m *orphanRequestManager) removePeer(peer *peerpkg.Peer) {
+	for root, r := range m.roots {
+		for i, p := range r.peers {
+			if p == peer {
+				r.peers = append(r.peers[:i], r.peers[i+1:]...)
+				break
+			}
+		}
+		if len(r.peers) == 0 {
+			delete(m.roots, root)
+		}
+	}
+}
+
+// state returns the pending requests and the request counts.
+func (m *orphanRequestManager) state() OrphanRequests {
+	state := OrphanRequests{
+		Pending:    make([]OrphanParentRequest, 0, len(m.roots)),
+		Requests:   m.requests,
+		Rerequests: m.rerequests,
+		Abandoned:  m.abandoned,
+	}
+	for root, r := range m.roots {
+		req := OrphanParentRequest{Root: root, Attempts: r.attempts, Requested: r.requested}
+		for _, p := range r.peers {
+			req.Peers = append(req.Peers, p.Addr())
+		}
+		state.Pending = append(state.Pending, req)
+	}
+	return state
+}
+
+// requestOrphanParents records that the peer has the chain of orphan blocks starting with root, and asks it for the
+// blocks from the latest block of the chain up to root.
+func (sm *SyncManager) requestOrphanParents(root *chainhash.Hash, peer *peerpkg.Peer) {
+	sm.orphanRequests.announce(*root, peer)
+	locator, e := sm.chain.LatestBlockLocator()
+	if e != nil {
+		E.Ln("failed to get block locator for the latest block:", e)
+		return
+	}
+	if e = peer.PushGetBlocksMsg(locator, root); E.Chk(e) {
+		return
+	}
+	sm.orphanRequests.requested(*root, peer, time.Now())
+}
+
+// checkOrphanRequests requests the blocks leading up to the chains of orphan blocks whose missing parent has not
+// arrived in time from the next peer known to have the orphans.
+func (sm *SyncManager) checkOrphanRequests() {
+	for root, peer := range sm.orphanRequests.due(time.Now(), sm.chain.IsKnownOrphan) {
+		root := root
+		D.F("requesting the missing parent of orphan block %v again from %s", root, peer)
+		sm.requestOrphanParents(&root, peer)
+	}
+}
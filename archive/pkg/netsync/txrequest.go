@@ -0,0 +1,215 @@
+package netsync
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/chainhash"
+	peerpkg "github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+const (
+	// maxInFlightTxPerPeer is the maximum number of transactions requested from a peer that have not yet arrived. The
+	// rest of the transactions the peer announces are requested as earlier requests are answered, or from other peers.
+	maxInFlightTxPerPeer = 100
+	// txRequestTimeout is how long a peer may take to send a requested transaction before it is requested from
+	// another peer that announced it.
+	txRequestTimeout = time.Minute
+	// txRequestCheckInterval is how often the sync manager looks for timed out transaction requests and for
+	// announced transactions waiting for a peer to request them from.
+	txRequestCheckInterval = 10 * time.Second
+	// maxAnnouncedTxns is the maximum number of announced transactions that are tracked. Announcements of further
+	// transactions are ignored until some of them are received or given up on.
+	maxAnnouncedTxns = 4 * wire.MaxInvPerMsg
+)
+
+// txAnnouncement holds the peers that announced a transaction and the request for it in flight, if any.
+type txAnnouncement struct {
+	// peers are the peers that announced the transaction and have not yet failed to send it, in the order they
+	// announced it.
+	peers []*peerpkg.Peer
+	// requestedFrom is the peer the transaction was requested from, or nil if it is waiting to be requested.
+	requestedFrom *peerpkg.Peer
+	requested     time.Time
+}
+
+// txRequestManager tracks the transactions announced by peers and requests each one from a single peer at a time,
+// limiting how many requests each peer has in flight and retrying from another peer that announced the transaction
+// when a request times out or the peer does not have it. It must only be used from the blockHandler thread.
+type txRequestManager struct {
+	announced map[chainhash.Hash]*txAnnouncement
+	inFlight  map[*peerpkg.Peer]int
+}
+
+// newTxRequestManager returns an empty txRequestManager.
+func newTxRequestManager() *txRequestManager {
+	return &txRequestManager{
+		announced: make(map[chainhash.Hash]*txAnnouncement),
+		inFlight:  make(map[*peerpkg.Peer]int),
+	}
+}
+
+// announce records that the peer announced the transaction. It returns false if the peer already announced it, or
+// the transaction is not tracked because too many are, so the announcement needs no further handling.
+func (m *txRequestManager) announce(hash chainhash.Hash, peer *peerpkg.Peer) bool {
+	ann, exists := m.announced[hash]
+	if !exists {
+		if len(m.announced) >= maxAnnouncedTxns {
+			return false
+		}
+		ann = &txAnnouncement{}
+		m.announced[hash] = ann
+	}
+	for _, p := range ann.peers {
+		if p == peer {
+			return false
+		}
+	}
+	ann.peers = append(ann.peers, peer)
+	return true
+}
+
+// request marks the transaction as requested from the peer at the time now and returns true, unless it is not an
+// announced transaction, it is already in flight or the peer has its maximum number of requests in flight.
+func (m *txRequestManager) request(hash chainhash.Hash, peer *peerpkg.Peer, now time.Time) bool {
+	ann, exists := m.announced[hash]
+	if !exists || ann.requestedFrom != nil || m.inFlight[peer] >= maxInFlightTxPerPeer {
+		return false
+	}
+	ann.requestedFrom = peer
+	ann.requested = now
+	m.inFlight[peer]++
+	return true
+}
+
+// forget stops tracking the transaction, because it was received or is no longer wanted.
+func (m *txRequestManager) forget(hash chainhash.Hash) {
+	ann, exists := m.announced[hash]
+	if !exists {
+		return
+	}
+	m.release(ann)
+	delete(m.announced, hash)
+}
+
+// notFound records that the peer does not have the transaction, so it is requested from another peer that announced
+// it on the next retry.
+func (m *txRequestManager) notFound(hash chainhash.Hash, peer *peerpkg.Peer) {
+	ann, exists := m.announced[hash]
+	if !exists {
+		return
+	}
+	if ann.requestedFrom == peer {
+		m.release(ann)
+	}
+	m.dropPeer(hash, ann, peer)
+}
+
+// removePeer forgets the announcements and requests of a disconnected peer. Transactions it was sending are requested
+// from other peers that announced them on the next retry.
+func (m *txRequestManager) removePeer(peer *peerpkg.Peer) {
+	for hash, ann := range m.announced {
+		if ann.requestedFrom == peer {
+			ann.requestedFrom = nil
+		}
+		m.dropPeer(hash, ann, peer)
+	}
+	delete(m.inFlight, peer)
+}
+
+// retry gives up on the requests made before now less txRequestTimeout, dropping the peers that did not answer them
+// from the announcers of the transactions, and returns the transactions waiting to be requested, each with the first
+// peer that announced it and can take another request. Transactions with no announcers left are forgotten.
+func (m *txRequestManager) retry(now time.Time) map[*peerpkg.Peer][]chainhash.Hash {
+	requests := make(map[*peerpkg.Peer][]chainhash.Hash)
+	for hash, ann := range m.announced {
+		if ann.requestedFrom != nil {
+			if now.Sub(ann.requested) < txRequestTimeout {
+				continue
+			}
+			peer := ann.requestedFrom
+			T.F("timed out waiting for transaction %v from %s", hash, peer)
+			m.release(ann)
+			if !m.dropPeer(hash, ann, peer) {
+				continue
+			}
+		}
+		for _, peer := range ann.peers {
+			if m.request(hash, peer, now) {
+				requests[peer] = append(requests[peer], hash)
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// release ends the request in flight for an announced transaction, if any.
+func (m *txRequestManager) release(ann *txAnnouncement) {
+	if ann.requestedFrom == nil {
+		return
+	}
+	if m.inFlight[ann.requestedFrom]--; m.inFlight[ann.requestedFrom] <= 0 {
+		delete(m.inFlight, ann.requestedFrom)
+	}
+	ann.requestedFrom = nil
+}
+
+// dropPeer removes the peer from the announcers of the transaction, forgetting the transaction if no announcers are
+// left. It returns whether the transaction is still tracked.
+func (m *txRequestManager) dropPeer(hash chainhash.Hash, ann *txAnnouncement, peer *peerpkg.Peer) bool {
+	for i, p := range ann.peers {
+		if p == peer {
+			ann.peers = append(ann.peers[:i], ann.peers[i+1:]...)
+			break
+		}
+	}
+	if len(ann.peers) == 0 {
+		delete(m.announced, hash)
+		return false
+	}
+	return true
+}
+
+// checkTxRequests requests the announced transactions whose requests timed out, or that are waiting for a peer with
+// room for more requests in flight, from the peers that announced them. Transactions that became known or were
+// rejected since they were announced are forgotten instead.
+func (sm *SyncManager) checkTxRequests() {
+	for peer, hashes := range sm.txRequests.retry(time.Now()) {
+		gdmsg := wire.NewMsgGetData()
+		for i := range hashes {
+			iv := wire.NewInvVect(wire.InvTypeTx, &hashes[i])
+			_, rejected := sm.rejectedTxns[hashes[i]]
+			if haveInv, e := sm.haveInventory(iv); rejected || e != nil || haveInv {
+				sm.txRequests.forget(hashes[i])
+				continue
+			}
+			if e := gdmsg.AddInvVect(iv); E.Chk(e) {
+				sm.txRequests.forget(hashes[i])
+			}
+		}
+		if len(gdmsg.InvList) > 0 {
+			T.F("requesting %d announced transactions from %s", len(gdmsg.InvList), peer)
+			peer.QueueMessage(gdmsg, nil)
+		}
+	}
+}
+
+// handleNotFoundMsg handles notfound messages from all peers. Transactions the peer does not have are requested from
+// another peer that announced them.
+func (sm *SyncManager) handleNotFoundMsg(nfmsg *notFoundMsg) {
+	if _, exists := sm.peerStates[nfmsg.peer]; !exists {
+		T.Ln("received notfound message from unknown peer", nfmsg.peer)
+		return
+	}
+	var missing bool
+	for _, iv := range nfmsg.notFound.InvList {
+		if iv.Type == wire.InvTypeTx {
+			sm.txRequests.notFound(iv.Hash, nfmsg.peer)
+			missing = true
+		}
+	}
+	if missing {
+		sm.checkTxRequests()
+	}
+}
@@ -15,6 +15,8 @@ type (
 		OmitEmpty     bool
 		Name          string
 		DefaultPort   int
+		// Live is true when the value is read each time it is used, so it can be changed without restarting
+		Live bool
 	}
 )
 
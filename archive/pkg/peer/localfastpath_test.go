@@ -0,0 +1,52 @@
+package peer
+
+import (
+	"net"
+	"testing"
+)
+
+// addrConn is a connection that only has addresses.
+type addrConn struct {
+	net.Conn
+	local, remote net.Addr
+}
+
+func (c addrConn) LocalAddr() net.Addr  { return c.local }
+func (c addrConn) RemoteAddr() net.Addr { return c.remote }
+
+// TestOffersLocal ensures the local encoding is only offered through the listed listeners of the local fast path and
+// only to peers on the same host.
+func TestOffersLocal(t *testing.T) {
+	listener := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 11047}
+	other := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 11048}
+	client := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000}
+	remote := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 50000}
+	socket := &net.UnixAddr{Name: "/run/pod/p2p.sock", Net: "unix"}
+	fastPath := []string{"127.0.0.1:11047", "/run/pod/p2p.sock"}
+	tests := []struct {
+		name          string
+		fastPath      []string
+		inbound       bool
+		local, remote net.Addr
+		offers        bool
+	}{
+		{"not enabled", nil, true, listener, client, false},
+		{"inbound through a listed listener", fastPath, true, listener, client, true},
+		{"inbound through another listener", fastPath, true, other, client, false},
+		{"inbound from another host", fastPath, true, listener, remote, false},
+		{"outbound to a listed listener", fastPath, false, client, listener, true},
+		{"outbound to another listener", fastPath, false, client, other, false},
+		{"inbound through a listed unix socket", fastPath, true, socket, &net.UnixAddr{Net: "unix"}, true},
+		{"outbound to a listed unix socket", fastPath, false, &net.UnixAddr{Net: "unix"}, socket, true},
+	}
+	for _, test := range tests {
+		p := &Peer{
+			cfg:     Config{LocalFastPath: test.fastPath},
+			inbound: test.inbound,
+			conn:    addrConn{local: test.local, remote: test.remote},
+		}
+		if offers := p.offersLocal(); offers != test.offers {
+			t.Errorf("%s: offers the local encoding %v, want %v", test.name, offers, test.offers)
+		}
+	}
+}
@@ -120,6 +120,10 @@ type MessageListeners struct {
 	OnGetCFCheckpt func(p *Peer, msg *wire.MsgGetCFCheckpt)
 	// OnFeeFilter is invoked when a peer receives a feefilter bitcoin message.
 	OnFeeFilter func(p *Peer, msg *wire.MsgFeeFilter)
+	// OnGetUTXOs is invoked when a peer receives a getutxos bitcoin message.
+	OnGetUTXOs func(p *Peer, msg *wire.MsgGetUTXOs)
+	// OnUTXOs is invoked when a peer receives a utxos bitcoin message.
+	OnUTXOs func(p *Peer, msg *wire.MsgUTXOs)
 	// OnFilterAdd is invoked when a peer receives a filteradd bitcoin message.
 	OnFilterAdd func(p *Peer, msg *wire.MsgFilterAdd)
 	// OnFilterClear is invoked when a peer receives a filterclear bitcoin
@@ -195,6 +199,15 @@ type Config struct {
 	TrickleInterval time.Duration
 	IP              net.IP
 	Port            uint16
+	// LocalFastPath lists the addresses of listeners on the loopback interface or unix sockets through which the remote
+	// peer is offered to use wire.LocalEncoding, such as between pod components on the same host. An inbound connection
+	// is matched by its local address and an outbound one by its remote address, as the connection gives them. The
+	// encoding is used once both peers offer it, and is never offered if the list is empty.
+	LocalFastPath []string
+	// OnSelfConnection is invoked with the nonce of the version message of an inbound connection that turns out to come
+	// from this node, such as a probe of whether the listening address can be reached from outside, before the
+	// connection is dropped. It can be nil.
+	OnSelfConnection func(nonce uint64)
 }
 
 // minUint32 is a helper function to return the minimum of two uint32s. This avoids a math import and the need to cast
@@ -338,8 +351,11 @@ type Peer struct {
 	bytesSent     uint64
 	lastRecv      int64
 	lastSend      int64
+	lastDecode    int64
 	connected     int32
 	disconnect    int32
+	zeroCopy      int32
+	local         int32
 	conn          net.Conn
 	// These fields are set at creation time and never modified, so they are safe to read from concurrently without a
 	// mutex.
@@ -635,6 +651,71 @@ func (p *Peer) BytesReceived() uint64 {
 	return atomic.LoadUint64(&p.bytesReceived)
 }
 
+// LastDecodeTime returns how long it took to decode the last message received from the peer. The message listeners are
+// run after the message is decoded, so a listener can use it to find out how long its own message took to decode.
+//
+// This function is safe for concurrent access.
+func (p *Peer) LastDecodeTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&p.lastDecode))
+}
+
+// SetZeroCopyBlocks sets whether the blocks received from the peer are decoded with wire.Block.DeserializeZeroCopy, so
+// the scripts of their transactions refer to the buffer the block message was read into instead of being copied. It
+// is meant for the initial block download, where it saves copying the scripts of every block.
+//
+// This function is safe for concurrent access.
+func (p *Peer) SetZeroCopyBlocks(zeroCopy bool) {
+	var v int32
+	if zeroCopy {
+		v = 1
+	}
+	atomic.StoreInt32(&p.zeroCopy, v)
+}
+
+// LocalEncoding returns whether messages to and from the peer use wire.LocalEncoding, which both peers must offer.
+//
+// This function is safe for concurrent access.
+func (p *Peer) LocalEncoding() bool {
+	return atomic.LoadInt32(&p.local) != 0
+}
+
+// offersLocal returns whether the local peer offers the remote peer to use wire.LocalEncoding, which it does when the
+// connection does not leave the host and is through one of the listeners of the local fast path.
+func (p *Peer) offersLocal() bool {
+	if len(p.cfg.LocalFastPath) == 0 || p.conn == nil || !isLocalAddr(p.conn.RemoteAddr()) {
+		return false
+	}
+	listener := p.conn.RemoteAddr()
+	if p.inbound {
+		listener = p.conn.LocalAddr()
+	}
+	for _, addr := range p.cfg.LocalFastPath {
+		if addr == listener.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalAddr returns whether the address is on the loopback interface or a unix socket. Connections through a proxy
+// are never local, whatever the address they are proxied to.
+func isLocalAddr(addr net.Addr) bool {
+	switch a := addr.(type) {
+	case *net.UnixAddr:
+		return true
+	case *net.TCPAddr:
+		return a.IP.IsLoopback()
+	case *socks.ProxiedAddr:
+		return false
+	}
+	host, _, e := net.SplitHostPort(addr.String())
+	if e != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // TimeConnected returns the time at which the peer connected.
 //
 // This function is safe for concurrent access.
@@ -866,11 +947,12 @@ func (p *Peer) handlePongMsg(msg *wire.MsgPong) {
 
 // readMessage reads the next bitcoin message from the peer with logging.
 func (p *Peer) readMessage(encoding wire.MessageEncoding) (wire.Message, []byte, error) {
-	n, msg, buf, e := wire.ReadMessageWithEncodingN(
+	n, msg, buf, decodeTime, e := wire.ReadMessageWithDecodeTime(
 		p.conn,
 		p.ProtocolVersion(), p.cfg.ChainParams.Net, encoding,
 	)
 	atomic.AddUint64(&p.bytesReceived, uint64(n))
+	atomic.StoreInt64(&p.lastDecode, int64(decodeTime))
 	if p.cfg.Listeners.OnRead != nil {
 		p.cfg.Listeners.OnRead(p, n, msg, e)
 	}
@@ -1183,7 +1265,14 @@ out:
 	for atomic.LoadInt32(&p.disconnect) == 0 {
 		// Read a message and stop the idle timer as soon as the read is done. The timer is reset below for the next
 		// iteration if needed.
-		rMsg, buf, e := p.readMessage(p.wireEncoding)
+		encoding := p.wireEncoding
+		if atomic.LoadInt32(&p.zeroCopy) != 0 {
+			encoding |= wire.ZeroCopyEncoding
+		}
+		if atomic.LoadInt32(&p.local) != 0 {
+			encoding |= wire.LocalEncoding
+		}
+		rMsg, buf, e := p.readMessage(encoding)
 		idleTimer.Stop()
 		if e != nil {
 			T.Ln(e)
@@ -1326,6 +1415,14 @@ out:
 			if p.cfg.Listeners.OnFeeFilter != nil {
 				p.cfg.Listeners.OnFeeFilter(p, msg)
 			}
+		case *wire.MsgGetUTXOs:
+			if p.cfg.Listeners.OnGetUTXOs != nil {
+				p.cfg.Listeners.OnGetUTXOs(p, msg)
+			}
+		case *wire.MsgUTXOs:
+			if p.cfg.Listeners.OnUTXOs != nil {
+				p.cfg.Listeners.OnUTXOs(p, msg)
+			}
 		case *wire.MsgFilterAdd:
 			if p.cfg.Listeners.OnFilterAdd != nil {
 				p.cfg.Listeners.OnFilterAdd(p, msg)
@@ -1539,7 +1636,11 @@ out:
 				}
 			}
 			p.stallControl <- stallControlMsg{sccSendMessage, msg.msg}
-			e := p.writeMessage(msg.msg, msg.encoding)
+			encoding := msg.encoding
+			if atomic.LoadInt32(&p.local) != 0 {
+				encoding |= wire.LocalEncoding
+			}
+			e := p.writeMessage(msg.msg, encoding)
 			if e != nil {
 				p.Disconnect()
 				if p.shouldLogWriteError(e) {
@@ -1697,6 +1798,9 @@ func (p *Peer) readRemoteVersionMsg() (msg *wire.MsgVersion, e error) {
 	}
 	// Detect self connections.
 	if !AllowSelfConns && SentNonces.Exists(msg.Nonce) {
+		if p.inbound && p.cfg.OnSelfConnection != nil {
+			p.cfg.OnSelfConnection(msg.Nonce)
+		}
 		e = errors.New("disconnecting peer connected to self")
 		return
 	}
@@ -1712,6 +1816,12 @@ func (p *Peer) readRemoteVersionMsg() (msg *wire.MsgVersion, e error) {
 		"negotiated protocol version %d for peer %s",
 		p.protocolVersion, p,
 	)
+	// The messages after the version message of each side use the local encoding once both sides have offered it. The
+	// version messages themselves are always checksummed.
+	if p.offersLocal() && msg.Services&wire.SFNodeLocal != 0 {
+		atomic.StoreInt32(&p.local, 1)
+		D.Ln("using the local encoding with", p)
+	}
 	// Updating a bunch of stats including block based stats, and the peer's time offset.
 	p.statsMtx.Lock()
 	p.lastBlock = msg.LastBlock
@@ -1826,6 +1936,9 @@ func (p *Peer) localVersionMsg() (mv *wire.MsgVersion, e error) {
 	}
 	// Advertise local services.
 	msg.Services = p.cfg.Services
+	if p.offersLocal() {
+		msg.Services |= wire.SFNodeLocal
+	}
 	// Advertise our max supported protocol version.
 	msg.ProtocolVersion = int32(p.cfg.ProtocolVersion)
 	// Advertise if inv messages for transactions are desired.
@@ -390,6 +390,12 @@ func TestPeerListeners(t *testing.T) {
 			OnFeeFilter: func(p *peer.Peer, msg *wire.MsgFeeFilter) {
 				ok <- msg
 			},
+			OnGetUTXOs: func(p *peer.Peer, msg *wire.MsgGetUTXOs) {
+				ok <- msg
+			},
+			OnUTXOs: func(p *peer.Peer, msg *wire.MsgUTXOs) {
+				ok <- msg
+			},
 			OnFilterAdd: func(p *peer.Peer, msg *wire.MsgFilterAdd) {
 				ok <- msg
 			},
@@ -540,6 +546,14 @@ func TestPeerListeners(t *testing.T) {
 			"OnFeeFilter",
 			wire.NewMsgFeeFilter(15000),
 		},
+		{
+			"OnGetUTXOs",
+			wire.NewMsgGetUTXOs(true),
+		},
+		{
+			"OnUTXOs",
+			wire.NewMsgUTXOs(0, &chainhash.Hash{}),
+		},
 		{
 			"OnFilterAdd",
 			wire.NewMsgFilterAdd([]byte{0x01}),
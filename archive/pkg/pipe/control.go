@@ -0,0 +1,51 @@
+package pipe
+
+import (
+	"errors"
+	"io"
+)
+
+// Control messages are sent both ways over the pipe between a parent and a worker process. Each starts with a four byte
+// magic that names what it is, followed by its payload.
+const (
+	// MagicRun, MagicStop, MagicLevel and MagicKill are sent to a worker to start and stop sending its log entries, to
+	// set its log level and to shut it down.
+	MagicRun   = "run "
+	MagicStop  = "stop"
+	MagicLevel = "slvl"
+	MagicKill  = "kill"
+	// MagicEntry is sent by a worker with each of its log entries.
+	MagicEntry = "entr"
+)
+
+// ControlHandlers handle the control messages received over a pipe by their magic. Each handler is given the whole
+// message, magic included, as some payloads are encoded along with it.
+type ControlHandlers map[string]func(b []byte) (e error)
+
+// Handle passes a message to the handler for its magic, ignoring messages that are too short to have one or that have
+// no handler.
+func (h ControlHandlers) Handle(b []byte) (e error) {
+	if len(b) < 4 {
+		return
+	}
+	if handler, ok := h[string(b[:4])]; ok {
+		e = handler(b)
+	}
+	return
+}
+
+// SendControl writes a control message with the magic and payload to the pipe in a single write, so it is read as one
+// message on the other side.
+func SendControl(w io.Writer, magic string, payload []byte) (e error) {
+	if len(magic) != 4 {
+		return errors.New("control message magic must be four bytes: " + magic)
+	}
+	var n int
+	if n, e = w.Write(append([]byte(magic), payload...)); E.Chk(e) {
+		return
+	}
+	if n < len(magic)+len(payload) {
+		e = io.ErrShortWrite
+	}
+	return
+}
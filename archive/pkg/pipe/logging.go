@@ -14,46 +14,40 @@ func LogConsume(
 	filter func(pkg string) (out bool), args ...string,
 ) *Worker {
 	D.Ln("starting log consumer")
+	// we are only listening for entries
 	return Consume(
-		quit, func(b []byte) (e error) {
-			// we are only listening for entries
-			if len(b) >= 4 {
-				magic := string(b[:4])
-				switch magic {
-				case "entr":
-					var ent log.Entry
-					n := gotiny.Unmarshal(b, &ent)
-					D.Ln("consume", n)
-					if filter(ent.Package) {
-						// if the worker filter is out of sync this stops it printing
-						return
-					}
-					switch ent.Level {
-					case log.Fatal:
-					case log.Error:
-					case log.Warn:
-					case log.Info:
-					case log.Check:
-					case log.Debug:
-					case log.Trace:
-					default:
-						D.Ln("got an empty log entry")
-						return
-					}
-					if e = handler(&ent); E.Chk(e) {
-					}
+		quit, ControlHandlers{
+			MagicEntry: func(b []byte) (e error) {
+				var ent log.Entry
+				n := gotiny.Unmarshal(b, &ent)
+				D.Ln("consume", n)
+				if filter(ent.Package) {
+					// if the worker filter is out of sync this stops it printing
+					return
 				}
-			}
-			return
-		}, args...,
+				switch ent.Level {
+				case log.Fatal:
+				case log.Error:
+				case log.Warn:
+				case log.Info:
+				case log.Check:
+				case log.Debug:
+				case log.Trace:
+				default:
+					D.Ln("got an empty log entry")
+					return
+				}
+				if e = handler(&ent); E.Chk(e) {
+				}
+				return
+			},
+		}.Handle, args...,
 	)
 }
 
 func Start(w *Worker) {
 	D.Ln("sending start signal")
-	var n int
-	var e error
-	if n, e = w.StdConn.Write([]byte("run ")); n < 1 || E.Chk(e) {
+	if e := SendControl(w.StdConn, MagicRun, nil); e != nil {
 		D.Ln("failed to write", w.Args)
 	}
 }
@@ -61,9 +55,7 @@ func Start(w *Worker) {
 // Stop running the worker
 func Stop(w *Worker) {
 	D.Ln("sending stop signal")
-	var n int
-	var e error
-	if n, e = w.StdConn.Write([]byte("stop")); n < 1 || E.Chk(e) {
+	if e := SendControl(w.StdConn, MagicStop, nil); e != nil {
 		D.Ln("failed to write", w.Args)
 	}
 }
@@ -75,13 +67,12 @@ func Kill(w *Worker) {
 		D.Ln("asked to kill worker that is already nil")
 		return
 	}
-	var n int
 	D.Ln("sending kill signal")
-	if n, e = w.StdConn.Write([]byte("kill")); n < 1 || E.Chk(e) {
+	if e = SendControl(w.StdConn, MagicKill, nil); e != nil {
 		D.Ln("failed to write")
 		return
 	}
-	if e = w.Cmd.Wait(); E.Chk(e) {
+	if e = w.Wait(); E.Chk(e) {
 	}
 	D.Ln("sent kill signal")
 }
@@ -98,10 +89,7 @@ func SetLevel(w *Worker, level string) {
 			lvl = i
 		}
 	}
-	var n int
-	var e error
-	if n, e = w.StdConn.Write([]byte("slvl" + string(byte(lvl)))); n < 1 ||
-		E.Chk(e) {
+	if e := SendControl(w.StdConn, MagicLevel, []byte{byte(lvl)}); e != nil {
 		D.Ln("failed to write")
 	}
 }
@@ -112,29 +100,34 @@ func LogServe(quit qu.C, appName string) {
 	lc := log.AddLogChan()
 	var logOn atomic.Bool
 	logOn.Store(false)
+	// listen for commands to enable/disable logging
 	p := Serve(
-		quit, func(b []byte) (e error) {
-			// listen for commands to enable/disable logging
-			if len(b) >= 4 {
-				magic := string(b[:4])
-				switch magic {
-				case "run ":
-					D.Ln("setting to run")
-					logOn.Store(true)
-				case "stop":
-					D.Ln("stopping")
-					logOn.Store(false)
-				case "slvl":
-					D.Ln("setting level", log.Levels[b[4]])
-					log.SetLogLevel(log.Levels[b[4]])
-				case "kill":
-					D.Ln("received kill signal from pipe, shutting down", appName)
-					interrupt.Request()
-					quit.Q()
+		quit, ControlHandlers{
+			MagicRun: func(b []byte) (e error) {
+				D.Ln("setting to run")
+				logOn.Store(true)
+				return
+			},
+			MagicStop: func(b []byte) (e error) {
+				D.Ln("stopping")
+				logOn.Store(false)
+				return
+			},
+			MagicLevel: func(b []byte) (e error) {
+				if len(b) < 5 || int(b[4]) >= len(log.Levels) {
+					return
 				}
-			}
-			return
-		},
+				D.Ln("setting level", log.Levels[b[4]])
+				log.SetLogLevel(log.Levels[b[4]])
+				return
+			},
+			MagicKill: func(b []byte) (e error) {
+				D.Ln("received kill signal from pipe, shutting down", appName)
+				interrupt.Request()
+				quit.Q()
+				return
+			},
+		}.Handle,
 	)
 	go func() {
 	out:
@@ -0,0 +1,284 @@
+package pipe
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/p9c/qu"
+)
+
+// The states a supervised process goes through.
+const (
+	StateStarting = "starting"
+	StateRunning  = "running"
+	StateBackoff  = "backoff"
+	StateStopped  = "stopped"
+	StateFailed   = "failed"
+)
+
+// shutdownGrace is how long a process has to exit after being asked to before it is killed.
+const shutdownGrace = time.Second * 10
+
+// RestartPolicy sets how a supervised process is restarted when it exits without being asked to.
+type RestartPolicy struct {
+	// MaxRestarts is how many times in a row the process is restarted before the supervisor gives up on it, negative
+	// for no limit.
+	MaxRestarts int
+	// Backoff is how long to wait before the first restart in a row, which doubles with each further one up to
+	// MaxBackoff.
+	Backoff, MaxBackoff time.Duration
+	// Stable is how long the process must run for before its next restart is no longer counted as in a row, zero for
+	// counting every restart.
+	Stable time.Duration
+}
+
+// DefaultRestartPolicy restarts a process up to five times in a row, waiting from a second up to a minute between.
+var DefaultRestartPolicy = RestartPolicy{
+	MaxRestarts: 5,
+	Backoff:     time.Second,
+	MaxBackoff:  time.Minute,
+	Stable:      time.Minute,
+}
+
+// delay returns how long to wait before restarting a process that has already been restarted the number of times in a
+// row, and false when the policy gives up on it.
+func (p RestartPolicy) delay(restarts int) (d time.Duration, ok bool) {
+	if p.MaxRestarts >= 0 && restarts >= p.MaxRestarts {
+		return
+	}
+	d = p.Backoff
+	for i := 0; i < restarts && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d, true
+}
+
+// Status is a report of the state of a supervised process.
+type Status struct {
+	Name  string
+	State string
+	// PID is the process id while it is running.
+	PID int
+	// Restarts is how many times the process has been restarted, and InRow how many of those were in a row without it
+	// running stably in between.
+	Restarts, InRow int
+	// Since is when the process entered the state.
+	Since time.Time
+	// LastExit is the error the process last exited with without being asked to.
+	LastExit string
+}
+
+// SupervisorConfig is the configuration of a supervised process.
+type SupervisorConfig struct {
+	Name string
+	// Args are the executable and arguments of the process, which is started with Spawn unless the Spawn function is
+	// set, for processes that need more set up, such as consuming their logs.
+	Args  []string
+	Spawn func() (w *Worker, e error)
+	// Started is called with each process once it is started, to attach to its connection. The process is killed and
+	// restarted under the policy if it returns an error.
+	Started func(w *Worker) (e error)
+	// Stop asks a process to shut down when the supervisor is stopped, and the process is killed if it is not set, or
+	// if it has not exited within the shutdown grace period.
+	Stop func(w *Worker) (e error)
+	// Policy sets how the process is restarted when it exits without being asked to.
+	Policy RestartPolicy
+	// Report is called with the status of the process each time its state changes, if it is set.
+	Report func(st Status)
+}
+
+// Supervisor runs a child process, restarting it under a restart policy when it exits without being asked to, and
+// reports on its state.
+type Supervisor struct {
+	cfg    SupervisorConfig
+	quit   qu.C
+	mx     sync.Mutex
+	worker *Worker
+	status Status
+	// stop is closed to stop supervising, and done once the process has been shut down, for the current run.
+	stop, done qu.C
+}
+
+// NewSupervisor creates a supervisor for the configured process, which is started with Start. The process is shut down
+// when quit is closed.
+func NewSupervisor(cfg SupervisorConfig, quit qu.C) *Supervisor {
+	return &Supervisor{
+		cfg:  cfg,
+		quit: quit,
+		status: Status{
+			Name:  cfg.Name,
+			State: StateStopped,
+			Since: time.Now(),
+		},
+	}
+}
+
+// Start starts the process and supervises it until Stop is called or quit is closed. It does nothing while the process
+// is already supervised.
+func (s *Supervisor) Start() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.done != nil {
+		select {
+		case <-s.done.Wait():
+		default:
+			return
+		}
+	}
+	s.stop, s.done = qu.T(), qu.T()
+	go s.supervise(s.stop, s.done)
+}
+
+// Stop shuts the process down without restarting it, and returns once it has exited.
+func (s *Supervisor) Stop() {
+	s.mx.Lock()
+	stop, done := s.stop, s.done
+	s.mx.Unlock()
+	if stop == nil {
+		return
+	}
+	stop.Q()
+	<-done.Wait()
+}
+
+// Status returns the current status of the process.
+func (s *Supervisor) Status() Status {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.status
+}
+
+// Worker returns the current process, which is nil before it is first started.
+func (s *Supervisor) Worker() *Worker {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.worker
+}
+
+// Send sends a control message to the process while it is running.
+func (s *Supervisor) Send(magic string, payload []byte) (e error) {
+	s.mx.Lock()
+	w, state := s.worker, s.status.State
+	s.mx.Unlock()
+	if w == nil || state != StateRunning {
+		return errors.New(s.cfg.Name + " is not running")
+	}
+	return SendControl(w.StdConn, magic, payload)
+}
+
+func (s *Supervisor) supervise(stop, done qu.C) {
+	defer done.Q()
+	var inRow int
+	for {
+		s.report(StateStarting, nil, nil, inRow)
+		w, e := s.spawn()
+		var started time.Time
+		if w != nil {
+			s.mx.Lock()
+			s.worker = w
+			s.mx.Unlock()
+			if e == nil && s.cfg.Started != nil {
+				if e = s.cfg.Started(w); E.Chk(e) {
+					_ = w.Kill()
+				}
+			}
+			if e == nil {
+				started = time.Now()
+				s.report(StateRunning, w, nil, inRow)
+			}
+			select {
+			case <-w.Exited().Wait():
+				if e == nil {
+					if e = w.Wait(); e == nil {
+						e = errors.New("exited")
+					}
+				}
+			case <-stop.Wait():
+				s.shutdown(w)
+				s.report(StateStopped, nil, nil, inRow)
+				return
+			case <-s.quit.Wait():
+				s.shutdown(w)
+				s.report(StateStopped, nil, nil, inRow)
+				return
+			}
+		}
+		if s.cfg.Policy.Stable > 0 && !started.IsZero() && time.Since(started) >= s.cfg.Policy.Stable {
+			inRow = 0
+		}
+		delay, ok := s.cfg.Policy.delay(inRow)
+		if !ok {
+			E.Ln(s.cfg.Name, "exited", inRow, "times in a row, giving up on it:", e)
+			s.report(StateFailed, nil, e, inRow)
+			return
+		}
+		inRow++
+		W.Ln(s.cfg.Name, "exited, restarting in", delay, "-", e)
+		s.report(StateBackoff, nil, e, inRow)
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-stop.Wait():
+			t.Stop()
+			s.report(StateStopped, nil, nil, inRow)
+			return
+		case <-s.quit.Wait():
+			t.Stop()
+			s.report(StateStopped, nil, nil, inRow)
+			return
+		}
+	}
+}
+
+func (s *Supervisor) spawn() (w *Worker, e error) {
+	if s.cfg.Spawn != nil {
+		return s.cfg.Spawn()
+	}
+	return Spawn(s.quit, s.cfg.Args...)
+}
+
+// shutdown asks the process to stop and waits for it to exit, killing it if it does not exit in the grace period.
+func (s *Supervisor) shutdown(w *Worker) {
+	if s.cfg.Stop != nil {
+		go func() {
+			if e := s.cfg.Stop(w); E.Chk(e) {
+			}
+		}()
+	} else if e := w.Kill(); E.Chk(e) {
+	}
+	t := time.NewTimer(shutdownGrace)
+	defer t.Stop()
+	select {
+	case <-w.Exited().Wait():
+	case <-t.C:
+		W.Ln(s.cfg.Name, "did not stop in", shutdownGrace, "killing it")
+		if e := w.Kill(); E.Chk(e) {
+		}
+		<-w.Exited().Wait()
+	}
+}
+
+// report updates the status of the process and passes it to the report function.
+func (s *Supervisor) report(state string, w *Worker, exit error, inRow int) {
+	s.mx.Lock()
+	if state == StateBackoff {
+		s.status.Restarts++
+	}
+	s.status.State, s.status.InRow, s.status.Since, s.status.PID = state, inRow, time.Now(), 0
+	if w != nil && w.Cmd.Process != nil {
+		s.status.PID = w.Cmd.Process.Pid
+	}
+	if exit != nil {
+		s.status.LastExit = exit.Error()
+	}
+	st := s.status
+	s.mx.Unlock()
+	D.Ln(st.Name, "is", st.State)
+	if s.cfg.Report != nil {
+		s.cfg.Report(st)
+	}
+}
@@ -0,0 +1,97 @@
+package pipe
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/p9c/qu"
+)
+
+// TestRestartPolicy ensures the wait before each restart in a row doubles up to the maximum, and that the policy gives
+// up after the maximum number of restarts unless it has no limit.
+func TestRestartPolicy(t *testing.T) {
+	want := []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 16}
+	for i := range want {
+		if d, ok := DefaultRestartPolicy.delay(i); !ok || d != want[i] {
+			t.Fatalf("restart %d waits %v %v, want %v", i, d, ok, want[i])
+		}
+	}
+	if _, ok := DefaultRestartPolicy.delay(len(want)); ok {
+		t.Fatal("the policy did not give up after the maximum number of restarts")
+	}
+	p := RestartPolicy{MaxRestarts: -1, Backoff: time.Second, MaxBackoff: time.Minute}
+	if d, ok := p.delay(1000); !ok || d != time.Minute {
+		t.Fatalf("restart 1000 with no limit waits %v %v, want a minute", d, ok)
+	}
+}
+
+// TestSupervisor ensures a process that keeps exiting is restarted under the policy until it is given up on, and that a
+// running process is shut down when the supervisor is stopped.
+func TestSupervisor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a unix shell")
+	}
+	quit := qu.T()
+	defer quit.Q()
+	states := make(chan Status, 16)
+	s := NewSupervisor(
+		SupervisorConfig{
+			Name:   "failing",
+			Args:   []string{"sh", "-c", "exit 3"},
+			Policy: RestartPolicy{MaxRestarts: 2, Backoff: time.Millisecond, MaxBackoff: time.Millisecond * 2},
+			Report: func(st Status) {
+				states <- st
+			},
+		}, quit,
+	)
+	s.Start()
+	var got []string
+	timeout := time.After(time.Second * 10)
+	for len(got) == 0 || got[len(got)-1] != StateFailed {
+		select {
+		case st := <-states:
+			got = append(got, st.State)
+		case <-timeout:
+			t.Fatalf("the process was not given up on, states %v", got)
+		}
+	}
+	if n := strings.Count(strings.Join(got, " "), StateStarting); n != 3 {
+		t.Fatalf("the process was started %d times, want 3, states %v", n, got)
+	}
+	if st := s.Status(); st.Restarts != 2 || !strings.Contains(st.LastExit, "exit status 3") {
+		t.Fatalf("got status %+v", st)
+	}
+	s = NewSupervisor(
+		SupervisorConfig{
+			Name:   "sleeping",
+			Args:   []string{"sleep", "60"},
+			Policy: DefaultRestartPolicy,
+		}, quit,
+	)
+	s.Start()
+	for s.Status().State != StateRunning {
+		time.Sleep(time.Millisecond * 10)
+	}
+	pid := s.Status().PID
+	if pid == 0 {
+		t.Fatal("a running process has no pid")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second * 5):
+		t.Fatal("the process was not stopped")
+	}
+	if st := s.Status(); st.State != StateStopped || st.Restarts != 0 {
+		t.Fatalf("got status %+v after stopping", st)
+	}
+	if e := s.Worker().Wait(); e == nil {
+		t.Fatal("the process exited cleanly after being killed")
+	}
+}
@@ -16,33 +16,57 @@ type Worker struct {
 	// Stderr  io.WriteCloser
 	// StdPipe io.ReadCloser
 	StdConn *StdConn
+	// exited is closed once the process has exited, with the error it exited with in exitErr, so any number of callers
+	// can wait for it.
+	exited  qu.C
+	exitErr error
 }
 
 // Spawn starts up an arbitrary executable file with given arguments and
 // attaches a connection to its stdin/stdout
 func Spawn(quit qu.C, args ...string) (w *Worker, e error) {
 	w = &Worker{
-		Cmd:  exec.Command(args[0], args[1:]...),
-		Args: args,
+		Cmd:    exec.Command(args[0], args[1:]...),
+		Args:   args,
+		exited: qu.T(),
 	}
 	var cmdOut io.ReadCloser
 	if cmdOut, e = w.Cmd.StdoutPipe(); E.Chk(e) {
+		w.exit(e)
 		return
 	}
 	var cmdIn io.WriteCloser
 	if cmdIn, e = w.Cmd.StdinPipe(); E.Chk(e) {
+		w.exit(e)
 		return
 	}
 	w.StdConn = New(cmdOut, cmdIn, quit)
 	w.Cmd.Stderr = os.Stderr
 	if e = w.Cmd.Start(); E.Chk(e) {
+		w.exit(e)
+		return
 	}
+	go func() {
+		w.exit(w.Cmd.Wait())
+	}()
 	return
 }
 
-// Wait for the process to finish running
+func (w *Worker) exit(e error) {
+	w.exitErr = e
+	w.exited.Q()
+}
+
+// Wait for the process to finish running, returning the error it exited with. Unlike exec.Cmd.Wait it may be called
+// any number of times, from any number of goroutines.
 func (w *Worker) Wait() (e error) {
-	return w.Cmd.Wait()
+	<-w.exited.Wait()
+	return w.exitErr
+}
+
+// Exited returns a channel that is closed once the process has exited.
+func (w *Worker) Exited() qu.C {
+	return w.exited
 }
 
 // Interrupt the child process.
@@ -0,0 +1,11 @@
+/*
+Package psbt encodes and decodes partially signed transactions in the format of BIP 174, which carries an unsigned
+transaction along with what a signer needs to know to sign it, such as the transactions whose outputs it spends and the
+derivation paths of the keys that sign them, and the signatures as they are added.
+
+A watching-only wallet creates the packet, an offline signer adds its signatures to it, and the wallet finalizes the
+inputs into their signature scripts and extracts the signed transaction to broadcast it. The fields of the format for
+segregated witness are kept as unknown fields, as it is not active on this chain, and the inputs that can be finalized
+are those paying to a public key, a public key hash, or a script hash of a multisig or either of the others.
+*/
+package psbt
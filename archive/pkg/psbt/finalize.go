@@ -0,0 +1,350 @@
+package psbt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// PrevOut returns the output spent by input i, from the transaction carried with the input.
+func (p *Packet) PrevOut(i int) (out *wire.TxOut, e error) {
+	in := &p.Inputs[i]
+	if in.NonWitnessUtxo == nil {
+		return nil, fmt.Errorf("psbt: input %d does not carry the transaction it spends", i)
+	}
+	prev := p.UnsignedTx.TxIn[i].PreviousOutPoint
+	if in.NonWitnessUtxo.TxHash() != prev.Hash {
+		return nil, fmt.Errorf("psbt: input %d carries a transaction other than the one it spends", i)
+	}
+	if int(prev.Index) >= len(in.NonWitnessUtxo.TxOut) {
+		return nil, fmt.Errorf("psbt: input %d spends output %d which does not exist", i, prev.Index)
+	}
+	return in.NonWitnessUtxo.TxOut[prev.Index], nil
+}
+
+// IsComplete returns whether all inputs of the packet are finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if p.Inputs[i].FinalScriptSig == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Finalize builds the signature script of input i from its signatures, if it is not finalized already, and removes the
+// fields only needed to sign it. An input paying to a public key, a public key hash, or a script hash of a multisig or
+// either of the others can be finalized once it has the signatures it needs.
+func Finalize(p *Packet, i int) (e error) {
+	in := &p.Inputs[i]
+	if in.FinalScriptSig != nil {
+		return
+	}
+	var prevOut *wire.TxOut
+	if prevOut, e = p.PrevOut(i); e != nil {
+		return
+	}
+	script := prevOut.PkScript
+	var redeemScript []byte
+	if txscript.GetScriptClass(script) == txscript.ScriptHashTy {
+		if script, e = redeemedScript(script, in.RedeemScript, i); e != nil {
+			return
+		}
+		redeemScript = script
+	}
+	b := txscript.NewScriptBuilder()
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyHashTy:
+		sig := in.sigMatching(
+			func(pubKey []byte) bool {
+				return bytes.Equal(script[3:23], btcaddr.Hash160(pubKey))
+			},
+		)
+		if sig == nil {
+			return fmt.Errorf("psbt: input %d has no signature of the key it pays to", i)
+		}
+		b.AddData(sig.Signature).AddData(sig.PubKey)
+	case txscript.PubKeyTy:
+		pushes, _ := txscript.PushedData(script)
+		sig := in.sigMatching(
+			func(pubKey []byte) bool {
+				return bytes.Equal(pushes[0], pubKey)
+			},
+		)
+		if sig == nil {
+			return fmt.Errorf("psbt: input %d has no signature of the key it pays to", i)
+		}
+		b.AddData(sig.Signature)
+	case txscript.MultiSigTy:
+		var required int
+		if _, required, e = txscript.CalcMultiSigStats(script); e != nil {
+			return
+		}
+		pubKeys, _ := txscript.PushedData(script)
+		// Signatures must be in the order of the keys of the script. A multisig input takes an extra item off the stack
+		// because of a bug in OP_CHECKMULTISIG.
+		b.AddOp(txscript.OP_0)
+		var n int
+		for _, pubKey := range pubKeys {
+			if n == required {
+				break
+			}
+			sig := in.sigMatching(
+				func(k []byte) bool {
+					return bytes.Equal(k, pubKey)
+				},
+			)
+			if sig != nil {
+				b.AddData(sig.Signature)
+				n++
+			}
+		}
+		if n < required {
+			return fmt.Errorf("psbt: input %d has %d of the %d signatures it needs", i, n, required)
+		}
+	default:
+		return fmt.Errorf("psbt: input %d pays to a script that can't be finalized", i)
+	}
+	if redeemScript != nil {
+		b.AddData(redeemScript)
+	}
+	var sigScript []byte
+	if sigScript, e = b.Script(); e != nil {
+		return
+	}
+	in.FinalScriptSig = sigScript
+	in.PartialSigs, in.SighashType, in.RedeemScript, in.Bip32Derivation = nil, 0, nil, nil
+	return
+}
+
+// redeemedScript returns the redeem script of input i if it matches the script hash pkScript pays to.
+func redeemedScript(pkScript, redeemScript []byte, i int) ([]byte, error) {
+	if redeemScript == nil {
+		return nil, fmt.Errorf("psbt: input %d spends a script hash but has no redeem script", i)
+	}
+	if !bytes.Equal(pkScript[2:22], btcaddr.Hash160(redeemScript)) {
+		return nil, fmt.Errorf("psbt: redeem script of input %d does not match the script hash it spends", i)
+	}
+	return redeemScript, nil
+}
+
+// paysTo returns whether the script pays to the public key, alone or as one of the keys of a multisig.
+func paysTo(script, pubKey []byte) bool {
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyHashTy:
+		return bytes.Equal(script[3:23], btcaddr.Hash160(pubKey))
+	case txscript.PubKeyTy, txscript.MultiSigTy:
+		pushes, _ := txscript.PushedData(script)
+		for _, k := range pushes {
+			if bytes.Equal(k, pubKey) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckSigs returns an error if a signature other, a packet for the same transaction, has for an input of p is not a
+// valid signature of the transaction by a key the output the input spends pays to, or if a final signature script of
+// other does not spend the output. The outputs spent come from p, and a redeem script from other is only used for an
+// input p has none for, so the signatures of a packet from a signer can be checked against the packet it was given
+// before they are combined with it.
+func CheckSigs(p, other *Packet) (e error) {
+	if p.UnsignedTx == nil || other.UnsignedTx == nil {
+		return ErrNoUnsignedTx
+	}
+	if p.UnsignedTx.TxHash() != other.UnsignedTx.TxHash() || len(p.Inputs) != len(other.Inputs) {
+		return ErrDifferentTx
+	}
+	for i := range p.Inputs {
+		in, o := &p.Inputs[i], &other.Inputs[i]
+		if in.FinalScriptSig != nil || o.FinalScriptSig == nil && len(o.PartialSigs) == 0 {
+			continue
+		}
+		var prevOut *wire.TxOut
+		if prevOut, e = p.PrevOut(i); e != nil {
+			return
+		}
+		if o.FinalScriptSig != nil {
+			tx := p.UnsignedTx.Copy()
+			tx.TxIn[i].SignatureScript = o.FinalScriptSig
+			var vm *txscript.Engine
+			if vm, e = txscript.NewEngine(
+				prevOut.PkScript, tx, i, txscript.StandardVerifyFlags, nil, nil, prevOut.Value,
+			); e != nil {
+				return
+			}
+			if e = vm.Execute(); e != nil {
+				return fmt.Errorf("psbt: final signature script of input %d is not valid: %v", i, e)
+			}
+			continue
+		}
+		script := prevOut.PkScript
+		if txscript.GetScriptClass(script) == txscript.ScriptHashTy {
+			redeemScript := in.RedeemScript
+			if redeemScript == nil {
+				redeemScript = o.RedeemScript
+			}
+			if script, e = redeemedScript(script, redeemScript, i); e != nil {
+				return
+			}
+		}
+		for _, sig := range o.PartialSigs {
+			if e = checkSig(p, i, script, sig); e != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// checkSig returns an error if sig is not a valid signature of input i of the packet by a key the script it signs pays
+// to, with the sighash type of the input if it has one.
+func checkSig(p *Packet, i int, script []byte, sig *PartialSig) (e error) {
+	var pubKey *ecc.PublicKey
+	if pubKey, e = ecc.ParsePubKey(sig.PubKey, ecc.S256()); e != nil {
+		return fmt.Errorf("psbt: public key %x of input %d is not valid: %v", sig.PubKey, i, e)
+	}
+	if !paysTo(script, sig.PubKey) {
+		return fmt.Errorf("psbt: input %d does not pay to public key %x", i, sig.PubKey)
+	}
+	if len(sig.Signature) == 0 {
+		return fmt.Errorf("psbt: signature of public key %x for input %d is empty", sig.PubKey, i)
+	}
+	hashType := txscript.SigHashType(sig.Signature[len(sig.Signature)-1])
+	if p.Inputs[i].SighashType != 0 && hashType != p.Inputs[i].SighashType {
+		return fmt.Errorf(
+			"psbt: signature of public key %x for input %d has sighash type %d, not %d",
+			sig.PubKey, i, hashType, p.Inputs[i].SighashType,
+		)
+	}
+	var signature *ecc.Signature
+	if signature, e = ecc.ParseDERSignature(sig.Signature[:len(sig.Signature)-1], ecc.S256()); e != nil {
+		return fmt.Errorf("psbt: signature of public key %x for input %d is not valid: %v", sig.PubKey, i, e)
+	}
+	var hash []byte
+	if hash, e = txscript.CalcSignatureHash(script, hashType, p.UnsignedTx, i); e != nil {
+		return
+	}
+	if !signature.Verify(hash, pubKey) {
+		return fmt.Errorf("psbt: signature of public key %x for input %d does not sign the transaction", sig.PubKey, i)
+	}
+	return
+}
+
+// FinalizeAll finalizes all inputs of the packet.
+func FinalizeAll(p *Packet) (e error) {
+	for i := range p.Inputs {
+		if e = Finalize(p, i); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// sigMatching returns the signature of the input by the first key match returns true for, or nil if there is none.
+func (in *Input) sigMatching(match func(pubKey []byte) bool) *PartialSig {
+	for _, sig := range in.PartialSigs {
+		if match(sig.PubKey) {
+			return sig
+		}
+	}
+	return nil
+}
+
+// Extract returns the signed transaction of a packet whose inputs are all finalized.
+func Extract(p *Packet) (tx *wire.MsgTx, e error) {
+	if !p.IsComplete() {
+		return nil, ErrIncomplete
+	}
+	tx = p.UnsignedTx.Copy()
+	for i := range tx.TxIn {
+		tx.TxIn[i].SignatureScript = p.Inputs[i].FinalScriptSig
+	}
+	return
+}
+
+// Combine adds the fields of other, a packet for the same transaction, that p does not have to p, such as the
+// signatures another signer added to its copy.
+func Combine(p, other *Packet) (e error) {
+	if p.UnsignedTx == nil || other.UnsignedTx == nil {
+		return ErrNoUnsignedTx
+	}
+	if p.UnsignedTx.TxHash() != other.UnsignedTx.TxHash() ||
+		len(p.Inputs) != len(other.Inputs) || len(p.Outputs) != len(other.Outputs) {
+		return ErrDifferentTx
+	}
+	p.Unknowns = combineUnknowns(p.Unknowns, other.Unknowns)
+	for i := range p.Inputs {
+		in, o := &p.Inputs[i], &other.Inputs[i]
+		if in.NonWitnessUtxo == nil {
+			in.NonWitnessUtxo = o.NonWitnessUtxo
+		}
+		if in.FinalScriptSig != nil {
+			continue
+		}
+		if o.FinalScriptSig != nil {
+			in.FinalScriptSig = o.FinalScriptSig
+			in.PartialSigs, in.SighashType, in.RedeemScript, in.Bip32Derivation = nil, 0, nil, nil
+			continue
+		}
+		for _, sig := range o.PartialSigs {
+			if in.sigMatching(
+				func(pubKey []byte) bool {
+					return bytes.Equal(pubKey, sig.PubKey)
+				},
+			) == nil {
+				in.PartialSigs = append(in.PartialSigs, sig)
+			}
+		}
+		if in.SighashType == 0 {
+			in.SighashType = o.SighashType
+		}
+		if in.RedeemScript == nil {
+			in.RedeemScript = o.RedeemScript
+		}
+		in.Bip32Derivation = combineDerivations(in.Bip32Derivation, o.Bip32Derivation)
+		in.Unknowns = combineUnknowns(in.Unknowns, o.Unknowns)
+	}
+	for i := range p.Outputs {
+		out, o := &p.Outputs[i], &other.Outputs[i]
+		if out.RedeemScript == nil {
+			out.RedeemScript = o.RedeemScript
+		}
+		out.Bip32Derivation = combineDerivations(out.Bip32Derivation, o.Bip32Derivation)
+		out.Unknowns = combineUnknowns(out.Unknowns, o.Unknowns)
+	}
+	return
+}
+
+// combineDerivations returns the derivations of a with those of the keys in b that a does not have.
+func combineDerivations(a, b []*Bip32Derivation) []*Bip32Derivation {
+next:
+	for _, d := range b {
+		for _, have := range a {
+			if bytes.Equal(have.PubKey, d.PubKey) {
+				continue next
+			}
+		}
+		a = append(a, d)
+	}
+	return a
+}
+
+// combineUnknowns returns the unknown fields of a with those of b whose keys a does not have.
+func combineUnknowns(a, b []*Unknown) []*Unknown {
+next:
+	for _, u := range b {
+		for _, have := range a {
+			if bytes.Equal(have.Key, u.Key) {
+				continue next
+			}
+		}
+		a = append(a, u)
+	}
+	return a
+}
@@ -0,0 +1,400 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// magic starts every serialized packet, "psbt" followed by 0xff.
+var magic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// The types of the keys of the fields of a packet that are known. Keys of other types are kept as unknown fields.
+const (
+	globalUnsignedTx   = 0x00
+	inNonWitnessUtxo   = 0x00
+	inPartialSig       = 0x02
+	inSighashType      = 0x03
+	inRedeemScript     = 0x04
+	inBip32Derivation  = 0x06
+	inFinalScriptSig   = 0x07
+	outRedeemScript    = 0x00
+	outBip32Derivation = 0x02
+)
+
+// maxFieldSize is the largest key or value read from a serialized packet.
+const maxFieldSize = wire.MaxBlockPayload
+
+var (
+	// ErrInvalidMagic is returned when the data parsed is not a packet.
+	ErrInvalidMagic = errors.New("psbt: data does not start with the magic bytes of a packet")
+	// ErrNoUnsignedTx is returned when a packet does not have its unsigned transaction.
+	ErrNoUnsignedTx = errors.New("psbt: packet has no unsigned transaction")
+	// ErrIncomplete is returned when a transaction is extracted from a packet whose inputs are not all finalized.
+	ErrIncomplete = errors.New("psbt: not all inputs of the packet are finalized")
+	// ErrDifferentTx is returned when packets for different transactions are combined.
+	ErrDifferentTx = errors.New("psbt: packets are for different transactions")
+)
+
+// Unknown is a field of a packet whose key type is not known, which is kept so that it is passed on unchanged.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PartialSig is a signature of an input by one of the keys it needs, with the signature hash type appended.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// Bip32Derivation is the fingerprint of the master key a public key was derived from and the path it was derived on.
+type Bip32Derivation struct {
+	PubKey      []byte
+	Fingerprint [4]byte
+	Path        []uint32
+}
+
+// Input is what a packet carries for an input of its transaction.
+type Input struct {
+	// NonWitnessUtxo is the transaction whose output the input spends.
+	NonWitnessUtxo  *wire.MsgTx
+	PartialSigs     []*PartialSig
+	SighashType     txscript.SigHashType
+	RedeemScript    []byte
+	Bip32Derivation []*Bip32Derivation
+	// FinalScriptSig is the signature script of the input once it is finalized.
+	FinalScriptSig []byte
+	Unknowns       []*Unknown
+}
+
+// Output is what a packet carries for an output of its transaction, which lets a signer recognise its change.
+type Output struct {
+	RedeemScript    []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// Packet is a partially signed transaction.
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []Input
+	Outputs    []Output
+	Unknowns   []*Unknown
+}
+
+// New returns a packet for the unsigned transaction, which must not have signature scripts.
+func New(tx *wire.MsgTx) (p *Packet, e error) {
+	for i, in := range tx.TxIn {
+		if len(in.SignatureScript) != 0 {
+			return nil, fmt.Errorf("psbt: input %d of the transaction is signed", i)
+		}
+	}
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]Input, len(tx.TxIn)),
+		Outputs:    make([]Output, len(tx.TxOut)),
+	}, nil
+}
+
+// Parse decodes a serialized packet.
+func Parse(r io.Reader) (p *Packet, e error) {
+	prefix := make([]byte, len(magic))
+	if _, e = io.ReadFull(r, prefix); e != nil || !bytes.Equal(prefix, magic) {
+		return nil, ErrInvalidMagic
+	}
+	p = &Packet{}
+	if e = readMap(
+		r, func(key, value []byte) (e error) {
+			if key[0] != globalUnsignedTx || len(key) != 1 {
+				p.Unknowns = append(p.Unknowns, &Unknown{Key: key, Value: value})
+				return
+			}
+			tx := &wire.MsgTx{}
+			if e = tx.DeserializeNoWitness(bytes.NewReader(value)); e != nil {
+				return fmt.Errorf("psbt: can't decode the unsigned transaction: %v", e)
+			}
+			p.UnsignedTx = tx
+			return
+		},
+	); e != nil {
+		return nil, e
+	}
+	if p.UnsignedTx == nil {
+		return nil, ErrNoUnsignedTx
+	}
+	for i, in := range p.UnsignedTx.TxIn {
+		if len(in.SignatureScript) != 0 {
+			return nil, fmt.Errorf("psbt: input %d of the unsigned transaction is signed", i)
+		}
+	}
+	p.Inputs = make([]Input, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		if e = readMap(r, p.Inputs[i].decodeField); e != nil {
+			return nil, fmt.Errorf("psbt: input %d: %v", i, e)
+		}
+	}
+	p.Outputs = make([]Output, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		if e = readMap(r, p.Outputs[i].decodeField); e != nil {
+			return nil, fmt.Errorf("psbt: output %d: %v", i, e)
+		}
+	}
+	return
+}
+
+// ParseBase64 decodes a packet serialized in base64, the form packets are passed around in as text.
+func ParseBase64(s string) (p *Packet, e error) {
+	var b []byte
+	if b, e = base64.StdEncoding.DecodeString(s); e != nil {
+		return nil, fmt.Errorf("psbt: packet is not base64 encoded: %v", e)
+	}
+	return Parse(bytes.NewReader(b))
+}
+
+// readMap reads the fields of a map of a packet up to its separator, calling fn with each of them. Keys may not appear
+// twice in a map.
+func readMap(r io.Reader, fn func(key, value []byte) error) (e error) {
+	seen := make(map[string]struct{})
+	for {
+		var key []byte
+		if key, e = wire.ReadVarBytes(r, 0, maxFieldSize, "psbt key"); e != nil {
+			return
+		}
+		if len(key) == 0 {
+			return
+		}
+		if _, ok := seen[string(key)]; ok {
+			return fmt.Errorf("duplicate key %x", key)
+		}
+		seen[string(key)] = struct{}{}
+		var value []byte
+		if value, e = wire.ReadVarBytes(r, 0, maxFieldSize, "psbt value"); e != nil {
+			return
+		}
+		if e = fn(key, value); e != nil {
+			return
+		}
+	}
+}
+
+// decodeField sets the field of the input with the key to the value.
+func (in *Input) decodeField(key, value []byte) (e error) {
+	switch key[0] {
+	case inNonWitnessUtxo:
+		if len(key) != 1 {
+			break
+		}
+		tx := &wire.MsgTx{}
+		if e = tx.Deserialize(bytes.NewReader(value)); e != nil {
+			return fmt.Errorf("can't decode the transaction spent: %v", e)
+		}
+		in.NonWitnessUtxo = tx
+		return
+	case inPartialSig:
+		if e = checkPubKey(key[1:]); e != nil {
+			return
+		}
+		in.PartialSigs = append(in.PartialSigs, &PartialSig{PubKey: key[1:], Signature: value})
+		return
+	case inSighashType:
+		if len(key) != 1 {
+			break
+		}
+		if len(value) != 4 {
+			return errors.New("signature hash type is not 4 bytes")
+		}
+		in.SighashType = txscript.SigHashType(binary.LittleEndian.Uint32(value))
+		return
+	case inRedeemScript:
+		if len(key) != 1 {
+			break
+		}
+		in.RedeemScript = value
+		return
+	case inBip32Derivation:
+		var d *Bip32Derivation
+		if d, e = decodeBip32Derivation(key[1:], value); e != nil {
+			return
+		}
+		in.Bip32Derivation = append(in.Bip32Derivation, d)
+		return
+	case inFinalScriptSig:
+		if len(key) != 1 {
+			break
+		}
+		in.FinalScriptSig = value
+		return
+	}
+	in.Unknowns = append(in.Unknowns, &Unknown{Key: key, Value: value})
+	return
+}
+
+// decodeField sets the field of the output with the key to the value.
+func (out *Output) decodeField(key, value []byte) (e error) {
+	switch key[0] {
+	case outRedeemScript:
+		if len(key) != 1 {
+			break
+		}
+		out.RedeemScript = value
+		return
+	case outBip32Derivation:
+		var d *Bip32Derivation
+		if d, e = decodeBip32Derivation(key[1:], value); e != nil {
+			return
+		}
+		out.Bip32Derivation = append(out.Bip32Derivation, d)
+		return
+	}
+	out.Unknowns = append(out.Unknowns, &Unknown{Key: key, Value: value})
+	return
+}
+
+// checkPubKey returns an error if the key is not the length of a compressed or uncompressed public key.
+func checkPubKey(pubKey []byte) error {
+	if len(pubKey) != 33 && len(pubKey) != 65 {
+		return fmt.Errorf("public key %x is %d bytes", pubKey, len(pubKey))
+	}
+	return nil
+}
+
+// decodeBip32Derivation decodes the derivation of the public key, which is the fingerprint of the master key followed
+// by the indexes of the path, little endian.
+func decodeBip32Derivation(pubKey, value []byte) (d *Bip32Derivation, e error) {
+	if e = checkPubKey(pubKey); e != nil {
+		return
+	}
+	if len(value) < 4 || len(value)%4 != 0 {
+		return nil, fmt.Errorf("derivation of public key %x is %d bytes", pubKey, len(value))
+	}
+	d = &Bip32Derivation{PubKey: pubKey}
+	copy(d.Fingerprint[:], value)
+	for i := 4; i < len(value); i += 4 {
+		d.Path = append(d.Path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return
+}
+
+// encode returns the serialized derivation.
+func (d *Bip32Derivation) encode() []byte {
+	value := make([]byte, 4+4*len(d.Path))
+	copy(value, d.Fingerprint[:])
+	for i, index := range d.Path {
+		binary.LittleEndian.PutUint32(value[4+4*i:], index)
+	}
+	return value
+}
+
+// Serialize writes the packet in its binary form.
+func (p *Packet) Serialize(w io.Writer) (e error) {
+	if p.UnsignedTx == nil {
+		return ErrNoUnsignedTx
+	}
+	if _, e = w.Write(magic); e != nil {
+		return
+	}
+	var tx bytes.Buffer
+	if e = p.UnsignedTx.SerializeNoWitness(&tx); e != nil {
+		return
+	}
+	m := &mapWriter{w: w}
+	m.field([]byte{globalUnsignedTx}, tx.Bytes())
+	m.unknowns(p.Unknowns)
+	m.end()
+	for i := range p.Inputs {
+		p.Inputs[i].encode(m)
+		m.end()
+	}
+	for i := range p.Outputs {
+		p.Outputs[i].encode(m)
+		m.end()
+	}
+	return m.e
+}
+
+// B64Encode returns the packet serialized in base64.
+func (p *Packet) B64Encode() (s string, e error) {
+	var b bytes.Buffer
+	if e = p.Serialize(&b); e != nil {
+		return
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// encode writes the fields of the input.
+func (in *Input) encode(m *mapWriter) {
+	if in.NonWitnessUtxo != nil {
+		var tx bytes.Buffer
+		if m.e = in.NonWitnessUtxo.Serialize(&tx); m.e != nil {
+			return
+		}
+		m.field([]byte{inNonWitnessUtxo}, tx.Bytes())
+	}
+	for _, sig := range in.PartialSigs {
+		m.field(append([]byte{inPartialSig}, sig.PubKey...), sig.Signature)
+	}
+	if in.SighashType != 0 {
+		var value [4]byte
+		binary.LittleEndian.PutUint32(value[:], uint32(in.SighashType))
+		m.field([]byte{inSighashType}, value[:])
+	}
+	if in.RedeemScript != nil {
+		m.field([]byte{inRedeemScript}, in.RedeemScript)
+	}
+	for _, d := range in.Bip32Derivation {
+		m.field(append([]byte{inBip32Derivation}, d.PubKey...), d.encode())
+	}
+	if in.FinalScriptSig != nil {
+		m.field([]byte{inFinalScriptSig}, in.FinalScriptSig)
+	}
+	m.unknowns(in.Unknowns)
+}
+
+// encode writes the fields of the output.
+func (out *Output) encode(m *mapWriter) {
+	if out.RedeemScript != nil {
+		m.field([]byte{outRedeemScript}, out.RedeemScript)
+	}
+	for _, d := range out.Bip32Derivation {
+		m.field(append([]byte{outBip32Derivation}, d.PubKey...), d.encode())
+	}
+	m.unknowns(out.Unknowns)
+}
+
+// mapWriter writes the fields of the maps of a packet, keeping the first error so that it only needs to be checked
+// once they are all written.
+type mapWriter struct {
+	w io.Writer
+	e error
+}
+
+func (m *mapWriter) field(key, value []byte) {
+	if m.e != nil {
+		return
+	}
+	if m.e = wire.WriteVarBytes(m.w, 0, key); m.e != nil {
+		return
+	}
+	m.e = wire.WriteVarBytes(m.w, 0, value)
+}
+
+func (m *mapWriter) unknowns(unknowns []*Unknown) {
+	for _, u := range unknowns {
+		m.field(u.Key, u.Value)
+	}
+}
+
+// end writes the separator after the fields of a map.
+func (m *mapWriter) end() {
+	if m.e != nil {
+		return
+	}
+	_, m.e = m.w.Write([]byte{0})
+}
@@ -0,0 +1,276 @@
+package psbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// testKeys returns n new private keys.
+func testKeys(t *testing.T, n int) (keys []*ec.PrivateKey) {
+	for i := 0; i < n; i++ {
+		key, e := ec.NewPrivateKey(ec.S256())
+		if e != nil {
+			t.Fatal(e)
+		}
+		keys = append(keys, key)
+	}
+	return
+}
+
+// testPacket returns a packet spending the first output of a transaction paying to pkScript, carrying that transaction.
+func testPacket(t *testing.T, pkScript []byte) *Packet {
+	prev := wire.NewMsgTx(1)
+	prev.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{txscript.OP_TRUE}, nil))
+	prev.AddTxOut(wire.NewTxOut(5e8, pkScript))
+	prevHash := prev.TxHash()
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(4e8, []byte{txscript.OP_TRUE}))
+	p, e := New(tx)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p.Inputs[0].NonWitnessUtxo = prev
+	p.Inputs[0].SighashType = txscript.SigHashAll
+	return p
+}
+
+// sign adds the signature of input 0 of the packet by the key, signing the script.
+func sign(t *testing.T, p *Packet, script []byte, key *ec.PrivateKey) {
+	sig, e := txscript.RawTxInSignature(p.UnsignedTx, 0, script, txscript.SigHashAll, key)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p.Inputs[0].PartialSigs = append(
+		p.Inputs[0].PartialSigs, &PartialSig{PubKey: key.PubKey().SerializeCompressed(), Signature: sig},
+	)
+}
+
+// roundTrip returns the packet after serializing it in base64 and parsing it back.
+func roundTrip(t *testing.T, p *Packet) *Packet {
+	s, e := p.B64Encode()
+	if e != nil {
+		t.Fatal(e)
+	}
+	parsed, e := ParseBase64(s)
+	if e != nil {
+		t.Fatal(e)
+	}
+	again, e := parsed.B64Encode()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if again != s {
+		t.Fatal("packet changed in a round trip")
+	}
+	return parsed
+}
+
+// checkSpend finalizes the packet, extracts its transaction and executes its input against the output it spends.
+func checkSpend(t *testing.T, p *Packet) {
+	if e := FinalizeAll(p); e != nil {
+		t.Fatal(e)
+	}
+	if len(p.Inputs[0].PartialSigs) != 0 || p.Inputs[0].RedeemScript != nil {
+		t.Fatal("finalized input kept its signing fields")
+	}
+	p = roundTrip(t, p)
+	tx, e := Extract(p)
+	if e != nil {
+		t.Fatal(e)
+	}
+	prevOut, e := p.PrevOut(0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	vm, e := txscript.NewEngine(
+		prevOut.PkScript, tx, 0, txscript.ScriptBip16|txscript.ScriptVerifyDERSignatures, nil, nil, prevOut.Value,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = vm.Execute(); e != nil {
+		t.Fatalf("extracted transaction does not verify: %v", e)
+	}
+}
+
+// TestPubKeyHash ensures a packet spending a public key hash output is carried through a round trip, finalized and
+// extracted into a transaction that verifies.
+func TestPubKeyHash(t *testing.T) {
+	key := testKeys(t, 1)[0]
+	pubKey := key.PubKey().SerializeCompressed()
+	addr, e := btcaddr.NewPubKeyHash(btcaddr.Hash160(pubKey), &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p := testPacket(t, pkScript)
+	p.Inputs[0].Bip32Derivation = []*Bip32Derivation{
+		{PubKey: pubKey, Fingerprint: [4]byte{1, 2, 3, 4}, Path: []uint32{44 | 1<<31, 0, 7}},
+	}
+	p.Outputs[0].Unknowns = []*Unknown{{Key: []byte{0xfc, 1}, Value: []byte{2}}}
+	p = roundTrip(t, p)
+	d := p.Inputs[0].Bip32Derivation[0]
+	if d.Fingerprint != [4]byte{1, 2, 3, 4} || len(d.Path) != 3 || d.Path[2] != 7 {
+		t.Fatalf("derivation is %+v", d)
+	}
+	if _, e = Extract(p); e != ErrIncomplete {
+		t.Fatalf("unsigned packet extracted with error %v", e)
+	}
+	if e = Finalize(p, 0); e == nil {
+		t.Fatal("unsigned input was finalized")
+	}
+	sign(t, p, pkScript, key)
+	checkSpend(t, p)
+}
+
+// TestMultiSig ensures the signatures of two signers of a 2 of 3 multisig script hash output, each added to its own
+// copy of the packet, are combined into a transaction that verifies.
+func TestMultiSig(t *testing.T) {
+	keys := testKeys(t, 3)
+	var pubKeys []*btcaddr.PubKey
+	for _, key := range keys {
+		pubKey, e := btcaddr.NewPubKey(key.PubKey().SerializeCompressed(), &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	redeemScript, e := txscript.MultiSigScript(pubKeys, 2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	addr, e := btcaddr.NewScriptHash(redeemScript, &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p := testPacket(t, pkScript)
+	p.Inputs[0].RedeemScript = redeemScript
+	other := roundTrip(t, p)
+	// The signers sign out of the order of the keys, which the finalized script must put them back in.
+	sign(t, p, redeemScript, keys[2])
+	if e = Finalize(p, 0); e == nil {
+		t.Fatal("input with one of two signatures was finalized")
+	}
+	sign(t, other, redeemScript, keys[0])
+	if e = CheckSigs(p, other); e != nil {
+		t.Fatal(e)
+	}
+	if e = Combine(p, other); e != nil {
+		t.Fatal(e)
+	}
+	if len(p.Inputs[0].PartialSigs) != 2 {
+		t.Fatalf("combined packet has %d signatures", len(p.Inputs[0].PartialSigs))
+	}
+	checkSpend(t, p)
+	other.UnsignedTx.TxOut[0].Value--
+	if e = Combine(p, other); e != ErrDifferentTx {
+		t.Fatalf("packets of different transactions combined with error %v", e)
+	}
+}
+
+// TestCheckSigs ensures signatures of a signer are only accepted if they are valid signatures of the transaction by a
+// key the input pays to, and final signature scripts only if they spend the output.
+func TestCheckSigs(t *testing.T) {
+	keys := testKeys(t, 2)
+	pubKey := keys[0].PubKey().SerializeCompressed()
+	addr, e := btcaddr.NewPubKeyHash(btcaddr.Hash160(pubKey), &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pkScript, e := txscript.PayToAddrScript(addr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	p := testPacket(t, pkScript)
+	signed := func(sig *PartialSig) *Packet {
+		other := roundTrip(t, p)
+		other.Inputs[0].PartialSigs = []*PartialSig{sig}
+		return other
+	}
+	valid := roundTrip(t, p)
+	sign(t, valid, pkScript, keys[0])
+	sig := valid.Inputs[0].PartialSigs[0]
+	if e = CheckSigs(p, valid); e != nil {
+		t.Fatalf("valid signature refused: %v", e)
+	}
+	otherKey := roundTrip(t, p)
+	sign(t, otherKey, pkScript, keys[1])
+	tampered := append([]byte{}, sig.Signature...)
+	tampered[len(tampered)-2] ^= 1
+	otherHashType := append(append([]byte{}, sig.Signature[:len(sig.Signature)-1]...), byte(txscript.SigHashSingle))
+	otherTx := roundTrip(t, p)
+	otherTx.UnsignedTx.TxOut[0].Value--
+	sign(t, otherTx, pkScript, keys[0])
+	tests := []struct {
+		name  string
+		other *Packet
+	}{
+		{"key not paid to", otherKey},
+		{"invalid public key", signed(&PartialSig{PubKey: []byte{2, 1}, Signature: sig.Signature})},
+		{"empty signature", signed(&PartialSig{PubKey: pubKey})},
+		{"invalid signature", signed(&PartialSig{PubKey: pubKey, Signature: []byte{0x30, txscript.OP_TRUE}})},
+		{"tampered signature", signed(&PartialSig{PubKey: pubKey, Signature: tampered})},
+		{"other sighash type", signed(&PartialSig{PubKey: pubKey, Signature: otherHashType})},
+		{"signature of another transaction", signed(otherTx.Inputs[0].PartialSigs[0])},
+	}
+	for _, test := range tests {
+		if e = CheckSigs(p, test.other); e == nil {
+			t.Errorf("%s: signature accepted", test.name)
+		}
+	}
+	if e = Finalize(valid, 0); e != nil {
+		t.Fatal(e)
+	}
+	if e = CheckSigs(p, valid); e != nil {
+		t.Fatalf("valid final signature script refused: %v", e)
+	}
+	valid.Inputs[0].FinalScriptSig = []byte{txscript.OP_TRUE}
+	if e = CheckSigs(p, valid); e == nil {
+		t.Error("final signature script that does not spend the output accepted")
+	}
+}
+
+// TestParseErrors ensures data that is not a valid packet is refused.
+func TestParseErrors(t *testing.T) {
+	p := testPacket(t, []byte{txscript.OP_TRUE})
+	var b bytes.Buffer
+	if e := p.Serialize(&b); e != nil {
+		t.Fatal(e)
+	}
+	valid := b.Bytes()
+	if _, e := Parse(bytes.NewReader(valid)); e != nil {
+		t.Fatal(e)
+	}
+	// The separator of the global map is replaced by a second unsigned transaction field.
+	duplicate := append([]byte{}, valid[:len(magic)+3+p.UnsignedTx.SerializeSize()]...)
+	duplicate = append(duplicate, valid[len(magic):]...)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"bad magic", append([]byte("psbu"), valid[4:]...)},
+		{"truncated", valid[:len(valid)-1]},
+		{"no unsigned tx", append(append([]byte{}, magic...), 0)},
+		{"duplicate key", duplicate},
+	}
+	for _, test := range tests {
+		if _, e := Parse(bytes.NewReader(test.data)); e == nil {
+			t.Errorf("%s: parsed", test.name)
+		}
+	}
+}
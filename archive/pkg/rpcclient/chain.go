@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	js "encoding/json"
 	
+	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/wire"
@@ -358,6 +359,179 @@ func (c *Client) GetBlockHeaderVerbose(blockHash *chainhash.Hash) (*btcjson.GetB
 	return c.GetBlockHeaderVerboseAsync(blockHash).Receive()
 }
 
+// FutureGetBlockPropagationResult is a future promise to deliver the result of a GetBlockPropagationAsync RPC
+// invocation (or an applicable error).
+type FutureGetBlockPropagationResult chan *response
+
+// Receive waits for the response promised by the future and returns the propagation of the most recently connected
+// blocks.
+func (r FutureGetBlockPropagationResult) Receive() (*btcjson.GetBlockPropagationResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var propagation btcjson.GetBlockPropagationResult
+	e = js.Unmarshal(res, &propagation)
+	if e != nil {
+		return nil, e
+	}
+	return &propagation, nil
+}
+
+// GetBlockPropagationAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetBlockPropagation for the blocking version and
+// more details.
+func (c *Client) GetBlockPropagationAsync(count int) FutureGetBlockPropagationResult {
+	cmd := btcjson.NewGetBlockPropagationCmd(&count)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockPropagation returns the time from the first announcement to validation, the peer that announced first and
+// the number of peers that announced before download completed, for up to count of the most recently connected
+// blocks.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockPropagation(count int) (*btcjson.GetBlockPropagationResult, error) {
+	return c.GetBlockPropagationAsync(count).Receive()
+}
+
+// FutureGetOrphanBlocksResult is a future promise to deliver the result of a GetOrphanBlocksAsync RPC invocation (or
+// an applicable error).
+type FutureGetOrphanBlocksResult chan *response
+
+// Receive waits for the response promised by the future and returns the orphan blocks held by the server.
+func (r FutureGetOrphanBlocksResult) Receive() (*btcjson.GetOrphanBlocksResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var orphans btcjson.GetOrphanBlocksResult
+	e = js.Unmarshal(res, &orphans)
+	if e != nil {
+		return nil, e
+	}
+	return &orphans, nil
+}
+
+// GetOrphanBlocksAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetOrphanBlocks for the blocking version and
+// more details.
+func (c *Client) GetOrphanBlocksAsync() FutureGetOrphanBlocksResult {
+	cmd := btcjson.NewGetOrphanBlocksCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetOrphanBlocks returns the blocks the server holds because their parent is not known, the counts of what happened
+// to the orphan blocks it held, and the requests for their missing parents.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetOrphanBlocks() (*btcjson.GetOrphanBlocksResult, error) {
+	return c.GetOrphanBlocksAsync().Receive()
+}
+
+// FutureGetOrphanTxsResult is a future promise to deliver the result of a GetOrphanTxsAsync RPC invocation (or an
+// applicable error).
+type FutureGetOrphanTxsResult chan *response
+
+// Receive waits for the response promised by the future and returns the orphan transactions held by the server.
+func (r FutureGetOrphanTxsResult) Receive() (*btcjson.GetOrphanTxsResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var orphans btcjson.GetOrphanTxsResult
+	e = js.Unmarshal(res, &orphans)
+	if e != nil {
+		return nil, e
+	}
+	return &orphans, nil
+}
+
+// GetOrphanTxsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetOrphanTxs for the blocking version and more
+// details.
+func (c *Client) GetOrphanTxsAsync() FutureGetOrphanTxsResult {
+	cmd := btcjson.NewGetOrphanTxsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetOrphanTxs returns the transactions the server holds because some of the outputs they spend are not known, and
+// the counts of what happened to the orphan transactions it held.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetOrphanTxs() (*btcjson.GetOrphanTxsResult, error) {
+	return c.GetOrphanTxsAsync().Receive()
+}
+
+// FutureGetScriptFlagsResult is a future promise to deliver the result of a GetScriptFlagsAsync RPC invocation (or an
+// applicable error).
+type FutureGetScriptFlagsResult chan *response
+
+// Receive waits for the response promised by the future and returns the script verification flags at the height.
+func (r FutureGetScriptFlagsResult) Receive() (*btcjson.GetScriptFlagsResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var flags btcjson.GetScriptFlagsResult
+	e = js.Unmarshal(res, &flags)
+	if e != nil {
+		return nil, e
+	}
+	return &flags, nil
+}
+
+// GetScriptFlagsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetScriptFlags for the blocking version and
+// more details.
+func (c *Client) GetScriptFlagsAsync(height *int32) FutureGetScriptFlagsResult {
+	cmd := btcjson.NewGetScriptFlagsCmd(height)
+	return c.sendCmd(cmd)
+}
+
+// GetScriptFlags returns the script verification flags a block at the height is checked with, those of the next
+// block when height is nil, along with the network upgrades that switch them on.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetScriptFlags(height *int32) (*btcjson.GetScriptFlagsResult, error) {
+	return c.GetScriptFlagsAsync(height).Receive()
+}
+
+// FutureGetTimestampInfoResult is a future promise to deliver the result of a GetTimestampInfoAsync RPC invocation (or
+// an applicable error).
+type FutureGetTimestampInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the timestamp information of the chain.
+func (r FutureGetTimestampInfoResult) Receive() (*btcjson.GetTimestampInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var info btcjson.GetTimestampInfoResult
+	e = js.Unmarshal(res, &info)
+	if e != nil {
+		return nil, e
+	}
+	return &info, nil
+}
+
+// GetTimestampInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetTimestampInfo for the blocking version and
+// more details.
+func (c *Client) GetTimestampInfoAsync(blocks int) FutureGetTimestampInfoResult {
+	cmd := btcjson.NewGetTimestampInfoCmd(&blocks)
+	return c.sendCmd(cmd)
+}
+
+// GetTimestampInfo returns the median time past of the best block, the earliest and latest timestamps the next block
+// may have, and how the timestamps of each algorithm drift over the given number of recent blocks. Miners use it to
+// set valid timestamps and monitoring to spot attempts to warp time.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetTimestampInfo(blocks int) (*btcjson.GetTimestampInfoResult, error) {
+	return c.GetTimestampInfoAsync(blocks).Receive()
+}
+
 // FutureGetMempoolEntryResult is a future promise to deliver the result of a GetMempoolEntryAsync RPC invocation (or an
 // applicable error).
 type FutureGetMempoolEntryResult chan *response
@@ -467,6 +641,54 @@ func (c *Client) GetRawMempoolVerbose() (map[string]btcjson.GetRawMempoolVerbose
 	return c.GetRawMempoolVerboseAsync().Receive()
 }
 
+// FutureCalculatePackageFeeResult is a future promise to deliver the result of a CalculatePackageFeeAsync RPC invocation
+// (or an applicable error).
+type FutureCalculatePackageFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the fee of the package and the fee a child must pay
+// for it to reach the target fee rate.
+func (r FutureCalculatePackageFeeResult) Receive() (*btcjson.CalculatePackageFeeResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var pkg btcjson.CalculatePackageFeeResult
+	e = js.Unmarshal(res, &pkg)
+	if e != nil {
+		return nil, e
+	}
+	return &pkg, nil
+}
+
+// CalculatePackageFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See CalculatePackageFee for the blocking version and
+// more details.
+func (c *Client) CalculatePackageFeeAsync(
+	txHashes []*chainhash.Hash, targetFeeRate amt.Amount, childVSize int64,
+) FutureCalculatePackageFeeResult {
+	txIDs := make([]string, len(txHashes))
+	for i := range txHashes {
+		txIDs[i] = txHashes[i].String()
+	}
+	var size *int64
+	if childVSize > 0 {
+		size = &childVSize
+	}
+	cmd := btcjson.NewCalculatePackageFeeCmd(txIDs, targetFeeRate.ToDUO(), size)
+	return c.sendCmd(cmd)
+}
+
+// CalculatePackageFee returns the fee and size of the unconfirmed transactions with their ancestors in the mempool, and
+// the fee a child of the given virtual size spending from them must pay for them all to reach the target fee rate per
+// kilobyte. A childVSize of zero uses the size of a child spending one input to one pay to pubkey hash output.
+//
+// NOTE: This is a pod extension.
+func (c *Client) CalculatePackageFee(
+	txHashes []*chainhash.Hash, targetFeeRate amt.Amount, childVSize int64,
+) (*btcjson.CalculatePackageFeeResult, error) {
+	return c.CalculatePackageFeeAsync(txHashes, targetFeeRate, childVSize).Receive()
+}
+
 // FutureEstimateFeeResult is a future promise to deliver the result of a EstimateFeeAsync RPC invocation (or an
 // applicable error).
 type FutureEstimateFeeResult chan *response
@@ -498,6 +720,156 @@ func (c *Client) EstimateFee(numBlocks int64) (float64, error) {
 	return c.EstimateFeeAsync(numBlocks).Receive()
 }
 
+// FutureEstimateSmartFeeResult is a future promise to deliver the result of a EstimateSmartFeeAsync RPC invocation (or
+// an applicable error).
+type FutureEstimateSmartFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the estimated fee.
+func (r FutureEstimateSmartFeeResult) Receive() (*btcjson.EstimateSmartFeeResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var estimate btcjson.EstimateSmartFeeResult
+	e = js.Unmarshal(res, &estimate)
+	if e != nil {
+		return nil, e
+	}
+	return &estimate, nil
+}
+
+// EstimateSmartFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See EstimateSmartFee for the blocking version and more
+// details.
+func (c *Client) EstimateSmartFeeAsync(confTarget int64, mode string) FutureEstimateSmartFeeResult {
+	cmd := btcjson.NewEstimateSmartFeeCmd(confTarget, &mode)
+	return c.sendCmd(cmd)
+}
+
+// EstimateSmartFee estimates the fee per kilobyte for a transaction to confirm within confTarget blocks, or with the
+// mode btcjson.EstimateModeTime within confTarget minutes.
+//
+// NOTE: This is a pod extension.
+func (c *Client) EstimateSmartFee(confTarget int64, mode string) (*btcjson.EstimateSmartFeeResult, error) {
+	return c.EstimateSmartFeeAsync(confTarget, mode).Receive()
+}
+
+// FutureEstimatorStatsResult is a future promise to deliver the result of a EstimatorStatsAsync RPC invocation (or an
+// applicable error).
+type FutureEstimatorStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the calibration of the fee estimator.
+func (r FutureEstimatorStatsResult) Receive() (*btcjson.EstimatorStatsResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var stats btcjson.EstimatorStatsResult
+	e = js.Unmarshal(res, &stats)
+	if e != nil {
+		return nil, e
+	}
+	return &stats, nil
+}
+
+// EstimatorStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See EstimatorStats for the blocking version and more
+// details.
+func (c *Client) EstimatorStatsAsync() FutureEstimatorStatsResult {
+	cmd := btcjson.NewEstimatorStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// EstimatorStats returns how well the fee estimates have matched the blocks the transactions paying them took to
+// confirm, for each confirmation target.
+//
+// NOTE: This is a pod extension.
+func (c *Client) EstimatorStats() (*btcjson.EstimatorStatsResult, error) {
+	return c.EstimatorStatsAsync().Receive()
+}
+
+// FutureGetFeaturesResult is a future promise to deliver the result of a GetFeaturesAsync RPC invocation (or an
+// applicable error).
+type FutureGetFeaturesResult chan *response
+
+// Receive waits for the response promised by the future and returns the optional features of the node.
+func (r FutureGetFeaturesResult) Receive() ([]btcjson.FeatureResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var features []btcjson.FeatureResult
+	e = js.Unmarshal(res, &features)
+	if e != nil {
+		return nil, e
+	}
+	return features, nil
+}
+
+// GetFeaturesAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetFeatures for the blocking version and more details.
+func (c *Client) GetFeaturesAsync() FutureGetFeaturesResult {
+	cmd := btcjson.NewGetFeaturesCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetFeatures returns the optional features of the node and whether each one is on.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetFeatures() ([]btcjson.FeatureResult, error) {
+	return c.GetFeaturesAsync().Receive()
+}
+
+// FutureSetConfigResult is a future promise to deliver the result of a SetConfigAsync RPC invocation (or an applicable
+// error).
+type FutureSetConfigResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when setting the option.
+func (r FutureSetConfigResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// SetConfigAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See SetConfig for the blocking version and more details.
+func (c *Client) SetConfigAsync(name, value string) FutureSetConfigResult {
+	cmd := btcjson.NewSetConfigCmd(name, value)
+	return c.sendCmd(cmd)
+}
+
+// SetConfig sets an option of the configuration of the node that takes effect without a restart, with the value in
+// the form of the configuration schema. The configuration file of the node is saved with the new value.
+//
+// NOTE: This is a pod extension.
+func (c *Client) SetConfig(name, value string) (e error) {
+	return c.SetConfigAsync(name, value).Receive()
+}
+
+// FutureSetFeatureResult is a future promise to deliver the result of a SetFeatureAsync RPC invocation (or an
+// applicable error).
+type FutureSetFeatureResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when switching the
+// feature.
+func (r FutureSetFeatureResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// SetFeatureAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See SetFeature for the blocking version and more details.
+func (c *Client) SetFeatureAsync(name string, enable bool) FutureSetFeatureResult {
+	cmd := btcjson.NewSetFeatureCmd(name, enable)
+	return c.sendCmd(cmd)
+}
+
+// SetFeature switches an optional feature of the node on or off. The setting is kept when the node restarts.
+//
+// NOTE: This is a pod extension.
+func (c *Client) SetFeature(name string, enable bool) (e error) {
+	return c.SetFeatureAsync(name, enable).Receive()
+}
+
 // FutureVerifyChainResult is a future promise to deliver the result of a VerifyChainAsync, VerifyChainLevelAsyncRPC, or
 // VerifyChainBlocksAsync invocation (or an applicable error).
 type FutureVerifyChainResult chan *response
@@ -772,3 +1144,131 @@ func (c *Client) GetCFilterHeader(
 ) (*wire.MsgCFHeaders, error) {
 	return c.GetCFilterHeaderAsync(blockHash, filterType).Receive()
 }
+
+// FutureGetCacheStatsResult is a future promise to deliver the result of a GetCacheStatsAsync RPC invocation (or an
+// applicable error).
+type FutureGetCacheStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the statistics of the validation caches of the
+// server.
+func (r FutureGetCacheStatsResult) Receive() (*btcjson.GetCacheStatsResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var stats btcjson.GetCacheStatsResult
+	e = js.Unmarshal(res, &stats)
+	if e != nil {
+		return nil, e
+	}
+	return &stats, nil
+}
+
+// GetCacheStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetCacheStats for the blocking version and more
+// details.
+func (c *Client) GetCacheStatsAsync() FutureGetCacheStatsResult {
+	cmd := btcjson.NewGetCacheStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetCacheStats returns the size and hit counters of the signature verification cache of the server.
+func (c *Client) GetCacheStats() (*btcjson.GetCacheStatsResult, error) {
+	return c.GetCacheStatsAsync().Receive()
+}
+
+// FutureGetValidationTraceResult is a future promise to deliver the result of a GetValidationTraceAsync RPC invocation
+// (or an applicable error).
+type FutureGetValidationTraceResult chan *response
+
+// Receive waits for the response promised by the future and returns the time spent in each phase of validating the
+// most recently processed blocks.
+func (r FutureGetValidationTraceResult) Receive() ([]btcjson.GetValidationTraceResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var traces []btcjson.GetValidationTraceResult
+	e = js.Unmarshal(res, &traces)
+	if e != nil {
+		return nil, e
+	}
+	return traces, nil
+}
+
+// GetValidationTraceAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetValidationTrace for the blocking version and
+// more details.
+func (c *Client) GetValidationTraceAsync(count int) FutureGetValidationTraceResult {
+	cmd := btcjson.NewGetValidationTraceCmd(&count)
+	return c.sendCmd(cmd)
+}
+
+// GetValidationTrace returns how long each phase of validating up to count of the most recently processed blocks took,
+// newest first. The server must be running with validation tracing enabled.
+func (c *Client) GetValidationTrace(count int) ([]btcjson.GetValidationTraceResult, error) {
+	return c.GetValidationTraceAsync(count).Receive()
+}
+
+// FutureSelfTestResult is a future promise to deliver the result of a SelfTestAsync RPC invocation (or an applicable
+// error).
+type FutureSelfTestResult chan *response
+
+// Receive waits for the response promised by the future and returns the outcome of the proof of work algorithm test
+// vectors of the server.
+func (r FutureSelfTestResult) Receive() (*btcjson.SelfTestResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SelfTestResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// SelfTestAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See SelfTest for the blocking version and more details.
+func (c *Client) SelfTestAsync() FutureSelfTestResult {
+	cmd := btcjson.NewSelfTestCmd()
+	return c.sendCmd(cmd)
+}
+
+// SelfTest runs the known answer test vectors of the proof of work algorithms of the server and returns the outcome.
+func (c *Client) SelfTest() (*btcjson.SelfTestResult, error) {
+	return c.SelfTestAsync().Receive()
+}
+
+// FutureGetIndexInfoResult is a future promise to deliver the result of a GetIndexInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetIndexInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the sync state of the optional indexes of the
+// server, keyed by index name.
+func (r FutureGetIndexInfoResult) Receive() (map[string]btcjson.GetIndexInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result map[string]btcjson.GetIndexInfoResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// GetIndexInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetIndexInfo for the blocking version and more details.
+func (c *Client) GetIndexInfoAsync(indexName *string) FutureGetIndexInfoResult {
+	cmd := btcjson.NewGetIndexInfoCmd(indexName)
+	return c.sendCmd(cmd)
+}
+
+// GetIndexInfo returns the sync state of the optional indexes of the server, or only of the named index if indexName
+// is not nil.
+func (c *Client) GetIndexInfo(indexName *string) (map[string]btcjson.GetIndexInfoResult, error) {
+	return c.GetIndexInfoAsync(indexName).Receive()
+}
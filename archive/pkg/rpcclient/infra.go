@@ -3,6 +3,7 @@ package rpcclient
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -14,17 +15,18 @@ import (
 	"math"
 	"net"
 	"net/http"
-	"net/url"
 	"sync"
 	"sync/atomic"
 	"time"
 	
 	"github.com/p9c/qu"
 	
-	"github.com/btcsuite/go-socks/socks"
 	"github.com/btcsuite/websocket"
 	
+	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/btcjson"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/netproxy"
 )
 
 var (
@@ -90,6 +92,8 @@ type jsonRequest struct {
 	cmd            interface{}
 	marshalledJSON []byte
 	responseChan   chan *response
+	// retries is the number of times the request has been sent again after a transient transport error.
+	retries int
 }
 
 // Client represents a Bitcoin RPC client which allows easy access to the
@@ -214,12 +218,17 @@ func (c *Client) trackRegisteredNtfns(cmd interface{}) {
 	switch bcmd := cmd.(type) {
 	case *btcjson.NotifyBlocksCmd:
 		c.ntfnState.notifyBlocks = true
+	case *btcjson.NotifyPeersCmd:
+		c.ntfnState.notifyPeers = true
+	case *btcjson.NotifyTipDeltasCmd:
+		c.ntfnState.notifyTipDeltas = true
 	case *btcjson.NotifyNewTransactionsCmd:
 		if bcmd.Verbose != nil && *bcmd.Verbose {
 			c.ntfnState.notifyNewTxVerbose = true
 		} else {
 			c.ntfnState.notifyNewTx = true
 		}
+		c.ntfnState.notifyNewTxFiltered = bcmd.Filtered != nil && *bcmd.Filtered
 	case *btcjson.NotifySpentCmd:
 		for _, op := range bcmd.OutPoints {
 			c.ntfnState.notifySpent[op] = struct{}{}
@@ -228,6 +237,13 @@ func (c *Client) trackRegisteredNtfns(cmd interface{}) {
 		for _, addr := range bcmd.Addresses {
 			c.ntfnState.notifyReceived[addr] = struct{}{}
 		}
+	case *btcjson.NotifyWalletEventsCmd:
+		c.ntfnState.notifyWalletEvents = true
+		// A registration on reconnect resumes from the last event received rather than restarting.
+		if c.ntfnState.walletEventSequence == nil {
+			c.ntfnState.walletEventSequence = bcmd.SinceSequence
+			c.ntfnState.walletEventsSinceBlock = bcmd.SinceBlock
+		}
 	}
 }
 
@@ -469,13 +485,47 @@ func (c *Client) reregisterNtfns() (e error) {
 			return e
 		}
 	}
+	// Reregister notifywalletevents if needed, from the last event received so the events sent while the client was
+	// disconnected are not missed.
+	if stateCopy.notifyWalletEvents {
+		D.Ln("reregistering [notifywalletevents]")
+		var e error
+		if stateCopy.walletEventSequence != nil {
+			e = c.NotifyWalletEventsAsync(stateCopy.walletEventSequence, nil).Receive()
+		} else {
+			cmd := btcjson.NewNotifyWalletEventsCmd(nil, stateCopy.walletEventsSinceBlock)
+			e = FutureNotifyWalletEventsResult(c.sendCmd(cmd)).Receive()
+		}
+		if E.Chk(e) {
+			return e
+		}
+	}
+	// Reregister notifypeers if needed.
+	if stateCopy.notifyPeers {
+		D.Ln("reregistering [notifypeers]")
+		if e := c.NotifyPeers(); E.Chk(e) {
+			return e
+		}
+	}
+	// Reregister notifytipdeltas if needed.
+	if stateCopy.notifyTipDeltas {
+		D.Ln("reregistering [notifytipdeltas]")
+		if e := c.NotifyTipDeltas(); E.Chk(e) {
+			return e
+		}
+	}
 	// Reregister notifynewtransactions if needed.
 	if stateCopy.notifyNewTx || stateCopy.notifyNewTxVerbose {
 		D.F(
-			"reregistering [notifynewtransactions] (verbose=%v)",
-			stateCopy.notifyNewTxVerbose,
+			"reregistering [notifynewtransactions] (verbose=%v, filtered=%v)",
+			stateCopy.notifyNewTxVerbose, stateCopy.notifyNewTxFiltered,
 		)
-		e := c.NotifyNewTransactions(stateCopy.notifyNewTxVerbose)
+		var e error
+		if stateCopy.notifyNewTxFiltered {
+			e = c.NotifyFilteredTransactions(stateCopy.notifyNewTxVerbose)
+		} else {
+			e = c.NotifyNewTransactions(stateCopy.notifyNewTxVerbose)
+		}
 		if e != nil {
 			return e
 		}
@@ -540,6 +590,13 @@ func (c *Client) resendRequests() {
 			// since no reply is expected.
 			delete(c.requestMap, jReq.id)
 			c.requestList.Remove(e)
+		} else if !IsReadOnlyMethod(jReq.method) {
+			// The server may have carried out a request that changes its state before the
+			// connection was lost, so rather than risk doing it twice the caller is left to
+			// reconcile it.
+			delete(c.requestMap, jReq.id)
+			c.requestList.Remove(e)
+			jReq.responseChan <- &response{err: newIndeterminateError(jReq, ErrClientDisconnect)}
 		} else {
 			resendReqs = append(resendReqs, jReq)
 		}
@@ -629,16 +686,17 @@ func (c *Client) handleSendPostMessage(details *sendPostDetails) {
 	// Tracef("sending command [%s] with id %d", jReq.method, jReq.id)
 	httpResponse, e := c.httpClient.Do(details.httpRequest)
 	if e != nil {
-		jReq.responseChan <- &response{err: e}
+		c.handleTransportError(jReq, newTransportError(e))
 		return
 	}
 	// Read the raw bytes and close the response.
-	var respBytes []byte
-	if respBytes, e = ioutil.ReadAll(httpResponse.Body); E.Chk(e) {
+	respBytes, e := ioutil.ReadAll(httpResponse.Body)
+	if ce := httpResponse.Body.Close(); E.Chk(ce) && e == nil {
+		e = ce
 	}
-	if e = httpResponse.Body.Close(); E.Chk(e) {
-		e = fmt.Errorf("error reading json reply: %v", e)
-		jReq.responseChan <- &response{err: e}
+	if e != nil {
+		e = fmt.Errorf("error reading json reply: %w", e)
+		c.handleTransportError(jReq, newTransportError(e))
 		return
 	}
 	// Try to unmarshal the response as a regular JSON-RPC response.
@@ -647,6 +705,10 @@ func (c *Client) handleSendPostMessage(details *sendPostDetails) {
 		// When the response itself isn't a valid JSON-RPC response return an error
 		// which includes the HTTP status code and raw response bytes.
 		e = fmt.Errorf("status code: %d, response: %q", httpResponse.StatusCode, string(respBytes))
+		if isTransientStatus(httpResponse.StatusCode) {
+			c.handleTransportError(jReq, &transportError{err: e, transient: true})
+			return
+		}
 		jReq.responseChan <- &response{err: e}
 		return
 	}
@@ -700,6 +762,7 @@ func (c *Client) sendPostRequest(httpReq *http.Request, jReq *jsonRequest) {
 	select {
 	case <-c.shutdown.Wait():
 		jReq.responseChan <- &response{result: nil, err: ErrClientShutdown}
+		return
 	default:
 	}
 	c.sendPostChan <- &sendPostDetails{
@@ -919,9 +982,13 @@ func (c *Client) Disconnect() {
 	if c.config.DisableAutoReconnect {
 		for e := c.requestList.Front(); e != nil; e = e.Next() {
 			req := e.Value.(*jsonRequest)
+			var err error = ErrClientDisconnect
+			if !IsReadOnlyMethod(req.method) {
+				err = newIndeterminateError(req, ErrClientDisconnect)
+			}
 			req.responseChan <- &response{
 				result: nil,
-				err:    ErrClientDisconnect,
+				err:    err,
 			}
 		}
 		c.removeAllRequests()
@@ -984,6 +1051,19 @@ func (c *Client) WaitForShutdown() {
 	c.wg.Wait()
 }
 
+// decodeAddress decodes an address returned by the server for the network of the server given in the connection
+// configuration, or returns it as a btcaddr.Raw if it can not be decoded and the configuration allows it.
+func (c *Client) decodeAddress(addr string) (a btcaddr.Address, e error) {
+	params := c.config.Params
+	if params == nil {
+		params = &chaincfg.MainNetParams
+	}
+	if a, e = btcaddr.DecodeForNet(addr, params); e != nil && c.config.RawAddresses {
+		return btcaddr.Raw(addr), nil
+	}
+	return
+}
+
 // ConnConfig describes the connection configuration parameters for the client.
 type ConnConfig struct {
 	// Host is the IP address and port of the RPC server you want to connect to.
@@ -1001,9 +1081,13 @@ type ConnConfig struct {
 	// Certificates are the bytes for a PEM-encoded certificate chain used for the
 	// TLS connection. It has no effect if the DisableTLS parameter is true.
 	Certificates []byte
-	// Proxy specifies to connect through a SOCKS 5 proxy server. It may be an empty
-	// string if a proxy is not required.
+	// Proxy specifies to connect through a proxy server, of the kind set by
+	// ProxyType. It may be an empty string if a proxy is not required.
 	Proxy string
+	// ProxyType is the kind of proxy server at Proxy, socks5 for a SOCKS 5 proxy,
+	// or http or https for an HTTP proxy the connection is tunnelled through with
+	// the CONNECT method. A SOCKS 5 proxy is assumed when it is empty.
+	ProxyType string
 	// ProxyUser is an optional username to use for the proxy server if it requires
 	// authentication. It has no effect if the Proxy parameter is not set.
 	ProxyUser string
@@ -1029,19 +1113,41 @@ type ConnConfig struct {
 	// EnableBCInfoHacks is an opt provided to enable compatibility hacks when
 	// connecting to blockchain.info RPC server
 	EnableBCInfoHacks bool
+	// Retry is the policy for retrying read-only requests that fail with a
+	// transient transport error in HTTP POST mode. DefaultRetryPolicy is used
+	// when it is nil, and a policy with no retries disables them.
+	Retry *RetryPolicy
+	// Params are the parameters of the network the server runs on, which addresses returned by the server are decoded
+	// for. The main network is assumed when it is nil.
+	Params *chaincfg.Params
+	// RawAddresses returns addresses from the server that can not be decoded for the network, such as those of a
+	// format unknown to the client, as btcaddr.Raw holding the string instead of failing the request.
+	RawAddresses bool
+}
+
+// proxy returns the proxy server the connection is made through.
+func (config *ConnConfig) proxy() netproxy.Config {
+	return netproxy.Config{
+		Kind: config.ProxyType,
+		Addr: config.Proxy,
+		User: config.ProxyUser,
+		Pass: config.ProxyPass,
+	}
 }
 
 // newHTTPClient returns a new http client that is configured according to the
 // proxy and TLS settings in the associated connection configuration.
 func newHTTPClient(config *ConnConfig) (*http.Client, error) {
-	// Set proxy function if there is a proxy configured.
-	var proxyFunc func(*http.Request) (*url.URL, error)
+	// Dial through the proxy if there is one configured.
+	var dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
 	if config.Proxy != "" {
-		proxyURL, e := url.Parse(config.Proxy)
+		proxy, e := config.proxy().Dialer()
 		if e != nil {
 			return nil, e
 		}
-		proxyFunc = http.ProxyURL(proxyURL)
+		dialFunc = func(_ context.Context, network, addr string) (net.Conn, error) {
+			return proxy.Dial(network, addr)
+		}
 	}
 	// Configure TLS if needed.
 	var tlsConfig *tls.Config
@@ -1056,7 +1162,7 @@ func newHTTPClient(config *ConnConfig) (*http.Client, error) {
 	}
 	client := http.Client{
 		Transport: &http.Transport{
-			Proxy:           proxyFunc,
+			DialContext:     dialFunc,
 			TLSClientConfig: tlsConfig,
 		},
 	}
@@ -1086,10 +1192,9 @@ func dial(config *ConnConfig) (*websocket.Conn, error) {
 	dialer := websocket.Dialer{TLSClientConfig: tlsConfig}
 	// Setup the proxy if one is configured.
 	if config.Proxy != "" {
-		proxy := &socks.Proxy{
-			Addr:     config.Proxy,
-			Username: config.ProxyUser,
-			Password: config.ProxyPass,
+		proxy, e := config.proxy().Dialer()
+		if e != nil {
+			return nil, e
 		}
 		dialer.NetDial = proxy.Dial
 	}
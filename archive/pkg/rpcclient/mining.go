@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/p9c/pod/pkg/block"
 	
+	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/btcjson"
 	"github.com/p9c/pod/pkg/chainhash"
 )
@@ -168,6 +169,171 @@ func (c *Client) GetMiningInfo() (*btcjson.GetMiningInfoResult, error) {
 	return c.GetMiningInfoAsync().Receive()
 }
 
+// FutureGetMiningAddressesResult is a future promise to deliver the result of a GetMiningAddressesAsync RPC invocation
+// (or an applicable error).
+type FutureGetMiningAddressesResult chan *response
+
+// Receive waits for the response promised by the future and returns the mining payout addresses and their rotation.
+func (r FutureGetMiningAddressesResult) Receive() (*btcjson.GetMiningAddressesResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getminingaddresses result object.
+	var addrsResult btcjson.GetMiningAddressesResult
+	e = js.Unmarshal(res, &addrsResult)
+	if e != nil {
+		return nil, e
+	}
+	return &addrsResult, nil
+}
+
+// GetMiningAddressesAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMiningAddresses for the blocking version and
+// more details.
+func (c *Client) GetMiningAddressesAsync() FutureGetMiningAddressesResult {
+	cmd := btcjson.NewGetMiningAddressesCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMiningAddresses returns the addresses the server pays the coinbase of the blocks it mines to, and the policy for
+// picking the address of each block.
+func (c *Client) GetMiningAddresses() (*btcjson.GetMiningAddressesResult, error) {
+	return c.GetMiningAddressesAsync().Receive()
+}
+
+// FutureGetMiningExpectationResult is a future promise to deliver the result of a GetMiningExpectationAsync RPC
+// invocation (or an applicable error).
+type FutureGetMiningExpectationResult chan *response
+
+// Receive waits for the response promised by the future and returns the expected time for the miners of the mining
+// controller to find a block.
+func (r FutureGetMiningExpectationResult) Receive() (*btcjson.GetMiningExpectationResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getminingexpectation result object.
+	var expectResult btcjson.GetMiningExpectationResult
+	e = js.Unmarshal(res, &expectResult)
+	if e != nil {
+		return nil, e
+	}
+	return &expectResult, nil
+}
+
+// GetMiningExpectationAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMiningExpectation for the blocking version and
+// more details.
+func (c *Client) GetMiningExpectationAsync() FutureGetMiningExpectationResult {
+	cmd := btcjson.NewGetMiningExpectationCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMiningExpectation returns how long the miners of the mining controller of the server can expect to take to find a
+// block with each algorithm, and the chance of finding one within a few periods.
+func (c *Client) GetMiningExpectation() (*btcjson.GetMiningExpectationResult, error) {
+	return c.GetMiningExpectationAsync().Receive()
+}
+
+// FutureGetMiningTemplateResult is a future promise to deliver the result of a GetMiningTemplateAsync RPC invocation
+// (or an applicable error).
+type FutureGetMiningTemplateResult chan *response
+
+// Receive waits for the response promised by the future and returns the blocks to mine with each of the algorithms.
+func (r FutureGetMiningTemplateResult) Receive() (*btcjson.GetMiningTemplateResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getminingtemplate result object.
+	var tplResult btcjson.GetMiningTemplateResult
+	e = js.Unmarshal(res, &tplResult)
+	if e != nil {
+		return nil, e
+	}
+	return &tplResult, nil
+}
+
+// GetMiningTemplateAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMiningTemplate for the blocking version and
+// more details.
+func (c *Client) GetMiningTemplateAsync(address string) FutureGetMiningTemplateResult {
+	cmd := btcjson.NewGetMiningTemplateCmd(address)
+	return c.sendCmd(cmd)
+}
+
+// GetMiningTemplate returns a block to mine with each of the algorithms, building on the best block of the server and
+// paying to the address.
+func (c *Client) GetMiningTemplate(address string) (*btcjson.GetMiningTemplateResult, error) {
+	return c.GetMiningTemplateAsync(address).Receive()
+}
+
+// FutureGetWorkSourceResult is a future promise to deliver the result of a GetWorkSourceAsync RPC invocation (or an
+// applicable error).
+type FutureGetWorkSourceResult chan *response
+
+// Receive waits for the response promised by the future and returns the work source status of the mining controller.
+func (r FutureGetWorkSourceResult) Receive() (*btcjson.GetWorkSourceResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getworksource result object.
+	var sourceResult btcjson.GetWorkSourceResult
+	e = js.Unmarshal(res, &sourceResult)
+	if e != nil {
+		return nil, e
+	}
+	return &sourceResult, nil
+}
+
+// GetWorkSourceAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetWorkSource for the blocking version and more details.
+func (c *Client) GetWorkSourceAsync() FutureGetWorkSourceResult {
+	cmd := btcjson.NewGetWorkSourceCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetWorkSource returns the node the mining controller of the server is taking its work from, and the health of the
+// nodes it can fail over to.
+func (c *Client) GetWorkSource() (*btcjson.GetWorkSourceResult, error) {
+	return c.GetWorkSourceAsync().Receive()
+}
+
+// FutureSetMiningAddressesResult is a future promise to deliver the result of a SetMiningAddressesAsync RPC invocation
+// (or an applicable error).
+type FutureSetMiningAddressesResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when setting the mining
+// payout addresses.
+func (r FutureSetMiningAddressesResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// SetMiningAddressesAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See SetMiningAddresses for the blocking version and
+// more details.
+func (c *Client) SetMiningAddressesAsync(addresses []btcaddr.Address, rotation string) FutureSetMiningAddressesResult {
+	addrs := make([]string, len(addresses))
+	for i, addr := range addresses {
+		addrs[i] = addr.EncodeAddress()
+	}
+	var rot *string
+	if rotation != "" {
+		rot = &rotation
+	}
+	cmd := btcjson.NewSetMiningAddressesCmd(addrs, rot)
+	return c.sendCmd(cmd)
+}
+
+// SetMiningAddresses replaces the addresses the server pays the coinbase of the blocks it mines to, and the policy for
+// picking the address of each block, which is kept as it is when rotation is empty.
+func (c *Client) SetMiningAddresses(addresses []btcaddr.Address, rotation string) (e error) {
+	return c.SetMiningAddressesAsync(addresses, rotation).Receive()
+}
+
 // FutureGetNetworkHashPS is a future promise to deliver the result of a GetNetworkHashPSAsync RPC invocation (or an
 // applicable error).
 type FutureGetNetworkHashPS chan *response
@@ -346,4 +512,45 @@ func (c *Client) SubmitBlock(block *block.Block, options *btcjson.SubmitBlockOpt
 	return c.SubmitBlockAsync(block, options).Receive()
 }
 
+// FuturePrioritiseTransactionResult is a future promise to deliver the result of a PrioritiseTransactionAsync RPC
+// invocation (or an applicable error).
+type FuturePrioritiseTransactionResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the transaction could not be
+// prioritised.
+func (r FuturePrioritiseTransactionResult) Receive() error {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return e
+	}
+	// Unmarshal the result as a boolean.
+	var ok bool
+	e = js.Unmarshal(res, &ok)
+	if e != nil {
+		return e
+	}
+	if !ok {
+		return errors.New("transaction was not prioritised")
+	}
+	return nil
+}
+
+// PrioritiseTransactionAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See PrioritiseTransaction for the blocking version
+// and more details.
+func (c *Client) PrioritiseTransactionAsync(txHash *chainhash.Hash, feeDelta int64) FuturePrioritiseTransactionResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+	cmd := btcjson.NewPrioritiseTransactionCmd(hash, 0, feeDelta)
+	return c.sendCmd(cmd)
+}
+
+// PrioritiseTransaction asks the server to rank the transaction by its fee plus the given delta in satoshi when
+// selecting transactions for blocks it mines, such as to honor a fee paid to the miner out of band.
+func (c *Client) PrioritiseTransaction(txHash *chainhash.Hash, feeDelta int64) (e error) {
+	return c.PrioritiseTransactionAsync(txHash, feeDelta).Receive()
+}
+
 // TODO(davec): Implement GetBlockTemplate
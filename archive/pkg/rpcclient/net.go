@@ -265,3 +265,108 @@ func (c *Client) GetNetTotalsAsync() FutureGetNetTotalsResult {
 func (c *Client) GetNetTotals() (*btcjson.GetNetTotalsResult, error) {
 	return c.GetNetTotalsAsync().Receive()
 }
+
+// FutureGetNetworkInfoResult is a future promise to deliver the result of a GetNetworkInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetNetworkInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the state of the peer-to-peer network.
+func (r FutureGetNetworkInfoResult) Receive() (*btcjson.GetNetworkInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var info btcjson.GetNetworkInfoResult
+	e = js.Unmarshal(res, &info)
+	if e != nil {
+		return nil, e
+	}
+	return &info, nil
+}
+
+// GetNetworkInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetNetworkInfo for the blocking version and more details.
+func (c *Client) GetNetworkInfoAsync() FutureGetNetworkInfoResult {
+	cmd := btcjson.NewGetNetworkInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetworkInfo returns the state of the peer-to-peer network, with warnings such as experimental features being
+// enabled.
+func (c *Client) GetNetworkInfo() (*btcjson.GetNetworkInfoResult, error) {
+	return c.GetNetworkInfoAsync().Receive()
+}
+
+// FutureGetNodeAddressesResult is a future promise to deliver the result of a GetNodeAddressesAsync RPC invocation (or
+// an applicable error).
+type FutureGetNodeAddressesResult chan *response
+
+// Receive waits for the response promised by the future and returns the known addresses of nodes.
+func (r FutureGetNodeAddressesResult) Receive() ([]btcjson.NodeAddressResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var addrs []btcjson.NodeAddressResult
+	e = js.Unmarshal(res, &addrs)
+	if e != nil {
+		return nil, e
+	}
+	return addrs, nil
+}
+
+// GetNodeAddressesAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetNodeAddresses for the blocking version and more details.
+func (c *Client) GetNodeAddressesAsync(count int, network string) FutureGetNodeAddressesResult {
+	var net *string
+	if network != "" {
+		net = &network
+	}
+	cmd := btcjson.NewGetNodeAddressesCmd(&count, net)
+	return c.sendCmd(cmd)
+}
+
+// GetNodeAddresses returns at most count randomly chosen addresses of nodes known to the server that are routable and
+// have not repeatedly failed, or all of them if count is zero, only on the network, ipv4, ipv6 or onion, unless it is
+// empty. It lets crawlers and new nodes bootstrap from a running node.
+func (c *Client) GetNodeAddresses(count int, network string) ([]btcjson.NodeAddressResult, error) {
+	return c.GetNodeAddressesAsync(count, network).Receive()
+}
+
+// FutureGetDNSAuditResult is a future promise to deliver the result of a GetDNSAuditAsync RPC invocation (or an
+// applicable error).
+type FutureGetDNSAuditResult chan *response
+
+// Receive waits for the response promised by the future and returns how the host names looked up by the server were
+// resolved.
+func (r FutureGetDNSAuditResult) Receive() (*btcjson.GetDNSAuditResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var audit btcjson.GetDNSAuditResult
+	e = js.Unmarshal(res, &audit)
+	if e != nil {
+		return nil, e
+	}
+	return &audit, nil
+}
+
+// GetDNSAuditAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetDNSAudit for the blocking version and more details.
+func (c *Client) GetDNSAuditAsync() FutureGetDNSAuditResult {
+	cmd := btcjson.NewGetDNSAuditCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetDNSAudit returns how each host name the server looked up was resolved, through its proxy or with the system
+// resolver, so names leaked to the local network can be found.
+func (c *Client) GetDNSAudit() (*btcjson.GetDNSAuditResult, error) {
+	return c.GetDNSAuditAsync().Receive()
+}
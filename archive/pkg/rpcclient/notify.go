@@ -29,11 +29,20 @@ var (
 // notificationState is used to track the current state of successfully registered notification so the state can be
 // automatically re-established on reconnect.
 type notificationState struct {
-	notifyBlocks       bool
-	notifyNewTx        bool
-	notifyNewTxVerbose bool
-	notifyReceived     map[string]struct{}
-	notifySpent        map[btcjson.OutPoint]struct{}
+	notifyBlocks        bool
+	notifyNewTx         bool
+	notifyNewTxVerbose  bool
+	notifyNewTxFiltered bool
+	notifyPeers         bool
+	notifyTipDeltas     bool
+	notifyReceived      map[string]struct{}
+	notifySpent         map[btcjson.OutPoint]struct{}
+	notifyWalletEvents  bool
+	// walletEventSequence is the sequence number of the last wallet event received, which the events are registered
+	// for again after on reconnect. Until an event is received walletEventsSinceBlock, the block the events were
+	// registered for after, is used instead, if it was given.
+	walletEventSequence    *uint64
+	walletEventsSinceBlock *string
 }
 
 // Copy returns a deep copy of the receiver.
@@ -42,6 +51,9 @@ func (s *notificationState) Copy() *notificationState {
 	stateCopy.notifyBlocks = s.notifyBlocks
 	stateCopy.notifyNewTx = s.notifyNewTx
 	stateCopy.notifyNewTxVerbose = s.notifyNewTxVerbose
+	stateCopy.notifyNewTxFiltered = s.notifyNewTxFiltered
+	stateCopy.notifyPeers = s.notifyPeers
+	stateCopy.notifyTipDeltas = s.notifyTipDeltas
 	stateCopy.notifyReceived = make(map[string]struct{})
 	for addr := range s.notifyReceived {
 		stateCopy.notifyReceived[addr] = struct{}{}
@@ -50,6 +62,12 @@ func (s *notificationState) Copy() *notificationState {
 	for op := range s.notifySpent {
 		stateCopy.notifySpent[op] = struct{}{}
 	}
+	stateCopy.notifyWalletEvents = s.notifyWalletEvents
+	if s.walletEventSequence != nil {
+		seq := *s.walletEventSequence
+		stateCopy.walletEventSequence = &seq
+	}
+	stateCopy.walletEventsSinceBlock = s.walletEventsSinceBlock
 	return &stateCopy
 }
 
@@ -140,6 +158,21 @@ type NotificationHandlers struct {
 	// preceding call to NotifyNewTransactions with the verbose flag set to true has been made to register for the
 	// notification and the function is non-nil.
 	OnTxAcceptedVerbose func(txDetails *btcjson.TxRawResult)
+	// OnTxExpired is invoked when an unmined transaction is evicted from the memory pool because it stayed there
+	// longer than the expiry of the mempool. It will only be invoked if a preceding call to NotifyBlocks has been made
+	// to register for the notification and the function is non-nil.
+	OnTxExpired func(hash *chainhash.Hash)
+	// OnPeerConnected is invoked when the node adds a peer to its connected peers. It will only be invoked if a
+	// preceding call to NotifyPeers has been made to register for the notification and the function is non-nil.
+	OnPeerConnected func(peer *btcjson.PeerEventResult)
+	// OnPeerDisconnected is invoked when the node removes a peer from its connected peers. The Reason field of the
+	// peer says why it was disconnected. It will only be invoked if a preceding call to NotifyPeers has been made to
+	// register for the notification and the function is non-nil.
+	OnPeerDisconnected func(peer *btcjson.PeerEventResult)
+	// OnTipDelta is invoked when a block becomes the new tip of the main chain, with its header, its coinbase and the
+	// short ids of its other transactions. It will only be invoked if a preceding call to NotifyTipDeltas has been made
+	// to register for the notification and the function is non-nil.
+	OnTipDelta func(delta *btcjson.TipDeltaResult)
 	// OnPodConnected is invoked when a wallet connects or disconnects from pod. This will only be available when client
 	// is connected to a wallet server such as btcwallet.
 	OnPodConnected func(connected bool)
@@ -149,6 +182,11 @@ type NotificationHandlers struct {
 	// OnWalletLockState is invoked when a wallet is locked or unlocked. This will only be available when client is
 	// connected to a wallet server such as btcwallet.
 	OnWalletLockState func(locked bool)
+	// OnWalletEvent is invoked with each event of the notification journal of a wallet, in order. After a reconnect
+	// the events are registered for again from the last event received, so none are missed, and an event is never
+	// delivered twice. It will only be invoked if a preceding call to NotifyWalletEvents has been made to register for
+	// the notification and the function is non-nil.
+	OnWalletEvent func(event *btcjson.WalletEventResult)
 	// OnUnknownNotification is invoked when an unrecognized notification is received. This typically means the
 	// notification handling code for this package needs to be updated for a new notification type or the caller is
 	// using a custom notification this package does not know about.
@@ -306,6 +344,58 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			return
 		}
 		c.ntfnHandlers.OnTxAccepted(hash, amt)
+	// OnTxExpired
+	case btcjson.TxExpiredNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnTxExpired == nil {
+			D.Ln("<<<no OnTxExpired callback registered>>>")
+			return
+		}
+		hash, e := parseTxExpiredNtfnParams(ntfn.Params)
+		if e != nil {
+			W.Ln("received invalid tx expired notification:", e)
+			return
+		}
+		c.ntfnHandlers.OnTxExpired(hash)
+	// OnPeerConnected
+	case btcjson.PeerConnectedNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnPeerConnected == nil {
+			D.Ln("<<<no OnPeerConnected callback registered>>>")
+			return
+		}
+		peer, e := parsePeerEventNtfnParams(ntfn.Params)
+		if e != nil {
+			W.Ln("received invalid peer connected notification:", e)
+			return
+		}
+		c.ntfnHandlers.OnPeerConnected(peer)
+	// OnPeerDisconnected
+	case btcjson.PeerDisconnectedNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnPeerDisconnected == nil {
+			D.Ln("<<<no OnPeerDisconnected callback registered>>>")
+			return
+		}
+		peer, e := parsePeerEventNtfnParams(ntfn.Params)
+		if e != nil {
+			W.Ln("received invalid peer disconnected notification:", e)
+			return
+		}
+		c.ntfnHandlers.OnPeerDisconnected(peer)
+	// OnTipDelta
+	case btcjson.TipDeltaNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnTipDelta == nil {
+			D.Ln("<<<no OnTipDelta callback registered>>>")
+			return
+		}
+		delta, e := parseTipDeltaNtfnParams(ntfn.Params)
+		if e != nil {
+			W.Ln("received invalid tip delta notification:", e)
+			return
+		}
+		c.ntfnHandlers.OnTipDelta(delta)
 	// OnTxAcceptedVerbose
 	case btcjson.TxAcceptedVerboseNtfnMethod:
 		// Ignore the notification if the client is not interested in it.
@@ -359,6 +449,29 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			return
 		}
 		c.ntfnHandlers.OnWalletLockState(locked)
+	// OnWalletEvent
+	case btcjson.WalletEventNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnWalletEvent == nil {
+			D.Ln("<<<no OnWalletEvent callback registered>>>")
+			return
+		}
+		event, e := parseWalletEventNtfnParams(ntfn.Params)
+		if e != nil {
+			W.Ln("received invalid wallet event notification:", e)
+			return
+		}
+		// The sequence number of the event is recorded so the events are registered for after it on reconnect, and an
+		// event sent again is dropped.
+		c.ntfnStateLock.Lock()
+		if last := c.ntfnState.walletEventSequence; last != nil && event.Sequence <= *last {
+			c.ntfnStateLock.Unlock()
+			return
+		}
+		seq := event.Sequence
+		c.ntfnState.walletEventSequence = &seq
+		c.ntfnStateLock.Unlock()
+		c.ntfnHandlers.OnWalletEvent(event)
 	// OnUnknownNotification
 	default:
 		if c.ntfnHandlers.OnUnknownNotification == nil {
@@ -584,6 +697,58 @@ func parseRescanProgressParams(params []js.RawMessage) (*chainhash.Hash, int32,
 }
 
 // parseTxAcceptedNtfnParams parses out the transaction hash and total amount from the parameters of a txaccepted
+// parsePeerEventNtfnParams parses out the peer details from the parameters of a peerconnected or peerdisconnected
+// notification.
+func parsePeerEventNtfnParams(params []js.RawMessage) (*btcjson.PeerEventResult, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+	var peer btcjson.PeerEventResult
+	if e := js.Unmarshal(params[0], &peer); e != nil {
+		return nil, e
+	}
+	return &peer, nil
+}
+
+// parseWalletEventNtfnParams parses out the event from the parameters of a walletevent notification.
+func parseWalletEventNtfnParams(params []js.RawMessage) (*btcjson.WalletEventResult, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+	var event btcjson.WalletEventResult
+	if e := js.Unmarshal(params[0], &event); e != nil {
+		return nil, e
+	}
+	return &event, nil
+}
+
+// parseTipDeltaNtfnParams parses out the tip delta from the parameters of a tipdelta notification.
+func parseTipDeltaNtfnParams(params []js.RawMessage) (*btcjson.TipDeltaResult, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+	var delta btcjson.TipDeltaResult
+	if e := js.Unmarshal(params[0], &delta); e != nil {
+		return nil, e
+	}
+	return &delta, nil
+}
+
+// parseTxExpiredNtfnParams parses out the transaction hash from the parameters of a txexpired notification.
+func parseTxExpiredNtfnParams(params []js.RawMessage) (*chainhash.Hash, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+	// Unmarshal first parameter as a string.
+	var txHashStr string
+	e := js.Unmarshal(params[0], &txHashStr)
+	if e != nil {
+		return nil, e
+	}
+	// Decode string encoding of transaction sha.
+	return chainhash.NewHashFromStr(txHashStr)
+}
+
 // notification.
 func parseTxAcceptedNtfnParams(params []js.RawMessage) (
 	*chainhash.Hash,
@@ -751,6 +916,134 @@ func (c *Client) NotifyBlocks() (e error) {
 	return c.NotifyBlocksAsync().Receive()
 }
 
+// FutureNotifyPeersResult is a future promise to deliver the result of a NotifyPeersAsync RPC invocation (or an
+// applicable error).
+type FutureNotifyPeersResult chan *response
+
+// Receive waits for the response promised by the future and returns an
+// error if the registration was not successful.
+func (r FutureNotifyPeersResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// NotifyPeersAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See NotifyPeers for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyPeersAsync() FutureNotifyPeersResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+	// Ignore the notification if the client is not interested in notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+	cmd := btcjson.NewNotifyPeersCmd()
+	return c.sendCmd(cmd)
+}
+
+// NotifyPeers registers the client to receive notifications when the node connects to or disconnects from peers.
+//
+// The notifications delivered as a result of this call will be via one of OnPeerConnected or OnPeerDisconnected.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyPeers() (e error) {
+	return c.NotifyPeersAsync().Receive()
+}
+
+// FutureNotifyWalletEventsResult is a future promise to deliver the result of a NotifyWalletEventsAsync RPC invocation
+// (or an applicable error).
+type FutureNotifyWalletEventsResult chan *response
+
+// Receive waits for the response promised by the future and returns an
+// error if the registration was not successful.
+func (r FutureNotifyWalletEventsResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// NotifyWalletEventsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See NotifyWalletEvents for the blocking version and more details.
+//
+// NOTE: This is a btcwallet extension and requires a websocket connection.
+func (c *Client) NotifyWalletEventsAsync(sinceSequence *uint64, sinceBlock *chainhash.Hash) FutureNotifyWalletEventsResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+	// Ignore the notification if the client is not interested in notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+	var block *string
+	if sinceBlock != nil {
+		block = btcjson.String(sinceBlock.String())
+	}
+	cmd := btcjson.NewNotifyWalletEventsCmd(sinceSequence, block)
+	return c.sendCmd(cmd)
+}
+
+// NotifyWalletEvents registers the client to receive the events of the notification journal of the wallet, which
+// records the blocks connected and disconnected and the relevant transactions in the order they change the wallet. The
+// events after the sequence number sinceSequence, or after the connection of the block sinceBlock, are sent first, so a
+// consumer that saves the sequence number of the last event it handled is sent the events it missed while it was not
+// running. With neither only new events are sent. It is an error if some of the events to send first are no longer in
+// the journal, in which case the consumer should fall back to listsinceblock.
+//
+// The notifications delivered as a result of this call will be via OnWalletEvent.
+//
+// NOTE: This is a btcwallet extension and requires a websocket connection.
+func (c *Client) NotifyWalletEvents(sinceSequence *uint64, sinceBlock *chainhash.Hash) (e error) {
+	return c.NotifyWalletEventsAsync(sinceSequence, sinceBlock).Receive()
+}
+
+// FutureNotifyTipDeltasResult is a future promise to deliver the result of a NotifyTipDeltasAsync RPC invocation (or
+// an applicable error).
+type FutureNotifyTipDeltasResult chan *response
+
+// Receive waits for the response promised by the future and returns an
+// error if the registration was not successful.
+func (r FutureNotifyTipDeltasResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// NotifyTipDeltasAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See NotifyTipDeltas for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyTipDeltasAsync() FutureNotifyTipDeltasResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+	// Ignore the notification if the client is not interested in notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+	cmd := btcjson.NewNotifyTipDeltasCmd()
+	return c.sendCmd(cmd)
+}
+
+// NotifyTipDeltas registers the client to receive notifications when a block becomes the new tip of the main chain,
+// with its header, its coinbase and the short ids of its other transactions, which can be matched against the mempool
+// with chainrpc.ShortIDKey and chainrpc.ShortTxID.
+//
+// The notifications delivered as a result of this call will be via OnTipDelta.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyTipDeltas() (e error) {
+	return c.NotifyTipDeltasAsync().Receive()
+}
+
 // FutureNotifySpentResult is a future promise to deliver the result of a NotifySpentAsync RPC invocation (or an
 // applicable error).
 //
@@ -852,7 +1145,7 @@ func (c *Client) NotifyNewTransactionsAsync(verbose bool) FutureNotifyNewTransac
 	if c.ntfnHandlers == nil {
 		return newNilFutureResult()
 	}
-	cmd := btcjson.NewNotifyNewTransactionsCmd(&verbose)
+	cmd := btcjson.NewNotifyNewTransactionsCmd(&verbose, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -869,6 +1162,36 @@ func (c *Client) NotifyNewTransactions(verbose bool) (e error) {
 	return c.NotifyNewTransactionsAsync(verbose).Receive()
 }
 
+// NotifyFilteredTransactionsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See NotifyFilteredTransactions for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyFilteredTransactionsAsync(verbose bool) FutureNotifyNewTransactionsResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+	// Ignore the notification if the client is not interested in notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+	filtered := true
+	cmd := btcjson.NewNotifyNewTransactionsCmd(&verbose, &filtered)
+	return c.sendCmd(cmd)
+}
+
+// NotifyFilteredTransactions registers the client to receive notifications for the transactions accepted to the memory
+// pool that match the transaction filter loaded with LoadTxFilter, in the same form as NotifyNewTransactions.
+//
+// The filter is not restored on reconnect, so it must be loaded again before any notifications are delivered.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) NotifyFilteredTransactions(verbose bool) (e error) {
+	return c.NotifyFilteredTransactionsAsync(verbose).Receive()
+}
+
 // FutureNotifyReceivedResult is a future promise to deliver the result of a NotifyReceivedAsync RPC invocation (or an
 // applicable error).
 //
@@ -1143,7 +1466,7 @@ func (c *Client) LoadTxFilterAsync(
 			Index: outPoints[i].Index,
 		}
 	}
-	cmd := btcjson.NewLoadTxFilterCmd(reload, addrStrs, outPointObjects)
+	cmd := btcjson.NewLoadTxFilterCmd(reload, addrStrs, outPointObjects, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -1156,3 +1479,57 @@ func (c *Client) LoadTxFilterAsync(
 func (c *Client) LoadTxFilter(reload bool, addresses []btcaddr.Address, outPoints []wire.OutPoint) (e error) {
 	return c.LoadTxFilterAsync(reload, addresses, outPoints).Receive()
 }
+
+// FutureUpdateTxFilterResult is a future promise to deliver the result of an UpdateTxFilterAsync RPC invocation (or an
+// applicable error).
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+type FutureUpdateTxFilterResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the update was not successful.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (r FutureUpdateTxFilterResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// UpdateTxFilterAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See UpdateTxFilter for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) UpdateTxFilterAsync(
+	remove bool, addresses []btcaddr.Address,
+	outPoints []wire.OutPoint, scripts [][]byte,
+) FutureUpdateTxFilterResult {
+	addrStrs := make([]string, len(addresses))
+	for i, a := range addresses {
+		addrStrs[i] = a.EncodeAddress()
+	}
+	outPointObjects := make([]btcjson.OutPoint, len(outPoints))
+	for i := range outPoints {
+		outPointObjects[i] = btcjson.OutPoint{
+			Hash:  outPoints[i].Hash.String(),
+			Index: outPoints[i].Index,
+		}
+	}
+	scriptStrs := make([]string, len(scripts))
+	for i, script := range scripts {
+		scriptStrs[i] = hex.EncodeToString(script)
+	}
+	cmd := btcjson.NewUpdateTxFilterCmd(remove, addrStrs, outPointObjects, &scriptStrs)
+	return c.sendCmd(cmd)
+}
+
+// UpdateTxFilter adds the addresses, outpoints and output scripts to the websocket client's loaded transaction filter,
+// or removes them from it when remove is true.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) UpdateTxFilter(
+	remove bool, addresses []btcaddr.Address,
+	outPoints []wire.OutPoint, scripts [][]byte,
+) (e error) {
+	return c.UpdateTxFilterAsync(remove, addresses, outPoints, scripts).Receive()
+}
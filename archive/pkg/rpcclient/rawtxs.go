@@ -258,6 +258,49 @@ func (c *Client) SendRawTransaction(tx *wire.MsgTx, allowHighFees bool) (*chainh
 	return c.SendRawTransactionAsync(tx, allowHighFees).Receive()
 }
 
+// FutureTraceMempoolAcceptResult is a future promise to deliver the result of a TraceMempoolAcceptAsync RPC invocation
+// (or an applicable error).
+type FutureTraceMempoolAcceptResult chan *response
+
+// Receive waits for the response promised by the future and returns the checks the server ran on the transaction to
+// decide whether to accept it to its memory pool.
+func (r FutureTraceMempoolAcceptResult) Receive() (*btcjson.TraceMempoolAcceptResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.TraceMempoolAcceptResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// TraceMempoolAcceptAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See TraceMempoolAccept for the blocking version and more details.
+func (c *Client) TraceMempoolAcceptAsync(tx *wire.MsgTx, allowHighFees bool) FutureTraceMempoolAcceptResult {
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if e := tx.Serialize(buf); E.Chk(e) {
+			return newFutureError(e)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+	cmd := btcjson.NewTraceMempoolAcceptCmd(txHex, &allowHighFees)
+	return c.sendCmd(cmd)
+}
+
+// TraceMempoolAccept asks the server to run the checks for accepting the transaction to its memory pool without
+// accepting or relaying it, and returns the checks in the order they ran with the reason for any rejection.
+func (c *Client) TraceMempoolAccept(tx *wire.MsgTx, allowHighFees bool) (*btcjson.TraceMempoolAcceptResult, error) {
+	return c.TraceMempoolAcceptAsync(tx, allowHighFees).Receive()
+}
+
 // FutureSignRawTransactionResult is a future promise to deliver the result of one of the SignRawTransactionAsync family
 // of RPC invocations (or an applicable error).
 type FutureSignRawTransactionResult chan *response
@@ -300,7 +343,7 @@ func (c *Client) SignRawTransactionAsync(tx *wire.MsgTx) FutureSignRawTransactio
 		}
 		txHex = hex.EncodeToString(buf.Bytes())
 	}
-	cmd := btcjson.NewSignRawTransactionCmd(txHex, nil, nil, nil)
+	cmd := btcjson.NewSignRawTransactionCmd(txHex, nil, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -329,7 +372,7 @@ func (c *Client) SignRawTransaction2Async(tx *wire.MsgTx, inputs []btcjson.RawTx
 		}
 		txHex = hex.EncodeToString(buf.Bytes())
 	}
-	cmd := btcjson.NewSignRawTransactionCmd(txHex, &inputs, nil, nil)
+	cmd := btcjson.NewSignRawTransactionCmd(txHex, &inputs, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -364,7 +407,7 @@ func (c *Client) SignRawTransaction3Async(
 	}
 	cmd := btcjson.NewSignRawTransactionCmd(
 		txHex, &inputs, &privKeysWIF,
-		nil,
+		nil, nil,
 	)
 	return c.sendCmd(cmd)
 }
@@ -410,7 +453,7 @@ func (c *Client) SignRawTransaction4Async(
 	}
 	cmd := btcjson.NewSignRawTransactionCmd(
 		txHex, &inputs, &privKeysWIF,
-		btcjson.String(string(hashType)),
+		btcjson.String(string(hashType)), nil,
 	)
 	return c.sendCmd(cmd)
 }
@@ -596,3 +639,69 @@ func (c *Client) DecodeScriptAsync(serializedScript []byte) FutureDecodeScriptRe
 func (c *Client) DecodeScript(serializedScript []byte) (*btcjson.DecodeScriptResult, error) {
 	return c.DecodeScriptAsync(serializedScript).Receive()
 }
+
+// FutureGetDescriptorInfoResult is a future promise to deliver the result of a GetDescriptorInfoAsync RPC invocation
+// (or an applicable error).
+type FutureGetDescriptorInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns information about the descriptor.
+func (r FutureGetDescriptorInfoResult) Receive() (*btcjson.GetDescriptorInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var info btcjson.GetDescriptorInfoResult
+	e = js.Unmarshal(res, &info)
+	if e != nil {
+		return nil, e
+	}
+	return &info, nil
+}
+
+// GetDescriptorInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetDescriptorInfo for the blocking version and more details.
+func (c *Client) GetDescriptorInfoAsync(descriptor string) FutureGetDescriptorInfoResult {
+	cmd := btcjson.NewGetDescriptorInfoCmd(descriptor)
+	return c.sendCmd(cmd)
+}
+
+// GetDescriptorInfo returns the canonical form and checksum of an output script descriptor, and whether it is a range,
+// is solvable and has private keys.
+func (c *Client) GetDescriptorInfo(descriptor string) (*btcjson.GetDescriptorInfoResult, error) {
+	return c.GetDescriptorInfoAsync(descriptor).Receive()
+}
+
+// FutureDeriveAddressesResult is a future promise to deliver the result of a DeriveAddressesAsync RPC invocation (or an
+// applicable error).
+type FutureDeriveAddressesResult chan *response
+
+// Receive waits for the response promised by the future and returns the derived addresses.
+func (r FutureDeriveAddressesResult) Receive() ([]string, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var addrs []string
+	e = js.Unmarshal(res, &addrs)
+	if e != nil {
+		return nil, e
+	}
+	return addrs, nil
+}
+
+// DeriveAddressesAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See DeriveAddresses for the blocking version and more details.
+func (c *Client) DeriveAddressesAsync(descriptor string, rng *[]int64) FutureDeriveAddressesResult {
+	cmd := btcjson.NewDeriveAddressesCmd(descriptor, rng)
+	return c.sendCmd(cmd)
+}
+
+// DeriveAddresses returns the addresses of the output scripts described by a descriptor with its checksum appended,
+// for the indexes in the range if it is a ranged descriptor.
+func (c *Client) DeriveAddresses(descriptor string, rng *[]int64) ([]string, error) {
+	return c.DeriveAddressesAsync(descriptor, rng).Receive()
+}
@@ -0,0 +1,289 @@
+package rpcclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures the automatic retry of requests that fail with a transient transport error, such as a refused
+// or reset connection or a timeout, when the client is running in HTTP POST mode.
+//
+// Only read-only methods, for which IsReadOnlyMethod returns true, are retried. Requests of any other method are never
+// sent twice by the client, and when their transport fails after they may have reached the server the error is an
+// *IndeterminateError.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after the first attempt. Zero disables retries.
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry, which doubles with each further retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, from 0 to 1, of each delay that is randomised so clients failing together don't retry
+	// together.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the retry policy used when the connection configuration does not set one.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:  3,
+	BaseBackoff: time.Millisecond * 250,
+	MaxBackoff:  time.Second * 5,
+	Jitter:      0.5,
+}
+
+// backoff returns the delay before the given retry, counting from zero.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseBackoff
+	for i := 0; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+	return d
+}
+
+// readOnlyMethods are the methods that don't change the state of the node or wallet, so a request of them can be sent
+// again when it is not known whether the server received it.
+var readOnlyMethods = map[string]struct{}{
+	"createmultisig":          {},
+	"createrawtransaction":    {},
+	"decoderawtransaction":    {},
+	"decodescript":            {},
+	"deriveaddresses":         {},
+	"estimatefee":             {},
+	"estimatepriority":        {},
+	"estimatesmartfee":        {},
+	"estimatorstats":          {},
+	"getaccount":              {},
+	"getaddednodeinfo":        {},
+	"getaddressesbyaccount":   {},
+	"getauditlog":             {},
+	"getbalance":              {},
+	"getbestblock":            {},
+	"getbestblockhash":        {},
+	"getblock":                {},
+	"getblockchaininfo":       {},
+	"getblockcount":           {},
+	"getblockhash":            {},
+	"getblockheader":          {},
+	"getblockpropagation":     {},
+	"getcachestats":           {},
+	"getcfilter":              {},
+	"getcfilterheader":        {},
+	"getchaintips":            {},
+	"getconnectioncount":      {},
+	"getcurrentnet":           {},
+	"getdescriptorinfo":       {},
+	"getdifficulty":           {},
+	"getdnsaudit":             {},
+	"getfeatures":             {},
+	"getgenerate":             {},
+	"gethashespersec":         {},
+	"getheaders":              {},
+	"getindexinfo":            {},
+	"getinfo":                 {},
+	"getmempoolentry":         {},
+	"getmempoolinfo":          {},
+	"getmininginfo":           {},
+	"getnettotals":            {},
+	"getnetworkhashps":        {},
+	"getnetworkinfo":          {},
+	"getnodeaddresses":        {},
+	"getnotificationinfo":     {},
+	"getorphanblocks":         {},
+	"getorphantxs":            {},
+	"getpeerinfo":             {},
+	"getrawmempool":           {},
+	"getrawtransaction":       {},
+	"getreceivedbyaccount":    {},
+	"getreceivedbyaddress":    {},
+	"getscriptflags":          {},
+	"gettimestampinfo":        {},
+	"gettransaction":          {},
+	"gettxout":                {},
+	"gettxoutproof":           {},
+	"gettxoutsetinfo":         {},
+	"getunconfirmedbalance":   {},
+	"getvalidationtrace":      {},
+	"getwalletinfo":           {},
+	"help":                    {},
+	"listaccounts":            {},
+	"listaddressgroupings":    {},
+	"listaddresstransactions": {},
+	"listalltransactions":     {},
+	"listimmature":            {},
+	"listlockunspent":         {},
+	"listreceivedbyaccount":   {},
+	"listreceivedbyaddress":   {},
+	"listsinceblock":          {},
+	"listtransactions":        {},
+	"listtransactionspage":    {},
+	"listunlockattempts":      {},
+	"listunspent":             {},
+	"ping":                    {},
+	"searchrawtransactions":   {},
+	"selftest":                {},
+	"signmessage":             {},
+	"signrawtransaction":      {},
+	"tracemempoolaccept":      {},
+	"uptime":                  {},
+	"validateaddress":         {},
+	"verifychain":             {},
+	"verifymessage":           {},
+	"verifytxoutproof":        {},
+	"version":                 {},
+	"walletdbstats":           {},
+	"walletislocked":          {},
+}
+
+// IsReadOnlyMethod returns whether the RPC method doesn't change the state of the node or wallet, and so is retried
+// automatically on transient transport errors. Methods that generate addresses, such as getnewaddress, are not
+// read-only.
+func IsReadOnlyMethod(method string) bool {
+	_, ok := readOnlyMethods[method]
+	return ok
+}
+
+// IndeterminateError is returned for a request of a method that is not read-only when its transport failed after the
+// request may have reached the server, so it is not known whether the server carried it out. The client does not send
+// such requests again, as doing so could, for example, pay an address twice.
+//
+// The request should be reconciled with the state of the server before it is retried, for example by looking for the
+// transaction of a sendtoaddress request with listtransactions or listsinceblock.
+type IndeterminateError struct {
+	// Method is the method of the request.
+	Method string
+	// ID is the JSON-RPC id of the request.
+	ID uint64
+	// Cmd is the command of the request, which is nil for raw requests.
+	Cmd interface{}
+	// Request is the marshalled JSON-RPC request.
+	Request []byte
+	// Err is the transport error the request failed with.
+	Err error
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e *IndeterminateError) Error() string {
+	return fmt.Sprintf("outcome of %s request %d is unknown: %v", e.Method, e.ID, e.Err)
+}
+
+// Unwrap returns the transport error the request failed with.
+func (e *IndeterminateError) Unwrap() error {
+	return e.Err
+}
+
+// newIndeterminateError returns an IndeterminateError for the request that failed with the transport error err.
+func newIndeterminateError(jReq *jsonRequest, err error) *IndeterminateError {
+	return &IndeterminateError{
+		Method:  jReq.method,
+		ID:      jReq.id,
+		Cmd:     jReq.cmd,
+		Request: jReq.marshalledJSON,
+		Err:     err,
+	}
+}
+
+// transportError is an error in carrying a request to the server and its reply back, as opposed to an error returned
+// by the server.
+type transportError struct {
+	err error
+	// transient is whether the error is likely to go away if the request is sent again.
+	transient bool
+	// unsent is whether the request certainly did not reach the server.
+	unsent bool
+}
+
+// newTransportError classifies an error returned by the HTTP client when sending a request or reading its reply.
+func newTransportError(e error) *transportError {
+	te := &transportError{err: e}
+	var certErr x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	if errors.As(e, &certErr) || errors.As(e, &hostErr) {
+		return te
+	}
+	var opErr *net.OpError
+	if errors.As(e, &opErr) {
+		te.transient = true
+		te.unsent = opErr.Op == "dial"
+		return te
+	}
+	var netErr net.Error
+	switch {
+	case errors.As(e, &netErr) && netErr.Timeout(),
+		errors.Is(e, io.EOF),
+		errors.Is(e, io.ErrUnexpectedEOF),
+		errors.Is(e, syscall.ECONNREFUSED),
+		errors.Is(e, syscall.ECONNRESET),
+		errors.Is(e, syscall.EPIPE):
+		te.transient = true
+	}
+	return te
+}
+
+// isTransientStatus returns whether an HTTP status code of a reply that is not a JSON-RPC response is likely to be from
+// a proxy or load balancer in front of the server that will pass the request on if it is sent again.
+func isTransientStatus(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryPolicy returns the retry policy of the client.
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.config.Retry != nil {
+		return c.config.Retry
+	}
+	return &DefaultRetryPolicy
+}
+
+// handleTransportError delivers the reply to a request whose transport failed with te. Read-only requests that failed
+// with a transient error are sent again after a delay, while other requests that may have reached the server are failed
+// with an IndeterminateError.
+func (c *Client) handleTransportError(jReq *jsonRequest, te *transportError) {
+	if !IsReadOnlyMethod(jReq.method) {
+		if te.unsent {
+			jReq.responseChan <- &response{err: te.err}
+		} else {
+			jReq.responseChan <- &response{err: newIndeterminateError(jReq, te.err)}
+		}
+		return
+	}
+	policy := c.retryPolicy()
+	if !te.transient || jReq.retries >= policy.MaxRetries {
+		jReq.responseChan <- &response{err: te.err}
+		return
+	}
+	delay := policy.backoff(jReq.retries)
+	jReq.retries++
+	D.F(
+		"retrying %s request %d in %v after transport error (retry %d of %d): %v",
+		jReq.method, jReq.id, delay, jReq.retries, policy.MaxRetries, te.err,
+	)
+	// The retry waits in its own goroutine so the send handler can carry on with other requests.
+	go func() {
+		select {
+		case <-time.After(delay):
+			c.sendPost(jReq)
+		case <-c.shutdown.Wait():
+			jReq.responseChan <- &response{err: ErrClientShutdown}
+		}
+	}()
+}
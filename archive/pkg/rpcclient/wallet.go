@@ -1,10 +1,10 @@
 package rpcclient
 
 import (
+	"encoding/hex"
 	js "encoding/json"
 	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/btcaddr"
-	"github.com/p9c/pod/pkg/chaincfg"
 	"strconv"
 	
 	"github.com/p9c/pod/pkg/btcjson"
@@ -126,6 +126,49 @@ func (c *Client) ListTransactionsCountFrom(account string, count, from int) ([]b
 	return c.ListTransactionsCountFromAsync(account, count, from).Receive()
 }
 
+// FutureListTransactionsPageResult is a future promise to deliver the result of a ListTransactionsPageAsync RPC
+// invocation (or an applicable error).
+type FutureListTransactionsPageResult chan *response
+
+// Receive waits for the response promised by the future and returns a page of the most recent transactions along with
+// the cursor to get the next page with.
+func (r FutureListTransactionsPageResult) Receive() (*btcjson.ListTransactionsPageResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var page btcjson.ListTransactionsPageResult
+	e = js.Unmarshal(res, &page)
+	if e != nil {
+		return nil, e
+	}
+	return &page, nil
+}
+
+// ListTransactionsPageAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListTransactionsPage for the blocking version and more details.
+func (c *Client) ListTransactionsPageAsync(
+	cursor string, count int, filter *btcjson.ListTransactionsFilter,
+) FutureListTransactionsPageResult {
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+	cmd := btcjson.NewListTransactionsPageCmd(cursorPtr, &count, filter)
+	return c.sendCmd(cmd)
+}
+
+// ListTransactionsPage returns up to count of the most recent transactions that pass the filter, which may be nil,
+// starting after the transaction the cursor points at. The first page is returned for an empty cursor, and the
+// NextCursor of the result is empty when there are no more pages.
+func (c *Client) ListTransactionsPage(
+	cursor string, count int, filter *btcjson.ListTransactionsFilter,
+) (*btcjson.ListTransactionsPageResult, error) {
+	return c.ListTransactionsPageAsync(cursor, count, filter).Receive()
+}
+
 // FutureListUnspentResult is a future promise to deliver the result of a ListUnspentAsync, ListUnspentMinAsync,
 // ListUnspentMinMaxAsync, or ListUnspentMinMaxAddressesAsync RPC invocation (or an applicable error).
 type FutureListUnspentResult chan *response
@@ -373,430 +416,1969 @@ func (c *Client) ListLockUnspent() ([]*wire.OutPoint, error) {
 	return c.ListLockUnspentAsync().Receive()
 }
 
-// FutureSetTxFeeResult is a future promise to deliver the result of a SetTxFeeAsync RPC invocation (or an applicable
-// error).
-type FutureSetTxFeeResult chan *response
+// FutureFreezeUnspentResult is a future promise to deliver the error result of a FreezeUnspentAsync RPC invocation.
+type FutureFreezeUnspentResult chan *response
 
-// Receive waits for the response promised by the future and returns the result of setting an optional transaction fee
-// per KB that helps ensure transactions are processed quickly. Most transaction are 1KB.
-func (r FutureSetTxFeeResult) Receive() (e error) {
+// Receive waits for the response promised by the future and returns the result of freezing or unfreezing the
+// output(s).
+func (r FutureFreezeUnspentResult) Receive() (e error) {
 	_, e = receiveFuture(r)
 	return e
 }
 
-// SetTxFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// FreezeUnspentAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
 // invoking the Receive function on the returned instance.
 //
-// See SetTxFee for the blocking version and more details.
-func (c *Client) SetTxFeeAsync(fee amt.Amount) FutureSetTxFeeResult {
-	cmd := btcjson.NewSetTxFeeCmd(fee.ToDUO())
+// See FreezeUnspent for the blocking version and more details.
+func (c *Client) FreezeUnspentAsync(unfreeze bool, ops []*wire.OutPoint, reason string) FutureFreezeUnspentResult {
+	outputs := make([]btcjson.TransactionInput, len(ops))
+	for i, op := range ops {
+		outputs[i] = btcjson.TransactionInput{
+			Txid: op.Hash.String(),
+			Vout: op.Index,
+		}
+	}
+	var r *string
+	if !unfreeze {
+		r = &reason
+	}
+	cmd := btcjson.NewFreezeUnspentCmd(unfreeze, outputs, r)
 	return c.sendCmd(cmd)
 }
 
-// SetTxFee sets an optional transaction fee per KB that helps ensure transactions are processed quickly. Most
-// transaction are 1KB.
-func (c *Client) SetTxFee(fee amt.Amount) (e error) {
-	return c.SetTxFeeAsync(fee).Receive()
+// FreezeUnspent freezes or unfreezes outputs, depending on the value of the unfreeze bool. Frozen outputs are never
+// selected as inputs for newly created, non-raw transactions, and are not returned in ListUnspent results, until they
+// are unfrozen.
+//
+// Unlike LockUnspent, the frozen outputs are stored in the wallet with the reason they were frozen for, so they stay
+// frozen when the wallet is restarted, and all of them can't be unfrozen at once.
+func (c *Client) FreezeUnspent(unfreeze bool, ops []*wire.OutPoint, reason string) (e error) {
+	return c.FreezeUnspentAsync(unfreeze, ops, reason).Receive()
 }
 
-// FutureSendToAddressResult is a future promise to deliver the result of a SendToAddressAsync RPC invocation (or an
+// FutureListFrozenResult is a future promise to deliver the result of a ListFrozenAsync RPC invocation (or an
 // applicable error).
-type FutureSendToAddressResult chan *response
+type FutureListFrozenResult chan *response
 
-// Receive waits for the response promised by the future and returns the hash of the transaction sending the passed
-// amount to the given address.
-func (r FutureSendToAddressResult) Receive() (*chainhash.Hash, error) {
+// Receive waits for the response promised by the future and returns the frozen outputs.
+func (r FutureListFrozenResult) Receive() ([]btcjson.FrozenOutputResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmarshal result as a string.
-	var txHash string
-	e = js.Unmarshal(res, &txHash)
+	var result []btcjson.FrozenOutputResult
+	e = js.Unmarshal(res, &result)
 	if e != nil {
 		return nil, e
 	}
-	return chainhash.NewHashFromStr(txHash)
+	return result, nil
 }
 
-// SendToAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// ListFrozenAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
 // invoking the Receive function on the returned instance.
 //
-// See SendToAddress for the blocking version and more details.
-func (c *Client) SendToAddressAsync(address btcaddr.Address, amount amt.Amount) FutureSendToAddressResult {
-	addr := address.EncodeAddress()
-	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil)
+// See ListFrozen for the blocking version and more details.
+func (c *Client) ListFrozenAsync() FutureListFrozenResult {
+	cmd := btcjson.NewListFrozenCmd()
 	return c.sendCmd(cmd)
 }
 
-// SendToAddress sends the passed amount to the given address.
-//
-// See SendToAddressComment to associate comments with the transaction in the wallet. The comments are not part of the
-// transaction and are only internal to the wallet.
-//
-// NOTE: This function requires to the wallet to be unlocked.
-//
-// See the WalletPassphrase function for more details.
-func (c *Client) SendToAddress(address btcaddr.Address, amount amt.Amount) (*chainhash.Hash, error) {
-	return c.SendToAddressAsync(address, amount).Receive()
+// ListFrozen returns the outputs frozen in the wallet, in the order they were frozen, with the reasons they were frozen
+// for.
+func (c *Client) ListFrozen() ([]btcjson.FrozenOutputResult, error) {
+	return c.ListFrozenAsync().Receive()
 }
 
-// SendToAddressCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// FutureAddPortfolioEntryResult is a future promise to deliver the result of an AddPortfolioEntryAsync RPC invocation
+// (or an applicable error).
+type FutureAddPortfolioEntryResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of adding the portfolio entry.
+func (r FutureAddPortfolioEntryResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// AddPortfolioEntryAsync returns an instance of a type that can be used to get the result of the RPC at some future
 // time by invoking the Receive function on the returned instance.
 //
-// See SendToAddressComment for the blocking version and more details.
-func (c *Client) SendToAddressCommentAsync(
-	address btcaddr.Address,
-	amount amt.Amount, comment,
-	commentTo string,
-) FutureSendToAddressResult {
-	addr := address.EncodeAddress()
-	cmd := btcjson.NewSendToAddressCmd(
-		addr, amount.ToDUO(), &comment,
-		&commentTo,
-	)
+// See AddPortfolioEntry for the blocking version and more details.
+func (c *Client) AddPortfolioEntryAsync(
+	name string, descriptors []string, rng int, rescan bool,
+) FutureAddPortfolioEntryResult {
+	cmd := btcjson.NewAddPortfolioEntryCmd(name, descriptors, &rng, &rescan)
 	return c.sendCmd(cmd)
 }
 
-// SendToAddressComment sends the passed amount to the given address and stores the provided comment and comment to in
-// the wallet. The comment parameter is intended to be used for the purpose of the transaction while the commentTo
-// parameter is indended to be used for who the transaction is being sent to.
-//
-// The comments are not part of the transaction and are only internal to the wallet.
-//
-// See SendToAddress to avoid using comments.
+// AddPortfolioEntry adds a cold wallet to the portfolio of the wallet under the name, watching the output scripts of
+// its public descriptors or extended public keys, with rng scripts derived from each that is ranged. When rescan is
+// true, the block history is searched for the scripts in the background.
+func (c *Client) AddPortfolioEntry(name string, descriptors []string, rng int, rescan bool) (e error) {
+	return c.AddPortfolioEntryAsync(name, descriptors, rng, rescan).Receive()
+}
+
+// FutureRemovePortfolioEntryResult is a future promise to deliver the result of a RemovePortfolioEntryAsync RPC
+// invocation (or an applicable error).
+type FutureRemovePortfolioEntryResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of removing the portfolio entry.
+func (r FutureRemovePortfolioEntryResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// RemovePortfolioEntryAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
 //
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendToAddressComment(
-	address btcaddr.Address,
-	amount amt.Amount,
-	comment, commentTo string,
-) (*chainhash.Hash, error) {
-	return c.SendToAddressCommentAsync(
-		address, amount, comment,
-		commentTo,
-	).Receive()
+// See RemovePortfolioEntry for the blocking version and more details.
+func (c *Client) RemovePortfolioEntryAsync(name string) FutureRemovePortfolioEntryResult {
+	cmd := btcjson.NewRemovePortfolioEntryCmd(name)
+	return c.sendCmd(cmd)
 }
 
-// FutureSendFromResult is a future promise to deliver the result of a SendFromAsync, SendFromMinConfAsync, or
-// SendFromCommentAsync RPC invocation (or an applicable error).
-type FutureSendFromResult chan *response
+// RemovePortfolioEntry removes the cold wallet with the name from the portfolio of the wallet.
+func (c *Client) RemovePortfolioEntry(name string) (e error) {
+	return c.RemovePortfolioEntryAsync(name).Receive()
+}
 
-// Receive waits for the response promised by the future and returns the hash of the transaction sending amount to the
-// given address using the provided account as a source of funds.
-func (r FutureSendFromResult) Receive() (*chainhash.Hash, error) {
+// FutureListPortfolioResult is a future promise to deliver the result of a ListPortfolioAsync RPC invocation (or an
+// applicable error).
+type FutureListPortfolioResult chan *response
+
+// Receive waits for the response promised by the future and returns the entries of the portfolio with their funds.
+func (r FutureListPortfolioResult) Receive() (*btcjson.ListPortfolioResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmarshal result as a string.
-	var txHash string
-	e = js.Unmarshal(res, &txHash)
+	var result btcjson.ListPortfolioResult
+	e = js.Unmarshal(res, &result)
 	if e != nil {
 		return nil, e
 	}
-	return chainhash.NewHashFromStr(txHash)
+	return &result, nil
 }
 
-// SendFromAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// ListPortfolioAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
 // invoking the Receive function on the returned instance.
 //
-// See SendFrom for the blocking version and more details.
-func (c *Client) SendFromAsync(fromAccount string, toAddress btcaddr.Address, amount amt.Amount) FutureSendFromResult {
-	addr := toAddress.EncodeAddress()
-	cmd := btcjson.NewSendFromCmd(
-		fromAccount, addr, amount.ToDUO(), nil,
-		nil, nil,
-	)
+// See ListPortfolio for the blocking version and more details.
+func (c *Client) ListPortfolioAsync(minConf int) FutureListPortfolioResult {
+	cmd := btcjson.NewListPortfolioCmd(&minConf)
 	return c.sendCmd(cmd)
 }
 
-// SendFrom sends the passed amount to the given address using the provided account as a source of funds. Only funds
-// with the default number of minimum confirmations will be used.
-//
-// See SendFromMinConf and SendFromComment for different options.
-//
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendFrom(fromAccount string, toAddress btcaddr.Address, amount amt.Amount) (*chainhash.Hash, error) {
-	return c.SendFromAsync(fromAccount, toAddress, amount).Receive()
+// ListPortfolio returns the cold wallets in the portfolio of the wallet with the funds each holds, counting outputs
+// with at least minConf confirmations as confirmed, and those of all of them added up.
+func (c *Client) ListPortfolio(minConf int) (*btcjson.ListPortfolioResult, error) {
+	return c.ListPortfolioAsync(minConf).Receive()
 }
 
-// SendFromMinConfAsync returns an instance of a type that can be used to get the result of the RPC at some future time
-// by invoking the Receive function on the returned instance.
+// FutureListPortfolioTransactionsResult is a future promise to deliver the result of a ListPortfolioTransactionsAsync
+// RPC invocation (or an applicable error).
+type FutureListPortfolioTransactionsResult chan *response
+
+// Receive waits for the response promised by the future and returns the changes transactions made to the funds of the
+// portfolio.
+func (r FutureListPortfolioTransactionsResult) Receive() ([]btcjson.PortfolioTransactionResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.PortfolioTransactionResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListPortfolioTransactionsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
 //
-// See SendFromMinConf for the blocking version and more details.
-func (c *Client) SendFromMinConfAsync(
-	fromAccount string,
-	toAddress btcaddr.Address,
-	amount amt.Amount,
-	minConfirms int,
-) FutureSendFromResult {
-	addr := toAddress.EncodeAddress()
-	cmd := btcjson.NewSendFromCmd(
-		fromAccount, addr, amount.ToDUO(),
-		&minConfirms, nil, nil,
-	)
+// See ListPortfolioTransactions for the blocking version and more details.
+func (c *Client) ListPortfolioTransactionsAsync(name string, count int) FutureListPortfolioTransactionsResult {
+	cmd := btcjson.NewListPortfolioTransactionsCmd(&name, &count)
 	return c.sendCmd(cmd)
 }
 
-// SendFromMinConf sends the passed amount to the given address using the provided account as a source of funds. Only
-// funds with the passed number of minimum confirmations will be used.
-//
-// See SendFrom to use the default number of minimum confirmations and SendFromComment for additional options.
-//
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendFromMinConf(
-	fromAccount string,
-	toAddress btcaddr.Address,
-	amount amt.Amount,
-	minConfirms int,
-) (*chainhash.Hash, error) {
-	return c.SendFromMinConfAsync(
-		fromAccount, toAddress, amount,
-		minConfirms,
-	).Receive()
+// ListPortfolioTransactions returns the latest count changes transactions made to the funds of the portfolio entry
+// with the name, or of every entry if name is empty, newest first.
+func (c *Client) ListPortfolioTransactions(name string, count int) ([]btcjson.PortfolioTransactionResult, error) {
+	return c.ListPortfolioTransactionsAsync(name, count).Receive()
 }
 
-// SendFromCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time
-// by invoking the Receive function on the returned instance.
+// FutureExportPaymentBundleResult is a future promise to deliver the result of an ExportPaymentBundleAsync RPC
+// invocation (or an applicable error).
+type FutureExportPaymentBundleResult chan *response
+
+// Receive waits for the response promised by the future and returns the signed payment bundle.
+func (r FutureExportPaymentBundleResult) Receive() (*btcjson.ExportPaymentBundleResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.ExportPaymentBundleResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// ExportPaymentBundleAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
 //
-// See SendFromComment for the blocking version and more details.
-func (c *Client) SendFromCommentAsync(
-	fromAccount string,
-	toAddress btcaddr.Address, amount amt.Amount, minConfirms int,
-	comment, commentTo string,
-) FutureSendFromResult {
-	addr := toAddress.EncodeAddress()
-	cmd := btcjson.NewSendFromCmd(
-		fromAccount, addr, amount.ToDUO(),
-		&minConfirms, &comment, &commentTo,
-	)
+// See ExportPaymentBundle for the blocking version and more details.
+func (c *Client) ExportPaymentBundleAsync(
+	account string, requests []btcjson.PaymentBundleRequest, expires int64, signAddress string,
+) FutureExportPaymentBundleResult {
+	cmd := btcjson.NewExportPaymentBundleCmd(account, requests, &expires, &signAddress)
 	return c.sendCmd(cmd)
 }
 
-// SendFromComment sends the passed amount to the given address using the provided account as a source of funds and
-// stores the provided comment and comment to in the wallet. The comment parameter is intended to be used for the
-// purpose of the transaction while the commentTo parameter is intended to be used for who the transaction is being sent
-// to. Only funds with the passed number of minimum confirmations will be used.
-//
-// See SendFrom and SendFromMinConf to use defaults.
-//
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendFromComment(
-	fromAccount string, toAddress btcaddr.Address,
-	amount amt.Amount, minConfirms int,
-	comment, commentTo string,
-) (*chainhash.Hash, error) {
-	return c.SendFromCommentAsync(
-		fromAccount, toAddress, amount,
-		minConfirms, comment, commentTo,
-	).Receive()
+// ExportPaymentBundle makes a fresh receiving address of the account for each payment request and returns them as a
+// bundle signed with the key of signAddress, or of the first address of the account if it is empty. The addresses
+// expire after expires seconds, never if it is 0.
+func (c *Client) ExportPaymentBundle(
+	account string, requests []btcjson.PaymentBundleRequest, expires int64, signAddress string,
+) (*btcjson.ExportPaymentBundleResult, error) {
+	return c.ExportPaymentBundleAsync(account, requests, expires, signAddress).Receive()
 }
 
-// FutureSendManyResult is a future promise to deliver the result of a SendManyAsync, SendManyMinConfAsync, or
-// SendManyCommentAsync RPC invocation (or an applicable error).
-type FutureSendManyResult chan *response
+// FutureGetPaymentBundleResult is a future promise to deliver the result of a GetPaymentBundleAsync RPC invocation (or
+// an applicable error).
+type FutureGetPaymentBundleResult chan *response
 
-// Receive waits for the response promised by the future and returns the hash of the transaction sending multiple
-// amounts to multiple addresses using the provided account as a source of funds.
-func (r FutureSendManyResult) Receive() (*chainhash.Hash, error) {
+// Receive waits for the response promised by the future and returns the settlement of the payment bundle.
+func (r FutureGetPaymentBundleResult) Receive() (*btcjson.PaymentBundleResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmashal result as a string.
-	var txHash string
-	e = js.Unmarshal(res, &txHash)
+	var result btcjson.PaymentBundleResult
+	e = js.Unmarshal(res, &result)
 	if e != nil {
 		return nil, e
 	}
-	return chainhash.NewHashFromStr(txHash)
+	return &result, nil
 }
 
-// SendManyAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
-// invoking the Receive function on the returned instance.
+// GetPaymentBundleAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
 //
-// See SendMany for the blocking version and more details.
-func (c *Client) SendManyAsync(fromAccount string, amounts map[btcaddr.Address]amt.Amount) FutureSendManyResult {
-	convertedAmounts := make(map[string]float64, len(amounts))
-	for addr, amount := range amounts {
-		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
-	}
-	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
+// See GetPaymentBundle for the blocking version and more details.
+func (c *Client) GetPaymentBundleAsync(id string, minConf int) FutureGetPaymentBundleResult {
+	cmd := btcjson.NewGetPaymentBundleCmd(id, &minConf)
 	return c.sendCmd(cmd)
 }
 
-// SendMany sends multiple amounts to multiple addresses using the provided account as a source of funds in a single
-// transaction. Only funds with the default number of minimum confirmations will be used.
-//
-// See SendManyMinConf and SendManyComment for different options.
-//
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendMany(fromAccount string, amounts map[btcaddr.Address]amt.Amount) (*chainhash.Hash, error) {
-	return c.SendManyAsync(fromAccount, amounts).Receive()
+// GetPaymentBundle returns the settlement of the payment bundle with the id, counting payments with at least minConf
+// confirmations.
+func (c *Client) GetPaymentBundle(id string, minConf int) (*btcjson.PaymentBundleResult, error) {
+	return c.GetPaymentBundleAsync(id, minConf).Receive()
 }
 
-// SendManyMinConfAsync returns an instance of a type that can be used to get the result of the RPC at some future time
-// by invoking the Receive function on the returned instance.
-//
-// See SendManyMinConf for the blocking version and more details.
-func (c *Client) SendManyMinConfAsync(
-	fromAccount string,
-	amounts map[btcaddr.Address]amt.Amount,
-	minConfirms int,
-) FutureSendManyResult {
-	convertedAmounts := make(map[string]float64, len(amounts))
-	for addr, amount := range amounts {
-		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+// FutureListPaymentBundlesResult is a future promise to deliver the result of a ListPaymentBundlesAsync RPC invocation
+// (or an applicable error).
+type FutureListPaymentBundlesResult chan *response
+
+// Receive waits for the response promised by the future and returns the settlement of every payment bundle.
+func (r FutureListPaymentBundlesResult) Receive() ([]btcjson.PaymentBundleResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
 	}
-	cmd := btcjson.NewSendManyCmd(
-		fromAccount, convertedAmounts,
-		&minConfirms, nil,
-	)
+	var result []btcjson.PaymentBundleResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListPaymentBundlesAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListPaymentBundles for the blocking version and more details.
+func (c *Client) ListPaymentBundlesAsync(minConf int) FutureListPaymentBundlesResult {
+	cmd := btcjson.NewListPaymentBundlesCmd(&minConf)
 	return c.sendCmd(cmd)
 }
 
-// SendManyMinConf sends multiple amounts to multiple addresses using the provided account as a source of funds in a
-// single transaction. Only funds with the passed number of minimum confirmations will be used.
+// ListPaymentBundles returns the settlement of every payment bundle of the wallet, oldest first, counting payments
+// with at least minConf confirmations.
+func (c *Client) ListPaymentBundles(minConf int) ([]btcjson.PaymentBundleResult, error) {
+	return c.ListPaymentBundlesAsync(minConf).Receive()
+}
+
+// FutureGetUTXOReportResult is a future promise to deliver the result of a GetUTXOReportAsync RPC invocation (or an
+// applicable error).
+type FutureGetUTXOReportResult chan *response
+
+// Receive waits for the response promised by the future and returns the report of the unspent outputs of the wallet.
+func (r FutureGetUTXOReportResult) Receive() (*btcjson.GetUTXOReportResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.GetUTXOReportResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetUTXOReportAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
 //
-// See SendMany to use the default number of minimum confirmations and SendManyComment for additional options.
+// See GetUTXOReport for the blocking version and more details.
+func (c *Client) GetUTXOReportAsync(feeRate amt.Amount) FutureGetUTXOReportResult {
+	var rate *float64
+	if feeRate > 0 {
+		rate = btcjson.Float64(feeRate.ToDUO())
+	}
+	cmd := btcjson.NewGetUTXOReportCmd(rate)
+	return c.sendCmd(cmd)
+}
+
+// GetUTXOReport returns the unspent outputs of the wallet summed up by age and by amount, with those that cost more to
+// spend than they are worth at the fee rate per kilobyte, or at the fee rate of the wallet if it is zero.
+func (c *Client) GetUTXOReport(feeRate amt.Amount) (*btcjson.GetUTXOReportResult, error) {
+	return c.GetUTXOReportAsync(feeRate).Receive()
+}
+
+// FutureListDustOutputsResult is a future promise to deliver the result of a ListDustOutputsAsync RPC invocation (or
+// an applicable error).
+type FutureListDustOutputsResult chan *response
+
+// Receive waits for the response promised by the future and returns the outputs taken for a dusting attack.
+func (r FutureListDustOutputsResult) Receive() ([]btcjson.DustOutputResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.DustOutputResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListDustOutputsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
 //
-// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendManyMinConf(
-	fromAccount string,
-	amounts map[btcaddr.Address]amt.Amount,
-	minConfirms int,
-) (*chainhash.Hash, error) {
-	return c.SendManyMinConfAsync(fromAccount, amounts, minConfirms).Receive()
+// See ListDustOutputs for the blocking version and more details.
+func (c *Client) ListDustOutputsAsync() FutureListDustOutputsResult {
+	cmd := btcjson.NewListDustOutputsCmd()
+	return c.sendCmd(cmd)
 }
 
-// SendManyCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// ListDustOutputs returns the outputs the wallet took for the outputs of a dusting attack, in the order they were
+// detected, and whether each is still frozen.
+func (c *Client) ListDustOutputs() ([]btcjson.DustOutputResult, error) {
+	return c.ListDustOutputsAsync().Receive()
+}
+
+// FutureGetRescanInfoResult is a future promise to deliver the result of a GetRescanInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetRescanInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the progress of the rescan of the wallet.
+func (r FutureGetRescanInfoResult) Receive() (*btcjson.GetRescanInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.GetRescanInfoResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetRescanInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
 // by invoking the Receive function on the returned instance.
 //
-// See SendManyComment for the blocking version and more details.
-func (c *Client) SendManyCommentAsync(
-	fromAccount string,
-	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
-	comment string,
-) FutureSendManyResult {
-	convertedAmounts := make(map[string]float64, len(amounts))
-	for addr, amount := range amounts {
-		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+// See GetRescanInfo for the blocking version and more details.
+func (c *Client) GetRescanInfoAsync() FutureGetRescanInfoResult {
+	cmd := btcjson.NewGetRescanInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetRescanInfo returns the progress of the rescan the wallet is running, or last ran, and the unmined transactions it
+// removed because the rescan found their inputs spent.
+func (c *Client) GetRescanInfo() (*btcjson.GetRescanInfoResult, error) {
+	return c.GetRescanInfoAsync().Receive()
+}
+
+// FutureGetScrubInfoResult is a future promise to deliver the result of a GetScrubInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetScrubInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the progress of the scrubbing of the wallet
+// database.
+func (r FutureGetScrubInfoResult) Receive() (*btcjson.GetScrubInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
 	}
-	cmd := btcjson.NewSendManyCmd(
-		fromAccount, convertedAmounts,
-		&minConfirms, &comment,
-	)
+	var result btcjson.GetScrubInfoResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetScrubInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetScrubInfo for the blocking version and more details.
+func (c *Client) GetScrubInfoAsync() FutureGetScrubInfoResult {
+	cmd := btcjson.NewGetScrubInfoCmd()
 	return c.sendCmd(cmd)
 }
 
-// SendManyComment sends multiple amounts to multiple addresses using the provided account as a source of funds in a
-// single transaction and stores the provided comment in the wallet. The comment parameter is intended to be used for
-// the purpose of the transaction Only funds with the passed number of minimum confirmations will be used.
+// GetScrubInfo returns the progress of the scrubbing of the wallet database and the problems it found.
+func (c *Client) GetScrubInfo() (*btcjson.GetScrubInfoResult, error) {
+	return c.GetScrubInfoAsync().Receive()
+}
+
+// FutureSpendAuthResult is a future promise to deliver the result of a GetSpendAuthAsync or SetSpendAuthAsync RPC
+// invocation (or an applicable error).
+type FutureSpendAuthResult chan *response
+
+// Receive waits for the response promised by the future and returns the code the wallet requires for spends above its
+// limit.
+func (r FutureSpendAuthResult) Receive() (*btcjson.SpendAuthResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SpendAuthResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetSpendAuthAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
 //
-// See SendMany and SendManyMinConf to use defaults.
+// See GetSpendAuth for the blocking version and more details.
+func (c *Client) GetSpendAuthAsync() FutureSpendAuthResult {
+	cmd := btcjson.NewGetSpendAuthCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetSpendAuth returns whether the wallet requires a PIN or time-based code to send more than its limit, and the
+// limit.
+func (c *Client) GetSpendAuth() (*btcjson.SpendAuthResult, error) {
+	return c.GetSpendAuthAsync().Receive()
+}
+
+// SetSpendAuthAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SetSpendAuth for the blocking version and more details.
+func (c *Client) SetSpendAuthAsync(method string, limit amt.Amount, secret, code string) FutureSpendAuthResult {
+	cmd := btcjson.NewSetSpendAuthCmd(method, btcjson.Float64(limit.ToDUO()), &secret, &code)
+	return c.sendCmd(cmd)
+}
+
+// SetSpendAuth sets the code the wallet requires to send more than limit, which is a PIN given as the secret, or a
+// time-based code whose secret is generated if none is given and returned with its otpauth URI. The method "none"
+// removes the requirement. Changing a requirement that is already set needs its current code.
 //
 // NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
-func (c *Client) SendManyComment(
-	fromAccount string,
-	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
-	comment string,
-) (*chainhash.Hash, error) {
-	return c.SendManyCommentAsync(
-		fromAccount, amounts, minConfirms,
-		comment,
-	).Receive()
+func (c *Client) SetSpendAuth(method string, limit amt.Amount, secret, code string) (*btcjson.SpendAuthResult, error) {
+	return c.SetSpendAuthAsync(method, limit, secret, code).Receive()
 }
 
-// *************************
-// Address/Account Functions
-// *************************
+// FutureBackupRemoteResult is a future promise to deliver the result of a BackupRemoteAsync RPC invocation (or an
+// applicable error).
+type FutureBackupRemoteResult chan *response
 
-// FutureAddMultisigAddressResult is a future promise to deliver the result of a AddMultisigAddressAsync RPC invocation
-// (or an applicable error).
-type FutureAddMultisigAddressResult chan *response
+// Receive waits for the response promised by the future and returns the outcome of the latest backup to each backup
+// target.
+func (r FutureBackupRemoteResult) Receive() ([]btcjson.BackupTargetResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var results []btcjson.BackupTargetResult
+	e = js.Unmarshal(res, &results)
+	if e != nil {
+		return nil, e
+	}
+	return results, nil
+}
 
-// Receive waits for the response promised by the future and returns the multisignature address that requires the
-// specified number of signatures for the provided addresses.
-func (r FutureAddMultisigAddressResult) Receive() (btcaddr.Address, error) {
+// BackupRemoteAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See BackupRemote for the blocking version and more details.
+func (c *Client) BackupRemoteAsync(force bool) FutureBackupRemoteResult {
+	cmd := btcjson.NewBackupRemoteCmd(&force)
+	return c.sendCmd(cmd)
+}
+
+// BackupRemote backs the wallet up to the remote backup targets it has changed since it was last backed up to, or to
+// all of them if force is set, and returns the outcome of the latest backup to each target.
+func (c *Client) BackupRemote(force bool) ([]btcjson.BackupTargetResult, error) {
+	return c.BackupRemoteAsync(force).Receive()
+}
+
+// FutureOverrideDustResult is a future promise to deliver the error result of an OverrideDustAsync RPC invocation.
+type FutureOverrideDustResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of releasing or freezing the
+// output(s).
+func (r FutureOverrideDustResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// OverrideDustAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See OverrideDust for the blocking version and more details.
+func (c *Client) OverrideDustAsync(release bool, ops []*wire.OutPoint) FutureOverrideDustResult {
+	outputs := make([]btcjson.TransactionInput, len(ops))
+	for i, op := range ops {
+		outputs[i] = btcjson.TransactionInput{
+			Txid: op.Hash.String(),
+			Vout: op.Index,
+		}
+	}
+	cmd := btcjson.NewOverrideDustCmd(release, outputs)
+	return c.sendCmd(cmd)
+}
+
+// OverrideDust releases outputs the wallet took for the outputs of a dusting attack so they can be spent, or freezes
+// them again, depending on the value of the release bool.
+func (c *Client) OverrideDust(release bool, ops []*wire.OutPoint) (e error) {
+	return c.OverrideDustAsync(release, ops).Receive()
+}
+
+// FutureListUnlockAttemptsResult is a future promise to deliver the result of a ListUnlockAttemptsAsync RPC
+// invocation (or an applicable error).
+type FutureListUnlockAttemptsResult chan *response
+
+// Receive waits for the response promised by the future and returns the audit log of the most recent attempts to
+// unlock the wallet.
+func (r FutureListUnlockAttemptsResult) Receive() ([]btcjson.UnlockAttemptResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmarshal result as a string.
-	var addr string
-	e = js.Unmarshal(res, &addr)
+	var attempts []btcjson.UnlockAttemptResult
+	e = js.Unmarshal(res, &attempts)
 	if e != nil {
 		return nil, e
 	}
-	return btcaddr.Decode(addr, &chaincfg.MainNetParams)
+	return attempts, nil
 }
 
-// AddMultisigAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// ListUnlockAttemptsAsync returns an instance of a type that can be used to get the result of the RPC at some future
 // time by invoking the Receive function on the returned instance.
 //
-// See AddMultisigAddress for the blocking version and more details.
-func (c *Client) AddMultisigAddressAsync(
-	requiredSigs int,
-	addresses []btcaddr.Address,
-	account string,
-) FutureAddMultisigAddressResult {
-	addrs := make([]string, 0, len(addresses))
-	for _, addr := range addresses {
-		addrs = append(addrs, addr.String())
-	}
-	cmd := btcjson.NewAddMultisigAddressCmd(requiredSigs, addrs, &account)
+// See ListUnlockAttempts for the blocking version and more details.
+func (c *Client) ListUnlockAttemptsAsync() FutureListUnlockAttemptsResult {
+	cmd := btcjson.NewListUnlockAttemptsCmd()
 	return c.sendCmd(cmd)
 }
 
-// AddMultisigAddress adds a multisignature address that requires the specified number of signatures for the provided
-// addresses to the wallet.
-func (c *Client) AddMultisigAddress(requiredSigs int, addresses []btcaddr.Address, account string) (btcaddr.Address,
-	error,
-) {
-	return c.AddMultisigAddressAsync(
-		requiredSigs, addresses,
-		account,
-	).Receive()
+// ListUnlockAttempts returns the audit log of the most recent attempts to unlock the wallet, oldest first.
+func (c *Client) ListUnlockAttempts() ([]btcjson.UnlockAttemptResult, error) {
+	return c.ListUnlockAttemptsAsync().Receive()
 }
 
-// FutureCreateMultisigResult is a future promise to deliver the result of a CreateMultisigAsync RPC invocation (or an
+// FutureGetAuditLogResult is a future promise to deliver the result of a GetAuditLogAsync RPC invocation (or an
 // applicable error).
-type FutureCreateMultisigResult chan *response
+type FutureGetAuditLogResult chan *response
 
-// Receive waits for the response promised by the future and returns the multisignature address and script needed to
-// redeem it.
-func (r FutureCreateMultisigResult) Receive() (*btcjson.CreateMultiSigResult, error) {
+// Receive waits for the response promised by the future and returns the requested entries of the audit log of the
+// wallet, and whether its hash chain is intact.
+func (r FutureGetAuditLogResult) Receive() (*btcjson.GetAuditLogResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmarshal result as a createmultisig result object.
-	var multisigRes btcjson.CreateMultiSigResult
-	e = js.Unmarshal(res, &multisigRes)
+	var result btcjson.GetAuditLogResult
+	e = js.Unmarshal(res, &result)
 	if e != nil {
 		return nil, e
 	}
-	return &multisigRes, nil
+	return &result, nil
+}
+
+// GetAuditLogAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetAuditLog for the blocking version and more details.
+func (c *Client) GetAuditLogAsync(from int64, count int, startTime, endTime int64) FutureGetAuditLogResult {
+	cmd := btcjson.NewGetAuditLogCmd(&from, &count, &startTime, &endTime)
+	return c.sendCmd(cmd)
+}
+
+// GetAuditLog returns at most count entries of the audit log of the wallet from the sequence number from onwards, made
+// between the Unix times startTime and endTime, where 0 leaves the range open on that side.
+func (c *Client) GetAuditLog(from int64, count int, startTime, endTime int64) (*btcjson.GetAuditLogResult, error) {
+	return c.GetAuditLogAsync(from, count, startTime, endTime).Receive()
+}
+
+// FutureGeneratePaperKeyResult is a future promise to deliver the result of a GeneratePaperKeyAsync RPC invocation (or
+// an applicable error).
+type FutureGeneratePaperKeyResult chan *response
+
+// Receive waits for the response promised by the future and returns the generated key pairs.
+func (r FutureGeneratePaperKeyResult) Receive() ([]btcjson.PaperKeyResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var keys []btcjson.PaperKeyResult
+	e = js.Unmarshal(res, &keys)
+	if e != nil {
+		return nil, e
+	}
+	return keys, nil
+}
+
+// GeneratePaperKeyAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GeneratePaperKey for the blocking version and more details.
+func (c *Client) GeneratePaperKeyAsync(count int) FutureGeneratePaperKeyResult {
+	cmd := btcjson.NewGeneratePaperKeyCmd(&count)
+	return c.sendCmd(cmd)
+}
+
+// GeneratePaperKey returns count new key pairs that are not stored in the wallet, for paper wallets and gifts.
+func (c *Client) GeneratePaperKey(count int) ([]btcjson.PaperKeyResult, error) {
+	return c.GeneratePaperKeyAsync(count).Receive()
+}
+
+// FuturePreviewSendResult is a future promise to deliver the result of a PreviewSendAsync RPC invocation (or an
+// applicable error).
+type FuturePreviewSendResult chan *response
+
+// Receive waits for the response promised by the future and returns the preview of the send.
+func (r FuturePreviewSendResult) Receive() (*btcjson.PreviewSendResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.PreviewSendResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// PreviewSendAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See PreviewSend for the blocking version and more details.
+func (c *Client) PreviewSendAsync(
+	fromAccount string, amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+) FuturePreviewSendResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewPreviewSendCmd(fromAccount, convertedAmounts, &minConfirms, nil)
+	return c.sendCmd(cmd)
+}
+
+// PreviewSend returns the inputs, size, fee, change and fee rate of the transaction SendManyMinConf would make with
+// the same arguments, without signing or sending it, so it does not require the wallet to be unlocked.
+func (c *Client) PreviewSend(
+	fromAccount string, amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+) (*btcjson.PreviewSendResult, error) {
+	return c.PreviewSendAsync(fromAccount, amounts, minConfirms).Receive()
+}
+
+// FutureSweepAccountResult is a future promise to deliver the result of a SweepAccountAsync RPC invocation (or an
+// applicable error).
+type FutureSweepAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the sweep of the account.
+func (r FutureSweepAccountResult) Receive() (*btcjson.SweepAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SweepAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// SweepAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SweepAccount for the blocking version and more details.
+func (c *Client) SweepAccountAsync(
+	account string, address btcaddr.Address, minConf int, reserve amt.Amount, dryRun bool,
+) FutureSweepAccountResult {
+	reserveDUO := reserve.ToDUO()
+	cmd := btcjson.NewSweepAccountCmd(account, address.EncodeAddress(), &minConf, &reserveDUO, &dryRun, nil)
+	return c.sendCmd(cmd)
+}
+
+// SweepAccount moves the spendable balance of the account, counting outputs with at least minConf confirmations, to
+// the address, less the fee and the reserve left in the account. With dryRun set the sweep is only worked out and
+// returned, without sending it.
+func (c *Client) SweepAccount(
+	account string, address btcaddr.Address, minConf int, reserve amt.Amount, dryRun bool,
+) (*btcjson.SweepAccountResult, error) {
+	return c.SweepAccountAsync(account, address, minConf, reserve, dryRun).Receive()
+}
+
+// FutureTransferAccountResult is a future promise to deliver the result of a TransferAccountAsync RPC invocation (or an
+// applicable error).
+type FutureTransferAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the transfer between the accounts.
+func (r FutureTransferAccountResult) Receive() (*btcjson.TransferAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.TransferAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// TransferAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See TransferAccount for the blocking version and more details.
+func (c *Client) TransferAccountAsync(
+	fromAccount, toAccount string, amount amt.Amount, feeAccount string, minConf int, dryRun bool,
+) FutureTransferAccountResult {
+	cmd := btcjson.NewTransferAccountCmd(fromAccount, toAccount, amount.ToDUO(), &feeAccount, &minConf, &dryRun, nil)
+	return c.sendCmd(cmd)
+}
+
+// TransferAccount moves the amount from one account of the wallet to the current address of another, spending outputs
+// with at least minConf confirmations. The fee is paid by feeAccount, or by the source account if it is empty. With
+// dryRun set the transfer is only worked out and returned, without sending it.
+func (c *Client) TransferAccount(
+	fromAccount, toAccount string, amount amt.Amount, feeAccount string, minConf int, dryRun bool,
+) (*btcjson.TransferAccountResult, error) {
+	return c.TransferAccountAsync(fromAccount, toAccount, amount, feeAccount, minConf, dryRun).Receive()
+}
+
+// FutureSweepPrivKeyResult is a future promise to deliver the result of a SweepPrivKeyAsync RPC invocation (or an
+// applicable error).
+type FutureSweepPrivKeyResult chan *response
+
+// Receive waits for the response promised by the future and returns the sweep of the key.
+func (r FutureSweepPrivKeyResult) Receive() (*btcjson.SweepPrivKeyResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SweepPrivKeyResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// SweepPrivKeyAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SweepPrivKey for the blocking version and more details.
+func (c *Client) SweepPrivKeyAsync(privKeyWIF *util.WIF, account string, dryRun bool) FutureSweepPrivKeyResult {
+	wif := ""
+	if privKeyWIF != nil {
+		wif = privKeyWIF.String()
+	}
+	cmd := btcjson.NewSweepPrivKeyCmd(wif, &account, &dryRun)
+	return c.sendCmd(cmd)
+}
+
+// SweepPrivKey moves all the funds of the passed private key, which is not in the wallet, to an address of the account.
+// With dryRun set the sweep is only worked out and returned, without sending it.
+func (c *Client) SweepPrivKey(privKeyWIF *util.WIF, account string, dryRun bool) (*btcjson.SweepPrivKeyResult, error) {
+	return c.SweepPrivKeyAsync(privKeyWIF, account, dryRun).Receive()
+}
+
+// FutureWalletDBStatsResult is a future promise to deliver the result of a WalletDBStatsAsync RPC invocation (or an
+// applicable error).
+type FutureWalletDBStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the space used by the buckets of the wallet
+// database.
+func (r FutureWalletDBStatsResult) Receive() ([]btcjson.WalletDBBucketResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var buckets []btcjson.WalletDBBucketResult
+	e = js.Unmarshal(res, &buckets)
+	if e != nil {
+		return nil, e
+	}
+	return buckets, nil
+}
+
+// WalletDBStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See WalletDBStats for the blocking version and more details.
+func (c *Client) WalletDBStatsAsync(largest int) FutureWalletDBStatsResult {
+	cmd := btcjson.NewWalletDBStatsCmd(&largest)
+	return c.sendCmd(cmd)
+}
+
+// WalletDBStats returns the key counts and space used by each bucket of the wallet database, with the largest key/value
+// pairs of each.
+func (c *Client) WalletDBStats(largest int) ([]btcjson.WalletDBBucketResult, error) {
+	return c.WalletDBStatsAsync(largest).Receive()
+}
+
+// FutureListImmatureResult is a future promise to deliver the result of a ListImmatureAsync or
+// ListImmatureAccountAsync RPC invocation (or an applicable error).
+type FutureListImmatureResult chan *response
+
+// Receive waits for the response promised by the future and returns the wallet's coinbase outputs that have not yet
+// matured.
+func (r FutureListImmatureResult) Receive() (*btcjson.ListImmatureResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.ListImmatureResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// ListImmatureAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ListImmature for the blocking version and more details.
+func (c *Client) ListImmatureAsync() FutureListImmatureResult {
+	cmd := btcjson.NewListImmatureCmd(nil)
+	return c.sendCmd(cmd)
+}
+
+// ListImmature returns the coinbase outputs of all wallet accounts that have not yet reached coinbase maturity along
+// with the number of blocks remaining until each can be spent.
+func (c *Client) ListImmature() (*btcjson.ListImmatureResult, error) {
+	return c.ListImmatureAsync().Receive()
+}
+
+// ListImmatureAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListImmatureAccount for the blocking version and more details.
+func (c *Client) ListImmatureAccountAsync(account string) FutureListImmatureResult {
+	cmd := btcjson.NewListImmatureCmd(&account)
+	return c.sendCmd(cmd)
+}
+
+// ListImmatureAccount returns the coinbase outputs of the passed account that have not yet reached coinbase maturity.
+func (c *Client) ListImmatureAccount(account string) (*btcjson.ListImmatureResult, error) {
+	return c.ListImmatureAccountAsync(account).Receive()
+}
+
+// FutureSetTxFeeResult is a future promise to deliver the result of a SetTxFeeAsync RPC invocation (or an applicable
+// error).
+type FutureSetTxFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of setting an optional transaction fee
+// per KB that helps ensure transactions are processed quickly. Most transaction are 1KB.
+func (r FutureSetTxFeeResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// SetTxFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SetTxFee for the blocking version and more details.
+func (c *Client) SetTxFeeAsync(fee amt.Amount) FutureSetTxFeeResult {
+	cmd := btcjson.NewSetTxFeeCmd(fee.ToDUO())
+	return c.sendCmd(cmd)
+}
+
+// SetTxFee sets an optional transaction fee per KB that helps ensure transactions are processed quickly. Most
+// transaction are 1KB.
+func (c *Client) SetTxFee(fee amt.Amount) (e error) {
+	return c.SetTxFeeAsync(fee).Receive()
+}
+
+// FutureSendToAddressResult is a future promise to deliver the result of a SendToAddressAsync RPC invocation (or an
+// applicable error).
+type FutureSendToAddressResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the transaction sending the passed
+// amount to the given address.
+func (r FutureSendToAddressResult) Receive() (*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a string.
+	var txHash string
+	e = js.Unmarshal(res, &txHash)
+	if e != nil {
+		return nil, e
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// SendToAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SendToAddress for the blocking version and more details.
+func (c *Client) SendToAddressAsync(address btcaddr.Address, amount amt.Amount) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil, nil, nil, nil)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddress sends the passed amount to the given address.
+//
+// See SendToAddressComment to associate comments with the transaction in the wallet. The comments are not part of the
+// transaction and are only internal to the wallet.
+//
+// NOTE: This function requires to the wallet to be unlocked.
+//
+// See the WalletPassphrase function for more details.
+func (c *Client) SendToAddress(address btcaddr.Address, amount amt.Amount) (*chainhash.Hash, error) {
+	return c.SendToAddressAsync(address, amount).Receive()
+}
+
+// SendToAddressCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressComment for the blocking version and more details.
+func (c *Client) SendToAddressCommentAsync(
+	address btcaddr.Address,
+	amount amt.Amount, comment,
+	commentTo string,
+) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	cmd := btcjson.NewSendToAddressCmd(
+		addr, amount.ToDUO(), &comment,
+		&commentTo, nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressComment sends the passed amount to the given address and stores the provided comment and comment to in
+// the wallet. The comment parameter is intended to be used for the purpose of the transaction while the commentTo
+// parameter is indended to be used for who the transaction is being sent to.
+//
+// The comments are not part of the transaction and are only internal to the wallet.
+//
+// See SendToAddress to avoid using comments.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressComment(
+	address btcaddr.Address,
+	amount amt.Amount,
+	comment, commentTo string,
+) (*chainhash.Hash, error) {
+	return c.SendToAddressCommentAsync(
+		address, amount, comment,
+		commentTo,
+	).Receive()
+}
+
+// SendToAddressAuthAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressAuth for the blocking version and more details.
+func (c *Client) SendToAddressAuthAsync(
+	address btcaddr.Address,
+	amount amt.Amount, authCode string,
+) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	// the comments must be given for the code that follows them to be sent, empty comments are not stored
+	var comment, commentTo string
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), &comment, &commentTo, &authCode, nil, nil)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressAuth sends the passed amount to the given address with the PIN or time-based code the wallet requires
+// for spends above its limit, which is ignored for smaller amounts or if the wallet requires no code.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressAuth(
+	address btcaddr.Address,
+	amount amt.Amount, authCode string,
+) (*chainhash.Hash, error) {
+	return c.SendToAddressAuthAsync(address, amount, authCode).Receive()
+}
+
+// SendToAddressFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressFee for the blocking version and more details.
+func (c *Client) SendToAddressFeeAsync(
+	address btcaddr.Address,
+	amount amt.Amount, opts btcjson.SendFeeOptions, authCode string,
+) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	// the comments and code must be given for the fee options that follow them to be sent
+	var comment, commentTo string
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), &comment, &commentTo, &authCode, &opts, nil)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressFee sends the passed amount to the given address paying the fee rate or the absolute fee set in the
+// options in place of the fee rate of the wallet. The PIN or time-based code is only needed for spends above the limit
+// of the wallet and may otherwise be empty.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressFee(
+	address btcaddr.Address,
+	amount amt.Amount, opts btcjson.SendFeeOptions, authCode string,
+) (*chainhash.Hash, error) {
+	return c.SendToAddressFeeAsync(address, amount, opts, authCode).Receive()
+}
+
+// SendToAddressIdempotentAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressIdempotent for the blocking version and more details.
+func (c *Client) SendToAddressIdempotentAsync(
+	address btcaddr.Address,
+	amount amt.Amount, opts btcjson.SendFeeOptions, authCode, idempotencyKey string,
+) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	// the parameters before the key must be given for it to be sent, empty fee options pay the wallet's fee rate
+	var comment, commentTo string
+	cmd := btcjson.NewSendToAddressCmd(
+		addr, amount.ToDUO(), &comment, &commentTo, &authCode, &opts, &idempotencyKey,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressIdempotent is SendToAddressFee with a key chosen by the caller. When a call times out it can be retried
+// with the same key and parameters, and the wallet returns the hash of the transaction it sent the first time, if it
+// did, instead of sending again.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressIdempotent(
+	address btcaddr.Address,
+	amount amt.Amount, opts btcjson.SendFeeOptions, authCode, idempotencyKey string,
+) (*chainhash.Hash, error) {
+	return c.SendToAddressIdempotentAsync(address, amount, opts, authCode, idempotencyKey).Receive()
+}
+
+// FutureSendFromResult is a future promise to deliver the result of a SendFromAsync, SendFromMinConfAsync, or
+// SendFromCommentAsync RPC invocation (or an applicable error).
+type FutureSendFromResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the transaction sending amount to the
+// given address using the provided account as a source of funds.
+func (r FutureSendFromResult) Receive() (*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a string.
+	var txHash string
+	e = js.Unmarshal(res, &txHash)
+	if e != nil {
+		return nil, e
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// SendFromAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SendFrom for the blocking version and more details.
+func (c *Client) SendFromAsync(fromAccount string, toAddress btcaddr.Address, amount amt.Amount) FutureSendFromResult {
+	addr := toAddress.EncodeAddress()
+	cmd := btcjson.NewSendFromCmd(
+		fromAccount, addr, amount.ToDUO(), nil,
+		nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendFrom sends the passed amount to the given address using the provided account as a source of funds. Only funds
+// with the default number of minimum confirmations will be used.
+//
+// See SendFromMinConf and SendFromComment for different options.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendFrom(fromAccount string, toAddress btcaddr.Address, amount amt.Amount) (*chainhash.Hash, error) {
+	return c.SendFromAsync(fromAccount, toAddress, amount).Receive()
+}
+
+// SendFromMinConfAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendFromMinConf for the blocking version and more details.
+func (c *Client) SendFromMinConfAsync(
+	fromAccount string,
+	toAddress btcaddr.Address,
+	amount amt.Amount,
+	minConfirms int,
+) FutureSendFromResult {
+	addr := toAddress.EncodeAddress()
+	cmd := btcjson.NewSendFromCmd(
+		fromAccount, addr, amount.ToDUO(),
+		&minConfirms, nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendFromMinConf sends the passed amount to the given address using the provided account as a source of funds. Only
+// funds with the passed number of minimum confirmations will be used.
+//
+// See SendFrom to use the default number of minimum confirmations and SendFromComment for additional options.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendFromMinConf(
+	fromAccount string,
+	toAddress btcaddr.Address,
+	amount amt.Amount,
+	minConfirms int,
+) (*chainhash.Hash, error) {
+	return c.SendFromMinConfAsync(
+		fromAccount, toAddress, amount,
+		minConfirms,
+	).Receive()
+}
+
+// SendFromCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendFromComment for the blocking version and more details.
+func (c *Client) SendFromCommentAsync(
+	fromAccount string,
+	toAddress btcaddr.Address, amount amt.Amount, minConfirms int,
+	comment, commentTo string,
+) FutureSendFromResult {
+	addr := toAddress.EncodeAddress()
+	cmd := btcjson.NewSendFromCmd(
+		fromAccount, addr, amount.ToDUO(),
+		&minConfirms, &comment, &commentTo, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendFromComment sends the passed amount to the given address using the provided account as a source of funds and
+// stores the provided comment and comment to in the wallet. The comment parameter is intended to be used for the
+// purpose of the transaction while the commentTo parameter is intended to be used for who the transaction is being sent
+// to. Only funds with the passed number of minimum confirmations will be used.
+//
+// See SendFrom and SendFromMinConf to use defaults.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendFromComment(
+	fromAccount string, toAddress btcaddr.Address,
+	amount amt.Amount, minConfirms int,
+	comment, commentTo string,
+) (*chainhash.Hash, error) {
+	return c.SendFromCommentAsync(
+		fromAccount, toAddress, amount,
+		minConfirms, comment, commentTo,
+	).Receive()
+}
+
+// FutureSendManyResult is a future promise to deliver the result of a SendManyAsync, SendManyMinConfAsync, or
+// SendManyCommentAsync RPC invocation (or an applicable error).
+type FutureSendManyResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the transaction sending multiple
+// amounts to multiple addresses using the provided account as a source of funds.
+func (r FutureSendManyResult) Receive() (*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmashal result as a string.
+	var txHash string
+	e = js.Unmarshal(res, &txHash)
+	if e != nil {
+		return nil, e
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// SendManyAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SendMany for the blocking version and more details.
+func (c *Client) SendManyAsync(fromAccount string, amounts map[btcaddr.Address]amt.Amount) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil, nil, nil, nil, nil)
+	return c.sendCmd(cmd)
+}
+
+// SendMany sends multiple amounts to multiple addresses using the provided account as a source of funds in a single
+// transaction. Only funds with the default number of minimum confirmations will be used.
+//
+// See SendManyMinConf and SendManyComment for different options.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendMany(fromAccount string, amounts map[btcaddr.Address]amt.Amount) (*chainhash.Hash, error) {
+	return c.SendManyAsync(fromAccount, amounts).Receive()
+}
+
+// SendManyMinConfAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendManyMinConf for the blocking version and more details.
+func (c *Client) SendManyMinConfAsync(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount,
+	minConfirms int,
+) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(
+		fromAccount, convertedAmounts,
+		&minConfirms, nil, nil, nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendManyMinConf sends multiple amounts to multiple addresses using the provided account as a source of funds in a
+// single transaction. Only funds with the passed number of minimum confirmations will be used.
+//
+// See SendMany to use the default number of minimum confirmations and SendManyComment for additional options.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyMinConf(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount,
+	minConfirms int,
+) (*chainhash.Hash, error) {
+	return c.SendManyMinConfAsync(fromAccount, amounts, minConfirms).Receive()
+}
+
+// SendManyCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendManyComment for the blocking version and more details.
+func (c *Client) SendManyCommentAsync(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	comment string,
+) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(
+		fromAccount, convertedAmounts,
+		&minConfirms, &comment, nil, nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendManyComment sends multiple amounts to multiple addresses using the provided account as a source of funds in a
+// single transaction and stores the provided comment in the wallet. The comment parameter is intended to be used for
+// the purpose of the transaction Only funds with the passed number of minimum confirmations will be used.
+//
+// See SendMany and SendManyMinConf to use defaults.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyComment(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	comment string,
+) (*chainhash.Hash, error) {
+	return c.SendManyCommentAsync(
+		fromAccount, amounts, minConfirms,
+		comment,
+	).Receive()
+}
+
+// SendManyScriptsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendManyScripts for the blocking version and more details.
+func (c *Client) SendManyScriptsAsync(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount,
+	scripts map[string]amt.Amount, minConfirms int,
+) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	convertedScripts := make(map[string]float64, len(scripts))
+	for script, amount := range scripts {
+		convertedScripts[script] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(
+		fromAccount, convertedAmounts,
+		&minConfirms, nil, &convertedScripts, nil, nil, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendManyScripts sends multiple amounts to multiple addresses and to raw output scripts, keyed by their hex encoding,
+// using the provided account as a source of funds in a single transaction. This allows paying to scripts that have no
+// address form, such as bare multisig. Each script must be one of the standard forms relayed by the network. Only
+// funds with the passed number of minimum confirmations will be used.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyScripts(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount,
+	scripts map[string]amt.Amount, minConfirms int,
+) (*chainhash.Hash, error) {
+	return c.SendManyScriptsAsync(fromAccount, amounts, scripts, minConfirms).Receive()
+}
+
+// SendManyFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SendManyFee for the blocking version and more details.
+func (c *Client) SendManyFeeAsync(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	opts btcjson.SendFeeOptions, authCode string,
+) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	// the parameters before the fee options must be given for them to be sent
+	var comment string
+	scripts := map[string]float64{}
+	cmd := btcjson.NewSendManyCmd(
+		fromAccount, convertedAmounts,
+		&minConfirms, &comment, &scripts, &authCode, &opts, nil,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendManyFee sends multiple amounts to multiple addresses using the provided account as a source of funds in a single
+// transaction, paying the fee rate or the absolute fee set in the options in place of the fee rate of the wallet. The
+// PIN or time-based code is only needed for spends above the limit of the wallet and may otherwise be empty.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyFee(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	opts btcjson.SendFeeOptions, authCode string,
+) (*chainhash.Hash, error) {
+	return c.SendManyFeeAsync(fromAccount, amounts, minConfirms, opts, authCode).Receive()
+}
+
+// SendManyIdempotentAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendManyIdempotent for the blocking version and more details.
+func (c *Client) SendManyIdempotentAsync(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	opts btcjson.SendFeeOptions, authCode, idempotencyKey string,
+) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	// the parameters before the key must be given for it to be sent, empty fee options pay the wallet's fee rate
+	var comment string
+	scripts := map[string]float64{}
+	cmd := btcjson.NewSendManyCmd(
+		fromAccount, convertedAmounts,
+		&minConfirms, &comment, &scripts, &authCode, &opts, &idempotencyKey,
+	)
+	return c.sendCmd(cmd)
+}
+
+// SendManyIdempotent is SendManyFee with a key chosen by the caller. When a call times out it can be retried with the
+// same key and parameters, and the wallet returns the hash of the transaction it sent the first time, if it did,
+// instead of sending again.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyIdempotent(
+	fromAccount string,
+	amounts map[btcaddr.Address]amt.Amount, minConfirms int,
+	opts btcjson.SendFeeOptions, authCode, idempotencyKey string,
+) (*chainhash.Hash, error) {
+	return c.SendManyIdempotentAsync(fromAccount, amounts, minConfirms, opts, authCode, idempotencyKey).Receive()
+}
+
+// *************************
+// Address/Account Functions
+// *************************
+
+// FutureAddMultisigAddressResult is a future promise to deliver the result of a AddMultisigAddressAsync RPC invocation
+// (or an applicable error).
+type FutureAddMultisigAddressResult struct {
+	client          *Client
+	responseChannel chan *response
+}
+
+// Receive waits for the response promised by the future and returns the multisignature address that requires the
+// specified number of signatures for the provided addresses.
+func (r FutureAddMultisigAddressResult) Receive() (btcaddr.Address, error) {
+	res, e := receiveFuture(r.responseChannel)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a string.
+	var addr string
+	e = js.Unmarshal(res, &addr)
+	if e != nil {
+		return nil, e
+	}
+	return r.client.decodeAddress(addr)
+}
+
+// AddMultisigAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See AddMultisigAddress for the blocking version and more details.
+func (c *Client) AddMultisigAddressAsync(
+	requiredSigs int,
+	addresses []btcaddr.Address,
+	account string,
+) FutureAddMultisigAddressResult {
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, addr.String())
+	}
+	cmd := btcjson.NewAddMultisigAddressCmd(requiredSigs, addrs, &account)
+	return FutureAddMultisigAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
+}
+
+// AddMultisigAddress adds a multisignature address that requires the specified number of signatures for the provided
+// addresses to the wallet.
+func (c *Client) AddMultisigAddress(requiredSigs int, addresses []btcaddr.Address, account string) (btcaddr.Address,
+	error,
+) {
+	return c.AddMultisigAddressAsync(
+		requiredSigs, addresses,
+		account,
+	).Receive()
+}
+
+// FutureCreateMultisigResult is a future promise to deliver the result of a CreateMultisigAsync RPC invocation (or an
+// applicable error).
+type FutureCreateMultisigResult chan *response
+
+// Receive waits for the response promised by the future and returns the multisignature address and script needed to
+// redeem it.
+func (r FutureCreateMultisigResult) Receive() (*btcjson.CreateMultiSigResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a createmultisig result object.
+	var multisigRes btcjson.CreateMultiSigResult
+	e = js.Unmarshal(res, &multisigRes)
+	if e != nil {
+		return nil, e
+	}
+	return &multisigRes, nil
+}
+
+// CreateMultisigAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See CreateMultisig for the blocking version and more details.
+func (c *Client) CreateMultisigAsync(requiredSigs int, addresses []btcaddr.Address) FutureCreateMultisigResult {
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, addr.String())
+	}
+	cmd := btcjson.NewCreateMultisigCmd(requiredSigs, addrs)
+	return c.sendCmd(cmd)
+}
+
+// CreateMultisig creates a multisignature address that requires the specified number of signatures for the provided
+// addresses and returns the multisignature address and script needed to redeem it.
+func (c *Client) CreateMultisig(requiredSigs int, addresses []btcaddr.Address) (*btcjson.CreateMultiSigResult, error) {
+	return c.CreateMultisigAsync(requiredSigs, addresses).Receive()
+}
+
+// FutureGetAccountXPubResult is a future promise to deliver the result of a GetAccountXPubAsync RPC invocation (or an
+// applicable error).
+type FutureGetAccountXPubResult chan *response
+
+// Receive waits for the response promised by the future and returns the extended public key of the account.
+func (r FutureGetAccountXPubResult) Receive() (string, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return "", e
+	}
+	var xpub string
+	e = js.Unmarshal(res, &xpub)
+	if e != nil {
+		return "", e
+	}
+	return xpub, nil
+}
+
+// GetAccountXPubAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetAccountXPub for the blocking version and more details.
+func (c *Client) GetAccountXPubAsync(account string) FutureGetAccountXPubResult {
+	cmd := btcjson.NewGetAccountXPubCmd(account)
+	return c.sendCmd(cmd)
+}
+
+// GetAccountXPub returns the extended public key of the account, which is given to the other cosigners of a multisig
+// account.
+func (c *Client) GetAccountXPub(account string) (string, error) {
+	return c.GetAccountXPubAsync(account).Receive()
+}
+
+// FutureMultiSigAccountResult is a future promise to deliver the result of a CreateMultiSigAccountAsync RPC invocation
+// (or an applicable error).
+type FutureMultiSigAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the multisig account.
+func (r FutureMultiSigAccountResult) Receive() (*btcjson.MultiSigAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.MultiSigAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// CreateMultiSigAccountAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See CreateMultiSigAccount for the blocking version and more details.
+func (c *Client) CreateMultiSigAccountAsync(name string, requiredSigs int, xpubs []string) FutureMultiSigAccountResult {
+	cmd := btcjson.NewCreateMultiSigAccountCmd(name, requiredSigs, xpubs)
+	return c.sendCmd(cmd)
+}
+
+// CreateMultiSigAccount adds a multisig account to the wallet whose deposit addresses need requiredSigs signatures of
+// the cosigners given by the extended public keys of their accounts.
+func (c *Client) CreateMultiSigAccount(name string, requiredSigs int, xpubs []string) (
+	*btcjson.MultiSigAccountResult, error,
+) {
+	return c.CreateMultiSigAccountAsync(name, requiredSigs, xpubs).Receive()
+}
+
+// FutureGetNewMultiSigAddressResult is a future promise to deliver the result of a GetNewMultiSigAddressAsync RPC
+// invocation (or an applicable error).
+type FutureGetNewMultiSigAddressResult chan *response
+
+// Receive waits for the response promised by the future and returns the deposit address with its redeem script.
+func (r FutureGetNewMultiSigAddressResult) Receive() (*btcjson.MultiSigAddressResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.MultiSigAddressResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetNewMultiSigAddressAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See GetNewMultiSigAddress for the blocking version and more details.
+func (c *Client) GetNewMultiSigAddressAsync(name string) FutureGetNewMultiSigAddressResult {
+	cmd := btcjson.NewGetNewMultiSigAddressCmd(name)
+	return c.sendCmd(cmd)
+}
+
+// GetNewMultiSigAddress returns the next deposit address of the multisig account along with its redeem script.
+func (c *Client) GetNewMultiSigAddress(name string) (*btcjson.MultiSigAddressResult, error) {
+	return c.GetNewMultiSigAddressAsync(name).Receive()
+}
+
+// FutureListMultiSigAccountsResult is a future promise to deliver the result of a ListMultiSigAccountsAsync RPC
+// invocation (or an applicable error).
+type FutureListMultiSigAccountsResult chan *response
+
+// Receive waits for the response promised by the future and returns the multisig accounts of the wallet.
+func (r FutureListMultiSigAccountsResult) Receive() ([]btcjson.MultiSigAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.MultiSigAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListMultiSigAccountsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See ListMultiSigAccounts for the blocking version and more details.
+func (c *Client) ListMultiSigAccountsAsync() FutureListMultiSigAccountsResult {
+	cmd := btcjson.NewListMultiSigAccountsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListMultiSigAccounts returns the multisig accounts of the wallet.
+func (c *Client) ListMultiSigAccounts() ([]btcjson.MultiSigAccountResult, error) {
+	return c.ListMultiSigAccountsAsync().Receive()
+}
+
+// FutureCreateVaultAccountResult is a future promise to deliver the result of a CreateVaultAccountAsync RPC invocation
+// (or an applicable error).
+type FutureCreateVaultAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the vault account that was added.
+func (r FutureCreateVaultAccountResult) Receive() (*btcjson.VaultAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.VaultAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// CreateVaultAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See CreateVaultAccount for the blocking version and more details.
+func (c *Client) CreateVaultAccountAsync(name string, lockHeight, delay int32) FutureCreateVaultAccountResult {
+	cmd := btcjson.NewCreateVaultAccountCmd(name, &lockHeight, &delay)
+	return c.sendCmd(cmd)
+}
+
+// CreateVaultAccount adds a vault account whose deposit addresses are locked until the chain reaches lockHeight, or
+// when it is zero, until delay blocks after they are generated.
+func (c *Client) CreateVaultAccount(name string, lockHeight, delay int32) (*btcjson.VaultAccountResult, error) {
+	return c.CreateVaultAccountAsync(name, lockHeight, delay).Receive()
+}
+
+// FutureGetNewVaultAddressResult is a future promise to deliver the result of a GetNewVaultAddressAsync RPC invocation
+// (or an applicable error).
+type FutureGetNewVaultAddressResult chan *response
+
+// Receive waits for the response promised by the future and returns the new deposit address of the vault account.
+func (r FutureGetNewVaultAddressResult) Receive() (*btcjson.VaultAddressResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.VaultAddressResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetNewVaultAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetNewVaultAddress for the blocking version and more details.
+func (c *Client) GetNewVaultAddressAsync(name string) FutureGetNewVaultAddressResult {
+	cmd := btcjson.NewGetNewVaultAddressCmd(name)
+	return c.sendCmd(cmd)
+}
+
+// GetNewVaultAddress returns the next deposit address of the vault account with its redeem script and lock height.
+func (c *Client) GetNewVaultAddress(name string) (*btcjson.VaultAddressResult, error) {
+	return c.GetNewVaultAddressAsync(name).Receive()
+}
+
+// FutureGetVaultScheduleResult is a future promise to deliver the result of a GetVaultScheduleAsync RPC invocation (or
+// an applicable error).
+type FutureGetVaultScheduleResult chan *response
+
+// Receive waits for the response promised by the future and returns the deposit addresses of the vault account in the
+// order they unlock.
+func (r FutureGetVaultScheduleResult) Receive() (*btcjson.VaultScheduleResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.VaultScheduleResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetVaultScheduleAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetVaultSchedule for the blocking version and more details.
+func (c *Client) GetVaultScheduleAsync(name string) FutureGetVaultScheduleResult {
+	cmd := btcjson.NewGetVaultScheduleCmd(name)
+	return c.sendCmd(cmd)
+}
+
+// GetVaultSchedule returns the deposit addresses of the vault account in the order they unlock, with the amounts that
+// are still locked and those that can be withdrawn.
+func (c *Client) GetVaultSchedule(name string) (*btcjson.VaultScheduleResult, error) {
+	return c.GetVaultScheduleAsync(name).Receive()
+}
+
+// FutureListVaultAccountsResult is a future promise to deliver the result of a ListVaultAccountsAsync RPC invocation
+// (or an applicable error).
+type FutureListVaultAccountsResult chan *response
+
+// Receive waits for the response promised by the future and returns the vault accounts of the wallet.
+func (r FutureListVaultAccountsResult) Receive() ([]btcjson.VaultAccountResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.VaultAccountResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListVaultAccountsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListVaultAccounts for the blocking version and more details.
+func (c *Client) ListVaultAccountsAsync() FutureListVaultAccountsResult {
+	cmd := btcjson.NewListVaultAccountsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListVaultAccounts returns the vault accounts of the wallet.
+func (c *Client) ListVaultAccounts() ([]btcjson.VaultAccountResult, error) {
+	return c.ListVaultAccountsAsync().Receive()
+}
+
+// FutureWalletSelfTestResult is a future promise to deliver the result of a WalletSelfTestAsync RPC invocation (or an
+// applicable error).
+type FutureWalletSelfTestResult chan *response
+
+// Receive waits for the response promised by the future and returns the outcome of the consistency checks of the
+// wallet.
+func (r FutureWalletSelfTestResult) Receive() (*btcjson.WalletSelfTestResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var report btcjson.WalletSelfTestResult
+	e = js.Unmarshal(res, &report)
+	if e != nil {
+		return nil, e
+	}
+	return &report, nil
+}
+
+// WalletSelfTestAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See WalletSelfTest for the blocking version and more details.
+func (c *Client) WalletSelfTestAsync() FutureWalletSelfTestResult {
+	cmd := btcjson.NewWalletSelfTestCmd()
+	return c.sendCmd(cmd)
+}
+
+// WalletSelfTest runs the consistency checks of the wallet and returns what they found.
+func (c *Client) WalletSelfTest() (*btcjson.WalletSelfTestResult, error) {
+	return c.WalletSelfTestAsync().Receive()
+}
+
+// FutureWithdrawVaultResult is a future promise to deliver the result of a WithdrawVaultAsync RPC invocation (or an
+// applicable error).
+type FutureWithdrawVaultResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the withdrawal transaction.
+func (r FutureWithdrawVaultResult) Receive() (*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a string.
+	var txHash string
+	e = js.Unmarshal(res, &txHash)
+	if e != nil {
+		return nil, e
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// WithdrawVaultAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See WithdrawVault for the blocking version and more details.
+func (c *Client) WithdrawVaultAsync(name string, address btcaddr.Address) FutureWithdrawVaultResult {
+	cmd := btcjson.NewWithdrawVaultCmd(name, address.EncodeAddress(), nil)
+	return c.sendCmd(cmd)
+}
+
+// WithdrawVault sends the unlocked outputs of the vault account to the address, less the fee.
+//
+// NOTE: This function requires the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) WithdrawVault(name string, address btcaddr.Address) (*chainhash.Hash, error) {
+	return c.WithdrawVaultAsync(name, address).Receive()
+}
+
+// FutureSweepTimeLockedResult is a future promise to deliver the result of a SweepTimeLockedAsync RPC invocation (or
+// an applicable error).
+type FutureSweepTimeLockedResult chan *response
+
+// Receive waits for the response promised by the future and returns the sweep, with the hash of its transaction
+// unless it was a dry run.
+func (r FutureSweepTimeLockedResult) Receive() (*btcjson.SweepTimeLockedResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SweepTimeLockedResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// SweepTimeLockedAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SweepTimeLocked for the blocking version and more details.
+func (c *Client) SweepTimeLockedAsync(address btcaddr.Address, dryRun bool) FutureSweepTimeLockedResult {
+	cmd := btcjson.NewSweepTimeLockedCmd(address.EncodeAddress(), &dryRun, nil)
+	return c.sendCmd(cmd)
+}
+
+// SweepTimeLocked sends the unlocked outputs of the time locked scripts of the wallet to the address, less the fee.
+// With dryRun set the sweep is only worked out and returned, without sending it.
+//
+// NOTE: This function requires the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SweepTimeLocked(address btcaddr.Address, dryRun bool) (*btcjson.SweepTimeLockedResult, error) {
+	return c.SweepTimeLockedAsync(address, dryRun).Receive()
+}
+
+// FutureListQueuedPSBTsResult is a future promise to deliver the result of a ListQueuedPSBTsAsync RPC invocation (or an
+// applicable error).
+type FutureListQueuedPSBTsResult chan *response
+
+// Receive waits for the response promised by the future and returns the transactions in the signing queue.
+func (r FutureListQueuedPSBTsResult) Receive() ([]btcjson.QueuedPSBTResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var queued []btcjson.QueuedPSBTResult
+	e = js.Unmarshal(res, &queued)
+	if e != nil {
+		return nil, e
+	}
+	return queued, nil
+}
+
+// ListQueuedPSBTsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ListQueuedPSBTs for the blocking version and more details.
+func (c *Client) ListQueuedPSBTsAsync() FutureListQueuedPSBTsResult {
+	cmd := btcjson.NewListQueuedPSBTsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListQueuedPSBTs returns the transactions a watching-only wallet queued for an external signer, with the PSBTs to
+// sign.
+func (c *Client) ListQueuedPSBTs() ([]btcjson.QueuedPSBTResult, error) {
+	return c.ListQueuedPSBTsAsync().Receive()
+}
+
+// FutureSubmitSignedPSBTResult is a future promise to deliver the result of a SubmitSignedPSBTAsync RPC invocation (or
+// an applicable error).
+type FutureSubmitSignedPSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the broadcast transaction.
+func (r FutureSubmitSignedPSBTResult) Receive() (*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a string.
+	var txHash string
+	e = js.Unmarshal(res, &txHash)
+	if e != nil {
+		return nil, e
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// SubmitSignedPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SubmitSignedPSBT for the blocking version and more details.
+func (c *Client) SubmitSignedPSBTAsync(psbt string) FutureSubmitSignedPSBTResult {
+	cmd := btcjson.NewSubmitSignedPSBTCmd(psbt)
+	return c.sendCmd(cmd)
 }
 
-// CreateMultisigAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// SubmitSignedPSBT returns the base64 encoded PSBT signed by an external signer to the wallet, which broadcasts the
+// queued transaction it signs once it is fully signed.
+func (c *Client) SubmitSignedPSBT(psbt string) (*chainhash.Hash, error) {
+	return c.SubmitSignedPSBTAsync(psbt).Receive()
+}
+
+// FutureCancelQueuedPSBTResult is a future promise to deliver the result of a CancelQueuedPSBTAsync RPC invocation (or
+// an applicable error).
+type FutureCancelQueuedPSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of removing the queued transaction.
+func (r FutureCancelQueuedPSBTResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// CancelQueuedPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time
 // by invoking the Receive function on the returned instance.
 //
-// See CreateMultisig for the blocking version and more details.
-func (c *Client) CreateMultisigAsync(requiredSigs int, addresses []btcaddr.Address) FutureCreateMultisigResult {
-	addrs := make([]string, 0, len(addresses))
-	for _, addr := range addresses {
-		addrs = append(addrs, addr.String())
-	}
-	cmd := btcjson.NewCreateMultisigCmd(requiredSigs, addrs)
+// See CancelQueuedPSBT for the blocking version and more details.
+func (c *Client) CancelQueuedPSBTAsync(id *chainhash.Hash) FutureCancelQueuedPSBTResult {
+	cmd := btcjson.NewCancelQueuedPSBTCmd(id.String())
 	return c.sendCmd(cmd)
 }
 
-// CreateMultisig creates a multisignature address that requires the specified number of signatures for the provided
-// addresses and returns the multisignature address and script needed to redeem it.
-func (c *Client) CreateMultisig(requiredSigs int, addresses []btcaddr.Address) (*btcjson.CreateMultiSigResult, error) {
-	return c.CreateMultisigAsync(requiredSigs, addresses).Receive()
+// CancelQueuedPSBT removes the transaction with the ID from the signing queue, unlocking its inputs.
+func (c *Client) CancelQueuedPSBT(id *chainhash.Hash) (e error) {
+	return c.CancelQueuedPSBTAsync(id).Receive()
 }
 
 // FutureCreateNewAccountResult is a future promise to deliver the result of a CreateNewAccountAsync RPC invocation (or
@@ -825,11 +2407,14 @@ func (c *Client) CreateNewAccount(account string) (e error) {
 
 // FutureGetNewAddressResult is a future promise to deliver the result of a GetNewAddressAsync RPC invocation (or an
 // applicable error).
-type FutureGetNewAddressResult chan *response
+type FutureGetNewAddressResult struct {
+	client          *Client
+	responseChannel chan *response
+}
 
 // Receive waits for the response promised by the future and returns a new address.
 func (r FutureGetNewAddressResult) Receive() (btcaddr.Address, error) {
-	res, e := receiveFuture(r)
+	res, e := receiveFuture(r.responseChannel)
 	if e != nil {
 		return nil, e
 	}
@@ -839,7 +2424,7 @@ func (r FutureGetNewAddressResult) Receive() (btcaddr.Address, error) {
 	if e != nil {
 		return nil, e
 	}
-	return btcaddr.Decode(addr, &chaincfg.MainNetParams)
+	return r.client.decodeAddress(addr)
 }
 
 // GetNewAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
@@ -848,9 +2433,9 @@ func (r FutureGetNewAddressResult) Receive() (btcaddr.Address, error) {
 // See GetNewAddress for the blocking version and more details.
 func (c *Client) GetNewAddressAsync(account string) FutureGetNewAddressResult {
 	T.Ln("### GetNewAddressAsync")
-	cmd := btcjson.NewGetNewAddressCmd(&account)
+	cmd := btcjson.NewGetNewAddressCmd(&account, nil)
 	// D.S(cmd)
-	return c.sendCmd(cmd)
+	return FutureGetNewAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
 }
 
 // GetNewAddress returns a new address.
@@ -859,14 +2444,31 @@ func (c *Client) GetNewAddress(account string) (btcaddr.Address, error) {
 	return c.GetNewAddressAsync(account).Receive()
 }
 
+// GetNewAddressTypeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetNewAddressType for the blocking version and more details.
+func (c *Client) GetNewAddressTypeAsync(account, addressType string) FutureGetNewAddressResult {
+	cmd := btcjson.NewGetNewAddressCmd(&account, &addressType)
+	return FutureGetNewAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
+}
+
+// GetNewAddressType returns a new address of the given type, which must be active on the network.
+func (c *Client) GetNewAddressType(account, addressType string) (btcaddr.Address, error) {
+	return c.GetNewAddressTypeAsync(account, addressType).Receive()
+}
+
 // FutureGetRawChangeAddressResult is a future promise to deliver the result of a GetRawChangeAddressAsync RPC
 // invocation (or an applicable error).
-type FutureGetRawChangeAddressResult chan *response
+type FutureGetRawChangeAddressResult struct {
+	client          *Client
+	responseChannel chan *response
+}
 
 // Receive waits for the response promised by the future and returns a new address for receiving change that will be
 // associated with the provided account. Note that this is only for raw transactions and NOT for normal use.
 func (r FutureGetRawChangeAddressResult) Receive() (btcaddr.Address, error) {
-	res, e := receiveFuture(r)
+	res, e := receiveFuture(r.responseChannel)
 	if e != nil {
 		return nil, e
 	}
@@ -876,7 +2478,7 @@ func (r FutureGetRawChangeAddressResult) Receive() (btcaddr.Address, error) {
 	if e != nil {
 		return nil, e
 	}
-	return btcaddr.Decode(addr, &chaincfg.MainNetParams)
+	return r.client.decodeAddress(addr)
 }
 
 // GetRawChangeAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
@@ -885,7 +2487,7 @@ func (r FutureGetRawChangeAddressResult) Receive() (btcaddr.Address, error) {
 // See GetRawChangeAddress for the blocking version and more details.
 func (c *Client) GetRawChangeAddressAsync(account string) FutureGetRawChangeAddressResult {
 	cmd := btcjson.NewGetRawChangeAddressCmd(&account)
-	return c.sendCmd(cmd)
+	return FutureGetRawChangeAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
 }
 
 // GetRawChangeAddress returns a new address for receiving change that will be associated with the provided account.
@@ -897,11 +2499,14 @@ func (c *Client) GetRawChangeAddress(account string) (btcaddr.Address, error) {
 
 // FutureAddWitnessAddressResult is a future promise to deliver the result of a
 // AddWitnessAddressAsync RPC invocation (or an applicable error).
-type FutureAddWitnessAddressResult chan *response
+type FutureAddWitnessAddressResult struct {
+	client          *Client
+	responseChannel chan *response
+}
 
 // Receive waits for the response promised by the future and returns the new address.
 func (r FutureAddWitnessAddressResult) Receive() (btcaddr.Address, error) {
-	res, e := receiveFuture(r)
+	res, e := receiveFuture(r.responseChannel)
 	if e != nil {
 		return nil, e
 	}
@@ -911,7 +2516,7 @@ func (r FutureAddWitnessAddressResult) Receive() (btcaddr.Address, error) {
 	if e != nil {
 		return nil, e
 	}
-	return btcaddr.Decode(addr, &chaincfg.MainNetParams)
+	return r.client.decodeAddress(addr)
 }
 
 // AddWitnessAddressAsync returns an instance of a type that can be used to get
@@ -921,7 +2526,7 @@ func (r FutureAddWitnessAddressResult) Receive() (btcaddr.Address, error) {
 // See AddWitnessAddress for the blocking version and more details.
 func (c *Client) AddWitnessAddressAsync(address string) FutureAddWitnessAddressResult {
 	cmd := btcjson.NewAddWitnessAddressCmd(address)
-	return c.sendCmd(cmd)
+	return FutureAddWitnessAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
 }
 
 // AddWitnessAddress adds a witness address for a script and returns the new
@@ -932,12 +2537,15 @@ func (c *Client) AddWitnessAddress(address string) (btcaddr.Address, error) {
 
 // FutureGetAccountAddressResult is a future promise to deliver the result of a GetAccountAddressAsync RPC invocation
 // (or an applicable error).
-type FutureGetAccountAddressResult chan *response
+type FutureGetAccountAddressResult struct {
+	client          *Client
+	responseChannel chan *response
+}
 
 // Receive waits for the response promised by the future and returns the current Bitcoin address for receiving payments
 // to the specified account.
 func (r FutureGetAccountAddressResult) Receive() (btcaddr.Address, error) {
-	res, e := receiveFuture(r)
+	res, e := receiveFuture(r.responseChannel)
 	if e != nil {
 		return nil, e
 	}
@@ -947,7 +2555,7 @@ func (r FutureGetAccountAddressResult) Receive() (btcaddr.Address, error) {
 	if e != nil {
 		return nil, e
 	}
-	return btcaddr.Decode(addr, &chaincfg.MainNetParams)
+	return r.client.decodeAddress(addr)
 }
 
 // GetAccountAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
@@ -956,7 +2564,7 @@ func (r FutureGetAccountAddressResult) Receive() (btcaddr.Address, error) {
 // See GetAccountAddress for the blocking version and more details.
 func (c *Client) GetAccountAddressAsync(account string) FutureGetAccountAddressResult {
 	cmd := btcjson.NewGetAccountAddressCmd(account)
-	return c.sendCmd(cmd)
+	return FutureGetAccountAddressResult{client: c, responseChannel: c.sendCmd(cmd)}
 }
 
 // GetAccountAddress returns the current Bitcoin address for receiving payments to the specified account.
@@ -1026,12 +2634,15 @@ func (c *Client) SetAccount(address btcaddr.Address, account string) (e error) {
 
 // FutureGetAddressesByAccountResult is a future promise to deliver the result of a GetAddressesByAccountAsync RPC
 // invocation (or an applicable error).
-type FutureGetAddressesByAccountResult chan *response
+type FutureGetAddressesByAccountResult struct {
+	client          *Client
+	responseChannel chan *response
+}
 
 // Receive waits for the response promised by the future and returns the list of addresses associated with the passed
 // account.
 func (r FutureGetAddressesByAccountResult) Receive() ([]btcaddr.Address, error) {
-	res, e := receiveFuture(r)
+	res, e := receiveFuture(r.responseChannel)
 	if e != nil {
 		return nil, e
 	}
@@ -1043,10 +2654,7 @@ func (r FutureGetAddressesByAccountResult) Receive() ([]btcaddr.Address, error)
 	}
 	addrs := make([]btcaddr.Address, 0, len(addrStrings))
 	for _, addrStr := range addrStrings {
-		addr, e := btcaddr.Decode(
-			addrStr,
-			&chaincfg.MainNetParams,
-		)
+		addr, e := r.client.decodeAddress(addrStr)
 		if e != nil {
 			return nil, e
 		}
@@ -1061,7 +2669,7 @@ func (r FutureGetAddressesByAccountResult) Receive() ([]btcaddr.Address, error)
 // See GetAddressesByAccount for the blocking version and more details.
 func (c *Client) GetAddressesByAccountAsync(account string) FutureGetAddressesByAccountResult {
 	cmd := btcjson.NewGetAddressesByAccountCmd(account)
-	return c.sendCmd(cmd)
+	return FutureGetAddressesByAccountResult{client: c, responseChannel: c.sendCmd(cmd)}
 }
 
 // GetAddressesByAccount returns the list of addresses associated with the passed account.
@@ -1123,139 +2731,476 @@ func (c *Client) MoveMinConfAsync(
 	return c.sendCmd(cmd)
 }
 
-// MoveMinConf moves specified amount from one account in your wallet to another. Only funds with the passed number of
-// minimum confirmations will be used.
-//
-// See Move to use the default number of minimum confirmations and MoveComment for additional options.
-func (c *Client) MoveMinConf(fromAccount, toAccount string, amount amt.Amount, minConf int) (bool, error) {
-	return c.MoveMinConfAsync(fromAccount, toAccount, amount, minConf).Receive()
+// MoveMinConf moves specified amount from one account in your wallet to another. Only funds with the passed number of
+// minimum confirmations will be used.
+//
+// See Move to use the default number of minimum confirmations and MoveComment for additional options.
+func (c *Client) MoveMinConf(fromAccount, toAccount string, amount amt.Amount, minConf int) (bool, error) {
+	return c.MoveMinConfAsync(fromAccount, toAccount, amount, minConf).Receive()
+}
+
+// MoveCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See MoveComment for the blocking version and more details.
+func (c *Client) MoveCommentAsync(
+	fromAccount, toAccount string,
+	amount amt.Amount, minConfirms int, comment string,
+) FutureMoveResult {
+	cmd := btcjson.NewMoveCmd(
+		fromAccount, toAccount, amount.ToDUO(),
+		&minConfirms, &comment,
+	)
+	return c.sendCmd(cmd)
+}
+
+// MoveComment moves specified amount from one account in your wallet to another and stores the provided comment in the
+// wallet. The comment parameter is only available in the wallet. Only funds with the passed number of minimum
+// confirmations will be used.
+//
+// See Move and MoveMinConf to use defaults.
+func (c *Client) MoveComment(
+	fromAccount, toAccount string, amount amt.Amount,
+	minConf int, comment string,
+) (bool, error) {
+	return c.MoveCommentAsync(
+		fromAccount, toAccount, amount, minConf,
+		comment,
+	).Receive()
+}
+
+// FutureRenameAccountResult is a future promise to deliver the result of a RenameAccountAsync RPC invocation (or an
+// applicable error).
+type FutureRenameAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of creating new account.
+func (r FutureRenameAccountResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// RenameAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See RenameAccount for the blocking version and more details.
+func (c *Client) RenameAccountAsync(oldAccount, newAccount string) FutureRenameAccountResult {
+	cmd := btcjson.NewRenameAccountCmd(oldAccount, newAccount)
+	return c.sendCmd(cmd)
+}
+
+// RenameAccount creates a new wallet account.
+func (c *Client) RenameAccount(oldAccount, newAccount string) (e error) {
+	return c.RenameAccountAsync(oldAccount, newAccount).Receive()
+}
+
+// FutureArchiveAccountResult is a future promise to deliver the result of an ArchiveAccountAsync or
+// UnarchiveAccountAsync RPC invocation (or an applicable error).
+type FutureArchiveAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns whether the account was archived or unarchived.
+func (r FutureArchiveAccountResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// ArchiveAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ArchiveAccount for the blocking version and more details.
+func (c *Client) ArchiveAccountAsync(account string) FutureArchiveAccountResult {
+	cmd := btcjson.NewArchiveAccountCmd(account)
+	return c.sendCmd(cmd)
+}
+
+// ArchiveAccount archives an account, leaving it out of the account listings and the total balance of the wallet
+// until it is unarchived.
+func (c *Client) ArchiveAccount(account string) (e error) {
+	return c.ArchiveAccountAsync(account).Receive()
+}
+
+// UnarchiveAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See UnarchiveAccount for the blocking version and more details.
+func (c *Client) UnarchiveAccountAsync(account string) FutureArchiveAccountResult {
+	cmd := btcjson.NewUnarchiveAccountCmd(account)
+	return c.sendCmd(cmd)
+}
+
+// UnarchiveAccount restores an archived account to the account listings and the total balance of the wallet.
+func (c *Client) UnarchiveAccount(account string) (e error) {
+	return c.UnarchiveAccountAsync(account).Receive()
+}
+
+// FutureValidateAddressResult is a future promise to deliver the result of a ValidateAddressAsync RPC invocation (or an
+// applicable error).
+type FutureValidateAddressResult chan *response
+
+// Receive waits for the response promised by the future and returns information about the given bitcoin address.
+func (r FutureValidateAddressResult) Receive() (*btcjson.ValidateAddressWalletResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a validateaddress result object.
+	var addrResult btcjson.ValidateAddressWalletResult
+	e = js.Unmarshal(res, &addrResult)
+	if e != nil {
+		return nil, e
+	}
+	return &addrResult, nil
+}
+
+// ValidateAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ValidateAddress for the blocking version and more details.
+func (c *Client) ValidateAddressAsync(address btcaddr.Address) FutureValidateAddressResult {
+	addr := address.EncodeAddress()
+	cmd := btcjson.NewValidateAddressCmd(addr)
+	return c.sendCmd(cmd)
+}
+
+// ValidateAddress returns information about the given bitcoin address.
+func (c *Client) ValidateAddress(address btcaddr.Address) (*btcjson.ValidateAddressWalletResult, error) {
+	return c.ValidateAddressAsync(address).Receive()
+}
+
+// FutureKeyPoolRefillResult is a future promise to deliver the result of a KeyPoolRefillAsync RPC invocation (or an
+// applicable error).
+type FutureKeyPoolRefillResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of refilling the key pool.
+func (r FutureKeyPoolRefillResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// KeyPoolRefillAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See KeyPoolRefill for the blocking version and more details.
+func (c *Client) KeyPoolRefillAsync() FutureKeyPoolRefillResult {
+	cmd := btcjson.NewKeyPoolRefillCmd(nil)
+	return c.sendCmd(cmd)
+}
+
+// KeyPoolRefill fills the key pool as necessary to reach the default size. See KeyPoolRefillSize to override the size
+// of the key pool.
+func (c *Client) KeyPoolRefill() (e error) {
+	return c.KeyPoolRefillAsync().Receive()
+}
+
+// KeyPoolRefillSizeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See KeyPoolRefillSize for the blocking version and more details.
+func (c *Client) KeyPoolRefillSizeAsync(newSize uint) FutureKeyPoolRefillResult {
+	cmd := btcjson.NewKeyPoolRefillCmd(&newSize)
+	return c.sendCmd(cmd)
+}
+
+// KeyPoolRefillSize fills the key pool as necessary to reach the specified size.
+func (c *Client) KeyPoolRefillSize(newSize uint) (e error) {
+	return c.KeyPoolRefillSizeAsync(newSize).Receive()
+}
+
+// FutureGetAddressInfoResult is a future promise to deliver the result of a GetAddressInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetAddressInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns where the address is kept in the wallet.
+func (r FutureGetAddressInfoResult) Receive() (*btcjson.GetAddressInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.GetAddressInfoResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetAddressInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetAddressInfo for the blocking version and more details.
+func (c *Client) GetAddressInfoAsync(address btcaddr.Address) FutureGetAddressInfoResult {
+	cmd := btcjson.NewGetAddressInfoCmd(address.EncodeAddress())
+	return c.sendCmd(cmd)
+}
+
+// GetAddressInfo returns whether the address is an address of the wallet and, if so, its account and where it was
+// derived.
+func (c *Client) GetAddressInfo(address btcaddr.Address) (*btcjson.GetAddressInfoResult, error) {
+	return c.GetAddressInfoAsync(address).Receive()
+}
+
+// FutureAddressMetaResult is a future promise to deliver the result of a GetAddressMetaAsync or SetAddressMetaAsync
+// RPC invocation (or an applicable error).
+type FutureAddressMetaResult chan *response
+
+// Receive waits for the response promised by the future and returns the metadata of the address.
+func (r FutureAddressMetaResult) Receive() (*btcjson.AddressMetaResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.AddressMetaResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetAddressMetaAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetAddressMeta for the blocking version and more details.
+func (c *Client) GetAddressMetaAsync(address btcaddr.Address) FutureAddressMetaResult {
+	cmd := btcjson.NewGetAddressMetaCmd(address.EncodeAddress())
+	return c.sendCmd(cmd)
+}
+
+// GetAddressMeta returns the metadata stored in the wallet for the address, such as the amount and message of a
+// payment request.
+func (c *Client) GetAddressMeta(address btcaddr.Address) (*btcjson.AddressMetaResult, error) {
+	return c.GetAddressMetaAsync(address).Receive()
+}
+
+// SetAddressMetaAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SetAddressMeta for the blocking version and more details.
+func (c *Client) SetAddressMetaAsync(address btcaddr.Address, meta btcjson.AddressMetaFields) FutureAddressMetaResult {
+	cmd := btcjson.NewSetAddressMetaCmd(address.EncodeAddress(), meta)
+	return c.sendCmd(cmd)
+}
+
+// SetAddressMeta changes the fields of the metadata of the address that are set in meta, creating the metadata if the
+// address has none, and returns the metadata as it is stored in the wallet.
+func (c *Client) SetAddressMeta(address btcaddr.Address, meta btcjson.AddressMetaFields) (
+	*btcjson.AddressMetaResult, error,
+) {
+	return c.SetAddressMetaAsync(address, meta).Receive()
+}
+
+// FutureListAddressMetaResult is a future promise to deliver the result of a ListAddressMetaAsync RPC invocation (or
+// an applicable error).
+type FutureListAddressMetaResult chan *response
+
+// Receive waits for the response promised by the future and returns the metadata of the addresses.
+func (r FutureListAddressMetaResult) Receive() ([]btcjson.AddressMetaResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.AddressMetaResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListAddressMetaAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ListAddressMeta for the blocking version and more details.
+func (c *Client) ListAddressMetaAsync(category string) FutureListAddressMetaResult {
+	var cat *string
+	if category != "" {
+		cat = &category
+	}
+	cmd := btcjson.NewListAddressMetaCmd(cat)
+	return c.sendCmd(cmd)
+}
+
+// ListAddressMeta returns the metadata stored in the wallet for the addresses in the category, "receive" or "send", or
+// for every address if category is empty, oldest first.
+func (c *Client) ListAddressMeta(category string) ([]btcjson.AddressMetaResult, error) {
+	return c.ListAddressMetaAsync(category).Receive()
 }
 
-// MoveCommentAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// FutureListInvoicesResult is a future promise to deliver the result of a ListInvoicesAsync RPC invocation (or an
+// applicable error).
+type FutureListInvoicesResult chan *response
+
+// Receive waits for the response promised by the future and returns the payment requests.
+func (r FutureListInvoicesResult) Receive() ([]btcjson.InvoiceResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.InvoiceResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListInvoicesAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
 // invoking the Receive function on the returned instance.
 //
-// See MoveComment for the blocking version and more details.
-func (c *Client) MoveCommentAsync(
-	fromAccount, toAccount string,
-	amount amt.Amount, minConfirms int, comment string,
-) FutureMoveResult {
-	cmd := btcjson.NewMoveCmd(
-		fromAccount, toAccount, amount.ToDUO(),
-		&minConfirms, &comment,
-	)
+// See ListInvoices for the blocking version and more details.
+func (c *Client) ListInvoicesAsync(state string, minConf int) FutureListInvoicesResult {
+	var st *string
+	if state != "" {
+		st = &state
+	}
+	cmd := btcjson.NewListInvoicesCmd(st, &minConf)
 	return c.sendCmd(cmd)
 }
 
-// MoveComment moves specified amount from one account in your wallet to another and stores the provided comment in the
-// wallet. The comment parameter is only available in the wallet. Only funds with the passed number of minimum
-// confirmations will be used.
-//
-// See Move and MoveMinConf to use defaults.
-func (c *Client) MoveComment(
-	fromAccount, toAccount string, amount amt.Amount,
-	minConf int, comment string,
-) (bool, error) {
-	return c.MoveCommentAsync(
-		fromAccount, toAccount, amount, minConf,
-		comment,
-	).Receive()
+// ListInvoices returns the payment requests made with addresses of the wallet in the state, or in any state if it is
+// empty, oldest first. Payments to the addresses count towards the amount requested once they have minConf
+// confirmations.
+func (c *Client) ListInvoices(state string, minConf int) ([]btcjson.InvoiceResult, error) {
+	return c.ListInvoicesAsync(state, minConf).Receive()
 }
 
-// FutureRenameAccountResult is a future promise to deliver the result of a RenameAccountAsync RPC invocation (or an
-// applicable error).
-type FutureRenameAccountResult chan *response
+// FutureSetInvoiceIssuanceResult is a future promise to deliver the error result of a SetInvoiceIssuanceAsync RPC
+// invocation.
+type FutureSetInvoiceIssuanceResult chan *response
 
-// Receive waits for the response promised by the future and returns the result of creating new account.
-func (r FutureRenameAccountResult) Receive() (e error) {
+// Receive waits for the response promised by the future and returns the result of switching the issuance of the
+// account.
+func (r FutureSetInvoiceIssuanceResult) Receive() (e error) {
 	_, e = receiveFuture(r)
 	return e
 }
 
-// RenameAccountAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
-// invoking the Receive function on the returned instance.
+// SetInvoiceIssuanceAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
 //
-// See RenameAccount for the blocking version and more details.
-func (c *Client) RenameAccountAsync(oldAccount, newAccount string) FutureRenameAccountResult {
-	cmd := btcjson.NewRenameAccountCmd(oldAccount, newAccount)
+// See SetInvoiceIssuance for the blocking version and more details.
+func (c *Client) SetInvoiceIssuanceAsync(account string, enable bool) FutureSetInvoiceIssuanceResult {
+	cmd := btcjson.NewSetInvoiceIssuanceCmd(account, enable)
 	return c.sendCmd(cmd)
 }
 
-// RenameAccount creates a new wallet account.
-func (c *Client) RenameAccount(oldAccount, newAccount string) (e error) {
-	return c.RenameAccountAsync(oldAccount, newAccount).Receive()
+// SetInvoiceIssuance switches the sequential issuance of the addresses of the account on or off. While it is on, the
+// addresses of the account are only handed out by ReserveInvoiceAddress, in order of their index with no gaps.
+func (c *Client) SetInvoiceIssuance(account string, enable bool) (e error) {
+	return c.SetInvoiceIssuanceAsync(account, enable).Receive()
 }
 
-// FutureValidateAddressResult is a future promise to deliver the result of a ValidateAddressAsync RPC invocation (or an
-// applicable error).
-type FutureValidateAddressResult chan *response
+// FutureInvoiceReservationResult is a future promise to deliver the result of a ReserveInvoiceAddressAsync RPC
+// invocation (or an applicable error).
+type FutureInvoiceReservationResult chan *response
 
-// Receive waits for the response promised by the future and returns information about the given bitcoin address.
-func (r FutureValidateAddressResult) Receive() (*btcjson.ValidateAddressWalletResult, error) {
+// Receive waits for the response promised by the future and returns the reserved address.
+func (r FutureInvoiceReservationResult) Receive() (*btcjson.InvoiceReservationResult, error) {
 	res, e := receiveFuture(r)
 	if e != nil {
 		return nil, e
 	}
-	// Unmarshal result as a validateaddress result object.
-	var addrResult btcjson.ValidateAddressWalletResult
-	e = js.Unmarshal(res, &addrResult)
+	var result btcjson.InvoiceReservationResult
+	e = js.Unmarshal(res, &result)
 	if e != nil {
 		return nil, e
 	}
-	return &addrResult, nil
+	return &result, nil
 }
 
-// ValidateAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future time
-// by invoking the Receive function on the returned instance.
+// ReserveInvoiceAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
 //
-// See ValidateAddress for the blocking version and more details.
-func (c *Client) ValidateAddressAsync(address btcaddr.Address) FutureValidateAddressResult {
-	addr := address.EncodeAddress()
-	cmd := btcjson.NewValidateAddressCmd(addr)
+// See ReserveInvoiceAddress for the blocking version and more details.
+func (c *Client) ReserveInvoiceAddressAsync(account, reference string) FutureInvoiceReservationResult {
+	cmd := btcjson.NewReserveInvoiceAddressCmd(account, &reference)
 	return c.sendCmd(cmd)
 }
 
-// ValidateAddress returns information about the given bitcoin address.
-func (c *Client) ValidateAddress(address btcaddr.Address) (*btcjson.ValidateAddressWalletResult, error) {
-	return c.ValidateAddressAsync(address).Receive()
+// ReserveInvoiceAddress reserves the next address of the account, which must issue its addresses sequentially, for
+// the reference of an order. Reserving again with the same reference returns the same address.
+func (c *Client) ReserveInvoiceAddress(account, reference string) (*btcjson.InvoiceReservationResult, error) {
+	return c.ReserveInvoiceAddressAsync(account, reference).Receive()
 }
 
-// FutureKeyPoolRefillResult is a future promise to deliver the result of a KeyPoolRefillAsync RPC invocation (or an
-// applicable error).
-type FutureKeyPoolRefillResult chan *response
+// FutureReleaseInvoiceAddressResult is a future promise to deliver the error result of a ReleaseInvoiceAddressAsync
+// RPC invocation.
+type FutureReleaseInvoiceAddressResult chan *response
 
-// Receive waits for the response promised by the future and returns the result of refilling the key pool.
-func (r FutureKeyPoolRefillResult) Receive() (e error) {
+// Receive waits for the response promised by the future and returns the result of releasing the address.
+func (r FutureReleaseInvoiceAddressResult) Receive() (e error) {
 	_, e = receiveFuture(r)
 	return e
 }
 
-// KeyPoolRefillAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
-// invoking the Receive function on the returned instance.
+// ReleaseInvoiceAddressAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
 //
-// See KeyPoolRefill for the blocking version and more details.
-func (c *Client) KeyPoolRefillAsync() FutureKeyPoolRefillResult {
-	cmd := btcjson.NewKeyPoolRefillCmd(nil)
+// See ReleaseInvoiceAddress for the blocking version and more details.
+func (c *Client) ReleaseInvoiceAddressAsync(address btcaddr.Address) FutureReleaseInvoiceAddressResult {
+	cmd := btcjson.NewReleaseInvoiceAddressCmd(address.EncodeAddress())
 	return c.sendCmd(cmd)
 }
 
-// KeyPoolRefill fills the key pool as necessary to reach the default size. See KeyPoolRefillSize to override the size
-// of the key pool.
-func (c *Client) KeyPoolRefill() (e error) {
-	return c.KeyPoolRefillAsync().Receive()
+// ReleaseInvoiceAddress releases an address reserved for an order that was not paid, so it is the next address
+// reserved in its account.
+func (c *Client) ReleaseInvoiceAddress(address btcaddr.Address) (e error) {
+	return c.ReleaseInvoiceAddressAsync(address).Receive()
 }
 
-// KeyPoolRefillSizeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// FutureListInvoiceReservationsResult is a future promise to deliver the result of a ListInvoiceReservationsAsync RPC
+// invocation (or an applicable error).
+type FutureListInvoiceReservationsResult chan *response
+
+// Receive waits for the response promised by the future and returns the reserved addresses.
+func (r FutureListInvoiceReservationsResult) Receive() ([]btcjson.InvoiceReservationResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result []btcjson.InvoiceReservationResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return result, nil
+}
+
+// ListInvoiceReservationsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See ListInvoiceReservations for the blocking version and more details.
+func (c *Client) ListInvoiceReservationsAsync(account string) FutureListInvoiceReservationsResult {
+	cmd := btcjson.NewListInvoiceReservationsCmd(&account)
+	return c.sendCmd(cmd)
+}
+
+// ListInvoiceReservations returns the addresses reserved in the account, in order of their index.
+func (c *Client) ListInvoiceReservations(account string) ([]btcjson.InvoiceReservationResult, error) {
+	return c.ListInvoiceReservationsAsync(account).Receive()
+}
+
+// FutureDeleteAddressMetaResult is a future promise to deliver the result of a DeleteAddressMetaAsync RPC invocation
+// (or an applicable error).
+type FutureDeleteAddressMetaResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of removing the metadata of the address.
+func (r FutureDeleteAddressMetaResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// DeleteAddressMetaAsync returns an instance of a type that can be used to get the result of the RPC at some future
 // time by invoking the Receive function on the returned instance.
 //
-// See KeyPoolRefillSize for the blocking version and more details.
-func (c *Client) KeyPoolRefillSizeAsync(newSize uint) FutureKeyPoolRefillResult {
-	cmd := btcjson.NewKeyPoolRefillCmd(&newSize)
+// See DeleteAddressMeta for the blocking version and more details.
+func (c *Client) DeleteAddressMetaAsync(address btcaddr.Address) FutureDeleteAddressMetaResult {
+	cmd := btcjson.NewDeleteAddressMetaCmd(address.EncodeAddress())
 	return c.sendCmd(cmd)
 }
 
-// KeyPoolRefillSize fills the key pool as necessary to reach the specified size.
-func (c *Client) KeyPoolRefillSize(newSize uint) (e error) {
-	return c.KeyPoolRefillSizeAsync(newSize).Receive()
+// DeleteAddressMeta removes the metadata stored in the wallet for the address.
+func (c *Client) DeleteAddressMeta(address btcaddr.Address) (e error) {
+	return c.DeleteAddressMetaAsync(address).Receive()
 }
 
 // ************************
@@ -1295,7 +3240,7 @@ func (r FutureListAccountsResult) Receive() (map[string]amt.Amount, error) {
 //
 // See ListAccounts for the blocking version and more details.
 func (c *Client) ListAccountsAsync() FutureListAccountsResult {
-	cmd := btcjson.NewListAccountsCmd(nil)
+	cmd := btcjson.NewListAccountsCmd(nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -1312,7 +3257,7 @@ func (c *Client) ListAccounts() (map[string]amt.Amount, error) {
 //
 // See ListAccountsMinConf for the blocking version and more details.
 func (c *Client) ListAccountsMinConfAsync(minConfirms int) FutureListAccountsResult {
-	cmd := btcjson.NewListAccountsCmd(&minConfirms)
+	cmd := btcjson.NewListAccountsCmd(&minConfirms, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -1324,6 +3269,22 @@ func (c *Client) ListAccountsMinConf(minConfirms int) (map[string]amt.Amount, er
 	return c.ListAccountsMinConfAsync(minConfirms).Receive()
 }
 
+// ListAllAccountsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ListAllAccounts for the blocking version and more details.
+func (c *Client) ListAllAccountsAsync(minConfirms int) FutureListAccountsResult {
+	includeArchived := true
+	cmd := btcjson.NewListAccountsCmd(&minConfirms, &includeArchived)
+	return c.sendCmd(cmd)
+}
+
+// ListAllAccounts returns a map of account names and their associated balances using the specified number of minimum
+// confirmations, including the archived accounts left out by ListAccounts.
+func (c *Client) ListAllAccounts(minConfirms int) (map[string]amt.Amount, error) {
+	return c.ListAllAccountsAsync(minConfirms).Receive()
+}
+
 // FutureGetBalanceResult is a future promise to deliver the result of a GetBalanceAsync or GetBalanceMinConfAsync RPC
 // invocation (or an applicable error).
 type FutureGetBalanceResult chan *response
@@ -1416,6 +3377,40 @@ func (c *Client) GetBalanceMinConf(account string, minConfirms int) (amt.Amount,
 	return c.GetBalanceMinConfAsync(account, minConfirms).Receive()
 }
 
+// FutureGetBalanceAtResult is a future promise to deliver the result of a GetBalanceAtAsync RPC invocation (or an
+// applicable error).
+type FutureGetBalanceAtResult chan *response
+
+// Receive waits for the response promised by the future and returns the balance of the wallet as of the requested
+// block.
+func (r FutureGetBalanceAtResult) Receive() (*btcjson.GetBalanceAtResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.GetBalanceAtResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// GetBalanceAtAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetBalanceAt for the blocking version and more details.
+func (c *Client) GetBalanceAtAsync(heightOrTime int64, perAccount bool) FutureGetBalanceAtResult {
+	cmd := btcjson.NewGetBalanceAtCmd(heightOrTime, &perAccount)
+	return c.sendCmd(cmd)
+}
+
+// GetBalanceAt returns the balance of the wallet, and of each account when perAccount is set, as of the block at the
+// height heightOrTime, or the last block not after it as a Unix time when it is at least txscript.LockTimeThreshold.
+func (c *Client) GetBalanceAt(heightOrTime int64, perAccount bool) (*btcjson.GetBalanceAtResult, error) {
+	return c.GetBalanceAtAsync(heightOrTime, perAccount).Receive()
+}
+
 // FutureGetReceivedByAccountResult is a future promise to deliver the result of a GetReceivedByAccountAsync or
 // GetReceivedByAccountMinConfAsync RPC invocation (or an applicable error).
 type FutureGetReceivedByAccountResult chan *response
@@ -1922,6 +3917,111 @@ func (c *Client) DumpPrivKey(address btcaddr.Address) (*util.WIF, error) {
 	return c.DumpPrivKeyAsync(address).Receive()
 }
 
+// FutureExportAccountXprvResult is a future promise to deliver the result of an ExportAccountXprvAsync RPC invocation
+// (or an applicable error).
+type FutureExportAccountXprvResult chan *response
+
+// Receive waits for the response promised by the future and returns the extended private key of the account, which is
+// encrypted with the xprv export password unless plaintext was requested.
+func (r FutureExportAccountXprvResult) Receive() (*btcjson.ExportAccountXprvResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.ExportAccountXprvResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// ExportAccountXprvAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ExportAccountXprv for the blocking version and more details.
+func (c *Client) ExportAccountXprvAsync(account, password string, plaintext bool) FutureExportAccountXprvResult {
+	cmd := btcjson.NewExportAccountXprvCmd(account, password, &plaintext)
+	return c.sendCmd(cmd)
+}
+
+// ExportAccountXprv gets the extended private key of an account, encrypted with the xprv export password unless
+// plaintext is true.
+//
+// NOTE: This function requires to the wallet to be unlocked, and the wallet must be configured to allow the export.
+func (c *Client) ExportAccountXprv(
+	account, password string, plaintext bool,
+) (*btcjson.ExportAccountXprvResult, error) {
+	return c.ExportAccountXprvAsync(account, password, plaintext).Receive()
+}
+
+// FutureExportLedgerResult is a future promise to deliver the result of an ExportLedgerAsync RPC invocation (or an
+// applicable error).
+type FutureExportLedgerResult chan *response
+
+// Receive waits for the response promised by the future and returns the journal of the history of the wallet.
+func (r FutureExportLedgerResult) Receive() (string, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return "", e
+	}
+	var journal string
+	e = js.Unmarshal(res, &journal)
+	if e != nil {
+		return "", e
+	}
+	return journal, nil
+}
+
+// ExportLedgerAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ExportLedger for the blocking version and more details.
+func (c *Client) ExportLedgerAsync(format, commodity string) FutureExportLedgerResult {
+	cmd := btcjson.NewExportLedgerCmd(&format, &commodity)
+	return c.sendCmd(cmd)
+}
+
+// ExportLedger gets the history of the wallet as a double-entry journal in the format, "ledger" or "beancount", with
+// the amounts in the commodity.
+func (c *Client) ExportLedger(format, commodity string) (string, error) {
+	return c.ExportLedgerAsync(format, commodity).Receive()
+}
+
+// FutureExportWatchSetResult is a future promise to deliver the result of an ExportWatchSetAsync RPC invocation (or an
+// applicable error).
+type FutureExportWatchSetResult chan *response
+
+// Receive waits for the response promised by the future and returns the output scripts and unspent outputs of the
+// wallet.
+func (r FutureExportWatchSetResult) Receive() (*btcjson.WatchSetResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var set btcjson.WatchSetResult
+	e = js.Unmarshal(res, &set)
+	if e != nil {
+		return nil, e
+	}
+	return &set, nil
+}
+
+// ExportWatchSetAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ExportWatchSet for the blocking version and more details.
+func (c *Client) ExportWatchSetAsync() FutureExportWatchSetResult {
+	cmd := btcjson.NewExportWatchSetCmd()
+	return c.sendCmd(cmd)
+}
+
+// ExportWatchSet gets the output scripts of the wallet and its unspent outputs as of the block it is synced to, for
+// running a watcher that alerts when its funds move.
+func (c *Client) ExportWatchSet() (*btcjson.WatchSetResult, error) {
+	return c.ExportWatchSetAsync().Receive()
+}
+
 // FutureImportAddressResult is a future promise to deliver the result of an ImportAddressAsync RPC invocation (or an
 // applicable error).
 type FutureImportAddressResult chan *response
@@ -1961,6 +4061,40 @@ func (c *Client) ImportAddressRescan(address string, account string, rescan bool
 	return c.ImportAddressRescanAsync(address, account, rescan).Receive()
 }
 
+// FutureImportCoreWalletResult is a future promise to deliver the result of an ImportCoreWalletAsync RPC invocation (or
+// an applicable error).
+type FutureImportCoreWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the outcome of importing the wallet.
+func (r FutureImportCoreWalletResult) Receive() (*btcjson.ImportCoreWalletResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.ImportCoreWalletResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// ImportCoreWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ImportCoreWallet for the blocking version and more details.
+func (c *Client) ImportCoreWalletAsync(path, passphrase string, rescan bool) FutureImportCoreWalletResult {
+	cmd := btcjson.NewImportCoreWalletCmd(path, &passphrase, &rescan)
+	return c.sendCmd(cmd)
+}
+
+// ImportCoreWallet imports the private keys and address labels of the Bitcoin Core wallet.dat at path, which is a path
+// on the machine the wallet runs on, to the imported account. The passphrase is only needed if the keys of the wallet
+// are encrypted. When rescan is true, the block history is scanned from the earliest birthday of the keys.
+func (c *Client) ImportCoreWallet(path, passphrase string, rescan bool) (*btcjson.ImportCoreWalletResult, error) {
+	return c.ImportCoreWalletAsync(path, passphrase, rescan).Receive()
+}
+
 // FutureImportPrivKeyResult is a future promise to deliver the result of an ImportPrivKeyAsync RPC invocation (or an
 // applicable error).
 type FutureImportPrivKeyResult chan *response
@@ -2029,6 +4163,67 @@ func (c *Client) ImportPrivKeyRescan(privKeyWIF *util.WIF, label string, rescan
 	return c.ImportPrivKeyRescanAsync(privKeyWIF, label, rescan).Receive()
 }
 
+// FutureImportScriptPubKeyResult is a future promise to deliver the result of an ImportScriptPubKeyAsync RPC
+// invocation (or an applicable error).
+type FutureImportScriptPubKeyResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of watching the passed output script.
+func (r FutureImportScriptPubKeyResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// ImportScriptPubKeyAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ImportScriptPubKey for the blocking version and more details.
+func (c *Client) ImportScriptPubKeyAsync(script []byte, label string, rescan bool) FutureImportScriptPubKeyResult {
+	cmd := btcjson.NewImportScriptPubKeyCmd(hex.EncodeToString(script), &label, &rescan)
+	return c.sendCmd(cmd)
+}
+
+// ImportScriptPubKey makes the wallet watch the passed output script, which need not pay to an address, for payments
+// and their spends. When rescan is true, the block history is searched for the script in the background.
+func (c *Client) ImportScriptPubKey(script []byte, label string, rescan bool) (e error) {
+	return c.ImportScriptPubKeyAsync(script, label, rescan).Receive()
+}
+
+// FutureImportTimeLockScriptResult is a future promise to deliver the result of an ImportTimeLockScriptAsync RPC
+// invocation (or an applicable error).
+type FutureImportTimeLockScriptResult chan *response
+
+// Receive waits for the response promised by the future and returns the address and lock of the imported script.
+func (r FutureImportTimeLockScriptResult) Receive() (*btcjson.TimeLockScriptResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.TimeLockScriptResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// ImportTimeLockScriptAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ImportTimeLockScript for the blocking version and more details.
+func (c *Client) ImportTimeLockScriptAsync(redeemScript []byte, rescan bool) FutureImportTimeLockScriptResult {
+	cmd := btcjson.NewImportTimeLockScriptCmd(hex.EncodeToString(redeemScript), &rescan)
+	return c.sendCmd(cmd)
+}
+
+// ImportTimeLockScript adds a redeem script paying to a key of the wallet once a CLTV or CSV time lock has passed, so
+// the outputs paid to its P2SH address are tracked and can be swept with SweepTimeLocked once they unlock. When rescan
+// is true, the block history is searched for the script in the background.
+//
+// NOTE: This function requires the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) ImportTimeLockScript(redeemScript []byte, rescan bool) (*btcjson.TimeLockScriptResult, error) {
+	return c.ImportTimeLockScriptAsync(redeemScript, rescan).Receive()
+}
+
 // FutureImportPubKeyResult is a future promise to deliver the result of an ImportPubKeyAsync RPC invocation (or an
 // applicable error).
 type FutureImportPubKeyResult chan *response
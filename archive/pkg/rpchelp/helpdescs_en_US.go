@@ -9,6 +9,30 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
+	// AddPortfolioEntryCmd help.
+	"addportfolioentry--synopsis": "Adds a cold wallet to the portfolio, which watches the output scripts of its public descriptors and adds up the funds they hold apart from the balance of the wallet.\n" +
+		"Descriptors or extended keys with private keys are refused. Requires a websocket connection to the chain server.",
+	"addportfolioentry-name":        "The name of the entry",
+	"addportfolioentry-descriptors": "The output script descriptors of the cold wallet, an extended public key standing for its pay to public key hash receiving and change chains",
+	"addportfolioentry-range":       "The number of scripts derived from each ranged descriptor",
+	"addportfolioentry-rescan":      "Search the blockchain (since the genesis block) in the background for outputs paying to the scripts, or watch them only from the current block",
+	// ArchiveAccountCmd help.
+	"archiveaccount--synopsis": "Archives an account, which leaves it out of listaccounts and the total balance of the wallet for wallets with many accounts no longer used.\n" +
+		"Nothing else about the account changes: its addresses are still watched and rescanned, its balance can be asked for by name and spent, and it can be unarchived with unarchiveaccount.\n" +
+		"The default and imported accounts cannot be archived.",
+	"archiveaccount-account": "The name of the account to archive",
+	// BackupRemoteCmd help.
+	"backupremote--synopsis": "Backs the wallet up to the remote backup targets it has changed since it was last backed up to, and returns the outcome of the latest backup to each target.\n" +
+		"Backups are encrypted with a key derived from the seed of the wallet, which is derived when the wallet is first unlocked after it is started.",
+	"backupremote-force": "Back the wallet up to every target even if it has not changed",
+	// BackupTargetResult help.
+	"backuptargetresult-target": "The URL of the backup target without its credentials",
+	"backuptargetresult-time":   "The time the wallet was last backed up to the target in seconds since 1 Jan 1970 GMT, or 0 if it has not been since the wallet was started",
+	"backuptargetresult-size":   "The size in bytes of the last backup",
+	"backuptargetresult-error":  "Why the latest backup to the target failed, if it did",
+	// CancelQueuedPSBTCmd help.
+	"cancelqueuedpsbt--synopsis": "Removes a transaction waiting for its signed PSBT from the signing queue of a watching-only wallet, unlocking its inputs.",
+	"cancelqueuedpsbt-id":        "The ID of the queued transaction, the hash of the unsigned transaction",
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -16,10 +40,114 @@ var helpDescsEnUS = map[string]string{
 	// CreateMultisigResult help.
 	"createmultisigresult-address":      "The generated pay-to-script-hash address",
 	"createmultisigresult-redeemScript": "The script required to redeem outputs paid to the multisig address",
+	// CreateMultiSigAccountCmd help.
+	"createmultisigaccount--synopsis": "Adds an M-of-N multisig account whose deposit addresses pay to a P2SH multisig script of keys derived from the cosigner extended public keys.\n" +
+		"Cosigner keys that are the extended public key of an account of the wallet are marked as ours, and the wallet signs for them.",
+	"createmultisigaccount-name":      "The name of the multisig account",
+	"createmultisigaccount-nrequired": "The number of signatures required to spend outputs paid to the account",
+	"createmultisigaccount-xpubs":     "The extended public keys of the accounts of the cosigners",
+	// MultiSigAccountResult help.
+	"multisigaccountresult-name":      "The name of the multisig account",
+	"multisigaccountresult-required":  "The number of signatures required to spend outputs paid to the account",
+	"multisigaccountresult-cosigners": "The cosigners of the account",
+	"multisigaccountresult-nextindex": "The index of the next deposit address",
+	// MultiSigCosignerResult help.
+	"multisigcosignerresult-xpub":    "The extended public key of the account of the cosigner",
+	"multisigcosignerresult-ours":    "Whether the key is the extended public key of an account of the wallet",
+	"multisigcosignerresult-account": "The wallet account of the key when it is ours",
+	// CreateVaultAccountCmd help.
+	"createvaultaccount--synopsis": "Adds an account whose deposit addresses pay to a script that can only be spent once the chain reaches the lock height of the address, protecting its funds from a thief with the keys of the wallet until then.\n" +
+		"Every address is locked to the lock height when it is set, or else until the delay after the height it is generated at, as the chain has no relative lock times. The wallet must be unlocked.",
+	"createvaultaccount-name":       "The name of the vault account",
+	"createvaultaccount-lockheight": "The block height every deposit address is locked until, or 0 to use the delay",
+	"createvaultaccount-delay":      "The number of blocks each deposit address is locked for after it is generated, when there is no lock height",
+	// VaultAccountResult help.
+	"vaultaccountresult-name":       "The name of the vault account",
+	"vaultaccountresult-lockheight": "The block height every deposit address is locked until",
+	"vaultaccountresult-delay":      "The number of blocks each deposit address is locked for after it is generated",
+	// DeleteAddressMetaCmd help.
+	"deleteaddressmeta--synopsis": "Removes the metadata stored in the wallet for an address.",
+	"deleteaddressmeta-address":   "The address to remove the metadata of",
+	// AddressMetaResult help.
+	"addressmetaresult-address":  "The address the metadata is for",
+	"addressmetaresult-category": "\"receive\" for a payment request made with an address of the wallet, or \"send\" for an address book entry of a recipient",
+	"addressmetaresult-amount":   "The amount requested with a receive entry, or paid to a send entry, valued in bitcoin",
+	"addressmetaresult-message":  "The message of the payment request or payment",
+	"addressmetaresult-label":    "The label of the address",
+	"addressmetaresult-state":    "The stored invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"",
+	"addressmetaresult-txid":     "The hash of the transaction that paid the request or made the payment",
+	"addressmetaresult-created":  "The time the metadata was created in seconds since 1 Jan 1970 GMT",
+	"addressmetaresult-modified": "The time the metadata was last changed in seconds since 1 Jan 1970 GMT",
+	"addressmetaresult-expires":  "The time a receive entry expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire",
 	// DumpPrivKeyCmd help.
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
+	// ExportAccountXprvCmd help.
+	"exportaccountxprv--synopsis": "Returns the extended private key of an account so it can be restored in other wallet software.\n" +
+		"The wallet must be unlocked, and the command must be enabled with allowxprvexport and an xprvexportpass set in the wallet configuration.",
+	"exportaccountxprv-account":   "The name of the account to export",
+	"exportaccountxprv-password":  "The xprv export password, which is separate from the RPC password",
+	"exportaccountxprv-plaintext": "Return the key unencrypted instead of encrypted with the xprv export password",
+	// ExportAccountXprvResult help.
+	"exportaccountxprvresult-account":   "The name of the exported account",
+	"exportaccountxprvresult-encrypted": "Whether xprv is encrypted with the xprv export password",
+	"exportaccountxprvresult-xprv":      "The extended private key of the account, or the hex of the encrypted key if it is encrypted",
+	"exportaccountxprvresult-keyparams": "The hex of the salt and scrypt parameters that derive the encryption key from the xprv export password, unset if the key is not encrypted",
+	// ExportLedgerCmd help.
+	"exportledger--synopsis": "Returns the history of the wallet as double-entry journal entries for importing into plaintext accounting tools.\n" +
+		"The balance of each wallet account is kept under Assets:Wallet, payments are posted under Income:Received and Expenses:Payments by the label of the other address, and fees to Expenses:Fees.",
+	"exportledger-format":    "The journal format, ledger (also read by hledger) or beancount",
+	"exportledger-commodity": "The commodity name of the amounts",
+	"exportledger--result0":  "The journal entries, oldest first",
+	// ExportPaymentBundleCmd help.
+	"exportpaymentbundle--synopsis": "Makes a fresh receiving address of an account for each payment request and returns them as a signed JSON bundle for an offline point of sale device.\n" +
+		"Each address is recorded as a payment request with its label and amount, so the settlement of the bundle can be followed with getpaymentbundle and its addresses are listed by listinvoices. The wallet must be unlocked.",
+	"exportpaymentbundle-account":     "The account to make the addresses in",
+	"exportpaymentbundle-requests":    "The payment requests, one for each address",
+	"exportpaymentbundle-expires":     "The time the payment requests expire in seconds since 1 Jan 1970 GMT, or 0 if they do not expire",
+	"exportpaymentbundle-signaddress": "The address of the wallet whose key signs the bundle, by default the first receiving address of the account so every bundle of the account is signed with the same key",
+	// PaymentBundleRequest help.
+	"paymentbundlerequest-label":  "The label of the payment, shown by the device",
+	"paymentbundlerequest-amount": "The amount requested valued in bitcoin, or 0 to accept any amount",
+	// ExportPaymentBundleResult help.
+	"exportpaymentbundleresult-id":          "The ID of the bundle",
+	"exportpaymentbundleresult-bundle":      "The JSON document of the bundle with the network, account, signing address and expiry, and the address, label, amount and payment URI of each request",
+	"exportpaymentbundleresult-signaddress": "The address whose key signed the bundle",
+	"exportpaymentbundleresult-signature":   "The signature of the bundle document as made by signmessage with the signing address, which verifymessage checks",
+	// ExportWatchSetCmd help.
+	"exportwatchset--synopsis": "Returns the output scripts of the wallet and its unspent outputs as of the block it is synced to, holding no keys.\n" +
+		"Save the result to the watch set file of a 'pod watch' process, on another machine, which alerts through its webhook or command when the funds of the wallet move.",
+	// WatchSetResult help.
+	"watchsetresult-network":   "The network the wallet is on",
+	"watchsetresult-height":    "The height of the block the wallet is synced to",
+	"watchsetresult-hash":      "The hash of the block the wallet is synced to",
+	"watchsetresult-scripts":   "The hex of the output scripts of all addresses of the wallet and the scripts it watches",
+	"watchsetresult-outpoints": "The unspent outputs paying to the scripts",
+	// WatchOutpointResult help.
+	"watchoutpointresult-txid":   "The hash of the transaction of the output",
+	"watchoutpointresult-vout":   "The index of the output in the transaction",
+	"watchoutpointresult-amount": "The value of the output",
+	"watchoutpointresult-script": "The hex of the output script",
+	// FreezeUnspentCmd help.
+	"freezeunspent--synopsis": "Freezes or unfreezes outputs, with the reason they are frozen for.\n" +
+		"Frozen outputs are never chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\n" +
+		"Unlike the locks made with lockunspent, frozen outputs are saved in the wallet and are not unfrozen by unlocking all outputs.",
+	"freezeunspent-unfreeze":     "True to unfreeze outputs, false to freeze",
+	"freezeunspent-transactions": "Transaction outputs to freeze or unfreeze",
+	"freezeunspent-reason":       "Why the outputs are frozen, such as \"under dispute\" or \"dusting attack output\", which replaces the reason of outputs that are already frozen",
+	"freezeunspent--result0":     "The boolean 'true'",
+	// GeneratePaperKeyCmd help.
+	"generatepaperkey--synopsis": "Generates new key pairs that are not stored in the wallet, for printing on paper wallets or giving away.\n" +
+		"Their funds can be moved into the wallet later with sweepprivkey.",
+	"generatepaperkey-count":    "Number of key pairs to generate, at most 100",
+	"generatepaperkey--result0": "The generated key pairs",
+	// PaperKeyResult help.
+	"paperkeyresult-address":   "The pay to public key hash address of the key",
+	"paperkeyresult-privkey":   "The private key in WIF format",
+	"paperkeyresult-pubkey":    "The hex-encoded compressed public key",
+	"paperkeyresult-addressqr": "The text to encode in the QR code of the address, a payment URI",
+	"paperkeyresult-privkeyqr": "The text to encode in the QR code of the private key",
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -29,18 +157,72 @@ var helpDescsEnUS = map[string]string{
 		"A new address is generated for the account if the most recently generated address has been seen on the blockchain or in mempool.",
 	"getaccountaddress-account":  "The account of the returned address",
 	"getaccountaddress--result0": "The unused address for 'account'",
+	// GetAccountXPubCmd help.
+	"getaccountxpub--synopsis": "Returns the extended public key of an account, which is given to the other cosigners of a multisig account.",
+	"getaccountxpub-account":   "The name of the account",
+	"getaccountxpub--result0":  "The extended public key of the account",
+	// GetAddressInfoCmd help.
+	"getaddressinfo--synopsis": "Returns where an address is kept in the wallet, found from the index of the output scripts paying to the addresses of the wallet.",
+	"getaddressinfo-address":   "The address to return the information of",
+	// GetAddressInfoResult help.
+	"getaddressinforesult-address":      "The address the information is for",
+	"getaddressinforesult-scriptPubKey": "The hex-encoded output script paying to the address",
+	"getaddressinforesult-ismine":       "Whether the address is an address of the wallet",
+	"getaddressinforesult-iswatchonly":  "Whether the output script of the address is watched by the wallet",
+	"getaddressinforesult-isscript":     "Whether the address is a pay to script hash address",
+	"getaddressinforesult-ischange":     "Whether the address is on the internal branch of its account, as change addresses are",
+	"getaddressinforesult-account":      "The account of the address",
+	"getaddressinforesult-branch":       "The branch of the account the address was derived on, 0 for external and 1 for internal",
+	"getaddressinforesult-index":        "The index of the address on its branch",
+	"getaddressinforesult-hdkeypath":    "The derivation path of the key of the address, if it was derived from the seed of the wallet",
+	// GetAddressMetaCmd help.
+	"getaddressmeta--synopsis": "Returns the metadata stored in the wallet for an address, such as the amount and message of a payment request.",
+	"getaddressmeta-address":   "The address to return the metadata of",
 	// GetAddressesByAccountCmd help.
 	"getaddressesbyaccount--synopsis": "DEPRECATED -- Returns all addresses strings controlled by a single account.",
 	"getaddressesbyaccount-account":   "Account name to fetch addresses for",
 	"getaddressesbyaccount--result0":  "All addresses controlled by 'account'",
+	// GetAuditLogCmd help.
+	"getauditlog--synopsis": "Returns entries of the audit log of requests that changed the wallet or exported keys from it, oldest first.\n" +
+		"Each entry is chained to the one before it by its hash, so changes to the log can be detected.",
+	"getauditlog-from":      "The sequence number of the first entry to return",
+	"getauditlog-count":     "Maximum number of entries to return",
+	"getauditlog-starttime": "If not 0, only entries made at or after this Unix time are returned",
+	"getauditlog-endtime":   "If not 0, only entries made at or before this Unix time are returned",
+	// GetAuditLogResult help.
+	"getauditlogresult-entries":  "The entries of the audit log",
+	"getauditlogresult-verified": "Whether the hash chain of the whole audit log is intact",
+	// AuditLogEntryResult help.
+	"auditlogentryresult-seq":      "The sequence number of the entry",
+	"auditlogentryresult-time":     "The Unix time of the request",
+	"auditlogentryresult-identity": "The user name the client authenticated with and its address",
+	"auditlogentryresult-action":   "The RPC method of the request",
+	"auditlogentryresult-detail":   "The parameters of the request, leaving out secrets, and the transaction hash of sends",
+	"auditlogentryresult-error":    "The error the request failed with, unset if it succeeded",
+	"auditlogentryresult-hash":     "The hash of the previous entry and this one",
 	// GetBalanceCmd help.
 	"getbalance--synopsis":   "Calculates and returns the balance of one or all accounts.",
-	"getbalance-minconf":     "Minimum number of block confirmations required before an unspent output's value is included in the balance",
+	"getbalance-minconf":     "Minimum number of block confirmations required before an unspent output's value is included in the balance, or unset to use the wallet's minconfchange and minconfreceived settings",
 	"getbalance-account":     "DEPRECATED -- The account name to query the balance for, or \"*\" to consider all accounts (default=\"*\")",
 	"getbalance--condition0": "account != \"*\"",
 	"getbalance--condition1": "account = \"*\"",
 	"getbalance--result0":    "The balance of 'account' valued in bitcoin",
-	"getbalance--result1":    "The balance of all accounts valued in bitcoin",
+	"getbalance--result1":    "The balance of all accounts valued in bitcoin, leaving out archived accounts",
+	// GetBalanceAtCmd help.
+	"getbalanceat--synopsis": "Returns the balance of the wallet as of a past block, found by replaying the credits and debits of the transactions mined up to and including it.\n" +
+		"Immature coinbase outputs are counted. A timestamp selects the last block whose time is not after it.",
+	"getbalanceat-heightortime": "The block height, or a unix timestamp when it is 500000000 or more, like a transaction lock time",
+	"getbalanceat-peraccount":   "Also return the balance of each account",
+	// GetBalanceAtResult help.
+	"getbalanceatresult-height":   "The height of the block the balance is of",
+	"getbalanceatresult-hash":     "The hash of the block the balance is of",
+	"getbalanceatresult-time":     "The timestamp of the block the balance is of",
+	"getbalanceatresult-balance":  "The balance of the wallet valued in bitcoin",
+	"getbalanceatresult-accounts": "The accounts with a non-zero balance, when requested, with outputs that pay to no account last with an empty name",
+	// AccountBalanceAtResult help.
+	"accountbalanceatresult-account": "The name of the account",
+	"accountbalanceatresult-scope":   "The key scope of the account",
+	"accountbalanceatresult-balance": "The balance of the account valued in bitcoin",
 	// GetBestBlockHashCmd help.
 	"getbestblockhash--synopsis": "Returns the hash of the newest block in the best chain that wallet has finished syncing with.",
 	"getbestblockhash--result0":  "The hash of the most recent synced-to block",
@@ -67,9 +249,39 @@ var helpDescsEnUS = map[string]string{
 	"infowalletresult-keypoolsize":     "Unset",
 	"infowalletresult-keypoololdest":   "Unset",
 	// GetNewAddressCmd help.
-	"getnewaddress--synopsis": "Generates and returns a new payment address.",
-	"getnewaddress-account":   "DEPRECATED -- Account name the new address will belong to (default=\"default\")",
-	"getnewaddress--result0":  "The payment address",
+	"getnewaddress--synopsis":   "Generates and returns a new payment address.",
+	"getnewaddress-account":     "DEPRECATED -- Account name the new address will belong to (default=\"default\")",
+	"getnewaddress-addresstype": "Type of the new address: legacy, p2sh-segwit or bech32 if active on the network (default is set by the wallet, then the configuration, then the network)",
+	"getnewaddress--result0":    "The payment address",
+	// GetNewMultiSigAddressCmd help.
+	"getnewmultisigaddress--synopsis": "Returns the next deposit address of a multisig account and imports its redeem script into the wallet.\n" +
+		"Every cosigner derives the same address at the same index. The wallet must be unlocked.",
+	"getnewmultisigaddress-name": "The name of the multisig account",
+	// MultiSigAddressResult help.
+	"multisigaddressresult-address":      "The pay-to-script-hash deposit address",
+	"multisigaddressresult-redeemScript": "The script required to redeem outputs paid to the address",
+	"multisigaddressresult-index":        "The index of the address, which is the index of the cosigner keys it is made from",
+	// GetNewVaultAddressCmd help.
+	"getnewvaultaddress--synopsis": "Returns the next deposit address of a vault account and imports its redeem script into the wallet. The wallet must be unlocked.",
+	"getnewvaultaddress-name":      "The name of the vault account",
+	// VaultAddressResult help.
+	"vaultaddressresult-address":      "The pay-to-script-hash deposit address",
+	"vaultaddressresult-redeemScript": "The script required to redeem outputs paid to the address",
+	"vaultaddressresult-lockheight":   "The block height the address is locked until",
+	// GetVaultScheduleCmd help.
+	"getvaultschedule--synopsis": "Returns the deposit addresses of a vault account in the order they unlock, with the amount paid to each that has not been spent.",
+	"getvaultschedule-name":      "The name of the vault account",
+	// VaultScheduleResult help.
+	"vaultscheduleresult-height":   "The height of the block the wallet is synced to",
+	"vaultscheduleresult-locked":   "The unspent amount paid to addresses that are still locked, valued in bitcoin",
+	"vaultscheduleresult-unlocked": "The unspent amount paid to addresses that have unlocked, which withdrawvault spends, valued in bitcoin",
+	"vaultscheduleresult-locks":    "The deposit addresses of the account",
+	// VaultLockResult help.
+	"vaultlockresult-address":    "The deposit address",
+	"vaultlockresult-lockheight": "The block height the address is locked until",
+	"vaultlockresult-blocksleft": "The number of blocks until the address unlocks, 0 once it has",
+	"vaultlockresult-amount":     "The unspent amount paid to the address, valued in bitcoin",
+	"vaultlockresult-outputs":    "The number of unspent outputs paid to the address",
 	// GetRawChangeAddressCmd help.
 	"getrawchangeaddress--synopsis": "Generates and returns a new internal payment address for use as a change address in raw transactions.",
 	"getrawchangeaddress-account":   "Account name the new internal address will belong to (default=\"default\")",
@@ -84,10 +296,93 @@ var helpDescsEnUS = map[string]string{
 	"getreceivedbyaddress-address":   "Payment address which received outputs to include in total",
 	"getreceivedbyaddress-minconf":   "Minimum number of block confirmations required before an output's value is included in the total",
 	"getreceivedbyaddress--result0":  "The total received amount valued in bitcoin",
+	// GetSpendAuthCmd help.
+	"getspendauth--synopsis": "Returns the PIN or authenticator code the wallet requires to send more than its spend limit, and the limit.",
+	// GetPaymentBundleCmd help.
+	"getpaymentbundle--synopsis": "Returns the settlement of a payment bundle exported with exportpaymentbundle, with the payments made to each of its addresses.",
+	"getpaymentbundle-id":        "The ID of the bundle",
+	"getpaymentbundle-minconf":   "Minimum number of block confirmations a payment needs to count towards the amount requested",
+	// PaymentBundleResult help.
+	"paymentbundleresult-id":          "The ID of the bundle",
+	"paymentbundleresult-account":     "The account of the addresses of the bundle",
+	"paymentbundleresult-signaddress": "The address whose key signed the bundle",
+	"paymentbundleresult-state":       "\"open\" while none of the requests has been paid, \"partial\" when some have, \"settled\" when every request that was not cancelled has been paid, or \"expired\" when the requests expired before any was paid",
+	"paymentbundleresult-requested":   "The amounts of all the requests added up valued in bitcoin",
+	"paymentbundleresult-received":    "The amount paid to the addresses of the bundle with enough confirmations valued in bitcoin",
+	"paymentbundleresult-pending":     "The amount paid to the addresses of the bundle without enough confirmations yet valued in bitcoin",
+	"paymentbundleresult-paid":        "The number of requests that have been paid",
+	"paymentbundleresult-requests":    "The payment requests of the bundle in the order they were exported in",
+	"paymentbundleresult-created":     "The time the bundle was exported in seconds since 1 Jan 1970 GMT",
+	"paymentbundleresult-expires":     "The time the requests of the bundle expire in seconds since 1 Jan 1970 GMT, omitted if they do not expire",
+	// GetRescanInfoCmd help.
+	"getrescaninfo--synopsis": "Returns the progress of the rescan the wallet is running, or last ran.\n" +
+		"Rescans run in the background, the wallet only knows of the transactions in the blocks a rescan has passed, and an unmined transaction found to double spend a mined one is removed and listed as a conflict.",
+	// GetRescanInfoResult help.
+	"getrescaninforesult-running":     "Whether a rescan is running",
+	"getrescaninforesult-started":     "The time the rescan started in seconds since 1 Jan 1970 GMT, or 0 if the wallet has not rescanned since it was started",
+	"getrescaninforesult-addresses":   "The number of addresses rescanned for",
+	"getrescaninforesult-outpoints":   "The number of outputs rescanned for spends of",
+	"getrescaninforesult-startheight": "The height of the block the rescan started at",
+	"getrescaninforesult-height":      "The height of the last block the rescan has passed",
+	"getrescaninforesult-bestheight":  "The height of the best block of the chain server when the rescan started",
+	"getrescaninforesult-queued":      "The number of rescans waiting for this one to finish",
+	"getrescaninforesult-conflicts":   "The unmined transactions removed since the rescan started because a mined transaction spends the same output",
+	// RescanConflictResult help.
+	"rescanconflictresult-txid":    "The transaction hash of the output spent twice",
+	"rescanconflictresult-vout":    "The output index of the output spent twice",
+	"rescanconflictresult-removed": "The hash of the unmined transaction that was removed, along with those spending its outputs",
+	"rescanconflictresult-spentby": "The hash of the mined transaction spending the output",
+	"rescanconflictresult-height":  "The height of the block the spending transaction was mined in",
+	// GetScrubInfoCmd help.
+	"getscrubinfo--synopsis": "Returns the progress of the scrubbing of the wallet database and the problems it found.\n" +
+		"The wallet reads back every row of its database in the background, a few at a time, checking each deserializes and agrees with the rows it refers to. " +
+		"Problems with the index of unspent outputs are repaired if walletscrubrepair is set, others are only reported, as they need a rescan or a backup to recover from.",
+	// GetScrubInfoResult help.
+	"getscrubinforesult-enabled":  "Whether the wallet database is scrubbed, which is set by walletscrubinterval",
+	"getscrubinforesult-repair":   "Whether the problems that can be repaired are, which is set by walletscrubrepair",
+	"getscrubinforesult-phase":    "The kind of rows being checked, omitted while waiting for the next pass",
+	"getscrubinforesult-passes":   "The number of passes over the database completed since the wallet was started",
+	"getscrubinforesult-started":  "The time the running or last pass started in seconds since 1 Jan 1970 GMT, or 0 if none has",
+	"getscrubinforesult-checked":  "The number of rows checked by the running or last pass",
+	"getscrubinforesult-finished": "The time the last complete pass finished in seconds since 1 Jan 1970 GMT, or 0 if none has",
+	"getscrubinforesult-repaired": "The number of problems repaired since the wallet was started",
+	"getscrubinforesult-findings": "The problems found by the running pass and the last complete pass, the latest 100",
+	// ScrubFindingResult help.
+	"scrubfindingresult-pass":     "The number of the pass that found the problem, counting from 1",
+	"scrubfindingresult-time":     "The time the problem was found in seconds since 1 Jan 1970 GMT",
+	"scrubfindingresult-kind":     "The kind of rows the problem was found in (addresses, blocks, txrecords, credits, unspent, debits or unmined)",
+	"scrubfindingresult-key":      "The key of the row in hex, or the key scope for addresses",
+	"scrubfindingresult-problem":  "What is wrong with the row",
+	"scrubfindingresult-repaired": "Whether the problem was repaired",
 	// GetTransactionCmd help.
 	"gettransaction--synopsis":        "Returns a JSON object with details regarding a transaction relevant to this wallet.",
 	"gettransaction-txid":             "Hash of the transaction to query",
 	"gettransaction-includewatchonly": "Also consider transactions involving watched addresses",
+	// GetUTXOReportCmd help.
+	"getutxoreport--synopsis": "Returns the unspent outputs of the wallet, including unmined ones, summed up by age and by amount, with the outputs that cost more in fees to spend at the fee rate than they are worth.",
+	"getutxoreport-feerate":   "The fee rate per kilobyte the cost of spending the outputs is found at, valued in bitcoin (default=the fee rate of the wallet)",
+	// GetUTXOReportResult help.
+	"getutxoreportresult-height":           "The height of the block the wallet is synced to",
+	"getutxoreportresult-feerate":          "The fee rate per kilobyte of the report, valued in bitcoin",
+	"getutxoreportresult-spendcost":        "The fee spending one output adds to a transaction at the fee rate, valued in bitcoin",
+	"getutxoreportresult-count":            "The number of unspent outputs",
+	"getutxoreportresult-amount":           "The total amount of the unspent outputs, valued in bitcoin",
+	"getutxoreportresult-uneconomic":       "The number of outputs that cost more to spend than they are worth",
+	"getutxoreportresult-uneconomicamount": "The total amount of the uneconomic outputs, valued in bitcoin",
+	"getutxoreportresult-dust":             "The number of outputs too small for nodes to relay a transaction paying them at the fee rate",
+	"getutxoreportresult-frozen":           "The number of frozen outputs",
+	"getutxoreportresult-locked":           "The number of outputs locked with lockunspent",
+	"getutxoreportresult-byage":            "The outputs by the number of seconds since they were mined, with the unmined outputs first",
+	"getutxoreportresult-byvalue":          "The outputs by their amounts, valued in bitcoin",
+	// UTXOBucketResult help.
+	"utxobucketresult-name":             "The name of the range",
+	"utxobucketresult-min":              "The least age or amount in the range",
+	"utxobucketresult-max":              "The age or amount the range ends below, left out for the last range",
+	"utxobucketresult-count":            "The number of outputs in the range",
+	"utxobucketresult-amount":           "The total amount of the outputs in the range, valued in bitcoin",
+	"utxobucketresult-uneconomic":       "The number of outputs in the range that cost more to spend than they are worth",
+	"utxobucketresult-uneconomicamount": "The total amount of the uneconomic outputs in the range, valued in bitcoin",
+	"utxobucketresult-spendcost":        "The fee spending all the outputs in the range adds to a transaction at the fee rate, valued in bitcoin",
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -97,7 +392,7 @@ var helpDescsEnUS = map[string]string{
 	"help--result1":    "Help for specified command",
 	// GetTransactionResult help.
 	"gettransactionresult-amount":          "The total amount this transaction credits to the wallet, valued in bitcoin",
-	"gettransactionresult-fee":             "The total input value minus the total output value, or 0 if 'txid' is not a sent transaction",
+	"gettransactionresult-fee":             "The total output value minus the total input value, a negative amount, or 0 if the inputs of the transaction were not all spent by the wallet",
 	"gettransactionresult-confirmations":   "The number of block confirmations of the transaction",
 	"gettransactionresult-blockhash":       "The hash of the block this transaction is mined in, or the empty string if unmined",
 	"gettransactionresult-blockindex":      "Unset",
@@ -113,28 +408,168 @@ var helpDescsEnUS = map[string]string{
 	"gettransactiondetailsresult-address":           "The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input",
 	"gettransactiondetailsresult-category":          `The kind of detail: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, or "recv" for all other received outputs`,
 	"gettransactiondetailsresult-amount":            "The amount of a received output",
-	"gettransactiondetailsresult-fee":               "The included fee for a sent transaction",
+	"gettransactiondetailsresult-fee":               "The fee of the transaction as in the fee of the result, set on every detail when the inputs of the transaction were all spent by the wallet",
 	"gettransactiondetailsresult-vout":              "The transaction output index",
 	"gettransactiondetailsresult-involveswatchonly": "Unset",
+	// ImportCoreWalletCmd help.
+	"importcorewallet--synopsis": "Imports the private keys and address labels of a Bitcoin Core wallet.dat, such as that of the legacy Qt wallet, to the 'imported' account.\n" +
+		"The old client should be closed first so its wallet is complete. The birthday of each key is the block before the time its wallet recorded it was made, or the genesis block if it was not recorded, and the birthday of this wallet is moved back to the earliest of them.\n" +
+		"This wallet must be unlocked.",
+	"importcorewallet-path":       "The path of the wallet.dat file",
+	"importcorewallet-passphrase": "The passphrase of the wallet.dat, if its keys are encrypted",
+	"importcorewallet-rescan":     "Rescan the blockchain from the earliest birthday of the keys for outputs controlled by them",
+	// ImportCoreWalletResult help.
+	"importcorewalletresult-keys":           "The number of private keys in the wallet.dat",
+	"importcorewalletresult-imported":       "The number of keys that were imported",
+	"importcorewalletresult-duplicates":     "The number of keys that were already in the wallet",
+	"importcorewalletresult-labels":         "The number of address labels that were set",
+	"importcorewalletresult-rescanfrom":     "The height of the block the rescan starts from",
+	"importcorewalletresult-rescanfromhash": "The hash of the block the rescan starts from",
 	// ImportPrivKeyCmd help.
 	"importprivkey--synopsis": "Imports a WIF-encoded private key to the 'imported' account.",
 	"importprivkey-privkey":   "The WIF-encoded private key",
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
 	"importprivkey-rescan":    "Rescan the blockchain (since the genesis block) for outputs controlled by the imported key",
+	// ImportScriptPubKeyCmd help.
+	"importscriptpubkey--synopsis": "Watches an output script, which need not pay to an address, for payments and their spends. Outputs paying to watched scripts are listed by listunspent as not spendable and are not part of the balance. Requires a websocket connection to the chain server.",
+	"importscriptpubkey-script":    "The hex-encoded output script",
+	"importscriptpubkey-label":     "A label for the script",
+	"importscriptpubkey-rescan":    "Search the blockchain (since the genesis block) in the background for outputs paying to the script, or watch it only from the current block",
+	// ImportTimeLockScriptCmd help.
+	"importtimelockscript--synopsis": "Imports a redeem script that pays to a key of the wallet once an OP_CHECKLOCKTIMEVERIFY or OP_CHECKSEQUENCEVERIFY time lock has passed. " +
+		"Outputs paid to its P2SH address are listed by listunspent with the height or time they unlock at, are not spent by other sends, and are spent by sweeptimelocked once they unlock. The wallet must be unlocked.",
+	"importtimelockscript-redeemscript": "The hex-encoded redeem script",
+	"importtimelockscript-rescan":       "Search the blockchain (since the genesis block) in the background for outputs paid to the script, or watch it only from the current block",
+	// TimeLockScriptResult help.
+	"timelockscriptresult-address":  "The P2SH address of the script",
+	"timelockscriptresult-relative": "Whether the lock is checked by OP_CHECKSEQUENCEVERIFY, counting from the block an output is mined in",
+	"timelockscriptresult-seconds":  "Whether the lock is in seconds rather than blocks",
+	"timelockscriptresult-lock":     "The height or time in seconds since 1 Jan 1970 GMT the chain must reach, or for a relative lock the number of blocks or seconds that must pass",
 	// KeypoolRefillCmd help.
 	"keypoolrefill--synopsis": "DEPRECATED -- This request does nothing since no keypool is maintained.",
 	"keypoolrefill-newsize":   "Unused",
 	// ListAccountsCmd help.
-	"listaccounts--synopsis":       "DEPRECATED -- Returns a JSON object of all accounts and their balances.",
+	"listaccounts--synopsis":       "DEPRECATED -- Returns a JSON object of all accounts and their balances, leaving out archived accounts unless includearchived is set.",
 	"listaccounts-minconf":         "Minimum number of block confirmations required before an unspent output's value is included in the balance",
+	"listaccounts-includearchived": "Also list the accounts archived with archiveaccount",
 	"listaccounts--result0--desc":  "JSON object with account names as keys and bitcoin amounts as values",
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
+	// ListAddressMetaCmd help.
+	"listaddressmeta--synopsis": "Returns the metadata stored in the wallet for addresses, oldest first.",
+	"listaddressmeta-category":  "If set, only the metadata of this category, \"receive\" or \"send\", is returned",
+	// ListDustOutputsCmd help.
+	"listdustoutputs--synopsis": "Returns the outputs taken for the outputs of a dusting attack, which sends tiny amounts to many addresses of the wallet to link them, in the order they were detected.\n" +
+		"Such outputs are frozen when they are detected, release them with overridedust to spend them.",
+	// DustOutputResult help.
+	"dustoutputresult-txid":     "The transaction hash of the output",
+	"dustoutputresult-vout":     "The output index of the output",
+	"dustoutputresult-address":  "The address of the wallet the output pays to",
+	"dustoutputresult-amount":   "The value of the output valued in bitcoin",
+	"dustoutputresult-detected": "The time the output was detected in seconds since 1 Jan 1970 GMT",
+	"dustoutputresult-frozen":   "Whether the output is still frozen, false once it has been released",
+	// ListFrozenCmd help.
+	"listfrozen--synopsis": "Returns the outputs frozen (with freezeunspent) in the wallet, in the order they were frozen.",
+	// FrozenOutputResult help.
+	"frozenoutputresult-txid":    "The transaction hash of the frozen output",
+	"frozenoutputresult-vout":    "The output index of the frozen output",
+	"frozenoutputresult-reason":  "Why the output was frozen",
+	"frozenoutputresult-frozen":  "The time the output was frozen in seconds since 1 Jan 1970 GMT",
+	"frozenoutputresult-unspent": "Whether the output is an unspent output of the wallet, false if it was spent or is not known to the wallet yet",
+	"frozenoutputresult-address": "The address the output pays to, omitted unless it is unspent",
+	"frozenoutputresult-amount":  "The value of the output valued in bitcoin, omitted unless it is unspent",
+	// ListInvoicesCmd help.
+	"listinvoices--synopsis": "Returns the payment requests made with addresses of the wallet, oldest first, with the payments made to each address.",
+	"listinvoices-state":     "If set, only the invoices in this state, \"open\", \"partial\", \"paid\", \"expired\" or \"cancelled\", are returned",
+	"listinvoices-minconf":   "Minimum number of block confirmations a payment needs to count towards the amount requested",
+	// InvoiceResult help.
+	"invoiceresult-address":     "The address the payment was requested with",
+	"invoiceresult-amount":      "The amount requested valued in bitcoin",
+	"invoiceresult-message":     "The message of the payment request",
+	"invoiceresult-label":       "The label of the address",
+	"invoiceresult-state":       "\"open\" while waiting for payment, \"partial\" when less than the amount was paid, \"paid\", \"expired\" when nothing was paid in time, or \"cancelled\"",
+	"invoiceresult-received":    "The amount paid to the address with enough confirmations valued in bitcoin",
+	"invoiceresult-pending":     "The amount paid to the address without enough confirmations yet valued in bitcoin",
+	"invoiceresult-payments":    "The hashes of the transactions paying to the address",
+	"invoiceresult-created":     "The time the payment was requested in seconds since 1 Jan 1970 GMT",
+	"invoiceresult-expires":     "The time the payment request expires in seconds since 1 Jan 1970 GMT, omitted if it does not expire",
+	"invoiceresult-lastpayment": "The time the latest payment was seen in seconds since 1 Jan 1970 GMT, omitted if there is none",
+	// ListInvoiceReservationsCmd help.
+	"listinvoicereservations--synopsis": "Returns the addresses reserved (with reserveinvoiceaddress) in an account that issues its addresses sequentially, in order of their index.",
+	"listinvoicereservations-account":   "The account to list the reserved addresses of",
+	// InvoiceReservationResult help.
+	"invoicereservationresult-address":   "The reserved address",
+	"invoicereservationresult-account":   "The account of the address",
+	"invoicereservationresult-index":     "The index of the address in the external branch of the account",
+	"invoicereservationresult-reference": "The reference of the order the address was reserved for, omitted if there is none",
+	"invoicereservationresult-reserved":  "The time the address was reserved in seconds since 1 Jan 1970 GMT",
+	// ListImmatureCmd help.
+	"listimmature--synopsis": "Returns the wallet's coinbase outputs that have not yet reached coinbase maturity and how many blocks remain until each can be spent.",
+	"listimmature-account":   "Only include outputs paying to this account, or \"*\" for all accounts",
+	// ListImmatureResult help.
+	"listimmatureresult-total":   "The total value of the immature coinbase outputs valued in bitcoin",
+	"listimmatureresult-outputs": "The immature coinbase outputs, oldest first",
+	// ListImmatureOutputResult help.
+	"listimmatureoutputresult-txid":            "The hash of the coinbase transaction",
+	"listimmatureoutputresult-vout":            "The output index of the coinbase output",
+	"listimmatureoutputresult-address":         "The payment address that received the output",
+	"listimmatureoutputresult-account":         "The account associated with the receiving payment address",
+	"listimmatureoutputresult-amount":          "The amount of the output valued in bitcoin",
+	"listimmatureoutputresult-blockhash":       "The hash of the block that mined the coinbase transaction",
+	"listimmatureoutputresult-blockheight":     "The height of the block that mined the coinbase transaction",
+	"listimmatureoutputresult-confirmations":   "The number of block confirmations of the coinbase transaction",
+	"listimmatureoutputresult-maturityheight":  "The block height at which the output becomes spendable",
+	"listimmatureoutputresult-blocksremaining": "The number of blocks remaining until the output becomes spendable",
 	// ListLockUnspentCmd help.
 	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
 	// TransactionInput help.
 	"transactioninput-txid": "The transaction hash of the referenced output",
 	"transactioninput-vout": "The output index of the referenced output",
+	// ListMultiSigAccountsCmd help.
+	"listmultisigaccounts--synopsis": "Returns the multisig accounts of the wallet.",
+	// ListPaymentBundlesCmd help.
+	"listpaymentbundles--synopsis": "Returns the settlement of every payment bundle exported with exportpaymentbundle, oldest first.",
+	"listpaymentbundles-minconf":   "Minimum number of block confirmations a payment needs to count towards the amount requested",
+	// ListPortfolioCmd help.
+	"listportfolio--synopsis": "Returns the cold wallets in the portfolio (added with addportfolioentry) in the order of their names, with the funds each holds and those of all of them added up.",
+	"listportfolio-minconf":   "Minimum number of block confirmations an output needs to count towards the balance rather than the unconfirmed funds",
+	// ListPortfolioResult help.
+	"listportfolioresult-balance":     "The confirmed funds of all the entries",
+	"listportfolioresult-unconfirmed": "The unconfirmed funds of all the entries",
+	"listportfolioresult-entries":     "The entries of the portfolio",
+	// PortfolioEntryResult help.
+	"portfolioentryresult-name":        "The name of the entry",
+	"portfolioentryresult-descriptors": "The descriptors of the entry, with their checksums",
+	"portfolioentryresult-range":       "The number of scripts derived from each ranged descriptor",
+	"portfolioentryresult-height":      "The height of the block from which the entry is watched",
+	"portfolioentryresult-added":       "The time the entry was added in seconds since 1 Jan 1970 GMT",
+	"portfolioentryresult-balance":     "The value of the unspent outputs of the entry with at least minconf confirmations",
+	"portfolioentryresult-unconfirmed": "The value of the other unspent outputs of the entry",
+	"portfolioentryresult-unspent":     "The number of unspent outputs of the entry",
+	// ListPortfolioTransactionsCmd help.
+	"listportfoliotransactions--synopsis": "Returns the changes transactions made to the funds of the cold wallets in the portfolio, newest first with the unmined transactions before the mined ones.",
+	"listportfoliotransactions-name":      "If set, only the transactions of the entry with this name are returned",
+	"listportfoliotransactions-count":     "The most transactions to return",
+	// PortfolioTransactionResult help.
+	"portfoliotransactionresult-entry":         "The name of the entry",
+	"portfoliotransactionresult-txid":          "The hash of the transaction",
+	"portfoliotransactionresult-amount":        "The value the transaction paid to the entry less the value it spent from it, negative for a payment out of the entry",
+	"portfoliotransactionresult-confirmations": "The number of block confirmations of the transaction",
+	"portfoliotransactionresult-blockheight":   "The height of the block the transaction was mined in, omitted while it is unmined",
+	"portfoliotransactionresult-time":          "The time the transaction was mined, or seen while it is unmined, in seconds since 1 Jan 1970 GMT",
+	// ListQueuedPSBTsCmd help.
+	"listqueuedpsbts--synopsis": "Returns the transactions in the signing queue of a watching-only wallet, oldest first.\n" +
+		"A watching-only wallet can't sign the transactions it sends, so they are queued with a PSBT carrying the transactions the inputs spend and the BIP0032 derivations of the keys of the inputs and change for an external signer.\n" +
+		"The inputs of a pending transaction are locked until the signed PSBT is returned with submitsignedpsbt, or the transaction is cancelled with cancelqueuedpsbt.",
+	// QueuedPSBTResult help.
+	"queuedpsbtresult-id":      "The ID of the queued transaction, the hash of the unsigned transaction, which is returned by the command that sent it",
+	"queuedpsbtresult-account": "The account the transaction spends from",
+	"queuedpsbtresult-created": "The time the transaction was queued in seconds since 1 Jan 1970 GMT",
+	"queuedpsbtresult-state":   "Whether the transaction is pending its signatures or was broadcast",
+	"queuedpsbtresult-psbt":    "The base64 encoded PSBT, with the signatures submitted so far, or finalized once the transaction was broadcast",
+	"queuedpsbtresult-txid":    "The hash of the signed transaction, once it was broadcast",
+	// ListVaultAccountsCmd help.
+	"listvaultaccounts--synopsis": "Returns the vault accounts of the wallet.",
 	// ListReceivedByAccountCmd help.
 	"listreceivedbyaccount--synopsis":        "DEPRECATED -- Returns a JSON array of objects listing all accounts and the total amount received by each account.",
 	"listreceivedbyaccount-minconf":          "Minimum number of block confirmations required before a transaction is considered",
@@ -173,7 +608,8 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-address":            "Payment address for a transaction output",
 	"listtransactionsresult-category":           `The kind of transaction: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, or "recv" for all other received outputs.  Note: A single output may be included multiple times under different categories`,
 	"listtransactionsresult-amount":             "The value of the transaction output valued in bitcoin",
-	"listtransactionsresult-fee":                "The total input value minus the total output value for sent transactions",
+	"listtransactionsresult-fee":                "The total output value minus the total input value, a negative amount, on every entry of a transaction whose inputs were all spent by the wallet, including its received entries, and omitted for any other transaction as its fee is not known",
+	"listtransactionsresult-outputfee":          "The share of the fee attributed to this output in proportion to its value among the outputs that are not change, so the shares of the outputs of a sendmany add up to the fee, negative and omitted as the fee is",
 	"listtransactionsresult-confirmations":      "The number of block confirmations of the transaction",
 	"listtransactionsresult-generated":          "Whether the transaction output is a coinbase output",
 	"listtransactionsresult-blockhash":          "The hash of the block this transaction is mined in, or the empty string if unmined",
@@ -196,6 +632,21 @@ var helpDescsEnUS = map[string]string{
 	"listtransactions-count":            "Maximum number of transactions to create results from",
 	"listtransactions-from":             "Number of transactions to skip before results are created",
 	"listtransactions-includewatchonly": "Unused",
+	// ListTransactionsPageCmd help.
+	"listtransactionspage--synopsis": "Returns a page of verbose details for wallet transactions, newest first, that pass the filter.\n" +
+		"The next page is returned when the nextcursor of a result is passed back as the cursor.",
+	"listtransactionspage-cursor": "The nextcursor of the previous page, or unset for the first page",
+	"listtransactionspage-count":  "Maximum number of results in the page",
+	"listtransactionspage-filter": "If set, only results that match all of the set fields of the filter are returned",
+	// ListTransactionsFilter help.
+	"listtransactionsfilter-categories": "The categories of the results to return, such as \"send\", \"receive\", \"generate\" or \"immature\"",
+	"listtransactionsfilter-label":      "The account the results must belong to",
+	"listtransactionsfilter-starttime":  "The earliest transaction time in seconds since 1 Jan 1970 GMT",
+	"listtransactionsfilter-endtime":    "The latest transaction time in seconds since 1 Jan 1970 GMT",
+	"listtransactionsfilter-minamount":  "The smallest absolute amount of the results in bitcoin",
+	// ListTransactionsPageResult help.
+	"listtransactionspageresult-transactions": "The results in the page",
+	"listtransactionspageresult-nextcursor":   "The cursor to get the next page with, unset if this is the last page",
 	// ListUnspentCmd help.
 	"listunspent--synopsis": "Returns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.",
 	"listunspent-minconf":   "Minimum number of block confirmations required before a transaction output is considered",
@@ -207,10 +658,20 @@ var helpDescsEnUS = map[string]string{
 	"listunspentresult-address":       "The payment address that received the output",
 	"listunspentresult-account":       "The account associated with the receiving payment address",
 	"listunspentresult-scriptPubKey":  "The output script encoded as a hexadecimal string",
-	"listunspentresult-redeemScript":  "Unset",
+	"listunspentresult-redeemScript":  "The redeem script of an output paid to a time locked script of the wallet, unset otherwise",
 	"listunspentresult-amount":        "The amount of the output valued in bitcoin",
 	"listunspentresult-confirmations": "The number of block confirmations of the transaction",
 	"listunspentresult-spendable":     "Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)",
+	"listunspentresult-timelocked":    "Whether the output is paid to a time locked script whose lock can't yet be passed in the next block",
+	"listunspentresult-unlockheight":  "The height the best block must reach for an output under a lock in blocks to be spent, unset for other outputs and relative locks of outputs that are not mined",
+	"listunspentresult-unlocktime":    "The median time past, in seconds since 1 Jan 1970 GMT, the best block must reach for an output under a lock in seconds to be spent, unset for other outputs and relative locks of outputs that are not mined",
+	// ListUnlockAttemptsCmd help.
+	"listunlockattempts--synopsis": "Returns the audit log of the most recent attempts to unlock the wallet with walletpassphrase, oldest first.\n" +
+		"After repeated incorrect passphrases, attempts are refused for a time that doubles with every further incorrect passphrase.",
+	// UnlockAttemptResult help.
+	"unlockattemptresult-time":    "The time of the attempt in seconds since 1 Jan 1970 GMT",
+	"unlockattemptresult-success": "Whether the wallet was unlocked",
+	"unlockattemptresult-reason":  "Why the attempt failed, or 'unlocked' if it succeeded",
 	// LockUnspentCmd help.
 	"lockunspent--synopsis": "Locks or unlocks an unspent output.\n" +
 		"Locked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\n" +
@@ -219,16 +680,62 @@ var helpDescsEnUS = map[string]string{
 	"lockunspent-unlock":       "True to unlock outputs, false to lock",
 	"lockunspent-transactions": "Transaction outputs to lock or unlock",
 	"lockunspent--result0":     "The boolean 'true'",
+	// OverrideDustCmd help.
+	"overridedust--synopsis": "Releases outputs taken for the outputs of a dusting attack (listed by listdustoutputs), unfreezing them so they can be spent, or freezes them again.\n" +
+		"Spending dust along with other outputs links the addresses it was sent to, which is what a dusting attack is made for.",
+	"overridedust-release":      "True to release the outputs, false to freeze them again",
+	"overridedust-transactions": "Dust outputs to release or freeze",
+	"overridedust--result0":     "The boolean 'true'",
+	// PreviewSendCmd help.
+	"previewsend--synopsis": "Works out the transaction a sendmany with the same arguments would make, without signing or broadcasting it.\n" +
+		"Returns the unspent outputs selected to fund it, its size, fee, change and fee rate, so the send can be confirmed before it is made.\n" +
+		"The selected outputs are not locked, so the send can select different ones if other transactions are made in between.",
+	"previewsend-fromaccount":    "Account to pick unspent outputs from",
+	"previewsend-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"previewsend-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in DUO to send to each address",
+	"previewsend-amounts--key":   "Address to pay",
+	"previewsend-amounts--value": "Amount to send to the payment address valued in DUO",
+	"previewsend-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings",
+	"previewsend-scripts":        "Pairs of hex encoded output scripts and the output amount to pay each",
+	"previewsend-scripts--desc":  "JSON object using hex encoded output scripts in one of the standard forms, such as bare multisig, as keys and output amounts valued in DUO to pay to each script",
+	"previewsend-scripts--key":   "Hex encoded output script to pay",
+	"previewsend-scripts--value": "Amount to pay to the output script valued in DUO",
+	// PreviewSendResult help.
+	"previewsendresult-inputs":  "The unspent outputs selected to fund the transaction",
+	"previewsendresult-vsize":   "The estimated size in bytes of the transaction once it is signed",
+	"previewsendresult-fee":     "The fee paid by the transaction in DUO",
+	"previewsendresult-change":  "The amount in DUO returned to the wallet as change, or 0 if there is no change output",
+	"previewsendresult-feerate": "The fee paid per kilobyte of the transaction in DUO",
+	// PreviewSendInput help.
+	"previewsendinput-txid":    "The hash of the transaction of the output",
+	"previewsendinput-vout":    "The index of the output in its transaction",
+	"previewsendinput-address": "The address the output pays to",
+	"previewsendinput-amount":  "The value of the output in DUO",
 	// SendFromCmd help.
+	// ReleaseInvoiceAddressCmd help.
+	"releaseinvoiceaddress--synopsis": "Releases an address reserved (with reserveinvoiceaddress) for an order that was not paid, so it is the next address reserved in its account.\n" +
+		"Addresses that have been paid can't be released.",
+	"releaseinvoiceaddress-address":   "The reserved address to release",
+	"releaseinvoiceaddress--result0":  "The boolean 'true'",
+	// RemovePortfolioEntryCmd help.
+	"removeportfolioentry--synopsis": "Removes a cold wallet from the portfolio along with the outputs recorded as paying to it.",
+	"removeportfolioentry-name":      "The name of the entry",
+	// ReserveInvoiceAddressCmd help.
+	"reserveinvoiceaddress--synopsis": "Reserves the next external address of an account that issues its addresses sequentially (switched on with setinvoiceissuance) for the reference of an order.\n" +
+		"The address is the lowest released one, or the next address of the account if none were released, so the indexes handed out have no gaps or duplicates even when addresses are reserved concurrently.\n" +
+		"Reserving again with a reference that is already reserved returns the same address.",
+	"reserveinvoiceaddress-account":   "The account to reserve the address in",
+	"reserveinvoiceaddress-reference": "The reference of the order, such as its ID, which must be unique in the account if it is not empty",
 	"sendfrom--synopsis": "DEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
 	"sendfrom-fromaccount": "Account to pick unspent outputs from",
 	"sendfrom-toaddress":   "Address to pay",
 	"sendfrom-amount":      "Amount to send to the payment address valued in bitcoin",
-	"sendfrom-minconf":     "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"sendfrom-minconf":     "Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings",
 	"sendfrom-comment":     "Unused",
 	"sendfrom-commentto":   "Unused",
-	"sendfrom--result0":    "The transaction hash of the sent transaction",
+	"sendfrom-authcode":    "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	"sendfrom--result0":    "The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet",
 	// SendManyCmd help.
 	"sendmany--synopsis": "Authors, signs, and sends a transaction that outputs to many payment addresses.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
@@ -237,18 +744,62 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
 	"sendmany-amounts--key":   "Address to pay",
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
-	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent, or unset to use the wallet's minconfchange and minconfreceived settings",
 	"sendmany-comment":        "Unused",
-	"sendmany--result0":       "The transaction hash of the sent transaction",
+	"sendmany-scripts":        "Pairs of hex encoded output scripts and the output amount to pay each",
+	"sendmany-scripts--desc":  "JSON object using hex encoded output scripts in one of the standard forms, such as bare multisig, as keys and output amounts valued in DUO to pay to each script",
+	"sendmany-scripts--key":   "Hex encoded output script to pay",
+	"sendmany-scripts--value": "Amount to pay to the output script valued in DUO",
+	"sendmany-authcode":       "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	"sendmany-feeoptions":     "A fee rate or an absolute fee to pay in place of the wallet's fee rate, only one of which may be set",
+	"sendmany-idempotencykey": "A key of up to 128 characters chosen by the client, a retry with the same key and request returns the hash of the transaction sent the first time instead of sending again, and a different request with the key, or a retry of a send that was interrupted before it finished, is refused",
+	"sendmany--result0":       "The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet",
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
-	"sendtoaddress-address":   "Address to pay",
-	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
-	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	"sendtoaddress-address":    "Address to pay",
+	"sendtoaddress-amount":     "Amount to send to the payment address valued in bitcoin",
+	"sendtoaddress-comment":    "Unused",
+	"sendtoaddress-commentto":  "Unused",
+	"sendtoaddress-authcode":   "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	"sendtoaddress-feeoptions": "A fee rate or an absolute fee to pay in place of the wallet's fee rate, only one of which may be set",
+	"sendtoaddress-idempotencykey": "A key of up to 128 characters chosen by the client, a retry with the same key and request returns the hash of the transaction sent the first time instead of sending again, and a different request with the key, or a retry of a send that was interrupted before it finished, is refused",
+	"sendtoaddress--result0":   "The transaction hash of the sent transaction, or the ID of the transaction queued for an external signer by a watching-only wallet",
+	// SendFeeOptions help.
+	"sendfeeoptions-feerate": "Fee rate in DUO/kB to pay, which must be at least the minimum relay fee rate",
+	"sendfeeoptions-fee":     "Absolute fee in DUO to pay, which must be no more than the maxtxfee setting and at least the minimum relay fee for the size of the transaction",
+	// SetAddressMetaCmd help.
+	"setaddressmeta--synopsis": "Changes the metadata stored in the wallet for an address, creating it if the address has none, and returns it.\n" +
+		"New metadata is a receive entry, which starts out as an open payment request, if the address is in the wallet, and a send entry otherwise.",
+	"setaddressmeta-address": "The address to change the metadata of",
+	"setaddressmeta-meta":    "The fields of the metadata to change, where fields that are not set are left as they are",
+	// AddressMetaFields help.
+	"addressmetafields-amount":  "The amount requested or paid valued in bitcoin",
+	"addressmetafields-message": "The message of the payment request or payment",
+	"addressmetafields-label":   "The label of the address",
+	"addressmetafields-state":   "The invoice state of a receive entry, \"open\", \"paid\" or \"cancelled\"",
+	"addressmetafields-txid":    "The hash of the transaction that paid the request or made the payment",
+	"addressmetafields-expires": "The time a receive entry expires in seconds since 1 Jan 1970 GMT, or 0 for it to not expire",
+	// SetInvoiceIssuanceCmd help.
+	"setinvoiceissuance--synopsis": "Switches the sequential issuance of the external addresses of an account on or off.\n" +
+		"While it is on, the addresses of the account are only handed out by reserveinvoiceaddress, and getnewaddress and getaccountaddress fail for it. Switching it off forgets the reservations of the account.",
+	"setinvoiceissuance-account":   "The account to switch the issuance of",
+	"setinvoiceissuance-enable":    "True to issue the addresses of the account sequentially, false to stop",
+	"setinvoiceissuance--result0":  "The boolean 'true'",
+	// SetSpendAuthCmd help.
+	"setspendauth--synopsis": "Sets the PIN or authenticator code the wallet requires to send more than a limit in a transaction, with the method \"pin\", \"totp\" or \"none\".\n" +
+		"A send above the limit without the code fails with error code -40 and one with a wrong code with -41, and codes are throttled after repeated wrong ones.\n" +
+		"Changing a spend limit that is already set requires its current code, and the wallet must be unlocked.",
+	"setspendauth-method": "The code to require, \"pin\" for a PIN, \"totp\" for a time-based authenticator code, or \"none\" to remove the requirement",
+	"setspendauth-limit":  "The most that may be sent in a transaction without the code, valued in DUO",
+	"setspendauth-secret": "The PIN, or the base32 encoded time-based code secret, which is generated if it is empty; an empty secret keeps the current one if the method is not changed",
+	"setspendauth-code":   "The current PIN or authenticator code, needed to change a spend limit that is already set",
+	// SpendAuthResult help.
+	"spendauthresult-method": "The code required to send more than the limit, \"pin\", \"totp\" or \"none\"",
+	"spendauthresult-limit":  "The most that may be sent in a transaction without the code, valued in DUO",
+	"spendauthresult-secret": "The base32 encoded time-based code secret to add to an authenticator, only returned when it is set",
+	"spendauthresult-uri":    "The otpauth URI of the time-based code secret, for showing as a QR code, only returned when it is set",
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
 	"settxfee-amount":    "The new fee increment valued in bitcoin",
@@ -260,11 +811,13 @@ var helpDescsEnUS = map[string]string{
 	"signmessage--result0":  "The signed message encoded as a base64 string",
 	// SignRawTransactionCmd help.
 	"signrawtransaction--synopsis": "Signs transaction inputs using private keys from this wallet and request.\n" +
-		"The valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+		"The valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n" +
+		"Signing with the keys of this wallet a transaction that spends more than the spend limit set by setspendauth, less what it pays back to the wallet, needs the code, failing with error code -40 without it and -41 with a wrong one, as sends do.",
 	"signrawtransaction-rawtx":    "Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string",
 	"signrawtransaction-inputs":   "Additional data regarding inputs that this wallet may not be tracking",
 	"signrawtransaction-privkeys": "Additional WIF-encoded private keys to use when creating signatures",
 	"signrawtransaction-flags":    "Sighash flags",
+	"signrawtransaction-authcode": "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
 	// SignRawTransactionResult help.
 	"signrawtransactionresult-hex":      "The resulting transaction encoded as a hexadecimal string",
 	"signrawtransactionresult-complete": "Whether all input signatures have been created",
@@ -275,6 +828,65 @@ var helpDescsEnUS = map[string]string{
 	"signrawtransactionerror-scriptSig": "The hex-encoded signature script",
 	"signrawtransactionerror-txid":      "The transaction hash of the referenced previous output",
 	"signrawtransactionerror-vout":      "The output index of the referenced previous output",
+	// SubmitSignedPSBTCmd help.
+	"submitsignedpsbt--synopsis": "Adds the signatures of a PSBT returned by an external signer to the transaction it signs in the signing queue of a watching-only wallet, and broadcasts the transaction once it is fully signed.\n" +
+		"The PSBT of a transaction that needs more signatures keeps those submitted, so it can be given to the next signer, and an error is returned.\n" +
+		"A PSBT with a signature that is not a valid signature of the transaction by a key the input pays to is refused.",
+	"submitsignedpsbt-psbt":     "The base64 encoded signed PSBT",
+	"submitsignedpsbt--result0": "The hash of the broadcast transaction",
+	// SweepAccountCmd help.
+	"sweepaccount--synopsis": "Moves the whole spendable balance of an account to an address, with the relay fee taken out of the amount sent.\n" +
+		"Only outputs with at least minconf confirmations are spent, and a reserve can be left in the account as change.",
+	"sweepaccount-account":  "The account to sweep",
+	"sweepaccount-address":  "The address to move the funds to",
+	"sweepaccount-minconf":  "Minimum number of block confirmations of the outputs that are spent",
+	"sweepaccount-reserve":  "The amount in DUO to leave in the account",
+	"sweepaccount-dryrun":   "Only work out the sweep and return it, without sending the transaction",
+	"sweepaccount-authcode": "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	// SweepAccountResult help.
+	"sweepaccountresult-account":     "The swept account",
+	"sweepaccountresult-destination": "The address the funds are moved to",
+	"sweepaccountresult-inputs":      "The number of unspent outputs of the account that are spent",
+	"sweepaccountresult-amount":      "The amount in DUO sent to the destination, after the reserve and fee",
+	"sweepaccountresult-reserve":     "The amount in DUO left in the account",
+	"sweepaccountresult-fee":         "The fee paid out of the swept balance in DUO",
+	"sweepaccountresult-txid":        "The hash of the sweep transaction, unset with dry run",
+	// SweepPrivKeyCmd help.
+	"sweepprivkey--synopsis": "Moves all the funds of a private key that is not in the wallet, such as the key of a paper wallet, to an address of an account of the wallet, less the relay fee.\n" +
+		"The outputs of the key are found with the address index of the chain server, which must be enabled (--addrindex).",
+	"sweepprivkey-privkey": "The private key in WIF format",
+	"sweepprivkey-account": "The account to move the funds to",
+	"sweepprivkey-dryrun":  "Only work out the sweep and return it, without sending the transaction",
+	// SweepPrivKeyResult help.
+	"sweepprivkeyresult-address":     "The address of the swept key",
+	"sweepprivkeyresult-destination": "The wallet address the funds are moved to",
+	"sweepprivkeyresult-outputs":     "The number of unspent outputs of the key that are spent",
+	"sweepprivkeyresult-amount":      "The total value of the outputs in DUO",
+	"sweepprivkeyresult-fee":         "The fee paid out of the amount in DUO",
+	"sweepprivkeyresult-txid":        "The hash of the sweep transaction, unset with dry run",
+	// TransferAccountCmd help.
+	"transferaccount--synopsis": "Moves an amount from one account of the wallet to the current address of another in a transaction, which is replaced by a new one once it has been paid.\n" +
+		"When a fee account is given the fee is paid from its outputs, which get their own change, so the source account goes down by exactly the amount, as for the client sub-accounts of an exchange. Otherwise the source account pays the fee as for any send.",
+	"transferaccount-fromaccount": "The account to take the amount from",
+	"transferaccount-toaccount":   "The account to move the amount to",
+	"transferaccount-amount":      "The amount in DUO to move",
+	"transferaccount-feeaccount":  "The account paying the fee, which must differ from the other two, or empty for the source account to pay it",
+	"transferaccount-minconf":     "Minimum number of block confirmations of the outputs that are spent",
+	"transferaccount-dryrun":      "Only work out the transfer and return it, without sending the transaction",
+	"transferaccount-authcode":    "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	// TransferAccountResult help.
+	"transferaccountresult-fromaccount":  "The account the amount is taken from",
+	"transferaccountresult-toaccount":    "The account the amount is moved to",
+	"transferaccountresult-feeaccount":   "The account paying the fee",
+	"transferaccountresult-address":      "The current address of the destination account the amount is paid to",
+	"transferaccountresult-amount":       "The amount in DUO moved",
+	"transferaccountresult-fee":          "The fee in DUO paid by the fee account",
+	"transferaccountresult-sourcechange": "The change in DUO returned to the source account",
+	"transferaccountresult-feechange":    "The change in DUO returned to the fee account, when it is not the source account",
+	"transferaccountresult-txid":         "The hash of the transfer transaction, unset with dry run",
+	// UnarchiveAccountCmd help.
+	"unarchiveaccount--synopsis": "Restores an account archived with archiveaccount to listaccounts and the total balance of the wallet.",
+	"unarchiveaccount-account":   "The name of the account to unarchive",
 	// ValidateAddressCmd help.
 	"validateaddress--synopsis": "Verify that an address is valid.\n" +
 		"Extra details are returned if the address is controlled by this wallet.\n" +
@@ -302,6 +914,22 @@ var helpDescsEnUS = map[string]string{
 	"verifymessage-signature": "The signature to verify",
 	"verifymessage-message":   "The message to verify",
 	"verifymessage--result0":  "Whether the message was signed with the private key of 'address'",
+	// WalletDBStatsCmd help.
+	"walletdbstats--synopsis": "Returns the key counts and space used by each bucket of the wallet database, to see what the wallet file grows with.\n" +
+		"Nested buckets follow the bucket they are in, and their paths are the keys of the buckets separated by slashes.",
+	"walletdbstats-largest":  "Number of largest key/value pairs of each bucket to return, at most 100",
+	"walletdbstats--result0": "The buckets of the wallet database",
+	// WalletDBBucketResult help.
+	"walletdbbucketresult-path":        "The keys of the bucket and the buckets it is in, separated by slashes, in hex if they are not printable",
+	"walletdbbucketresult-keys":        "The number of key/value pairs in the bucket, not counting nested buckets",
+	"walletdbbucketresult-buckets":     "The number of buckets nested in the bucket",
+	"walletdbbucketresult-keybytes":    "The size of the keys in the bucket, including the keys of nested buckets",
+	"walletdbbucketresult-valuebytes":  "The size of the values in the bucket",
+	"walletdbbucketresult-totalbytes":  "The size of the keys and values in the bucket and the buckets nested in it",
+	"walletdbbucketresult-largestkeys": "The largest key/value pairs in the bucket, largest first",
+	// WalletDBKeyResult help.
+	"walletdbkeyresult-key":   "The key, in hex if it is not printable",
+	"walletdbkeyresult-bytes": "The size of the key and its value",
 	// WalletLockCmd help.
 	"walletlock--synopsis": "Lock the wallet.",
 	// WalletPassphraseCmd help.
@@ -312,6 +940,35 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange--synopsis":     "Change the wallet passphrase.",
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
+	// WalletSelfTestCmd help.
+	"walletselftest--synopsis": "Runs consistency checks of the wallet and returns what they found, for diagnosing problems before attempting repairs.\n" +
+		"The checks are that the balance is the sum of the unspent credits, that the addresses are those derived at their indexes, that the private keys decrypt to the keys of their addresses, which needs the wallet to be unlocked, and that the block the wallet is synced to is in the chain.",
+	// WalletSelfTestResult help.
+	"walletselftestresult-passed": "Whether none of the checks failed",
+	"walletselftestresult-checks": "The outcome of each check",
+	// WalletSelfTestCheckResult help.
+	"walletselftestcheckresult-name":    "The name of the check",
+	"walletselftestcheckresult-status":  "Whether the check passed, failed or was skipped",
+	"walletselftestcheckresult-checked": "The number of items the check went through",
+	"walletselftestcheckresult-details": "The problems found, or why the check was skipped",
+	// SweepTimeLockedCmd help.
+	"sweeptimelocked--synopsis": "Sends the unlocked outputs paid to the time locked scripts imported with importtimelockscript and to vault deposit addresses to an address, less the fee. " +
+		"Outputs under relative locks are only spent once the chain server relays transactions spending them. The wallet must be unlocked.",
+	"sweeptimelocked-address":  "The address to send the funds to",
+	"sweeptimelocked-dryrun":   "Only work out the sweep and return it, without sending the transaction",
+	"sweeptimelocked-authcode": "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	// SweepTimeLockedResult help.
+	"sweeptimelockedresult-destination": "The address the funds are sent to",
+	"sweeptimelockedresult-inputs":      "The number of unlocked time locked outputs that are spent",
+	"sweeptimelockedresult-amount":      "The amount in DUO sent to the destination, after the fee",
+	"sweeptimelockedresult-fee":         "The fee paid out of the unlocked outputs in DUO",
+	"sweeptimelockedresult-txid":        "The hash of the sweep transaction, unset with dry run",
+	// WithdrawVaultCmd help.
+	"withdrawvault--synopsis": "Sends the outputs paid to the unlocked deposit addresses of a vault account to an address, less the fee. The wallet must be unlocked.",
+	"withdrawvault-name":      "The name of the vault account",
+	"withdrawvault-address":   "The address to send the funds to",
+	"withdrawvault-authcode":  "The PIN or authenticator code required by setspendauth to send more than the spend limit, ignored for smaller amounts",
+	"withdrawvault--result0":  "The transaction ID of the withdrawal",
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
@@ -337,6 +994,12 @@ var helpDescsEnUS = map[string]string{
 	// ListAllTransactionsCmd help.
 	"listalltransactions--synopsis": "Returns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.",
 	"listalltransactions-account":   "Unused (must be unset or \"*\")",
+	// NotifyWalletEventsCmd help.
+	"notifywalletevents--synopsis": "Sends the events of the notification journal of the wallet, which records blocks connected and disconnected and relevant transactions in the order they change the wallet, as walletevent notifications.\n" +
+		"The events after the given sequence number or block are sent first, so a client that reconnects is sent the events it missed, and may be sent some again, in order.\n" +
+		"It is an error if some of those events are no longer in the journal.",
+	"notifywalletevents-sincesequence": "The sequence number of the last event the client received",
+	"notifywalletevents-sinceblock":    "The hash of the block after whose connection events are sent, instead of a sequence number (default=only new events)",
 	// RenameAccountCmd help.
 	"renameaccount--synopsis":  "Renames an account.",
 	"renameaccount-oldaccount": "The old account name to rename",
@@ -21,48 +21,109 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"addportfolioentry", nil},
+	{"archiveaccount", nil},
+	{"backupremote", []interface{}{(*[]btcjson.BackupTargetResult)(nil)}},
+	{"cancelqueuedpsbt", nil},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
+	{"createmultisigaccount", []interface{}{(*btcjson.MultiSigAccountResult)(nil)}},
+	{"createvaultaccount", []interface{}{(*btcjson.VaultAccountResult)(nil)}},
+	{"deleteaddressmeta", nil},
 	{"dumpprivkey", returnsString},
+	{"exportaccountxprv", []interface{}{(*btcjson.ExportAccountXprvResult)(nil)}},
+	{"exportledger", returnsString},
+	{"exportpaymentbundle", []interface{}{(*btcjson.ExportPaymentBundleResult)(nil)}},
+	{"exportwatchset", []interface{}{(*btcjson.WatchSetResult)(nil)}},
+	{"freezeunspent", returnsBool},
+	{"generatepaperkey", []interface{}{(*[]btcjson.PaperKeyResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
+	{"getaccountxpub", returnsString},
+	{"getaddressinfo", []interface{}{(*btcjson.GetAddressInfoResult)(nil)}},
+	{"getaddressmeta", []interface{}{(*btcjson.AddressMetaResult)(nil)}},
 	{"getaddressesbyaccount", returnsStringArray},
+	{"getauditlog", []interface{}{(*btcjson.GetAuditLogResult)(nil)}},
 	{"getbalance", append(returnsNumber, returnsNumber[0])},
+	{"getbalanceat", []interface{}{(*btcjson.GetBalanceAtResult)(nil)}},
 	{"getbestblockhash", returnsString},
 	{"getblockcount", returnsNumber},
 	{"getinfo", []interface{}{(*btcjson.InfoWalletResult)(nil)}},
 	{"getnewaddress", returnsString},
+	{"getnewmultisigaddress", []interface{}{(*btcjson.MultiSigAddressResult)(nil)}},
+	{"getnewvaultaddress", []interface{}{(*btcjson.VaultAddressResult)(nil)}},
 	{"getrawchangeaddress", returnsString},
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
+	{"getpaymentbundle", []interface{}{(*btcjson.PaymentBundleResult)(nil)}},
+	{"getrescaninfo", []interface{}{(*btcjson.GetRescanInfoResult)(nil)}},
+	{"getscrubinfo", []interface{}{(*btcjson.GetScrubInfoResult)(nil)}},
+	{"getspendauth", []interface{}{(*btcjson.SpendAuthResult)(nil)}},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"getutxoreport", []interface{}{(*btcjson.GetUTXOReportResult)(nil)}},
+	{"getvaultschedule", []interface{}{(*btcjson.VaultScheduleResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
+	{"importcorewallet", []interface{}{(*btcjson.ImportCoreWalletResult)(nil)}},
 	{"importprivkey", nil},
+	{"importscriptpubkey", nil},
+	{"importtimelockscript", []interface{}{(*btcjson.TimeLockScriptResult)(nil)}},
 	{"keypoolrefill", nil},
 	{"listaccounts", []interface{}{(*map[string]float64)(nil)}},
+	{"listaddressmeta", []interface{}{(*[]btcjson.AddressMetaResult)(nil)}},
+	{"listimmature", []interface{}{(*btcjson.ListImmatureResult)(nil)}},
+	{"listdustoutputs", []interface{}{(*[]btcjson.DustOutputResult)(nil)}},
+	{"listfrozen", []interface{}{(*[]btcjson.FrozenOutputResult)(nil)}},
+	{"listinvoices", []interface{}{(*[]btcjson.InvoiceResult)(nil)}},
+	{"listinvoicereservations", []interface{}{(*[]btcjson.InvoiceReservationResult)(nil)}},
 	{"listlockunspent", []interface{}{(*[]btcjson.TransactionInput)(nil)}},
+	{"listmultisigaccounts", []interface{}{(*[]btcjson.MultiSigAccountResult)(nil)}},
+	{"listpaymentbundles", []interface{}{(*[]btcjson.PaymentBundleResult)(nil)}},
+	{"listportfolio", []interface{}{(*btcjson.ListPortfolioResult)(nil)}},
+	{"listportfoliotransactions", []interface{}{(*[]btcjson.PortfolioTransactionResult)(nil)}},
+	{"listqueuedpsbts", []interface{}{(*[]btcjson.QueuedPSBTResult)(nil)}},
 	{"listreceivedbyaccount", []interface{}{(*[]btcjson.ListReceivedByAccountResult)(nil)}},
 	{"listreceivedbyaddress", []interface{}{(*[]btcjson.ListReceivedByAddressResult)(nil)}},
 	{"listsinceblock", []interface{}{(*btcjson.ListSinceBlockResult)(nil)}},
 	{"listtransactions", returnsLTRArray},
+	{"listtransactionspage", []interface{}{(*btcjson.ListTransactionsPageResult)(nil)}},
+	{"listunlockattempts", []interface{}{(*[]btcjson.UnlockAttemptResult)(nil)}},
 	{"listunspent", []interface{}{(*btcjson.ListUnspentResult)(nil)}},
+	{"listvaultaccounts", []interface{}{(*[]btcjson.VaultAccountResult)(nil)}},
 	{"lockunspent", returnsBool},
+	{"overridedust", returnsBool},
+	{"previewsend", []interface{}{(*btcjson.PreviewSendResult)(nil)}},
+	{"releaseinvoiceaddress", returnsBool},
+	{"removeportfolioentry", nil},
+	{"reserveinvoiceaddress", []interface{}{(*btcjson.InvoiceReservationResult)(nil)}},
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
 	{"sendtoaddress", returnsString},
+	{"setaddressmeta", []interface{}{(*btcjson.AddressMetaResult)(nil)}},
+	{"setinvoiceissuance", returnsBool},
+	{"setspendauth", []interface{}{(*btcjson.SpendAuthResult)(nil)}},
 	{"settxfee", returnsBool},
 	{"signmessage", returnsString},
 	{"signrawtransaction", []interface{}{(*btcjson.SignRawTransactionResult)(nil)}},
+	{"submitsignedpsbt", returnsString},
+	{"sweepaccount", []interface{}{(*btcjson.SweepAccountResult)(nil)}},
+	{"sweepprivkey", []interface{}{(*btcjson.SweepPrivKeyResult)(nil)}},
+	{"sweeptimelocked", []interface{}{(*btcjson.SweepTimeLockedResult)(nil)}},
+	{"transferaccount", []interface{}{(*btcjson.TransferAccountResult)(nil)}},
+	{"unarchiveaccount", nil},
 	{"validateaddress", []interface{}{(*btcjson.ValidateAddressWalletResult)(nil)}},
 	{"verifymessage", returnsBool},
+	{"walletdbstats", []interface{}{(*[]btcjson.WalletDBBucketResult)(nil)}},
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"walletselftest", []interface{}{(*btcjson.WalletSelfTestResult)(nil)}},
+	{"withdrawvault", returnsString},
 	{"createnewaccount", nil},
 	{"exportwatchingwallet", returnsString},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
 	{"getunconfirmedbalance", returnsNumber},
 	{"listaddresstransactions", returnsLTRArray},
 	{"listalltransactions", returnsLTRArray},
+	{"notifywalletevents", nil},
 	{"renameaccount", nil},
 	{"walletislocked", returnsBool},
 }
@@ -0,0 +1,36 @@
+// +build !windows
+
+package secretstore
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+// runTool runs a command line tool of the secure storage with the input given on its standard input, so that secrets
+// are never passed as arguments where other users can see them, and returns its standard output. The exit code is
+// returned as well, or -1 if the tool could not be run.
+func runTool(input []byte, name string, args ...string) (out []byte, code int, e error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if e = cmd.Run(); e != nil {
+		code = -1
+		if ee, ok := e.(*exec.ExitError); ok {
+			code = ee.ExitCode()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			D.Ln(name, "failed:", msg)
+		}
+		return nil, code, e
+	}
+	return stdout.Bytes(), 0, nil
+}
+
+// hasTool returns whether the command line tool is installed.
+func hasTool(name string) bool {
+	_, e := exec.LookPath(name)
+	return e == nil
+}
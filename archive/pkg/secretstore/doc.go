@@ -0,0 +1,11 @@
+/*
+Package secretstore keeps small secrets, such as the key that unlocks a wallet, in the secure storage of the operating
+system, so they can be used after the user authenticates to the system rather than by typing a passphrase.
+
+Storage is reached through the Store interface. The storage of the system the program was built for, the Keychain on
+macOS, the Secret Service of libsecret on Linux and the BSDs and the Data Protection API on Windows, is registered when
+the package is loaded, and other storage, such as a hardware token, can be added with Register. Whether the system asks
+the user to authenticate, by their login password or a fingerprint, before giving out a secret depends on how the
+storage is set up, so the wallet always keeps its passphrase as a fallback.
+*/
+package secretstore
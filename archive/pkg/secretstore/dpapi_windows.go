@@ -0,0 +1,124 @@
+package secretstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+// cryptProtectUIForbidden stops the Data Protection API from showing a prompt, which the wallet can't answer.
+const cryptProtectUIForbidden = 0x1
+
+var (
+	crypt32           = syscall.NewLazyDLL("crypt32.dll")
+	kernel32          = syscall.NewLazyDLL("kernel32.dll")
+	procProtectData   = crypt32.NewProc("CryptProtectData")
+	procUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree     = kernel32.NewProc("LocalFree")
+)
+
+func init() {
+	registerPlatform(
+		"dpapi", func(dataDir string) Store {
+			return dpapi{dir: filepath.Join(dataDir, "secrets")}
+		},
+	)
+}
+
+// dpapi keeps secrets in files in the data directory, each encrypted by the Data Protection API with a key of the
+// Windows account of the user, so only programs running as that user can decrypt them.
+type dpapi struct {
+	dir string
+}
+
+// Name returns the name of the storage.
+func (dpapi) Name() string {
+	return "Windows Data Protection"
+}
+
+// Available returns whether the Data Protection API can be loaded.
+func (dpapi) Available() bool {
+	return procProtectData.Find() == nil && procUnprotectData.Find() == nil
+}
+
+// path returns the file the secret for the service and account is kept in, named by a hash so the names of the
+// service and account need not be valid in file names.
+func (d dpapi) path(service, account string) string {
+	h := sha256.Sum256([]byte(service + "\x00" + account))
+	return filepath.Join(d.dir, hex.EncodeToString(h[:16])+".dpapi")
+}
+
+// Has returns whether a secret is stored for the service and account.
+func (d dpapi) Has(service, account string) (bool, error) {
+	_, e := os.Stat(d.path(service, account))
+	if os.IsNotExist(e) {
+		return false, nil
+	}
+	return e == nil, e
+}
+
+// Get returns the secret stored for the service and account.
+func (d dpapi) Get(service, account string) (secret []byte, e error) {
+	var blob []byte
+	if blob, e = ioutil.ReadFile(d.path(service, account)); e != nil {
+		if os.IsNotExist(e) {
+			return nil, ErrNotFound
+		}
+		return
+	}
+	return cryptData(procUnprotectData, blob)
+}
+
+// Set stores the secret for the service and account.
+func (d dpapi) Set(service, account string, secret []byte) (e error) {
+	var blob []byte
+	if blob, e = cryptData(procProtectData, secret); e != nil {
+		return
+	}
+	if e = os.MkdirAll(d.dir, 0700); e != nil {
+		return
+	}
+	return ioutil.WriteFile(d.path(service, account), blob, 0600)
+}
+
+// Delete removes the secret stored for the service and account.
+func (d dpapi) Delete(service, account string) (e error) {
+	if e = os.Remove(d.path(service, account)); os.IsNotExist(e) {
+		return nil
+	}
+	return
+}
+
+// dataBlob is the DATA_BLOB structure the Data Protection API takes and returns data in.
+type dataBlob struct {
+	cbData uint32
+	pbData *byte
+}
+
+// cryptData encrypts or decrypts the data with CryptProtectData or CryptUnprotectData, which take the same arguments
+// for the uses here.
+func cryptData(proc *syscall.LazyProc, in []byte) (out []byte, e error) {
+	var inBlob, outBlob dataBlob
+	if len(in) > 0 {
+		inBlob = dataBlob{cbData: uint32(len(in)), pbData: &in[0]}
+	}
+	r, _, ee := proc.Call(
+		uintptr(unsafe.Pointer(&inBlob)), 0, 0, 0, 0, cryptProtectUIForbidden, uintptr(unsafe.Pointer(&outBlob)),
+	)
+	if r == 0 {
+		return nil, ee
+	}
+	defer func() {
+		_, _, _ = procLocalFree.Call(uintptr(unsafe.Pointer(outBlob.pbData)))
+	}()
+	buf := (*[1 << 30]byte)(unsafe.Pointer(outBlob.pbData))[:outBlob.cbData:outBlob.cbData]
+	out = append([]byte(nil), buf...)
+	zero.Bytes(buf)
+	return
+}
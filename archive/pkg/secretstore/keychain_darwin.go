@@ -0,0 +1,74 @@
+package secretstore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keychainTool is the command line tool of the Keychain.
+const keychainTool = "/usr/bin/security"
+
+// keychainNotFound is the exit code of the Keychain tool when no item matches.
+const keychainNotFound = 44
+
+func init() {
+	registerPlatform("keychain", func(string) Store { return keychain{} })
+}
+
+// keychain keeps secrets as generic passwords in the login Keychain of the user. Secrets are hex encoded as the
+// password of the item, so they can be read back as text.
+type keychain struct{}
+
+// Name returns the name of the storage.
+func (keychain) Name() string {
+	return "macOS Keychain"
+}
+
+// Available returns whether the Keychain tool is installed.
+func (keychain) Available() bool {
+	return hasTool(keychainTool)
+}
+
+// Has returns whether a secret is stored for the service and account. Only the attributes of the item are read, which
+// does not ask the user to authenticate.
+func (keychain) Has(service, account string) (bool, error) {
+	_, code, e := runTool(nil, keychainTool, "find-generic-password", "-s", service, "-a", account)
+	if code == keychainNotFound {
+		return false, nil
+	}
+	return e == nil, e
+}
+
+// Get returns the secret stored for the service and account. The Keychain may ask the user to allow access to it.
+func (keychain) Get(service, account string) ([]byte, error) {
+	out, code, e := runTool(nil, keychainTool, "find-generic-password", "-s", service, "-a", account, "-w")
+	if code == keychainNotFound {
+		return nil, ErrNotFound
+	}
+	if e != nil {
+		return nil, e
+	}
+	return hex.DecodeString(strings.TrimSpace(string(out)))
+}
+
+// Set stores the secret for the service and account. The command is given to the interactive mode of the tool on its
+// standard input so the secret does not appear in its arguments.
+func (keychain) Set(service, account string, secret []byte) (e error) {
+	cmd := fmt.Sprintf(
+		"add-generic-password -U -s %s -a %s -w %s\n",
+		strconv.Quote(service), strconv.Quote(account), hex.EncodeToString(secret),
+	)
+	_, _, e = runTool([]byte(cmd), keychainTool, "-i")
+	return
+}
+
+// Delete removes the secret stored for the service and account.
+func (keychain) Delete(service, account string) error {
+	_, code, e := runTool(nil, keychainTool, "delete-generic-password", "-s", service, "-a", account)
+	if code == keychainNotFound {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,66 @@
+// +build linux freebsd openbsd netbsd dragonfly
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/hex"
+)
+
+// secretTool is the command line tool of libsecret.
+const secretTool = "secret-tool"
+
+func init() {
+	registerPlatform("libsecret", func(string) Store { return libsecret{} })
+}
+
+// libsecret keeps secrets in the Secret Service of the desktop, such as the GNOME Keyring or KWallet, through the tool
+// of libsecret. Secrets are hex encoded so they can be read back as text.
+type libsecret struct{}
+
+// Name returns the name of the storage.
+func (libsecret) Name() string {
+	return "Secret Service"
+}
+
+// Available returns whether the libsecret tool is installed.
+func (libsecret) Available() bool {
+	return hasTool(secretTool)
+}
+
+// Has returns whether a secret is stored for the service and account. The tool prints nothing when no item matches.
+func (libsecret) Has(service, account string) (bool, error) {
+	out, _, e := runTool(nil, secretTool, "search", "service", service, "account", account)
+	if e != nil {
+		return false, e
+	}
+	return len(bytes.TrimSpace(out)) > 0, nil
+}
+
+// Get returns the secret stored for the service and account. The Secret Service may ask the user to unlock the keyring
+// holding it. The tool exits with an error and prints nothing when no item matches.
+func (libsecret) Get(service, account string) ([]byte, error) {
+	out, code, e := runTool(nil, secretTool, "lookup", "service", service, "account", account)
+	if code == 1 && len(out) == 0 {
+		return nil, ErrNotFound
+	}
+	if e != nil {
+		return nil, e
+	}
+	return hex.DecodeString(string(bytes.TrimSpace(out)))
+}
+
+// Set stores the secret for the service and account. The tool reads the secret from its standard input.
+func (libsecret) Set(service, account string, secret []byte) (e error) {
+	_, _, e = runTool(
+		[]byte(hex.EncodeToString(secret)), secretTool, "store", "--label="+service,
+		"service", service, "account", account,
+	)
+	return
+}
+
+// Delete removes the secret stored for the service and account.
+func (libsecret) Delete(service, account string) (e error) {
+	_, _, e = runTool(nil, secretTool, "clear", "service", service, "account", account)
+	return
+}
@@ -0,0 +1,154 @@
+package secretstore
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+var (
+	// ErrNotFound is returned when no secret is stored for a service and account.
+	ErrNotFound = errors.New("secret not found")
+	// ErrUnavailable is returned when the storage can't be used on this system.
+	ErrUnavailable = errors.New("secure storage is not available")
+)
+
+// Store is a secure storage of secrets, each kept for a service and an account of it.
+type Store interface {
+	// Name returns the name of the storage, as shown to the user.
+	Name() string
+	// Available returns whether the storage can be used on this system.
+	Available() bool
+	// Has returns whether a secret is stored for the service and account, without asking the user to authenticate.
+	Has(service, account string) (bool, error)
+	// Get returns the secret stored for the service and account, or ErrNotFound.
+	Get(service, account string) ([]byte, error)
+	// Set stores the secret for the service and account, replacing any stored before.
+	Set(service, account string, secret []byte) error
+	// Delete removes the secret stored for the service and account. It is not an error if there is none.
+	Delete(service, account string) error
+}
+
+// Opener returns the storage registered under a name. Storage that keeps its secrets in files keeps them in dataDir.
+type Opener func(dataDir string) Store
+
+var (
+	mx       sync.Mutex
+	openers  = make(map[string]Opener)
+	platform string
+)
+
+// Register makes storage available to Open under the name. Registering a name twice replaces the first.
+func Register(name string, open Opener) {
+	mx.Lock()
+	defer mx.Unlock()
+	openers[name] = open
+}
+
+// registerPlatform registers the storage of the operating system and makes it the one Open returns by default.
+func registerPlatform(name string, open Opener) {
+	Register(name, open)
+	mx.Lock()
+	platform = name
+	mx.Unlock()
+}
+
+// Names returns the names of the registered storage in order.
+func Names() (names []string) {
+	mx.Lock()
+	defer mx.Unlock()
+	for name := range openers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// Open returns the storage registered under the name, or the storage of the operating system if the name is empty. An
+// error is returned if there is no such storage, or if it can't be used on this system.
+func Open(name, dataDir string) (s Store, e error) {
+	mx.Lock()
+	if name == "" {
+		name = platform
+	}
+	open, ok := openers[name]
+	mx.Unlock()
+	if !ok {
+		if name == "" {
+			return nil, ErrUnavailable
+		}
+		return nil, fmt.Errorf("unknown secure storage %q, must be one of %v", name, Names())
+	}
+	if s = open(dataDir); !s.Available() {
+		return nil, fmt.Errorf("%s: %v", s.Name(), ErrUnavailable)
+	}
+	return
+}
+
+func init() {
+	Register("memory", func(string) Store { return NewMemory() })
+}
+
+// Memory keeps secrets in memory, for tests and for systems without secure storage where they need only last as long
+// as the program runs.
+type Memory struct {
+	mx      sync.Mutex
+	secrets map[[2]string][]byte
+}
+
+// NewMemory returns an empty memory store.
+func NewMemory() *Memory {
+	return &Memory{secrets: make(map[[2]string][]byte)}
+}
+
+// Name returns the name of the storage.
+func (m *Memory) Name() string {
+	return "memory"
+}
+
+// Available returns true as memory can always be used.
+func (m *Memory) Available() bool {
+	return true
+}
+
+// Has returns whether a secret is stored for the service and account.
+func (m *Memory) Has(service, account string) (bool, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	_, ok := m.secrets[[2]string{service, account}]
+	return ok, nil
+}
+
+// Get returns a copy of the secret stored for the service and account.
+func (m *Memory) Get(service, account string) ([]byte, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	secret, ok := m.secrets[[2]string{service, account}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), secret...), nil
+}
+
+// Set stores a copy of the secret for the service and account.
+func (m *Memory) Set(service, account string, secret []byte) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	m.secrets[[2]string{service, account}] = append([]byte(nil), secret...)
+	return nil
+}
+
+// Delete zeroes and removes the secret stored for the service and account.
+func (m *Memory) Delete(service, account string) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	key := [2]string{service, account}
+	if secret, ok := m.secrets[key]; ok {
+		zero.Bytes(secret)
+		delete(m.secrets, key)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package secretstore
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMemory ensures the memory store keeps secrets apart by service and account and that registered storage can be
+// opened by name.
+func TestMemory(t *testing.T) {
+	s, e := Open("memory", "")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, e = Open("nosuchstore", ""); e == nil {
+		t.Fatal("expected an error opening unknown storage")
+	}
+	if _, e = s.Get("wallet", "mainnet"); e != ErrNotFound {
+		t.Fatalf("got error %v getting a missing secret, want %v", e, ErrNotFound)
+	}
+	secret := []byte("unlock key")
+	if e = s.Set("wallet", "mainnet", secret); e != nil {
+		t.Fatal(e)
+	}
+	secret[0] = 'X'
+	var got []byte
+	if got, e = s.Get("wallet", "mainnet"); e != nil || !bytes.Equal(got, []byte("unlock key")) {
+		t.Fatalf("got secret %q, %v, want %q", got, e, "unlock key")
+	}
+	if has, _ := s.Has("wallet", "testnet"); has {
+		t.Fatal("secret found for another account")
+	}
+	if e = s.Delete("wallet", "mainnet"); e != nil {
+		t.Fatal(e)
+	}
+	if has, _ := s.Has("wallet", "mainnet"); has {
+		t.Fatal("secret found after it was deleted")
+	}
+	if e = s.Delete("wallet", "mainnet"); e != nil {
+		t.Fatalf("deleting a missing secret failed: %v", e)
+	}
+}
@@ -3,6 +3,7 @@ package txauthor
 
 import (
 	"errors"
+	"fmt"
 	"github.com/p9c/pod/pkg/amt"
 	"github.com/p9c/pod/pkg/chaincfg"
 	
@@ -82,10 +83,35 @@ func (insufficientFundsError) Error() string {
 func NewUnsignedTransaction(
 	outputs []*wire.TxOut, relayFeePerKb amt.Amount,
 	fetchInputs InputSource, fetchChange ChangeSource,
+) (*AuthoredTx, error) {
+	feeForSize := func(size int) amt.Amount {
+		return txrules.FeeForSerializeSize(relayFeePerKb, size)
+	}
+	return newUnsignedTransaction(outputs, relayFeePerKb, feeForSize, fetchInputs, fetchChange)
+}
+
+// NewUnsignedTransactionFee creates an unsigned transaction paying to one or more non-change outputs like
+// NewUnsignedTransaction, but paying exactly fee whatever the size of the transaction. The relay fee is only used to
+// decide whether the change is too small to be worth an output.
+func NewUnsignedTransactionFee(
+	outputs []*wire.TxOut, fee, relayFeePerKb amt.Amount,
+	fetchInputs InputSource, fetchChange ChangeSource,
+) (*AuthoredTx, error) {
+	feeForSize := func(int) amt.Amount {
+		return fee
+	}
+	return newUnsignedTransaction(outputs, relayFeePerKb, feeForSize, fetchInputs, fetchChange)
+}
+
+// newUnsignedTransaction creates an unsigned transaction paying to the outputs and the fee feeForSize returns for the
+// worst case size of the transaction once it is signed.
+func newUnsignedTransaction(
+	outputs []*wire.TxOut, relayFeePerKb amt.Amount, feeForSize func(size int) amt.Amount,
+	fetchInputs InputSource, fetchChange ChangeSource,
 ) (*AuthoredTx, error) {
 	targetAmount := h.SumOutputValues(outputs)
 	estimatedSize := txsizes.EstimateVirtualSize(1, 0, 0, outputs, true)
-	targetFee := txrules.FeeForSerializeSize(relayFeePerKb, estimatedSize)
+	targetFee := feeForSize(estimatedSize)
 	for {
 		inputAmount, inputs, inputValues, scripts, e := fetchInputs(targetAmount + targetFee)
 		if e != nil {
@@ -111,7 +137,7 @@ func NewUnsignedTransaction(
 			p2pkh, p2wpkh,
 			nested, outputs, true,
 		)
-		maxRequiredFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize)
+		maxRequiredFee := feeForSize(maxSignedSize)
 		remainingAmount := inputAmount - targetAmount
 		if remainingAmount < maxRequiredFee {
 			targetFee = maxRequiredFee
@@ -154,18 +180,44 @@ func NewUnsignedTransaction(
 	}
 }
 
-// RandomizeOutputPosition randomizes the position of a transaction's output by swapping it with a random output. The
-// new index is returned. This should be done before signing.
-func RandomizeOutputPosition(outputs []*wire.TxOut, index int) int {
-	r := cprng.Int31n(int32(len(outputs)))
-	outputs[r], outputs[index] = outputs[index], outputs[r]
-	return int(r)
-}
+// MaxStandardMultiSigKeys is the most public keys a bare multisig output script can have and still be relayed by nodes
+// with the default mempool policy.
+const MaxStandardMultiSigKeys = 3
 
-// RandomizeChangePosition randomizes the position of an authored transaction's change output. This should be done
-// before signing.
-func (tx *AuthoredTx) RandomizeChangePosition() {
-	tx.ChangeIndex = RandomizeOutputPosition(tx.Tx.TxOut, tx.ChangeIndex)
+// NewScriptOutput returns an output paying amount to a raw output script, so outputs that have no address form, such
+// as bare multisig, can be authored. The script must be one of the standard forms relayed by nodes with the default
+// mempool policy, and data carrier outputs can not carry an amount, as it would be burned.
+func NewScriptOutput(pkScript []byte, amount, relayFeePerKb amt.Amount) (txOut *wire.TxOut, e error) {
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.NonStandardTy:
+		return nil, errors.New("output script is not a standard script form")
+	case txscript.MultiSigTy:
+		var numPubKeys, numSigs int
+		if numPubKeys, numSigs, e = txscript.CalcMultiSigStats(pkScript); e != nil {
+			return nil, fmt.Errorf("cannot parse multisig output script: %v", e)
+		}
+		if numPubKeys < 1 || numPubKeys > MaxStandardMultiSigKeys {
+			return nil, fmt.Errorf(
+				"multisig output script has %d public keys, it must have from 1 to %d",
+				numPubKeys, MaxStandardMultiSigKeys,
+			)
+		}
+		if numSigs < 1 || numSigs > numPubKeys {
+			return nil, fmt.Errorf(
+				"multisig output script requires %d signatures, it must require from 1 to %d",
+				numSigs, numPubKeys,
+			)
+		}
+	case txscript.NullDataTy:
+		if amount != 0 {
+			return nil, errors.New("data carrier output scripts can not be paid an amount")
+		}
+	}
+	txOut = wire.NewTxOut(int64(amount), pkScript)
+	if e = txrules.CheckOutput(txOut, relayFeePerKb); e != nil {
+		return nil, e
+	}
+	return
 }
 
 // AddAllInputScripts modifies transaction a transaction by adding inputs
@@ -6,6 +6,7 @@ import (
 	"testing"
 	
 	"github.com/p9c/pod/pkg/txrules"
+	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/txsizes"
 	"github.com/p9c/pod/pkg/wire"
 )
@@ -223,3 +224,96 @@ func TestNewUnsignedTransaction(t *testing.T) {
 		}
 	}
 }
+
+// multiSigScript returns a bare multisig output script requiring nRequired signatures from nKeys dummy public keys.
+func multiSigScript(t *testing.T, nRequired, nKeys int) []byte {
+	builder := txscript.NewScriptBuilder().AddInt64(int64(nRequired))
+	for i := 0; i < nKeys; i++ {
+		pubKey := make([]byte, 33)
+		pubKey[0] = 0x02
+		pubKey[1] = byte(i)
+		builder.AddData(pubKey)
+	}
+	script, e := builder.AddInt64(int64(nKeys)).AddOp(txscript.OP_CHECKMULTISIG).Script()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return script
+}
+func TestNewScriptOutput(t *testing.T) {
+	nullData, e := txscript.NullDataScript([]byte("pod"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	tests := []struct {
+		name   string
+		script []byte
+		amount amt.Amount
+		valid  bool
+	}{
+		{"1 of 2 multisig", multiSigScript(t, 1, 2), 1e6, true},
+		{"3 of 3 multisig", multiSigScript(t, 3, 3), 1e6, true},
+		{"1 of 4 multisig", multiSigScript(t, 1, 4), 1e6, false},
+		{"multisig dust", multiSigScript(t, 1, 2), 1, false},
+		{"null data", nullData, 0, true},
+		{"null data with amount", nullData, 1e6, false},
+		{"non-standard", []byte{txscript.OP_TRUE}, 1e6, false},
+	}
+	for _, test := range tests {
+		txOut, e := NewScriptOutput(test.script, test.amount, txrules.DefaultRelayFeePerKb)
+		if test.valid != (e == nil) {
+			t.Errorf("%s: unexpected error state %v", test.name, e)
+			continue
+		}
+		if e == nil && txOut.Value != int64(test.amount) {
+			t.Errorf("%s: output pays %d, expected %d", test.name, txOut.Value, test.amount)
+		}
+	}
+}
+
+// TestNewUnsignedTransactionFee ensures a transaction with a fixed fee pays exactly that fee whatever its size, taking
+// more inputs when the first does not cover it.
+func TestNewUnsignedTransactionFee(t *testing.T) {
+	changeSource := func() ([]byte, error) {
+		return make([]byte, txsizes.P2PKHPkScriptSize), nil
+	}
+	tests := []struct {
+		unspent []*wire.TxOut
+		fee     amt.Amount
+		change  amt.Amount
+		inputs  int
+	}{
+		{p2pkhOutputs(1e8), 1e5, 1e8 - 1e6 - 1e5, 1},
+		{p2pkhOutputs(1e8), 1, 1e8 - 1e6 - 1, 1},
+		{p2pkhOutputs(1e6+5e4, 1e8), 1e5, 1e6 + 5e4 + 1e8 - 1e6 - 1e5, 2},
+		{p2pkhOutputs(1e6 + 1e5), 1e5, 0, 1},
+	}
+	for i, test := range tests {
+		tx, e := NewUnsignedTransactionFee(
+			p2pkhOutputs(1e6), test.fee, txrules.DefaultRelayFeePerKb, makeInputSource(test.unspent), changeSource,
+		)
+		if e != nil {
+			t.Errorf("test %d: %v", i, e)
+			continue
+		}
+		fee := tx.TotalInput
+		for _, txOut := range tx.Tx.TxOut {
+			fee -= amt.Amount(txOut.Value)
+		}
+		if fee != test.fee || len(tx.Tx.TxIn) != test.inputs {
+			t.Errorf("test %d: got fee %v with %d inputs, want %v with %d", i, fee, len(tx.Tx.TxIn), test.fee, test.inputs)
+		}
+		var change amt.Amount
+		if tx.ChangeIndex >= 0 {
+			change = amt.Amount(tx.Tx.TxOut[tx.ChangeIndex].Value)
+		}
+		if change != test.change {
+			t.Errorf("test %d: got change %v, want %v", i, change, test.change)
+		}
+	}
+	if _, e := NewUnsignedTransactionFee(
+		p2pkhOutputs(1e6), 1e5, txrules.DefaultRelayFeePerKb, makeInputSource(p2pkhOutputs(1e6+5e4)), changeSource,
+	); e == nil {
+		t.Error("expected an error when the inputs do not cover the fee")
+	}
+}
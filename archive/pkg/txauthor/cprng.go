@@ -1,15 +1,14 @@
 package txauthor
 
 import (
-	"crypto/rand"
-	"encoding/binary"
 	mrand "math/rand"
 	"sync"
 )
 
 // cprng is a cryptographically random-seeded math/rand prng. It is seeded
-// during package init. Any initialization errors result in panics. It is safe
-// for concurrent access.
+// during package init, with the fixed seed of the txordertest build tag in
+// test builds. Any initialization errors result in panics. It is safe for
+// concurrent access.
 var cprng = cprngType{}
 
 type cprngType struct {
@@ -18,16 +17,19 @@ type cprngType struct {
 }
 
 func init() {
-	buf := make([]byte, 8)
-	_, e := rand.Read(buf)
-	if e != nil {
-		panic("Failed to seed prng: " + e.Error())
-	}
-	seed := int64(binary.LittleEndian.Uint64(buf))
-	cprng.r = mrand.New(mrand.NewSource(seed))
+	cprng.seed(orderSeed())
 }
-func (c *cprngType) Int31n(n int32) int32 {
-	defer c.mu.Unlock() // Int31n may panic
+
+// seed restarts the prng from the seed.
+func (c *cprngType) seed(seed int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.r = mrand.New(mrand.NewSource(seed))
+}
+
+// Perm returns a random permutation of the integers from 0 to n-1.
+func (c *cprngType) Perm(n int) []int {
 	c.mu.Lock()
-	return c.r.Int31n(n)
+	defer c.mu.Unlock()
+	return c.r.Perm(n)
 }
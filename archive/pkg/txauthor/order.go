@@ -0,0 +1,106 @@
+package txauthor
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// Ordering is the order the inputs and outputs of an authored transaction are put in before it is signed. Inputs are
+// chosen and change is appended in an order that tells which outputs belong to the wallet, so every transaction the
+// wallet creates is put in an order that does not.
+type Ordering string
+
+const (
+	// OrderRandom shuffles the inputs and outputs with the cryptographically seeded prng of the package.
+	OrderRandom Ordering = "random"
+	// OrderBIP69 sorts the inputs and outputs as specified by BIP 69, so the transactions of the wallet are ordered
+	// the same as those of other wallets following it.
+	OrderBIP69 Ordering = "bip69"
+)
+
+// Order puts the inputs and outputs of the transaction in the ordering, which is random for any ordering other than
+// OrderBIP69, keeping the previous output scripts and values with their inputs. The change index and the output
+// indexes pointed to by track, such as the positions of other change outputs, are moved with their outputs. This must
+// be done before signing, and as it does not change the size of the transaction, the fee remains valid.
+func (tx *AuthoredTx) Order(ordering Ordering, track ...*int) {
+	var inputs, outputs []int
+	if ordering == OrderBIP69 {
+		inputs, outputs = bip69Order(tx.Tx)
+	} else {
+		inputs, outputs = cprng.Perm(len(tx.Tx.TxIn)), cprng.Perm(len(tx.Tx.TxOut))
+	}
+	txIn := make([]*wire.TxIn, len(inputs))
+	for i, from := range inputs {
+		txIn[i] = tx.Tx.TxIn[from]
+	}
+	tx.Tx.TxIn = txIn
+	if len(tx.PrevScripts) == len(inputs) {
+		scripts := make([][]byte, len(inputs))
+		for i, from := range inputs {
+			scripts[i] = tx.PrevScripts[from]
+		}
+		tx.PrevScripts = scripts
+	}
+	if len(tx.PrevInputValues) == len(inputs) {
+		values := make([]amt.Amount, len(inputs))
+		for i, from := range inputs {
+			values[i] = tx.PrevInputValues[from]
+		}
+		tx.PrevInputValues = values
+	}
+	// The outputs are copied to a new slice as the old one may be the outputs passed by the caller.
+	txOut := make([]*wire.TxOut, len(outputs))
+	moved := make([]int, len(outputs))
+	for i, from := range outputs {
+		txOut[i] = tx.Tx.TxOut[from]
+		moved[from] = i
+	}
+	tx.Tx.TxOut = txOut
+	for _, index := range append(track, &tx.ChangeIndex) {
+		if index != nil && *index >= 0 && *index < len(moved) {
+			*index = moved[*index]
+		}
+	}
+}
+
+// bip69Order returns the positions of the inputs and outputs of the transaction in the order of BIP 69. Inputs are
+// sorted by the hash of the previous transaction, compared in the reversed byte order it is displayed in, and then by
+// the index of the previous output. Outputs are sorted by amount and then by their script.
+func bip69Order(msgTx *wire.MsgTx) (inputs, outputs []int) {
+	inputs, outputs = identity(len(msgTx.TxIn)), identity(len(msgTx.TxOut))
+	sort.SliceStable(
+		inputs, func(i, j int) bool {
+			a, b := &msgTx.TxIn[inputs[i]].PreviousOutPoint, &msgTx.TxIn[inputs[j]].PreviousOutPoint
+			if a.Hash != b.Hash {
+				for k := len(a.Hash) - 1; k >= 0; k-- {
+					if a.Hash[k] != b.Hash[k] {
+						return a.Hash[k] < b.Hash[k]
+					}
+				}
+			}
+			return a.Index < b.Index
+		},
+	)
+	sort.SliceStable(
+		outputs, func(i, j int) bool {
+			a, b := msgTx.TxOut[outputs[i]], msgTx.TxOut[outputs[j]]
+			if a.Value != b.Value {
+				return a.Value < b.Value
+			}
+			return bytes.Compare(a.PkScript, b.PkScript) < 0
+		},
+	)
+	return
+}
+
+// identity returns the positions from 0 to n-1 in order.
+func identity(n int) (positions []int) {
+	positions = make([]int, n)
+	for i := range positions {
+		positions[i] = i
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package txauthor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// orderTestTx returns a transaction with inputs whose previous output scripts and values are their index, and outputs
+// whose script is their index, with the change being the output at changeIndex.
+func orderTestTx(outPoints []wire.OutPoint, values []int64, changeIndex int) (tx *AuthoredTx, outputs []*wire.TxOut) {
+	tx = &AuthoredTx{Tx: wire.NewMsgTx(wire.TxVersion), ChangeIndex: changeIndex}
+	for i := range outPoints {
+		tx.Tx.AddTxIn(wire.NewTxIn(&outPoints[i], nil, nil))
+		tx.PrevScripts = append(tx.PrevScripts, []byte{byte(i)})
+		tx.PrevInputValues = append(tx.PrevInputValues, amt.Amount(i))
+	}
+	for i, v := range values {
+		tx.Tx.AddTxOut(wire.NewTxOut(v, []byte{byte(i)}))
+	}
+	outputs = tx.Tx.TxOut
+	return
+}
+
+// checkOrderedInputs ensures the previous output scripts and values of the inputs of an ordered transaction are still
+// those of their input.
+func checkOrderedInputs(t *testing.T, tx *AuthoredTx, outPoints []wire.OutPoint) {
+	for i, in := range tx.Tx.TxIn {
+		from := int(tx.PrevScripts[i][0])
+		if in.PreviousOutPoint != outPoints[from] || tx.PrevInputValues[i] != amt.Amount(from) {
+			t.Errorf("input %d was moved away from its previous output script and value", i)
+		}
+	}
+}
+
+// TestOrderBIP69 ensures inputs are sorted by the reversed hash of the previous transaction and the index of the
+// previous output, outputs by amount and script, and the change index follows the change output.
+func TestOrderBIP69(t *testing.T) {
+	var low, high chainhash.Hash
+	// the hashes are compared from their last byte, so low sorts first even though its first byte is greater
+	low[0], low[31] = 0xff, 0x01
+	high[0], high[31] = 0x00, 0x02
+	outPoints := []wire.OutPoint{{Hash: high, Index: 0}, {Hash: low, Index: 1}, {Hash: low, Index: 0}}
+	tx, _ := orderTestTx(outPoints, []int64{500, 100, 100}, 0)
+	feeChange := 2
+	tx.Order(OrderBIP69, &feeChange)
+	var got []wire.OutPoint
+	for _, in := range tx.Tx.TxIn {
+		got = append(got, in.PreviousOutPoint)
+	}
+	if want := []wire.OutPoint{outPoints[2], outPoints[1], outPoints[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inputs ordered %v, want %v", got, want)
+	}
+	checkOrderedInputs(t, tx, outPoints)
+	var scripts []byte
+	for _, out := range tx.Tx.TxOut {
+		scripts = append(scripts, out.PkScript[0])
+	}
+	if want := []byte{1, 2, 0}; !reflect.DeepEqual(scripts, want) {
+		t.Errorf("outputs ordered %v, want %v", scripts, want)
+	}
+	if tx.ChangeIndex != 2 || feeChange != 1 {
+		t.Errorf("change outputs at %d and %d, want 2 and 1", tx.ChangeIndex, feeChange)
+	}
+}
+
+// TestOrderRandom ensures the random ordering is the same from the same seed, keeps inputs with their previous output
+// scripts and values and the change index with the change output, and does not reorder the outputs of the caller.
+func TestOrderRandom(t *testing.T) {
+	outPoints := make([]wire.OutPoint, 8)
+	values := make([]int64, 8)
+	for i := range outPoints {
+		outPoints[i].Index = uint32(i)
+		values[i] = int64(i + 1)
+	}
+	order := func(seed int64) (tx *AuthoredTx) {
+		var outputs []*wire.TxOut
+		tx, outputs = orderTestTx(outPoints, values, 3)
+		callers := append([]*wire.TxOut(nil), outputs...)
+		cprng.seed(seed)
+		tx.Order(OrderRandom)
+		if !reflect.DeepEqual(outputs, callers) {
+			t.Error("the outputs of the caller were reordered")
+		}
+		checkOrderedInputs(t, tx, outPoints)
+		if tx.Tx.TxOut[tx.ChangeIndex].Value != values[3] {
+			t.Errorf("change index %d is not the change output", tx.ChangeIndex)
+		}
+		return
+	}
+	a, b := order(1), order(1)
+	if !reflect.DeepEqual(a.Tx, b.Tx) || a.ChangeIndex != b.ChangeIndex {
+		t.Error("the same seed ordered the transaction differently")
+	}
+	if c := order(2); reflect.DeepEqual(a.Tx, c.Tx) {
+		t.Error("different seeds ordered the transaction the same")
+	}
+}
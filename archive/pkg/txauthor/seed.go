@@ -0,0 +1,18 @@
+// +build !txordertest
+
+package txauthor
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// orderSeed returns a cryptographically random seed for the prng that shuffles the inputs and outputs of transactions.
+func orderSeed() int64 {
+	buf := make([]byte, 8)
+	_, e := rand.Read(buf)
+	if e != nil {
+		panic("Failed to seed prng: " + e.Error())
+	}
+	return int64(binary.LittleEndian.Uint64(buf))
+}
@@ -0,0 +1,21 @@
+// +build txordertest
+
+package txauthor
+
+// Building with the txordertest tag seeds the prng that shuffles the inputs and outputs of transactions with a fixed
+// seed, so that tests constructing transactions get the same transactions on every run. It must never be used for a
+// wallet that sends transactions, as the order of their inputs and outputs would then tell which are its own.
+
+// TestOrderSeed is the seed of the prng in builds with the txordertest tag.
+const TestOrderSeed = 69
+
+// orderSeed returns the fixed seed of test builds.
+func orderSeed() int64 {
+	return TestOrderSeed
+}
+
+// SeedOrdering restarts the prng that shuffles the inputs and outputs of transactions from the seed, so a test can
+// reproduce the order of the transactions it constructs whatever ran before it.
+func SeedOrdering(seed int64) {
+	cprng.seed(seed)
+}
@@ -0,0 +1,60 @@
+package txscript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scriptFlagNames are the names of the script verification flags, which are those Bitcoin Core uses for them
+var scriptFlagNames = []struct {
+	flag ScriptFlags
+	name string
+}{
+	{ScriptBip16, "P2SH"},
+	{ScriptVerifyStrictEncoding, "STRICTENC"},
+	{ScriptVerifyDERSignatures, "DERSIG"},
+	{ScriptVerifyLowS, "LOW_S"},
+	{ScriptStrictMultiSig, "NULLDUMMY"},
+	{ScriptVerifySigPushOnly, "SIGPUSHONLY"},
+	{ScriptVerifyMinimalData, "MINIMALDATA"},
+	{ScriptDiscourageUpgradableNops, "DISCOURAGE_UPGRADABLE_NOPS"},
+	{ScriptVerifyCleanStack, "CLEANSTACK"},
+	{ScriptVerifyCheckLockTimeVerify, "CHECKLOCKTIMEVERIFY"},
+	{ScriptVerifyCheckSequenceVerify, "CHECKSEQUENCEVERIFY"},
+	{ScriptVerifyWitness, "WITNESS"},
+	{ScriptVerifyDiscourageUpgradeableWitnessProgram, "DISCOURAGE_UPGRADABLE_WITNESS_PROGRAM"},
+	{ScriptVerifyMinimalIf, "MINIMALIF"},
+	{ScriptVerifyNullFail, "NULLFAIL"},
+	{ScriptVerifyWitnessPubKeyType, "WITNESS_PUBKEYTYPE"},
+}
+
+// Names returns the names of the flags that are set, in the order they were introduced to Bitcoin.
+func (f ScriptFlags) Names() (names []string) {
+	names = []string{}
+	for _, n := range scriptFlagNames {
+		if f&n.flag == n.flag {
+			names = append(names, n.name)
+		}
+	}
+	return
+}
+
+// String returns the names of the flags that are set separated by commas.
+func (f ScriptFlags) String() string {
+	return strings.Join(f.Names(), ",")
+}
+
+// ParseScriptFlags returns the flags with the given names, which are matched regardless of case.
+func ParseScriptFlags(names []string) (f ScriptFlags, e error) {
+next:
+	for _, name := range names {
+		for _, n := range scriptFlagNames {
+			if strings.EqualFold(name, n.name) {
+				f |= n.flag
+				continue next
+			}
+		}
+		return 0, fmt.Errorf("unknown script verification flag %q", name)
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package txscript
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestScriptFlagNames ensures every script verification flag has the name the reference tests use for it, and that
+// flags survive being turned into names and back.
+func TestScriptFlagNames(t *testing.T) {
+	var all ScriptFlags
+	for _, n := range scriptFlagNames {
+		want, e := parseScriptFlags(n.name)
+		if e != nil || want != n.flag {
+			t.Errorf("flag %s is %#x, the reference tests parse it as %#x, %v", n.name, n.flag, want, e)
+		}
+		if all&n.flag != 0 {
+			t.Errorf("flag %s is named more than once", n.name)
+		}
+		all |= n.flag
+	}
+	if all != ScriptVerifyWitnessPubKeyType<<1-1 {
+		t.Errorf("flags %#x are not all named", ScriptVerifyWitnessPubKeyType<<1-1&^all)
+	}
+	f, e := ParseScriptFlags(all.Names())
+	if e != nil || f != all {
+		t.Errorf("got flags %#x, %v parsing the names of %#x", f, e, all)
+	}
+	f, e = ParseScriptFlags([]string{"p2sh", "DERSIG"})
+	if e != nil || f != ScriptBip16|ScriptVerifyDERSignatures {
+		t.Errorf("got flags %#x, %v", f, e)
+	}
+	if names := f.Names(); !reflect.DeepEqual(names, []string{"P2SH", "DERSIG"}) || f.String() != "P2SH,DERSIG" {
+		t.Errorf("got names %v for %#x", names, f)
+	}
+	if names := ScriptFlags(0).Names(); names == nil || len(names) != 0 {
+		t.Errorf("got names %#v for no flags", names)
+	}
+	if _, e = ParseScriptFlags([]string{"P2SH", "SEGWIT"}); e == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+}
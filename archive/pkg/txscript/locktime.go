@@ -0,0 +1,180 @@
+package txscript
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TimeLock is the lock of a script that pays to a single key once a time lock has passed, checked either by
+// OP_CHECKLOCKTIMEVERIFY against a height or time the chain must reach, or by OP_CHECKSEQUENCEVERIFY against a number of
+// blocks or seconds that must pass after the output is mined. The script is one of:
+//
+//	<lock> OP_CHECKLOCKTIMEVERIFY|OP_CHECKSEQUENCEVERIFY OP_DROP <pubKey> OP_CHECKSIG
+//	<lock> OP_CHECKLOCKTIMEVERIFY|OP_CHECKSEQUENCEVERIFY OP_DROP OP_DUP OP_HASH160 <pubKeyHash> OP_EQUALVERIFY OP_CHECKSIG
+type TimeLock struct {
+	// Relative is set for a lock checked by OP_CHECKSEQUENCEVERIFY, which counts from the block the output is mined in.
+	Relative bool
+	// Seconds is set for a lock in time rather than blocks.
+	Seconds bool
+	// Value is the height or Unix time the chain must reach for an absolute lock, or the number of blocks or seconds
+	// that must pass for a relative one. Relative locks in seconds have a granularity of 512 seconds.
+	Value int64
+	// PubKey is the serialized key the script pays to, which is nil when it pays to the hash of the key.
+	PubKey []byte
+	// PubKeyHash is the hash of the key the script pays to.
+	PubKeyHash []byte
+}
+
+// LockTimeToSequence returns the sequence number of a transaction input that locks the output it spends for lockTime
+// blocks, or when isSeconds is set, lockTime seconds rounded down to a multiple of 512, after the output is mined.
+func LockTimeToSequence(isSeconds bool, lockTime uint32) uint32 {
+	if !isSeconds {
+		return lockTime
+	}
+	return wire.SequenceLockTimeIsSeconds | lockTime>>wire.SequenceLockTimeGranularity
+}
+
+// TimeLockScript returns the script of the time lock, which pays to PubKey if it is set and otherwise to PubKeyHash.
+func TimeLockScript(tl *TimeLock) ([]byte, error) {
+	lock := tl.Value
+	op := byte(OP_CHECKLOCKTIMEVERIFY)
+	switch {
+	case tl.Relative:
+		limit := int64(wire.SequenceLockTimeMask)
+		if tl.Seconds {
+			limit <<= wire.SequenceLockTimeGranularity
+		}
+		if lock < 0 || lock > limit {
+			return nil, fmt.Errorf("relative lock of %d is outside the range 0-%d", lock, limit)
+		}
+		lock = int64(LockTimeToSequence(tl.Seconds, uint32(lock)))
+		op = OP_CHECKSEQUENCEVERIFY
+	case tl.Seconds && (lock < LockTimeThreshold || lock > int64(wire.MaxTxInSequenceNum)):
+		return nil, fmt.Errorf(
+			"lock time %d is outside the range %d-%d", lock, int64(LockTimeThreshold), wire.MaxTxInSequenceNum,
+		)
+	case !tl.Seconds && (lock < 0 || lock >= LockTimeThreshold):
+		return nil, fmt.Errorf("lock height %d is outside the range 0-%d", lock, int64(LockTimeThreshold)-1)
+	}
+	builder := NewScriptBuilder().AddInt64(lock).AddOp(op).AddOp(OP_DROP)
+	if tl.PubKey != nil {
+		builder.AddData(tl.PubKey)
+	} else {
+		builder.AddOp(OP_DUP).AddOp(OP_HASH160).AddData(tl.PubKeyHash).AddOp(OP_EQUALVERIFY)
+	}
+	return builder.AddOp(OP_CHECKSIG).Script()
+}
+
+// ExtractTimeLock returns the time lock of a script paying to a single key once a time lock has passed, or nil if the
+// script is not one.
+func ExtractTimeLock(script []byte) *TimeLock {
+	pops, e := parseScript(script)
+	if e != nil || len(pops) < 5 {
+		return nil
+	}
+	tl := &TimeLock{}
+	switch {
+	case len(pops) == 5 && isPubkey(pops[3:]):
+		tl.PubKey = pops[3].data
+		tl.PubKeyHash = btcaddr.Hash160(tl.PubKey)
+	case len(pops) == 8 && isPubkeyHash(pops[3:]):
+		tl.PubKeyHash = pops[5].data
+	default:
+		return nil
+	}
+	if pops[2].opcode.value != OP_DROP {
+		return nil
+	}
+	var lock int64
+	switch op := pops[0].opcode; {
+	case isSmallInt(op):
+		lock = int64(asSmallInt(op))
+	case canonicalPush(pops[0]) && pops[0].data != nil:
+		n, e := makeScriptNum(pops[0].data, true, 5)
+		if e != nil || n < 0 {
+			return nil
+		}
+		lock = int64(n)
+	default:
+		return nil
+	}
+	switch pops[1].opcode.value {
+	case OP_CHECKLOCKTIMEVERIFY:
+		tl.Seconds = lock >= LockTimeThreshold
+		tl.Value = lock
+	case OP_CHECKSEQUENCEVERIFY:
+		// A sequence with the disable flag set does not lock the output.
+		if lock&wire.SequenceLockTimeDisabled != 0 {
+			return nil
+		}
+		tl.Relative = true
+		tl.Seconds = lock&wire.SequenceLockTimeIsSeconds != 0
+		tl.Value = lock & wire.SequenceLockTimeMask
+		if tl.Seconds {
+			tl.Value <<= wire.SequenceLockTimeGranularity
+		}
+	default:
+		return nil
+	}
+	return tl
+}
+
+// LockTime returns the lock time a transaction spending an output locked by an absolute lock must have, which is zero
+// for a relative lock.
+func (tl *TimeLock) LockTime() uint32 {
+	if tl.Relative {
+		return 0
+	}
+	return uint32(tl.Value)
+}
+
+// Sequence returns the sequence number of the input spending an output locked by the time lock. An absolute lock only
+// needs an input that is not final, so its lock time is checked, while a relative lock is checked against the sequence
+// number, which only counts for transactions of version 2 or above.
+func (tl *TimeLock) Sequence() uint32 {
+	if !tl.Relative {
+		return wire.MaxTxInSequenceNum - 1
+	}
+	return LockTimeToSequence(tl.Seconds, uint32(tl.Value))
+}
+
+// UnlockAt returns the height, or when the lock is in seconds the median time past as a Unix time, that the best block
+// must reach for an output locked by the time lock to be spent in the next block. confHeight and confTime are the
+// height and the timestamp of the block the output is mined in, and for an output that is not mined, which a relative
+// lock can't be counted from, ok is false.
+//
+// The timestamp of the block an output is mined in is never before the median time past of the block before it, which
+// relative locks in seconds are counted from, so the time returned for them may be a little later than needed, but
+// never earlier.
+func (tl *TimeLock) UnlockAt(confHeight int32, confTime time.Time) (at int64, ok bool) {
+	switch {
+	case !tl.Relative && tl.Seconds:
+		// The lock time of a transaction must be before the median time past.
+		return tl.Value + 1, true
+	case !tl.Relative:
+		// The lock time of a transaction must be below the height of the block it is mined in.
+		return tl.Value, true
+	case confHeight < 0:
+		return 0, false
+	case tl.Seconds:
+		return confTime.Unix() + tl.Value, true
+	default:
+		return int64(confHeight) + tl.Value - 1, true
+	}
+}
+
+// Reached returns whether an output locked by the time lock, mined in the block at confHeight with the timestamp
+// confTime, can be spent in the block after the best block at height with the median time past medianTime.
+func (tl *TimeLock) Reached(height int32, medianTime time.Time, confHeight int32, confTime time.Time) bool {
+	at, ok := tl.UnlockAt(confHeight, confTime)
+	if !ok {
+		return false
+	}
+	if tl.Seconds {
+		return medianTime.Unix() >= at
+	}
+	return int64(height) >= at
+}
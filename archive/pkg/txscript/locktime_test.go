@@ -0,0 +1,181 @@
+package txscript
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestTimeLockScript ensures the time locks of the scripts made for them are extracted from the scripts unchanged, and
+// that scripts that are not time locks are not taken for one.
+func TestTimeLockScript(t *testing.T) {
+	pubKey := append([]byte{2}, bytes.Repeat([]byte{1}, 32)...)
+	pubKeyHash := btcaddr.Hash160(pubKey)
+	tests := []TimeLock{
+		{Value: 0, PubKey: pubKey},
+		{Value: 12, PubKeyHash: pubKeyHash},
+		{Value: 700000, PubKey: pubKey},
+		{Seconds: true, Value: 1600000000, PubKeyHash: pubKeyHash},
+		{Relative: true, Value: 144, PubKey: pubKey},
+		{Relative: true, Seconds: true, Value: 512 * 100, PubKeyHash: pubKeyHash},
+	}
+	for i, test := range tests {
+		script, e := TimeLockScript(&test)
+		if e != nil {
+			t.Fatalf("test %d: %v", i, e)
+		}
+		tl := ExtractTimeLock(script)
+		if tl == nil {
+			t.Fatalf("test %d: time lock not extracted from %x", i, script)
+		}
+		if tl.Relative != test.Relative || tl.Seconds != test.Seconds || tl.Value != test.Value ||
+			!bytes.Equal(tl.PubKey, test.PubKey) || !bytes.Equal(tl.PubKeyHash, pubKeyHash) {
+			t.Errorf("test %d: extracted %+v, want %+v", i, tl, test)
+		}
+		if GetScriptClass(script) != NonStandardTy {
+			t.Errorf("test %d: script is of class %v", i, GetScriptClass(script))
+		}
+	}
+	invalid := []TimeLock{
+		{Value: LockTimeThreshold, PubKey: pubKey},
+		{Seconds: true, Value: 1000, PubKey: pubKey},
+		{Relative: true, Value: wire.SequenceLockTimeMask + 1, PubKey: pubKey},
+		{Value: -1, PubKey: pubKey},
+	}
+	for i, test := range invalid {
+		if _, e := TimeLockScript(&test); e == nil {
+			t.Errorf("invalid test %d: script made for %+v", i, test)
+		}
+	}
+	// A disabled relative lock, a lock that is dropped by something else, and a plain key script are not time locks.
+	disabled, _ := NewScriptBuilder().AddInt64(wire.SequenceLockTimeDisabled).AddOp(OP_CHECKSEQUENCEVERIFY).
+		AddOp(OP_DROP).AddData(pubKey).AddOp(OP_CHECKSIG).Script()
+	noDrop, _ := NewScriptBuilder().AddInt64(10).AddOp(OP_CHECKLOCKTIMEVERIFY).
+		AddOp(OP_NOP).AddData(pubKey).AddOp(OP_CHECKSIG).Script()
+	plain, _ := payToPubKeyScript(pubKey)
+	for _, script := range [][]byte{disabled, noDrop, plain} {
+		if tl := ExtractTimeLock(script); tl != nil {
+			t.Errorf("time lock %+v extracted from %x", tl, script)
+		}
+	}
+}
+
+// TestTimeLockReached ensures each kind of time lock is reached at the height or time it unlocks at, and not before.
+func TestTimeLockReached(t *testing.T) {
+	confTime := time.Unix(1600000000, 0)
+	tests := []struct {
+		lock       TimeLock
+		confHeight int32
+		at         int64
+		ok         bool
+	}{
+		{TimeLock{Value: 100}, -1, 100, true},
+		{TimeLock{Seconds: true, Value: 1600000500}, -1, 1600000501, true},
+		{TimeLock{Relative: true, Value: 10}, 50, 59, true},
+		{TimeLock{Relative: true, Value: 10}, -1, 0, false},
+		{TimeLock{Relative: true, Seconds: true, Value: 1024}, 50, 1600001024, true},
+	}
+	for i, test := range tests {
+		at, ok := test.lock.UnlockAt(test.confHeight, confTime)
+		if at != test.at || ok != test.ok {
+			t.Errorf("test %d: unlocks at %d, %v, want %d, %v", i, at, ok, test.at, test.ok)
+			continue
+		}
+		if !ok {
+			if test.lock.Reached(1<<30, time.Unix(1<<40, 0), test.confHeight, confTime) {
+				t.Errorf("test %d: lock of an output that is not mined reached", i)
+			}
+			continue
+		}
+		height, median := int32(at), time.Unix(at, 0)
+		if !test.lock.Reached(height, median, test.confHeight, confTime) {
+			t.Errorf("test %d: lock not reached at %d", i, at)
+		}
+		if test.lock.Seconds {
+			median = median.Add(-time.Second)
+		} else {
+			height--
+		}
+		if test.lock.Reached(height, median, test.confHeight, confTime) {
+			t.Errorf("test %d: lock reached before %d", i, at)
+		}
+	}
+}
+
+// TestSignTimeLock ensures a pay to script hash output of an absolute time lock is signed by SignTxOutput once the
+// transaction has the lock time and sequence of the lock, and the signature verifies with the standard script flags.
+func TestSignTimeLock(t *testing.T) {
+	key, e := ecc.NewPrivateKey(ecc.S256())
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, payToHash := range []bool{false, true} {
+		tl := &TimeLock{Value: 500}
+		if payToHash {
+			tl.PubKeyHash = btcaddr.Hash160(key.PubKey().SerializeCompressed())
+		} else {
+			tl.PubKey = key.PubKey().SerializeCompressed()
+		}
+		script, e := TimeLockScript(tl)
+		if e != nil {
+			t.Fatal(e)
+		}
+		addr, e := btcaddr.NewScriptHash(script, &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		pkScript, e := PayToAddrScript(addr)
+		if e != nil {
+			t.Fatal(e)
+		}
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(1e8, []byte{OP_TRUE}))
+		kdb := KeyClosure(
+			func(btcaddr.Address) (*ecc.PrivateKey, bool, error) {
+				return key, true, nil
+			},
+		)
+		sdb := ScriptClosure(
+			func(a btcaddr.Address) ([]byte, error) {
+				if a.EncodeAddress() != addr.EncodeAddress() {
+					return nil, errors.New("unknown script")
+				}
+				return script, nil
+			},
+		)
+		verify := func() error {
+			sigScript, e := SignTxOutput(&chaincfg.MainNetParams, tx, 0, pkScript, SigHashAll, kdb, sdb, nil)
+			if e != nil {
+				return e
+			}
+			tx.TxIn[0].SignatureScript = sigScript
+			vm, e := NewEngine(pkScript, tx, 0, StandardVerifyFlags, nil, nil, 1e8)
+			if e != nil {
+				return e
+			}
+			return vm.Execute()
+		}
+		// The lock time is not checked while the input is final.
+		tx.LockTime = tl.LockTime()
+		if e = verify(); e == nil {
+			t.Fatalf("pay to hash %v: spend with a final input verified", payToHash)
+		}
+		tx.TxIn[0].Sequence = tl.Sequence()
+		tx.LockTime = tl.LockTime() - 1
+		if e = verify(); e == nil {
+			t.Fatalf("pay to hash %v: spend before the lock time verified", payToHash)
+		}
+		tx.LockTime = tl.LockTime()
+		if e = verify(); e != nil {
+			t.Fatalf("pay to hash %v: %v", payToHash, e)
+		}
+	}
+}
@@ -312,7 +312,7 @@ func testScripts(t *testing.T, tests [][]interface{}, useSigCache bool) {
 	// Create a signature cache to use only if requested.
 	var sigCache *SigCache
 	if useSigCache {
-		sigCache = NewSigCache(10)
+		sigCache = NewSigCache(10 * SigCacheEntrySize)
 	}
 	for i, test := range tests {
 		// "Format is: [[wit..., amount]?, scriptSig, scriptPubKey,
@@ -1,75 +1,232 @@
 package txscript
 
 import (
+	"container/list"
+	"math/bits"
 	"sync"
+	"sync/atomic"
 	
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/ecc"
 )
 
+const (
+	// sigCacheEntryOverhead is the approximate number of bytes used to hold an entry apart from the words of the big
+	// integers in its signature and public key. It covers the map key and value, the list element, the entry itself and
+	// the headers of the signature, the public key and their big integers.
+	sigCacheEntryOverhead = 320
+	// SigCacheEntrySize is the approximate number of bytes used to hold a typical entry in the SigCache.
+	SigCacheEntrySize = sigCacheEntryOverhead + 4*32
+	// SigCacheMaxEntriesLimit is the largest signature cache size that is read as a number of entries rather than a
+	// number of bytes by SigCacheMaxBytes. The cache used to be sized in entries, and no useful cache is this few bytes,
+	// so sizes from configurations written before the cache was sized in bytes keep their meaning.
+	SigCacheMaxEntriesLimit = 1 << 20
+	// sigCacheHotShare is the share of the bytes of the SigCache, in fifths, that may be taken by the hot tier.
+	sigCacheHotShare = 4
+)
+
+// SigCacheMaxBytes returns the number of bytes a signature cache of the configured size may use. Sizes up to
+// SigCacheMaxEntriesLimit are numbers of entries, as the size was given before the cache was sized in bytes, and are
+// converted to bytes with SigCacheEntrySize. Larger sizes are numbers of bytes.
+func SigCacheMaxBytes(size uint) uint {
+	if size <= SigCacheMaxEntriesLimit {
+		return size * SigCacheEntrySize
+	}
+	return size
+}
+
 // sigCacheEntry represents an entry in the SigCache. Entries within the SigCache are keyed according to the sigHash of
 // the signature. In the scenario of a cache-hit (according to the sigHash), an additional comparison of the signature,
 // and public key will be executed in order to ensure a complete match. In the occasion that two sigHashes collide, the
 // newer sigHash will simply overwrite the existing entry.
 type sigCacheEntry struct {
-	sig    *ecc.Signature
-	pubKey *ecc.PublicKey
+	sigHash chainhash.Hash
+	sig     *ecc.Signature
+	pubKey  *ecc.PublicKey
+	size    uint
+	hot     bool
+	// moved is the count of moves to the front of the hot tier when the entry was last moved there.
+	moved uint64
+}
+
+// sigCacheEntrySize returns the approximate number of bytes used to hold an entry for the signature and public key.
+func sigCacheEntrySize(sig *ecc.Signature, pubKey *ecc.PublicKey) uint {
+	words := len(sig.R.Bits()) + len(sig.S.Bits()) + len(pubKey.X.Bits()) + len(pubKey.Y.Bits())
+	return sigCacheEntryOverhead + uint(words)*bits.UintSize/8
 }
 
-// SigCache implements an ECDSA signature verification cache with a randomized entry eviction policy. Only valid
-// signatures will be added to the cache. The benefits of SigCache are two fold. Firstly, usage of SigCache mitigates a
-// DoS attack wherein an attack causes a victim's client to hang due to worst-case behavior triggered while processing
-// attacker crafted invalid transactions. A detailed description of the mitigated DoS attack can be found here:
+// SigCacheStats is a snapshot of how full a SigCache is and how well it has served lookups.
+type SigCacheStats struct {
+	HotEntries  int
+	ColdEntries int
+	HotBytes    uint
+	ColdBytes   uint
+	MaxBytes    uint
+	Hits        uint64
+	Misses      uint64
+	Promotions  uint64
+	Evictions   uint64
+}
+
+// SigCache implements an ECDSA signature verification cache with a two tier least recently used eviction policy. Only
+// valid signatures will be added to the cache. The benefits of SigCache are two fold. Firstly, usage of SigCache
+// mitigates a DoS attack wherein an attack causes a victim's client to hang due to worst-case behavior triggered while
+// processing attacker crafted invalid transactions. A detailed description of the mitigated DoS attack can be found
+// here:
 // https://bitslog.wordpress.com/2013/01/23/fixed-bitcoin-vulnerability-explanation-why-the-signature-cache-is-a-dos-protection/.
 // Secondly, usage of the SigCache introduces a signature verification optimization which speeds up the validation of
 // transactions within a block, if they've already been seen and verified within the mempool.
+//
+// New entries go into the cold tier, and are moved to the hot tier the first time they are found. The hot tier may take
+// up to four fifths of the cache, and entries pushed out of it drop back into the cold tier, so a burst of new
+// signatures only evicts entries that were never used or have not been used for a long time. The size of the cache is
+// accounted in bytes.
+//
+// Lookups are made under a read lock. The write lock is only taken to promote an entry to the hot tier, or to move a
+// hot entry back to the front once half of the hot tier has been moved ahead of it, so hits on entries that are
+// already near the front don't contend with each other.
 type SigCache struct {
+	// hits and misses are updated atomically, and are first in the struct to keep them aligned on 32 bit platforms.
+	hits   uint64
+	misses uint64
 	sync.RWMutex
-	validSigs  map[chainhash.Hash]sigCacheEntry
-	maxEntries uint
+	validSigs  map[chainhash.Hash]*list.Element
+	hot        *list.List
+	cold       *list.List
+	hotBytes   uint
+	coldBytes  uint
+	maxBytes   uint
+	moves      uint64
+	promotions uint64
+	evictions  uint64
 }
 
-// NewSigCache creates and initializes a new instance of SigCache. Its sole parameter 'maxEntries' represents the
-// maximum number of entries allowed to exist in the SigCache at any particular moment. Random entries are evicted make
-// room for new entries that would cause the number of entries in the cache to exceed the max.
-func NewSigCache(maxEntries uint) *SigCache {
+// NewSigCache creates and initializes a new instance of SigCache. Its sole parameter 'maxBytes' is the most memory in
+// bytes that the entries of the SigCache may use at any particular moment. The least recently used entries are evicted
+// to make room for new entries that would take the cache over that size.
+func NewSigCache(maxBytes uint) *SigCache {
 	return &SigCache{
-		validSigs:  make(map[chainhash.Hash]sigCacheEntry, maxEntries),
-		maxEntries: maxEntries,
+		validSigs: make(map[chainhash.Hash]*list.Element, maxBytes/SigCacheEntrySize),
+		hot:       list.New(),
+		cold:      list.New(),
+		maxBytes:  maxBytes,
 	}
 }
 
 // Exists returns true if an existing entry of 'sig' over 'sigHash' for public key 'pubKey' is found within the
-// SigCache. Otherwise, false is returned. NOTE: This function is safe for concurrent access. Readers won't be blocked
-// unless there exists a writer, adding an entry to the SigCache.
+// SigCache. Otherwise, false is returned. An entry that is found is promoted to the hot tier, or moved to its front if
+// it has fallen into the back half of the hot tier.
+// NOTE: This function is safe for concurrent access.
 func (s *SigCache) Exists(sigHash chainhash.Hash, sig *ecc.Signature, pubKey *ecc.PublicKey) bool {
 	s.RLock()
-	entry, ok := s.validSigs[sigHash]
+	elem, ok := s.validSigs[sigHash]
+	if !ok {
+		s.RUnlock()
+		atomic.AddUint64(&s.misses, 1)
+		return false
+	}
+	entry := elem.Value.(*sigCacheEntry)
+	if !entry.pubKey.IsEqual(pubKey) || !entry.sig.IsEqual(sig) {
+		s.RUnlock()
+		atomic.AddUint64(&s.misses, 1)
+		return false
+	}
+	// Fewer entries than were moved to the front since this one are ahead of it in the hot tier.
+	nearFront := entry.hot && s.moves-entry.moved < uint64(s.hot.Len()/2)
 	s.RUnlock()
-	return ok && entry.pubKey.IsEqual(pubKey) && entry.sig.IsEqual(sig)
+	atomic.AddUint64(&s.hits, 1)
+	if !nearFront {
+		s.touch(sigHash, entry)
+	}
+	return true
 }
 
-// Add adds an entry for a signature over 'sigHash' under public key 'pubKey' to the signature cache. In the event that
-// the SigCache is 'full', an existing entry is randomly chosen to be evicted in order to make space for the new entry.
-// NOTE: This function is safe for concurrent access. Writers will block simultaneous readers until function execution
-// has concluded.
+// touch moves a hot entry that was found to the front of the hot tier, or promotes a cold one to it.
+func (s *SigCache) touch(sigHash chainhash.Hash, entry *sigCacheEntry) {
+	s.Lock()
+	defer s.Unlock()
+	// The entry may have been evicted or replaced since it was found.
+	elem, ok := s.validSigs[sigHash]
+	if !ok || elem.Value != entry {
+		return
+	}
+	s.moves++
+	entry.moved = s.moves
+	if entry.hot {
+		s.hot.MoveToFront(elem)
+		return
+	}
+	// Promote the entry to the hot tier, and drop the least recently used entries of the hot tier back to the cold
+	// tier if it is now over its share of the cache.
+	s.cold.Remove(elem)
+	s.coldBytes -= entry.size
+	entry.hot = true
+	s.validSigs[sigHash] = s.hot.PushFront(entry)
+	s.hotBytes += entry.size
+	s.promotions++
+	for s.hotBytes > s.maxBytes/5*sigCacheHotShare && s.hot.Len() > 1 {
+		demoted := s.hot.Remove(s.hot.Back()).(*sigCacheEntry)
+		s.hotBytes -= demoted.size
+		demoted.hot = false
+		s.validSigs[demoted.sigHash] = s.cold.PushFront(demoted)
+		s.coldBytes += demoted.size
+	}
+}
+
+// Add adds an entry for a signature over 'sigHash' under public key 'pubKey' to the cold tier of the signature cache.
+// In the event that the SigCache is 'full', the least recently used entries of the cold tier, and then of the hot tier,
+// are evicted in order to make space for the new entry.
+// NOTE: This function is safe for concurrent access.
 func (s *SigCache) Add(sigHash chainhash.Hash, sig *ecc.Signature, pubKey *ecc.PublicKey) {
 	s.Lock()
 	defer s.Unlock()
-	if s.maxEntries <= 0 {
+	size := sigCacheEntrySize(sig, pubKey)
+	if size > s.maxBytes {
 		return
 	}
-	// If adding this new entry will put us over the max number of allowed entries, then evict an entry.
-	if uint(len(s.validSigs)+1) > s.maxEntries {
-		// Remove a random entry from the map. Relying on the random starting point of Go's map iteration. It's worth
-		// noting that the random iteration starting point is not 100% guaranteed by the spec, however most Go compilers
-		// support it. Ultimately, the iteration order isn't important here because in order to manipulate which items
-		// are evicted, an adversary would need to be able to execute preimage attacks on the hashing function in order
-		// to start eviction at a specific entry.
-		for sigEntry := range s.validSigs {
-			delete(s.validSigs, sigEntry)
-			break
+	// An entry with the same sigHash is replaced.
+	if elem, ok := s.validSigs[sigHash]; ok {
+		s.remove(elem)
+	}
+	s.validSigs[sigHash] = s.cold.PushFront(&sigCacheEntry{sigHash: sigHash, sig: sig, pubKey: pubKey, size: size})
+	s.coldBytes += size
+	for s.hotBytes+s.coldBytes > s.maxBytes {
+		elem := s.cold.Back()
+		if elem == nil {
+			elem = s.hot.Back()
 		}
+		s.remove(elem)
+		s.evictions++
+	}
+}
+
+// remove takes the entry in the list element out of the cache.
+func (s *SigCache) remove(elem *list.Element) {
+	entry := elem.Value.(*sigCacheEntry)
+	if entry.hot {
+		s.hot.Remove(elem)
+		s.hotBytes -= entry.size
+	} else {
+		s.cold.Remove(elem)
+		s.coldBytes -= entry.size
+	}
+	delete(s.validSigs, entry.sigHash)
+}
+
+// Stats returns a snapshot of the occupancy and counters of the SigCache.
+// NOTE: This function is safe for concurrent access.
+func (s *SigCache) Stats() SigCacheStats {
+	s.RLock()
+	defer s.RUnlock()
+	return SigCacheStats{
+		HotEntries:  s.hot.Len(),
+		ColdEntries: s.cold.Len(),
+		HotBytes:    s.hotBytes,
+		ColdBytes:   s.coldBytes,
+		MaxBytes:    s.maxBytes,
+		Hits:        atomic.LoadUint64(&s.hits),
+		Misses:      atomic.LoadUint64(&s.misses),
+		Promotions:  s.promotions,
+		Evictions:   s.evictions,
 	}
-	s.validSigs[sigHash] = sigCacheEntry{sig, pubKey}
 }
@@ -2,6 +2,7 @@ package txscript
 
 import (
 	"crypto/rand"
+	"sync"
 	"testing"
 	
 	"github.com/p9c/pod/pkg/chainhash"
@@ -29,7 +30,7 @@ func genRandomSig() (*chainhash.Hash, *ecc.Signature, *ecc.PublicKey, error) {
 // TestSigCacheAddExists tests the ability to add, and later check the existence of a signature triplet in the signature
 // cache.
 func TestSigCacheAddExists(t *testing.T) {
-	sigCache := NewSigCache(200)
+	sigCache := NewSigCache(200 * SigCacheEntrySize)
 	// Generate a random sigCache entry triplet.
 	msg1, sig1, key1, e := genRandomSig()
 	if e != nil {
@@ -46,11 +47,11 @@ func TestSigCacheAddExists(t *testing.T) {
 }
 
 // TestSigCacheAddEvictEntry tests the eviction case where a new signature triplet is added to a full signature cache
-// which should trigger randomized eviction, followed by adding the new element to the cache.
+// which should trigger eviction of the least recently used entry, followed by adding the new element to the cache.
 func TestSigCacheAddEvictEntry(t *testing.T) {
 	// Create a sigcache that can hold up to 100 entries.
 	sigCacheSize := uint(100)
-	sigCache := NewSigCache(sigCacheSize)
+	sigCache := NewSigCache(sigCacheSize * SigCacheEntrySize)
 	// Fill the sigcache up with some random sig triplets.
 	for i := uint(0); i < sigCacheSize; i++ {
 		msg, sig, key, e := genRandomSig()
@@ -72,7 +73,7 @@ func TestSigCacheAddEvictEntry(t *testing.T) {
 			sigCacheSize, len(sigCache.validSigs),
 		)
 	}
-	// Add a new entry, this should cause eviction of a randomly chosen previous entry.
+	// Add a new entry, this should cause eviction of the first entry that was added.
 	msgNew, sigNew, keyNew, e := genRandomSig()
 	if e != nil {
 		t.Fatalf("unable to generate random signature test data")
@@ -119,3 +120,130 @@ func TestSigCacheAddMaxEntriesZeroOrNegative(t *testing.T) {
 		)
 	}
 }
+
+// TestSigCacheHotTier tests that entries that have been found in the signature cache stay in it while a burst of new
+// entries evicts the entries that were never found.
+func TestSigCacheHotTier(t *testing.T) {
+	sigCacheSize := uint(50)
+	sigCache := NewSigCache(sigCacheSize * SigCacheEntrySize)
+	type triplet struct {
+		msg *chainhash.Hash
+		sig *ecc.Signature
+		key *ecc.PublicKey
+	}
+	genTriplet := func() triplet {
+		msg, sig, key, e := genRandomSig()
+		if e != nil {
+			t.Fatalf("unable to generate random signature test data")
+		}
+		return triplet{msg, sig, key}
+	}
+	// Fill the cache and look up the first ten entries so they are promoted to the hot tier.
+	var entries []triplet
+	for i := uint(0); i < sigCacheSize; i++ {
+		entry := genTriplet()
+		sigCache.Add(*entry.msg, entry.sig, entry.key)
+		entries = append(entries, entry)
+	}
+	for _, entry := range entries[:10] {
+		if !sigCache.Exists(*entry.msg, entry.sig, entry.key) {
+			t.Fatalf("previously added item not found in signature cache")
+		}
+	}
+	// Replace the whole cache worth of entries with new ones.
+	for i := uint(0); i < sigCacheSize; i++ {
+		entry := genTriplet()
+		sigCache.Add(*entry.msg, entry.sig, entry.key)
+	}
+	for i, entry := range entries {
+		found := sigCache.Exists(*entry.msg, entry.sig, entry.key)
+		if i < 10 && !found {
+			t.Errorf("hot entry %d was evicted from the signature cache", i)
+		}
+		if i >= 10 && found {
+			t.Errorf("cold entry %d was not evicted from the signature cache", i)
+		}
+	}
+	stats := sigCache.Stats()
+	if stats.HotEntries != 10 || stats.HotEntries+stats.ColdEntries != int(sigCacheSize) {
+		t.Errorf("unexpected entries in the signature cache: %d hot and %d cold", stats.HotEntries, stats.ColdEntries)
+	}
+	if stats.HotBytes+stats.ColdBytes > stats.MaxBytes {
+		t.Errorf("signature cache uses %d bytes, more than its %d", stats.HotBytes+stats.ColdBytes, stats.MaxBytes)
+	}
+	if stats.Hits != 20 || stats.Misses != uint64(sigCacheSize)-10 || stats.Promotions != 10 ||
+		stats.Evictions != uint64(sigCacheSize) {
+		t.Errorf("unexpected signature cache counters: %+v", stats)
+	}
+}
+
+// TestSigCacheConcurrentExists tests that entries can be looked up while others are added from other goroutines, and
+// that every lookup is counted.
+func TestSigCacheConcurrentExists(t *testing.T) {
+	sigCacheSize := uint(40)
+	sigCache := NewSigCache(sigCacheSize * SigCacheEntrySize)
+	type triplet struct {
+		msg *chainhash.Hash
+		sig *ecc.Signature
+		key *ecc.PublicKey
+	}
+	var entries []triplet
+	for i := uint(0); i < sigCacheSize; i++ {
+		msg, sig, key, e := genRandomSig()
+		if e != nil {
+			t.Fatalf("unable to generate random signature test data")
+		}
+		entries = append(entries, triplet{msg, sig, key})
+	}
+	for _, entry := range entries[:sigCacheSize/2] {
+		sigCache.Add(*entry.msg, entry.sig, entry.key)
+	}
+	const lookups = 200
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < lookups; i++ {
+				entry := entries[(g*7+i)%len(entries)]
+				sigCache.Exists(*entry.msg, entry.sig, entry.key)
+			}
+		}(g)
+	}
+	for _, entry := range entries[sigCacheSize/2:] {
+		sigCache.Add(*entry.msg, entry.sig, entry.key)
+	}
+	wg.Wait()
+	stats := sigCache.Stats()
+	if stats.Hits+stats.Misses != 4*lookups {
+		t.Errorf("%d hits and %d misses counted for %d lookups", stats.Hits, stats.Misses, 4*lookups)
+	}
+	if stats.HotBytes+stats.ColdBytes > stats.MaxBytes {
+		t.Errorf("signature cache uses %d bytes, more than its %d", stats.HotBytes+stats.ColdBytes, stats.MaxBytes)
+	}
+	for i, entry := range entries {
+		if !sigCache.Exists(*entry.msg, entry.sig, entry.key) {
+			t.Errorf("entry %d not found in signature cache", i)
+		}
+	}
+}
+
+// TestSigCacheMaxBytes tests that signature cache sizes given as numbers of entries are converted to bytes, and that
+// larger sizes are taken as bytes.
+func TestSigCacheMaxBytes(t *testing.T) {
+	tests := []struct {
+		size uint
+		want uint
+	}{
+		{0, 0},
+		{100000, 100000 * SigCacheEntrySize},
+		{SigCacheMaxEntriesLimit, SigCacheMaxEntriesLimit * SigCacheEntrySize},
+		{SigCacheMaxEntriesLimit + 1, SigCacheMaxEntriesLimit + 1},
+		{32 << 20, 32 << 20},
+	}
+	for _, test := range tests {
+		if got := SigCacheMaxBytes(test.size); got != test.want {
+			t.Errorf("SigCacheMaxBytes(%d): got %d, want %d", test.size, got, test.want)
+		}
+	}
+}
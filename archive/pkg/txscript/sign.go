@@ -203,6 +203,32 @@ func sign(
 	case NullDataTy:
 		return nil, class, nil, 0,
 			errors.New("can't sign NULLDATA transactions")
+	case NonStandardTy:
+		// A time locked script is signed like the script of the key it pays to, once the transaction has the lock time
+		// and sequence that satisfy the lock.
+		tl := ExtractTimeLock(subScript)
+		if tl == nil {
+			return nil, class, nil, 0,
+				errors.New("can't sign unknown transactions")
+		}
+		addr, e := btcaddr.NewPubKeyHash(tl.PubKeyHash, chainParams)
+		if e != nil {
+			return nil, class, nil, 0, e
+		}
+		key, compressed, e := kdb.GetKey(addr)
+		if e != nil {
+			return nil, class, nil, 0, e
+		}
+		var script []byte
+		if tl.PubKey != nil {
+			script, e = p2pkSignatureScript(tx, idx, subScript, hashType, key)
+		} else {
+			script, e = SignatureScript(tx, idx, subScript, hashType, key, compressed)
+		}
+		if e != nil {
+			return nil, class, nil, 0, e
+		}
+		return script, class, nil, 0, nil
 	default:
 		return nil, class, nil, 0,
 			errors.New("can't sign unknown transactions")
@@ -338,11 +338,11 @@ func payToPubKeyHashScript(pubKeyHash []byte) ([]byte, error) {
 		Script()
 }
 
-// // payToWitnessPubKeyHashScript creates a new script to pay to a version 0
-// // pubkey hash witness program. The passed hash is expected to be valid.
-// func payToWitnessPubKeyHashScript(pubKeyHash []byte) ([]byte, error) {
-// 	return NewScriptBuilder().AddOp(OP_0).AddData(pubKeyHash).Script()
-// }
+// payToWitnessPubKeyHashScript creates a new script to pay to a version 0
+// pubkey hash witness program. The passed hash is expected to be valid.
+func payToWitnessPubKeyHashScript(pubKeyHash []byte) ([]byte, error) {
+	return NewScriptBuilder().AddOp(OP_0).AddData(pubKeyHash).Script()
+}
 
 // payToScriptHashScript creates a new script to pay a transaction output to a
 // script hash. It is expected that the input is a valid hash.
@@ -351,7 +351,7 @@ func payToScriptHashScript(scriptHash []byte) ([]byte, error) {
 		AddOp(OP_EQUAL).Script()
 }
 
-// payToWitnessPubKeyHashScript creates a new script to pay to a version 0
+// payToWitnessScriptHashScript creates a new script to pay to a version 0
 // script hash witness program. The passed hash is expected to be valid.
 func payToWitnessScriptHashScript(scriptHash []byte) ([]byte, error) {
 	return NewScriptBuilder().AddOp(OP_0).AddData(scriptHash).Script()
@@ -392,18 +392,22 @@ func PayToAddrScript(addr btcaddr.Address) ([]byte, error) {
 			)
 		}
 		return payToPubKeyScript(addr.ScriptAddress())
-		// case *util.AddressWitnessPubKeyHash:
-		// 	if addr == nil {
-		// 		return nil, scriptError(ErrUnsupportedAddress,
-		// 			nilAddrErrStr)
-		// 	}
-		// 	return payToWitnessPubKeyHashScript(addr.ScriptAddress())
-		// case *util.AddressWitnessScriptHash:
-		// 	if addr == nil {
-		// 		return nil, scriptError(ErrUnsupportedAddress,
-		// 			nilAddrErrStr)
-		// 	}
-		// 	return payToWitnessScriptHashScript(addr.ScriptAddress())
+	case *btcaddr.WitnessPubKeyHash:
+		if addr == nil {
+			return nil, scriptError(
+				ErrUnsupportedAddress,
+				nilAddrErrStr,
+			)
+		}
+		return payToWitnessPubKeyHashScript(addr.ScriptAddress())
+	case *btcaddr.WitnessScriptHash:
+		if addr == nil {
+			return nil, scriptError(
+				ErrUnsupportedAddress,
+				nilAddrErrStr,
+			)
+		}
+		return payToWitnessScriptHashScript(addr.ScriptAddress())
 	}
 	str := fmt.Sprintf(
 		"unable to generate payment script for unsupported "+
@@ -0,0 +1,342 @@
+package upnp
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the protocols a NAT maps ports with, returned by its Protocol method.
+const (
+	ProtocolUPnP   = "upnp"
+	ProtocolNATPMP = "natpmp"
+	ProtocolPCP    = "pcp"
+)
+
+// pmpPort is the port NAT-PMP and PCP servers listen on at the gateway.
+const pmpPort = 5351
+
+// Versions, opcodes and result codes of NAT-PMP (RFC 6886) and PCP (RFC 6887).
+const (
+	natPMPVersion    = 0
+	pcpVersion       = 2
+	natPMPOpExternal = 0
+	natPMPOpMapUDP   = 1
+	natPMPOpMapTCP   = 2
+	pcpOpAnnounce    = 0
+	pcpOpMap         = 1
+	pmpResponseBit   = 0x80
+	pmpResultSuccess = 0
+)
+
+// DiscoverAny searches the local network for a UPnP router, and failing that for a gateway that maps ports with PCP or
+// NAT-PMP, returning a NAT for the first one found.
+func DiscoverAny() (nat NAT, e error) {
+	if nat, e = Discover(); e == nil {
+		return
+	}
+	D.Ln("no UPnP router found, trying PCP and NAT-PMP:", e)
+	return DiscoverPMP()
+}
+
+// pmpNAT implements NAT with PCP, or with NAT-PMP if the gateway does not speak PCP.
+type pmpNAT struct {
+	gateway net.IP
+	ourIP   net.IP
+	pcp     bool
+	// nonce identifies the PCP mappings of this client, so that they can be renewed and deleted.
+	nonce [12]byte
+	// mtx protects external, which is the external address the gateway gave with the last PCP mapping, as PCP has no
+	// request for it.
+	mtx      sync.Mutex
+	external net.IP
+}
+
+// DiscoverPMP looks for a PCP or NAT-PMP server at the default gateway, returning a NAT for it if there is one.
+func DiscoverPMP() (nat NAT, e error) {
+	var gateway net.IP
+	if gateway, e = defaultGateway(); E.Chk(e) {
+		return
+	}
+	n := &pmpNAT{gateway: gateway}
+	if _, e = rand.Read(n.nonce[:]); E.Chk(e) {
+		return
+	}
+	var conn net.Conn
+	if conn, e = net.Dial("udp4", net.JoinHostPort(gateway.String(), strconv.Itoa(pmpPort))); E.Chk(e) {
+		return
+	}
+	n.ourIP = conn.LocalAddr().(*net.UDPAddr).IP
+	if e = conn.Close(); E.Chk(e) {
+	}
+	// A PCP server answers an announce request, and a NAT-PMP server answers it with an unsupported version error in
+	// the NAT-PMP format.
+	var response []byte
+	if response, e = n.request(pcpRequest(pcpOpAnnounce, 0, n.ourIP, nil)); e != nil {
+		return nil, fmt.Errorf("no PCP or NAT-PMP server at gateway %v: %v", gateway, e)
+	}
+	if response[0] == pcpVersion {
+		if e = pcpResult(response); E.Chk(e) {
+			return
+		}
+		n.pcp = true
+		I.Ln("found PCP server at gateway", gateway)
+		return n, nil
+	}
+	if _, e = n.GetExternalAddress(); E.Chk(e) {
+		return
+	}
+	I.Ln("found NAT-PMP server at gateway", gateway)
+	return n, nil
+}
+
+// Protocol implements the NAT interface.
+func (n *pmpNAT) Protocol() string {
+	if n.pcp {
+		return ProtocolPCP
+	}
+	return ProtocolNATPMP
+}
+
+// GetExternalAddress implements the NAT interface. With PCP it is only known once a port is mapped.
+func (n *pmpNAT) GetExternalAddress() (addr net.IP, e error) {
+	if n.pcp {
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		if n.external == nil {
+			return nil, errors.New("the external address is not known until a port is mapped")
+		}
+		return n.external, nil
+	}
+	var response []byte
+	if response, e = n.request([]byte{natPMPVersion, natPMPOpExternal}); E.Chk(e) {
+		return
+	}
+	if e = natPMPResult(response, natPMPOpExternal, 12); E.Chk(e) {
+		return
+	}
+	return net.IPv4(response[8], response[9], response[10], response[11]), nil
+}
+
+// AddPortMapping implements the NAT interface. The gateway may map another external port than the one asked for.
+func (n *pmpNAT) AddPortMapping(
+	protocol string, externalPort, internalPort int, description string, timeout int,
+) (mappedExternalPort int, e error) {
+	return n.mapPort(protocol, externalPort, internalPort, timeout)
+}
+
+// DeletePortMapping implements the NAT interface by asking for a mapping with no lifetime.
+func (n *pmpNAT) DeletePortMapping(protocol string, externalPort, internalPort int) (e error) {
+	_, e = n.mapPort(protocol, 0, internalPort, 0)
+	return
+}
+
+// mapPort asks the gateway to map the internal port for the lifetime in seconds, deleting the mapping if it is zero.
+func (n *pmpNAT) mapPort(protocol string, externalPort, internalPort, lifetime int) (mapped int, e error) {
+	var response []byte
+	if n.pcp {
+		request := pcpRequest(
+			pcpOpMap, uint32(lifetime), n.ourIP,
+			pcpMapPayload(n.nonce, protocol, uint16(internalPort), uint16(externalPort)),
+		)
+		if response, e = n.request(request); E.Chk(e) {
+			return
+		}
+		var external net.IP
+		if mapped, external, e = parsePCPMap(response, n.nonce); E.Chk(e) {
+			return
+		}
+		if lifetime > 0 {
+			n.mtx.Lock()
+			n.external = external
+			n.mtx.Unlock()
+		}
+		return
+	}
+	op := byte(natPMPOpMapTCP)
+	if strings.ToLower(protocol) == "udp" {
+		op = natPMPOpMapUDP
+	}
+	request := make([]byte, 12)
+	request[1] = op
+	binary.BigEndian.PutUint16(request[4:], uint16(internalPort))
+	binary.BigEndian.PutUint16(request[6:], uint16(externalPort))
+	binary.BigEndian.PutUint32(request[8:], uint32(lifetime))
+	if response, e = n.request(request); E.Chk(e) {
+		return
+	}
+	if e = natPMPResult(response, op, 16); E.Chk(e) {
+		return
+	}
+	return int(binary.BigEndian.Uint16(response[10:])), nil
+}
+
+// request sends the request to the gateway and returns the response, resending it with a doubling timeout from 250ms
+// as both protocols ask, up to 4 times.
+func (n *pmpNAT) request(request []byte) (response []byte, e error) {
+	var conn *net.UDPConn
+	if conn, e = net.DialUDP("udp4", nil, &net.UDPAddr{IP: n.gateway, Port: pmpPort}); E.Chk(e) {
+		return
+	}
+	defer func() {
+		if e := conn.Close(); E.Chk(e) {
+		}
+	}()
+	buf := make([]byte, 1100)
+	timeout := 250 * time.Millisecond
+	for i := 0; i < 4; i++ {
+		if _, e = conn.Write(request); E.Chk(e) {
+			return
+		}
+		if e = conn.SetReadDeadline(time.Now().Add(timeout)); E.Chk(e) {
+			return
+		}
+		var length int
+		if length, e = conn.Read(buf); e != nil {
+			timeout *= 2
+			continue
+		}
+		if length < 4 {
+			continue
+		}
+		return buf[:length], nil
+	}
+	return nil, fmt.Errorf("no response from gateway %v: %v", n.gateway, e)
+}
+
+// natPMPResult checks a NAT-PMP response is of the right length and to the opcode, and returns its result code as an
+// error if it is not success.
+func natPMPResult(response []byte, op byte, length int) error {
+	if response[0] != natPMPVersion || response[1] != op|pmpResponseBit {
+		return fmt.Errorf("unexpected NAT-PMP response version %d opcode %d", response[0], response[1])
+	}
+	if result := binary.BigEndian.Uint16(response[2:]); result != pmpResultSuccess {
+		return fmt.Errorf("NAT-PMP request failed with result code %d", result)
+	}
+	if len(response) < length {
+		return fmt.Errorf("NAT-PMP response is %d bytes, want %d", len(response), length)
+	}
+	return nil
+}
+
+// pcpRequest returns a PCP request with the opcode and lifetime from the client address, followed by the opcode
+// specific payload.
+func pcpRequest(op byte, lifetime uint32, clientIP net.IP, payload []byte) []byte {
+	request := make([]byte, 24, 24+len(payload))
+	request[0] = pcpVersion
+	request[1] = op
+	binary.BigEndian.PutUint32(request[4:], lifetime)
+	copy(request[8:24], clientIP.To16())
+	return append(request, payload...)
+}
+
+// pcpMapPayload returns the payload of a PCP map request for the internal port, suggesting the external port if it is
+// not zero. Requests with the same nonce renew and delete the same mapping.
+func pcpMapPayload(nonce [12]byte, protocol string, internalPort, externalPort uint16) []byte {
+	payload := make([]byte, 36)
+	copy(payload, nonce[:])
+	payload[12] = 6
+	if strings.ToLower(protocol) == "udp" {
+		payload[12] = 17
+	}
+	binary.BigEndian.PutUint16(payload[16:], internalPort)
+	binary.BigEndian.PutUint16(payload[18:], externalPort)
+	// Any external address is asked for with the IPv4 unspecified address in its IPv6 mapped form.
+	copy(payload[20:], net.IPv4zero.To16())
+	return payload
+}
+
+// pcpResult returns the result code of a PCP response as an error if it is not success.
+func pcpResult(response []byte) error {
+	if len(response) < 24 {
+		return fmt.Errorf("PCP response is %d bytes, too short for its header", len(response))
+	}
+	if response[1]&pmpResponseBit == 0 {
+		return errors.New("PCP response is a request")
+	}
+	if result := response[3]; result != pmpResultSuccess {
+		return fmt.Errorf("PCP request failed with result code %d", result)
+	}
+	return nil
+}
+
+// parsePCPMap returns the external port and address of the mapping in a response to a PCP map request with the nonce.
+func parsePCPMap(response []byte, nonce [12]byte) (port int, external net.IP, e error) {
+	if e = pcpResult(response); e != nil {
+		return
+	}
+	if response[0] != pcpVersion || response[1] != pcpOpMap|pmpResponseBit || len(response) < 60 {
+		return 0, nil, errors.New("unexpected PCP map response")
+	}
+	payload := response[24:]
+	if string(payload[:12]) != string(nonce[:]) {
+		return 0, nil, errors.New("PCP map response is for another client")
+	}
+	port = int(binary.BigEndian.Uint16(payload[18:]))
+	external = net.IP(append([]byte{}, payload[20:36]...))
+	if ip4 := external.To4(); ip4 != nil {
+		external = ip4
+	}
+	return
+}
+
+// defaultGateway returns the address of the default IPv4 gateway from the routing table, or, where that cannot be read,
+// guesses it is the first address of the network of the local address.
+func defaultGateway() (gateway net.IP, e error) {
+	if gateway = routeTableGateway(); gateway != nil {
+		return
+	}
+	var ourIP string
+	if ourIP, e = getOurIP(); E.Chk(e) {
+		return
+	}
+	ip := net.ParseIP(ourIP).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("cannot guess the gateway of %s", ourIP)
+	}
+	return net.IPv4(ip[0], ip[1], ip[2], 1), nil
+}
+
+// routeTableGateway returns the default gateway in the Linux routing table, or nil if there is none or it cannot be
+// read.
+func routeTableGateway() net.IP {
+	f, e := os.Open("/proc/net/route")
+	if e != nil {
+		return nil
+	}
+	defer func() {
+		if e := f.Close(); E.Chk(e) {
+		}
+	}()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if gateway := parseRouteLine(scanner.Text()); gateway != nil {
+			return gateway
+		}
+	}
+	return nil
+}
+
+// parseRouteLine returns the gateway of a line of the Linux routing table if it is the default route.
+func parseRouteLine(line string) net.IP {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[1] != "00000000" {
+		return nil
+	}
+	gw, e := strconv.ParseUint(fields[2], 16, 32)
+	if e != nil || gw == 0 {
+		return nil
+	}
+	// The table has the addresses in host byte order, which is little endian on the hosts it is found on.
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(gw))
+	return net.IP(b)
+}
@@ -0,0 +1,79 @@
+package upnp
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+// TestPCPMap ensures a PCP map request carries the client address, ports and nonce, and that the response to it is
+// parsed for the mapped port and external address, while responses for other clients or with errors are refused.
+func TestPCPMap(t *testing.T) {
+	nonce := [12]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	clientIP := net.IPv4(192, 168, 1, 20)
+	request := pcpRequest(pcpOpMap, 1200, clientIP, pcpMapPayload(nonce, "tcp", 11047, 11047))
+	if len(request) != 60 || request[0] != pcpVersion || request[1] != pcpOpMap {
+		t.Fatalf("request is %x", request)
+	}
+	if binary.BigEndian.Uint32(request[4:]) != 1200 || !net.IP(request[8:24]).Equal(clientIP) {
+		t.Fatalf("request has lifetime %d from %v", binary.BigEndian.Uint32(request[4:]), net.IP(request[8:24]))
+	}
+	if request[36] != 6 || binary.BigEndian.Uint16(request[40:]) != 11047 {
+		t.Fatalf("request maps protocol %d port %d", request[36], binary.BigEndian.Uint16(request[40:]))
+	}
+	// The server answers with the request, its response bit set and the mapping it made.
+	response := append([]byte{}, request...)
+	response[1] |= pmpResponseBit
+	binary.BigEndian.PutUint16(response[42:], 21047)
+	copy(response[44:], net.IPv4(203, 0, 113, 7).To16())
+	port, external, e := parsePCPMap(response, nonce)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if port != 21047 || !external.Equal(net.IPv4(203, 0, 113, 7)) {
+		t.Fatalf("mapped %v:%d", external, port)
+	}
+	if _, _, e = parsePCPMap(response, [12]byte{}); e == nil {
+		t.Fatal("response for another client was accepted")
+	}
+	response[3] = 2
+	if _, _, e = parsePCPMap(response, nonce); e == nil {
+		t.Fatal("response with an error was accepted")
+	}
+}
+
+// TestNATPMPResult ensures NAT-PMP responses are checked for their opcode, result code and length.
+func TestNATPMPResult(t *testing.T) {
+	response := make([]byte, 16)
+	response[1] = natPMPOpMapTCP | pmpResponseBit
+	if e := natPMPResult(response, natPMPOpMapTCP, 16); e != nil {
+		t.Fatal(e)
+	}
+	if e := natPMPResult(response, natPMPOpMapUDP, 16); e == nil {
+		t.Fatal("response to another opcode was accepted")
+	}
+	if e := natPMPResult(response[:12], natPMPOpMapTCP, 16); e == nil {
+		t.Fatal("short response was accepted")
+	}
+	response[3] = 3
+	if e := natPMPResult(response, natPMPOpMapTCP, 16); e == nil {
+		t.Fatal("response with an error was accepted")
+	}
+}
+
+// TestParseRouteLine ensures the gateway of the default route is read from the Linux routing table.
+func TestParseRouteLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want net.IP
+	}{
+		{"eth0\t00000000\t0101A8C0\t0003\t0\t0\t100\t00000000\t0\t0\t0", net.IPv4(192, 168, 1, 1)},
+		{"eth0\t0001A8C0\t00000000\t0001\t0\t0\t100\t00FFFFFF\t0\t0\t0", nil},
+		{"Iface\tDestination\tGateway\tFlags", nil},
+	}
+	for _, test := range tests {
+		if got := parseRouteLine(test.line); !got.Equal(test.want) {
+			t.Errorf("%q: got %v, want %v", test.line, got, test.want)
+		}
+	}
+}
@@ -39,6 +39,8 @@ import (
 // NAT is an interface representing a NAT traversal options for example UPNP or NAT-PMP. It provides methods to query
 // and manipulate this traversal to allow access to services.
 type NAT interface {
+	// Protocol - The name of the protocol the NAT maps ports with, one of the Protocol constants.
+	Protocol() string
 	// GetExternalAddress - Get the external address from outside the NAT.
 	GetExternalAddress() (addr net.IP, e error)
 	// AddPortMapping - Add a port mapping for protocol (
@@ -341,6 +343,11 @@ type getExternalIPAddressResponse struct {
 	ExternalIPAddress string   `xml:"NewExternalIPAddress"`
 }
 
+// Protocol implements the NAT interface.
+func (n *upnpNAT) Protocol() string {
+	return ProtocolUPnP
+}
+
 // GetExternalAddress implements the NAT interface by fetching the external IP from the UPnP router.
 func (n *upnpNAT) GetExternalAddress() (addr net.IP, e error) {
 	message := "<u:GetExternalIPAddress xmlns:u=\"urn:schemas-upnp-org:service:WANIPConnection:1\"/>\r\n"
@@ -11,13 +11,13 @@ import (
 )
 
 // RunUnit handles correctly starting and stopping child processes that have StdConn pipe logging enabled, allowing
-// custom hooks to run on start and stop,
+// custom hooks to run on start and stop, and restarting them if they crash.
 type RunUnit struct {
 	name                  string
 	args                  []string
 	running, shuttingDown uberatomic.Bool
 	commandChan           chan bool
-	worker                *pipe.Worker
+	supervisor            *pipe.Supervisor
 	quit                  qu.C
 }
 
@@ -35,9 +35,30 @@ func New(
 	}
 	r.running.Store(false)
 	r.shuttingDown.Store(false)
+	// quit from rununit's quit, which closes after the main quit triggers stopping in the watcher loop
+	r.supervisor = pipe.NewSupervisor(
+		pipe.SupervisorConfig{
+			Name: name,
+			Spawn: func() (w *pipe.Worker, e error) {
+				w = pipe.LogConsume(r.quit, logger, pkgFilter, args...)
+				pipe.Start(w)
+				return
+			},
+			Stop: func(w *pipe.Worker) (e error) {
+				pipe.Kill(w)
+				return
+			},
+			Policy: pipe.DefaultRestartPolicy,
+			Report: func(st pipe.Status) {
+				// when the process keeps crashing it is given up on, and the unit is stopped
+				if st.State == pipe.StateFailed && r.running.Swap(false) {
+					stop()
+				}
+			},
+		}, r.quit,
+	)
 	go func() {
 		D.Ln("run unit command loop", args)
-		var e error
 	out:
 		for {
 			select {
@@ -49,14 +70,8 @@ func New(
 						D.Ln("already running", args)
 						continue
 					}
-					if r.worker != nil {
-						if e = r.worker.Kill(); E.Chk(e) {
-						}
-					}
-					// quit from rununit's quit, which closes after the main quit triggers stopping in the watcher loop
-					r.worker = pipe.LogConsume(r.quit, logger, pkgFilter, args...)
-					// D.S(r.worker)
-					pipe.Start(r.worker)
+					r.supervisor.Stop()
+					r.supervisor.Start()
 					r.running.Store(true)
 					run()
 					// D.Ln(r.running.Load())
@@ -67,10 +82,7 @@ func New(
 						D.Ln("wasn't running", args)
 						continue
 					}
-					pipe.Kill(r.worker)
-					// var e error
-					// if e = r.worker.Wait(); E.Chk(e) {
-					// }
+					r.supervisor.Stop()
 					r.running.Store(false)
 					stop()
 					D.Ln(args, "after stop", r.running.Load())
@@ -94,10 +106,7 @@ func New(
 				break out
 			}
 			// r.quit.Q()
-			pipe.Kill(r.worker)
-			var e error
-			if e = r.worker.Wait(); E.Chk(e) {
-			}
+			r.supervisor.Stop()
 			r.running.Store(false)
 			stop()
 			D.Ln(args, "after stop", r.running.Load())
@@ -116,6 +125,11 @@ func (r *RunUnit) Running() bool {
 	return r.running.Load()
 }
 
+// Status returns the status of the child process
+func (r *RunUnit) Status() pipe.Status {
+	return r.supervisor.Status()
+}
+
 // Start signals the run unit to start
 func (r *RunUnit) Start() {
 	r.commandChan <- true
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	ec "github.com/p9c/pod/pkg/ecc"
+	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/hdkeychain"
 	"github.com/p9c/pod/pkg/util/zero"
@@ -29,14 +30,14 @@ const (
 	// indicates that a scoped manager with this address type shouldn't be consulted
 	// during historical rescans.
 	RawPubKey
-	// // NestedWitnessPubKey represents a p2wkh output nested within a p2sh output.
-	// // Using this address type, the wallet can receive funds from other wallet's
-	// // which don't yet recognize the new segwit standard output types. Receiving
-	// // funds to this address maintains the scalability, and malleability fixes due
-	// // to segwit in a backwards compatible manner.
-	// NestedWitnessPubKey
-	// // WitnessPubKey represents a p2wkh (pay-to-witness-key-hash) address type.
-	// WitnessPubKey
+	// NestedWitnessPubKey represents a p2wkh output nested within a p2sh output.
+	// Using this address type, the wallet can receive funds from other wallet's
+	// which don't yet recognize the new segwit standard output types. Receiving
+	// funds to this address maintains the scalability, and malleability fixes due
+	// to segwit in a backwards compatible manner.
+	NestedWitnessPubKey
+	// WitnessPubKey represents a p2wkh (pay-to-witness-key-hash) address type.
+	WitnessPubKey
 )
 
 // ManagedAddress is an interface that provides access to information regarding
@@ -180,8 +181,8 @@ func (a *managedAddress) AddrHash() []byte {
 		hash = n.Hash160()[:]
 	case *btcaddr.ScriptHash:
 		hash = n.Hash160()[:]
-		// case *util.AddressWitnessPubKeyHash:
-		// 	hash = n.Hash160()[:]
+	case *btcaddr.WitnessPubKeyHash:
+		hash = n.Hash160()[:]
 	}
 	return hash
 }
@@ -318,44 +319,44 @@ func newManagedAddressWithoutPrivKey(
 	var address btcaddr.Address
 	var e error
 	switch addrType {
-	// case NestedWitnessPubKey:
-	// // For this address type we'l generate an address which is backwards compatible
-	// // to Bitcoin nodes running 0.6.0 onwards, but allows us to take advantage of
-	// // segwit's scripting improvements, and malleability fixes.
-	// //
-	// // First, we'll generate a normal p2wkh address from the pubkey hash.
-	// var witAddr *util.AddressWitnessPubKeyHash
-	// if witAddr, e = util.NewAddressWitnessPubKeyHash(
-	// 	pubKeyHash, m.rootManager.chainParams,
-	// ); E.Chk(e) {
-	// 	return nil, e
-	// }
-	// // Next we'll generate the witness program which can be used as a pkScript to
-	// // pay to this generated address.
-	// var witnessProgram []byte
-	// if witnessProgram, e = txscript.PayToAddrScript(witAddr); E.Chk(e) {
-	// 	return nil, e
-	// }
-	// // Finally, we'll use the witness program itself as the pre-image to a p2sh
-	// // address. In order to spend, we first use the witnessProgram as the sigScript,
-	// // then present the proper <sig, pubkey> pair as the witness.
-	// if address, e = util.NewScriptHash(
-	// 	witnessProgram, m.rootManager.chainParams,
-	// ); E.Chk(e) {
-	// 	return nil, e
-	// }
+	case NestedWitnessPubKey:
+		// For this address type we'l generate an address which is backwards compatible
+		// to Bitcoin nodes running 0.6.0 onwards, but allows us to take advantage of
+		// segwit's scripting improvements, and malleability fixes.
+		//
+		// First, we'll generate a normal p2wkh address from the pubkey hash.
+		var witAddr *btcaddr.WitnessPubKeyHash
+		if witAddr, e = btcaddr.NewWitnessPubKeyHash(
+			pubKeyHash, m.rootManager.chainParams,
+		); E.Chk(e) {
+			return nil, e
+		}
+		// Next we'll generate the witness program which can be used as a pkScript to
+		// pay to this generated address.
+		var witnessProgram []byte
+		if witnessProgram, e = txscript.PayToAddrScript(witAddr); E.Chk(e) {
+			return nil, e
+		}
+		// Finally, we'll use the witness program itself as the pre-image to a p2sh
+		// address. In order to spend, we first use the witnessProgram as the sigScript,
+		// then present the proper <sig, pubkey> pair as the witness.
+		if address, e = btcaddr.NewScriptHash(
+			witnessProgram, m.rootManager.chainParams,
+		); E.Chk(e) {
+			return nil, e
+		}
 	case PubKeyHash:
 		if address, e = btcaddr.NewPubKeyHash(
 			pubKeyHash, m.rootManager.chainParams,
 		); E.Chk(e) {
 			return nil, e
 		}
-		// case WitnessPubKey:
-		// 	if address, e = util.NewAddressWitnessPubKeyHash(
-		// 		pubKeyHash, m.rootManager.chainParams,
-		// 	); E.Chk(e) {
-		// 		return nil, e
-		// 	}
+	case WitnessPubKey:
+		if address, e = btcaddr.NewWitnessPubKeyHash(
+			pubKeyHash, m.rootManager.chainParams,
+		); E.Chk(e) {
+			return nil, e
+		}
 	}
 	return &managedAddress{
 		manager:          m,
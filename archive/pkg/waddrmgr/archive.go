@@ -0,0 +1,109 @@
+package waddrmgr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// archivedAcctBucketName is the bucket below the scope's meta bucket that stores the archived accounts of the scope,
+// keyed by account number, with the time they were archived. It is created when the first account is archived.
+var archivedAcctBucketName = []byte("archivedaccts")
+
+// ArchiveAccount archives an account, which hides it from the listings and the total balance of the wallet. Nothing of
+// the account is removed: its addresses are still watched, rescanned and can be spent from, and it can be unarchived
+// at any time. The default and imported accounts can't be archived.
+func (s *ScopedKeyManager) ArchiveAccount(ns walletdb.ReadWriteBucket, account uint32) (e error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if account == DefaultAccountNum || isReservedAccountNum(account) {
+		str := "the default and imported accounts cannot be archived"
+		return managerError(ErrInvalidAccount, str, nil)
+	}
+	if _, e = fetchAccountInfo(ns, &s.scope, account); E.Chk(e) {
+		return
+	}
+	var scopedBucket walletdb.ReadWriteBucket
+	if scopedBucket, e = fetchWriteScopeBucket(ns, &s.scope); E.Chk(e) {
+		return
+	}
+	var bucket walletdb.ReadWriteBucket
+	bucket, e = scopedBucket.NestedReadWriteBucket(metaBucketName).
+		CreateBucketIfNotExists(archivedAcctBucketName)
+	if E.Chk(e) {
+		str := "failed to create archived account bucket"
+		return managerError(ErrDatabase, str, e)
+	}
+	if bucket.Get(uint32ToBytes(account)) != nil {
+		return nil
+	}
+	v := make([]byte, 8)
+	binary.LittleEndian.PutUint64(v, uint64(time.Now().Unix()))
+	if e = bucket.Put(uint32ToBytes(account), v); E.Chk(e) {
+		str := fmt.Sprintf("failed to archive account %d", account)
+		return managerError(ErrDatabase, str, e)
+	}
+	return nil
+}
+
+// UnarchiveAccount restores an archived account to the listings and the total balance of the wallet. Unarchiving an
+// account that is not archived does nothing.
+func (s *ScopedKeyManager) UnarchiveAccount(ns walletdb.ReadWriteBucket, account uint32) (e error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, e = fetchAccountInfo(ns, &s.scope, account); E.Chk(e) {
+		return
+	}
+	var scopedBucket walletdb.ReadWriteBucket
+	if scopedBucket, e = fetchWriteScopeBucket(ns, &s.scope); E.Chk(e) {
+		return
+	}
+	bucket := scopedBucket.NestedReadWriteBucket(metaBucketName).
+		NestedReadWriteBucket(archivedAcctBucketName)
+	if bucket == nil {
+		return nil
+	}
+	if e = bucket.Delete(uint32ToBytes(account)); E.Chk(e) {
+		str := fmt.Sprintf("failed to unarchive account %d", account)
+		return managerError(ErrDatabase, str, e)
+	}
+	return nil
+}
+
+// ArchivedAccounts returns the archived accounts of the scope with the time they were archived.
+func (s *ScopedKeyManager) ArchivedAccounts(ns walletdb.ReadBucket) (archived map[uint32]time.Time, e error) {
+	archived = make(map[uint32]time.Time)
+	var scopedBucket walletdb.ReadBucket
+	if scopedBucket, e = fetchReadScopeBucket(ns, &s.scope); E.Chk(e) {
+		return nil, e
+	}
+	bucket := scopedBucket.NestedReadBucket(metaBucketName).
+		NestedReadBucket(archivedAcctBucketName)
+	if bucket == nil {
+		return
+	}
+	e = bucket.ForEach(
+		func(k, v []byte) error {
+			if len(k) != 4 || len(v) != 8 {
+				str := fmt.Sprintf("malformed archived account %x", k)
+				return managerError(ErrDatabase, str, nil)
+			}
+			archived[binary.LittleEndian.Uint32(k)] = time.Unix(int64(binary.LittleEndian.Uint64(v)), 0)
+			return nil
+		},
+	)
+	return
+}
+
+// isAccountArchived returns whether the account is archived.
+func isAccountArchived(ns walletdb.ReadBucket, scope *KeyScope, account uint32) bool {
+	scopedBucket, e := fetchReadScopeBucket(ns, scope)
+	if e != nil {
+		return false
+	}
+	bucket := scopedBucket.NestedReadBucket(metaBucketName).
+		NestedReadBucket(archivedAcctBucketName)
+	return bucket != nil && bucket.Get(uint32ToBytes(account)) != nil
+}
@@ -13,7 +13,7 @@ import (
 
 const (
 	// LatestMgrVersion is the most recent manager version.
-	LatestMgrVersion = 5
+	LatestMgrVersion = 6
 	
 	// latestMgrVersion is the most recent manager version as a variable so the
 	// tests can change it to force errors.
@@ -146,6 +146,7 @@ var (
 	// scopeBucket -> scope -> acctIDIdxBucketName
 	// scopeBucket -> scope -> metaBucket
 	// scopeBucket -> scope -> metaBucket -> lastAccountNameKey
+	// scopeBucket -> scope -> metaBucket -> changeLeaseBucket
 	// scopeBucket -> scope -> coinTypePrivKey
 	// scopeBucket -> scope -> coinTypePubKey
 	scopeBucketName = []byte("scope")
@@ -199,6 +200,10 @@ var (
 	metaBucketName = []byte("meta")
 	// lastAccountName is used to store the metadata - last account in the manager
 	lastAccountName = []byte("lastaccount")
+	// changeLeaseBucketName is the bucket below the scope's meta bucket that
+	// stores the change addresses reserved for transactions under construction,
+	// keyed by address hash. It is created when the first lease is taken.
+	changeLeaseBucketName = []byte("changelease")
 	// mainBucketName is the name of the bucket that stores the encrypted crypto
 	// keys that encrypt all other generated keys, the watch only flag, the master
 	// private key (encrypted), the master HD private key (encrypted), and also
@@ -225,6 +230,8 @@ var (
 	cryptoPubKeyName    = []byte("cpub")
 	cryptoScriptKeyName = []byte("cscript")
 	watchingOnlyName    = []byte("watchonly")
+	// Wallet setting key names (main bucket).
+	defaultAddrTypeName = []byte("defaultaddrtype")
 	// Sync related key names (sync bucket).
 	syncedToName   = []byte("syncedto")
 	startBlockName = []byte("startblock")
@@ -607,6 +614,29 @@ func putWatchingOnly(ns walletdb.ReadWriteBucket, watchingOnly bool) (e error) {
 	return nil
 }
 
+// fetchDefaultAddressType loads the default address type of the wallet from the
+// database. An empty string is returned if none has been set.
+func fetchDefaultAddressType(ns walletdb.ReadBucket) string {
+	bucket := ns.NestedReadBucket(mainBucketName)
+	return string(bucket.Get(defaultAddrTypeName))
+}
+
+// putDefaultAddressType stores the default address type of the wallet to the
+// database. An empty address type removes the setting.
+func putDefaultAddressType(ns walletdb.ReadWriteBucket, addressType string) (e error) {
+	bucket := ns.NestedReadWriteBucket(mainBucketName)
+	if addressType == "" {
+		e = bucket.Delete(defaultAddrTypeName)
+	} else {
+		e = bucket.Put(defaultAddrTypeName, []byte(addressType))
+	}
+	if E.Chk(e) {
+		str := "failed to store default address type"
+		return managerError(ErrDatabase, str, e)
+	}
+	return nil
+}
+
 // deserializeAccountRow deserializes the passed serialized account information.
 // This is used as a common base for the various account types to deserialize
 // the common parts.
@@ -1261,6 +1291,131 @@ func fetchAddressUsed(ns walletdb.ReadBucket, scope *KeyScope, addressID []byte)
 	return bucket.Get(addrHash[:]) != nil
 }
 
+// dbChangeLeaseRow houses a change address reservation as stored in the
+// database.
+type dbChangeLeaseRow struct {
+	account   uint32
+	expiry    int64
+	addressID []byte
+}
+
+// serializeChangeLease returns the serialization of the passed change lease.
+func serializeChangeLease(row *dbChangeLeaseRow) []byte {
+	// The serialized change lease format is:
+	//
+	//   <account><expiry><addressID>
+	//
+	// 4 bytes account + 8 bytes expiry unix time + address id
+	buf := make([]byte, 12+len(row.addressID))
+	binary.LittleEndian.PutUint32(buf[0:4], row.account)
+	binary.LittleEndian.PutUint64(buf[4:12], uint64(row.expiry))
+	copy(buf[12:], row.addressID)
+	return buf
+}
+
+// deserializeChangeLease deserializes the passed serialized change lease.
+func deserializeChangeLease(serializedLease []byte) (*dbChangeLeaseRow, error) {
+	if len(serializedLease) < 12 {
+		str := fmt.Sprintf("malformed serialized change lease: %x", serializedLease)
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	row := &dbChangeLeaseRow{
+		account:   binary.LittleEndian.Uint32(serializedLease[0:4]),
+		expiry:    int64(binary.LittleEndian.Uint64(serializedLease[4:12])),
+		addressID: make([]byte, len(serializedLease)-12),
+	}
+	copy(row.addressID, serializedLease[12:])
+	return row, nil
+}
+
+// fetchChangeLease returns the lease held on the provided address id, or nil if
+// there is none.
+func fetchChangeLease(
+	ns walletdb.ReadBucket, scope *KeyScope,
+	addressID []byte,
+) (*dbChangeLeaseRow, error) {
+	scopedBucket, e := fetchReadScopeBucket(ns, scope)
+	if E.Chk(e) {
+		return nil, e
+	}
+	bucket := scopedBucket.NestedReadBucket(metaBucketName).
+		NestedReadBucket(changeLeaseBucketName)
+	if bucket == nil {
+		return nil, nil
+	}
+	addrHash := sha256.Sum256(addressID)
+	val := bucket.Get(addrHash[:])
+	if val == nil {
+		return nil, nil
+	}
+	return deserializeChangeLease(val)
+}
+
+// fetchChangeLeases returns every change lease stored for the scope.
+func fetchChangeLeases(ns walletdb.ReadBucket, scope *KeyScope) (leases []*dbChangeLeaseRow, e error) {
+	var scopedBucket walletdb.ReadBucket
+	if scopedBucket, e = fetchReadScopeBucket(ns, scope); E.Chk(e) {
+		return nil, e
+	}
+	bucket := scopedBucket.NestedReadBucket(metaBucketName).
+		NestedReadBucket(changeLeaseBucketName)
+	if bucket == nil {
+		return nil, nil
+	}
+	e = bucket.ForEach(
+		func(k, v []byte) (e error) {
+			var row *dbChangeLeaseRow
+			if row, e = deserializeChangeLease(v); E.Chk(e) {
+				return e
+			}
+			leases = append(leases, row)
+			return nil
+		},
+	)
+	return leases, e
+}
+
+// putChangeLease stores the provided change lease, replacing any lease already
+// held on the same address.
+func putChangeLease(ns walletdb.ReadWriteBucket, scope *KeyScope, row *dbChangeLeaseRow) (e error) {
+	var scopedBucket walletdb.ReadWriteBucket
+	if scopedBucket, e = fetchWriteScopeBucket(ns, scope); E.Chk(e) {
+		return e
+	}
+	var bucket walletdb.ReadWriteBucket
+	bucket, e = scopedBucket.NestedReadWriteBucket(metaBucketName).
+		CreateBucketIfNotExists(changeLeaseBucketName)
+	if E.Chk(e) {
+		str := "failed to create change lease bucket"
+		return managerError(ErrDatabase, str, e)
+	}
+	addrHash := sha256.Sum256(row.addressID)
+	if e = bucket.Put(addrHash[:], serializeChangeLease(row)); E.Chk(e) {
+		str := fmt.Sprintf("failed to store change lease for %x", row.addressID)
+		return managerError(ErrDatabase, str, e)
+	}
+	return nil
+}
+
+// deleteChangeLease removes the lease held on the provided address id, if any.
+func deleteChangeLease(ns walletdb.ReadWriteBucket, scope *KeyScope, addressID []byte) (e error) {
+	var scopedBucket walletdb.ReadWriteBucket
+	if scopedBucket, e = fetchWriteScopeBucket(ns, scope); E.Chk(e) {
+		return e
+	}
+	bucket := scopedBucket.NestedReadWriteBucket(metaBucketName).
+		NestedReadWriteBucket(changeLeaseBucketName)
+	if bucket == nil {
+		return nil
+	}
+	addrHash := sha256.Sum256(addressID)
+	if e = bucket.Delete(addrHash[:]); E.Chk(e) {
+		str := fmt.Sprintf("failed to delete change lease for %x", addressID)
+		return managerError(ErrDatabase, str, e)
+	}
+	return nil
+}
+
 // markAddressUsed flags the provided address id as used in the database.
 func markAddressUsed(
 	ns walletdb.ReadWriteBucket, scope *KeyScope,
@@ -1491,6 +1646,32 @@ func forEachAccountAddress(
 	return nil
 }
 
+// forEachAddressHash calls the given function with the hash of the ID of each
+// address stored in the manager, breaking early on error. Unlike
+// forEachActiveAddress the address rows are not deserialized.
+func forEachAddressHash(
+	ns walletdb.ReadBucket, scope *KeyScope,
+	fn func(addrHash []byte) error,
+) (e error) {
+	var scopedBucket walletdb.ReadBucket
+	if scopedBucket, e = fetchReadScopeBucket(ns, scope); E.Chk(e) {
+		return e
+	}
+	bucket := scopedBucket.NestedReadBucket(addrBucketName)
+	if e = bucket.ForEach(
+		func(k, v []byte) (e error) {
+			// Skip buckets.
+			if v == nil {
+				return nil
+			}
+			return fn(k)
+		},
+	); E.Chk(e) {
+		return maybeConvertDbError(e)
+	}
+	return nil
+}
+
 // forEachActiveAddress calls the given function with each active address stored
 // in the manager, breaking early on error.
 func forEachActiveAddress(
@@ -1910,6 +2091,10 @@ func createManagerNS(
 			return e
 		}
 	}
+	if _, e = ns.CreateBucket(scriptIndexBucketName); E.Chk(e) {
+		str := "failed to create script index bucket"
+		return managerError(ErrDatabase, str, e)
+	}
 	if e = putManagerVersion(ns, latestMgrVersion); E.Chk(e) {
 		return e
 	}
@@ -1966,6 +2151,18 @@ func upgradeManager(
 		// The manager is now at version 5.
 		version = 5
 	}
+	if version < 6 {
+		if e = walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) (e error) {
+				ns := tx.ReadWriteBucket(namespaceKey)
+				return upgradeToVersion6(ns, pubPassPhrase, chainParams)
+			},
+		); E.Chk(e) {
+			return e
+		}
+		// The manager is now at version 6.
+		version = 6
+	}
 	// Ensure the manager is upgraded to the latest version. This check is to
 	// intentionally cause a failure if the manager version is updated without
 	// writing code to handle the upgrade.
@@ -1980,6 +2177,34 @@ func upgradeManager(
 	return nil
 }
 
+// upgradeToVersion6 upgrades the database from version 5 to version 6, which
+// adds the script index of the addresses of every scope. The index is built by
+// loading the manager with the public passphrase and deriving the output script
+// of each address stored in it.
+func upgradeToVersion6(
+	ns walletdb.ReadWriteBucket, pubPassPhrase []byte,
+	chainParams *chaincfg.Params,
+) (e error) {
+	if e = putManagerVersion(ns, 6); E.Chk(e) {
+		return e
+	}
+	if _, e = ns.CreateBucketIfNotExists(scriptIndexBucketName); E.Chk(e) {
+		str := "failed to create script index bucket"
+		return managerError(ErrDatabase, str, e)
+	}
+	var mgr *Manager
+	if mgr, e = loadManager(ns, pubPassPhrase, chainParams); E.Chk(e) {
+		return e
+	}
+	defer mgr.Close()
+	for _, scopedMgr := range mgr.scopedManagers {
+		if e = scopedMgr.indexAllScripts(ns); E.Chk(e) {
+			return e
+		}
+	}
+	return nil
+}
+
 // upgradeToVersion5 upgrades the database from version 4 to version 5. After
 // this update, the new ScopedKeyManager features cannot be used. This is due to
 // the fact that in version 5, we now store the encrypted master private keys on
@@ -0,0 +1,176 @@
+package waddrmgr
+
+import (
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+// KeyMemoryOptions sets how the manager holds the crypto keys it decrypts on
+// unlock in memory.
+type KeyMemoryOptions struct {
+	// Lock asks for the memory holding the keys to be locked so that it is never
+	// written out to swap.
+	Lock bool
+	// GuardPages places the keys in a memory mapping of their own between two
+	// inaccessible pages, so that overruns of neighbouring memory fault instead of
+	// reaching the keys.
+	GuardPages bool
+}
+
+var (
+	// DefaultKeyMemoryOptions are the options used unless SetKeyMemoryOptions is
+	// called.
+	DefaultKeyMemoryOptions = KeyMemoryOptions{Lock: true}
+	keyMemoryOptions        = DefaultKeyMemoryOptions
+	keyMemoryOptionsMtx     sync.RWMutex
+)
+
+// SetKeyMemoryOptions replaces the options used for holding decrypted crypto
+// keys in memory and returns the previous ones. The options apply to managers
+// opened or created after the call.
+func SetKeyMemoryOptions(opts KeyMemoryOptions) KeyMemoryOptions {
+	keyMemoryOptionsMtx.Lock()
+	old := keyMemoryOptions
+	keyMemoryOptions = opts
+	keyMemoryOptionsMtx.Unlock()
+	return old
+}
+
+// KeyResidency describes a decrypted crypto key the manager keeps in memory
+// while it is unlocked.
+type KeyResidency struct {
+	// Name is the name of the key.
+	Name string
+	// Resident is true when the decrypted key is in memory.
+	Resident bool
+	// Since is when the key was decrypted, if it is resident.
+	Since time.Time
+	// Duration is how long the key has been resident.
+	Duration time.Duration
+	// Locked is true if the memory holding the key is locked against swapping.
+	Locked bool
+	// Guarded is true if the memory holding the key is between guard pages.
+	Guarded bool
+}
+
+// keyMemory is memory set aside for holding a key.
+type keyMemory struct {
+	buf []byte
+	// region is the whole memory mapping the buffer is part of, if it was mapped.
+	region  []byte
+	locked  bool
+	guarded bool
+}
+
+// secureCryptoKey is a crypto key that keeps its decrypted key in memory
+// allocated according to the KeyMemoryOptions, and records when the key was put
+// there.
+type secureCryptoKey struct {
+	name  string
+	mem   *keyMemory
+	key   *snacl.CryptoKey
+	since time.Time
+}
+
+// newSecureCryptoKey allocates the memory for a crypto key with the current
+// KeyMemoryOptions.
+func newSecureCryptoKey(name string) *secureCryptoKey {
+	keyMemoryOptionsMtx.RLock()
+	opts := keyMemoryOptions
+	keyMemoryOptionsMtx.RUnlock()
+	mem := allocKeyMemory(len(snacl.CryptoKey{}), opts)
+	return &secureCryptoKey{
+		name: name,
+		mem:  mem,
+		key:  (*snacl.CryptoKey)(unsafe.Pointer(&mem.buf[0])),
+	}
+}
+
+// Encrypt encrypts the passed data with the key.
+//
+// This is part of the EncryptorDecryptor interface implementation.
+func (ck *secureCryptoKey) Encrypt(in []byte) ([]byte, error) {
+	return ck.key.Encrypt(in)
+}
+
+// Decrypt decrypts the passed data with the key.
+//
+// This is part of the EncryptorDecryptor interface implementation.
+func (ck *secureCryptoKey) Decrypt(in []byte) ([]byte, error) {
+	return ck.key.Decrypt(in)
+}
+
+// Bytes returns the key's byte slice.
+//
+// This is part of the EncryptorDecryptor interface implementation.
+func (ck *secureCryptoKey) Bytes() []byte {
+	return ck.key[:]
+}
+
+// CopyBytes copies the bytes from the given slice into the key and marks the key
+// as resident from now.
+//
+// This is part of the EncryptorDecryptor interface implementation.
+func (ck *secureCryptoKey) CopyBytes(from []byte) {
+	copy(ck.key[:], from)
+	ck.since = time.Now()
+}
+
+// Zero clears the key.
+//
+// This is part of the EncryptorDecryptor interface implementation.
+func (ck *secureCryptoKey) Zero() {
+	zero.Bytes(ck.mem.buf)
+	ck.since = time.Time{}
+}
+
+// residency reports on the key held in memory.
+func (ck *secureCryptoKey) residency() KeyResidency {
+	r := KeyResidency{
+		Name:     ck.name,
+		Resident: !ck.since.IsZero(),
+		Since:    ck.since,
+		Locked:   ck.mem.locked,
+		Guarded:  ck.mem.guarded,
+	}
+	if r.Resident {
+		r.Duration = time.Since(ck.since)
+	}
+	return r
+}
+
+// free clears the key and gives back its memory. The key can not be used after
+// this.
+func (ck *secureCryptoKey) free() {
+	ck.Zero()
+	ck.mem.free()
+	ck.key = nil
+}
+
+// freeCryptoKeys clears the given crypto keys and gives back the memory set aside
+// for those that were allocated with newSecureCryptoKey.
+func freeCryptoKeys(keys ...EncryptorDecryptor) {
+	for _, key := range keys {
+		if ck, ok := key.(*secureCryptoKey); ok {
+			ck.free()
+		}
+	}
+}
+
+// KeyResidency reports on the decrypted crypto keys the manager holds in memory
+// and how long they have been there, for auditing how long key material stays
+// exposed while the manager is unlocked.
+func (m *Manager) KeyResidency() (keys []KeyResidency) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	for _, key := range []EncryptorDecryptor{m.cryptoKeyPriv, m.cryptoKeyScript} {
+		if ck, ok := key.(*secureCryptoKey); ok {
+			keys = append(keys, ck.residency())
+		}
+	}
+	return
+}
@@ -0,0 +1,17 @@
+// +build windows plan9
+
+package waddrmgr
+
+// allocKeyMemory takes the memory for a key from the heap, as memory can't be
+// locked or guarded on this platform.
+func allocKeyMemory(size int, opts KeyMemoryOptions) *keyMemory {
+	if opts.Lock || opts.GuardPages {
+		D.Ln("locked and guarded memory for keys is not supported on this platform")
+	}
+	return &keyMemory{buf: make([]byte, size)}
+}
+
+// free drops the memory of the key.
+func (km *keyMemory) free() {
+	km.buf = nil
+}
@@ -0,0 +1,65 @@
+// +build !windows,!plan9
+
+package waddrmgr
+
+import (
+	"os"
+	"syscall"
+)
+
+// allocKeyMemory maps a page of memory for a key of the given size, between two
+// inaccessible guard pages if asked for, and locks it if asked for. If the
+// memory can't be mapped it falls back to memory from the heap, and if it can't
+// be locked it is used unlocked, so that the wallet still works on systems that
+// restrict these calls.
+func allocKeyMemory(size int, opts KeyMemoryOptions) (km *keyMemory) {
+	km = &keyMemory{}
+	pageSize := os.Getpagesize()
+	pages := (size + pageSize - 1) / pageSize
+	start, length := 0, pages*pageSize
+	if opts.GuardPages {
+		start, length = pageSize, length+2*pageSize
+	}
+	region, e := syscall.Mmap(
+		-1, 0, length, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE,
+	)
+	if e != nil {
+		W.Ln("unable to map memory for keys, using the heap:", e)
+		km.buf = make([]byte, size)
+		return
+	}
+	km.region = region
+	km.buf = region[start : start+size]
+	if opts.GuardPages {
+		if e = syscall.Mprotect(region[:pageSize], syscall.PROT_NONE); E.Chk(e) {
+		} else if e = syscall.Mprotect(region[length-pageSize:], syscall.PROT_NONE); E.Chk(e) {
+		} else {
+			km.guarded = true
+		}
+	}
+	if opts.Lock {
+		if e = syscall.Mlock(region[start : start+pages*pageSize]); e != nil {
+			W.Ln("unable to lock memory for keys, they may be swapped to disk:", e)
+		} else {
+			km.locked = true
+		}
+	}
+	return
+}
+
+// free unlocks and unmaps the memory of the key.
+func (km *keyMemory) free() {
+	if km.region == nil {
+		return
+	}
+	var e error
+	if km.locked {
+		if e = syscall.Munlock(km.region); E.Chk(e) {
+		}
+		km.locked = false
+	}
+	if e = syscall.Munmap(km.region); E.Chk(e) {
+	}
+	km.region, km.buf = nil, nil
+}
@@ -142,6 +142,9 @@ type AccountProperties struct {
 	ExternalKeyCount uint32
 	InternalKeyCount uint32
 	ImportedKeyCount uint32
+	// Archived is whether the account is archived, which leaves it out of the
+	// account listings and the total balance of the wallet.
+	Archived bool
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a
@@ -301,6 +304,49 @@ func (m *Manager) WatchOnly() bool {
 	return m.watchingOnly
 }
 
+// MasterFingerprint returns the fingerprint of the master HD key of the wallet,
+// the first four bytes of the hash160 of its public key, which identifies the
+// root of the derivation paths of the keys of the wallet to a signer. It is
+// found from the master HD public key, which a watching-only wallet keeps, so
+// the wallet need not be unlocked. The fingerprint is zero if the wallet has no
+// master HD public key.
+func (m *Manager) MasterFingerprint(ns walletdb.ReadBucket) (fingerprint [4]byte, e error) {
+	var masterHDPubEnc []byte
+	if _, masterHDPubEnc, e = fetchMasterHDKeys(ns); E.Chk(e) || masterHDPubEnc == nil {
+		return
+	}
+	m.mtx.RLock()
+	serialized, e := m.cryptoKeyPub.Decrypt(masterHDPubEnc)
+	m.mtx.RUnlock()
+	if E.Chk(e) {
+		return fingerprint, managerError(ErrCrypto, "failed to decrypt master HD public key", e)
+	}
+	var masterHDPub *hdkeychain.ExtendedKey
+	if masterHDPub, e = hdkeychain.NewKeyFromString(string(serialized)); E.Chk(e) {
+		return fingerprint, managerError(ErrKeyChain, "failed to decode master HD public key", e)
+	}
+	pubKey, e := masterHDPub.ECPubKey()
+	if E.Chk(e) {
+		return fingerprint, managerError(ErrKeyChain, "failed to read master HD public key", e)
+	}
+	copy(fingerprint[:], btcaddr.Hash160(pubKey.SerializeCompressed()))
+	return
+}
+
+// DefaultAddressType returns the address type the wallet hands out when none is
+// asked for, or an empty string if the wallet does not set one.
+func (m *Manager) DefaultAddressType(ns walletdb.ReadBucket) string {
+	return fetchDefaultAddressType(ns)
+}
+
+// SetDefaultAddressType sets the address type the wallet hands out when none is
+// asked for. An empty address type removes the setting.
+func (m *Manager) SetDefaultAddressType(ns walletdb.ReadWriteBucket,
+	addressType string,
+) (e error) {
+	return putDefaultAddressType(ns, addressType)
+}
+
 // lock performs a best try effort to remove and zero all secret keys associated
 // with the address manager.
 //
@@ -355,6 +401,8 @@ func (m *Manager) Close() {
 	if !m.watchingOnly && !m.locked {
 		m.lock()
 	}
+	// Give back the memory set aside for the private and script crypto keys.
+	freeCryptoKeys(m.cryptoKeyScript, m.cryptoKeyPriv)
 	// Remove clear text public master and crypto keys from memory.
 	m.cryptoKeyPub.Zero()
 	m.masterKeyPub.Zero()
@@ -453,6 +501,7 @@ func (m *Manager) NewScopedKeyManager(
 		addrSchema:  addrSchema,
 		rootManager: m,
 		addrs:       make(map[addrKey]ManagedAddress),
+		ownedAddrs:  make(map[addrHashKey]struct{}),
 		acctInfo:    make(map[uint32]*accountInfo),
 	}
 	m.externalAddrSchemas[addrSchema.ExternalAddrType] = append(
@@ -852,6 +901,7 @@ func (m *Manager) ConvertToWatchingOnly(ns walletdb.ReadWriteBucket) (e error) {
 		}
 	}
 	// Clear and remove encrypted private and script crypto keys.
+	freeCryptoKeys(m.cryptoKeyScript, m.cryptoKeyPriv)
 	zero.Bytes(m.cryptoKeyScriptEncrypted)
 	m.cryptoKeyScriptEncrypted = nil
 	m.cryptoKeyScript = nil
@@ -1113,9 +1163,9 @@ func newManager(
 		masterKeyPriv:            masterKeyPriv,
 		cryptoKeyPub:             cryptoKeyPub,
 		cryptoKeyPrivEncrypted:   cryptoKeyPrivEncrypted,
-		cryptoKeyPriv:            &cryptoKey{},
+		cryptoKeyPriv:            newSecureCryptoKey("crypto private key"),
 		cryptoKeyScriptEncrypted: cryptoKeyScriptEncrypted,
-		cryptoKeyScript:          &cryptoKey{},
+		cryptoKeyScript:          newSecureCryptoKey("crypto script key"),
 		privPassphraseSalt:       privPassphraseSalt,
 		scopedManagers:           scopedManagers,
 		externalAddrSchemas:      make(map[AddressType][]KeyScope),
@@ -1328,12 +1378,16 @@ func loadManager(
 			if e != nil {
 				return e
 			}
-			scopedManagers[scope] = &ScopedKeyManager{
+			scopedManager := &ScopedKeyManager{
 				scope:      scope,
 				addrSchema: *scopeSchema,
 				addrs:      make(map[addrKey]ManagedAddress),
 				acctInfo:   make(map[uint32]*accountInfo),
 			}
+			if e = scopedManager.loadOwned(ns); E.Chk(e) {
+				return e
+			}
+			scopedManagers[scope] = scopedManager
 			return nil
 		},
 	); E.Chk(e) {
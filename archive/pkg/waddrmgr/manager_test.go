@@ -2,6 +2,7 @@
 package waddrmgr_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"github.com/p9c/pod/pkg/btcaddr"
@@ -15,7 +16,9 @@ import (
 	"github.com/p9c/pod/pkg/chaincfg"
 	"github.com/p9c/pod/pkg/chainhash"
 	"github.com/p9c/pod/pkg/snacl"
+	"github.com/p9c/pod/pkg/txscript"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
 	"github.com/p9c/pod/pkg/waddrmgr"
 	"github.com/p9c/pod/pkg/walletdb"
 )
@@ -2504,3 +2507,838 @@ func TestEncryptDecrypt(t *testing.T) {
 // 		)
 // 	}
 // }
+
+// TestOwnedAddresses ensures lookups of addresses that are not known to the
+// manager fail with ErrAddressNotFound, and that derived addresses are still
+// found both before and after the manager is reopened.
+func TestOwnedAddresses(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	var derived btcaddr.Address
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			addrs, e := scopedMgr.NextExternalAddresses(ns, 0, 1)
+			if e != nil {
+				return e
+			}
+			derived = addrs[0].Address()
+			return nil
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to derive address: %v", e)
+	}
+	foreign, e := btcaddr.NewPubKeyHash(make([]byte, 20), &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create address: %v", e)
+	}
+	check := func(prefix string, mgr *waddrmgr.Manager) {
+		e := walletdb.View(
+			db, func(tx walletdb.ReadTx) (e error) {
+				ns := tx.ReadBucket(waddrmgrNamespaceKey)
+				if _, e = mgr.Address(ns, derived); e != nil {
+					t.Errorf("%s: derived address not found: %v", prefix, e)
+				}
+				_, e = mgr.Address(ns, foreign)
+				checkManagerError(
+					t, prefix+": foreign address", e,
+					waddrmgr.ErrAddressNotFound,
+				)
+				return nil
+			},
+		)
+		if e != nil {
+			t.Fatalf("%s: %v", prefix, e)
+		}
+	}
+	check("new manager", mgr)
+	// Reopen the manager so the set of owned addresses is loaded from the
+	// database rather than built up as addresses are derived.
+	var reopened *waddrmgr.Manager
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			reopened, e = waddrmgr.Open(ns, pubPassphrase, &chaincfg.MainNetParams)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to reopen manager: %v", e)
+	}
+	defer reopened.Close()
+	check("reopened manager", reopened)
+}
+
+// TestChangeAddressLeases ensures that leased change addresses are distinct
+// while their leases are held, that released addresses are handed out again,
+// and that addresses used on chain are never recycled.
+func TestChangeAddressLeases(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	lease := func() (addr btcaddr.Address) {
+		e := walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) (e error) {
+				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				ma, e := scopedMgr.LeaseChangeAddress(ns, 0, time.Hour)
+				if e != nil {
+					return e
+				}
+				if !ma.Internal() {
+					t.Errorf("leased address %v is not internal", ma.Address())
+				}
+				addr = ma.Address()
+				return nil
+			},
+		)
+		if e != nil {
+			t.Fatalf("unable to lease change address: %v", e)
+		}
+		return addr
+	}
+	update := func(prefix string, fn func(ns walletdb.ReadWriteBucket) error) {
+		e := walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) (e error) {
+				return fn(tx.ReadWriteBucket(waddrmgrNamespaceKey))
+			},
+		)
+		if e != nil {
+			t.Fatalf("%s: %v", prefix, e)
+		}
+	}
+	first, second := lease(), lease()
+	if first.EncodeAddress() == second.EncodeAddress() {
+		t.Fatalf("concurrent leases share change address %v", first)
+	}
+	update(
+		"unable to release change address", func(ns walletdb.ReadWriteBucket) error {
+			return scopedMgr.ReleaseChangeAddress(ns, first)
+		},
+	)
+	if recycled := lease(); recycled.EncodeAddress() != first.EncodeAddress() {
+		t.Errorf("released address %v was not recycled, got %v", first, recycled)
+	}
+	update(
+		"unable to mark address used", func(ns walletdb.ReadWriteBucket) error {
+			return scopedMgr.MarkUsed(ns, second)
+		},
+	)
+	update(
+		"unable to release change address", func(ns walletdb.ReadWriteBucket) error {
+			return scopedMgr.ReleaseChangeAddress(ns, second)
+		},
+	)
+	third := lease()
+	if third.EncodeAddress() == first.EncodeAddress() ||
+		third.EncodeAddress() == second.EncodeAddress() {
+		t.Errorf("leased address %v is still held or was used", third)
+	}
+}
+
+// TestKeyResidency ensures the decrypted crypto private key is reported resident
+// only while the manager is unlocked, and that guarded key memory can be used.
+func TestKeyResidency(t *testing.T) {
+	old := waddrmgr.SetKeyMemoryOptions(
+		waddrmgr.KeyMemoryOptions{Lock: true, GuardPages: true},
+	)
+	defer waddrmgr.SetKeyMemoryOptions(old)
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	privResidency := func() (r waddrmgr.KeyResidency) {
+		for _, r = range mgr.KeyResidency() {
+			if r.Name == "crypto private key" {
+				return
+			}
+		}
+		t.Fatal("crypto private key missing from key residency")
+		return
+	}
+	if r := privResidency(); r.Resident {
+		t.Fatalf("crypto private key resident while locked: %+v", r)
+	}
+	e := walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			return mgr.Unlock(tx.ReadBucket(waddrmgrNamespaceKey), privPassphrase)
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to unlock manager: %v", e)
+	}
+	r := privResidency()
+	if !r.Resident || r.Since.IsZero() {
+		t.Fatalf("crypto private key not resident while unlocked: %+v", r)
+	}
+	if !r.Guarded {
+		t.Logf("crypto private key memory is not guarded on this system")
+	}
+	if e = mgr.Lock(); e != nil {
+		t.Fatalf("unable to lock manager: %v", e)
+	}
+	if r = privResidency(); r.Resident || r.Duration != 0 {
+		t.Fatalf("crypto private key resident after lock: %+v", r)
+	}
+}
+
+// TestDeriveAccountAddresses ensures the addresses derived for an account that
+// does not exist yet match those the account gives once it is added, and that
+// they can't be derived while the manager is locked.
+func TestDeriveAccountAddresses(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	const account, count = 1, 5
+	derive := func() (addrs map[uint32]btcaddr.Address, e error) {
+		e = walletdb.View(
+			db, func(tx walletdb.ReadTx) (e error) {
+				addrs, e = scopedMgr.DeriveAccountAddresses(
+					tx.ReadBucket(waddrmgrNamespaceKey), account, waddrmgr.ExternalBranch, 0, count,
+				)
+				return e
+			},
+		)
+		return
+	}
+	if _, e = derive(); !waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+		t.Fatalf("derived account addresses while locked: %v", e)
+	}
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			return mgr.Unlock(tx.ReadBucket(waddrmgrNamespaceKey), privPassphrase)
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to unlock manager: %v", e)
+	}
+	derived, e := derive()
+	if e != nil {
+		t.Fatalf("unable to derive account addresses: %v", e)
+	}
+	if len(derived) != count {
+		t.Fatalf("derived %d addresses, want %d", len(derived), count)
+	}
+	var added []waddrmgr.ManagedAddress
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e = scopedMgr.NewRawAccount(ns, account); e != nil {
+				return e
+			}
+			added, e = scopedMgr.NextExternalAddresses(ns, account, count)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to add account addresses: %v", e)
+	}
+	for i, ma := range added {
+		if got := derived[uint32(i)]; got == nil || got.EncodeAddress() != ma.Address().EncodeAddress() {
+			t.Errorf("address %d: derived %v, account has %v", i, got, ma.Address())
+		}
+	}
+}
+
+// TestDeriveExternalAddresses ensures the external addresses derived ahead of
+// the next index of an account while the manager is locked are those the account
+// stores when it is extended to them.
+func TestDeriveExternalAddresses(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	const account, start, end = 0, 3, 8
+	var derived map[uint32]btcaddr.Address
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			derived, e = scopedMgr.DeriveExternalAddresses(
+				tx.ReadBucket(waddrmgrNamespaceKey), account, start, end,
+			)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to derive external addresses: %v", e)
+	}
+	if len(derived) != end-start {
+		t.Fatalf("derived %d addresses, want %d", len(derived), end-start)
+	}
+	var props *waddrmgr.AccountProperties
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e = scopedMgr.ExtendExternalAddresses(ns, account, end-1); e != nil {
+				return e
+			}
+			props, e = scopedMgr.AccountProperties(ns, account)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to extend external addresses: %v", e)
+	}
+	if props.ExternalKeyCount != end {
+		t.Fatalf("account has %d external addresses, want %d", props.ExternalKeyCount, end)
+	}
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			for index := uint32(start); index < end; index++ {
+				path := waddrmgr.DerivationPath{Account: account, Branch: waddrmgr.ExternalBranch, Index: index}
+				var ma waddrmgr.ManagedAddress
+				if ma, e = scopedMgr.DeriveFromKeyPath(ns, path); e != nil {
+					return e
+				}
+				if got := derived[index]; got == nil || got.EncodeAddress() != ma.Address().EncodeAddress() {
+					t.Errorf("address %d: derived %v, account has %v", index, got, ma.Address())
+				}
+				if _, e = scopedMgr.Address(ns, ma.Address()); e != nil {
+					t.Errorf("address %d is not stored: %v", index, e)
+				}
+			}
+			return nil
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to check the stored addresses: %v", e)
+	}
+}
+
+// TestAccountPrivKey ensures the extended private key of an account is only
+// returned while the manager is unlocked, and that it derives the addresses of
+// the account.
+func TestAccountPrivKey(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	privKey := func(account uint32) (xprv string, e error) {
+		e = walletdb.View(
+			db, func(tx walletdb.ReadTx) (e error) {
+				xprv, e = scopedMgr.AccountPrivKey(tx.ReadBucket(waddrmgrNamespaceKey), account)
+				return e
+			},
+		)
+		return
+	}
+	if _, e = privKey(waddrmgr.DefaultAccountNum); !waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+		t.Fatalf("returned the account private key while locked: %v", e)
+	}
+	var derived map[uint32]btcaddr.Address
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			if e = mgr.Unlock(ns, privPassphrase); e != nil {
+				return e
+			}
+			derived, e = scopedMgr.DeriveAccountAddresses(
+				ns, waddrmgr.DefaultAccountNum, waddrmgr.ExternalBranch, 0, 1,
+			)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to unlock manager: %v", e)
+	}
+	xprv, e := privKey(waddrmgr.DefaultAccountNum)
+	if e != nil {
+		t.Fatalf("unable to get the account private key: %v", e)
+	}
+	acctKey, e := hdkeychain.NewKeyFromString(xprv)
+	if e != nil {
+		t.Fatalf("unable to parse the account private key: %v", e)
+	}
+	if !acctKey.IsPrivate() {
+		t.Fatalf("account key %s is not private", xprv)
+	}
+	branchKey, _ := acctKey.Child(waddrmgr.ExternalBranch)
+	child, _ := branchKey.Child(0)
+	addr, e := child.Address(scopedMgr.ChainParams())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if derived[0] == nil || addr.EncodeAddress() != derived[0].EncodeAddress() {
+		t.Fatalf("account key derives %v, account has %v", addr, derived[0])
+	}
+	if _, e = privKey(waddrmgr.ImportedAddrAccount); !waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount) {
+		t.Fatalf("returned a private key for the imported account: %v", e)
+	}
+}
+
+// TestWatchedScripts ensures imported output scripts are watched, and that a script can't be imported twice.
+func TestWatchedScripts(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	htlc, _ := hex.DecodeString("63a820" + "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff" + "8876a914" +
+		"0011223344556677889900112233445566778899" + "6702e803b17576a914" + "9988776655443322110099887766554433221100" +
+		"6888ac")
+	bs := &waddrmgr.BlockStamp{Hash: chainhash.Hash{1}, Height: 100}
+	e := walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if mgr.IsWatchedScript(ns, htlc) {
+				t.Errorf("script is watched before it was imported")
+			}
+			if _, e = mgr.ImportScriptPubKey(ns, htlc, "htlc", bs); e != nil {
+				return e
+			}
+			if _, e = mgr.ImportScriptPubKey(ns, htlc, "again", bs); !waddrmgr.IsError(e, waddrmgr.ErrDuplicateAddress) {
+				t.Errorf("importing a watched script again returned %v, want ErrDuplicateAddress", e)
+			}
+			return nil
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to import script: %v", e)
+	}
+	var watched []*waddrmgr.WatchedScript
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			if !mgr.IsWatchedScript(ns, htlc) {
+				t.Errorf("imported script is not watched")
+			}
+			return mgr.ForEachWatchedScript(
+				ns, func(ws *waddrmgr.WatchedScript) error {
+					watched = append(watched, ws)
+					return nil
+				},
+			)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := []*waddrmgr.WatchedScript{{Script: htlc, Label: "htlc", Block: *bs}}
+	if !reflect.DeepEqual(watched, want) {
+		t.Fatalf("got watched scripts %v, want %v", spew.Sdump(watched), spew.Sdump(want))
+	}
+}
+
+// TestMultiSigAccounts ensures a multisig account recognises the cosigner key of one of the accounts of the manager,
+// and that its deposit addresses pay to the sorted multisig script of the derived cosigner keys, whose key of ours is
+// held by the manager.
+func TestMultiSigAccounts(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatalf("unable to fetch scope: %v", e)
+	}
+	var xprv, xpub string
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			if e = mgr.Unlock(ns, privPassphrase); e != nil {
+				return e
+			}
+			if xpub, e = scopedMgr.AccountPubKey(ns, waddrmgr.DefaultAccountNum); e != nil {
+				return e
+			}
+			xprv, e = scopedMgr.AccountPrivKey(ns, waddrmgr.DefaultAccountNum)
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to get the account keys: %v", e)
+	}
+	ourKey, _ := hdkeychain.NewKeyFromString(xprv)
+	if ourPub, _ := ourKey.Neuter(); ourPub.String() != xpub {
+		t.Fatalf("account public key %s does not match the private key", xpub)
+	}
+	xpubs := []string{xpub}
+	for i := byte(1); i <= 2; i++ {
+		master, e := hdkeychain.NewMaster(append([]byte{i}, seed[1:]...), &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		pub, _ := master.Neuter()
+		xpubs = append(xpubs, pub.String())
+	}
+	bs := &waddrmgr.BlockStamp{Hash: chainhash.Hash{1}, Height: 100}
+	var ma *waddrmgr.MultiSigAccount
+	var addrs []waddrmgr.ManagedScriptAddress
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if _, e = mgr.NewMultiSigAccount(ns, "bad", 4, xpubs); !waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount) {
+				t.Errorf("created a 4 of 3 multisig account: %v", e)
+			}
+			if _, e = mgr.NewMultiSigAccount(ns, "bad", 1, []string{xprv}); !waddrmgr.IsError(e, waddrmgr.ErrInvalidKeyType) {
+				t.Errorf("created a multisig account from a private key: %v", e)
+			}
+			if ma, e = mgr.NewMultiSigAccount(ns, "vault", 2, xpubs); e != nil {
+				return e
+			}
+			if _, e = mgr.NewMultiSigAccount(ns, "vault", 2, xpubs); !waddrmgr.IsError(e, waddrmgr.ErrDuplicateAccount) {
+				t.Errorf("creating a multisig account twice returned %v, want ErrDuplicateAccount", e)
+			}
+			for i := 0; i < 2; i++ {
+				var addr waddrmgr.ManagedScriptAddress
+				if addr, e = mgr.NextMultiSigAddress(ns, "vault", bs); e != nil {
+					return e
+				}
+				addrs = append(addrs, addr)
+			}
+			return
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to use multisig account: %v", e)
+	}
+	wantCosigners := []waddrmgr.MultiSigCosigner{
+		{XPub: xpubs[0], Ours: true, Account: waddrmgr.DefaultAccountNum},
+		{XPub: xpubs[1]},
+		{XPub: xpubs[2]},
+	}
+	if !reflect.DeepEqual(ma.Cosigners, wantCosigners) {
+		t.Fatalf("got cosigners %v, want %v", spew.Sdump(ma.Cosigners), spew.Sdump(wantCosigners))
+	}
+	for i, addr := range addrs {
+		script, e := ma.RedeemScript(uint32(i), &chaincfg.MainNetParams)
+		if e != nil {
+			t.Fatal(e)
+		}
+		want, _ := btcaddr.NewScriptHash(script, &chaincfg.MainNetParams)
+		if addr.Address().EncodeAddress() != want.EncodeAddress() {
+			t.Errorf("address %d is %v, want %v", i, addr.Address(), want)
+		}
+		branchKey, _ := ourKey.Child(waddrmgr.ExternalBranch)
+		child, _ := branchKey.Child(uint32(i))
+		pkh, _ := child.Address(&chaincfg.MainNetParams)
+		e = walletdb.View(
+			db, func(tx walletdb.ReadTx) (e error) {
+				_, e = scopedMgr.Address(tx.ReadBucket(waddrmgrNamespaceKey), pkh)
+				return e
+			},
+		)
+		if e != nil {
+			t.Errorf("signing key of address %d is not held by the manager: %v", i, e)
+		}
+	}
+	var listed []*waddrmgr.MultiSigAccount
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			return mgr.ForEachMultiSigAccount(
+				tx.ReadBucket(waddrmgrNamespaceKey), func(ma *waddrmgr.MultiSigAccount) error {
+					listed = append(listed, ma)
+					return nil
+				},
+			)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(listed) != 1 || listed[0].NextIndex != 2 || !reflect.DeepEqual(listed[0].Cosigners, wantCosigners) {
+		t.Fatalf("got multisig accounts %v", spew.Sdump(listed))
+	}
+}
+
+// TestVaultAccounts ensures the deposit addresses of vault accounts are locked to the lock height of the account or
+// the delay after the height they are generated at, and are kept with the key that signs for them.
+func TestVaultAccounts(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	bs := &waddrmgr.BlockStamp{Hash: chainhash.Hash{1}, Height: 100}
+	var fixed, delayed *waddrmgr.VaultAddress
+	var fixedScript []byte
+	e := walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e = mgr.Unlock(ns, privPassphrase); e != nil {
+				return e
+			}
+			if _, e = mgr.NewVaultAccount(ns, "bad", 1000, 10); !waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount) {
+				t.Errorf("created a vault account with a lock height and a delay: %v", e)
+			}
+			if _, e = mgr.NewVaultAccount(ns, "bad", 500000000, 0); !waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount) {
+				t.Errorf("created a vault account locked to a timestamp: %v", e)
+			}
+			if _, e = mgr.NewVaultAccount(ns, "savings", 1000, 0); e != nil {
+				return e
+			}
+			if _, e = mgr.NewVaultAccount(ns, "savings", 1000, 0); !waddrmgr.IsError(e, waddrmgr.ErrDuplicateAccount) {
+				t.Errorf("creating a vault account twice returned %v, want ErrDuplicateAccount", e)
+			}
+			if _, e = mgr.NewVaultAccount(ns, "rolling", 0, 50); e != nil {
+				return e
+			}
+			if fixed, fixedScript, e = mgr.NextVaultAddress(ns, "savings", bs); e != nil {
+				return e
+			}
+			delayed, _, e = mgr.NextVaultAddress(ns, "rolling", bs)
+			return
+		},
+	)
+	if e != nil {
+		t.Fatalf("unable to use vault accounts: %v", e)
+	}
+	if fixed.LockHeight != 1000 || delayed.LockHeight != 150 {
+		t.Fatalf("got lock heights %d and %d, want 1000 and 150", fixed.LockHeight, delayed.LockHeight)
+	}
+	want, _ := waddrmgr.VaultScript(fixed.PubKey, 1000)
+	if !bytes.Equal(fixedScript, want) {
+		t.Fatalf("got redeem script %x, want %x", fixedScript, want)
+	}
+	var listed []*waddrmgr.VaultAddress
+	e = walletdb.View(
+		db, func(tx walletdb.ReadTx) (e error) {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			va, e := mgr.FetchVaultAccount(ns, "savings")
+			if e != nil {
+				return e
+			}
+			if vault, e := mgr.IsVaultAccount(ns, va.Account); e != nil || !vault {
+				t.Errorf("account %d of vault account savings is not a vault account: %v", va.Account, e)
+			}
+			if vault, e := mgr.IsVaultAccount(ns, waddrmgr.DefaultAccountNum); e != nil || vault {
+				t.Errorf("default account is a vault account: %v", e)
+			}
+			pkh, _ := btcaddr.NewPubKeyHash(btcaddr.Hash160(fixed.PubKey), &chaincfg.MainNetParams)
+			if _, e = mgr.Address(ns, pkh); e != nil {
+				t.Errorf("signing key of the vault address is not held by the manager: %v", e)
+			}
+			return mgr.ForEachVaultAddress(
+				ns, "savings", func(addr *waddrmgr.VaultAddress) error {
+					listed = append(listed, addr)
+					return nil
+				},
+			)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !reflect.DeepEqual(listed, []*waddrmgr.VaultAddress{fixed}) {
+		t.Fatalf("got vault addresses %v, want %v", spew.Sdump(listed), spew.Sdump(fixed))
+	}
+}
+
+// TestArchiveAccount ensures accounts can be archived and unarchived, show as archived in their properties, and that
+// the default and imported accounts can't be archived.
+func TestArchiveAccount(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var account uint32
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e = mgr.Unlock(ns, privPassphrase); e != nil {
+				return e
+			}
+			if account, e = scopedMgr.NewAccount(ns, "old"); e != nil {
+				return e
+			}
+			for _, reserved := range []uint32{waddrmgr.DefaultAccountNum, waddrmgr.ImportedAddrAccount} {
+				if e = scopedMgr.ArchiveAccount(ns, reserved); !waddrmgr.IsError(e, waddrmgr.ErrInvalidAccount) {
+					t.Errorf("archiving account %d returned %v, want ErrInvalidAccount", reserved, e)
+				}
+			}
+			if e = scopedMgr.ArchiveAccount(ns, account+1); !waddrmgr.IsError(e, waddrmgr.ErrAccountNotFound) {
+				t.Errorf("archiving a missing account returned %v, want ErrAccountNotFound", e)
+			}
+			if e = scopedMgr.ArchiveAccount(ns, account); e != nil {
+				return e
+			}
+			// archiving again keeps the account archived
+			return scopedMgr.ArchiveAccount(ns, account)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	archived := func() (props *waddrmgr.AccountProperties, listed map[uint32]time.Time) {
+		e := walletdb.View(
+			db, func(tx walletdb.ReadTx) (e error) {
+				ns := tx.ReadBucket(waddrmgrNamespaceKey)
+				if props, e = scopedMgr.AccountProperties(ns, account); e != nil {
+					return e
+				}
+				listed, e = scopedMgr.ArchivedAccounts(ns)
+				return e
+			},
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return
+	}
+	if props, listed := archived(); !props.Archived || len(listed) != 1 || listed[account].IsZero() {
+		t.Fatalf("account not archived: %+v %v", props, listed)
+	}
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			return scopedMgr.UnarchiveAccount(tx.ReadWriteBucket(waddrmgrNamespaceKey), account)
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if props, listed := archived(); props.Archived || len(listed) != 0 {
+		t.Fatalf("account still archived: %+v %v", props, listed)
+	}
+}
+
+// TestScriptIndex ensures the output scripts of derived and imported addresses are found in the script index with the
+// account, branch and index of the address, and that the address can be marked used through the index.
+func TestScriptIndex(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	scopedMgr, e := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var external, internal []waddrmgr.ManagedAddress
+	var imported waddrmgr.ManagedScriptAddress
+	redeemScript, _ := hex.DecodeString("51")
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) (e error) {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e = mgr.Unlock(ns, privPassphrase); e != nil {
+				return e
+			}
+			if external, e = scopedMgr.NextExternalAddresses(ns, 0, 2); e != nil {
+				return e
+			}
+			if internal, e = scopedMgr.NextInternalAddresses(ns, 0, 1); e != nil {
+				return e
+			}
+			imported, e = scopedMgr.ImportScript(ns, redeemScript, &waddrmgr.BlockStamp{})
+			return e
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	lookup := func(addr btcaddr.Address) (loc *waddrmgr.ScriptLocation) {
+		script, e := txscript.PayToAddrScript(addr)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !waddrmgr.IsIndexedScript(script) {
+			t.Fatalf("script of %v is not of an indexed class", addr)
+		}
+		e = walletdb.View(
+			db, func(tx walletdb.ReadTx) error {
+				loc, _ = mgr.LookupScript(tx.ReadBucket(waddrmgrNamespaceKey), script)
+				return nil
+			},
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return
+	}
+	tests := []struct {
+		addr waddrmgr.ManagedAddress
+		want waddrmgr.ScriptLocation
+	}{
+		{external[0], waddrmgr.ScriptLocation{Derived: true, Branch: waddrmgr.ExternalBranch, Index: 0}},
+		{external[1], waddrmgr.ScriptLocation{Derived: true, Branch: waddrmgr.ExternalBranch, Index: 1}},
+		{internal[0], waddrmgr.ScriptLocation{Derived: true, Branch: waddrmgr.InternalBranch, Index: 0}},
+		{imported, waddrmgr.ScriptLocation{Account: waddrmgr.ImportedAddrAccount}},
+	}
+	for _, test := range tests {
+		want := test.want
+		want.Scope = waddrmgr.KeyScopeBIP0044
+		want.AddressID = test.addr.Address().ScriptAddress()
+		loc := lookup(test.addr.Address())
+		if loc == nil || !reflect.DeepEqual(*loc, want) {
+			t.Errorf("got location %v of %v, want %v", spew.Sdump(loc), test.addr.Address(), spew.Sdump(want))
+		}
+	}
+	if loc := lookup(internal[0].Address()); loc == nil || !loc.Internal() {
+		t.Fatalf("change address is not internal in the index")
+	}
+	other, _ := btcaddr.NewPubKeyHash(make([]byte, 20), &chaincfg.MainNetParams)
+	if loc := lookup(other); loc != nil {
+		t.Fatalf("found address %v that is not in the wallet: %v", other, spew.Sdump(loc))
+	}
+	used := lookup(external[1].Address())
+	e = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			if e := mgr.MarkScriptUsed(ns, used); e != nil {
+				return e
+			}
+			ma, e := mgr.Address(ns, external[1].Address())
+			if e != nil {
+				return e
+			}
+			if !ma.Used(ns) {
+				t.Errorf("address marked used through the script index is not used")
+			}
+			return nil
+		},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+}
+
+// TestMasterFingerprint ensures the fingerprint of the master key is found from
+// the master public key, and is kept when the wallet is made watching-only.
+func TestMasterFingerprint(t *testing.T) {
+	t.Parallel()
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+	master, e := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	pubKey, e := master.ECPubKey()
+	if e != nil {
+		t.Fatal(e)
+	}
+	var want [4]byte
+	copy(want[:], btcaddr.Hash160(pubKey.SerializeCompressed()))
+	for _, watchOnly := range []bool{false, true} {
+		var got [4]byte
+		e = walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) (e error) {
+				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+				if watchOnly {
+					if e = mgr.ConvertToWatchingOnly(ns); e != nil {
+						return e
+					}
+				}
+				got, e = mgr.MasterFingerprint(ns)
+				return e
+			},
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if got != want {
+			t.Fatalf("watching-only %v: fingerprint is %x, want %x", watchOnly, got, want)
+		}
+	}
+}
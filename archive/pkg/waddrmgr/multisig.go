@@ -0,0 +1,303 @@
+package waddrmgr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/chaincfg"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// MaxMultiSigKeys is the most cosigners a multisig account can have, which is the most public keys a standard P2SH
+// multisig redeem script can hold.
+const MaxMultiSigKeys = 15
+
+// multiSigBucketName is the name of the bucket that stores the multisig accounts of the manager, keyed by the account
+// name. It is created when the first multisig account is added.
+var multiSigBucketName = []byte("multisigaccounts")
+
+// MultiSigCosigner is one of the cosigners of a multisig account, given by the extended public key of their account.
+type MultiSigCosigner struct {
+	XPub string
+	// Ours is set when the key is the extended public key of one of the BIP0044 accounts of the manager, in which
+	// case Account is that account and the manager holds the keys to sign for this cosigner.
+	Ours    bool
+	Account uint32
+}
+
+// MultiSigAccount is an M-of-N multisig account. The deposit address at index i pays to a P2SH multisig script of
+// the external key at index i of every cosigner, sorted as in BIP0067, so every cosigner derives the same addresses.
+// Spending needs Required signatures, which cosigners add in turn to the transaction with signrawtransaction.
+type MultiSigAccount struct {
+	Name      string
+	Required  int
+	Cosigners []MultiSigCosigner
+	// NextIndex is the index of the next deposit address.
+	NextIndex uint32
+}
+
+// The multisig account value is serialized as such:
+//
+//	[0:1] Required signatures (1 byte)
+//	[1:5] Next address index (4 bytes)
+//	[5:6] Number of cosigners (1 byte)
+//	[6:]  For each cosigner:
+//	        Ours flag (1 byte)
+//	        Account (4 bytes)
+//	        Extended public key length (2 bytes)
+//	        Extended public key
+func serializeMultiSigAccount(ma *MultiSigAccount) []byte {
+	size := 6
+	for _, c := range ma.Cosigners {
+		size += 7 + len(c.XPub)
+	}
+	v := make([]byte, 6, size)
+	v[0] = byte(ma.Required)
+	binary.LittleEndian.PutUint32(v[1:5], ma.NextIndex)
+	v[5] = byte(len(ma.Cosigners))
+	for _, c := range ma.Cosigners {
+		var cv [7]byte
+		if c.Ours {
+			cv[0] = 1
+		}
+		binary.LittleEndian.PutUint32(cv[1:5], c.Account)
+		binary.LittleEndian.PutUint16(cv[5:7], uint16(len(c.XPub)))
+		v = append(v, cv[:]...)
+		v = append(v, c.XPub...)
+	}
+	return v
+}
+
+func deserializeMultiSigAccount(k, v []byte) (ma *MultiSigAccount, e error) {
+	str := "malformed serialized multisig account"
+	if len(v) < 6 {
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	ma = &MultiSigAccount{
+		Name:      string(k),
+		Required:  int(v[0]),
+		NextIndex: binary.LittleEndian.Uint32(v[1:5]),
+		Cosigners: make([]MultiSigCosigner, v[5]),
+	}
+	v = v[6:]
+	for i := range ma.Cosigners {
+		if len(v) < 7 {
+			return nil, managerError(ErrDatabase, str, nil)
+		}
+		n := int(binary.LittleEndian.Uint16(v[5:7]))
+		if len(v) < 7+n {
+			return nil, managerError(ErrDatabase, str, nil)
+		}
+		ma.Cosigners[i] = MultiSigCosigner{
+			XPub:    string(v[7 : 7+n]),
+			Ours:    v[0] == 1,
+			Account: binary.LittleEndian.Uint32(v[1:5]),
+		}
+		v = v[7+n:]
+	}
+	return
+}
+
+// RedeemScript returns the redeem script of the deposit address at the index.
+func (ma *MultiSigAccount) RedeemScript(index uint32, net *chaincfg.Params) (script []byte, e error) {
+	pubKeys := make([]*btcaddr.PubKey, len(ma.Cosigners))
+	for i, c := range ma.Cosigners {
+		var key *hdkeychain.ExtendedKey
+		if key, e = hdkeychain.NewKeyFromString(c.XPub); E.Chk(e) {
+			str := fmt.Sprintf("failed to parse the extended key of cosigner %d", i)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		if key, e = key.Child(ExternalBranch); E.Chk(e) {
+			str := fmt.Sprintf("failed to derive the external branch of cosigner %d", i)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		if key, e = key.Child(index); E.Chk(e) {
+			str := fmt.Sprintf("failed to derive key %d of cosigner %d", index, i)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		pubKey, _ := key.ECPubKey()
+		if pubKeys[i], e = btcaddr.NewPubKey(pubKey.SerializeCompressed(), net); E.Chk(e) {
+			return
+		}
+	}
+	sort.Slice(
+		pubKeys, func(i, j int) bool {
+			return bytes.Compare(pubKeys[i].ScriptAddress(), pubKeys[j].ScriptAddress()) < 0
+		},
+	)
+	return txscript.MultiSigScript(pubKeys, ma.Required)
+}
+
+// NewMultiSigAccount adds an M-of-N multisig account with the name, needing required signatures of the cosigners given
+// by the extended public keys of their accounts. Keys matching one of the BIP0044 accounts of the manager are marked as
+// ours, and those accounts should be kept for the multisig account, as their external keys are used by its addresses.
+func (m *Manager) NewMultiSigAccount(
+	ns walletdb.ReadWriteBucket, name string, required int, xpubs []string,
+) (ma *MultiSigAccount, e error) {
+	if name == "" {
+		str := "multisig account name can't be empty"
+		return nil, managerError(ErrInvalidAccount, str, nil)
+	}
+	if len(xpubs) == 0 || len(xpubs) > MaxMultiSigKeys {
+		str := fmt.Sprintf("a multisig account needs 1 to %d cosigners, got %d", MaxMultiSigKeys, len(xpubs))
+		return nil, managerError(ErrInvalidAccount, str, nil)
+	}
+	if required < 1 || required > len(xpubs) {
+		str := fmt.Sprintf("required signatures must be between 1 and %d, got %d", len(xpubs), required)
+		return nil, managerError(ErrInvalidAccount, str, nil)
+	}
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(multiSigBucketName); E.Chk(e) {
+		str := "failed to create multisig accounts bucket"
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	if b.Get([]byte(name)) != nil {
+		str := fmt.Sprintf("multisig account '%s' already exists", name)
+		return nil, managerError(ErrDuplicateAccount, str, nil)
+	}
+	var s *ScopedKeyManager
+	if s, e = m.FetchScopedKeyManager(KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	var ours map[string]uint32
+	if ours, e = s.accountPubKeys(ns); E.Chk(e) {
+		return
+	}
+	ma = &MultiSigAccount{Name: name, Required: required, Cosigners: make([]MultiSigCosigner, len(xpubs))}
+	seen := make(map[string]struct{}, len(xpubs))
+	for i, xpub := range xpubs {
+		var key *hdkeychain.ExtendedKey
+		if key, e = hdkeychain.NewKeyFromString(xpub); E.Chk(e) {
+			str := fmt.Sprintf("failed to parse the extended key of cosigner %d", i)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		if key.IsPrivate() {
+			str := fmt.Sprintf("the key of cosigner %d is private, only extended public keys are accepted", i)
+			return nil, managerError(ErrInvalidKeyType, str, nil)
+		}
+		if !key.IsForNet(m.chainParams) {
+			str := fmt.Sprintf("the key of cosigner %d is not for the %s network", i, m.chainParams.Name)
+			return nil, managerError(ErrWrongNet, str, nil)
+		}
+		xpub = key.String()
+		if _, ok := seen[xpub]; ok {
+			str := fmt.Sprintf("the key of cosigner %d is given twice", i)
+			return nil, managerError(ErrInvalidKeyType, str, nil)
+		}
+		seen[xpub] = struct{}{}
+		account, isOurs := ours[xpub]
+		ma.Cosigners[i] = MultiSigCosigner{XPub: xpub, Ours: isOurs, Account: account}
+	}
+	if e = b.Put([]byte(name), serializeMultiSigAccount(ma)); E.Chk(e) {
+		str := fmt.Sprintf("failed to store multisig account '%s'", name)
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// FetchMultiSigAccount returns the multisig account with the name.
+func (m *Manager) FetchMultiSigAccount(ns walletdb.ReadBucket, name string) (*MultiSigAccount, error) {
+	var v []byte
+	if b := ns.NestedReadBucket(multiSigBucketName); b != nil {
+		v = b.Get([]byte(name))
+	}
+	if v == nil {
+		str := fmt.Sprintf("multisig account '%s' not found", name)
+		return nil, managerError(ErrAccountNotFound, str, nil)
+	}
+	return deserializeMultiSigAccount([]byte(name), v)
+}
+
+// ForEachMultiSigAccount calls fn with each multisig account of the manager, in name order. Iteration stops at the
+// first error returned by fn, which is returned.
+func (m *Manager) ForEachMultiSigAccount(ns walletdb.ReadBucket, fn func(ma *MultiSigAccount) error) error {
+	b := ns.NestedReadBucket(multiSigBucketName)
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(
+		func(k, v []byte) (e error) {
+			var ma *MultiSigAccount
+			if ma, e = deserializeMultiSigAccount(k, v); E.Chk(e) {
+				return
+			}
+			return fn(ma)
+		},
+	)
+}
+
+// NextMultiSigAddress returns the next deposit address of the multisig account with the name. The redeem script is
+// imported into the BIP0044 scope and the signing keys of the cosigners that are ours are derived and stored, so the
+// wallet tracks payments to the address and can add its signatures when they are spent. The manager must be unlocked.
+func (m *Manager) NextMultiSigAddress(
+	ns walletdb.ReadWriteBucket, name string, bs *BlockStamp,
+) (addr ManagedScriptAddress, e error) {
+	var ma *MultiSigAccount
+	if ma, e = m.FetchMultiSigAccount(ns, name); E.Chk(e) {
+		return
+	}
+	var s *ScopedKeyManager
+	if s, e = m.FetchScopedKeyManager(KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	index := ma.NextIndex
+	var script []byte
+	if script, e = ma.RedeemScript(index, m.chainParams); E.Chk(e) {
+		return
+	}
+	for _, c := range ma.Cosigners {
+		if !c.Ours {
+			continue
+		}
+		if e = s.ExtendExternalAddresses(ns, c.Account, index); E.Chk(e) {
+			return
+		}
+	}
+	if addr, e = s.ImportScript(ns, script, bs); e != nil {
+		if !IsError(e, ErrDuplicateAddress) {
+			return
+		}
+		// The address was already imported, such as by importing the redeem script from a cosigner.
+		var p2sh *btcaddr.ScriptHash
+		if p2sh, e = btcaddr.NewScriptHash(script, m.chainParams); E.Chk(e) {
+			return
+		}
+		var known ManagedAddress
+		if known, e = s.Address(ns, p2sh); E.Chk(e) {
+			return
+		}
+		addr = known.(ManagedScriptAddress)
+	}
+	ma.NextIndex++
+	if e = ns.NestedReadWriteBucket(multiSigBucketName).Put([]byte(name), serializeMultiSigAccount(ma)); E.Chk(e) {
+		str := fmt.Sprintf("failed to store multisig account '%s'", name)
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// accountPubKeys returns the accounts of the scope keyed by their extended public key.
+func (s *ScopedKeyManager) accountPubKeys(ns walletdb.ReadBucket) (keys map[string]uint32, e error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	keys = make(map[string]uint32)
+	e = forEachAccount(
+		ns, &s.scope, func(account uint32) (e error) {
+			if account == ImportedAddrAccount {
+				return
+			}
+			var acctInfo *accountInfo
+			if acctInfo, e = s.loadAccountInfo(ns, account); E.Chk(e) {
+				return
+			}
+			keys[acctInfo.acctKeyPub.String()] = account
+			return
+		},
+	)
+	return
+}
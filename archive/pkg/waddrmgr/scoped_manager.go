@@ -1,10 +1,12 @@
 package waddrmgr
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"github.com/p9c/pod/pkg/btcaddr"
 	"github.com/p9c/pod/pkg/chaincfg"
 	"sync"
+	"time"
 
 	ec "github.com/p9c/pod/pkg/ecc"
 	"github.com/p9c/pod/pkg/util"
@@ -88,12 +90,6 @@ type ScopeAddrSchema struct {
 // 	Purpose: 49,
 // 	Coin:    0,
 // }
-// // KeyScopeBIP0084 is the key scope for BIP0084 derivation. BIP0084 will be used
-// // to derive all p2wkh addresses.
-// KeyScopeBIP0084 = KeyScope{
-// 	Purpose: 84,
-// 	Coin:    0,
-// }
 
 var (
 	// KeyScopeBIP0044 is the key scope for BIP0044 derivation. Legacy wallets will
@@ -102,6 +98,14 @@ var (
 		Purpose: 44,
 		Coin:    0,
 	}
+	// KeyScopeBIP0084 is the key scope for BIP0084 derivation. BIP0084 will be used
+	// to derive all p2wkh addresses. It is not one of the default key scopes, and is
+	// only created with NewScopedKeyManager when a wallet first hands out a bech32
+	// address.
+	KeyScopeBIP0084 = KeyScope{
+		Purpose: 84,
+		Coin:    0,
+	}
 	// DefaultKeyScopes is the set of default key scopes that will be created by the
 	// root manager upon initial creation.
 	DefaultKeyScopes = []KeyScope{
@@ -117,10 +121,6 @@ var (
 		// 	ExternalAddrType: NestedWitnessPubKey,
 		// 	InternalAddrType: WitnessPubKey,
 		// },
-		// KeyScopeBIP0084: {
-		// 	ExternalAddrType: WitnessPubKey,
-		// 	InternalAddrType: WitnessPubKey,
-		// },
 		KeyScopeBIP0044: {
 			InternalAddrType: PubKeyHash,
 			ExternalAddrType: PubKeyHash,
@@ -148,6 +148,12 @@ type ScopedKeyManager struct {
 	rootManager *Manager
 	// addrs is a cached map of all the addresses that we currently manager.
 	addrs map[addrKey]ManagedAddress
+	// ownedAddrs is the set of hashes of the IDs of every address known to
+	// this manager. It is loaded when the manager is opened and updated as
+	// addresses are derived and imported, so lookups of addresses that are not
+	// ours, which is the common case when scanning blocks, can be answered
+	// without touching the database.
+	ownedAddrs map[addrHashKey]struct{}
 	// acctInfo houses information about accounts including what is needed to
 	// generate deterministic chained keys for each created account.
 	acctInfo map[uint32]*accountInfo
@@ -159,6 +165,48 @@ type ScopedKeyManager struct {
 	mtx            sync.RWMutex
 }
 
+// addrHashKey is the hash of an address ID as used to key the address bucket.
+type addrHashKey [sha256.Size]byte
+
+// addOwned records that the passed address ID is known to the manager.
+//
+// This function MUST be called with the manager lock held for writes.
+func (s *ScopedKeyManager) addOwned(addressID []byte) {
+	if s.ownedAddrs == nil {
+		return
+	}
+	s.ownedAddrs[sha256.Sum256(addressID)] = struct{}{}
+}
+
+// mayOwn returns false when the passed address ID is certainly not known to
+// the manager, in which case there is no need to look for it in the database.
+//
+// This function MUST be called with the manager lock held for reads.
+func (s *ScopedKeyManager) mayOwn(addressID []byte) bool {
+	if s.ownedAddrs == nil {
+		return true
+	}
+	_, ok := s.ownedAddrs[sha256.Sum256(addressID)]
+	return ok
+}
+
+// loadOwned populates the set of owned addresses from the database.
+func (s *ScopedKeyManager) loadOwned(ns walletdb.ReadBucket) (e error) {
+	owned := make(map[addrHashKey]struct{})
+	if e = forEachAddressHash(
+		ns, &s.scope, func(addrHash []byte) error {
+			var k addrHashKey
+			copy(k[:], addrHash)
+			owned[k] = struct{}{}
+			return nil
+		},
+	); E.Chk(e) {
+		return e
+	}
+	s.ownedAddrs = owned
+	return nil
+}
+
 // Scope returns the exact KeyScope of this scoped key manager.
 func (s *ScopedKeyManager) Scope() KeyScope {
 	return s.scope
@@ -401,9 +449,59 @@ func (s *ScopedKeyManager) AccountProperties(
 		}
 		props.ImportedKeyCount = importedKeyCount
 	}
+	props.Archived = isAccountArchived(ns, &s.scope, account)
 	return props, nil
 }
 
+// AccountPubKey returns the serialized extended public key of an account, which
+// cosigners of a multisig account share with each other. The imported account
+// has no extended key to return.
+func (s *ScopedKeyManager) AccountPubKey(
+	ns walletdb.ReadBucket,
+	account uint32,
+) (string, error) {
+	if account == ImportedAddrAccount {
+		str := "the imported account has no extended key"
+		return "", managerError(ErrInvalidAccount, str, nil)
+	}
+	defer s.mtx.Unlock()
+	s.mtx.Lock()
+	acctInfo, e := s.loadAccountInfo(ns, account)
+	if E.Chk(e) {
+		return "", e
+	}
+	return acctInfo.acctKeyPub.String(), nil
+}
+
+// AccountPrivKey returns the serialized extended private key of an account, so
+// the account can be restored in other wallet software. The manager must be
+// unlocked, and the imported account has no extended key to return.
+func (s *ScopedKeyManager) AccountPrivKey(
+	ns walletdb.ReadBucket,
+	account uint32,
+) (string, error) {
+	if s.rootManager.WatchOnly() {
+		return "", managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
+	if account == ImportedAddrAccount {
+		str := "the imported account has no extended key"
+		return "", managerError(ErrInvalidAccount, str, nil)
+	}
+	defer s.mtx.Unlock()
+	s.mtx.Lock()
+	if s.rootManager.IsLocked() {
+		return "", managerError(ErrLocked, errLocked, nil)
+	}
+	acctInfo, e := s.loadAccountInfo(ns, account)
+	if E.Chk(e) {
+		return "", e
+	}
+	if acctInfo.acctKeyPriv == nil {
+		return "", managerError(ErrLocked, errLocked, nil)
+	}
+	return acctInfo.acctKeyPriv.String(), nil
+}
+
 // DeriveFromKeyPath attempts to derive a maximal child key (under the BIP0044
 // scheme) from a given key path. If key derivation isn't possible, then an
 // error will be returned.
@@ -553,6 +651,7 @@ func (s *ScopedKeyManager) loadAndCacheAddress(
 	}
 	// Cache and return the new managed address.
 	s.addrs[addrKey(managedAddr.Address().ScriptAddress())] = managedAddr
+	s.addOwned(managedAddr.Address().ScriptAddress())
 	return managedAddr, nil
 }
 
@@ -565,6 +664,9 @@ func (s *ScopedKeyManager) existsAddress(ns walletdb.ReadBucket, addressID []byt
 	if _, ok := s.addrs[addrKey(addressID)]; ok {
 		return true
 	}
+	if !s.mayOwn(addressID) {
+		return false
+	}
 	// Chk the database if not already found above.
 	return existsAddress(ns, &s.scope, addressID)
 }
@@ -596,6 +698,13 @@ func (s *ScopedKeyManager) Address(
 		s.mtx.RUnlock()
 		return ma, nil
 	}
+	// Addresses that were never derived or imported by this manager are not in
+	// the database either.
+	if !s.mayOwn(addr.ScriptAddress()) {
+		s.mtx.RUnlock()
+		str := fmt.Sprintf("failed to fetch address '%s': address not found", addr.ScriptAddress())
+		return nil, managerError(ErrAddressNotFound, str, nil)
+	}
 	s.mtx.RUnlock()
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -746,6 +855,9 @@ func (s *ScopedKeyManager) nextAddresses(
 				return nil, maybeConvertDbError(e)
 			}
 		}
+		if e = s.indexScript(ns, ma); E.Chk(e) {
+			return nil, e
+		}
 	}
 	// Finally update the next address tracking and add the addresses to the cache
 	// after the newly generated addresses have been successfully added to the db.
@@ -753,6 +865,7 @@ func (s *ScopedKeyManager) nextAddresses(
 	for _, info := range addressInfo {
 		ma := info.managedAddr
 		s.addrs[addrKey(ma.Address().ScriptAddress())] = ma
+		s.addOwned(ma.Address().ScriptAddress())
 		// Add the new managed address to the list of addresses that need their private
 		// keys derived when the address manager is next unlocked.
 		if s.rootManager.IsLocked() && !s.rootManager.WatchOnly() {
@@ -910,12 +1023,16 @@ func (s *ScopedKeyManager) extendAddresses(
 				return maybeConvertDbError(e)
 			}
 		}
+		if e = s.indexScript(ns, ma); E.Chk(e) {
+			return e
+		}
 	}
 	// Finally update the next address tracking and add the addresses to the cache
 	// after the newly generated addresses have been successfully added to the db.
 	for _, info := range addressInfo {
 		ma := info.managedAddr
 		s.addrs[addrKey(ma.Address().ScriptAddress())] = ma
+		s.addOwned(ma.Address().ScriptAddress())
 		// Add the new managed address to the list of addresses that need their private
 		// keys derived when the address manager is next unlocked.
 		if s.rootManager.IsLocked() && !s.rootManager.WatchOnly() {
@@ -968,6 +1085,91 @@ func (s *ScopedKeyManager) NextInternalAddresses(
 	return s.nextAddresses(ns, account, numAddresses, true)
 }
 
+// LeaseChangeAddress returns an internal address for the account that is not
+// held by any other lease and reserves it for the given duration, so that
+// transactions built at the same time never share a change address. The address
+// of an expired lease that has not been used on chain is handed out again before
+// a new one is derived, so abandoned transactions don't leave gaps in the
+// internal branch. Leases on addresses that have since been used are dropped.
+func (s *ScopedKeyManager) LeaseChangeAddress(
+	ns walletdb.ReadWriteBucket,
+	account uint32, duration time.Duration,
+) (ma ManagedAddress, e error) {
+	if account > MaxAccountNum {
+		if e = managerError(ErrAccountNumTooHigh, errAcctTooHigh, nil); E.Chk(e) {
+		}
+		return nil, e
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var leases []*dbChangeLeaseRow
+	if leases, e = fetchChangeLeases(ns, &s.scope); E.Chk(e) {
+		return nil, maybeConvertDbError(e)
+	}
+	now := time.Now()
+	var recycled *dbChangeLeaseRow
+	for _, lease := range leases {
+		if fetchAddressUsed(ns, &s.scope, lease.addressID) {
+			if e = deleteChangeLease(ns, &s.scope, lease.addressID); E.Chk(e) {
+				return nil, maybeConvertDbError(e)
+			}
+			continue
+		}
+		if recycled == nil && lease.account == account && lease.expiry <= now.Unix() {
+			recycled = lease
+		}
+	}
+	if recycled != nil {
+		var rowInterface interface{}
+		if rowInterface, e = fetchAddress(ns, &s.scope, recycled.addressID); E.Chk(e) {
+			return nil, maybeConvertDbError(e)
+		}
+		if ma, e = s.rowInterfaceToManaged(ns, rowInterface); E.Chk(e) {
+			return nil, e
+		}
+		s.addrs[addrKey(recycled.addressID)] = ma
+		s.addOwned(recycled.addressID)
+		D.Ln("recycling expired change address lease", ma.Address().EncodeAddress())
+	} else {
+		var addrs []ManagedAddress
+		if addrs, e = s.nextAddresses(ns, account, 1, true); E.Chk(e) {
+			return nil, e
+		}
+		ma = addrs[0]
+	}
+	lease := &dbChangeLeaseRow{
+		account:   account,
+		expiry:    now.Add(duration).Unix(),
+		addressID: ma.Address().ScriptAddress(),
+	}
+	if e = putChangeLease(ns, &s.scope, lease); E.Chk(e) {
+		return nil, maybeConvertDbError(e)
+	}
+	return ma, nil
+}
+
+// ReleaseChangeAddress expires the lease held on the passed change address, if
+// there is one, so that it can be handed out again by LeaseChangeAddress. It is
+// called when the transaction the address was leased for is abandoned.
+func (s *ScopedKeyManager) ReleaseChangeAddress(
+	ns walletdb.ReadWriteBucket,
+	address btcaddr.Address,
+) (e error) {
+	addressID := address.ScriptAddress()
+	var lease *dbChangeLeaseRow
+	if lease, e = fetchChangeLease(ns, &s.scope, addressID); E.Chk(e) {
+		return maybeConvertDbError(e)
+	}
+	if lease == nil {
+		return nil
+	}
+	lease.expiry = 0
+	if e = putChangeLease(ns, &s.scope, lease); E.Chk(e) {
+		return maybeConvertDbError(e)
+	}
+	return nil
+}
+
 // ExtendExternalAddresses ensures that all valid external keys through
 // lastIndex are derived and stored in the wallet. This is used to ensure that
 // wallet's persistent state catches up to a external child that was found
@@ -1127,9 +1329,9 @@ func (s *ScopedKeyManager) newAccount(
 		return e
 	}
 	// Chk that account with the same name does not exist
-	if _, e = s.lookupAccount(ns, name); E.Chk(e) {
+	if _, e = s.lookupAccount(ns, name); e == nil {
 		str := fmt.Sprintf("account with the same name already exists")
-		return managerError(ErrDuplicateAccount, str, e)
+		return managerError(ErrDuplicateAccount, str, nil)
 	}
 	// Fetch the cointype key which will be used to derive the next account extended
 	// keys
@@ -1348,8 +1550,12 @@ func (s *ScopedKeyManager) ImportPrivateKey(
 		return nil, e
 	}
 	managedAddr.imported = true
+	if e = s.indexScript(ns, managedAddr); E.Chk(e) {
+		return nil, e
+	}
 	// Add the new managed address to the cache of recent addresses and return it.
 	s.addrs[addrKey(managedAddr.Address().ScriptAddress())] = managedAddr
+	s.addOwned(managedAddr.Address().ScriptAddress())
 	return managedAddr, nil
 }
 
@@ -1444,8 +1650,12 @@ func (s *ScopedKeyManager) ImportScript(
 		scriptAddr.scriptCT = make([]byte, len(script))
 		copy(scriptAddr.scriptCT, script)
 	}
+	if e = s.indexScript(ns, scriptAddr); E.Chk(e) {
+		return nil, e
+	}
 	// Add the new managed address to the cache of recent addresses and return it.
 	s.addrs[addrKey(scriptHash)] = scriptAddr
+	s.addOwned(scriptHash)
 	return scriptAddr, nil
 }
 
@@ -1477,7 +1687,11 @@ func (s *ScopedKeyManager) MarkUsed(
 	ns walletdb.ReadWriteBucket,
 	address btcaddr.Address,
 ) (e error) {
-	addressID := address.ScriptAddress()
+	return s.markUsed(ns, address.ScriptAddress())
+}
+
+// markUsed updates the used flag for the address with the passed address ID.
+func (s *ScopedKeyManager) markUsed(ns walletdb.ReadWriteBucket, addressID []byte) (e error) {
 	if e = markAddressUsed(ns, &s.scope, addressID); E.Chk(e) {
 		return maybeConvertDbError(e)
 	}
@@ -1510,6 +1724,135 @@ func (s *ScopedKeyManager) ForEachAccount(
 	return forEachAccount(ns, &s.scope, fn)
 }
 
+// DeriveAccountAddresses derives the addresses at indexes from start up to but not
+// including end on a branch of an account, which need not exist yet, without
+// storing the account or the addresses. Indexes that do not give a valid child
+// key are left out. This is used to look for an account on chain before adding
+// it. Since account keys are hardened, it requires the manager to be unlocked.
+func (s *ScopedKeyManager) DeriveAccountAddresses(
+	ns walletdb.ReadBucket,
+	account, branch, start, end uint32,
+) (addrs map[uint32]btcaddr.Address, e error) {
+	if s.rootManager.WatchOnly() {
+		return nil, managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.rootManager.IsLocked() {
+		return nil, managerError(ErrLocked, errLocked, nil)
+	}
+	var coinTypePrivEnc []byte
+	if _, coinTypePrivEnc, e = fetchCoinTypeKeys(ns, &s.scope); E.Chk(e) {
+		return nil, e
+	}
+	var serializedKeyPriv []byte
+	if serializedKeyPriv, e = s.rootManager.cryptoKeyPriv.Decrypt(coinTypePrivEnc); E.Chk(e) {
+		str := fmt.Sprintf("failed to decrypt cointype serialized private key")
+		return nil, managerError(ErrLocked, str, e)
+	}
+	var coinTypeKeyPriv *hdkeychain.ExtendedKey
+	coinTypeKeyPriv, e = hdkeychain.NewKeyFromString(string(serializedKeyPriv))
+	zero.Bytes(serializedKeyPriv)
+	if E.Chk(e) {
+		str := fmt.Sprintf("failed to create cointype extended private key")
+		return nil, managerError(ErrKeyChain, str, e)
+	}
+	var acctKeyPriv *hdkeychain.ExtendedKey
+	acctKeyPriv, e = deriveAccountKey(coinTypeKeyPriv, account)
+	coinTypeKeyPriv.Zero()
+	if E.Chk(e) {
+		str := "failed to convert private key for account"
+		return nil, managerError(ErrKeyChain, str, e)
+	}
+	// The public key shares memory with the private key, so it is only zeroed once
+	// the addresses are derived.
+	defer acctKeyPriv.Zero()
+	var branchKey *hdkeychain.ExtendedKey
+	if branchKey, e = acctKeyPriv.Neuter(); E.Chk(e) {
+		str := "failed to convert public key for account"
+		return nil, managerError(ErrKeyChain, str, e)
+	}
+	if branchKey, e = branchKey.Child(branch); E.Chk(e) {
+		str := fmt.Sprintf("failed to derive extended key branch %d", branch)
+		return nil, managerError(ErrKeyChain, str, e)
+	}
+	addrType := s.addrSchema.ExternalAddrType
+	if branch == InternalBranch {
+		addrType = s.addrSchema.InternalAddrType
+	}
+	addrs = make(map[uint32]btcaddr.Address, end-start)
+	for index := start; index < end; index++ {
+		var key *hdkeychain.ExtendedKey
+		if key, e = branchKey.Child(index); e == hdkeychain.ErrInvalidChild {
+			continue
+		} else if E.Chk(e) {
+			str := fmt.Sprintf("failed to derive child extended key -- branch %d, child %d", branch, index)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		var ma *managedAddress
+		if ma, e = newManagedAddressFromExtKey(
+			s, DerivationPath{Account: account, Branch: branch, Index: index}, key, addrType,
+		); E.Chk(e) {
+			return nil, e
+		}
+		addrs[index] = ma.Address()
+	}
+	return addrs, nil
+}
+
+// DeriveExternalAddresses derives the external addresses of an existing account
+// at indexes from start up to but not including end from the account public key,
+// without storing them, so it works while the manager is locked. Indexes that do
+// not give a valid child key are left out. This is used to look ahead of the
+// next external address of the account before choosing one to hand out, and the
+// addresses up to the chosen one are then stored with ExtendExternalAddresses.
+func (s *ScopedKeyManager) DeriveExternalAddresses(
+	ns walletdb.ReadBucket,
+	account, start, end uint32,
+) (addrs map[uint32]btcaddr.Address, e error) {
+	if account > MaxAccountNum {
+		return nil, managerError(ErrAccountNumTooHigh, errAcctTooHigh, nil)
+	}
+	if end > MaxAddressesPerAccount {
+		str := fmt.Sprintf(
+			"index %d would exceed the maximum allowed number of addresses per account of %d",
+			end, MaxAddressesPerAccount,
+		)
+		return nil, managerError(ErrTooManyAddresses, str, nil)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var acctInfo *accountInfo
+	if acctInfo, e = s.loadAccountInfo(ns, account); E.Chk(e) {
+		return nil, e
+	}
+	var branchKey *hdkeychain.ExtendedKey
+	if branchKey, e = acctInfo.acctKeyPub.Child(ExternalBranch); E.Chk(e) {
+		str := fmt.Sprintf("failed to derive extended key branch %d", ExternalBranch)
+		return nil, managerError(ErrKeyChain, str, e)
+	}
+	addrs = make(map[uint32]btcaddr.Address)
+	for index := start; index < end; index++ {
+		var key *hdkeychain.ExtendedKey
+		if key, e = branchKey.Child(index); e == hdkeychain.ErrInvalidChild {
+			continue
+		} else if E.Chk(e) {
+			str := fmt.Sprintf("failed to derive child extended key -- branch %d, child %d", ExternalBranch, index)
+			return nil, managerError(ErrKeyChain, str, e)
+		}
+		key.SetNet(s.rootManager.chainParams)
+		var ma *managedAddress
+		if ma, e = newManagedAddressFromExtKey(
+			s, DerivationPath{Account: account, Branch: ExternalBranch, Index: index}, key,
+			s.addrSchema.ExternalAddrType,
+		); E.Chk(e) {
+			return nil, e
+		}
+		addrs[index] = ma.Address()
+	}
+	return addrs, nil
+}
+
 // LastAccount returns the last account stored in the manager.
 func (s *ScopedKeyManager) LastAccount(ns walletdb.ReadBucket) (uint32, error) {
 	return fetchLastAccount(ns, &s.scope)
@@ -0,0 +1,174 @@
+package waddrmgr
+
+import (
+	"encoding/binary"
+
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// scriptIndexBucketName is the name of the bucket that indexes the addresses of every scope by the output script that
+// pays to them, so outputs can be matched to the wallet without deriving or decoding their addresses. It is created
+// with the manager, or by the upgrade to version 6 for older wallets, and kept up to date as addresses are derived and
+// imported.
+var scriptIndexBucketName = []byte("scriptidx")
+
+// ScriptLocation is where the address an output script pays to is kept in the manager.
+type ScriptLocation struct {
+	Scope   KeyScope
+	Account uint32
+	// Derived is whether the address was derived from the account key at Branch and Index, which is not so for
+	// imported keys and scripts.
+	Derived bool
+	Branch  uint32
+	Index   uint32
+	// AddressID is the key or script hash of the address.
+	AddressID []byte
+}
+
+// Internal returns whether the address is on the internal branch of its account, as change addresses are.
+func (l *ScriptLocation) Internal() bool {
+	return l.Derived && l.Branch == InternalBranch
+}
+
+// The script index value is serialized as such:
+//
+//	[0:4]   Scope purpose (4 bytes)
+//	[4:8]   Scope coin (4 bytes)
+//	[8:12]  Account (4 bytes)
+//	[12]    1 if the address was derived, 0 otherwise (1 byte)
+//	[13:17] Branch (4 bytes)
+//	[17:21] Index (4 bytes)
+//	[21:]   Address ID
+func serializeScriptLocation(loc *ScriptLocation) []byte {
+	v := make([]byte, 21+len(loc.AddressID))
+	binary.LittleEndian.PutUint32(v[0:4], loc.Scope.Purpose)
+	binary.LittleEndian.PutUint32(v[4:8], loc.Scope.Coin)
+	binary.LittleEndian.PutUint32(v[8:12], loc.Account)
+	if loc.Derived {
+		v[12] = 1
+	}
+	binary.LittleEndian.PutUint32(v[13:17], loc.Branch)
+	binary.LittleEndian.PutUint32(v[17:21], loc.Index)
+	copy(v[21:], loc.AddressID)
+	return v
+}
+
+func deserializeScriptLocation(v []byte) (loc *ScriptLocation, e error) {
+	if len(v) < 21 {
+		str := "malformed serialized script location"
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	loc = &ScriptLocation{
+		Scope: KeyScope{
+			Purpose: binary.LittleEndian.Uint32(v[0:4]),
+			Coin:    binary.LittleEndian.Uint32(v[4:8]),
+		},
+		Account:   binary.LittleEndian.Uint32(v[8:12]),
+		Derived:   v[12] == 1,
+		Branch:    binary.LittleEndian.Uint32(v[13:17]),
+		Index:     binary.LittleEndian.Uint32(v[17:21]),
+		AddressID: append([]byte(nil), v[21:]...),
+	}
+	return
+}
+
+// IsIndexedScript returns whether outputs with the script are matched to the wallet by the script index. Scripts of
+// other classes, such as pay to public key, can pay to an address of the wallet without being in the index.
+func IsIndexedScript(script []byte) bool {
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyHashTy, txscript.ScriptHashTy:
+		return true
+	}
+	return false
+}
+
+// indexScript adds the output script paying to the address to the script index. Addresses that can't be paid to with
+// a standard script are left out.
+func (s *ScopedKeyManager) indexScript(ns walletdb.ReadWriteBucket, ma ManagedAddress) (e error) {
+	script, ee := txscript.PayToAddrScript(ma.Address())
+	if ee != nil {
+		D.Ln("not indexing address", ma.Address(), "without a standard script:", ee)
+		return nil
+	}
+	loc := &ScriptLocation{
+		Scope:     s.scope,
+		Account:   ma.Account(),
+		AddressID: ma.Address().ScriptAddress(),
+	}
+	if pka, ok := ma.(ManagedPubKeyAddress); ok {
+		var path DerivationPath
+		if _, path, loc.Derived = pka.DerivationInfo(); loc.Derived {
+			loc.Branch, loc.Index = path.Branch, path.Index
+		}
+	}
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(scriptIndexBucketName); E.Chk(e) {
+		str := "failed to create script index bucket"
+		return managerError(ErrDatabase, str, e)
+	}
+	if e = b.Put(script, serializeScriptLocation(loc)); E.Chk(e) {
+		str := "failed to store script index entry"
+		return managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// indexAllScripts adds the output scripts of every address stored for the scope to the script index.
+func (s *ScopedKeyManager) indexAllScripts(ns walletdb.ReadWriteBucket) (e error) {
+	var hashes [][]byte
+	if e = forEachAddressHash(
+		ns, &s.scope, func(addrHash []byte) error {
+			hashes = append(hashes, append([]byte(nil), addrHash...))
+			return nil
+		},
+	); E.Chk(e) {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, addrHash := range hashes {
+		var row interface{}
+		if row, e = fetchAddressByHash(ns, &s.scope, addrHash); E.Chk(e) {
+			return
+		}
+		var ma ManagedAddress
+		if ma, e = s.rowInterfaceToManaged(ns, row); E.Chk(e) {
+			return
+		}
+		if e = s.indexScript(ns, ma); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// LookupScript returns where the address the output script pays to is kept in the manager, or false if the script
+// is not in the script index.
+func (m *Manager) LookupScript(ns walletdb.ReadBucket, script []byte) (loc *ScriptLocation, ok bool) {
+	b := ns.NestedReadBucket(scriptIndexBucketName)
+	if b == nil {
+		return nil, false
+	}
+	v := b.Get(script)
+	if v == nil {
+		return nil, false
+	}
+	var e error
+	if loc, e = deserializeScriptLocation(v); E.Chk(e) {
+		return nil, false
+	}
+	return loc, true
+}
+
+// MarkScriptUsed updates the used flag of the address found in the script index.
+func (m *Manager) MarkScriptUsed(ns walletdb.ReadWriteBucket, loc *ScriptLocation) (e error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	s, ok := m.scopedManagers[loc.Scope]
+	if !ok {
+		str := "unable to find the scope of the address"
+		return managerError(ErrScopeNotFound, str, nil)
+	}
+	return s.markUsed(ns, loc.AddressID)
+}
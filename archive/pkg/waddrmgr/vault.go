@@ -0,0 +1,247 @@
+package waddrmgr
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/btcaddr"
+	"github.com/p9c/pod/pkg/txscript"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+var (
+	// vaultBucketName is the name of the bucket that stores the vault accounts of the manager, keyed by the account
+	// name. It is created when the first vault account is added.
+	vaultBucketName = []byte("vaultaccounts")
+	// vaultAddressBucketName is the name of the bucket holding a bucket for each vault account, named after it, that
+	// stores its deposit addresses keyed by their script hash.
+	vaultAddressBucketName = []byte("vaultaddresses")
+)
+
+// VaultAccount is a BIP0044 account whose deposit addresses pay to a script that can only be spent with the key of the
+// address once the chain has reached the lock height of the address, so a thief holding the keys of the wallet has to
+// wait as long as its owner does.
+//
+// The lock height of every address is LockHeight when it is set, and otherwise Delay blocks after the height the
+// address is generated at. The delay is counted from when the address is generated, not from when it is paid, as the
+// chain has no relative lock times.
+type VaultAccount struct {
+	Name       string
+	Account    uint32
+	LockHeight int32
+	Delay      int32
+}
+
+// VaultAddress is a deposit address of a vault account.
+type VaultAddress struct {
+	Address *btcaddr.ScriptHash
+	// PubKey is the serialized compressed key that signs for the address once it is unlocked.
+	PubKey     []byte
+	LockHeight int32
+}
+
+// The vault account value is serialized as such:
+//
+//	[0:4]  Account (4 bytes)
+//	[4:8]  Lock height (4 bytes)
+//	[8:12] Delay (4 bytes)
+func serializeVaultAccount(va *VaultAccount) []byte {
+	v := make([]byte, 12)
+	binary.LittleEndian.PutUint32(v[0:4], va.Account)
+	binary.LittleEndian.PutUint32(v[4:8], uint32(va.LockHeight))
+	binary.LittleEndian.PutUint32(v[8:12], uint32(va.Delay))
+	return v
+}
+
+func deserializeVaultAccount(k, v []byte) (*VaultAccount, error) {
+	if len(v) != 12 {
+		str := "malformed serialized vault account"
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	return &VaultAccount{
+		Name:       string(k),
+		Account:    binary.LittleEndian.Uint32(v[0:4]),
+		LockHeight: int32(binary.LittleEndian.Uint32(v[4:8])),
+		Delay:      int32(binary.LittleEndian.Uint32(v[8:12])),
+	}, nil
+}
+
+// The vault address value is serialized as such:
+//
+//	[0:4] Lock height (4 bytes)
+//	[4:]  Serialized public key
+func serializeVaultAddress(va *VaultAddress) []byte {
+	v := make([]byte, 4, 4+len(va.PubKey))
+	binary.LittleEndian.PutUint32(v, uint32(va.LockHeight))
+	return append(v, va.PubKey...)
+}
+
+// VaultScript returns the redeem script of a vault deposit address, which checks the spending transaction is locked to
+// at least lockHeight before checking the signature of the key:
+//
+//	<lockHeight> OP_CHECKLOCKTIMEVERIFY OP_DROP <pubKey> OP_CHECKSIG
+func VaultScript(pubKey []byte, lockHeight int32) ([]byte, error) {
+	return txscript.TimeLockScript(&txscript.TimeLock{Value: int64(lockHeight), PubKey: pubKey})
+}
+
+// NewVaultAccount adds a BIP0044 account with the name whose deposit addresses are locked until the chain reaches
+// lockHeight, or when it is zero, until delay blocks after they are generated. Exactly one of them must be set, and a
+// lock height must be below txscript.LockTimeThreshold. The manager must be unlocked to add the account.
+func (m *Manager) NewVaultAccount(
+	ns walletdb.ReadWriteBucket, name string, lockHeight, delay int32,
+) (va *VaultAccount, e error) {
+	if (lockHeight == 0) == (delay == 0) || lockHeight < 0 || delay < 0 {
+		str := "a vault account needs either a lock height or a delay"
+		return nil, managerError(ErrInvalidAccount, str, nil)
+	}
+	if lockHeight >= txscript.LockTimeThreshold {
+		str := fmt.Sprintf("lock height %d is a timestamp, it must be below %d", lockHeight, int64(txscript.LockTimeThreshold))
+		return nil, managerError(ErrInvalidAccount, str, nil)
+	}
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(vaultBucketName); E.Chk(e) {
+		str := "failed to create vault accounts bucket"
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	if b.Get([]byte(name)) != nil {
+		str := fmt.Sprintf("vault account '%s' already exists", name)
+		return nil, managerError(ErrDuplicateAccount, str, nil)
+	}
+	var s *ScopedKeyManager
+	if s, e = m.FetchScopedKeyManager(KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	va = &VaultAccount{Name: name, LockHeight: lockHeight, Delay: delay}
+	if va.Account, e = s.NewAccount(ns, name); E.Chk(e) {
+		return nil, e
+	}
+	if e = b.Put([]byte(name), serializeVaultAccount(va)); E.Chk(e) {
+		str := fmt.Sprintf("failed to store vault account '%s'", name)
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// FetchVaultAccount returns the vault account with the name.
+func (m *Manager) FetchVaultAccount(ns walletdb.ReadBucket, name string) (*VaultAccount, error) {
+	var v []byte
+	if b := ns.NestedReadBucket(vaultBucketName); b != nil {
+		v = b.Get([]byte(name))
+	}
+	if v == nil {
+		str := fmt.Sprintf("vault account '%s' not found", name)
+		return nil, managerError(ErrAccountNotFound, str, nil)
+	}
+	return deserializeVaultAccount([]byte(name), v)
+}
+
+// ForEachVaultAccount calls fn with each vault account of the manager, in name order. Iteration stops at the first
+// error returned by fn, which is returned.
+func (m *Manager) ForEachVaultAccount(ns walletdb.ReadBucket, fn func(va *VaultAccount) error) error {
+	b := ns.NestedReadBucket(vaultBucketName)
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(
+		func(k, v []byte) (e error) {
+			var va *VaultAccount
+			if va, e = deserializeVaultAccount(k, v); E.Chk(e) {
+				return
+			}
+			return fn(va)
+		},
+	)
+}
+
+// IsVaultAccount returns whether the BIP0044 account is a vault account, which only hands out vault deposit addresses.
+func (m *Manager) IsVaultAccount(ns walletdb.ReadBucket, account uint32) (vault bool, e error) {
+	e = m.ForEachVaultAccount(
+		ns, func(va *VaultAccount) error {
+			vault = vault || va.Account == account
+			return nil
+		},
+	)
+	return
+}
+
+// NextVaultAddress returns the next deposit address of the vault account with the name, which wraps the next external
+// key of the account. The redeem script is imported into the BIP0044 scope so the wallet tracks payments to the
+// address. The manager must be unlocked.
+func (m *Manager) NextVaultAddress(
+	ns walletdb.ReadWriteBucket, name string, bs *BlockStamp,
+) (addr *VaultAddress, script []byte, e error) {
+	// The lock is checked first so the next external key of the account is not used up when the script can't be
+	// imported.
+	if m.IsLocked() {
+		return nil, nil, managerError(ErrLocked, errLocked, nil)
+	}
+	var va *VaultAccount
+	if va, e = m.FetchVaultAccount(ns, name); E.Chk(e) {
+		return
+	}
+	var s *ScopedKeyManager
+	if s, e = m.FetchScopedKeyManager(KeyScopeBIP0044); E.Chk(e) {
+		return
+	}
+	var keys []ManagedAddress
+	if keys, e = s.NextExternalAddresses(ns, va.Account, 1); E.Chk(e) {
+		return
+	}
+	addr = &VaultAddress{
+		PubKey:     keys[0].(ManagedPubKeyAddress).PubKey().SerializeCompressed(),
+		LockHeight: va.LockHeight,
+	}
+	if va.LockHeight == 0 {
+		addr.LockHeight = bs.Height + va.Delay
+	}
+	if script, e = VaultScript(addr.PubKey, addr.LockHeight); E.Chk(e) {
+		return
+	}
+	var msa ManagedScriptAddress
+	if msa, e = s.ImportScript(ns, script, bs); E.Chk(e) {
+		return
+	}
+	addr.Address = msa.Address().(*btcaddr.ScriptHash)
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(vaultAddressBucketName); E.Chk(e) {
+		str := "failed to create vault addresses bucket"
+		return nil, nil, managerError(ErrDatabase, str, e)
+	}
+	if b, e = b.CreateBucketIfNotExists([]byte(name)); E.Chk(e) {
+		str := fmt.Sprintf("failed to create addresses bucket of vault account '%s'", name)
+		return nil, nil, managerError(ErrDatabase, str, e)
+	}
+	if e = b.Put(addr.Address.ScriptAddress(), serializeVaultAddress(addr)); E.Chk(e) {
+		str := fmt.Sprintf("failed to store address of vault account '%s'", name)
+		return nil, nil, managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// ForEachVaultAddress calls fn with each deposit address of the vault account with the name. Iteration stops at the
+// first error returned by fn, which is returned.
+func (m *Manager) ForEachVaultAddress(ns walletdb.ReadBucket, name string, fn func(addr *VaultAddress) error) error {
+	b := ns.NestedReadBucket(vaultAddressBucketName)
+	if b != nil {
+		b = b.NestedReadBucket([]byte(name))
+	}
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(
+		func(k, v []byte) (e error) {
+			if len(v) < 4 {
+				str := "malformed serialized vault address"
+				return managerError(ErrDatabase, str, nil)
+			}
+			addr := &VaultAddress{
+				PubKey:     append([]byte{}, v[4:]...),
+				LockHeight: int32(binary.LittleEndian.Uint32(v[:4])),
+			}
+			if addr.Address, e = btcaddr.NewScriptHashFromHash(k, m.chainParams); E.Chk(e) {
+				return
+			}
+			return fn(addr)
+		},
+	)
+}
@@ -0,0 +1,101 @@
+package waddrmgr
+
+import (
+	"encoding/binary"
+
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// watchScriptsBucketName is the name of the bucket that stores the output scripts watched by the manager, keyed by the
+// script. It is created when the first script is imported.
+var watchScriptsBucketName = []byte("watchscripts")
+
+// WatchedScript is an output script that is watched without being controlled by the manager, such as a script that
+// can't be expressed as an address. Payments to it and spends of them are tracked but never counted in the balance.
+type WatchedScript struct {
+	Script []byte
+	Label  string
+	// Block is the block from which the script is watched.
+	Block BlockStamp
+}
+
+// The watched script value is serialized as such:
+//
+//	[0:4]  Block height (4 bytes)
+//	[4:36] Block hash (32 bytes)
+//	[36:]  Label
+func serializeWatchedScript(ws *WatchedScript) []byte {
+	v := make([]byte, 36+len(ws.Label))
+	binary.LittleEndian.PutUint32(v[0:4], uint32(ws.Block.Height))
+	copy(v[4:36], ws.Block.Hash[:])
+	copy(v[36:], ws.Label)
+	return v
+}
+
+func deserializeWatchedScript(k, v []byte) (ws *WatchedScript, e error) {
+	if len(v) < 36 {
+		str := "malformed serialized watched script"
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	ws = &WatchedScript{
+		Script: append([]byte(nil), k...),
+		Label:  string(v[36:]),
+	}
+	ws.Block.Height = int32(binary.LittleEndian.Uint32(v[0:4]))
+	copy(ws.Block.Hash[:], v[4:36])
+	return
+}
+
+// ImportScriptPubKey adds an output script to the scripts watched by the manager from the block bs onwards. It is an
+// error to import a script that is already watched.
+func (m *Manager) ImportScriptPubKey(
+	ns walletdb.ReadWriteBucket, script []byte, label string, bs *BlockStamp,
+) (ws *WatchedScript, e error) {
+	if len(script) == 0 {
+		str := "empty output script"
+		return nil, managerError(ErrInvalidKeyType, str, nil)
+	}
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(watchScriptsBucketName); E.Chk(e) {
+		str := "failed to create watched scripts bucket"
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	if b.Get(script) != nil {
+		str := "output script is already watched"
+		return nil, managerError(ErrDuplicateAddress, str, nil)
+	}
+	ws = &WatchedScript{
+		Script: append([]byte(nil), script...),
+		Label:  label,
+		Block:  *bs,
+	}
+	if e = b.Put(ws.Script, serializeWatchedScript(ws)); E.Chk(e) {
+		str := "failed to store watched script"
+		return nil, managerError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// IsWatchedScript returns whether the output script is watched by the manager.
+func (m *Manager) IsWatchedScript(ns walletdb.ReadBucket, script []byte) bool {
+	b := ns.NestedReadBucket(watchScriptsBucketName)
+	return b != nil && b.Get(script) != nil
+}
+
+// ForEachWatchedScript calls fn with each output script watched by the manager, in script order. Iteration stops at
+// the first error returned by fn, which is returned.
+func (m *Manager) ForEachWatchedScript(ns walletdb.ReadBucket, fn func(ws *WatchedScript) error) error {
+	b := ns.NestedReadBucket(watchScriptsBucketName)
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(
+		func(k, v []byte) (e error) {
+			var ws *WatchedScript
+			if ws, e = deserializeWatchedScript(k, v); E.Chk(e) {
+				return
+			}
+			return fn(ws)
+		},
+	)
+}
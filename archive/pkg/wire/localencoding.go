@@ -0,0 +1,53 @@
+package wire
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxReusedBuffer is the largest buffer kept for reuse, so the occasional large message does not hold on to its memory.
+const maxReusedBuffer = 1 << 21
+
+// encodePool holds the buffers messages are encoded into with LocalEncoding.
+var encodePool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getEncodeBuffer takes an empty buffer from the pool.
+func getEncodeBuffer() *bytes.Buffer {
+	b := encodePool.Get().(*bytes.Buffer)
+	b.Reset()
+	return b
+}
+
+// putEncodeBuffer returns a buffer to the pool once what was encoded into it has been written.
+func putEncodeBuffer(b *bytes.Buffer) {
+	if b.Cap() <= maxReusedBuffer {
+		encodePool.Put(b)
+	}
+}
+
+// payloadPool holds the buffers the payloads of messages are read into with LocalEncoding.
+var payloadPool = sync.Pool{
+	New: func() interface{} {
+		return new([]byte)
+	},
+}
+
+// getPayloadBuffer takes a buffer of length n from the pool, making a larger one if the one taken is too small.
+func getPayloadBuffer(n uint32) []byte {
+	b := payloadPool.Get().(*[]byte)
+	if uint32(cap(*b)) < n {
+		*b = make([]byte, n)
+	}
+	return (*b)[:n]
+}
+
+// putPayloadBuffer returns a buffer to the pool once the message read into it has been decoded.
+func putPayloadBuffer(b []byte) {
+	if cap(b) <= maxReusedBuffer {
+		payloadPool.Put(&b)
+	}
+}
@@ -0,0 +1,103 @@
+package wire
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+// TestLocalEncoding ensures messages written with LocalEncoding have no checksum, so they are only read with it, that
+// they decode to the message that was written, and that only the payload of a block is returned as the buffers of other
+// messages are reused.
+func TestLocalEncoding(t *testing.T) {
+	pver := ProtocolVersion
+	for _, msg := range []Message{multiTx, &blockOne} {
+		var buf bytes.Buffer
+		if _, e := WriteMessageWithEncodingN(&buf, msg, pver, MainNet, BaseEncoding|LocalEncoding); e != nil {
+			t.Fatalf("WriteMessageWithEncodingN %s: %v", msg.Command(), e)
+		}
+		raw := buf.Bytes()
+		if checksum := raw[MessageHeaderSize-4 : MessageHeaderSize]; !bytes.Equal(checksum, make([]byte, 4)) {
+			t.Fatalf("%s was written with checksum %x", msg.Command(), checksum)
+		}
+		if _, _, _, e := ReadMessageWithEncodingN(bytes.NewReader(raw), pver, MainNet, BaseEncoding); e == nil {
+			t.Fatalf("%s without a checksum was read without LocalEncoding", msg.Command())
+		}
+		// A message written with its checksum is also read with LocalEncoding.
+		var checked bytes.Buffer
+		if _, e := WriteMessageWithEncodingN(&checked, msg, pver, MainNet, BaseEncoding); e != nil {
+			t.Fatalf("WriteMessageWithEncodingN %s: %v", msg.Command(), e)
+		}
+		for _, b := range [][]byte{raw, checked.Bytes()} {
+			n, got, payload, e := ReadMessageWithEncodingN(bytes.NewReader(b), pver, MainNet, BaseEncoding|LocalEncoding)
+			if e != nil {
+				t.Fatalf("ReadMessageWithEncodingN %s: %v", msg.Command(), e)
+			}
+			if n != len(b) || !reflect.DeepEqual(got, msg) {
+				t.Fatalf("read %d bytes of %d, got %+v, want %+v", n, len(b), got, msg)
+			}
+			if _, isBlock := msg.(*Block); isBlock != (payload != nil) {
+				t.Fatalf("%s returned payload %x", msg.Command(), payload)
+			}
+		}
+	}
+}
+
+// benchMessageRoundTrip writes and reads back a block of a thousand transactions with the encoding.
+func benchMessageRoundTrip(b *testing.B, enc MessageEncoding) {
+	var blk Block
+	if e := blk.Deserialize(bytes.NewReader(benchBlockBytes(b))); e != nil {
+		b.Fatal(e)
+	}
+	var buf bytes.Buffer
+	if _, e := WriteMessageWithEncodingN(&buf, &blk, ProtocolVersion, MainNet, enc); e != nil {
+		b.Fatal(e)
+	}
+	raw := buf.Bytes()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = WriteMessageWithEncodingN(ioutil.Discard, &blk, ProtocolVersion, MainNet, enc)
+		_, _, _, _ = ReadMessageWithEncodingN(bytes.NewReader(raw), ProtocolVersion, MainNet, enc)
+	}
+}
+
+// BenchmarkMessageRoundTrip performs a benchmark on how long it takes to write and read a block message with its
+// checksum.
+func BenchmarkMessageRoundTrip(b *testing.B) {
+	benchMessageRoundTrip(b, BaseEncoding)
+}
+
+// BenchmarkMessageRoundTripLocal performs a benchmark on how long it takes to write and read a block message with
+// LocalEncoding.
+func BenchmarkMessageRoundTripLocal(b *testing.B) {
+	benchMessageRoundTrip(b, BaseEncoding|LocalEncoding)
+}
+
+// benchTxRoundTrip writes and reads back a transaction message with the encoding.
+func benchTxRoundTrip(b *testing.B, enc MessageEncoding) {
+	var buf bytes.Buffer
+	if _, e := WriteMessageWithEncodingN(&buf, multiTx, ProtocolVersion, MainNet, enc); e != nil {
+		b.Fatal(e)
+	}
+	raw := buf.Bytes()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = WriteMessageWithEncodingN(ioutil.Discard, multiTx, ProtocolVersion, MainNet, enc)
+		_, _, _, _ = ReadMessageWithEncodingN(bytes.NewReader(raw), ProtocolVersion, MainNet, enc)
+	}
+}
+
+// BenchmarkTxRoundTrip performs a benchmark on how long it takes to write and read a transaction message with its
+// checksum.
+func BenchmarkTxRoundTrip(b *testing.B) {
+	benchTxRoundTrip(b, BaseEncoding)
+}
+
+// BenchmarkTxRoundTripLocal performs a benchmark on how long it takes to write and read a transaction message with
+// LocalEncoding.
+func BenchmarkTxRoundTripLocal(b *testing.B) {
+	benchTxRoundTrip(b, BaseEncoding|LocalEncoding)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"time"
 	"unicode/utf8"
 	
 	"github.com/p9c/pod/pkg/chainhash"
@@ -51,6 +52,8 @@ const (
 	CmdCFilter      = "cfilter"
 	CmdCFHeaders    = "cfheaders"
 	CmdCFCheckpt    = "cfcheckpt"
+	CmdGetUTXOs     = "getutxos"
+	CmdUTXOs        = "utxos"
 )
 
 // MessageEncoding represents the wire message encoding format to be used.
@@ -59,6 +62,15 @@ type MessageEncoding uint32
 const (
 	// BaseEncoding encodes all messages in the default format specified for the Bitcoin wire protocol.
 	BaseEncoding MessageEncoding = 1 << iota
+	// ZeroCopyEncoding is combined with another encoding to decode block messages with Block.DeserializeZeroCopy, so
+	// the scripts of their transactions are slices of the message payload rather than copies.
+	ZeroCopyEncoding
+	// LocalEncoding is combined with another encoding on trusted connections within the host, such as between pod
+	// components over the loopback interface or a unix socket, where messages cannot be corrupted on the way. Messages
+	// are written with a zero checksum and read without checking it, and the payloads are encoded into and, other than
+	// those of blocks, read into reused buffers. As the buffer a message other than a block was read into is reused, its
+	// payload is not returned.
+	LocalEncoding
 	// // WitnessEncoding encodes all messages other than transaction messages using
 	// // the default Bitcoin wire protocol specification. For transaction messages,
 	// // the new encoding format detailed in BIP0144 will be used.
@@ -140,6 +152,10 @@ func makeEmptyMessage(command string) (Message, error) {
 		msg = &MsgCFHeaders{}
 	case CmdCFCheckpt:
 		msg = &MsgCFCheckpt{}
+	case CmdGetUTXOs:
+		msg = &MsgGetUTXOs{}
+	case CmdUTXOs:
+		msg = &MsgUTXOs{}
 	default:
 		return nil, fmt.Errorf("unhandled command [%s]", command)
 	}
@@ -232,8 +248,14 @@ func WriteMessageWithEncodingN(
 	}
 	copy(command[:], cmd)
 	// Encode the message payload.
-	var bw bytes.Buffer
-	if e = msg.BtcEncode(&bw, pver, encoding); E.Chk(e) {
+	var bw *bytes.Buffer
+	if encoding&LocalEncoding != 0 {
+		bw = getEncodeBuffer()
+		defer putEncodeBuffer(bw)
+	} else {
+		bw = new(bytes.Buffer)
+	}
+	if e = msg.BtcEncode(bw, pver, encoding); E.Chk(e) {
 		return totalBytes, e
 	}
 	payload := bw.Bytes()
@@ -262,7 +284,9 @@ func WriteMessageWithEncodingN(
 	hdr.magic = btcnet
 	hdr.command = cmd
 	hdr.length = uint32(lenp)
-	copy(hdr.checksum[:], chainhash.DoubleHashB(payload)[0:4])
+	if encoding&LocalEncoding == 0 {
+		copy(hdr.checksum[:], chainhash.DoubleHashB(payload)[0:4])
+	}
 	// Encode the header for the message. This is done to a buffer rather than directly to the writer since
 	// writeElements doesn't return the number of bytes written.
 	hw := bytes.NewBuffer(make([]byte, 0, MessageHeaderSize))
@@ -287,6 +311,15 @@ func WriteMessageWithEncodingN(
 // message encoding is to to consult when decoding wire messages.
 func ReadMessageWithEncodingN(r io.Reader, pver uint32, btcnet BitcoinNet, enc MessageEncoding) (
 	totalBytes int, msg Message, payload []byte, e error,
+) {
+	totalBytes, msg, payload, _, e = ReadMessageWithDecodeTime(r, pver, btcnet, enc)
+	return
+}
+
+// ReadMessageWithDecodeTime is the same as ReadMessageWithEncodingN except it also returns how long it took to decode
+// the payload of the message once it was read, which leaves out the time spent waiting for it to arrive.
+func ReadMessageWithDecodeTime(r io.Reader, pver uint32, btcnet BitcoinNet, enc MessageEncoding) (
+	totalBytes int, msg Message, payload []byte, decodeTime time.Duration, e error,
 ) {
 	var hdr *messageHeader
 	var n int
@@ -309,25 +342,25 @@ func ReadMessageWithEncodingN(r io.Reader, pver uint32, btcnet BitcoinNet, enc M
 				"indicates %d bytes, but max message payload is %d "+
 				"bytes.", hdr.length, MaxMessagePayload,
 		)
-		return totalBytes, nil, nil, messageError("ReadMessage", str)
+		return totalBytes, nil, nil, 0, messageError("ReadMessage", str)
 	}
 	// Chk for messages from the wrong bitcoin network.
 	if hdr.magic != btcnet {
 		discardInput(r, hdr.length)
 		str := fmt.Sprintf("message from other network [%v]", hdr.magic)
-		return totalBytes, nil, nil, messageError("ReadMessage", str)
+		return totalBytes, nil, nil, 0, messageError("ReadMessage", str)
 	}
 	// Chk for malformed commands.
 	command := hdr.command
 	if !utf8.ValidString(command) {
 		discardInput(r, hdr.length)
 		str := fmt.Sprintf("invalid command %v", []byte(command))
-		return totalBytes, nil, nil, messageError("ReadMessage", str)
+		return totalBytes, nil, nil, 0, messageError("ReadMessage", str)
 	}
 	// Create struct of appropriate message type based on the command.
 	if msg, e = makeEmptyMessage(command); E.Chk(e) {
 		discardInput(r, hdr.length)
-		return totalBytes, nil, nil, messageError(
+		return totalBytes, nil, nil, 0, messageError(
 			"ReadMessage",
 			e.Error(),
 		)
@@ -342,29 +375,50 @@ func ReadMessageWithEncodingN(r io.Reader, pver uint32, btcnet BitcoinNet, enc M
 				"indicates %v bytes, but max payload size for "+
 				"messages of type [%v] is %v.", hdr.length, command, mpl,
 		)
-		return totalBytes, nil, nil, messageError("ReadMessage", str)
+		return totalBytes, nil, nil, 0, messageError("ReadMessage", str)
+	}
+	// Read payload. Blocks keep referring to their payload, so only the payloads of other messages are read into reused
+	// buffers.
+	_, isBlock := msg.(*Block)
+	reuse := enc&LocalEncoding != 0 && !isBlock
+	if reuse {
+		payload = getPayloadBuffer(hdr.length)
+		defer putPayloadBuffer(payload)
+	} else {
+		payload = make([]byte, hdr.length)
 	}
-	// Read payload.
-	payload = make([]byte, hdr.length)
 	n, e = io.ReadFull(r, payload)
 	totalBytes += n
 	if E.Chk(e) {
-		return totalBytes, nil, nil, e
+		return totalBytes, nil, nil, 0, e
 	}
 	// Test checksum.
-	checksum := chainhash.DoubleHashB(payload)[0:4]
-	if !bytes.Equal(checksum[:], hdr.checksum[:]) {
-		str := fmt.Sprintf(
-			"payload checksum failed - header "+
-				"indicates %v, but actual checksum is %v.",
-			hdr.checksum, checksum,
-		)
-		return totalBytes, nil, nil, messageError("ReadMessage", str)
+	if enc&LocalEncoding == 0 {
+		checksum := chainhash.DoubleHashB(payload)[0:4]
+		if !bytes.Equal(checksum[:], hdr.checksum[:]) {
+			str := fmt.Sprintf(
+				"payload checksum failed - header "+
+					"indicates %v, but actual checksum is %v.",
+				hdr.checksum, checksum,
+			)
+			return totalBytes, nil, nil, 0, messageError("ReadMessage", str)
+		}
 	}
 	// Unmarshal message. NOTE: This must be a *bytes.Buffer since the MsgVersion BtcDecode function requires it.
 	pr := bytes.NewBuffer(payload)
-	if e = msg.BtcDecode(pr, pver, enc); E.Chk(e) {
-		return totalBytes, nil, nil, e
+	decodeStart := time.Now()
+	if blk, ok := msg.(*Block); ok && enc&ZeroCopyEncoding != 0 {
+		// The payload was allocated for this message alone, so the block can keep referring to it.
+		e = blk.DeserializeZeroCopy(payload)
+	} else {
+		e = msg.BtcDecode(pr, pver, enc)
+	}
+	if E.Chk(e) {
+		return totalBytes, nil, nil, 0, e
+	}
+	decodeTime = time.Since(decodeStart)
+	if reuse {
+		payload = nil
 	}
 	return
 }
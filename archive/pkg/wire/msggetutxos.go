@@ -0,0 +1,99 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+// MaxGetUTXOsOutPoints is the maximum number of outpoints a getutxos message may ask about.
+const MaxGetUTXOsOutPoints = 100
+
+// MsgGetUTXOs implements the Message interface and represents a bitcoin getutxos message (BIP0064). It is used to ask a
+// peer which of a list of outpoints are unspent, optionally including the transactions in its memory pool, so light
+// clients can check for outputs without downloading the blocks they are in. The peer replies with a utxos message.
+type MsgGetUTXOs struct {
+	CheckMempool bool
+	OutPoints    []OutPoint
+}
+
+// AddOutPoint adds an outpoint to the list of outpoints to ask about.
+func (msg *MsgGetUTXOs) AddOutPoint(op *OutPoint) (e error) {
+	if len(msg.OutPoints)+1 > MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf(
+			"too many outpoints in message [max %v]",
+			MaxGetUTXOsOutPoints,
+		)
+		return messageError("MsgGetUTXOs.AddOutPoint", str)
+	}
+	msg.OutPoints = append(msg.OutPoints, *op)
+	return
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgGetUTXOs) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) (e error) {
+	if e = readElement(r, &msg.CheckMempool); E.Chk(e) {
+		return
+	}
+	var count uint64
+	if count, e = ReadVarInt(r, pver); E.Chk(e) {
+		return
+	}
+	// Limit to max outpoints per message.
+	if count > MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf("too many outpoints in message [%v]", count)
+		return messageError("MsgGetUTXOs.BtcDecode", str)
+	}
+	msg.OutPoints = make([]OutPoint, count)
+	for i := range msg.OutPoints {
+		if e = readOutPoint(r, pver, TxVersion, &msg.OutPoints[i]); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgGetUTXOs) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) (e error) {
+	// Limit to max outpoints per message.
+	count := len(msg.OutPoints)
+	if count > MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf("too many outpoints in message [%v]", count)
+		return messageError("MsgGetUTXOs.BtcEncode", str)
+	}
+	if e = writeElement(w, msg.CheckMempool); E.Chk(e) {
+		return
+	}
+	if e = WriteVarInt(w, pver, uint64(count)); E.Chk(e) {
+		return
+	}
+	for i := range msg.OutPoints {
+		if e = writeOutPoint(w, pver, TxVersion, &msg.OutPoints[i]); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgGetUTXOs) Command() string {
+	return CmdGetUTXOs
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgGetUTXOs) MaxPayloadLength(pver uint32) uint32 {
+	// Check mempool flag 1 byte + num outpoints (varInt) + max allowed outpoints at 36 bytes each.
+	return 1 + MaxVarIntPayload + MaxGetUTXOsOutPoints*(chainhash.HashSize+4)
+}
+
+// NewMsgGetUTXOs returns a new bitcoin getutxos message that conforms to the Message interface. See MsgGetUTXOs for
+// details.
+func NewMsgGetUTXOs(checkMempool bool) *MsgGetUTXOs {
+	return &MsgGetUTXOs{
+		CheckMempool: checkMempool,
+	}
+}
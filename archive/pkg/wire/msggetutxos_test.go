@@ -0,0 +1,68 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestGetUTXOsWire tests the MsgGetUTXOs wire encode and decode, and the limit on the number of outpoints.
+func TestGetUTXOsWire(t *testing.T) {
+	pver := ProtocolVersion
+	msg := NewMsgGetUTXOs(true)
+	if cmd := msg.Command(); cmd != "getutxos" {
+		t.Errorf("NewMsgGetUTXOs: wrong command - got %v want getutxos", cmd)
+	}
+	op := NewOutPoint(&mainNetGenesisHash, 1)
+	if e := msg.AddOutPoint(op); e != nil {
+		t.Fatalf("AddOutPoint: %v", e)
+	}
+	want := []byte{
+		0x01, // Check mempool
+		0x01, // Varint for number of outpoints
+		0x6f, 0xe2, 0x8c, 0x0a, 0xb6, 0xf1, 0xb3, 0x72,
+		0xc1, 0xa6, 0xa2, 0x46, 0xae, 0x63, 0xf7, 0x4f,
+		0x93, 0x1e, 0x83, 0x65, 0xe1, 0x5a, 0x08, 0x9c,
+		0x68, 0xd6, 0x19, 0x00, 0x00, 0x00, 0x00, 0x00, // Hash
+		0x01, 0x00, 0x00, 0x00, // Index
+	}
+	var buf bytes.Buffer
+	if e := msg.BtcEncode(&buf, pver, BaseEncoding); e != nil {
+		t.Fatalf("BtcEncode: %v", e)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("BtcEncode\n got: %s want: %s", spew.Sdump(buf.Bytes()), spew.Sdump(want))
+	}
+	var readmsg MsgGetUTXOs
+	if e := readmsg.BtcDecode(bytes.NewReader(want), pver, BaseEncoding); e != nil {
+		t.Fatalf("BtcDecode: %v", e)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(&readmsg), spew.Sdump(msg))
+	}
+	// Fill the message to the limit and ensure one more outpoint is rejected.
+	for len(msg.OutPoints) < MaxGetUTXOsOutPoints {
+		if e := msg.AddOutPoint(op); e != nil {
+			t.Fatalf("AddOutPoint: %v", e)
+		}
+	}
+	if e := msg.AddOutPoint(op); e == nil {
+		t.Errorf("AddOutPoint: expected error adding more than %d outpoints", MaxGetUTXOsOutPoints)
+	}
+	buf.Reset()
+	if e := msg.BtcEncode(&buf, pver, BaseEncoding); e != nil {
+		t.Fatalf("BtcEncode: %v", e)
+	}
+	if uint32(buf.Len()) > msg.MaxPayloadLength(pver) {
+		t.Errorf("encoded length %d exceeds max payload length %d", buf.Len(), msg.MaxPayloadLength(pver))
+	}
+	// A message asking about too many outpoints must not decode.
+	tooMany := []byte{0x00, 0xfd, 0xe8, 0x03} // 1000 outpoints
+	if e := readmsg.BtcDecode(bytes.NewReader(tooMany), pver, BaseEncoding); e == nil {
+		t.Errorf("BtcDecode: expected error decoding %d outpoints", 1000)
+	} else if _, ok := e.(*MessageError); !ok {
+		t.Errorf("BtcDecode: wrong error type - got %T, want *MessageError", e)
+	}
+}
@@ -0,0 +1,137 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/p9c/pod/pkg/chainhash"
+)
+
+// UTXOMempoolHeight is the height reported in a utxos message for outputs of transactions in the memory pool.
+const UTXOMempoolHeight = 0x7fffffff
+
+// UTXO is an unspent transaction output in a utxos message, with the version of the transaction that created it and the
+// height of the block it is in.
+type UTXO struct {
+	TxVersion uint32
+	Height    uint32
+	Out       TxOut
+}
+
+// MsgUTXOs implements the Message interface and represents a bitcoin utxos message (BIP0064). It is the reply to a
+// getutxos message, giving the best chain tip of the peer, a bitmap with a bit set for each outpoint asked about that is
+// unspent, in the order they were asked about, and the unspent outputs in the same order.
+type MsgUTXOs struct {
+	ChainHeight  uint32
+	ChainTipHash chainhash.Hash
+	Bitmap       []byte
+	UTXOs        []*UTXO
+}
+
+// maxUTXOsBitmapLen is the length of the bitmap of a utxos message replying to the largest getutxos message.
+const maxUTXOsBitmapLen = (MaxGetUTXOsOutPoints + 7) / 8
+
+// AddUTXO sets the bit of the outpoint at index i of the getutxos message being replied to, and adds its output, or
+// only extends the bitmap to cover index i if u is nil because the outpoint is spent or unknown.
+func (msg *MsgUTXOs) AddUTXO(i int, u *UTXO) (e error) {
+	if i < 0 || i >= MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf(
+			"outpoint index %v out of range [max %v]", i,
+			MaxGetUTXOsOutPoints,
+		)
+		return messageError("MsgUTXOs.AddUTXO", str)
+	}
+	for len(msg.Bitmap) <= i/8 {
+		msg.Bitmap = append(msg.Bitmap, 0)
+	}
+	if u != nil {
+		msg.Bitmap[i/8] |= 1 << uint(i%8)
+		msg.UTXOs = append(msg.UTXOs, u)
+	}
+	return
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgUTXOs) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) (e error) {
+	if e = readElements(r, &msg.ChainHeight, &msg.ChainTipHash); E.Chk(e) {
+		return
+	}
+	if msg.Bitmap, e = ReadVarBytes(r, pver, maxUTXOsBitmapLen, "utxos bitmap"); E.Chk(e) {
+		return
+	}
+	var count uint64
+	if count, e = ReadVarInt(r, pver); E.Chk(e) {
+		return
+	}
+	// Limit to max outpoints per getutxos message.
+	if count > MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf("too many utxos in message [%v]", count)
+		return messageError("MsgUTXOs.BtcDecode", str)
+	}
+	utxos := make([]UTXO, count)
+	msg.UTXOs = make([]*UTXO, count)
+	for i := range utxos {
+		u := &utxos[i]
+		if e = readElements(r, &u.TxVersion, &u.Height); E.Chk(e) {
+			return
+		}
+		if e = readTxOut(r, pver, TxVersion, &u.Out); E.Chk(e) {
+			return
+		}
+		msg.UTXOs[i] = u
+	}
+	return
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgUTXOs) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) (e error) {
+	if len(msg.Bitmap) > maxUTXOsBitmapLen {
+		str := fmt.Sprintf("utxos bitmap too long [%v]", len(msg.Bitmap))
+		return messageError("MsgUTXOs.BtcEncode", str)
+	}
+	count := len(msg.UTXOs)
+	if count > MaxGetUTXOsOutPoints {
+		str := fmt.Sprintf("too many utxos in message [%v]", count)
+		return messageError("MsgUTXOs.BtcEncode", str)
+	}
+	if e = writeElements(w, msg.ChainHeight, &msg.ChainTipHash); E.Chk(e) {
+		return
+	}
+	if e = WriteVarBytes(w, pver, msg.Bitmap); E.Chk(e) {
+		return
+	}
+	if e = WriteVarInt(w, pver, uint64(count)); E.Chk(e) {
+		return
+	}
+	for _, u := range msg.UTXOs {
+		if e = writeElements(w, u.TxVersion, u.Height); E.Chk(e) {
+			return
+		}
+		if e = WriteTxOut(w, pver, TxVersion, &u.Out); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgUTXOs) Command() string {
+	return CmdUTXOs
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgUTXOs) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgUTXOs returns a new bitcoin utxos message for the chain tip with the given height and hash that conforms to the
+// Message interface. See MsgUTXOs for details.
+func NewMsgUTXOs(height uint32, tipHash *chainhash.Hash) *MsgUTXOs {
+	return &MsgUTXOs{
+		ChainHeight:  height,
+		ChainTipHash: *tipHash,
+	}
+}
@@ -0,0 +1,66 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestUTXOsWire tests the MsgUTXOs wire encode and decode, and the limits on the bitmap and number of outputs.
+func TestUTXOsWire(t *testing.T) {
+	pver := ProtocolVersion
+	msg := NewMsgUTXOs(0x10203, &mainNetGenesisHash)
+	if cmd := msg.Command(); cmd != "utxos" {
+		t.Errorf("NewMsgUTXOs: wrong command - got %v want utxos", cmd)
+	}
+	// The first of two outpoints is unspent, in the memory pool.
+	u := &UTXO{TxVersion: 1, Height: UTXOMempoolHeight, Out: TxOut{Value: 5, PkScript: []byte{0x51}}}
+	if e := msg.AddUTXO(0, u); e != nil {
+		t.Fatalf("AddUTXO: %v", e)
+	}
+	if e := msg.AddUTXO(1, nil); e != nil {
+		t.Fatalf("AddUTXO: %v", e)
+	}
+	if e := msg.AddUTXO(MaxGetUTXOsOutPoints, nil); e == nil {
+		t.Errorf("AddUTXO: expected error for outpoint index %d", MaxGetUTXOsOutPoints)
+	}
+	want := []byte{
+		0x03, 0x02, 0x01, 0x00, // Chain height
+		0x6f, 0xe2, 0x8c, 0x0a, 0xb6, 0xf1, 0xb3, 0x72,
+		0xc1, 0xa6, 0xa2, 0x46, 0xae, 0x63, 0xf7, 0x4f,
+		0x93, 0x1e, 0x83, 0x65, 0xe1, 0x5a, 0x08, 0x9c,
+		0x68, 0xd6, 0x19, 0x00, 0x00, 0x00, 0x00, 0x00, // Chain tip hash
+		0x01, 0x01, // Bitmap
+		0x01,                   // Varint for number of utxos
+		0x01, 0x00, 0x00, 0x00, // Transaction version
+		0xff, 0xff, 0xff, 0x7f, // Height
+		0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Value
+		0x01, 0x51, // Public key script
+	}
+	var buf bytes.Buffer
+	if e := msg.BtcEncode(&buf, pver, BaseEncoding); e != nil {
+		t.Fatalf("BtcEncode: %v", e)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("BtcEncode\n got: %s want: %s", spew.Sdump(buf.Bytes()), spew.Sdump(want))
+	}
+	var readmsg MsgUTXOs
+	if e := readmsg.BtcDecode(bytes.NewReader(want), pver, BaseEncoding); e != nil {
+		t.Fatalf("BtcDecode: %v", e)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(&readmsg), spew.Sdump(msg))
+	}
+	// Bitmaps longer than needed for the largest getutxos message and too many outputs must not decode.
+	tests := [][]byte{
+		append(append([]byte{}, want[:36]...), 0x0e),
+		append(append([]byte{}, want[:38]...), 0xfd, 0xe8, 0x03),
+	}
+	for i, test := range tests {
+		if e := readmsg.BtcDecode(bytes.NewReader(test), pver, BaseEncoding); e == nil {
+			t.Errorf("BtcDecode #%d: expected error", i)
+		}
+	}
+}
@@ -54,6 +54,10 @@ const (
 	SFNodeCF
 	// SFNode2X is a flag used to indicate a peer is running the Segwit2X software.
 	SFNode2X
+	// SFNodeLocal is a flag used to indicate a peer offers to use LocalEncoding on a connection within the host. It is
+	// only advertised in the version message of such a connection, and the encoding is used once both peers advertise
+	// it.
+	SFNodeLocal
 )
 
 // Map of service flags back to their constant names for pretty printing.
@@ -66,6 +70,7 @@ var sfStrings = map[ServiceFlag]string{
 	SFNodeBit5:    "SFNodeBit5",
 	SFNodeCF:      "SFNodeCF",
 	SFNode2X:      "SFNode2X",
+	SFNodeLocal:   "SFNodeLocal",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to lowest.
@@ -78,6 +83,7 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeBit5,
 	SFNodeCF,
 	SFNode2X,
+	SFNodeLocal,
 }
 
 // String returns the ServiceFlag in human-readable form.
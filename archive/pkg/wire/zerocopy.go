@@ -0,0 +1,175 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// zeroCopyReader reads from a byte slice, and hands out the variable length fields it reads as slices of it rather than
+// copies.
+type zeroCopyReader struct {
+	buf []byte
+	pos int
+}
+
+// Read copies the next bytes of the buffer into p. This is used for the fixed size fields.
+func (r *zeroCopyReader) Read(p []byte) (n int, e error) {
+	if r.pos >= len(r.buf) {
+		return 0, io.EOF
+	}
+	n = copy(p, r.buf[r.pos:])
+	r.pos += n
+	return
+}
+
+// next returns the next n bytes of the buffer without copying them. The capacity of the returned slice is limited to
+// its length so appending to it can not overwrite the rest of the buffer.
+func (r *zeroCopyReader) next(n uint64) (b []byte, e error) {
+	if n > uint64(len(r.buf)-r.pos) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	end := r.pos + int(n)
+	b = r.buf[r.pos:end:end]
+	r.pos = end
+	return
+}
+
+// script reads a variable length script in the same way as readScript, except the script is a slice of the buffer.
+func (r *zeroCopyReader) script(pver uint32, fieldName string) (b []byte, e error) {
+	var count uint64
+	if count, e = ReadVarInt(r, pver); E.Chk(e) {
+		return
+	}
+	if count > MaxMessagePayload {
+		str := fmt.Sprintf(
+			"%s is larger than the max allowed size "+
+				"[count %d, max %d]", fieldName, count, MaxMessagePayload,
+		)
+		return nil, messageError("readScript", str)
+	}
+	return r.next(count)
+}
+
+// newTxInputs returns the inputs of a transaction decoded by DeserializeZeroCopy, allocated together rather than one by
+// one.
+func newTxInputs(count uint64) []*TxIn {
+	txIns := make([]TxIn, count)
+	ptrs := make([]*TxIn, count)
+	for i := range txIns {
+		ptrs[i] = &txIns[i]
+	}
+	return ptrs
+}
+
+// newTxOutputs returns the outputs of a transaction decoded by DeserializeZeroCopy, allocated together rather than one
+// by one.
+func newTxOutputs(count uint64) []*TxOut {
+	txOuts := make([]TxOut, count)
+	ptrs := make([]*TxOut, count)
+	for i := range txOuts {
+		ptrs[i] = &txOuts[i]
+	}
+	return ptrs
+}
+
+// DeserializeZeroCopy decodes a block from buf into the receiver in the same way as Deserialize, but without copying
+// the scripts of the transactions: the signature scripts and public key scripts are slices of buf. The inputs and
+// outputs of each transaction are allocated together.
+//
+// The transactions are not pooled or reused, so they may be kept, and handed to other goroutines, for as long as the
+// caller likes. A pool would have to be refilled by the caller once the block is processed, but the transactions of a
+// processed block may still be held by the orphan pool and by the notifications sent to subscribers.
+//
+// This avoids allocating and copying the scripts of the block, at the cost of a contract on the lifetime of buf:
+//
+// - buf must not be modified or reused while the block, any of its transactions, or any script taken from them, is in
+// use, because the scripts change with it.
+//
+// - As long as any script from the block is referenced the whole of buf is kept in memory, so scripts that are kept
+// longer than the block, such as in a cache of unspent outputs, should be copied if buf is much larger than them.
+//
+// This makes it suitable for blocks read into a buffer of their own, such as the payload of a block message during the
+// initial block download.
+func (msg *Block) DeserializeZeroCopy(buf []byte) (e error) {
+	r := &zeroCopyReader{buf: buf}
+	if e = readBlockHeader(r, 0, &msg.Header); E.Chk(e) {
+		return
+	}
+	var txCount uint64
+	if txCount, e = ReadVarInt(r, 0); E.Chk(e) {
+		return
+	}
+	if txCount > maxTxPerBlock {
+		str := fmt.Sprintf(
+			"too many transactions to fit into a block [count %d, max %d]",
+			txCount, maxTxPerBlock,
+		)
+		return messageError("Block.DeserializeZeroCopy", str)
+	}
+	msg.Transactions = make([]*MsgTx, 0, txCount)
+	for i := uint64(0); i < txCount; i++ {
+		var tx *MsgTx
+		if tx, e = decodeTxZeroCopy(r); E.Chk(e) {
+			msg.Transactions = nil
+			return
+		}
+		msg.Transactions = append(msg.Transactions, tx)
+	}
+	return
+}
+
+// decodeTxZeroCopy decodes the next transaction of r in the same way as MsgTx.BtcDecode.
+func decodeTxZeroCopy(r *zeroCopyReader) (tx *MsgTx, e error) {
+	var version uint32
+	if version, e = binarySerializer.Uint32(r, littleEndian); E.Chk(e) {
+		return
+	}
+	var count uint64
+	if count, e = ReadVarInt(r, 0); E.Chk(e) {
+		return
+	}
+	if count > uint64(maxTxInPerMessage) {
+		str := fmt.Sprintf(
+			"too many input transactions to fit into "+
+				"max message size [count %d, max %d]", count,
+			maxTxInPerMessage,
+		)
+		return nil, messageError("MsgTx.BtcDecode", str)
+	}
+	tx = &MsgTx{Version: int32(version), TxIn: newTxInputs(count)}
+	for _, ti := range tx.TxIn {
+		if e = readOutPoint(r, 0, tx.Version, &ti.PreviousOutPoint); E.Chk(e) {
+			return nil, e
+		}
+		if ti.SignatureScript, e = r.script(0, "transaction input signature script"); E.Chk(e) {
+			return nil, e
+		}
+		if e = readElement(r, &ti.Sequence); E.Chk(e) {
+			return nil, e
+		}
+	}
+	if count, e = ReadVarInt(r, 0); E.Chk(e) {
+		return nil, e
+	}
+	if count > uint64(maxTxOutPerMessage) {
+		str := fmt.Sprintf(
+			"too many output transactions to fit into "+
+				"max message size [count %d, max %d]", count,
+			maxTxOutPerMessage,
+		)
+		return nil, messageError("MsgTx.BtcDecode", str)
+	}
+	tx.TxOut = newTxOutputs(count)
+	for _, to := range tx.TxOut {
+		if e = readElement(r, &to.Value); E.Chk(e) {
+			return nil, e
+		}
+		if to.PkScript, e = r.script(0, "transaction output public key script"); E.Chk(e) {
+			return nil, e
+		}
+	}
+	if tx.LockTime, e = binarySerializer.Uint32(r, littleEndian); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
@@ -0,0 +1,112 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestBlockDeserializeZeroCopy ensures blocks decoded without copying match the block they were serialized from, that their
+// scripts refer to the buffer, and that truncated blocks are rejected.
+func TestBlockDeserializeZeroCopy(t *testing.T) {
+	buf := append([]byte{}, blockOneBytes...)
+	var blk Block
+	if e := blk.DeserializeZeroCopy(buf); e != nil {
+		t.Fatalf("DeserializeZeroCopy: %v", e)
+	}
+	if !reflect.DeepEqual(blk.Header, blockOne.Header) ||
+		!reflect.DeepEqual(blk.Transactions, blockOne.Transactions) {
+		t.Fatalf("got block %v, want %v", spew.Sdump(blk), spew.Sdump(blockOne))
+	}
+	// The scripts are slices of the buffer, so they change with it.
+	pkScript := blk.Transactions[0].TxOut[0].PkScript
+	idx := bytes.Index(buf, pkScript)
+	buf[idx] ^= 0xff
+	if pkScript[0] != buf[idx] {
+		t.Errorf("public key script was copied")
+	}
+	if cap(pkScript) != len(pkScript) {
+		t.Errorf("script capacity %d reaches past its length %d", cap(pkScript), len(pkScript))
+	}
+	// Decoding another block must not change the transactions of the first, which may still be in use.
+	tx := blk.Transactions[0]
+	var other Block
+	if e := other.DeserializeZeroCopy(append([]byte{}, blockOneBytes...)); e != nil {
+		t.Fatalf("DeserializeZeroCopy: %v", e)
+	}
+	if other.Transactions[0] == tx || &other.Transactions[0].TxOut[0].PkScript[0] == &pkScript[0] {
+		t.Errorf("transaction of a block in use was reused")
+	}
+	for i := 0; i < len(blockOneBytes); i++ {
+		var truncated Block
+		if e := truncated.DeserializeZeroCopy(blockOneBytes[:i]); e == nil {
+			t.Fatalf("block truncated to %d bytes decoded", i)
+		}
+	}
+}
+
+// benchBlockBytes returns a serialized block of a thousand copies of multiTx.
+func benchBlockBytes(b *testing.B) []byte {
+	blk := NewMsgBlock(&blockOne.Header)
+	for i := 0; i < 1000; i++ {
+		if e := blk.AddTransaction(multiTx); e != nil {
+			b.Fatal(e)
+		}
+	}
+	var buf bytes.Buffer
+	if e := blk.Serialize(&buf); e != nil {
+		b.Fatal(e)
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkDeserializeBlock performs a benchmark on how long it takes to deserialize a block with Deserialize.
+func BenchmarkDeserializeBlock(b *testing.B) {
+	buf := benchBlockBytes(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var blk Block
+		_ = blk.Deserialize(bytes.NewReader(buf))
+	}
+}
+
+// BenchmarkDeserializeBlockZeroCopy performs a benchmark on how long it takes to deserialize a block without copying
+// its scripts. The scripts of every decoded block are checked to be slices of the buffer, and the bytes of scripts the
+// buffer saved from being copied are reported.
+func BenchmarkDeserializeBlockZeroCopy(b *testing.B) {
+	buf := benchBlockBytes(b)
+	start, end := &buf[0], &buf[len(buf)-1]
+	inBuf := func(script []byte) bool {
+		return len(script) == 0 || uintptr(unsafe.Pointer(&script[0])) >= uintptr(unsafe.Pointer(start)) &&
+			uintptr(unsafe.Pointer(&script[0])) <= uintptr(unsafe.Pointer(end))
+	}
+	var shared int
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var blk Block
+		if e := blk.DeserializeZeroCopy(buf); e != nil {
+			b.Fatal(e)
+		}
+		shared = 0
+		for _, tx := range blk.Transactions {
+			for _, txIn := range tx.TxIn {
+				if !inBuf(txIn.SignatureScript) {
+					b.Fatal("signature script was copied")
+				}
+				shared += len(txIn.SignatureScript)
+			}
+			for _, txOut := range tx.TxOut {
+				if !inBuf(txOut.PkScript) {
+					b.Fatal("public key script was copied")
+				}
+				shared += len(txOut.PkScript)
+			}
+		}
+	}
+	b.ReportMetric(float64(shared), "shared-script-B/op")
+}
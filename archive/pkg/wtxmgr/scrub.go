@@ -0,0 +1,308 @@
+package wtxmgr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// The kinds of rows checked by Scrub.
+const (
+	ScrubBlocks    = "blocks"
+	ScrubTxRecords = "txrecords"
+	ScrubCredits   = "credits"
+	ScrubUnspent   = "unspent"
+	ScrubDebits    = "debits"
+	ScrubUnmined   = "unmined"
+)
+
+// ScrubKinds are the kinds of rows checked by Scrub, in the order a full check of the store goes through them.
+var ScrubKinds = []string{ScrubBlocks, ScrubTxRecords, ScrubCredits, ScrubUnspent, ScrubDebits, ScrubUnmined}
+
+// ScrubFinding is an inconsistency found in a row of the store by Scrub.
+type ScrubFinding struct {
+	Kind    string
+	Key     []byte
+	Problem string
+	// index is true for problems with the unspent index, which can be repaired.
+	index bool
+}
+
+// problemf returns a finding with the problem described by the format and arguments.
+func problemf(format string, args ...interface{}) *ScrubFinding {
+	return &ScrubFinding{Problem: fmt.Sprintf(format, args...)}
+}
+
+// indexProblemf returns a finding of a problem with the unspent index described by the format and arguments.
+func indexProblemf(format string, args ...interface{}) *ScrubFinding {
+	f := problemf(format, args...)
+	f.index = true
+	return f
+}
+
+// Repairable returns whether Repair can fix the problem.
+func (f *ScrubFinding) Repairable() bool {
+	return f.index
+}
+
+// Repair fixes the problem if it still exists. Only the unspent index, which is rebuilt from the credits, is repaired,
+// as the other rows hold data that cannot be recovered from the rest of the store.
+func (f *ScrubFinding) Repair(ns walletdb.ReadWriteBucket) (e error) {
+	if !f.index {
+		return storeError(ErrInput, "the problem cannot be repaired", nil)
+	}
+	return repairUnspentIndex(ns, f.Kind, f.Key)
+}
+
+// Scrub checks up to limit rows of a kind, starting after the row with the given key, or from the first row if it is
+// nil, and returns the problems found, the number of rows checked and the key of the last row checked, which is nil
+// once the last row of the kind has been checked. Each row is checked to deserialize and to agree with the rows it refers
+// to, so that damage is found before the row is used. Checking a few rows at a time keeps the read transactions short.
+func (s *Store) Scrub(ns walletdb.ReadBucket, kind string, after []byte, limit int) (
+	findings []*ScrubFinding, checked int, last []byte, e error,
+) {
+	var bucket []byte
+	var check func(ns walletdb.ReadBucket, k, v []byte) []*ScrubFinding
+	switch kind {
+	case ScrubBlocks:
+		bucket, check = bucketBlocks, scrubBlock
+	case ScrubTxRecords:
+		bucket, check = bucketTxRecords, scrubTxRecord
+	case ScrubCredits:
+		bucket, check = bucketCredits, scrubCredit
+	case ScrubUnspent:
+		bucket, check = bucketUnspent, scrubUnspent
+	case ScrubDebits:
+		bucket, check = bucketDebits, scrubDebit
+	case ScrubUnmined:
+		bucket, check = bucketUnmined, scrubUnmined
+	default:
+		return nil, 0, nil, storeError(ErrInput, "unknown kind of row "+kind, nil)
+	}
+	c := ns.NestedReadBucket(bucket).ReadCursor()
+	var k, v []byte
+	if after == nil {
+		k, v = c.First()
+	} else if k, v = c.Seek(after); bytes.Equal(k, after) {
+		k, v = c.Next()
+	}
+	for ; k != nil && checked < limit; k, v = c.Next() {
+		for _, f := range check(ns, k, v) {
+			f.Kind, f.Key = kind, append([]byte{}, k...)
+			findings = append(findings, f)
+		}
+		checked++
+		last = append(last[:0], k...)
+	}
+	if k == nil {
+		last = nil
+	}
+	return
+}
+
+// scrubBlock checks that a block record deserializes and that the transactions it lists are recorded.
+func scrubBlock(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	var block blockRecord
+	if e := readRawBlockRecord(k, v, &block); e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	for i := range block.transactions {
+		if _, rec := existsTxRecord(ns, &block.transactions[i], &block.Block); rec == nil {
+			problems = append(
+				problems, problemf(
+					"block %v at height %d lists transaction %v which is not recorded", block.Hash, block.Height,
+					block.transactions[i],
+				),
+			)
+		}
+	}
+	return
+}
+
+// scrubTxRecord checks that a transaction record deserializes to the transaction of its key, and that its block is
+// recorded.
+func scrubTxRecord(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	var block Block
+	if e := readRawTxRecordBlock(k, &block); e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	var rec TxRecord
+	copy(rec.Hash[:], k)
+	if e := readRawTxRecord(&rec.Hash, v, &rec); e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	if hash := rec.MsgTx.TxHash(); hash != rec.Hash {
+		problems = append(problems, problemf("the record of transaction %v holds transaction %v", rec.Hash, hash))
+	}
+	if _, bv := existsBlockRecord(ns, block.Height); len(bv) < 32 || !bytes.Equal(bv[:32], block.Hash[:]) {
+		problems = append(
+			problems, problemf(
+				"transaction %v is recorded in block %v at height %d which is not recorded", rec.Hash, block.Hash,
+				block.Height,
+			),
+		)
+	}
+	return
+}
+
+// scrubCredit checks that a credit is an output of a recorded transaction with the amount of the output, that the
+// debit spending it is recorded if it is spent, and that it is in the unspent index if it is not.
+func scrubCredit(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	if len(k) < 72 {
+		return []*ScrubFinding{problemf("%s: short key (expected %d bytes, read %d)", bucketCredits, 72, len(k))}
+	}
+	amount, spent, e := fetchRawCreditAmountSpent(v)
+	if e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	var rec TxRecord
+	copy(rec.Hash[:], k)
+	index := extractRawCreditIndex(k)
+	if rv := existsRawTxRecord(ns, k[:68]); rv == nil {
+		problems = append(
+			problems, problemf("output %v:%d is credited but its transaction is not recorded", rec.Hash, index),
+		)
+	} else if e = readRawTxRecord(&rec.Hash, rv, &rec); e != nil {
+		problems = append(
+			problems, problemf("output %v:%d is credited but its transaction cannot be read: %v", rec.Hash, index, e),
+		)
+	} else if int(index) >= len(rec.MsgTx.TxOut) {
+		problems = append(
+			problems, problemf("output %v:%d is credited but the transaction has no such output", rec.Hash, index),
+		)
+	} else if value := rec.MsgTx.TxOut[index].Value; value != int64(amount) {
+		problems = append(
+			problems, problemf(
+				"output %v:%d is credited with %v but the output is worth %v", rec.Hash, index, amount,
+				amt.Amount(value),
+			),
+		)
+	}
+	switch {
+	case spent && len(v) < 81:
+		problems = append(
+			problems, problemf("output %v:%d is spent but the spending debit is not recorded in it", rec.Hash, index),
+		)
+	case spent:
+		if ns.NestedReadBucket(bucketDebits).Get(v[9:81]) == nil {
+			var spender chainhash.Hash
+			copy(spender[:], v[9:41])
+			problems = append(
+				problems, problemf("output %v:%d is spent by %v which has no debit recorded", rec.Hash, index, spender),
+			)
+		}
+	case !bytes.Equal(existsRawUnspent(ns, canonicalOutPoint(&rec.Hash, index)), k):
+		problems = append(
+			problems, indexProblemf("output %v:%d is unspent but missing from the unspent index", rec.Hash, index),
+		)
+	}
+	return
+}
+
+// scrubUnspent checks that an entry of the unspent index refers to a credit that is not spent.
+func scrubUnspent(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	credKey := existsRawUnspent(ns, k)
+	if credKey == nil {
+		return []*ScrubFinding{
+			indexProblemf(
+				"%s: short entry (key of %d bytes and value of %d bytes)", bucketUnspent, len(k), len(v),
+			),
+		}
+	}
+	var hash chainhash.Hash
+	copy(hash[:], k)
+	index := extractRawCreditIndex(credKey)
+	cv := existsRawCredit(ns, credKey)
+	if cv == nil {
+		return []*ScrubFinding{indexProblemf("output %v:%d is in the unspent index but is not credited", hash, index)}
+	}
+	if _, spent, e := fetchRawCreditAmountSpent(cv); e == nil && spent {
+		problems = append(problems, indexProblemf("output %v:%d is in the unspent index but is spent", hash, index))
+	}
+	return
+}
+
+// scrubDebit checks that a debit belongs to a recorded transaction and spends a credit marked spent by it.
+func scrubDebit(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	if len(k) < 72 || len(v) < 80 {
+		return []*ScrubFinding{
+			problemf(
+				"%s: short entry (key of %d bytes and value of %d bytes)", bucketDebits, len(k), len(v),
+			),
+		}
+	}
+	var hash chainhash.Hash
+	copy(hash[:], k)
+	index := byteOrder.Uint32(k[68:72])
+	if existsRawTxRecord(ns, k[:68]) == nil {
+		problems = append(problems, problemf("input %v:%d is debited but its transaction is not recorded", hash, index))
+	}
+	credKey := extractRawDebitCreditKey(v)
+	cv := existsRawCredit(ns, credKey)
+	switch {
+	case cv == nil:
+		problems = append(problems, problemf("input %v:%d debits a credit that is not recorded", hash, index))
+	case len(cv) < 81 || cv[8]&(1<<0) == 0:
+		problems = append(problems, problemf("input %v:%d debits a credit that is not marked spent", hash, index))
+	case !bytes.Equal(cv[9:81], k):
+		problems = append(
+			problems, problemf("input %v:%d debits a credit that is marked spent by another input", hash, index),
+		)
+	}
+	return
+}
+
+// scrubUnmined checks that an unmined transaction deserializes to the transaction of its key.
+func scrubUnmined(ns walletdb.ReadBucket, k, v []byte) (problems []*ScrubFinding) {
+	var rec TxRecord
+	if e := readRawUnminedHash(k, &rec.Hash); e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	if e := readRawTxRecord(&rec.Hash, v, &rec); e != nil {
+		return []*ScrubFinding{problemf("%v", e)}
+	}
+	if hash := rec.MsgTx.TxHash(); hash != rec.Hash {
+		problems = append(
+			problems, problemf("the unmined record of transaction %v holds transaction %v", rec.Hash, hash),
+		)
+	}
+	return
+}
+
+// repairUnspentIndex repairs the unspent index for a problem found with a credit or an entry of the index, if it still
+// needs repairing. A credit that is not spent is added to the index, and an entry for a credit that is spent or not
+// recorded is removed from it. The mined balance is left alone, as it is kept as credits are added and spent, not from
+// the index.
+func repairUnspentIndex(ns walletdb.ReadWriteBucket, kind string, k []byte) (e error) {
+	if kind == ScrubCredits {
+		cv := existsRawCredit(ns, k)
+		if cv == nil || len(k) < 72 {
+			return nil
+		}
+		var spent bool
+		if _, spent, e = fetchRawCreditAmountSpent(cv); e != nil || spent {
+			return e
+		}
+		var hash chainhash.Hash
+		copy(hash[:], k)
+		outPoint := canonicalOutPoint(&hash, extractRawCreditIndex(k))
+		if bytes.Equal(existsRawUnspent(ns, outPoint), k) {
+			return nil
+		}
+		return putRawUnspent(ns, outPoint, k[32:68])
+	}
+	if ns.NestedReadBucket(bucketUnspent).Get(k) == nil {
+		return nil
+	}
+	if credKey := existsRawUnspent(ns, k); credKey != nil {
+		if cv := existsRawCredit(ns, credKey); cv != nil {
+			if _, spent, _ := fetchRawCreditAmountSpent(cv); !spent {
+				return nil
+			}
+		}
+	}
+	return deleteRawUnspent(ns, k)
+}
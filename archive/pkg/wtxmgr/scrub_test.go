@@ -0,0 +1,109 @@
+package wtxmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// TestScrub ensures a consistent store has no findings, that damage to the unspent index is found and repaired, and
+// that a damaged transaction record is reported.
+func TestScrub(t *testing.T) {
+	t.Parallel()
+	s, db, teardown, e := testStore()
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer teardown()
+	dbtx, e := db.BeginReadWriteTx()
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer func() {
+		if e = dbtx.Commit(); e != nil {
+			t.Log(e)
+		}
+	}()
+	ns := dbtx.ReadWriteBucket(namespaceKey)
+	recvRec, e := NewTxRecord(TstRecvSerializedTx, time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = s.InsertTx(ns, recvRec, TstRecvTxBlockDetails); e != nil {
+		t.Fatal(e)
+	}
+	if e = s.AddCredit(ns, recvRec, TstRecvTxBlockDetails, 0, false); e != nil {
+		t.Fatal(e)
+	}
+	spendingRec, e := NewTxRecord(TstSpendingSerializedTx, time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = s.InsertTx(ns, spendingRec, TstSignedTxBlockDetails); e != nil {
+		t.Fatal(e)
+	}
+	if e = s.AddCredit(ns, spendingRec, TstSignedTxBlockDetails, 0, false); e != nil {
+		t.Fatal(e)
+	}
+	// scrub goes through every kind of row one at a time, as the wallet does in the background.
+	scrub := func() (findings []*ScrubFinding) {
+		for _, kind := range ScrubKinds {
+			var after []byte
+			for {
+				f, checked, last, e := s.Scrub(ns, kind, after, 1)
+				if e != nil {
+					t.Fatalf("scrubbing %s: %v", kind, e)
+				}
+				if checked > 1 {
+					t.Fatalf("scrubbing %s checked %d rows, more than the limit", kind, checked)
+				}
+				findings = append(findings, f...)
+				if last == nil {
+					break
+				}
+				after = last
+			}
+		}
+		return
+	}
+	if findings := scrub(); len(findings) != 0 {
+		t.Fatalf("consistent store has findings: %s", findings[0].Problem)
+	}
+	// Drop an unspent output from the index, and add an output that is not credited to it.
+	if e = deleteRawUnspent(ns, canonicalOutPoint(&spendingRec.Hash, 0)); e != nil {
+		t.Fatal(e)
+	}
+	if e = putUnspent(ns, wire.NewOutPoint(&spendingRec.Hash, 1), &TstSignedTxBlockDetails.Block); e != nil {
+		t.Fatal(e)
+	}
+	findings := scrub()
+	if len(findings) != 2 || findings[0].Kind != ScrubCredits || findings[1].Kind != ScrubUnspent {
+		t.Fatalf("damaged unspent index has %d findings", len(findings))
+	}
+	for _, f := range findings {
+		if !f.Repairable() {
+			t.Fatalf("finding %q cannot be repaired", f.Problem)
+		}
+		if e = f.Repair(ns); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if findings = scrub(); len(findings) != 0 {
+		t.Fatalf("repaired store has findings: %s", findings[0].Problem)
+	}
+	unspent, e := s.UnspentOutputs(ns)
+	if e != nil || len(unspent) != 2 {
+		t.Fatalf("repaired store has unspent outputs %v, %v", unspent, e)
+	}
+	// A transaction record that does not deserialize can only be reported.
+	k, v := existsTxRecord(ns, &recvRec.Hash, &TstRecvTxBlockDetails.Block)
+	if e = ns.NestedReadWriteBucket(bucketTxRecords).Put(k, v[:len(v)-1]); e != nil {
+		t.Fatal(e)
+	}
+	findings = scrub()
+	// The record itself and the credit of its output are found.
+	if len(findings) != 2 || findings[0].Kind != ScrubTxRecords || findings[0].Repairable() {
+		t.Fatalf("damaged transaction record has %d findings", len(findings))
+	}
+}
@@ -74,6 +74,7 @@ type (
 		PkScript     []byte
 		Received     time.Time
 		FromCoinBase bool
+		Change       bool
 	}
 	// Store implements a transaction store for storing and managing wallet transactions.
 	Store struct {
@@ -605,6 +606,9 @@ func (s *Store) rollback(ns walletdb.ReadWriteBucket, height int32) (e error) {
 			}
 		}
 	}
+	if e = rollbackWatchedCredits(ns, height); E.Chk(e) {
+		return e
+	}
 	return putMinedBalance(ns, minedBalance)
 }
 
@@ -640,6 +644,11 @@ func // UnspentOutputs returns all unspent received transaction outputs.
 			if e != nil {
 				return e
 			}
+			_, credVal := existsCredit(ns, &op.Hash, op.Index, &block)
+			var change bool
+			if _, change, e = fetchRawCreditAmountChange(credVal); e != nil {
+				return e
+			}
 			txOut := rec.MsgTx.TxOut[op.Index]
 			cred := Credit{
 				OutPoint: op,
@@ -651,6 +660,7 @@ func // UnspentOutputs returns all unspent received transaction outputs.
 				PkScript:     txOut.PkScript,
 				Received:     rec.Received,
 				FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+				Change:       change,
 			}
 			unspent = append(unspent, cred)
 			return nil
@@ -682,6 +692,10 @@ func // UnspentOutputs returns all unspent received transaction outputs.
 			if e != nil {
 				return e
 			}
+			var change bool
+			if _, change, e = fetchRawUnminedCreditAmountChange(v); e != nil {
+				return e
+			}
 			txOut := rec.MsgTx.TxOut[op.Index]
 			cred := Credit{
 				OutPoint: op,
@@ -692,6 +706,7 @@ func // UnspentOutputs returns all unspent received transaction outputs.
 				PkScript:     txOut.PkScript,
 				Received:     rec.Received,
 				FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+				Change:       change,
 			}
 			unspent = append(unspent, cred)
 			return nil
@@ -715,6 +730,15 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 // Balance may return unexpected results if syncHeight is lower than the block
 // height of the most recent mined transaction in the store.
 (s *Store) Balance(ns walletdb.ReadBucket, minConf int32, syncHeight int32) (amt.Amount, error) {
+	return s.BalanceConfs(ns, minConf, minConf, syncHeight)
+}
+
+func // BalanceConfs returns the spendable wallet balance like Balance, except
+// that change outputs need changeConf confirmations and all other outputs
+// need receivedConf confirmations to be included.
+(s *Store) BalanceConfs(
+	ns walletdb.ReadBucket, changeConf, receivedConf int32, syncHeight int32,
+) (amt.Amount, error) {
 	bal, e := fetchMinedBalance(ns)
 	if e != nil {
 		return 0, e
@@ -755,7 +779,10 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 	// Decrement the balance for any unspent credit with less than
 	// minConf confirmations and any (unspent) immature coinbase credit.
 	coinbaseMaturity := int32(s.chainParams.CoinbaseMaturity)
-	stopConf := minConf
+	stopConf := changeConf
+	if receivedConf > stopConf {
+		stopConf = receivedConf
+	}
 	if coinbaseMaturity > stopConf {
 		stopConf = coinbaseMaturity
 	}
@@ -787,7 +814,7 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 					continue
 				}
 				var amountSpent amt.Amount
-				var spent bool
+				var spent, change bool
 				amountSpent, spent, e = fetchRawCreditAmountSpent(v)
 				if e != nil {
 					return 0, e
@@ -795,6 +822,13 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 				if spent {
 					continue
 				}
+				if _, change, e = fetchRawCreditAmountChange(v); e != nil {
+					return 0, e
+				}
+				minConf := receivedConf
+				if change {
+					minConf = changeConf
+				}
 				confs := syncHeight - block.Height + 1
 				if confs < minConf || (blockchain.IsCoinBaseTx(&rec.MsgTx) &&
 					confs < coinbaseMaturity) {
@@ -808,7 +842,7 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 	}
 	// If unmined outputs are included, increment the balance for each
 	// output that is unspent.
-	if minConf == 0 {
+	if changeConf == 0 || receivedConf == 0 {
 		e = ns.NestedReadBucket(bucketUnminedCredits).ForEach(
 			func(k, v []byte) (e error) {
 				if existsRawUnminedInput(ns, k) != nil {
@@ -816,11 +850,13 @@ func // Balance returns the spendable wallet balance (total value of all unspent
 					// Skip to next unmined credit.
 					return nil
 				}
-				amount, e := fetchRawUnminedCreditAmount(v)
+				amount, change, e := fetchRawUnminedCreditAmountChange(v)
 				if e != nil {
 					return e
 				}
-				bal += amount
+				if (change && changeConf == 0) || (!change && receivedConf == 0) {
+					bal += amount
+				}
 				return nil
 			},
 		)
@@ -1689,6 +1689,16 @@ func TestInsertConfirmedDoubleSpendTx(t *testing.T) {
 				len(unminedTxs),
 			)
 		}
+		spenders := store.UnminedSpenders(ns, &wire.OutPoint{Hash: cbRec1.Hash, Index: 0})
+		if len(spenders) != 2 {
+			t.Fatalf("expected 2 unmined spenders of the first output, got %d", len(spenders))
+		}
+		spenders = store.UnminedSpenders(ns, &wire.OutPoint{Hash: cbRec2.Hash, Index: 0})
+		if len(spenders) != 1 || spenders[0] != firstSpendRec2.Hash {
+			t.Fatalf("expected the unmined spender of the second output to be %v, got %v",
+				firstSpendRec2.Hash, spenders,
+			)
+		}
 	},
 	)
 	// Then, we'll insert the confirmed spend at a height deep enough that allows us to successfully spend the coinbase
@@ -1735,6 +1745,9 @@ func TestInsertConfirmedDoubleSpendTx(t *testing.T) {
 				len(unminedTxs),
 			)
 		}
+		if spenders := store.UnminedSpenders(ns, &wire.OutPoint{Hash: cbRec1.Hash, Index: 0}); len(spenders) != 0 {
+			t.Fatalf("expected no unmined spenders after the confirmed spend, got %v", spenders)
+		}
 		minedTxs, e := store.UnspentOutputs(ns)
 		if e != nil {
 			t.Fatal(e)
@@ -1881,3 +1894,79 @@ func TestAddDuplicateCreditAfterConfirm(t *testing.T) {
 	},
 	)
 }
+
+// TestBalanceConfs ensures change and received outputs are only included in the balance once they have the number of
+// confirmations required for their kind, and that unspent outputs report whether they are change.
+func TestBalanceConfs(t *testing.T) {
+	t.Parallel()
+	store, db, teardown, e := testStore()
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer teardown()
+	const received, change = int64(1e8), int64(2e8)
+	tx := spendOutput(&chainhash.Hash{1}, 0, received, change)
+	rec, e := NewTxRecordFromMsgTx(tx, time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	addTx := func(block *BlockMeta) {
+		commitDBTx(
+			t, store, db, func(ns walletdb.ReadWriteBucket) {
+				if e = store.InsertTx(ns, rec, block); e != nil {
+					t.Fatal(e)
+				}
+				if e = store.AddCredit(ns, rec, block, 0, false); e != nil {
+					t.Fatal(e)
+				}
+				if e = store.AddCredit(ns, rec, block, 1, true); e != nil {
+					t.Fatal(e)
+				}
+			},
+		)
+	}
+	checkBalance := func(changeConf, receivedConf, syncHeight int32, want int64) {
+		t.Helper()
+		commitDBTx(
+			t, store, db, func(ns walletdb.ReadWriteBucket) {
+				t.Helper()
+				b, e := store.BalanceConfs(ns, changeConf, receivedConf, syncHeight)
+				if e != nil {
+					t.Fatalf("unable to retrieve balance: %v", e)
+				}
+				if b != amt.Amount(want) {
+					t.Fatalf(
+						"balance with %d change and %d received confirmations at height %d is %v, want %v",
+						changeConf, receivedConf, syncHeight, b, amt.Amount(want),
+					)
+				}
+			},
+		)
+	}
+	addTx(nil)
+	commitDBTx(
+		t, store, db, func(ns walletdb.ReadWriteBucket) {
+			unspent, e := store.UnspentOutputs(ns)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if len(unspent) != 2 {
+				t.Fatalf("got %d unspent outputs, want 2", len(unspent))
+			}
+			for _, cred := range unspent {
+				if cred.Change != (cred.Index == 1) {
+					t.Errorf("output %d has change %v", cred.Index, cred.Change)
+				}
+			}
+		},
+	)
+	checkBalance(0, 0, 99, received+change)
+	checkBalance(0, 1, 99, change)
+	checkBalance(1, 0, 99, received)
+	checkBalance(1, 1, 99, 0)
+	addTx(&BlockMeta{Block: Block{Height: 100}, Time: time.Now()})
+	checkBalance(1, 2, 100, change)
+	checkBalance(2, 1, 100, received)
+	checkBalance(1, 1, 100, received+change)
+	checkBalance(2, 2, 101, received+change)
+}
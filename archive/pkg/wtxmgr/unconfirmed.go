@@ -164,6 +164,12 @@ func (s *Store) unminedTxRecords(ns walletdb.ReadBucket) (map[chainhash.Hash]*Tx
 	return unmined, e
 }
 
+// UnminedSpenders returns the hashes of the unmined transactions that spend the outpoint. There is more than one when
+// unmined transactions double spend it, which are removed when a transaction spending it is mined.
+func (s *Store) UnminedSpenders(ns walletdb.ReadBucket, op *wire.OutPoint) []chainhash.Hash {
+	return fetchUnminedInputSpendTxHashes(ns, canonicalOutPoint(&op.Hash, op.Index))
+}
+
 // UnminedTxHashes returns the hashes of all transactions not known to have been mined in a block.
 func (s *Store) UnminedTxHashes(ns walletdb.ReadBucket) ([]*chainhash.Hash, error) {
 	return s.unminedTxHashes(ns)
@@ -0,0 +1,216 @@
+package wtxmgr
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+	"github.com/p9c/pod/pkg/wire"
+)
+
+// bucketWatchedCredits holds the outputs paying to watched scripts, which are not controlled by the wallet and so are
+// kept apart from the credits that make up its balance. The bucket is created with the first watched credit.
+var bucketWatchedCredits = []byte("w")
+
+// WatchedCredit is an output paying to a watched script. Block.Height is -1 while the transaction is unmined, and
+// SpentBy is nil until a transaction spending the output is seen.
+type WatchedCredit struct {
+	wire.OutPoint
+	Block         Block
+	Amount        amt.Amount
+	PkScript      []byte
+	SpentBy       *chainhash.Hash
+	SpenderHeight int32
+}
+
+// Watched credits are keyed by their canonical outpoint. The value is serialized as such:
+//
+//	[0:8]   Amount (8 bytes)
+//	[8:12]  Block height, -1 when unmined (4 bytes)
+//	[12:44] Block hash (32 bytes)
+//	[44:76] Spender transaction hash (32 bytes)
+//	[76:80] Spender block height, -1 when unmined (4 bytes)
+//	[80]    Flags (1 byte)
+//	          0x01: Spent
+//	[81:]   Output script
+func valueWatchedCredit(c *WatchedCredit) []byte {
+	v := make([]byte, 81+len(c.PkScript))
+	byteOrder.PutUint64(v, uint64(c.Amount))
+	byteOrder.PutUint32(v[8:12], uint32(c.Block.Height))
+	copy(v[12:44], c.Block.Hash[:])
+	if c.SpentBy != nil {
+		copy(v[44:76], c.SpentBy[:])
+		byteOrder.PutUint32(v[76:80], uint32(c.SpenderHeight))
+		v[80] |= 1 << 0
+	}
+	copy(v[81:], c.PkScript)
+	return v
+}
+
+// readWatchedCredit reads a watched credit from its key and value.
+func readWatchedCredit(k, v []byte, c *WatchedCredit) (e error) {
+	if e = readCanonicalOutPoint(k, &c.OutPoint); E.Chk(e) {
+		return
+	}
+	if len(v) < 81 {
+		str := fmt.Sprintf(
+			"%s: short read (expected at least %d bytes, read %d)",
+			bucketWatchedCredits, 81, len(v),
+		)
+		return storeError(ErrData, str, nil)
+	}
+	c.Amount = amt.Amount(byteOrder.Uint64(v))
+	c.Block.Height = int32(byteOrder.Uint32(v[8:12]))
+	copy(c.Block.Hash[:], v[12:44])
+	c.SpentBy, c.SpenderHeight = nil, 0
+	if v[80]&(1<<0) != 0 {
+		c.SpentBy = new(chainhash.Hash)
+		copy(c.SpentBy[:], v[44:76])
+		c.SpenderHeight = int32(byteOrder.Uint32(v[76:80]))
+	}
+	c.PkScript = append([]byte(nil), v[81:]...)
+	return
+}
+
+// putWatchedCredit stores a watched credit, creating the bucket of watched credits if it does not exist yet.
+func putWatchedCredit(ns walletdb.ReadWriteBucket, c *WatchedCredit) (e error) {
+	var b walletdb.ReadWriteBucket
+	if b, e = ns.CreateBucketIfNotExists(bucketWatchedCredits); E.Chk(e) {
+		str := "failed to create watched credits bucket"
+		return storeError(ErrDatabase, str, e)
+	}
+	if e = b.Put(canonicalOutPoint(&c.Hash, c.Index), valueWatchedCredit(c)); E.Chk(e) {
+		str := "failed to put watched credit"
+		return storeError(ErrDatabase, str, e)
+	}
+	return
+}
+
+// fetchWatchedCredit returns the watched credit of an outpoint, or nil if the outpoint is not a watched credit.
+func fetchWatchedCredit(ns walletdb.ReadBucket, op *wire.OutPoint) (c *WatchedCredit, e error) {
+	b := ns.NestedReadBucket(bucketWatchedCredits)
+	if b == nil {
+		return
+	}
+	k := canonicalOutPoint(&op.Hash, op.Index)
+	v := b.Get(k)
+	if v == nil {
+		return
+	}
+	c = new(WatchedCredit)
+	if e = readWatchedCredit(k, v, c); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+// AddWatchedCredit records output index of the transaction as paying to a watched script. The output is not added to
+// the balance of the wallet. Adding an output that is already recorded updates the block it was mined in.
+func (s *Store) AddWatchedCredit(ns walletdb.ReadWriteBucket, rec *TxRecord, block *BlockMeta, index uint32) (e error) {
+	if int(index) >= len(rec.MsgTx.TxOut) {
+		str := "transaction output does not exist"
+		return storeError(ErrInput, str, nil)
+	}
+	op := wire.OutPoint{Hash: rec.Hash, Index: index}
+	var c *WatchedCredit
+	if c, e = fetchWatchedCredit(ns, &op); E.Chk(e) {
+		return
+	}
+	switch {
+	case c == nil:
+		c = &WatchedCredit{
+			OutPoint: op,
+			Block:    Block{Height: -1},
+			Amount:   amt.Amount(rec.MsgTx.TxOut[index].Value),
+			PkScript: rec.MsgTx.TxOut[index].PkScript,
+		}
+	case block == nil:
+		// The unmined notification of a transaction can come after the mined one, which leaves the output as it is.
+		return
+	}
+	if block != nil {
+		c.Block = block.Block
+	}
+	return putWatchedCredit(ns, c)
+}
+
+// SpendWatchedCredits marks the watched credits spent by the inputs of the transaction as spent by it.
+func (s *Store) SpendWatchedCredits(ns walletdb.ReadWriteBucket, rec *TxRecord, block *BlockMeta) (e error) {
+	if ns.NestedReadBucket(bucketWatchedCredits) == nil {
+		return
+	}
+	for _, in := range rec.MsgTx.TxIn {
+		var c *WatchedCredit
+		if c, e = fetchWatchedCredit(ns, &in.PreviousOutPoint); E.Chk(e) {
+			return
+		}
+		if c == nil {
+			continue
+		}
+		c.SpentBy, c.SpenderHeight = &rec.Hash, -1
+		if block != nil {
+			c.SpenderHeight = block.Height
+		}
+		if e = putWatchedCredit(ns, c); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// WatchedCredits returns all the recorded outputs paying to watched scripts, spent or not, in outpoint order.
+func (s *Store) WatchedCredits(ns walletdb.ReadBucket) (credits []WatchedCredit, e error) {
+	b := ns.NestedReadBucket(bucketWatchedCredits)
+	if b == nil {
+		return
+	}
+	e = b.ForEach(
+		func(k, v []byte) (e error) {
+			var c WatchedCredit
+			if e = readWatchedCredit(k, v, &c); E.Chk(e) {
+				return
+			}
+			credits = append(credits, c)
+			return
+		},
+	)
+	return
+}
+
+// rollbackWatchedCredits returns the watched credits mined, and the spends of watched credits mined, at height onwards
+// to the unmined state.
+func rollbackWatchedCredits(ns walletdb.ReadWriteBucket, height int32) (e error) {
+	b := ns.NestedReadBucket(bucketWatchedCredits)
+	if b == nil {
+		return
+	}
+	var changed []WatchedCredit
+	if e = b.ForEach(
+		func(k, v []byte) (e error) {
+			var c WatchedCredit
+			if e = readWatchedCredit(k, v, &c); E.Chk(e) {
+				return
+			}
+			var rolledBack bool
+			if c.Block.Height >= height {
+				c.Block, rolledBack = Block{Height: -1}, true
+			}
+			if c.SpentBy != nil && c.SpenderHeight >= height {
+				c.SpenderHeight, rolledBack = -1, true
+			}
+			if rolledBack {
+				changed = append(changed, c)
+			}
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	for i := range changed {
+		if e = putWatchedCredit(ns, &changed[i]); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package wtxmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/amt"
+	"github.com/p9c/pod/pkg/chainhash"
+	"github.com/p9c/pod/pkg/walletdb"
+)
+
+// TestWatchedCredits ensures outputs paying to watched scripts are recorded apart from the balance, have their spends
+// recorded, and are returned to the unmined state by a rollback.
+func TestWatchedCredits(t *testing.T) {
+	t.Parallel()
+	store, db, teardown, e := testStore()
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer teardown()
+	const value = int64(3e8)
+	rec, e := NewTxRecordFromMsgTx(spendOutput(&chainhash.Hash{1}, 0, value), time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	spend, e := NewTxRecordFromMsgTx(spendOutput(&rec.Hash, 0, value-1e5), time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	mined := &BlockMeta{Block: Block{Hash: chainhash.Hash{2}, Height: 100}, Time: time.Now()}
+	spent := &BlockMeta{Block: Block{Hash: chainhash.Hash{3}, Height: 101}, Time: time.Now()}
+	watched := func() (credits []WatchedCredit) {
+		t.Helper()
+		commitDBTx(
+			t, store, db, func(ns walletdb.ReadWriteBucket) {
+				if credits, e = store.WatchedCredits(ns); e != nil {
+					t.Fatal(e)
+				}
+			},
+		)
+		return
+	}
+	if credits := watched(); len(credits) != 0 {
+		t.Fatalf("got %d watched credits in a new store", len(credits))
+	}
+	commitDBTx(
+		t, store, db, func(ns walletdb.ReadWriteBucket) {
+			if e = store.InsertTx(ns, rec, nil); e != nil {
+				t.Fatal(e)
+			}
+			if e = store.AddWatchedCredit(ns, rec, nil, 0); e != nil {
+				t.Fatal(e)
+			}
+		},
+	)
+	credits := watched()
+	if len(credits) != 1 || credits[0].Hash != rec.Hash || credits[0].Amount != amt.Amount(value) ||
+		credits[0].Block.Height != -1 || credits[0].SpentBy != nil {
+		t.Fatalf("got watched credits %+v", credits)
+	}
+	commitDBTx(
+		t, store, db, func(ns walletdb.ReadWriteBucket) {
+			if e = store.InsertTx(ns, rec, mined); e != nil {
+				t.Fatal(e)
+			}
+			if e = store.AddWatchedCredit(ns, rec, mined, 0); e != nil {
+				t.Fatal(e)
+			}
+			if e = store.InsertTx(ns, spend, spent); e != nil {
+				t.Fatal(e)
+			}
+			if e = store.SpendWatchedCredits(ns, spend, spent); e != nil {
+				t.Fatal(e)
+			}
+			var b amt.Amount
+			if b, e = store.Balance(ns, 0, 101); e != nil || b != 0 {
+				t.Fatalf("got balance %v, error %v, want no balance", b, e)
+			}
+		},
+	)
+	credits = watched()
+	if len(credits) != 1 || credits[0].Block.Height != 100 || credits[0].SpentBy == nil ||
+		*credits[0].SpentBy != spend.Hash || credits[0].SpenderHeight != 101 {
+		t.Fatalf("got watched credits %+v", credits)
+	}
+	commitDBTx(
+		t, store, db, func(ns walletdb.ReadWriteBucket) {
+			if e = store.Rollback(ns, 101); e != nil {
+				t.Fatal(e)
+			}
+		},
+	)
+	credits = watched()
+	if len(credits) != 1 || credits[0].Block.Height != 100 || credits[0].SpentBy == nil ||
+		credits[0].SpenderHeight != -1 {
+		t.Fatalf("got watched credits %+v after rollback", credits)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/p9c/log"
 	"github.com/p9c/pod/pkg/apputil"
 	"github.com/p9c/pod/pkg/constant"
+	"github.com/p9c/pod/pkg/netproxy"
 	"github.com/p9c/opts/binary"
 	"github.com/p9c/opts/cmds"
 	"github.com/p9c/opts/duration"
@@ -615,6 +616,17 @@ func (c *Config) ReadCAFile() []byte {
 	return certs
 }
 
+// RPCProxy returns the proxy that RPC clients connect to the node and wallet servers through, which is configured apart
+// from the proxy used for peers.
+func (c *Config) RPCProxy() netproxy.Config {
+	return netproxy.Config{
+		Kind: c.RPCProxyType.V(),
+		Addr: c.RPCProxyAddress.V(),
+		User: c.RPCProxyUser.V(),
+		Pass: c.RPCProxyPass.V(),
+	}
+}
+
 func ifcToStrings(ifc []interface{}) (o []string) {
 	for i := range ifc {
 		o = append(o, ifc[i].(string))
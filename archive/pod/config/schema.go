@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p9c/opts/binary"
+	"github.com/p9c/opts/duration"
+	"github.com/p9c/opts/float"
+	"github.com/p9c/opts/integer"
+	"github.com/p9c/opts/list"
+	"github.com/p9c/opts/opt"
+	"github.com/p9c/opts/sanitizers"
+	"github.com/p9c/opts/text"
+)
+
+// The schema of the configuration describes every option with its type, default, help text and whether it can be
+// changed while the applications using it are running, so interfaces such as the settings page of the GUI can be built
+// from it instead of keeping their own lists of options. Values are given as strings in the same form for reading and
+// writing: booleans are true or false, durations are in the form time.ParseDuration takes, and lists are comma
+// separated.
+
+// The types of the options in the schema.
+const (
+	SchemaBool     = "bool"
+	SchemaInt      = "int"
+	SchemaFloat    = "float"
+	SchemaDuration = "duration"
+	SchemaList     = "list"
+	SchemaString   = "string"
+)
+
+// SchemaItem describes an option of the configuration.
+type SchemaItem struct {
+	Name        string   `json:"name"`
+	Group       string   `json:"group"`
+	Tags        []string `json:"tags,omitempty"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	// Options are the values a string option is limited to, and Min and Max the range of a number or duration, which
+	// are empty when it has none.
+	Options []string `json:"options,omitempty"`
+	Min     string   `json:"min,omitempty"`
+	Max     string   `json:"max,omitempty"`
+	Default string   `json:"default"`
+	Value   string   `json:"value"`
+	// Secret is true for passwords, whose values are left out of the schema.
+	Secret bool `json:"secret,omitempty"`
+	// Live is true when a new value takes effect as soon as it is set, and false when the applications using it must
+	// be restarted for it to.
+	Live bool `json:"live"`
+}
+
+// Schema returns the schema of the options of the configuration with their current values.
+func (c *Config) Schema() (items []SchemaItem) {
+	c.ForEach(
+		func(o opt.Option) bool {
+			items = append(items, schemaItem(o))
+			return true
+		},
+	)
+	return
+}
+
+// schemaItem returns the schema of an option.
+func schemaItem(o opt.Option) (item SchemaItem) {
+	m := o.GetMetadata()
+	item = SchemaItem{
+		Name:        m.Name,
+		Group:       m.Group,
+		Tags:        m.Tags,
+		Label:       m.Label,
+		Description: m.Description,
+		Options:     m.Options,
+		Secret:      m.Type == sanitizers.Password,
+		Live:        m.Live,
+	}
+	switch x := o.Type().(type) {
+	case *binary.Opt:
+		item.Type, item.Default, item.Value = SchemaBool, strconv.FormatBool(x.Def), strconv.FormatBool(x.True())
+	case *integer.Opt:
+		item.Type, item.Default, item.Value = SchemaInt, fmt.Sprint(x.Def), fmt.Sprint(x.V())
+		if x.Max > x.Min {
+			item.Min, item.Max = fmt.Sprint(x.Min), fmt.Sprint(x.Max)
+		}
+	case *float.Opt:
+		item.Type, item.Default, item.Value = SchemaFloat, formatFloat(x.Def), formatFloat(x.V())
+		if x.Max > x.Min {
+			item.Min, item.Max = formatFloat(x.Min), formatFloat(x.Max)
+		}
+	case *duration.Opt:
+		item.Type, item.Default, item.Value = SchemaDuration, x.Def.String(), x.V().String()
+		if x.Max > x.Min {
+			item.Min, item.Max = x.Min.String(), x.Max.String()
+		}
+	case *list.Opt:
+		item.Type, item.Default, item.Value = SchemaList, strings.Join(x.Def, ","), strings.Join(x.V(), ",")
+	case *text.Opt:
+		item.Type, item.Default, item.Value = SchemaString, x.Def, x.V()
+	}
+	if item.Secret {
+		item.Value = ""
+	}
+	return
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ValidateOption checks that the value can be given to the named option, without setting it. Unlike reading the
+// options from the command line or the configuration file, numbers and durations out of the range of the option are
+// refused rather than clamped, so the user is told the value they gave is not the one that would be used.
+func (c *Config) ValidateOption(name, value string) (e error) {
+	_, e = c.parseOption(name, value)
+	return
+}
+
+// SetOption sets the named option from a value in the form of the schema, after validating it.
+func (c *Config) SetOption(name, value string) (e error) {
+	var set func() error
+	if set, e = c.parseOption(name, value); E.Chk(e) {
+		return
+	}
+	return set()
+}
+
+// parseOption validates the value for the named option and returns the function setting it.
+func (c *Config) parseOption(name, value string) (set func() error, e error) {
+	o, ok := c.Map[name]
+	if !ok {
+		return nil, fmt.Errorf("there is no option %s", name)
+	}
+	m := o.GetMetadata()
+	outOfRange := func(min, max interface{}) error {
+		return fmt.Errorf("%s must be between %v and %v", name, min, max)
+	}
+	switch x := o.Type().(type) {
+	case *binary.Opt:
+		var b bool
+		if b, e = strconv.ParseBool(value); e != nil {
+			return nil, fmt.Errorf("%s must be true or false", name)
+		}
+		return func() error { return x.Set(b) }, nil
+	case *integer.Opt:
+		var i int
+		if i, e = strconv.Atoi(value); e != nil {
+			return nil, fmt.Errorf("%s must be a whole number", name)
+		}
+		if x.Max > x.Min && (i < x.Min || i > x.Max) {
+			return nil, outOfRange(x.Min, x.Max)
+		}
+		return func() error { return x.Set(i) }, nil
+	case *float.Opt:
+		var f float64
+		if f, e = strconv.ParseFloat(value, 64); e != nil {
+			return nil, fmt.Errorf("%s must be a number", name)
+		}
+		if x.Max > x.Min && (f < x.Min || f > x.Max) {
+			return nil, outOfRange(x.Min, x.Max)
+		}
+		return func() error { return x.Set(f) }, nil
+	case *duration.Opt:
+		var d time.Duration
+		if d, e = time.ParseDuration(value); e != nil {
+			return nil, fmt.Errorf("%s must be a duration such as 90s or 1h30m", name)
+		}
+		if x.Max > x.Min && (d < x.Min || d > x.Max) {
+			return nil, outOfRange(x.Min, x.Max)
+		}
+		return func() error { return x.Set(d) }, nil
+	case *list.Opt:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			if item, e = sanitizeString(m.Type, item, m.DefaultPort); e != nil {
+				return nil, fmt.Errorf("%s: %v", name, e)
+			}
+			items = append(items, item)
+		}
+		return func() error { return x.Set(items) }, nil
+	case *text.Opt:
+		if m.Options != nil {
+			for _, option := range m.Options {
+				if value == option {
+					return func() error { return x.Set(value) }, nil
+				}
+			}
+			return nil, fmt.Errorf("%s must be one of %s", name, strings.Join(m.Options, ", "))
+		}
+		if value != "" {
+			if value, e = sanitizeString(m.Type, value, m.DefaultPort); e != nil {
+				return nil, fmt.Errorf("%s: %v", name, e)
+			}
+		}
+		return func() error { return x.Set(value) }, nil
+	}
+	return nil, fmt.Errorf("option %s can not be set", name)
+}
+
+// sanitizeString returns the value cleaned up for its type of string, such as a network address with the default port
+// added, or the value itself if there is nothing to clean up.
+func sanitizeString(typ, value string, defaultPort int) (cleaned string, e error) {
+	if cleaned, e = sanitizers.StringType(typ, value, defaultPort); e != nil || cleaned == "" {
+		cleaned = value
+	}
+	return
+}
@@ -25,9 +25,15 @@ type Config struct {
 	RunningCommand         cmds.Command
 	ExtraArgs              []string
 	FoundArgs              []string
+	AccountDiscoveryGap    *integer.Opt
 	AddCheckpoints         *list.Opt
 	AddPeers               *list.Opt
 	AddrIndex              *binary.Opt
+	AllowXprvExport        *binary.Opt
+	Analyzers              *list.Opt
+	ArchivalServeDepth     *integer.Opt
+	ArchivalServeRate      *integer.Opt
+	AssumeValid            *text.Opt
 	AutoListen             *binary.Opt
 	AutoPorts              *binary.Opt
 	BanDuration            *duration.Opt
@@ -38,12 +44,17 @@ type Config struct {
 	BlockMinWeight         *integer.Opt
 	BlockPrioritySize      *integer.Opt
 	BlocksOnly             *binary.Opt
+	BytesPerSigOp          *integer.Opt
 	CAFile                 *text.Opt
 	CPUProfile             *text.Opt
 	ClientTLS              *binary.Opt
+	ClockSkewWarning       *duration.Opt
+	CompatLegacy           *binary.Opt
 	ConfigFile             *text.Opt
 	ConnectPeers           *list.Opt
 	Controller             *binary.Opt
+	ControllerNodes        *list.Opt
+	ControllerStallTimeout *duration.Opt
 	DarkTheme              *binary.Opt
 	DataDir                *text.Opt
 	DbType                 *text.Opt
@@ -53,7 +64,12 @@ type Config struct {
 	DisableListen          *binary.Opt
 	DisableRPC             *binary.Opt
 	Discovery              *binary.Opt
+	DustAttackAddresses    *integer.Opt
+	DustAttackThreshold    *float.Opt
+	DustAttackWindow       *duration.Opt
 	ExternalIPs            *list.Opt
+	Features               *list.Opt
+	FeeEstimatorAutoBias   *binary.Opt
 	FreeTxRelayLimit       *float.Opt
 	GenThreads             *integer.Opt
 	Generate               *binary.Opt
@@ -61,13 +77,28 @@ type Config struct {
 	LAN                    *binary.Opt
 	LimitPass              *text.Opt
 	LimitUser              *text.Opt
+	LocalFastPath          *list.Opt
 	Locale                 *text.Opt
+	LogAlertErrors         *integer.Opt
+	LogAlertMatch          *list.Opt
+	LogAlertWebhook        *text.Opt
+	LogAlertWindow         *duration.Opt
 	LogDir                 *text.Opt
 	LogFilter              *list.Opt
 	LogLevel               *text.Opt
+	LogShipHTTP            *text.Opt
+	LogShipLevel           *text.Opt
+	LogShipSyslog          *text.Opt
+	MaxOrphanBlocks        *integer.Opt
 	MaxOrphanTxs           *integer.Opt
 	MaxPeers               *integer.Opt
+	MaxTxFee               *float.Opt
+	MempoolExpiry          *duration.Opt
+	MinConfChange          *integer.Opt
+	MinConfReceived        *integer.Opt
 	MinRelayTxFee          *float.Opt
+	MiningAddrRotation     *text.Opt
+	MiningAddrs            *list.Opt
 	MulticastPass          *text.Opt
 	Network                *text.Opt
 	NoCFilters             *binary.Opt
@@ -83,10 +114,14 @@ type Config struct {
 	P2PConnect             *list.Opt
 	P2PListeners           *list.Opt
 	Password               *text.Opt
+	PeerEventsWebhook      *text.Opt
+	PeerPatternResponses   *list.Opt
+	PeerUTXOService        *binary.Opt
 	PipeLog                *binary.Opt
 	Profile                *text.Opt
 	ProxyAddress           *text.Opt
 	ProxyPass              *text.Opt
+	ProxyType              *text.Opt
 	ProxyUser              *text.Opt
 	RPCCert                *text.Opt
 	RPCConnect             *text.Opt
@@ -95,16 +130,30 @@ type Config struct {
 	RPCMaxClients          *integer.Opt
 	RPCMaxConcurrentReqs   *integer.Opt
 	RPCMaxWebsockets       *integer.Opt
+	RPCNtfnQueueLimit      *integer.Opt
+	RPCProxyAddress        *text.Opt
+	RPCProxyPass           *text.Opt
+	RPCProxyType           *text.Opt
+	RPCProxyUser           *text.Opt
 	RPCQuirks              *binary.Opt
+	RPCSlowClients         *text.Opt
+	ReindexAnalyze         *binary.Opt
 	RejectNonStd           *binary.Opt
 	RelayNonStd            *binary.Opt
 	RunAsService           *binary.Opt
 	Save                   *binary.Opt
 	ServerTLS              *binary.Opt
 	SigCacheMaxSize        *integer.Opt
+	SignerConnect          *text.Opt
+	SignerListen           *text.Opt
+	SignerSecret           *text.Opt
+	SignetChallenge        *text.Opt
+	SignetKey              *text.Opt
+	SnapshotInterval       *integer.Opt
 	Solo                   *binary.Opt
 	TLSSkipVerify          *binary.Opt
 	TorIsolation           *binary.Opt
+	TorOnly                *binary.Opt
 	TrickleInterval        *duration.Opt
 	TxIndex                *binary.Opt
 	UPNP                   *binary.Opt
@@ -112,12 +161,27 @@ type Config struct {
 	UseWallet              *binary.Opt
 	UserAgentComments      *list.Opt
 	Username               *text.Opt
+	ValTrace               *binary.Opt
+	WalletAddressType      *text.Opt
+	WalletBackupInterval   *duration.Opt
+	WalletBackupTargets    *list.Opt
+	WalletBech32           *binary.Opt
 	WalletFile             *text.Opt
+	WalletIdleLock         *duration.Opt
+	WalletKeystore         *binary.Opt
 	WalletOff              *binary.Opt
 	WalletPass             *text.Opt
 	WalletRPCListeners     *list.Opt
 	WalletRPCMaxClients    *integer.Opt
 	WalletRPCMaxWebsockets *integer.Opt
+	WalletScrubInterval    *duration.Opt
+	WalletScrubRepair      *binary.Opt
 	WalletServer           *text.Opt
+	WalletTxOrdering       *text.Opt
+	WatchCommand           *text.Opt
+	WatchInterval          *duration.Opt
+	WatchSet               *text.Opt
+	WatchWebhook           *text.Opt
 	Whitelists             *list.Opt
+	XprvExportPass         *text.Opt
 }
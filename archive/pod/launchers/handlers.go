@@ -12,6 +12,7 @@ import (
 	"github.com/p9c/pod/cmd/ctl"
 	"github.com/p9c/pod/cmd/node"
 	"github.com/p9c/pod/cmd/wallet"
+	"github.com/p9c/pod/cmd/watch"
 	"github.com/p9c/pod/pkg/constant"
 	"github.com/p9c/pod/pod/state"
 
@@ -113,6 +114,43 @@ func WalletHandle(ifc interface{}) (e error) {
 	return
 }
 
+// WalletDBStatsHandle prints the key counts and space used by each bucket of the wallet database. It reads the
+// database file directly, so the wallet must not be running, the walletdbstats RPC reports the same for a running
+// wallet.
+func WalletDBStatsHandle(ifc interface{}) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	dbPath := filepath.Join(cx.Config.DataDir.V(), cx.ActiveNet.Name, constant.DbName)
+	var stats []wallet.BucketStats
+	if stats, e = wallet.DBStatsFile(dbPath, wallet.DefaultDBStatsLargest); E.Chk(e) {
+		return
+	}
+	fmt.Println("wallet database", dbPath)
+	if info, e := os.Stat(dbPath); !E.Chk(e) {
+		fmt.Println("file size", info.Size(), "bytes")
+	}
+	return wallet.WriteDBStats(os.Stdout, stats)
+}
+
+// WatchHandle runs the watcher, which polls the node for transactions moving the funds of the wallet in the configured
+// watch set, and alerts through the configured webhook or command when it finds them
+func WatchHandle(ifc interface{}) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	interrupt.AddHandler(
+		func() {
+			cx.KillAll.Q()
+		},
+	)
+	return watch.Run(cx.Config, cx.ActiveNet, cx.KillAll)
+}
+
 func CtlHandleList(ifc interface{}) (e error) {
 	fmt.Println(ctl.ListCommands())
 	return nil
@@ -125,6 +163,6 @@ func CtlHandle(ifc interface{}) (e error) {
 		return fmt.Errorf("cannot run without a state")
 	}
 	cx.Config.LogLevel.Set("off")
-	ctl.CtlMain(cx.Config)
+	ctl.CtlMain(cx.Config, cx.ActiveNet)
 	return nil
 }
@@ -79,10 +79,26 @@ func GetCommands() (c cmds.Commands) {
 				"reset the wallet transaction history",
 					Entrypoint: func(c interface{}) error { return nil },
 				},
+				{Name: "dbstats", Title:
+				"report the key counts and space used by each bucket of the wallet database",
+					Entrypoint: launchers.WalletDBStatsHandle,
+				},
 			},
 			Colorizer: color.Bit24(255, 255, 128, false).Sprint,
 			AppText:   "wallet",
 		},
+		{Name: "watch", Title:
+		"watch a wallet from another machine and alert when its funds move",
+			Entrypoint: launchers.WatchHandle,
+			Colorizer:  color.Bit24(255, 192, 128, false).Sprint,
+			AppText:    " watch",
+			Description: "The watcher holds only the output scripts and unspent" +
+			" outputs of a wallet, exported from it with exportwatchset, and" +
+			" alerts through a webhook or a command when transactions spend" +
+			" its funds or pay to it.\n" +
+			"It is run apart from the wallet, such as on a VPS, as an early" +
+			" warning if the machine of the wallet is compromised",
+		},
 		{Name: "kopach", Title:
 		"standalone multicast miner for easy mining farm deployment",
 			Entrypoint: launchers.Kopach,
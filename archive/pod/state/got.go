@@ -2,6 +2,7 @@ package state
 
 import (
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -25,6 +26,8 @@ import (
 	"github.com/p9c/pod/pkg/chainrpc"
 	"github.com/p9c/pod/pkg/connmgr"
 	"github.com/p9c/pod/pkg/fork"
+	"github.com/p9c/pod/pkg/logship"
+	"github.com/p9c/pod/pkg/netproxy"
 	"github.com/p9c/pod/pkg/pipe"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/routeable"
@@ -67,6 +70,15 @@ func GetNew(
 	case "simnet", "s":
 		fork.IsTestnet = true
 		s.ActiveNet = &chaincfg.SimNetParams
+	case "signet":
+		fork.IsTestnet = true
+		var challenge []byte
+		if challenge, e = hex.DecodeString(s.Config.SignetChallenge.V()); F.Chk(e) {
+			return
+		}
+		if s.ActiveNet, e = chaincfg.SigNetParams(challenge); F.Chk(e) {
+			return
+		}
 	default:
 		if s.Config.Network.V() != "mainnet" &&
 			s.Config.Network.V() != "m" {
@@ -74,6 +86,17 @@ func GetNew(
 		}
 		s.ActiveNet = &chaincfg.MainNetParams
 	}
+	if e = fork.SetSchedule(s.ActiveNet.AlgoSchedule); F.Chk(e) {
+		return
+	}
+	if s.Config.WalletBech32.True() && !s.ActiveNet.AddressTypeActive(chaincfg.AddressTypeBech32) {
+		if e = fmt.Errorf(
+			"walletbech32 can't be set on %s, where bech32 addresses are not active and outputs paying to them "+
+				"could be spent by anyone", s.ActiveNet.Name,
+		); F.Chk(e) {
+			return
+		}
+	}
 	if (s.Config.LAN.True() || s.Config.Solo.True()) && s.ActiveNet.Name == "mainnet" {
 		if e = fmt.Errorf("neither Solo or LAN can be active on mainnet for obvious reasons"); F.Chk(e) {
 			return
@@ -92,6 +115,19 @@ func GetNew(
 	if e = log.SetLogWriteToFile(s.Config.LogDir.V(),
 		s.Config.RunningCommand.Name); E.Chk(e) {
 	}
+	if _, e = logship.Start(
+		logship.Config{
+			Level:        s.Config.LogShipLevel.V(),
+			Syslog:       s.Config.LogShipSyslog.V(),
+			HTTP:         s.Config.LogShipHTTP.V(),
+			AlertWebhook: s.Config.LogAlertWebhook.V(),
+			AlertErrors:  s.Config.LogAlertErrors.V(),
+			AlertWindow:  s.Config.LogAlertWindow.V(),
+			AlertMatch:   s.Config.LogAlertMatch.V(),
+			App:          s.Config.RunningCommand.Name,
+		}, s.KillAll,
+	); E.Chk(e) {
+	}
 	// set up TLS stuff if it hasn't been set up yet. We assume if the configured values correspond to files the files
 	// are valid TLS cert/pairs, and that the key will be absent if onetimetlskey was set
 	if (s.Config.ClientTLS.True() || s.Config.ServerTLS.True()) &&
@@ -234,6 +270,15 @@ func GetNew(
 		_, _ = fmt.Fprintln(os.Stderr, e)
 		os.Exit(0)
 	}
+	T.Ln("checking max tx fee")
+	s.StateCfg.ActiveMaxTxFee, e = amt.NewAmount(s.Config.MaxTxFee.V())
+	if e != nil {
+		E.Ln(e)
+		str := "invalid maxtxfee: %v"
+		e = fmt.Errorf(str, e)
+		_, _ = fmt.Fprintln(os.Stderr, e)
+		os.Exit(0)
+	}
 	I.Ln("autolisten", s.Config.AutoListen.True())
 	// if autolisten is set, set default ports on all p2p listeners discovered to be available
 	if s.Config.AutoListen.True() {
@@ -312,17 +357,21 @@ func GetNew(
 	// as the system DNS resolver. When a proxy is specified, the dial function is
 	// set to the proxy specific dial function and the lookup is set to use tor
 	// (unless --noonion is specified in which case the system DNS resolver is
-	// used).
+	// used). The proxy names are resolved through is kept in resolveProxy, and
+	// with --toronly names are never resolved with the system resolver.
 	T.Ln("setting network dialer and lookup")
 	s.StateCfg.Dial = net.DialTimeout
-	s.StateCfg.Lookup = net.LookupIP
+	var resolveProxy string
 	if !s.Config.ProxyAddress.Empty() {
 		T.Ln("we are loading a proxy!")
-		_, _, e = net.SplitHostPort(s.Config.ProxyAddress.V())
-		if e != nil {
+		p2pProxy := netproxy.Config{
+			Kind: s.Config.ProxyType.V(),
+			Addr: s.Config.ProxyAddress.V(),
+			User: s.Config.ProxyUser.V(),
+			Pass: s.Config.ProxyPass.V(),
+		}
+		if _, e = p2pProxy.Dialer(); e != nil {
 			E.Ln(e)
-			str := "proxy address '%s' is invalid: %v"
-			e = fmt.Errorf(str, s.Config.ProxyAddress.V(), e)
 			fmt.Fprintln(os.Stderr, e)
 			// os.Exit(1)
 			return
@@ -357,20 +406,18 @@ func GetNew(
 					" proxy user credentials",
 			)
 		}
-		proxy := &socks.Proxy{
-			Addr:         s.Config.ProxyAddress.V(),
-			Username:     s.Config.ProxyUser.V(),
-			Password:     s.Config.ProxyPass.V(),
-			TorIsolation: torIsolation,
+		p2pProxy.TorIsolation = torIsolation
+		var proxy netproxy.Dialer
+		if proxy, e = p2pProxy.Dialer(); E.Chk(e) {
+			return
 		}
 		s.StateCfg.Dial = proxy.DialTimeout
-		// Treat the proxy as tor and perform DNS resolution through it unless the
-		// --noonion flag is set or there is an onion-specific proxy configured.
-		if s.Config.OnionEnabled.True() &&
+		// Treat a SOCKS proxy as tor and perform DNS resolution through it unless the
+		// --noonion flag is set or there is an onion-specific proxy configured. An HTTP
+		// proxy can not resolve names, so the system resolver is kept for it.
+		if p2pProxy.IsSOCKS() && s.Config.OnionEnabled.True() &&
 			s.Config.OnionProxyAddress.Empty() {
-			s.StateCfg.Lookup = func(host string) ([]net.IP, error) {
-				return connmgr.TorLookupIP(host, s.Config.ProxyAddress.V())
-			}
+			resolveProxy = s.Config.ProxyAddress.V()
 		}
 	}
 	// Setup onion address dial function depending on the specified options. The
@@ -410,15 +457,31 @@ func GetNew(
 
 	// When configured in bridge mode (both --onion and --proxy are configured), it
 	// means that the proxy configured by --proxy is not a tor proxy, so override
-	// the DNS resolution to use the onion-specific proxy.
+	// the DNS resolution to use the onion-specific proxy. With only an onion proxy,
+	// names are resolved through it when --toronly is set.
 	T.Ln("setting proxy lookup")
-	if !s.Config.ProxyAddress.Empty() {
-		s.StateCfg.Lookup = func(host string) ([]net.IP, error) {
-			return connmgr.TorLookupIP(host, s.Config.OnionProxyAddress.V())
+	if !s.Config.OnionProxyAddress.Empty() {
+		if !s.Config.ProxyAddress.Empty() || s.Config.TorOnly.True() {
+			resolveProxy = s.Config.OnionProxyAddress.V()
 		}
 	} else {
 		s.StateCfg.Oniondial = s.StateCfg.Dial
 	}
+	s.StateCfg.Resolver = connmgr.NewResolver(resolveProxy, s.Config.TorOnly.True())
+	s.StateCfg.Lookup = s.StateCfg.Resolver.Lookup(connmgr.PurposeOther)
+	if s.Config.TorOnly.True() && resolveProxy == "" {
+		W.Ln(
+			"tor only operation is set but there is no SOCKS proxy to resolve names" +
+				" through, every host name lookup will fail",
+		)
+	}
+	// RPC clients resolve the name of their server themselves, so one that doesn't
+	// connect through a proxy, which is given the name to resolve, would look it up
+	// with the system resolver.
+	if e = s.routeRPCConnect(); E.Chk(e) {
+		_, _ = fmt.Fprintln(os.Stderr, e)
+		return
+	}
 	// Specifying --noonion means the onion address dial function results in an error.
 	if s.Config.OnionEnabled.False() {
 		s.StateCfg.Oniondial = func(a, b string, t time.Duration) (
@@ -444,6 +507,23 @@ func GetNew(
 	return
 }
 
+// routeRPCConnect records how the RPC clients resolve the names of the node and wallet servers they connect to, and
+// returns an error if either would be looked up with the system resolver when only lookups through a proxy are
+// allowed. An RPC client connecting through a proxy hands it the name of the server to resolve.
+func (s *State) routeRPCConnect() error {
+	proxied := s.Config.RPCProxy().Enabled()
+	for _, addr := range []string{s.Config.RPCConnect.V(), s.Config.WalletServer.V()} {
+		host, _, e := net.SplitHostPort(addr)
+		if e != nil || host == "" {
+			continue
+		}
+		if e = s.StateCfg.Resolver.Route(connmgr.PurposeRPCConnect, host, proxied); e != nil {
+			return fmt.Errorf("%v, set an RPC proxy to connect to %s through", e, addr)
+		}
+	}
+	return nil
+}
+
 // GetFreePort asks the kernel for free open ports that are ready to use.
 func GetFreePort() (int, error) {
 	var port int